@@ -0,0 +1,175 @@
+// Package anomaly detects runs whose duration deviates from the baseline of the runs of their job before them
+package anomaly
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// pageSize is the number of runs read at once while computing a baseline
+const pageSize = 50
+
+// storeTimeout limits checking a single run, it doesn't use the context of the executor
+const storeTimeout = 5 * time.Second
+
+// minStddevShare is the share of the mean the standard deviation counts as at least when scoring, so runs of jobs that
+// always take about the same time aren't anomalies for being a few milliseconds off
+const minStddevShare = 0.1
+
+// Baseline is the mean and standard deviation of the durations of the succeeded runs of a job
+type Baseline struct {
+	Mean   time.Duration
+	Stddev time.Duration
+	// Samples is the number of runs the baseline was computed from
+	Samples int
+}
+
+// RunStore is the part of the run storage the detector needs
+type RunStore interface {
+	// List returns runs of a job newest first like repository.RunRepository.List
+	List(ctx context.Context, jobID, before string, limit int) ([]*repository.Run, error)
+}
+
+// BaselineBefore computes the baseline of the job with jobID from up to window runs that succeeded before the run
+// with ID before, newest first. Failed runs often stop early, so they aren't part of it.
+func BaselineBefore(ctx context.Context, runs RunStore, jobID, before string, window int) (*Baseline, error) {
+	var durations []float64
+	for len(durations) < window {
+		page, err := runs.List(ctx, jobID, before, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range page {
+			if run.Status == executor.StatusSucceeded && len(durations) < window {
+				durations = append(durations, float64(run.Duration))
+			}
+		}
+		if len(page) < pageSize {
+			break
+		}
+		before = page[len(page)-1].ID
+	}
+	baseline := &Baseline{Samples: len(durations)}
+	if len(durations) < 2 {
+		return baseline, nil
+	}
+	var sum float64
+	for _, d := range durations {
+		sum += d
+	}
+	mean := sum / float64(len(durations))
+	var squares float64
+	for _, d := range durations {
+		squares += (d - mean) * (d - mean)
+	}
+	baseline.Mean = time.Duration(mean)
+	// The sample standard deviation, the runs are a sample of all runs the job will have
+	baseline.Stddev = time.Duration(math.Sqrt(squares / float64(len(durations)-1)))
+	return baseline, nil
+}
+
+// Score is how many standard deviations duration is away from the mean of baseline, negative when it is shorter. The
+// standard deviation counts as at least a tenth of the mean.
+func (b *Baseline) Score(duration time.Duration) float64 {
+	stddev := math.Max(float64(b.Stddev), minStddevShare*float64(b.Mean))
+	if stddev == 0 {
+		return 0
+	}
+	return float64(duration-b.Mean) / stddev
+}
+
+// AnomalyFunc is called once for every anomaly the detector recorded
+type AnomalyFunc func(ctx context.Context, job *repository.Job, anomaly *repository.Anomaly)
+
+// Detector records the succeeded runs whose duration is more than a threshold of standard deviations away from the
+// baseline of the runs of their job before them. Jobs are only checked once they have a minimum number of runs.
+type Detector struct {
+	anomalies repository.AnomalyRepository
+	runs      RunStore
+	threshold float64
+	window    int
+	minRuns   int
+	onAnomaly []AnomalyFunc
+	logger    *zap.Logger
+	wg        sync.WaitGroup
+}
+
+// New creates a Detector comparing runs with the baseline of the last window succeeded runs of their job, once the
+// job has at least minRuns of them
+func New(anomalies repository.AnomalyRepository, runs RunStore, threshold float64, window, minRuns int, logger *zap.Logger) *Detector {
+	return &Detector{anomalies: anomalies, runs: runs, threshold: threshold, window: window, minRuns: minRuns, logger: logger}
+}
+
+// OnAnomaly makes the detector call f for every anomaly it recorded, it must be called before runs finish
+func (d *Detector) OnAnomaly(f AnomalyFunc) {
+	d.onAnomaly = append(d.onAnomaly, f)
+}
+
+// RunFinished checks a succeeded run of job in the background. It can be passed to the OnFinish of the executor.
+func (d *Detector) RunFinished(ctx context.Context, job *repository.Job, run *repository.Run) {
+	if run.Status != executor.StatusSucceeded {
+		return
+	}
+	// The executor is done with both, but copies keep it free to change them
+	j, r := *job, *run
+	tenantID := tenant.FromContext(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ctx, cancel := context.WithTimeout(tenant.NewContext(context.Background(), tenantID), storeTimeout)
+		defer cancel()
+		d.check(ctx, &j, &r)
+	}()
+}
+
+// Wait blocks until the finished runs that are being checked were checked, the executor must not finish runs anymore
+func (d *Detector) Wait() {
+	d.wg.Wait()
+}
+
+// check records an anomaly when the duration of run is further away from the baseline of its job than the threshold
+func (d *Detector) check(ctx context.Context, job *repository.Job, run *repository.Run) {
+	baseline, err := BaselineBefore(ctx, d.runs, job.ID, run.ID, d.window)
+	if err != nil {
+		d.logger.Error("Could not compute the duration baseline", zap.String("job_id", job.ID), zap.String("run_id", run.ID), zap.Error(err))
+		return
+	}
+	score := baseline.Score(run.Duration)
+	if baseline.Samples < d.minRuns || math.Abs(score) <= d.threshold {
+		return
+	}
+	recorded, err := d.anomalies.Add(ctx, &repository.Anomaly{
+		JobID:      job.ID,
+		RunID:      run.ID,
+		Duration:   run.Duration,
+		Mean:       baseline.Mean,
+		Stddev:     baseline.Stddev,
+		Samples:    baseline.Samples,
+		Score:      score,
+		DetectedAt: time.Now().UTC().Truncate(time.Millisecond),
+	})
+	if err == repository.ErrExists {
+		return
+	} else if err != nil {
+		d.logger.Error("Could not record anomaly", zap.String("job_id", job.ID), zap.String("run_id", run.ID), zap.Error(err))
+		return
+	}
+	direction := "slower"
+	if score < 0 {
+		direction = "faster"
+	}
+	metrics.RecordRunAnomaly(direction)
+	d.logger.Warn("Run duration is an anomaly", zap.String("job_id", job.ID), zap.String("run_id", run.ID),
+		zap.Duration("duration", run.Duration), zap.Duration("mean", baseline.Mean), zap.Float64("score", score))
+	for _, f := range d.onAnomaly {
+		f(ctx, job, recorded)
+	}
+}
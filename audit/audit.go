@@ -0,0 +1,510 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/secrets"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// writeTimeout limits storing an entry, it doesn't use the context of the call so clients that hang up right after a
+// change can't keep it out of the log
+const writeTimeout = 5 * time.Second
+
+// jobMethods are the unary methods that change jobs, all but the createMethods carry the ids of the jobs in the request
+var jobMethods = map[string]bool{
+	"/model.JobService/CreateJob":                  true,
+	"/model.TemplateService/CreateJobFromTemplate": true,
+	"/model.JobService/CloneJob":                   true,
+	"/model.JobService/UpdateJob":                  true,
+	"/model.JobService/RollbackJob":                true,
+	"/model.JobService/DeleteJob":                  true,
+	"/model.JobService/DeleteJobs":                 true,
+	"/model.JobService/RestoreJob":                 true,
+	"/model.JobService/ArchiveJob":                 true,
+	"/model.JobService/UnarchiveJob":               true,
+	"/model.JobService/PauseJob":                   true,
+	"/model.JobService/ResumeJob":                  true,
+	"/model.JobService/CancelJob":                  true,
+	"/model.ScheduleService/SetSchedule":           true,
+	"/model.ScheduleService/RemoveSchedule":        true,
+	"/model.CalendarService/SetJobCalendars":       true,
+}
+
+// createMethods return the job they created, the ids their requests may carry are ignored
+var createMethods = map[string]bool{
+	"/model.JobService/CreateJob":                  true,
+	"/model.TemplateService/CreateJobFromTemplate": true,
+	"/model.JobService/CloneJob":                   true,
+}
+
+// createdJob is the response of the createMethods
+type createdJob interface {
+	GetJob() *model.Job
+}
+
+// pendingChange is the response of the jobMethods that may only request a change of the job
+type pendingChange interface {
+	GetJob() *model.Job
+	GetChange() *model.JobChange
+}
+
+// importMethod creates jobs from a client stream, it is recorded as a single entry without a job
+const importMethod = "/model.JobService/ImportJobs"
+
+// restoreMethod restores the jobs of a backup, it is recorded as a single entry without a job like imports
+const restoreMethod = "/model.AdminService/RestoreJobs"
+
+// archiveMethod archives the jobs matching a filter, only the response tells which ones
+const archiveMethod = "/model.JobService/ArchiveJobs"
+
+// promoteMethod copies a job into another environment, the job it updates or creates is only known by its name
+// before the call
+const promoteMethod = "/model.JobService/PromoteJob"
+
+// secretMethods change secrets, they are recorded without a job and never with the value of the secret
+var secretMethods = map[string]bool{
+	"/model.SecretService/CreateSecret": true,
+	"/model.SecretService/UpdateSecret": true,
+	"/model.SecretService/DeleteSecret": true,
+}
+
+// changeMethods approve or reject a pending change of a job, the request only carries the id of the change
+var changeMethods = map[string]bool{
+	"/model.JobService/ApproveChange": true,
+	"/model.JobService/RejectChange":  true,
+}
+
+// Jobs is the part of the job storage the recorder reads jobs before and after a change from
+type Jobs interface {
+	Get(ctx context.Context, id string, q repository.Query) (*repository.Job, error)
+	GetByName(ctx context.Context, owner, environment, name string) (*repository.Job, error)
+}
+
+// Changes is the part of the change storage the recorder looks up the job of a reviewed change in
+type Changes interface {
+	Get(ctx context.Context, id string, q repository.Query) (*repository.Change, error)
+}
+
+// Store is the part of the storage the recorder writes entries to
+type Store interface {
+	Create(ctx context.Context, entry *repository.AuditEntry) (*repository.AuditEntry, error)
+}
+
+// Recorder writes an audit entry for every successful call that changed a job
+type Recorder struct {
+	jobs    Jobs
+	changes Changes
+	entries Store
+}
+
+// New creates a Recorder comparing jobs read from jobs, looking up reviewed changes in changes and storing the
+// entries in entries
+func New(jobs Jobs, changes Changes, entries Store) *Recorder {
+	return &Recorder{jobs: jobs, changes: changes, entries: entries}
+}
+
+// UnaryServerInterceptor records the changes made by unary calls to jobs. Failed calls and calls that didn't change
+// anything, like deleting a job twice, aren't recorded.
+func (r *Recorder) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if info.FullMethod == restoreMethod {
+		return r.recordRestore(ctx, req, info, handler)
+	}
+	if info.FullMethod == archiveMethod {
+		return r.recordArchive(ctx, req, info, handler)
+	}
+	if secretMethods[info.FullMethod] {
+		return r.recordSecret(ctx, req, info, handler)
+	}
+	if info.FullMethod == promoteMethod {
+		return r.recordPromote(ctx, req, info, handler)
+	}
+	if changeMethods[info.FullMethod] {
+		return r.recordReview(ctx, req, info, handler)
+	}
+	if !jobMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	var ids []string
+	if !createMethods[info.FullMethod] {
+		ids = jobIDs(req)
+	}
+	before := r.snapshot(ctx, ids)
+	// Jobs are created with millisecond precision
+	started := time.Now().UTC().Truncate(time.Millisecond)
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	// Created jobs only have an id once the handler returned. A retried create returns the job of the first call, it
+	// was recorded back then.
+	if res, ok := resp.(createdJob); ok && createMethods[info.FullMethod] && res.GetJob().GetId() != "" {
+		created, err := ptypes.Timestamp(res.GetJob().GetCreatedAt())
+		if err == nil && created.Before(started) {
+			return resp, nil
+		}
+		ids = []string{res.GetJob().GetId()}
+	}
+	// An update or rollback of a job in a protected environment only requested a change, the job is the same as before
+	if res, ok := resp.(pendingChange); ok && res.GetChange() != nil {
+		change := res.GetChange()
+		r.record(ctx, &repository.AuditEntry{Method: info.FullMethod, JobID: change.GetJobId(), Owner: res.GetJob().GetOwner(), Changes: []repository.AuditChange{
+			{Field: "change.id", After: change.GetId()},
+			{Field: "change.status", After: changeStatus(change.GetStatus())},
+		}})
+		return resp, nil
+	}
+	after := r.snapshot(ctx, ids)
+	for _, id := range ids {
+		changes := Diff(before[id], after[id])
+		if len(changes) == 0 {
+			continue
+		}
+		entry := &repository.AuditEntry{Method: info.FullMethod, JobID: id, Changes: changes}
+		if job := after[id]; job != nil {
+			entry.Owner = job.Owner
+		} else if job := before[id]; job != nil {
+			entry.Owner = job.Owner
+		}
+		r.record(ctx, entry)
+	}
+	return resp, nil
+}
+
+// StreamServerInterceptor records imports, the response only tells how many jobs were created
+func (r *Recorder) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if info.FullMethod != importMethod {
+		return handler(srv, ss)
+	}
+	stream := &importStream{ServerStream: ss}
+	if err := handler(srv, stream); err != nil {
+		return err
+	}
+	if stream.imported > 0 {
+		entry := &repository.AuditEntry{
+			Method:  info.FullMethod,
+			Changes: []repository.AuditChange{{Field: "imported_count", After: strconv.Itoa(int(stream.imported))}},
+		}
+		// Only admins can import jobs for other owners, so the caller owns the jobs of everybody else's imports
+		if claims, ok := auth.FromContext(ss.Context()); ok {
+			entry.Owner = claims.Subject
+		}
+		r.record(ss.Context(), entry)
+	}
+	return nil
+}
+
+// recordRestore records a restore that stored jobs or runs, the response only tells how many there were
+func (r *Recorder) recordRestore(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	res, ok := resp.(*model.RestoreJobsRes)
+	if err != nil || !ok || res.GetRestoredJobs()+res.GetRestoredRuns() == 0 {
+		return resp, err
+	}
+	entry := &repository.AuditEntry{
+		Method: info.FullMethod,
+		Changes: []repository.AuditChange{
+			{Field: "backup", After: req.(*model.RestoreJobsReq).GetName()},
+			{Field: "restored_jobs", After: strconv.FormatInt(res.GetRestoredJobs(), 10)},
+			{Field: "restored_runs", After: strconv.FormatInt(res.GetRestoredRuns(), 10)},
+		},
+	}
+	// Only admins can restore, the entry is the caller's like the ones of imports
+	if claims, ok := auth.FromContext(ctx); ok {
+		entry.Owner = claims.Subject
+	}
+	r.record(ctx, entry)
+	return resp, nil
+}
+
+// recordArchive records every job a bulk archive archived. Archiving only sets the archive time, so the jobs before
+// the call are the ones after it without it.
+func (r *Recorder) recordArchive(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	res, ok := resp.(*model.ArchiveJobsRes)
+	if err != nil || !ok {
+		return resp, err
+	}
+	for id, job := range r.snapshot(ctx, res.GetIds()) {
+		before := *job
+		before.ArchivedAt = nil
+		r.record(ctx, &repository.AuditEntry{Method: info.FullMethod, JobID: id, Owner: job.Owner, Changes: Diff(&before, job)})
+	}
+	return resp, nil
+}
+
+// recordPromote records the job a promotion created or updated in the target environment. The target is looked up
+// by the owner and name of the promoted job before the call, the promoted job itself doesn't change.
+func (r *Recorder) recordPromote(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	promote := req.(*model.PromoteJobReq)
+	var before *repository.Job
+	if source := r.snapshot(ctx, []string{promote.GetId()})[promote.GetId()]; source != nil {
+		target, err := r.jobs.GetByName(ctx, source.Owner, promote.GetTargetEnvironment(), source.Name)
+		if err == nil {
+			before = target
+		} else if err != repository.ErrNotFound {
+			logging.FromContext(ctx).Warn("Could not read job for the audit log", zap.String("job_id", promote.GetId()), zap.Error(err))
+		}
+	}
+	resp, err := handler(ctx, req)
+	res, ok := resp.(*model.PromoteJobRes)
+	if err != nil || !ok {
+		return resp, err
+	}
+	id := res.GetJob().GetId()
+	if res.GetCreated() {
+		before = nil
+	}
+	if changes := Diff(before, r.snapshot(ctx, []string{id})[id]); len(changes) > 0 {
+		r.record(ctx, &repository.AuditEntry{Method: info.FullMethod, JobID: id, Owner: res.GetJob().GetOwner(), Changes: changes})
+	}
+	return resp, nil
+}
+
+// recordReview records an approved or rejected change along with the changes its approval made to the job. The job
+// is looked up by the change before the call.
+func (r *Recorder) recordReview(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := req.(interface{ GetId() string }).GetId()
+	var jobID string
+	if change, err := r.changes.Get(ctx, id, repository.Query{}); err == nil {
+		jobID = change.JobID
+	} else if err != repository.ErrNotFound && err != repository.ErrInvalidID {
+		logging.FromContext(ctx).Warn("Could not read change for the audit log", zap.String("change_id", id), zap.Error(err))
+	}
+	before := r.snapshot(ctx, []string{jobID})
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	res, ok := resp.(interface{ GetChange() *model.JobChange })
+	if !ok {
+		return resp, nil
+	}
+	change := res.GetChange()
+	entry := &repository.AuditEntry{Method: info.FullMethod, JobID: change.GetJobId()}
+	if job := before[change.GetJobId()]; job != nil {
+		entry.Owner = job.Owner
+	}
+	if approved, ok := resp.(*model.ApproveChangeRes); ok {
+		entry.Owner = approved.GetJob().GetOwner()
+		entry.Changes = Diff(before[change.GetJobId()], r.snapshot(ctx, []string{change.GetJobId()})[change.GetJobId()])
+	}
+	entry.Changes = append(entry.Changes,
+		repository.AuditChange{Field: "change.id", After: change.GetId()},
+		repository.AuditChange{Field: "change.requested_by", After: change.GetRequestedBy()},
+		repository.AuditChange{Field: "change.status", Before: repository.ChangePending, After: changeStatus(change.GetStatus())},
+	)
+	if change.GetComment() != "" {
+		entry.Changes = append(entry.Changes, repository.AuditChange{Field: "change.comment", After: change.GetComment()})
+	}
+	r.record(ctx, entry)
+	return resp, nil
+}
+
+// changeStatus returns status without its prefix, like the job fields of an entry
+func changeStatus(status model.ChangeStatus) string {
+	return strings.TrimPrefix(status.String(), "CHANGE_STATUS_")
+}
+
+// recordSecret records a created, updated or deleted secret. A changed value is recorded as redacted, the value
+// itself never gets into the log.
+func (r *Recorder) recordSecret(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	entry := &repository.AuditEntry{Method: info.FullMethod}
+	if res, ok := resp.(interface{ GetSecret() *model.Secret }); ok {
+		secret := res.GetSecret()
+		entry.Owner = secret.GetOwner()
+		entry.Changes = []repository.AuditChange{
+			{Field: "secret.id", After: secret.GetId()},
+			{Field: "secret.name", After: secret.GetName()},
+		}
+		if secret.GetDescription() != "" {
+			entry.Changes = append(entry.Changes, repository.AuditChange{Field: "secret.description", After: secret.GetDescription()})
+		}
+		if req.(interface{ GetSecret() *model.Secret }).GetSecret().GetValue() != "" {
+			entry.Changes = append(entry.Changes, repository.AuditChange{Field: "secret.value", After: secrets.Redacted})
+		}
+	} else {
+		// Deleted secrets are gone, only the request tells which one it was and the entry is the caller's
+		entry.Changes = []repository.AuditChange{{Field: "secret.id", Before: req.(*model.DeleteSecretReq).GetId()}}
+		if claims, ok := auth.FromContext(ctx); ok {
+			entry.Owner = claims.Subject
+		}
+	}
+	r.record(ctx, entry)
+	return resp, nil
+}
+
+// importStream remembers the number of jobs an import created from the response sent to the client
+type importStream struct {
+	grpc.ServerStream
+	imported int32
+}
+
+func (s *importStream) SendMsg(m interface{}) error {
+	if res, ok := m.(*model.ImportJobsRes); ok {
+		s.imported = res.GetImportedCount()
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// jobIDs returns the ids of the jobs a request changes
+func jobIDs(req interface{}) []string {
+	switch r := req.(type) {
+	case interface{ GetIds() []string }:
+		return r.GetIds()
+	case interface{ GetId() string }:
+		return []string{r.GetId()}
+	case interface{ GetJobId() string }:
+		return []string{r.GetJobId()}
+	case interface{ GetJob() *model.Job }:
+		return []string{r.GetJob().GetId()}
+	}
+	return nil
+}
+
+// snapshot reads the jobs with the given ids regardless of their owner, the handler checks who may change them.
+// Jobs that don't exist are left out.
+func (r *Recorder) snapshot(ctx context.Context, ids []string) map[string]*repository.Job {
+	jobs := map[string]*repository.Job{}
+	for _, id := range ids {
+		job, err := r.jobs.Get(ctx, id, repository.Query{IncludeDeleted: true})
+		if err == nil {
+			jobs[id] = job
+		} else if err != repository.ErrNotFound && err != repository.ErrInvalidID && err != repository.ErrOtherTenant {
+			logging.FromContext(ctx).Warn("Could not read job for the audit log", zap.String("job_id", id), zap.Error(err))
+		}
+	}
+	return jobs
+}
+
+// record stores entry with the caller as actor, failures are logged since the change already happened
+func (r *Recorder) record(ctx context.Context, entry *repository.AuditEntry) {
+	if claims, ok := auth.FromContext(ctx); ok {
+		entry.Actor = claims.Subject
+	}
+	entry.Time = time.Now().UTC().Truncate(time.Millisecond)
+	// Keep the tenant, the entry belongs to the tenant of the caller
+	writeCtx, cancel := context.WithTimeout(tenant.NewContext(context.Background(), tenant.FromContext(ctx)), writeTimeout)
+	defer cancel()
+	if _, err := r.entries.Create(writeCtx, entry); err != nil {
+		logging.FromContext(ctx).Error("Could not write audit entry", zap.String("job_id", entry.JobID), zap.Error(err))
+	}
+}
+
+// Diff returns the fields that differ between two versions of a job ordered by field name, a nil job has no fields
+func Diff(before, after *repository.Job) []repository.AuditChange {
+	old, updated := fields(before), fields(after)
+	names := []string{}
+	for name := range old {
+		names = append(names, name)
+	}
+	for name := range updated {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	changes := []repository.AuditChange{}
+	for _, name := range names {
+		if old[name] != updated[name] {
+			changes = append(changes, repository.AuditChange{Field: name, Before: old[name], After: updated[name]})
+		}
+	}
+	return changes
+}
+
+// fields returns the fields of a job clients can change, directly or through other calls, by their name in the API.
+// Fields that aren't set are left out. Timestamps the server maintains on every change are left out too.
+func fields(job *repository.Job) map[string]string {
+	f := map[string]string{}
+	if job == nil {
+		return f
+	}
+	set := func(name, value string) {
+		if value != "" {
+			f[name] = value
+		}
+	}
+	set("name", job.Name)
+	set("description", job.Description)
+	set("owner", job.Owner)
+	set("handler", job.Handler)
+	set("command", job.Command)
+	set("status", job.Status)
+	if job.Schedule != nil {
+		set("schedule.cron", job.Schedule.Cron)
+		if job.Schedule.Interval != 0 {
+			set("schedule.interval", job.Schedule.Interval.String())
+		}
+		set("schedule.timezone", job.Schedule.Timezone)
+	}
+	if job.Timeout != 0 {
+		set("timeout", job.Timeout.String())
+	}
+	if job.RetryPolicy != nil {
+		// A struct of numbers always marshals
+		data, _ := json.Marshal(job.RetryPolicy)
+		set("retry_policy", string(data))
+	}
+	for key, value := range job.Labels {
+		set("labels."+key, value)
+	}
+	set("depends_on", strings.Join(job.DependsOn, ","))
+	set("priority", job.Priority)
+	set("concurrency_policy", job.ConcurrencyPolicy)
+	set("catch_up_policy", job.CatchUpPolicy)
+	if job.Notifications != nil {
+		set("notifications.failure_threshold", strconv.Itoa(job.Notifications.FailureThreshold))
+		set("notifications.slack_webhook_url", job.Notifications.SlackWebhookURL)
+		set("notifications.email_recipients", strings.Join(job.Notifications.EmailRecipients, ","))
+		if job.Notifications.Anomalies {
+			set("notifications.anomalies", "true")
+		}
+	}
+	if job.Template != nil {
+		set("template.template_id", job.Template.TemplateID)
+		set("template.version", strconv.FormatInt(job.Template.Version, 10))
+	}
+	if job.Blackout != nil {
+		set("blackout.calendar_ids", strings.Join(job.Blackout.CalendarIDs, ","))
+		if job.Blackout.Defer {
+			set("blackout.defer", "true")
+		}
+	}
+	for key, value := range job.Params {
+		// Params only hold JSON values, they always marshal
+		data, _ := json.Marshal(value)
+		set("params."+key, string(data))
+	}
+	set("environment", job.Environment)
+	if job.Promotion != nil {
+		set("promotion.source_job_id", job.Promotion.SourceJobID)
+		set("promotion.source_environment", job.Promotion.SourceEnvironment)
+		set("promotion.approval", job.Promotion.Approval)
+		set("promotion.promoted_by", job.Promotion.PromotedBy)
+		set("promotion.promoted_at", job.Promotion.PromotedAt.UTC().Format(time.RFC3339Nano))
+	}
+	if job.DeletedAt != nil {
+		set("deleted_at", job.DeletedAt.UTC().Format(time.RFC3339Nano))
+	}
+	if job.ArchivedAt != nil {
+		set("archived_at", job.ArchivedAt.UTC().Format(time.RFC3339Nano))
+	}
+	return f
+}
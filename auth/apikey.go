@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// APIKeyPrefix starts every API key, it tells them apart from JWTs
+const APIKeyPrefix = "sk_"
+
+// ErrInvalidAPIKey is returned by APIKeys for keys that don't exist or whose secret doesn't match
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// APIKeys verifies the API keys machine clients authenticate with instead of a token
+type APIKeys interface {
+	// VerifyAPIKey returns the claims of the caller holding the key with id and secret, ErrInvalidAPIKey when there is
+	// no such key
+	VerifyAPIKey(ctx context.Context, id, secret string) (*Claims, error)
+}
+
+// NewAPIKeySecret generates the secret of a new key, only its hash is stored
+func NewAPIKeySecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashAPIKeySecret returns the hex encoded SHA-256 hash of a secret. Secrets are random, so they need no salt or slow
+// hash like passwords do.
+func HashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// FormatAPIKey returns the key clients send as bearer token, sk_<id>.<secret>
+func FormatAPIKey(id, secret string) string {
+	return APIKeyPrefix + id + "." + secret
+}
+
+// ParseAPIKey splits a key written by FormatAPIKey into its id and secret, ok is false when key isn't one
+func ParseAPIKey(key string) (id, secret string, ok bool) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, APIKeyPrefix), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Claims are the verified contents of a caller's token
+type Claims struct {
+	jwt.RegisteredClaims
+	// Roles granted to the caller by the identity provider
+	Roles []string `json:"roles,omitempty"`
+	// TenantID is the customer the caller belongs to, it scopes all data when multi-tenancy is enabled
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// AdminRole lets a caller access jobs of every owner
+const AdminRole = "admin"
+
+// HasRole reports whether the caller was granted role
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type claimsKey struct{}
+
+// NewContext returns a copy of ctx carrying the caller's claims
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims of the authenticated caller, ok is false for unauthenticated calls
+func FromContext(ctx context.Context) (claims *Claims, ok bool) {
+	claims, ok = ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultExemptMethods can be called without a token
+var DefaultExemptMethods = []string{
+	"/model.HelloService/SayHello",
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+// Authenticator verifies the bearer token of every call and puts the claims into the context
+type Authenticator struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+	exempt   map[string]bool
+	parser   *jwt.Parser
+	apiKeys  APIKeys
+}
+
+// NewAuthenticator creates an Authenticator verifying tokens with the keys published at jwksURL.
+// Empty issuer or audience values are not checked. Methods are full gRPC method names like /model.JobService/ReadJob.
+func NewAuthenticator(jwksURL, issuer, audience string, exemptMethods []string) *Authenticator {
+	exempt := map[string]bool{}
+	for _, method := range exemptMethods {
+		exempt[method] = true
+	}
+	return &Authenticator{
+		keys:     NewKeySet(jwksURL),
+		issuer:   issuer,
+		audience: audience,
+		exempt:   exempt,
+		// Only asymmetric algorithms make sense with a JWKS
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"})),
+	}
+}
+
+// AcceptAPIKeys lets callers authenticate with an API key verified by keys instead of a JWT
+func (a *Authenticator) AcceptAPIKeys(keys APIKeys) {
+	a.apiKeys = keys
+}
+
+// UnaryServerInterceptor rejects unary calls without a valid token
+func (a *Authenticator) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects streams without a valid token
+func (a *Authenticator) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream replaces the context of a stream with one carrying the claims
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate verifies the token of a call to method and returns a context carrying its claims
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a.exempt[method] {
+		return ctx, nil
+	}
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, fmt.Sprintf("Unauthenticated: %v", err))
+	}
+	return a.Verify(ctx, token)
+}
+
+// Verify checks token like the bearer token of a call and returns a context carrying its claims, for requests that
+// don't come in over gRPC. Errors are gRPC statuses.
+func (a *Authenticator) Verify(ctx context.Context, token string) (context.Context, error) {
+	if a.apiKeys != nil && strings.HasPrefix(token, APIKeyPrefix) {
+		return a.authenticateAPIKey(ctx, token)
+	}
+	claims := &Claims{}
+	_, err := a.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return a.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, fmt.Sprintf("Invalid token: %v", err))
+	}
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid token issuer")
+	}
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid token audience")
+	}
+	return NewContext(ctx, claims), nil
+}
+
+// authenticateAPIKey verifies the API key of a call and returns a context carrying the claims of its user
+func (a *Authenticator) authenticateAPIKey(ctx context.Context, key string) (context.Context, error) {
+	id, secret, ok := ParseAPIKey(key)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid API key")
+	}
+	claims, err := a.apiKeys.VerifyAPIKey(ctx, id, secret)
+	if err == ErrInvalidAPIKey {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid API key")
+	} else if err != nil {
+		// The key may well be valid, the client should try again instead of asking for a new one
+		return nil, status.Errorf(codes.Unavailable, "Could not verify API key")
+	}
+	return NewContext(ctx, claims), nil
+}
+
+// bearerToken reads the token from the authorization metadata of the call
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", errors.New("missing authorization metadata")
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") || parts[1] == "" {
+		return "", errors.New("authorization metadata must be a bearer token")
+	}
+	return parts[1], nil
+}
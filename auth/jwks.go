@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval limits how often an unknown key id can trigger a refetch of the key set
+const minRefreshInterval = time.Minute
+
+// KeySet fetches and caches the public keys published at a JWKS URL
+type KeySet struct {
+	url    string
+	client *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+// NewKeySet creates a KeySet for url, keys are fetched lazily on first use
+func NewKeySet(url string) *KeySet {
+	return &KeySet{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		keys:   map[string]interface{}{},
+	}
+}
+
+// Key returns the public key with the given key id, refetching the key set if the id is unknown (e.g. after a rotation)
+func (k *KeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	stale := time.Since(k.fetchedAt) > minRefreshInterval
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if key, ok := k.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+// jwk is a single JSON web key, only the fields for RSA and EC keys are decoded
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refresh downloads the key set and replaces the cached keys
+func (k *KeySet) refresh(ctx context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	// Another caller may have refreshed while we waited for the lock
+	if time.Since(k.fetchedAt) <= minRefreshInterval {
+		return nil
+	}
+	k.fetchedAt = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not fetch JWKS: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not fetch JWKS: unexpected status %s", resp.Status)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("could not decode JWKS: %v", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		pub, err := key.publicKey()
+		if err != nil {
+			// Skip keys we don't understand instead of rejecting the whole set
+			continue
+		}
+		keys[key.Kid] = pub
+	}
+	k.keys = keys
+	return nil
+}
+
+// publicKey decodes the key material of an RSA or EC key
+func (j *jwk) publicKey() (interface{}, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, errors.New("unsupported key type")
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
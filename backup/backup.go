@@ -0,0 +1,315 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// pageSize is the number of jobs or runs read at once while backing up
+const pageSize = 500
+
+// Conflict policies of Restore, they tell what happens to jobs and runs of a backup that are stored already
+const (
+	// PolicySkip keeps the stored job or run
+	PolicySkip = "skip"
+	// PolicyOverwrite replaces the stored job or run with the one of the backup
+	PolicyOverwrite = "overwrite"
+	// PolicyFail stops the restore at the first conflict
+	PolicyFail = "fail"
+)
+
+var (
+	// ErrInvalidName is returned by Restore for names Backup could never have generated
+	ErrInvalidName = errors.New("invalid backup name")
+	// ErrOtherTenant is returned by Restore when the backup wasn't taken for the tenant of the context
+	ErrOtherTenant = errors.New("backup belongs to another tenant")
+)
+
+// validName matches the names of backups, they can't escape the directory or prefix of the store
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+// ConflictError is returned by Restore with PolicyFail for the first job or run of the backup that conflicts with a
+// stored one
+type ConflictError struct {
+	// Kind is job or run
+	Kind string
+	ID   string
+	Err  error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Kind, e.ID, e.Err)
+}
+
+// Info describes a backup that was written
+type Info struct {
+	Name      string
+	CreatedAt time.Time
+	Jobs      int64
+	Runs      int64
+	// Size is the size of the compressed backup in bytes
+	Size int64
+}
+
+// Result counts what Restore did, jobs and runs are skipped when they conflict with stored ones
+type Result struct {
+	RestoredJobs int64
+	SkippedJobs  int64
+	RestoredRuns int64
+	SkippedRuns  int64
+}
+
+// Backups writes the jobs and runs to gzip compressed NDJSON files in a Store and restores them from there. The
+// first line of a file is a header, every following one holds a job or a run of the job before it.
+type Backups struct {
+	jobs  repository.JobRepository
+	runs  repository.RunRepository
+	store Store
+	// tenants are the tenants with a database of their own, their jobs aren't listed with the others
+	tenants []string
+	logger  *zap.Logger
+}
+
+// New creates Backups of jobs and runs keeping them in store. tenants are the tenants whose jobs are stored apart
+// from the others, they are backed up one after the other.
+func New(jobs repository.JobRepository, runs repository.RunRepository, store Store, tenants []string, logger *zap.Logger) *Backups {
+	sorted := append([]string(nil), tenants...)
+	sort.Strings(sorted)
+	return &Backups{jobs: jobs, runs: runs, store: store, tenants: sorted, logger: logger}
+}
+
+// Backup writes a backup of the jobs of the tenant of ctx, or of every tenant when ctx has none, together with their
+// runs unless jobsOnly is set. Deleted jobs that weren't purged yet are included.
+func (b *Backups) Backup(ctx context.Context, jobsOnly bool) (*Info, error) {
+	now := time.Now().UTC()
+	info := &Info{Name: "jobs-" + now.Format("20060102T150405.000Z") + ".ndjson.gz", CreatedAt: now}
+	// The backup is only handed to the store once it's complete, a temporary file keeps it out of memory
+	file, err := ioutil.TempFile("", "schedulytics-backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	enc := json.NewEncoder(gz)
+	if err := enc.Encode(&header{Version: formatVersion, CreatedAt: now, Tenant: tenant.FromContext(ctx)}); err != nil {
+		return nil, err
+	}
+	for _, scope := range b.scopes(ctx) {
+		if err := b.write(scope, enc, jobsOnly, info); err != nil {
+			return nil, err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	if info.Size, err = file.Seek(0, io.SeekCurrent); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := b.store.Put(ctx, info.Name, file); err != nil {
+		return nil, fmt.Errorf("could not store backup: %v", err)
+	}
+	return info, nil
+}
+
+// scopes returns the contexts the jobs of ctx are listed with, one for the shared storage and one for each tenant
+// with a database of its own when ctx has no tenant
+func (b *Backups) scopes(ctx context.Context) []context.Context {
+	if tenant.FromContext(ctx) != "" {
+		return []context.Context{ctx}
+	}
+	scopes := []context.Context{ctx}
+	for _, t := range b.tenants {
+		scopes = append(scopes, tenant.NewContext(ctx, t))
+	}
+	return scopes
+}
+
+// write encodes the jobs listed with ctx and their runs
+func (b *Backups) write(ctx context.Context, enc *json.Encoder, jobsOnly bool, info *Info) error {
+	q := repository.Query{IncludeDeleted: true}
+	after := ""
+	for {
+		page, err := b.jobs.List(ctx, q, after, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, job := range page {
+			if err := enc.Encode(&record{Job: newJobRecord(job)}); err != nil {
+				return err
+			}
+			info.Jobs++
+			if jobsOnly {
+				continue
+			}
+			if err := b.writeRuns(ctx, enc, job, info); err != nil {
+				return err
+			}
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+		after = page[len(page)-1].ID
+	}
+}
+
+// writeRuns encodes the runs of job newest first
+func (b *Backups) writeRuns(ctx context.Context, enc *json.Encoder, job *repository.Job, info *Info) error {
+	before := ""
+	for {
+		page, err := b.runs.List(ctx, job.ID, before, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, run := range page {
+			if err := enc.Encode(&record{Run: newRunRecord(run)}); err != nil {
+				return err
+			}
+			info.Runs++
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+		before = page[len(page)-1].ID
+	}
+}
+
+// Restore stores the jobs and runs of the backup with the given name under their IDs, resolving conflicts with
+// stored ones by policy. Jobs and runs keep the tenant they were backed up from when ctx has no tenant, a backup
+// can only be restored for the tenant of ctx when it was taken for that tenant. A restore isn't atomic, what was
+// restored before an error stays.
+func (b *Backups) Restore(ctx context.Context, name, policy string) (*Result, error) {
+	if !validName.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+	r, err := b.store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read backup: %v", err)
+	}
+	dec := json.NewDecoder(gz)
+	var h header
+	if err := dec.Decode(&h); err != nil {
+		return nil, fmt.Errorf("could not read backup: %v", err)
+	}
+	if h.Version != formatVersion {
+		return nil, fmt.Errorf("backup has unsupported format version %d", h.Version)
+	}
+	scoped := tenant.FromContext(ctx)
+	if scoped != "" && h.Tenant != scoped {
+		return nil, ErrOtherTenant
+	}
+
+	result := &Result{}
+	replace := policy == PolicyOverwrite
+	// The runs of jobs that couldn't be restored would belong to no job
+	left := map[string]bool{}
+	for {
+		var rec record
+		if err := dec.Decode(&rec); err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return result, fmt.Errorf("could not read backup: %v", err)
+		}
+		switch {
+		case rec.Job != nil:
+			job := rec.Job.toJob()
+			err := b.jobs.Put(b.scope(ctx, job.Tenant), job, replace)
+			if err == nil {
+				result.RestoredJobs++
+				continue
+			}
+			if !conflict(err) {
+				return result, fmt.Errorf("could not restore job %s: %v", job.ID, err)
+			}
+			if policy == PolicyFail {
+				return result, &ConflictError{Kind: "job", ID: job.ID, Err: err}
+			}
+			// An existing job with the same ID keeps the runs, a job whose name is taken doesn't exist
+			if err != repository.ErrExists {
+				left[job.ID] = true
+				b.logger.Warn("Could not restore job", zap.String("job_id", job.ID), zap.String("job_name", job.Name), zap.Error(err))
+			}
+			result.SkippedJobs++
+		case rec.Run != nil:
+			run := rec.Run.toRun()
+			if left[run.JobID] {
+				result.SkippedRuns++
+				continue
+			}
+			err := b.runs.Put(b.scope(ctx, run.Tenant), run, replace)
+			if err == nil {
+				result.RestoredRuns++
+				continue
+			}
+			if !conflict(err) {
+				return result, fmt.Errorf("could not restore run %s: %v", run.ID, err)
+			}
+			if policy == PolicyFail {
+				return result, &ConflictError{Kind: "run", ID: run.ID, Err: err}
+			}
+			result.SkippedRuns++
+		}
+	}
+}
+
+// scope returns the context a job or run of tenant t is restored with, callers with a tenant restore into theirs
+func (b *Backups) scope(ctx context.Context, t string) context.Context {
+	if tenant.FromContext(ctx) != "" {
+		return ctx
+	}
+	return tenant.NewContext(ctx, t)
+}
+
+// conflict reports whether err means a job or run of a backup collides with a stored one
+func conflict(err error) bool {
+	switch err {
+	case repository.ErrExists, repository.ErrNameTaken, repository.ErrIdempotencyKeyUsed, repository.ErrRunExists:
+		return true
+	}
+	return false
+}
+
+// Run writes a backup of every tenant every interval until ctx is cancelled, the first one an interval after it started
+func (b *Backups) Run(ctx context.Context, interval time.Duration) {
+	b.logger.Info("Scheduled backups started", zap.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := b.Backup(ctx, false)
+		if err != nil {
+			if ctx.Err() == nil {
+				b.logger.Error("Could not back up jobs", zap.Error(err))
+			}
+			continue
+		}
+		b.logger.Info("Backed up jobs", zap.String("backup", info.Name), zap.Int64("jobs", info.Jobs), zap.Int64("runs", info.Runs),
+			zap.Int64("size", info.Size))
+	}
+}
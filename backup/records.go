@@ -0,0 +1,219 @@
+package backup
+
+import (
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+)
+
+// formatVersion is the version of the backup format written, it changes when backups can't be read the old way anymore
+const formatVersion = 1
+
+// header is the first line of every backup
+type header struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Tenant is the tenant the backup was taken for, empty when it holds the jobs of every tenant
+	Tenant string `json:"tenant_id,omitempty"`
+}
+
+// record is a line of a backup after the header, it holds either a job or a run. Runs follow the job they belong to.
+type record struct {
+	Job *jobRecord `json:"job,omitempty"`
+	Run *runRecord `json:"run,omitempty"`
+}
+
+// jobRecord is a job as it is backed up, durations are in nanoseconds
+type jobRecord struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Owner          string                 `json:"owner"`
+	Description    string                 `json:"description,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	Schedule       *scheduleRecord        `json:"schedule,omitempty"`
+	NextRunTime    *time.Time             `json:"next_run_time,omitempty"`
+	Handler        string                 `json:"handler,omitempty"`
+	Command        string                 `json:"command,omitempty"`
+	Status         string                 `json:"status"`
+	DeletedAt      *time.Time             `json:"deleted_at,omitempty"`
+	ArchivedAt     *time.Time             `json:"archived_at,omitempty"`
+	RetryPolicy    *scheduler.RetryPolicy `json:"retry_policy,omitempty"`
+	Timeout        time.Duration          `json:"timeout,omitempty"`
+	Tenant         string                 `json:"tenant_id,omitempty"`
+	IdempotencyKey string                 `json:"idempotency_key,omitempty"`
+	Labels         map[string]string      `json:"labels,omitempty"`
+	DependsOn      []string               `json:"depends_on,omitempty"`
+	Priority       string                 `json:"priority"`
+	// ConcurrencyPolicy is missing from backups taken before jobs had one
+	ConcurrencyPolicy string `json:"concurrency_policy,omitempty"`
+	// CatchUpPolicy is missing from backups taken before jobs had one
+	CatchUpPolicy string                      `json:"catch_up_policy,omitempty"`
+	Notifications *repository.Notifications   `json:"notifications,omitempty"`
+	Template      *repository.TemplateLineage `json:"template,omitempty"`
+	Blackout      *repository.Blackout        `json:"blackout,omitempty"`
+	Params        map[string]interface{}      `json:"params,omitempty"`
+	Environment   string                      `json:"environment,omitempty"`
+	Promotion     *repository.Promotion       `json:"promotion,omitempty"`
+}
+
+// scheduleRecord is the schedule of a backed up job
+type scheduleRecord struct {
+	Cron     string        `json:"cron,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Timezone string        `json:"timezone,omitempty"`
+}
+
+// runRecord is a run as it is backed up, durations are in nanoseconds
+type runRecord struct {
+	ID          string        `json:"id"`
+	JobID       string        `json:"job_id"`
+	Status      string        `json:"status"`
+	QueuedAt    time.Time     `json:"queued_at"`
+	StartTime   *time.Time    `json:"start_time,omitempty"`
+	EndTime     *time.Time    `json:"end_time,omitempty"`
+	Output      string        `json:"output,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Attempt     int           `json:"attempt"`
+	RetryAt     *time.Time    `json:"retry_at,omitempty"`
+	Timeout     time.Duration `json:"timeout,omitempty"`
+	Duration    time.Duration `json:"duration,omitempty"`
+	Tenant      string        `json:"tenant_id,omitempty"`
+	CycleID     string        `json:"cycle_id,omitempty"`
+	Priority    string        `json:"priority"`
+	Trigger     string        `json:"trigger,omitempty"`
+	TriggeredBy string        `json:"triggered_by,omitempty"`
+	Worker      string        `json:"worker,omitempty"`
+	// Backups taken before costs were recorded restore runs that cost nothing
+	CostPerSecond float64 `json:"cost_per_second,omitempty"`
+	Cost          float64 `json:"cost,omitempty"`
+}
+
+func newJobRecord(job *repository.Job) *jobRecord {
+	r := &jobRecord{
+		ID:                job.ID,
+		Name:              job.Name,
+		Owner:             job.Owner,
+		Description:       job.Description,
+		CreatedAt:         job.CreatedAt,
+		UpdatedAt:         job.UpdatedAt,
+		NextRunTime:       job.NextRunTime,
+		Handler:           job.Handler,
+		Command:           job.Command,
+		Status:            job.Status,
+		DeletedAt:         job.DeletedAt,
+		ArchivedAt:        job.ArchivedAt,
+		RetryPolicy:       job.RetryPolicy,
+		Timeout:           job.Timeout,
+		Tenant:            job.Tenant,
+		IdempotencyKey:    job.IdempotencyKey,
+		Labels:            job.Labels,
+		DependsOn:         job.DependsOn,
+		Priority:          job.Priority,
+		ConcurrencyPolicy: job.ConcurrencyPolicy,
+		CatchUpPolicy:     job.CatchUpPolicy,
+		Notifications:     job.Notifications,
+		Template:          job.Template,
+		Blackout:          job.Blackout,
+		Params:            job.Params,
+		Environment:       job.Environment,
+		Promotion:         job.Promotion,
+	}
+	if job.Schedule != nil {
+		r.Schedule = &scheduleRecord{Cron: job.Schedule.Cron, Interval: job.Schedule.Interval, Timezone: job.Schedule.Timezone}
+	}
+	return r
+}
+
+func (r *jobRecord) toJob() *repository.Job {
+	job := &repository.Job{
+		ID:                r.ID,
+		Name:              r.Name,
+		Owner:             r.Owner,
+		Description:       r.Description,
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+		NextRunTime:       r.NextRunTime,
+		Handler:           r.Handler,
+		Command:           r.Command,
+		Status:            r.Status,
+		DeletedAt:         r.DeletedAt,
+		ArchivedAt:        r.ArchivedAt,
+		RetryPolicy:       r.RetryPolicy,
+		Timeout:           r.Timeout,
+		Tenant:            r.Tenant,
+		IdempotencyKey:    r.IdempotencyKey,
+		Labels:            r.Labels,
+		DependsOn:         r.DependsOn,
+		Priority:          r.Priority,
+		ConcurrencyPolicy: r.ConcurrencyPolicy,
+		CatchUpPolicy:     r.CatchUpPolicy,
+		Notifications:     r.Notifications,
+		Template:          r.Template,
+		Blackout:          r.Blackout,
+		Params:            r.Params,
+		Environment:       r.Environment,
+		Promotion:         r.Promotion,
+	}
+	if job.ConcurrencyPolicy == "" {
+		job.ConcurrencyPolicy = repository.ConcurrencyAllow
+	}
+	if job.CatchUpPolicy == "" {
+		job.CatchUpPolicy = scheduler.CatchUpFireOnce
+	}
+	if r.Schedule != nil {
+		job.Schedule = &scheduler.Spec{Cron: r.Schedule.Cron, Interval: r.Schedule.Interval, Timezone: r.Schedule.Timezone}
+	}
+	return job
+}
+
+func newRunRecord(run *repository.Run) *runRecord {
+	return &runRecord{
+		ID:            run.ID,
+		JobID:         run.JobID,
+		Status:        run.Status,
+		QueuedAt:      run.QueuedAt,
+		StartTime:     run.StartTime,
+		EndTime:       run.EndTime,
+		Output:        run.Output,
+		Error:         run.Error,
+		Attempt:       run.Attempt,
+		RetryAt:       run.RetryAt,
+		Timeout:       run.Timeout,
+		Duration:      run.Duration,
+		Tenant:        run.Tenant,
+		CycleID:       run.CycleID,
+		Priority:      run.Priority,
+		Trigger:       run.Trigger,
+		TriggeredBy:   run.TriggeredBy,
+		Worker:        run.Worker,
+		CostPerSecond: run.CostPerSecond,
+		Cost:          run.Cost,
+	}
+}
+
+func (r *runRecord) toRun() *repository.Run {
+	return &repository.Run{
+		ID:            r.ID,
+		JobID:         r.JobID,
+		Status:        r.Status,
+		QueuedAt:      r.QueuedAt,
+		StartTime:     r.StartTime,
+		EndTime:       r.EndTime,
+		Output:        r.Output,
+		Error:         r.Error,
+		Attempt:       r.Attempt,
+		RetryAt:       r.RetryAt,
+		Timeout:       r.Timeout,
+		Duration:      r.Duration,
+		Tenant:        r.Tenant,
+		CycleID:       r.CycleID,
+		Priority:      r.Priority,
+		Trigger:       r.Trigger,
+		TriggeredBy:   r.TriggeredBy,
+		Worker:        r.Worker,
+		CostPerSecond: r.CostPerSecond,
+		Cost:          r.Cost,
+	}
+}
@@ -0,0 +1,164 @@
+package backup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty body, the payload hash of requests without one
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3Store keeps backups as objects in an S3 compatible bucket, requests are signed with AWS Signature Version 4
+type s3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	bucket   string
+	// prefix is prepended to the names of backups, it has no leading or trailing slash
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+	// virtualHost addresses the bucket as a subdomain of the endpoint instead of the first segment of the path
+	virtualHost bool
+}
+
+// url returns the URL of the object of the backup with the given name
+func (s *s3Store) url(name string) *url.URL {
+	key := name
+	if s.prefix != "" {
+		key = s.prefix + "/" + name
+	}
+	u := *s.endpoint
+	u.Path = "/" + key
+	if s.virtualHost {
+		u.Host = s.bucket + "." + u.Host
+	} else {
+		u.Path = "/" + s.bucket + u.Path
+	}
+	// Signatures cover the path escaped the way S3 escapes it, which url.URL would do differently
+	u.RawPath = escapePath(u.Path)
+	return &u
+}
+
+func (s *s3Store) Put(ctx context.Context, name string, file *os.File) error {
+	// S3 needs the length of a single part upload up front, hashing the file also tells it
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url(name).String(), ioutil.NopCloser(file))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/gzip")
+	s.sign(req, hex.EncodeToString(hash.Sum(nil)), time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64<<10))
+	return nil
+}
+
+func (s *s3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url(name).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, emptyPayloadHash, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp.Body, nil
+}
+
+// sign adds the headers of AWS Signature Version 4 to req, signing the host, the date and the payload hash
+func (s *s3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	date := now.UTC().Format("20060102T150405Z")
+	day := date[:8]
+	req.Header.Set("X-Amz-Date", date)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + date + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + s.region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + s.secretKey)
+	for _, part := range []string{day, s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+", SignedHeaders="+
+		signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapePath percent-encodes everything in p but unreserved characters and slashes, like S3 expects in signatures
+func escapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// responseError returns the error an S3 compatible service answered with, the code and message of its XML body
+// when it has one
+func responseError(resp *http.Response) error {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err != nil || body.Code == "" {
+		return fmt.Errorf("object storage answered %s", resp.Status)
+	}
+	return fmt.Errorf("object storage answered %s: %s: %s", resp.Status, body.Code, body.Message)
+}
@@ -0,0 +1,126 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by Get when there is no backup with the name
+var ErrNotFound = errors.New("backup not found")
+
+// Store keeps backups by name
+type Store interface {
+	// Put stores the contents of file under name, replacing a backup with the same name
+	Put(ctx context.Context, name string, file *os.File) error
+	// Get opens the backup with the given name, ErrNotFound when there is none
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+}
+
+// S3Options are the endpoint and credentials of stores in S3 and Google Cloud Storage buckets. Cloud Storage is used
+// through its S3 compatible XML API with an HMAC key.
+type S3Options struct {
+	// Endpoint is the URL of an S3 compatible service like MinIO, AWS or Cloud Storage are used when it's empty
+	Endpoint string
+	// Region defaults to us-east-1 for S3 and auto for Cloud Storage
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewStore returns the store for location, which is s3://bucket/prefix, gs://bucket/prefix or a local directory
+func NewStore(location string, opts S3Options) (Store, error) {
+	if !strings.HasPrefix(location, "s3://") && !strings.HasPrefix(location, "gs://") {
+		return &dirStore{dir: location}, nil
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("backup location %q has no bucket", location)
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, errors.New("backups in buckets need an access key ID and a secret access key")
+	}
+	s := &s3Store{
+		client:    &http.Client{Timeout: 30 * time.Minute},
+		bucket:    u.Host,
+		prefix:    strings.Trim(u.Path, "/"),
+		region:    opts.Region,
+		accessKey: opts.AccessKeyID,
+		secretKey: opts.SecretAccessKey,
+	}
+	endpoint := opts.Endpoint
+	switch {
+	case u.Scheme == "gs":
+		if s.region == "" {
+			s.region = "auto"
+		}
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+	default:
+		if s.region == "" {
+			s.region = "us-east-1"
+		}
+		if endpoint == "" {
+			// AWS prefers buckets in the host name, other services are more likely to support them in the path
+			endpoint = "https://s3." + s.region + ".amazonaws.com"
+			s.virtualHost = true
+		}
+	}
+	s.endpoint, err = url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup endpoint: %v", err)
+	}
+	if s.endpoint.Scheme != "http" && s.endpoint.Scheme != "https" || s.endpoint.Host == "" {
+		return nil, fmt.Errorf("backup endpoint %q must be an http or https URL", endpoint)
+	}
+	return s, nil
+}
+
+// dirStore keeps backups as files in a local directory, which may be a mounted volume
+type dirStore struct {
+	dir string
+}
+
+func (s *dirStore) Put(ctx context.Context, name string, file *os.File) error {
+	if err := os.MkdirAll(s.dir, 0750); err != nil {
+		return err
+	}
+	// Write next to the backup and rename, so a backup is never seen half written
+	tmp, err := ioutil.TempFile(s.dir, "."+name+".")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
+
+func (s *dirStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
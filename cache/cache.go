@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Backends jobs can be cached in
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Store keeps values by key until their TTL passed
+type Store interface {
+	// Get returns the value of key, false when there is none or it expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	Close() error
+}
+
+// LRU keeps values in the memory of the process and drops the least recently used one when it's full. Every replica
+// has its own, so it doesn't see the changes of other replicas.
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// entry is a value kept by LRU, the elements of its order hold them
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRU creates a store keeping at most size values
+func NewLRU(size int) *LRU {
+	return &LRU{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *LRU) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := elem.Value.(*entry)
+	if !time.Now().Before(e.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true, nil
+}
+
+func (c *LRU) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = &entry{key: key, value: value, expires: time.Now().Add(ttl)}
+		c.order.MoveToFront(elem)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expires: time.Now().Add(ttl)})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+	return nil
+}
+
+func (c *LRU) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *LRU) Close() error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// jobKey returns the key a job is cached under, jobs are cached once for all owners and tenants
+func jobKey(id string) string {
+	return "job:" + id
+}
+
+// Jobs is a JobRepository that keeps the jobs Read returned in a Store and drops them again whenever they are
+// changed through it. Everything else goes to the repository, so only calls that can live with a job that is up to
+// the TTL old should Read. Jobs changed by a replica that doesn't share the store, by a transaction that committed
+// after a Read cached the job from before it, and purged jobs stay in the cache until the TTL passed.
+type Jobs struct {
+	repository.JobRepository
+	store  Store
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+// NewJobs creates a repository caching the jobs of jobs in store for ttl
+func NewJobs(jobs repository.JobRepository, store Store, ttl time.Duration, logger *zap.Logger) *Jobs {
+	return &Jobs{JobRepository: jobs, store: store, ttl: ttl, logger: logger}
+}
+
+// Read returns the job with id like Get, from the cache when it's there. A cache that can't be reached is logged and
+// bypassed.
+func (j *Jobs) Read(ctx context.Context, id string, q repository.Query) (*repository.Job, error) {
+	if job, ok := j.cached(ctx, id); ok {
+		if t := tenant.FromContext(ctx); t != "" && job.Tenant != t {
+			// Let the repository tell jobs of other tenants from missing ones
+			return j.JobRepository.Get(ctx, id, q)
+		}
+		return matching(job, q)
+	}
+	// Deleted jobs are cached too, so a cached job can be returned whether or not the query includes deleted ones
+	job, err := j.JobRepository.Get(ctx, id, repository.Query{IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	if err := j.store.Set(ctx, jobKey(id), data, j.ttl); err != nil {
+		j.logger.Warn("Could not cache job", zap.String("job_id", id), zap.Error(err))
+	}
+	return matching(job, q)
+}
+
+// matching returns job when it matches the owner and deletion of q, repository.ErrNotFound otherwise
+func matching(job *repository.Job, q repository.Query) (*repository.Job, error) {
+	if !q.OwnedBy(job.Owner) || (job.DeletedAt != nil && !q.IncludeDeleted) {
+		return nil, repository.ErrNotFound
+	}
+	return job, nil
+}
+
+// cached returns the job with id from the cache and counts the hit or miss
+func (j *Jobs) cached(ctx context.Context, id string) (*repository.Job, bool) {
+	data, ok, err := j.store.Get(ctx, jobKey(id))
+	if err != nil {
+		metrics.RecordCacheLookup("error")
+		j.logger.Warn("Could not read cached job", zap.String("job_id", id), zap.Error(err))
+		return nil, false
+	}
+	if !ok {
+		metrics.RecordCacheLookup("miss")
+		return nil, false
+	}
+	job := &repository.Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		metrics.RecordCacheLookup("error")
+		j.logger.Warn("Could not decode cached job", zap.String("job_id", id), zap.Error(err))
+		return nil, false
+	}
+	metrics.RecordCacheLookup("hit")
+	return job, true
+}
+
+// drop removes the job with id from the cache after it was changed, whether or not the change succeeded
+func (j *Jobs) drop(ctx context.Context, id string) {
+	if err := j.store.Delete(ctx, jobKey(id)); err != nil {
+		j.logger.Error("Could not drop changed job from the cache, it is read from there until it expires", zap.String("job_id", id),
+			zap.Duration("ttl", j.ttl), zap.Error(err))
+	}
+}
+
+func (j *Jobs) Put(ctx context.Context, job *repository.Job, replace bool) error {
+	defer j.drop(ctx, job.ID)
+	return j.JobRepository.Put(ctx, job, replace)
+}
+
+func (j *Jobs) Update(ctx context.Context, id string, q repository.Query, update *repository.JobUpdate) (*repository.Job, error) {
+	defer j.drop(ctx, id)
+	return j.JobRepository.Update(ctx, id, q, update)
+}
+
+func (j *Jobs) Delete(ctx context.Context, id string, q repository.Query, deletedAt time.Time) (bool, error) {
+	defer j.drop(ctx, id)
+	return j.JobRepository.Delete(ctx, id, q, deletedAt)
+}
+
+func (j *Jobs) SetStatus(ctx context.Context, id string, q repository.Query, from, to string, updatedAt time.Time) (*repository.Job, error) {
+	defer j.drop(ctx, id)
+	return j.JobRepository.SetStatus(ctx, id, q, from, to, updatedAt)
+}
+
+func (j *Jobs) Restore(ctx context.Context, id string, q repository.Query, updatedAt time.Time) (*repository.Job, error) {
+	defer j.drop(ctx, id)
+	return j.JobRepository.Restore(ctx, id, q, updatedAt)
+}
+
+func (j *Jobs) ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error) {
+	defer j.drop(ctx, id)
+	return j.JobRepository.ClaimNextRun(ctx, id, prev, next)
+}
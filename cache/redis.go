@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// keyPrefix keeps the keys of the cache apart from other data in the same Redis database
+const keyPrefix = "schedulytics:"
+
+// Redis keeps values in Redis, which expires them. All replicas share it, so changes made by one replica are seen
+// by the others.
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a store for the Redis server at url, redis://[:password@]host:port/db or rediss:// for TLS. It
+// only connects once it's used.
+func NewRedis(url string) (*Redis, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return &Redis{client: redis.NewClient(opts)}, nil
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, keyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, keyPrefix+key, value, ttl).Err()
+}
+
+func (c *Redis) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, keyPrefix+key).Err()
+}
+
+func (c *Redis) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,94 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+)
+
+// Reloader holds the server certificate (and optionally a client CA pool) and can re-read them from disk
+// without restarting the server. Every new TLS handshake picks up the most recently loaded files.
+type Reloader struct {
+	certFile          string
+	keyFile           string
+	clientCAFile      string
+	requireClientCert bool
+
+	mu     sync.RWMutex
+	config *tls.Config
+}
+
+// NewReloader loads the certificate/key pair and the optional client CA and returns a ready to use Reloader
+func NewReloader(certFile, keyFile, clientCAFile string, requireClientCert bool) (*Reloader, error) {
+	if requireClientCert && clientCAFile == "" {
+		return nil, errors.New("a client CA is required to enforce mutual TLS")
+	}
+	r := &Reloader{
+		certFile:          certFile,
+		keyFile:           keyFile,
+		clientCAFile:      clientCAFile,
+		requireClientCert: requireClientCert,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads all files again. On error the previously loaded configuration stays active.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load certificate/key pair: %v", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// gRPC requires HTTP/2 to be negotiated via ALPN
+		NextProtos: []string{"h2"},
+	}
+
+	if r.clientCAFile != "" {
+		pem, err := ioutil.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("could not read client CA: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no valid certificates found in %s", r.clientCAFile)
+		}
+		config.ClientCAs = pool
+		// Without enforcement client certificates are still verified when a client presents one
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.requireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	r.mu.Lock()
+	r.config = config
+	r.mu.Unlock()
+	return nil
+}
+
+// TLSConfig returns a tls.Config that always hands out the most recently loaded certificates
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.config, nil
+		},
+	}
+}
+
+// GetCertificate returns the most recently loaded certificate, for servers that only need the certificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &r.config.Certificates[0], nil
+}
@@ -0,0 +1,1089 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/backup"
+	"github.com/noltedennis/schedulytics-backend/cache"
+	"github.com/noltedennis/schedulytics-backend/events"
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/middleware"
+	"github.com/noltedennis/schedulytics-backend/quota"
+	"github.com/noltedennis/schedulytics-backend/ratelimit"
+	"github.com/noltedennis/schedulytics-backend/report"
+	"github.com/noltedennis/schedulytics-backend/secrets"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.uber.org/zap/zapcore"
+)
+
+// Defaults used when neither a flag nor an environment variable is set
+const (
+	defaultMongoUser           = "schedulytics"
+	defaultMongoHost           = "mongodb:27017"
+	defaultMongoDatabase       = "schedulytics"
+	defaultMongoCollection     = "job"
+	defaultRunCollection       = "job_run"
+	defaultLeaseCollection     = "lease"
+	defaultAuditCollection     = "audit"
+	defaultHookCollection      = "webhook"
+	defaultLetterCollection    = "webhook_dead_letter"
+	defaultOutboxCollection    = "outbox"
+	defaultUserCollection      = "user"
+	defaultAPIKeyCollection    = "api_key"
+	defaultTeamCollection      = "team"
+	defaultTemplateCollection  = "template"
+	defaultCalendarCollection  = "calendar"
+	defaultSecretCollection    = "secret"
+	defaultChangeCollection    = "job_change"
+	defaultRevisionCollection  = "job_revision"
+	defaultRetentionCollection = "run_retention"
+	defaultSLACollection       = "sla"
+	defaultBreachCollection    = "sla_breach"
+	defaultAnomalyCollection   = "run_anomaly"
+	defaultMigrationCollection = "migration"
+	defaultEventsTopic         = "schedulytics.events"
+	defaultListenAddr          = "0.0.0.0:8010"
+	// The maintenance mode of the scheduler is stored once for all tenants
+	defaultMaintenanceCollection = "maintenance"
+	// Like the maintenance mode, the environments of all tenants are stored in one collection
+	defaultEnvironmentCollection = "environment"
+	// The limits gRPC applies itself when none are set
+	defaultMaxRecvMsgSize = 4 << 20
+	defaultMaxSendMsgSize = math.MaxInt32
+)
+
+// Storage backends that can be selected with StorageBackend
+const (
+	StorageMongoDB  = "mongodb"
+	StoragePostgres = "postgres"
+	// StorageMemory keeps everything in the memory of the process, it is lost on restart
+	StorageMemory = "memory"
+	// StorageSQLite keeps everything in a SQLite database file, for a single replica
+	StorageSQLite = "sqlite"
+)
+
+// Config holds every setting the server needs at startup
+type Config struct {
+	// ConfigFile is the path of the YAML or TOML file settings are read from, environment variables and flags
+	// overwrite them
+	ConfigFile string
+	// PrintConfig prints the effective configuration with secrets redacted instead of starting the server
+	PrintConfig bool
+	// ConfigWatch reloads the configuration whenever ConfigFile changes, not only on SIGHUP
+	ConfigWatch bool
+
+	// StorageBackend selects where jobs and runs are stored, StorageMongoDB, StoragePostgres, StorageMemory or
+	// StorageSQLite
+	StorageBackend string
+	// PostgresURL is the connection string of the PostgreSQL database
+	PostgresURL string
+	// SQLitePath is the path of the SQLite database file, it is created when it doesn't exist
+	SQLitePath string
+
+	// MongoURI is the full MongoDB connection string
+	MongoURI string
+	// MongoDatabase is the database that holds the job collection
+	MongoDatabase string
+	// MongoCollection is the collection jobs are stored in
+	MongoCollection string
+	// MongoRunCollection is the collection job run records are stored in
+	MongoRunCollection string
+	// MongoLeaseCollection is the collection the leader election keeps its lease in
+	MongoLeaseCollection string
+	// MongoAuditCollection is the collection the audit log is stored in
+	MongoAuditCollection string
+	// MongoWebhookCollection is the collection webhooks are stored in
+	MongoWebhookCollection string
+	// MongoDeadLetterCollection is the collection deliveries to webhooks that failed every attempt are stored in
+	MongoDeadLetterCollection string
+	// MongoOutboxCollection is the collection events are kept in until they were published
+	MongoOutboxCollection string
+	// MongoUserCollection is the collection users are stored in, always in MongoDatabase
+	MongoUserCollection string
+	// MongoAPIKeyCollection is the collection the API keys of users are stored in, always in MongoDatabase
+	MongoAPIKeyCollection string
+	// MongoTeamCollection is the collection teams are stored in
+	MongoTeamCollection string
+	// MongoTemplateCollection is the collection job templates are stored in
+	MongoTemplateCollection string
+	// MongoCalendarCollection is the collection calendars are stored in
+	MongoCalendarCollection string
+	// MongoSecretCollection is the collection secrets are stored in
+	MongoSecretCollection string
+	// MongoChangeCollection is the collection the changes of jobs waiting for approval are stored in
+	MongoChangeCollection string
+	// MongoRevisionCollection is the collection the versions of jobs before their updates are stored in
+	MongoRevisionCollection string
+	// MongoMaintenanceCollection is the collection the maintenance mode of the scheduler is stored in, always in
+	// MongoDatabase
+	MongoMaintenanceCollection string
+	// MongoEnvironmentCollection is the collection the paused environments of all tenants are stored in, always in
+	// MongoDatabase
+	MongoEnvironmentCollection string
+	// MongoRetentionCollection is the collection the retention policies of runs are stored in, always in MongoDatabase
+	MongoRetentionCollection string
+	// MongoSLACollection and MongoSLABreachCollection are the collections the SLAs of jobs and their breaches are
+	// stored in, always in MongoDatabase
+	MongoSLACollection       string
+	MongoSLABreachCollection string
+	// MongoAnomalyCollection is the collection the anomalies of runs are stored in
+	MongoAnomalyCollection string
+	// MongoMigrationCollection is the collection the applied migrations are recorded in, in every database
+	MongoMigrationCollection string
+	// MongoMigrateDryRun makes the server log the migrations it would apply and exit without changing anything
+	MongoMigrateDryRun bool
+	// MongoSkipMigrations starts the server without migrating, for users that may only read. The indexes must have
+	// been created by a server with write access before.
+	MongoSkipMigrations bool
+	// MongoConnectTimeout is how long the server keeps trying to reach MongoDB on startup before giving up
+	MongoConnectTimeout time.Duration
+	// MongoCursorBatchSize is the number of jobs the cursors of listings fetch from MongoDB per round trip, the
+	// driver's default when it's 0
+	MongoCursorBatchSize int
+
+	// ListenAddr is the host:port the gRPC server listens on, or unix:<path> for a Unix domain socket
+	ListenAddr string
+	// KeepaliveTime is how long a connection may be idle before the server pings the client, so load balancers see
+	// traffic on idle streams. KeepaliveTimeout is how long it waits for the answer before closing the connection.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// KeepaliveMinTime is how often clients may send keepalive pings at most, clients pinging more often are disconnected
+	KeepaliveMinTime time.Duration
+	// KeepalivePermitWithoutStream lets clients send keepalive pings while they have no call in flight
+	KeepalivePermitWithoutStream bool
+	// MaxRecvMsgSize and MaxSendMsgSize are the largest messages in bytes the gRPC server receives and sends, calls
+	// with larger ones fail with ResourceExhausted. Compressed requests must also fit once decompressed, compressed
+	// responses only need to fit compressed.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// TLSCertFile and TLSKeyFile enable TLS on the gRPC server when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile is used to verify client certificates (mutual TLS)
+	TLSClientCAFile string
+	// TLSRequireClientCert rejects clients that don't present a certificate signed by TLSClientCAFile
+	TLSRequireClientCert bool
+
+	// SchedulerEnabled starts the in-process scheduler that fires due jobs
+	SchedulerEnabled bool
+	// SchedulerPollInterval is how often the scheduler looks for due jobs
+	SchedulerPollInterval time.Duration
+	// LeaderElection only runs the scheduler and hands out the runs of external workers on the replica holding the
+	// scheduler lease
+	LeaderElection bool
+	// LeaderLeaseTTL is how long the lease stays valid without being renewed, so how long failover takes at most
+	LeaderLeaseTTL time.Duration
+
+	// ExecutorWorkers is the number of jobs that can run at the same time
+	ExecutorWorkers int
+	// ExecutorQueueSize is the number of runs that can wait for a free worker
+	ExecutorQueueSize int
+	// ExecutorPriorityShares are the shares of the workers runs of every priority get while several are waiting
+	ExecutorPriorityShares executor.Shares
+	// ExecutorMaxQueueWait is how long a queued run waits at most before it gets the next free worker regardless of
+	// its priority, 0 always follows the shares
+	ExecutorMaxQueueWait time.Duration
+	// ExecutorCostPerSecond is what a second of compute time of a run costs, runs cost nothing when it's 0
+	ExecutorCostPerSecond float64
+	// WorkerLeaseTTL is how long external workers keep the runs they leased without sending a heartbeat
+	WorkerLeaseTTL time.Duration
+
+	// DeletedJobRetention is how long deleted jobs can be restored before they are purged, 0 keeps them forever
+	DeletedJobRetention time.Duration
+	// PurgeInterval is how often deleted jobs past their retention are purged and runs past their retention policy
+	// removed
+	PurgeInterval time.Duration
+	// IdempotencyKeyRetention is how long the idempotency keys of CreateJob are kept at least
+	IdempotencyKeyRetention time.Duration
+	// SLACheckInterval is how often the SLA monitor checks whether the deadlines of jobs were met
+	SLACheckInterval time.Duration
+	// AnomalyThreshold is how many standard deviations the duration of a run may be away from the baseline of its
+	// job before it is an anomaly, 0 disables anomaly detection
+	AnomalyThreshold float64
+	// AnomalyWindow is the number of succeeded runs the baseline of a job is computed from, AnomalyMinRuns the number
+	// a job needs at least before its runs are checked
+	AnomalyWindow  int
+	AnomalyMinRuns int
+
+	// DisabledMiddlewares are the names of optional middlewares the server runs without
+	DisabledMiddlewares []string
+	// AuditLog records every change made to jobs through the API
+	AuditLog bool
+
+	// ImportBatchSize is the number of jobs ImportJobs stores at once
+	ImportBatchSize int
+
+	// WebhookWorkers is the number of deliveries to webhooks that are made at the same time
+	WebhookWorkers int
+	// WebhookQueueSize is the number of events that can wait for a free worker, later ones become dead letters
+	WebhookQueueSize int
+	// WebhookMaxAttempts is the number of attempts of a delivery before it becomes a dead letter
+	WebhookMaxAttempts int
+	// WebhookTimeout is how long a single attempt may take
+	WebhookTimeout time.Duration
+
+	// SMTPAddr is the host:port of the SMTP server notification emails are sent over, empty disables emails
+	SMTPAddr string
+	// SMTPUsername and SMTPPassword authenticate with the SMTP server when the username is set
+	SMTPUsername string
+	SMTPPassword string
+	// SMTPFrom is the sender address of notification emails
+	SMTPFrom string
+	// NotificationTemplates is the path of a file whose templates replace the built-in notification messages
+	NotificationTemplates string
+	// NotificationTimeout is how long sending a notification to a single channel may take
+	NotificationTimeout time.Duration
+
+	// EventsBroker is events.BrokerNATS or events.BrokerKafka, empty doesn't publish events
+	EventsBroker string
+	// EventsNATSURL is the NATS server events are published to
+	EventsNATSURL string
+	// EventsKafkaBrokers are the host:port addresses of the Kafka brokers events are published to
+	EventsKafkaBrokers []string
+	// EventsTopic is the Kafka topic of all events or the prefix of the NATS subjects
+	EventsTopic string
+	// EventsPollInterval is how often the outbox is checked for events that weren't published yet
+	EventsPollInterval time.Duration
+
+	// BackupLocation is where backups are kept, s3://bucket/prefix, gs://bucket/prefix or a local directory. Empty
+	// disables backups.
+	BackupLocation string
+	// BackupEndpoint is the URL of an S3 compatible service, AWS or Cloud Storage are used when it's empty
+	BackupEndpoint string
+	// BackupRegion is the region of the bucket, us-east-1 for S3 and auto for Cloud Storage when it's empty
+	BackupRegion string
+	// BackupAccessKeyID and BackupSecretAccessKey sign the requests to buckets, Cloud Storage needs an HMAC key
+	BackupAccessKeyID     string
+	BackupSecretAccessKey string
+	// BackupInterval is how often the jobs and runs of every tenant are backed up, zero only backs them up on request
+	BackupInterval time.Duration
+
+	// SecretsMasterKey is the base64 encoded 32 byte key the values of secrets are encrypted with, SecretsMasterKeyFile
+	// the path of a file holding it, like one written by a KMS or secret manager. Secrets are disabled without either.
+	SecretsMasterKey     string
+	SecretsMasterKeyFile string
+
+	// ReportSchedule are the periods, report.PeriodWeek or report.PeriodMonth, whose reports are delivered once they
+	// ended. Empty only generates reports on request.
+	ReportSchedule []string
+	// ReportFormat is report.FormatCSV or report.FormatPDF, the format scheduled reports are written in
+	ReportFormat string
+	// ReportRecipients are the email addresses scheduled reports are sent to
+	ReportRecipients []string
+	// ReportLocation is where scheduled reports are kept, like BackupLocation. Buckets use the endpoint, region and
+	// credentials of the backups.
+	ReportLocation string
+
+	// CacheBackend is cache.BackendMemory or cache.BackendRedis, empty reads every job from the storage backend
+	CacheBackend string
+	// CacheTTL is how long a job stays cached at most, changes made by other replicas show up after it at the latest
+	CacheTTL time.Duration
+	// CacheSize is the number of jobs the memory cache keeps
+	CacheSize int
+	// CacheRedisURL is the redis:// or rediss:// URL of the Redis server of the redis cache
+	CacheRedisURL string
+
+	// MetricsAddr is the address of the HTTP server exposing Prometheus metrics, empty disables it
+	MetricsAddr string
+	// HealthAddr is the address of the HTTP server of the liveness and readiness probes, empty disables it
+	HealthAddr string
+	// DebugEndpoints serves pprof profiles and expvar variables on HealthAddr to callers with the admin role
+	DebugEndpoints bool
+
+	// GatewayAddr is the address of the REST/JSON gateway, empty disables it
+	GatewayAddr string
+	// GatewayDocs serves the OpenAPI document of the gateway at /openapi.json and Swagger UI at /docs
+	GatewayDocs bool
+	// Reflection registers the gRPC reflection service, so tools can explore the API without the proto files, and the
+	// channelz service, so they can inspect the connections of the server
+	Reflection bool
+
+	// HealthCheckInterval is how often MongoDB is pinged to report the health of the services
+	HealthCheckInterval time.Duration
+
+	// LogLevel is the minimum level of log entries (debug, info, warn, error)
+	LogLevel string
+	// LogFormat is logging.FormatJSON or logging.FormatConsole
+	LogFormat string
+
+	// ShutdownTimeout is how long in-flight RPCs may take to finish after a shutdown signal
+	ShutdownTimeout time.Duration
+	// BackgroundShutdownTimeout is how long the background subsystems, like the scheduler and the executor, may take
+	// to stop altogether after a shutdown signal, 0 waits as long as they take
+	BackgroundShutdownTimeout time.Duration
+
+	// AuthJWKSURL is where the public keys for verifying bearer tokens are published, empty disables authentication
+	AuthJWKSURL string
+	// AuthIssuer and AuthAudience must match the iss and aud claims of a token when set
+	AuthIssuer   string
+	AuthAudience string
+	// AuthExemptMethods are full gRPC method names that can be called without a token
+	AuthExemptMethods []string
+	// RBAC checks the viewer, editor and admin roles of callers against the role every method needs
+	RBAC bool
+
+	// RateLimit applies to every method of every client without a limit in RateLimitMethods, zero doesn't limit them
+	RateLimit ratelimit.Limit
+	// RateLimitMethods are the limits of single methods by full gRPC method name
+	RateLimitMethods ratelimit.MethodLimits
+	// Quotas limit the jobs of owners and tenants and how often the jobs of owners are triggered, zero doesn't limit
+	Quotas quota.Limits
+
+	// MultiTenancy scopes all data to the tenant_id claim of the caller's token
+	MultiTenancy bool
+	// TenantDatabases maps tenants to the MongoDB database their data is kept in, all other tenants share MongoDatabase
+	TenantDatabases map[string]string
+
+	// flags are the flags of every setting, Print prints their values
+	flags *flag.FlagSet
+}
+
+// envVars maps every flag to the environment variable it can also be set with
+var envVars = map[string]string{
+	"config":                          "CONFIG_FILE",
+	"config-watch":                    "CONFIG_WATCH_ENABLED",
+	"storage-backend":                 "STORAGE_BACKEND",
+	"postgres-url":                    "POSTGRES_URL",
+	"sqlite-path":                     "SQLITE_PATH",
+	"mongo-uri":                       "MONGO_URI",
+	"mongo-db":                        "MONGO_DB",
+	"mongo-collection":                "MONGO_COLLECTION",
+	"mongo-run-collection":            "MONGO_RUN_COLLECTION",
+	"mongo-lease-collection":          "MONGO_LEASE_COLLECTION",
+	"mongo-audit-collection":          "MONGO_AUDIT_COLLECTION",
+	"mongo-webhook-collection":        "MONGO_WEBHOOK_COLLECTION",
+	"mongo-dead-letter-collection":    "MONGO_DEAD_LETTER_COLLECTION",
+	"mongo-outbox-collection":         "MONGO_OUTBOX_COLLECTION",
+	"mongo-user-collection":           "MONGO_USER_COLLECTION",
+	"mongo-api-key-collection":        "MONGO_API_KEY_COLLECTION",
+	"mongo-team-collection":           "MONGO_TEAM_COLLECTION",
+	"mongo-template-collection":       "MONGO_TEMPLATE_COLLECTION",
+	"mongo-calendar-collection":       "MONGO_CALENDAR_COLLECTION",
+	"mongo-secret-collection":         "MONGO_SECRET_COLLECTION",
+	"mongo-change-collection":         "MONGO_CHANGE_COLLECTION",
+	"mongo-revision-collection":       "MONGO_REVISION_COLLECTION",
+	"mongo-maintenance-collection":    "MONGO_MAINTENANCE_COLLECTION",
+	"mongo-environment-collection":    "MONGO_ENVIRONMENT_COLLECTION",
+	"mongo-retention-collection":      "MONGO_RETENTION_COLLECTION",
+	"mongo-sla-collection":            "MONGO_SLA_COLLECTION",
+	"mongo-sla-breach-collection":     "MONGO_SLA_BREACH_COLLECTION",
+	"mongo-anomaly-collection":        "MONGO_ANOMALY_COLLECTION",
+	"mongo-migration-collection":      "MONGO_MIGRATION_COLLECTION",
+	"mongo-migrate-dry-run":           "MONGO_MIGRATE_DRY_RUN",
+	"mongo-skip-migrations":           "MONGO_SKIP_MIGRATIONS",
+	"mongo-connect-timeout":           "MONGO_CONNECT_TIMEOUT",
+	"mongo-cursor-batch-size":         "MONGO_CURSOR_BATCH_SIZE",
+	"listen-addr":                     "GRPC_LISTEN_ADDR",
+	"keepalive-time":                  "GRPC_KEEPALIVE_TIME",
+	"keepalive-timeout":               "GRPC_KEEPALIVE_TIMEOUT",
+	"keepalive-min-time":              "GRPC_KEEPALIVE_MIN_TIME",
+	"keepalive-permit-without-stream": "GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM",
+	"max-recv-msg-size":               "GRPC_MAX_RECV_MSG_SIZE",
+	"max-send-msg-size":               "GRPC_MAX_SEND_MSG_SIZE",
+	"tls-cert":                        "TLS_CERT_FILE",
+	"tls-key":                         "TLS_KEY_FILE",
+	"tls-client-ca":                   "TLS_CLIENT_CA_FILE",
+	"tls-require-client-cert":         "TLS_REQUIRE_CLIENT_CERT",
+	"scheduler":                       "SCHEDULER_ENABLED",
+	"scheduler-poll-interval":         "SCHEDULER_POLL_INTERVAL",
+	"leader-election":                 "LEADER_ELECTION_ENABLED",
+	"leader-lease-ttl":                "LEADER_LEASE_TTL",
+	"executor-workers":                "EXECUTOR_WORKERS",
+	"executor-queue-size":             "EXECUTOR_QUEUE_SIZE",
+	"executor-priority-shares":        "EXECUTOR_PRIORITY_SHARES",
+	"executor-max-queue-wait":         "EXECUTOR_MAX_QUEUE_WAIT",
+	"executor-cost-per-second":        "EXECUTOR_COST_PER_SECOND",
+	"worker-lease-ttl":                "WORKER_LEASE_TTL",
+	"deleted-job-retention":           "DELETED_JOB_RETENTION",
+	"purge-interval":                  "PURGE_INTERVAL",
+	"sla-check-interval":              "SLA_CHECK_INTERVAL",
+	"anomaly-threshold":               "ANOMALY_THRESHOLD",
+	"anomaly-window":                  "ANOMALY_WINDOW",
+	"anomaly-min-runs":                "ANOMALY_MIN_RUNS",
+	"idempotency-key-retention":       "IDEMPOTENCY_KEY_RETENTION",
+	"audit-log":                       "AUDIT_LOG_ENABLED",
+	"disabled-middlewares":            "DISABLED_MIDDLEWARES",
+	"import-batch-size":               "IMPORT_BATCH_SIZE",
+	"webhook-workers":                 "WEBHOOK_WORKERS",
+	"webhook-queue-size":              "WEBHOOK_QUEUE_SIZE",
+	"webhook-max-attempts":            "WEBHOOK_MAX_ATTEMPTS",
+	"webhook-timeout":                 "WEBHOOK_TIMEOUT",
+	"smtp-addr":                       "SMTP_ADDR",
+	"smtp-username":                   "SMTP_USERNAME",
+	"smtp-password":                   "SMTP_PASSWORD",
+	"smtp-from":                       "SMTP_FROM",
+	"notification-templates":          "NOTIFICATION_TEMPLATES",
+	"notification-timeout":            "NOTIFICATION_TIMEOUT",
+	"events-broker":                   "EVENTS_BROKER",
+	"events-nats-url":                 "EVENTS_NATS_URL",
+	"events-kafka-brokers":            "EVENTS_KAFKA_BROKERS",
+	"events-topic":                    "EVENTS_TOPIC",
+	"events-poll-interval":            "EVENTS_POLL_INTERVAL",
+	"backup-location":                 "BACKUP_LOCATION",
+	"backup-endpoint":                 "BACKUP_ENDPOINT",
+	"backup-region":                   "BACKUP_REGION",
+	"backup-access-key-id":            "BACKUP_ACCESS_KEY_ID",
+	"backup-secret-access-key":        "BACKUP_SECRET_ACCESS_KEY",
+	"backup-interval":                 "BACKUP_INTERVAL",
+	"secrets-master-key":              "SECRETS_MASTER_KEY",
+	"secrets-master-key-file":         "SECRETS_MASTER_KEY_FILE",
+	"report-schedule":                 "REPORT_SCHEDULE",
+	"report-format":                   "REPORT_FORMAT",
+	"report-recipients":               "REPORT_RECIPIENTS",
+	"report-location":                 "REPORT_LOCATION",
+	"cache-backend":                   "CACHE_BACKEND",
+	"cache-ttl":                       "CACHE_TTL",
+	"cache-size":                      "CACHE_SIZE",
+	"cache-redis-url":                 "CACHE_REDIS_URL",
+	"metrics-addr":                    "METRICS_ADDR",
+	"health-addr":                     "HEALTH_ADDR",
+	"debug-endpoints":                 "DEBUG_ENDPOINTS_ENABLED",
+	"gateway-addr":                    "GATEWAY_ADDR",
+	"gateway-docs":                    "GATEWAY_DOCS_ENABLED",
+	"reflection":                      "REFLECTION_ENABLED",
+	"health-check-interval":           "HEALTH_CHECK_INTERVAL",
+	"log-level":                       "LOG_LEVEL",
+	"log-format":                      "LOG_FORMAT",
+	"shutdown-timeout":                "SHUTDOWN_TIMEOUT",
+	"background-shutdown-timeout":     "BACKGROUND_SHUTDOWN_TIMEOUT",
+	"auth-jwks-url":                   "AUTH_JWKS_URL",
+	"auth-issuer":                     "AUTH_ISSUER",
+	"auth-audience":                   "AUTH_AUDIENCE",
+	"auth-exempt-methods":             "AUTH_EXEMPT_METHODS",
+	"rbac":                            "RBAC_ENABLED",
+	"rate-limit":                      "RATE_LIMIT",
+	"rate-limit-methods":              "RATE_LIMIT_METHODS",
+	"quota-jobs-per-owner":            "QUOTA_JOBS_PER_OWNER",
+	"quota-jobs-per-tenant":           "QUOTA_JOBS_PER_TENANT",
+	"quota-concurrent-runs":           "QUOTA_CONCURRENT_RUNS",
+	"quota-runs-per-day":              "QUOTA_RUNS_PER_DAY",
+	"multi-tenancy":                   "MULTI_TENANCY_ENABLED",
+	"tenant-databases":                "TENANT_DATABASES",
+}
+
+// Load builds the configuration from the environment, the given command line arguments and the config file they name.
+// Precedence is: command line flag > environment variable > config file > built-in default.
+// When no MONGO_URI is supplied the legacy MONGO_PW variable is used to build the old default connection string.
+func Load(args []string) (*Config, error) {
+	cfg := &Config{}
+
+	fs := flag.NewFlagSet("schedulytics-backend", flag.ContinueOnError)
+	fs.StringVar(&cfg.ConfigFile, "config", "", "path of a .yaml, .yml or .toml file with settings named like the flags")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the effective configuration with secrets redacted and exit")
+	fs.BoolVar(&cfg.ConfigWatch, "config-watch", false, "reload the configuration whenever the config file changes")
+	fs.StringVar(&cfg.StorageBackend, "storage-backend", StorageMongoDB, "where jobs and runs are stored, mongodb, postgres, memory or sqlite")
+	fs.StringVar(&cfg.PostgresURL, "postgres-url", "", "PostgreSQL connection string")
+	fs.StringVar(&cfg.SQLitePath, "sqlite-path", "", "path of the SQLite database file")
+	fs.StringVar(&cfg.MongoURI, "mongo-uri", "", "MongoDB connection string")
+	fs.StringVar(&cfg.MongoDatabase, "mongo-db", "", "MongoDB database name (defaults to the database in the connection string)")
+	fs.StringVar(&cfg.MongoCollection, "mongo-collection", defaultMongoCollection, "MongoDB collection for jobs")
+	fs.StringVar(&cfg.MongoRunCollection, "mongo-run-collection", defaultRunCollection, "MongoDB collection for job runs")
+	fs.StringVar(&cfg.MongoLeaseCollection, "mongo-lease-collection", defaultLeaseCollection, "MongoDB collection for the leader election lease")
+	fs.StringVar(&cfg.MongoAuditCollection, "mongo-audit-collection", defaultAuditCollection, "MongoDB collection for the audit log")
+	fs.StringVar(&cfg.MongoWebhookCollection, "mongo-webhook-collection", defaultHookCollection, "MongoDB collection for webhooks")
+	fs.StringVar(&cfg.MongoDeadLetterCollection, "mongo-dead-letter-collection", defaultLetterCollection, "MongoDB collection for failed webhook deliveries")
+	fs.StringVar(&cfg.MongoOutboxCollection, "mongo-outbox-collection", defaultOutboxCollection, "MongoDB collection for events that weren't published yet")
+	fs.StringVar(&cfg.MongoUserCollection, "mongo-user-collection", defaultUserCollection, "MongoDB collection for users")
+	fs.StringVar(&cfg.MongoAPIKeyCollection, "mongo-api-key-collection", defaultAPIKeyCollection, "MongoDB collection for the API keys of users")
+	fs.StringVar(&cfg.MongoTeamCollection, "mongo-team-collection", defaultTeamCollection, "MongoDB collection for teams")
+	fs.StringVar(&cfg.MongoTemplateCollection, "mongo-template-collection", defaultTemplateCollection, "MongoDB collection for job templates")
+	fs.StringVar(&cfg.MongoCalendarCollection, "mongo-calendar-collection", defaultCalendarCollection, "MongoDB collection for calendars")
+	fs.StringVar(&cfg.MongoSecretCollection, "mongo-secret-collection", defaultSecretCollection, "MongoDB collection for secrets")
+	fs.StringVar(&cfg.MongoChangeCollection, "mongo-change-collection", defaultChangeCollection, "MongoDB collection for the changes of jobs waiting for approval")
+	fs.StringVar(&cfg.MongoRevisionCollection, "mongo-revision-collection", defaultRevisionCollection, "MongoDB collection for the revisions of jobs")
+	fs.StringVar(&cfg.MongoMaintenanceCollection, "mongo-maintenance-collection", defaultMaintenanceCollection, "MongoDB collection for the maintenance mode of the scheduler")
+	fs.StringVar(&cfg.MongoEnvironmentCollection, "mongo-environment-collection", defaultEnvironmentCollection, "MongoDB collection for the paused environments of jobs")
+	fs.StringVar(&cfg.MongoRetentionCollection, "mongo-retention-collection", defaultRetentionCollection, "MongoDB collection for the retention policies of runs")
+	fs.StringVar(&cfg.MongoSLACollection, "mongo-sla-collection", defaultSLACollection, "MongoDB collection for the SLAs of jobs")
+	fs.StringVar(&cfg.MongoSLABreachCollection, "mongo-sla-breach-collection", defaultBreachCollection, "MongoDB collection for the breaches of SLAs")
+	fs.StringVar(&cfg.MongoAnomalyCollection, "mongo-anomaly-collection", defaultAnomalyCollection, "MongoDB collection for the anomalies of runs")
+	fs.StringVar(&cfg.MongoMigrationCollection, "mongo-migration-collection", defaultMigrationCollection, "MongoDB collection recording the applied migrations")
+	fs.BoolVar(&cfg.MongoMigrateDryRun, "mongo-migrate-dry-run", false, "log the migrations that would be applied to MongoDB and exit")
+	fs.BoolVar(&cfg.MongoSkipMigrations, "mongo-skip-migrations", false, "start without migrating MongoDB, for read-only users")
+	fs.DurationVar(&cfg.MongoConnectTimeout, "mongo-connect-timeout", time.Minute, "how long to keep trying to reach MongoDB on startup")
+	fs.IntVar(&cfg.MongoCursorBatchSize, "mongo-cursor-batch-size", 0, "number of jobs listings fetch from MongoDB per round trip, 0 for the driver's default")
+	fs.StringVar(&cfg.ListenAddr, "listen-addr", defaultListenAddr, "host:port the gRPC server listens on, or unix:<path> for a Unix domain socket")
+	fs.DurationVar(&cfg.KeepaliveTime, "keepalive-time", 2*time.Hour, "how long a connection may be idle before the server pings the client")
+	fs.DurationVar(&cfg.KeepaliveTimeout, "keepalive-timeout", 20*time.Second, "how long the server waits for the answer to a keepalive ping")
+	fs.DurationVar(&cfg.KeepaliveMinTime, "keepalive-min-time", 5*time.Minute, "shortest interval clients may send keepalive pings in")
+	fs.BoolVar(&cfg.KeepalivePermitWithoutStream, "keepalive-permit-without-stream", false, "allow keepalive pings from clients without calls in flight")
+	fs.IntVar(&cfg.MaxRecvMsgSize, "max-recv-msg-size", defaultMaxRecvMsgSize, "largest message in bytes the gRPC server receives")
+	fs.IntVar(&cfg.MaxSendMsgSize, "max-send-msg-size", defaultMaxSendMsgSize, "largest message in bytes the gRPC server sends")
+	fs.StringVar(&cfg.TLSCertFile, "tls-cert", "", "path to the TLS certificate")
+	fs.StringVar(&cfg.TLSKeyFile, "tls-key", "", "path to the TLS private key")
+	fs.StringVar(&cfg.TLSClientCAFile, "tls-client-ca", "", "path to the CA used to verify client certificates")
+	fs.BoolVar(&cfg.TLSRequireClientCert, "tls-require-client-cert", false, "enforce mutual TLS")
+	fs.BoolVar(&cfg.SchedulerEnabled, "scheduler", true, "run the scheduler that fires due jobs")
+	fs.DurationVar(&cfg.SchedulerPollInterval, "scheduler-poll-interval", 10*time.Second, "how often the scheduler looks for due jobs")
+	fs.BoolVar(&cfg.LeaderElection, "leader-election", true, "only run the scheduler and hand out the runs of external workers on the replica that was elected leader")
+	fs.DurationVar(&cfg.LeaderLeaseTTL, "leader-lease-ttl", 15*time.Second, "how long the leader lease stays valid without being renewed")
+	fs.IntVar(&cfg.ExecutorWorkers, "executor-workers", 4, "number of jobs that can run at the same time")
+	fs.IntVar(&cfg.ExecutorQueueSize, "executor-queue-size", 100, "number of runs that can wait for a free worker")
+	cfg.ExecutorPriorityShares = executor.DefaultShares()
+	fs.Var(&cfg.ExecutorPriorityShares, "executor-priority-shares", "comma separated shares of the workers by priority like CRITICAL=8,HIGH=4,NORMAL=2,LOW=1")
+	fs.DurationVar(&cfg.ExecutorMaxQueueWait, "executor-max-queue-wait", 30*time.Second, "how long a queued run waits at most before it gets the next free worker regardless of its priority, 0 disables it")
+	fs.Float64Var(&cfg.ExecutorCostPerSecond, "executor-cost-per-second", 0, "cost of a second of compute time of a run, in the currency of the cost reports")
+	fs.DurationVar(&cfg.WorkerLeaseTTL, "worker-lease-ttl", 30*time.Second, "how long external workers keep the runs they leased without sending a heartbeat")
+	fs.DurationVar(&cfg.DeletedJobRetention, "deleted-job-retention", 30*24*time.Hour, "how long deleted jobs can be restored before they are purged, 0 keeps them forever")
+	fs.DurationVar(&cfg.PurgeInterval, "purge-interval", time.Hour, "how often deleted jobs and runs past their retention are removed")
+	fs.DurationVar(&cfg.IdempotencyKeyRetention, "idempotency-key-retention", 24*time.Hour, "how long idempotency keys of CreateJob are kept at least")
+	fs.DurationVar(&cfg.SLACheckInterval, "sla-check-interval", time.Minute, "how often the deadlines of the SLAs of jobs are checked")
+	fs.Float64Var(&cfg.AnomalyThreshold, "anomaly-threshold", 3, "standard deviations from the baseline that make the duration of a run an anomaly, 0 disables anomaly detection")
+	fs.IntVar(&cfg.AnomalyWindow, "anomaly-window", 50, "number of succeeded runs the duration baseline of a job is computed from")
+	fs.IntVar(&cfg.AnomalyMinRuns, "anomaly-min-runs", 10, "number of succeeded runs a job needs before its runs are checked for anomalies")
+	fs.BoolVar(&cfg.AuditLog, "audit-log", true, "record every change made to jobs through the API")
+	fs.Var((*listValue)(&cfg.DisabledMiddlewares), "disabled-middlewares", "comma separated optional middlewares to run without")
+	fs.IntVar(&cfg.ImportBatchSize, "import-batch-size", 500, "number of jobs ImportJobs stores at once")
+	fs.IntVar(&cfg.WebhookWorkers, "webhook-workers", 4, "number of deliveries to webhooks made at the same time")
+	fs.IntVar(&cfg.WebhookQueueSize, "webhook-queue-size", 1000, "number of webhook events that can wait for a free worker")
+	fs.IntVar(&cfg.WebhookMaxAttempts, "webhook-max-attempts", 5, "number of attempts of a webhook delivery before it becomes a dead letter")
+	fs.DurationVar(&cfg.WebhookTimeout, "webhook-timeout", 10*time.Second, "how long a single webhook delivery attempt may take")
+	fs.StringVar(&cfg.SMTPAddr, "smtp-addr", "", "host:port of the SMTP server notification emails are sent over, empty disables emails")
+	fs.StringVar(&cfg.SMTPUsername, "smtp-username", "", "username for the SMTP server, empty doesn't authenticate")
+	fs.StringVar(&cfg.SMTPPassword, "smtp-password", "", "password for the SMTP server")
+	fs.StringVar(&cfg.SMTPFrom, "smtp-from", "", "sender address of notification emails")
+	fs.StringVar(&cfg.NotificationTemplates, "notification-templates", "", "path of a file with templates replacing the built-in notification messages")
+	fs.DurationVar(&cfg.NotificationTimeout, "notification-timeout", 10*time.Second, "how long sending a notification to a single channel may take")
+	fs.StringVar(&cfg.EventsBroker, "events-broker", "", "broker job and run events are published to, nats or kafka, empty disables it")
+	fs.StringVar(&cfg.EventsNATSURL, "events-nats-url", "", "URL of the NATS server events are published to")
+	fs.Var((*listValue)(&cfg.EventsKafkaBrokers), "events-kafka-brokers", "comma separated host:port addresses of the Kafka brokers events are published to")
+	fs.StringVar(&cfg.EventsTopic, "events-topic", defaultEventsTopic, "Kafka topic of the events, or the prefix of their NATS subjects")
+	fs.DurationVar(&cfg.EventsPollInterval, "events-poll-interval", 5*time.Second, "how often events that weren't published yet are looked for")
+	fs.StringVar(&cfg.BackupLocation, "backup-location", "", "where backups are kept, s3://bucket/prefix, gs://bucket/prefix or a local directory, empty disables backups")
+	fs.StringVar(&cfg.BackupEndpoint, "backup-endpoint", "", "URL of an S3 compatible service backups are kept in instead of AWS or Cloud Storage")
+	fs.StringVar(&cfg.BackupRegion, "backup-region", "", "region of the backup bucket, us-east-1 for S3 and auto for Cloud Storage when empty")
+	fs.StringVar(&cfg.BackupAccessKeyID, "backup-access-key-id", "", "access key ID of the backup bucket, an HMAC key for Cloud Storage")
+	fs.StringVar(&cfg.BackupSecretAccessKey, "backup-secret-access-key", "", "secret access key of the backup bucket")
+	fs.DurationVar(&cfg.BackupInterval, "backup-interval", 0, "how often all jobs and runs are backed up, 0 only backs them up on request")
+	fs.StringVar(&cfg.SecretsMasterKey, "secrets-master-key", "", "base64 encoded 32 byte key the values of secrets are encrypted with")
+	fs.StringVar(&cfg.SecretsMasterKeyFile, "secrets-master-key-file", "", "path of a file holding the master key of secrets instead")
+	fs.Var((*listValue)(&cfg.ReportSchedule), "report-schedule", "comma separated periods, week or month, whose reports are delivered once they ended")
+	fs.StringVar(&cfg.ReportFormat, "report-format", report.FormatCSV, "format of scheduled reports, csv or pdf")
+	fs.Var((*listValue)(&cfg.ReportRecipients), "report-recipients", "comma separated email addresses scheduled reports are sent to")
+	fs.StringVar(&cfg.ReportLocation, "report-location", "", "where scheduled reports are kept, s3://bucket/prefix, gs://bucket/prefix or a local directory")
+	fs.StringVar(&cfg.CacheBackend, "cache-backend", "", "where ReadJob caches jobs, memory or redis, empty disables the cache")
+	fs.DurationVar(&cfg.CacheTTL, "cache-ttl", 30*time.Second, "how long a job stays cached at most")
+	fs.IntVar(&cfg.CacheSize, "cache-size", 10000, "number of jobs the memory cache keeps")
+	fs.StringVar(&cfg.CacheRedisURL, "cache-redis-url", "", "redis:// or rediss:// URL of the Redis server jobs are cached in")
+	fs.StringVar(&cfg.MetricsAddr, "metrics-addr", "0.0.0.0:9090", "address of the Prometheus /metrics endpoint, empty disables it")
+	fs.StringVar(&cfg.HealthAddr, "health-addr", "0.0.0.0:8081", "address of the HTTP /healthz and /readyz endpoints, empty disables them")
+	fs.BoolVar(&cfg.DebugEndpoints, "debug-endpoints", false, "serve /debug/pprof/ and /debug/vars next to the health probes to admins")
+	fs.StringVar(&cfg.GatewayAddr, "gateway-addr", "0.0.0.0:8080", "address of the REST/JSON gateway, empty disables it")
+	fs.BoolVar(&cfg.GatewayDocs, "gateway-docs", true, "serve the OpenAPI document and Swagger UI on the REST gateway")
+	fs.BoolVar(&cfg.Reflection, "reflection", true, "register the gRPC reflection and channelz services for tools like grpcurl and grpcdebug")
+	fs.DurationVar(&cfg.HealthCheckInterval, "health-check-interval", 10*time.Second, "how often MongoDB is pinged for health checks")
+	fs.StringVar(&cfg.LogLevel, "log-level", "info", "minimum level of log entries: debug, info, warn or error")
+	fs.StringVar(&cfg.LogFormat, "log-format", logging.FormatJSON, "format of log entries: json or console")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", 30*time.Second, "how long in-flight RPCs may take to finish on shutdown")
+	fs.DurationVar(&cfg.BackgroundShutdownTimeout, "background-shutdown-timeout", 30*time.Second, "how long the background subsystems may take to stop on shutdown, 0 waits as long as they take")
+	fs.StringVar(&cfg.AuthJWKSURL, "auth-jwks-url", "", "JWKS URL used to verify bearer tokens, empty disables authentication")
+	fs.StringVar(&cfg.AuthIssuer, "auth-issuer", "", "required token issuer")
+	fs.StringVar(&cfg.AuthAudience, "auth-audience", "", "required token audience")
+	cfg.AuthExemptMethods = append([]string{}, auth.DefaultExemptMethods...)
+	fs.Var((*listValue)(&cfg.AuthExemptMethods), "auth-exempt-methods", "comma separated gRPC methods that can be called without a token")
+	fs.BoolVar(&cfg.RBAC, "rbac", false, "only let callers with the viewer, editor or admin role call the methods of their role")
+	fs.Var(&cfg.RateLimit, "rate-limit", "calls every client may make to each method like 100/s, 100/m or 100/h, empty disables it")
+	fs.Var(&cfg.RateLimitMethods, "rate-limit-methods", "comma separated limits of single methods like /model.JobService/CreateJob=10/s")
+	fs.Int64Var(&cfg.Quotas.JobsPerOwner, "quota-jobs-per-owner", 0, "jobs every owner may have, 0 doesn't limit them")
+	fs.Int64Var(&cfg.Quotas.JobsPerTenant, "quota-jobs-per-tenant", 0, "jobs every tenant may have, of the whole server without multi-tenancy, 0 doesn't limit them")
+	fs.Int64Var(&cfg.Quotas.ConcurrentRuns, "quota-concurrent-runs", 0, "queued and running runs the jobs of an owner may have for TriggerJob to start another one, 0 doesn't limit them")
+	fs.Int64Var(&cfg.Quotas.RunsPerDay, "quota-runs-per-day", 0, "runs the jobs of an owner may have per day in UTC for TriggerJob to start another one, 0 doesn't limit them")
+	fs.BoolVar(&cfg.MultiTenancy, "multi-tenancy", false, "scope all data to the tenant_id claim of the caller's token")
+	fs.Var((*mapValue)(&cfg.TenantDatabases), "tenant-databases", "comma separated MongoDB databases of single tenants like acme=acme_jobs")
+
+	// Environment variables overwrite the defaults, flags parsed afterwards overwrite both
+	if err := applyEnv(fs); err != nil {
+		return nil, err
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	// The file only sets what neither an environment variable nor a flag did
+	if cfg.ConfigFile != "" {
+		if err := applyFile(fs, cfg.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+	cfg.flags = fs
+
+	// Fall back to the connection string we always used, built from MONGO_PW
+	if cfg.MongoURI == "" && cfg.StorageBackend == StorageMongoDB {
+		pw, ok := os.LookupEnv("MONGO_PW")
+		if !ok {
+			return nil, errors.New("unable to find MONGO_URI or MONGO_PW in the environment")
+		}
+		cfg.MongoURI = fmt.Sprintf("mongodb://%s@%s/%s", url.UserPassword(defaultMongoUser, pw), defaultMongoHost, defaultMongoDatabase)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyEnv sets every flag that has its environment variable set, so values are parsed and type checked like flags
+func applyEnv(fs *flag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		key, ok := envVars[f.Name]
+		if !ok || err != nil {
+			return
+		}
+		if v, ok := os.LookupEnv(key); ok {
+			if setErr := fs.Set(f.Name, v); setErr != nil {
+				err = fmt.Errorf("invalid value %q for %s: %v", v, key, setErr)
+			}
+		}
+	})
+	return err
+}
+
+// listValue is a comma separated list flag, setting it replaces the default list
+type listValue []string
+
+func (l *listValue) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *listValue) Set(v string) error {
+	*l = nil
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// mapValue is a comma separated list of key=value pairs, setting it replaces the whole map
+type mapValue map[string]string
+
+func (m *mapValue) String() string {
+	if m == nil {
+		return ""
+	}
+	items := []string{}
+	for key, value := range *m {
+		items = append(items, key+"="+value)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (m *mapValue) Set(v string) error {
+	values := map[string]string{}
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return fmt.Errorf("invalid item %q, expected <key>=<value>", item)
+		}
+		values[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	*m = values
+	return nil
+}
+
+// validate checks the settings for consistency and fills in values that depend on each other
+func (c *Config) validate() error {
+	switch c.StorageBackend {
+	case StorageMongoDB:
+		if err := c.validateMongo(); err != nil {
+			return err
+		}
+	case StoragePostgres:
+		if c.PostgresURL == "" {
+			return errors.New("the postgres storage backend requires POSTGRES_URL")
+		}
+	case StorageMemory:
+	case StorageSQLite:
+		if c.SQLitePath == "" {
+			return errors.New("the sqlite storage backend requires SQLITE_PATH")
+		}
+	default:
+		return fmt.Errorf("unknown storage backend %q", c.StorageBackend)
+	}
+
+	if network, address := c.Listener(); network == "unix" {
+		if address == "" {
+			return errors.New("a unix listen address needs a socket path")
+		}
+	} else if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("invalid listen address %q: %v", c.ListenAddr, err)
+	}
+	// gRPC raises shorter keepalive times to a second
+	if c.KeepaliveTime < time.Second {
+		return errors.New("keepalive time must be at least 1s")
+	}
+	if c.KeepaliveTimeout <= 0 {
+		return errors.New("keepalive timeout must be positive")
+	}
+	if c.KeepaliveMinTime < 0 {
+		return errors.New("keepalive min time must not be negative")
+	}
+	if c.MaxRecvMsgSize <= 0 || c.MaxSendMsgSize <= 0 {
+		return errors.New("max message sizes must be positive")
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return errors.New("TLS certificate and key must be set together")
+	}
+	if !c.TLSEnabled() && c.TLSClientCAFile != "" {
+		return errors.New("a TLS client CA requires a server certificate and key")
+	}
+	if c.TLSRequireClientCert && c.TLSClientCAFile == "" {
+		return errors.New("enforcing mutual TLS requires a TLS client CA")
+	}
+	// The gateway connects to the gRPC server without a client certificate
+	if c.TLSRequireClientCert && c.GatewayAddr != "" {
+		return errors.New("the REST gateway can't be used while mutual TLS is enforced")
+	}
+
+	if c.SchedulerPollInterval <= 0 {
+		return errors.New("scheduler poll interval must be positive")
+	}
+	// The lease is renewed every third of the TTL
+	if c.LeaderLeaseTTL < 3*time.Second {
+		return errors.New("leader lease TTL must be at least 3s")
+	}
+	if c.MongoConnectTimeout <= 0 {
+		return errors.New("MongoDB connect timeout must be positive")
+	}
+	if c.MongoCursorBatchSize < 0 {
+		return errors.New("MongoDB cursor batch size must not be negative")
+	}
+	if c.HealthCheckInterval <= 0 {
+		return errors.New("health check interval must be positive")
+	}
+	if c.ShutdownTimeout < 0 {
+		return errors.New("shutdown timeout must not be negative")
+	}
+	if c.BackgroundShutdownTimeout < 0 {
+		return errors.New("background shutdown timeout must not be negative")
+	}
+	if c.ExecutorWorkers < 1 {
+		return errors.New("executor needs at least one worker")
+	}
+	if c.ExecutorQueueSize < 0 {
+		return errors.New("executor queue size must not be negative")
+	}
+	if c.ExecutorMaxQueueWait < 0 {
+		return errors.New("executor max queue wait must not be negative")
+	}
+	if c.ExecutorCostPerSecond < 0 {
+		return errors.New("executor cost per second must not be negative")
+	}
+	// Expired leases are looked for every half of the TTL
+	if c.WorkerLeaseTTL < 2*time.Second {
+		return errors.New("worker lease TTL must be at least 2s")
+	}
+	if c.DeletedJobRetention < 0 {
+		return errors.New("deleted job retention must not be negative")
+	}
+	if c.IdempotencyKeyRetention <= 0 {
+		return errors.New("idempotency key retention must be positive")
+	}
+	if c.PurgeInterval <= 0 {
+		return errors.New("purge interval must be positive")
+	}
+	if c.SLACheckInterval <= 0 {
+		return errors.New("SLA check interval must be positive")
+	}
+	if c.AnomalyThreshold < 0 {
+		return errors.New("anomaly threshold must not be negative")
+	}
+	if c.AnomalyMinRuns < 2 {
+		return errors.New("anomaly detection needs at least 2 runs")
+	}
+	if c.AnomalyWindow < c.AnomalyMinRuns {
+		return errors.New("anomaly window must hold at least the minimum number of runs")
+	}
+	if c.ImportBatchSize < 1 {
+		return errors.New("import batch size must be at least 1")
+	}
+	if c.WebhookWorkers < 1 {
+		return errors.New("webhooks need at least one worker")
+	}
+	if c.WebhookQueueSize < 0 {
+		return errors.New("webhook queue size must not be negative")
+	}
+	if c.WebhookMaxAttempts < 1 {
+		return errors.New("webhook deliveries need at least one attempt")
+	}
+	if c.WebhookTimeout <= 0 {
+		return errors.New("webhook timeout must be positive")
+	}
+	if c.NotificationTimeout <= 0 {
+		return errors.New("notification timeout must be positive")
+	}
+	if c.SMTPAddr != "" {
+		if _, _, err := net.SplitHostPort(c.SMTPAddr); err != nil {
+			return fmt.Errorf("invalid SMTP address %q, expected host:port", c.SMTPAddr)
+		}
+		if addr, err := mail.ParseAddress(c.SMTPFrom); err != nil || addr.Address != c.SMTPFrom {
+			return fmt.Errorf("SMTP sender %q must be a plain email address", c.SMTPFrom)
+		}
+	}
+	switch c.EventsBroker {
+	case "":
+	case events.BrokerNATS:
+		if c.EventsNATSURL == "" {
+			return errors.New("publishing events to NATS needs a NATS URL")
+		}
+	case events.BrokerKafka:
+		if len(c.EventsKafkaBrokers) == 0 {
+			return errors.New("publishing events to Kafka needs at least one broker")
+		}
+	default:
+		return fmt.Errorf("unknown events broker %q, expected %s or %s", c.EventsBroker, events.BrokerNATS, events.BrokerKafka)
+	}
+	if c.EventsBroker != "" {
+		if c.EventsTopic == "" {
+			return errors.New("events topic must not be empty")
+		}
+		if c.EventsPollInterval <= 0 {
+			return errors.New("events poll interval must be positive")
+		}
+	}
+	if c.BackupLocation != "" {
+		if _, err := c.BackupStore(); err != nil {
+			return fmt.Errorf("invalid backup location: %v", err)
+		}
+	}
+	if c.BackupInterval < 0 {
+		return errors.New("backup interval must not be negative")
+	}
+	if c.BackupInterval > 0 && c.BackupLocation == "" {
+		return errors.New("scheduled backups need a backup location")
+	}
+	if c.SecretsMasterKey != "" && c.SecretsMasterKeyFile != "" {
+		return errors.New("the master key of secrets can't be set both directly and as a file")
+	}
+	if _, err := c.SecretsCipher(); err != nil {
+		return fmt.Errorf("invalid master key of secrets: %v", err)
+	}
+	for _, period := range c.ReportSchedule {
+		if period != report.PeriodWeek && period != report.PeriodMonth {
+			return fmt.Errorf("unknown report period %q, expected %s or %s", period, report.PeriodWeek, report.PeriodMonth)
+		}
+	}
+	if c.ReportFormat != report.FormatCSV && c.ReportFormat != report.FormatPDF {
+		return fmt.Errorf("unknown report format %q, expected %s or %s", c.ReportFormat, report.FormatCSV, report.FormatPDF)
+	}
+	for _, recipient := range c.ReportRecipients {
+		if addr, err := mail.ParseAddress(recipient); err != nil || addr.Address != recipient {
+			return fmt.Errorf("report recipient %q must be a plain email address", recipient)
+		}
+	}
+	if len(c.ReportRecipients) > 0 && c.SMTPAddr == "" {
+		return errors.New("sending reports by email needs an SMTP server")
+	}
+	if c.ReportLocation != "" {
+		if _, err := c.ReportStore(); err != nil {
+			return fmt.Errorf("invalid report location: %v", err)
+		}
+	}
+	if len(c.ReportSchedule) > 0 && len(c.ReportRecipients) == 0 && c.ReportLocation == "" {
+		return errors.New("scheduled reports need recipients or a report location")
+	}
+	switch c.CacheBackend {
+	case "":
+	case cache.BackendMemory:
+		if c.CacheSize <= 0 {
+			return errors.New("cache size must be positive")
+		}
+	case cache.BackendRedis:
+		if c.CacheRedisURL == "" {
+			return errors.New("the redis cache needs CACHE_REDIS_URL")
+		}
+	default:
+		return fmt.Errorf("unknown cache backend %q, expected %s or %s", c.CacheBackend, cache.BackendMemory, cache.BackendRedis)
+	}
+	if c.CacheBackend != "" && c.CacheTTL <= 0 {
+		return errors.New("cache TTL must be positive")
+	}
+
+	if c.LogFormat != logging.FormatJSON && c.LogFormat != logging.FormatConsole {
+		return fmt.Errorf("unknown log format %q", c.LogFormat)
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return fmt.Errorf("unknown log level %q", c.LogLevel)
+	}
+	for _, name := range c.DisabledMiddlewares {
+		optional := false
+		for _, o := range middleware.Optional {
+			optional = optional || name == o
+		}
+		if !optional {
+			return fmt.Errorf("middleware %q can't be disabled, only %s can", name, strings.Join(middleware.Optional, ", "))
+		}
+	}
+
+	if c.AuthJWKSURL != "" {
+		if u, err := url.Parse(c.AuthJWKSURL); err != nil || u.Host == "" {
+			return fmt.Errorf("invalid JWKS URL %q", c.AuthJWKSURL)
+		}
+	}
+
+	// Roles are taken from the tokens and the users and teams of callers
+	if c.RBAC && !c.AuthEnabled() {
+		return errors.New("RBAC requires authentication")
+	}
+	if c.ConfigWatch && c.ConfigFile == "" {
+		return errors.New("watching the config file requires a config file")
+	}
+	// Profiles and variables reveal the internals of the server, only admins may read them
+	if c.DebugEndpoints && !c.AuthEnabled() {
+		return errors.New("debug endpoints require authentication")
+	}
+	if c.DebugEndpoints && c.HealthAddr == "" {
+		return errors.New("debug endpoints are served on the health address, it must not be empty")
+	}
+	if c.Quotas.JobsPerOwner < 0 || c.Quotas.JobsPerTenant < 0 || c.Quotas.ConcurrentRuns < 0 || c.Quotas.RunsPerDay < 0 {
+		return errors.New("quotas must not be negative")
+	}
+	// Tenants are taken from the tokens
+	if c.MultiTenancy && !c.AuthEnabled() {
+		return errors.New("multi-tenancy requires authentication")
+	}
+	if len(c.TenantDatabases) > 0 {
+		if !c.MultiTenancy {
+			return errors.New("tenant databases require multi-tenancy")
+		}
+		if c.StorageBackend != StorageMongoDB {
+			return errors.New("tenant databases are only supported by the mongodb storage backend")
+		}
+	}
+	return nil
+}
+
+// validateMongo checks the MongoDB settings and fills in the database from the connection string
+func (c *Config) validateMongo() error {
+	cs, err := connstring.Parse(c.MongoURI)
+	if err != nil {
+		return fmt.Errorf("invalid MongoDB connection string: %v", err)
+	}
+	// The database from the connection string wins over the default but not over an explicit setting
+	if c.MongoDatabase == "" {
+		c.MongoDatabase = cs.Database
+	}
+	if c.MongoDatabase == "" {
+		c.MongoDatabase = defaultMongoDatabase
+	}
+	if c.MongoCollection == "" || c.MongoRunCollection == "" {
+		return errors.New("MongoDB collection names must not be empty")
+	}
+	if c.MongoMigrateDryRun && c.MongoSkipMigrations {
+		return errors.New("a dry run of the MongoDB migrations can't skip them")
+	}
+	return nil
+}
+
+// unixPrefix marks listen addresses that are the path of a Unix domain socket
+const unixPrefix = "unix:"
+
+// Listener returns the network and address the gRPC server listens on, unix:/path and unix:///path both name /path
+func (c *Config) Listener() (network, address string) {
+	if strings.HasPrefix(c.ListenAddr, unixPrefix) {
+		return "unix", strings.TrimPrefix(strings.TrimPrefix(c.ListenAddr, unixPrefix), "//")
+	}
+	return "tcp", c.ListenAddr
+}
+
+// DialTarget returns the target the REST gateway connects to the gRPC server with, servers listening on all
+// interfaces are reached over loopback
+func (c *Config) DialTarget() string {
+	network, address := c.Listener()
+	if network == "unix" {
+		return unixPrefix + address
+	}
+	host, port, _ := net.SplitHostPort(address)
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// TLSEnabled reports whether the gRPC server should serve TLS
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// AuthEnabled reports whether calls need a valid bearer token
+func (c *Config) AuthEnabled() bool {
+	return c.AuthJWKSURL != ""
+}
+
+// RateLimitEnabled reports whether calls are rate limited
+func (c *Config) RateLimitEnabled() bool {
+	return c.RateLimit.Rate > 0 || len(c.RateLimitMethods) > 0
+}
+
+// BackupStore returns the store of BackupLocation
+func (c *Config) BackupStore() (backup.Store, error) {
+	return backup.NewStore(c.BackupLocation, backup.S3Options{
+		Endpoint:        c.BackupEndpoint,
+		Region:          c.BackupRegion,
+		AccessKeyID:     c.BackupAccessKeyID,
+		SecretAccessKey: c.BackupSecretAccessKey,
+	})
+}
+
+// ReportStore returns the store of ReportLocation, buckets are accessed like the one of the backups
+func (c *Config) ReportStore() (backup.Store, error) {
+	return backup.NewStore(c.ReportLocation, backup.S3Options{
+		Endpoint:        c.BackupEndpoint,
+		Region:          c.BackupRegion,
+		AccessKeyID:     c.BackupAccessKeyID,
+		SecretAccessKey: c.BackupSecretAccessKey,
+	})
+}
+
+// SecretsCipher returns the cipher of the master key of secrets, nil when none is set
+func (c *Config) SecretsCipher() (*secrets.Cipher, error) {
+	var key []byte
+	var err error
+	switch {
+	case c.SecretsMasterKey != "":
+		key, err = secrets.ParseKey(c.SecretsMasterKey)
+	case c.SecretsMasterKeyFile != "":
+		key, err = secrets.LoadKey(c.SecretsMasterKeyFile)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewCipher(key)
+}
+
+// RedactedMongoURI returns the connection string with the password masked so it can be logged
+func (c *Config) RedactedMongoURI() string {
+	u, err := url.Parse(c.MongoURI)
+	if err != nil {
+		return "<unparsable connection string>"
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	}
+	return u.String()
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// fileFlags can't be set in a config file, they select the file and what is done with the configuration
+var fileFlags = map[string]bool{"config": true, "print-config": true}
+
+// secretFlags hold credentials, they are redacted when the configuration is printed
+var secretFlags = map[string]bool{
+	"smtp-password":            true,
+	"backup-secret-access-key": true,
+	"secrets-master-key":       true,
+}
+
+// urlFlags are connection strings that may carry a password, only the password is redacted when they are printed
+var urlFlags = map[string]bool{
+	"mongo-uri":       true,
+	"postgres-url":    true,
+	"events-nats-url": true,
+	"cache-redis-url": true,
+}
+
+// redacted replaces secrets in the printed configuration
+const redacted = "REDACTED"
+
+// applyFile sets every flag the YAML or TOML file at path has a setting of, except the ones set by environment
+// variables or flags already. The settings are named like the flags, nested tables or mappings join their keys with
+// dashes, so the setting jobs-per-owner in the table quota sets quota-jobs-per-owner. Lists and mappings are set like
+// their comma separated flags.
+func applyFile(fs *flag.FlagSet, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %v", err)
+	}
+	values := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return fmt.Errorf("config file %s must be a .yaml, .yml or .toml file", path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not parse config file %s: %v", path, err)
+	}
+	settings := map[string]interface{}{}
+	flatten(fs, "", values, settings)
+
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	// Check the settings in a fixed order, so a file with several invalid ones always reports the same
+	sort.Strings(names)
+	for _, name := range names {
+		if fs.Lookup(name) == nil || fileFlags[name] {
+			return fmt.Errorf("unknown setting %q in config file %s", name, path)
+		}
+		if set[name] || settings[name] == nil {
+			continue
+		}
+		v, err := settingValue(settings[name])
+		if err != nil {
+			return fmt.Errorf("invalid value for %s in config file %s: %v", name, path, err)
+		}
+		if err := fs.Set(name, v); err != nil {
+			return fmt.Errorf("invalid value %q for %s in config file %s: %v", v, name, path, err)
+		}
+	}
+	return nil
+}
+
+// flatten joins the keys of nested tables and mappings with dashes, mappings of flags taking key=value pairs are kept as
+// they are
+func flatten(fs *flag.FlagSet, prefix string, values map[string]interface{}, settings map[string]interface{}) {
+	for key, value := range values {
+		name := key
+		if prefix != "" {
+			name = prefix + "-" + key
+		}
+		nested, ok := mapping(value)
+		if f := fs.Lookup(name); !ok || f != nil && isMapFlag(f) {
+			settings[name] = value
+			continue
+		}
+		flatten(fs, name, nested, settings)
+	}
+}
+
+// isMapFlag reports whether f takes key=value pairs
+func isMapFlag(f *flag.Flag) bool {
+	_, ok := f.Value.(*mapValue)
+	return ok
+}
+
+// mapping converts the tables of TOML and mappings of YAML to maps with string keys
+func mapping(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for key, v := range m {
+			converted[fmt.Sprint(key)] = v
+		}
+		return converted, true
+	}
+	return nil, false
+}
+
+// settingValue formats a setting of a config file like the value of its flag
+func settingValue(value interface{}) (string, error) {
+	if m, ok := mapping(value); ok {
+		items := make([]string, 0, len(m))
+		for key, v := range m {
+			if _, nested := mapping(v); nested {
+				return "", fmt.Errorf("%s must not be a mapping", key)
+			}
+			items = append(items, fmt.Sprintf("%s=%v", key, v))
+		}
+		sort.Strings(items)
+		return strings.Join(items, ","), nil
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, nested := mapping(item); nested {
+				return "", fmt.Errorf("items of lists must not be mappings")
+			}
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ","), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// Print writes the effective configuration as a YAML config file, every setting named like its flag. Passwords and
+// keys are redacted, so the output can be shared but not loaded as it is.
+func (c *Config) Print(w io.Writer) error {
+	settings := map[string]interface{}{}
+	c.flags.VisitAll(func(f *flag.Flag) {
+		if fileFlags[f.Name] {
+			return
+		}
+		settings[f.Name] = printedValue(f)
+	})
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Settings returns every setting by the name of its flag with its value formatted like the flag, redacted like Print
+func (c *Config) Settings() map[string]string {
+	settings := map[string]string{}
+	c.flags.VisitAll(func(f *flag.Flag) {
+		if !fileFlags[f.Name] {
+			settings[f.Name] = fmt.Sprint(printedValue(f))
+		}
+	})
+	return settings
+}
+
+// Changes returns the names of the settings other has different values of, sorted
+func (c *Config) Changes(other *Config) []string {
+	var names []string
+	c.flags.VisitAll(func(f *flag.Flag) {
+		if !fileFlags[f.Name] && f.Value.String() != other.flags.Lookup(f.Name).Value.String() {
+			names = append(names, f.Name)
+		}
+	})
+	return names
+}
+
+// Keep sets the setting with name back to its value in other, for settings that can't change while the server runs
+func (c *Config) Keep(name string, other *Config) error {
+	return c.flags.Set(name, other.flags.Lookup(name).Value.String())
+}
+
+// printedValue returns the value of f as it is printed, booleans and numbers keep their type
+func printedValue(f *flag.Flag) interface{} {
+	v := f.Value.String()
+	switch {
+	case secretFlags[f.Name]:
+		if v != "" {
+			return redacted
+		}
+		return v
+	case urlFlags[f.Name]:
+		return redactURL(v)
+	}
+	if getter, ok := f.Value.(flag.Getter); ok {
+		switch typed := getter.Get().(type) {
+		case bool, int, int64, uint, uint64, float64:
+			return typed
+		}
+	}
+	return v
+}
+
+// redactURL replaces the password of a connection string, connection strings that aren't URLs are redacted as a whole
+func redactURL(v string) string {
+	if v == "" {
+		return v
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" {
+		return redacted
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
+}
@@ -0,0 +1,90 @@
+package debug
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	// Leaking goroutines show up here long before they show in the memory statistics
+	expvar.Publish("goroutines", expvar.Func(func() interface{} {
+		return runtime.NumGoroutine()
+	}))
+}
+
+// Verifier checks the bearer token of a request and returns a context carrying its claims, like *auth.Authenticator
+type Verifier interface {
+	Verify(ctx context.Context, token string) (context.Context, error)
+}
+
+// Roles looks up the roles callers were granted besides the ones of their token, like *rbac.Authorizer
+type Roles interface {
+	HasRole(ctx context.Context, role string) (bool, error)
+}
+
+// Handler serves the profiles of net/http/pprof under /debug/pprof/ and the expvar variables under /debug/vars. Only
+// callers with a bearer token verified by verifier and the admin role may use them. The role is looked up in roles
+// as well when it isn't nil.
+func Handler(verifier Verifier, roles Roles) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := authorize(r, verifier, roles); err != nil {
+			st := status.Convert(err)
+			http.Error(w, st.Message(), httpStatus(st.Code()))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorize returns a gRPC status telling why the caller of r may not use the debug endpoints
+func authorize(r *http.Request, verifier Verifier, roles Roles) error {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") || parts[1] == "" {
+		return status.Error(codes.Unauthenticated, "Unauthenticated: authorization header must be a bearer token")
+	}
+	ctx, err := verifier.Verify(r.Context(), parts[1])
+	if err != nil {
+		return err
+	}
+	if claims, _ := auth.FromContext(ctx); claims.HasRole(auth.AdminRole) {
+		return nil
+	}
+	if roles != nil {
+		admin, err := roles.HasRole(ctx, auth.AdminRole)
+		if err != nil {
+			return err
+		}
+		if admin {
+			return nil
+		}
+	}
+	return status.Error(codes.PermissionDenied, "The debug endpoints require the admin role")
+}
+
+// httpStatus maps the codes of the errors of authorize to HTTP status codes
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+)
+
+// Brokers events can be published to
+const (
+	BrokerNATS  = "nats"
+	BrokerKafka = "kafka"
+)
+
+// NATSPublisher publishes events to NATS JetStream on the subject <subject>.<type>, so a stream has to capture
+// <subject>.>. The event ID is the message ID, JetStream drops events published again within its duplicate window.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	subject string
+}
+
+// NewNATSPublisher connects to the NATS server at url. The server doesn't have to be up yet, the connection is retried
+// in the background forever.
+func NewNATSPublisher(url, subject string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("schedulytics"), nats.RetryOnFailedConnect(true), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &NATSPublisher{conn: conn, js: js, subject: subject}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, messages []*Message) error {
+	for _, m := range messages {
+		msg := nats.NewMsg(p.subject + "." + m.Type)
+		msg.Data = m.Body
+		msg.Header.Set("Schedulytics-Key", m.Key)
+		if _, err := p.js.PublishMsg(msg, nats.MsgId(m.ID), nats.Context(ctx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
+
+// KafkaPublisher publishes events to a Kafka topic. Events are keyed with the ID of their job, so the events of a job
+// end up in the same partition in order. Every message has the headers id and type.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher creates a publisher for the topic on the given brokers, it only connects once it publishes
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		// Batches are handed over as a whole, there is nothing to wait for
+		BatchTimeout: 10 * time.Millisecond,
+	}}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, messages []*Message) error {
+	batch := make([]kafka.Message, 0, len(messages))
+	for _, m := range messages {
+		batch = append(batch, kafka.Message{
+			Key:   []byte(m.Key),
+			Value: m.Body,
+			Headers: []kafka.Header{
+				{Key: "id", Value: []byte(m.ID)},
+				{Key: "type", Value: []byte(m.Type)},
+			},
+		})
+	}
+	return p.writer.WriteMessages(ctx, batch...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,163 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+)
+
+// Types of the events published to the broker
+const (
+	JobCreated   = "JobCreated"
+	JobUpdated   = "JobUpdated"
+	JobDeleted   = "JobDeleted"
+	RunCompleted = "RunCompleted"
+)
+
+// jobEventTypes maps the events of jobs changed through the API, which are named like the events of webhooks, to the
+// types they are published with
+var jobEventTypes = map[string]string{
+	webhook.JobCreated: JobCreated,
+	webhook.JobUpdated: JobUpdated,
+	webhook.JobDeleted: JobDeleted,
+}
+
+// Data is the data of an event, the job it is about and for RunCompleted the run
+type Data struct {
+	Job *Job `json:"job"`
+	Run *Run `json:"run,omitempty"`
+}
+
+// Job is a job as it was after the change, JobDeleted carries the job as it was before it was deleted
+type Job struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Owner       string            `json:"owner"`
+	Description string            `json:"description,omitempty"`
+	Status      string            `json:"status"`
+	Handler     string            `json:"handler,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	DependsOn   []string          `json:"depends_on,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   *time.Time        `json:"deleted_at,omitempty"`
+}
+
+// Run is a finished run
+type Run struct {
+	ID          string     `json:"id"`
+	JobID       string     `json:"job_id"`
+	Status      string     `json:"status"`
+	Attempt     int        `json:"attempt"`
+	Trigger     string     `json:"trigger,omitempty"`
+	TriggeredBy string     `json:"triggered_by,omitempty"`
+	CycleID     string     `json:"cycle_id,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	QueuedAt    time.Time  `json:"queued_at"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	DurationMs  int64      `json:"duration_ms"`
+	Worker      string     `json:"worker,omitempty"`
+	Cost        float64    `json:"cost"`
+}
+
+// newJob returns the event form of a job
+func newJob(job *repository.Job) *Job {
+	return &Job{
+		ID:          job.ID,
+		Name:        job.Name,
+		Owner:       job.Owner,
+		Description: job.Description,
+		Status:      job.Status,
+		Handler:     job.Handler,
+		Priority:    job.Priority,
+		Labels:      job.Labels,
+		DependsOn:   job.DependsOn,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		DeletedAt:   job.DeletedAt,
+	}
+}
+
+// newRun returns the event form of a run
+func newRun(run *repository.Run) *Run {
+	return &Run{
+		ID:          run.ID,
+		JobID:       run.JobID,
+		Status:      run.Status,
+		Attempt:     run.Attempt,
+		Trigger:     run.Trigger,
+		TriggeredBy: run.TriggeredBy,
+		CycleID:     run.CycleID,
+		Error:       run.Error,
+		QueuedAt:    run.QueuedAt,
+		StartTime:   run.StartTime,
+		EndTime:     run.EndTime,
+		DurationMs:  run.Duration.Milliseconds(),
+		Worker:      run.Worker,
+		Cost:        run.Cost,
+	}
+}
+
+// Recorder stores the events of jobs and runs in the outbox in the transaction of the change they are about, so an
+// event is stored if and only if its change is. The relay publishes them from there.
+type Recorder struct {
+	outbox       repository.OutboxRepository
+	transactions repository.Transactor
+	relay        *Relay
+}
+
+// NewRecorder creates a Recorder storing events in outbox, transactions must be of the same storage. relay is woken
+// up after every committed transaction, it may be nil.
+func NewRecorder(outbox repository.OutboxRepository, transactions repository.Transactor, relay *Relay) *Recorder {
+	return &Recorder{outbox: outbox, transactions: transactions, relay: relay}
+}
+
+// InTransaction runs f in a transaction, the events stored with the context f gets are kept if f succeeds
+func (r *Recorder) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	if err := r.transactions.InTransaction(ctx, f); err != nil {
+		return err
+	}
+	// The events are only visible to the relay once they were committed
+	if r.relay != nil {
+		r.relay.Wake()
+	}
+	return nil
+}
+
+// JobChanged stores JobCreated, JobUpdated or JobDeleted for the webhook event of the same name, it must be called
+// with the context of InTransaction
+func (r *Recorder) JobChanged(ctx context.Context, event string, job *repository.Job) error {
+	eventType, ok := jobEventTypes[event]
+	if !ok {
+		return nil
+	}
+	return r.record(ctx, eventType, job.ID, &Data{Job: newJob(job)})
+}
+
+// RunFinished stores RunCompleted for a finished run of job, whatever its outcome. It must be called with the context
+// of InTransaction.
+func (r *Recorder) RunFinished(ctx context.Context, job *repository.Job, run *repository.Run) error {
+	return r.record(ctx, RunCompleted, job.ID, &Data{Job: newJob(job), Run: newRun(run)})
+}
+
+// record stores an event for the tenant of ctx
+func (r *Recorder) record(ctx context.Context, eventType, key string, data *Data) error {
+	// Data only holds strings, numbers and times, it always marshals
+	payload, _ := json.Marshal(data)
+	_, err := r.outbox.Create(ctx, &repository.OutboxEvent{
+		Type:      eventType,
+		Key:       key,
+		Payload:   string(payload),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	})
+	if err != nil {
+		return fmt.Errorf("could not store %s event: %v", eventType, err)
+	}
+	return nil
+}
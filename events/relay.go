@@ -0,0 +1,139 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// batchSize is the number of events published at once
+const batchSize = 100
+
+// Message is an event as it is handed to a Publisher
+type Message struct {
+	// ID is the ID of the event in the outbox, it stays the same when the event is published again
+	ID   string
+	Type string
+	// Key is the ID of the job the event is about
+	Key  string
+	Body []byte
+}
+
+// Publisher sends messages to a message broker
+type Publisher interface {
+	// Publish returns once the broker acknowledged every message, some of them may have been published when it fails
+	Publish(ctx context.Context, messages []*Message) error
+	// Close releases the connection to the broker
+	Close() error
+}
+
+// envelope is the JSON body of every message
+type envelope struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Time   time.Time       `json:"time"`
+	Tenant string          `json:"tenant_id,omitempty"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// newMessage wraps an event from the outbox into its envelope
+func newMessage(event *repository.OutboxEvent) *Message {
+	// The payload was marshalled by the recorder, so it is valid JSON
+	body, _ := json.Marshal(&envelope{
+		ID:     event.ID,
+		Type:   event.Type,
+		Time:   event.CreatedAt,
+		Tenant: event.Tenant,
+		Data:   json.RawMessage(event.Payload),
+	})
+	return &Message{ID: event.ID, Type: event.Type, Key: event.Key, Body: body}
+}
+
+// Relay publishes the events in the outbox oldest first and removes them once the broker acknowledged them. Events are
+// published at least once, events that were published but couldn't be removed are published again.
+type Relay struct {
+	outbox    repository.OutboxRepository
+	publisher Publisher
+	interval  time.Duration
+	wake      chan struct{}
+	logger    *zap.Logger
+}
+
+// NewRelay creates a Relay looking for events every interval, or right away when it is woken up
+func NewRelay(outbox repository.OutboxRepository, publisher Publisher, interval time.Duration, logger *zap.Logger) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		publisher: publisher,
+		interval:  interval,
+		wake:      make(chan struct{}, 1),
+		logger:    logger,
+	}
+}
+
+// Wake makes a running relay look for events right away
+func (r *Relay) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes events until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	r.logger.Info("Event relay started", zap.Duration("interval", r.interval))
+	for {
+		r.publishPending(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// publishPending publishes the events in the outbox batch by batch. A failed batch stops it, so events aren't
+// published out of order, they are attempted again the next time.
+func (r *Relay) publishPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		pending, err := r.outbox.Pending(ctx, batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error("Could not read the outbox", zap.Error(err))
+			}
+			return
+		}
+		if len(pending) == 0 {
+			return
+		}
+		messages := make([]*Message, 0, len(pending))
+		for _, event := range pending {
+			messages = append(messages, newMessage(event))
+		}
+		if err := r.publisher.Publish(ctx, messages); err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error("Could not publish events", zap.Int("events", len(messages)), zap.Error(err))
+			}
+			for _, event := range pending {
+				metrics.RecordEvent(event.Type, "failed")
+			}
+			return
+		}
+		for _, event := range pending {
+			metrics.RecordEvent(event.Type, "published")
+			if err := r.outbox.Delete(tenant.NewContext(ctx, event.Tenant), event.ID); err != nil {
+				r.logger.Error("Could not remove published event, it will be published again", zap.String("event_id", event.ID), zap.Error(err))
+			}
+		}
+		if len(pending) < batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,678 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrQueueFull is returned by Submit when all workers are busy and the queue has no room left
+	ErrQueueFull = errors.New("executor queue is full")
+	// ErrCancelled is the error of runs stopped by Cancel
+	ErrCancelled = errors.New("run was cancelled")
+	// ErrTimedOut is the error of runs stopped because they exceeded the timeout of their job
+	ErrTimedOut = errors.New("run timed out")
+	// ErrReplaced is the error of runs cancelled because a newer run of their job replaced them
+	ErrReplaced = errors.New("run was replaced by a newer run of its job")
+	// ErrStillRunning is returned by Fire when the job forbids overlapping runs and one of its runs isn't finished
+	ErrStillRunning = errors.New("job is still running")
+)
+
+// finishTimeout limits storing the result of a run that was cancelled by a shutdown
+const finishTimeout = 10 * time.Second
+
+// FinishFunc is called once a run finished and its outcome was stored, runs whose job couldn't be loaded are left out
+type FinishFunc func(ctx context.Context, job *repository.Job, run *repository.Run)
+
+// RunStore keeps something about finished runs in the transaction that stores their outcome, like their events
+type RunStore interface {
+	// InTransaction runs f in a transaction, the changes stored with the context f gets are kept if f succeeds
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+	// RunFinished is called in the transaction with the finished run of job, an error rolls the outcome back
+	RunFinished(ctx context.Context, job *repository.Job, run *repository.Run) error
+}
+
+// Resolver replaces references in the params of jobs before their handlers run, like the ones to secrets
+type Resolver interface {
+	// Resolve returns the params of job with the references replaced and a function redacting the values it replaced
+	// them with from the output and error of the run, nil when there is nothing to redact
+	Resolve(ctx context.Context, job *repository.Job) (params map[string]interface{}, redact func(text string) string, err error)
+}
+
+// Executor runs jobs on a bounded pool of workers and records every execution as a run
+type Executor struct {
+	jobs     repository.JobRepository
+	runs     repository.RunRepository
+	handlers map[string]Handler
+	types    map[string]Type
+	onFinish []FinishFunc
+	store    RunStore
+	resolver Resolver
+	queue    *queue
+	wg       sync.WaitGroup
+	logger   *zap.Logger
+	// host names the workers of this replica, costPerSecond is what a second of compute time costs
+	host          string
+	costPerSecond float64
+
+	// workersMu guards the pool of workers, workers is how many there should be, started how many run and spawned
+	// numbers the next one. SetWorkers closes wake to get idle workers to stop when there are too many.
+	workersMu sync.Mutex
+	workers   int
+	started   int
+	spawned   int
+	ctx       context.Context
+	wake      chan struct{}
+
+	mu sync.Mutex
+	// running holds the runs being executed by job and run ID
+	running map[string]map[string]*activeRun
+	// pending counts the runs of every job that were handed to the workers and didn't finish yet
+	pending map[string]int
+}
+
+// activeRun is a run being executed
+type activeRun struct {
+	cancel context.CancelFunc
+	// replaced is set when a newer run of the job cancelled the run
+	replaced bool
+	// done is closed once the handler of the run returned
+	done chan struct{}
+}
+
+// New creates an Executor with the given number of workers and queue capacity. Queued runs get the workers according to
+// the shares of their priorities, a run that waited maxWait gets the next free worker regardless. Zero never lets runs
+// skip the order of the shares. The noop, command, shell and http handlers are registered by default.
+func New(jobs repository.JobRepository, runs repository.RunRepository, workers, queueSize int, shares Shares, maxWait time.Duration, logger *zap.Logger) *Executor {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	e := &Executor{
+		host:     host,
+		jobs:     jobs,
+		runs:     runs,
+		handlers: map[string]Handler{},
+		types:    map[string]Type{},
+		queue:    newQueue(queueSize, workers, shares, maxWait),
+		workers:  workers,
+		wake:     make(chan struct{}),
+		logger:   logger,
+		running:  map[string]map[string]*activeRun{},
+		pending:  map[string]int{},
+	}
+	for _, t := range []struct {
+		handler Handler
+		Type
+	}{{Noop, NoopType}, {Command, CommandType}, {Shell, ShellType}, {HTTP, HTTPType}} {
+		e.Register(t.Name, t.handler)
+		e.Describe(t.Type)
+	}
+	return e
+}
+
+// Register makes a handler available under name, it must be called before Start
+func (e *Executor) Register(name string, h Handler) {
+	e.handlers[name] = h
+}
+
+// Describe tells what the jobs of the handler registered under the name of t do and which params they take, handlers
+// that weren't described take no params. It must be called before Start.
+func (e *Executor) Describe(t Type) {
+	e.types[t.Name] = t
+}
+
+// Types returns the types of the registered handlers ordered by name
+func (e *Executor) Types() []Type {
+	types := make([]Type, 0, len(e.handlers))
+	for name := range e.handlers {
+		t, ok := e.types[name]
+		if !ok {
+			t = Type{Name: name}
+		}
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
+
+// ValidateParams returns an error describing what's wrong with the params of a job run by the handler with this name
+// unless they match its schema
+func (e *Executor) ValidateParams(name string, params map[string]interface{}) error {
+	if name == "" {
+		name = DefaultHandler
+	}
+	return e.types[name].Schema.Validate(params)
+}
+
+// OnFinish makes the executor call f for every finished run, it must be called before Start
+func (e *Executor) OnFinish(f FinishFunc) {
+	e.onFinish = append(e.onFinish, f)
+}
+
+// StoreWith makes the executor store the outcome of every run together with what store keeps about it, runs whose job
+// couldn't be loaded are left out. It must be called before Start.
+func (e *Executor) StoreWith(store RunStore) {
+	e.store = store
+}
+
+// ResolveWith makes the executor hand the params resolved by resolver to the handlers, it must be called before
+// Start. Handlers get the params as they are stored without it.
+func (e *Executor) ResolveWith(resolver Resolver) {
+	e.resolver = resolver
+}
+
+// Charge makes the executor record what every run cost at costPerSecond of compute time, it must be called before
+// Start. Runs cost nothing without it.
+func (e *Executor) Charge(costPerSecond float64) {
+	e.costPerSecond = costPerSecond
+}
+
+// HasHandler reports whether a handler with this name is registered
+func (e *Executor) HasHandler(name string) bool {
+	if name == "" {
+		name = DefaultHandler
+	}
+	_, ok := e.handlers[name]
+	return ok
+}
+
+// Start launches the workers. Cancelling ctx cancels the running handlers and stops the workers.
+func (e *Executor) Start(ctx context.Context) {
+	e.workersMu.Lock()
+	defer e.workersMu.Unlock()
+	e.ctx = ctx
+	for e.started < e.workers {
+		e.startWorker()
+	}
+	e.logger.Info("Executor started", zap.Int("workers", e.workers))
+}
+
+// SetWorkers changes the number of workers. Added workers start right away while the executor runs, removed ones stop
+// once they finished their current run. Added workers don't make room for more queued runs, the queue keeps the
+// capacity it was created with.
+func (e *Executor) SetWorkers(n int) {
+	e.workersMu.Lock()
+	defer e.workersMu.Unlock()
+	e.workers = n
+	if e.ctx == nil {
+		return
+	}
+	e.logger.Info("Changed the number of workers", zap.Int("workers", n), zap.Int("running", e.started))
+	for e.started < e.workers {
+		e.startWorker()
+	}
+	if e.started > e.workers {
+		close(e.wake)
+		e.wake = make(chan struct{})
+	}
+}
+
+// startWorker launches another worker, the caller must hold workersMu
+func (e *Executor) startWorker() {
+	worker := fmt.Sprintf("%s/%d", e.host, e.spawned)
+	e.spawned++
+	e.started++
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		for {
+			wake, retire := e.retire()
+			if retire {
+				return
+			}
+			run := e.queue.pop(e.ctx, wake)
+			if run == nil {
+				if e.ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+			e.execute(e.ctx, worker, run)
+		}
+	}()
+}
+
+// retire reports whether a worker should stop since there are too many, otherwise it returns the channel that is
+// closed once there are
+func (e *Executor) retire() (<-chan struct{}, bool) {
+	e.workersMu.Lock()
+	defer e.workersMu.Unlock()
+	if e.started > e.workers {
+		e.started--
+		return nil, true
+	}
+	return e.wake, false
+}
+
+// Wait blocks until all workers returned
+func (e *Executor) Wait() {
+	e.wg.Wait()
+}
+
+// Submit records a queued run for the job with the priority of the job and hands it to the worker pool. trigger tells
+// what started the run, triggeredBy who did for manual runs. The run starts a new scheduling cycle, the jobs depending
+// on the job run once it succeeded.
+func (e *Executor) Submit(ctx context.Context, jobID, priority, trigger, triggeredBy string) (string, error) {
+	run, err := e.runs.Create(ctx, &repository.Run{
+		JobID:       jobID,
+		Status:      StatusQueued,
+		QueuedAt:    now(),
+		Attempt:     1,
+		Priority:    priority,
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not record run: %v", err)
+	}
+	return run.ID, e.enqueue(ctx, run)
+}
+
+// Fire submits a run of a job fired by the scheduler. When the concurrency policy of the job forbids overlapping runs
+// and one of its runs is still queued or running on this executor, no run is recorded and it returns ErrStillRunning.
+func (e *Executor) Fire(ctx context.Context, job *scheduler.DueJob) (string, error) {
+	if job.ConcurrencyPolicy == repository.ConcurrencyForbid && e.isPending(job.ID) {
+		return "", ErrStillRunning
+	}
+	return e.Submit(ctx, job.ID, job.Priority, repository.TriggerSchedule, "")
+}
+
+// Retry claims a waiting retry and hands it to the worker pool. Retries another replica claimed first are skipped.
+func (e *Executor) Retry(ctx context.Context, runID string) error {
+	claimed, err := e.runs.ClaimRetry(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("could not claim retry: %v", err)
+	}
+	if !claimed {
+		return nil
+	}
+	run, err := e.runs.Get(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("could not load retry: %v", err)
+	}
+	return e.enqueue(ctx, run)
+}
+
+// enqueue hands a queued run to the worker pool
+func (e *Executor) enqueue(ctx context.Context, run *repository.Run) error {
+	// Count the run before a worker can take it, so it's never finished before it was counted
+	e.mu.Lock()
+	e.pending[run.JobID]++
+	e.mu.Unlock()
+	if e.queue.push(run) {
+		return nil
+	}
+	e.done(run.JobID)
+	// Don't leave a queued run behind that nobody will ever pick up
+	e.finish(ctx, nil, run, "", ErrQueueFull)
+	return ErrQueueFull
+}
+
+// execute loads the job, runs its handler on worker and stores the outcome
+func (e *Executor) execute(ctx context.Context, worker string, run *repository.Run) {
+	defer e.done(run.JobID)
+	// Workers serve all tenants, access the job and run with the tenant the run was created for
+	ctx = tenant.NewContext(ctx, run.Tenant)
+	stored, err := e.jobs.Get(ctx, run.JobID, repository.Query{})
+	if err != nil {
+		e.finish(ctx, nil, run, "", fmt.Errorf("could not load job: %v", err))
+		return
+	}
+	defer e.finished(ctx, stored, run)
+	if stored.Status == repository.JobPaused {
+		e.finish(ctx, stored, run, "", errors.New("job is paused"))
+		return
+	}
+	job := &Job{
+		ID:      stored.ID,
+		Name:    stored.Name,
+		Handler: stored.Handler,
+		Command: stored.Command,
+		RunID:   run.ID,
+		Params:  stored.Params,
+	}
+	name := job.Handler
+	if name == "" {
+		name = DefaultHandler
+	}
+	handler, ok := e.handlers[name]
+	if !ok {
+		e.finish(ctx, stored, run, "", fmt.Errorf("unknown handler %q", name))
+		return
+	}
+	var redact func(string) string
+	if e.resolver != nil {
+		params, r, err := e.resolver.Resolve(ctx, stored)
+		if err != nil {
+			e.finish(ctx, stored, run, "", fmt.Errorf("could not resolve params: %v", err))
+			return
+		}
+		job.Params, redact = params, r
+	}
+
+	// The run is tracked before it waits for the runs it replaces, so a newer run can replace it in the meantime
+	cancelCtx, cancel := context.WithCancel(ctx)
+	e.track(job.ID, run.ID, cancel)
+	// A scheduled run of a job that replaces its runs starts once the others stopped
+	if run.Trigger == repository.TriggerSchedule && stored.ConcurrencyPolicy == repository.ConcurrencyReplace {
+		e.replace(cancelCtx, job.ID, run.ID)
+	}
+
+	started := now()
+	run.StartTime = &started
+	run.Status = StatusRunning
+	run.Timeout = stored.Timeout
+	run.Worker = worker
+	run.CostPerSecond = e.costPerSecond
+	if err := e.runs.Update(ctx, run); err != nil {
+		e.logger.Error("Could not mark run as running", zap.String("run_id", run.ID), zap.Error(err))
+	}
+	// With overlapping runs the job already is running
+	if stored.Status != repository.JobRunning {
+		e.setJobStatus(ctx, job.ID, stored.Status, repository.JobRunning)
+	}
+
+	runCtx, cancelRun := runContext(cancelCtx, stored.Timeout)
+	var ranOn string
+	output, err := handler.Run(context.WithValue(runCtx, workerKey{}, &ranOn), job)
+	if ranOn != "" {
+		run.Worker = ranOn
+	}
+	// The values of secrets never end up in the stored run
+	if redact != nil {
+		output = redact(output)
+		if err != nil {
+			if msg := redact(err.Error()); msg != err.Error() {
+				err = errors.New(msg)
+			}
+		}
+	}
+	replaced := e.untrack(job.ID, run.ID)
+	// Only Cancel, replacing runs and the timeout stop a run without stopping the executor
+	stopped := runCtx.Err()
+	cancelRun()
+	cancel()
+	if ctx.Err() == nil && stopped == context.Canceled {
+		// CancelJob already moved the job to CANCELLED, the run that replaced this one keeps it RUNNING
+		if replaced {
+			e.finish(ctx, stored, run, output, ErrReplaced)
+		} else {
+			e.finish(ctx, stored, run, output, ErrCancelled)
+		}
+		return
+	}
+	if ctx.Err() == nil && stopped == context.DeadlineExceeded {
+		err = ErrTimedOut
+	}
+	e.finish(ctx, stored, run, output, err)
+
+	// The job keeps the outcome of its latest run, unless its status was changed in the meantime
+	outcome := repository.JobSucceeded
+	if err != nil {
+		outcome = repository.JobFailed
+	}
+	ctx, cancelStore := storeContext(ctx)
+	defer cancelStore()
+	e.setJobStatus(ctx, job.ID, repository.JobRunning, outcome)
+	if err != nil {
+		e.retry(ctx, stored, run)
+	} else {
+		e.runDependents(ctx, run)
+	}
+}
+
+// finished calls the functions registered with OnFinish
+func (e *Executor) finished(ctx context.Context, job *repository.Job, run *repository.Run) {
+	for _, f := range e.onFinish {
+		f(ctx, job, run)
+	}
+}
+
+// runDependents submits the jobs depending on the job of a succeeded run whose upstream jobs all succeeded in the
+// cycle of the run. When two upstream jobs finish at once both may find a dependent ready, the repository only lets
+// one of them record its run.
+func (e *Executor) runDependents(ctx context.Context, run *repository.Run) {
+	dependents, err := e.jobs.Dependents(ctx, run.JobID)
+	if err != nil {
+		e.logger.Error("Could not load dependent jobs", zap.String("job_id", run.JobID), zap.Error(err))
+		return
+	}
+	if len(dependents) == 0 {
+		return
+	}
+	runs, err := e.runs.CycleRuns(ctx, run.CycleID)
+	if err != nil {
+		e.logger.Error("Could not load runs of cycle", zap.String("cycle_id", run.CycleID), zap.Error(err))
+		return
+	}
+	succeeded := map[string]bool{}
+	for _, r := range runs {
+		if r.Status == StatusSucceeded {
+			succeeded[r.JobID] = true
+		}
+	}
+	for _, job := range dependents {
+		// Archived jobs don't run after their upstream jobs either
+		if job.ArchivedAt != nil || !allSucceeded(job.DependsOn, succeeded) {
+			continue
+		}
+		next, err := e.runs.Create(ctx, &repository.Run{
+			JobID:    job.ID,
+			Status:   StatusQueued,
+			QueuedAt: now(),
+			Attempt:  1,
+			CycleID:  run.CycleID,
+			Priority: job.Priority,
+			Trigger:  repository.TriggerDependency,
+		})
+		if err == repository.ErrRunExists {
+			continue
+		} else if err != nil {
+			e.logger.Error("Could not record run of dependent job", zap.String("job_id", job.ID), zap.Error(err))
+			continue
+		}
+		e.logger.Info("Running dependent job", zap.String("job_id", job.ID), zap.String("upstream_run_id", run.ID),
+			zap.String("run_id", next.ID), zap.String("cycle_id", run.CycleID))
+		if err := e.enqueue(ctx, next); err != nil {
+			e.logger.Error("Could not submit dependent job", zap.String("job_id", job.ID), zap.Error(err))
+		}
+	}
+}
+
+// allSucceeded reports whether every job in jobIDs is in succeeded
+func allSucceeded(jobIDs []string, succeeded map[string]bool) bool {
+	for _, id := range jobIDs {
+		if !succeeded[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// retry records the next attempt of a failed run when the retry policy of the job allows another one.
+// The scheduler hands it back to Retry once its backoff has passed.
+func (e *Executor) retry(ctx context.Context, job *repository.Job, run *repository.Run) {
+	if !job.RetryPolicy.Retries(run.Attempt) {
+		return
+	}
+	queuedAt := now()
+	retryAt := queuedAt.Add(job.RetryPolicy.Backoff(run.Attempt))
+	next, err := e.runs.Create(ctx, &repository.Run{
+		JobID:       run.JobID,
+		Status:      StatusWaiting,
+		QueuedAt:    queuedAt,
+		Attempt:     run.Attempt + 1,
+		RetryAt:     &retryAt,
+		CycleID:     run.CycleID,
+		Priority:    job.Priority,
+		Trigger:     run.Trigger,
+		TriggeredBy: run.TriggeredBy,
+	})
+	if err != nil {
+		e.logger.Error("Could not record retry of run", zap.String("run_id", run.ID), zap.Error(err))
+		return
+	}
+	e.logger.Info("Retrying failed run", zap.String("job_id", run.JobID), zap.String("run_id", run.ID),
+		zap.String("retry_run_id", next.ID), zap.Int("attempt", next.Attempt), zap.Time("retry_at", retryAt))
+}
+
+// Cancel stops the runs of the job that are being executed and reports whether there were any.
+// Runs that are still queued are executed regardless.
+func (e *Executor) Cancel(jobID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, active := range e.running[jobID] {
+		active.cancel()
+	}
+	return len(e.running[jobID]) > 0
+}
+
+// replace cancels the runs of the job being executed other than the run with runID and waits until their handlers
+// returned or ctx is done. Runs executed by other replicas aren't stopped.
+func (e *Executor) replace(ctx context.Context, jobID, runID string) {
+	e.mu.Lock()
+	replaced := []string{}
+	done := []chan struct{}{}
+	for id, active := range e.running[jobID] {
+		if id == runID {
+			continue
+		}
+		active.replaced = true
+		active.cancel()
+		replaced = append(replaced, id)
+		done = append(done, active.done)
+	}
+	e.mu.Unlock()
+	if len(replaced) == 0 {
+		return
+	}
+	e.logger.Info("Replacing runs of job", zap.String("job_id", jobID), zap.String("run_id", runID), zap.Strings("replaced_run_ids", replaced))
+	for _, d := range done {
+		select {
+		case <-d:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// track registers the cancel function of a run that is being executed
+func (e *Executor) track(jobID, runID string, cancel context.CancelFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running[jobID] == nil {
+		e.running[jobID] = map[string]*activeRun{}
+	}
+	e.running[jobID][runID] = &activeRun{cancel: cancel, done: make(chan struct{})}
+}
+
+// untrack removes a run registered with track once its handler returned and reports whether it was replaced
+func (e *Executor) untrack(jobID, runID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	active := e.running[jobID][runID]
+	close(active.done)
+	delete(e.running[jobID], runID)
+	if len(e.running[jobID]) == 0 {
+		delete(e.running, jobID)
+	}
+	return active.replaced
+}
+
+// isPending reports whether a run of the job was handed to the workers and didn't finish yet
+func (e *Executor) isPending(jobID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.pending[jobID] > 0
+}
+
+// done counts a run counted by enqueue as finished
+func (e *Executor) done(jobID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pending[jobID]--
+	if e.pending[jobID] <= 0 {
+		delete(e.pending, jobID)
+	}
+}
+
+// setJobStatus moves the job from status from to status to, a job whose status changed in the meantime is left alone
+func (e *Executor) setJobStatus(ctx context.Context, jobID, from, to string) {
+	_, err := e.jobs.SetStatus(ctx, jobID, repository.Query{}, from, to, now())
+	if err != nil && err != repository.ErrStatusConflict && err != repository.ErrNotFound {
+		e.logger.Error("Could not store job status", zap.String("job_id", jobID), zap.String("status", to), zap.Error(err))
+	}
+}
+
+// finish stores the final status, output, error and cost of a run of job, which is nil when the job couldn't be loaded
+func (e *Executor) finish(ctx context.Context, job *repository.Job, run *repository.Run, output string, runErr error) {
+	ended := now()
+	run.EndTime = &ended
+	if run.StartTime != nil {
+		run.Duration = ended.Sub(*run.StartTime)
+		run.Cost = run.Duration.Seconds() * run.CostPerSecond
+	}
+	run.Output = truncate(output)
+	run.Status = StatusSucceeded
+	run.Error = ""
+	if runErr == ErrCancelled || runErr == ErrReplaced {
+		run.Status = StatusCancelled
+		run.Error = runErr.Error()
+	} else if runErr == ErrTimedOut {
+		run.Status = StatusTimedOut
+		run.Error = fmt.Sprintf("run timed out after %v", run.Timeout)
+	} else if runErr != nil {
+		run.Status = StatusFailed
+		run.Error = runErr.Error()
+	}
+	ctx, cancel := storeContext(ctx)
+	defer cancel()
+	var err error
+	if e.store != nil && job != nil {
+		err = e.store.InTransaction(ctx, func(ctx context.Context) error {
+			if err := e.runs.Update(ctx, run); err != nil {
+				return err
+			}
+			return e.store.RunFinished(ctx, job, run)
+		})
+	} else {
+		err = e.runs.Update(ctx, run)
+	}
+	if err != nil {
+		e.logger.Error("Could not store result of run", zap.String("run_id", run.ID), zap.Error(err))
+	}
+}
+
+// runContext returns the context to run a handler with. Handlers must stop once it is done, the command handler
+// kills its process, so the context ends when the timeout passed.
+func runContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// storeContext returns the context to store the outcome of a run with. The outcome must be written even if the
+// executor is shutting down, but without blocking shutdown forever. It keeps the tenant of ctx.
+func storeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx.Err() != nil {
+		return context.WithTimeout(tenant.NewContext(context.Background(), tenant.FromContext(ctx)), finishTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// now returns the current time with the millisecond precision dates are stored with
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,89 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Handler runs a job and returns its output
+type Handler interface {
+	Run(ctx context.Context, job *Job) (string, error)
+}
+
+// HandlerFunc adapts an ordinary function to the Handler interface
+type HandlerFunc func(ctx context.Context, job *Job) (string, error)
+
+// Run calls f(ctx, job)
+func (f HandlerFunc) Run(ctx context.Context, job *Job) (string, error) {
+	return f(ctx, job)
+}
+
+// DefaultHandler is used for jobs that don't name a handler
+const DefaultHandler = "noop"
+
+// Noop succeeds immediately without doing anything
+var Noop = HandlerFunc(func(ctx context.Context, job *Job) (string, error) {
+	return "", nil
+})
+
+// NoopType describes the noop handler
+var NoopType = Type{Name: DefaultHandler, Description: "Succeeds right away without doing anything"}
+
+// Command runs the job's command as a child process. The command is split on whitespace, there is no shell involved.
+// It takes the params of CommandType.
+var Command = HandlerFunc(func(ctx context.Context, job *Job) (string, error) {
+	args := strings.Fields(job.Command)
+	if len(args) == 0 {
+		return "", errors.New("job has no command")
+	}
+	return runProcess(ctx, job, args[0], args[1:]...)
+})
+
+// Shell runs the job's command with /bin/sh, so it can use pipes, redirections and variables. It takes the params of
+// ShellType.
+var Shell = HandlerFunc(func(ctx context.Context, job *Job) (string, error) {
+	if strings.TrimSpace(job.Command) == "" {
+		return "", errors.New("job has no command")
+	}
+	return runProcess(ctx, job, "/bin/sh", "-c", job.Command)
+})
+
+// processParams are the params of the handlers running child processes
+var processParams = []Param{
+	{Name: "dir", Type: ParamString, Description: "Working directory of the command, the one of the server by default"},
+	{Name: "env", Type: ParamObject, Values: ParamString, Description: "Environment variables added to the ones of the server"},
+}
+
+// CommandType describes the command handler
+var CommandType = Type{
+	Name:        "command",
+	Description: "Runs the command of the job split on whitespace as a child process, without a shell",
+	Schema:      Schema{Params: processParams},
+}
+
+// ShellType describes the shell handler
+var ShellType = Type{
+	Name:        "shell",
+	Description: "Runs the command of the job with /bin/sh -c",
+	Schema:      Schema{Params: processParams},
+}
+
+// runProcess runs a child process in the dir and with the env params of job and returns its combined output
+func runProcess(ctx context.Context, job *Job, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if dir, ok := job.Params["dir"].(string); ok {
+		cmd.Dir = dir
+	}
+	if env, ok := job.Params["env"].(map[string]interface{}); ok {
+		cmd.Env = os.Environ()
+		for _, name := range sortedKeys(env) {
+			value, _ := env[name].(string)
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
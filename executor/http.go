@@ -0,0 +1,250 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// defaultHTTPTimeout limits the requests of jobs without a timeout param or a timeout of their own
+	defaultHTTPTimeout = time.Minute
+	// maxHTTPTimeout is the longest timeout param of the http handler
+	maxHTTPTimeout = time.Hour
+	// maxSnippet is the number of bytes of the response body the errors of failed assertions quote
+	maxSnippet = 256
+)
+
+var (
+	// httpMethods are the methods the http handler sends requests with
+	httpMethods = map[string]bool{
+		http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+		http.MethodPatch: true, http.MethodDelete: true, http.MethodOptions: true,
+	}
+	// headerNamePattern matches the tokens header names consist of
+	headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	// httpClient sends the requests of the http handler
+	httpClient = &http.Client{}
+)
+
+// HTTPRequest is what the templates in the url, headers and body params of the http handler can refer to, like
+// {{.RunID}} or {{.Time.Unix}}
+type HTTPRequest struct {
+	RunID   string
+	JobID   string
+	JobName string
+	// Time is when the request is sent, in UTC
+	Time time.Time
+}
+
+// HTTP sends a request to the url param of the job. The run fails unless the response passes the assertions of the
+// params, which expect a 2xx status by default. The output is the status line followed by the start of the response
+// body. It takes the params of HTTPType.
+var HTTP = HandlerFunc(func(ctx context.Context, job *Job) (string, error) {
+	method, _ := job.Params["method"].(string)
+	if method == "" {
+		method = http.MethodGet
+	}
+	data := &HTTPRequest{RunID: job.RunID, JobID: job.ID, JobName: job.Name, Time: time.Now().UTC()}
+	render := func(param, text string) (string, error) {
+		out, err := renderTemplate(text, data)
+		if err != nil {
+			return "", fmt.Errorf("could not render param %q: %v", param, err)
+		}
+		return out, nil
+	}
+	target, _ := job.Params["url"].(string)
+	target, err := render("url", target)
+	if err != nil {
+		return "", err
+	}
+	var body io.Reader
+	if b, _ := job.Params["body"].(string); b != "" {
+		rendered, err := render("body", b)
+		if err != nil {
+			return "", err
+		}
+		body = strings.NewReader(rendered)
+	}
+	if timeout, _ := job.Params["timeout"].(string); timeout != "" {
+		// checkHTTPParams made sure it parses
+		d, _ := time.ParseDuration(timeout)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	} else if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultHTTPTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "schedulytics-http")
+	headers, _ := job.Params["headers"].(map[string]interface{})
+	for _, name := range sortedKeys(headers) {
+		value, _ := headers[name].(string)
+		if value, err = render("headers", value); err != nil {
+			return "", err
+		}
+		req.Header.Set(name, value)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxOutput))
+	output := resp.Proto + " " + resp.Status + "\n" + string(respBody)
+	if err != nil {
+		return output, fmt.Errorf("could not read response: %v", err)
+	}
+	if err := checkResponse(job.Params, resp.StatusCode, string(respBody)); err != nil {
+		return output, fmt.Errorf("%v; response %s: %s", err, resp.Status, snippet(string(respBody)))
+	}
+	return output, nil
+})
+
+// checkResponse returns an error describing the first assertion of params the response doesn't pass
+func checkResponse(params map[string]interface{}, status int, body string) error {
+	if err := checkStatus(params, status); err != nil {
+		return err
+	}
+	if contains, _ := params["body_contains"].(string); contains != "" && !strings.Contains(body, contains) {
+		return fmt.Errorf("response body doesn't contain %q", contains)
+	}
+	if pattern, _ := params["body_matches"].(string); pattern != "" {
+		// checkHTTPParams made sure it compiles
+		if !regexp.MustCompile(pattern).MatchString(body) {
+			return fmt.Errorf("response body doesn't match %q", pattern)
+		}
+	}
+	return nil
+}
+
+// checkStatus returns an error unless status is one of the expected_status param, or 2xx without it
+func checkStatus(params map[string]interface{}, status int) error {
+	expected, _ := params["expected_status"].([]interface{})
+	if len(expected) == 0 {
+		if status < 200 || status > 299 {
+			return fmt.Errorf("expected a 2xx status, got %d", status)
+		}
+		return nil
+	}
+	for _, s := range expected {
+		if code, _ := s.(float64); int(code) == status {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected a status of %v, got %d", expected, status)
+}
+
+// snippet quotes the start of a response body for the error of a failed assertion
+func snippet(body string) string {
+	if len(body) <= maxSnippet {
+		return fmt.Sprintf("%q", body)
+	}
+	cut := maxSnippet
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%q...", body[:cut])
+}
+
+// renderTemplate executes text as a template of an HTTPRequest
+func renderTemplate(text string, data *HTTPRequest) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	t, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// HTTPType describes the http handler
+var HTTPType = Type{
+	Name:        "http",
+	Description: "Sends an HTTP request, the run fails unless the response passes the assertions, a 2xx status by default",
+	Schema: Schema{
+		Params: []Param{
+			{Name: "url", Type: ParamString, Required: true, Description: "Absolute http or https URL the request is sent to, a template"},
+			{Name: "method", Type: ParamString, Description: "Method of the request, GET by default"},
+			{Name: "headers", Type: ParamObject, Values: ParamString, Description: "Headers of the request, their values are templates"},
+			{Name: "body", Type: ParamString, Description: "Body of the request, a template, empty by default"},
+			{Name: "timeout", Type: ParamString, Description: "How long the request may take like 10s, at most 1h. The timeout of the job by default, or 1m without one."},
+			{Name: "expected_status", Type: ParamList, Values: ParamNumber, Description: "Status codes the response must have, any 2xx by default"},
+			{Name: "body_contains", Type: ParamString, Description: "Text the start of the response body must contain"},
+			{Name: "body_matches", Type: ParamString, Description: "RE2 regular expression the start of the response body must match"},
+		},
+		Check: checkHTTPParams,
+	},
+}
+
+// checkHTTPParams checks the params of the http handler beyond their types
+func checkHTTPParams(params map[string]interface{}) error {
+	// The templates are rendered with an example, so they can only refer to fields that exist
+	example := &HTTPRequest{RunID: "run", JobID: "job", JobName: "job", Time: time.Now().UTC()}
+	target, _ := params["url"].(string)
+	target, err := renderTemplate(target, example)
+	if err != nil {
+		return fmt.Errorf("param \"url\" must be a valid template: %v", err)
+	}
+	if u, err := url.Parse(target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("param \"url\" must be an absolute http or https URL")
+	}
+	if method, _ := params["method"].(string); method != "" && !httpMethods[method] {
+		return fmt.Errorf("param \"method\" must be GET, HEAD, POST, PUT, PATCH, DELETE or OPTIONS, got %q", method)
+	}
+	headers, _ := params["headers"].(map[string]interface{})
+	for _, name := range sortedKeys(headers) {
+		if !headerNamePattern.MatchString(name) {
+			return fmt.Errorf("param \"headers\" must only have valid header names, got %q", name)
+		}
+		value, _ := headers[name].(string)
+		if _, err := renderTemplate(value, example); err != nil {
+			return fmt.Errorf("param \"headers\" must only have valid templates, the one of %q isn't: %v", name, err)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("param \"headers\" must not have line breaks in the value of %q", name)
+		}
+	}
+	if body, _ := params["body"].(string); body != "" {
+		if _, err := renderTemplate(body, example); err != nil {
+			return fmt.Errorf("param \"body\" must be a valid template: %v", err)
+		}
+	}
+	if timeout, _ := params["timeout"].(string); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 || d > maxHTTPTimeout {
+			return fmt.Errorf("param \"timeout\" must be a duration between 0s and %v like 10s, got %q", maxHTTPTimeout, timeout)
+		}
+	}
+	expected, _ := params["expected_status"].([]interface{})
+	for _, s := range expected {
+		if code, _ := s.(float64); code != math.Trunc(code) || code < 100 || code > 599 {
+			return fmt.Errorf("param \"expected_status\" must only hold status codes between 100 and 599, got %v", s)
+		}
+	}
+	if pattern, _ := params["body_matches"].(string); pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("param \"body_matches\" must be a valid regular expression: %v", err)
+		}
+	}
+	return nil
+}
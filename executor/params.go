@@ -0,0 +1,121 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Param types, the JSON types the values of params have
+const (
+	ParamString = "string"
+	ParamNumber = "number"
+	ParamBool   = "bool"
+	ParamObject = "object"
+	ParamList   = "list"
+)
+
+// Param is a param a handler takes
+type Param struct {
+	Name string
+	// Type is one of the Param constants
+	Type string
+	// Values is the type the values of object and list params must have, they can have any type when it's empty
+	Values   string
+	Required bool
+	// Description tells users what the handler does with the param
+	Description string
+}
+
+// Schema tells which params a handler takes, the zero value takes none
+type Schema struct {
+	Params []Param
+	// Open schemas also take params they don't list, with values of any type
+	Open bool
+	// Check is called with params that matched the schema, for the rules the types of params can't express. It may
+	// be nil.
+	Check func(params map[string]interface{}) error
+}
+
+// Type describes the jobs a handler runs, so clients can offer the handlers and a form for their params
+type Type struct {
+	// Name is the name the handler is registered with
+	Name        string
+	Description string
+	Schema      Schema
+}
+
+// Validate returns an error describing what's wrong with params unless they hold JSON values matching the schema.
+// Params set to null count as missing.
+func (s Schema) Validate(params map[string]interface{}) error {
+	listed := map[string]bool{}
+	for _, p := range s.Params {
+		listed[p.Name] = true
+		value := params[p.Name]
+		if value == nil {
+			if p.Required {
+				return fmt.Errorf("param %q is required", p.Name)
+			}
+			continue
+		}
+		if t := paramType(value); t != p.Type {
+			return fmt.Errorf("param %q must be a %s, got a %s", p.Name, p.Type, t)
+		}
+		if p.Values == "" {
+			continue
+		}
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedKeys(value) {
+				if t := paramType(value[key]); t != p.Values {
+					return fmt.Errorf("param %q must only hold %s values, got a %s for %q", p.Name, p.Values, t, key)
+				}
+			}
+		case []interface{}:
+			for i, v := range value {
+				if t := paramType(v); t != p.Values {
+					return fmt.Errorf("param %q must only hold %s values, got a %s at %d", p.Name, p.Values, t, i)
+				}
+			}
+		}
+	}
+	if !s.Open {
+		for _, name := range sortedKeys(params) {
+			if !listed[name] {
+				return fmt.Errorf("unknown param %q", name)
+			}
+		}
+	}
+	if s.Check != nil {
+		return s.Check(params)
+	}
+	return nil
+}
+
+// paramType returns the Param constant of the type of a JSON value, null for nil
+func paramType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return ParamString
+	case float64:
+		return ParamNumber
+	case bool:
+		return ParamBool
+	case map[string]interface{}:
+		return ParamObject
+	case []interface{}:
+		return ParamList
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// sortedKeys returns the keys of an object in order
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
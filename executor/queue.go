@@ -0,0 +1,187 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+)
+
+// Priorities lists the priorities of runs, highest first
+var Priorities = []string{repository.PriorityCritical, repository.PriorityHigh, repository.PriorityNormal, repository.PriorityLow}
+
+// Shares maps every priority to its share of the workers while runs of several priorities are waiting. A priority
+// with share 4 gets twice as many workers as one with share 2, runs of a single priority get all of them.
+type Shares map[string]int
+
+// DefaultShares returns the shares used unless configured otherwise
+func DefaultShares() Shares {
+	return Shares{
+		repository.PriorityCritical: 8,
+		repository.PriorityHigh:     4,
+		repository.PriorityNormal:   2,
+		repository.PriorityLow:      1,
+	}
+}
+
+func (s *Shares) String() string {
+	if s == nil {
+		return ""
+	}
+	items := []string{}
+	for _, priority := range Priorities {
+		if share, ok := (*s)[priority]; ok {
+			items = append(items, priority+"="+strconv.Itoa(share))
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// Set parses a comma separated list like CRITICAL=8,HIGH=4, priorities it leaves out keep their default share
+func (s *Shares) Set(value string) error {
+	shares := DefaultShares()
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid share %q, expected <priority>=<share>", item)
+		}
+		priority := strings.ToUpper(strings.TrimSpace(parts[0]))
+		if _, ok := shares[priority]; !ok {
+			return fmt.Errorf("unknown priority %q, expected %s", parts[0], strings.Join(Priorities, ", "))
+		}
+		share, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || share < 1 {
+			return fmt.Errorf("share of %s must be a positive number", priority)
+		}
+		shares[priority] = share
+	}
+	*s = shares
+	return nil
+}
+
+// queuedRun is a run waiting in the queue and the time it was queued at
+type queuedRun struct {
+	run      *repository.Run
+	queuedAt time.Time
+}
+
+// queue holds the runs waiting for a worker by priority. Workers take the run of the priority that got the smallest
+// part of its share so far, which is stride scheduling, unless a run waited longer than maxWait. Then the run that
+// waited longest is taken, so runs of low priorities don't starve behind a steady stream of higher ones.
+type queue struct {
+	capacity int
+	shares   Shares
+	maxWait  time.Duration
+	// ready holds a token for every queued run, workers wait on it
+	ready chan struct{}
+
+	mu   sync.Mutex
+	runs map[string][]queuedRun
+	size int
+	// idle is the number of workers waiting for a run, runs handed to them don't take up capacity
+	idle int
+	// pass is the virtual time of every priority, it advances by 1/share for every run taken. clock is the pass of
+	// the priority of the last run taken.
+	pass  map[string]float64
+	clock float64
+}
+
+// newQueue creates a queue holding up to capacity runs on top of the ones idle workers take right away
+func newQueue(capacity, workers int, shares Shares, maxWait time.Duration) *queue {
+	return &queue{
+		capacity: capacity,
+		shares:   shares,
+		maxWait:  maxWait,
+		ready:    make(chan struct{}, capacity+workers),
+		runs:     map[string][]queuedRun{},
+		pass:     map[string]float64{},
+	}
+}
+
+// priority returns the priority a run is queued with, runs stored before priorities existed are normal
+func priority(run *repository.Run) string {
+	if _, ok := DefaultShares()[run.Priority]; ok {
+		return run.Priority
+	}
+	return repository.PriorityNormal
+}
+
+// push queues a run and reports false when the queue is full
+func (q *queue) push(run *repository.Run) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	// Workers added by SetWorkers can be idle on top of the ones ready has room for
+	if q.size >= q.capacity+q.idle || q.size >= cap(q.ready) {
+		return false
+	}
+	p := priority(run)
+	// A priority that had nothing to do doesn't get to catch up on the share it didn't use
+	if len(q.runs[p]) == 0 && q.pass[p] < q.clock {
+		q.pass[p] = q.clock
+	}
+	q.runs[p] = append(q.runs[p], queuedRun{run: run, queuedAt: time.Now()})
+	q.size++
+	metrics.SetQueuedRuns(p, len(q.runs[p]))
+	q.ready <- struct{}{}
+	return true
+}
+
+// pop blocks until a run is queued and takes the next one, it returns nil once ctx is done or wake is closed
+func (q *queue) pop(ctx context.Context, wake <-chan struct{}) *repository.Run {
+	q.mu.Lock()
+	q.idle++
+	q.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		q.mu.Lock()
+		q.idle--
+		q.mu.Unlock()
+		return nil
+	case <-wake:
+		q.mu.Lock()
+		q.idle--
+		q.mu.Unlock()
+		return nil
+	case <-q.ready:
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.idle--
+	p := q.next()
+	run := q.runs[p][0].run
+	q.runs[p] = q.runs[p][1:]
+	q.size--
+	q.clock = q.pass[p]
+	q.pass[p] += 1 / float64(q.shares[p])
+	metrics.SetQueuedRuns(p, len(q.runs[p]))
+	return run
+}
+
+// next returns the priority to take a run of, the caller must hold the lock and there must be a queued run
+func (q *queue) next() string {
+	oldest := ""
+	for _, p := range Priorities {
+		if len(q.runs[p]) > 0 && (oldest == "" || q.runs[p][0].queuedAt.Before(q.runs[oldest][0].queuedAt)) {
+			oldest = p
+		}
+	}
+	if q.maxWait > 0 && time.Since(q.runs[oldest][0].queuedAt) >= q.maxWait {
+		return oldest
+	}
+	// Ties go to the higher priority
+	next := ""
+	for _, p := range Priorities {
+		if len(q.runs[p]) > 0 && (next == "" || q.pass[p] < q.pass[next]) {
+			next = p
+		}
+	}
+	return next
+}
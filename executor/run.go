@@ -0,0 +1,54 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+)
+
+// Run statuses as they are stored with every run
+const (
+	StatusQueued    = repository.RunQueued
+	StatusRunning   = "RUNNING"
+	StatusSucceeded = "SUCCEEDED"
+	StatusFailed    = "FAILED"
+	StatusCancelled = "CANCELLED"
+	// StatusTimedOut runs were stopped because they took longer than the timeout of their job
+	StatusTimedOut = "TIMED_OUT"
+	// StatusWaiting runs are retries of a failed run waiting for their backoff to pass
+	StatusWaiting = repository.RunWaiting
+)
+
+// maxOutput is the number of bytes of handler output kept in a run record
+const maxOutput = 64 * 1024
+
+// Job is the part of a stored job a handler needs to run it
+type Job struct {
+	ID      string
+	Name    string
+	Handler string
+	Command string
+	// RunID is the run being executed
+	RunID string
+	// Params are the params of the job, they matched the schema of the handler when the job was stored
+	Params map[string]interface{}
+}
+
+// workerKey is the context key of the worker a handler handed its run to
+type workerKey struct{}
+
+// RanOn records that the handler running with ctx handed the run to worker, the run is recorded with its name instead
+// of the executor's worker
+func RanOn(ctx context.Context, worker string) {
+	if w, ok := ctx.Value(workerKey{}).(*string); ok {
+		*w = worker
+	}
+}
+
+// truncate cuts output down to maxOutput bytes
+func truncate(output string) string {
+	if len(output) <= maxOutput {
+		return output
+	}
+	return output[:maxOutput]
+}
@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+)
+
+//go:generate go run gen_openapi.go
+
+// swaggerUIVersion is the version of swagger-ui-dist the docs page loads
+const swaggerUIVersion = "3.36.0"
+
+// docsPage renders the OpenAPI document with Swagger UI. The assets are loaded from unpkg, so the server doesn't have
+// to ship them.
+var docsPage = strings.NewReplacer("{{version}}", swaggerUIVersion).Replace(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Schedulytics API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@{{version}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      window.ui = SwaggerUIBundle({url: "openapi.json", dom_id: "#swagger-ui", deepLinking: true});
+    };
+  </script>
+</body>
+</html>
+`)
+
+// withDocs serves the OpenAPI document at /openapi.json and the Swagger UI page at /docs, everything else goes to next
+func withDocs(next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", next)
+	mux.HandleFunc("/openapi.json", static("application/json", openAPISpec))
+	mux.HandleFunc("/docs", static("text/html; charset=utf-8", docsPage))
+	return mux
+}
+
+// static answers GET and HEAD requests with body
+func static(contentType, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}
+}
@@ -0,0 +1,83 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// registerFuncs registers the REST handlers of every service that has HTTP bindings in its proto definition
+var registerFuncs = []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+	model.RegisterJobServiceHandlerFromEndpoint,
+	model.RegisterScheduleServiceHandlerFromEndpoint,
+	model.RegisterCalendarServiceHandlerFromEndpoint,
+	model.RegisterRunServiceHandlerFromEndpoint,
+	model.RegisterAnalyticsServiceHandlerFromEndpoint,
+	model.RegisterAuditServiceHandlerFromEndpoint,
+	model.RegisterWebhookServiceHandlerFromEndpoint,
+	model.RegisterUserServiceHandlerFromEndpoint,
+	model.RegisterTeamServiceHandlerFromEndpoint,
+	model.RegisterTemplateServiceHandlerFromEndpoint,
+	model.RegisterAdminServiceHandlerFromEndpoint,
+	model.RegisterReportServiceHandlerFromEndpoint,
+	model.RegisterWorkerServiceHandlerFromEndpoint,
+	model.RegisterSecretServiceHandlerFromEndpoint,
+}
+
+// NewServer returns an HTTP server translating REST/JSON requests into calls to the gRPC server at grpcAddr.
+// Going through the gRPC server keeps authentication, metrics and tracing in one place.
+// The connection to the gRPC server is closed when ctx is cancelled. With docs it also serves the OpenAPI document and
+// a Swagger UI page.
+func NewServer(ctx context.Context, addr, grpcAddr string, docs bool, opts ...grpc.DialOption) (*http.Server, error) {
+	mux := runtime.NewServeMux(
+		// Use the proto field names in JSON, like the .proto files do
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true}),
+		// Pass the request ID on in both directions under the same name as in gRPC
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if strings.EqualFold(key, logging.RequestIDHeader) {
+				return logging.RequestIDHeader, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			if key == logging.RequestIDHeader {
+				return key, true
+			}
+			return runtime.MetadataHeaderPrefix + key, true
+		}),
+	)
+	for _, register := range registerFuncs {
+		if err := register(ctx, mux, grpcAddr, opts); err != nil {
+			return nil, err
+		}
+	}
+	handler := rejectQueryMasks(mux)
+	if docs {
+		return &http.Server{Addr: addr, Handler: withDocs(handler)}, nil
+	}
+	return &http.Server{Addr: addr, Handler: handler}, nil
+}
+
+// rejectQueryMasks fails PATCH requests with an update_mask query parameter. The gateway builds the update mask of a
+// PATCH from the fields of its body and would add the paths of the parameter to it, updating fields the caller didn't
+// name in it.
+func rejectQueryMasks(mux *runtime.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			if _, ok := r.URL.Query()["update_mask"]; ok {
+				_, outbound := runtime.MarshalerForRequest(mux, r)
+				err := status.Error(codes.InvalidArgument, "The update mask of a PATCH is the set of fields in its body, the update_mask query parameter isn't supported")
+				runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
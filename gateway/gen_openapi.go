@@ -0,0 +1,62 @@
+// +build ignore
+
+// gen_openapi turns the OpenAPI document protoc-gen-swagger generated from the proto files into openapi_spec.go, so
+// the gateway can serve it without reading files at runtime.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+)
+
+// source is the document generated from the proto files
+const source = "../model/schedulytics.swagger.json"
+
+func main() {
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		fail(err)
+	}
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		fail(err)
+	}
+	// protoc-gen-swagger names the merged document after the first proto file and doesn't know about bearer tokens
+	doc["info"] = raw(map[string]string{"title": "Schedulytics API", "version": "v1"})
+	doc["securityDefinitions"] = raw(map[string]interface{}{
+		"bearer": map[string]string{
+			"type":        "apiKey",
+			"name":        "Authorization",
+			"in":          "header",
+			"description": "A JWT as Bearer <token>, required when authentication is enabled",
+		},
+	})
+	doc["security"] = raw([]map[string][]string{{"bearer": {}}})
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fail(err)
+	}
+	out := "// Code generated by gen_openapi.go from " + source + ". DO NOT EDIT.\n\n" +
+		"package gateway\n\n" +
+		"// openAPISpec is the OpenAPI v2 document of the REST API\n" +
+		"const openAPISpec = " + strconv.Quote(string(spec)+"\n") + "\n"
+	if err := ioutil.WriteFile("openapi_spec.go", []byte(out), 0644); err != nil {
+		fail(err)
+	}
+}
+
+func raw(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fail(err)
+	}
+	return data
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -3,7 +3,29 @@ module github.com/noltedennis/schedulytics-backend
 go 1.14
 
 require (
-	github.com/golang/protobuf v1.4.0
+	github.com/BurntSushi/toml v0.3.1
+	github.com/go-redis/redis/v8 v8.3.3
+	github.com/golang-jwt/jwt/v4 v4.2.0
+	github.com/golang/protobuf v1.4.2
+	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
+	github.com/grpc-ecosystem/grpc-gateway v1.14.6
+	github.com/jackc/pgconn v1.6.4
+	github.com/jackc/pgx/v4 v4.8.1
+	github.com/mattn/go-sqlite3 v1.14.10
+	github.com/nats-io/nats-server/v2 v2.2.0 // indirect
+	github.com/nats-io/nats.go v1.11.0
+	github.com/prometheus/client_golang v1.6.0
+	github.com/robfig/cron/v3 v3.0.1
+	github.com/segmentio/kafka-go v0.4.8
 	go.mongodb.org/mongo-driver v1.3.3
-	google.golang.org/grpc v1.29.1
+	go.opentelemetry.io/otel v0.13.0
+	go.opentelemetry.io/otel/exporters/otlp v0.13.0
+	go.opentelemetry.io/otel/exporters/trace/jaeger v0.13.0
+	go.opentelemetry.io/otel/sdk v0.13.0
+	go.uber.org/zap v1.16.0
+	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
+	google.golang.org/genproto v0.0.0-20200904004341-0bd0a958aa1d
+	google.golang.org/grpc v1.32.0
+	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,112 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// PingFunc checks that the storage backend can be reached
+type PingFunc func(ctx context.Context) error
+
+// Checker serves the grpc.health.v1.Health service and keeps the status of storage backed services up to date
+type Checker struct {
+	srv      *health.Server
+	ping     PingFunc
+	interval time.Duration
+	// services depend on the storage backend and are NOT_SERVING while it is unreachable
+	services []string
+	logger   *zap.Logger
+
+	mu sync.RWMutex
+	// last is the error of the last ping, errPending before the first one
+	last error
+}
+
+// New creates a Checker that pings the storage backend every interval. The given services, as well as the overall
+// server status (empty service name), follow the outcome of the ping.
+func New(ping PingFunc, interval time.Duration, logger *zap.Logger, services ...string) *Checker {
+	c := &Checker{
+		srv:      health.NewServer(),
+		ping:     ping,
+		interval: interval,
+		services: append([]string{""}, services...),
+		logger:   logger,
+		last:     errPending,
+	}
+	// Nothing is known before the first ping
+	for _, service := range c.services {
+		c.srv.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return c
+}
+
+// Register adds the health service to s
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.srv)
+}
+
+// SetServing marks a service that doesn't depend on the storage backend as serving
+func (c *Checker) SetServing(service string) {
+	c.srv.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+}
+
+// Run pings the storage backend until ctx is cancelled and updates the status of the dependent services
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	serving := false
+	for {
+		ok := c.check(ctx)
+		metrics.SetStorageUp(ok)
+		if ok != serving {
+			serving = ok
+			status := healthpb.HealthCheckResponse_NOT_SERVING
+			if ok {
+				status = healthpb.HealthCheckResponse_SERVING
+			}
+			c.logger.Info("Storage health changed", zap.Stringer("status", status))
+			for _, service := range c.services {
+				c.srv.SetServingStatus(service, status)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown sets every service to NOT_SERVING and ignores all later updates
+func (c *Checker) Shutdown() {
+	c.srv.Shutdown()
+}
+
+// Check returns the error of the last ping of the storage backend, it is the storage check of the readiness probe
+func (c *Checker) Check(context.Context) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.last
+}
+
+// check reports whether the storage backend answered within the check interval
+func (c *Checker) check(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+	err := c.ping(ctx)
+	c.mu.Lock()
+	c.last = err
+	c.mu.Unlock()
+	if err != nil {
+		c.logger.Warn("Storage health check failed", zap.Error(err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CheckFunc reports why the server isn't ready, nil when it is
+type CheckFunc func(ctx context.Context) error
+
+// probeTimeout limits how long the checks of a single readiness probe may take
+const probeTimeout = 5 * time.Second
+
+// errPending is reported by the checks that weren't set yet, the server is still starting
+var errPending = errors.New("not started yet")
+
+// errShutdown is reported by every check once the server is stopping
+var errShutdown = errors.New("shutting down")
+
+// Result is the outcome of a single check in the body of /readyz
+type Result struct {
+	// Status is ok or failing
+	Status string `json:"status"`
+	// Error tells why a failing check failed
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the body of /healthz and /readyz
+type Report struct {
+	// Status is ok or failing, /readyz is failing when any of its checks is
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Probes serves the liveness and readiness of the server over HTTP for load balancers that can't check gRPC health.
+// /healthz answers while the process runs, /readyz runs the checks and fails with 503 when any of them does.
+type Probes struct {
+	mu       sync.RWMutex
+	names    []string
+	checks   map[string]CheckFunc
+	shutdown bool
+	mux      *http.ServeMux
+}
+
+// NewProbes creates probes with the checks of the given names, every check fails until it is set, so the server isn't
+// ready before everything it checks started
+func NewProbes(names ...string) *Probes {
+	p := &Probes{names: names, checks: map[string]CheckFunc{}, mux: http.NewServeMux()}
+	p.mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, &Report{Status: "ok"})
+	})
+	p.mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		defer cancel()
+		report := p.check(ctx)
+		code := http.StatusOK
+		if report.Status != "ok" {
+			code = http.StatusServiceUnavailable
+		}
+		writeReport(w, code, report)
+	})
+	return p
+}
+
+// Set sets the check with name, it must be one of the names the probes were created with
+func (p *Probes) Set(name string, check CheckFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checks[name] = check
+}
+
+// Passed is the check of things that were done once, like migrations
+func Passed(context.Context) error {
+	return nil
+}
+
+// Shutdown fails every readiness probe from now on, so load balancers stop sending traffic while the server drains
+func (p *Probes) Shutdown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shutdown = true
+}
+
+// Handle serves handler for pattern next to the probes, like the debug endpoints of the admin port. It can be called
+// while the server already runs.
+func (p *Probes) Handle(pattern string, handler http.Handler) {
+	p.mux.Handle(pattern, handler)
+}
+
+// NewServer creates the HTTP server of the probes listening on addr
+func (p *Probes) NewServer(addr string) *http.Server {
+	return &http.Server{Addr: addr, Handler: p.mux}
+}
+
+// check runs every check and sums them up
+func (p *Probes) check(ctx context.Context) *Report {
+	p.mu.RLock()
+	names := p.names
+	checks := make(map[string]CheckFunc, len(p.checks))
+	for name, check := range p.checks {
+		checks[name] = check
+	}
+	shutdown := p.shutdown
+	p.mu.RUnlock()
+
+	report := &Report{Status: "ok", Checks: make(map[string]Result, len(names))}
+	for _, name := range names {
+		err := errPending
+		if shutdown {
+			err = errShutdown
+		} else if check, ok := checks[name]; ok {
+			err = check(ctx)
+		}
+		if err != nil {
+			report.Status = "failing"
+			report.Checks[name] = Result{Status: "failing", Error: err.Error()}
+		} else {
+			report.Checks[name] = Result{Status: "ok"}
+		}
+	}
+	return report
+}
+
+func writeReport(w http.ResponseWriter, code int, report *Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,196 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Operator tells how a requirement compares the value of its label
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Maximum lengths of the parts of keys and of values, they are the same as in Kubernetes
+const (
+	maxNameLength   = 63
+	maxPrefixLength = 253
+)
+
+var (
+	// namePattern matches the name of a key and non-empty values
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	// prefixPattern matches the optional DNS subdomain prefix of a key
+	prefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	// setPattern matches the in and notin requirements like env in (dev, staging)
+	setPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// ValidateKey checks a label key like Kubernetes does, an optional DNS subdomain prefix and a slash followed by a
+// name of at most 63 letters, digits, - _ and . that starts and ends with a letter or digit
+func ValidateKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) > maxPrefixLength || !prefixPattern.MatchString(prefix) {
+			return fmt.Errorf("prefix of key %q must be a DNS subdomain of at most %d characters", key, maxPrefixLength)
+		}
+	}
+	if len(name) > maxNameLength || !namePattern.MatchString(name) {
+		return fmt.Errorf("key %q must have a name of at most %d letters, digits, - _ and . that starts and ends with a letter or digit", key, maxNameLength)
+	}
+	return nil
+}
+
+// ValidateValue checks a label value, it is empty or follows the rules of the name of a key
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxNameLength || !namePattern.MatchString(value) {
+		return fmt.Errorf("value %q must be at most %d letters, digits, - _ and . that start and end with a letter or digit", value, maxNameLength)
+	}
+	return nil
+}
+
+// Requirement is a single condition of a selector, Values holds one value for Equals and NotEquals, none for Exists
+// and DoesNotExist
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Matches reports whether labels fulfill the requirement. Like in Kubernetes NotEquals and NotIn also match label
+// sets without the key.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case Equals, In:
+		return ok && contains(r.Values, value)
+	case NotEquals, NotIn:
+		return !ok || !contains(r.Values, value)
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// contains reports whether values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Selector selects the label sets fulfilling all of its requirements, the empty selector selects all of them
+type Selector []Requirement
+
+// Matches reports whether the selector selects labels
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse reads a comma separated list of Kubernetes style requirements like
+// team=data,env!=prod,tier in (web, api),region notin (eu),canary,!legacy. Keys and values are validated.
+func Parse(s string) (Selector, error) {
+	selector := Selector{}
+	for _, part := range split(s) {
+		if part = strings.TrimSpace(part); part == "" {
+			return nil, fmt.Errorf("empty requirement in selector %q", s)
+		}
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateKey(r.Key); err != nil {
+			return nil, err
+		}
+		for _, value := range r.Values {
+			if err := ValidateValue(value); err != nil {
+				return nil, err
+			}
+		}
+		selector = append(selector, r)
+	}
+	return selector, nil
+}
+
+// split splits a selector at the commas that aren't within the parentheses of a set, an empty selector has no parts
+func split(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := []string{}
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseRequirement reads a single requirement without validating its key and values
+func parseRequirement(s string) (Requirement, error) {
+	if m := setPattern.FindStringSubmatch(s); m != nil {
+		values := []string{}
+		for _, value := range strings.Split(m[3], ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+		return Requirement{Key: m[1], Operator: Operator(m[2]), Values: values}, nil
+	}
+	if strings.HasPrefix(s, "!") {
+		return Requirement{Key: strings.TrimSpace(s[1:]), Operator: DoesNotExist}, nil
+	}
+	for _, op := range []struct {
+		token    string
+		operator Operator
+	}{{"!=", NotEquals}, {"==", Equals}, {"=", Equals}} {
+		if i := strings.Index(s, op.token); i >= 0 {
+			key, value := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(op.token):])
+			return Requirement{Key: key, Operator: op.operator, Values: []string{value}}, nil
+		}
+	}
+	if strings.ContainsAny(s, " ()") {
+		return Requirement{}, fmt.Errorf("invalid requirement %q", s)
+	}
+	return Requirement{Key: s, Operator: Exists}, nil
+}
+
+// Keys returns the keys of labels in sorted order
+func Keys(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package labels
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Selector
+	}{
+		{"empty", "", Selector{}},
+		{"blank", "  ", Selector{}},
+		{"equals", "team=data", Selector{{Key: "team", Operator: Equals, Values: []string{"data"}}}},
+		{"double equals", "team==data", Selector{{Key: "team", Operator: Equals, Values: []string{"data"}}}},
+		{"not equals", "env!=prod", Selector{{Key: "env", Operator: NotEquals, Values: []string{"prod"}}}},
+		{"empty value", "env=", Selector{{Key: "env", Operator: Equals, Values: []string{""}}}},
+		{"in", "tier in (web, api)", Selector{{Key: "tier", Operator: In, Values: []string{"web", "api"}}}},
+		{"notin", "region notin (eu)", Selector{{Key: "region", Operator: NotIn, Values: []string{"eu"}}}},
+		{"exists", "canary", Selector{{Key: "canary", Operator: Exists}}},
+		{"does not exist", "!legacy", Selector{{Key: "legacy", Operator: DoesNotExist}}},
+		{"prefixed key", "example.com/team=data", Selector{{Key: "example.com/team", Operator: Equals, Values: []string{"data"}}}},
+		{"spaces around operators", " team = data , ! legacy ", Selector{
+			{Key: "team", Operator: Equals, Values: []string{"data"}},
+			{Key: "legacy", Operator: DoesNotExist},
+		}},
+		{"several", "team=data,env!=prod,tier in (web, api),region notin (eu),canary,!legacy", Selector{
+			{Key: "team", Operator: Equals, Values: []string{"data"}},
+			{Key: "env", Operator: NotEquals, Values: []string{"prod"}},
+			{Key: "tier", Operator: In, Values: []string{"web", "api"}},
+			{Key: "region", Operator: NotIn, Values: []string{"eu"}},
+			{Key: "canary", Operator: Exists},
+			{Key: "legacy", Operator: DoesNotExist},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.selector, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{"empty requirement", "team=data,,env=prod", "empty requirement"},
+		{"trailing comma", "team=data,", "empty requirement"},
+		{"invalid requirement", "tier in web", "invalid requirement"},
+		{"unclosed set", "tier in (web", "invalid requirement"},
+		{"invalid key", "-team=data", "key"},
+		{"key too long", strings.Repeat("a", 64) + "=data", "key"},
+		{"invalid prefix", "Example.com/team=data", "prefix"},
+		{"invalid value", "team=-data", "value"},
+		{"invalid value in set", "tier in (web, -api)", "value"},
+		{"empty key", "=data", "key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.selector)
+			if err == nil {
+				t.Fatalf("Parse(%q) succeeded, want an error", tt.selector)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) failed with %q, want it to mention %q", tt.selector, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirementMatches(t *testing.T) {
+	labels := map[string]string{"team": "data", "env": "prod", "empty": ""}
+	tests := []struct {
+		name        string
+		requirement Requirement
+		want        bool
+	}{
+		{"equals", Requirement{Key: "team", Operator: Equals, Values: []string{"data"}}, true},
+		{"equals other value", Requirement{Key: "team", Operator: Equals, Values: []string{"web"}}, false},
+		{"equals missing key", Requirement{Key: "tier", Operator: Equals, Values: []string{"web"}}, false},
+		{"equals empty value", Requirement{Key: "empty", Operator: Equals, Values: []string{""}}, true},
+		{"equals empty value of missing key", Requirement{Key: "tier", Operator: Equals, Values: []string{""}}, false},
+		{"not equals", Requirement{Key: "env", Operator: NotEquals, Values: []string{"dev"}}, true},
+		{"not equals same value", Requirement{Key: "env", Operator: NotEquals, Values: []string{"prod"}}, false},
+		{"not equals missing key", Requirement{Key: "tier", Operator: NotEquals, Values: []string{"web"}}, true},
+		{"in", Requirement{Key: "env", Operator: In, Values: []string{"dev", "prod"}}, true},
+		{"in other values", Requirement{Key: "env", Operator: In, Values: []string{"dev", "staging"}}, false},
+		{"in missing key", Requirement{Key: "tier", Operator: In, Values: []string{"web"}}, false},
+		{"notin", Requirement{Key: "env", Operator: NotIn, Values: []string{"dev", "staging"}}, true},
+		{"notin same value", Requirement{Key: "env", Operator: NotIn, Values: []string{"dev", "prod"}}, false},
+		{"notin missing key", Requirement{Key: "tier", Operator: NotIn, Values: []string{"web"}}, true},
+		{"exists", Requirement{Key: "team", Operator: Exists}, true},
+		{"exists with empty value", Requirement{Key: "empty", Operator: Exists}, true},
+		{"exists missing key", Requirement{Key: "tier", Operator: Exists}, false},
+		{"does not exist", Requirement{Key: "tier", Operator: DoesNotExist}, true},
+		{"does not exist present key", Requirement{Key: "team", Operator: DoesNotExist}, false},
+		{"unknown operator", Requirement{Key: "team", Operator: "~", Values: []string{"data"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.requirement.Matches(labels); got != tt.want {
+				t.Errorf("%+v.Matches(%v) = %v, want %v", tt.requirement, labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	tests := []struct {
+		selector string
+		labels   map[string]string
+		want     bool
+	}{
+		{"", nil, true},
+		{"", map[string]string{"team": "data"}, true},
+		{"team=data,env!=prod", map[string]string{"team": "data", "env": "dev"}, true},
+		{"team=data,env!=prod", map[string]string{"team": "data", "env": "prod"}, false},
+		{"team=data,env!=prod", map[string]string{"team": "data"}, true},
+		{"tier in (web, api),!legacy", map[string]string{"tier": "api"}, true},
+		{"tier in (web, api),!legacy", map[string]string{"tier": "api", "legacy": "true"}, false},
+	}
+	for _, tt := range tests {
+		selector, err := Parse(tt.selector)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.selector, err)
+		}
+		if got := selector.Matches(tt.labels); got != tt.want {
+			t.Errorf("Parse(%q).Matches(%v) = %v, want %v", tt.selector, tt.labels, got, tt.want)
+		}
+	}
+}
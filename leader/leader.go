@@ -0,0 +1,126 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// releaseTimeout limits giving up the lease on shutdown
+const releaseTimeout = 5 * time.Second
+
+// Store is the part of the storage the elector keeps its lease in
+type Store interface {
+	// AcquireLease takes the lease called name for holder until now+ttl and reports whether it succeeded.
+	// It succeeds when nobody holds the lease, holder already holds it or the lease of another holder expired.
+	AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error)
+	// ReleaseLease gives up the lease called name if holder holds it
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// Elector makes sure only one of several replicas leads at a time. The leader holds a lease in the store and
+// renews it well before it expires, when the leader dies another replica takes the lease over once it expired.
+// Clocks of the replicas must not drift apart by more than a fraction of the TTL.
+type Elector struct {
+	store  Store
+	name   string
+	id     string
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+// New creates an Elector competing for the lease called name, leases expire ttl after they were last renewed
+func New(store Store, name string, ttl time.Duration, logger *zap.Logger) *Elector {
+	return &Elector{
+		store:  store,
+		name:   name,
+		id:     newID(),
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+// newID identifies this replica, the start time tells apart restarts of containers with the same host name and pid
+func newID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%x", host, os.Getpid(), time.Now().UnixNano())
+}
+
+// ID returns the holder name this replica uses for the lease
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// Run blocks until ctx is cancelled. Whenever this replica becomes the leader it calls lead with a context that is
+// cancelled as soon as leadership is lost, and waits for lead to return before competing again.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	e.logger.Info("Leader election started", zap.String("lease", e.name), zap.String("id", e.id), zap.Duration("ttl", e.ttl))
+	// Renew three times per TTL, so a single failed renewal doesn't cost the lease
+	interval := e.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var stopLeading context.CancelFunc
+	var done chan struct{}
+	var renewed time.Time
+	stepDown := func() {
+		stopLeading()
+		<-done
+		stopLeading = nil
+		e.logger.Warn("Lost leadership", zap.String("lease", e.name))
+	}
+	for {
+		now := time.Now().UTC()
+		acquired, err := e.store.AcquireLease(ctx, e.name, e.id, now, e.ttl)
+		if err != nil && ctx.Err() == nil {
+			e.logger.Error("Could not renew lease", zap.String("lease", e.name), zap.Error(err))
+		}
+		switch {
+		case acquired:
+			renewed = now
+			if stopLeading == nil {
+				e.logger.Info("Became leader", zap.String("lease", e.name))
+				var leadCtx context.Context
+				leadCtx, stopLeading = context.WithCancel(ctx)
+				done = make(chan struct{})
+				go func() {
+					defer close(done)
+					lead(leadCtx)
+				}()
+			}
+		case stopLeading != nil && err == nil:
+			// Another replica took the lease over
+			stepDown()
+		case stopLeading != nil && now.Sub(renewed) > e.ttl-interval:
+			// Step down before the lease expires, so two replicas never lead at the same time
+			stepDown()
+		}
+
+		select {
+		case <-ctx.Done():
+			if stopLeading != nil {
+				stopLeading()
+				<-done
+				e.release()
+			}
+			e.logger.Info("Leader election stopped", zap.String("lease", e.name))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// release gives up the lease so another replica can take over right away instead of waiting for it to expire
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := e.store.ReleaseLease(ctx, e.name, e.id); err != nil {
+		e.logger.Warn("Could not release lease", zap.String("lease", e.name), zap.Error(err))
+	}
+}
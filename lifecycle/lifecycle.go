@@ -0,0 +1,135 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// StartFunc runs a subsystem until ctx is cancelled. Subsystems with goroutines of their own, like the executor, may
+// return once they started them and wait for them in their StopFunc. An error stops the server.
+type StartFunc func(ctx context.Context) error
+
+// StopFunc waits for a subsystem to finish what it still does after its context was cancelled, like storing pending
+// deliveries
+type StopFunc func()
+
+// Run adapts the Run methods of subsystems that can't fail
+func Run(run func(ctx context.Context)) StartFunc {
+	return func(ctx context.Context) error {
+		run(ctx)
+		return nil
+	}
+}
+
+type subsystem struct {
+	name   string
+	start  StartFunc
+	stop   StopFunc
+	cancel context.CancelFunc
+	// done is closed once start returned
+	done chan struct{}
+}
+
+// Manager starts the background subsystems of the server in the order they were registered and stops them in the
+// reverse order, so subsystems are registered after the ones they hand work to. The scheduler stops before the
+// executor it fires runs on, which stops before the dispatcher its finished runs are delivered by.
+type Manager struct {
+	timeout    time.Duration
+	logger     *zap.Logger
+	subsystems []*subsystem
+	group      *errgroup.Group
+	ctx        context.Context
+}
+
+// New creates a manager that waits at most timeout for all subsystems to stop, 0 waits as long as they take
+func New(timeout time.Duration, logger *zap.Logger) *Manager {
+	return &Manager{timeout: timeout, logger: logger}
+}
+
+// Register adds the subsystem called name, start and stop may be nil for subsystems without a loop or without work
+// left to wait for. Subsystems must be registered before the manager starts.
+func (m *Manager) Register(name string, start StartFunc, stop StopFunc) {
+	m.subsystems = append(m.subsystems, &subsystem{name: name, start: start, stop: stop})
+}
+
+// Start runs every subsystem in a goroutine of its own. Once one of them fails the contexts of all of them are
+// cancelled and Done is closed.
+func (m *Manager) Start(ctx context.Context) {
+	m.group, m.ctx = errgroup.WithContext(ctx)
+	for _, s := range m.subsystems {
+		s := s
+		var subsystemCtx context.Context
+		subsystemCtx, s.cancel = context.WithCancel(m.ctx)
+		s.done = make(chan struct{})
+		if s.start == nil {
+			close(s.done)
+			continue
+		}
+		m.group.Go(func() error {
+			defer close(s.done)
+			if err := s.start(subsystemCtx); err != nil {
+				m.logger.Error("Background subsystem failed", zap.String("subsystem", s.name), zap.Error(err))
+				return fmt.Errorf("%s: %v", s.name, err)
+			}
+			return nil
+		})
+	}
+	m.logger.Info("Started background subsystems", zap.Strings("subsystems", names(m.subsystems)))
+}
+
+// Done is closed once a subsystem failed, the server should stop then
+func (m *Manager) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+// Stop stops the subsystems one after the other in the reverse order they were registered. It cancels the context of
+// each, waits for its StartFunc to return and then for its StopFunc. Once the timeout passed the remaining subsystems
+// are cancelled at once and left behind, the error names them. Subsystems that failed were logged when they did.
+func (m *Manager) Stop() error {
+	started := time.Now()
+	var deadline <-chan time.Time
+	if m.timeout > 0 {
+		timer := time.NewTimer(m.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	for i := len(m.subsystems) - 1; i >= 0; i-- {
+		s := m.subsystems[i]
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			s.cancel()
+			<-s.done
+			if s.stop != nil {
+				s.stop()
+			}
+		}()
+		select {
+		case <-stopped:
+			m.logger.Debug("Stopped background subsystem", zap.String("subsystem", s.name))
+		case <-deadline:
+			left := m.subsystems[:i+1]
+			for _, s := range left {
+				s.cancel()
+			}
+			return fmt.Errorf("background subsystems %s did not stop within %v", strings.Join(names(left), ", "), m.timeout)
+		}
+	}
+	// Every subsystem returned already, the group only cancels its context
+	m.group.Wait()
+	m.logger.Info("Stopped background subsystems", zap.Duration("took", time.Since(started)))
+	return nil
+}
+
+func names(subsystems []*subsystem) []string {
+	names := make([]string, len(subsystems))
+	for i, s := range subsystems {
+		names[i] = s.name
+	}
+	return names
+}
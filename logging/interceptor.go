@@ -0,0 +1,150 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the metadata key the request ID is read from and returned in
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength is the length of the longest request ID taken from a client, longer ones are replaced
+const maxRequestIDLength = 128
+
+// UnaryServerInterceptor logs every unary RPC once it finished and adds its request ID to the error
+func UnaryServerInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, requestLogger := withRequestLogger(ctx, logger, info.FullMethod)
+		resp, err := handler(ctx, req)
+		logCall(requestLogger, start, err)
+		return resp, withRequestInfo(err, RequestIDFromContext(ctx))
+	}
+}
+
+// StreamServerInterceptor logs every streaming RPC once the stream ended and adds its request ID to the error
+func StreamServerInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, requestLogger := withRequestLogger(ss.Context(), logger, info.FullMethod)
+		err := handler(srv, &loggedStream{ServerStream: ss, ctx: ctx})
+		logCall(requestLogger, start, err)
+		return withRequestInfo(err, RequestIDFromContext(ctx))
+	}
+}
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID of the call of ctx, empty outside of calls
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// withRequestInfo adds a google.rpc.RequestInfo detail with requestID to err, keeping its code, message and details
+func withRequestInfo(err error, requestID string) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	withID, detailErr := st.WithDetails(&errdetails.RequestInfo{RequestId: requestID})
+	if detailErr != nil {
+		return err
+	}
+	return withID.Err()
+}
+
+// loggedStream replaces the context of a stream with one carrying the request logger
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+// withRequestLogger attaches the request ID and a logger with the fields of the call to ctx. The request ID sent by
+// the client is kept, so calls can be followed across services, otherwise a new one is generated. It is sent back in
+// the response header and trailer, the header is lost when a call fails before sending it.
+func withRequestLogger(ctx context.Context, logger *zap.Logger, fullMethod string) (context.Context, *zap.Logger) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 && validRequestID(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+	grpc.SetTrailer(ctx, metadata.Pairs(RequestIDHeader, requestID))
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+
+	fields := []zap.Field{zap.String("method", fullMethod), zap.String("request_id", requestID)}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	requestLogger := logger.With(fields...)
+	return NewContext(ctx, requestLogger), requestLogger
+}
+
+// logCall writes the entry of a finished call, its level depends on the status code
+func logCall(logger *zap.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{zap.Duration("latency", time.Since(start)), zap.String("code", code.String())}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+	if entry := logger.Check(codeLevel(code), "Finished call"); entry != nil {
+		entry.Write(fields...)
+	}
+}
+
+// codeLevel returns the level calls with this status code are logged at. Mistakes of the client are
+// expected and logged at info, codes hinting at problems of the server at warn or error.
+func codeLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return zapcore.InfoLevel
+	case codes.Unknown, codes.Unimplemented, codes.Internal, codes.DataLoss:
+		return zapcore.ErrorLevel
+	}
+	return zapcore.WarnLevel
+}
+
+// validRequestID reports whether a request ID sent by a client is short and printable enough to be logged and returned
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random version 4 UUID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b)
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
@@ -0,0 +1,176 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Subsystems groups the named loggers of the server, the level of a subsystem applies to all of its loggers. Loggers
+// of no subsystem, like the unnamed one, always log at the global level.
+var Subsystems = map[string][]string{
+	"grpc":          {"grpc"},
+	"scheduler":     {"scheduler", "leader", "executor", "workers", "sla", "anomaly", "purger", "janitor"},
+	"storage":       {"storage", "healthcheck", "cache", "backup"},
+	"notifications": {"webhook", "notify", "events", "report"},
+}
+
+// subsystemOf maps the names of loggers to their subsystem
+var subsystemOf = func() map[string]string {
+	m := map[string]string{}
+	for subsystem, loggers := range Subsystems {
+		for _, logger := range loggers {
+			m[logger] = subsystem
+		}
+	}
+	return m
+}()
+
+// levelState is a snapshot of the levels, it is replaced as a whole on every change so entries are checked without a
+// lock
+type levelState struct {
+	global     zapcore.Level
+	subsystems map[string]zapcore.Level
+	// min is the lowest of all levels, entries below it are dropped before their logger is looked at
+	min zapcore.Level
+}
+
+// Levels are the levels of the logger of New, a global one and the ones set for single subsystems. They can be
+// changed while the logger is used.
+type Levels struct {
+	// mu serializes changes, reads load the state
+	mu    sync.Mutex
+	state atomic.Value
+}
+
+func newLevels(global zapcore.Level) *Levels {
+	l := &Levels{}
+	l.state.Store(&levelState{global: global, subsystems: map[string]zapcore.Level{}, min: global})
+	return l
+}
+
+func (l *Levels) load() *levelState {
+	return l.state.Load().(*levelState)
+}
+
+// Global returns the level of the loggers of subsystems without a level of their own
+func (l *Levels) Global() zapcore.Level {
+	return l.load().global
+}
+
+// SetGlobal sets the level of the loggers of subsystems without a level of their own
+func (l *Levels) SetGlobal(level zapcore.Level) {
+	l.update(func(s *levelState) {
+		s.global = level
+	})
+}
+
+// Level returns the level of subsystem and whether it was set for the subsystem, the global level otherwise
+func (l *Levels) Level(subsystem string) (zapcore.Level, bool) {
+	s := l.load()
+	if level, ok := s.subsystems[subsystem]; ok {
+		return level, true
+	}
+	return s.global, false
+}
+
+// SetLevel sets the level of the loggers of subsystem, which must be one of Subsystems
+func (l *Levels) SetLevel(subsystem string, level zapcore.Level) error {
+	if err := checkSubsystem(subsystem); err != nil {
+		return err
+	}
+	l.update(func(s *levelState) {
+		s.subsystems[subsystem] = level
+	})
+	return nil
+}
+
+// ResetLevel lets the loggers of subsystem log at the global level again
+func (l *Levels) ResetLevel(subsystem string) error {
+	if err := checkSubsystem(subsystem); err != nil {
+		return err
+	}
+	l.update(func(s *levelState) {
+		delete(s.subsystems, subsystem)
+	})
+	return nil
+}
+
+// update applies change to a copy of the state and stores it
+func (l *Levels) update(change func(s *levelState)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	old := l.load()
+	s := &levelState{global: old.global, subsystems: make(map[string]zapcore.Level, len(old.subsystems))}
+	for subsystem, level := range old.subsystems {
+		s.subsystems[subsystem] = level
+	}
+	change(s)
+	s.min = s.global
+	for _, level := range s.subsystems {
+		if level < s.min {
+			s.min = level
+		}
+	}
+	l.state.Store(s)
+}
+
+// enabled reports whether an entry of the logger with name at level is logged. Loggers named below a logger, like
+// leader.lease, belong to its subsystem.
+func (l *Levels) enabled(name string, level zapcore.Level) bool {
+	s := l.load()
+	if level < s.min {
+		return false
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	if subsystem, ok := subsystemOf[name]; ok {
+		if subsystemLevel, ok := s.subsystems[subsystem]; ok {
+			return level >= subsystemLevel
+		}
+	}
+	return level >= s.global
+}
+
+// SubsystemNames returns the names of Subsystems, sorted
+func SubsystemNames() []string {
+	names := make([]string, 0, len(Subsystems))
+	for name := range Subsystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkSubsystem(subsystem string) error {
+	if _, ok := Subsystems[subsystem]; !ok {
+		return fmt.Errorf("unknown subsystem %q, expected one of %s", subsystem, strings.Join(SubsystemNames(), ", "))
+	}
+	return nil
+}
+
+// levelCore drops the entries the levels don't enable, the core it wraps logs at every level
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return level >= c.levels.load().min
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabled(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
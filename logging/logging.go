@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Formats the logger can write entries in
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// New creates a logger writing entries of at least level to stderr in the given format. The levels it returns change
+// the level of the logger, or of the loggers of single subsystems, while it is used.
+func New(level, format string) (*zap.Logger, *Levels, error) {
+	var cfg zap.Config
+	switch format {
+	case FormatJSON:
+		cfg = zap.NewProductionConfig()
+	case FormatConsole:
+		cfg = zap.NewDevelopmentConfig()
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q", format)
+	}
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The levels decide what is logged, the core below them logs everything they let through
+	levels := newLevels(lvl)
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	cfg.EncoderConfig.TimeKey = "time"
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	// Stack traces are only useful for panics, which add their own
+	cfg.DisableStacktrace = true
+	logger, err := cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, levels: levels}
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+	return logger, levels, nil
+}
+
+// ParseLevel parses the names of levels like debug or warn
+func ParseLevel(level string) (zapcore.Level, error) {
+	lvl := zapcore.InfoLevel
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return lvl, fmt.Errorf("unknown log level %q", level)
+	}
+	return lvl, nil
+}
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying logger
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the request, with its request fields attached, or the global logger outside of requests
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// SizeHandler logs calls that failed because a message exceeded the size limits of the server. gRPC rejects requests
+// that are too large before the interceptors see them, so it watches the calls as a stats handler instead.
+type SizeHandler struct {
+	logger *zap.Logger
+}
+
+// NewSizeHandler creates a handler writing to logger, pass it to grpc.StatsHandler
+func NewSizeHandler(logger *zap.Logger) *SizeHandler {
+	return &SizeHandler{logger: logger}
+}
+
+type methodKey struct{}
+
+func (h *SizeHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, methodKey{}, info.FullMethodName)
+}
+
+func (h *SizeHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	end, ok := s.(*stats.End)
+	if !ok || status.Code(end.Error) != codes.ResourceExhausted {
+		return
+	}
+	// gRPC tells the limit and the size of the message only in the text of the error
+	msg := status.Convert(end.Error).Message()
+	var direction string
+	switch {
+	case strings.Contains(msg, "received message larger than max"):
+		direction = "received"
+	case strings.Contains(msg, "send message larger than max"):
+		direction = "sent"
+	default:
+		return
+	}
+	method, _ := ctx.Value(methodKey{}).(string)
+	h.logger.Warn("Message exceeded the size limit", zap.String("method", method), zap.String("direction", direction),
+		zap.String("error", msg))
+}
+
+func (h *SizeHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *SizeHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -2,112 +2,994 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/noltedennis/schedulytics-backend/anomaly"
+	"github.com/noltedennis/schedulytics-backend/audit"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/backup"
+	"github.com/noltedennis/schedulytics-backend/cache"
+	"github.com/noltedennis/schedulytics-backend/certs"
+	"github.com/noltedennis/schedulytics-backend/config"
+	"github.com/noltedennis/schedulytics-backend/debug"
+	"github.com/noltedennis/schedulytics-backend/events"
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/gateway"
+	"github.com/noltedennis/schedulytics-backend/healthcheck"
+	"github.com/noltedennis/schedulytics-backend/leader"
+	"github.com/noltedennis/schedulytics-backend/lifecycle"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/middleware"
 	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/notify"
+	"github.com/noltedennis/schedulytics-backend/quota"
+	"github.com/noltedennis/schedulytics-backend/ratelimit"
+	"github.com/noltedennis/schedulytics-backend/rbac"
+	"github.com/noltedennis/schedulytics-backend/recovery"
+	"github.com/noltedennis/schedulytics-backend/reload"
+	"github.com/noltedennis/schedulytics-backend/report"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/retention"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/secrets"
 	"github.com/noltedennis/schedulytics-backend/services"
+	"github.com/noltedennis/schedulytics-backend/sla"
+	"github.com/noltedennis/schedulytics-backend/team"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"github.com/noltedennis/schedulytics-backend/tracing"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+	"github.com/noltedennis/schedulytics-backend/worker"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/credentials"
+	// Registers the gzip compressor, so clients can send compressed requests and are answered compressed
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
-// Global variables for db connection , collection and context
-var db *mongo.Client
-var jobdb *mongo.Collection
-var mongoCtx context.Context
+// connectTimeout limits how long connecting to PostgreSQL or SQLite and creating the MongoDB collections may take on startup
+const connectTimeout = 30 * time.Second
+
+// configWatchInterval is how often the config file is checked for changes with CONFIG_WATCH_ENABLED
+const configWatchInterval = 10 * time.Second
 
 func main() {
-	// Configure 'log' package to give file name and line number on eg. log.Fatal
-	// Pipe flags to one another (log.LstdFLags = log.Ldate | log.Ltime)
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-
-	// Read in ENV values
-	pw, ok := os.LookupEnv("MONGO_PW")
-	if !ok {
-		fmt.Println("error: unable to find MONGO_PW in the environment")
+	// Read in configuration from flags and ENV values
+	cfg, err := config.Load(os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	} else if err != nil {
+		fmt.Println("error:", err)
 		os.Exit(1)
 	}
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Initialize MongoDb client
-	fmt.Println("Connecting to MongoDB...")
-	mongoURI := fmt.Sprintf("mongodb://%s:%s@%s", "schedulytics", pw, "mongodb:27017/schedulytics")
-	fmt.Println("connection string is:", mongoURI)
+	// Every component gets the logger passed in, the global one and the standard library's log package are only
+	// redirected for code we don't control
+	logger, levels, err := logging.New(cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+	zap.ReplaceGlobals(logger)
+	zap.RedirectStdLog(logger)
 
-	// non-nil empty context
-	mongoCtx = context.Background()
+	// Components that can change their settings while the server runs register them with the reloader, changes of
+	// all other settings wait for a restart
+	configReloader := reload.New(cfg, os.Args[1:], logger.Named("reload"))
+	// SIGHUP is handled once everything is set up, until then it waits here instead of stopping the process
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	configReloader.Handle(func(c *config.Config) error {
+		lvl, err := logging.ParseLevel(c.LogLevel)
+		if err != nil {
+			return err
+		}
+		levels.SetGlobal(lvl)
+		return nil
+	}, "log-level")
 
-	// Connect takes in a context and options, the connection URI is the only option we pass for now
-	db, err := mongo.Connect(mongoCtx, options.Client().ApplyURI(mongoURI))
-	// Handle potential errors
+	// Install the tracer provider, exporters are configured through the OTEL_* environment variables
+	shutdownTracing, err := tracing.Setup()
 	if err != nil {
-		log.Fatal(err)
+		logger.Fatal("Could not set up tracing", zap.Error(err))
 	}
 
-	// Check whether the connection was succesful by pinging the MongoDB server
-	err = db.Ping(mongoCtx, nil)
-	if err != nil {
-		log.Fatalf("Could not connect to MongoDB: %v\n", err)
-	} else {
-		fmt.Println("Connected to Mongodb")
+	// Serve the liveness and readiness probes right away, so long migrations don't get the server restarted. It isn't
+	// ready until every check was set.
+	probeNames := []string{"storage", "migrations"}
+	if cfg.SchedulerEnabled {
+		probeNames = append(probeNames, "scheduler")
 	}
+	probes := healthcheck.NewProbes(probeNames...)
+	var probeSrv *http.Server
+	if cfg.HealthAddr != "" {
+		probeSrv = probes.NewServer(cfg.HealthAddr)
+		go func() {
+			if err := probeSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve health probes", zap.Error(err))
+			}
+		}()
+		logger.Info("Serving health probes", zap.String("addr", cfg.HealthAddr))
+	}
+
+	// Connect to the storage backend, everything else only accesses it through the repositories
+	storageLogger := logger.Named("storage")
+	connectCtx, cancelConnect := context.WithTimeout(context.Background(), connectTimeout)
+	var jobRepo repository.JobRepository
+	var runRepo repository.RunRepository
+	var leaseRepo leader.Store
+	var auditRepo repository.AuditRepository
+	var webhookRepo repository.WebhookRepository
+	var userRepo repository.UserRepository
+	var teamRepo repository.TeamRepository
+	var templateRepo repository.TemplateRepository
+	var calendarRepo repository.CalendarRepository
+	var secretRepo repository.SecretRepository
+	var changeRepo repository.ChangeRepository
+	var revisionRepo repository.RevisionRepository
+	var maintenanceRepo repository.MaintenanceRepository
+	var environmentRepo repository.EnvironmentRepository
+	var retentionRepo repository.RunRetentionRepository
+	var slaRepo repository.SLARepository
+	var anomalyRepo repository.AnomalyRepository
+	var outboxRepo repository.OutboxRepository
+	var transactions repository.Transactor
+	var ping healthcheck.PingFunc
+	var closeStorage func()
+	switch cfg.StorageBackend {
+	case config.StorageMongoDB:
+		// Initialize MongoDb client
+		storageLogger.Info("Connecting to MongoDB", zap.String("uri", cfg.RedactedMongoURI()))
 
-	// Bind our collection to our global variable for use in other methods
-	jobdb := db.Database("schedulytics").Collection("job")
+		// Besides the connection URI we attach monitors for metrics and for logging lost connections, the driver
+		// reconnects on its own while the health checker reports the storage as unreachable
+		opts := options.Client().ApplyURI(cfg.MongoURI).SetMonitor(metrics.MongoMonitor()).SetPoolMonitor(&event.PoolMonitor{
+			Event: func(e *event.PoolEvent) {
+				if e.Type == event.PoolCleared {
+					storageLogger.Warn("Lost connection to MongoDB, reconnecting", zap.String("address", e.Address))
+				}
+			},
+		})
+		// MongoDB may still be starting, keep trying until MONGO_CONNECT_TIMEOUT passed
+		mongoCtx, cancelMongo := context.WithTimeout(context.Background(), cfg.MongoConnectTimeout)
+		db, err := repository.OpenMongo(mongoCtx, opts, func(attempt int, wait time.Duration, err error) {
+			storageLogger.Warn("Could not connect to MongoDB, retrying", zap.Int("attempt", attempt), zap.Duration("backoff", wait), zap.Error(err))
+		})
+		cancelMongo()
+		if err != nil {
+			storageLogger.Fatal("Could not connect to MongoDB", zap.Error(err))
+		}
+		storageLogger.Info("Connected to MongoDB")
 
-	// Start to listen on port 8010
-	fmt.Println("Starting server on port :8010...")
-	path := "0.0.0.0:8010"
-	lis, err := net.Listen("tcp", path)
+		// Bind our collections to the repositories
+		jobdb := db.Database(cfg.MongoDatabase).Collection(cfg.MongoCollection)
+		rundb := db.Database(cfg.MongoDatabase).Collection(cfg.MongoRunCollection)
+		// Tenants with a database of their own keep the same collections there, the lease is shared by all
+		tenantJobs := map[string]*mongo.Collection{}
+		tenantRuns := map[string]*mongo.Collection{}
+		tenantAudit := map[string]*mongo.Collection{}
+		tenantHooks := map[string]*mongo.Collection{}
+		tenantLetters := map[string]*mongo.Collection{}
+		tenantOutbox := map[string]*mongo.Collection{}
+		tenantTeams := map[string]*mongo.Collection{}
+		tenantTemplates := map[string]*mongo.Collection{}
+		tenantCalendars := map[string]*mongo.Collection{}
+		tenantSecrets := map[string]*mongo.Collection{}
+		tenantChanges := map[string]*mongo.Collection{}
+		tenantRevisions := map[string]*mongo.Collection{}
+		tenantAnomalies := map[string]*mongo.Collection{}
+		for tenantID, name := range cfg.TenantDatabases {
+			tenantJobs[tenantID] = db.Database(name).Collection(cfg.MongoCollection)
+			tenantRuns[tenantID] = db.Database(name).Collection(cfg.MongoRunCollection)
+			tenantAudit[tenantID] = db.Database(name).Collection(cfg.MongoAuditCollection)
+			tenantHooks[tenantID] = db.Database(name).Collection(cfg.MongoWebhookCollection)
+			tenantLetters[tenantID] = db.Database(name).Collection(cfg.MongoDeadLetterCollection)
+			tenantOutbox[tenantID] = db.Database(name).Collection(cfg.MongoOutboxCollection)
+			tenantTeams[tenantID] = db.Database(name).Collection(cfg.MongoTeamCollection)
+			tenantTemplates[tenantID] = db.Database(name).Collection(cfg.MongoTemplateCollection)
+			tenantCalendars[tenantID] = db.Database(name).Collection(cfg.MongoCalendarCollection)
+			tenantSecrets[tenantID] = db.Database(name).Collection(cfg.MongoSecretCollection)
+			tenantChanges[tenantID] = db.Database(name).Collection(cfg.MongoChangeCollection)
+			tenantRevisions[tenantID] = db.Database(name).Collection(cfg.MongoRevisionCollection)
+			tenantAnomalies[tenantID] = db.Database(name).Collection(cfg.MongoAnomalyCollection)
+		}
+		mongoJobs := repository.NewMongoJobRepository(jobdb, tenantJobs)
+		mongoJobs.BatchSize = int32(cfg.MongoCursorBatchSize)
+		mongoRuns := repository.NewMongoRunRepository(rundb, tenantRuns)
+		if cfg.MongoSkipMigrations {
+			storageLogger.Warn("Skipping MongoDB migrations, queries are slow and names aren't unique without their indexes")
+		} else {
+			// The migrations create the indexes the repositories rely on and backfill fields of old documents.
+			// Building indexes of large collections may take longer than connecting, so they run without a deadline.
+			migrations, err := repository.MigrateMongo(context.Background(), mongoJobs, mongoRuns, cfg.MongoMigrationCollection,
+				cfg.MongoMigrateDryRun, func(b repository.IndexBuild) {
+					fields := []zap.Field{zap.String("database", b.Database), zap.String("collection", b.Collection), zap.String("index", b.Index)}
+					if b.Done {
+						storageLogger.Info("Built MongoDB index", append(fields, zap.Duration("took", b.Took))...)
+					} else {
+						storageLogger.Info("Building MongoDB index", fields...)
+					}
+				})
+			for _, m := range migrations {
+				msg := "Applied MongoDB migration"
+				if cfg.MongoMigrateDryRun {
+					msg = "Would apply MongoDB migration"
+				}
+				storageLogger.Info(msg, zap.String("database", m.Database), zap.Int("version", m.Version), zap.String("description", m.Description),
+					zap.Int64("changed", m.Changed))
+			}
+			if err != nil {
+				storageLogger.Fatal("Could not migrate MongoDB", zap.Error(err))
+			}
+			if cfg.MongoMigrateDryRun {
+				storageLogger.Info("Dry run of the MongoDB migrations finished", zap.Int("pending", len(migrations)))
+				// The connect timeout may have passed while the migrations ran
+				ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+				defer cancel()
+				if err := db.Disconnect(ctx); err != nil {
+					storageLogger.Error("Could not disconnect from MongoDB", zap.Error(err))
+				}
+				cancelConnect()
+				return
+			}
+		}
+		jobRepo = mongoJobs
+		runRepo = mongoRuns
+		leaseRepo = repository.NewMongoLeaseRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoLeaseCollection))
+		auditRepo = repository.NewMongoAuditRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoAuditCollection), tenantAudit)
+		webhookRepo = repository.NewMongoWebhookRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoWebhookCollection),
+			db.Database(cfg.MongoDatabase).Collection(cfg.MongoDeadLetterCollection), tenantHooks, tenantLetters)
+		// Users stay in MONGO_DB for every tenant, API keys are looked up before the tenant of a call is known
+		userRepo = repository.NewMongoUserRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoUserCollection),
+			db.Database(cfg.MongoDatabase).Collection(cfg.MongoAPIKeyCollection))
+		teamRepo = repository.NewMongoTeamRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoTeamCollection), tenantTeams)
+		templateRepo = repository.NewMongoTemplateRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoTemplateCollection), tenantTemplates)
+		calendarRepo = repository.NewMongoCalendarRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoCalendarCollection), tenantCalendars)
+		secretRepo = repository.NewMongoSecretRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoSecretCollection), tenantSecrets)
+		changeRepo = repository.NewMongoChangeRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoChangeCollection), tenantChanges)
+		revisionRepo = repository.NewMongoRevisionRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoRevisionCollection), tenantRevisions)
+		// The maintenance mode is the same for all tenants
+		maintenanceRepo = repository.NewMongoMaintenanceRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoMaintenanceCollection))
+		// The scheduler finds the environments of all tenants in one collection
+		environmentRepo = repository.NewMongoEnvironmentRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoEnvironmentCollection))
+		// The janitor finds the policies of all tenants in one collection
+		retentionRepo = repository.NewMongoRunRetentionRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoRetentionCollection))
+		// Like the policies, the monitor finds the SLAs of all tenants in one collection
+		slaRepo = repository.NewMongoSLARepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoSLACollection),
+			db.Database(cfg.MongoDatabase).Collection(cfg.MongoSLABreachCollection))
+		anomalyRepo = repository.NewMongoAnomalyRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoAnomalyCollection), tenantAnomalies)
+		mongoOutbox := repository.NewMongoOutboxRepository(db.Database(cfg.MongoDatabase).Collection(cfg.MongoOutboxCollection), tenantOutbox)
+		if cfg.EventsBroker != "" {
+			if err := mongoOutbox.CreateCollections(connectCtx); err != nil {
+				storageLogger.Fatal("Could not create MongoDB collections", zap.Error(err))
+			}
+		}
+		outboxRepo = mongoOutbox
+		transactions = repository.NewMongoTransactor(db)
+		ping = func(ctx context.Context) error { return db.Ping(ctx, nil) }
+		closeStorage = func() {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+			defer cancel()
+			db.Disconnect(ctx)
+		}
+	case config.StoragePostgres:
+		storageLogger.Info("Connecting to PostgreSQL")
+		// OpenPostgres also migrates the schema to the version this build expects
+		pool, err := repository.OpenPostgres(connectCtx, cfg.PostgresURL)
+		if err != nil {
+			storageLogger.Fatal("Could not connect to PostgreSQL", zap.Error(err))
+		}
+		storageLogger.Info("Connected to PostgreSQL")
+		jobRepo = repository.NewPostgresJobRepository(pool)
+		runRepo = repository.NewPostgresRunRepository(pool)
+		leaseRepo = repository.NewPostgresLeaseRepository(pool)
+		auditRepo = repository.NewPostgresAuditRepository(pool)
+		webhookRepo = repository.NewPostgresWebhookRepository(pool)
+		userRepo = repository.NewPostgresUserRepository(pool)
+		teamRepo = repository.NewPostgresTeamRepository(pool)
+		templateRepo = repository.NewPostgresTemplateRepository(pool)
+		calendarRepo = repository.NewPostgresCalendarRepository(pool)
+		secretRepo = repository.NewPostgresSecretRepository(pool)
+		changeRepo = repository.NewPostgresChangeRepository(pool)
+		revisionRepo = repository.NewPostgresRevisionRepository(pool)
+		maintenanceRepo = repository.NewPostgresMaintenanceRepository(pool)
+		environmentRepo = repository.NewPostgresEnvironmentRepository(pool)
+		retentionRepo = repository.NewPostgresRunRetentionRepository(pool)
+		slaRepo = repository.NewPostgresSLARepository(pool)
+		anomalyRepo = repository.NewPostgresAnomalyRepository(pool)
+		outboxRepo = repository.NewPostgresOutboxRepository(pool)
+		transactions = repository.NewPostgresTransactor(pool)
+		ping = func(ctx context.Context) error {
+			_, err := pool.Exec(ctx, "SELECT 1")
+			return err
+		}
+		closeStorage = pool.Close
+	case config.StorageMemory:
+		// Nothing to connect to, everything stored is gone when the process exits
+		storageLogger.Warn("Keeping jobs and runs in memory, they are lost on restart")
+		jobRepo = repository.NewMemoryJobRepository()
+		runRepo = repository.NewMemoryRunRepository()
+		leaseRepo = repository.NewMemoryLeaseRepository()
+		auditRepo = repository.NewMemoryAuditRepository()
+		webhookRepo = repository.NewMemoryWebhookRepository()
+		userRepo = repository.NewMemoryUserRepository()
+		teamRepo = repository.NewMemoryTeamRepository()
+		templateRepo = repository.NewMemoryTemplateRepository()
+		calendarRepo = repository.NewMemoryCalendarRepository()
+		secretRepo = repository.NewMemorySecretRepository()
+		changeRepo = repository.NewMemoryChangeRepository()
+		revisionRepo = repository.NewMemoryRevisionRepository()
+		maintenanceRepo = repository.NewMemoryMaintenanceRepository()
+		environmentRepo = repository.NewMemoryEnvironmentRepository()
+		retentionRepo = repository.NewMemoryRunRetentionRepository()
+		slaRepo = repository.NewMemorySLARepository()
+		anomalyRepo = repository.NewMemoryAnomalyRepository()
+		outboxRepo = repository.NewMemoryOutboxRepository()
+		transactions = repository.NewMemoryTransactor()
+		ping = func(ctx context.Context) error { return nil }
+		closeStorage = func() {}
+	case config.StorageSQLite:
+		// OpenSQLite also migrates the schema to the version this build expects
+		db, err := repository.OpenSQLite(connectCtx, cfg.SQLitePath)
+		if err != nil {
+			storageLogger.Fatal("Could not open SQLite database", zap.String("path", cfg.SQLitePath), zap.Error(err))
+		}
+		storageLogger.Info("Opened SQLite database", zap.String("path", cfg.SQLitePath))
+		jobRepo = repository.NewSQLiteJobRepository(db)
+		runRepo = repository.NewSQLiteRunRepository(db)
+		leaseRepo = repository.NewSQLiteLeaseRepository(db)
+		auditRepo = repository.NewSQLiteAuditRepository(db)
+		webhookRepo = repository.NewSQLiteWebhookRepository(db)
+		userRepo = repository.NewSQLiteUserRepository(db)
+		teamRepo = repository.NewSQLiteTeamRepository(db)
+		templateRepo = repository.NewSQLiteTemplateRepository(db)
+		calendarRepo = repository.NewSQLiteCalendarRepository(db)
+		secretRepo = repository.NewSQLiteSecretRepository(db)
+		changeRepo = repository.NewSQLiteChangeRepository(db)
+		revisionRepo = repository.NewSQLiteRevisionRepository(db)
+		maintenanceRepo = repository.NewSQLiteMaintenanceRepository(db)
+		environmentRepo = repository.NewSQLiteEnvironmentRepository(db)
+		retentionRepo = repository.NewSQLiteRunRetentionRepository(db)
+		slaRepo = repository.NewSQLiteSLARepository(db)
+		anomalyRepo = repository.NewSQLiteAnomalyRepository(db)
+		outboxRepo = repository.NewSQLiteOutboxRepository(db)
+		transactions = repository.NewSQLiteTransactor(db)
+		ping = db.PingContext
+		closeStorage = func() { db.Close() }
+	}
+	cancelConnect()
+	// Every backend migrated its storage by now, unless the MongoDB migrations were skipped on purpose
+	probes.Set("migrations", healthcheck.Passed)
+
+	// ReadJob reads jobs from the cache, everything else from the storage backend. The cache has to drop every job
+	// that is changed, so everything gets the caching repository.
+	var jobCache *cache.Jobs
+	var cacheStore cache.Store
+	switch cfg.CacheBackend {
+	case cache.BackendMemory:
+		cacheStore = cache.NewLRU(cfg.CacheSize)
+	case cache.BackendRedis:
+		redisStore, err := cache.NewRedis(cfg.CacheRedisURL)
+		if err != nil {
+			logger.Fatal("Could not set up the Redis cache", zap.Error(err))
+		}
+		cacheStore = redisStore
+	}
+	if cacheStore != nil {
+		jobCache = cache.NewJobs(jobRepo, cacheStore, cfg.CacheTTL, logger.Named("cache"))
+		jobRepo = jobCache
+		logger.Info("Caching jobs", zap.String("backend", cfg.CacheBackend), zap.Duration("ttl", cfg.CacheTTL))
+	}
+
+	// The executor runs jobs in the background, both when they are due and on demand
+	exec := executor.New(jobRepo, runRepo, cfg.ExecutorWorkers, cfg.ExecutorQueueSize, cfg.ExecutorPriorityShares, cfg.ExecutorMaxQueueWait, logger.Named("executor"))
+	exec.Charge(cfg.ExecutorCostPerSecond)
+	configReloader.Handle(func(c *config.Config) error {
+		exec.SetWorkers(c.ExecutorWorkers)
+		return nil
+	}, "executor-workers")
+	// Handlers get the params of jobs with the values of the secrets they refer to, the runs never show them
+	cipher, err := cfg.SecretsCipher()
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		logger.Fatal("Could not load the master key of secrets", zap.Error(err))
+	}
+	if cipher == nil {
+		logger.Info("Secrets are disabled, SECRETS_MASTER_KEY is not set")
+	}
+	exec.ResolveWith(secrets.NewResolver(secretRepo, cipher))
+	// Jobs with the remote handler are run by the external workers that lease them. The pool only keeps them in
+	// memory, so with leader election only the scheduler leader hands out remote runs.
+	workers := worker.NewPool(cfg.WorkerLeaseTTL, logger.Named("workers"))
+	if cfg.LeaderElection {
+		workers.SetLeader(false)
+	}
+	exec.Register(worker.Handler, workers)
+	exec.Describe(worker.Type)
+	// The dispatcher posts the events of jobs and finished runs to the webhooks of their owners
+	dispatcher := webhook.New(webhookRepo, cfg.WebhookWorkers, cfg.WebhookQueueSize, cfg.WebhookMaxAttempts, cfg.WebhookTimeout, logger.Named("webhook"))
+	// Jobs with the webhook handler are posted with the client of the dispatcher
+	exec.Register(webhook.Handler, executor.HandlerFunc(dispatcher.Call))
+	exec.Describe(webhook.Type)
+	exec.OnFinish(dispatcher.RunFinished)
+	// The notifier tells the Slack channels and email recipients of jobs whose runs keep failing
+	templates, err := notify.ParseTemplates(cfg.NotificationTemplates)
+	if err != nil {
+		logger.Fatal("Could not load notification templates", zap.Error(err))
+	}
+	var mailer *notify.Mailer
+	if cfg.SMTPAddr != "" {
+		mailer = &notify.Mailer{Addr: cfg.SMTPAddr, Username: cfg.SMTPUsername, Password: cfg.SMTPPassword, From: cfg.SMTPFrom}
+		configReloader.Handle(func(c *config.Config) error {
+			if c.SMTPAddr == "" {
+				return errors.New("emails can't be turned off without a restart")
+			}
+			mailer.Update(c.SMTPAddr, c.SMTPUsername, c.SMTPPassword, c.SMTPFrom)
+			return nil
+		}, "smtp-addr", "smtp-username", "smtp-password", "smtp-from")
+	}
+	notifier := notify.New(runRepo, templates, mailer, cfg.NotificationTimeout, logger.Named("notify"))
+	exec.OnFinish(notifier.RunFinished)
+	// The SLA monitor records runs that took too long and missed deadlines, breaches go to webhooks and channels
+	slaMonitor := sla.New(slaRepo, jobRepo, runRepo, cfg.SLACheckInterval, logger.Named("sla"))
+	slaMonitor.OnBreach(dispatcher.SLABreached)
+	slaMonitor.OnBreach(notifier.SLABreached)
+	exec.OnFinish(slaMonitor.RunFinished)
+	// The anomaly detector records succeeded runs whose duration deviates from the baseline of their job
+	detector := anomaly.New(anomalyRepo, runRepo, cfg.AnomalyThreshold, cfg.AnomalyWindow, cfg.AnomalyMinRuns, logger.Named("anomaly"))
+	detector.OnAnomaly(dispatcher.RunAnomalous)
+	detector.OnAnomaly(notifier.RunAnomalous)
+	if cfg.AnomalyThreshold > 0 {
+		exec.OnFinish(detector.RunFinished)
+	}
+	// The recorder keeps the events of jobs and finished runs in the outbox, the relay publishes them to the broker
+	var publisher events.Publisher
+	switch cfg.EventsBroker {
+	case events.BrokerNATS:
+		natsPublisher, err := events.NewNATSPublisher(cfg.EventsNATSURL, cfg.EventsTopic)
+		if err != nil {
+			logger.Fatal("Could not connect to NATS", zap.Error(err))
+		}
+		publisher = natsPublisher
+	case events.BrokerKafka:
+		publisher = events.NewKafkaPublisher(cfg.EventsKafkaBrokers, cfg.EventsTopic)
+	}
+	var relay *events.Relay
+	var recorder *events.Recorder
+	if publisher != nil {
+		relay = events.NewRelay(outboxRepo, publisher, cfg.EventsPollInterval, logger.Named("events"))
+		recorder = events.NewRecorder(outboxRepo, transactions, relay)
+		exec.StoreWith(recorder)
+	}
+	// Backups and reports cover the tenants with a database of their own one after the other
+	var tenants []string
+	if cfg.StorageBackend == config.StorageMongoDB {
+		for tenantID := range cfg.TenantDatabases {
+			tenants = append(tenants, tenantID)
+		}
+	}
+	// Backups snapshot the jobs and runs to BACKUP_LOCATION on request and every BACKUP_INTERVAL
+	var backups *backup.Backups
+	if cfg.BackupLocation != "" {
+		store, err := cfg.BackupStore()
+		if err != nil {
+			logger.Fatal("Could not set up backups", zap.Error(err))
+		}
+		backups = backup.New(jobRepo, runRepo, store, tenants, logger.Named("backup"))
+	}
+	// Reports are generated on request and for every period of REPORT_SCHEDULE once it ended
+	reports := report.NewBuilder(jobRepo, runRepo, slaRepo, tenants)
+	var reportScheduler *report.Scheduler
+	if len(cfg.ReportSchedule) > 0 {
+		var deliveries []report.Delivery
+		if len(cfg.ReportRecipients) > 0 {
+			deliveries = append(deliveries, &report.MailDelivery{Mailer: mailer, Recipients: cfg.ReportRecipients})
+		}
+		if cfg.ReportLocation != "" {
+			store, err := cfg.ReportStore()
+			if err != nil {
+				logger.Fatal("Could not set up reports", zap.Error(err))
+			}
+			deliveries = append(deliveries, &report.StoreDelivery{Store: store})
+		}
+		reportScheduler = report.NewScheduler(reports, cfg.ReportSchedule, cfg.ReportFormat, deliveries, logger.Named("report"))
 	}
-	log.Printf("Listening on %s", path)
+
+	// Start to listen on the configured TCP address or Unix domain socket
+	network, path := cfg.Listener()
+	if network == "unix" {
+		// A socket left behind by a process that didn't shut down cleanly would make Listen fail
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(path)
+		}
+	}
+	lis, err := net.Listen(network, path)
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.String("network", network), zap.String("addr", path), zap.Error(err))
+	}
+	logger.Info("Listening", zap.String("network", network), zap.String("addr", path))
 
 	// Set options, here we can configure things like TLS support
-	opts := []grpc.ServerOption{}
-	// Create new gRPC server with (blank) options
+	opts := []grpc.ServerOption{
+		// Ping clients on idle connections, so load balancers and proxies don't drop long running streams
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}),
+		// Disconnect clients that ping more often than allowed instead of letting them keep idle connections busy
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.KeepaliveMinTime,
+			PermitWithoutStream: cfg.KeepalivePermitWithoutStream,
+		}),
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgSize),
+		// Calls failing on these limits never reach the interceptors, so they are logged by a stats handler
+		grpc.StatsHandler(logging.NewSizeHandler(logger)),
+	}
+	var reloader *certs.Reloader
+	if cfg.TLSEnabled() {
+		reloader, err = certs.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile, cfg.TLSRequireClientCert)
+		if err != nil {
+			logger.Fatal("Could not load TLS certificates", zap.Error(err))
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
+		logger.Info("TLS enabled", zap.Bool("mutual_tls_enforced", cfg.TLSRequireClientCert))
+	}
+	chain := middleware.NewChain(cfg.DisabledMiddlewares)
+	// Log every RPC with a request ID, first so the entry covers everything below including rejected calls
+	grpcLogger := logger.Named("grpc")
+	chain.Use(middleware.Logging, logging.UnaryServerInterceptor(grpcLogger), logging.StreamServerInterceptor(grpcLogger))
+	// Turn panics of handlers into Internal errors, inside logging so the failed call is still logged with its request fields
+	chain.Use(middleware.Recovery, recovery.UnaryServerInterceptor, recovery.StreamServerInterceptor)
+	// Count and time every RPC
+	chain.Use(middleware.Metrics, metrics.UnaryServerInterceptor, metrics.StreamServerInterceptor)
+	// Trace every RPC, continuing traces started by the client
+	chain.Use(middleware.Tracing, tracing.UnaryServerInterceptor, tracing.StreamServerInterceptor)
+	// The UserService also verifies the API keys machine clients authenticate with
+	userSrv := &services.UserServiceServer{
+		Users: userRepo,
+	}
+	// Require a valid bearer token or API key, after tracing so rejected calls still show up in traces
+	var authenticator *auth.Authenticator
+	if cfg.AuthEnabled() {
+		authenticator = auth.NewAuthenticator(cfg.AuthJWKSURL, cfg.AuthIssuer, cfg.AuthAudience, cfg.AuthExemptMethods)
+		authenticator.AcceptAPIKeys(userSrv)
+		chain.Use(middleware.Auth, authenticator.UnaryServerInterceptor, authenticator.StreamServerInterceptor)
+		logger.Info("Authentication enabled", zap.String("jwks_url", cfg.AuthJWKSURL))
+	}
+	// Scope every call to the tenant of the caller's token, right after authentication so everything below is scoped
+	if cfg.MultiTenancy {
+		chain.Use(middleware.Tenant, tenant.UnaryServerInterceptor, tenant.StreamServerInterceptor)
+		logger.Info("Multi-tenancy enabled", zap.Int("tenant_databases", len(cfg.TenantDatabases)))
+	}
+	// Look up the teams of the caller, within the tenant so only teams of the caller's tenant count
+	if cfg.AuthEnabled() {
+		resolver := team.New(teamRepo)
+		chain.Use(middleware.Teams, resolver.UnaryServerInterceptor, resolver.StreamServerInterceptor)
+	}
+	// Check the roles of the caller, after the teams since they grant roles too
+	// The debug endpoints check the roles of the token and, with RBAC, of the user, a nil *rbac.Authorizer must stay nil
+	var debugRoles debug.Roles
+	if cfg.RBAC {
+		authorizer := rbac.New(userRepo, rbac.Policy)
+		chain.Use(middleware.RBAC, authorizer.UnaryServerInterceptor, authorizer.StreamServerInterceptor)
+		debugRoles = authorizer
+		logger.Info("RBAC enabled")
+	}
+	if cfg.DebugEndpoints {
+		probes.Handle("/debug/", debug.Handler(authenticator, debugRoles))
+		logger.Info("Serving debug endpoints", zap.String("addr", cfg.HealthAddr))
+	}
+	// Limit the calls of every client, after authentication so clients with a token are told apart by their subject
+	if cfg.RateLimitEnabled() {
+		limiter := ratelimit.New(cfg.RateLimit, cfg.RateLimitMethods)
+		chain.Use(middleware.RateLimit, limiter.UnaryServerInterceptor, limiter.StreamServerInterceptor)
+		configReloader.Handle(func(c *config.Config) error {
+			limiter.SetLimits(c.RateLimit, c.RateLimitMethods)
+			return nil
+		}, "rate-limit", "rate-limit-methods")
+		logger.Info("Rate limiting enabled", zap.Stringer("limit", &cfg.RateLimit), zap.Stringer("method_limits", &cfg.RateLimitMethods))
+	}
+	// Record changes to jobs, after authentication so entries name the caller
+	if cfg.AuditLog {
+		recorder := audit.New(jobRepo, changeRepo, auditRepo)
+		chain.Use(middleware.Audit, recorder.UnaryServerInterceptor, recorder.StreamServerInterceptor)
+	}
+	opts = append(opts, chain.ServerOptions()...)
+	logger.Info("Middlewares", zap.Strings("chain", chain.Names()))
+	// Create new gRPC server with options
 	s := grpc.NewServer(opts...)
 
+	// The services store events in the transactions of their changes, a nil recorder must stay a nil store
+	var eventStore services.EventStore
+	if recorder != nil {
+		eventStore = recorder
+	}
+
+	// Quotas are counted in the storage whenever a call is checked against them
+	var quotas *quota.Quotas
+	if cfg.Quotas.Enabled() {
+		quotas = quota.New(jobRepo, runRepo, cfg.Quotas)
+		logger.Info("Quotas enabled", zap.Int64("jobs_per_owner", cfg.Quotas.JobsPerOwner), zap.Int64("jobs_per_tenant", cfg.Quotas.JobsPerTenant),
+			zap.Int64("concurrent_runs", cfg.Quotas.ConcurrentRuns), zap.Int64("runs_per_day", cfg.Quotas.RunsPerDay))
+	}
+
 	// Create JobService type
 	jobSrv := &services.JobServiceServer{
-		JobDb:    jobdb,
-		MongoCtx: mongoCtx,
+		Jobs:            jobRepo,
+		Cache:           jobCache,
+		Executor:        exec,
+		ImportBatchSize: cfg.ImportBatchSize,
+		Events:          eventStore,
+		Listeners:       []services.JobListener{dispatcher},
+		Users:           userRepo,
+		Teams:           teamRepo,
+		Maintenance:     maintenanceRepo,
+		Environments:    environmentRepo,
+		Changes:         changeRepo,
+		Revisions:       revisionRepo,
+		Quotas:          quotas,
 	}
 	// Register the service with the server
 	model.RegisterJobServiceServer(s, jobSrv)
 
+	// The ScheduleService works on the same collection as the JobService
+	scheduleSrv := &services.ScheduleServiceServer{
+		Jobs:      jobRepo,
+		Events:    eventStore,
+		Listeners: []services.JobListener{dispatcher},
+	}
+	model.RegisterScheduleServiceServer(s, scheduleSrv)
+
+	// The RunService exposes the records the executor writes
+	runSrv := &services.RunServiceServer{
+		Runs:     runRepo,
+		Policies: retentionRepo,
+		Jobs:     jobRepo,
+	}
+	model.RegisterRunServiceServer(s, runSrv)
+
+	// The AnalyticsService aggregates the run records of a job
+	analyticsSrv := &services.AnalyticsServiceServer{
+		Jobs:      jobRepo,
+		Runs:      runRepo,
+		SLAs:      slaRepo,
+		Anomalies: anomalyRepo,
+	}
+	model.RegisterAnalyticsServiceServer(s, analyticsSrv)
+
+	// The AuditService lists the changes the audit interceptor recorded
+	auditSrv := &services.AuditServiceServer{
+		Entries: auditRepo,
+	}
+	model.RegisterAuditServiceServer(s, auditSrv)
+
+	// The WebhookService manages the webhooks the dispatcher delivers to
+	webhookSrv := &services.WebhookServiceServer{
+		Webhooks: webhookRepo,
+		Users:    userRepo,
+	}
+	model.RegisterWebhookServiceServer(s, webhookSrv)
+
+	// The UserService manages the users jobs are owned by and their API keys
+	model.RegisterUserServiceServer(s, userSrv)
+
+	// The TeamService manages the teams sharing the jobs they own
+	teamSrv := &services.TeamServiceServer{
+		Teams: teamRepo,
+		Users: userRepo,
+		Jobs:  jobRepo,
+	}
+	model.RegisterTeamServiceServer(s, teamSrv)
+
+	// The TemplateService stores job templates and creates jobs from them through the JobService
+	templateSrv := &services.TemplateServiceServer{
+		Templates: templateRepo,
+		Jobs:      jobSrv,
+	}
+	model.RegisterTemplateServiceServer(s, templateSrv)
+
+	// The CalendarService stores the calendars the scheduler reads the blackouts of jobs from
+	calendarSrv := &services.CalendarServiceServer{
+		Calendars: calendarRepo,
+		Jobs:      jobRepo,
+		Users:     userRepo,
+		Teams:     teamRepo,
+		Events:    eventStore,
+		Listeners: []services.JobListener{dispatcher},
+	}
+	model.RegisterCalendarServiceServer(s, calendarSrv)
+
+	// The SecretService stores the encrypted secrets the executor resolves in the params of jobs
+	secretSrv := &services.SecretServiceServer{
+		Secrets: secretRepo,
+		Cipher:  cipher,
+		Users:   userRepo,
+		Teams:   teamRepo,
+	}
+	model.RegisterSecretServiceServer(s, secretSrv)
+
+	// The AdminService backs up and restores the jobs and runs
+	adminSrv := &services.AdminServiceServer{
+		Backups:      backups,
+		Maintenance:  maintenanceRepo,
+		Environments: environmentRepo,
+		Config:       configReloader,
+		LogLevels:    levels,
+	}
+	model.RegisterAdminServiceServer(s, adminSrv)
+
+	// The ReportService generates the reports of weeks and months on request
+	reportSrv := &services.ReportServiceServer{
+		Reports: reports,
+	}
+	model.RegisterReportServiceServer(s, reportSrv)
+
+	// The WorkerService hands the runs of the remote handler to external workers
+	workerSrv := &services.WorkerServiceServer{
+		Workers: workers,
+	}
+	model.RegisterWorkerServiceServer(s, workerSrv)
+
 	// Same for the HelloService
 	helloSrv := &services.HelloServiceServer{}
 	model.RegisterHelloServiceServer(s, helloSrv)
 
+	// Report the health of every service, the storage backed ones follow a periodic ping
+	checker := healthcheck.New(ping, cfg.HealthCheckInterval, logger.Named("healthcheck"), "model.JobService", "model.ScheduleService", "model.RunService",
+		"model.AnalyticsService", "model.AuditService", "model.WebhookService", "model.UserService", "model.TeamService", "model.TemplateService", "model.AdminService",
+		"model.ReportService", "model.CalendarService", "model.SecretService", "model.WorkerService")
+	checker.SetServing("model.HelloService")
+	checker.Register(s)
+	probes.Set("storage", checker.Check)
+
+	// Let tools like grpcurl and evans list the services and messages without the proto files, and grpcdebug inspect
+	// the sockets and streams of the server
+	if cfg.Reflection {
+		reflection.Register(s)
+		channelz.RegisterChannelzServiceToServer(s)
+	}
+
+	// Initialize the metrics of all registered services and expose them over HTTP
+	metrics.Register(s)
+	var metricsSrv *http.Server
+	if cfg.MetricsAddr != "" {
+		metricsSrv = metrics.NewServer(cfg.MetricsAddr)
+		go func() {
+			if err := metricsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve metrics", zap.Error(err))
+			}
+		}()
+		logger.Info("Serving metrics", zap.String("addr", cfg.MetricsAddr))
+	}
+
+	// Serve the REST/JSON gateway, it forwards every request to the gRPC server so all interceptors apply
+	var gatewaySrv *http.Server
+	gatewayCtx, stopGateway := context.WithCancel(context.Background())
+	if cfg.GatewayAddr != "" {
+		dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+		if reloader != nil {
+			// The gateway talks to this very server over loopback, where the certificate's names usually don't match
+			dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}))}
+		}
+		// Leave the limits to the server, the client's own would fail responses larger than 4 MiB the server sends
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.MaxSendMsgSize),
+			grpc.MaxCallSendMsgSize(cfg.MaxRecvMsgSize)))
+		gatewaySrv, err = gateway.NewServer(gatewayCtx, cfg.GatewayAddr, cfg.DialTarget(), cfg.GatewayDocs, dialOpts...)
+		if err != nil {
+			logger.Fatal("Could not set up the REST gateway", zap.Error(err))
+		}
+		go func() {
+			var err error
+			if reloader != nil {
+				// Serve HTTPS with the same certificate as the gRPC server
+				gatewaySrv.TLSConfig = &tls.Config{GetCertificate: reloader.GetCertificate, MinVersion: tls.VersionTLS12}
+				err = gatewaySrv.ListenAndServeTLS("", "")
+			} else {
+				err = gatewaySrv.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve the REST gateway", zap.Error(err))
+			}
+		}()
+		logger.Info("Serving REST gateway", zap.String("addr", cfg.GatewayAddr))
+	}
+
 	go func() {
 		if err := s.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve: %v", err)
+			logger.Fatal("Failed to serve", zap.Error(err))
+		}
+	}()
+	logger.Info("Server successfully started", zap.String("addr", path))
+
+	// Subsystems are registered after the ones they hand work to, they stop in the reverse order. Breaches of the last
+	// runs are still handed to the dispatcher and the notifier, deliveries that are still pending are stored as dead
+	// letters.
+	background := lifecycle.New(cfg.BackgroundShutdownTimeout, logger.Named("lifecycle"))
+	background.Register("notifier", nil, notifier.Wait)
+	background.Register("webhooks", func(ctx context.Context) error {
+		dispatcher.Start(ctx)
+		return nil
+	}, dispatcher.Wait)
+	background.Register("anomalies", nil, detector.Wait)
+	// elected runs run only while this replica is the leader of the lease called name, when leader election is enabled.
+	// The elector releases its lease when it stops, so another replica takes over without waiting for it to expire.
+	elected := func(name string, run func(ctx context.Context)) lifecycle.StartFunc {
+		if !cfg.LeaderElection {
+			return lifecycle.Run(run)
+		}
+		elector := leader.New(leaseRepo, name, cfg.LeaderLeaseTTL, logger.Named("leader"))
+		return func(ctx context.Context) error {
+			elector.Run(ctx, run)
+			return nil
+		}
+	}
+	// A single replica checks the deadlines, the others would only find the same breaches again
+	background.Register("sla", elected("sla", slaMonitor.Run), slaMonitor.Wait)
+	background.Register("executor", func(ctx context.Context) error {
+		exec.Start(ctx)
+		return nil
+	}, exec.Wait)
+	background.Register("healthcheck", lifecycle.Run(checker.Run), nil)
+	background.Register("workers", lifecycle.Run(workers.Reap), nil)
+	if cfg.SchedulerEnabled {
+		schedulerLogger := logger.Named("scheduler")
+		sched := scheduler.New(jobRepo, runRepo, calendarRepo, maintenanceRepo, environmentRepo, cfg.SchedulerPollInterval, func(ctx context.Context, job *scheduler.DueJob) {
+			if _, err := exec.Fire(ctx, job); err == executor.ErrStillRunning {
+				schedulerLogger.Info("Skipped run of job that is still running", zap.String("job_id", job.ID), zap.String("job_name", job.Name))
+			} else if err != nil {
+				schedulerLogger.Error("Could not run job", zap.String("job_id", job.ID), zap.String("job_name", job.Name), zap.Error(err))
+			}
+		}, func(ctx context.Context, retry *scheduler.DueRetry) {
+			if err := exec.Retry(ctx, retry.RunID); err != nil {
+				schedulerLogger.Error("Could not retry job", zap.String("job_id", retry.JobID), zap.String("run_id", retry.RunID), zap.Error(err))
+			}
+		}, schedulerLogger)
+		probes.Set("scheduler", sched.Check)
+		run := sched.Run
+		if cfg.LeaderElection {
+			run = func(ctx context.Context) {
+				metrics.SetLeader(true)
+				defer metrics.SetLeader(false)
+				workers.SetLeader(true)
+				defer workers.SetLeader(false)
+				sched.Run(ctx)
+			}
+		}
+		// Only the leader fires jobs, so replicas don't fire the same jobs
+		background.Register("scheduler", elected("scheduler", run), nil)
+	}
+	if relay != nil {
+		// Replicas share the outbox, a single one publishes it so events stay in order
+		background.Register("events", elected("events", relay.Run), nil)
+	}
+	if backups != nil && cfg.BackupInterval > 0 {
+		// A single replica takes the scheduled backups, the others would only write the same ones again
+		background.Register("backups", elected("backup", func(ctx context.Context) {
+			backups.Run(ctx, cfg.BackupInterval)
+		}), nil)
+	}
+	if reportScheduler != nil {
+		// A single replica delivers the scheduled reports, the others would only send the same ones again
+		background.Register("reports", elected("report", reportScheduler.Run), nil)
+	}
+	// Remove deleted jobs for good once they can't be restored anymore and free expired idempotency keys
+	purger := retention.New(jobRepo, cfg.DeletedJobRetention, cfg.IdempotencyKeyRetention, cfg.PurgeInterval, logger.Named("purger"))
+	background.Register("purger", lifecycle.Run(purger.Run), nil)
+	// Remove finished runs past the retention policies of their tenant
+	janitor := retention.NewRunJanitor(runRepo, retentionRepo, cfg.PurgeInterval, logger.Named("janitor"))
+	background.Register("janitor", lifecycle.Run(janitor.Run), nil)
+	if cfg.ConfigWatch {
+		background.Register("config-watch", lifecycle.Run(func(ctx context.Context) {
+			configReloader.Watch(ctx, cfg.ConfigFile, configWatchInterval)
+		}), nil)
+	}
+	background.Start(context.Background())
+
+	// Re-read the certificates from disk and reload the configuration whenever we receive SIGHUP
+	go func() {
+		for range hup {
+			if reloader != nil {
+				if err := reloader.Reload(); err != nil {
+					logger.Error("Could not reload TLS certificates, keeping the old ones", zap.Error(err))
+				} else {
+					logger.Info("Reloaded TLS certificates")
+				}
+			}
+			configReloader.Reload()
 		}
 	}()
-	fmt.Println("Server succesfully started on port :8010")
 
 	// Right way to stop the server using a SHUTDOWN HOOK
 	// Create a channel to receive OS signals
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 
-	// Relay os.Interrupt to our channel (os.Interrupt = CTRL+C)
+	// Relay os.Interrupt (CTRL+C) and SIGTERM (sent by docker and kubernetes) to our channel
 	// Ignore other incoming signals
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+
+	// Block main routine until a signal is received or a background subsystem failed
+	// As long as no signal is passed our main routine keeps running
+	select {
+	case sig := <-c:
+		// After receiving the signal properly stop the server
+		logger.Info("Stopping the server", zap.Stringer("signal", sig))
+	case <-background.Done():
+		logger.Error("Stopping the server after a background subsystem failed")
+	}
+	// Tell load balancers to stop sending traffic while we drain
+	checker.Shutdown()
+	probes.Shutdown()
+	// Nothing fires runs or sends notifications from here on
+	if err := background.Stop(); err != nil {
+		logger.Error("Could not stop the background subsystems", zap.Error(err))
+	}
 
-	// Block main routine until a signal is received
-	// As long as user doesn't press CTRL+C a message is not passed and our main routine keeps running
-	<-c
+	// Let in-flight REST requests finish first, they need the gRPC server below to answer
+	if gatewaySrv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		if err := gatewaySrv.Shutdown(shutdownCtx); err != nil {
+			gatewaySrv.Close()
+		}
+		cancel()
+	}
+	stopGateway()
 
-	// After receiving CTRL+C Properly stop the server
-	fmt.Println("\nStopping the server...")
-	s.Stop()
+	// GracefulStop waits for in-flight RPCs (including open streams) to finish, fall back to Stop after the timeout
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		logger.Info("All RPCs finished")
+	case <-time.After(cfg.ShutdownTimeout):
+		logger.Warn("Drain timeout exceeded, cancelling remaining RPCs")
+		s.Stop()
+		<-stopped
+	}
 	lis.Close()
-	fmt.Println("Closing MongoDB connection")
-	db.Disconnect(mongoCtx)
-	fmt.Println("Done.")
+	if publisher != nil {
+		publisher.Close()
+	}
+	if cacheStore != nil {
+		cacheStore.Close()
+	}
+	if metricsSrv != nil {
+		metricsSrv.Close()
+	}
+	if probeSrv != nil {
+		probeSrv.Close()
+	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		logger.Error("Could not flush traces", zap.Error(err))
+	}
+
+	// Only disconnect once nothing uses the database anymore
+	logger.Info("Closing storage connection")
+	closeStorage()
+	logger.Info("Done")
 }
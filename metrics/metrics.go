@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+	"google.golang.org/grpc"
+)
+
+// mongoCommands observes the duration of every MongoDB command, labelled by command name and outcome
+var mongoCommands = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "schedulytics",
+	Subsystem: "mongodb",
+	Name:      "command_duration_seconds",
+	Help:      "Duration of MongoDB commands.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"command", "result"})
+
+// handlerPanics counts the panics of RPC handlers the server recovered from, labelled by method
+var handlerPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Subsystem: "grpc",
+	Name:      "handler_panics_total",
+	Help:      "Panics of RPC handlers that were recovered from.",
+}, []string{"grpc_method"})
+
+// rateLimited counts the calls rejected by the rate limiter, labelled by method
+var rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Subsystem: "grpc",
+	Name:      "rate_limited_total",
+	Help:      "Calls rejected because the client exceeded the rate limit.",
+}, []string{"grpc_method"})
+
+// schedulerLeader is 1 while this replica runs the scheduler
+var schedulerLeader = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "schedulytics",
+	Subsystem: "scheduler",
+	Name:      "leader",
+	Help:      "Whether this replica runs the scheduler.",
+})
+
+// storageUp is 1 while the last health check reached the storage backend
+var storageUp = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "schedulytics",
+	Subsystem: "storage",
+	Name:      "up",
+	Help:      "Whether the last health check reached the storage backend.",
+})
+
+// queuedRuns is the number of runs waiting for a worker of the executor, labelled by priority
+var queuedRuns = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "schedulytics",
+	Subsystem: "executor",
+	Name:      "queued_runs",
+	Help:      "Runs waiting for a free worker.",
+}, []string{"priority"})
+
+// webhookDeliveries counts the attempts to deliver events to webhooks, labelled by event type and outcome
+var webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Subsystem: "webhook",
+	Name:      "deliveries_total",
+	Help:      "Attempts to deliver events to webhooks.",
+}, []string{"event", "result"})
+
+// notifications counts the notifications about failing jobs, labelled by channel and outcome
+var notifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Name:      "notifications_total",
+	Help:      "Notifications sent about jobs whose runs keep failing.",
+}, []string{"channel", "result"})
+
+// slaBreaches counts the breaches of the SLAs of jobs, labelled by kind
+var slaBreaches = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Name:      "sla_breaches_total",
+	Help:      "Runs and deadlines that breached the SLA of their job.",
+}, []string{"kind"})
+
+// runAnomalies counts the runs whose duration was an anomaly, labelled by whether they were slower or faster
+var runAnomalies = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Name:      "run_anomalies_total",
+	Help:      "Runs whose duration deviated from the baseline of their job.",
+}, []string{"direction"})
+
+// events counts the domain events published to the message broker, labelled by event type and outcome
+var events = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Name:      "events_total",
+	Help:      "Domain events published to the message broker.",
+}, []string{"type", "result"})
+
+// cacheLookups counts the lookups of jobs in the cache, labelled by outcome
+var cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "schedulytics",
+	Subsystem: "cache",
+	Name:      "lookups_total",
+	Help:      "Lookups of jobs in the cache.",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(mongoCommands, handlerPanics, rateLimited, schedulerLeader, storageUp, queuedRuns, webhookDeliveries, notifications,
+		slaBreaches, runAnomalies, events, cacheLookups)
+	// Latency histograms are disabled in go-grpc-prometheus by default
+	grpc_prometheus.EnableHandlingTimeHistogram()
+}
+
+// UnaryServerInterceptor counts and times every unary RPC
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return grpc_prometheus.UnaryServerInterceptor(ctx, req, info, handler)
+}
+
+// StreamServerInterceptor counts and times every streaming RPC
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return grpc_prometheus.StreamServerInterceptor(srv, ss, info, handler)
+}
+
+// Register initializes the per-RPC metrics for all services registered on s, so they are exported before the first call
+func Register(s *grpc.Server) {
+	grpc_prometheus.Register(s)
+}
+
+// MongoMonitor returns a command monitor recording MongoDB command metrics, pass it to options.Client().SetMonitor
+func MongoMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			observe(e.CommandName, "success", e.DurationNanos)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			observe(e.CommandName, "failure", e.DurationNanos)
+		},
+	}
+}
+
+// observe records a single finished MongoDB command
+func observe(command, result string, nanos int64) {
+	mongoCommands.WithLabelValues(command, result).Observe(time.Duration(nanos).Seconds())
+}
+
+// RecordPanic counts a recovered panic of the handler of fullMethod
+func RecordPanic(fullMethod string) {
+	handlerPanics.WithLabelValues(fullMethod).Inc()
+}
+
+// RecordRateLimited counts a call to fullMethod the rate limiter rejected
+func RecordRateLimited(fullMethod string) {
+	rateLimited.WithLabelValues(fullMethod).Inc()
+}
+
+// SetLeader records whether this replica runs the scheduler
+func SetLeader(leading bool) {
+	if leading {
+		schedulerLeader.Set(1)
+	} else {
+		schedulerLeader.Set(0)
+	}
+}
+
+// SetStorageUp records whether the storage backend could be reached
+func SetStorageUp(up bool) {
+	if up {
+		storageUp.Set(1)
+	} else {
+		storageUp.Set(0)
+	}
+}
+
+// SetQueuedRuns records the number of runs of a priority waiting for a worker
+func SetQueuedRuns(priority string, n int) {
+	queuedRuns.WithLabelValues(priority).Set(float64(n))
+}
+
+// RecordWebhookDelivery counts an attempt to deliver an event, result is succeeded, retried or dead_lettered
+func RecordWebhookDelivery(event, result string) {
+	webhookDeliveries.WithLabelValues(event, result).Inc()
+}
+
+// RecordNotification counts a notification sent to a channel (slack or email), result is sent or failed
+func RecordNotification(channel, result string) {
+	notifications.WithLabelValues(channel, result).Inc()
+}
+
+// RecordSLABreach counts a breach of an SLA, kind is DURATION or DEADLINE
+func RecordSLABreach(kind string) {
+	slaBreaches.WithLabelValues(kind).Inc()
+}
+
+// RecordRunAnomaly counts a run whose duration was an anomaly, direction is slower or faster
+func RecordRunAnomaly(direction string) {
+	runAnomalies.WithLabelValues(direction).Inc()
+}
+
+// RecordEvent counts an attempt to publish an event, result is published or failed for attempts that are repeated
+func RecordEvent(eventType, result string) {
+	events.WithLabelValues(eventType, result).Inc()
+}
+
+// RecordCacheLookup counts a lookup of a job in the cache, result is hit, miss or error
+func RecordCacheLookup(result string) {
+	cacheLookups.WithLabelValues(result).Inc()
+}
+
+// NewServer returns an HTTP server exposing all metrics on /metrics
+func NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"google.golang.org/grpc"
+)
+
+// Names of the middlewares of the server, in the order they run
+const (
+	Logging   = "logging"
+	Recovery  = "recovery"
+	Metrics   = "metrics"
+	Tracing   = "tracing"
+	Auth      = "auth"
+	Tenant    = "tenant"
+	Teams     = "teams"
+	RBAC      = "rbac"
+	RateLimit = "ratelimit"
+	Audit     = "audit"
+)
+
+// Optional are the middlewares that run unless they are disabled. The others are enabled by their own settings,
+// disabling them by name would let calls skip authentication or the scope of their tenant.
+var Optional = []string{Logging, Recovery, Metrics, Tracing}
+
+// Chain collects the interceptors of the middlewares of a server. Every middleware wraps the ones used after it, so
+// the order of Use matters: a middleware only sees what the ones before it let through and put into the context.
+type Chain struct {
+	disabled map[string]bool
+	names    []string
+	unary    []grpc.UnaryServerInterceptor
+	stream   []grpc.StreamServerInterceptor
+}
+
+// NewChain creates an empty chain leaving out the middlewares named in disabled
+func NewChain(disabled []string) *Chain {
+	c := &Chain{disabled: map[string]bool{}}
+	for _, name := range disabled {
+		c.disabled[name] = true
+	}
+	return c
+}
+
+// Use appends the middleware with name to the chain unless it's disabled, it runs after the ones used before
+func (c *Chain) Use(name string, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) {
+	if c.disabled[name] {
+		return
+	}
+	c.names = append(c.names, name)
+	c.unary = append(c.unary, unary)
+	c.stream = append(c.stream, stream)
+}
+
+// Names returns the names of the middlewares in the chain in the order they run
+func (c *Chain) Names() []string {
+	return append([]string{}, c.names...)
+}
+
+// ServerOptions returns the options installing the chain on a server
+func (c *Chain) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(c.unary...), grpc.ChainStreamInterceptor(c.stream...)}
+}
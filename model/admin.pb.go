@@ -0,0 +1,2161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ConflictPolicy tells RestoreJobs what to do with jobs and runs of a backup that are stored already, including jobs
+// whose name another job of their owner has
+type ConflictPolicy int32
+
+const (
+	// Same as CONFLICT_POLICY_FAIL
+	ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED ConflictPolicy = 0
+	// Keep the stored job or run and leave the one of the backup out, runs of jobs left out for their name are too
+	ConflictPolicy_CONFLICT_POLICY_SKIP ConflictPolicy = 1
+	// Replace the stored job or run with the one of the backup, jobs whose name is taken are left out
+	ConflictPolicy_CONFLICT_POLICY_OVERWRITE ConflictPolicy = 2
+	// Stop at the first conflict, what was restored before stays
+	ConflictPolicy_CONFLICT_POLICY_FAIL ConflictPolicy = 3
+)
+
+var ConflictPolicy_name = map[int32]string{
+	0: "CONFLICT_POLICY_UNSPECIFIED",
+	1: "CONFLICT_POLICY_SKIP",
+	2: "CONFLICT_POLICY_OVERWRITE",
+	3: "CONFLICT_POLICY_FAIL",
+}
+
+var ConflictPolicy_value = map[string]int32{
+	"CONFLICT_POLICY_UNSPECIFIED": 0,
+	"CONFLICT_POLICY_SKIP":        1,
+	"CONFLICT_POLICY_OVERWRITE":   2,
+	"CONFLICT_POLICY_FAIL":        3,
+}
+
+func (x ConflictPolicy) String() string {
+	return proto.EnumName(ConflictPolicy_name, int32(x))
+}
+
+func (ConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+// Backup is a snapshot of the jobs, deleted ones included, and their runs
+type Backup struct {
+	// Name to restore the backup by, it is also the name of its file or object
+	Name      string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Jobs      int64                `protobuf:"varint,3,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	Runs      int64                `protobuf:"varint,4,opt,name=runs,proto3" json:"runs,omitempty"`
+	// Size of the compressed backup
+	SizeBytes            int64    `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Backup) Reset()         { *m = Backup{} }
+func (m *Backup) String() string { return proto.CompactTextString(m) }
+func (*Backup) ProtoMessage()    {}
+func (*Backup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *Backup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backup.Unmarshal(m, b)
+}
+func (m *Backup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backup.Marshal(b, m, deterministic)
+}
+func (m *Backup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backup.Merge(m, src)
+}
+func (m *Backup) XXX_Size() int {
+	return xxx_messageInfo_Backup.Size(m)
+}
+func (m *Backup) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backup proto.InternalMessageInfo
+
+func (m *Backup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Backup) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Backup) GetJobs() int64 {
+	if m != nil {
+		return m.Jobs
+	}
+	return 0
+}
+
+func (m *Backup) GetRuns() int64 {
+	if m != nil {
+		return m.Runs
+	}
+	return 0
+}
+
+func (m *Backup) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+type BackupJobsReq struct {
+	// Leave the runs out of the backup
+	JobsOnly             bool     `protobuf:"varint,1,opt,name=jobs_only,json=jobsOnly,proto3" json:"jobs_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupJobsReq) Reset()         { *m = BackupJobsReq{} }
+func (m *BackupJobsReq) String() string { return proto.CompactTextString(m) }
+func (*BackupJobsReq) ProtoMessage()    {}
+func (*BackupJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *BackupJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupJobsReq.Unmarshal(m, b)
+}
+func (m *BackupJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupJobsReq.Marshal(b, m, deterministic)
+}
+func (m *BackupJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupJobsReq.Merge(m, src)
+}
+func (m *BackupJobsReq) XXX_Size() int {
+	return xxx_messageInfo_BackupJobsReq.Size(m)
+}
+func (m *BackupJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupJobsReq proto.InternalMessageInfo
+
+func (m *BackupJobsReq) GetJobsOnly() bool {
+	if m != nil {
+		return m.JobsOnly
+	}
+	return false
+}
+
+type BackupJobsRes struct {
+	Backup               *Backup  `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupJobsRes) Reset()         { *m = BackupJobsRes{} }
+func (m *BackupJobsRes) String() string { return proto.CompactTextString(m) }
+func (*BackupJobsRes) ProtoMessage()    {}
+func (*BackupJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *BackupJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupJobsRes.Unmarshal(m, b)
+}
+func (m *BackupJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupJobsRes.Marshal(b, m, deterministic)
+}
+func (m *BackupJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupJobsRes.Merge(m, src)
+}
+func (m *BackupJobsRes) XXX_Size() int {
+	return xxx_messageInfo_BackupJobsRes.Size(m)
+}
+func (m *BackupJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupJobsRes proto.InternalMessageInfo
+
+func (m *BackupJobsRes) GetBackup() *Backup {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+type RestoreJobsReq struct {
+	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ConflictPolicy       ConflictPolicy `protobuf:"varint,2,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=model.ConflictPolicy" json:"conflict_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RestoreJobsReq) Reset()         { *m = RestoreJobsReq{} }
+func (m *RestoreJobsReq) String() string { return proto.CompactTextString(m) }
+func (*RestoreJobsReq) ProtoMessage()    {}
+func (*RestoreJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *RestoreJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreJobsReq.Unmarshal(m, b)
+}
+func (m *RestoreJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreJobsReq.Marshal(b, m, deterministic)
+}
+func (m *RestoreJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreJobsReq.Merge(m, src)
+}
+func (m *RestoreJobsReq) XXX_Size() int {
+	return xxx_messageInfo_RestoreJobsReq.Size(m)
+}
+func (m *RestoreJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreJobsReq proto.InternalMessageInfo
+
+func (m *RestoreJobsReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RestoreJobsReq) GetConflictPolicy() ConflictPolicy {
+	if m != nil {
+		return m.ConflictPolicy
+	}
+	return ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED
+}
+
+type RestoreJobsRes struct {
+	RestoredJobs         int64    `protobuf:"varint,1,opt,name=restored_jobs,json=restoredJobs,proto3" json:"restored_jobs,omitempty"`
+	SkippedJobs          int64    `protobuf:"varint,2,opt,name=skipped_jobs,json=skippedJobs,proto3" json:"skipped_jobs,omitempty"`
+	RestoredRuns         int64    `protobuf:"varint,3,opt,name=restored_runs,json=restoredRuns,proto3" json:"restored_runs,omitempty"`
+	SkippedRuns          int64    `protobuf:"varint,4,opt,name=skipped_runs,json=skippedRuns,proto3" json:"skipped_runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreJobsRes) Reset()         { *m = RestoreJobsRes{} }
+func (m *RestoreJobsRes) String() string { return proto.CompactTextString(m) }
+func (*RestoreJobsRes) ProtoMessage()    {}
+func (*RestoreJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *RestoreJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreJobsRes.Unmarshal(m, b)
+}
+func (m *RestoreJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreJobsRes.Marshal(b, m, deterministic)
+}
+func (m *RestoreJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreJobsRes.Merge(m, src)
+}
+func (m *RestoreJobsRes) XXX_Size() int {
+	return xxx_messageInfo_RestoreJobsRes.Size(m)
+}
+func (m *RestoreJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreJobsRes proto.InternalMessageInfo
+
+func (m *RestoreJobsRes) GetRestoredJobs() int64 {
+	if m != nil {
+		return m.RestoredJobs
+	}
+	return 0
+}
+
+func (m *RestoreJobsRes) GetSkippedJobs() int64 {
+	if m != nil {
+		return m.SkippedJobs
+	}
+	return 0
+}
+
+func (m *RestoreJobsRes) GetRestoredRuns() int64 {
+	if m != nil {
+		return m.RestoredRuns
+	}
+	return 0
+}
+
+func (m *RestoreJobsRes) GetSkippedRuns() int64 {
+	if m != nil {
+		return m.SkippedRuns
+	}
+	return 0
+}
+
+// Maintenance is the maintenance mode of the scheduler, it is shared by all tenants
+type Maintenance struct {
+	// While enabled the scheduler doesn't fire jobs or retry runs and jobs can't be triggered
+	Enabled bool   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Reason  string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Subject of the admin who last enabled or disabled it
+	UpdatedBy            string               `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Maintenance) Reset()         { *m = Maintenance{} }
+func (m *Maintenance) String() string { return proto.CompactTextString(m) }
+func (*Maintenance) ProtoMessage()    {}
+func (*Maintenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{5}
+}
+
+func (m *Maintenance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Maintenance.Unmarshal(m, b)
+}
+func (m *Maintenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Maintenance.Marshal(b, m, deterministic)
+}
+func (m *Maintenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Maintenance.Merge(m, src)
+}
+func (m *Maintenance) XXX_Size() int {
+	return xxx_messageInfo_Maintenance.Size(m)
+}
+func (m *Maintenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Maintenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Maintenance proto.InternalMessageInfo
+
+func (m *Maintenance) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Maintenance) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Maintenance) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+func (m *Maintenance) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetMaintenanceReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMaintenanceReq) Reset()         { *m = GetMaintenanceReq{} }
+func (m *GetMaintenanceReq) String() string { return proto.CompactTextString(m) }
+func (*GetMaintenanceReq) ProtoMessage()    {}
+func (*GetMaintenanceReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{6}
+}
+
+func (m *GetMaintenanceReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMaintenanceReq.Unmarshal(m, b)
+}
+func (m *GetMaintenanceReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMaintenanceReq.Marshal(b, m, deterministic)
+}
+func (m *GetMaintenanceReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMaintenanceReq.Merge(m, src)
+}
+func (m *GetMaintenanceReq) XXX_Size() int {
+	return xxx_messageInfo_GetMaintenanceReq.Size(m)
+}
+func (m *GetMaintenanceReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMaintenanceReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMaintenanceReq proto.InternalMessageInfo
+
+type GetMaintenanceRes struct {
+	Maintenance          *Maintenance `protobuf:"bytes,1,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetMaintenanceRes) Reset()         { *m = GetMaintenanceRes{} }
+func (m *GetMaintenanceRes) String() string { return proto.CompactTextString(m) }
+func (*GetMaintenanceRes) ProtoMessage()    {}
+func (*GetMaintenanceRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{7}
+}
+
+func (m *GetMaintenanceRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMaintenanceRes.Unmarshal(m, b)
+}
+func (m *GetMaintenanceRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMaintenanceRes.Marshal(b, m, deterministic)
+}
+func (m *GetMaintenanceRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMaintenanceRes.Merge(m, src)
+}
+func (m *GetMaintenanceRes) XXX_Size() int {
+	return xxx_messageInfo_GetMaintenanceRes.Size(m)
+}
+func (m *GetMaintenanceRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMaintenanceRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMaintenanceRes proto.InternalMessageInfo
+
+func (m *GetMaintenanceRes) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+type EnableMaintenanceReq struct {
+	// Why the scheduler is paused, shown to users whose triggers are rejected
+	Reason               string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnableMaintenanceReq) Reset()         { *m = EnableMaintenanceReq{} }
+func (m *EnableMaintenanceReq) String() string { return proto.CompactTextString(m) }
+func (*EnableMaintenanceReq) ProtoMessage()    {}
+func (*EnableMaintenanceReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{8}
+}
+
+func (m *EnableMaintenanceReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnableMaintenanceReq.Unmarshal(m, b)
+}
+func (m *EnableMaintenanceReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnableMaintenanceReq.Marshal(b, m, deterministic)
+}
+func (m *EnableMaintenanceReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnableMaintenanceReq.Merge(m, src)
+}
+func (m *EnableMaintenanceReq) XXX_Size() int {
+	return xxx_messageInfo_EnableMaintenanceReq.Size(m)
+}
+func (m *EnableMaintenanceReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnableMaintenanceReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnableMaintenanceReq proto.InternalMessageInfo
+
+func (m *EnableMaintenanceReq) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type EnableMaintenanceRes struct {
+	Maintenance          *Maintenance `protobuf:"bytes,1,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *EnableMaintenanceRes) Reset()         { *m = EnableMaintenanceRes{} }
+func (m *EnableMaintenanceRes) String() string { return proto.CompactTextString(m) }
+func (*EnableMaintenanceRes) ProtoMessage()    {}
+func (*EnableMaintenanceRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{9}
+}
+
+func (m *EnableMaintenanceRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnableMaintenanceRes.Unmarshal(m, b)
+}
+func (m *EnableMaintenanceRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnableMaintenanceRes.Marshal(b, m, deterministic)
+}
+func (m *EnableMaintenanceRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnableMaintenanceRes.Merge(m, src)
+}
+func (m *EnableMaintenanceRes) XXX_Size() int {
+	return xxx_messageInfo_EnableMaintenanceRes.Size(m)
+}
+func (m *EnableMaintenanceRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnableMaintenanceRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnableMaintenanceRes proto.InternalMessageInfo
+
+func (m *EnableMaintenanceRes) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+type DisableMaintenanceReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisableMaintenanceReq) Reset()         { *m = DisableMaintenanceReq{} }
+func (m *DisableMaintenanceReq) String() string { return proto.CompactTextString(m) }
+func (*DisableMaintenanceReq) ProtoMessage()    {}
+func (*DisableMaintenanceReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{10}
+}
+
+func (m *DisableMaintenanceReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisableMaintenanceReq.Unmarshal(m, b)
+}
+func (m *DisableMaintenanceReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisableMaintenanceReq.Marshal(b, m, deterministic)
+}
+func (m *DisableMaintenanceReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisableMaintenanceReq.Merge(m, src)
+}
+func (m *DisableMaintenanceReq) XXX_Size() int {
+	return xxx_messageInfo_DisableMaintenanceReq.Size(m)
+}
+func (m *DisableMaintenanceReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisableMaintenanceReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisableMaintenanceReq proto.InternalMessageInfo
+
+type DisableMaintenanceRes struct {
+	Maintenance          *Maintenance `protobuf:"bytes,1,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DisableMaintenanceRes) Reset()         { *m = DisableMaintenanceRes{} }
+func (m *DisableMaintenanceRes) String() string { return proto.CompactTextString(m) }
+func (*DisableMaintenanceRes) ProtoMessage()    {}
+func (*DisableMaintenanceRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{11}
+}
+
+func (m *DisableMaintenanceRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisableMaintenanceRes.Unmarshal(m, b)
+}
+func (m *DisableMaintenanceRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisableMaintenanceRes.Marshal(b, m, deterministic)
+}
+func (m *DisableMaintenanceRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisableMaintenanceRes.Merge(m, src)
+}
+func (m *DisableMaintenanceRes) XXX_Size() int {
+	return xxx_messageInfo_DisableMaintenanceRes.Size(m)
+}
+func (m *DisableMaintenanceRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisableMaintenanceRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisableMaintenanceRes proto.InternalMessageInfo
+
+func (m *DisableMaintenanceRes) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+// Environment is the scheduling state of an environment of the jobs of the caller's tenant
+type Environment struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// While paused the scheduler doesn't fire the jobs in the environment and they can't be triggered
+	Paused bool   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Subject of the admin who last paused or resumed it
+	UpdatedBy string               `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Updates of the jobs in a protected environment need the approval of a second person
+	Protected bool `protobuf:"varint,6,opt,name=protected,proto3" json:"protected,omitempty"`
+	// Subject of the admin who last protected or unprotected it
+	ProtectedBy          string               `protobuf:"bytes,7,opt,name=protected_by,json=protectedBy,proto3" json:"protected_by,omitempty"`
+	ProtectedAt          *timestamp.Timestamp `protobuf:"bytes,8,opt,name=protected_at,json=protectedAt,proto3" json:"protected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Environment) Reset()         { *m = Environment{} }
+func (m *Environment) String() string { return proto.CompactTextString(m) }
+func (*Environment) ProtoMessage()    {}
+func (*Environment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{12}
+}
+
+func (m *Environment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Environment.Unmarshal(m, b)
+}
+func (m *Environment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Environment.Marshal(b, m, deterministic)
+}
+func (m *Environment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Environment.Merge(m, src)
+}
+func (m *Environment) XXX_Size() int {
+	return xxx_messageInfo_Environment.Size(m)
+}
+func (m *Environment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Environment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Environment proto.InternalMessageInfo
+
+func (m *Environment) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Environment) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *Environment) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Environment) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+func (m *Environment) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Environment) GetProtected() bool {
+	if m != nil {
+		return m.Protected
+	}
+	return false
+}
+
+func (m *Environment) GetProtectedBy() string {
+	if m != nil {
+		return m.ProtectedBy
+	}
+	return ""
+}
+
+func (m *Environment) GetProtectedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ProtectedAt
+	}
+	return nil
+}
+
+type ListEnvironmentsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEnvironmentsReq) Reset()         { *m = ListEnvironmentsReq{} }
+func (m *ListEnvironmentsReq) String() string { return proto.CompactTextString(m) }
+func (*ListEnvironmentsReq) ProtoMessage()    {}
+func (*ListEnvironmentsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{13}
+}
+
+func (m *ListEnvironmentsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEnvironmentsReq.Unmarshal(m, b)
+}
+func (m *ListEnvironmentsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEnvironmentsReq.Marshal(b, m, deterministic)
+}
+func (m *ListEnvironmentsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEnvironmentsReq.Merge(m, src)
+}
+func (m *ListEnvironmentsReq) XXX_Size() int {
+	return xxx_messageInfo_ListEnvironmentsReq.Size(m)
+}
+func (m *ListEnvironmentsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEnvironmentsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEnvironmentsReq proto.InternalMessageInfo
+
+type ListEnvironmentsRes struct {
+	// The environments that were ever paused or protected ordered by name, the others run unprotected
+	Environments         []*Environment `protobuf:"bytes,1,rep,name=environments,proto3" json:"environments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListEnvironmentsRes) Reset()         { *m = ListEnvironmentsRes{} }
+func (m *ListEnvironmentsRes) String() string { return proto.CompactTextString(m) }
+func (*ListEnvironmentsRes) ProtoMessage()    {}
+func (*ListEnvironmentsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{14}
+}
+
+func (m *ListEnvironmentsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEnvironmentsRes.Unmarshal(m, b)
+}
+func (m *ListEnvironmentsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEnvironmentsRes.Marshal(b, m, deterministic)
+}
+func (m *ListEnvironmentsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEnvironmentsRes.Merge(m, src)
+}
+func (m *ListEnvironmentsRes) XXX_Size() int {
+	return xxx_messageInfo_ListEnvironmentsRes.Size(m)
+}
+func (m *ListEnvironmentsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEnvironmentsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEnvironmentsRes proto.InternalMessageInfo
+
+func (m *ListEnvironmentsRes) GetEnvironments() []*Environment {
+	if m != nil {
+		return m.Environments
+	}
+	return nil
+}
+
+type PauseEnvironmentReq struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Why the environment is paused, shown to users whose triggers are rejected
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseEnvironmentReq) Reset()         { *m = PauseEnvironmentReq{} }
+func (m *PauseEnvironmentReq) String() string { return proto.CompactTextString(m) }
+func (*PauseEnvironmentReq) ProtoMessage()    {}
+func (*PauseEnvironmentReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{15}
+}
+
+func (m *PauseEnvironmentReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseEnvironmentReq.Unmarshal(m, b)
+}
+func (m *PauseEnvironmentReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseEnvironmentReq.Marshal(b, m, deterministic)
+}
+func (m *PauseEnvironmentReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseEnvironmentReq.Merge(m, src)
+}
+func (m *PauseEnvironmentReq) XXX_Size() int {
+	return xxx_messageInfo_PauseEnvironmentReq.Size(m)
+}
+func (m *PauseEnvironmentReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseEnvironmentReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseEnvironmentReq proto.InternalMessageInfo
+
+func (m *PauseEnvironmentReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PauseEnvironmentReq) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type PauseEnvironmentRes struct {
+	Environment          *Environment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PauseEnvironmentRes) Reset()         { *m = PauseEnvironmentRes{} }
+func (m *PauseEnvironmentRes) String() string { return proto.CompactTextString(m) }
+func (*PauseEnvironmentRes) ProtoMessage()    {}
+func (*PauseEnvironmentRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{16}
+}
+
+func (m *PauseEnvironmentRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseEnvironmentRes.Unmarshal(m, b)
+}
+func (m *PauseEnvironmentRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseEnvironmentRes.Marshal(b, m, deterministic)
+}
+func (m *PauseEnvironmentRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseEnvironmentRes.Merge(m, src)
+}
+func (m *PauseEnvironmentRes) XXX_Size() int {
+	return xxx_messageInfo_PauseEnvironmentRes.Size(m)
+}
+func (m *PauseEnvironmentRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseEnvironmentRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseEnvironmentRes proto.InternalMessageInfo
+
+func (m *PauseEnvironmentRes) GetEnvironment() *Environment {
+	if m != nil {
+		return m.Environment
+	}
+	return nil
+}
+
+type ResumeEnvironmentReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeEnvironmentReq) Reset()         { *m = ResumeEnvironmentReq{} }
+func (m *ResumeEnvironmentReq) String() string { return proto.CompactTextString(m) }
+func (*ResumeEnvironmentReq) ProtoMessage()    {}
+func (*ResumeEnvironmentReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{17}
+}
+
+func (m *ResumeEnvironmentReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeEnvironmentReq.Unmarshal(m, b)
+}
+func (m *ResumeEnvironmentReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeEnvironmentReq.Marshal(b, m, deterministic)
+}
+func (m *ResumeEnvironmentReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeEnvironmentReq.Merge(m, src)
+}
+func (m *ResumeEnvironmentReq) XXX_Size() int {
+	return xxx_messageInfo_ResumeEnvironmentReq.Size(m)
+}
+func (m *ResumeEnvironmentReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeEnvironmentReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeEnvironmentReq proto.InternalMessageInfo
+
+func (m *ResumeEnvironmentReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ResumeEnvironmentRes struct {
+	Environment          *Environment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ResumeEnvironmentRes) Reset()         { *m = ResumeEnvironmentRes{} }
+func (m *ResumeEnvironmentRes) String() string { return proto.CompactTextString(m) }
+func (*ResumeEnvironmentRes) ProtoMessage()    {}
+func (*ResumeEnvironmentRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{18}
+}
+
+func (m *ResumeEnvironmentRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeEnvironmentRes.Unmarshal(m, b)
+}
+func (m *ResumeEnvironmentRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeEnvironmentRes.Marshal(b, m, deterministic)
+}
+func (m *ResumeEnvironmentRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeEnvironmentRes.Merge(m, src)
+}
+func (m *ResumeEnvironmentRes) XXX_Size() int {
+	return xxx_messageInfo_ResumeEnvironmentRes.Size(m)
+}
+func (m *ResumeEnvironmentRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeEnvironmentRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeEnvironmentRes proto.InternalMessageInfo
+
+func (m *ResumeEnvironmentRes) GetEnvironment() *Environment {
+	if m != nil {
+		return m.Environment
+	}
+	return nil
+}
+
+type ProtectEnvironmentReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProtectEnvironmentReq) Reset()         { *m = ProtectEnvironmentReq{} }
+func (m *ProtectEnvironmentReq) String() string { return proto.CompactTextString(m) }
+func (*ProtectEnvironmentReq) ProtoMessage()    {}
+func (*ProtectEnvironmentReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{19}
+}
+
+func (m *ProtectEnvironmentReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProtectEnvironmentReq.Unmarshal(m, b)
+}
+func (m *ProtectEnvironmentReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProtectEnvironmentReq.Marshal(b, m, deterministic)
+}
+func (m *ProtectEnvironmentReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtectEnvironmentReq.Merge(m, src)
+}
+func (m *ProtectEnvironmentReq) XXX_Size() int {
+	return xxx_messageInfo_ProtectEnvironmentReq.Size(m)
+}
+func (m *ProtectEnvironmentReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtectEnvironmentReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtectEnvironmentReq proto.InternalMessageInfo
+
+func (m *ProtectEnvironmentReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ProtectEnvironmentRes struct {
+	Environment          *Environment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ProtectEnvironmentRes) Reset()         { *m = ProtectEnvironmentRes{} }
+func (m *ProtectEnvironmentRes) String() string { return proto.CompactTextString(m) }
+func (*ProtectEnvironmentRes) ProtoMessage()    {}
+func (*ProtectEnvironmentRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{20}
+}
+
+func (m *ProtectEnvironmentRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProtectEnvironmentRes.Unmarshal(m, b)
+}
+func (m *ProtectEnvironmentRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProtectEnvironmentRes.Marshal(b, m, deterministic)
+}
+func (m *ProtectEnvironmentRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtectEnvironmentRes.Merge(m, src)
+}
+func (m *ProtectEnvironmentRes) XXX_Size() int {
+	return xxx_messageInfo_ProtectEnvironmentRes.Size(m)
+}
+func (m *ProtectEnvironmentRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtectEnvironmentRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtectEnvironmentRes proto.InternalMessageInfo
+
+func (m *ProtectEnvironmentRes) GetEnvironment() *Environment {
+	if m != nil {
+		return m.Environment
+	}
+	return nil
+}
+
+type UnprotectEnvironmentReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnprotectEnvironmentReq) Reset()         { *m = UnprotectEnvironmentReq{} }
+func (m *UnprotectEnvironmentReq) String() string { return proto.CompactTextString(m) }
+func (*UnprotectEnvironmentReq) ProtoMessage()    {}
+func (*UnprotectEnvironmentReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{21}
+}
+
+func (m *UnprotectEnvironmentReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnprotectEnvironmentReq.Unmarshal(m, b)
+}
+func (m *UnprotectEnvironmentReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnprotectEnvironmentReq.Marshal(b, m, deterministic)
+}
+func (m *UnprotectEnvironmentReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnprotectEnvironmentReq.Merge(m, src)
+}
+func (m *UnprotectEnvironmentReq) XXX_Size() int {
+	return xxx_messageInfo_UnprotectEnvironmentReq.Size(m)
+}
+func (m *UnprotectEnvironmentReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnprotectEnvironmentReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnprotectEnvironmentReq proto.InternalMessageInfo
+
+func (m *UnprotectEnvironmentReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UnprotectEnvironmentRes struct {
+	Environment          *Environment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UnprotectEnvironmentRes) Reset()         { *m = UnprotectEnvironmentRes{} }
+func (m *UnprotectEnvironmentRes) String() string { return proto.CompactTextString(m) }
+func (*UnprotectEnvironmentRes) ProtoMessage()    {}
+func (*UnprotectEnvironmentRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{22}
+}
+
+func (m *UnprotectEnvironmentRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnprotectEnvironmentRes.Unmarshal(m, b)
+}
+func (m *UnprotectEnvironmentRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnprotectEnvironmentRes.Marshal(b, m, deterministic)
+}
+func (m *UnprotectEnvironmentRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnprotectEnvironmentRes.Merge(m, src)
+}
+func (m *UnprotectEnvironmentRes) XXX_Size() int {
+	return xxx_messageInfo_UnprotectEnvironmentRes.Size(m)
+}
+func (m *UnprotectEnvironmentRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnprotectEnvironmentRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnprotectEnvironmentRes proto.InternalMessageInfo
+
+func (m *UnprotectEnvironmentRes) GetEnvironment() *Environment {
+	if m != nil {
+		return m.Environment
+	}
+	return nil
+}
+
+// ConfigSetting is a setting of the configuration the server runs with, named like its flag. Passwords and keys are
+// redacted.
+type ConfigSetting struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Whether a change of the setting applies when the configuration is reloaded
+	Reloadable bool `protobuf:"varint,3,opt,name=reloadable,proto3" json:"reloadable,omitempty"`
+	// Whether the configuration was reloaded with the pending_value, which only applies after a restart
+	RestartRequired      bool     `protobuf:"varint,4,opt,name=restart_required,json=restartRequired,proto3" json:"restart_required,omitempty"`
+	PendingValue         string   `protobuf:"bytes,5,opt,name=pending_value,json=pendingValue,proto3" json:"pending_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigSetting) Reset()         { *m = ConfigSetting{} }
+func (m *ConfigSetting) String() string { return proto.CompactTextString(m) }
+func (*ConfigSetting) ProtoMessage()    {}
+func (*ConfigSetting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{23}
+}
+
+func (m *ConfigSetting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigSetting.Unmarshal(m, b)
+}
+func (m *ConfigSetting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigSetting.Marshal(b, m, deterministic)
+}
+func (m *ConfigSetting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigSetting.Merge(m, src)
+}
+func (m *ConfigSetting) XXX_Size() int {
+	return xxx_messageInfo_ConfigSetting.Size(m)
+}
+func (m *ConfigSetting) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigSetting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigSetting proto.InternalMessageInfo
+
+func (m *ConfigSetting) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConfigSetting) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ConfigSetting) GetReloadable() bool {
+	if m != nil {
+		return m.Reloadable
+	}
+	return false
+}
+
+func (m *ConfigSetting) GetRestartRequired() bool {
+	if m != nil {
+		return m.RestartRequired
+	}
+	return false
+}
+
+func (m *ConfigSetting) GetPendingValue() string {
+	if m != nil {
+		return m.PendingValue
+	}
+	return ""
+}
+
+type GetConfigReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigReq) Reset()         { *m = GetConfigReq{} }
+func (m *GetConfigReq) String() string { return proto.CompactTextString(m) }
+func (*GetConfigReq) ProtoMessage()    {}
+func (*GetConfigReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{24}
+}
+
+func (m *GetConfigReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigReq.Unmarshal(m, b)
+}
+func (m *GetConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigReq.Marshal(b, m, deterministic)
+}
+func (m *GetConfigReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigReq.Merge(m, src)
+}
+func (m *GetConfigReq) XXX_Size() int {
+	return xxx_messageInfo_GetConfigReq.Size(m)
+}
+func (m *GetConfigReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigReq proto.InternalMessageInfo
+
+type GetConfigRes struct {
+	// Sorted by name
+	Settings []*ConfigSetting     `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty"`
+	LoadedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=loaded_at,json=loadedAt,proto3" json:"loaded_at,omitempty"`
+	// Not set before the configuration was reloaded successfully
+	ReloadedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=reloaded_at,json=reloadedAt,proto3" json:"reloaded_at,omitempty"`
+	// Why the last reload failed, empty when it didn't
+	ReloadError          string   `protobuf:"bytes,4,opt,name=reload_error,json=reloadError,proto3" json:"reload_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigRes) Reset()         { *m = GetConfigRes{} }
+func (m *GetConfigRes) String() string { return proto.CompactTextString(m) }
+func (*GetConfigRes) ProtoMessage()    {}
+func (*GetConfigRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{25}
+}
+
+func (m *GetConfigRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigRes.Unmarshal(m, b)
+}
+func (m *GetConfigRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigRes.Marshal(b, m, deterministic)
+}
+func (m *GetConfigRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRes.Merge(m, src)
+}
+func (m *GetConfigRes) XXX_Size() int {
+	return xxx_messageInfo_GetConfigRes.Size(m)
+}
+func (m *GetConfigRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigRes proto.InternalMessageInfo
+
+func (m *GetConfigRes) GetSettings() []*ConfigSetting {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *GetConfigRes) GetLoadedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LoadedAt
+	}
+	return nil
+}
+
+func (m *GetConfigRes) GetReloadedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ReloadedAt
+	}
+	return nil
+}
+
+func (m *GetConfigRes) GetReloadError() string {
+	if m != nil {
+		return m.ReloadError
+	}
+	return ""
+}
+
+// SubsystemLogLevel is the level the loggers of a subsystem log at
+type SubsystemLogLevel struct {
+	// One of grpc, notifications, scheduler and storage
+	Subsystem string `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	// One of debug, info, warn and error
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// Whether the subsystem logs at the global level, since no level was set for it
+	Inherited            bool     `protobuf:"varint,3,opt,name=inherited,proto3" json:"inherited,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubsystemLogLevel) Reset()         { *m = SubsystemLogLevel{} }
+func (m *SubsystemLogLevel) String() string { return proto.CompactTextString(m) }
+func (*SubsystemLogLevel) ProtoMessage()    {}
+func (*SubsystemLogLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{26}
+}
+
+func (m *SubsystemLogLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubsystemLogLevel.Unmarshal(m, b)
+}
+func (m *SubsystemLogLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubsystemLogLevel.Marshal(b, m, deterministic)
+}
+func (m *SubsystemLogLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubsystemLogLevel.Merge(m, src)
+}
+func (m *SubsystemLogLevel) XXX_Size() int {
+	return xxx_messageInfo_SubsystemLogLevel.Size(m)
+}
+func (m *SubsystemLogLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubsystemLogLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubsystemLogLevel proto.InternalMessageInfo
+
+func (m *SubsystemLogLevel) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+func (m *SubsystemLogLevel) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SubsystemLogLevel) GetInherited() bool {
+	if m != nil {
+		return m.Inherited
+	}
+	return false
+}
+
+type GetLogLevelReq struct {
+	// Returns the level of this subsystem only, empty for the global level and the ones of every subsystem
+	Subsystem            string   `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogLevelReq) Reset()         { *m = GetLogLevelReq{} }
+func (m *GetLogLevelReq) String() string { return proto.CompactTextString(m) }
+func (*GetLogLevelReq) ProtoMessage()    {}
+func (*GetLogLevelReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{27}
+}
+
+func (m *GetLogLevelReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogLevelReq.Unmarshal(m, b)
+}
+func (m *GetLogLevelReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogLevelReq.Marshal(b, m, deterministic)
+}
+func (m *GetLogLevelReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogLevelReq.Merge(m, src)
+}
+func (m *GetLogLevelReq) XXX_Size() int {
+	return xxx_messageInfo_GetLogLevelReq.Size(m)
+}
+func (m *GetLogLevelReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogLevelReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogLevelReq proto.InternalMessageInfo
+
+func (m *GetLogLevelReq) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+type GetLogLevelRes struct {
+	// The global level, or the one of the subsystem of the request
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Every subsystem, sorted by name. Empty when the request asked for a single subsystem.
+	Subsystems           []*SubsystemLogLevel `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetLogLevelRes) Reset()         { *m = GetLogLevelRes{} }
+func (m *GetLogLevelRes) String() string { return proto.CompactTextString(m) }
+func (*GetLogLevelRes) ProtoMessage()    {}
+func (*GetLogLevelRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{28}
+}
+
+func (m *GetLogLevelRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogLevelRes.Unmarshal(m, b)
+}
+func (m *GetLogLevelRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogLevelRes.Marshal(b, m, deterministic)
+}
+func (m *GetLogLevelRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogLevelRes.Merge(m, src)
+}
+func (m *GetLogLevelRes) XXX_Size() int {
+	return xxx_messageInfo_GetLogLevelRes.Size(m)
+}
+func (m *GetLogLevelRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogLevelRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogLevelRes proto.InternalMessageInfo
+
+func (m *GetLogLevelRes) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *GetLogLevelRes) GetSubsystems() []*SubsystemLogLevel {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+type SetLogLevelReq struct {
+	// One of debug, info, warn and error. Empty with a subsystem to let the subsystem log at the global level again.
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Sets the level of this subsystem only, empty to set the global level
+	Subsystem            string   `protobuf:"bytes,2,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelReq) Reset()         { *m = SetLogLevelReq{} }
+func (m *SetLogLevelReq) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelReq) ProtoMessage()    {}
+func (*SetLogLevelReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{29}
+}
+
+func (m *SetLogLevelReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelReq.Unmarshal(m, b)
+}
+func (m *SetLogLevelReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelReq.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelReq.Merge(m, src)
+}
+func (m *SetLogLevelReq) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelReq.Size(m)
+}
+func (m *SetLogLevelReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelReq proto.InternalMessageInfo
+
+func (m *SetLogLevelReq) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SetLogLevelReq) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+type SetLogLevelRes struct {
+	// The global level
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Every subsystem, sorted by name
+	Subsystems           []*SubsystemLogLevel `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SetLogLevelRes) Reset()         { *m = SetLogLevelRes{} }
+func (m *SetLogLevelRes) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelRes) ProtoMessage()    {}
+func (*SetLogLevelRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{30}
+}
+
+func (m *SetLogLevelRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelRes.Unmarshal(m, b)
+}
+func (m *SetLogLevelRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelRes.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelRes.Merge(m, src)
+}
+func (m *SetLogLevelRes) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelRes.Size(m)
+}
+func (m *SetLogLevelRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelRes proto.InternalMessageInfo
+
+func (m *SetLogLevelRes) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SetLogLevelRes) GetSubsystems() []*SubsystemLogLevel {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("model.ConflictPolicy", ConflictPolicy_name, ConflictPolicy_value)
+	proto.RegisterType((*Backup)(nil), "model.Backup")
+	proto.RegisterType((*BackupJobsReq)(nil), "model.BackupJobsReq")
+	proto.RegisterType((*BackupJobsRes)(nil), "model.BackupJobsRes")
+	proto.RegisterType((*RestoreJobsReq)(nil), "model.RestoreJobsReq")
+	proto.RegisterType((*RestoreJobsRes)(nil), "model.RestoreJobsRes")
+	proto.RegisterType((*Maintenance)(nil), "model.Maintenance")
+	proto.RegisterType((*GetMaintenanceReq)(nil), "model.GetMaintenanceReq")
+	proto.RegisterType((*GetMaintenanceRes)(nil), "model.GetMaintenanceRes")
+	proto.RegisterType((*EnableMaintenanceReq)(nil), "model.EnableMaintenanceReq")
+	proto.RegisterType((*EnableMaintenanceRes)(nil), "model.EnableMaintenanceRes")
+	proto.RegisterType((*DisableMaintenanceReq)(nil), "model.DisableMaintenanceReq")
+	proto.RegisterType((*DisableMaintenanceRes)(nil), "model.DisableMaintenanceRes")
+	proto.RegisterType((*Environment)(nil), "model.Environment")
+	proto.RegisterType((*ListEnvironmentsReq)(nil), "model.ListEnvironmentsReq")
+	proto.RegisterType((*ListEnvironmentsRes)(nil), "model.ListEnvironmentsRes")
+	proto.RegisterType((*PauseEnvironmentReq)(nil), "model.PauseEnvironmentReq")
+	proto.RegisterType((*PauseEnvironmentRes)(nil), "model.PauseEnvironmentRes")
+	proto.RegisterType((*ResumeEnvironmentReq)(nil), "model.ResumeEnvironmentReq")
+	proto.RegisterType((*ResumeEnvironmentRes)(nil), "model.ResumeEnvironmentRes")
+	proto.RegisterType((*ProtectEnvironmentReq)(nil), "model.ProtectEnvironmentReq")
+	proto.RegisterType((*ProtectEnvironmentRes)(nil), "model.ProtectEnvironmentRes")
+	proto.RegisterType((*UnprotectEnvironmentReq)(nil), "model.UnprotectEnvironmentReq")
+	proto.RegisterType((*UnprotectEnvironmentRes)(nil), "model.UnprotectEnvironmentRes")
+	proto.RegisterType((*ConfigSetting)(nil), "model.ConfigSetting")
+	proto.RegisterType((*GetConfigReq)(nil), "model.GetConfigReq")
+	proto.RegisterType((*GetConfigRes)(nil), "model.GetConfigRes")
+	proto.RegisterType((*SubsystemLogLevel)(nil), "model.SubsystemLogLevel")
+	proto.RegisterType((*GetLogLevelReq)(nil), "model.GetLogLevelReq")
+	proto.RegisterType((*GetLogLevelRes)(nil), "model.GetLogLevelRes")
+	proto.RegisterType((*SetLogLevelReq)(nil), "model.SetLogLevelReq")
+	proto.RegisterType((*SetLogLevelRes)(nil), "model.SetLogLevelRes")
+}
+
+func init() { proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c) }
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 1400 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x41, 0x73, 0x13, 0x37,
+	0x1b, 0xfe, 0x36, 0x4e, 0x82, 0xfd, 0x3a, 0x31, 0x8e, 0xe2, 0x84, 0xfd, 0x36, 0x09, 0x04, 0xf1,
+	0x31, 0x84, 0x00, 0x36, 0x5f, 0xda, 0xa1, 0x6d, 0x3a, 0xed, 0x8c, 0x13, 0x0c, 0xe3, 0x62, 0x48,
+	0x66, 0x0d, 0xb4, 0x9d, 0x1e, 0xdc, 0xb5, 0x2d, 0xcc, 0x82, 0xbd, 0x6b, 0x56, 0x72, 0x66, 0x4c,
+	0xa7, 0x3d, 0x30, 0x3d, 0xf5, 0xda, 0x53, 0x2f, 0x9d, 0x5e, 0x3b, 0xfc, 0x9c, 0x9e, 0x7a, 0xef,
+	0x0f, 0xe9, 0x48, 0xab, 0xf5, 0x6a, 0x6d, 0xd9, 0x49, 0x33, 0xd3, 0xdb, 0xea, 0x79, 0x1f, 0xbd,
+	0xcf, 0x2b, 0xbd, 0x5a, 0xe9, 0x81, 0xac, 0xd3, 0xee, 0xb9, 0x5e, 0xb1, 0x1f, 0xf8, 0xcc, 0x47,
+	0x0b, 0x3d, 0xbf, 0x4d, 0xba, 0xd6, 0x66, 0xc7, 0xf7, 0x3b, 0x5d, 0x52, 0x72, 0xfa, 0x6e, 0xc9,
+	0xf1, 0x3c, 0x9f, 0x39, 0xcc, 0xf5, 0x3d, 0x1a, 0x92, 0xac, 0x2b, 0x32, 0x2a, 0x46, 0xcd, 0xc1,
+	0x8b, 0x12, 0x73, 0x7b, 0x84, 0x32, 0xa7, 0xd7, 0x0f, 0x09, 0xf8, 0x57, 0x03, 0x16, 0x0f, 0x9c,
+	0xd6, 0xeb, 0x41, 0x1f, 0x21, 0x98, 0xf7, 0x9c, 0x1e, 0x31, 0x8d, 0x6d, 0x63, 0x27, 0x63, 0x8b,
+	0x6f, 0xf4, 0x09, 0x40, 0x2b, 0x20, 0x0e, 0x23, 0xed, 0x86, 0xc3, 0xcc, 0xb9, 0x6d, 0x63, 0x27,
+	0xbb, 0x67, 0x15, 0xc3, 0xa4, 0xc5, 0x28, 0x69, 0xf1, 0x69, 0x94, 0xd4, 0xce, 0x48, 0x76, 0x99,
+	0xf1, 0x74, 0xaf, 0xfc, 0x26, 0x35, 0x53, 0xdb, 0xc6, 0x4e, 0xca, 0x16, 0xdf, 0x1c, 0x0b, 0x06,
+	0x1e, 0x35, 0xe7, 0x43, 0x8c, 0x7f, 0xa3, 0x2d, 0x00, 0xea, 0xbe, 0x25, 0x8d, 0xe6, 0x90, 0x11,
+	0x6a, 0x2e, 0x88, 0x48, 0x86, 0x23, 0x07, 0x1c, 0xc0, 0xb7, 0x61, 0x39, 0xac, 0xef, 0x0b, 0xbf,
+	0x49, 0x6d, 0xf2, 0x06, 0x6d, 0x40, 0x86, 0xe7, 0x6a, 0xf8, 0x5e, 0x77, 0x28, 0x6a, 0x4d, 0xdb,
+	0x69, 0x0e, 0x1c, 0x79, 0xdd, 0x21, 0xbe, 0x97, 0x64, 0x53, 0x74, 0x1d, 0x16, 0x9b, 0x02, 0x10,
+	0xd4, 0xec, 0xde, 0x72, 0x51, 0x6c, 0x5b, 0x31, 0x64, 0xd9, 0x32, 0x88, 0xdb, 0x90, 0xb3, 0x09,
+	0x65, 0x7e, 0x40, 0x22, 0x19, 0xdd, 0x6e, 0x7c, 0x0e, 0x17, 0x5b, 0xbe, 0xf7, 0xa2, 0xeb, 0xb6,
+	0x58, 0xa3, 0xef, 0x77, 0xdd, 0xd6, 0x50, 0x6c, 0x49, 0x6e, 0x6f, 0x4d, 0x66, 0x3d, 0x94, 0xd1,
+	0x63, 0x11, 0xb4, 0x73, 0xad, 0xc4, 0x18, 0xff, 0x66, 0x8c, 0xc9, 0x50, 0x74, 0x0d, 0x96, 0x83,
+	0x10, 0x69, 0x37, 0xc4, 0x76, 0x19, 0x62, 0x03, 0x96, 0x22, 0x90, 0xf3, 0xd0, 0x55, 0x58, 0xa2,
+	0xaf, 0xdd, 0x7e, 0x3f, 0xe2, 0xcc, 0x09, 0x4e, 0x56, 0x62, 0x82, 0xa2, 0xe6, 0x11, 0x5b, 0x9c,
+	0x4a, 0xe6, 0xb1, 0x07, 0x5e, 0x22, 0x8f, 0xd2, 0x86, 0x28, 0x0f, 0xa7, 0xe0, 0x5f, 0x0c, 0xc8,
+	0x3e, 0x76, 0x5c, 0x8f, 0x11, 0xcf, 0xf1, 0x5a, 0x04, 0x99, 0x70, 0x81, 0x78, 0x4e, 0xb3, 0x4b,
+	0xda, 0x72, 0xaf, 0xa3, 0x21, 0x5a, 0x87, 0xc5, 0x80, 0x38, 0xd4, 0xf7, 0x44, 0x39, 0x19, 0x5b,
+	0x8e, 0x78, 0x3f, 0x07, 0xfd, 0xb6, 0x38, 0x32, 0xcd, 0xa1, 0x28, 0x23, 0x63, 0x67, 0x24, 0x72,
+	0x30, 0xe4, 0x27, 0x2a, 0x0a, 0x3b, 0xcc, 0x9c, 0x3f, 0xfd, 0x44, 0x49, 0x76, 0x99, 0xe1, 0x55,
+	0x58, 0x79, 0x48, 0x98, 0x52, 0x9d, 0x4d, 0xde, 0xe0, 0xea, 0x24, 0x48, 0xd1, 0x87, 0x90, 0xed,
+	0xc5, 0x88, 0x6c, 0x3d, 0x92, 0x4d, 0x52, 0xb9, 0x2a, 0x0d, 0x17, 0xa1, 0x50, 0x11, 0x8b, 0x4b,
+	0x4a, 0x28, 0x2b, 0x35, 0xd4, 0x95, 0xe2, 0x9a, 0x96, 0x7f, 0x5e, 0xf5, 0x4b, 0xb0, 0x76, 0xdf,
+	0xa5, 0x93, 0xf2, 0xf8, 0xb1, 0x3e, 0x70, 0x5e, 0x9d, 0xf7, 0x73, 0x90, 0xad, 0x78, 0x27, 0x6e,
+	0xe0, 0x7b, 0x3d, 0xe2, 0x31, 0xed, 0x41, 0x5f, 0x87, 0xc5, 0xbe, 0x33, 0xa0, 0xa4, 0x2d, 0x7a,
+	0x9b, 0xb6, 0xe5, 0x48, 0xd9, 0x89, 0xd4, 0x8c, 0x9e, 0xcf, 0xcf, 0xee, 0xf9, 0xc2, 0x3f, 0xe8,
+	0x39, 0xda, 0x84, 0x0c, 0x27, 0x90, 0x16, 0x23, 0x6d, 0x73, 0x51, 0x14, 0x13, 0x03, 0xfc, 0x40,
+	0x8f, 0x06, 0x5c, 0xf9, 0x82, 0x50, 0xce, 0x8e, 0xb0, 0x83, 0x21, 0xfa, 0x4c, 0xa5, 0x38, 0xcc,
+	0x4c, 0x9f, 0xaa, 0x1e, 0x4f, 0x2f, 0x33, 0xbc, 0x06, 0xab, 0x35, 0x97, 0x32, 0x65, 0xc3, 0x68,
+	0xd8, 0x13, 0x0d, 0x4c, 0xd1, 0x3d, 0x58, 0x22, 0x0a, 0x64, 0x1a, 0xdb, 0x29, 0xa5, 0x25, 0x0a,
+	0xdb, 0x4e, 0xf0, 0x70, 0x19, 0x56, 0x8f, 0xf9, 0x0e, 0xab, 0x8c, 0x29, 0x77, 0xd0, 0x94, 0xdf,
+	0x0e, 0x3f, 0xd2, 0xa5, 0x10, 0x67, 0x44, 0x51, 0x1a, 0x3b, 0x23, 0x2a, 0x57, 0xa5, 0xe1, 0x5d,
+	0x28, 0xd8, 0x84, 0x0e, 0x7a, 0x67, 0x28, 0x08, 0xd7, 0xb4, 0xdc, 0xf3, 0x2a, 0xdf, 0x82, 0xb5,
+	0xe3, 0x70, 0xfb, 0xcf, 0x20, 0xfd, 0x58, 0x4f, 0x3e, 0xaf, 0xf6, 0x1d, 0xb8, 0xf4, 0xcc, 0xeb,
+	0x9f, 0x59, 0xfd, 0x68, 0x1a, 0xfd, 0xbc, 0xfa, 0xbf, 0x1b, 0xb0, 0xcc, 0x5f, 0x10, 0xb7, 0x53,
+	0x27, 0x8c, 0xb9, 0x5e, 0x47, 0x7b, 0x00, 0x0a, 0xb0, 0x70, 0xe2, 0x74, 0x07, 0x44, 0xf6, 0x3f,
+	0x1c, 0xa0, 0xcb, 0x00, 0x01, 0xe9, 0xfa, 0x4e, 0x9b, 0xdf, 0x13, 0xe2, 0xef, 0x4c, 0xdb, 0x0a,
+	0x82, 0x6e, 0x42, 0x9e, 0x3f, 0x05, 0x4e, 0xc0, 0x1a, 0x01, 0x79, 0x33, 0x70, 0x03, 0xd2, 0x16,
+	0xff, 0x69, 0xda, 0xbe, 0x28, 0x71, 0x5b, 0xc2, 0xfc, 0x29, 0xe9, 0x13, 0xaf, 0xed, 0x7a, 0x9d,
+	0x46, 0x28, 0xb4, 0x20, 0x84, 0x96, 0x24, 0xf8, 0x9c, 0x63, 0x38, 0x07, 0x4b, 0x0f, 0x09, 0x0b,
+	0xab, 0xe5, 0x3f, 0xc4, 0x9f, 0x46, 0x02, 0xa0, 0xe8, 0x2e, 0xa4, 0x69, 0xb8, 0x8a, 0xe8, 0x37,
+	0x28, 0x28, 0x8f, 0xe4, 0x68, 0x89, 0xf6, 0x88, 0x85, 0x3e, 0x82, 0x0c, 0x2f, 0xf7, 0xac, 0x56,
+	0x23, 0x1d, 0x92, 0xcb, 0x0c, 0x7d, 0x0a, 0xd9, 0x80, 0xc4, 0x53, 0x53, 0xa7, 0x4e, 0x85, 0x88,
+	0x5e, 0x66, 0xfc, 0x0a, 0x09, 0x47, 0x0d, 0x12, 0x04, 0x7e, 0x20, 0x2f, 0x2f, 0x99, 0xb0, 0xc2,
+	0x21, 0x4c, 0x60, 0xa5, 0x3e, 0x68, 0xd2, 0x21, 0x65, 0xa4, 0x57, 0xf3, 0x3b, 0x35, 0x72, 0x42,
+	0xba, 0xfc, 0x62, 0xa2, 0x11, 0x28, 0xfb, 0x13, 0x03, 0xbc, 0x49, 0x5d, 0x4e, 0x8b, 0x9a, 0xd4,
+	0x8d, 0xe6, 0xb8, 0xde, 0x4b, 0x12, 0xb8, 0xfc, 0x32, 0x0b, 0x7b, 0x14, 0x03, 0xb8, 0x08, 0xb9,
+	0x87, 0x84, 0x45, 0x02, 0xfc, 0xd4, 0xcd, 0xd4, 0xc0, 0xdf, 0x8e, 0xf1, 0x69, 0xac, 0x6a, 0xa8,
+	0xaa, 0x1f, 0x03, 0x8c, 0x26, 0x71, 0xef, 0xc0, 0x7b, 0x61, 0xca, 0x5e, 0x4c, 0xac, 0xcb, 0x56,
+	0xb8, 0xf8, 0x3e, 0xe4, 0xea, 0xc9, 0x8a, 0xf4, 0x0a, 0x89, 0x3a, 0xe7, 0x34, 0x75, 0xd6, 0xff,
+	0xd5, 0x3a, 0x77, 0x7f, 0x34, 0x20, 0x97, 0xb4, 0x5e, 0xe8, 0x0a, 0x6c, 0x1c, 0x1e, 0x3d, 0x79,
+	0x50, 0xab, 0x1e, 0x3e, 0x6d, 0x1c, 0x1f, 0xd5, 0xaa, 0x87, 0x5f, 0x37, 0x9e, 0x3d, 0xa9, 0x1f,
+	0x57, 0x0e, 0xab, 0x0f, 0xaa, 0x95, 0xfb, 0xf9, 0xff, 0x20, 0x13, 0x0a, 0xe3, 0x84, 0xfa, 0xa3,
+	0xea, 0x71, 0xde, 0x40, 0x5b, 0xf0, 0xdf, 0xf1, 0xc8, 0xd1, 0xf3, 0x8a, 0xfd, 0xa5, 0x5d, 0x7d,
+	0x5a, 0xc9, 0xcf, 0xe9, 0x26, 0x3e, 0x28, 0x57, 0x6b, 0xf9, 0xd4, 0xde, 0xfb, 0x2c, 0x2c, 0x95,
+	0xb9, 0x43, 0xaf, 0x93, 0xe0, 0xc4, 0x6d, 0x11, 0xf4, 0x1c, 0x20, 0x76, 0xa3, 0xa8, 0x90, 0xb0,
+	0x9e, 0xd2, 0x67, 0x5a, 0x3a, 0x94, 0xe2, 0xcd, 0x77, 0x7f, 0xfc, 0xf5, 0xf3, 0xdc, 0x3a, 0x5e,
+	0x29, 0x9d, 0xfc, 0xbf, 0x24, 0x5c, 0x7f, 0x29, 0x74, 0xaa, 0x74, 0xdf, 0xd8, 0x45, 0x2f, 0x21,
+	0xab, 0xd8, 0x48, 0x14, 0xb9, 0xcf, 0xa4, 0x83, 0xb5, 0xb4, 0x30, 0xc5, 0xb7, 0x44, 0xea, 0xeb,
+	0x78, 0x7b, 0x22, 0x75, 0xe9, 0x3b, 0x7e, 0xc1, 0x7c, 0xbf, 0x2f, 0x3d, 0x23, 0x57, 0x6a, 0x89,
+	0x33, 0x96, 0x30, 0x84, 0x32, 0xeb, 0x84, 0x13, 0xb3, 0xa6, 0x45, 0x28, 0xde, 0x12, 0x92, 0x97,
+	0xd0, 0x5a, 0x2c, 0xa9, 0x38, 0x12, 0x74, 0x02, 0x2b, 0x13, 0x3e, 0x0a, 0x6d, 0x8c, 0x6e, 0xcb,
+	0x49, 0x4b, 0x64, 0xcd, 0x08, 0x52, 0x7c, 0x43, 0xa8, 0x5d, 0xc5, 0x9b, 0x5a, 0xb5, 0xfd, 0xd0,
+	0xbf, 0xf2, 0xc5, 0xbd, 0x05, 0x34, 0x69, 0xac, 0xd0, 0xa6, 0xcc, 0xad, 0x35, 0x63, 0xd6, 0xac,
+	0x28, 0xc5, 0x3b, 0x42, 0x1a, 0xe3, 0x2d, 0xbd, 0x74, 0xdb, 0xa5, 0x91, 0xf6, 0x2b, 0xc8, 0x8f,
+	0x1b, 0x08, 0x64, 0xc9, 0xdc, 0x1a, 0xc3, 0x61, 0x4d, 0x8f, 0x51, 0x7c, 0x59, 0xa8, 0x9a, 0x68,
+	0x3d, 0x56, 0x55, 0xdd, 0x05, 0x7a, 0x0b, 0xf9, 0x71, 0x6b, 0x30, 0xd2, 0xd2, 0xd8, 0x0e, 0x6b,
+	0x7a, 0x8c, 0xe2, 0xa2, 0xd0, 0xda, 0xc1, 0xd7, 0xf4, 0x5a, 0xd1, 0x11, 0x12, 0x7e, 0x91, 0xaf,
+	0xf3, 0x07, 0x58, 0x99, 0x70, 0x07, 0xa3, 0xde, 0xea, 0x3c, 0x86, 0x35, 0x23, 0x48, 0x71, 0x49,
+	0xc8, 0xdf, 0xc4, 0xff, 0x9b, 0x2d, 0x1f, 0x88, 0xb9, 0x5c, 0xff, 0x9d, 0x01, 0x68, 0xd2, 0x23,
+	0x8c, 0x9a, 0xac, 0xf5, 0x1a, 0xd6, 0xac, 0x28, 0xc5, 0x77, 0x45, 0x0d, 0xbb, 0xf8, 0xfa, 0x29,
+	0x5b, 0x10, 0x4e, 0xe6, 0x45, 0xfc, 0x64, 0x40, 0x41, 0x67, 0x15, 0xd0, 0x65, 0x29, 0x34, 0xc5,
+	0x76, 0x58, 0xb3, 0xe3, 0x14, 0xef, 0x89, 0x52, 0x6e, 0xe3, 0x1b, 0xb3, 0x4b, 0x19, 0x78, 0x4a,
+	0x31, 0x47, 0x90, 0x19, 0x3d, 0xd4, 0x68, 0x35, 0xfe, 0x67, 0x47, 0x6f, 0xb9, 0xa5, 0x01, 0x29,
+	0x36, 0x85, 0x14, 0x42, 0xf9, 0x58, 0xaa, 0x15, 0xe6, 0xf8, 0x0a, 0xb2, 0xca, 0x3b, 0x34, 0xba,
+	0x8d, 0x92, 0x6f, 0x99, 0xa5, 0x85, 0x29, 0xde, 0x10, 0x69, 0xd7, 0xd0, 0x6a, 0x9c, 0xb6, 0xeb,
+	0x77, 0xee, 0x84, 0x2f, 0xc2, 0x37, 0x90, 0xad, 0x6b, 0x32, 0xd7, 0xf5, 0x99, 0xeb, 0xc9, 0xcc,
+	0xf2, 0xaf, 0xc0, 0xba, 0xcc, 0xfb, 0xc6, 0x6e, 0x73, 0x51, 0x18, 0x83, 0x0f, 0xfe, 0x1e, 0x00,
+	0xdd, 0x7f, 0x93, 0xf6, 0x55, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// Writes a backup to the configured backup location, callers with a tenant back up the jobs of their tenant. Only
+	// admins may call it.
+	BackupJobs(ctx context.Context, in *BackupJobsReq, opts ...grpc.CallOption) (*BackupJobsRes, error)
+	// Restores the jobs and runs of a backup under their IDs. Callers with a tenant can only restore the backups of
+	// their tenant. Only admins may call it.
+	RestoreJobs(ctx context.Context, in *RestoreJobsReq, opts ...grpc.CallOption) (*RestoreJobsRes, error)
+	// Returns the maintenance mode of the scheduler. Only admins may call it.
+	GetMaintenance(ctx context.Context, in *GetMaintenanceReq, opts ...grpc.CallOption) (*GetMaintenanceRes, error)
+	// Pauses the scheduler until maintenance is disabled. Runs that are queued or running finish, the occurrences
+	// missed during maintenance are handled by the catch-up policies of the jobs. Only admins may call it.
+	EnableMaintenance(ctx context.Context, in *EnableMaintenanceReq, opts ...grpc.CallOption) (*EnableMaintenanceRes, error)
+	// Resumes the scheduler. Only admins may call it.
+	DisableMaintenance(ctx context.Context, in *DisableMaintenanceReq, opts ...grpc.CallOption) (*DisableMaintenanceRes, error)
+	// Returns the environments of the caller's tenant that were ever paused. Only admins may call it.
+	ListEnvironments(ctx context.Context, in *ListEnvironmentsReq, opts ...grpc.CallOption) (*ListEnvironmentsRes, error)
+	// Pauses the scheduling of the jobs in an environment of the caller's tenant until it is resumed, like maintenance
+	// does for all jobs. Only admins may call it.
+	PauseEnvironment(ctx context.Context, in *PauseEnvironmentReq, opts ...grpc.CallOption) (*PauseEnvironmentRes, error)
+	// Resumes an environment, the occurrences missed while it was paused are handled by the catch-up policies of its
+	// jobs. Only admins may call it.
+	ResumeEnvironment(ctx context.Context, in *ResumeEnvironmentReq, opts ...grpc.CallOption) (*ResumeEnvironmentRes, error)
+	// Makes updates of the jobs in an environment of the caller's tenant wait for the approval of a second person.
+	// Only admins may call it.
+	ProtectEnvironment(ctx context.Context, in *ProtectEnvironmentReq, opts ...grpc.CallOption) (*ProtectEnvironmentRes, error)
+	// Lets the jobs in an environment be updated right away again, pending changes stay pending. Only admins may call
+	// it.
+	UnprotectEnvironment(ctx context.Context, in *UnprotectEnvironmentReq, opts ...grpc.CallOption) (*UnprotectEnvironmentRes, error)
+	// Returns the configuration the server runs with, the settings reloaded on SIGHUP or changes of the config file
+	// included. Only admins may call it.
+	GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigRes, error)
+	// Returns the levels the server logs at. Only admins may call it.
+	GetLogLevel(ctx context.Context, in *GetLogLevelReq, opts ...grpc.CallOption) (*GetLogLevelRes, error)
+	// Changes the level the server logs at, or the one of a subsystem, until the server restarts or a reload of the
+	// configuration changes LOG_LEVEL. Only admins may call it.
+	SetLogLevel(ctx context.Context, in *SetLogLevelReq, opts ...grpc.CallOption) (*SetLogLevelRes, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) BackupJobs(ctx context.Context, in *BackupJobsReq, opts ...grpc.CallOption) (*BackupJobsRes, error) {
+	out := new(BackupJobsRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/BackupJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RestoreJobs(ctx context.Context, in *RestoreJobsReq, opts ...grpc.CallOption) (*RestoreJobsRes, error) {
+	out := new(RestoreJobsRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/RestoreJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetMaintenance(ctx context.Context, in *GetMaintenanceReq, opts ...grpc.CallOption) (*GetMaintenanceRes, error) {
+	out := new(GetMaintenanceRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/GetMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EnableMaintenance(ctx context.Context, in *EnableMaintenanceReq, opts ...grpc.CallOption) (*EnableMaintenanceRes, error) {
+	out := new(EnableMaintenanceRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/EnableMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DisableMaintenance(ctx context.Context, in *DisableMaintenanceReq, opts ...grpc.CallOption) (*DisableMaintenanceRes, error) {
+	out := new(DisableMaintenanceRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/DisableMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListEnvironments(ctx context.Context, in *ListEnvironmentsReq, opts ...grpc.CallOption) (*ListEnvironmentsRes, error) {
+	out := new(ListEnvironmentsRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/ListEnvironments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PauseEnvironment(ctx context.Context, in *PauseEnvironmentReq, opts ...grpc.CallOption) (*PauseEnvironmentRes, error) {
+	out := new(PauseEnvironmentRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/PauseEnvironment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeEnvironment(ctx context.Context, in *ResumeEnvironmentReq, opts ...grpc.CallOption) (*ResumeEnvironmentRes, error) {
+	out := new(ResumeEnvironmentRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/ResumeEnvironment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ProtectEnvironment(ctx context.Context, in *ProtectEnvironmentReq, opts ...grpc.CallOption) (*ProtectEnvironmentRes, error) {
+	out := new(ProtectEnvironmentRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/ProtectEnvironment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnprotectEnvironment(ctx context.Context, in *UnprotectEnvironmentReq, opts ...grpc.CallOption) (*UnprotectEnvironmentRes, error) {
+	out := new(UnprotectEnvironmentRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/UnprotectEnvironment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigRes, error) {
+	out := new(GetConfigRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLogLevel(ctx context.Context, in *GetLogLevelReq, opts ...grpc.CallOption) (*GetLogLevelRes, error) {
+	out := new(GetLogLevelRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/GetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelReq, opts ...grpc.CallOption) (*SetLogLevelRes, error) {
+	out := new(SetLogLevelRes)
+	err := c.cc.Invoke(ctx, "/model.AdminService/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// Writes a backup to the configured backup location, callers with a tenant back up the jobs of their tenant. Only
+	// admins may call it.
+	BackupJobs(context.Context, *BackupJobsReq) (*BackupJobsRes, error)
+	// Restores the jobs and runs of a backup under their IDs. Callers with a tenant can only restore the backups of
+	// their tenant. Only admins may call it.
+	RestoreJobs(context.Context, *RestoreJobsReq) (*RestoreJobsRes, error)
+	// Returns the maintenance mode of the scheduler. Only admins may call it.
+	GetMaintenance(context.Context, *GetMaintenanceReq) (*GetMaintenanceRes, error)
+	// Pauses the scheduler until maintenance is disabled. Runs that are queued or running finish, the occurrences
+	// missed during maintenance are handled by the catch-up policies of the jobs. Only admins may call it.
+	EnableMaintenance(context.Context, *EnableMaintenanceReq) (*EnableMaintenanceRes, error)
+	// Resumes the scheduler. Only admins may call it.
+	DisableMaintenance(context.Context, *DisableMaintenanceReq) (*DisableMaintenanceRes, error)
+	// Returns the environments of the caller's tenant that were ever paused. Only admins may call it.
+	ListEnvironments(context.Context, *ListEnvironmentsReq) (*ListEnvironmentsRes, error)
+	// Pauses the scheduling of the jobs in an environment of the caller's tenant until it is resumed, like maintenance
+	// does for all jobs. Only admins may call it.
+	PauseEnvironment(context.Context, *PauseEnvironmentReq) (*PauseEnvironmentRes, error)
+	// Resumes an environment, the occurrences missed while it was paused are handled by the catch-up policies of its
+	// jobs. Only admins may call it.
+	ResumeEnvironment(context.Context, *ResumeEnvironmentReq) (*ResumeEnvironmentRes, error)
+	// Makes updates of the jobs in an environment of the caller's tenant wait for the approval of a second person.
+	// Only admins may call it.
+	ProtectEnvironment(context.Context, *ProtectEnvironmentReq) (*ProtectEnvironmentRes, error)
+	// Lets the jobs in an environment be updated right away again, pending changes stay pending. Only admins may call
+	// it.
+	UnprotectEnvironment(context.Context, *UnprotectEnvironmentReq) (*UnprotectEnvironmentRes, error)
+	// Returns the configuration the server runs with, the settings reloaded on SIGHUP or changes of the config file
+	// included. Only admins may call it.
+	GetConfig(context.Context, *GetConfigReq) (*GetConfigRes, error)
+	// Returns the levels the server logs at. Only admins may call it.
+	GetLogLevel(context.Context, *GetLogLevelReq) (*GetLogLevelRes, error)
+	// Changes the level the server logs at, or the one of a subsystem, until the server restarts or a reload of the
+	// configuration changes LOG_LEVEL. Only admins may call it.
+	SetLogLevel(context.Context, *SetLogLevelReq) (*SetLogLevelRes, error)
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_BackupJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupJobsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BackupJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/BackupJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BackupJobs(ctx, req.(*BackupJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RestoreJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreJobsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RestoreJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/RestoreJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RestoreJobs(ctx, req.(*RestoreJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaintenanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/GetMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetMaintenance(ctx, req.(*GetMaintenanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EnableMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableMaintenanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EnableMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/EnableMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EnableMaintenance(ctx, req.(*EnableMaintenanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DisableMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableMaintenanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DisableMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/DisableMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DisableMaintenance(ctx, req.(*DisableMaintenanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListEnvironments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnvironmentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListEnvironments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/ListEnvironments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListEnvironments(ctx, req.(*ListEnvironmentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PauseEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseEnvironmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PauseEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/PauseEnvironment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PauseEnvironment(ctx, req.(*PauseEnvironmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeEnvironmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/ResumeEnvironment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeEnvironment(ctx, req.(*ResumeEnvironmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ProtectEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtectEnvironmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ProtectEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/ProtectEnvironment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ProtectEnvironment(ctx, req.(*ProtectEnvironmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnprotectEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnprotectEnvironmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnprotectEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/UnprotectEnvironment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnprotectEnvironment(ctx, req.(*UnprotectEnvironmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetConfig(ctx, req.(*GetConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/GetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLogLevel(ctx, req.(*GetLogLevelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AdminService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BackupJobs",
+			Handler:    _AdminService_BackupJobs_Handler,
+		},
+		{
+			MethodName: "RestoreJobs",
+			Handler:    _AdminService_RestoreJobs_Handler,
+		},
+		{
+			MethodName: "GetMaintenance",
+			Handler:    _AdminService_GetMaintenance_Handler,
+		},
+		{
+			MethodName: "EnableMaintenance",
+			Handler:    _AdminService_EnableMaintenance_Handler,
+		},
+		{
+			MethodName: "DisableMaintenance",
+			Handler:    _AdminService_DisableMaintenance_Handler,
+		},
+		{
+			MethodName: "ListEnvironments",
+			Handler:    _AdminService_ListEnvironments_Handler,
+		},
+		{
+			MethodName: "PauseEnvironment",
+			Handler:    _AdminService_PauseEnvironment_Handler,
+		},
+		{
+			MethodName: "ResumeEnvironment",
+			Handler:    _AdminService_ResumeEnvironment_Handler,
+		},
+		{
+			MethodName: "ProtectEnvironment",
+			Handler:    _AdminService_ProtectEnvironment_Handler,
+		},
+		{
+			MethodName: "UnprotectEnvironment",
+			Handler:    _AdminService_UnprotectEnvironment_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _AdminService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetLogLevel",
+			Handler:    _AdminService_GetLogLevel_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
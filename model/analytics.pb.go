@@ -0,0 +1,2377 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: analytics.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// CostGroup is what the rows of a cost report sum up the runs by
+type CostGroup int32
+
+const (
+	// Defaults to jobs
+	CostGroup_COST_GROUP_UNSPECIFIED CostGroup = 0
+	CostGroup_COST_GROUP_JOB         CostGroup = 1
+	CostGroup_COST_GROUP_OWNER       CostGroup = 2
+	// Only jobs owned by teams are reported
+	CostGroup_COST_GROUP_TEAM CostGroup = 3
+	// Calendar months in UTC
+	CostGroup_COST_GROUP_MONTH CostGroup = 4
+)
+
+var CostGroup_name = map[int32]string{
+	0: "COST_GROUP_UNSPECIFIED",
+	1: "COST_GROUP_JOB",
+	2: "COST_GROUP_OWNER",
+	3: "COST_GROUP_TEAM",
+	4: "COST_GROUP_MONTH",
+}
+
+var CostGroup_value = map[string]int32{
+	"COST_GROUP_UNSPECIFIED": 0,
+	"COST_GROUP_JOB":         1,
+	"COST_GROUP_OWNER":       2,
+	"COST_GROUP_TEAM":        3,
+	"COST_GROUP_MONTH":       4,
+}
+
+func (x CostGroup) String() string {
+	return proto.EnumName(CostGroup_name, int32(x))
+}
+
+func (CostGroup) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{0}
+}
+
+// TimeSeriesBucket is the length of the buckets of a time series
+type TimeSeriesBucket int32
+
+const (
+	// Defaults to days
+	TimeSeriesBucket_TIME_SERIES_BUCKET_UNSPECIFIED TimeSeriesBucket = 0
+	TimeSeriesBucket_TIME_SERIES_BUCKET_HOUR        TimeSeriesBucket = 1
+	TimeSeriesBucket_TIME_SERIES_BUCKET_DAY         TimeSeriesBucket = 2
+	// Weeks start on Monday
+	TimeSeriesBucket_TIME_SERIES_BUCKET_WEEK TimeSeriesBucket = 3
+)
+
+var TimeSeriesBucket_name = map[int32]string{
+	0: "TIME_SERIES_BUCKET_UNSPECIFIED",
+	1: "TIME_SERIES_BUCKET_HOUR",
+	2: "TIME_SERIES_BUCKET_DAY",
+	3: "TIME_SERIES_BUCKET_WEEK",
+}
+
+var TimeSeriesBucket_value = map[string]int32{
+	"TIME_SERIES_BUCKET_UNSPECIFIED": 0,
+	"TIME_SERIES_BUCKET_HOUR":        1,
+	"TIME_SERIES_BUCKET_DAY":         2,
+	"TIME_SERIES_BUCKET_WEEK":        3,
+}
+
+func (x TimeSeriesBucket) String() string {
+	return proto.EnumName(TimeSeriesBucket_name, int32(x))
+}
+
+func (TimeSeriesBucket) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{1}
+}
+
+// SLABreachKind tells how a job breached its SLA
+type SLABreachKind int32
+
+const (
+	SLABreachKind_SLA_BREACH_KIND_UNSPECIFIED SLABreachKind = 0
+	// A run took longer than the max duration
+	SLABreachKind_SLA_BREACH_KIND_DURATION SLABreachKind = 1
+	// A deadline passed without a succeeded run
+	SLABreachKind_SLA_BREACH_KIND_DEADLINE SLABreachKind = 2
+)
+
+var SLABreachKind_name = map[int32]string{
+	0: "SLA_BREACH_KIND_UNSPECIFIED",
+	1: "SLA_BREACH_KIND_DURATION",
+	2: "SLA_BREACH_KIND_DEADLINE",
+}
+
+var SLABreachKind_value = map[string]int32{
+	"SLA_BREACH_KIND_UNSPECIFIED": 0,
+	"SLA_BREACH_KIND_DURATION":    1,
+	"SLA_BREACH_KIND_DEADLINE":    2,
+}
+
+func (x SLABreachKind) String() string {
+	return proto.EnumName(SLABreachKind_name, int32(x))
+}
+
+func (SLABreachKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{2}
+}
+
+// TimeRange selects the runs queued at or after start_time and before end_time
+type TimeRange struct {
+	// Defaults to 30 days before end_time
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Defaults to now
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TimeRange) Reset()         { *m = TimeRange{} }
+func (m *TimeRange) String() string { return proto.CompactTextString(m) }
+func (*TimeRange) ProtoMessage()    {}
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{0}
+}
+
+func (m *TimeRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeRange.Unmarshal(m, b)
+}
+func (m *TimeRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeRange.Marshal(b, m, deterministic)
+}
+func (m *TimeRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeRange.Merge(m, src)
+}
+func (m *TimeRange) XXX_Size() int {
+	return xxx_messageInfo_TimeRange.Size(m)
+}
+func (m *TimeRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeRange proto.InternalMessageInfo
+
+func (m *TimeRange) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *TimeRange) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+// JobStats summarizes the runs of a job within a time range
+type JobStats struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// The time range the stats were computed for, with the defaults filled in
+	TimeRange *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// Number of runs in the time range, including retries and runs that didn't finish yet
+	TotalRuns     int64 `protobuf:"varint,3,opt,name=total_runs,json=totalRuns,proto3" json:"total_runs,omitempty"`
+	SucceededRuns int64 `protobuf:"varint,4,opt,name=succeeded_runs,json=succeededRuns,proto3" json:"succeeded_runs,omitempty"`
+	FailedRuns    int64 `protobuf:"varint,5,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	TimedOutRuns  int64 `protobuf:"varint,6,opt,name=timed_out_runs,json=timedOutRuns,proto3" json:"timed_out_runs,omitempty"`
+	CancelledRuns int64 `protobuf:"varint,7,opt,name=cancelled_runs,json=cancelledRuns,proto3" json:"cancelled_runs,omitempty"`
+	// Queued, waiting and running runs
+	PendingRuns int64 `protobuf:"varint,8,opt,name=pending_runs,json=pendingRuns,proto3" json:"pending_runs,omitempty"`
+	// Shares of the finished runs that succeeded and that failed or timed out, 0 without finished runs
+	SuccessRate float64 `protobuf:"fixed64,9,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	FailureRate float64 `protobuf:"fixed64,10,opt,name=failure_rate,json=failureRate,proto3" json:"failure_rate,omitempty"`
+	// Durations of the runs that started and finished, the percentiles use the nearest rank
+	MeanDuration         *duration.Duration `protobuf:"bytes,11,opt,name=mean_duration,json=meanDuration,proto3" json:"mean_duration,omitempty"`
+	P50Duration          *duration.Duration `protobuf:"bytes,12,opt,name=p50_duration,json=p50Duration,proto3" json:"p50_duration,omitempty"`
+	P95Duration          *duration.Duration `protobuf:"bytes,13,opt,name=p95_duration,json=p95Duration,proto3" json:"p95_duration,omitempty"`
+	P99Duration          *duration.Duration `protobuf:"bytes,14,opt,name=p99_duration,json=p99Duration,proto3" json:"p99_duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *JobStats) Reset()         { *m = JobStats{} }
+func (m *JobStats) String() string { return proto.CompactTextString(m) }
+func (*JobStats) ProtoMessage()    {}
+func (*JobStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{1}
+}
+
+func (m *JobStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobStats.Unmarshal(m, b)
+}
+func (m *JobStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobStats.Marshal(b, m, deterministic)
+}
+func (m *JobStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStats.Merge(m, src)
+}
+func (m *JobStats) XXX_Size() int {
+	return xxx_messageInfo_JobStats.Size(m)
+}
+func (m *JobStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobStats proto.InternalMessageInfo
+
+func (m *JobStats) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobStats) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+func (m *JobStats) GetTotalRuns() int64 {
+	if m != nil {
+		return m.TotalRuns
+	}
+	return 0
+}
+
+func (m *JobStats) GetSucceededRuns() int64 {
+	if m != nil {
+		return m.SucceededRuns
+	}
+	return 0
+}
+
+func (m *JobStats) GetFailedRuns() int64 {
+	if m != nil {
+		return m.FailedRuns
+	}
+	return 0
+}
+
+func (m *JobStats) GetTimedOutRuns() int64 {
+	if m != nil {
+		return m.TimedOutRuns
+	}
+	return 0
+}
+
+func (m *JobStats) GetCancelledRuns() int64 {
+	if m != nil {
+		return m.CancelledRuns
+	}
+	return 0
+}
+
+func (m *JobStats) GetPendingRuns() int64 {
+	if m != nil {
+		return m.PendingRuns
+	}
+	return 0
+}
+
+func (m *JobStats) GetSuccessRate() float64 {
+	if m != nil {
+		return m.SuccessRate
+	}
+	return 0
+}
+
+func (m *JobStats) GetFailureRate() float64 {
+	if m != nil {
+		return m.FailureRate
+	}
+	return 0
+}
+
+func (m *JobStats) GetMeanDuration() *duration.Duration {
+	if m != nil {
+		return m.MeanDuration
+	}
+	return nil
+}
+
+func (m *JobStats) GetP50Duration() *duration.Duration {
+	if m != nil {
+		return m.P50Duration
+	}
+	return nil
+}
+
+func (m *JobStats) GetP95Duration() *duration.Duration {
+	if m != nil {
+		return m.P95Duration
+	}
+	return nil
+}
+
+func (m *JobStats) GetP99Duration() *duration.Duration {
+	if m != nil {
+		return m.P99Duration
+	}
+	return nil
+}
+
+type GetJobStatsReq struct {
+	JobId                string     `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	TimeRange            *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetJobStatsReq) Reset()         { *m = GetJobStatsReq{} }
+func (m *GetJobStatsReq) String() string { return proto.CompactTextString(m) }
+func (*GetJobStatsReq) ProtoMessage()    {}
+func (*GetJobStatsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{2}
+}
+
+func (m *GetJobStatsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobStatsReq.Unmarshal(m, b)
+}
+func (m *GetJobStatsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobStatsReq.Marshal(b, m, deterministic)
+}
+func (m *GetJobStatsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobStatsReq.Merge(m, src)
+}
+func (m *GetJobStatsReq) XXX_Size() int {
+	return xxx_messageInfo_GetJobStatsReq.Size(m)
+}
+func (m *GetJobStatsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobStatsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobStatsReq proto.InternalMessageInfo
+
+func (m *GetJobStatsReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *GetJobStatsReq) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+type GetJobStatsRes struct {
+	Stats                *JobStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetJobStatsRes) Reset()         { *m = GetJobStatsRes{} }
+func (m *GetJobStatsRes) String() string { return proto.CompactTextString(m) }
+func (*GetJobStatsRes) ProtoMessage()    {}
+func (*GetJobStatsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{3}
+}
+
+func (m *GetJobStatsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobStatsRes.Unmarshal(m, b)
+}
+func (m *GetJobStatsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobStatsRes.Marshal(b, m, deterministic)
+}
+func (m *GetJobStatsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobStatsRes.Merge(m, src)
+}
+func (m *GetJobStatsRes) XXX_Size() int {
+	return xxx_messageInfo_GetJobStatsRes.Size(m)
+}
+func (m *GetJobStatsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobStatsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobStatsRes proto.InternalMessageInfo
+
+func (m *GetJobStatsRes) GetStats() *JobStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// OwnerSummary sums up the jobs of an owner and their runs within a time range for dashboards
+type OwnerSummary struct {
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The time range the runs were summed up for, with the defaults filled in
+	TimeRange *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// Jobs of the owner that aren't deleted, archived and paused ones included
+	TotalJobs int64 `protobuf:"varint,3,opt,name=total_jobs,json=totalJobs,proto3" json:"total_jobs,omitempty"`
+	// Jobs with a schedule that the scheduler fires, which leaves out paused and archived jobs
+	ActiveSchedules int64 `protobuf:"varint,4,opt,name=active_schedules,json=activeSchedules,proto3" json:"active_schedules,omitempty"`
+	// Runs of the jobs queued within the time range like in JobStats, runs of deleted jobs count until they are purged
+	TotalRuns     int64 `protobuf:"varint,5,opt,name=total_runs,json=totalRuns,proto3" json:"total_runs,omitempty"`
+	SucceededRuns int64 `protobuf:"varint,6,opt,name=succeeded_runs,json=succeededRuns,proto3" json:"succeeded_runs,omitempty"`
+	FailedRuns    int64 `protobuf:"varint,7,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	TimedOutRuns  int64 `protobuf:"varint,8,opt,name=timed_out_runs,json=timedOutRuns,proto3" json:"timed_out_runs,omitempty"`
+	CancelledRuns int64 `protobuf:"varint,9,opt,name=cancelled_runs,json=cancelledRuns,proto3" json:"cancelled_runs,omitempty"`
+	PendingRuns   int64 `protobuf:"varint,10,opt,name=pending_runs,json=pendingRuns,proto3" json:"pending_runs,omitempty"`
+	// Shares of the finished runs that succeeded and that failed or timed out, 0 without finished runs
+	SuccessRate float64 `protobuf:"fixed64,11,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	FailureRate float64 `protobuf:"fixed64,12,opt,name=failure_rate,json=failureRate,proto3" json:"failure_rate,omitempty"`
+	// Sum of the durations of the runs
+	ComputeTime          *duration.Duration `protobuf:"bytes,13,opt,name=compute_time,json=computeTime,proto3" json:"compute_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *OwnerSummary) Reset()         { *m = OwnerSummary{} }
+func (m *OwnerSummary) String() string { return proto.CompactTextString(m) }
+func (*OwnerSummary) ProtoMessage()    {}
+func (*OwnerSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{4}
+}
+
+func (m *OwnerSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerSummary.Unmarshal(m, b)
+}
+func (m *OwnerSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerSummary.Marshal(b, m, deterministic)
+}
+func (m *OwnerSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerSummary.Merge(m, src)
+}
+func (m *OwnerSummary) XXX_Size() int {
+	return xxx_messageInfo_OwnerSummary.Size(m)
+}
+func (m *OwnerSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnerSummary proto.InternalMessageInfo
+
+func (m *OwnerSummary) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *OwnerSummary) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+func (m *OwnerSummary) GetTotalJobs() int64 {
+	if m != nil {
+		return m.TotalJobs
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetActiveSchedules() int64 {
+	if m != nil {
+		return m.ActiveSchedules
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetTotalRuns() int64 {
+	if m != nil {
+		return m.TotalRuns
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetSucceededRuns() int64 {
+	if m != nil {
+		return m.SucceededRuns
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetFailedRuns() int64 {
+	if m != nil {
+		return m.FailedRuns
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetTimedOutRuns() int64 {
+	if m != nil {
+		return m.TimedOutRuns
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetCancelledRuns() int64 {
+	if m != nil {
+		return m.CancelledRuns
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetPendingRuns() int64 {
+	if m != nil {
+		return m.PendingRuns
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetSuccessRate() float64 {
+	if m != nil {
+		return m.SuccessRate
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetFailureRate() float64 {
+	if m != nil {
+		return m.FailureRate
+	}
+	return 0
+}
+
+func (m *OwnerSummary) GetComputeTime() *duration.Duration {
+	if m != nil {
+		return m.ComputeTime
+	}
+	return nil
+}
+
+type GetOwnerSummaryReq struct {
+	// Defaults to the caller, only admins may ask for owners other than themselves and their teams
+	Owner                string     `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	TimeRange            *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetOwnerSummaryReq) Reset()         { *m = GetOwnerSummaryReq{} }
+func (m *GetOwnerSummaryReq) String() string { return proto.CompactTextString(m) }
+func (*GetOwnerSummaryReq) ProtoMessage()    {}
+func (*GetOwnerSummaryReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{5}
+}
+
+func (m *GetOwnerSummaryReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOwnerSummaryReq.Unmarshal(m, b)
+}
+func (m *GetOwnerSummaryReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOwnerSummaryReq.Marshal(b, m, deterministic)
+}
+func (m *GetOwnerSummaryReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOwnerSummaryReq.Merge(m, src)
+}
+func (m *GetOwnerSummaryReq) XXX_Size() int {
+	return xxx_messageInfo_GetOwnerSummaryReq.Size(m)
+}
+func (m *GetOwnerSummaryReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOwnerSummaryReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOwnerSummaryReq proto.InternalMessageInfo
+
+func (m *GetOwnerSummaryReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *GetOwnerSummaryReq) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+type GetOwnerSummaryRes struct {
+	Summary              *OwnerSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetOwnerSummaryRes) Reset()         { *m = GetOwnerSummaryRes{} }
+func (m *GetOwnerSummaryRes) String() string { return proto.CompactTextString(m) }
+func (*GetOwnerSummaryRes) ProtoMessage()    {}
+func (*GetOwnerSummaryRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{6}
+}
+
+func (m *GetOwnerSummaryRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOwnerSummaryRes.Unmarshal(m, b)
+}
+func (m *GetOwnerSummaryRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOwnerSummaryRes.Marshal(b, m, deterministic)
+}
+func (m *GetOwnerSummaryRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOwnerSummaryRes.Merge(m, src)
+}
+func (m *GetOwnerSummaryRes) XXX_Size() int {
+	return xxx_messageInfo_GetOwnerSummaryRes.Size(m)
+}
+func (m *GetOwnerSummaryRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOwnerSummaryRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOwnerSummaryRes proto.InternalMessageInfo
+
+func (m *GetOwnerSummaryRes) GetSummary() *OwnerSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type GetCostReportReq struct {
+	// Only report the jobs of this owner. Defaults to every job the caller may read, only admins may ask for owners
+	// other than themselves and their teams.
+	Owner                string     `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	TimeRange            *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	GroupBy              CostGroup  `protobuf:"varint,3,opt,name=group_by,json=groupBy,proto3,enum=model.CostGroup" json:"group_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetCostReportReq) Reset()         { *m = GetCostReportReq{} }
+func (m *GetCostReportReq) String() string { return proto.CompactTextString(m) }
+func (*GetCostReportReq) ProtoMessage()    {}
+func (*GetCostReportReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{7}
+}
+
+func (m *GetCostReportReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCostReportReq.Unmarshal(m, b)
+}
+func (m *GetCostReportReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCostReportReq.Marshal(b, m, deterministic)
+}
+func (m *GetCostReportReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCostReportReq.Merge(m, src)
+}
+func (m *GetCostReportReq) XXX_Size() int {
+	return xxx_messageInfo_GetCostReportReq.Size(m)
+}
+func (m *GetCostReportReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCostReportReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCostReportReq proto.InternalMessageInfo
+
+func (m *GetCostReportReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *GetCostReportReq) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+func (m *GetCostReportReq) GetGroupBy() CostGroup {
+	if m != nil {
+		return m.GroupBy
+	}
+	return CostGroup_COST_GROUP_UNSPECIFIED
+}
+
+// GetCostReportRes is a single row of a cost report, rows without runs are left out
+type GetCostReportRes struct {
+	// The job ID, owner, team ID or month as YYYY-MM the row sums up
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Name of the job, only set for rows of jobs
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Runs queued within the time range, including retries and runs that didn't finish yet
+	TotalRuns int64 `protobuf:"varint,3,opt,name=total_runs,json=totalRuns,proto3" json:"total_runs,omitempty"`
+	// Sum of the durations of the runs
+	ComputeTime *duration.Duration `protobuf:"bytes,4,opt,name=compute_time,json=computeTime,proto3" json:"compute_time,omitempty"`
+	// Sum of the costs of the runs, runs cost what a second of compute time cost when they started
+	Cost                 float64  `protobuf:"fixed64,5,opt,name=cost,proto3" json:"cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCostReportRes) Reset()         { *m = GetCostReportRes{} }
+func (m *GetCostReportRes) String() string { return proto.CompactTextString(m) }
+func (*GetCostReportRes) ProtoMessage()    {}
+func (*GetCostReportRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{8}
+}
+
+func (m *GetCostReportRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCostReportRes.Unmarshal(m, b)
+}
+func (m *GetCostReportRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCostReportRes.Marshal(b, m, deterministic)
+}
+func (m *GetCostReportRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCostReportRes.Merge(m, src)
+}
+func (m *GetCostReportRes) XXX_Size() int {
+	return xxx_messageInfo_GetCostReportRes.Size(m)
+}
+func (m *GetCostReportRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCostReportRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCostReportRes proto.InternalMessageInfo
+
+func (m *GetCostReportRes) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetCostReportRes) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetCostReportRes) GetTotalRuns() int64 {
+	if m != nil {
+		return m.TotalRuns
+	}
+	return 0
+}
+
+func (m *GetCostReportRes) GetComputeTime() *duration.Duration {
+	if m != nil {
+		return m.ComputeTime
+	}
+	return nil
+}
+
+func (m *GetCostReportRes) GetCost() float64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+type GetJobTimeSeriesReq struct {
+	JobId     string           `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	TimeRange *TimeRange       `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	Bucket    TimeSeriesBucket `protobuf:"varint,3,opt,name=bucket,proto3,enum=model.TimeSeriesBucket" json:"bucket,omitempty"`
+	// IANA time zone the days and weeks start in, defaults to UTC
+	Timezone             string   `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobTimeSeriesReq) Reset()         { *m = GetJobTimeSeriesReq{} }
+func (m *GetJobTimeSeriesReq) String() string { return proto.CompactTextString(m) }
+func (*GetJobTimeSeriesReq) ProtoMessage()    {}
+func (*GetJobTimeSeriesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{9}
+}
+
+func (m *GetJobTimeSeriesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobTimeSeriesReq.Unmarshal(m, b)
+}
+func (m *GetJobTimeSeriesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobTimeSeriesReq.Marshal(b, m, deterministic)
+}
+func (m *GetJobTimeSeriesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobTimeSeriesReq.Merge(m, src)
+}
+func (m *GetJobTimeSeriesReq) XXX_Size() int {
+	return xxx_messageInfo_GetJobTimeSeriesReq.Size(m)
+}
+func (m *GetJobTimeSeriesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobTimeSeriesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobTimeSeriesReq proto.InternalMessageInfo
+
+func (m *GetJobTimeSeriesReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *GetJobTimeSeriesReq) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+func (m *GetJobTimeSeriesReq) GetBucket() TimeSeriesBucket {
+	if m != nil {
+		return m.Bucket
+	}
+	return TimeSeriesBucket_TIME_SERIES_BUCKET_UNSPECIFIED
+}
+
+func (m *GetJobTimeSeriesReq) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+// GetJobTimeSeriesRes is a single bucket of the time series, buckets without runs are sent too
+type GetJobTimeSeriesRes struct {
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The start of the next bucket, the first and last bucket are cut to the time range
+	EndTime       *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TotalRuns     int64                `protobuf:"varint,3,opt,name=total_runs,json=totalRuns,proto3" json:"total_runs,omitempty"`
+	SucceededRuns int64                `protobuf:"varint,4,opt,name=succeeded_runs,json=succeededRuns,proto3" json:"succeeded_runs,omitempty"`
+	FailedRuns    int64                `protobuf:"varint,5,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	TimedOutRuns  int64                `protobuf:"varint,6,opt,name=timed_out_runs,json=timedOutRuns,proto3" json:"timed_out_runs,omitempty"`
+	CancelledRuns int64                `protobuf:"varint,7,opt,name=cancelled_runs,json=cancelledRuns,proto3" json:"cancelled_runs,omitempty"`
+	// Mean duration of the runs that started and finished, unset without finished runs
+	MeanDuration         *duration.Duration `protobuf:"bytes,8,opt,name=mean_duration,json=meanDuration,proto3" json:"mean_duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetJobTimeSeriesRes) Reset()         { *m = GetJobTimeSeriesRes{} }
+func (m *GetJobTimeSeriesRes) String() string { return proto.CompactTextString(m) }
+func (*GetJobTimeSeriesRes) ProtoMessage()    {}
+func (*GetJobTimeSeriesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{10}
+}
+
+func (m *GetJobTimeSeriesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobTimeSeriesRes.Unmarshal(m, b)
+}
+func (m *GetJobTimeSeriesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobTimeSeriesRes.Marshal(b, m, deterministic)
+}
+func (m *GetJobTimeSeriesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobTimeSeriesRes.Merge(m, src)
+}
+func (m *GetJobTimeSeriesRes) XXX_Size() int {
+	return xxx_messageInfo_GetJobTimeSeriesRes.Size(m)
+}
+func (m *GetJobTimeSeriesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobTimeSeriesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobTimeSeriesRes proto.InternalMessageInfo
+
+func (m *GetJobTimeSeriesRes) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *GetJobTimeSeriesRes) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *GetJobTimeSeriesRes) GetTotalRuns() int64 {
+	if m != nil {
+		return m.TotalRuns
+	}
+	return 0
+}
+
+func (m *GetJobTimeSeriesRes) GetSucceededRuns() int64 {
+	if m != nil {
+		return m.SucceededRuns
+	}
+	return 0
+}
+
+func (m *GetJobTimeSeriesRes) GetFailedRuns() int64 {
+	if m != nil {
+		return m.FailedRuns
+	}
+	return 0
+}
+
+func (m *GetJobTimeSeriesRes) GetTimedOutRuns() int64 {
+	if m != nil {
+		return m.TimedOutRuns
+	}
+	return 0
+}
+
+func (m *GetJobTimeSeriesRes) GetCancelledRuns() int64 {
+	if m != nil {
+		return m.CancelledRuns
+	}
+	return 0
+}
+
+func (m *GetJobTimeSeriesRes) GetMeanDuration() *duration.Duration {
+	if m != nil {
+		return m.MeanDuration
+	}
+	return nil
+}
+
+// JobSLA is what the runs of a job are expected to meet, every limit that is set has to be met
+type JobSLA struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// How long a run may take at most, unset doesn't limit it
+	MaxDuration *duration.Duration `protobuf:"bytes,2,opt,name=max_duration,json=maxDuration,proto3" json:"max_duration,omitempty"`
+	// Time of day as HH:MM a run queued since the previous deadline must have succeeded by, every day. Empty for jobs
+	// without a daily deadline.
+	Deadline string `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// IANA time zone of the deadline, defaults to UTC
+	Timezone string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Set by the server
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *JobSLA) Reset()         { *m = JobSLA{} }
+func (m *JobSLA) String() string { return proto.CompactTextString(m) }
+func (*JobSLA) ProtoMessage()    {}
+func (*JobSLA) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{11}
+}
+
+func (m *JobSLA) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobSLA.Unmarshal(m, b)
+}
+func (m *JobSLA) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobSLA.Marshal(b, m, deterministic)
+}
+func (m *JobSLA) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSLA.Merge(m, src)
+}
+func (m *JobSLA) XXX_Size() int {
+	return xxx_messageInfo_JobSLA.Size(m)
+}
+func (m *JobSLA) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSLA.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobSLA proto.InternalMessageInfo
+
+func (m *JobSLA) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobSLA) GetMaxDuration() *duration.Duration {
+	if m != nil {
+		return m.MaxDuration
+	}
+	return nil
+}
+
+func (m *JobSLA) GetDeadline() string {
+	if m != nil {
+		return m.Deadline
+	}
+	return ""
+}
+
+func (m *JobSLA) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *JobSLA) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+// SLABreach records a job missing its SLA, every run and deadline is recorded once at most
+type SLABreach struct {
+	Id    string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId string        `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Kind  SLABreachKind `protobuf:"varint,3,opt,name=kind,proto3,enum=model.SLABreachKind" json:"kind,omitempty"`
+	// The run that took too long and how long it took, only set for duration breaches
+	RunId    string             `protobuf:"bytes,4,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Duration *duration.Duration `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	// The deadline that was missed, only set for deadline breaches
+	Deadline             *timestamp.Timestamp `protobuf:"bytes,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	DetectedAt           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SLABreach) Reset()         { *m = SLABreach{} }
+func (m *SLABreach) String() string { return proto.CompactTextString(m) }
+func (*SLABreach) ProtoMessage()    {}
+func (*SLABreach) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{12}
+}
+
+func (m *SLABreach) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SLABreach.Unmarshal(m, b)
+}
+func (m *SLABreach) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SLABreach.Marshal(b, m, deterministic)
+}
+func (m *SLABreach) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SLABreach.Merge(m, src)
+}
+func (m *SLABreach) XXX_Size() int {
+	return xxx_messageInfo_SLABreach.Size(m)
+}
+func (m *SLABreach) XXX_DiscardUnknown() {
+	xxx_messageInfo_SLABreach.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SLABreach proto.InternalMessageInfo
+
+func (m *SLABreach) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SLABreach) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *SLABreach) GetKind() SLABreachKind {
+	if m != nil {
+		return m.Kind
+	}
+	return SLABreachKind_SLA_BREACH_KIND_UNSPECIFIED
+}
+
+func (m *SLABreach) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *SLABreach) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *SLABreach) GetDeadline() *timestamp.Timestamp {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
+func (m *SLABreach) GetDetectedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return nil
+}
+
+// SLAStatus tells whether a job currently meets its SLA
+type SLAStatus struct {
+	JobId string  `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Sla   *JobSLA `protobuf:"bytes,2,opt,name=sla,proto3" json:"sla,omitempty"`
+	// Whether the last finished run took no longer than the max duration and a run succeeded by the last deadline.
+	// Deadlines before the SLA was set are met.
+	Met bool `protobuf:"varint,3,opt,name=met,proto3" json:"met,omitempty"`
+	// The last deadline that passed and the next one, unset without a deadline
+	LastDeadline *timestamp.Timestamp `protobuf:"bytes,4,opt,name=last_deadline,json=lastDeadline,proto3" json:"last_deadline,omitempty"`
+	NextDeadline *timestamp.Timestamp `protobuf:"bytes,5,opt,name=next_deadline,json=nextDeadline,proto3" json:"next_deadline,omitempty"`
+	// Whether a run queued since the last deadline succeeded already, which meets the next one
+	NextDeadlineMet bool `protobuf:"varint,6,opt,name=next_deadline_met,json=nextDeadlineMet,proto3" json:"next_deadline_met,omitempty"`
+	// The time range breaches were counted in, with the defaults filled in
+	TimeRange   *TimeRange `protobuf:"bytes,7,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	BreachCount int64      `protobuf:"varint,8,opt,name=breach_count,json=breachCount,proto3" json:"breach_count,omitempty"`
+	// The breach detected last, also when it was before the time range. Unset for jobs that never breached their SLA.
+	LastBreach           *SLABreach `protobuf:"bytes,9,opt,name=last_breach,json=lastBreach,proto3" json:"last_breach,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SLAStatus) Reset()         { *m = SLAStatus{} }
+func (m *SLAStatus) String() string { return proto.CompactTextString(m) }
+func (*SLAStatus) ProtoMessage()    {}
+func (*SLAStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{13}
+}
+
+func (m *SLAStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SLAStatus.Unmarshal(m, b)
+}
+func (m *SLAStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SLAStatus.Marshal(b, m, deterministic)
+}
+func (m *SLAStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SLAStatus.Merge(m, src)
+}
+func (m *SLAStatus) XXX_Size() int {
+	return xxx_messageInfo_SLAStatus.Size(m)
+}
+func (m *SLAStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SLAStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SLAStatus proto.InternalMessageInfo
+
+func (m *SLAStatus) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *SLAStatus) GetSla() *JobSLA {
+	if m != nil {
+		return m.Sla
+	}
+	return nil
+}
+
+func (m *SLAStatus) GetMet() bool {
+	if m != nil {
+		return m.Met
+	}
+	return false
+}
+
+func (m *SLAStatus) GetLastDeadline() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastDeadline
+	}
+	return nil
+}
+
+func (m *SLAStatus) GetNextDeadline() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextDeadline
+	}
+	return nil
+}
+
+func (m *SLAStatus) GetNextDeadlineMet() bool {
+	if m != nil {
+		return m.NextDeadlineMet
+	}
+	return false
+}
+
+func (m *SLAStatus) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+func (m *SLAStatus) GetBreachCount() int64 {
+	if m != nil {
+		return m.BreachCount
+	}
+	return 0
+}
+
+func (m *SLAStatus) GetLastBreach() *SLABreach {
+	if m != nil {
+		return m.LastBreach
+	}
+	return nil
+}
+
+type SetJobSLAReq struct {
+	Sla                  *JobSLA  `protobuf:"bytes,1,opt,name=sla,proto3" json:"sla,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetJobSLAReq) Reset()         { *m = SetJobSLAReq{} }
+func (m *SetJobSLAReq) String() string { return proto.CompactTextString(m) }
+func (*SetJobSLAReq) ProtoMessage()    {}
+func (*SetJobSLAReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{14}
+}
+
+func (m *SetJobSLAReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetJobSLAReq.Unmarshal(m, b)
+}
+func (m *SetJobSLAReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetJobSLAReq.Marshal(b, m, deterministic)
+}
+func (m *SetJobSLAReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetJobSLAReq.Merge(m, src)
+}
+func (m *SetJobSLAReq) XXX_Size() int {
+	return xxx_messageInfo_SetJobSLAReq.Size(m)
+}
+func (m *SetJobSLAReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetJobSLAReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetJobSLAReq proto.InternalMessageInfo
+
+func (m *SetJobSLAReq) GetSla() *JobSLA {
+	if m != nil {
+		return m.Sla
+	}
+	return nil
+}
+
+type SetJobSLARes struct {
+	Sla                  *JobSLA  `protobuf:"bytes,1,opt,name=sla,proto3" json:"sla,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetJobSLARes) Reset()         { *m = SetJobSLARes{} }
+func (m *SetJobSLARes) String() string { return proto.CompactTextString(m) }
+func (*SetJobSLARes) ProtoMessage()    {}
+func (*SetJobSLARes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{15}
+}
+
+func (m *SetJobSLARes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetJobSLARes.Unmarshal(m, b)
+}
+func (m *SetJobSLARes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetJobSLARes.Marshal(b, m, deterministic)
+}
+func (m *SetJobSLARes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetJobSLARes.Merge(m, src)
+}
+func (m *SetJobSLARes) XXX_Size() int {
+	return xxx_messageInfo_SetJobSLARes.Size(m)
+}
+func (m *SetJobSLARes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetJobSLARes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetJobSLARes proto.InternalMessageInfo
+
+func (m *SetJobSLARes) GetSla() *JobSLA {
+	if m != nil {
+		return m.Sla
+	}
+	return nil
+}
+
+type DeleteJobSLAReq struct {
+	JobId                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobSLAReq) Reset()         { *m = DeleteJobSLAReq{} }
+func (m *DeleteJobSLAReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobSLAReq) ProtoMessage()    {}
+func (*DeleteJobSLAReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{16}
+}
+
+func (m *DeleteJobSLAReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobSLAReq.Unmarshal(m, b)
+}
+func (m *DeleteJobSLAReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobSLAReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobSLAReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobSLAReq.Merge(m, src)
+}
+func (m *DeleteJobSLAReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobSLAReq.Size(m)
+}
+func (m *DeleteJobSLAReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobSLAReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobSLAReq proto.InternalMessageInfo
+
+func (m *DeleteJobSLAReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type DeleteJobSLARes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobSLARes) Reset()         { *m = DeleteJobSLARes{} }
+func (m *DeleteJobSLARes) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobSLARes) ProtoMessage()    {}
+func (*DeleteJobSLARes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{17}
+}
+
+func (m *DeleteJobSLARes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobSLARes.Unmarshal(m, b)
+}
+func (m *DeleteJobSLARes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobSLARes.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobSLARes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobSLARes.Merge(m, src)
+}
+func (m *DeleteJobSLARes) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobSLARes.Size(m)
+}
+func (m *DeleteJobSLARes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobSLARes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobSLARes proto.InternalMessageInfo
+
+type GetSLAStatusReq struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// The time range breaches are counted in by the time they were detected
+	TimeRange            *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetSLAStatusReq) Reset()         { *m = GetSLAStatusReq{} }
+func (m *GetSLAStatusReq) String() string { return proto.CompactTextString(m) }
+func (*GetSLAStatusReq) ProtoMessage()    {}
+func (*GetSLAStatusReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{18}
+}
+
+func (m *GetSLAStatusReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSLAStatusReq.Unmarshal(m, b)
+}
+func (m *GetSLAStatusReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSLAStatusReq.Marshal(b, m, deterministic)
+}
+func (m *GetSLAStatusReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSLAStatusReq.Merge(m, src)
+}
+func (m *GetSLAStatusReq) XXX_Size() int {
+	return xxx_messageInfo_GetSLAStatusReq.Size(m)
+}
+func (m *GetSLAStatusReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSLAStatusReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSLAStatusReq proto.InternalMessageInfo
+
+func (m *GetSLAStatusReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *GetSLAStatusReq) GetTimeRange() *TimeRange {
+	if m != nil {
+		return m.TimeRange
+	}
+	return nil
+}
+
+type GetSLAStatusRes struct {
+	Status               *SLAStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetSLAStatusRes) Reset()         { *m = GetSLAStatusRes{} }
+func (m *GetSLAStatusRes) String() string { return proto.CompactTextString(m) }
+func (*GetSLAStatusRes) ProtoMessage()    {}
+func (*GetSLAStatusRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{19}
+}
+
+func (m *GetSLAStatusRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSLAStatusRes.Unmarshal(m, b)
+}
+func (m *GetSLAStatusRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSLAStatusRes.Marshal(b, m, deterministic)
+}
+func (m *GetSLAStatusRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSLAStatusRes.Merge(m, src)
+}
+func (m *GetSLAStatusRes) XXX_Size() int {
+	return xxx_messageInfo_GetSLAStatusRes.Size(m)
+}
+func (m *GetSLAStatusRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSLAStatusRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSLAStatusRes proto.InternalMessageInfo
+
+func (m *GetSLAStatusRes) GetStatus() *SLAStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ListSLABreachesReq struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Maximum number of breaches to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue listing after the last breach returned
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSLABreachesReq) Reset()         { *m = ListSLABreachesReq{} }
+func (m *ListSLABreachesReq) String() string { return proto.CompactTextString(m) }
+func (*ListSLABreachesReq) ProtoMessage()    {}
+func (*ListSLABreachesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{20}
+}
+
+func (m *ListSLABreachesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSLABreachesReq.Unmarshal(m, b)
+}
+func (m *ListSLABreachesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSLABreachesReq.Marshal(b, m, deterministic)
+}
+func (m *ListSLABreachesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSLABreachesReq.Merge(m, src)
+}
+func (m *ListSLABreachesReq) XXX_Size() int {
+	return xxx_messageInfo_ListSLABreachesReq.Size(m)
+}
+func (m *ListSLABreachesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSLABreachesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSLABreachesReq proto.InternalMessageInfo
+
+func (m *ListSLABreachesReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ListSLABreachesReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListSLABreachesReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListSLABreachesRes struct {
+	Breach *SLABreach `protobuf:"bytes,1,opt,name=breach,proto3" json:"breach,omitempty"`
+	// Only set on the last message of a page when more breaches are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSLABreachesRes) Reset()         { *m = ListSLABreachesRes{} }
+func (m *ListSLABreachesRes) String() string { return proto.CompactTextString(m) }
+func (*ListSLABreachesRes) ProtoMessage()    {}
+func (*ListSLABreachesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{21}
+}
+
+func (m *ListSLABreachesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSLABreachesRes.Unmarshal(m, b)
+}
+func (m *ListSLABreachesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSLABreachesRes.Marshal(b, m, deterministic)
+}
+func (m *ListSLABreachesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSLABreachesRes.Merge(m, src)
+}
+func (m *ListSLABreachesRes) XXX_Size() int {
+	return xxx_messageInfo_ListSLABreachesRes.Size(m)
+}
+func (m *ListSLABreachesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSLABreachesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSLABreachesRes proto.InternalMessageInfo
+
+func (m *ListSLABreachesRes) GetBreach() *SLABreach {
+	if m != nil {
+		return m.Breach
+	}
+	return nil
+}
+
+func (m *ListSLABreachesRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// Anomaly is a succeeded run whose duration deviated from the baseline of its job by more than the threshold of the
+// server
+type Anomaly struct {
+	Id       string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId    string             `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	RunId    string             `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Duration *duration.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// The mean and standard deviation of the durations of the last baseline_runs succeeded runs before the run
+	BaselineMean   *duration.Duration `protobuf:"bytes,5,opt,name=baseline_mean,json=baselineMean,proto3" json:"baseline_mean,omitempty"`
+	BaselineStddev *duration.Duration `protobuf:"bytes,6,opt,name=baseline_stddev,json=baselineStddev,proto3" json:"baseline_stddev,omitempty"`
+	BaselineRuns   int32              `protobuf:"varint,7,opt,name=baseline_runs,json=baselineRuns,proto3" json:"baseline_runs,omitempty"`
+	// How many standard deviations the run took longer than the mean, negative when it was faster. The standard
+	// deviation counts as at least a tenth of the mean.
+	Score                float64              `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	DetectedAt           *timestamp.Timestamp `protobuf:"bytes,9,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Anomaly) Reset()         { *m = Anomaly{} }
+func (m *Anomaly) String() string { return proto.CompactTextString(m) }
+func (*Anomaly) ProtoMessage()    {}
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{22}
+}
+
+func (m *Anomaly) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Anomaly.Unmarshal(m, b)
+}
+func (m *Anomaly) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Anomaly.Marshal(b, m, deterministic)
+}
+func (m *Anomaly) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Anomaly.Merge(m, src)
+}
+func (m *Anomaly) XXX_Size() int {
+	return xxx_messageInfo_Anomaly.Size(m)
+}
+func (m *Anomaly) XXX_DiscardUnknown() {
+	xxx_messageInfo_Anomaly.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Anomaly proto.InternalMessageInfo
+
+func (m *Anomaly) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Anomaly) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *Anomaly) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *Anomaly) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *Anomaly) GetBaselineMean() *duration.Duration {
+	if m != nil {
+		return m.BaselineMean
+	}
+	return nil
+}
+
+func (m *Anomaly) GetBaselineStddev() *duration.Duration {
+	if m != nil {
+		return m.BaselineStddev
+	}
+	return nil
+}
+
+func (m *Anomaly) GetBaselineRuns() int32 {
+	if m != nil {
+		return m.BaselineRuns
+	}
+	return 0
+}
+
+func (m *Anomaly) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Anomaly) GetDetectedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return nil
+}
+
+type ListAnomaliesReq struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Maximum number of anomalies to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue listing after the last anomaly returned
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAnomaliesReq) Reset()         { *m = ListAnomaliesReq{} }
+func (m *ListAnomaliesReq) String() string { return proto.CompactTextString(m) }
+func (*ListAnomaliesReq) ProtoMessage()    {}
+func (*ListAnomaliesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{23}
+}
+
+func (m *ListAnomaliesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAnomaliesReq.Unmarshal(m, b)
+}
+func (m *ListAnomaliesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAnomaliesReq.Marshal(b, m, deterministic)
+}
+func (m *ListAnomaliesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAnomaliesReq.Merge(m, src)
+}
+func (m *ListAnomaliesReq) XXX_Size() int {
+	return xxx_messageInfo_ListAnomaliesReq.Size(m)
+}
+func (m *ListAnomaliesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAnomaliesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAnomaliesReq proto.InternalMessageInfo
+
+func (m *ListAnomaliesReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ListAnomaliesReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListAnomaliesReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListAnomaliesRes struct {
+	Anomaly *Anomaly `protobuf:"bytes,1,opt,name=anomaly,proto3" json:"anomaly,omitempty"`
+	// Only set on the last message of a page when more anomalies are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAnomaliesRes) Reset()         { *m = ListAnomaliesRes{} }
+func (m *ListAnomaliesRes) String() string { return proto.CompactTextString(m) }
+func (*ListAnomaliesRes) ProtoMessage()    {}
+func (*ListAnomaliesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a62568081fc9ca55, []int{24}
+}
+
+func (m *ListAnomaliesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAnomaliesRes.Unmarshal(m, b)
+}
+func (m *ListAnomaliesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAnomaliesRes.Marshal(b, m, deterministic)
+}
+func (m *ListAnomaliesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAnomaliesRes.Merge(m, src)
+}
+func (m *ListAnomaliesRes) XXX_Size() int {
+	return xxx_messageInfo_ListAnomaliesRes.Size(m)
+}
+func (m *ListAnomaliesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAnomaliesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAnomaliesRes proto.InternalMessageInfo
+
+func (m *ListAnomaliesRes) GetAnomaly() *Anomaly {
+	if m != nil {
+		return m.Anomaly
+	}
+	return nil
+}
+
+func (m *ListAnomaliesRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("model.CostGroup", CostGroup_name, CostGroup_value)
+	proto.RegisterEnum("model.TimeSeriesBucket", TimeSeriesBucket_name, TimeSeriesBucket_value)
+	proto.RegisterEnum("model.SLABreachKind", SLABreachKind_name, SLABreachKind_value)
+	proto.RegisterType((*TimeRange)(nil), "model.TimeRange")
+	proto.RegisterType((*JobStats)(nil), "model.JobStats")
+	proto.RegisterType((*GetJobStatsReq)(nil), "model.GetJobStatsReq")
+	proto.RegisterType((*GetJobStatsRes)(nil), "model.GetJobStatsRes")
+	proto.RegisterType((*OwnerSummary)(nil), "model.OwnerSummary")
+	proto.RegisterType((*GetOwnerSummaryReq)(nil), "model.GetOwnerSummaryReq")
+	proto.RegisterType((*GetOwnerSummaryRes)(nil), "model.GetOwnerSummaryRes")
+	proto.RegisterType((*GetCostReportReq)(nil), "model.GetCostReportReq")
+	proto.RegisterType((*GetCostReportRes)(nil), "model.GetCostReportRes")
+	proto.RegisterType((*GetJobTimeSeriesReq)(nil), "model.GetJobTimeSeriesReq")
+	proto.RegisterType((*GetJobTimeSeriesRes)(nil), "model.GetJobTimeSeriesRes")
+	proto.RegisterType((*JobSLA)(nil), "model.JobSLA")
+	proto.RegisterType((*SLABreach)(nil), "model.SLABreach")
+	proto.RegisterType((*SLAStatus)(nil), "model.SLAStatus")
+	proto.RegisterType((*SetJobSLAReq)(nil), "model.SetJobSLAReq")
+	proto.RegisterType((*SetJobSLARes)(nil), "model.SetJobSLARes")
+	proto.RegisterType((*DeleteJobSLAReq)(nil), "model.DeleteJobSLAReq")
+	proto.RegisterType((*DeleteJobSLARes)(nil), "model.DeleteJobSLARes")
+	proto.RegisterType((*GetSLAStatusReq)(nil), "model.GetSLAStatusReq")
+	proto.RegisterType((*GetSLAStatusRes)(nil), "model.GetSLAStatusRes")
+	proto.RegisterType((*ListSLABreachesReq)(nil), "model.ListSLABreachesReq")
+	proto.RegisterType((*ListSLABreachesRes)(nil), "model.ListSLABreachesRes")
+	proto.RegisterType((*Anomaly)(nil), "model.Anomaly")
+	proto.RegisterType((*ListAnomaliesReq)(nil), "model.ListAnomaliesReq")
+	proto.RegisterType((*ListAnomaliesRes)(nil), "model.ListAnomaliesRes")
+}
+
+func init() { proto.RegisterFile("analytics.proto", fileDescriptor_a62568081fc9ca55) }
+
+var fileDescriptor_a62568081fc9ca55 = []byte{
+	// 1748 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x4b, 0x6f, 0xe3, 0xc8,
+	0x11, 0x0e, 0x25, 0xeb, 0x55, 0x7a, 0x71, 0x7b, 0x1e, 0xd6, 0x70, 0x1e, 0x9e, 0xe5, 0x6e, 0x16,
+	0x8e, 0x83, 0x58, 0xde, 0x0d, 0x9c, 0xc0, 0xd9, 0xc1, 0x2e, 0x64, 0x5b, 0xf1, 0x68, 0xc6, 0xb6,
+	0x06, 0x94, 0x8c, 0xcd, 0x62, 0xb3, 0x20, 0x5a, 0x64, 0x8f, 0x87, 0x33, 0x12, 0xa9, 0x65, 0x37,
+	0x9d, 0xf1, 0x24, 0x7b, 0xc9, 0x21, 0x87, 0x20, 0xb7, 0xdc, 0x93, 0x73, 0xf2, 0x6b, 0x02, 0xe4,
+	0x2f, 0xe4, 0x92, 0xbf, 0xb0, 0xb9, 0x04, 0xdd, 0x6c, 0x52, 0x14, 0x2d, 0x0d, 0x35, 0x88, 0x13,
+	0x20, 0x37, 0x76, 0xd5, 0x57, 0x8f, 0xae, 0xaa, 0xae, 0xea, 0x26, 0x34, 0xb1, 0x8b, 0xc7, 0x97,
+	0xcc, 0xb1, 0xe8, 0xf6, 0xd4, 0xf7, 0x98, 0x87, 0x0a, 0x13, 0xcf, 0x26, 0x63, 0xed, 0xde, 0xb9,
+	0xe7, 0x9d, 0x8f, 0x49, 0x1b, 0x4f, 0x9d, 0x36, 0x76, 0x5d, 0x8f, 0x61, 0xe6, 0x78, 0xae, 0x04,
+	0x69, 0x0f, 0x24, 0x57, 0xac, 0x46, 0xc1, 0xf3, 0xb6, 0x1d, 0xf8, 0x02, 0x20, 0xf9, 0x1b, 0x69,
+	0x3e, 0x73, 0x26, 0x84, 0x32, 0x3c, 0x99, 0x86, 0x00, 0xfd, 0x5b, 0xa8, 0x0c, 0x9d, 0x09, 0x31,
+	0xb0, 0x7b, 0x4e, 0xd0, 0x1e, 0x00, 0x65, 0xd8, 0x67, 0x26, 0x47, 0xb5, 0x94, 0x87, 0xca, 0x66,
+	0xf5, 0x13, 0x6d, 0x3b, 0x54, 0xb1, 0x1d, 0xa9, 0xd8, 0x1e, 0x46, 0x2a, 0x8c, 0x8a, 0x40, 0xf3,
+	0x35, 0xda, 0x85, 0x32, 0x71, 0xed, 0x50, 0x30, 0x97, 0x29, 0x58, 0x22, 0xae, 0xcd, 0x57, 0xfa,
+	0x3f, 0xd7, 0xa0, 0xfc, 0xc4, 0x1b, 0x0d, 0x18, 0x66, 0x14, 0xdd, 0x82, 0xe2, 0x4b, 0x6f, 0x64,
+	0x3a, 0xb6, 0x30, 0x5d, 0x31, 0x0a, 0x2f, 0xbd, 0x51, 0xcf, 0x46, 0x6d, 0x00, 0xae, 0xd6, 0xf4,
+	0xb9, 0x8f, 0x52, 0xb9, 0xba, 0x2d, 0xa2, 0xb3, 0x1d, 0xfb, 0x6e, 0x54, 0x58, 0xbc, 0x8d, 0xfb,
+	0x00, 0xcc, 0x63, 0x78, 0x6c, 0xfa, 0x81, 0x4b, 0x5b, 0xf9, 0x87, 0xca, 0x66, 0xde, 0xa8, 0x08,
+	0x8a, 0x11, 0xb8, 0x14, 0x7d, 0x1f, 0x1a, 0x34, 0xb0, 0x2c, 0x42, 0x6c, 0x62, 0x87, 0x90, 0x35,
+	0x01, 0xa9, 0xc7, 0x54, 0x01, 0xdb, 0x80, 0xea, 0x73, 0xec, 0x8c, 0x23, 0x4c, 0x41, 0x60, 0x20,
+	0x24, 0x09, 0xc0, 0x87, 0xd0, 0xe0, 0x36, 0x6d, 0xd3, 0x0b, 0x58, 0x88, 0x29, 0x0a, 0x4c, 0x4d,
+	0x50, 0xfb, 0x01, 0x8b, 0xac, 0x59, 0xd8, 0xb5, 0xc8, 0x38, 0xd6, 0x54, 0x0a, 0xad, 0xc5, 0x54,
+	0x01, 0x7b, 0x1f, 0x6a, 0x53, 0xe2, 0xda, 0x8e, 0x7b, 0x1e, 0x82, 0xca, 0x02, 0x54, 0x95, 0xb4,
+	0x08, 0x22, 0x3c, 0xa4, 0xd4, 0xf4, 0x31, 0x23, 0xad, 0xca, 0x43, 0x65, 0x53, 0x31, 0xaa, 0x92,
+	0x66, 0x60, 0x46, 0x38, 0x84, 0x3b, 0x18, 0xf8, 0x24, 0x84, 0x40, 0x08, 0x91, 0x34, 0x01, 0xf9,
+	0x0c, 0xea, 0x13, 0x82, 0x5d, 0x33, 0x2a, 0x94, 0x56, 0x55, 0x04, 0xf4, 0xce, 0x95, 0x6c, 0x1d,
+	0x4a, 0x80, 0x51, 0xe3, 0xf8, 0x68, 0x85, 0x1e, 0x41, 0x6d, 0xba, 0xbb, 0x33, 0x13, 0xaf, 0x65,
+	0x89, 0x57, 0xa7, 0xbb, 0x3b, 0x73, 0xd2, 0x7b, 0xbb, 0x33, 0xe9, 0x7a, 0xb6, 0xf4, 0xde, 0xee,
+	0xbc, 0xf4, 0xde, 0x4c, 0xba, 0xb1, 0x82, 0xf4, 0x5e, 0xb4, 0xd0, 0x7f, 0x01, 0x8d, 0x23, 0xc2,
+	0xa2, 0x6a, 0x33, 0xc8, 0x37, 0xd7, 0x55, 0x70, 0xfa, 0x4f, 0x53, 0x9a, 0x79, 0xd6, 0x0b, 0x94,
+	0x7f, 0xcb, 0x43, 0xd4, 0x94, 0xd2, 0x31, 0x24, 0xe4, 0xea, 0xdf, 0xe5, 0xa1, 0xd6, 0xff, 0x95,
+	0x4b, 0xfc, 0x41, 0x30, 0x99, 0x60, 0xff, 0x12, 0xdd, 0x84, 0x82, 0xc7, 0xd7, 0x91, 0x43, 0x62,
+	0xf1, 0x1f, 0x9c, 0x80, 0x97, 0xde, 0x68, 0xfe, 0x04, 0x3c, 0xf1, 0x46, 0x14, 0xfd, 0x00, 0x54,
+	0x6c, 0x31, 0xe7, 0x82, 0x98, 0xd4, 0x7a, 0x41, 0xec, 0x60, 0x4c, 0xa2, 0x33, 0xd0, 0x0c, 0xe9,
+	0x83, 0x88, 0x9c, 0x3a, 0x4b, 0x85, 0xec, 0xb3, 0x54, 0x5c, 0xe1, 0x2c, 0x95, 0x56, 0x38, 0x4b,
+	0xe5, 0x95, 0xce, 0x52, 0x65, 0x95, 0xb3, 0x04, 0xd9, 0x67, 0xa9, 0x9a, 0x7d, 0x96, 0x6a, 0x57,
+	0xcf, 0xd2, 0x23, 0xa8, 0x59, 0xde, 0x64, 0x1a, 0x30, 0x12, 0x36, 0xbe, 0xec, 0x6a, 0x96, 0x70,
+	0xd1, 0xfb, 0xbe, 0x02, 0x74, 0x44, 0x58, 0x32, 0xfd, 0xbc, 0x26, 0xaf, 0xa7, 0x02, 0xf4, 0x83,
+	0x05, 0xca, 0x29, 0xfa, 0x11, 0x94, 0x68, 0xb8, 0x92, 0x85, 0x79, 0x43, 0xea, 0x98, 0x03, 0x46,
+	0x18, 0xfd, 0x77, 0x0a, 0xa8, 0x47, 0x84, 0x1d, 0x78, 0x94, 0x19, 0x64, 0xea, 0xf9, 0xec, 0xfa,
+	0x1c, 0x44, 0x3f, 0x84, 0xf2, 0xb9, 0xef, 0x05, 0x53, 0x73, 0x74, 0x29, 0x0a, 0xb4, 0x11, 0xc3,
+	0xb9, 0xb9, 0x23, 0xce, 0x32, 0x4a, 0x02, 0xb1, 0x7f, 0xa9, 0xff, 0xe5, 0xaa, 0x23, 0x14, 0xa9,
+	0x90, 0x7f, 0x45, 0x2e, 0xa5, 0x1b, 0xfc, 0x13, 0x21, 0x58, 0x73, 0xb1, 0x1c, 0x40, 0x15, 0x43,
+	0x7c, 0x67, 0x0d, 0x83, 0x74, 0x0a, 0xd7, 0xde, 0x25, 0x85, 0xdc, 0xa0, 0xe5, 0x51, 0x26, 0xce,
+	0x85, 0x62, 0x88, 0x6f, 0xfd, 0xaf, 0x0a, 0xdc, 0x08, 0xbb, 0x01, 0x87, 0x0c, 0x88, 0xef, 0x90,
+	0xeb, 0x6c, 0x36, 0xa8, 0x0d, 0xc5, 0x51, 0x60, 0xbd, 0x22, 0x4c, 0x86, 0x6d, 0x3d, 0x01, 0x0e,
+	0xad, 0xed, 0x0b, 0xb6, 0x21, 0x61, 0x48, 0x83, 0x32, 0x97, 0x7e, 0xe3, 0xb9, 0xe1, 0xf6, 0x2a,
+	0x46, 0xbc, 0xd6, 0xff, 0x95, 0x5b, 0xe4, 0x2c, 0xfd, 0xdf, 0xdf, 0x04, 0xfe, 0x2f, 0x87, 0xf6,
+	0x95, 0x59, 0x5a, 0x7e, 0xa7, 0x59, 0xaa, 0xff, 0x4d, 0x81, 0x22, 0x1f, 0x09, 0xc7, 0x9d, 0x65,
+	0xd5, 0xf1, 0x08, 0x6a, 0x13, 0xfc, 0x7a, 0x66, 0x20, 0x97, 0x59, 0x9e, 0x13, 0xfc, 0x3a, 0x5a,
+	0xf0, 0xcc, 0xdb, 0x04, 0xdb, 0x63, 0xc7, 0x25, 0x22, 0xa2, 0x15, 0x23, 0x5e, 0xbf, 0xad, 0x2a,
+	0x78, 0xf6, 0x83, 0xa9, 0x8d, 0x19, 0xb1, 0x4d, 0x1c, 0x16, 0x77, 0x46, 0xf6, 0x25, 0xba, 0xc3,
+	0xf4, 0x3f, 0xe7, 0xa0, 0x32, 0x38, 0xee, 0xec, 0xfb, 0x04, 0x5b, 0x2f, 0x50, 0x03, 0x72, 0xf1,
+	0x8e, 0x72, 0x8e, 0x9d, 0xd8, 0x65, 0x2e, 0xb9, 0xcb, 0x4d, 0x58, 0x7b, 0xe5, 0xb8, 0xb6, 0x2c,
+	0xe8, 0x9b, 0xb2, 0xa0, 0x63, 0x35, 0x4f, 0x1d, 0xd7, 0x36, 0x04, 0x82, 0x2b, 0xf0, 0x03, 0x97,
+	0x2b, 0x08, 0x7d, 0x2e, 0xf8, 0x81, 0xdb, 0xb3, 0x79, 0xcd, 0xc5, 0x21, 0x2a, 0x64, 0x85, 0x28,
+	0x86, 0xa2, 0x9f, 0x24, 0xe2, 0x53, 0xcc, 0xdc, 0xe5, 0x2c, 0x76, 0x9f, 0x42, 0xd5, 0x26, 0x8c,
+	0x58, 0x32, 0x40, 0xa5, 0x4c, 0x51, 0x88, 0xe0, 0x1d, 0xa6, 0xff, 0x3e, 0x2f, 0x22, 0xc4, 0xef,
+	0x01, 0xc1, 0xd2, 0x3b, 0xef, 0x06, 0xe4, 0xe9, 0x18, 0xcb, 0x74, 0xd7, 0x13, 0xb7, 0x87, 0xe3,
+	0x8e, 0xc1, 0x39, 0xbc, 0xf9, 0x4d, 0x64, 0x0b, 0x28, 0x1b, 0xfc, 0x13, 0x7d, 0x0e, 0xf5, 0x31,
+	0xa6, 0xcc, 0x8c, 0x77, 0xb4, 0x96, 0xe9, 0x56, 0x8d, 0x0b, 0x1c, 0x46, 0xbb, 0xfa, 0x1c, 0xea,
+	0x2e, 0x79, 0x9d, 0x50, 0x90, 0x9d, 0xf8, 0x1a, 0x17, 0x88, 0x15, 0x6c, 0xc1, 0x7b, 0x73, 0x0a,
+	0x4c, 0xee, 0x61, 0x51, 0x78, 0xd8, 0x4c, 0x02, 0x4f, 0x08, 0x4b, 0xb5, 0xbd, 0x52, 0x76, 0xdb,
+	0x7b, 0x1f, 0x6a, 0x23, 0x51, 0x0d, 0xa6, 0xe5, 0x05, 0x2e, 0x8b, 0x2e, 0xc8, 0x21, 0xed, 0x80,
+	0x93, 0xd0, 0xc7, 0x50, 0x15, 0x11, 0x08, 0x69, 0xad, 0xca, 0x9c, 0xd2, 0xb8, 0x9a, 0x0c, 0xe0,
+	0xa0, 0xf0, 0x5b, 0x6f, 0x43, 0x6d, 0x10, 0xde, 0xdc, 0x8e, 0x3b, 0xbc, 0x49, 0xcb, 0xb8, 0x2b,
+	0xcb, 0xe2, 0x9e, 0x12, 0xa0, 0xd9, 0x02, 0x9b, 0xd0, 0x3c, 0x24, 0x63, 0xc2, 0xc8, 0xcc, 0xc8,
+	0xe2, 0x9c, 0xeb, 0xef, 0xa5, 0x91, 0x54, 0xff, 0x12, 0x9a, 0x47, 0x84, 0xc5, 0xd5, 0x72, 0x9d,
+	0x77, 0xd6, 0x4f, 0xd3, 0xaa, 0x29, 0xda, 0x84, 0x22, 0x15, 0x8b, 0x96, 0x32, 0x27, 0x3f, 0x03,
+	0x49, 0xbe, 0x7e, 0x0e, 0xe8, 0xd8, 0xa1, 0x2c, 0x8e, 0xe9, 0x5b, 0x27, 0xdc, 0x5d, 0xa8, 0x4c,
+	0xf1, 0x39, 0x31, 0xa9, 0xf3, 0x26, 0xf4, 0xac, 0x60, 0x94, 0x39, 0x61, 0xe0, 0xbc, 0x11, 0x6d,
+	0x5f, 0x30, 0x99, 0xf7, 0x8a, 0xb8, 0xb2, 0x49, 0x09, 0xf8, 0x90, 0x13, 0xf4, 0xe7, 0x0b, 0x0c,
+	0x09, 0x47, 0x65, 0x8e, 0x95, 0x25, 0x39, 0x96, 0x7c, 0xf4, 0x11, 0x88, 0xca, 0x33, 0x13, 0x36,
+	0xc2, 0xce, 0x23, 0x4a, 0xfd, 0x59, 0x6c, 0xe7, 0xbb, 0x1c, 0x94, 0x3a, 0xae, 0x37, 0xc1, 0xe3,
+	0xcb, 0x55, 0x9b, 0xd6, 0xac, 0x15, 0xe5, 0x97, 0xb5, 0xa2, 0xb5, 0xd5, 0x5b, 0xd1, 0x67, 0x50,
+	0x1f, 0x61, 0x4a, 0xe4, 0xb1, 0xc1, 0x2b, 0xb4, 0xb1, 0x5a, 0x84, 0x3f, 0x21, 0xd8, 0x45, 0xfb,
+	0xd0, 0x8c, 0xe5, 0x29, 0xb3, 0x6d, 0x72, 0xd1, 0x2a, 0x66, 0x69, 0x68, 0x44, 0x12, 0x03, 0x21,
+	0x80, 0x3e, 0x48, 0xf8, 0x10, 0x0f, 0xbd, 0xc2, 0xcc, 0x90, 0x98, 0x79, 0x37, 0xa1, 0x40, 0x2d,
+	0xcf, 0x27, 0xe2, 0x00, 0x2a, 0x46, 0xb8, 0x48, 0x77, 0xc4, 0xca, 0x3b, 0x75, 0x44, 0x02, 0x2a,
+	0x4f, 0x72, 0x18, 0x7f, 0xe7, 0xbf, 0x56, 0x4b, 0xf6, 0x15, 0x33, 0xbc, 0x92, 0x4a, 0x38, 0x4c,
+	0xbb, 0x2c, 0xa5, 0x86, 0x2c, 0x25, 0x59, 0x0c, 0x46, 0xc4, 0x5e, 0xb5, 0x92, 0xb6, 0x7e, 0x03,
+	0x95, 0xf8, 0x02, 0x8b, 0x34, 0xb8, 0x7d, 0xd0, 0x1f, 0x0c, 0xcd, 0x23, 0xa3, 0x7f, 0xf6, 0xcc,
+	0x3c, 0x3b, 0x1d, 0x3c, 0xeb, 0x1e, 0xf4, 0x7e, 0xde, 0xeb, 0x1e, 0xaa, 0xdf, 0x43, 0x08, 0x1a,
+	0x09, 0xde, 0x93, 0xfe, 0xbe, 0xaa, 0xa0, 0x9b, 0xa0, 0x26, 0x68, 0xfd, 0x2f, 0x4e, 0xbb, 0x86,
+	0x9a, 0x43, 0x37, 0xa0, 0x99, 0xa0, 0x0e, 0xbb, 0x9d, 0x13, 0x35, 0x9f, 0x82, 0x9e, 0xf4, 0x4f,
+	0x87, 0x8f, 0xd5, 0xb5, 0xad, 0x3f, 0x28, 0xa0, 0xa6, 0x2f, 0x82, 0x48, 0x87, 0x07, 0xc3, 0xde,
+	0x49, 0xd7, 0x1c, 0x74, 0x8d, 0x5e, 0x77, 0x60, 0xee, 0x9f, 0x1d, 0x3c, 0xed, 0x0e, 0x53, 0xde,
+	0xdc, 0x85, 0xf5, 0x05, 0x98, 0xc7, 0xfd, 0x33, 0x43, 0x55, 0xf8, 0x36, 0x16, 0x30, 0x0f, 0x3b,
+	0x5f, 0xaa, 0xb9, 0x25, 0x82, 0x5f, 0x74, 0xbb, 0x4f, 0xd5, 0xfc, 0xd6, 0x18, 0xea, 0x73, 0x53,
+	0x1c, 0x6d, 0xc0, 0xdd, 0xc1, 0x71, 0xc7, 0xdc, 0x37, 0xba, 0x9d, 0x83, 0xc7, 0xe6, 0xd3, 0xde,
+	0xe9, 0x61, 0xca, 0x8f, 0x7b, 0xd0, 0x4a, 0x03, 0x0e, 0xcf, 0x8c, 0xce, 0xb0, 0xd7, 0x3f, 0x55,
+	0x95, 0x85, 0xdc, 0x6e, 0xe7, 0xf0, 0xb8, 0x77, 0xda, 0x55, 0x73, 0x9f, 0xfc, 0xa9, 0x04, 0x6a,
+	0x27, 0xfa, 0x8b, 0x36, 0x20, 0xfe, 0x85, 0x63, 0x11, 0xf4, 0x4b, 0xa8, 0x26, 0xde, 0xe6, 0xe8,
+	0x96, 0xcc, 0xef, 0xfc, 0x9f, 0x00, 0x6d, 0x21, 0x99, 0xea, 0x1b, 0xbf, 0xfd, 0xfb, 0x3f, 0xfe,
+	0x98, 0xbb, 0x83, 0xd6, 0xdb, 0x17, 0x1f, 0xb7, 0xf9, 0x5b, 0xba, 0xfd, 0xeb, 0xb0, 0x2a, 0xbf,
+	0x6d, 0x8b, 0x07, 0x3c, 0xc2, 0xa2, 0x8b, 0xce, 0x3d, 0xe1, 0xef, 0xcc, 0x54, 0xa5, 0xde, 0x76,
+	0xda, 0x52, 0x16, 0xd5, 0x5b, 0xc2, 0x12, 0x42, 0x2a, 0xb7, 0xe4, 0x25, 0xf5, 0x7d, 0x05, 0xf5,
+	0xb9, 0xa7, 0x0f, 0x5a, 0x9f, 0x69, 0x99, 0x7b, 0x99, 0x69, 0x4b, 0x18, 0x54, 0xbf, 0x2d, 0x94,
+	0xab, 0xa8, 0xc1, 0x95, 0x5b, 0x31, 0x6b, 0x47, 0x41, 0x3e, 0xa8, 0xe9, 0xeb, 0x3f, 0xd2, 0xe6,
+	0x62, 0x31, 0xf7, 0x88, 0xd1, 0x96, 0xf3, 0xa8, 0xfe, 0xa1, 0xb0, 0xf2, 0x00, 0xdd, 0xbb, 0x1a,
+	0x2c, 0xf1, 0xd7, 0x51, 0x00, 0x77, 0x14, 0xf4, 0x35, 0x54, 0xe2, 0x11, 0x8a, 0xa2, 0x07, 0x68,
+	0x72, 0x0a, 0x6b, 0x0b, 0x88, 0x54, 0xff, 0x48, 0xa8, 0x7f, 0xa8, 0xdd, 0x99, 0xa9, 0xa7, 0x63,
+	0xbc, 0x1d, 0xe7, 0x63, 0x8c, 0x7f, 0x26, 0x6e, 0x46, 0x5f, 0x43, 0x2d, 0x39, 0x46, 0xd1, 0x6d,
+	0xa9, 0x2c, 0x35, 0x85, 0xb5, 0xc5, 0x74, 0xaa, 0xdf, 0x17, 0x76, 0xd6, 0xb7, 0x6e, 0x2d, 0xc8,
+	0x79, 0xa8, 0x3e, 0x39, 0x37, 0x63, 0xf5, 0xa9, 0x39, 0xad, 0x2d, 0xa6, 0xc7, 0xea, 0xd1, 0x12,
+	0xf5, 0xdf, 0x40, 0x33, 0x35, 0xf0, 0xe2, 0x82, 0xba, 0x3a, 0x71, 0xb5, 0xa5, 0xac, 0x38, 0x5c,
+	0xe8, 0xc1, 0x42, 0x3b, 0xed, 0x91, 0x84, 0xee, 0x28, 0xe8, 0x05, 0xd4, 0xe7, 0xfa, 0x62, 0x5c,
+	0x60, 0xe9, 0xa6, 0xac, 0x2d, 0x61, 0x50, 0xfd, 0x03, 0x61, 0xec, 0x3e, 0xba, 0x7b, 0xd5, 0x18,
+	0x8e, 0x70, 0x3b, 0xca, 0xa8, 0x28, 0x06, 0xc1, 0x8f, 0xff, 0x3d, 0x00, 0x7f, 0xc3, 0xaa, 0xe2,
+	0xec, 0x16, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AnalyticsServiceClient interface {
+	GetJobStats(ctx context.Context, in *GetJobStatsReq, opts ...grpc.CallOption) (*GetJobStatsRes, error)
+	// GetOwnerSummary sums up the jobs of an owner and their runs in one call
+	GetOwnerSummary(ctx context.Context, in *GetOwnerSummaryReq, opts ...grpc.CallOption) (*GetOwnerSummaryRes, error)
+	// GetCostReport streams the costs of the runs of jobs rolled up by job, owner, team or month for chargeback. Months
+	// are sent oldest first, the other rows most expensive first.
+	GetCostReport(ctx context.Context, in *GetCostReportReq, opts ...grpc.CallOption) (AnalyticsService_GetCostReportClient, error)
+	// GetJobTimeSeries streams the runs of a job bucketed by the time they were queued, oldest bucket first
+	GetJobTimeSeries(ctx context.Context, in *GetJobTimeSeriesReq, opts ...grpc.CallOption) (AnalyticsService_GetJobTimeSeriesClient, error)
+	// SetJobSLA sets the SLA of a job, replacing the one it had
+	SetJobSLA(ctx context.Context, in *SetJobSLAReq, opts ...grpc.CallOption) (*SetJobSLARes, error)
+	// DeleteJobSLA removes the SLA of a job, its breaches are kept
+	DeleteJobSLA(ctx context.Context, in *DeleteJobSLAReq, opts ...grpc.CallOption) (*DeleteJobSLARes, error)
+	GetSLAStatus(ctx context.Context, in *GetSLAStatusReq, opts ...grpc.CallOption) (*GetSLAStatusRes, error)
+	// ListSLABreaches streams the breaches of the SLA of a job, newest first
+	ListSLABreaches(ctx context.Context, in *ListSLABreachesReq, opts ...grpc.CallOption) (AnalyticsService_ListSLABreachesClient, error)
+	// ListAnomalies streams the runs of a job whose duration was an anomaly, newest first
+	ListAnomalies(ctx context.Context, in *ListAnomaliesReq, opts ...grpc.CallOption) (AnalyticsService_ListAnomaliesClient, error)
+}
+
+type analyticsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAnalyticsServiceClient(cc *grpc.ClientConn) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetJobStats(ctx context.Context, in *GetJobStatsReq, opts ...grpc.CallOption) (*GetJobStatsRes, error) {
+	out := new(GetJobStatsRes)
+	err := c.cc.Invoke(ctx, "/model.AnalyticsService/GetJobStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetOwnerSummary(ctx context.Context, in *GetOwnerSummaryReq, opts ...grpc.CallOption) (*GetOwnerSummaryRes, error) {
+	out := new(GetOwnerSummaryRes)
+	err := c.cc.Invoke(ctx, "/model.AnalyticsService/GetOwnerSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetCostReport(ctx context.Context, in *GetCostReportReq, opts ...grpc.CallOption) (AnalyticsService_GetCostReportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AnalyticsService_serviceDesc.Streams[0], "/model.AnalyticsService/GetCostReport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &analyticsServiceGetCostReportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnalyticsService_GetCostReportClient interface {
+	Recv() (*GetCostReportRes, error)
+	grpc.ClientStream
+}
+
+type analyticsServiceGetCostReportClient struct {
+	grpc.ClientStream
+}
+
+func (x *analyticsServiceGetCostReportClient) Recv() (*GetCostReportRes, error) {
+	m := new(GetCostReportRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *analyticsServiceClient) GetJobTimeSeries(ctx context.Context, in *GetJobTimeSeriesReq, opts ...grpc.CallOption) (AnalyticsService_GetJobTimeSeriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AnalyticsService_serviceDesc.Streams[1], "/model.AnalyticsService/GetJobTimeSeries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &analyticsServiceGetJobTimeSeriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnalyticsService_GetJobTimeSeriesClient interface {
+	Recv() (*GetJobTimeSeriesRes, error)
+	grpc.ClientStream
+}
+
+type analyticsServiceGetJobTimeSeriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *analyticsServiceGetJobTimeSeriesClient) Recv() (*GetJobTimeSeriesRes, error) {
+	m := new(GetJobTimeSeriesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *analyticsServiceClient) SetJobSLA(ctx context.Context, in *SetJobSLAReq, opts ...grpc.CallOption) (*SetJobSLARes, error) {
+	out := new(SetJobSLARes)
+	err := c.cc.Invoke(ctx, "/model.AnalyticsService/SetJobSLA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) DeleteJobSLA(ctx context.Context, in *DeleteJobSLAReq, opts ...grpc.CallOption) (*DeleteJobSLARes, error) {
+	out := new(DeleteJobSLARes)
+	err := c.cc.Invoke(ctx, "/model.AnalyticsService/DeleteJobSLA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetSLAStatus(ctx context.Context, in *GetSLAStatusReq, opts ...grpc.CallOption) (*GetSLAStatusRes, error) {
+	out := new(GetSLAStatusRes)
+	err := c.cc.Invoke(ctx, "/model.AnalyticsService/GetSLAStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListSLABreaches(ctx context.Context, in *ListSLABreachesReq, opts ...grpc.CallOption) (AnalyticsService_ListSLABreachesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AnalyticsService_serviceDesc.Streams[2], "/model.AnalyticsService/ListSLABreaches", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &analyticsServiceListSLABreachesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnalyticsService_ListSLABreachesClient interface {
+	Recv() (*ListSLABreachesRes, error)
+	grpc.ClientStream
+}
+
+type analyticsServiceListSLABreachesClient struct {
+	grpc.ClientStream
+}
+
+func (x *analyticsServiceListSLABreachesClient) Recv() (*ListSLABreachesRes, error) {
+	m := new(ListSLABreachesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *analyticsServiceClient) ListAnomalies(ctx context.Context, in *ListAnomaliesReq, opts ...grpc.CallOption) (AnalyticsService_ListAnomaliesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AnalyticsService_serviceDesc.Streams[3], "/model.AnalyticsService/ListAnomalies", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &analyticsServiceListAnomaliesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnalyticsService_ListAnomaliesClient interface {
+	Recv() (*ListAnomaliesRes, error)
+	grpc.ClientStream
+}
+
+type analyticsServiceListAnomaliesClient struct {
+	grpc.ClientStream
+}
+
+func (x *analyticsServiceListAnomaliesClient) Recv() (*ListAnomaliesRes, error) {
+	m := new(ListAnomaliesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+type AnalyticsServiceServer interface {
+	GetJobStats(context.Context, *GetJobStatsReq) (*GetJobStatsRes, error)
+	// GetOwnerSummary sums up the jobs of an owner and their runs in one call
+	GetOwnerSummary(context.Context, *GetOwnerSummaryReq) (*GetOwnerSummaryRes, error)
+	// GetCostReport streams the costs of the runs of jobs rolled up by job, owner, team or month for chargeback. Months
+	// are sent oldest first, the other rows most expensive first.
+	GetCostReport(*GetCostReportReq, AnalyticsService_GetCostReportServer) error
+	// GetJobTimeSeries streams the runs of a job bucketed by the time they were queued, oldest bucket first
+	GetJobTimeSeries(*GetJobTimeSeriesReq, AnalyticsService_GetJobTimeSeriesServer) error
+	// SetJobSLA sets the SLA of a job, replacing the one it had
+	SetJobSLA(context.Context, *SetJobSLAReq) (*SetJobSLARes, error)
+	// DeleteJobSLA removes the SLA of a job, its breaches are kept
+	DeleteJobSLA(context.Context, *DeleteJobSLAReq) (*DeleteJobSLARes, error)
+	GetSLAStatus(context.Context, *GetSLAStatusReq) (*GetSLAStatusRes, error)
+	// ListSLABreaches streams the breaches of the SLA of a job, newest first
+	ListSLABreaches(*ListSLABreachesReq, AnalyticsService_ListSLABreachesServer) error
+	// ListAnomalies streams the runs of a job whose duration was an anomaly, newest first
+	ListAnomalies(*ListAnomaliesReq, AnalyticsService_ListAnomaliesServer) error
+}
+
+func RegisterAnalyticsServiceServer(s *grpc.Server, srv AnalyticsServiceServer) {
+	s.RegisterService(&_AnalyticsService_serviceDesc, srv)
+}
+
+func _AnalyticsService_GetJobStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetJobStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AnalyticsService/GetJobStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetJobStats(ctx, req.(*GetJobStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetOwnerSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOwnerSummaryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetOwnerSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AnalyticsService/GetOwnerSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetOwnerSummary(ctx, req.(*GetOwnerSummaryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetCostReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCostReportReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyticsServiceServer).GetCostReport(m, &analyticsServiceGetCostReportServer{stream})
+}
+
+type AnalyticsService_GetCostReportServer interface {
+	Send(*GetCostReportRes) error
+	grpc.ServerStream
+}
+
+type analyticsServiceGetCostReportServer struct {
+	grpc.ServerStream
+}
+
+func (x *analyticsServiceGetCostReportServer) Send(m *GetCostReportRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AnalyticsService_GetJobTimeSeries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobTimeSeriesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyticsServiceServer).GetJobTimeSeries(m, &analyticsServiceGetJobTimeSeriesServer{stream})
+}
+
+type AnalyticsService_GetJobTimeSeriesServer interface {
+	Send(*GetJobTimeSeriesRes) error
+	grpc.ServerStream
+}
+
+type analyticsServiceGetJobTimeSeriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *analyticsServiceGetJobTimeSeriesServer) Send(m *GetJobTimeSeriesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AnalyticsService_SetJobSLA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetJobSLAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).SetJobSLA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AnalyticsService/SetJobSLA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).SetJobSLA(ctx, req.(*SetJobSLAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_DeleteJobSLA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobSLAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).DeleteJobSLA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AnalyticsService/DeleteJobSLA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).DeleteJobSLA(ctx, req.(*DeleteJobSLAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetSLAStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSLAStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetSLAStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.AnalyticsService/GetSLAStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetSLAStatus(ctx, req.(*GetSLAStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListSLABreaches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSLABreachesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyticsServiceServer).ListSLABreaches(m, &analyticsServiceListSLABreachesServer{stream})
+}
+
+type AnalyticsService_ListSLABreachesServer interface {
+	Send(*ListSLABreachesRes) error
+	grpc.ServerStream
+}
+
+type analyticsServiceListSLABreachesServer struct {
+	grpc.ServerStream
+}
+
+func (x *analyticsServiceListSLABreachesServer) Send(m *ListSLABreachesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AnalyticsService_ListAnomalies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAnomaliesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyticsServiceServer).ListAnomalies(m, &analyticsServiceListAnomaliesServer{stream})
+}
+
+type AnalyticsService_ListAnomaliesServer interface {
+	Send(*ListAnomaliesRes) error
+	grpc.ServerStream
+}
+
+type analyticsServiceListAnomaliesServer struct {
+	grpc.ServerStream
+}
+
+func (x *analyticsServiceListAnomaliesServer) Send(m *ListAnomaliesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _AnalyticsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetJobStats",
+			Handler:    _AnalyticsService_GetJobStats_Handler,
+		},
+		{
+			MethodName: "GetOwnerSummary",
+			Handler:    _AnalyticsService_GetOwnerSummary_Handler,
+		},
+		{
+			MethodName: "SetJobSLA",
+			Handler:    _AnalyticsService_SetJobSLA_Handler,
+		},
+		{
+			MethodName: "DeleteJobSLA",
+			Handler:    _AnalyticsService_DeleteJobSLA_Handler,
+		},
+		{
+			MethodName: "GetSLAStatus",
+			Handler:    _AnalyticsService_GetSLAStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetCostReport",
+			Handler:       _AnalyticsService_GetCostReport_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetJobTimeSeries",
+			Handler:       _AnalyticsService_GetJobTimeSeries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListSLABreaches",
+			Handler:       _AnalyticsService_ListSLABreaches_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAnomalies",
+			Handler:       _AnalyticsService_ListAnomalies_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "analytics.proto",
+}
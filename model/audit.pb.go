@@ -0,0 +1,421 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: audit.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// AuditChange is the value of a single field of a job before and after a change, empty when the field wasn't set
+type AuditChange struct {
+	// Field path like name or schedule.cron
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before               string   `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After                string   `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditChange) Reset()         { *m = AuditChange{} }
+func (m *AuditChange) String() string { return proto.CompactTextString(m) }
+func (*AuditChange) ProtoMessage()    {}
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{0}
+}
+
+func (m *AuditChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditChange.Unmarshal(m, b)
+}
+func (m *AuditChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditChange.Marshal(b, m, deterministic)
+}
+func (m *AuditChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditChange.Merge(m, src)
+}
+func (m *AuditChange) XXX_Size() int {
+	return xxx_messageInfo_AuditChange.Size(m)
+}
+func (m *AuditChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditChange proto.InternalMessageInfo
+
+func (m *AuditChange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *AuditChange) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *AuditChange) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+// AuditEntry records a successful call that changed jobs
+type AuditEntry struct {
+	Id   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Subject of the caller's token, empty when authentication is disabled
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Full gRPC method like /model.JobService/UpdateJob
+	Method string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// Empty for imports, which are recorded as a single entry
+	JobId string `protobuf:"bytes,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Ordered by field
+	Changes              []*AuditChange `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{1}
+}
+
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEntry.Unmarshal(m, b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return xxx_messageInfo_AuditEntry.Size(m)
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *AuditEntry) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetChanges() []*AuditChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type ListAuditEntriesReq struct {
+	// Only list changes made by this actor
+	Actor string `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Only list changes of this job
+	JobId string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Maximum number of entries to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue listing after the last entry returned
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEntriesReq) Reset()         { *m = ListAuditEntriesReq{} }
+func (m *ListAuditEntriesReq) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEntriesReq) ProtoMessage()    {}
+func (*ListAuditEntriesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{2}
+}
+
+func (m *ListAuditEntriesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEntriesReq.Unmarshal(m, b)
+}
+func (m *ListAuditEntriesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEntriesReq.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEntriesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEntriesReq.Merge(m, src)
+}
+func (m *ListAuditEntriesReq) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEntriesReq.Size(m)
+}
+func (m *ListAuditEntriesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEntriesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEntriesReq proto.InternalMessageInfo
+
+func (m *ListAuditEntriesReq) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *ListAuditEntriesReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ListAuditEntriesReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListAuditEntriesReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListAuditEntriesRes struct {
+	Entry *AuditEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Only set on the last message of a page when more entries are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEntriesRes) Reset()         { *m = ListAuditEntriesRes{} }
+func (m *ListAuditEntriesRes) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEntriesRes) ProtoMessage()    {}
+func (*ListAuditEntriesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{3}
+}
+
+func (m *ListAuditEntriesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEntriesRes.Unmarshal(m, b)
+}
+func (m *ListAuditEntriesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEntriesRes.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEntriesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEntriesRes.Merge(m, src)
+}
+func (m *ListAuditEntriesRes) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEntriesRes.Size(m)
+}
+func (m *ListAuditEntriesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEntriesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEntriesRes proto.InternalMessageInfo
+
+func (m *ListAuditEntriesRes) GetEntry() *AuditEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *ListAuditEntriesRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*AuditChange)(nil), "model.AuditChange")
+	proto.RegisterType((*AuditEntry)(nil), "model.AuditEntry")
+	proto.RegisterType((*ListAuditEntriesReq)(nil), "model.ListAuditEntriesReq")
+	proto.RegisterType((*ListAuditEntriesRes)(nil), "model.ListAuditEntriesRes")
+}
+
+func init() { proto.RegisterFile("audit.proto", fileDescriptor_5594839dd8e38a1b) }
+
+var fileDescriptor_5594839dd8e38a1b = []byte{
+	// 403 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x8e, 0x94, 0x40,
+	0x10, 0xc6, 0x03, 0xbb, 0xa0, 0x53, 0xf8, 0x6f, 0xdb, 0x3f, 0x21, 0xa8, 0x71, 0xc3, 0x41, 0xf7,
+	0x60, 0x40, 0xf1, 0x09, 0x8c, 0xf1, 0x60, 0xe2, 0x41, 0xd9, 0xbd, 0x93, 0x66, 0x28, 0x98, 0x1e,
+	0x07, 0x1a, 0xe9, 0x9e, 0x89, 0xce, 0xc1, 0x83, 0xaf, 0xe0, 0x03, 0xf9, 0x10, 0xbe, 0x82, 0x0f,
+	0x62, 0xba, 0x1a, 0x1c, 0x62, 0xc6, 0xe3, 0x57, 0xf5, 0x51, 0xdf, 0xaf, 0x8a, 0x86, 0x80, 0x6f,
+	0x2b, 0xa1, 0x93, 0x7e, 0x90, 0x5a, 0x32, 0xaf, 0x95, 0x15, 0x6e, 0xa2, 0x47, 0x8d, 0x94, 0xcd,
+	0x06, 0x53, 0xde, 0x8b, 0x94, 0x77, 0x9d, 0xd4, 0x5c, 0x0b, 0xd9, 0x29, 0x6b, 0x8a, 0x9e, 0x8c,
+	0x5d, 0x52, 0xe5, 0xb6, 0x4e, 0xb5, 0x68, 0x51, 0x69, 0xde, 0xf6, 0xd6, 0x10, 0x7f, 0x84, 0xe0,
+	0xb5, 0x19, 0xfa, 0x66, 0xc5, 0xbb, 0x06, 0xd9, 0x3d, 0xf0, 0x6a, 0x81, 0x9b, 0x2a, 0x74, 0xce,
+	0x9d, 0x8b, 0x45, 0x6e, 0x05, 0x7b, 0x00, 0x7e, 0x89, 0xb5, 0x1c, 0x30, 0x74, 0xa9, 0x3c, 0x2a,
+	0xe3, 0xe6, 0xb5, 0xc6, 0x21, 0x3c, 0xb1, 0x6e, 0x12, 0xf1, 0x4f, 0x07, 0x80, 0x66, 0xbe, 0xed,
+	0xf4, 0xf0, 0x95, 0xdd, 0x02, 0x57, 0x4c, 0xf3, 0x5c, 0x51, 0xb1, 0x04, 0x4e, 0x0d, 0x04, 0x8d,
+	0x0a, 0xb2, 0x28, 0xb1, 0x84, 0xc9, 0x44, 0x98, 0x5c, 0x4d, 0x84, 0x39, 0xf9, 0x28, 0x64, 0xa9,
+	0xe5, 0x21, 0xc4, 0x08, 0x83, 0xd4, 0xa2, 0x5e, 0xc9, 0x2a, 0x3c, 0xb5, 0x48, 0x56, 0xb1, 0xfb,
+	0xe0, 0xaf, 0x65, 0x59, 0x88, 0x2a, 0xf4, 0xac, 0x7d, 0x2d, 0xcb, 0x77, 0x15, 0x7b, 0x0e, 0xd7,
+	0x96, 0xb4, 0xa1, 0x0a, 0xfd, 0xf3, 0x93, 0x8b, 0x20, 0x63, 0x09, 0x9d, 0x2f, 0x99, 0x2d, 0x9f,
+	0x4f, 0x96, 0xf8, 0x1b, 0xdc, 0x7d, 0x2f, 0x94, 0xfe, 0xbb, 0x84, 0x40, 0x95, 0xe3, 0xe7, 0x03,
+	0x89, 0x33, 0x27, 0x39, 0x24, 0xba, 0xf3, 0xc4, 0x87, 0xb0, 0xe8, 0x79, 0x83, 0x85, 0x12, 0x7b,
+	0x24, 0x74, 0x2f, 0xbf, 0x6e, 0x0a, 0x97, 0x62, 0x8f, 0xec, 0x31, 0x00, 0x35, 0xb5, 0xfc, 0x84,
+	0xdd, 0xb8, 0x01, 0xd9, 0xaf, 0x4c, 0x21, 0xae, 0x8f, 0xe5, 0x2b, 0xf6, 0x0c, 0x3c, 0x34, 0x27,
+	0xa5, 0xfc, 0x20, 0x3b, 0x9b, 0xaf, 0x40, 0xb7, 0xce, 0x6d, 0x9f, 0x3d, 0x85, 0xdb, 0x1d, 0x7e,
+	0xd1, 0xc5, 0x2c, 0xc3, 0xb2, 0xdd, 0x34, 0xe5, 0x0f, 0x53, 0x4e, 0x26, 0xe1, 0x06, 0x7d, 0x7c,
+	0x89, 0xc3, 0x4e, 0x2c, 0x91, 0x15, 0x70, 0xe7, 0xdf, 0x5c, 0x16, 0x8d, 0x29, 0x47, 0x0e, 0x12,
+	0xfd, 0xbf, 0xa7, 0xe2, 0xb3, 0xef, 0xbf, 0x7e, 0xff, 0x70, 0x03, 0xb6, 0x48, 0x77, 0x2f, 0x53,
+	0x7a, 0xb7, 0x2f, 0x9c, 0xd2, 0xa7, 0xbf, 0xfc, 0xea, 0xcf, 0x00, 0x62, 0xd0, 0x49, 0x3a, 0xc9,
+	0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AuditServiceClient is the client API for AuditService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuditServiceClient interface {
+	// Streams entries newest first, callers only see the changes of jobs they own
+	ListAuditEntries(ctx context.Context, in *ListAuditEntriesReq, opts ...grpc.CallOption) (AuditService_ListAuditEntriesClient, error)
+}
+
+type auditServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditServiceClient(cc *grpc.ClientConn) AuditServiceClient {
+	return &auditServiceClient{cc}
+}
+
+func (c *auditServiceClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesReq, opts ...grpc.CallOption) (AuditService_ListAuditEntriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AuditService_serviceDesc.Streams[0], "/model.AuditService/ListAuditEntries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &auditServiceListAuditEntriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AuditService_ListAuditEntriesClient interface {
+	Recv() (*ListAuditEntriesRes, error)
+	grpc.ClientStream
+}
+
+type auditServiceListAuditEntriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *auditServiceListAuditEntriesClient) Recv() (*ListAuditEntriesRes, error) {
+	m := new(ListAuditEntriesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AuditServiceServer is the server API for AuditService service.
+type AuditServiceServer interface {
+	// Streams entries newest first, callers only see the changes of jobs they own
+	ListAuditEntries(*ListAuditEntriesReq, AuditService_ListAuditEntriesServer) error
+}
+
+func RegisterAuditServiceServer(s *grpc.Server, srv AuditServiceServer) {
+	s.RegisterService(&_AuditService_serviceDesc, srv)
+}
+
+func _AuditService_ListAuditEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAuditEntriesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuditServiceServer).ListAuditEntries(m, &auditServiceListAuditEntriesServer{stream})
+}
+
+type AuditService_ListAuditEntriesServer interface {
+	Send(*ListAuditEntriesRes) error
+	grpc.ServerStream
+}
+
+type auditServiceListAuditEntriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *auditServiceListAuditEntriesServer) Send(m *ListAuditEntriesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _AuditService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.AuditService",
+	HandlerType: (*AuditServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAuditEntries",
+			Handler:       _AuditService_ListAuditEntries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "audit.proto",
+}
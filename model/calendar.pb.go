@@ -0,0 +1,1016 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: calendar.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BlackoutWindow is a time jobs aren't fired in, either once from start_time until end_time or recurring for duration
+// every time cron fires
+type BlackoutWindow struct {
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Excluded from the window, must be after start_time
+	EndTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Standard 5 field cron expression or a descriptor like @weekly, evaluated in the time zone of the calendar
+	Cron string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Between one minute and 7 days
+	Duration             *duration.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Description          string             `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BlackoutWindow) Reset()         { *m = BlackoutWindow{} }
+func (m *BlackoutWindow) String() string { return proto.CompactTextString(m) }
+func (*BlackoutWindow) ProtoMessage()    {}
+func (*BlackoutWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{0}
+}
+
+func (m *BlackoutWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlackoutWindow.Unmarshal(m, b)
+}
+func (m *BlackoutWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlackoutWindow.Marshal(b, m, deterministic)
+}
+func (m *BlackoutWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlackoutWindow.Merge(m, src)
+}
+func (m *BlackoutWindow) XXX_Size() int {
+	return xxx_messageInfo_BlackoutWindow.Size(m)
+}
+func (m *BlackoutWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlackoutWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlackoutWindow proto.InternalMessageInfo
+
+func (m *BlackoutWindow) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *BlackoutWindow) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *BlackoutWindow) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *BlackoutWindow) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *BlackoutWindow) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// Calendar holds maintenance windows and holidays, the scheduler doesn't fire the jobs attached to it in them
+type Calendar struct {
+	// Set by the server
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Defaults to the caller like the owner of a job, calendars owned by team:<team id> are shared with its members
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// IANA timezone like Europe/Berlin recurring windows and holidays are evaluated in, defaults to UTC
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// At most 100 windows
+	Windows []*BlackoutWindow `protobuf:"bytes,6,rep,name=windows,proto3" json:"windows,omitempty"`
+	// At most 366 dates like 2026-12-25, blacked out all day
+	Holidays []string `protobuf:"bytes,7,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	// Set by the server
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Calendar) Reset()         { *m = Calendar{} }
+func (m *Calendar) String() string { return proto.CompactTextString(m) }
+func (*Calendar) ProtoMessage()    {}
+func (*Calendar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{1}
+}
+
+func (m *Calendar) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Calendar.Unmarshal(m, b)
+}
+func (m *Calendar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Calendar.Marshal(b, m, deterministic)
+}
+func (m *Calendar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Calendar.Merge(m, src)
+}
+func (m *Calendar) XXX_Size() int {
+	return xxx_messageInfo_Calendar.Size(m)
+}
+func (m *Calendar) XXX_DiscardUnknown() {
+	xxx_messageInfo_Calendar.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Calendar proto.InternalMessageInfo
+
+func (m *Calendar) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Calendar) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Calendar) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Calendar) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Calendar) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *Calendar) GetWindows() []*BlackoutWindow {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+func (m *Calendar) GetHolidays() []string {
+	if m != nil {
+		return m.Holidays
+	}
+	return nil
+}
+
+func (m *Calendar) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Calendar) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type CreateCalendarReq struct {
+	Calendar             *Calendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateCalendarReq) Reset()         { *m = CreateCalendarReq{} }
+func (m *CreateCalendarReq) String() string { return proto.CompactTextString(m) }
+func (*CreateCalendarReq) ProtoMessage()    {}
+func (*CreateCalendarReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{2}
+}
+
+func (m *CreateCalendarReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCalendarReq.Unmarshal(m, b)
+}
+func (m *CreateCalendarReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCalendarReq.Marshal(b, m, deterministic)
+}
+func (m *CreateCalendarReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCalendarReq.Merge(m, src)
+}
+func (m *CreateCalendarReq) XXX_Size() int {
+	return xxx_messageInfo_CreateCalendarReq.Size(m)
+}
+func (m *CreateCalendarReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCalendarReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCalendarReq proto.InternalMessageInfo
+
+func (m *CreateCalendarReq) GetCalendar() *Calendar {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type CreateCalendarRes struct {
+	Calendar             *Calendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateCalendarRes) Reset()         { *m = CreateCalendarRes{} }
+func (m *CreateCalendarRes) String() string { return proto.CompactTextString(m) }
+func (*CreateCalendarRes) ProtoMessage()    {}
+func (*CreateCalendarRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{3}
+}
+
+func (m *CreateCalendarRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCalendarRes.Unmarshal(m, b)
+}
+func (m *CreateCalendarRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCalendarRes.Marshal(b, m, deterministic)
+}
+func (m *CreateCalendarRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCalendarRes.Merge(m, src)
+}
+func (m *CreateCalendarRes) XXX_Size() int {
+	return xxx_messageInfo_CreateCalendarRes.Size(m)
+}
+func (m *CreateCalendarRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCalendarRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCalendarRes proto.InternalMessageInfo
+
+func (m *CreateCalendarRes) GetCalendar() *Calendar {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type ReadCalendarReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadCalendarReq) Reset()         { *m = ReadCalendarReq{} }
+func (m *ReadCalendarReq) String() string { return proto.CompactTextString(m) }
+func (*ReadCalendarReq) ProtoMessage()    {}
+func (*ReadCalendarReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{4}
+}
+
+func (m *ReadCalendarReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadCalendarReq.Unmarshal(m, b)
+}
+func (m *ReadCalendarReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadCalendarReq.Marshal(b, m, deterministic)
+}
+func (m *ReadCalendarReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadCalendarReq.Merge(m, src)
+}
+func (m *ReadCalendarReq) XXX_Size() int {
+	return xxx_messageInfo_ReadCalendarReq.Size(m)
+}
+func (m *ReadCalendarReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadCalendarReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadCalendarReq proto.InternalMessageInfo
+
+func (m *ReadCalendarReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadCalendarRes struct {
+	Calendar             *Calendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ReadCalendarRes) Reset()         { *m = ReadCalendarRes{} }
+func (m *ReadCalendarRes) String() string { return proto.CompactTextString(m) }
+func (*ReadCalendarRes) ProtoMessage()    {}
+func (*ReadCalendarRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{5}
+}
+
+func (m *ReadCalendarRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadCalendarRes.Unmarshal(m, b)
+}
+func (m *ReadCalendarRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadCalendarRes.Marshal(b, m, deterministic)
+}
+func (m *ReadCalendarRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadCalendarRes.Merge(m, src)
+}
+func (m *ReadCalendarRes) XXX_Size() int {
+	return xxx_messageInfo_ReadCalendarRes.Size(m)
+}
+func (m *ReadCalendarRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadCalendarRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadCalendarRes proto.InternalMessageInfo
+
+func (m *ReadCalendarRes) GetCalendar() *Calendar {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type UpdateCalendarReq struct {
+	// Replaces everything but the fields set by the server
+	Calendar             *Calendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UpdateCalendarReq) Reset()         { *m = UpdateCalendarReq{} }
+func (m *UpdateCalendarReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateCalendarReq) ProtoMessage()    {}
+func (*UpdateCalendarReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{6}
+}
+
+func (m *UpdateCalendarReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCalendarReq.Unmarshal(m, b)
+}
+func (m *UpdateCalendarReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCalendarReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateCalendarReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCalendarReq.Merge(m, src)
+}
+func (m *UpdateCalendarReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateCalendarReq.Size(m)
+}
+func (m *UpdateCalendarReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCalendarReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCalendarReq proto.InternalMessageInfo
+
+func (m *UpdateCalendarReq) GetCalendar() *Calendar {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type UpdateCalendarRes struct {
+	Calendar             *Calendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UpdateCalendarRes) Reset()         { *m = UpdateCalendarRes{} }
+func (m *UpdateCalendarRes) String() string { return proto.CompactTextString(m) }
+func (*UpdateCalendarRes) ProtoMessage()    {}
+func (*UpdateCalendarRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{7}
+}
+
+func (m *UpdateCalendarRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCalendarRes.Unmarshal(m, b)
+}
+func (m *UpdateCalendarRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCalendarRes.Marshal(b, m, deterministic)
+}
+func (m *UpdateCalendarRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCalendarRes.Merge(m, src)
+}
+func (m *UpdateCalendarRes) XXX_Size() int {
+	return xxx_messageInfo_UpdateCalendarRes.Size(m)
+}
+func (m *UpdateCalendarRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCalendarRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCalendarRes proto.InternalMessageInfo
+
+func (m *UpdateCalendarRes) GetCalendar() *Calendar {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type DeleteCalendarReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCalendarReq) Reset()         { *m = DeleteCalendarReq{} }
+func (m *DeleteCalendarReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteCalendarReq) ProtoMessage()    {}
+func (*DeleteCalendarReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{8}
+}
+
+func (m *DeleteCalendarReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCalendarReq.Unmarshal(m, b)
+}
+func (m *DeleteCalendarReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCalendarReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteCalendarReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCalendarReq.Merge(m, src)
+}
+func (m *DeleteCalendarReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteCalendarReq.Size(m)
+}
+func (m *DeleteCalendarReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCalendarReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCalendarReq proto.InternalMessageInfo
+
+func (m *DeleteCalendarReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteCalendarRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCalendarRes) Reset()         { *m = DeleteCalendarRes{} }
+func (m *DeleteCalendarRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteCalendarRes) ProtoMessage()    {}
+func (*DeleteCalendarRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{9}
+}
+
+func (m *DeleteCalendarRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCalendarRes.Unmarshal(m, b)
+}
+func (m *DeleteCalendarRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCalendarRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteCalendarRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCalendarRes.Merge(m, src)
+}
+func (m *DeleteCalendarRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteCalendarRes.Size(m)
+}
+func (m *DeleteCalendarRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCalendarRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCalendarRes proto.InternalMessageInfo
+
+type ListCalendarsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCalendarsReq) Reset()         { *m = ListCalendarsReq{} }
+func (m *ListCalendarsReq) String() string { return proto.CompactTextString(m) }
+func (*ListCalendarsReq) ProtoMessage()    {}
+func (*ListCalendarsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{10}
+}
+
+func (m *ListCalendarsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCalendarsReq.Unmarshal(m, b)
+}
+func (m *ListCalendarsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCalendarsReq.Marshal(b, m, deterministic)
+}
+func (m *ListCalendarsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCalendarsReq.Merge(m, src)
+}
+func (m *ListCalendarsReq) XXX_Size() int {
+	return xxx_messageInfo_ListCalendarsReq.Size(m)
+}
+func (m *ListCalendarsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCalendarsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCalendarsReq proto.InternalMessageInfo
+
+type ListCalendarsRes struct {
+	Calendar             *Calendar `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListCalendarsRes) Reset()         { *m = ListCalendarsRes{} }
+func (m *ListCalendarsRes) String() string { return proto.CompactTextString(m) }
+func (*ListCalendarsRes) ProtoMessage()    {}
+func (*ListCalendarsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{11}
+}
+
+func (m *ListCalendarsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCalendarsRes.Unmarshal(m, b)
+}
+func (m *ListCalendarsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCalendarsRes.Marshal(b, m, deterministic)
+}
+func (m *ListCalendarsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCalendarsRes.Merge(m, src)
+}
+func (m *ListCalendarsRes) XXX_Size() int {
+	return xxx_messageInfo_ListCalendarsRes.Size(m)
+}
+func (m *ListCalendarsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCalendarsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCalendarsRes proto.InternalMessageInfo
+
+func (m *ListCalendarsRes) GetCalendar() *Calendar {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type SetJobCalendarsReq struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Replaces the calendars of the job, none detaches all of them
+	Blackout             *JobBlackout `protobuf:"bytes,2,opt,name=blackout,proto3" json:"blackout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetJobCalendarsReq) Reset()         { *m = SetJobCalendarsReq{} }
+func (m *SetJobCalendarsReq) String() string { return proto.CompactTextString(m) }
+func (*SetJobCalendarsReq) ProtoMessage()    {}
+func (*SetJobCalendarsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{12}
+}
+
+func (m *SetJobCalendarsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetJobCalendarsReq.Unmarshal(m, b)
+}
+func (m *SetJobCalendarsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetJobCalendarsReq.Marshal(b, m, deterministic)
+}
+func (m *SetJobCalendarsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetJobCalendarsReq.Merge(m, src)
+}
+func (m *SetJobCalendarsReq) XXX_Size() int {
+	return xxx_messageInfo_SetJobCalendarsReq.Size(m)
+}
+func (m *SetJobCalendarsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetJobCalendarsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetJobCalendarsReq proto.InternalMessageInfo
+
+func (m *SetJobCalendarsReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *SetJobCalendarsReq) GetBlackout() *JobBlackout {
+	if m != nil {
+		return m.Blackout
+	}
+	return nil
+}
+
+type SetJobCalendarsRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetJobCalendarsRes) Reset()         { *m = SetJobCalendarsRes{} }
+func (m *SetJobCalendarsRes) String() string { return proto.CompactTextString(m) }
+func (*SetJobCalendarsRes) ProtoMessage()    {}
+func (*SetJobCalendarsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3d25d49f056cdb2, []int{13}
+}
+
+func (m *SetJobCalendarsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetJobCalendarsRes.Unmarshal(m, b)
+}
+func (m *SetJobCalendarsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetJobCalendarsRes.Marshal(b, m, deterministic)
+}
+func (m *SetJobCalendarsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetJobCalendarsRes.Merge(m, src)
+}
+func (m *SetJobCalendarsRes) XXX_Size() int {
+	return xxx_messageInfo_SetJobCalendarsRes.Size(m)
+}
+func (m *SetJobCalendarsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetJobCalendarsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetJobCalendarsRes proto.InternalMessageInfo
+
+func (m *SetJobCalendarsRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BlackoutWindow)(nil), "model.BlackoutWindow")
+	proto.RegisterType((*Calendar)(nil), "model.Calendar")
+	proto.RegisterType((*CreateCalendarReq)(nil), "model.CreateCalendarReq")
+	proto.RegisterType((*CreateCalendarRes)(nil), "model.CreateCalendarRes")
+	proto.RegisterType((*ReadCalendarReq)(nil), "model.ReadCalendarReq")
+	proto.RegisterType((*ReadCalendarRes)(nil), "model.ReadCalendarRes")
+	proto.RegisterType((*UpdateCalendarReq)(nil), "model.UpdateCalendarReq")
+	proto.RegisterType((*UpdateCalendarRes)(nil), "model.UpdateCalendarRes")
+	proto.RegisterType((*DeleteCalendarReq)(nil), "model.DeleteCalendarReq")
+	proto.RegisterType((*DeleteCalendarRes)(nil), "model.DeleteCalendarRes")
+	proto.RegisterType((*ListCalendarsReq)(nil), "model.ListCalendarsReq")
+	proto.RegisterType((*ListCalendarsRes)(nil), "model.ListCalendarsRes")
+	proto.RegisterType((*SetJobCalendarsReq)(nil), "model.SetJobCalendarsReq")
+	proto.RegisterType((*SetJobCalendarsRes)(nil), "model.SetJobCalendarsRes")
+}
+
+func init() { proto.RegisterFile("calendar.proto", fileDescriptor_e3d25d49f056cdb2) }
+
+var fileDescriptor_e3d25d49f056cdb2 = []byte{
+	// 690 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x4f, 0x6e, 0xd3, 0x40,
+	0x14, 0xc6, 0x95, 0xa4, 0x69, 0xe3, 0x57, 0x9a, 0xd0, 0x47, 0x0b, 0xae, 0xa9, 0x68, 0x30, 0x0b,
+	0x2a, 0x10, 0x36, 0x04, 0xb1, 0x80, 0x05, 0x50, 0xda, 0x0d, 0x15, 0x2b, 0x17, 0x84, 0x54, 0x16,
+	0x65, 0x9c, 0x19, 0x8a, 0x43, 0xea, 0x49, 0x3d, 0x93, 0x56, 0x50, 0x75, 0xc3, 0x15, 0x38, 0x13,
+	0x27, 0xe0, 0x0a, 0xec, 0x39, 0x02, 0xc8, 0x93, 0x19, 0x13, 0xdb, 0x09, 0x55, 0xc4, 0xce, 0x33,
+	0xef, 0x7b, 0xbf, 0x79, 0x7f, 0x3e, 0x19, 0x9a, 0x5d, 0xd2, 0x67, 0x31, 0x25, 0x89, 0x37, 0x48,
+	0xb8, 0xe4, 0x58, 0x3f, 0xe2, 0x94, 0xf5, 0x9d, 0xf5, 0x43, 0xce, 0x0f, 0xfb, 0xcc, 0x27, 0x83,
+	0xc8, 0x27, 0x71, 0xcc, 0x25, 0x91, 0x11, 0x8f, 0xc5, 0x48, 0xe4, 0xdc, 0xd0, 0x51, 0x75, 0x0a,
+	0x87, 0x1f, 0x7c, 0x3a, 0x4c, 0x94, 0x40, 0xc7, 0x37, 0x8a, 0x71, 0x19, 0x1d, 0x31, 0x21, 0xc9,
+	0xd1, 0x40, 0x0b, 0xac, 0x1e, 0x0f, 0x47, 0x9f, 0xee, 0xaf, 0x0a, 0x34, 0x5f, 0xf4, 0x49, 0xf7,
+	0x13, 0x1f, 0xca, 0xb7, 0x51, 0x4c, 0xf9, 0x29, 0x3e, 0x06, 0x10, 0x92, 0x24, 0xf2, 0x20, 0x4d,
+	0xb3, 0x2b, 0xed, 0xca, 0xe6, 0x62, 0xc7, 0xf1, 0x46, 0x4c, 0xcf, 0x30, 0xbd, 0xd7, 0x86, 0x19,
+	0x58, 0x4a, 0x9d, 0x9e, 0xf1, 0x11, 0x34, 0x58, 0x4c, 0x47, 0x89, 0xd5, 0x0b, 0x13, 0x17, 0x58,
+	0x4c, 0x55, 0x1a, 0xc2, 0x5c, 0x37, 0xe1, 0xb1, 0x5d, 0x6b, 0x57, 0x36, 0xad, 0x40, 0x7d, 0xa7,
+	0x28, 0xd3, 0x96, 0x3d, 0xa7, 0x50, 0x6b, 0x25, 0xd4, 0x8e, 0x16, 0x04, 0x99, 0x14, 0xdb, 0xb0,
+	0x48, 0x99, 0xe8, 0x26, 0xd1, 0x40, 0x65, 0xd6, 0x15, 0x71, 0xfc, 0xca, 0xfd, 0x5e, 0x85, 0xc6,
+	0xb6, 0x9e, 0x3a, 0x36, 0xa1, 0x1a, 0x51, 0xd5, 0xa3, 0x15, 0x54, 0x23, 0x9a, 0x56, 0x12, 0x13,
+	0x5d, 0xbc, 0x15, 0xa8, 0xef, 0x22, 0xb2, 0x56, 0x42, 0xe2, 0x0a, 0xd4, 0xf9, 0x69, 0xcc, 0x12,
+	0x55, 0xa8, 0x15, 0x8c, 0x0e, 0xe8, 0x40, 0x23, 0x1d, 0xc4, 0x17, 0x1e, 0x33, 0x5d, 0x47, 0x76,
+	0x46, 0x1f, 0x16, 0x4e, 0xd5, 0xb4, 0x85, 0x3d, 0xdf, 0xae, 0x6d, 0x2e, 0x76, 0x56, 0x3d, 0xb5,
+	0x79, 0x2f, 0xbf, 0x8b, 0xc0, 0xa8, 0x52, 0xd8, 0x47, 0xde, 0x8f, 0x28, 0xf9, 0x2c, 0xec, 0x85,
+	0x76, 0x2d, 0x85, 0x99, 0x73, 0xba, 0xb0, 0x6e, 0xc2, 0x88, 0x64, 0xf4, 0x80, 0x48, 0xbb, 0x71,
+	0xf1, 0xc2, 0xb4, 0x7a, 0x4b, 0xa6, 0xa9, 0xc3, 0x01, 0x35, 0xa9, 0xd6, 0xc5, 0xa9, 0x5a, 0xbd,
+	0x25, 0xdd, 0xe7, 0xb0, 0xbc, 0xad, 0x38, 0x66, 0x98, 0x01, 0x3b, 0xc6, 0xbb, 0xd0, 0x30, 0x8e,
+	0xd6, 0xce, 0x69, 0xe9, 0xc6, 0x32, 0x55, 0x26, 0x98, 0x44, 0x10, 0xb3, 0x11, 0x6e, 0x42, 0x2b,
+	0x60, 0x84, 0x8e, 0x57, 0x50, 0xd8, 0xa8, 0xfb, 0xb4, 0x28, 0x11, 0x33, 0x17, 0xf9, 0x46, 0xf5,
+	0xfc, 0x3f, 0x6d, 0x16, 0x09, 0x33, 0xd6, 0x70, 0x0b, 0x96, 0x77, 0x58, 0x9f, 0x49, 0xf6, 0xaf,
+	0x46, 0xaf, 0x94, 0x45, 0xc2, 0x45, 0xb8, 0xfc, 0x2a, 0x12, 0xd2, 0x5c, 0x89, 0x80, 0x1d, 0xbb,
+	0xcf, 0x4a, 0x77, 0x33, 0x96, 0xf3, 0x0e, 0x70, 0x8f, 0xc9, 0x5d, 0x1e, 0x8e, 0x63, 0x71, 0x15,
+	0xe6, 0x7b, 0x3c, 0x3c, 0xc8, 0x6a, 0xaa, 0xf7, 0x78, 0xf8, 0x92, 0xa2, 0x07, 0x8d, 0x50, 0x7b,
+	0x5a, 0xff, 0x12, 0x50, 0x93, 0x77, 0x79, 0x68, 0xdc, 0x1e, 0x64, 0x1a, 0xb7, 0x33, 0x01, 0x2e,
+	0x70, 0x1d, 0x6a, 0x3d, 0x1e, 0xea, 0xd2, 0xe0, 0x2f, 0x20, 0x48, 0xaf, 0x3b, 0xbf, 0xe7, 0xa0,
+	0x65, 0xe4, 0x7b, 0x2c, 0x39, 0x89, 0xba, 0x0c, 0x19, 0x34, 0xf3, 0xe6, 0x42, 0xdb, 0x74, 0x54,
+	0x74, 0xad, 0x33, 0x2d, 0x22, 0xdc, 0x8d, 0xaf, 0x3f, 0x7e, 0x7e, 0xab, 0xae, 0xb9, 0x4b, 0xfe,
+	0xc9, 0x03, 0xdf, 0x0c, 0x40, 0x3c, 0xc9, 0x66, 0x81, 0xfb, 0x70, 0x69, 0xdc, 0x5e, 0x78, 0x55,
+	0xa3, 0x0a, 0xb6, 0x74, 0x26, 0xdf, 0x0b, 0xd7, 0x51, 0x0f, 0xac, 0x20, 0xe6, 0x1e, 0xf0, 0xcf,
+	0x22, 0x7a, 0x8e, 0x02, 0x9a, 0x79, 0xe3, 0x64, 0x2d, 0x94, 0x1c, 0xe9, 0x4c, 0x8b, 0x08, 0xf7,
+	0x9e, 0x7a, 0xe1, 0xb6, 0x73, 0xbd, 0xf0, 0x82, 0xf9, 0xf4, 0x22, 0x7a, 0x3e, 0xd6, 0xd0, 0x7b,
+	0x68, 0xe6, 0x6d, 0x94, 0x3d, 0x5a, 0xb2, 0xa0, 0x33, 0x2d, 0x92, 0xb5, 0x75, 0x67, 0x52, 0x5b,
+	0xfb, 0xb0, 0x94, 0xf3, 0x1f, 0x5e, 0xd3, 0x98, 0xa2, 0x53, 0x9d, 0x29, 0x01, 0xe1, 0xae, 0x2a,
+	0x7c, 0x0b, 0xf3, 0x6b, 0xb9, 0x5f, 0xc1, 0x13, 0x68, 0x15, 0xdc, 0x83, 0x6b, 0x1a, 0x52, 0xb6,
+	0xac, 0x33, 0x35, 0x54, 0x98, 0x5a, 0x8f, 0x87, 0xc2, 0x3f, 0x1b, 0xb9, 0xfb, 0x7c, 0xdc, 0x06,
+	0xc6, 0xb5, 0xe1, 0xbc, 0xfa, 0x57, 0x3e, 0xfc, 0x33, 0x00, 0xfa, 0x5c, 0xd3, 0xfa, 0xd0, 0x07,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CalendarServiceClient is the client API for CalendarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CalendarServiceClient interface {
+	CreateCalendar(ctx context.Context, in *CreateCalendarReq, opts ...grpc.CallOption) (*CreateCalendarRes, error)
+	ReadCalendar(ctx context.Context, in *ReadCalendarReq, opts ...grpc.CallOption) (*ReadCalendarRes, error)
+	// Replaces a calendar, the scheduler applies it to the jobs attached to it from their next occurrence on
+	UpdateCalendar(ctx context.Context, in *UpdateCalendarReq, opts ...grpc.CallOption) (*UpdateCalendarRes, error)
+	// Deletes a calendar, the jobs attached to it keep its id but aren't blacked out by it anymore
+	DeleteCalendar(ctx context.Context, in *DeleteCalendarReq, opts ...grpc.CallOption) (*DeleteCalendarRes, error)
+	// Streams the calendars of the caller and their teams oldest first
+	ListCalendars(ctx context.Context, in *ListCalendarsReq, opts ...grpc.CallOption) (CalendarService_ListCalendarsClient, error)
+	// Attaches calendars the caller can read to a job and tells whether its occurrences in their blackouts are skipped
+	// or deferred
+	SetJobCalendars(ctx context.Context, in *SetJobCalendarsReq, opts ...grpc.CallOption) (*SetJobCalendarsRes, error)
+}
+
+type calendarServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCalendarServiceClient(cc *grpc.ClientConn) CalendarServiceClient {
+	return &calendarServiceClient{cc}
+}
+
+func (c *calendarServiceClient) CreateCalendar(ctx context.Context, in *CreateCalendarReq, opts ...grpc.CallOption) (*CreateCalendarRes, error) {
+	out := new(CreateCalendarRes)
+	err := c.cc.Invoke(ctx, "/model.CalendarService/CreateCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ReadCalendar(ctx context.Context, in *ReadCalendarReq, opts ...grpc.CallOption) (*ReadCalendarRes, error) {
+	out := new(ReadCalendarRes)
+	err := c.cc.Invoke(ctx, "/model.CalendarService/ReadCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateCalendar(ctx context.Context, in *UpdateCalendarReq, opts ...grpc.CallOption) (*UpdateCalendarRes, error) {
+	out := new(UpdateCalendarRes)
+	err := c.cc.Invoke(ctx, "/model.CalendarService/UpdateCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteCalendar(ctx context.Context, in *DeleteCalendarReq, opts ...grpc.CallOption) (*DeleteCalendarRes, error) {
+	out := new(DeleteCalendarRes)
+	err := c.cc.Invoke(ctx, "/model.CalendarService/DeleteCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListCalendars(ctx context.Context, in *ListCalendarsReq, opts ...grpc.CallOption) (CalendarService_ListCalendarsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalendarService_serviceDesc.Streams[0], "/model.CalendarService/ListCalendars", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calendarServiceListCalendarsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CalendarService_ListCalendarsClient interface {
+	Recv() (*ListCalendarsRes, error)
+	grpc.ClientStream
+}
+
+type calendarServiceListCalendarsClient struct {
+	grpc.ClientStream
+}
+
+func (x *calendarServiceListCalendarsClient) Recv() (*ListCalendarsRes, error) {
+	m := new(ListCalendarsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calendarServiceClient) SetJobCalendars(ctx context.Context, in *SetJobCalendarsReq, opts ...grpc.CallOption) (*SetJobCalendarsRes, error) {
+	out := new(SetJobCalendarsRes)
+	err := c.cc.Invoke(ctx, "/model.CalendarService/SetJobCalendars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceServer is the server API for CalendarService service.
+type CalendarServiceServer interface {
+	CreateCalendar(context.Context, *CreateCalendarReq) (*CreateCalendarRes, error)
+	ReadCalendar(context.Context, *ReadCalendarReq) (*ReadCalendarRes, error)
+	// Replaces a calendar, the scheduler applies it to the jobs attached to it from their next occurrence on
+	UpdateCalendar(context.Context, *UpdateCalendarReq) (*UpdateCalendarRes, error)
+	// Deletes a calendar, the jobs attached to it keep its id but aren't blacked out by it anymore
+	DeleteCalendar(context.Context, *DeleteCalendarReq) (*DeleteCalendarRes, error)
+	// Streams the calendars of the caller and their teams oldest first
+	ListCalendars(*ListCalendarsReq, CalendarService_ListCalendarsServer) error
+	// Attaches calendars the caller can read to a job and tells whether its occurrences in their blackouts are skipped
+	// or deferred
+	SetJobCalendars(context.Context, *SetJobCalendarsReq) (*SetJobCalendarsRes, error)
+}
+
+func RegisterCalendarServiceServer(s *grpc.Server, srv CalendarServiceServer) {
+	s.RegisterService(&_CalendarService_serviceDesc, srv)
+}
+
+func _CalendarService_CreateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.CalendarService/CreateCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateCalendar(ctx, req.(*CreateCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ReadCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ReadCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.CalendarService/ReadCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ReadCalendar(ctx, req.(*ReadCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.CalendarService/UpdateCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateCalendar(ctx, req.(*UpdateCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.CalendarService/DeleteCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteCalendar(ctx, req.(*DeleteCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListCalendars_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCalendarsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ListCalendars(m, &calendarServiceListCalendarsServer{stream})
+}
+
+type CalendarService_ListCalendarsServer interface {
+	Send(*ListCalendarsRes) error
+	grpc.ServerStream
+}
+
+type calendarServiceListCalendarsServer struct {
+	grpc.ServerStream
+}
+
+func (x *calendarServiceListCalendarsServer) Send(m *ListCalendarsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CalendarService_SetJobCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetJobCalendarsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).SetJobCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.CalendarService/SetJobCalendars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).SetJobCalendars(ctx, req.(*SetJobCalendarsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CalendarService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.CalendarService",
+	HandlerType: (*CalendarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCalendar",
+			Handler:    _CalendarService_CreateCalendar_Handler,
+		},
+		{
+			MethodName: "ReadCalendar",
+			Handler:    _CalendarService_ReadCalendar_Handler,
+		},
+		{
+			MethodName: "UpdateCalendar",
+			Handler:    _CalendarService_UpdateCalendar_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _CalendarService_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "SetJobCalendars",
+			Handler:    _CalendarService_SetJobCalendars_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListCalendars",
+			Handler:       _CalendarService_ListCalendars_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "calendar.proto",
+}
@@ -7,6 +7,11 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -22,14 +27,384 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// JobStatus is the state of a job, it is set by the server
+type JobStatus int32
+
+const (
+	JobStatus_JOB_STATUS_UNSPECIFIED JobStatus = 0
+	// The job never ran
+	JobStatus_JOB_STATUS_PENDING JobStatus = 1
+	JobStatus_JOB_STATUS_RUNNING JobStatus = 2
+	// The latest run succeeded, failed or was cancelled
+	JobStatus_JOB_STATUS_SUCCEEDED JobStatus = 3
+	JobStatus_JOB_STATUS_FAILED    JobStatus = 4
+	JobStatus_JOB_STATUS_CANCELLED JobStatus = 5
+	// The scheduler doesn't fire the job until it is resumed
+	JobStatus_JOB_STATUS_PAUSED JobStatus = 6
+)
+
+var JobStatus_name = map[int32]string{
+	0: "JOB_STATUS_UNSPECIFIED",
+	1: "JOB_STATUS_PENDING",
+	2: "JOB_STATUS_RUNNING",
+	3: "JOB_STATUS_SUCCEEDED",
+	4: "JOB_STATUS_FAILED",
+	5: "JOB_STATUS_CANCELLED",
+	6: "JOB_STATUS_PAUSED",
+}
+
+var JobStatus_value = map[string]int32{
+	"JOB_STATUS_UNSPECIFIED": 0,
+	"JOB_STATUS_PENDING":     1,
+	"JOB_STATUS_RUNNING":     2,
+	"JOB_STATUS_SUCCEEDED":   3,
+	"JOB_STATUS_FAILED":      4,
+	"JOB_STATUS_CANCELLED":   5,
+	"JOB_STATUS_PAUSED":      6,
+}
+
+func (x JobStatus) String() string {
+	return proto.EnumName(JobStatus_name, int32(x))
+}
+
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{0}
+}
+
+// JobPriority decides the order queued runs get a worker in, unspecified means normal
+type JobPriority int32
+
+const (
+	JobPriority_JOB_PRIORITY_UNSPECIFIED JobPriority = 0
+	JobPriority_JOB_PRIORITY_LOW         JobPriority = 1
+	JobPriority_JOB_PRIORITY_NORMAL      JobPriority = 2
+	JobPriority_JOB_PRIORITY_HIGH        JobPriority = 3
+	JobPriority_JOB_PRIORITY_CRITICAL    JobPriority = 4
+)
+
+var JobPriority_name = map[int32]string{
+	0: "JOB_PRIORITY_UNSPECIFIED",
+	1: "JOB_PRIORITY_LOW",
+	2: "JOB_PRIORITY_NORMAL",
+	3: "JOB_PRIORITY_HIGH",
+	4: "JOB_PRIORITY_CRITICAL",
+}
+
+var JobPriority_value = map[string]int32{
+	"JOB_PRIORITY_UNSPECIFIED": 0,
+	"JOB_PRIORITY_LOW":         1,
+	"JOB_PRIORITY_NORMAL":      2,
+	"JOB_PRIORITY_HIGH":        3,
+	"JOB_PRIORITY_CRITICAL":    4,
+}
+
+func (x JobPriority) String() string {
+	return proto.EnumName(JobPriority_name, int32(x))
+}
+
+func (JobPriority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{1}
+}
+
+// ConcurrencyPolicy tells what happens when the scheduler fires a job while one of its runs is still running, like
+// the concurrency policy of a Kubernetes CronJob. Unspecified means allow.
+type ConcurrencyPolicy int32
+
+const (
+	ConcurrencyPolicy_CONCURRENCY_POLICY_UNSPECIFIED ConcurrencyPolicy = 0
+	// The runs of the job may overlap
+	ConcurrencyPolicy_CONCURRENCY_POLICY_ALLOW ConcurrencyPolicy = 1
+	// The job isn't fired while one of its runs is still queued or running
+	ConcurrencyPolicy_CONCURRENCY_POLICY_FORBID ConcurrencyPolicy = 2
+	// The runs of the job that are still running are cancelled once the new run starts
+	ConcurrencyPolicy_CONCURRENCY_POLICY_REPLACE ConcurrencyPolicy = 3
+)
+
+var ConcurrencyPolicy_name = map[int32]string{
+	0: "CONCURRENCY_POLICY_UNSPECIFIED",
+	1: "CONCURRENCY_POLICY_ALLOW",
+	2: "CONCURRENCY_POLICY_FORBID",
+	3: "CONCURRENCY_POLICY_REPLACE",
+}
+
+var ConcurrencyPolicy_value = map[string]int32{
+	"CONCURRENCY_POLICY_UNSPECIFIED": 0,
+	"CONCURRENCY_POLICY_ALLOW":       1,
+	"CONCURRENCY_POLICY_FORBID":      2,
+	"CONCURRENCY_POLICY_REPLACE":     3,
+}
+
+func (x ConcurrencyPolicy) String() string {
+	return proto.EnumName(ConcurrencyPolicy_name, int32(x))
+}
+
+func (ConcurrencyPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{2}
+}
+
+// CatchUpPolicy tells what happens to the occurrences of a schedule the scheduler missed because it wasn't running,
+// like while the server was down. Unspecified means fire once.
+type CatchUpPolicy int32
+
+const (
+	CatchUpPolicy_CATCH_UP_POLICY_UNSPECIFIED CatchUpPolicy = 0
+	// The job is fired once for all its missed occurrences
+	CatchUpPolicy_CATCH_UP_POLICY_FIRE_ONCE CatchUpPolicy = 1
+	// The job is fired for every missed occurrence, one per poll of the scheduler and at most 101 times
+	CatchUpPolicy_CATCH_UP_POLICY_FIRE_ALL CatchUpPolicy = 2
+	// The job isn't fired for its missed occurrences, only for the next one
+	CatchUpPolicy_CATCH_UP_POLICY_SKIP CatchUpPolicy = 3
+)
+
+var CatchUpPolicy_name = map[int32]string{
+	0: "CATCH_UP_POLICY_UNSPECIFIED",
+	1: "CATCH_UP_POLICY_FIRE_ONCE",
+	2: "CATCH_UP_POLICY_FIRE_ALL",
+	3: "CATCH_UP_POLICY_SKIP",
+}
+
+var CatchUpPolicy_value = map[string]int32{
+	"CATCH_UP_POLICY_UNSPECIFIED": 0,
+	"CATCH_UP_POLICY_FIRE_ONCE":   1,
+	"CATCH_UP_POLICY_FIRE_ALL":    2,
+	"CATCH_UP_POLICY_SKIP":        3,
+}
+
+func (x CatchUpPolicy) String() string {
+	return proto.EnumName(CatchUpPolicy_name, int32(x))
+}
+
+func (CatchUpPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{3}
+}
+
+// BlackoutAction tells what happens to the occurrences of a schedule in a blackout
+type BlackoutAction int32
+
+const (
+	// Defaults to skipping them
+	BlackoutAction_BLACKOUT_ACTION_UNSPECIFIED BlackoutAction = 0
+	// The scheduler moves on to the next occurrence
+	BlackoutAction_BLACKOUT_ACTION_SKIP BlackoutAction = 1
+	// The job runs once the blackout ended, the occurrences in it only run once
+	BlackoutAction_BLACKOUT_ACTION_DEFER BlackoutAction = 2
+)
+
+var BlackoutAction_name = map[int32]string{
+	0: "BLACKOUT_ACTION_UNSPECIFIED",
+	1: "BLACKOUT_ACTION_SKIP",
+	2: "BLACKOUT_ACTION_DEFER",
+}
+
+var BlackoutAction_value = map[string]int32{
+	"BLACKOUT_ACTION_UNSPECIFIED": 0,
+	"BLACKOUT_ACTION_SKIP":        1,
+	"BLACKOUT_ACTION_DEFER":       2,
+}
+
+func (x BlackoutAction) String() string {
+	return proto.EnumName(BlackoutAction_name, int32(x))
+}
+
+func (BlackoutAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{4}
+}
+
+type ChangeStatus int32
+
+const (
+	ChangeStatus_CHANGE_STATUS_UNSPECIFIED ChangeStatus = 0
+	// Waiting to be approved or rejected
+	ChangeStatus_CHANGE_STATUS_PENDING ChangeStatus = 1
+	// Approved and applied to the job
+	ChangeStatus_CHANGE_STATUS_APPROVED ChangeStatus = 2
+	// Rejected, the job wasn't changed
+	ChangeStatus_CHANGE_STATUS_REJECTED ChangeStatus = 3
+)
+
+var ChangeStatus_name = map[int32]string{
+	0: "CHANGE_STATUS_UNSPECIFIED",
+	1: "CHANGE_STATUS_PENDING",
+	2: "CHANGE_STATUS_APPROVED",
+	3: "CHANGE_STATUS_REJECTED",
+}
+
+var ChangeStatus_value = map[string]int32{
+	"CHANGE_STATUS_UNSPECIFIED": 0,
+	"CHANGE_STATUS_PENDING":     1,
+	"CHANGE_STATUS_APPROVED":    2,
+	"CHANGE_STATUS_REJECTED":    3,
+}
+
+func (x ChangeStatus) String() string {
+	return proto.EnumName(ChangeStatus_name, int32(x))
+}
+
+func (ChangeStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{5}
+}
+
+type JobEventType int32
+
+const (
+	JobEventType_JOB_EVENT_TYPE_UNSPECIFIED JobEventType = 0
+	JobEventType_JOB_EVENT_TYPE_CREATED     JobEventType = 1
+	JobEventType_JOB_EVENT_TYPE_UPDATED     JobEventType = 2
+	JobEventType_JOB_EVENT_TYPE_DELETED     JobEventType = 3
+)
+
+var JobEventType_name = map[int32]string{
+	0: "JOB_EVENT_TYPE_UNSPECIFIED",
+	1: "JOB_EVENT_TYPE_CREATED",
+	2: "JOB_EVENT_TYPE_UPDATED",
+	3: "JOB_EVENT_TYPE_DELETED",
+}
+
+var JobEventType_value = map[string]int32{
+	"JOB_EVENT_TYPE_UNSPECIFIED": 0,
+	"JOB_EVENT_TYPE_CREATED":     1,
+	"JOB_EVENT_TYPE_UPDATED":     2,
+	"JOB_EVENT_TYPE_DELETED":     3,
+}
+
+func (x JobEventType) String() string {
+	return proto.EnumName(JobEventType_name, int32(x))
+}
+
+func (JobEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{6}
+}
+
+// Formats ExportJobs writes jobs in
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	// Comma separated values with a header row, one job per row
+	ExportFormat_EXPORT_FORMAT_CSV ExportFormat = 1
+	// One JSON object per line, the jobs look like in the responses of the REST gateway
+	ExportFormat_EXPORT_FORMAT_NDJSON ExportFormat = 2
+)
+
+var ExportFormat_name = map[int32]string{
+	0: "EXPORT_FORMAT_UNSPECIFIED",
+	1: "EXPORT_FORMAT_CSV",
+	2: "EXPORT_FORMAT_NDJSON",
+}
+
+var ExportFormat_value = map[string]int32{
+	"EXPORT_FORMAT_UNSPECIFIED": 0,
+	"EXPORT_FORMAT_CSV":         1,
+	"EXPORT_FORMAT_NDJSON":      2,
+}
+
+func (x ExportFormat) String() string {
+	return proto.EnumName(ExportFormat_name, int32(x))
+}
+
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{7}
+}
+
+// ParamType is the JSON type of the value of a param
+type ParamType int32
+
+const (
+	// Any type, only used for the values of object and list params
+	ParamType_PARAM_TYPE_UNSPECIFIED ParamType = 0
+	ParamType_PARAM_TYPE_STRING      ParamType = 1
+	ParamType_PARAM_TYPE_NUMBER      ParamType = 2
+	ParamType_PARAM_TYPE_BOOL        ParamType = 3
+	ParamType_PARAM_TYPE_OBJECT      ParamType = 4
+	ParamType_PARAM_TYPE_LIST        ParamType = 5
+)
+
+var ParamType_name = map[int32]string{
+	0: "PARAM_TYPE_UNSPECIFIED",
+	1: "PARAM_TYPE_STRING",
+	2: "PARAM_TYPE_NUMBER",
+	3: "PARAM_TYPE_BOOL",
+	4: "PARAM_TYPE_OBJECT",
+	5: "PARAM_TYPE_LIST",
+}
+
+var ParamType_value = map[string]int32{
+	"PARAM_TYPE_UNSPECIFIED": 0,
+	"PARAM_TYPE_STRING":      1,
+	"PARAM_TYPE_NUMBER":      2,
+	"PARAM_TYPE_BOOL":        3,
+	"PARAM_TYPE_OBJECT":      4,
+	"PARAM_TYPE_LIST":        5,
+}
+
+func (x ParamType) String() string {
+	return proto.EnumName(ParamType_name, int32(x))
+}
+
+func (ParamType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{8}
+}
+
 type Job struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Owner                string   `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Defaults to the caller. Jobs owned by team:<team id> are shared with the members of the team, only admins can
+	// assign jobs to other users or to teams they aren't a member of.
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Set by the server, ignored when sent by a client
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// When set the scheduler fires the job according to it
+	Schedule *Schedule `protobuf:"bytes,7,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// Computed by the server from schedule, empty for unscheduled jobs
+	NextRunTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	// Name of the executor handler that runs the job, one of the names ListJobTypes returns, defaults to noop
+	Handler string `protobuf:"bytes,9,opt,name=handler,proto3" json:"handler,omitempty"`
+	// Program and arguments for the command handler, split on whitespace
+	Command string `protobuf:"bytes,10,opt,name=command,proto3" json:"command,omitempty"`
+	// Set by the server when the job was deleted, deleted jobs can be restored until they are purged
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Set by the server, changed with PauseJob, ResumeJob and CancelJob
+	Status JobStatus `protobuf:"varint,12,opt,name=status,proto3,enum=model.JobStatus" json:"status,omitempty"`
+	// When set failed runs are attempted again according to it
+	RetryPolicy *RetryPolicy `protobuf:"bytes,13,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// Runs taking longer are stopped and marked as timed out, at most 24 hours. Runs aren't limited when it's unset.
+	Timeout *duration.Duration `protobuf:"bytes,14,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Kubernetes style labels to find jobs by with the label selector of ListJobs, at most 64
+	Labels map[string]string `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// IDs of at most 16 jobs this job runs after. It runs once all of them succeeded in the same scheduling cycle, so it
+	// can't have a schedule of its own.
+	DependsOn []string `protobuf:"bytes,16,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// Runs of the job get a worker before the runs of lower priorities, set to normal when unspecified
+	Priority JobPriority `protobuf:"varint,17,opt,name=priority,proto3,enum=model.JobPriority" json:"priority,omitempty"`
+	// Who is notified when runs of the job keep failing, nobody is when it's unset
+	Notifications *NotificationSettings `protobuf:"bytes,18,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	// Set by the server for jobs created from a template with CreateJobFromTemplate
+	Template *TemplateLineage `protobuf:"bytes,19,opt,name=template,proto3" json:"template,omitempty"`
+	// Set by the server when the job was archived with ArchiveJob or ArchiveJobs, archived jobs aren't fired by the
+	// scheduler and only listed by ListJobs with archived set
+	ArchivedAt *timestamp.Timestamp `protobuf:"bytes,20,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// The calendars whose blackout windows and holidays the scheduler doesn't fire the job in, changed with
+	// CalendarService.SetJobCalendars and ignored when sent otherwise
+	Blackout *JobBlackout `protobuf:"bytes,21,opt,name=blackout,proto3" json:"blackout,omitempty"`
+	// What happens when the scheduler fires the job while one of its runs is still running, set to allow when
+	// unspecified. Manual runs, retries and runs after upstream jobs are neither skipped nor replace other runs.
+	ConcurrencyPolicy ConcurrencyPolicy `protobuf:"varint,22,opt,name=concurrency_policy,json=concurrencyPolicy,proto3,enum=model.ConcurrencyPolicy" json:"concurrency_policy,omitempty"`
+	// What happens to the occurrences the scheduler missed before it started, set to fire once when unspecified
+	CatchUpPolicy CatchUpPolicy `protobuf:"varint,23,opt,name=catch_up_policy,json=catchUpPolicy,proto3,enum=model.CatchUpPolicy" json:"catch_up_policy,omitempty"`
+	// Parameters handed to the handler of the job, like the environment of a command. They must match the params the
+	// handler takes and may be at most 64KiB as JSON.
+	Params *_struct.Struct `protobuf:"bytes,24,opt,name=params,proto3" json:"params,omitempty"`
+	// Environment like dev, staging or prod the job belongs to, lowercase letters, digits and - up to 32 characters.
+	// Names are unique per owner and environment, the scheduler doesn't fire the jobs of paused environments. Empty
+	// means the job belongs to none.
+	Environment string `protobuf:"bytes,25,opt,name=environment,proto3" json:"environment,omitempty"`
+	// Set by the server when the job was created or last updated by PromoteJob
+	Promotion            *JobPromotion `protobuf:"bytes,26,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *Job) Reset()         { *m = Job{} }
@@ -85,604 +460,5603 @@ func (m *Job) GetOwner() string {
 	return ""
 }
 
-type CreateJobReq struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
 }
 
-func (m *CreateJobReq) Reset()         { *m = CreateJobReq{} }
-func (m *CreateJobReq) String() string { return proto.CompactTextString(m) }
-func (*CreateJobReq) ProtoMessage()    {}
-func (*CreateJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{1}
+func (m *Job) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
 }
 
-func (m *CreateJobReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateJobReq.Unmarshal(m, b)
+func (m *Job) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
 }
-func (m *CreateJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateJobReq.Marshal(b, m, deterministic)
+
+func (m *Job) GetNextRunTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextRunTime
+	}
+	return nil
 }
-func (m *CreateJobReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateJobReq.Merge(m, src)
+
+func (m *Job) GetHandler() string {
+	if m != nil {
+		return m.Handler
+	}
+	return ""
 }
-func (m *CreateJobReq) XXX_Size() int {
-	return xxx_messageInfo_CreateJobReq.Size(m)
+
+func (m *Job) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
 }
-func (m *CreateJobReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateJobReq.DiscardUnknown(m)
+
+func (m *Job) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
 }
 
-var xxx_messageInfo_CreateJobReq proto.InternalMessageInfo
+func (m *Job) GetStatus() JobStatus {
+	if m != nil {
+		return m.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
 
-func (m *CreateJobReq) GetJob() *Job {
+func (m *Job) GetRetryPolicy() *RetryPolicy {
 	if m != nil {
-		return m.Job
+		return m.RetryPolicy
 	}
 	return nil
 }
 
-type CreateJobRes struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetTimeout() *duration.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
 }
 
-func (m *CreateJobRes) Reset()         { *m = CreateJobRes{} }
-func (m *CreateJobRes) String() string { return proto.CompactTextString(m) }
-func (*CreateJobRes) ProtoMessage()    {}
-func (*CreateJobRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{2}
+func (m *Job) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
 }
 
-func (m *CreateJobRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateJobRes.Unmarshal(m, b)
+func (m *Job) GetDependsOn() []string {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
 }
-func (m *CreateJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateJobRes.Marshal(b, m, deterministic)
+
+func (m *Job) GetPriority() JobPriority {
+	if m != nil {
+		return m.Priority
+	}
+	return JobPriority_JOB_PRIORITY_UNSPECIFIED
 }
-func (m *CreateJobRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateJobRes.Merge(m, src)
+
+func (m *Job) GetNotifications() *NotificationSettings {
+	if m != nil {
+		return m.Notifications
+	}
+	return nil
 }
-func (m *CreateJobRes) XXX_Size() int {
-	return xxx_messageInfo_CreateJobRes.Size(m)
+
+func (m *Job) GetTemplate() *TemplateLineage {
+	if m != nil {
+		return m.Template
+	}
+	return nil
 }
-func (m *CreateJobRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateJobRes.DiscardUnknown(m)
+
+func (m *Job) GetArchivedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ArchivedAt
+	}
+	return nil
 }
 
-var xxx_messageInfo_CreateJobRes proto.InternalMessageInfo
+func (m *Job) GetBlackout() *JobBlackout {
+	if m != nil {
+		return m.Blackout
+	}
+	return nil
+}
 
-func (m *CreateJobRes) GetJob() *Job {
+func (m *Job) GetConcurrencyPolicy() ConcurrencyPolicy {
 	if m != nil {
-		return m.Job
+		return m.ConcurrencyPolicy
+	}
+	return ConcurrencyPolicy_CONCURRENCY_POLICY_UNSPECIFIED
+}
+
+func (m *Job) GetCatchUpPolicy() CatchUpPolicy {
+	if m != nil {
+		return m.CatchUpPolicy
+	}
+	return CatchUpPolicy_CATCH_UP_POLICY_UNSPECIFIED
+}
+
+func (m *Job) GetParams() *_struct.Struct {
+	if m != nil {
+		return m.Params
 	}
 	return nil
 }
 
-type UpdateJobReq struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
 }
 
-func (m *UpdateJobReq) Reset()         { *m = UpdateJobReq{} }
-func (m *UpdateJobReq) String() string { return proto.CompactTextString(m) }
-func (*UpdateJobReq) ProtoMessage()    {}
-func (*UpdateJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{3}
+func (m *Job) GetPromotion() *JobPromotion {
+	if m != nil {
+		return m.Promotion
+	}
+	return nil
 }
 
-func (m *UpdateJobReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateJobReq.Unmarshal(m, b)
+// JobBlackout attaches calendars to a job
+type JobBlackout struct {
+	// IDs of at most 10 calendars, calendars deleted since are ignored
+	CalendarIds          []string       `protobuf:"bytes,1,rep,name=calendar_ids,json=calendarIds,proto3" json:"calendar_ids,omitempty"`
+	Action               BlackoutAction `protobuf:"varint,2,opt,name=action,proto3,enum=model.BlackoutAction" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
-func (m *UpdateJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateJobReq.Marshal(b, m, deterministic)
+
+func (m *JobBlackout) Reset()         { *m = JobBlackout{} }
+func (m *JobBlackout) String() string { return proto.CompactTextString(m) }
+func (*JobBlackout) ProtoMessage()    {}
+func (*JobBlackout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{1}
 }
-func (m *UpdateJobReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateJobReq.Merge(m, src)
+
+func (m *JobBlackout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobBlackout.Unmarshal(m, b)
 }
-func (m *UpdateJobReq) XXX_Size() int {
-	return xxx_messageInfo_UpdateJobReq.Size(m)
+func (m *JobBlackout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobBlackout.Marshal(b, m, deterministic)
 }
-func (m *UpdateJobReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateJobReq.DiscardUnknown(m)
+func (m *JobBlackout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobBlackout.Merge(m, src)
+}
+func (m *JobBlackout) XXX_Size() int {
+	return xxx_messageInfo_JobBlackout.Size(m)
+}
+func (m *JobBlackout) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobBlackout.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateJobReq proto.InternalMessageInfo
+var xxx_messageInfo_JobBlackout proto.InternalMessageInfo
 
-func (m *UpdateJobReq) GetJob() *Job {
+func (m *JobBlackout) GetCalendarIds() []string {
 	if m != nil {
-		return m.Job
+		return m.CalendarIds
 	}
 	return nil
 }
 
-type UpdateJobRes struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *JobBlackout) GetAction() BlackoutAction {
+	if m != nil {
+		return m.Action
+	}
+	return BlackoutAction_BLACKOUT_ACTION_UNSPECIFIED
 }
 
-func (m *UpdateJobRes) Reset()         { *m = UpdateJobRes{} }
-func (m *UpdateJobRes) String() string { return proto.CompactTextString(m) }
-func (*UpdateJobRes) ProtoMessage()    {}
-func (*UpdateJobRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{4}
+// TemplateLineage tells which template a job was created from and with which parameters
+type TemplateLineage struct {
+	// The id of the template, which may have been deleted since
+	TemplateId string `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	// The version the template had when the job was created
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The values of all parameters of the template, the defaults of the ones left out included
+	Parameters           map[string]string `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *UpdateJobRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateJobRes.Unmarshal(m, b)
+func (m *TemplateLineage) Reset()         { *m = TemplateLineage{} }
+func (m *TemplateLineage) String() string { return proto.CompactTextString(m) }
+func (*TemplateLineage) ProtoMessage()    {}
+func (*TemplateLineage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{2}
 }
-func (m *UpdateJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateJobRes.Marshal(b, m, deterministic)
+
+func (m *TemplateLineage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemplateLineage.Unmarshal(m, b)
 }
-func (m *UpdateJobRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateJobRes.Merge(m, src)
+func (m *TemplateLineage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemplateLineage.Marshal(b, m, deterministic)
 }
-func (m *UpdateJobRes) XXX_Size() int {
-	return xxx_messageInfo_UpdateJobRes.Size(m)
+func (m *TemplateLineage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemplateLineage.Merge(m, src)
 }
-func (m *UpdateJobRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateJobRes.DiscardUnknown(m)
+func (m *TemplateLineage) XXX_Size() int {
+	return xxx_messageInfo_TemplateLineage.Size(m)
+}
+func (m *TemplateLineage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemplateLineage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateJobRes proto.InternalMessageInfo
+var xxx_messageInfo_TemplateLineage proto.InternalMessageInfo
 
-func (m *UpdateJobRes) GetJob() *Job {
+func (m *TemplateLineage) GetTemplateId() string {
 	if m != nil {
-		return m.Job
+		return m.TemplateId
+	}
+	return ""
+}
+
+func (m *TemplateLineage) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *TemplateLineage) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
 	}
 	return nil
 }
 
-type ReadJobReq struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// JobPromotion tells which job a job was promoted from and who approved it
+type JobPromotion struct {
+	// The id of the job that was promoted, which may have been deleted since
+	SourceJobId string `protobuf:"bytes,1,opt,name=source_job_id,json=sourceJobId,proto3" json:"source_job_id,omitempty"`
+	// The environment the job was promoted from
+	SourceEnvironment string `protobuf:"bytes,2,opt,name=source_environment,json=sourceEnvironment,proto3" json:"source_environment,omitempty"`
+	// What the promotion was approved with, like a change ticket or the name of the approver
+	Approval string `protobuf:"bytes,3,opt,name=approval,proto3" json:"approval,omitempty"`
+	// The subject of the caller who promoted the job, empty without authentication
+	PromotedBy           string               `protobuf:"bytes,4,opt,name=promoted_by,json=promotedBy,proto3" json:"promoted_by,omitempty"`
+	PromotedAt           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=promoted_at,json=promotedAt,proto3" json:"promoted_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ReadJobReq) Reset()         { *m = ReadJobReq{} }
-func (m *ReadJobReq) String() string { return proto.CompactTextString(m) }
-func (*ReadJobReq) ProtoMessage()    {}
-func (*ReadJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{5}
+func (m *JobPromotion) Reset()         { *m = JobPromotion{} }
+func (m *JobPromotion) String() string { return proto.CompactTextString(m) }
+func (*JobPromotion) ProtoMessage()    {}
+func (*JobPromotion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{3}
 }
 
-func (m *ReadJobReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadJobReq.Unmarshal(m, b)
+func (m *JobPromotion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobPromotion.Unmarshal(m, b)
 }
-func (m *ReadJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadJobReq.Marshal(b, m, deterministic)
+func (m *JobPromotion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobPromotion.Marshal(b, m, deterministic)
 }
-func (m *ReadJobReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadJobReq.Merge(m, src)
+func (m *JobPromotion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobPromotion.Merge(m, src)
 }
-func (m *ReadJobReq) XXX_Size() int {
-	return xxx_messageInfo_ReadJobReq.Size(m)
+func (m *JobPromotion) XXX_Size() int {
+	return xxx_messageInfo_JobPromotion.Size(m)
 }
-func (m *ReadJobReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadJobReq.DiscardUnknown(m)
+func (m *JobPromotion) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobPromotion.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadJobReq proto.InternalMessageInfo
+var xxx_messageInfo_JobPromotion proto.InternalMessageInfo
 
-func (m *ReadJobReq) GetId() string {
+func (m *JobPromotion) GetSourceJobId() string {
 	if m != nil {
-		return m.Id
+		return m.SourceJobId
 	}
 	return ""
 }
 
-type ReadJobRes struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *JobPromotion) GetSourceEnvironment() string {
+	if m != nil {
+		return m.SourceEnvironment
+	}
+	return ""
 }
 
-func (m *ReadJobRes) Reset()         { *m = ReadJobRes{} }
-func (m *ReadJobRes) String() string { return proto.CompactTextString(m) }
-func (*ReadJobRes) ProtoMessage()    {}
-func (*ReadJobRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{6}
+func (m *JobPromotion) GetApproval() string {
+	if m != nil {
+		return m.Approval
+	}
+	return ""
 }
 
-func (m *ReadJobRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadJobRes.Unmarshal(m, b)
-}
-func (m *ReadJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadJobRes.Marshal(b, m, deterministic)
-}
-func (m *ReadJobRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadJobRes.Merge(m, src)
-}
-func (m *ReadJobRes) XXX_Size() int {
-	return xxx_messageInfo_ReadJobRes.Size(m)
-}
-func (m *ReadJobRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadJobRes.DiscardUnknown(m)
+func (m *JobPromotion) GetPromotedBy() string {
+	if m != nil {
+		return m.PromotedBy
+	}
+	return ""
 }
 
-var xxx_messageInfo_ReadJobRes proto.InternalMessageInfo
-
-func (m *ReadJobRes) GetJob() *Job {
+func (m *JobPromotion) GetPromotedAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.Job
+		return m.PromotedAt
 	}
 	return nil
 }
 
-type DeleteJobReq struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// Schedule describes when a job runs, exactly one of cron and interval must be set
+type Schedule struct {
+	// Standard 5 field cron expression or a descriptor like @daily
+	Cron string `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Fixed time between two runs, at least one second
+	Interval *duration.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// IANA timezone like Europe/Berlin the cron expression is evaluated in, defaults to UTC. Only applies to cron schedules.
+	Timezone             string   `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteJobReq) Reset()         { *m = DeleteJobReq{} }
-func (m *DeleteJobReq) String() string { return proto.CompactTextString(m) }
-func (*DeleteJobReq) ProtoMessage()    {}
-func (*DeleteJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{7}
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{4}
 }
 
-func (m *DeleteJobReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteJobReq.Unmarshal(m, b)
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
 }
-func (m *DeleteJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteJobReq.Marshal(b, m, deterministic)
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
 }
-func (m *DeleteJobReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteJobReq.Merge(m, src)
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
 }
-func (m *DeleteJobReq) XXX_Size() int {
-	return xxx_messageInfo_DeleteJobReq.Size(m)
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
 }
-func (m *DeleteJobReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteJobReq.DiscardUnknown(m)
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteJobReq proto.InternalMessageInfo
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
 
-func (m *DeleteJobReq) GetId() string {
+func (m *Schedule) GetCron() string {
 	if m != nil {
-		return m.Id
+		return m.Cron
 	}
 	return ""
 }
 
-type DeleteJobRes struct {
-	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+func (m *Schedule) GetInterval() *duration.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+func (m *Schedule) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+// RetryPolicy describes how failed runs are retried. The backoff starts at initial_backoff and is multiplied
+// by multiplier after every failed attempt up to max_backoff.
+type RetryPolicy struct {
+	// Total number of attempts including the first run, between 1 and 10
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// Time to wait before the first retry, defaults to one second
+	InitialBackoff *duration.Duration `protobuf:"bytes,2,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	// Longest time to wait between two attempts, defaults to one day
+	MaxBackoff *duration.Duration `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	// Factor the backoff grows by with every attempt, at least 1 and defaults to 2
+	Multiplier float64 `protobuf:"fixed64,4,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	// Fraction between 0 and 1 the backoff is randomly shortened or lengthened by
+	Jitter               float64  `protobuf:"fixed64,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteJobRes) Reset()         { *m = DeleteJobRes{} }
-func (m *DeleteJobRes) String() string { return proto.CompactTextString(m) }
-func (*DeleteJobRes) ProtoMessage()    {}
-func (*DeleteJobRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{8}
+func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()    {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{5}
 }
 
-func (m *DeleteJobRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteJobRes.Unmarshal(m, b)
+func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryPolicy.Unmarshal(m, b)
 }
-func (m *DeleteJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteJobRes.Marshal(b, m, deterministic)
+func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
 }
-func (m *DeleteJobRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteJobRes.Merge(m, src)
+func (m *RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryPolicy.Merge(m, src)
 }
-func (m *DeleteJobRes) XXX_Size() int {
-	return xxx_messageInfo_DeleteJobRes.Size(m)
+func (m *RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RetryPolicy.Size(m)
 }
-func (m *DeleteJobRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteJobRes.DiscardUnknown(m)
+func (m *RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteJobRes proto.InternalMessageInfo
+var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
 
-func (m *DeleteJobRes) GetSuccess() bool {
+func (m *RetryPolicy) GetMaxAttempts() int32 {
 	if m != nil {
-		return m.Success
+		return m.MaxAttempts
 	}
-	return false
+	return 0
 }
 
-type ListJobsReq struct {
+func (m *RetryPolicy) GetInitialBackoff() *duration.Duration {
+	if m != nil {
+		return m.InitialBackoff
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetMaxBackoff() *duration.Duration {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetMultiplier() float64 {
+	if m != nil {
+		return m.Multiplier
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+// NotificationSettings tell who is notified when runs of a job keep failing. The run that makes failure_threshold
+// failed runs in a row sends a notification to every channel, every failed attempt counts and a succeeded run
+// starts over.
+type NotificationSettings struct {
+	// Number of failed runs in a row that sends a notification, between 1 and 100 and defaults to 1
+	FailureThreshold int32 `protobuf:"varint,1,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	// Incoming webhook URL of the Slack channel messages are posted to
+	SlackWebhookUrl string `protobuf:"bytes,2,opt,name=slack_webhook_url,json=slackWebhookUrl,proto3" json:"slack_webhook_url,omitempty"`
+	// At most 10 addresses emails are sent to, the server needs an SMTP server to send them
+	EmailRecipients []string `protobuf:"bytes,3,rep,name=email_recipients,json=emailRecipients,proto3" json:"email_recipients,omitempty"`
+	// Whether runs whose duration deviates from the baseline of the job are notified as well, regardless of the
+	// failure threshold
+	Anomalies            bool     `protobuf:"varint,4,opt,name=anomalies,proto3" json:"anomalies,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListJobsReq) Reset()         { *m = ListJobsReq{} }
-func (m *ListJobsReq) String() string { return proto.CompactTextString(m) }
-func (*ListJobsReq) ProtoMessage()    {}
-func (*ListJobsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{9}
+func (m *NotificationSettings) Reset()         { *m = NotificationSettings{} }
+func (m *NotificationSettings) String() string { return proto.CompactTextString(m) }
+func (*NotificationSettings) ProtoMessage()    {}
+func (*NotificationSettings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{6}
 }
 
-func (m *ListJobsReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListJobsReq.Unmarshal(m, b)
+func (m *NotificationSettings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationSettings.Unmarshal(m, b)
 }
-func (m *ListJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListJobsReq.Marshal(b, m, deterministic)
+func (m *NotificationSettings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationSettings.Marshal(b, m, deterministic)
 }
-func (m *ListJobsReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListJobsReq.Merge(m, src)
+func (m *NotificationSettings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationSettings.Merge(m, src)
 }
-func (m *ListJobsReq) XXX_Size() int {
-	return xxx_messageInfo_ListJobsReq.Size(m)
+func (m *NotificationSettings) XXX_Size() int {
+	return xxx_messageInfo_NotificationSettings.Size(m)
 }
-func (m *ListJobsReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListJobsReq.DiscardUnknown(m)
+func (m *NotificationSettings) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationSettings.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListJobsReq proto.InternalMessageInfo
+var xxx_messageInfo_NotificationSettings proto.InternalMessageInfo
 
-type ListJobsRes struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+func (m *NotificationSettings) GetFailureThreshold() int32 {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return 0
+}
+
+func (m *NotificationSettings) GetSlackWebhookUrl() string {
+	if m != nil {
+		return m.SlackWebhookUrl
+	}
+	return ""
+}
+
+func (m *NotificationSettings) GetEmailRecipients() []string {
+	if m != nil {
+		return m.EmailRecipients
+	}
+	return nil
+}
+
+func (m *NotificationSettings) GetAnomalies() bool {
+	if m != nil {
+		return m.Anomalies
+	}
+	return false
+}
+
+type CreateJobReq struct {
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Optional key of at most 128 characters, repeating a call with the same key returns the job the first call
+	// created instead of creating another one. Keys are unique per owner and expire after a day by default.
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Checks the job like creating it would, name included, and returns it without an id instead of creating it
+	ValidateOnly         bool     `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListJobsRes) Reset()         { *m = ListJobsRes{} }
-func (m *ListJobsRes) String() string { return proto.CompactTextString(m) }
-func (*ListJobsRes) ProtoMessage()    {}
-func (*ListJobsRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{10}
+func (m *CreateJobReq) Reset()         { *m = CreateJobReq{} }
+func (m *CreateJobReq) String() string { return proto.CompactTextString(m) }
+func (*CreateJobReq) ProtoMessage()    {}
+func (*CreateJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{7}
 }
 
-func (m *ListJobsRes) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListJobsRes.Unmarshal(m, b)
+func (m *CreateJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJobReq.Unmarshal(m, b)
 }
-func (m *ListJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListJobsRes.Marshal(b, m, deterministic)
+func (m *CreateJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJobReq.Marshal(b, m, deterministic)
 }
-func (m *ListJobsRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListJobsRes.Merge(m, src)
+func (m *CreateJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJobReq.Merge(m, src)
 }
-func (m *ListJobsRes) XXX_Size() int {
-	return xxx_messageInfo_ListJobsRes.Size(m)
+func (m *CreateJobReq) XXX_Size() int {
+	return xxx_messageInfo_CreateJobReq.Size(m)
 }
-func (m *ListJobsRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListJobsRes.DiscardUnknown(m)
+func (m *CreateJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJobReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListJobsRes proto.InternalMessageInfo
+var xxx_messageInfo_CreateJobReq proto.InternalMessageInfo
 
-func (m *ListJobsRes) GetJob() *Job {
+func (m *CreateJobReq) GetJob() *Job {
 	if m != nil {
 		return m.Job
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Job)(nil), "model.Job")
-	proto.RegisterType((*CreateJobReq)(nil), "model.CreateJobReq")
-	proto.RegisterType((*CreateJobRes)(nil), "model.CreateJobRes")
-	proto.RegisterType((*UpdateJobReq)(nil), "model.UpdateJobReq")
-	proto.RegisterType((*UpdateJobRes)(nil), "model.UpdateJobRes")
-	proto.RegisterType((*ReadJobReq)(nil), "model.ReadJobReq")
-	proto.RegisterType((*ReadJobRes)(nil), "model.ReadJobRes")
-	proto.RegisterType((*DeleteJobReq)(nil), "model.DeleteJobReq")
-	proto.RegisterType((*DeleteJobRes)(nil), "model.DeleteJobRes")
-	proto.RegisterType((*ListJobsReq)(nil), "model.ListJobsReq")
-	proto.RegisterType((*ListJobsRes)(nil), "model.ListJobsRes")
-}
+func (m *CreateJobReq) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *CreateJobReq) GetValidateOnly() bool {
+	if m != nil {
+		return m.ValidateOnly
+	}
+	return false
+}
+
+type CreateJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateJobRes) Reset()         { *m = CreateJobRes{} }
+func (m *CreateJobRes) String() string { return proto.CompactTextString(m) }
+func (*CreateJobRes) ProtoMessage()    {}
+func (*CreateJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{8}
+}
+
+func (m *CreateJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJobRes.Unmarshal(m, b)
+}
+func (m *CreateJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJobRes.Marshal(b, m, deterministic)
+}
+func (m *CreateJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJobRes.Merge(m, src)
+}
+func (m *CreateJobRes) XXX_Size() int {
+	return xxx_messageInfo_CreateJobRes.Size(m)
+}
+func (m *CreateJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJobRes proto.InternalMessageInfo
+
+func (m *CreateJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type UpdateJobReq struct {
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Fields of job to update (name, description, owner, schedule, handler, command, labels, depends_on,
+	// priority, notifications), all of them when empty
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Checks the update like applying it would, name included, and returns the job as it would be stored instead of
+	// updating it. No change is requested in protected environments.
+	ValidateOnly         bool     `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateJobReq) Reset()         { *m = UpdateJobReq{} }
+func (m *UpdateJobReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateJobReq) ProtoMessage()    {}
+func (*UpdateJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{9}
+}
+
+func (m *UpdateJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateJobReq.Unmarshal(m, b)
+}
+func (m *UpdateJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateJobReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateJobReq.Merge(m, src)
+}
+func (m *UpdateJobReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateJobReq.Size(m)
+}
+func (m *UpdateJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateJobReq proto.InternalMessageInfo
+
+func (m *UpdateJobReq) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *UpdateJobReq) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateJobReq) GetValidateOnly() bool {
+	if m != nil {
+		return m.ValidateOnly
+	}
+	return false
+}
+
+type UpdateJobRes struct {
+	// The updated job, or the job as it is when the update became a pending change
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Set instead of updating the job when it's in a protected environment, the update is applied once a second person
+	// approves the change
+	Change               *JobChange `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateJobRes) Reset()         { *m = UpdateJobRes{} }
+func (m *UpdateJobRes) String() string { return proto.CompactTextString(m) }
+func (*UpdateJobRes) ProtoMessage()    {}
+func (*UpdateJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{10}
+}
+
+func (m *UpdateJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateJobRes.Unmarshal(m, b)
+}
+func (m *UpdateJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateJobRes.Marshal(b, m, deterministic)
+}
+func (m *UpdateJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateJobRes.Merge(m, src)
+}
+func (m *UpdateJobRes) XXX_Size() int {
+	return xxx_messageInfo_UpdateJobRes.Size(m)
+}
+func (m *UpdateJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateJobRes proto.InternalMessageInfo
+
+func (m *UpdateJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *UpdateJobRes) GetChange() *JobChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+// JobChange is an update of a job in a protected environment, it's applied once someone other than the requester
+// approves it
+type JobChange struct {
+	// Set by the server
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// The environment the job was in when the change was requested
+	Environment string `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	// The job and update mask of the UpdateJob call that requested the change
+	Job        *Job                  `protobuf:"bytes,4,opt,name=job,proto3" json:"job,omitempty"`
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Status     ChangeStatus          `protobuf:"varint,6,opt,name=status,proto3,enum=model.ChangeStatus" json:"status,omitempty"`
+	// The subject of the caller who requested the change
+	RequestedBy string               `protobuf:"bytes,7,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// The subject of the caller who approved or rejected the change and when
+	ReviewedBy string               `protobuf:"bytes,9,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	ReviewedAt *timestamp.Timestamp `protobuf:"bytes,10,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	// What the reviewer said about the change
+	Comment              string   `protobuf:"bytes,11,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobChange) Reset()         { *m = JobChange{} }
+func (m *JobChange) String() string { return proto.CompactTextString(m) }
+func (*JobChange) ProtoMessage()    {}
+func (*JobChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{11}
+}
+
+func (m *JobChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobChange.Unmarshal(m, b)
+}
+func (m *JobChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobChange.Marshal(b, m, deterministic)
+}
+func (m *JobChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobChange.Merge(m, src)
+}
+func (m *JobChange) XXX_Size() int {
+	return xxx_messageInfo_JobChange.Size(m)
+}
+func (m *JobChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobChange proto.InternalMessageInfo
+
+func (m *JobChange) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *JobChange) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobChange) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *JobChange) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *JobChange) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *JobChange) GetStatus() ChangeStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ChangeStatus_CHANGE_STATUS_UNSPECIFIED
+}
+
+func (m *JobChange) GetRequestedBy() string {
+	if m != nil {
+		return m.RequestedBy
+	}
+	return ""
+}
+
+func (m *JobChange) GetRequestedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RequestedAt
+	}
+	return nil
+}
+
+func (m *JobChange) GetReviewedBy() string {
+	if m != nil {
+		return m.ReviewedBy
+	}
+	return ""
+}
+
+func (m *JobChange) GetReviewedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ReviewedAt
+	}
+	return nil
+}
+
+func (m *JobChange) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type ReadChangeReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadChangeReq) Reset()         { *m = ReadChangeReq{} }
+func (m *ReadChangeReq) String() string { return proto.CompactTextString(m) }
+func (*ReadChangeReq) ProtoMessage()    {}
+func (*ReadChangeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{12}
+}
+
+func (m *ReadChangeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadChangeReq.Unmarshal(m, b)
+}
+func (m *ReadChangeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadChangeReq.Marshal(b, m, deterministic)
+}
+func (m *ReadChangeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadChangeReq.Merge(m, src)
+}
+func (m *ReadChangeReq) XXX_Size() int {
+	return xxx_messageInfo_ReadChangeReq.Size(m)
+}
+func (m *ReadChangeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadChangeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadChangeReq proto.InternalMessageInfo
+
+func (m *ReadChangeReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadChangeRes struct {
+	Change               *JobChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReadChangeRes) Reset()         { *m = ReadChangeRes{} }
+func (m *ReadChangeRes) String() string { return proto.CompactTextString(m) }
+func (*ReadChangeRes) ProtoMessage()    {}
+func (*ReadChangeRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{13}
+}
+
+func (m *ReadChangeRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadChangeRes.Unmarshal(m, b)
+}
+func (m *ReadChangeRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadChangeRes.Marshal(b, m, deterministic)
+}
+func (m *ReadChangeRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadChangeRes.Merge(m, src)
+}
+func (m *ReadChangeRes) XXX_Size() int {
+	return xxx_messageInfo_ReadChangeRes.Size(m)
+}
+func (m *ReadChangeRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadChangeRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadChangeRes proto.InternalMessageInfo
+
+func (m *ReadChangeRes) GetChange() *JobChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+type ListChangesReq struct {
+	// Only lists the changes of this job when set
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Only lists the changes with this status when set
+	Status               ChangeStatus `protobuf:"varint,2,opt,name=status,proto3,enum=model.ChangeStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListChangesReq) Reset()         { *m = ListChangesReq{} }
+func (m *ListChangesReq) String() string { return proto.CompactTextString(m) }
+func (*ListChangesReq) ProtoMessage()    {}
+func (*ListChangesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{14}
+}
+
+func (m *ListChangesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListChangesReq.Unmarshal(m, b)
+}
+func (m *ListChangesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListChangesReq.Marshal(b, m, deterministic)
+}
+func (m *ListChangesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListChangesReq.Merge(m, src)
+}
+func (m *ListChangesReq) XXX_Size() int {
+	return xxx_messageInfo_ListChangesReq.Size(m)
+}
+func (m *ListChangesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListChangesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListChangesReq proto.InternalMessageInfo
+
+func (m *ListChangesReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ListChangesReq) GetStatus() ChangeStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ChangeStatus_CHANGE_STATUS_UNSPECIFIED
+}
+
+type ListChangesRes struct {
+	Change               *JobChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListChangesRes) Reset()         { *m = ListChangesRes{} }
+func (m *ListChangesRes) String() string { return proto.CompactTextString(m) }
+func (*ListChangesRes) ProtoMessage()    {}
+func (*ListChangesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{15}
+}
+
+func (m *ListChangesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListChangesRes.Unmarshal(m, b)
+}
+func (m *ListChangesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListChangesRes.Marshal(b, m, deterministic)
+}
+func (m *ListChangesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListChangesRes.Merge(m, src)
+}
+func (m *ListChangesRes) XXX_Size() int {
+	return xxx_messageInfo_ListChangesRes.Size(m)
+}
+func (m *ListChangesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListChangesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListChangesRes proto.InternalMessageInfo
+
+func (m *ListChangesRes) GetChange() *JobChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+type ApproveChangeReq struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Optional, at most 4096 characters
+	Comment              string   `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChangeReq) Reset()         { *m = ApproveChangeReq{} }
+func (m *ApproveChangeReq) String() string { return proto.CompactTextString(m) }
+func (*ApproveChangeReq) ProtoMessage()    {}
+func (*ApproveChangeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{16}
+}
+
+func (m *ApproveChangeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChangeReq.Unmarshal(m, b)
+}
+func (m *ApproveChangeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChangeReq.Marshal(b, m, deterministic)
+}
+func (m *ApproveChangeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChangeReq.Merge(m, src)
+}
+func (m *ApproveChangeReq) XXX_Size() int {
+	return xxx_messageInfo_ApproveChangeReq.Size(m)
+}
+func (m *ApproveChangeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChangeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChangeReq proto.InternalMessageInfo
+
+func (m *ApproveChangeReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ApproveChangeReq) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type ApproveChangeRes struct {
+	Change *JobChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	// The job with the change applied
+	Job                  *Job     `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChangeRes) Reset()         { *m = ApproveChangeRes{} }
+func (m *ApproveChangeRes) String() string { return proto.CompactTextString(m) }
+func (*ApproveChangeRes) ProtoMessage()    {}
+func (*ApproveChangeRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{17}
+}
+
+func (m *ApproveChangeRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChangeRes.Unmarshal(m, b)
+}
+func (m *ApproveChangeRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChangeRes.Marshal(b, m, deterministic)
+}
+func (m *ApproveChangeRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChangeRes.Merge(m, src)
+}
+func (m *ApproveChangeRes) XXX_Size() int {
+	return xxx_messageInfo_ApproveChangeRes.Size(m)
+}
+func (m *ApproveChangeRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChangeRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChangeRes proto.InternalMessageInfo
+
+func (m *ApproveChangeRes) GetChange() *JobChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (m *ApproveChangeRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type RejectChangeReq struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Optional, at most 4096 characters
+	Comment              string   `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectChangeReq) Reset()         { *m = RejectChangeReq{} }
+func (m *RejectChangeReq) String() string { return proto.CompactTextString(m) }
+func (*RejectChangeReq) ProtoMessage()    {}
+func (*RejectChangeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{18}
+}
+
+func (m *RejectChangeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectChangeReq.Unmarshal(m, b)
+}
+func (m *RejectChangeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectChangeReq.Marshal(b, m, deterministic)
+}
+func (m *RejectChangeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectChangeReq.Merge(m, src)
+}
+func (m *RejectChangeReq) XXX_Size() int {
+	return xxx_messageInfo_RejectChangeReq.Size(m)
+}
+func (m *RejectChangeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectChangeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectChangeReq proto.InternalMessageInfo
+
+func (m *RejectChangeReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RejectChangeReq) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type RejectChangeRes struct {
+	Change               *JobChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RejectChangeRes) Reset()         { *m = RejectChangeRes{} }
+func (m *RejectChangeRes) String() string { return proto.CompactTextString(m) }
+func (*RejectChangeRes) ProtoMessage()    {}
+func (*RejectChangeRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{19}
+}
+
+func (m *RejectChangeRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectChangeRes.Unmarshal(m, b)
+}
+func (m *RejectChangeRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectChangeRes.Marshal(b, m, deterministic)
+}
+func (m *RejectChangeRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectChangeRes.Merge(m, src)
+}
+func (m *RejectChangeRes) XXX_Size() int {
+	return xxx_messageInfo_RejectChangeRes.Size(m)
+}
+func (m *RejectChangeRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectChangeRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectChangeRes proto.InternalMessageInfo
+
+func (m *RejectChangeRes) GetChange() *JobChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+type CloneJobReq struct {
+	// The job to clone, its runs aren't cloned
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Values replacing the ones of the job in the clone
+	Overrides *Job `protobuf:"bytes,2,opt,name=overrides,proto3" json:"overrides,omitempty"`
+	// Fields of overrides to apply, the same ones UpdateJob updates. Without name the clone is named "<name> (copy)".
+	OverrideMask *field_mask.FieldMask `protobuf:"bytes,3,opt,name=override_mask,json=overrideMask,proto3" json:"override_mask,omitempty"`
+	// Optional key like the one of CreateJob
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloneJobReq) Reset()         { *m = CloneJobReq{} }
+func (m *CloneJobReq) String() string { return proto.CompactTextString(m) }
+func (*CloneJobReq) ProtoMessage()    {}
+func (*CloneJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{20}
+}
+
+func (m *CloneJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloneJobReq.Unmarshal(m, b)
+}
+func (m *CloneJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloneJobReq.Marshal(b, m, deterministic)
+}
+func (m *CloneJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneJobReq.Merge(m, src)
+}
+func (m *CloneJobReq) XXX_Size() int {
+	return xxx_messageInfo_CloneJobReq.Size(m)
+}
+func (m *CloneJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloneJobReq proto.InternalMessageInfo
+
+func (m *CloneJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CloneJobReq) GetOverrides() *Job {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
+func (m *CloneJobReq) GetOverrideMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.OverrideMask
+	}
+	return nil
+}
+
+func (m *CloneJobReq) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type CloneJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloneJobRes) Reset()         { *m = CloneJobRes{} }
+func (m *CloneJobRes) String() string { return proto.CompactTextString(m) }
+func (*CloneJobRes) ProtoMessage()    {}
+func (*CloneJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{21}
+}
+
+func (m *CloneJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloneJobRes.Unmarshal(m, b)
+}
+func (m *CloneJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloneJobRes.Marshal(b, m, deterministic)
+}
+func (m *CloneJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneJobRes.Merge(m, src)
+}
+func (m *CloneJobRes) XXX_Size() int {
+	return xxx_messageInfo_CloneJobRes.Size(m)
+}
+func (m *CloneJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloneJobRes proto.InternalMessageInfo
+
+func (m *CloneJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+// JobRevision is the version of a job before an update, RollbackJob restores it
+type JobRevision struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Counts the revisions of the job, starting at 1
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// The job as it was before the update
+	Job *Job `protobuf:"bytes,3,opt,name=job,proto3" json:"job,omitempty"`
+	// The fields the update changed, ordered by field like the changes of audit entries
+	Changes []*AuditChange `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	// The subject of the caller who updated the job and when
+	CreatedBy            string               `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *JobRevision) Reset()         { *m = JobRevision{} }
+func (m *JobRevision) String() string { return proto.CompactTextString(m) }
+func (*JobRevision) ProtoMessage()    {}
+func (*JobRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{22}
+}
+
+func (m *JobRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobRevision.Unmarshal(m, b)
+}
+func (m *JobRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobRevision.Marshal(b, m, deterministic)
+}
+func (m *JobRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRevision.Merge(m, src)
+}
+func (m *JobRevision) XXX_Size() int {
+	return xxx_messageInfo_JobRevision.Size(m)
+}
+func (m *JobRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRevision proto.InternalMessageInfo
+
+func (m *JobRevision) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobRevision) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *JobRevision) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *JobRevision) GetChanges() []*AuditChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *JobRevision) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
+}
+
+func (m *JobRevision) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type ListJobRevisionsReq struct {
+	JobId                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobRevisionsReq) Reset()         { *m = ListJobRevisionsReq{} }
+func (m *ListJobRevisionsReq) String() string { return proto.CompactTextString(m) }
+func (*ListJobRevisionsReq) ProtoMessage()    {}
+func (*ListJobRevisionsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{23}
+}
+
+func (m *ListJobRevisionsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobRevisionsReq.Unmarshal(m, b)
+}
+func (m *ListJobRevisionsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobRevisionsReq.Marshal(b, m, deterministic)
+}
+func (m *ListJobRevisionsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobRevisionsReq.Merge(m, src)
+}
+func (m *ListJobRevisionsReq) XXX_Size() int {
+	return xxx_messageInfo_ListJobRevisionsReq.Size(m)
+}
+func (m *ListJobRevisionsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobRevisionsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobRevisionsReq proto.InternalMessageInfo
+
+func (m *ListJobRevisionsReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type ListJobRevisionsRes struct {
+	Revision             *JobRevision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListJobRevisionsRes) Reset()         { *m = ListJobRevisionsRes{} }
+func (m *ListJobRevisionsRes) String() string { return proto.CompactTextString(m) }
+func (*ListJobRevisionsRes) ProtoMessage()    {}
+func (*ListJobRevisionsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{24}
+}
+
+func (m *ListJobRevisionsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobRevisionsRes.Unmarshal(m, b)
+}
+func (m *ListJobRevisionsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobRevisionsRes.Marshal(b, m, deterministic)
+}
+func (m *ListJobRevisionsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobRevisionsRes.Merge(m, src)
+}
+func (m *ListJobRevisionsRes) XXX_Size() int {
+	return xxx_messageInfo_ListJobRevisionsRes.Size(m)
+}
+func (m *ListJobRevisionsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobRevisionsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobRevisionsRes proto.InternalMessageInfo
+
+func (m *ListJobRevisionsRes) GetRevision() *JobRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+type RollbackJobReq struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The revision to restore the job to
+	Revision             int64    `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackJobReq) Reset()         { *m = RollbackJobReq{} }
+func (m *RollbackJobReq) String() string { return proto.CompactTextString(m) }
+func (*RollbackJobReq) ProtoMessage()    {}
+func (*RollbackJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{25}
+}
+
+func (m *RollbackJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackJobReq.Unmarshal(m, b)
+}
+func (m *RollbackJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackJobReq.Marshal(b, m, deterministic)
+}
+func (m *RollbackJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackJobReq.Merge(m, src)
+}
+func (m *RollbackJobReq) XXX_Size() int {
+	return xxx_messageInfo_RollbackJobReq.Size(m)
+}
+func (m *RollbackJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackJobReq proto.InternalMessageInfo
+
+func (m *RollbackJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RollbackJobReq) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type RollbackJobRes struct {
+	// The restored job, unchanged when the rollback only requested a change
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Set when the job is in a protected environment, the rollback is applied once the change is approved
+	Change               *JobChange `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RollbackJobRes) Reset()         { *m = RollbackJobRes{} }
+func (m *RollbackJobRes) String() string { return proto.CompactTextString(m) }
+func (*RollbackJobRes) ProtoMessage()    {}
+func (*RollbackJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{26}
+}
+
+func (m *RollbackJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackJobRes.Unmarshal(m, b)
+}
+func (m *RollbackJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackJobRes.Marshal(b, m, deterministic)
+}
+func (m *RollbackJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackJobRes.Merge(m, src)
+}
+func (m *RollbackJobRes) XXX_Size() int {
+	return xxx_messageInfo_RollbackJobRes.Size(m)
+}
+func (m *RollbackJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackJobRes proto.InternalMessageInfo
+
+func (m *RollbackJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *RollbackJobRes) GetChange() *JobChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+type PromoteJobReq struct {
+	// The job to promote
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The environment to promote the job to, it must differ from the one of the job
+	TargetEnvironment string `protobuf:"bytes,2,opt,name=target_environment,json=targetEnvironment,proto3" json:"target_environment,omitempty"`
+	// Required, like a change ticket or the name of the approver, at most 1024 characters
+	Approval             string   `protobuf:"bytes,3,opt,name=approval,proto3" json:"approval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PromoteJobReq) Reset()         { *m = PromoteJobReq{} }
+func (m *PromoteJobReq) String() string { return proto.CompactTextString(m) }
+func (*PromoteJobReq) ProtoMessage()    {}
+func (*PromoteJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{27}
+}
+
+func (m *PromoteJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromoteJobReq.Unmarshal(m, b)
+}
+func (m *PromoteJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromoteJobReq.Marshal(b, m, deterministic)
+}
+func (m *PromoteJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteJobReq.Merge(m, src)
+}
+func (m *PromoteJobReq) XXX_Size() int {
+	return xxx_messageInfo_PromoteJobReq.Size(m)
+}
+func (m *PromoteJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromoteJobReq proto.InternalMessageInfo
+
+func (m *PromoteJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PromoteJobReq) GetTargetEnvironment() string {
+	if m != nil {
+		return m.TargetEnvironment
+	}
+	return ""
+}
+
+func (m *PromoteJobReq) GetApproval() string {
+	if m != nil {
+		return m.Approval
+	}
+	return ""
+}
+
+type PromoteJobRes struct {
+	// The job in the target environment
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Whether the job was created, it was updated when the owner already had a job with the name in the environment
+	Created              bool     `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PromoteJobRes) Reset()         { *m = PromoteJobRes{} }
+func (m *PromoteJobRes) String() string { return proto.CompactTextString(m) }
+func (*PromoteJobRes) ProtoMessage()    {}
+func (*PromoteJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{28}
+}
+
+func (m *PromoteJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromoteJobRes.Unmarshal(m, b)
+}
+func (m *PromoteJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromoteJobRes.Marshal(b, m, deterministic)
+}
+func (m *PromoteJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteJobRes.Merge(m, src)
+}
+func (m *PromoteJobRes) XXX_Size() int {
+	return xxx_messageInfo_PromoteJobRes.Size(m)
+}
+func (m *PromoteJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromoteJobRes proto.InternalMessageInfo
+
+func (m *PromoteJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *PromoteJobRes) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+type ReadJobReq struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Also find the job when it was deleted
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Only returns these fields of the job besides its id, like read_mask=name,owner. Whole jobs are returned when it's
+	// empty.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ReadJobReq) Reset()         { *m = ReadJobReq{} }
+func (m *ReadJobReq) String() string { return proto.CompactTextString(m) }
+func (*ReadJobReq) ProtoMessage()    {}
+func (*ReadJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{29}
+}
+
+func (m *ReadJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadJobReq.Unmarshal(m, b)
+}
+func (m *ReadJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadJobReq.Marshal(b, m, deterministic)
+}
+func (m *ReadJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadJobReq.Merge(m, src)
+}
+func (m *ReadJobReq) XXX_Size() int {
+	return xxx_messageInfo_ReadJobReq.Size(m)
+}
+func (m *ReadJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadJobReq proto.InternalMessageInfo
+
+func (m *ReadJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ReadJobReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *ReadJobReq) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+type ReadJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadJobRes) Reset()         { *m = ReadJobRes{} }
+func (m *ReadJobRes) String() string { return proto.CompactTextString(m) }
+func (*ReadJobRes) ProtoMessage()    {}
+func (*ReadJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{30}
+}
+
+func (m *ReadJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadJobRes.Unmarshal(m, b)
+}
+func (m *ReadJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadJobRes.Marshal(b, m, deterministic)
+}
+func (m *ReadJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadJobRes.Merge(m, src)
+}
+func (m *ReadJobRes) XXX_Size() int {
+	return xxx_messageInfo_ReadJobRes.Size(m)
+}
+func (m *ReadJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadJobRes proto.InternalMessageInfo
+
+func (m *ReadJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type BatchGetJobsReq struct {
+	// At most 1000 ids
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Also find jobs that were deleted
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Only returns these fields of the jobs besides their ids like with ReadJob
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BatchGetJobsReq) Reset()         { *m = BatchGetJobsReq{} }
+func (m *BatchGetJobsReq) String() string { return proto.CompactTextString(m) }
+func (*BatchGetJobsReq) ProtoMessage()    {}
+func (*BatchGetJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{31}
+}
+
+func (m *BatchGetJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetJobsReq.Unmarshal(m, b)
+}
+func (m *BatchGetJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetJobsReq.Marshal(b, m, deterministic)
+}
+func (m *BatchGetJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetJobsReq.Merge(m, src)
+}
+func (m *BatchGetJobsReq) XXX_Size() int {
+	return xxx_messageInfo_BatchGetJobsReq.Size(m)
+}
+func (m *BatchGetJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetJobsReq proto.InternalMessageInfo
+
+func (m *BatchGetJobsReq) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *BatchGetJobsReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *BatchGetJobsReq) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+type BatchGetJobsRes struct {
+	// The found jobs in the order of the request, each of them once
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// The requested ids without a job, in the order of the request. Jobs of other owners are missing like with ReadJob.
+	MissingIds           []string `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetJobsRes) Reset()         { *m = BatchGetJobsRes{} }
+func (m *BatchGetJobsRes) String() string { return proto.CompactTextString(m) }
+func (*BatchGetJobsRes) ProtoMessage()    {}
+func (*BatchGetJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{32}
+}
+
+func (m *BatchGetJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetJobsRes.Unmarshal(m, b)
+}
+func (m *BatchGetJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetJobsRes.Marshal(b, m, deterministic)
+}
+func (m *BatchGetJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetJobsRes.Merge(m, src)
+}
+func (m *BatchGetJobsRes) XXX_Size() int {
+	return xxx_messageInfo_BatchGetJobsRes.Size(m)
+}
+func (m *BatchGetJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetJobsRes proto.InternalMessageInfo
+
+func (m *BatchGetJobsRes) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *BatchGetJobsRes) GetMissingIds() []string {
+	if m != nil {
+		return m.MissingIds
+	}
+	return nil
+}
+
+type CountJobsReq struct {
+	// Selector like the one of ListJobs only counting the jobs whose labels match it
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// Also count deleted jobs
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Count the archived jobs instead of the others
+	Archived bool `protobuf:"varint,3,opt,name=archived,proto3" json:"archived,omitempty"`
+	// Only count the jobs in this environment
+	Environment          string   `protobuf:"bytes,4,opt,name=environment,proto3" json:"environment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountJobsReq) Reset()         { *m = CountJobsReq{} }
+func (m *CountJobsReq) String() string { return proto.CompactTextString(m) }
+func (*CountJobsReq) ProtoMessage()    {}
+func (*CountJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{33}
+}
+
+func (m *CountJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountJobsReq.Unmarshal(m, b)
+}
+func (m *CountJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountJobsReq.Marshal(b, m, deterministic)
+}
+func (m *CountJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountJobsReq.Merge(m, src)
+}
+func (m *CountJobsReq) XXX_Size() int {
+	return xxx_messageInfo_CountJobsReq.Size(m)
+}
+func (m *CountJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountJobsReq proto.InternalMessageInfo
+
+func (m *CountJobsReq) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+func (m *CountJobsReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *CountJobsReq) GetArchived() bool {
+	if m != nil {
+		return m.Archived
+	}
+	return false
+}
+
+func (m *CountJobsReq) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+type CountJobsRes struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountJobsRes) Reset()         { *m = CountJobsRes{} }
+func (m *CountJobsRes) String() string { return proto.CompactTextString(m) }
+func (*CountJobsRes) ProtoMessage()    {}
+func (*CountJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{34}
+}
+
+func (m *CountJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountJobsRes.Unmarshal(m, b)
+}
+func (m *CountJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountJobsRes.Marshal(b, m, deterministic)
+}
+func (m *CountJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountJobsRes.Merge(m, src)
+}
+func (m *CountJobsRes) XXX_Size() int {
+	return xxx_messageInfo_CountJobsRes.Size(m)
+}
+func (m *CountJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountJobsRes proto.InternalMessageInfo
+
+func (m *CountJobsRes) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type JobExistsReq struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deleted jobs exist too
+	IncludeDeleted       bool     `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobExistsReq) Reset()         { *m = JobExistsReq{} }
+func (m *JobExistsReq) String() string { return proto.CompactTextString(m) }
+func (*JobExistsReq) ProtoMessage()    {}
+func (*JobExistsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{35}
+}
+
+func (m *JobExistsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobExistsReq.Unmarshal(m, b)
+}
+func (m *JobExistsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobExistsReq.Marshal(b, m, deterministic)
+}
+func (m *JobExistsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobExistsReq.Merge(m, src)
+}
+func (m *JobExistsReq) XXX_Size() int {
+	return xxx_messageInfo_JobExistsReq.Size(m)
+}
+func (m *JobExistsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobExistsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobExistsReq proto.InternalMessageInfo
+
+func (m *JobExistsReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *JobExistsReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+type JobExistsRes struct {
+	// Jobs of other owners don't exist like with ReadJob
+	Exists               bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobExistsRes) Reset()         { *m = JobExistsRes{} }
+func (m *JobExistsRes) String() string { return proto.CompactTextString(m) }
+func (*JobExistsRes) ProtoMessage()    {}
+func (*JobExistsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{36}
+}
+
+func (m *JobExistsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobExistsRes.Unmarshal(m, b)
+}
+func (m *JobExistsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobExistsRes.Marshal(b, m, deterministic)
+}
+func (m *JobExistsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobExistsRes.Merge(m, src)
+}
+func (m *JobExistsRes) XXX_Size() int {
+	return xxx_messageInfo_JobExistsRes.Size(m)
+}
+func (m *JobExistsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobExistsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobExistsRes proto.InternalMessageInfo
+
+func (m *JobExistsRes) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+type DeleteJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobReq) Reset()         { *m = DeleteJobReq{} }
+func (m *DeleteJobReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobReq) ProtoMessage()    {}
+func (*DeleteJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{37}
+}
+
+func (m *DeleteJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobReq.Unmarshal(m, b)
+}
+func (m *DeleteJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobReq.Merge(m, src)
+}
+func (m *DeleteJobReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobReq.Size(m)
+}
+func (m *DeleteJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobReq proto.InternalMessageInfo
+
+func (m *DeleteJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteJobRes struct {
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobRes) Reset()         { *m = DeleteJobRes{} }
+func (m *DeleteJobRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobRes) ProtoMessage()    {}
+func (*DeleteJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{38}
+}
+
+func (m *DeleteJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobRes.Unmarshal(m, b)
+}
+func (m *DeleteJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobRes.Merge(m, src)
+}
+func (m *DeleteJobRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobRes.Size(m)
+}
+func (m *DeleteJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobRes proto.InternalMessageInfo
+
+func (m *DeleteJobRes) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type DeleteJobsReq struct {
+	// At most 1000 ids
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobsReq) Reset()         { *m = DeleteJobsReq{} }
+func (m *DeleteJobsReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobsReq) ProtoMessage()    {}
+func (*DeleteJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{39}
+}
+
+func (m *DeleteJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobsReq.Unmarshal(m, b)
+}
+func (m *DeleteJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobsReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobsReq.Merge(m, src)
+}
+func (m *DeleteJobsReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobsReq.Size(m)
+}
+func (m *DeleteJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobsReq proto.InternalMessageInfo
+
+func (m *DeleteJobsReq) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+// DeleteJobResult is the outcome of deleting a single job of a DeleteJobs request
+type DeleteJobResult struct {
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// Why the job wasn't deleted, empty on success
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobResult) Reset()         { *m = DeleteJobResult{} }
+func (m *DeleteJobResult) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobResult) ProtoMessage()    {}
+func (*DeleteJobResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{40}
+}
+
+func (m *DeleteJobResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobResult.Unmarshal(m, b)
+}
+func (m *DeleteJobResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobResult.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobResult.Merge(m, src)
+}
+func (m *DeleteJobResult) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobResult.Size(m)
+}
+func (m *DeleteJobResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobResult proto.InternalMessageInfo
+
+func (m *DeleteJobResult) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteJobResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *DeleteJobResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DeleteJobsRes struct {
+	// One result per requested id, in the order of the request
+	Results              []*DeleteJobResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeleteJobsRes) Reset()         { *m = DeleteJobsRes{} }
+func (m *DeleteJobsRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobsRes) ProtoMessage()    {}
+func (*DeleteJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{41}
+}
+
+func (m *DeleteJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobsRes.Unmarshal(m, b)
+}
+func (m *DeleteJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobsRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobsRes.Merge(m, src)
+}
+func (m *DeleteJobsRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobsRes.Size(m)
+}
+func (m *DeleteJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobsRes proto.InternalMessageInfo
+
+func (m *DeleteJobsRes) GetResults() []*DeleteJobResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ListJobsReq struct {
+	// Maximum number of jobs to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue listing after the last job returned
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Also list deleted jobs, the page token must come from a request with the same value
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Kubernetes style selector like team=data,env!=prod,tier in (web,api),!legacy only listing the jobs whose
+	// labels match it. The page token must come from a request with the same selector.
+	LabelSelector string `protobuf:"bytes,4,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// List the archived jobs instead of the others, the page token must come from a request with the same value
+	Archived bool `protobuf:"varint,5,opt,name=archived,proto3" json:"archived,omitempty"`
+	// Only streams these fields of the jobs besides their ids like with ReadJob
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,6,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// Only list the jobs in this environment, the page token must come from a request with the same value
+	Environment          string   `protobuf:"bytes,7,opt,name=environment,proto3" json:"environment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsReq) Reset()         { *m = ListJobsReq{} }
+func (m *ListJobsReq) String() string { return proto.CompactTextString(m) }
+func (*ListJobsReq) ProtoMessage()    {}
+func (*ListJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{42}
+}
+
+func (m *ListJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsReq.Unmarshal(m, b)
+}
+func (m *ListJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsReq.Marshal(b, m, deterministic)
+}
+func (m *ListJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsReq.Merge(m, src)
+}
+func (m *ListJobsReq) XXX_Size() int {
+	return xxx_messageInfo_ListJobsReq.Size(m)
+}
+func (m *ListJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsReq proto.InternalMessageInfo
+
+func (m *ListJobsReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListJobsReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListJobsReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *ListJobsReq) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+func (m *ListJobsReq) GetArchived() bool {
+	if m != nil {
+		return m.Archived
+	}
+	return false
+}
+
+func (m *ListJobsReq) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+func (m *ListJobsReq) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+type ListJobsRes struct {
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Only set on the last message of a page when more jobs are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsRes) Reset()         { *m = ListJobsRes{} }
+func (m *ListJobsRes) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRes) ProtoMessage()    {}
+func (*ListJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{43}
+}
+
+func (m *ListJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRes.Unmarshal(m, b)
+}
+func (m *ListJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRes.Marshal(b, m, deterministic)
+}
+func (m *ListJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRes.Merge(m, src)
+}
+func (m *ListJobsRes) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRes.Size(m)
+}
+func (m *ListJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsRes proto.InternalMessageInfo
+
+func (m *ListJobsRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *ListJobsRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type SearchJobsReq struct {
+	// Words to look for in the names and descriptions of jobs, at most 256 characters. Jobs containing any of them are
+	// found, "quoted phrases" must appear as a whole and words with a leading - must not appear.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Maximum number of jobs to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue with the next page, it must come from a request with the same query
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Also search deleted jobs
+	IncludeDeleted       bool     `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchJobsReq) Reset()         { *m = SearchJobsReq{} }
+func (m *SearchJobsReq) String() string { return proto.CompactTextString(m) }
+func (*SearchJobsReq) ProtoMessage()    {}
+func (*SearchJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{44}
+}
+
+func (m *SearchJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchJobsReq.Unmarshal(m, b)
+}
+func (m *SearchJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchJobsReq.Marshal(b, m, deterministic)
+}
+func (m *SearchJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchJobsReq.Merge(m, src)
+}
+func (m *SearchJobsReq) XXX_Size() int {
+	return xxx_messageInfo_SearchJobsReq.Size(m)
+}
+func (m *SearchJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchJobsReq proto.InternalMessageInfo
+
+func (m *SearchJobsReq) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchJobsReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchJobsReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *SearchJobsReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+// SearchHighlight is the value of a field of a found job with every matched word wrapped in <em> and </em>, the rest
+// is HTML escaped
+type SearchHighlight struct {
+	// name or description
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Fragment             string   `protobuf:"bytes,2,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchHighlight) Reset()         { *m = SearchHighlight{} }
+func (m *SearchHighlight) String() string { return proto.CompactTextString(m) }
+func (*SearchHighlight) ProtoMessage()    {}
+func (*SearchHighlight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{45}
+}
+
+func (m *SearchHighlight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchHighlight.Unmarshal(m, b)
+}
+func (m *SearchHighlight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchHighlight.Marshal(b, m, deterministic)
+}
+func (m *SearchHighlight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchHighlight.Merge(m, src)
+}
+func (m *SearchHighlight) XXX_Size() int {
+	return xxx_messageInfo_SearchHighlight.Size(m)
+}
+func (m *SearchHighlight) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchHighlight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchHighlight proto.InternalMessageInfo
+
+func (m *SearchHighlight) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *SearchHighlight) GetFragment() string {
+	if m != nil {
+		return m.Fragment
+	}
+	return ""
+}
+
+type SearchJobsRes struct {
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Relevance of the job for the query, higher is more relevant. Scores depend on the storage backend.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// One highlight per field with matched words
+	Highlights []*SearchHighlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	// Only set on the last message of a page when more jobs are available
+	NextPageToken        string   `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchJobsRes) Reset()         { *m = SearchJobsRes{} }
+func (m *SearchJobsRes) String() string { return proto.CompactTextString(m) }
+func (*SearchJobsRes) ProtoMessage()    {}
+func (*SearchJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{46}
+}
+
+func (m *SearchJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchJobsRes.Unmarshal(m, b)
+}
+func (m *SearchJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchJobsRes.Marshal(b, m, deterministic)
+}
+func (m *SearchJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchJobsRes.Merge(m, src)
+}
+func (m *SearchJobsRes) XXX_Size() int {
+	return xxx_messageInfo_SearchJobsRes.Size(m)
+}
+func (m *SearchJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchJobsRes proto.InternalMessageInfo
+
+func (m *SearchJobsRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *SearchJobsRes) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchJobsRes) GetHighlights() []*SearchHighlight {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+func (m *SearchJobsRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type RestoreJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreJobReq) Reset()         { *m = RestoreJobReq{} }
+func (m *RestoreJobReq) String() string { return proto.CompactTextString(m) }
+func (*RestoreJobReq) ProtoMessage()    {}
+func (*RestoreJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{47}
+}
+
+func (m *RestoreJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreJobReq.Unmarshal(m, b)
+}
+func (m *RestoreJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreJobReq.Marshal(b, m, deterministic)
+}
+func (m *RestoreJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreJobReq.Merge(m, src)
+}
+func (m *RestoreJobReq) XXX_Size() int {
+	return xxx_messageInfo_RestoreJobReq.Size(m)
+}
+func (m *RestoreJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreJobReq proto.InternalMessageInfo
+
+func (m *RestoreJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RestoreJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreJobRes) Reset()         { *m = RestoreJobRes{} }
+func (m *RestoreJobRes) String() string { return proto.CompactTextString(m) }
+func (*RestoreJobRes) ProtoMessage()    {}
+func (*RestoreJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{48}
+}
+
+func (m *RestoreJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreJobRes.Unmarshal(m, b)
+}
+func (m *RestoreJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreJobRes.Marshal(b, m, deterministic)
+}
+func (m *RestoreJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreJobRes.Merge(m, src)
+}
+func (m *RestoreJobRes) XXX_Size() int {
+	return xxx_messageInfo_RestoreJobRes.Size(m)
+}
+func (m *RestoreJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreJobRes proto.InternalMessageInfo
+
+func (m *RestoreJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type ArchiveJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveJobReq) Reset()         { *m = ArchiveJobReq{} }
+func (m *ArchiveJobReq) String() string { return proto.CompactTextString(m) }
+func (*ArchiveJobReq) ProtoMessage()    {}
+func (*ArchiveJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{49}
+}
+
+func (m *ArchiveJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveJobReq.Unmarshal(m, b)
+}
+func (m *ArchiveJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveJobReq.Marshal(b, m, deterministic)
+}
+func (m *ArchiveJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveJobReq.Merge(m, src)
+}
+func (m *ArchiveJobReq) XXX_Size() int {
+	return xxx_messageInfo_ArchiveJobReq.Size(m)
+}
+func (m *ArchiveJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveJobReq proto.InternalMessageInfo
+
+func (m *ArchiveJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ArchiveJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveJobRes) Reset()         { *m = ArchiveJobRes{} }
+func (m *ArchiveJobRes) String() string { return proto.CompactTextString(m) }
+func (*ArchiveJobRes) ProtoMessage()    {}
+func (*ArchiveJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{50}
+}
+
+func (m *ArchiveJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveJobRes.Unmarshal(m, b)
+}
+func (m *ArchiveJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveJobRes.Marshal(b, m, deterministic)
+}
+func (m *ArchiveJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveJobRes.Merge(m, src)
+}
+func (m *ArchiveJobRes) XXX_Size() int {
+	return xxx_messageInfo_ArchiveJobRes.Size(m)
+}
+func (m *ArchiveJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveJobRes proto.InternalMessageInfo
+
+func (m *ArchiveJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type UnarchiveJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnarchiveJobReq) Reset()         { *m = UnarchiveJobReq{} }
+func (m *UnarchiveJobReq) String() string { return proto.CompactTextString(m) }
+func (*UnarchiveJobReq) ProtoMessage()    {}
+func (*UnarchiveJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{51}
+}
+
+func (m *UnarchiveJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnarchiveJobReq.Unmarshal(m, b)
+}
+func (m *UnarchiveJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnarchiveJobReq.Marshal(b, m, deterministic)
+}
+func (m *UnarchiveJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnarchiveJobReq.Merge(m, src)
+}
+func (m *UnarchiveJobReq) XXX_Size() int {
+	return xxx_messageInfo_UnarchiveJobReq.Size(m)
+}
+func (m *UnarchiveJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnarchiveJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnarchiveJobReq proto.InternalMessageInfo
+
+func (m *UnarchiveJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UnarchiveJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnarchiveJobRes) Reset()         { *m = UnarchiveJobRes{} }
+func (m *UnarchiveJobRes) String() string { return proto.CompactTextString(m) }
+func (*UnarchiveJobRes) ProtoMessage()    {}
+func (*UnarchiveJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{52}
+}
+
+func (m *UnarchiveJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnarchiveJobRes.Unmarshal(m, b)
+}
+func (m *UnarchiveJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnarchiveJobRes.Marshal(b, m, deterministic)
+}
+func (m *UnarchiveJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnarchiveJobRes.Merge(m, src)
+}
+func (m *UnarchiveJobRes) XXX_Size() int {
+	return xxx_messageInfo_UnarchiveJobRes.Size(m)
+}
+func (m *UnarchiveJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnarchiveJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnarchiveJobRes proto.InternalMessageInfo
+
+func (m *UnarchiveJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+// ArchiveJobsReq selects the jobs to archive, the ones matching every given filter are archived. At least one filter
+// must be given.
+type ArchiveJobsReq struct {
+	// Label selector like the one of ListJobs
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// Only archive jobs with one of these statuses
+	Statuses []JobStatus `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=model.JobStatus" json:"statuses,omitempty"`
+	// Only archive jobs without a schedule, like one-off jobs that already ran
+	Unscheduled          bool     `protobuf:"varint,3,opt,name=unscheduled,proto3" json:"unscheduled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveJobsReq) Reset()         { *m = ArchiveJobsReq{} }
+func (m *ArchiveJobsReq) String() string { return proto.CompactTextString(m) }
+func (*ArchiveJobsReq) ProtoMessage()    {}
+func (*ArchiveJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{53}
+}
+
+func (m *ArchiveJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveJobsReq.Unmarshal(m, b)
+}
+func (m *ArchiveJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveJobsReq.Marshal(b, m, deterministic)
+}
+func (m *ArchiveJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveJobsReq.Merge(m, src)
+}
+func (m *ArchiveJobsReq) XXX_Size() int {
+	return xxx_messageInfo_ArchiveJobsReq.Size(m)
+}
+func (m *ArchiveJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveJobsReq proto.InternalMessageInfo
+
+func (m *ArchiveJobsReq) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+func (m *ArchiveJobsReq) GetStatuses() []JobStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *ArchiveJobsReq) GetUnscheduled() bool {
+	if m != nil {
+		return m.Unscheduled
+	}
+	return false
+}
+
+type ArchiveJobsRes struct {
+	// IDs of the archived jobs, jobs that were archived already or are running are left alone
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveJobsRes) Reset()         { *m = ArchiveJobsRes{} }
+func (m *ArchiveJobsRes) String() string { return proto.CompactTextString(m) }
+func (*ArchiveJobsRes) ProtoMessage()    {}
+func (*ArchiveJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{54}
+}
+
+func (m *ArchiveJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveJobsRes.Unmarshal(m, b)
+}
+func (m *ArchiveJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveJobsRes.Marshal(b, m, deterministic)
+}
+func (m *ArchiveJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveJobsRes.Merge(m, src)
+}
+func (m *ArchiveJobsRes) XXX_Size() int {
+	return xxx_messageInfo_ArchiveJobsRes.Size(m)
+}
+func (m *ArchiveJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveJobsRes proto.InternalMessageInfo
+
+func (m *ArchiveJobsRes) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type PauseJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseJobReq) Reset()         { *m = PauseJobReq{} }
+func (m *PauseJobReq) String() string { return proto.CompactTextString(m) }
+func (*PauseJobReq) ProtoMessage()    {}
+func (*PauseJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{55}
+}
+
+func (m *PauseJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseJobReq.Unmarshal(m, b)
+}
+func (m *PauseJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseJobReq.Marshal(b, m, deterministic)
+}
+func (m *PauseJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseJobReq.Merge(m, src)
+}
+func (m *PauseJobReq) XXX_Size() int {
+	return xxx_messageInfo_PauseJobReq.Size(m)
+}
+func (m *PauseJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseJobReq proto.InternalMessageInfo
+
+func (m *PauseJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type PauseJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseJobRes) Reset()         { *m = PauseJobRes{} }
+func (m *PauseJobRes) String() string { return proto.CompactTextString(m) }
+func (*PauseJobRes) ProtoMessage()    {}
+func (*PauseJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{56}
+}
+
+func (m *PauseJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseJobRes.Unmarshal(m, b)
+}
+func (m *PauseJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseJobRes.Marshal(b, m, deterministic)
+}
+func (m *PauseJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseJobRes.Merge(m, src)
+}
+func (m *PauseJobRes) XXX_Size() int {
+	return xxx_messageInfo_PauseJobRes.Size(m)
+}
+func (m *PauseJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseJobRes proto.InternalMessageInfo
+
+func (m *PauseJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type ResumeJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeJobReq) Reset()         { *m = ResumeJobReq{} }
+func (m *ResumeJobReq) String() string { return proto.CompactTextString(m) }
+func (*ResumeJobReq) ProtoMessage()    {}
+func (*ResumeJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{57}
+}
+
+func (m *ResumeJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeJobReq.Unmarshal(m, b)
+}
+func (m *ResumeJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeJobReq.Marshal(b, m, deterministic)
+}
+func (m *ResumeJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeJobReq.Merge(m, src)
+}
+func (m *ResumeJobReq) XXX_Size() int {
+	return xxx_messageInfo_ResumeJobReq.Size(m)
+}
+func (m *ResumeJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeJobReq proto.InternalMessageInfo
+
+func (m *ResumeJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ResumeJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeJobRes) Reset()         { *m = ResumeJobRes{} }
+func (m *ResumeJobRes) String() string { return proto.CompactTextString(m) }
+func (*ResumeJobRes) ProtoMessage()    {}
+func (*ResumeJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{58}
+}
+
+func (m *ResumeJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeJobRes.Unmarshal(m, b)
+}
+func (m *ResumeJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeJobRes.Marshal(b, m, deterministic)
+}
+func (m *ResumeJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeJobRes.Merge(m, src)
+}
+func (m *ResumeJobRes) XXX_Size() int {
+	return xxx_messageInfo_ResumeJobRes.Size(m)
+}
+func (m *ResumeJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeJobRes proto.InternalMessageInfo
+
+func (m *ResumeJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type CancelJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelJobReq) Reset()         { *m = CancelJobReq{} }
+func (m *CancelJobReq) String() string { return proto.CompactTextString(m) }
+func (*CancelJobReq) ProtoMessage()    {}
+func (*CancelJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{59}
+}
+
+func (m *CancelJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelJobReq.Unmarshal(m, b)
+}
+func (m *CancelJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelJobReq.Marshal(b, m, deterministic)
+}
+func (m *CancelJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelJobReq.Merge(m, src)
+}
+func (m *CancelJobReq) XXX_Size() int {
+	return xxx_messageInfo_CancelJobReq.Size(m)
+}
+func (m *CancelJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelJobReq proto.InternalMessageInfo
+
+func (m *CancelJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelJobRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelJobRes) Reset()         { *m = CancelJobRes{} }
+func (m *CancelJobRes) String() string { return proto.CompactTextString(m) }
+func (*CancelJobRes) ProtoMessage()    {}
+func (*CancelJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{60}
+}
+
+func (m *CancelJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelJobRes.Unmarshal(m, b)
+}
+func (m *CancelJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelJobRes.Marshal(b, m, deterministic)
+}
+func (m *CancelJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelJobRes.Merge(m, src)
+}
+func (m *CancelJobRes) XXX_Size() int {
+	return xxx_messageInfo_CancelJobRes.Size(m)
+}
+func (m *CancelJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelJobRes proto.InternalMessageInfo
+
+func (m *CancelJobRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type TriggerJobReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerJobReq) Reset()         { *m = TriggerJobReq{} }
+func (m *TriggerJobReq) String() string { return proto.CompactTextString(m) }
+func (*TriggerJobReq) ProtoMessage()    {}
+func (*TriggerJobReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{61}
+}
+
+func (m *TriggerJobReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerJobReq.Unmarshal(m, b)
+}
+func (m *TriggerJobReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerJobReq.Marshal(b, m, deterministic)
+}
+func (m *TriggerJobReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerJobReq.Merge(m, src)
+}
+func (m *TriggerJobReq) XXX_Size() int {
+	return xxx_messageInfo_TriggerJobReq.Size(m)
+}
+func (m *TriggerJobReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerJobReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerJobReq proto.InternalMessageInfo
+
+func (m *TriggerJobReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type TriggerJobRes struct {
+	// ID of the queued run, GetJobRun of the RunService tells its outcome
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerJobRes) Reset()         { *m = TriggerJobRes{} }
+func (m *TriggerJobRes) String() string { return proto.CompactTextString(m) }
+func (*TriggerJobRes) ProtoMessage()    {}
+func (*TriggerJobRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{62}
+}
+
+func (m *TriggerJobRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerJobRes.Unmarshal(m, b)
+}
+func (m *TriggerJobRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerJobRes.Marshal(b, m, deterministic)
+}
+func (m *TriggerJobRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerJobRes.Merge(m, src)
+}
+func (m *TriggerJobRes) XXX_Size() int {
+	return xxx_messageInfo_TriggerJobRes.Size(m)
+}
+func (m *TriggerJobRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerJobRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerJobRes proto.InternalMessageInfo
+
+func (m *TriggerJobRes) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type WatchJobsReq struct {
+	// Token of the last event a previous watch received, the stream continues right after it
+	ResumeToken          string   `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchJobsReq) Reset()         { *m = WatchJobsReq{} }
+func (m *WatchJobsReq) String() string { return proto.CompactTextString(m) }
+func (*WatchJobsReq) ProtoMessage()    {}
+func (*WatchJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{63}
+}
+
+func (m *WatchJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchJobsReq.Unmarshal(m, b)
+}
+func (m *WatchJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchJobsReq.Marshal(b, m, deterministic)
+}
+func (m *WatchJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchJobsReq.Merge(m, src)
+}
+func (m *WatchJobsReq) XXX_Size() int {
+	return xxx_messageInfo_WatchJobsReq.Size(m)
+}
+func (m *WatchJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchJobsReq proto.InternalMessageInfo
+
+func (m *WatchJobsReq) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type WatchJobsRes struct {
+	Type JobEventType `protobuf:"varint,1,opt,name=type,proto3,enum=model.JobEventType" json:"type,omitempty"`
+	// The job after the change, only the id is set for deletions
+	Job *Job `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	// Pass this token to WatchJobs to continue after this event
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchJobsRes) Reset()         { *m = WatchJobsRes{} }
+func (m *WatchJobsRes) String() string { return proto.CompactTextString(m) }
+func (*WatchJobsRes) ProtoMessage()    {}
+func (*WatchJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{64}
+}
+
+func (m *WatchJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchJobsRes.Unmarshal(m, b)
+}
+func (m *WatchJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchJobsRes.Marshal(b, m, deterministic)
+}
+func (m *WatchJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchJobsRes.Merge(m, src)
+}
+func (m *WatchJobsRes) XXX_Size() int {
+	return xxx_messageInfo_WatchJobsRes.Size(m)
+}
+func (m *WatchJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchJobsRes proto.InternalMessageInfo
+
+func (m *WatchJobsRes) GetType() JobEventType {
+	if m != nil {
+		return m.Type
+	}
+	return JobEventType_JOB_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *WatchJobsRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *WatchJobsRes) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type GetJobGraphReq struct {
+	JobId                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobGraphReq) Reset()         { *m = GetJobGraphReq{} }
+func (m *GetJobGraphReq) String() string { return proto.CompactTextString(m) }
+func (*GetJobGraphReq) ProtoMessage()    {}
+func (*GetJobGraphReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{65}
+}
+
+func (m *GetJobGraphReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobGraphReq.Unmarshal(m, b)
+}
+func (m *GetJobGraphReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobGraphReq.Marshal(b, m, deterministic)
+}
+func (m *GetJobGraphReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobGraphReq.Merge(m, src)
+}
+func (m *GetJobGraphReq) XXX_Size() int {
+	return xxx_messageInfo_GetJobGraphReq.Size(m)
+}
+func (m *GetJobGraphReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobGraphReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobGraphReq proto.InternalMessageInfo
+
+func (m *GetJobGraphReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+// JobGraphNode is a job in the dependency graph of GetJobGraph. Jobs several others depend on appear once for each
+// of them. Only job_id is set for jobs the caller can't read, like purged ones.
+type JobGraphNode struct {
+	JobId  string    `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Name   string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status JobStatus `protobuf:"varint,3,opt,name=status,proto3,enum=model.JobStatus" json:"status,omitempty"`
+	// The nodes of the jobs this job depends on, only set on the root and on upstream nodes
+	DependsOn []*JobGraphNode `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// The nodes of the jobs depending on this job, only set on the root and on downstream nodes
+	Dependents []*JobGraphNode `protobuf:"bytes,5,rep,name=dependents,proto3" json:"dependents,omitempty"`
+	// Set for jobs that were deleted, jobs depending on them don't run anymore
+	Deleted              bool     `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobGraphNode) Reset()         { *m = JobGraphNode{} }
+func (m *JobGraphNode) String() string { return proto.CompactTextString(m) }
+func (*JobGraphNode) ProtoMessage()    {}
+func (*JobGraphNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{66}
+}
+
+func (m *JobGraphNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobGraphNode.Unmarshal(m, b)
+}
+func (m *JobGraphNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobGraphNode.Marshal(b, m, deterministic)
+}
+func (m *JobGraphNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobGraphNode.Merge(m, src)
+}
+func (m *JobGraphNode) XXX_Size() int {
+	return xxx_messageInfo_JobGraphNode.Size(m)
+}
+func (m *JobGraphNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobGraphNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobGraphNode proto.InternalMessageInfo
+
+func (m *JobGraphNode) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobGraphNode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobGraphNode) GetStatus() JobStatus {
+	if m != nil {
+		return m.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (m *JobGraphNode) GetDependsOn() []*JobGraphNode {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+func (m *JobGraphNode) GetDependents() []*JobGraphNode {
+	if m != nil {
+		return m.Dependents
+	}
+	return nil
+}
+
+func (m *JobGraphNode) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type GetJobGraphRes struct {
+	// The requested job with the jobs it depends on and the jobs depending on it
+	Root                 *JobGraphNode `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetJobGraphRes) Reset()         { *m = GetJobGraphRes{} }
+func (m *GetJobGraphRes) String() string { return proto.CompactTextString(m) }
+func (*GetJobGraphRes) ProtoMessage()    {}
+func (*GetJobGraphRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{67}
+}
+
+func (m *GetJobGraphRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobGraphRes.Unmarshal(m, b)
+}
+func (m *GetJobGraphRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobGraphRes.Marshal(b, m, deterministic)
+}
+func (m *GetJobGraphRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobGraphRes.Merge(m, src)
+}
+func (m *GetJobGraphRes) XXX_Size() int {
+	return xxx_messageInfo_GetJobGraphRes.Size(m)
+}
+func (m *GetJobGraphRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobGraphRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobGraphRes proto.InternalMessageInfo
+
+func (m *GetJobGraphRes) GetRoot() *JobGraphNode {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+type ImportJobsReq struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportJobsReq) Reset()         { *m = ImportJobsReq{} }
+func (m *ImportJobsReq) String() string { return proto.CompactTextString(m) }
+func (*ImportJobsReq) ProtoMessage()    {}
+func (*ImportJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{68}
+}
+
+func (m *ImportJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportJobsReq.Unmarshal(m, b)
+}
+func (m *ImportJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportJobsReq.Marshal(b, m, deterministic)
+}
+func (m *ImportJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportJobsReq.Merge(m, src)
+}
+func (m *ImportJobsReq) XXX_Size() int {
+	return xxx_messageInfo_ImportJobsReq.Size(m)
+}
+func (m *ImportJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportJobsReq proto.InternalMessageInfo
+
+func (m *ImportJobsReq) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+// ImportJobError tells why a single job of an import wasn't created
+type ImportJobError struct {
+	// Position of the job in the request stream, starting at 0
+	Index                int32    `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportJobError) Reset()         { *m = ImportJobError{} }
+func (m *ImportJobError) String() string { return proto.CompactTextString(m) }
+func (*ImportJobError) ProtoMessage()    {}
+func (*ImportJobError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{69}
+}
+
+func (m *ImportJobError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportJobError.Unmarshal(m, b)
+}
+func (m *ImportJobError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportJobError.Marshal(b, m, deterministic)
+}
+func (m *ImportJobError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportJobError.Merge(m, src)
+}
+func (m *ImportJobError) XXX_Size() int {
+	return xxx_messageInfo_ImportJobError.Size(m)
+}
+func (m *ImportJobError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportJobError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportJobError proto.InternalMessageInfo
+
+func (m *ImportJobError) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportJobError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ImportJobsRes struct {
+	ImportedCount int32 `protobuf:"varint,1,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"`
+	// One entry for every job that wasn't created, ordered by index
+	Errors               []*ImportJobError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ImportJobsRes) Reset()         { *m = ImportJobsRes{} }
+func (m *ImportJobsRes) String() string { return proto.CompactTextString(m) }
+func (*ImportJobsRes) ProtoMessage()    {}
+func (*ImportJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{70}
+}
+
+func (m *ImportJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportJobsRes.Unmarshal(m, b)
+}
+func (m *ImportJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportJobsRes.Marshal(b, m, deterministic)
+}
+func (m *ImportJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportJobsRes.Merge(m, src)
+}
+func (m *ImportJobsRes) XXX_Size() int {
+	return xxx_messageInfo_ImportJobsRes.Size(m)
+}
+func (m *ImportJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportJobsRes proto.InternalMessageInfo
+
+func (m *ImportJobsRes) GetImportedCount() int32 {
+	if m != nil {
+		return m.ImportedCount
+	}
+	return 0
+}
+
+func (m *ImportJobsRes) GetErrors() []*ImportJobError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ExportJobsReq struct {
+	Format ExportFormat `protobuf:"varint,1,opt,name=format,proto3,enum=model.ExportFormat" json:"format,omitempty"`
+	// Also export deleted jobs
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Only export the jobs whose labels match this selector, like with ListJobs
+	LabelSelector        string   `protobuf:"bytes,3,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportJobsReq) Reset()         { *m = ExportJobsReq{} }
+func (m *ExportJobsReq) String() string { return proto.CompactTextString(m) }
+func (*ExportJobsReq) ProtoMessage()    {}
+func (*ExportJobsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{71}
+}
+
+func (m *ExportJobsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportJobsReq.Unmarshal(m, b)
+}
+func (m *ExportJobsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportJobsReq.Marshal(b, m, deterministic)
+}
+func (m *ExportJobsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportJobsReq.Merge(m, src)
+}
+func (m *ExportJobsReq) XXX_Size() int {
+	return xxx_messageInfo_ExportJobsReq.Size(m)
+}
+func (m *ExportJobsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportJobsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportJobsReq proto.InternalMessageInfo
+
+func (m *ExportJobsReq) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (m *ExportJobsReq) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *ExportJobsReq) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+type ExportJobsRes struct {
+	// The next part of the export, concatenated in order the chunks make up the file. Every chunk ends with a complete
+	// row or line.
+	Data                 string   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportJobsRes) Reset()         { *m = ExportJobsRes{} }
+func (m *ExportJobsRes) String() string { return proto.CompactTextString(m) }
+func (*ExportJobsRes) ProtoMessage()    {}
+func (*ExportJobsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{72}
+}
+
+func (m *ExportJobsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportJobsRes.Unmarshal(m, b)
+}
+func (m *ExportJobsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportJobsRes.Marshal(b, m, deterministic)
+}
+func (m *ExportJobsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportJobsRes.Merge(m, src)
+}
+func (m *ExportJobsRes) XXX_Size() int {
+	return xxx_messageInfo_ExportJobsRes.Size(m)
+}
+func (m *ExportJobsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportJobsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportJobsRes proto.InternalMessageInfo
+
+func (m *ExportJobsRes) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+// JobTypeParam is a param the jobs of a type take
+type JobTypeParam struct {
+	Name string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type ParamType `protobuf:"varint,2,opt,name=type,proto3,enum=model.ParamType" json:"type,omitempty"`
+	// The type of the values of object and list params, they can have any type when it's unspecified
+	Values               ParamType `protobuf:"varint,3,opt,name=values,proto3,enum=model.ParamType" json:"values,omitempty"`
+	Required             bool      `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Description          string    `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *JobTypeParam) Reset()         { *m = JobTypeParam{} }
+func (m *JobTypeParam) String() string { return proto.CompactTextString(m) }
+func (*JobTypeParam) ProtoMessage()    {}
+func (*JobTypeParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{73}
+}
+
+func (m *JobTypeParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobTypeParam.Unmarshal(m, b)
+}
+func (m *JobTypeParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobTypeParam.Marshal(b, m, deterministic)
+}
+func (m *JobTypeParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobTypeParam.Merge(m, src)
+}
+func (m *JobTypeParam) XXX_Size() int {
+	return xxx_messageInfo_JobTypeParam.Size(m)
+}
+func (m *JobTypeParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobTypeParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobTypeParam proto.InternalMessageInfo
+
+func (m *JobTypeParam) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobTypeParam) GetType() ParamType {
+	if m != nil {
+		return m.Type
+	}
+	return ParamType_PARAM_TYPE_UNSPECIFIED
+}
+
+func (m *JobTypeParam) GetValues() ParamType {
+	if m != nil {
+		return m.Values
+	}
+	return ParamType_PARAM_TYPE_UNSPECIFIED
+}
+
+func (m *JobTypeParam) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *JobTypeParam) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// JobType is a handler jobs can name, with the params it takes
+type JobType struct {
+	// The name jobs set as their handler
+	Name        string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string          `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Params      []*JobTypeParam `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty"`
+	// Jobs of open types can also have params that aren't listed, with values of any type
+	Open                 bool     `protobuf:"varint,4,opt,name=open,proto3" json:"open,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobType) Reset()         { *m = JobType{} }
+func (m *JobType) String() string { return proto.CompactTextString(m) }
+func (*JobType) ProtoMessage()    {}
+func (*JobType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{74}
+}
+
+func (m *JobType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobType.Unmarshal(m, b)
+}
+func (m *JobType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobType.Marshal(b, m, deterministic)
+}
+func (m *JobType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobType.Merge(m, src)
+}
+func (m *JobType) XXX_Size() int {
+	return xxx_messageInfo_JobType.Size(m)
+}
+func (m *JobType) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobType.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobType proto.InternalMessageInfo
+
+func (m *JobType) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobType) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *JobType) GetParams() []*JobTypeParam {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *JobType) GetOpen() bool {
+	if m != nil {
+		return m.Open
+	}
+	return false
+}
+
+type ListJobTypesReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobTypesReq) Reset()         { *m = ListJobTypesReq{} }
+func (m *ListJobTypesReq) String() string { return proto.CompactTextString(m) }
+func (*ListJobTypesReq) ProtoMessage()    {}
+func (*ListJobTypesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{75}
+}
+
+func (m *ListJobTypesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobTypesReq.Unmarshal(m, b)
+}
+func (m *ListJobTypesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobTypesReq.Marshal(b, m, deterministic)
+}
+func (m *ListJobTypesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobTypesReq.Merge(m, src)
+}
+func (m *ListJobTypesReq) XXX_Size() int {
+	return xxx_messageInfo_ListJobTypesReq.Size(m)
+}
+func (m *ListJobTypesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobTypesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobTypesReq proto.InternalMessageInfo
+
+type ListJobTypesRes struct {
+	// Ordered by name
+	JobTypes             []*JobType `protobuf:"bytes,1,rep,name=job_types,json=jobTypes,proto3" json:"job_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListJobTypesRes) Reset()         { *m = ListJobTypesRes{} }
+func (m *ListJobTypesRes) String() string { return proto.CompactTextString(m) }
+func (*ListJobTypesRes) ProtoMessage()    {}
+func (*ListJobTypesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{76}
+}
+
+func (m *ListJobTypesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobTypesRes.Unmarshal(m, b)
+}
+func (m *ListJobTypesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobTypesRes.Marshal(b, m, deterministic)
+}
+func (m *ListJobTypesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobTypesRes.Merge(m, src)
+}
+func (m *ListJobTypesRes) XXX_Size() int {
+	return xxx_messageInfo_ListJobTypesRes.Size(m)
+}
+func (m *ListJobTypesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobTypesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobTypesRes proto.InternalMessageInfo
+
+func (m *ListJobTypesRes) GetJobTypes() []*JobType {
+	if m != nil {
+		return m.JobTypes
+	}
+	return nil
+}
+
+type GetQuotaReq struct {
+	// Owner whose usage is counted, the caller when empty. Non-admins may only ask for themselves and their teams.
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetQuotaReq) Reset()         { *m = GetQuotaReq{} }
+func (m *GetQuotaReq) String() string { return proto.CompactTextString(m) }
+func (*GetQuotaReq) ProtoMessage()    {}
+func (*GetQuotaReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{77}
+}
+
+func (m *GetQuotaReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuotaReq.Unmarshal(m, b)
+}
+func (m *GetQuotaReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuotaReq.Marshal(b, m, deterministic)
+}
+func (m *GetQuotaReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuotaReq.Merge(m, src)
+}
+func (m *GetQuotaReq) XXX_Size() int {
+	return xxx_messageInfo_GetQuotaReq.Size(m)
+}
+func (m *GetQuotaReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuotaReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuotaReq proto.InternalMessageInfo
+
+func (m *GetQuotaReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+// QuotaUsage is how much of a single quota is used
+type QuotaUsage struct {
+	// The most the quota allows, 0 doesn't limit it
+	Limit                int64    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Used                 int64    `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuotaUsage) Reset()         { *m = QuotaUsage{} }
+func (m *QuotaUsage) String() string { return proto.CompactTextString(m) }
+func (*QuotaUsage) ProtoMessage()    {}
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{78}
+}
+
+func (m *QuotaUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuotaUsage.Unmarshal(m, b)
+}
+func (m *QuotaUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuotaUsage.Marshal(b, m, deterministic)
+}
+func (m *QuotaUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuotaUsage.Merge(m, src)
+}
+func (m *QuotaUsage) XXX_Size() int {
+	return xxx_messageInfo_QuotaUsage.Size(m)
+}
+func (m *QuotaUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuotaUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuotaUsage proto.InternalMessageInfo
+
+func (m *QuotaUsage) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *QuotaUsage) GetUsed() int64 {
+	if m != nil {
+		return m.Used
+	}
+	return 0
+}
+
+type Quota struct {
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Jobs of the owner that aren't deleted, archived ones count
+	JobsPerOwner *QuotaUsage `protobuf:"bytes,2,opt,name=jobs_per_owner,json=jobsPerOwner,proto3" json:"jobs_per_owner,omitempty"`
+	// Jobs of the tenant of the caller, of every owner
+	JobsPerTenant *QuotaUsage `protobuf:"bytes,3,opt,name=jobs_per_tenant,json=jobsPerTenant,proto3" json:"jobs_per_tenant,omitempty"`
+	// Queued and running runs of the jobs of the owner
+	ConcurrentRuns *QuotaUsage `protobuf:"bytes,4,opt,name=concurrent_runs,json=concurrentRuns,proto3" json:"concurrent_runs,omitempty"`
+	// Runs of the jobs of the owner queued since day_start_time
+	RunsPerDay *QuotaUsage `protobuf:"bytes,5,opt,name=runs_per_day,json=runsPerDay,proto3" json:"runs_per_day,omitempty"`
+	// Midnight UTC of the current day, runs_per_day starts over a day later
+	DayStartTime         *timestamp.Timestamp `protobuf:"bytes,6,opt,name=day_start_time,json=dayStartTime,proto3" json:"day_start_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Quota) Reset()         { *m = Quota{} }
+func (m *Quota) String() string { return proto.CompactTextString(m) }
+func (*Quota) ProtoMessage()    {}
+func (*Quota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{79}
+}
+
+func (m *Quota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quota.Unmarshal(m, b)
+}
+func (m *Quota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quota.Marshal(b, m, deterministic)
+}
+func (m *Quota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quota.Merge(m, src)
+}
+func (m *Quota) XXX_Size() int {
+	return xxx_messageInfo_Quota.Size(m)
+}
+func (m *Quota) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Quota proto.InternalMessageInfo
+
+func (m *Quota) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Quota) GetJobsPerOwner() *QuotaUsage {
+	if m != nil {
+		return m.JobsPerOwner
+	}
+	return nil
+}
+
+func (m *Quota) GetJobsPerTenant() *QuotaUsage {
+	if m != nil {
+		return m.JobsPerTenant
+	}
+	return nil
+}
+
+func (m *Quota) GetConcurrentRuns() *QuotaUsage {
+	if m != nil {
+		return m.ConcurrentRuns
+	}
+	return nil
+}
+
+func (m *Quota) GetRunsPerDay() *QuotaUsage {
+	if m != nil {
+		return m.RunsPerDay
+	}
+	return nil
+}
+
+func (m *Quota) GetDayStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DayStartTime
+	}
+	return nil
+}
+
+type GetQuotaRes struct {
+	Quota                *Quota   `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetQuotaRes) Reset()         { *m = GetQuotaRes{} }
+func (m *GetQuotaRes) String() string { return proto.CompactTextString(m) }
+func (*GetQuotaRes) ProtoMessage()    {}
+func (*GetQuotaRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{80}
+}
+
+func (m *GetQuotaRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuotaRes.Unmarshal(m, b)
+}
+func (m *GetQuotaRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuotaRes.Marshal(b, m, deterministic)
+}
+func (m *GetQuotaRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuotaRes.Merge(m, src)
+}
+func (m *GetQuotaRes) XXX_Size() int {
+	return xxx_messageInfo_GetQuotaRes.Size(m)
+}
+func (m *GetQuotaRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuotaRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuotaRes proto.InternalMessageInfo
+
+func (m *GetQuotaRes) GetQuota() *Quota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("model.JobStatus", JobStatus_name, JobStatus_value)
+	proto.RegisterEnum("model.JobPriority", JobPriority_name, JobPriority_value)
+	proto.RegisterEnum("model.ConcurrencyPolicy", ConcurrencyPolicy_name, ConcurrencyPolicy_value)
+	proto.RegisterEnum("model.CatchUpPolicy", CatchUpPolicy_name, CatchUpPolicy_value)
+	proto.RegisterEnum("model.BlackoutAction", BlackoutAction_name, BlackoutAction_value)
+	proto.RegisterEnum("model.ChangeStatus", ChangeStatus_name, ChangeStatus_value)
+	proto.RegisterEnum("model.JobEventType", JobEventType_name, JobEventType_value)
+	proto.RegisterEnum("model.ExportFormat", ExportFormat_name, ExportFormat_value)
+	proto.RegisterEnum("model.ParamType", ParamType_name, ParamType_value)
+	proto.RegisterType((*Job)(nil), "model.Job")
+	proto.RegisterMapType((map[string]string)(nil), "model.Job.LabelsEntry")
+	proto.RegisterType((*JobBlackout)(nil), "model.JobBlackout")
+	proto.RegisterType((*TemplateLineage)(nil), "model.TemplateLineage")
+	proto.RegisterMapType((map[string]string)(nil), "model.TemplateLineage.ParametersEntry")
+	proto.RegisterType((*JobPromotion)(nil), "model.JobPromotion")
+	proto.RegisterType((*Schedule)(nil), "model.Schedule")
+	proto.RegisterType((*RetryPolicy)(nil), "model.RetryPolicy")
+	proto.RegisterType((*NotificationSettings)(nil), "model.NotificationSettings")
+	proto.RegisterType((*CreateJobReq)(nil), "model.CreateJobReq")
+	proto.RegisterType((*CreateJobRes)(nil), "model.CreateJobRes")
+	proto.RegisterType((*UpdateJobReq)(nil), "model.UpdateJobReq")
+	proto.RegisterType((*UpdateJobRes)(nil), "model.UpdateJobRes")
+	proto.RegisterType((*JobChange)(nil), "model.JobChange")
+	proto.RegisterType((*ReadChangeReq)(nil), "model.ReadChangeReq")
+	proto.RegisterType((*ReadChangeRes)(nil), "model.ReadChangeRes")
+	proto.RegisterType((*ListChangesReq)(nil), "model.ListChangesReq")
+	proto.RegisterType((*ListChangesRes)(nil), "model.ListChangesRes")
+	proto.RegisterType((*ApproveChangeReq)(nil), "model.ApproveChangeReq")
+	proto.RegisterType((*ApproveChangeRes)(nil), "model.ApproveChangeRes")
+	proto.RegisterType((*RejectChangeReq)(nil), "model.RejectChangeReq")
+	proto.RegisterType((*RejectChangeRes)(nil), "model.RejectChangeRes")
+	proto.RegisterType((*CloneJobReq)(nil), "model.CloneJobReq")
+	proto.RegisterType((*CloneJobRes)(nil), "model.CloneJobRes")
+	proto.RegisterType((*JobRevision)(nil), "model.JobRevision")
+	proto.RegisterType((*ListJobRevisionsReq)(nil), "model.ListJobRevisionsReq")
+	proto.RegisterType((*ListJobRevisionsRes)(nil), "model.ListJobRevisionsRes")
+	proto.RegisterType((*RollbackJobReq)(nil), "model.RollbackJobReq")
+	proto.RegisterType((*RollbackJobRes)(nil), "model.RollbackJobRes")
+	proto.RegisterType((*PromoteJobReq)(nil), "model.PromoteJobReq")
+	proto.RegisterType((*PromoteJobRes)(nil), "model.PromoteJobRes")
+	proto.RegisterType((*ReadJobReq)(nil), "model.ReadJobReq")
+	proto.RegisterType((*ReadJobRes)(nil), "model.ReadJobRes")
+	proto.RegisterType((*BatchGetJobsReq)(nil), "model.BatchGetJobsReq")
+	proto.RegisterType((*BatchGetJobsRes)(nil), "model.BatchGetJobsRes")
+	proto.RegisterType((*CountJobsReq)(nil), "model.CountJobsReq")
+	proto.RegisterType((*CountJobsRes)(nil), "model.CountJobsRes")
+	proto.RegisterType((*JobExistsReq)(nil), "model.JobExistsReq")
+	proto.RegisterType((*JobExistsRes)(nil), "model.JobExistsRes")
+	proto.RegisterType((*DeleteJobReq)(nil), "model.DeleteJobReq")
+	proto.RegisterType((*DeleteJobRes)(nil), "model.DeleteJobRes")
+	proto.RegisterType((*DeleteJobsReq)(nil), "model.DeleteJobsReq")
+	proto.RegisterType((*DeleteJobResult)(nil), "model.DeleteJobResult")
+	proto.RegisterType((*DeleteJobsRes)(nil), "model.DeleteJobsRes")
+	proto.RegisterType((*ListJobsReq)(nil), "model.ListJobsReq")
+	proto.RegisterType((*ListJobsRes)(nil), "model.ListJobsRes")
+	proto.RegisterType((*SearchJobsReq)(nil), "model.SearchJobsReq")
+	proto.RegisterType((*SearchHighlight)(nil), "model.SearchHighlight")
+	proto.RegisterType((*SearchJobsRes)(nil), "model.SearchJobsRes")
+	proto.RegisterType((*RestoreJobReq)(nil), "model.RestoreJobReq")
+	proto.RegisterType((*RestoreJobRes)(nil), "model.RestoreJobRes")
+	proto.RegisterType((*ArchiveJobReq)(nil), "model.ArchiveJobReq")
+	proto.RegisterType((*ArchiveJobRes)(nil), "model.ArchiveJobRes")
+	proto.RegisterType((*UnarchiveJobReq)(nil), "model.UnarchiveJobReq")
+	proto.RegisterType((*UnarchiveJobRes)(nil), "model.UnarchiveJobRes")
+	proto.RegisterType((*ArchiveJobsReq)(nil), "model.ArchiveJobsReq")
+	proto.RegisterType((*ArchiveJobsRes)(nil), "model.ArchiveJobsRes")
+	proto.RegisterType((*PauseJobReq)(nil), "model.PauseJobReq")
+	proto.RegisterType((*PauseJobRes)(nil), "model.PauseJobRes")
+	proto.RegisterType((*ResumeJobReq)(nil), "model.ResumeJobReq")
+	proto.RegisterType((*ResumeJobRes)(nil), "model.ResumeJobRes")
+	proto.RegisterType((*CancelJobReq)(nil), "model.CancelJobReq")
+	proto.RegisterType((*CancelJobRes)(nil), "model.CancelJobRes")
+	proto.RegisterType((*TriggerJobReq)(nil), "model.TriggerJobReq")
+	proto.RegisterType((*TriggerJobRes)(nil), "model.TriggerJobRes")
+	proto.RegisterType((*WatchJobsReq)(nil), "model.WatchJobsReq")
+	proto.RegisterType((*WatchJobsRes)(nil), "model.WatchJobsRes")
+	proto.RegisterType((*GetJobGraphReq)(nil), "model.GetJobGraphReq")
+	proto.RegisterType((*JobGraphNode)(nil), "model.JobGraphNode")
+	proto.RegisterType((*GetJobGraphRes)(nil), "model.GetJobGraphRes")
+	proto.RegisterType((*ImportJobsReq)(nil), "model.ImportJobsReq")
+	proto.RegisterType((*ImportJobError)(nil), "model.ImportJobError")
+	proto.RegisterType((*ImportJobsRes)(nil), "model.ImportJobsRes")
+	proto.RegisterType((*ExportJobsReq)(nil), "model.ExportJobsReq")
+	proto.RegisterType((*ExportJobsRes)(nil), "model.ExportJobsRes")
+	proto.RegisterType((*JobTypeParam)(nil), "model.JobTypeParam")
+	proto.RegisterType((*JobType)(nil), "model.JobType")
+	proto.RegisterType((*ListJobTypesReq)(nil), "model.ListJobTypesReq")
+	proto.RegisterType((*ListJobTypesRes)(nil), "model.ListJobTypesRes")
+	proto.RegisterType((*GetQuotaReq)(nil), "model.GetQuotaReq")
+	proto.RegisterType((*QuotaUsage)(nil), "model.QuotaUsage")
+	proto.RegisterType((*Quota)(nil), "model.Quota")
+	proto.RegisterType((*GetQuotaRes)(nil), "model.GetQuotaRes")
+}
+
+func init() { proto.RegisterFile("job.proto", fileDescriptor_f32c477d91a04ead) }
+
+var fileDescriptor_f32c477d91a04ead = []byte{
+	// 4147 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0x5d, 0x6f, 0x1b, 0x49,
+	0x72, 0x19, 0x52, 0x92, 0xc9, 0xe2, 0xa7, 0xda, 0x92, 0x4c, 0x8f, 0xbd, 0xfe, 0xe8, 0xbd, 0xec,
+	0x29, 0xb2, 0x57, 0xf6, 0x7a, 0x71, 0x77, 0x59, 0xef, 0xde, 0xe5, 0x28, 0x8a, 0xb2, 0xe9, 0xa5,
+	0x45, 0xde, 0x90, 0xf2, 0xde, 0x1e, 0x92, 0x23, 0x86, 0x64, 0x4b, 0x1a, 0x9b, 0x9c, 0xa1, 0xa7,
+	0x87, 0x5a, 0x71, 0x83, 0x0b, 0x0e, 0x07, 0x04, 0x87, 0x04, 0x87, 0x20, 0x40, 0x82, 0x3c, 0xe5,
+	0x2d, 0x7f, 0x20, 0x79, 0x09, 0x92, 0xf7, 0xfc, 0x83, 0x7b, 0xcc, 0x6b, 0x70, 0xc8, 0x0f, 0xc8,
+	0x0f, 0x08, 0xfa, 0x93, 0x33, 0xc3, 0xa1, 0x29, 0x27, 0x41, 0xde, 0xa6, 0xab, 0xaa, 0xab, 0xab,
+	0xab, 0xab, 0xaa, 0xab, 0xab, 0x06, 0xb2, 0xaf, 0xbd, 0xfe, 0xfe, 0xc4, 0xf7, 0x02, 0x0f, 0xad,
+	0x8f, 0xbd, 0x21, 0x19, 0x99, 0x39, 0x7b, 0x3a, 0x74, 0x02, 0x01, 0x33, 0x6f, 0x9f, 0x79, 0xde,
+	0xd9, 0x88, 0x3c, 0xb2, 0x27, 0xce, 0x23, 0xdb, 0x75, 0xbd, 0xc0, 0x0e, 0x1c, 0xcf, 0xa5, 0x12,
+	0x7b, 0x47, 0x62, 0xf9, 0xa8, 0x3f, 0x3d, 0x7d, 0x34, 0x9c, 0xfa, 0x9c, 0x40, 0xe2, 0xef, 0xc5,
+	0xf1, 0xa7, 0x0e, 0x19, 0x0d, 0x7b, 0x63, 0x9b, 0xbe, 0x89, 0xf1, 0xd7, 0x14, 0x34, 0xf0, 0xa7,
+	0x03, 0xb5, 0xfa, 0xdd, 0x38, 0x36, 0x70, 0xc6, 0x84, 0x06, 0xf6, 0x78, 0x22, 0x08, 0xf0, 0x7f,
+	0x65, 0x21, 0xfd, 0xc2, 0xeb, 0xa3, 0x22, 0xa4, 0x9c, 0x61, 0xc5, 0xb8, 0x67, 0xec, 0x66, 0xad,
+	0x94, 0x33, 0x44, 0x08, 0xd6, 0x5c, 0x7b, 0x4c, 0x2a, 0x29, 0x0e, 0xe1, 0xdf, 0xe8, 0x1e, 0xe4,
+	0x86, 0x84, 0x0e, 0x7c, 0x67, 0xc2, 0x24, 0xac, 0xa4, 0x39, 0x2a, 0x0c, 0x42, 0x5b, 0xb0, 0xee,
+	0x7d, 0xe3, 0x12, 0xbf, 0xb2, 0xc6, 0x71, 0x62, 0x80, 0x3e, 0x03, 0x18, 0xf8, 0xc4, 0x0e, 0xc8,
+	0xb0, 0x67, 0x07, 0x95, 0xf5, 0x7b, 0xc6, 0x6e, 0xee, 0x89, 0xb9, 0x2f, 0x24, 0xdb, 0x57, 0x92,
+	0xed, 0x77, 0x95, 0x64, 0x56, 0x56, 0x52, 0x57, 0x03, 0x36, 0x75, 0x3a, 0x19, 0xaa, 0xa9, 0x1b,
+	0xab, 0xa7, 0x4a, 0xea, 0x6a, 0x80, 0x1e, 0x40, 0x86, 0x0e, 0xce, 0xc9, 0x70, 0x3a, 0x22, 0x95,
+	0x6b, 0x7c, 0x62, 0x69, 0x9f, 0x9f, 0xcf, 0x7e, 0x47, 0x82, 0x2d, 0x4d, 0x80, 0x7e, 0x04, 0x05,
+	0x97, 0x5c, 0x06, 0x3d, 0x7f, 0xea, 0xf6, 0x98, 0x8a, 0x2a, 0x99, 0x95, 0x4b, 0xe5, 0xd8, 0x04,
+	0x6b, 0xea, 0x32, 0x08, 0xaa, 0xc0, 0xb5, 0x73, 0xdb, 0x1d, 0x8e, 0x88, 0x5f, 0xc9, 0xf2, 0xad,
+	0xab, 0x21, 0xc3, 0x0c, 0xbc, 0xf1, 0xd8, 0x76, 0x87, 0x15, 0x10, 0x18, 0x39, 0x64, 0x7b, 0x1b,
+	0x92, 0x11, 0x91, 0x7b, 0xcb, 0xad, 0xde, 0x9b, 0xa4, 0xae, 0x06, 0x68, 0x17, 0x36, 0x68, 0x60,
+	0x07, 0x53, 0x5a, 0xc9, 0xdf, 0x33, 0x76, 0x8b, 0x4f, 0xca, 0x72, 0x67, 0x2f, 0xbc, 0x7e, 0x87,
+	0xc3, 0x2d, 0x89, 0x47, 0xdf, 0x83, 0xbc, 0x4f, 0x02, 0x7f, 0xd6, 0x9b, 0x78, 0x23, 0x67, 0x30,
+	0xab, 0x14, 0xf8, 0x32, 0x48, 0xd2, 0x5b, 0x0c, 0xd5, 0xe6, 0x18, 0x2b, 0xe7, 0xcf, 0x07, 0xe8,
+	0x53, 0xb8, 0xc6, 0xd4, 0xe0, 0x4d, 0x83, 0x4a, 0x91, 0xcf, 0xb8, 0xb9, 0x20, 0xd8, 0xa1, 0xb4,
+	0x54, 0x4b, 0x51, 0xa2, 0x7d, 0xd8, 0x18, 0xd9, 0x7d, 0x32, 0xa2, 0x95, 0xd2, 0xbd, 0xf4, 0x6e,
+	0xee, 0xc9, 0xce, 0x5c, 0xaa, 0xfd, 0x26, 0x47, 0xd4, 0xdd, 0xc0, 0x9f, 0x59, 0x92, 0x0a, 0x7d,
+	0xc0, 0x14, 0x30, 0x21, 0xee, 0x90, 0xf6, 0x3c, 0xb7, 0x52, 0xbe, 0x97, 0xde, 0xcd, 0x5a, 0x59,
+	0x09, 0x69, 0xb9, 0x68, 0x1f, 0x32, 0x13, 0xdf, 0xf1, 0x7c, 0x27, 0x98, 0x55, 0x36, 0xf9, 0x36,
+	0xd1, 0x9c, 0x61, 0x5b, 0x62, 0x2c, 0x4d, 0x83, 0xaa, 0x50, 0x70, 0xbd, 0xc0, 0x39, 0x75, 0x06,
+	0xc2, 0xc5, 0x2a, 0x88, 0x4b, 0x7e, 0x4b, 0x4e, 0x3a, 0x0e, 0xe1, 0x3a, 0x24, 0x08, 0x1c, 0xf7,
+	0x8c, 0x5a, 0xd1, 0x19, 0xe8, 0x09, 0x64, 0x02, 0x32, 0x9e, 0x8c, 0xec, 0x80, 0x54, 0xae, 0xdf,
+	0x33, 0x42, 0x7b, 0xe8, 0x4a, 0x70, 0xd3, 0x71, 0x89, 0x7d, 0x46, 0x2c, 0x4d, 0x87, 0x3e, 0x87,
+	0x9c, 0xed, 0x0f, 0xce, 0x9d, 0x0b, 0x71, 0x8e, 0x5b, 0x2b, 0xcf, 0x11, 0x14, 0x79, 0x95, 0xa9,
+	0x2c, 0xd3, 0x1f, 0xd9, 0x83, 0x37, 0x4c, 0xd1, 0xdb, 0x91, 0xa3, 0x79, 0xe1, 0xf5, 0x0f, 0x24,
+	0xc6, 0xd2, 0x34, 0xe8, 0x19, 0xa0, 0x81, 0xe7, 0x0e, 0xa6, 0xbe, 0x4f, 0xdc, 0x81, 0x3e, 0xd4,
+	0x1d, 0xae, 0x9d, 0x8a, 0x9c, 0x59, 0x9b, 0x13, 0xc8, 0xa3, 0xdd, 0x1c, 0xc4, 0x41, 0xe8, 0x0b,
+	0x28, 0x0d, 0xec, 0x60, 0x70, 0xde, 0x9b, 0x4e, 0x14, 0x97, 0x1b, 0x9c, 0xcb, 0x96, 0xe2, 0xc2,
+	0xb0, 0x27, 0x13, 0xc9, 0xa1, 0x30, 0x08, 0x0f, 0xd1, 0x23, 0xd8, 0x98, 0xd8, 0xbe, 0x3d, 0xa6,
+	0x95, 0x0a, 0x17, 0xfa, 0xc6, 0xc2, 0x76, 0x3b, 0x3c, 0x0a, 0x59, 0x92, 0x8c, 0x85, 0x0e, 0xe2,
+	0x5e, 0x38, 0xbe, 0xe7, 0x8e, 0x89, 0x1b, 0x54, 0x6e, 0x8a, 0xd0, 0x11, 0x02, 0xa1, 0x4f, 0x20,
+	0x3b, 0xf1, 0xbd, 0xb1, 0xc7, 0x43, 0x8b, 0xc9, 0xb9, 0x5e, 0x0f, 0x1f, 0xb7, 0x44, 0x59, 0x73,
+	0x2a, 0xf3, 0x33, 0xc8, 0x85, 0xcc, 0x0a, 0x95, 0x21, 0xfd, 0x86, 0xcc, 0x64, 0x0c, 0x63, 0x9f,
+	0x2c, 0x1c, 0x5d, 0xd8, 0xa3, 0xa9, 0x8a, 0x62, 0x62, 0xf0, 0x34, 0xf5, 0x87, 0x06, 0xee, 0x41,
+	0x2e, 0xa4, 0x60, 0x74, 0x1f, 0xf2, 0x03, 0x7b, 0x44, 0xdc, 0xa1, 0xed, 0xf7, 0x9c, 0x21, 0xad,
+	0x18, 0xdc, 0x16, 0x73, 0x0a, 0xd6, 0x18, 0x52, 0xf4, 0x31, 0x6c, 0xd8, 0x03, 0x2e, 0x5c, 0x8a,
+	0xeb, 0x69, 0x5b, 0x0a, 0xa7, 0x78, 0x54, 0x39, 0xd2, 0x92, 0x44, 0xf8, 0xb7, 0x06, 0x94, 0x62,
+	0x36, 0x83, 0xee, 0x42, 0x4e, 0x59, 0x4d, 0x4f, 0x07, 0x5b, 0x50, 0xa0, 0xc6, 0x90, 0xc5, 0x8a,
+	0x0b, 0xe2, 0x53, 0xb5, 0x48, 0xda, 0x52, 0x43, 0x74, 0x04, 0xc0, 0x35, 0x49, 0x02, 0xe2, 0xd3,
+	0x4a, 0x9a, 0xbb, 0xd7, 0x47, 0xc9, 0xa6, 0xb9, 0xdf, 0xd6, 0x84, 0xc2, 0xdd, 0x42, 0x33, 0xcd,
+	0x1f, 0x42, 0x29, 0x86, 0x7e, 0x2f, 0xb5, 0xfd, 0xbb, 0x01, 0xf9, 0xf0, 0x69, 0x20, 0x0c, 0x05,
+	0xea, 0x4d, 0xfd, 0x01, 0xe9, 0xbd, 0xf6, 0xfa, 0xf3, 0x4d, 0xe5, 0x04, 0xf0, 0x85, 0xd7, 0x6f,
+	0x0c, 0xd1, 0xc7, 0x80, 0x24, 0x4d, 0xd8, 0x04, 0x04, 0xef, 0x4d, 0x81, 0xa9, 0xcf, 0x11, 0xc8,
+	0x84, 0x8c, 0x3d, 0x99, 0xf8, 0xde, 0x85, 0x3d, 0x92, 0x57, 0x8c, 0x1e, 0x33, 0x0d, 0x8a, 0xe3,
+	0x27, 0xc3, 0x5e, 0x7f, 0x26, 0x6f, 0x19, 0x50, 0xa0, 0x83, 0x19, 0x73, 0x46, 0x4d, 0x70, 0xa5,
+	0xbb, 0x46, 0x4f, 0xae, 0x06, 0xf8, 0x2d, 0x64, 0xd4, 0xd5, 0xc0, 0xee, 0xbf, 0x81, 0xef, 0xb9,
+	0x72, 0x3f, 0xfc, 0x1b, 0x7d, 0x0f, 0x32, 0x8e, 0x1b, 0x10, 0x9f, 0x49, 0x96, 0x5a, 0x15, 0x15,
+	0x35, 0x29, 0xdb, 0x10, 0x8b, 0x90, 0xdf, 0x7a, 0x2e, 0x51, 0x1b, 0x52, 0x63, 0xfc, 0x9f, 0x06,
+	0xe4, 0x42, 0x41, 0x98, 0x19, 0xe2, 0xd8, 0xbe, 0xec, 0xd9, 0x01, 0xb3, 0x8a, 0x80, 0xf2, 0xe5,
+	0xd7, 0xad, 0xdc, 0xd8, 0xbe, 0xac, 0x4a, 0x10, 0x3a, 0x80, 0x92, 0xe3, 0x3a, 0x81, 0x63, 0x8f,
+	0x7a, 0x7d, 0x66, 0x7a, 0xa7, 0xa7, 0xab, 0x85, 0x29, 0xca, 0x19, 0x07, 0x62, 0x02, 0x7a, 0x0a,
+	0x8c, 0xa5, 0x9e, 0x9f, 0x5e, 0x35, 0x1f, 0xc6, 0xf6, 0xa5, 0x9a, 0x7b, 0x07, 0x60, 0x3c, 0x1d,
+	0x05, 0xce, 0x64, 0xe4, 0xc8, 0x8b, 0xde, 0xb0, 0x42, 0x10, 0xb4, 0x03, 0x1b, 0xaf, 0x9d, 0x20,
+	0x20, 0x3e, 0xd7, 0xbe, 0x61, 0xc9, 0x11, 0xfe, 0x17, 0x03, 0xb6, 0x92, 0x62, 0x30, 0x7a, 0x00,
+	0x9b, 0xa7, 0xb6, 0x33, 0x9a, 0xfa, 0xa4, 0x17, 0x9c, 0xfb, 0x84, 0x9e, 0x7b, 0xa3, 0xa1, 0xdc,
+	0x78, 0x59, 0x22, 0xba, 0x0a, 0x8e, 0xf6, 0x60, 0x93, 0x32, 0x8f, 0xeb, 0x7d, 0x43, 0xfa, 0xe7,
+	0x9e, 0xf7, 0xa6, 0x37, 0xf5, 0x47, 0xd2, 0x96, 0x4a, 0x1c, 0xf1, 0x95, 0x80, 0x9f, 0xf8, 0x23,
+	0xf4, 0x07, 0x50, 0x26, 0x63, 0xdb, 0x19, 0xf5, 0x7c, 0x32, 0x70, 0x26, 0x0e, 0x71, 0x03, 0xe1,
+	0x3a, 0x59, 0xab, 0xc4, 0xe1, 0x96, 0x06, 0xa3, 0xdb, 0x90, 0xb5, 0x5d, 0x6f, 0x6c, 0x8f, 0x1c,
+	0x42, 0xf9, 0x9e, 0x32, 0xd6, 0x1c, 0x80, 0xbf, 0x85, 0x7c, 0x8d, 0xa7, 0x24, 0x2f, 0xbc, 0xbe,
+	0x45, 0xde, 0xa2, 0xdb, 0x90, 0x7e, 0xed, 0xf5, 0xb9, 0x8c, 0xb9, 0x27, 0x30, 0x8f, 0x52, 0x16,
+	0x03, 0xa3, 0xef, 0x42, 0xc9, 0x19, 0x92, 0xf1, 0xc4, 0x0b, 0x78, 0x8c, 0x66, 0xce, 0x25, 0x04,
+	0x2c, 0x86, 0xc0, 0x5f, 0x92, 0x19, 0xfa, 0x10, 0x0a, 0x17, 0xf6, 0xc8, 0x61, 0x09, 0x4b, 0xcf,
+	0x73, 0x47, 0x33, 0x7e, 0x0e, 0x19, 0x2b, 0xaf, 0x80, 0x2d, 0x77, 0x34, 0xc3, 0x0f, 0x23, 0x6b,
+	0xd3, 0x77, 0xaf, 0x8d, 0xff, 0xca, 0x80, 0xfc, 0x09, 0x4f, 0x81, 0xae, 0x24, 0xea, 0xe7, 0x90,
+	0x13, 0x09, 0x13, 0xcf, 0x28, 0x2b, 0xa9, 0x25, 0xee, 0x72, 0xc4, 0x92, 0xce, 0x97, 0x36, 0x7d,
+	0x63, 0xc9, 0x6c, 0x8c, 0x7d, 0x5f, 0x4d, 0xfc, 0x57, 0x11, 0x79, 0x56, 0x88, 0xcf, 0xf2, 0x9a,
+	0xc1, 0xb9, 0xed, 0x9e, 0x11, 0x29, 0x4a, 0x28, 0xaf, 0xa9, 0x71, 0xb8, 0x25, 0xf1, 0xf8, 0x9f,
+	0xd3, 0x90, 0xd5, 0xd0, 0x85, 0xec, 0x75, 0x1b, 0x36, 0x64, 0x3c, 0x92, 0x21, 0xec, 0x35, 0x8f,
+	0x44, 0xb1, 0x5b, 0x28, 0xbd, 0x78, 0x0b, 0x49, 0xf1, 0xd6, 0xae, 0xa4, 0xae, 0xf5, 0xf7, 0x52,
+	0xd7, 0x03, 0x9d, 0xb3, 0x6d, 0xf0, 0x0b, 0x44, 0xdd, 0x6e, 0x62, 0x0b, 0xb1, 0xb4, 0xed, 0x3e,
+	0x4b, 0xdb, 0xde, 0x4e, 0x09, 0x95, 0x91, 0xee, 0x9a, 0x10, 0x55, 0xc3, 0x0e, 0x66, 0xe8, 0x87,
+	0x61, 0x12, 0x3b, 0xb8, 0x4a, 0xc6, 0xaa, 0xe9, 0xab, 0x01, 0x0b, 0xa5, 0x3e, 0xb9, 0x70, 0xc8,
+	0x37, 0x62, 0x01, 0x91, 0xb5, 0x82, 0x02, 0x89, 0x50, 0xaa, 0x09, 0xec, 0xa0, 0x02, 0x2b, 0xd9,
+	0xeb, 0xc9, 0xd5, 0x40, 0x65, 0xbd, 0xc4, 0x15, 0x89, 0xad, 0xcc, 0x7a, 0x89, 0x1b, 0xe0, 0xbb,
+	0x50, 0xb0, 0x88, 0x3d, 0x94, 0xc7, 0x49, 0xde, 0xc6, 0xcf, 0x0e, 0x7f, 0x16, 0x25, 0xa0, 0x21,
+	0xa3, 0x30, 0x56, 0x18, 0x45, 0x17, 0x8a, 0x4d, 0x87, 0x06, 0x02, 0x4a, 0x19, 0xf3, 0xb9, 0x21,
+	0x18, 0x61, 0x43, 0x98, 0x9f, 0x45, 0x6a, 0xe5, 0x59, 0xe0, 0xa7, 0x31, 0xae, 0xef, 0x23, 0xd1,
+	0x17, 0x50, 0xae, 0xf2, 0xcb, 0x8b, 0x2c, 0xdd, 0x70, 0x58, 0x57, 0xa9, 0xa8, 0xae, 0x7e, 0xb6,
+	0x30, 0xfb, 0x3d, 0xd6, 0x56, 0xb6, 0x9c, 0x4a, 0x8e, 0x14, 0x9f, 0x43, 0xc9, 0x22, 0xaf, 0xc9,
+	0x20, 0xf8, 0x9f, 0x08, 0xb6, 0x30, 0xf9, 0x7d, 0x74, 0xf2, 0x4f, 0x06, 0xe4, 0x6a, 0x23, 0xcf,
+	0x55, 0x21, 0x2a, 0xbe, 0xec, 0x2e, 0x64, 0xbd, 0x0b, 0xe2, 0xfb, 0xce, 0x90, 0xd0, 0x04, 0xe9,
+	0xe7, 0x48, 0xf4, 0x47, 0x50, 0x50, 0x03, 0xe1, 0x91, 0xe9, 0x95, 0x1e, 0x99, 0x57, 0x13, 0xd8,
+	0x28, 0x29, 0x54, 0xaf, 0x25, 0x85, 0x6a, 0xfc, 0x20, 0x2c, 0xf2, 0xaa, 0x20, 0xfc, 0x3b, 0x83,
+	0x67, 0x97, 0x16, 0xb9, 0x70, 0x78, 0xf2, 0xb6, 0xc4, 0x08, 0x4d, 0xc8, 0xf8, 0x92, 0x44, 0xa6,
+	0x7b, 0x7a, 0xac, 0x16, 0x48, 0x27, 0xc7, 0xa1, 0x87, 0x70, 0x4d, 0xe8, 0x92, 0xdd, 0x55, 0xe9,
+	0xd0, 0xa3, 0xa1, 0xca, 0x0a, 0x0f, 0x52, 0xdd, 0x8a, 0x84, 0x3d, 0xb3, 0xd4, 0xf3, 0xbb, 0x3f,
+	0xe3, 0x41, 0x2b, 0xab, 0x9f, 0xd8, 0x07, 0xb3, 0xd8, 0xeb, 0x7c, 0xe3, 0x3d, 0x5e, 0xe7, 0xf8,
+	0x21, 0x5c, 0x67, 0x9e, 0x11, 0xda, 0xeb, 0x3b, 0x9c, 0x0e, 0xd7, 0x93, 0xa8, 0x29, 0x7b, 0x02,
+	0x69, 0x35, 0x18, 0xf1, 0x27, 0x90, 0xa2, 0x9c, 0xab, 0x06, 0x7f, 0x01, 0x45, 0xcb, 0x1b, 0x8d,
+	0x58, 0xee, 0xb2, 0xc4, 0x80, 0xde, 0xa1, 0x58, 0xfc, 0xd3, 0xd8, 0xec, 0xff, 0xbb, 0x1b, 0xe9,
+	0x35, 0x14, 0x44, 0x5e, 0xbc, 0xcc, 0xae, 0x3f, 0x06, 0x14, 0xd8, 0xfe, 0x19, 0x09, 0x92, 0xf2,
+	0x60, 0x81, 0xb9, 0x62, 0x1e, 0x8c, 0x9f, 0x45, 0xd7, 0x5a, 0xb5, 0x09, 0xe6, 0xc8, 0xe2, 0xd0,
+	0xf8, 0x72, 0x19, 0x4b, 0x0d, 0xf1, 0x9f, 0x01, 0xb0, 0x60, 0xbb, 0x44, 0x62, 0xe6, 0x1e, 0xee,
+	0x60, 0x34, 0x1d, 0x92, 0x9e, 0xac, 0x3d, 0xc8, 0xf9, 0x45, 0x09, 0x3e, 0x14, 0x50, 0xf4, 0x03,
+	0xc8, 0xfa, 0xc4, 0x1e, 0x5e, 0xd5, 0x09, 0x33, 0x8c, 0x98, 0x7d, 0xe1, 0xbd, 0xd0, 0xfa, 0xab,
+	0xdc, 0xea, 0xcf, 0x0d, 0x28, 0x1d, 0xb0, 0x67, 0xe8, 0x33, 0xc2, 0x8c, 0x88, 0x9b, 0x5a, 0x19,
+	0xd2, 0xf3, 0xf7, 0x1a, 0xfb, 0xfc, 0x7f, 0x90, 0xd9, 0x8a, 0x8b, 0x41, 0xd1, 0x1d, 0x58, 0x7b,
+	0xed, 0xf5, 0x85, 0x1c, 0x51, 0xc9, 0x39, 0x9c, 0x5d, 0xb6, 0x63, 0x87, 0x52, 0xc7, 0x3d, 0xe3,
+	0xcf, 0xcb, 0x14, 0x17, 0x17, 0x24, 0xa8, 0x31, 0xa4, 0xf8, 0xef, 0x0d, 0xc8, 0xd7, 0xbc, 0xa9,
+	0xab, 0x37, 0xf6, 0xfb, 0x50, 0xe4, 0x55, 0x92, 0x1e, 0x25, 0x23, 0x32, 0x08, 0x3c, 0x5f, 0x1e,
+	0x4b, 0x81, 0x43, 0x3b, 0x12, 0x78, 0xf5, 0xdd, 0x32, 0x6b, 0x92, 0x65, 0x07, 0x99, 0xa7, 0xe9,
+	0x71, 0x3c, 0x2d, 0x5a, 0x5b, 0x48, 0x8b, 0xf0, 0x77, 0x22, 0xd2, 0x51, 0xf6, 0x42, 0x1c, 0xb0,
+	0x31, 0x17, 0x2a, 0x6d, 0x89, 0x01, 0x7e, 0xc6, 0x1f, 0x87, 0xf5, 0x4b, 0x87, 0x06, 0xf4, 0x7f,
+	0x63, 0x4e, 0xf8, 0xa3, 0x08, 0x23, 0xca, 0x9e, 0x14, 0x84, 0x0f, 0x38, 0xb3, 0x8c, 0x25, 0x47,
+	0xf8, 0x0e, 0xe4, 0xc5, 0x94, 0x64, 0xfb, 0xc5, 0xbb, 0x11, 0x3c, 0x65, 0x7e, 0x40, 0xa7, 0x83,
+	0x01, 0xa1, 0x8a, 0x91, 0x1a, 0xe2, 0xfb, 0x50, 0xd0, 0x94, 0xc9, 0x86, 0x85, 0x7f, 0x02, 0xa5,
+	0x30, 0xb3, 0xe9, 0x28, 0x48, 0xba, 0x30, 0x15, 0xff, 0x54, 0x84, 0x3f, 0x53, 0x18, 0xf1, 0x7d,
+	0xcf, 0x97, 0x9e, 0x2c, 0x06, 0xb8, 0x1a, 0x5d, 0x95, 0xa2, 0xc7, 0x70, 0xcd, 0xe7, 0xac, 0x95,
+	0x29, 0xa9, 0xf2, 0x53, 0x6c, 0x65, 0x4b, 0x91, 0xe1, 0xbf, 0x4e, 0x41, 0x4e, 0x46, 0x55, 0x2e,
+	0xf7, 0x2d, 0xc8, 0x4e, 0xec, 0x33, 0xd2, 0xa3, 0xce, 0xb7, 0x44, 0x3e, 0xa2, 0x32, 0x0c, 0xd0,
+	0x71, 0xbe, 0x25, 0xec, 0x26, 0xe0, 0xc8, 0xc0, 0x7b, 0x43, 0x5c, 0x19, 0x79, 0x38, 0x79, 0x97,
+	0x01, 0x92, 0xce, 0x27, 0x9d, 0x68, 0x4c, 0x8b, 0xc6, 0xb9, 0x96, 0x64, 0x9c, 0x61, 0x9b, 0x5b,
+	0x8f, 0xd9, 0x5c, 0xc4, 0xfb, 0x36, 0xae, 0xee, 0x7d, 0x71, 0x63, 0xbd, 0xb6, 0x68, 0xac, 0x9d,
+	0xb0, 0x46, 0x56, 0x85, 0xc6, 0x8f, 0xa0, 0xc4, 0x0b, 0xbf, 0x0b, 0x7a, 0xe1, 0xf5, 0xe0, 0xb6,
+	0xd2, 0x0d, 0xfe, 0x4b, 0x03, 0x0a, 0x1d, 0xc2, 0xc4, 0x57, 0x9a, 0xde, 0x82, 0xf5, 0xb7, 0x53,
+	0xe2, 0xab, 0xca, 0x89, 0x18, 0x44, 0xf5, 0x9f, 0x7a, 0xa7, 0xfe, 0xd3, 0x57, 0xd0, 0xff, 0x5a,
+	0xa2, 0x7f, 0xd4, 0xa0, 0x24, 0x64, 0x79, 0xee, 0x9c, 0x9d, 0x8f, 0x9c, 0xb3, 0xf3, 0x80, 0x49,
+	0xc3, 0x5b, 0x03, 0x4a, 0x1a, 0x3e, 0x60, 0x27, 0x70, 0xea, 0xdb, 0x67, 0xa1, 0x8b, 0x46, 0x8f,
+	0xf1, 0x3f, 0xc4, 0x76, 0xb4, 0x4a, 0x53, 0x5b, 0xb0, 0x4e, 0x07, 0x9e, 0x2f, 0x76, 0x65, 0x58,
+	0x62, 0x80, 0xbe, 0x0f, 0x70, 0xae, 0x84, 0x50, 0x85, 0x29, 0x65, 0xb4, 0x31, 0x19, 0xad, 0x10,
+	0x65, 0x92, 0xde, 0xd7, 0x92, 0xf4, 0xce, 0x9f, 0x0b, 0x34, 0xf0, 0xfc, 0x65, 0x3e, 0xfe, 0x71,
+	0x94, 0x60, 0xd5, 0x25, 0x72, 0x17, 0x0a, 0x55, 0x61, 0x83, 0xcb, 0xf9, 0x85, 0x09, 0x56, 0xf1,
+	0xbb, 0x0f, 0xa5, 0x13, 0xd7, 0x7e, 0x27, 0xc7, 0x47, 0x71, 0x92, 0x55, 0x3c, 0x7f, 0x6d, 0x40,
+	0x71, 0x2e, 0xc3, 0xfb, 0x5c, 0x07, 0x0f, 0x21, 0x23, 0x1e, 0x2d, 0x44, 0x5c, 0x32, 0x49, 0x9d,
+	0x01, 0x4d, 0xc1, 0x5c, 0x69, 0xea, 0xaa, 0x16, 0x88, 0xf2, 0xf5, 0x30, 0x08, 0xe3, 0x98, 0x20,
+	0x34, 0x21, 0x2e, 0x7e, 0x00, 0xb9, 0xb6, 0x3d, 0xa5, 0xcb, 0x76, 0xff, 0x20, 0x8c, 0x5e, 0xb5,
+	0xf3, 0x3b, 0x90, 0x67, 0x01, 0x6e, 0xbc, 0x8c, 0xd9, 0xc3, 0x08, 0xfe, 0x0a, 0xdc, 0x6a, 0xb6,
+	0x3b, 0x20, 0xa3, 0xe5, 0xdc, 0x42, 0xf8, 0x2b, 0x58, 0x4e, 0xd7, 0x77, 0xce, 0xce, 0x88, 0xbf,
+	0x84, 0xdd, 0x47, 0x51, 0x02, 0xca, 0xf2, 0x60, 0xd6, 0x4f, 0x9a, 0xe7, 0xc1, 0xfe, 0xd4, 0x6d,
+	0x0c, 0xf1, 0x27, 0x90, 0xff, 0x8a, 0xe5, 0x0f, 0xea, 0x6c, 0xf9, 0x5b, 0x9f, 0x6d, 0x4a, 0xfa,
+	0x81, 0xa1, 0xde, 0xfa, 0x0c, 0x26, 0xbc, 0xe0, 0x32, 0x32, 0x85, 0x25, 0x39, 0x6b, 0xc1, 0x6c,
+	0x22, 0x02, 0x7c, 0x31, 0x5c, 0x27, 0xaf, 0x5f, 0x10, 0x37, 0xe8, 0xce, 0x26, 0xc4, 0xe2, 0x04,
+	0xef, 0x7e, 0x03, 0x2e, 0xac, 0x9c, 0x5e, 0x5c, 0xf9, 0xbb, 0x50, 0x14, 0x79, 0xce, 0x33, 0xdf,
+	0x9e, 0x9c, 0xbf, 0x23, 0xbb, 0xff, 0x9d, 0x28, 0x0d, 0x73, 0xb2, 0x63, 0x6f, 0x48, 0x96, 0xd0,
+	0x25, 0x36, 0x16, 0xe7, 0xed, 0xac, 0xf4, 0x8a, 0x76, 0xd6, 0x93, 0x48, 0xcb, 0x48, 0x3c, 0x7e,
+	0x42, 0xdb, 0xd7, 0xab, 0x87, 0xfb, 0x48, 0x9f, 0xaa, 0x39, 0xbc, 0x00, 0xb8, 0xbe, 0x7c, 0x4e,
+	0x88, 0x8c, 0x5d, 0xe5, 0x2a, 0x06, 0x6f, 0x88, 0xab, 0x5c, 0x0e, 0xf1, 0x67, 0x31, 0x8d, 0xf0,
+	0xd3, 0xf0, 0x3d, 0x2f, 0x90, 0x86, 0x93, 0xc8, 0x9a, 0x13, 0xb0, 0xd8, 0xd2, 0x18, 0x4f, 0x3c,
+	0x5f, 0xdf, 0xd6, 0xef, 0xb6, 0xb8, 0x1f, 0x43, 0x51, 0x93, 0xd7, 0x59, 0xc2, 0xc0, 0x62, 0xb0,
+	0xe3, 0x0e, 0xc9, 0xa5, 0xbc, 0xd9, 0xc5, 0x80, 0xc9, 0x3a, 0x26, 0x94, 0xda, 0x67, 0x4a, 0xab,
+	0x6a, 0x88, 0x49, 0x74, 0x41, 0xca, 0xe2, 0x88, 0xc3, 0x01, 0x64, 0xd8, 0x9b, 0x67, 0x70, 0xeb,
+	0x56, 0x41, 0x41, 0x79, 0x9a, 0xc7, 0x9a, 0x1d, 0x3c, 0x43, 0x11, 0x51, 0x24, 0xa7, 0x9b, 0x1d,
+	0x51, 0x71, 0x2c, 0x49, 0x84, 0x7f, 0x63, 0x40, 0xa1, 0x7e, 0x19, 0xde, 0xd8, 0x03, 0xd8, 0x38,
+	0xf5, 0xfc, 0xb1, 0x1d, 0xc4, 0x4c, 0x54, 0x50, 0x1d, 0x71, 0x94, 0x25, 0x49, 0xae, 0x9e, 0xc4,
+	0x2e, 0x46, 0xc1, 0x74, 0x42, 0x14, 0xc4, 0x1f, 0x46, 0xa5, 0xa1, 0xcc, 0xe6, 0x86, 0x76, 0x60,
+	0xab, 0x62, 0x3e, 0xfb, 0xc6, 0xff, 0x28, 0xec, 0x95, 0xf9, 0x0a, 0xef, 0x88, 0x68, 0xc3, 0x34,
+	0x42, 0x86, 0xf9, 0x1d, 0xe9, 0x67, 0xa9, 0x88, 0x59, 0x72, 0xfa, 0x90, 0x93, 0xed, 0xc2, 0x06,
+	0x6f, 0x91, 0xc4, 0xcd, 0x77, 0x4e, 0x27, 0xf1, 0xe2, 0x65, 0xfa, 0x76, 0xea, 0xf8, 0xfa, 0x6a,
+	0xd7, 0xe3, 0x78, 0x77, 0x7d, 0x7d, 0xa1, 0xbb, 0x8e, 0x7f, 0x69, 0xc0, 0x35, 0x29, 0x72, 0xa2,
+	0xb4, 0x31, 0x0e, 0xa9, 0x05, 0x0e, 0xec, 0x58, 0x64, 0xdf, 0x2e, 0x1d, 0x77, 0x03, 0xad, 0x08,
+	0xdd, 0xb3, 0x43, 0xb0, 0xe6, 0x4d, 0xe4, 0xbd, 0x9c, 0xb1, 0xf8, 0x37, 0xde, 0x84, 0x92, 0xcc,
+	0xad, 0x18, 0x3d, 0x3b, 0x6a, 0xfc, 0xa3, 0x38, 0x88, 0x55, 0xf4, 0xd9, 0x4f, 0x11, 0x3d, 0xa6,
+	0x1c, 0x95, 0xc8, 0x16, 0xa3, 0x2b, 0x59, 0x99, 0xd7, 0x92, 0x1e, 0x7f, 0x08, 0xb9, 0x67, 0x24,
+	0xf8, 0xc9, 0xd4, 0x0b, 0x6c, 0x99, 0x56, 0x89, 0x5f, 0x08, 0x8c, 0xd0, 0x2f, 0x04, 0xf8, 0xfb,
+	0x00, 0x9c, 0xe2, 0x84, 0x99, 0x35, 0xa3, 0x19, 0x39, 0x63, 0x47, 0x3f, 0x3f, 0xf8, 0x80, 0xc9,
+	0x3b, 0xa5, 0xd2, 0x76, 0xd2, 0x16, 0xff, 0xc6, 0xff, 0x96, 0x82, 0x75, 0x3e, 0x31, 0x99, 0x2f,
+	0xfa, 0x01, 0x14, 0xd9, 0x03, 0xad, 0x37, 0x21, 0x7e, 0x4f, 0xa0, 0x45, 0xa8, 0xdc, 0x94, 0xe2,
+	0xce, 0x17, 0xb5, 0xf2, 0x8c, 0xb0, 0x4d, 0xfc, 0x96, 0xfc, 0xa7, 0xa1, 0xa4, 0x27, 0x06, 0xc4,
+	0xb5, 0x65, 0x39, 0x39, 0x71, 0x66, 0x41, 0xce, 0xec, 0x72, 0x3a, 0xf4, 0x14, 0x4a, 0xba, 0x1f,
+	0xcb, 0xff, 0x38, 0xa0, 0x95, 0xb5, 0x65, 0x53, 0x8b, 0x73, 0x4a, 0x6b, 0xea, 0x52, 0xf4, 0x29,
+	0xe4, 0xd9, 0x04, 0xbe, 0xec, 0xd0, 0x9e, 0x55, 0xd6, 0x97, 0x4d, 0x04, 0x46, 0xd6, 0x26, 0xfe,
+	0xa1, 0x3d, 0x43, 0x3f, 0x86, 0xe2, 0xd0, 0x9e, 0xf5, 0x68, 0x60, 0xfb, 0x81, 0xf8, 0xbb, 0x61,
+	0x75, 0x95, 0x27, 0x3f, 0xb4, 0x67, 0x1d, 0x36, 0x81, 0x81, 0xf0, 0x27, 0xe1, 0x33, 0xa2, 0x08,
+	0xb3, 0xd4, 0xd7, 0x93, 0x0e, 0x95, 0x7b, 0x92, 0x0f, 0x2f, 0x6f, 0x09, 0xd4, 0xde, 0xbf, 0x1a,
+	0x90, 0xd5, 0xf1, 0x1b, 0x99, 0xb0, 0xf3, 0xa2, 0x75, 0xd0, 0xeb, 0x74, 0xab, 0xdd, 0x93, 0x4e,
+	0xef, 0xe4, 0xb8, 0xd3, 0xae, 0xd7, 0x1a, 0x47, 0x8d, 0xfa, 0x61, 0xf9, 0xf7, 0xd0, 0x0e, 0xa0,
+	0x10, 0xae, 0x5d, 0x3f, 0x3e, 0x6c, 0x1c, 0x3f, 0x2b, 0x1b, 0x31, 0xb8, 0x75, 0x72, 0x7c, 0xcc,
+	0xe0, 0x29, 0x54, 0x81, 0xad, 0x10, 0xbc, 0x73, 0x52, 0xab, 0xd5, 0xeb, 0x87, 0xf5, 0xc3, 0x72,
+	0x1a, 0x6d, 0xc3, 0x66, 0x08, 0x73, 0x54, 0x6d, 0x34, 0xeb, 0x87, 0xe5, 0xb5, 0xd8, 0x84, 0x5a,
+	0xf5, 0xb8, 0x56, 0x6f, 0x32, 0xcc, 0x7a, 0x6c, 0x42, 0xbb, 0x7a, 0xd2, 0xa9, 0x1f, 0x96, 0x37,
+	0xf6, 0x7e, 0x23, 0x0a, 0x78, 0xea, 0x1f, 0x03, 0x74, 0x1b, 0x2a, 0x8c, 0xac, 0x6d, 0x35, 0x5a,
+	0x56, 0xa3, 0xfb, 0x75, 0x4c, 0xfe, 0x2d, 0x28, 0x47, 0xb0, 0xcd, 0xd6, 0x57, 0x65, 0x03, 0xdd,
+	0x80, 0xeb, 0x11, 0xe8, 0x71, 0xcb, 0x7a, 0x59, 0x6d, 0x96, 0x53, 0x6a, 0x4d, 0x8d, 0x78, 0xde,
+	0x78, 0xf6, 0xbc, 0x9c, 0x46, 0x37, 0x61, 0x3b, 0x02, 0xae, 0x59, 0x8d, 0x6e, 0xa3, 0x56, 0x6d,
+	0x96, 0xd7, 0xf6, 0xfe, 0xd6, 0x80, 0xcd, 0x85, 0xa6, 0x3e, 0xc2, 0x70, 0xa7, 0xd6, 0x3a, 0xae,
+	0x9d, 0x58, 0x56, 0xfd, 0xb8, 0xf6, 0x75, 0xaf, 0xdd, 0x6a, 0x36, 0x6a, 0x71, 0xd1, 0x6e, 0x43,
+	0x25, 0x81, 0xa6, 0xda, 0x14, 0x22, 0x7e, 0x00, 0x37, 0x13, 0xb0, 0x47, 0x2d, 0xeb, 0xa0, 0x71,
+	0x58, 0x4e, 0xa1, 0x3b, 0x60, 0x26, 0xa0, 0xad, 0x7a, 0xbb, 0x59, 0xad, 0xd5, 0xcb, 0xe9, 0xbd,
+	0x5f, 0x1b, 0x50, 0x88, 0xfc, 0x25, 0x80, 0xee, 0xc2, 0xad, 0x5a, 0xb5, 0x5b, 0x7b, 0xde, 0x3b,
+	0x69, 0x27, 0xcb, 0xc3, 0x56, 0x8c, 0x11, 0x1c, 0x35, 0xac, 0x7a, 0xaf, 0x75, 0x5c, 0xab, 0x97,
+	0x0d, 0x2e, 0x6e, 0x12, 0xba, 0xda, 0x6c, 0x8a, 0x73, 0x8f, 0x63, 0x3b, 0x5f, 0x36, 0xda, 0xe5,
+	0xf4, 0xde, 0x29, 0x14, 0xa3, 0x6d, 0x78, 0x26, 0xc9, 0x41, 0xb3, 0x5a, 0xfb, 0xb2, 0x75, 0xd2,
+	0xed, 0x55, 0x6b, 0xdd, 0x46, 0xeb, 0x38, 0x26, 0x49, 0x05, 0xb6, 0xe2, 0x04, 0x9c, 0x99, 0xc1,
+	0x0e, 0x22, 0x8e, 0x39, 0xac, 0x1f, 0xd5, 0xad, 0x72, 0x6a, 0xef, 0x97, 0xac, 0x4a, 0x13, 0x6a,
+	0x11, 0xf0, 0xfd, 0x3c, 0xaf, 0x1e, 0x3f, 0xab, 0x27, 0x5b, 0xf6, 0x4d, 0xd8, 0x8e, 0xa2, 0xe7,
+	0xc6, 0x6d, 0xc2, 0x4e, 0x14, 0x55, 0x6d, 0xb7, 0xad, 0xd6, 0xab, 0x3a, 0x53, 0xfc, 0x02, 0xce,
+	0xaa, 0xbf, 0xa8, 0xd7, 0xba, 0xcc, 0xc4, 0xf7, 0x7e, 0x25, 0xae, 0x2d, 0x9d, 0xe7, 0xb1, 0x53,
+	0x62, 0x76, 0x53, 0x7f, 0x55, 0x3f, 0xee, 0xf6, 0xba, 0x5f, 0xb7, 0xeb, 0x31, 0x19, 0xa4, 0xe7,
+	0x85, 0xf0, 0x35, 0xab, 0x5e, 0x65, 0xcc, 0x8c, 0x04, 0xdc, 0x49, 0xfb, 0xb0, 0xda, 0x55, 0x42,
+	0xc4, 0x70, 0x87, 0xf5, 0x66, 0x5d, 0x08, 0xf1, 0x73, 0xc8, 0x87, 0x2f, 0x72, 0xa6, 0x86, 0xfa,
+	0x4f, 0xdb, 0x2d, 0xab, 0xcb, 0x8c, 0xe7, 0x65, 0xb5, 0x1b, 0x13, 0x61, 0x1b, 0x36, 0xa3, 0xe8,
+	0x5a, 0xe7, 0x55, 0xd9, 0x60, 0x47, 0x10, 0x05, 0x1f, 0x1f, 0xbe, 0xe8, 0xb4, 0x8e, 0xcb, 0xa9,
+	0xbd, 0xbf, 0x33, 0x20, 0xab, 0x2f, 0x4f, 0x26, 0x49, 0xbb, 0x6a, 0x55, 0x5f, 0x26, 0xed, 0x6e,
+	0x1b, 0x36, 0x43, 0xb8, 0x4e, 0xd7, 0x12, 0xda, 0x8d, 0x82, 0x8f, 0x4f, 0x5e, 0x1e, 0xb0, 0xf3,
+	0x43, 0xd7, 0xa1, 0x14, 0x02, 0x1f, 0xb4, 0x5a, 0x4d, 0x11, 0x34, 0x42, 0xc0, 0xd6, 0x01, 0x53,
+	0x75, 0x79, 0x2d, 0x46, 0xdb, 0x6c, 0x74, 0xba, 0xe5, 0xf5, 0x27, 0x7f, 0xb1, 0x03, 0xc0, 0x82,
+	0x1a, 0xf1, 0x2f, 0x9c, 0x01, 0x41, 0x4d, 0xc8, 0xea, 0xde, 0x2c, 0xd2, 0x3d, 0xa4, 0x50, 0xa7,
+	0xd8, 0x4c, 0x00, 0x52, 0xbc, 0xfd, 0xab, 0xdf, 0xfe, 0xc7, 0xdf, 0xa4, 0x4a, 0x38, 0xf3, 0xe8,
+	0xe2, 0x93, 0x47, 0xec, 0x6e, 0x78, 0xca, 0xb3, 0xf1, 0x23, 0xb8, 0x26, 0x6b, 0xa1, 0x68, 0x53,
+	0xff, 0x9f, 0xa5, 0x6a, 0xb3, 0xe6, 0x02, 0x48, 0xf3, 0x41, 0x05, 0xc5, 0xe7, 0xd1, 0x9f, 0x3a,
+	0xc3, 0x5f, 0xa0, 0xaf, 0x21, 0x1f, 0xae, 0x4f, 0x22, 0xf5, 0x1c, 0x8f, 0xd5, 0x4e, 0xcd, 0x64,
+	0x38, 0xc5, 0x37, 0x39, 0xdb, 0xeb, 0x68, 0x53, 0x8b, 0xd7, 0x97, 0x14, 0xe8, 0x25, 0x64, 0x75,
+	0x1d, 0x70, 0xbe, 0xe1, 0x50, 0xdd, 0xd2, 0x4c, 0x00, 0x52, 0xbc, 0xc3, 0x39, 0x96, 0x51, 0x51,
+	0x73, 0xe4, 0xb9, 0x27, 0xea, 0x40, 0x56, 0xd7, 0xf9, 0x50, 0xf8, 0x15, 0xa3, 0x4a, 0x88, 0x66,
+	0x02, 0x90, 0xe2, 0xdb, 0x9c, 0xdd, 0x0e, 0xda, 0x8a, 0xec, 0xfb, 0xa9, 0x28, 0x0a, 0xa2, 0x13,
+	0xc8, 0xea, 0x8e, 0xb3, 0x66, 0x1a, 0xee, 0x89, 0x9b, 0x09, 0x40, 0x8a, 0xef, 0x70, 0xa6, 0x95,
+	0x27, 0x9b, 0x73, 0xa6, 0xec, 0xc7, 0x4e, 0xc6, 0x98, 0x9f, 0x8e, 0x05, 0x19, 0xd5, 0x01, 0x42,
+	0xaa, 0x41, 0x11, 0xea, 0x62, 0x99, 0x8b, 0x30, 0xcd, 0x13, 0x5f, 0x8f, 0x0a, 0x3a, 0x60, 0x24,
+	0x4f, 0x8d, 0x3d, 0xf4, 0x33, 0x80, 0x79, 0x19, 0x1f, 0xa9, 0x3f, 0xaf, 0x22, 0x5d, 0x04, 0x33,
+	0x09, 0x4a, 0xf1, 0x3d, 0xce, 0xd9, 0xc4, 0xdb, 0x51, 0xce, 0xf2, 0x67, 0x16, 0xc6, 0xdb, 0x12,
+	0x95, 0x75, 0xd9, 0x20, 0xdf, 0x0a, 0x59, 0x8f, 0x6e, 0xf8, 0x99, 0x49, 0x50, 0x8a, 0x2b, 0x9c,
+	0x37, 0x42, 0x65, 0xc6, 0x5b, 0x36, 0x91, 0x84, 0x65, 0x59, 0xa2, 0xb2, 0x26, 0x48, 0x29, 0x52,
+	0xaf, 0x82, 0x68, 0xcf, 0xd5, 0x4c, 0x04, 0x53, 0x7c, 0x9d, 0xb3, 0x2d, 0xa0, 0x5c, 0x88, 0xed,
+	0x63, 0x03, 0x9d, 0x42, 0x21, 0xd2, 0xe3, 0x44, 0x37, 0xe4, 0xf4, 0x78, 0xdf, 0xd4, 0x5c, 0x82,
+	0xa0, 0xf8, 0x43, 0xce, 0xf9, 0x03, 0x5c, 0x89, 0x0b, 0xfc, 0x54, 0x34, 0x4c, 0xb8, 0x3e, 0xfa,
+	0x90, 0x0f, 0xb7, 0x2c, 0xb5, 0x57, 0xc4, 0x9a, 0xa0, 0x66, 0x32, 0x9c, 0x62, 0xcc, 0x17, 0xb9,
+	0x8d, 0x6f, 0x2c, 0x2c, 0xe2, 0x73, 0x4a, 0xb6, 0xc6, 0x5b, 0x28, 0xc7, 0x3b, 0x5c, 0xc8, 0x0c,
+	0x69, 0x23, 0xd6, 0x28, 0x33, 0x97, 0xe3, 0xf4, 0xa6, 0xd0, 0xad, 0x88, 0x3d, 0xf6, 0x9c, 0xe1,
+	0x2f, 0x1e, 0xa9, 0x66, 0x16, 0x53, 0xdf, 0xcf, 0x21, 0x17, 0xea, 0x67, 0xe9, 0x23, 0x89, 0x76,
+	0xc8, 0xcc, 0x44, 0x30, 0xc5, 0xf7, 0xf9, 0x1a, 0xb7, 0xf0, 0x4e, 0xd4, 0x8a, 0x7c, 0x49, 0xc5,
+	0xb6, 0xd4, 0x84, 0xac, 0xae, 0x3d, 0x6b, 0x6f, 0x0a, 0x17, 0xdd, 0xcd, 0x04, 0xa0, 0x0e, 0x4d,
+	0x7b, 0x0b, 0xa1, 0x09, 0x34, 0x19, 0xd5, 0x46, 0x19, 0xa9, 0xbc, 0x9b, 0x49, 0x50, 0x8a, 0xef,
+	0x72, 0x86, 0x37, 0xf1, 0x56, 0x34, 0x28, 0x09, 0x22, 0xe9, 0x4b, 0xf3, 0x3a, 0x60, 0xc8, 0xde,
+	0x43, 0xb5, 0x43, 0x33, 0x09, 0xba, 0xd4, 0x97, 0x7c, 0x41, 0x24, 0x79, 0xcf, 0xcb, 0x60, 0x9a,
+	0x77, 0xa4, 0x8e, 0x68, 0x26, 0x41, 0x97, 0xf2, 0x96, 0xd5, 0x40, 0x69, 0x97, 0xe1, 0xea, 0xa0,
+	0xb6, 0xcb, 0x58, 0x55, 0xd1, 0x4c, 0x86, 0xc7, 0xec, 0x72, 0xbe, 0xc2, 0xd4, 0x0d, 0xad, 0xf1,
+	0x27, 0x90, 0x9b, 0x8b, 0x35, 0xf7, 0xdb, 0x68, 0x8d, 0xd1, 0x4c, 0x04, 0x27, 0x6c, 0x41, 0x68,
+	0xbe, 0x3a, 0x67, 0x6f, 0x41, 0x46, 0x95, 0xf8, 0x74, 0x68, 0x0c, 0x95, 0x04, 0xcd, 0x45, 0xd8,
+	0xd2, 0xd0, 0x38, 0x61, 0x24, 0x8c, 0xe7, 0x2b, 0xc8, 0xea, 0x4a, 0x9f, 0xb6, 0xbb, 0x70, 0x6d,
+	0xd0, 0x4c, 0x00, 0x26, 0x98, 0x89, 0x3e, 0xcb, 0xe9, 0x58, 0xf1, 0xd5, 0x35, 0xbf, 0xf9, 0x0d,
+	0x16, 0xaa, 0x12, 0x9a, 0x09, 0xc0, 0xa5, 0x7c, 0x07, 0x9c, 0x46, 0x9a, 0xc8, 0xbc, 0xf8, 0xa7,
+	0x4d, 0x24, 0x52, 0x30, 0x34, 0x93, 0xa0, 0x4b, 0x4d, 0x24, 0x10, 0x44, 0x8c, 0xf7, 0x11, 0x64,
+	0x54, 0x43, 0x43, 0xeb, 0x37, 0xd4, 0xf3, 0x31, 0x17, 0x61, 0x14, 0x97, 0x39, 0x57, 0x40, 0x3a,
+	0xc7, 0x78, 0x6c, 0xa0, 0x0e, 0xc0, 0xbc, 0xe0, 0xaf, 0x65, 0x8c, 0x74, 0x35, 0xcc, 0x24, 0x28,
+	0xc5, 0x37, 0x38, 0xb7, 0x4d, 0x54, 0xd2, 0x36, 0x40, 0x39, 0xfe, 0xb1, 0x81, 0xfe, 0x98, 0x3f,
+	0x0d, 0x55, 0xb1, 0x4b, 0xdb, 0x56, 0xb4, 0x68, 0x68, 0x26, 0x82, 0xb5, 0x5a, 0xd1, 0x8d, 0xc5,
+	0x20, 0x77, 0xc6, 0xd9, 0xbd, 0x02, 0x98, 0x17, 0xb0, 0xb4, 0xc8, 0x91, 0x22, 0x9a, 0x99, 0x04,
+	0xa5, 0xd8, 0xe4, 0xac, 0xb7, 0xf0, 0x5c, 0x64, 0x51, 0xe2, 0x7a, 0x6a, 0xec, 0xed, 0x72, 0x55,
+	0xd4, 0x2f, 0x17, 0xf8, 0xd6, 0x2f, 0x93, 0xf8, 0x86, 0xa1, 0x49, 0xaa, 0x20, 0x1c, 0xff, 0xd8,
+	0x40, 0x2d, 0xc8, 0xea, 0x2a, 0xad, 0xb6, 0xad, 0x70, 0xa9, 0xd7, 0x4c, 0x00, 0x26, 0x65, 0x47,
+	0xdf, 0x30, 0xf4, 0x63, 0x03, 0x75, 0x21, 0x1f, 0x2e, 0xad, 0xe8, 0xd8, 0x10, 0x2b, 0xc1, 0x98,
+	0xc9, 0x70, 0x8a, 0xb7, 0x38, 0xe7, 0x22, 0xca, 0x4b, 0xce, 0x82, 0x4b, 0x1d, 0x32, 0xea, 0x31,
+	0xaf, 0xcd, 0x29, 0x54, 0x81, 0x31, 0x17, 0x61, 0x14, 0x6f, 0x72, 0x4e, 0x39, 0x94, 0x65, 0x9c,
+	0xf8, 0x03, 0xbf, 0xbf, 0xc1, 0xab, 0x06, 0x9f, 0xfe, 0xf7, 0x00, 0x76, 0xf6, 0xf1, 0xdd, 0x00,
+	0x33, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// JobServiceClient is the client API for JobService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JobServiceClient interface {
+	CreateJob(ctx context.Context, in *CreateJobReq, opts ...grpc.CallOption) (*CreateJobRes, error)
+	ReadJob(ctx context.Context, in *ReadJobReq, opts ...grpc.CallOption) (*ReadJobRes, error)
+	// Reads several jobs at once
+	BatchGetJobs(ctx context.Context, in *BatchGetJobsReq, opts ...grpc.CallOption) (*BatchGetJobsRes, error)
+	// Counts the jobs of the caller without reading them
+	CountJobs(ctx context.Context, in *CountJobsReq, opts ...grpc.CallOption) (*CountJobsRes, error)
+	// Tells whether a job exists without reading it
+	JobExists(ctx context.Context, in *JobExistsReq, opts ...grpc.CallOption) (*JobExistsRes, error)
+	UpdateJob(ctx context.Context, in *UpdateJobReq, opts ...grpc.CallOption) (*UpdateJobRes, error)
+	// Creates a copy of a job with the overridden fields, it starts out pending without runs
+	CloneJob(ctx context.Context, in *CloneJobReq, opts ...grpc.CallOption) (*CloneJobRes, error)
+	// Copies the definition of a job into the job of its owner with the same name in another environment, which is
+	// created when it doesn't exist. Dependencies aren't copied, the runs of the target aren't touched.
+	PromoteJob(ctx context.Context, in *PromoteJobReq, opts ...grpc.CallOption) (*PromoteJobRes, error)
+	ReadChange(ctx context.Context, in *ReadChangeReq, opts ...grpc.CallOption) (*ReadChangeRes, error)
+	// Streams the changes of the jobs of the caller newest first
+	ListChanges(ctx context.Context, in *ListChangesReq, opts ...grpc.CallOption) (JobService_ListChangesClient, error)
+	// Applies a pending change to its job, the caller must be someone other than the one who requested it
+	ApproveChange(ctx context.Context, in *ApproveChangeReq, opts ...grpc.CallOption) (*ApproveChangeRes, error)
+	// Discards a pending change, its job stays as it is
+	RejectChange(ctx context.Context, in *RejectChangeReq, opts ...grpc.CallOption) (*RejectChangeRes, error)
+	// Streams the revisions of a job newest first
+	ListJobRevisions(ctx context.Context, in *ListJobRevisionsReq, opts ...grpc.CallOption) (JobService_ListJobRevisionsClient, error)
+	// Restores the fields UpdateJob updates to the ones of a revision, like an update that records a revision itself
+	RollbackJob(ctx context.Context, in *RollbackJobReq, opts ...grpc.CallOption) (*RollbackJobRes, error)
+	DeleteJob(ctx context.Context, in *DeleteJobReq, opts ...grpc.CallOption) (*DeleteJobRes, error)
+	// Deletes several jobs at once, a job that can't be deleted doesn't stop the others
+	DeleteJobs(ctx context.Context, in *DeleteJobsReq, opts ...grpc.CallOption) (*DeleteJobsRes, error)
+	// Undeletes a deleted job that wasn't purged yet
+	RestoreJob(ctx context.Context, in *RestoreJobReq, opts ...grpc.CallOption) (*RestoreJobRes, error)
+	// Hides a job that isn't running from ListJobs and stops the scheduler from firing it
+	ArchiveJob(ctx context.Context, in *ArchiveJobReq, opts ...grpc.CallOption) (*ArchiveJobRes, error)
+	// Lists an archived job again, a scheduled one runs next at its next scheduled time from now on
+	UnarchiveJob(ctx context.Context, in *UnarchiveJobReq, opts ...grpc.CallOption) (*UnarchiveJobRes, error)
+	// Archives every job of the caller matching the filters of the request
+	ArchiveJobs(ctx context.Context, in *ArchiveJobsReq, opts ...grpc.CallOption) (*ArchiveJobsRes, error)
+	// Stops the scheduler from firing a job that isn't running
+	PauseJob(ctx context.Context, in *PauseJobReq, opts ...grpc.CallOption) (*PauseJobRes, error)
+	// Lets the scheduler fire a paused job again, it becomes pending
+	ResumeJob(ctx context.Context, in *ResumeJobReq, opts ...grpc.CallOption) (*ResumeJobRes, error)
+	// Stops the running execution of a job
+	CancelJob(ctx context.Context, in *CancelJobReq, opts ...grpc.CallOption) (*CancelJobRes, error)
+	// Queues a run of a job right away, regardless of its schedule
+	TriggerJob(ctx context.Context, in *TriggerJobReq, opts ...grpc.CallOption) (*TriggerJobRes, error)
+	ListJobs(ctx context.Context, in *ListJobsReq, opts ...grpc.CallOption) (JobService_ListJobsClient, error)
+	// Streams the jobs whose name or description contains the words of the query, the most relevant first
+	SearchJobs(ctx context.Context, in *SearchJobsReq, opts ...grpc.CallOption) (JobService_SearchJobsClient, error)
+	// Returns the tree of jobs the job depends on and the tree of jobs depending on it
+	GetJobGraph(ctx context.Context, in *GetJobGraphReq, opts ...grpc.CallOption) (*GetJobGraphRes, error)
+	// Creates every job the client streams in batches, jobs that can't be created are reported in the response
+	ImportJobs(ctx context.Context, opts ...grpc.CallOption) (JobService_ImportJobsClient, error)
+	// Streams every job of the caller matching the filter as CSV or NDJSON in chunks
+	ExportJobs(ctx context.Context, in *ExportJobsReq, opts ...grpc.CallOption) (JobService_ExportJobsClient, error)
+	// Streams every change of a job until the client disconnects
+	WatchJobs(ctx context.Context, in *WatchJobsReq, opts ...grpc.CallOption) (JobService_WatchJobsClient, error)
+	// Lists the handlers jobs can name and the params each of them takes
+	ListJobTypes(ctx context.Context, in *ListJobTypesReq, opts ...grpc.CallOption) (*ListJobTypesRes, error)
+	// Tells how much of the quotas of the server an owner uses
+	GetQuota(ctx context.Context, in *GetQuotaReq, opts ...grpc.CallOption) (*GetQuotaRes, error)
+}
+
+type jobServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewJobServiceClient(cc *grpc.ClientConn) JobServiceClient {
+	return &jobServiceClient{cc}
+}
+
+func (c *jobServiceClient) CreateJob(ctx context.Context, in *CreateJobReq, opts ...grpc.CallOption) (*CreateJobRes, error) {
+	out := new(CreateJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/CreateJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ReadJob(ctx context.Context, in *ReadJobReq, opts ...grpc.CallOption) (*ReadJobRes, error) {
+	out := new(ReadJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ReadJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) BatchGetJobs(ctx context.Context, in *BatchGetJobsReq, opts ...grpc.CallOption) (*BatchGetJobsRes, error) {
+	out := new(BatchGetJobsRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/BatchGetJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CountJobs(ctx context.Context, in *CountJobsReq, opts ...grpc.CallOption) (*CountJobsRes, error) {
+	out := new(CountJobsRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/CountJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) JobExists(ctx context.Context, in *JobExistsReq, opts ...grpc.CallOption) (*JobExistsRes, error) {
+	out := new(JobExistsRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/JobExists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) UpdateJob(ctx context.Context, in *UpdateJobReq, opts ...grpc.CallOption) (*UpdateJobRes, error) {
+	out := new(UpdateJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/UpdateJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CloneJob(ctx context.Context, in *CloneJobReq, opts ...grpc.CallOption) (*CloneJobRes, error) {
+	out := new(CloneJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/CloneJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) PromoteJob(ctx context.Context, in *PromoteJobReq, opts ...grpc.CallOption) (*PromoteJobRes, error) {
+	out := new(PromoteJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/PromoteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ReadChange(ctx context.Context, in *ReadChangeReq, opts ...grpc.CallOption) (*ReadChangeRes, error) {
+	out := new(ReadChangeRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ReadChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListChanges(ctx context.Context, in *ListChangesReq, opts ...grpc.CallOption) (JobService_ListChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[0], "/model.JobService/ListChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceListChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_ListChangesClient interface {
+	Recv() (*ListChangesRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceListChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceListChangesClient) Recv() (*ListChangesRes, error) {
+	m := new(ListChangesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) ApproveChange(ctx context.Context, in *ApproveChangeReq, opts ...grpc.CallOption) (*ApproveChangeRes, error) {
+	out := new(ApproveChangeRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ApproveChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RejectChange(ctx context.Context, in *RejectChangeReq, opts ...grpc.CallOption) (*RejectChangeRes, error) {
+	out := new(RejectChangeRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/RejectChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobRevisions(ctx context.Context, in *ListJobRevisionsReq, opts ...grpc.CallOption) (JobService_ListJobRevisionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[1], "/model.JobService/ListJobRevisions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceListJobRevisionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_ListJobRevisionsClient interface {
+	Recv() (*ListJobRevisionsRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceListJobRevisionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceListJobRevisionsClient) Recv() (*ListJobRevisionsRes, error) {
+	m := new(ListJobRevisionsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) RollbackJob(ctx context.Context, in *RollbackJobReq, opts ...grpc.CallOption) (*RollbackJobRes, error) {
+	out := new(RollbackJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/RollbackJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DeleteJob(ctx context.Context, in *DeleteJobReq, opts ...grpc.CallOption) (*DeleteJobRes, error) {
+	out := new(DeleteJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/DeleteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DeleteJobs(ctx context.Context, in *DeleteJobsReq, opts ...grpc.CallOption) (*DeleteJobsRes, error) {
+	out := new(DeleteJobsRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/DeleteJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RestoreJob(ctx context.Context, in *RestoreJobReq, opts ...grpc.CallOption) (*RestoreJobRes, error) {
+	out := new(RestoreJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/RestoreJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ArchiveJob(ctx context.Context, in *ArchiveJobReq, opts ...grpc.CallOption) (*ArchiveJobRes, error) {
+	out := new(ArchiveJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ArchiveJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) UnarchiveJob(ctx context.Context, in *UnarchiveJobReq, opts ...grpc.CallOption) (*UnarchiveJobRes, error) {
+	out := new(UnarchiveJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/UnarchiveJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ArchiveJobs(ctx context.Context, in *ArchiveJobsReq, opts ...grpc.CallOption) (*ArchiveJobsRes, error) {
+	out := new(ArchiveJobsRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ArchiveJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) PauseJob(ctx context.Context, in *PauseJobReq, opts ...grpc.CallOption) (*PauseJobRes, error) {
+	out := new(PauseJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/PauseJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ResumeJob(ctx context.Context, in *ResumeJobReq, opts ...grpc.CallOption) (*ResumeJobRes, error) {
+	out := new(ResumeJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ResumeJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CancelJob(ctx context.Context, in *CancelJobReq, opts ...grpc.CallOption) (*CancelJobRes, error) {
+	out := new(CancelJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) TriggerJob(ctx context.Context, in *TriggerJobReq, opts ...grpc.CallOption) (*TriggerJobRes, error) {
+	out := new(TriggerJobRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/TriggerJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobs(ctx context.Context, in *ListJobsReq, opts ...grpc.CallOption) (JobService_ListJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[2], "/model.JobService/ListJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceListJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_ListJobsClient interface {
+	Recv() (*ListJobsRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceListJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceListJobsClient) Recv() (*ListJobsRes, error) {
+	m := new(ListJobsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) SearchJobs(ctx context.Context, in *SearchJobsReq, opts ...grpc.CallOption) (JobService_SearchJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[3], "/model.JobService/SearchJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceSearchJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_SearchJobsClient interface {
+	Recv() (*SearchJobsRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceSearchJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceSearchJobsClient) Recv() (*SearchJobsRes, error) {
+	m := new(SearchJobsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) GetJobGraph(ctx context.Context, in *GetJobGraphReq, opts ...grpc.CallOption) (*GetJobGraphRes, error) {
+	out := new(GetJobGraphRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/GetJobGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ImportJobs(ctx context.Context, opts ...grpc.CallOption) (JobService_ImportJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[4], "/model.JobService/ImportJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceImportJobsClient{stream}
+	return x, nil
+}
+
+type JobService_ImportJobsClient interface {
+	Send(*ImportJobsReq) error
+	CloseAndRecv() (*ImportJobsRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceImportJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceImportJobsClient) Send(m *ImportJobsReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *jobServiceImportJobsClient) CloseAndRecv() (*ImportJobsRes, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportJobsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) ExportJobs(ctx context.Context, in *ExportJobsReq, opts ...grpc.CallOption) (JobService_ExportJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[5], "/model.JobService/ExportJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceExportJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_ExportJobsClient interface {
+	Recv() (*ExportJobsRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceExportJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceExportJobsClient) Recv() (*ExportJobsRes, error) {
+	m := new(ExportJobsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) WatchJobs(ctx context.Context, in *WatchJobsReq, opts ...grpc.CallOption) (JobService_WatchJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[6], "/model.JobService/WatchJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceWatchJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_WatchJobsClient interface {
+	Recv() (*WatchJobsRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceWatchJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceWatchJobsClient) Recv() (*WatchJobsRes, error) {
+	m := new(WatchJobsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) ListJobTypes(ctx context.Context, in *ListJobTypesReq, opts ...grpc.CallOption) (*ListJobTypesRes, error) {
+	out := new(ListJobTypesRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/ListJobTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetQuota(ctx context.Context, in *GetQuotaReq, opts ...grpc.CallOption) (*GetQuotaRes, error) {
+	out := new(GetQuotaRes)
+	err := c.cc.Invoke(ctx, "/model.JobService/GetQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func init() { proto.RegisterFile("job.proto", fileDescriptor_f32c477d91a04ead) }
+// JobServiceServer is the server API for JobService service.
+type JobServiceServer interface {
+	CreateJob(context.Context, *CreateJobReq) (*CreateJobRes, error)
+	ReadJob(context.Context, *ReadJobReq) (*ReadJobRes, error)
+	// Reads several jobs at once
+	BatchGetJobs(context.Context, *BatchGetJobsReq) (*BatchGetJobsRes, error)
+	// Counts the jobs of the caller without reading them
+	CountJobs(context.Context, *CountJobsReq) (*CountJobsRes, error)
+	// Tells whether a job exists without reading it
+	JobExists(context.Context, *JobExistsReq) (*JobExistsRes, error)
+	UpdateJob(context.Context, *UpdateJobReq) (*UpdateJobRes, error)
+	// Creates a copy of a job with the overridden fields, it starts out pending without runs
+	CloneJob(context.Context, *CloneJobReq) (*CloneJobRes, error)
+	// Copies the definition of a job into the job of its owner with the same name in another environment, which is
+	// created when it doesn't exist. Dependencies aren't copied, the runs of the target aren't touched.
+	PromoteJob(context.Context, *PromoteJobReq) (*PromoteJobRes, error)
+	ReadChange(context.Context, *ReadChangeReq) (*ReadChangeRes, error)
+	// Streams the changes of the jobs of the caller newest first
+	ListChanges(*ListChangesReq, JobService_ListChangesServer) error
+	// Applies a pending change to its job, the caller must be someone other than the one who requested it
+	ApproveChange(context.Context, *ApproveChangeReq) (*ApproveChangeRes, error)
+	// Discards a pending change, its job stays as it is
+	RejectChange(context.Context, *RejectChangeReq) (*RejectChangeRes, error)
+	// Streams the revisions of a job newest first
+	ListJobRevisions(*ListJobRevisionsReq, JobService_ListJobRevisionsServer) error
+	// Restores the fields UpdateJob updates to the ones of a revision, like an update that records a revision itself
+	RollbackJob(context.Context, *RollbackJobReq) (*RollbackJobRes, error)
+	DeleteJob(context.Context, *DeleteJobReq) (*DeleteJobRes, error)
+	// Deletes several jobs at once, a job that can't be deleted doesn't stop the others
+	DeleteJobs(context.Context, *DeleteJobsReq) (*DeleteJobsRes, error)
+	// Undeletes a deleted job that wasn't purged yet
+	RestoreJob(context.Context, *RestoreJobReq) (*RestoreJobRes, error)
+	// Hides a job that isn't running from ListJobs and stops the scheduler from firing it
+	ArchiveJob(context.Context, *ArchiveJobReq) (*ArchiveJobRes, error)
+	// Lists an archived job again, a scheduled one runs next at its next scheduled time from now on
+	UnarchiveJob(context.Context, *UnarchiveJobReq) (*UnarchiveJobRes, error)
+	// Archives every job of the caller matching the filters of the request
+	ArchiveJobs(context.Context, *ArchiveJobsReq) (*ArchiveJobsRes, error)
+	// Stops the scheduler from firing a job that isn't running
+	PauseJob(context.Context, *PauseJobReq) (*PauseJobRes, error)
+	// Lets the scheduler fire a paused job again, it becomes pending
+	ResumeJob(context.Context, *ResumeJobReq) (*ResumeJobRes, error)
+	// Stops the running execution of a job
+	CancelJob(context.Context, *CancelJobReq) (*CancelJobRes, error)
+	// Queues a run of a job right away, regardless of its schedule
+	TriggerJob(context.Context, *TriggerJobReq) (*TriggerJobRes, error)
+	ListJobs(*ListJobsReq, JobService_ListJobsServer) error
+	// Streams the jobs whose name or description contains the words of the query, the most relevant first
+	SearchJobs(*SearchJobsReq, JobService_SearchJobsServer) error
+	// Returns the tree of jobs the job depends on and the tree of jobs depending on it
+	GetJobGraph(context.Context, *GetJobGraphReq) (*GetJobGraphRes, error)
+	// Creates every job the client streams in batches, jobs that can't be created are reported in the response
+	ImportJobs(JobService_ImportJobsServer) error
+	// Streams every job of the caller matching the filter as CSV or NDJSON in chunks
+	ExportJobs(*ExportJobsReq, JobService_ExportJobsServer) error
+	// Streams every change of a job until the client disconnects
+	WatchJobs(*WatchJobsReq, JobService_WatchJobsServer) error
+	// Lists the handlers jobs can name and the params each of them takes
+	ListJobTypes(context.Context, *ListJobTypesReq) (*ListJobTypesRes, error)
+	// Tells how much of the quotas of the server an owner uses
+	GetQuota(context.Context, *GetQuotaReq) (*GetQuotaRes, error)
+}
 
-var fileDescriptor_f32c477d91a04ead = []byte{
-	// 317 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x4f, 0xf2, 0x40,
-	0x10, 0x0d, 0x05, 0x3e, 0x60, 0xe0, 0x33, 0x71, 0xf4, 0xd0, 0x34, 0xc4, 0x90, 0x9e, 0x88, 0x1a,
-	0x34, 0xa8, 0xbf, 0x40, 0x4f, 0x8d, 0xa7, 0x1a, 0x7f, 0x40, 0xb7, 0x3b, 0x87, 0x25, 0xd0, 0x2d,
-	0x9d, 0xaa, 0x7f, 0xc5, 0x9f, 0x6b, 0xba, 0x96, 0x75, 0x2d, 0x4d, 0x1a, 0x6f, 0x9d, 0xf7, 0xde,
-	0xbc, 0x99, 0xbe, 0xc9, 0xc2, 0x64, 0xa3, 0xc5, 0x2a, 0x2f, 0x74, 0xa9, 0x71, 0xb8, 0xd3, 0x92,
-	0xb6, 0x61, 0x02, 0xfd, 0x48, 0x0b, 0x3c, 0x01, 0x4f, 0x49, 0xbf, 0xb7, 0xe8, 0x2d, 0x27, 0xb1,
-	0xa7, 0x24, 0x22, 0x0c, 0xb2, 0x64, 0x47, 0xbe, 0x67, 0x10, 0xf3, 0x8d, 0x0b, 0x98, 0x4a, 0xe2,
-	0xb4, 0x50, 0x79, 0xa9, 0x74, 0xe6, 0xf7, 0x0d, 0xe5, 0x42, 0x78, 0x0e, 0x43, 0xfd, 0x91, 0x51,
-	0xe1, 0x0f, 0x0c, 0xf7, 0x5d, 0x84, 0xd7, 0x30, 0x7b, 0x2c, 0x28, 0x29, 0x29, 0xd2, 0x22, 0xa6,
-	0x3d, 0xce, 0xa1, 0xbf, 0xd1, 0xc2, 0x0c, 0x9b, 0xae, 0x61, 0x65, 0xf6, 0x58, 0x55, 0x5c, 0x05,
-	0x37, 0xd4, 0xdc, 0xad, 0x7e, 0xcd, 0xe5, 0x1f, 0xbc, 0x1d, 0x75, 0x97, 0xf7, 0x1c, 0x20, 0xa6,
-	0x44, 0xd6, 0xce, 0x8d, 0x84, 0xc2, 0x4b, 0x87, 0xed, 0x72, 0xba, 0x80, 0xd9, 0x13, 0x6d, 0xc9,
-	0x6e, 0xd9, 0xf4, 0x5a, 0xfe, 0xe2, 0x19, 0x7d, 0x18, 0xf1, 0x5b, 0x9a, 0x12, 0xb3, 0x11, 0x8d,
-	0xe3, 0x43, 0x19, 0xfe, 0x87, 0xe9, 0xb3, 0xe2, 0x32, 0xd2, 0x82, 0x63, 0xda, 0x87, 0x57, 0x6e,
-	0xd9, 0xb1, 0xc5, 0xfa, 0xd3, 0x03, 0x88, 0xb4, 0x78, 0xa1, 0xe2, 0x5d, 0xa5, 0x84, 0x0f, 0x30,
-	0xb1, 0x41, 0xe3, 0x59, 0x2d, 0x76, 0x0f, 0x15, 0xb4, 0x80, 0x8c, 0x37, 0x30, 0xaa, 0xff, 0x1b,
-	0x4f, 0x6b, 0xfe, 0x27, 0xa5, 0xe0, 0x08, 0xe2, 0x6a, 0x8e, 0x0d, 0xdd, 0xce, 0x71, 0x8f, 0x16,
-	0xb4, 0x80, 0xa6, 0xcd, 0x66, 0x62, 0xdb, 0xdc, 0x14, 0x83, 0x16, 0x90, 0xf1, 0x1e, 0xc6, 0x87,
-	0x44, 0x10, 0x6b, 0x81, 0x93, 0x58, 0x70, 0x8c, 0xf1, 0x6d, 0x4f, 0xfc, 0x33, 0x6f, 0xe2, 0xee,
-	0x2b, 0x00, 0x00, 0xff, 0xff, 0xcf, 0x1e, 0x57, 0x17, 0x20, 0x03, 0x00, 0x00,
+func RegisterJobServiceServer(s *grpc.Server, srv JobServiceServer) {
+	s.RegisterService(&_JobService_serviceDesc, srv)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func _JobService_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CreateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/CreateJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CreateJob(ctx, req.(*CreateJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func _JobService_ReadJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ReadJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/ReadJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ReadJob(ctx, req.(*ReadJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// JobServiceClient is the client API for JobService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type JobServiceClient interface {
-	CreateJob(ctx context.Context, in *CreateJobReq, opts ...grpc.CallOption) (*CreateJobRes, error)
-	ReadJob(ctx context.Context, in *ReadJobReq, opts ...grpc.CallOption) (*ReadJobRes, error)
-	UpdateJob(ctx context.Context, in *UpdateJobReq, opts ...grpc.CallOption) (*UpdateJobRes, error)
-	DeleteJob(ctx context.Context, in *DeleteJobReq, opts ...grpc.CallOption) (*DeleteJobRes, error)
-	ListJobs(ctx context.Context, in *ListJobsReq, opts ...grpc.CallOption) (JobService_ListJobsClient, error)
+func _JobService_BatchGetJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetJobsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).BatchGetJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/BatchGetJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).BatchGetJobs(ctx, req.(*BatchGetJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type jobServiceClient struct {
-	cc *grpc.ClientConn
+func _JobService_CountJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountJobsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CountJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/CountJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CountJobs(ctx, req.(*CountJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewJobServiceClient(cc *grpc.ClientConn) JobServiceClient {
-	return &jobServiceClient{cc}
+func _JobService_JobExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobExistsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).JobExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/JobExists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).JobExists(ctx, req.(*JobExistsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *jobServiceClient) CreateJob(ctx context.Context, in *CreateJobReq, opts ...grpc.CallOption) (*CreateJobRes, error) {
-	out := new(CreateJobRes)
-	err := c.cc.Invoke(ctx, "/model.JobService/CreateJob", in, out, opts...)
-	if err != nil {
+func _JobService_UpdateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateJobReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(JobServiceServer).UpdateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/UpdateJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).UpdateJob(ctx, req.(*UpdateJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *jobServiceClient) ReadJob(ctx context.Context, in *ReadJobReq, opts ...grpc.CallOption) (*ReadJobRes, error) {
-	out := new(ReadJobRes)
-	err := c.cc.Invoke(ctx, "/model.JobService/ReadJob", in, out, opts...)
-	if err != nil {
+func _JobService_CloneJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CloneJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/CloneJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CloneJob(ctx, req.(*CloneJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_PromoteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).PromoteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/PromoteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).PromoteJob(ctx, req.(*PromoteJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ReadChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadChangeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ReadChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/ReadChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ReadChange(ctx, req.(*ReadChangeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListChangesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).ListChanges(m, &jobServiceListChangesServer{stream})
+}
+
+type JobService_ListChangesServer interface {
+	Send(*ListChangesRes) error
+	grpc.ServerStream
+}
+
+type jobServiceListChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceListChangesServer) Send(m *ListChangesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_ApproveChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveChangeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ApproveChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/ApproveChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ApproveChange(ctx, req.(*ApproveChangeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_RejectChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectChangeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RejectChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/RejectChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RejectChange(ctx, req.(*RejectChangeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListJobRevisions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListJobRevisionsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).ListJobRevisions(m, &jobServiceListJobRevisionsServer{stream})
+}
+
+type JobService_ListJobRevisionsServer interface {
+	Send(*ListJobRevisionsRes) error
+	grpc.ServerStream
+}
+
+type jobServiceListJobRevisionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceListJobRevisionsServer) Send(m *ListJobRevisionsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_RollbackJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RollbackJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/RollbackJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RollbackJob(ctx, req.(*RollbackJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/DeleteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DeleteJob(ctx, req.(*DeleteJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DeleteJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobsReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(JobServiceServer).DeleteJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/DeleteJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DeleteJobs(ctx, req.(*DeleteJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *jobServiceClient) UpdateJob(ctx context.Context, in *UpdateJobReq, opts ...grpc.CallOption) (*UpdateJobRes, error) {
-	out := new(UpdateJobRes)
-	err := c.cc.Invoke(ctx, "/model.JobService/UpdateJob", in, out, opts...)
-	if err != nil {
+func _JobService_RestoreJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreJobReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(JobServiceServer).RestoreJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/RestoreJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RestoreJob(ctx, req.(*RestoreJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *jobServiceClient) DeleteJob(ctx context.Context, in *DeleteJobReq, opts ...grpc.CallOption) (*DeleteJobRes, error) {
-	out := new(DeleteJobRes)
-	err := c.cc.Invoke(ctx, "/model.JobService/DeleteJob", in, out, opts...)
-	if err != nil {
+func _JobService_ArchiveJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveJobReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(JobServiceServer).ArchiveJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/ArchiveJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ArchiveJob(ctx, req.(*ArchiveJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *jobServiceClient) ListJobs(ctx context.Context, in *ListJobsReq, opts ...grpc.CallOption) (JobService_ListJobsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_JobService_serviceDesc.Streams[0], "/model.JobService/ListJobs", opts...)
-	if err != nil {
+func _JobService_UnarchiveJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveJobReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &jobServiceListJobsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(JobServiceServer).UnarchiveJob(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/UnarchiveJob",
 	}
-	return x, nil
-}
-
-type JobService_ListJobsClient interface {
-	Recv() (*ListJobsRes, error)
-	grpc.ClientStream
-}
-
-type jobServiceListJobsClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).UnarchiveJob(ctx, req.(*UnarchiveJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *jobServiceListJobsClient) Recv() (*ListJobsRes, error) {
-	m := new(ListJobsRes)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _JobService_ArchiveJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveJobsReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-// JobServiceServer is the server API for JobService service.
-type JobServiceServer interface {
-	CreateJob(context.Context, *CreateJobReq) (*CreateJobRes, error)
-	ReadJob(context.Context, *ReadJobReq) (*ReadJobRes, error)
-	UpdateJob(context.Context, *UpdateJobReq) (*UpdateJobRes, error)
-	DeleteJob(context.Context, *DeleteJobReq) (*DeleteJobRes, error)
-	ListJobs(*ListJobsReq, JobService_ListJobsServer) error
-}
-
-func RegisterJobServiceServer(s *grpc.Server, srv JobServiceServer) {
-	s.RegisterService(&_JobService_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(JobServiceServer).ArchiveJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/ArchiveJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ArchiveJobs(ctx, req.(*ArchiveJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateJobReq)
+func _JobService_PauseJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseJobReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).CreateJob(ctx, in)
+		return srv.(JobServiceServer).PauseJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/model.JobService/CreateJob",
+		FullMethod: "/model.JobService/PauseJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).CreateJob(ctx, req.(*CreateJobReq))
+		return srv.(JobServiceServer).PauseJob(ctx, req.(*PauseJobReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_ReadJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadJobReq)
+func _JobService_ResumeJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeJobReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).ReadJob(ctx, in)
+		return srv.(JobServiceServer).ResumeJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/model.JobService/ReadJob",
+		FullMethod: "/model.JobService/ResumeJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).ReadJob(ctx, req.(*ReadJobReq))
+		return srv.(JobServiceServer).ResumeJob(ctx, req.(*ResumeJobReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_UpdateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateJobReq)
+func _JobService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).UpdateJob(ctx, in)
+		return srv.(JobServiceServer).CancelJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/model.JobService/UpdateJob",
+		FullMethod: "/model.JobService/CancelJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).UpdateJob(ctx, req.(*UpdateJobReq))
+		return srv.(JobServiceServer).CancelJob(ctx, req.(*CancelJobReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteJobReq)
+func _JobService_TriggerJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerJobReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).DeleteJob(ctx, in)
+		return srv.(JobServiceServer).TriggerJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/model.JobService/DeleteJob",
+		FullMethod: "/model.JobService/TriggerJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).DeleteJob(ctx, req.(*DeleteJobReq))
+		return srv.(JobServiceServer).TriggerJob(ctx, req.(*TriggerJobReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -708,6 +6082,149 @@ func (x *jobServiceListJobsServer) Send(m *ListJobsRes) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _JobService_SearchJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchJobsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).SearchJobs(m, &jobServiceSearchJobsServer{stream})
+}
+
+type JobService_SearchJobsServer interface {
+	Send(*SearchJobsRes) error
+	grpc.ServerStream
+}
+
+type jobServiceSearchJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceSearchJobsServer) Send(m *SearchJobsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_GetJobGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobGraphReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJobGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/GetJobGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJobGraph(ctx, req.(*GetJobGraphReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ImportJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServiceServer).ImportJobs(&jobServiceImportJobsServer{stream})
+}
+
+type JobService_ImportJobsServer interface {
+	SendAndClose(*ImportJobsRes) error
+	Recv() (*ImportJobsReq, error)
+	grpc.ServerStream
+}
+
+type jobServiceImportJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceImportJobsServer) SendAndClose(m *ImportJobsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *jobServiceImportJobsServer) Recv() (*ImportJobsReq, error) {
+	m := new(ImportJobsReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _JobService_ExportJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportJobsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).ExportJobs(m, &jobServiceExportJobsServer{stream})
+}
+
+type JobService_ExportJobsServer interface {
+	Send(*ExportJobsRes) error
+	grpc.ServerStream
+}
+
+type jobServiceExportJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceExportJobsServer) Send(m *ExportJobsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_WatchJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).WatchJobs(m, &jobServiceWatchJobsServer{stream})
+}
+
+type JobService_WatchJobsServer interface {
+	Send(*WatchJobsRes) error
+	grpc.ServerStream
+}
+
+type jobServiceWatchJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceWatchJobsServer) Send(m *WatchJobsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_ListJobTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobTypesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/ListJobTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobTypes(ctx, req.(*ListJobTypesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.JobService/GetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetQuota(ctx, req.(*GetQuotaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _JobService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "model.JobService",
 	HandlerType: (*JobServiceServer)(nil),
@@ -720,21 +6237,135 @@ var _JobService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReadJob",
 			Handler:    _JobService_ReadJob_Handler,
 		},
+		{
+			MethodName: "BatchGetJobs",
+			Handler:    _JobService_BatchGetJobs_Handler,
+		},
+		{
+			MethodName: "CountJobs",
+			Handler:    _JobService_CountJobs_Handler,
+		},
+		{
+			MethodName: "JobExists",
+			Handler:    _JobService_JobExists_Handler,
+		},
 		{
 			MethodName: "UpdateJob",
 			Handler:    _JobService_UpdateJob_Handler,
 		},
+		{
+			MethodName: "CloneJob",
+			Handler:    _JobService_CloneJob_Handler,
+		},
+		{
+			MethodName: "PromoteJob",
+			Handler:    _JobService_PromoteJob_Handler,
+		},
+		{
+			MethodName: "ReadChange",
+			Handler:    _JobService_ReadChange_Handler,
+		},
+		{
+			MethodName: "ApproveChange",
+			Handler:    _JobService_ApproveChange_Handler,
+		},
+		{
+			MethodName: "RejectChange",
+			Handler:    _JobService_RejectChange_Handler,
+		},
+		{
+			MethodName: "RollbackJob",
+			Handler:    _JobService_RollbackJob_Handler,
+		},
 		{
 			MethodName: "DeleteJob",
 			Handler:    _JobService_DeleteJob_Handler,
 		},
+		{
+			MethodName: "DeleteJobs",
+			Handler:    _JobService_DeleteJobs_Handler,
+		},
+		{
+			MethodName: "RestoreJob",
+			Handler:    _JobService_RestoreJob_Handler,
+		},
+		{
+			MethodName: "ArchiveJob",
+			Handler:    _JobService_ArchiveJob_Handler,
+		},
+		{
+			MethodName: "UnarchiveJob",
+			Handler:    _JobService_UnarchiveJob_Handler,
+		},
+		{
+			MethodName: "ArchiveJobs",
+			Handler:    _JobService_ArchiveJobs_Handler,
+		},
+		{
+			MethodName: "PauseJob",
+			Handler:    _JobService_PauseJob_Handler,
+		},
+		{
+			MethodName: "ResumeJob",
+			Handler:    _JobService_ResumeJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _JobService_CancelJob_Handler,
+		},
+		{
+			MethodName: "TriggerJob",
+			Handler:    _JobService_TriggerJob_Handler,
+		},
+		{
+			MethodName: "GetJobGraph",
+			Handler:    _JobService_GetJobGraph_Handler,
+		},
+		{
+			MethodName: "ListJobTypes",
+			Handler:    _JobService_ListJobTypes_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _JobService_GetQuota_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListChanges",
+			Handler:       _JobService_ListChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListJobRevisions",
+			Handler:       _JobService_ListJobRevisions_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListJobs",
 			Handler:       _JobService_ListJobs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SearchJobs",
+			Handler:       _JobService_SearchJobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportJobs",
+			Handler:       _JobService_ImportJobs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportJobs",
+			Handler:       _JobService_ExportJobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchJobs",
+			Handler:       _JobService_WatchJobs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "job.proto",
 }
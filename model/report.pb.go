@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: report.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ReportPeriod is the time a report covers, weeks start on Monday and both follow the wall clock of UTC
+type ReportPeriod int32
+
+const (
+	// Defaults to weeks
+	ReportPeriod_REPORT_PERIOD_UNSPECIFIED ReportPeriod = 0
+	ReportPeriod_REPORT_PERIOD_WEEK        ReportPeriod = 1
+	ReportPeriod_REPORT_PERIOD_MONTH       ReportPeriod = 2
+)
+
+var ReportPeriod_name = map[int32]string{
+	0: "REPORT_PERIOD_UNSPECIFIED",
+	1: "REPORT_PERIOD_WEEK",
+	2: "REPORT_PERIOD_MONTH",
+}
+
+var ReportPeriod_value = map[string]int32{
+	"REPORT_PERIOD_UNSPECIFIED": 0,
+	"REPORT_PERIOD_WEEK":        1,
+	"REPORT_PERIOD_MONTH":       2,
+}
+
+func (x ReportPeriod) String() string {
+	return proto.EnumName(ReportPeriod_name, int32(x))
+}
+
+func (ReportPeriod) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{0}
+}
+
+// ReportFormat is the file format a report is written in
+type ReportFormat int32
+
+const (
+	// Defaults to CSV
+	ReportFormat_REPORT_FORMAT_UNSPECIFIED ReportFormat = 0
+	// Sections of a summary, the success rates of the jobs, the slowest jobs and the SLA breaches, each starting with
+	// a row holding its name and a header row
+	ReportFormat_REPORT_FORMAT_CSV ReportFormat = 1
+	ReportFormat_REPORT_FORMAT_PDF ReportFormat = 2
+)
+
+var ReportFormat_name = map[int32]string{
+	0: "REPORT_FORMAT_UNSPECIFIED",
+	1: "REPORT_FORMAT_CSV",
+	2: "REPORT_FORMAT_PDF",
+}
+
+var ReportFormat_value = map[string]int32{
+	"REPORT_FORMAT_UNSPECIFIED": 0,
+	"REPORT_FORMAT_CSV":         1,
+	"REPORT_FORMAT_PDF":         2,
+}
+
+func (x ReportFormat) String() string {
+	return proto.EnumName(ReportFormat_name, int32(x))
+}
+
+func (ReportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{1}
+}
+
+type GenerateReportReq struct {
+	Period ReportPeriod `protobuf:"varint,1,opt,name=period,proto3,enum=model.ReportPeriod" json:"period,omitempty"`
+	Format ReportFormat `protobuf:"varint,2,opt,name=format,proto3,enum=model.ReportFormat" json:"format,omitempty"`
+	// The report covers the last full week or month that ended at or before end_time, defaults to now
+	EndTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only report the jobs of this owner. Defaults to every job the caller may read, only admins may ask for owners
+	// other than themselves and their teams.
+	Owner                string   `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateReportReq) Reset()         { *m = GenerateReportReq{} }
+func (m *GenerateReportReq) String() string { return proto.CompactTextString(m) }
+func (*GenerateReportReq) ProtoMessage()    {}
+func (*GenerateReportReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{0}
+}
+
+func (m *GenerateReportReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateReportReq.Unmarshal(m, b)
+}
+func (m *GenerateReportReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateReportReq.Marshal(b, m, deterministic)
+}
+func (m *GenerateReportReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateReportReq.Merge(m, src)
+}
+func (m *GenerateReportReq) XXX_Size() int {
+	return xxx_messageInfo_GenerateReportReq.Size(m)
+}
+func (m *GenerateReportReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateReportReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateReportReq proto.InternalMessageInfo
+
+func (m *GenerateReportReq) GetPeriod() ReportPeriod {
+	if m != nil {
+		return m.Period
+	}
+	return ReportPeriod_REPORT_PERIOD_UNSPECIFIED
+}
+
+func (m *GenerateReportReq) GetFormat() ReportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ReportFormat_REPORT_FORMAT_UNSPECIFIED
+}
+
+func (m *GenerateReportReq) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *GenerateReportReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+// GenerateReportRes is a chunk of the report file, the chunks joined in order are the file
+type GenerateReportRes struct {
+	// Name and MIME type of the file, only set on the first message
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType          string   `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateReportRes) Reset()         { *m = GenerateReportRes{} }
+func (m *GenerateReportRes) String() string { return proto.CompactTextString(m) }
+func (*GenerateReportRes) ProtoMessage()    {}
+func (*GenerateReportRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{1}
+}
+
+func (m *GenerateReportRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateReportRes.Unmarshal(m, b)
+}
+func (m *GenerateReportRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateReportRes.Marshal(b, m, deterministic)
+}
+func (m *GenerateReportRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateReportRes.Merge(m, src)
+}
+func (m *GenerateReportRes) XXX_Size() int {
+	return xxx_messageInfo_GenerateReportRes.Size(m)
+}
+func (m *GenerateReportRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateReportRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateReportRes proto.InternalMessageInfo
+
+func (m *GenerateReportRes) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *GenerateReportRes) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *GenerateReportRes) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("model.ReportPeriod", ReportPeriod_name, ReportPeriod_value)
+	proto.RegisterEnum("model.ReportFormat", ReportFormat_name, ReportFormat_value)
+	proto.RegisterType((*GenerateReportReq)(nil), "model.GenerateReportReq")
+	proto.RegisterType((*GenerateReportRes)(nil), "model.GenerateReportRes")
+}
+
+func init() { proto.RegisterFile("report.proto", fileDescriptor_3eedb623aa6ca98c) }
+
+var fileDescriptor_3eedb623aa6ca98c = []byte{
+	// 415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xd1, 0x6e, 0xd3, 0x30,
+	0x14, 0x86, 0x71, 0xd9, 0xc6, 0x7a, 0x16, 0xa6, 0xcc, 0x1b, 0x23, 0x44, 0x43, 0x94, 0x5e, 0x55,
+	0x43, 0x4a, 0xa0, 0x88, 0x1b, 0xee, 0xd0, 0x9a, 0x40, 0x85, 0xb6, 0x44, 0x6e, 0x80, 0x0b, 0x24,
+	0x22, 0x6f, 0x39, 0xa9, 0x22, 0x35, 0x76, 0x70, 0xbc, 0xa1, 0xdd, 0xf2, 0x0a, 0xbc, 0x0d, 0xaf,
+	0xc1, 0x2b, 0xf0, 0x20, 0xa8, 0x4e, 0x86, 0x1a, 0xca, 0xee, 0x72, 0xce, 0xff, 0xe9, 0xe4, 0xb3,
+	0x7d, 0xc0, 0x52, 0x58, 0x49, 0xa5, 0xbd, 0x4a, 0x49, 0x2d, 0xe9, 0x66, 0x29, 0x33, 0x5c, 0xb8,
+	0x47, 0x73, 0x29, 0xe7, 0x0b, 0xf4, 0x79, 0x55, 0xf8, 0x5c, 0x08, 0xa9, 0xb9, 0x2e, 0xa4, 0xa8,
+	0x1b, 0xc8, 0x7d, 0xd2, 0xa6, 0xa6, 0x3a, 0xbf, 0xcc, 0x7d, 0x5d, 0x94, 0x58, 0x6b, 0x5e, 0x56,
+	0x0d, 0x30, 0xfc, 0x49, 0x60, 0xef, 0x2d, 0x0a, 0x54, 0x5c, 0x23, 0x33, 0xe3, 0x19, 0x7e, 0xa5,
+	0xcf, 0x60, 0xab, 0x42, 0x55, 0xc8, 0xcc, 0x21, 0x03, 0x32, 0xda, 0x1d, 0xef, 0x7b, 0xe6, 0x67,
+	0x5e, 0x43, 0xc4, 0x26, 0x62, 0x2d, 0xb2, 0x84, 0x73, 0xa9, 0x4a, 0xae, 0x9d, 0xde, 0x7f, 0xe0,
+	0xd0, 0x44, 0xac, 0x45, 0xe8, 0x2b, 0xd8, 0x46, 0x91, 0xa5, 0x4b, 0x0d, 0xe7, 0xee, 0x80, 0x8c,
+	0x76, 0xc6, 0xae, 0xd7, 0x38, 0x7a, 0x37, 0x8e, 0x5e, 0x72, 0xe3, 0xc8, 0xee, 0xa1, 0xc8, 0x96,
+	0x15, 0x3d, 0x80, 0x4d, 0xf9, 0x4d, 0xa0, 0x72, 0x36, 0x06, 0x64, 0xd4, 0x67, 0x4d, 0x31, 0xcc,
+	0xd7, 0xdd, 0x6b, 0xea, 0xc2, 0x76, 0x5e, 0x2c, 0x50, 0xf0, 0x12, 0x8d, 0x7d, 0x9f, 0xfd, 0xad,
+	0xe9, 0x53, 0xb0, 0x2e, 0xa4, 0xd0, 0x28, 0x74, 0xaa, 0xaf, 0x2b, 0x34, 0xc2, 0x7d, 0xb6, 0xd3,
+	0xf6, 0x92, 0xeb, 0x0a, 0x29, 0x85, 0x8d, 0x8c, 0x6b, 0x6e, 0xe4, 0x2c, 0x66, 0xbe, 0x8f, 0xbf,
+	0x80, 0xb5, 0x7a, 0x72, 0xfa, 0x18, 0x1e, 0xb1, 0x20, 0x8e, 0x58, 0x92, 0xc6, 0x01, 0x9b, 0x46,
+	0x93, 0xf4, 0xc3, 0xd9, 0x2c, 0x0e, 0x4e, 0xa6, 0xe1, 0x34, 0x98, 0xd8, 0x77, 0xe8, 0x21, 0xd0,
+	0x6e, 0xfc, 0x29, 0x08, 0xde, 0xdb, 0x84, 0x3e, 0x84, 0xfd, 0x6e, 0xff, 0x34, 0x3a, 0x4b, 0xde,
+	0xd9, 0xbd, 0xe3, 0xcf, 0x60, 0xad, 0x5e, 0xd6, 0xca, 0xfc, 0x30, 0x62, 0xa7, 0x6f, 0x92, 0x7f,
+	0xe6, 0x3f, 0x80, 0xbd, 0x6e, 0x7c, 0x32, 0xfb, 0x68, 0x93, 0xf5, 0x76, 0x3c, 0x09, 0xed, 0xde,
+	0xf8, 0x12, 0xee, 0x37, 0xc3, 0x67, 0xa8, 0xae, 0x8a, 0x0b, 0xa4, 0x19, 0xec, 0x76, 0x6f, 0x8d,
+	0x3a, 0xed, 0x8b, 0xad, 0x2d, 0x82, 0x7b, 0x5b, 0x52, 0x0f, 0x8f, 0xbe, 0xff, 0xfa, 0xfd, 0xa3,
+	0x77, 0x48, 0x0f, 0xfc, 0xab, 0x17, 0x7e, 0xb3, 0x98, 0xf5, 0xeb, 0x79, 0x8b, 0x3d, 0x27, 0xe7,
+	0x5b, 0xe6, 0x39, 0x5f, 0xfe, 0x19, 0x00, 0x4f, 0xa1, 0x34, 0x18, 0xb5, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReportServiceClient interface {
+	// GenerateReport builds the report of a week or month on demand and streams the file in chunks of up to 64KiB
+	GenerateReport(ctx context.Context, in *GenerateReportReq, opts ...grpc.CallOption) (ReportService_GenerateReportClient, error)
+}
+
+type reportServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewReportServiceClient(cc *grpc.ClientConn) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) GenerateReport(ctx context.Context, in *GenerateReportReq, opts ...grpc.CallOption) (ReportService_GenerateReportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReportService_serviceDesc.Streams[0], "/model.ReportService/GenerateReport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reportServiceGenerateReportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReportService_GenerateReportClient interface {
+	Recv() (*GenerateReportRes, error)
+	grpc.ClientStream
+}
+
+type reportServiceGenerateReportClient struct {
+	grpc.ClientStream
+}
+
+func (x *reportServiceGenerateReportClient) Recv() (*GenerateReportRes, error) {
+	m := new(GenerateReportRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReportServiceServer is the server API for ReportService service.
+type ReportServiceServer interface {
+	// GenerateReport builds the report of a week or month on demand and streams the file in chunks of up to 64KiB
+	GenerateReport(*GenerateReportReq, ReportService_GenerateReportServer) error
+}
+
+func RegisterReportServiceServer(s *grpc.Server, srv ReportServiceServer) {
+	s.RegisterService(&_ReportService_serviceDesc, srv)
+}
+
+func _ReportService_GenerateReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateReportReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReportServiceServer).GenerateReport(m, &reportServiceGenerateReportServer{stream})
+}
+
+type ReportService_GenerateReportServer interface {
+	Send(*GenerateReportRes) error
+	grpc.ServerStream
+}
+
+type reportServiceGenerateReportServer struct {
+	grpc.ServerStream
+}
+
+func (x *reportServiceGenerateReportServer) Send(m *GenerateReportRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ReportService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateReport",
+			Handler:       _ReportService_GenerateReport_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "report.proto",
+}
@@ -0,0 +1,1246 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: run.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type RunStatus int32
+
+const (
+	RunStatus_RUN_STATUS_UNSPECIFIED RunStatus = 0
+	RunStatus_RUN_STATUS_QUEUED      RunStatus = 1
+	RunStatus_RUN_STATUS_RUNNING     RunStatus = 2
+	RunStatus_RUN_STATUS_SUCCEEDED   RunStatus = 3
+	RunStatus_RUN_STATUS_FAILED      RunStatus = 4
+	RunStatus_RUN_STATUS_CANCELLED   RunStatus = 5
+	// A retry of a failed run waiting for its backoff to pass
+	RunStatus_RUN_STATUS_WAITING RunStatus = 6
+	// The run was stopped because it took longer than the timeout of its job
+	RunStatus_RUN_STATUS_TIMED_OUT RunStatus = 7
+)
+
+var RunStatus_name = map[int32]string{
+	0: "RUN_STATUS_UNSPECIFIED",
+	1: "RUN_STATUS_QUEUED",
+	2: "RUN_STATUS_RUNNING",
+	3: "RUN_STATUS_SUCCEEDED",
+	4: "RUN_STATUS_FAILED",
+	5: "RUN_STATUS_CANCELLED",
+	6: "RUN_STATUS_WAITING",
+	7: "RUN_STATUS_TIMED_OUT",
+}
+
+var RunStatus_value = map[string]int32{
+	"RUN_STATUS_UNSPECIFIED": 0,
+	"RUN_STATUS_QUEUED":      1,
+	"RUN_STATUS_RUNNING":     2,
+	"RUN_STATUS_SUCCEEDED":   3,
+	"RUN_STATUS_FAILED":      4,
+	"RUN_STATUS_CANCELLED":   5,
+	"RUN_STATUS_WAITING":     6,
+	"RUN_STATUS_TIMED_OUT":   7,
+}
+
+func (x RunStatus) String() string {
+	return proto.EnumName(RunStatus_name, int32(x))
+}
+
+func (RunStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{0}
+}
+
+// RunTrigger tells what started a run, retries keep the trigger of the run they retry
+type RunTrigger int32
+
+const (
+	// Runs recorded before triggers existed have none
+	RunTrigger_RUN_TRIGGER_UNSPECIFIED RunTrigger = 0
+	// The scheduler fired the job
+	RunTrigger_RUN_TRIGGER_SCHEDULE RunTrigger = 1
+	// A client called TriggerJob
+	RunTrigger_RUN_TRIGGER_MANUAL RunTrigger = 2
+	// The jobs the job depends on succeeded
+	RunTrigger_RUN_TRIGGER_DEPENDENCY RunTrigger = 3
+)
+
+var RunTrigger_name = map[int32]string{
+	0: "RUN_TRIGGER_UNSPECIFIED",
+	1: "RUN_TRIGGER_SCHEDULE",
+	2: "RUN_TRIGGER_MANUAL",
+	3: "RUN_TRIGGER_DEPENDENCY",
+}
+
+var RunTrigger_value = map[string]int32{
+	"RUN_TRIGGER_UNSPECIFIED": 0,
+	"RUN_TRIGGER_SCHEDULE":    1,
+	"RUN_TRIGGER_MANUAL":      2,
+	"RUN_TRIGGER_DEPENDENCY":  3,
+}
+
+func (x RunTrigger) String() string {
+	return proto.EnumName(RunTrigger_name, int32(x))
+}
+
+func (RunTrigger) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{1}
+}
+
+// JobRun records a single attempt to execute a job, every retry of a failed run is a new JobRun
+type JobRun struct {
+	Id        string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId     string               `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status    RunStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=model.RunStatus" json:"status,omitempty"`
+	QueuedAt  *timestamp.Timestamp `protobuf:"bytes,4,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	StartTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamp.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Combined stdout and stderr of the handler, truncated to 64KiB
+	Output string `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+	Error  string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// Counts the attempts from 1, retries of a failed run have the next higher attempt
+	Attempt int32 `protobuf:"varint,9,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// Only set for retries, the time the retry is executed at the earliest
+	RetryAt *timestamp.Timestamp `protobuf:"bytes,10,opt,name=retry_at,json=retryAt,proto3" json:"retry_at,omitempty"`
+	// Timeout of the job when the run started, unset when runs weren't limited
+	Timeout *duration.Duration `protobuf:"bytes,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Time between start_time and end_time, set once the run finished
+	Duration *duration.Duration `protobuf:"bytes,12,opt,name=duration,proto3" json:"duration,omitempty"`
+	// ID of the first run of the scheduling cycle, retries and the runs of dependent jobs share the cycle of the run
+	// they follow
+	CycleId string `protobuf:"bytes,13,opt,name=cycle_id,json=cycleId,proto3" json:"cycle_id,omitempty"`
+	// Priority of the job when the run was queued
+	Priority JobPriority `protobuf:"varint,14,opt,name=priority,proto3,enum=model.JobPriority" json:"priority,omitempty"`
+	Trigger  RunTrigger  `protobuf:"varint,15,opt,name=trigger,proto3,enum=model.RunTrigger" json:"trigger,omitempty"`
+	// Subject of the caller that triggered a manual run, empty without authentication
+	TriggeredBy string `protobuf:"bytes,16,opt,name=triggered_by,json=triggeredBy,proto3" json:"triggered_by,omitempty"`
+	// Executor worker that ran the run as <host>/<index>, empty for runs that never started
+	Worker string `protobuf:"bytes,17,opt,name=worker,proto3" json:"worker,omitempty"`
+	// Cost of a second of compute time when the run started and what the run cost in total, set once it finished
+	CostPerSecond        float64  `protobuf:"fixed64,18,opt,name=cost_per_second,json=costPerSecond,proto3" json:"cost_per_second,omitempty"`
+	Cost                 float64  `protobuf:"fixed64,19,opt,name=cost,proto3" json:"cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobRun) Reset()         { *m = JobRun{} }
+func (m *JobRun) String() string { return proto.CompactTextString(m) }
+func (*JobRun) ProtoMessage()    {}
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{0}
+}
+
+func (m *JobRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobRun.Unmarshal(m, b)
+}
+func (m *JobRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobRun.Marshal(b, m, deterministic)
+}
+func (m *JobRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRun.Merge(m, src)
+}
+func (m *JobRun) XXX_Size() int {
+	return xxx_messageInfo_JobRun.Size(m)
+}
+func (m *JobRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRun proto.InternalMessageInfo
+
+func (m *JobRun) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *JobRun) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobRun) GetStatus() RunStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RunStatus_RUN_STATUS_UNSPECIFIED
+}
+
+func (m *JobRun) GetQueuedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.QueuedAt
+	}
+	return nil
+}
+
+func (m *JobRun) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *JobRun) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *JobRun) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *JobRun) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *JobRun) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *JobRun) GetRetryAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RetryAt
+	}
+	return nil
+}
+
+func (m *JobRun) GetTimeout() *duration.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *JobRun) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *JobRun) GetCycleId() string {
+	if m != nil {
+		return m.CycleId
+	}
+	return ""
+}
+
+func (m *JobRun) GetPriority() JobPriority {
+	if m != nil {
+		return m.Priority
+	}
+	return JobPriority_JOB_PRIORITY_UNSPECIFIED
+}
+
+func (m *JobRun) GetTrigger() RunTrigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return RunTrigger_RUN_TRIGGER_UNSPECIFIED
+}
+
+func (m *JobRun) GetTriggeredBy() string {
+	if m != nil {
+		return m.TriggeredBy
+	}
+	return ""
+}
+
+func (m *JobRun) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *JobRun) GetCostPerSecond() float64 {
+	if m != nil {
+		return m.CostPerSecond
+	}
+	return 0
+}
+
+func (m *JobRun) GetCost() float64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+type ListJobRunsReq struct {
+	// Only list runs of this job, all runs when empty
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Maximum number of runs to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue listing after the last run returned
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobRunsReq) Reset()         { *m = ListJobRunsReq{} }
+func (m *ListJobRunsReq) String() string { return proto.CompactTextString(m) }
+func (*ListJobRunsReq) ProtoMessage()    {}
+func (*ListJobRunsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{1}
+}
+
+func (m *ListJobRunsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobRunsReq.Unmarshal(m, b)
+}
+func (m *ListJobRunsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobRunsReq.Marshal(b, m, deterministic)
+}
+func (m *ListJobRunsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobRunsReq.Merge(m, src)
+}
+func (m *ListJobRunsReq) XXX_Size() int {
+	return xxx_messageInfo_ListJobRunsReq.Size(m)
+}
+func (m *ListJobRunsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobRunsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobRunsReq proto.InternalMessageInfo
+
+func (m *ListJobRunsReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ListJobRunsReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListJobRunsReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListJobRunsRes struct {
+	Run *JobRun `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	// Only set on the last message of a page when more runs are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobRunsRes) Reset()         { *m = ListJobRunsRes{} }
+func (m *ListJobRunsRes) String() string { return proto.CompactTextString(m) }
+func (*ListJobRunsRes) ProtoMessage()    {}
+func (*ListJobRunsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{2}
+}
+
+func (m *ListJobRunsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobRunsRes.Unmarshal(m, b)
+}
+func (m *ListJobRunsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobRunsRes.Marshal(b, m, deterministic)
+}
+func (m *ListJobRunsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobRunsRes.Merge(m, src)
+}
+func (m *ListJobRunsRes) XXX_Size() int {
+	return xxx_messageInfo_ListJobRunsRes.Size(m)
+}
+func (m *ListJobRunsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobRunsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobRunsRes proto.InternalMessageInfo
+
+func (m *ListJobRunsRes) GetRun() *JobRun {
+	if m != nil {
+		return m.Run
+	}
+	return nil
+}
+
+func (m *ListJobRunsRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetJobRunReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRunReq) Reset()         { *m = GetJobRunReq{} }
+func (m *GetJobRunReq) String() string { return proto.CompactTextString(m) }
+func (*GetJobRunReq) ProtoMessage()    {}
+func (*GetJobRunReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{3}
+}
+
+func (m *GetJobRunReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRunReq.Unmarshal(m, b)
+}
+func (m *GetJobRunReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRunReq.Marshal(b, m, deterministic)
+}
+func (m *GetJobRunReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRunReq.Merge(m, src)
+}
+func (m *GetJobRunReq) XXX_Size() int {
+	return xxx_messageInfo_GetJobRunReq.Size(m)
+}
+func (m *GetJobRunReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRunReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRunReq proto.InternalMessageInfo
+
+func (m *GetJobRunReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetJobRunRes struct {
+	Run                  *JobRun  `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRunRes) Reset()         { *m = GetJobRunRes{} }
+func (m *GetJobRunRes) String() string { return proto.CompactTextString(m) }
+func (*GetJobRunRes) ProtoMessage()    {}
+func (*GetJobRunRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{4}
+}
+
+func (m *GetJobRunRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRunRes.Unmarshal(m, b)
+}
+func (m *GetJobRunRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRunRes.Marshal(b, m, deterministic)
+}
+func (m *GetJobRunRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRunRes.Merge(m, src)
+}
+func (m *GetJobRunRes) XXX_Size() int {
+	return xxx_messageInfo_GetJobRunRes.Size(m)
+}
+func (m *GetJobRunRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRunRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRunRes proto.InternalMessageInfo
+
+func (m *GetJobRunRes) GetRun() *JobRun {
+	if m != nil {
+		return m.Run
+	}
+	return nil
+}
+
+// RunRetentionPolicy limits how long the finished runs of jobs are kept. Queued, waiting and running runs are never
+// removed.
+type RunRetentionPolicy struct {
+	// The job the policy applies to. The policy without a job is the default of the tenant, which applies to every job
+	// without a policy of its own.
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Number of finished runs kept of every job, newest first. Any number is kept when it's 0.
+	KeepRuns int32 `protobuf:"varint,2,opt,name=keep_runs,json=keepRuns,proto3" json:"keep_runs,omitempty"`
+	// How long finished runs are kept after they were queued, at least an hour. Runs of any age are kept when it's unset.
+	MaxAge *duration.Duration `protobuf:"bytes,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Set by the server
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RunRetentionPolicy) Reset()         { *m = RunRetentionPolicy{} }
+func (m *RunRetentionPolicy) String() string { return proto.CompactTextString(m) }
+func (*RunRetentionPolicy) ProtoMessage()    {}
+func (*RunRetentionPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{5}
+}
+
+func (m *RunRetentionPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunRetentionPolicy.Unmarshal(m, b)
+}
+func (m *RunRetentionPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunRetentionPolicy.Marshal(b, m, deterministic)
+}
+func (m *RunRetentionPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunRetentionPolicy.Merge(m, src)
+}
+func (m *RunRetentionPolicy) XXX_Size() int {
+	return xxx_messageInfo_RunRetentionPolicy.Size(m)
+}
+func (m *RunRetentionPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunRetentionPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunRetentionPolicy proto.InternalMessageInfo
+
+func (m *RunRetentionPolicy) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *RunRetentionPolicy) GetKeepRuns() int32 {
+	if m != nil {
+		return m.KeepRuns
+	}
+	return 0
+}
+
+func (m *RunRetentionPolicy) GetMaxAge() *duration.Duration {
+	if m != nil {
+		return m.MaxAge
+	}
+	return nil
+}
+
+func (m *RunRetentionPolicy) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetRunRetentionPolicyReq struct {
+	// Empty for the default of the tenant
+	JobId                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRunRetentionPolicyReq) Reset()         { *m = GetRunRetentionPolicyReq{} }
+func (m *GetRunRetentionPolicyReq) String() string { return proto.CompactTextString(m) }
+func (*GetRunRetentionPolicyReq) ProtoMessage()    {}
+func (*GetRunRetentionPolicyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{6}
+}
+
+func (m *GetRunRetentionPolicyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunRetentionPolicyReq.Unmarshal(m, b)
+}
+func (m *GetRunRetentionPolicyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunRetentionPolicyReq.Marshal(b, m, deterministic)
+}
+func (m *GetRunRetentionPolicyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunRetentionPolicyReq.Merge(m, src)
+}
+func (m *GetRunRetentionPolicyReq) XXX_Size() int {
+	return xxx_messageInfo_GetRunRetentionPolicyReq.Size(m)
+}
+func (m *GetRunRetentionPolicyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunRetentionPolicyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunRetentionPolicyReq proto.InternalMessageInfo
+
+func (m *GetRunRetentionPolicyReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type GetRunRetentionPolicyRes struct {
+	Policy               *RunRetentionPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetRunRetentionPolicyRes) Reset()         { *m = GetRunRetentionPolicyRes{} }
+func (m *GetRunRetentionPolicyRes) String() string { return proto.CompactTextString(m) }
+func (*GetRunRetentionPolicyRes) ProtoMessage()    {}
+func (*GetRunRetentionPolicyRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{7}
+}
+
+func (m *GetRunRetentionPolicyRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunRetentionPolicyRes.Unmarshal(m, b)
+}
+func (m *GetRunRetentionPolicyRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunRetentionPolicyRes.Marshal(b, m, deterministic)
+}
+func (m *GetRunRetentionPolicyRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunRetentionPolicyRes.Merge(m, src)
+}
+func (m *GetRunRetentionPolicyRes) XXX_Size() int {
+	return xxx_messageInfo_GetRunRetentionPolicyRes.Size(m)
+}
+func (m *GetRunRetentionPolicyRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunRetentionPolicyRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunRetentionPolicyRes proto.InternalMessageInfo
+
+func (m *GetRunRetentionPolicyRes) GetPolicy() *RunRetentionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type SetRunRetentionPolicyReq struct {
+	Policy               *RunRetentionPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SetRunRetentionPolicyReq) Reset()         { *m = SetRunRetentionPolicyReq{} }
+func (m *SetRunRetentionPolicyReq) String() string { return proto.CompactTextString(m) }
+func (*SetRunRetentionPolicyReq) ProtoMessage()    {}
+func (*SetRunRetentionPolicyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{8}
+}
+
+func (m *SetRunRetentionPolicyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRunRetentionPolicyReq.Unmarshal(m, b)
+}
+func (m *SetRunRetentionPolicyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRunRetentionPolicyReq.Marshal(b, m, deterministic)
+}
+func (m *SetRunRetentionPolicyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRunRetentionPolicyReq.Merge(m, src)
+}
+func (m *SetRunRetentionPolicyReq) XXX_Size() int {
+	return xxx_messageInfo_SetRunRetentionPolicyReq.Size(m)
+}
+func (m *SetRunRetentionPolicyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRunRetentionPolicyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRunRetentionPolicyReq proto.InternalMessageInfo
+
+func (m *SetRunRetentionPolicyReq) GetPolicy() *RunRetentionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type SetRunRetentionPolicyRes struct {
+	Policy               *RunRetentionPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SetRunRetentionPolicyRes) Reset()         { *m = SetRunRetentionPolicyRes{} }
+func (m *SetRunRetentionPolicyRes) String() string { return proto.CompactTextString(m) }
+func (*SetRunRetentionPolicyRes) ProtoMessage()    {}
+func (*SetRunRetentionPolicyRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{9}
+}
+
+func (m *SetRunRetentionPolicyRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRunRetentionPolicyRes.Unmarshal(m, b)
+}
+func (m *SetRunRetentionPolicyRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRunRetentionPolicyRes.Marshal(b, m, deterministic)
+}
+func (m *SetRunRetentionPolicyRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRunRetentionPolicyRes.Merge(m, src)
+}
+func (m *SetRunRetentionPolicyRes) XXX_Size() int {
+	return xxx_messageInfo_SetRunRetentionPolicyRes.Size(m)
+}
+func (m *SetRunRetentionPolicyRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRunRetentionPolicyRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRunRetentionPolicyRes proto.InternalMessageInfo
+
+func (m *SetRunRetentionPolicyRes) GetPolicy() *RunRetentionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type DeleteRunRetentionPolicyReq struct {
+	// Empty for the default of the tenant
+	JobId                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRunRetentionPolicyReq) Reset()         { *m = DeleteRunRetentionPolicyReq{} }
+func (m *DeleteRunRetentionPolicyReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteRunRetentionPolicyReq) ProtoMessage()    {}
+func (*DeleteRunRetentionPolicyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{10}
+}
+
+func (m *DeleteRunRetentionPolicyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRunRetentionPolicyReq.Unmarshal(m, b)
+}
+func (m *DeleteRunRetentionPolicyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRunRetentionPolicyReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteRunRetentionPolicyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRunRetentionPolicyReq.Merge(m, src)
+}
+func (m *DeleteRunRetentionPolicyReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteRunRetentionPolicyReq.Size(m)
+}
+func (m *DeleteRunRetentionPolicyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRunRetentionPolicyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRunRetentionPolicyReq proto.InternalMessageInfo
+
+func (m *DeleteRunRetentionPolicyReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type DeleteRunRetentionPolicyRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRunRetentionPolicyRes) Reset()         { *m = DeleteRunRetentionPolicyRes{} }
+func (m *DeleteRunRetentionPolicyRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteRunRetentionPolicyRes) ProtoMessage()    {}
+func (*DeleteRunRetentionPolicyRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{11}
+}
+
+func (m *DeleteRunRetentionPolicyRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRunRetentionPolicyRes.Unmarshal(m, b)
+}
+func (m *DeleteRunRetentionPolicyRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRunRetentionPolicyRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteRunRetentionPolicyRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRunRetentionPolicyRes.Merge(m, src)
+}
+func (m *DeleteRunRetentionPolicyRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteRunRetentionPolicyRes.Size(m)
+}
+func (m *DeleteRunRetentionPolicyRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRunRetentionPolicyRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRunRetentionPolicyRes proto.InternalMessageInfo
+
+type ListRunRetentionPoliciesReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRunRetentionPoliciesReq) Reset()         { *m = ListRunRetentionPoliciesReq{} }
+func (m *ListRunRetentionPoliciesReq) String() string { return proto.CompactTextString(m) }
+func (*ListRunRetentionPoliciesReq) ProtoMessage()    {}
+func (*ListRunRetentionPoliciesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{12}
+}
+
+func (m *ListRunRetentionPoliciesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunRetentionPoliciesReq.Unmarshal(m, b)
+}
+func (m *ListRunRetentionPoliciesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunRetentionPoliciesReq.Marshal(b, m, deterministic)
+}
+func (m *ListRunRetentionPoliciesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunRetentionPoliciesReq.Merge(m, src)
+}
+func (m *ListRunRetentionPoliciesReq) XXX_Size() int {
+	return xxx_messageInfo_ListRunRetentionPoliciesReq.Size(m)
+}
+func (m *ListRunRetentionPoliciesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunRetentionPoliciesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRunRetentionPoliciesReq proto.InternalMessageInfo
+
+type ListRunRetentionPoliciesRes struct {
+	Policy               *RunRetentionPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListRunRetentionPoliciesRes) Reset()         { *m = ListRunRetentionPoliciesRes{} }
+func (m *ListRunRetentionPoliciesRes) String() string { return proto.CompactTextString(m) }
+func (*ListRunRetentionPoliciesRes) ProtoMessage()    {}
+func (*ListRunRetentionPoliciesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{13}
+}
+
+func (m *ListRunRetentionPoliciesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunRetentionPoliciesRes.Unmarshal(m, b)
+}
+func (m *ListRunRetentionPoliciesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunRetentionPoliciesRes.Marshal(b, m, deterministic)
+}
+func (m *ListRunRetentionPoliciesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunRetentionPoliciesRes.Merge(m, src)
+}
+func (m *ListRunRetentionPoliciesRes) XXX_Size() int {
+	return xxx_messageInfo_ListRunRetentionPoliciesRes.Size(m)
+}
+func (m *ListRunRetentionPoliciesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunRetentionPoliciesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRunRetentionPoliciesRes proto.InternalMessageInfo
+
+func (m *ListRunRetentionPoliciesRes) GetPolicy() *RunRetentionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("model.RunStatus", RunStatus_name, RunStatus_value)
+	proto.RegisterEnum("model.RunTrigger", RunTrigger_name, RunTrigger_value)
+	proto.RegisterType((*JobRun)(nil), "model.JobRun")
+	proto.RegisterType((*ListJobRunsReq)(nil), "model.ListJobRunsReq")
+	proto.RegisterType((*ListJobRunsRes)(nil), "model.ListJobRunsRes")
+	proto.RegisterType((*GetJobRunReq)(nil), "model.GetJobRunReq")
+	proto.RegisterType((*GetJobRunRes)(nil), "model.GetJobRunRes")
+	proto.RegisterType((*RunRetentionPolicy)(nil), "model.RunRetentionPolicy")
+	proto.RegisterType((*GetRunRetentionPolicyReq)(nil), "model.GetRunRetentionPolicyReq")
+	proto.RegisterType((*GetRunRetentionPolicyRes)(nil), "model.GetRunRetentionPolicyRes")
+	proto.RegisterType((*SetRunRetentionPolicyReq)(nil), "model.SetRunRetentionPolicyReq")
+	proto.RegisterType((*SetRunRetentionPolicyRes)(nil), "model.SetRunRetentionPolicyRes")
+	proto.RegisterType((*DeleteRunRetentionPolicyReq)(nil), "model.DeleteRunRetentionPolicyReq")
+	proto.RegisterType((*DeleteRunRetentionPolicyRes)(nil), "model.DeleteRunRetentionPolicyRes")
+	proto.RegisterType((*ListRunRetentionPoliciesReq)(nil), "model.ListRunRetentionPoliciesReq")
+	proto.RegisterType((*ListRunRetentionPoliciesRes)(nil), "model.ListRunRetentionPoliciesRes")
+}
+
+func init() { proto.RegisterFile("run.proto", fileDescriptor_e3419bc3417bf873) }
+
+var fileDescriptor_e3419bc3417bf873 = []byte{
+	// 1063 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdb, 0x72, 0xdb, 0x44,
+	0x18, 0x46, 0x4e, 0x7d, 0xfa, 0x73, 0x72, 0xb6, 0x75, 0xd8, 0x28, 0x34, 0x31, 0xba, 0xe8, 0x78,
+	0xc2, 0x8c, 0xdd, 0xa4, 0x30, 0x0c, 0xdc, 0x19, 0x4b, 0x0d, 0xee, 0x38, 0xc6, 0x48, 0xf6, 0x30,
+	0xbd, 0x60, 0x34, 0xb2, 0xb5, 0x78, 0x94, 0xc4, 0x5a, 0x75, 0xb5, 0x6a, 0xe3, 0x76, 0x7a, 0x41,
+	0x2f, 0x78, 0x01, 0x1e, 0x86, 0x37, 0xe0, 0x05, 0x7a, 0xcf, 0x15, 0x0f, 0xc2, 0xec, 0x4a, 0xb2,
+	0x5d, 0x27, 0xb6, 0xa1, 0xc3, 0x9d, 0xf6, 0xff, 0xbe, 0xff, 0x7c, 0x10, 0x14, 0x59, 0xe4, 0xd7,
+	0x02, 0x46, 0x39, 0x45, 0xd9, 0x31, 0x75, 0xc9, 0xb5, 0xfa, 0xd9, 0x88, 0xd2, 0xd1, 0x35, 0xa9,
+	0x3b, 0x81, 0x57, 0x77, 0x7c, 0x9f, 0x72, 0x87, 0x7b, 0xd4, 0x0f, 0x63, 0x92, 0x7a, 0x94, 0xa0,
+	0xf2, 0x35, 0x88, 0x7e, 0xa9, 0xbb, 0x11, 0x93, 0x84, 0x04, 0x3f, 0x5e, 0xc4, 0xb9, 0x37, 0x26,
+	0x21, 0x77, 0xc6, 0x41, 0x42, 0x28, 0x5e, 0xd2, 0x41, 0xfc, 0xa9, 0xfd, 0x95, 0x85, 0xdc, 0x33,
+	0x3a, 0x30, 0x23, 0x1f, 0xed, 0x40, 0xc6, 0x73, 0xb1, 0x52, 0x51, 0xaa, 0x45, 0x33, 0xe3, 0xb9,
+	0xa8, 0x0c, 0xb9, 0x4b, 0x3a, 0xb0, 0x3d, 0x17, 0x67, 0xa4, 0x2c, 0x7b, 0x49, 0x07, 0x2d, 0x17,
+	0x55, 0x21, 0x17, 0x72, 0x87, 0x47, 0x21, 0xde, 0xa8, 0x28, 0xd5, 0x9d, 0xb3, 0x52, 0x4d, 0xc6,
+	0x5c, 0x33, 0x23, 0xdf, 0x92, 0x72, 0x33, 0xc1, 0xd1, 0xd7, 0x50, 0x7c, 0x11, 0x91, 0x88, 0xb8,
+	0xb6, 0xc3, 0xf1, 0xbd, 0x8a, 0x52, 0xdd, 0x3c, 0x53, 0x6b, 0x71, 0x6c, 0xb5, 0x34, 0xb6, 0x5a,
+	0x2f, 0x8d, 0xcd, 0x2c, 0xc4, 0xe4, 0x06, 0x47, 0xdf, 0x00, 0x84, 0xdc, 0x61, 0xdc, 0x16, 0x81,
+	0xe3, 0xec, 0x5a, 0xcd, 0xa2, 0x64, 0x8b, 0x37, 0xfa, 0x0a, 0x0a, 0xc4, 0x77, 0x63, 0xc5, 0xdc,
+	0x5a, 0xc5, 0x3c, 0xf1, 0x5d, 0xa9, 0xb6, 0x0f, 0x39, 0x1a, 0xf1, 0x20, 0xe2, 0x38, 0x2f, 0x73,
+	0x4d, 0x5e, 0xe8, 0x01, 0x64, 0x09, 0x63, 0x94, 0xe1, 0x42, 0x5c, 0x02, 0xf9, 0x40, 0x18, 0xf2,
+	0x0e, 0xe7, 0x64, 0x1c, 0x70, 0x5c, 0xac, 0x28, 0xd5, 0xac, 0x99, 0x3e, 0x85, 0x7b, 0x46, 0x38,
+	0x9b, 0x88, 0x8c, 0x61, 0xbd, 0x7b, 0xc9, 0x6d, 0x70, 0xf4, 0x04, 0xf2, 0x22, 0x62, 0x1a, 0x71,
+	0xbc, 0x29, 0xb5, 0x0e, 0x6e, 0x69, 0xe9, 0x49, 0x8f, 0xcd, 0x94, 0x29, 0x7c, 0xa5, 0x8d, 0xc7,
+	0x5b, 0xeb, 0xb4, 0xa6, 0x54, 0x74, 0x00, 0x85, 0xe1, 0x64, 0x78, 0x4d, 0x44, 0x63, 0xb7, 0x65,
+	0x56, 0x79, 0xf9, 0x6e, 0xb9, 0xa8, 0x06, 0x85, 0x80, 0x79, 0x94, 0x79, 0x7c, 0x82, 0x77, 0x64,
+	0x73, 0x51, 0xd2, 0xdc, 0x67, 0x74, 0xd0, 0x4d, 0x10, 0x73, 0xca, 0x41, 0x5f, 0x40, 0x9e, 0x33,
+	0x6f, 0x34, 0x22, 0x0c, 0xef, 0x4a, 0xfa, 0xde, 0x6c, 0x16, 0x7a, 0x31, 0x60, 0xa6, 0x0c, 0xf4,
+	0x39, 0x6c, 0x25, 0x9f, 0xc4, 0xb5, 0x07, 0x13, 0x5c, 0x92, 0xbe, 0x37, 0xa7, 0xb2, 0xef, 0x26,
+	0xa2, 0x0b, 0xaf, 0x28, 0xbb, 0x22, 0x0c, 0xef, 0xc5, 0x5d, 0x88, 0x5f, 0xe8, 0x11, 0xec, 0x0e,
+	0x69, 0xc8, 0xed, 0x80, 0x30, 0x3b, 0x24, 0x43, 0xea, 0xbb, 0x18, 0x55, 0x94, 0xaa, 0x62, 0x6e,
+	0x0b, 0x71, 0x97, 0x30, 0x4b, 0x0a, 0x11, 0x82, 0x7b, 0x42, 0x80, 0xef, 0x4b, 0x50, 0x7e, 0x6b,
+	0x43, 0xd8, 0x69, 0x7b, 0x21, 0x8f, 0x67, 0x3c, 0x34, 0xc9, 0x8b, 0xb9, 0xb9, 0x56, 0xe6, 0xe7,
+	0xfa, 0x10, 0x8a, 0x81, 0x33, 0x22, 0x76, 0xe8, 0xbd, 0x26, 0x72, 0xe2, 0xb3, 0x66, 0x41, 0x08,
+	0x2c, 0xef, 0x35, 0x41, 0x0f, 0x01, 0x24, 0xc8, 0xe9, 0x15, 0xf1, 0xe5, 0xe0, 0x17, 0x4d, 0x49,
+	0xef, 0x09, 0x81, 0xf6, 0x7c, 0xc1, 0x49, 0x88, 0x8e, 0x61, 0x83, 0x45, 0xbe, 0xf4, 0xb0, 0x79,
+	0xb6, 0x3d, 0xab, 0xa2, 0x19, 0xf9, 0xa6, 0x40, 0x44, 0x4e, 0x3e, 0xb9, 0xe1, 0xf6, 0x9c, 0xd9,
+	0x78, 0xcd, 0xb6, 0x85, 0xb8, 0x3b, 0x35, 0x7d, 0x04, 0x5b, 0xe7, 0x24, 0xb1, 0x2c, 0xa2, 0x5f,
+	0xd8, 0x52, 0xad, 0xfe, 0x01, 0xbe, 0xde, 0xb1, 0xf6, 0x87, 0x02, 0x48, 0x72, 0x39, 0xf1, 0xc5,
+	0x40, 0x74, 0xe9, 0xb5, 0x37, 0x9c, 0xac, 0xa8, 0xca, 0x15, 0x21, 0x81, 0xcd, 0x22, 0x3f, 0x4c,
+	0xab, 0x22, 0x04, 0x22, 0x4f, 0x74, 0x06, 0xf9, 0xb1, 0x73, 0x63, 0x3b, 0x23, 0x82, 0x37, 0xd6,
+	0x0d, 0x60, 0x6e, 0xec, 0xdc, 0x34, 0x46, 0x44, 0xec, 0x76, 0x14, 0xb8, 0x0e, 0xff, 0xb7, 0x57,
+	0xa1, 0x98, 0xb0, 0x1b, 0x5c, 0x3b, 0x05, 0x7c, 0x4e, 0xf8, 0xed, 0xd8, 0x97, 0x37, 0x55, 0xbb,
+	0x58, 0xaa, 0x12, 0xa2, 0x53, 0xc8, 0x05, 0xf2, 0x91, 0x14, 0xeb, 0x60, 0x36, 0xbc, 0x8b, 0xec,
+	0x84, 0x28, 0xcc, 0x59, 0xcb, 0x22, 0xf8, 0x5f, 0xcd, 0x7d, 0x54, 0x74, 0x5f, 0xc2, 0xa1, 0x4e,
+	0xae, 0x09, 0x27, 0xff, 0xa9, 0x44, 0x0f, 0x57, 0x69, 0x85, 0x02, 0x16, 0xa3, 0x7d, 0x0b, 0xf4,
+	0x88, 0x58, 0x26, 0xad, 0xbb, 0x0a, 0xfe, 0x98, 0x2c, 0x4e, 0xde, 0x2b, 0x50, 0x9c, 0xfe, 0x4b,
+	0x90, 0x0a, 0xfb, 0x66, 0xbf, 0x63, 0x5b, 0xbd, 0x46, 0xaf, 0x6f, 0xd9, 0xfd, 0x8e, 0xd5, 0x35,
+	0x9a, 0xad, 0xa7, 0x2d, 0x43, 0x2f, 0x7d, 0x82, 0xca, 0xb0, 0x37, 0x87, 0xfd, 0xd8, 0x37, 0xfa,
+	0x86, 0x5e, 0x52, 0xd0, 0x3e, 0xa0, 0x39, 0xb1, 0xd9, 0xef, 0x74, 0x5a, 0x9d, 0xf3, 0x52, 0x06,
+	0x61, 0x78, 0x30, 0x27, 0xb7, 0xfa, 0xcd, 0xa6, 0x61, 0xe8, 0x86, 0x5e, 0xda, 0x58, 0x30, 0xf4,
+	0xb4, 0xd1, 0x6a, 0x1b, 0x7a, 0xe9, 0xde, 0x82, 0x42, 0xb3, 0xd1, 0x69, 0x1a, 0x6d, 0x81, 0x64,
+	0x17, 0x5c, 0xfc, 0xd4, 0x68, 0xf5, 0x84, 0x8b, 0xdc, 0x82, 0x46, 0xaf, 0x75, 0x61, 0xe8, 0xf6,
+	0x0f, 0xfd, 0x5e, 0x29, 0x7f, 0xf2, 0x0a, 0x60, 0x76, 0x14, 0xd1, 0x21, 0x7c, 0x2a, 0x78, 0x3d,
+	0xb3, 0x75, 0x7e, 0x6e, 0x98, 0x0b, 0x69, 0x25, 0x46, 0x52, 0xd0, 0x6a, 0x7e, 0x6f, 0xe8, 0xfd,
+	0xb6, 0x31, 0xcb, 0x2c, 0x45, 0x2e, 0x1a, 0x9d, 0x7e, 0xa3, 0x5d, 0xca, 0xa4, 0x45, 0x4a, 0xe5,
+	0xba, 0xd1, 0x35, 0x3a, 0xba, 0xd1, 0x69, 0x3e, 0x2f, 0x6d, 0x9c, 0xfd, 0x99, 0x95, 0x9e, 0x2d,
+	0xc2, 0x5e, 0x7a, 0x43, 0x82, 0x7e, 0x86, 0xcd, 0xb9, 0x4b, 0x85, 0xca, 0x49, 0x3f, 0x3e, 0x3c,
+	0x91, 0xea, 0x9d, 0xe2, 0x50, 0x3b, 0x7a, 0xf7, 0xfe, 0xef, 0xdf, 0x33, 0x18, 0xed, 0xd7, 0x5f,
+	0x9e, 0xd6, 0x2f, 0xe9, 0x20, 0xac, 0xbf, 0x89, 0x27, 0xea, 0x6d, 0x5d, 0xdc, 0x87, 0xc7, 0x0a,
+	0x6a, 0x43, 0x71, 0x7a, 0x8d, 0xd0, 0xfd, 0xc4, 0xca, 0xfc, 0xfd, 0x52, 0xef, 0x10, 0x86, 0x5a,
+	0x59, 0x1a, 0xde, 0x45, 0xdb, 0xc2, 0xb0, 0x30, 0x55, 0x7f, 0xe3, 0xb9, 0x6f, 0xd1, 0x04, 0xca,
+	0x77, 0x6e, 0x2f, 0x3a, 0x9e, 0x19, 0xb9, 0x73, 0xd6, 0xd5, 0x35, 0x84, 0x85, 0x54, 0xd8, 0x6d,
+	0x0f, 0xbf, 0x2a, 0x50, 0xb6, 0x56, 0xfa, 0xb6, 0xd6, 0xf9, 0x5e, 0xb6, 0xda, 0xda, 0x23, 0xe9,
+	0xbb, 0xa2, 0x2e, 0xf1, 0xfd, 0x6d, 0xb2, 0x09, 0xe8, 0x9d, 0x02, 0x78, 0xd9, 0x6a, 0x22, 0x2d,
+	0xf1, 0xb2, 0x62, 0xe3, 0xd5, 0xf5, 0x9c, 0x69, 0x21, 0x4e, 0x96, 0x15, 0xe2, 0x37, 0x05, 0xf0,
+	0xb2, 0x0d, 0x9f, 0x06, 0xb1, 0xe2, 0x42, 0xa8, 0xeb, 0x39, 0xa1, 0x56, 0x91, 0x41, 0xa8, 0x08,
+	0xdf, 0x19, 0x84, 0x47, 0xc2, 0xc7, 0xca, 0x20, 0x27, 0x7f, 0x0e, 0x4f, 0xfe, 0x19, 0x00, 0xe1,
+	0x64, 0xc4, 0xe3, 0x2e, 0x0b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RunServiceClient is the client API for RunService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RunServiceClient interface {
+	// Streams runs newest first
+	ListJobRuns(ctx context.Context, in *ListJobRunsReq, opts ...grpc.CallOption) (RunService_ListJobRunsClient, error)
+	GetJobRun(ctx context.Context, in *GetJobRunReq, opts ...grpc.CallOption) (*GetJobRunRes, error)
+	GetRunRetentionPolicy(ctx context.Context, in *GetRunRetentionPolicyReq, opts ...grpc.CallOption) (*GetRunRetentionPolicyRes, error)
+	// Replaces the policy of the job of the policy and removes the runs past it right away
+	SetRunRetentionPolicy(ctx context.Context, in *SetRunRetentionPolicyReq, opts ...grpc.CallOption) (*SetRunRetentionPolicyRes, error)
+	// Deletes a policy, the runs of the job are kept according to the default of the tenant then
+	DeleteRunRetentionPolicy(ctx context.Context, in *DeleteRunRetentionPolicyReq, opts ...grpc.CallOption) (*DeleteRunRetentionPolicyRes, error)
+	// Streams the policies of the tenant, the default first
+	ListRunRetentionPolicies(ctx context.Context, in *ListRunRetentionPoliciesReq, opts ...grpc.CallOption) (RunService_ListRunRetentionPoliciesClient, error)
+}
+
+type runServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRunServiceClient(cc *grpc.ClientConn) RunServiceClient {
+	return &runServiceClient{cc}
+}
+
+func (c *runServiceClient) ListJobRuns(ctx context.Context, in *ListJobRunsReq, opts ...grpc.CallOption) (RunService_ListJobRunsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RunService_serviceDesc.Streams[0], "/model.RunService/ListJobRuns", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runServiceListJobRunsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RunService_ListJobRunsClient interface {
+	Recv() (*ListJobRunsRes, error)
+	grpc.ClientStream
+}
+
+type runServiceListJobRunsClient struct {
+	grpc.ClientStream
+}
+
+func (x *runServiceListJobRunsClient) Recv() (*ListJobRunsRes, error) {
+	m := new(ListJobRunsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runServiceClient) GetJobRun(ctx context.Context, in *GetJobRunReq, opts ...grpc.CallOption) (*GetJobRunRes, error) {
+	out := new(GetJobRunRes)
+	err := c.cc.Invoke(ctx, "/model.RunService/GetJobRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRunRetentionPolicy(ctx context.Context, in *GetRunRetentionPolicyReq, opts ...grpc.CallOption) (*GetRunRetentionPolicyRes, error) {
+	out := new(GetRunRetentionPolicyRes)
+	err := c.cc.Invoke(ctx, "/model.RunService/GetRunRetentionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) SetRunRetentionPolicy(ctx context.Context, in *SetRunRetentionPolicyReq, opts ...grpc.CallOption) (*SetRunRetentionPolicyRes, error) {
+	out := new(SetRunRetentionPolicyRes)
+	err := c.cc.Invoke(ctx, "/model.RunService/SetRunRetentionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) DeleteRunRetentionPolicy(ctx context.Context, in *DeleteRunRetentionPolicyReq, opts ...grpc.CallOption) (*DeleteRunRetentionPolicyRes, error) {
+	out := new(DeleteRunRetentionPolicyRes)
+	err := c.cc.Invoke(ctx, "/model.RunService/DeleteRunRetentionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ListRunRetentionPolicies(ctx context.Context, in *ListRunRetentionPoliciesReq, opts ...grpc.CallOption) (RunService_ListRunRetentionPoliciesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RunService_serviceDesc.Streams[1], "/model.RunService/ListRunRetentionPolicies", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runServiceListRunRetentionPoliciesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RunService_ListRunRetentionPoliciesClient interface {
+	Recv() (*ListRunRetentionPoliciesRes, error)
+	grpc.ClientStream
+}
+
+type runServiceListRunRetentionPoliciesClient struct {
+	grpc.ClientStream
+}
+
+func (x *runServiceListRunRetentionPoliciesClient) Recv() (*ListRunRetentionPoliciesRes, error) {
+	m := new(ListRunRetentionPoliciesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RunServiceServer is the server API for RunService service.
+type RunServiceServer interface {
+	// Streams runs newest first
+	ListJobRuns(*ListJobRunsReq, RunService_ListJobRunsServer) error
+	GetJobRun(context.Context, *GetJobRunReq) (*GetJobRunRes, error)
+	GetRunRetentionPolicy(context.Context, *GetRunRetentionPolicyReq) (*GetRunRetentionPolicyRes, error)
+	// Replaces the policy of the job of the policy and removes the runs past it right away
+	SetRunRetentionPolicy(context.Context, *SetRunRetentionPolicyReq) (*SetRunRetentionPolicyRes, error)
+	// Deletes a policy, the runs of the job are kept according to the default of the tenant then
+	DeleteRunRetentionPolicy(context.Context, *DeleteRunRetentionPolicyReq) (*DeleteRunRetentionPolicyRes, error)
+	// Streams the policies of the tenant, the default first
+	ListRunRetentionPolicies(*ListRunRetentionPoliciesReq, RunService_ListRunRetentionPoliciesServer) error
+}
+
+func RegisterRunServiceServer(s *grpc.Server, srv RunServiceServer) {
+	s.RegisterService(&_RunService_serviceDesc, srv)
+}
+
+func _RunService_ListJobRuns_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListJobRunsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RunServiceServer).ListJobRuns(m, &runServiceListJobRunsServer{stream})
+}
+
+type RunService_ListJobRunsServer interface {
+	Send(*ListJobRunsRes) error
+	grpc.ServerStream
+}
+
+type runServiceListJobRunsServer struct {
+	grpc.ServerStream
+}
+
+func (x *runServiceListJobRunsServer) Send(m *ListJobRunsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RunService_GetJobRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRunReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetJobRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.RunService/GetJobRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetJobRun(ctx, req.(*GetJobRunReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_GetRunRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunRetentionPolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRunRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.RunService/GetRunRetentionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRunRetentionPolicy(ctx, req.(*GetRunRetentionPolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_SetRunRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRunRetentionPolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).SetRunRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.RunService/SetRunRetentionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).SetRunRetentionPolicy(ctx, req.(*SetRunRetentionPolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_DeleteRunRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRunRetentionPolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).DeleteRunRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.RunService/DeleteRunRetentionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).DeleteRunRetentionPolicy(ctx, req.(*DeleteRunRetentionPolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_ListRunRetentionPolicies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRunRetentionPoliciesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RunServiceServer).ListRunRetentionPolicies(m, &runServiceListRunRetentionPoliciesServer{stream})
+}
+
+type RunService_ListRunRetentionPoliciesServer interface {
+	Send(*ListRunRetentionPoliciesRes) error
+	grpc.ServerStream
+}
+
+type runServiceListRunRetentionPoliciesServer struct {
+	grpc.ServerStream
+}
+
+func (x *runServiceListRunRetentionPoliciesServer) Send(m *ListRunRetentionPoliciesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _RunService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.RunService",
+	HandlerType: (*RunServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetJobRun",
+			Handler:    _RunService_GetJobRun_Handler,
+		},
+		{
+			MethodName: "GetRunRetentionPolicy",
+			Handler:    _RunService_GetRunRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "SetRunRetentionPolicy",
+			Handler:    _RunService_SetRunRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteRunRetentionPolicy",
+			Handler:    _RunService_DeleteRunRetentionPolicy_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListJobRuns",
+			Handler:       _RunService_ListJobRuns_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRunRetentionPolicies",
+			Handler:       _RunService_ListRunRetentionPolicies_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "run.proto",
+}
@@ -0,0 +1,616 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: schedule.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SetScheduleReq struct {
+	JobId                string    `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Schedule             *Schedule `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SetScheduleReq) Reset()         { *m = SetScheduleReq{} }
+func (m *SetScheduleReq) String() string { return proto.CompactTextString(m) }
+func (*SetScheduleReq) ProtoMessage()    {}
+func (*SetScheduleReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{0}
+}
+
+func (m *SetScheduleReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetScheduleReq.Unmarshal(m, b)
+}
+func (m *SetScheduleReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetScheduleReq.Marshal(b, m, deterministic)
+}
+func (m *SetScheduleReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetScheduleReq.Merge(m, src)
+}
+func (m *SetScheduleReq) XXX_Size() int {
+	return xxx_messageInfo_SetScheduleReq.Size(m)
+}
+func (m *SetScheduleReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetScheduleReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetScheduleReq proto.InternalMessageInfo
+
+func (m *SetScheduleReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *SetScheduleReq) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type SetScheduleRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetScheduleRes) Reset()         { *m = SetScheduleRes{} }
+func (m *SetScheduleRes) String() string { return proto.CompactTextString(m) }
+func (*SetScheduleRes) ProtoMessage()    {}
+func (*SetScheduleRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{1}
+}
+
+func (m *SetScheduleRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetScheduleRes.Unmarshal(m, b)
+}
+func (m *SetScheduleRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetScheduleRes.Marshal(b, m, deterministic)
+}
+func (m *SetScheduleRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetScheduleRes.Merge(m, src)
+}
+func (m *SetScheduleRes) XXX_Size() int {
+	return xxx_messageInfo_SetScheduleRes.Size(m)
+}
+func (m *SetScheduleRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetScheduleRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetScheduleRes proto.InternalMessageInfo
+
+func (m *SetScheduleRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type RemoveScheduleReq struct {
+	JobId                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveScheduleReq) Reset()         { *m = RemoveScheduleReq{} }
+func (m *RemoveScheduleReq) String() string { return proto.CompactTextString(m) }
+func (*RemoveScheduleReq) ProtoMessage()    {}
+func (*RemoveScheduleReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{2}
+}
+
+func (m *RemoveScheduleReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveScheduleReq.Unmarshal(m, b)
+}
+func (m *RemoveScheduleReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveScheduleReq.Marshal(b, m, deterministic)
+}
+func (m *RemoveScheduleReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveScheduleReq.Merge(m, src)
+}
+func (m *RemoveScheduleReq) XXX_Size() int {
+	return xxx_messageInfo_RemoveScheduleReq.Size(m)
+}
+func (m *RemoveScheduleReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveScheduleReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveScheduleReq proto.InternalMessageInfo
+
+func (m *RemoveScheduleReq) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type RemoveScheduleRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveScheduleRes) Reset()         { *m = RemoveScheduleRes{} }
+func (m *RemoveScheduleRes) String() string { return proto.CompactTextString(m) }
+func (*RemoveScheduleRes) ProtoMessage()    {}
+func (*RemoveScheduleRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{3}
+}
+
+func (m *RemoveScheduleRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveScheduleRes.Unmarshal(m, b)
+}
+func (m *RemoveScheduleRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveScheduleRes.Marshal(b, m, deterministic)
+}
+func (m *RemoveScheduleRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveScheduleRes.Merge(m, src)
+}
+func (m *RemoveScheduleRes) XXX_Size() int {
+	return xxx_messageInfo_RemoveScheduleRes.Size(m)
+}
+func (m *RemoveScheduleRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveScheduleRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveScheduleRes proto.InternalMessageInfo
+
+func (m *RemoveScheduleRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type PreviewScheduleReq struct {
+	Schedule *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// Number of run times to compute, defaults to 10 and may be at most 100
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Run times are computed after this time, defaults to now
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PreviewScheduleReq) Reset()         { *m = PreviewScheduleReq{} }
+func (m *PreviewScheduleReq) String() string { return proto.CompactTextString(m) }
+func (*PreviewScheduleReq) ProtoMessage()    {}
+func (*PreviewScheduleReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{4}
+}
+
+func (m *PreviewScheduleReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviewScheduleReq.Unmarshal(m, b)
+}
+func (m *PreviewScheduleReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviewScheduleReq.Marshal(b, m, deterministic)
+}
+func (m *PreviewScheduleReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviewScheduleReq.Merge(m, src)
+}
+func (m *PreviewScheduleReq) XXX_Size() int {
+	return xxx_messageInfo_PreviewScheduleReq.Size(m)
+}
+func (m *PreviewScheduleReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviewScheduleReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviewScheduleReq proto.InternalMessageInfo
+
+func (m *PreviewScheduleReq) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+func (m *PreviewScheduleReq) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PreviewScheduleReq) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+type PreviewScheduleRes struct {
+	// The next run times of the schedule in ascending order, fewer than count when the schedule stops firing
+	NextRunTimes         []*timestamp.Timestamp `protobuf:"bytes,1,rep,name=next_run_times,json=nextRunTimes,proto3" json:"next_run_times,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PreviewScheduleRes) Reset()         { *m = PreviewScheduleRes{} }
+func (m *PreviewScheduleRes) String() string { return proto.CompactTextString(m) }
+func (*PreviewScheduleRes) ProtoMessage()    {}
+func (*PreviewScheduleRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{5}
+}
+
+func (m *PreviewScheduleRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviewScheduleRes.Unmarshal(m, b)
+}
+func (m *PreviewScheduleRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviewScheduleRes.Marshal(b, m, deterministic)
+}
+func (m *PreviewScheduleRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviewScheduleRes.Merge(m, src)
+}
+func (m *PreviewScheduleRes) XXX_Size() int {
+	return xxx_messageInfo_PreviewScheduleRes.Size(m)
+}
+func (m *PreviewScheduleRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviewScheduleRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviewScheduleRes proto.InternalMessageInfo
+
+func (m *PreviewScheduleRes) GetNextRunTimes() []*timestamp.Timestamp {
+	if m != nil {
+		return m.NextRunTimes
+	}
+	return nil
+}
+
+type PreviewCronReq struct {
+	// A standard cron expression with five fields or a descriptor like @daily
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// IANA name of the zone the expression is evaluated in, UTC when empty
+	Timezone string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Number of fire times to compute, defaults to 10 and may be at most 100
+	Count int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Fire times are computed after this time, defaults to now
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PreviewCronReq) Reset()         { *m = PreviewCronReq{} }
+func (m *PreviewCronReq) String() string { return proto.CompactTextString(m) }
+func (*PreviewCronReq) ProtoMessage()    {}
+func (*PreviewCronReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{6}
+}
+
+func (m *PreviewCronReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviewCronReq.Unmarshal(m, b)
+}
+func (m *PreviewCronReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviewCronReq.Marshal(b, m, deterministic)
+}
+func (m *PreviewCronReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviewCronReq.Merge(m, src)
+}
+func (m *PreviewCronReq) XXX_Size() int {
+	return xxx_messageInfo_PreviewCronReq.Size(m)
+}
+func (m *PreviewCronReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviewCronReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviewCronReq proto.InternalMessageInfo
+
+func (m *PreviewCronReq) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *PreviewCronReq) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *PreviewCronReq) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PreviewCronReq) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+type PreviewCronRes struct {
+	// The next fire times of the expression in ascending order, fewer than count when it stops firing
+	NextRunTimes         []*timestamp.Timestamp `protobuf:"bytes,1,rep,name=next_run_times,json=nextRunTimes,proto3" json:"next_run_times,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PreviewCronRes) Reset()         { *m = PreviewCronRes{} }
+func (m *PreviewCronRes) String() string { return proto.CompactTextString(m) }
+func (*PreviewCronRes) ProtoMessage()    {}
+func (*PreviewCronRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d00842e68e05382a, []int{7}
+}
+
+func (m *PreviewCronRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviewCronRes.Unmarshal(m, b)
+}
+func (m *PreviewCronRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviewCronRes.Marshal(b, m, deterministic)
+}
+func (m *PreviewCronRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviewCronRes.Merge(m, src)
+}
+func (m *PreviewCronRes) XXX_Size() int {
+	return xxx_messageInfo_PreviewCronRes.Size(m)
+}
+func (m *PreviewCronRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviewCronRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviewCronRes proto.InternalMessageInfo
+
+func (m *PreviewCronRes) GetNextRunTimes() []*timestamp.Timestamp {
+	if m != nil {
+		return m.NextRunTimes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SetScheduleReq)(nil), "model.SetScheduleReq")
+	proto.RegisterType((*SetScheduleRes)(nil), "model.SetScheduleRes")
+	proto.RegisterType((*RemoveScheduleReq)(nil), "model.RemoveScheduleReq")
+	proto.RegisterType((*RemoveScheduleRes)(nil), "model.RemoveScheduleRes")
+	proto.RegisterType((*PreviewScheduleReq)(nil), "model.PreviewScheduleReq")
+	proto.RegisterType((*PreviewScheduleRes)(nil), "model.PreviewScheduleRes")
+	proto.RegisterType((*PreviewCronReq)(nil), "model.PreviewCronReq")
+	proto.RegisterType((*PreviewCronRes)(nil), "model.PreviewCronRes")
+}
+
+func init() { proto.RegisterFile("schedule.proto", fileDescriptor_d00842e68e05382a) }
+
+var fileDescriptor_d00842e68e05382a = []byte{
+	// 496 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x52, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x96, 0x6b, 0x52, 0x35, 0x13, 0xe4, 0xc2, 0x8a, 0x48, 0x66, 0x89, 0x20, 0xda, 0x03, 0xaa,
+	0x02, 0xb2, 0xd5, 0x70, 0x22, 0x27, 0x24, 0x4e, 0x70, 0x42, 0x9b, 0x0a, 0x71, 0x8b, 0xec, 0x64,
+	0x69, 0xd7, 0x4a, 0x76, 0x8c, 0x77, 0x1d, 0x2a, 0x10, 0x17, 0x1e, 0x01, 0xce, 0x3c, 0x01, 0x8f,
+	0xc3, 0x2b, 0xf0, 0x20, 0xc8, 0x6b, 0x3b, 0x75, 0xfe, 0x88, 0x90, 0xb8, 0x79, 0xc6, 0xb3, 0xdf,
+	0x37, 0xdf, 0x37, 0x1f, 0x78, 0x7a, 0x7a, 0x25, 0x66, 0xf9, 0x5c, 0x04, 0x69, 0x86, 0x06, 0x49,
+	0x6b, 0x81, 0x33, 0x31, 0xa7, 0xbd, 0x4b, 0xc4, 0xcb, 0xb9, 0x08, 0xa3, 0x54, 0x86, 0x91, 0x52,
+	0x68, 0x22, 0x23, 0x51, 0xe9, 0x72, 0x88, 0x3e, 0xaa, 0xfe, 0xda, 0x2a, 0xce, 0xdf, 0x87, 0x46,
+	0x2e, 0x84, 0x36, 0xd1, 0x22, 0xad, 0x06, 0xda, 0x09, 0xc6, 0xe5, 0x27, 0xbb, 0x00, 0x6f, 0x2c,
+	0xcc, 0xb8, 0x62, 0xe1, 0xe2, 0x03, 0xe9, 0xc2, 0x71, 0x82, 0xf1, 0x44, 0xce, 0x7c, 0xa7, 0xef,
+	0x9c, 0xb5, 0x79, 0x2b, 0xc1, 0xf8, 0xd5, 0x8c, 0x3c, 0x81, 0x93, 0x7a, 0x17, 0xff, 0xa8, 0xef,
+	0x9c, 0x75, 0x86, 0xa7, 0x81, 0x5d, 0x26, 0x58, 0x3d, 0x5e, 0x0d, 0xb0, 0x60, 0x03, 0x55, 0x93,
+	0x1e, 0xb8, 0x09, 0xc6, 0x16, 0xb2, 0x33, 0x84, 0xea, 0xe5, 0x6b, 0x8c, 0x79, 0xd1, 0x66, 0x03,
+	0xb8, 0xcb, 0xc5, 0x02, 0x97, 0xe2, 0xf0, 0x22, 0xec, 0x7c, 0x7b, 0xf6, 0x10, 0xfc, 0x37, 0x07,
+	0xc8, 0x9b, 0x4c, 0x2c, 0xa5, 0xf8, 0xd8, 0x24, 0x68, 0x4a, 0x72, 0x0e, 0x48, 0x22, 0xf7, 0xa0,
+	0x35, 0xc5, 0x5c, 0x19, 0x2b, 0xbe, 0xc5, 0xcb, 0x82, 0x3c, 0x07, 0xd0, 0x26, 0xca, 0xcc, 0xa4,
+	0xb0, 0xd8, 0x77, 0x2d, 0x08, 0x0d, 0x4a, 0xff, 0x83, 0xda, 0xff, 0xe0, 0xa2, 0xf6, 0x9f, 0xb7,
+	0xed, 0x74, 0x51, 0xb3, 0xb7, 0x3b, 0x76, 0xd2, 0xe4, 0x05, 0x78, 0x4a, 0x5c, 0x9b, 0x49, 0x96,
+	0x2b, 0x8b, 0xa9, 0x7d, 0xa7, 0xef, 0x1e, 0x00, 0xbd, 0x5d, 0xbc, 0xe0, 0xb9, 0xb2, 0x1d, 0xf6,
+	0xc3, 0x01, 0xaf, 0x02, 0x7e, 0x99, 0xa1, 0x2a, 0x84, 0x3e, 0x04, 0x10, 0xd7, 0x69, 0x26, 0xb4,
+	0x96, 0xa8, 0x2a, 0x37, 0x1b, 0x1d, 0x42, 0xe1, 0xa4, 0xe0, 0xfa, 0x84, 0xaa, 0xbc, 0x6d, 0x9b,
+	0xaf, 0xea, 0x1b, 0xdd, 0xee, 0x7e, 0xdd, 0xb7, 0xfe, 0x45, 0x37, 0xdf, 0x58, 0xef, 0x3f, 0x68,
+	0x1e, 0xfe, 0x74, 0xe1, 0xb4, 0x76, 0x71, 0x2c, 0xb2, 0xa5, 0x9c, 0x0a, 0x22, 0xa1, 0xd3, 0xc8,
+	0x20, 0xe9, 0xd6, 0xa7, 0x5d, 0x4b, 0x3b, 0xdd, 0xd9, 0xd6, 0xec, 0xe9, 0xd7, 0x5f, 0xbf, 0xbf,
+	0x1f, 0x3d, 0xa6, 0x34, 0x5c, 0x9e, 0x87, 0x09, 0xc6, 0x3a, 0xfc, 0x5c, 0x66, 0xf1, 0x4b, 0x58,
+	0x27, 0x62, 0x74, 0x93, 0x8d, 0x2b, 0xf0, 0xd6, 0x23, 0x49, 0xfc, 0x0a, 0x76, 0x2b, 0xd5, 0x74,
+	0xdf, 0x1f, 0xcd, 0x98, 0xe5, 0xec, 0x0d, 0xfe, 0xc2, 0x49, 0x24, 0x9c, 0x6e, 0x84, 0x86, 0xdc,
+	0xaf, 0x00, 0xb7, 0x03, 0x4e, 0xf7, 0xfe, 0xd2, 0xac, 0x6f, 0xc9, 0x28, 0xeb, 0x16, 0x64, 0x35,
+	0xbc, 0x1e, 0xa5, 0xe5, 0xe4, 0xc8, 0x19, 0x90, 0x77, 0xd0, 0x69, 0xdc, 0x69, 0xe5, 0xdf, 0x7a,
+	0xb4, 0xe8, 0xce, 0xb6, 0x66, 0x0f, 0x2c, 0x7c, 0x97, 0xdd, 0x29, 0xe0, 0xa7, 0x19, 0xaa, 0x06,
+	0x72, 0x7c, 0x6c, 0xef, 0xf9, 0xec, 0xcf, 0x00, 0xec, 0x60, 0x14, 0xa3, 0xdd, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ScheduleServiceClient is the client API for ScheduleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ScheduleServiceClient interface {
+	SetSchedule(ctx context.Context, in *SetScheduleReq, opts ...grpc.CallOption) (*SetScheduleRes, error)
+	RemoveSchedule(ctx context.Context, in *RemoveScheduleReq, opts ...grpc.CallOption) (*RemoveScheduleRes, error)
+	PreviewSchedule(ctx context.Context, in *PreviewScheduleReq, opts ...grpc.CallOption) (*PreviewScheduleRes, error)
+	// Parses a cron expression and computes its next fire times, an expression that doesn't parse fails with the
+	// position of the wrong field
+	PreviewCron(ctx context.Context, in *PreviewCronReq, opts ...grpc.CallOption) (*PreviewCronRes, error)
+}
+
+type scheduleServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewScheduleServiceClient(cc *grpc.ClientConn) ScheduleServiceClient {
+	return &scheduleServiceClient{cc}
+}
+
+func (c *scheduleServiceClient) SetSchedule(ctx context.Context, in *SetScheduleReq, opts ...grpc.CallOption) (*SetScheduleRes, error) {
+	out := new(SetScheduleRes)
+	err := c.cc.Invoke(ctx, "/model.ScheduleService/SetSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) RemoveSchedule(ctx context.Context, in *RemoveScheduleReq, opts ...grpc.CallOption) (*RemoveScheduleRes, error) {
+	out := new(RemoveScheduleRes)
+	err := c.cc.Invoke(ctx, "/model.ScheduleService/RemoveSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) PreviewSchedule(ctx context.Context, in *PreviewScheduleReq, opts ...grpc.CallOption) (*PreviewScheduleRes, error) {
+	out := new(PreviewScheduleRes)
+	err := c.cc.Invoke(ctx, "/model.ScheduleService/PreviewSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) PreviewCron(ctx context.Context, in *PreviewCronReq, opts ...grpc.CallOption) (*PreviewCronRes, error) {
+	out := new(PreviewCronRes)
+	err := c.cc.Invoke(ctx, "/model.ScheduleService/PreviewCron", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScheduleServiceServer is the server API for ScheduleService service.
+type ScheduleServiceServer interface {
+	SetSchedule(context.Context, *SetScheduleReq) (*SetScheduleRes, error)
+	RemoveSchedule(context.Context, *RemoveScheduleReq) (*RemoveScheduleRes, error)
+	PreviewSchedule(context.Context, *PreviewScheduleReq) (*PreviewScheduleRes, error)
+	// Parses a cron expression and computes its next fire times, an expression that doesn't parse fails with the
+	// position of the wrong field
+	PreviewCron(context.Context, *PreviewCronReq) (*PreviewCronRes, error)
+}
+
+func RegisterScheduleServiceServer(s *grpc.Server, srv ScheduleServiceServer) {
+	s.RegisterService(&_ScheduleService_serviceDesc, srv)
+}
+
+func _ScheduleService_SetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScheduleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.ScheduleService/SetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetSchedule(ctx, req.(*SetScheduleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_RemoveSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveScheduleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).RemoveSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.ScheduleService/RemoveSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).RemoveSchedule(ctx, req.(*RemoveScheduleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_PreviewSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewScheduleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).PreviewSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.ScheduleService/PreviewSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).PreviewSchedule(ctx, req.(*PreviewScheduleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_PreviewCron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewCronReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).PreviewCron(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.ScheduleService/PreviewCron",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).PreviewCron(ctx, req.(*PreviewCronReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ScheduleService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.ScheduleService",
+	HandlerType: (*ScheduleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetSchedule",
+			Handler:    _ScheduleService_SetSchedule_Handler,
+		},
+		{
+			MethodName: "RemoveSchedule",
+			Handler:    _ScheduleService_RemoveSchedule_Handler,
+		},
+		{
+			MethodName: "PreviewSchedule",
+			Handler:    _ScheduleService_PreviewSchedule_Handler,
+		},
+		{
+			MethodName: "PreviewCron",
+			Handler:    _ScheduleService_PreviewCron_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "schedule.proto",
+}
@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: secret.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Secret is a value like a password or token the params of jobs refer to as ${secrets.<name>}. The value is stored
+// encrypted and never returned, the run of a job gets it in place of the reference.
+type Secret struct {
+	// Set by the server
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Letters, digits, _ and - up to 64 characters, unique per owner. It can't be changed.
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Defaults to the caller like the owner of a job, only the jobs of the owner can refer to the secret. It can't be
+	// changed.
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Only set in requests, at most 64KiB and never empty. Responses never carry it.
+	Value string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// Set by the server
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Secret) Reset()         { *m = Secret{} }
+func (m *Secret) String() string { return proto.CompactTextString(m) }
+func (*Secret) ProtoMessage()    {}
+func (*Secret) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{0}
+}
+
+func (m *Secret) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Secret.Unmarshal(m, b)
+}
+func (m *Secret) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Secret.Marshal(b, m, deterministic)
+}
+func (m *Secret) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Secret.Merge(m, src)
+}
+func (m *Secret) XXX_Size() int {
+	return xxx_messageInfo_Secret.Size(m)
+}
+func (m *Secret) XXX_DiscardUnknown() {
+	xxx_messageInfo_Secret.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Secret proto.InternalMessageInfo
+
+func (m *Secret) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Secret) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Secret) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Secret) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Secret) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Secret) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Secret) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type CreateSecretReq struct {
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSecretReq) Reset()         { *m = CreateSecretReq{} }
+func (m *CreateSecretReq) String() string { return proto.CompactTextString(m) }
+func (*CreateSecretReq) ProtoMessage()    {}
+func (*CreateSecretReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{1}
+}
+
+func (m *CreateSecretReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSecretReq.Unmarshal(m, b)
+}
+func (m *CreateSecretReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSecretReq.Marshal(b, m, deterministic)
+}
+func (m *CreateSecretReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSecretReq.Merge(m, src)
+}
+func (m *CreateSecretReq) XXX_Size() int {
+	return xxx_messageInfo_CreateSecretReq.Size(m)
+}
+func (m *CreateSecretReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSecretReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSecretReq proto.InternalMessageInfo
+
+func (m *CreateSecretReq) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type CreateSecretRes struct {
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSecretRes) Reset()         { *m = CreateSecretRes{} }
+func (m *CreateSecretRes) String() string { return proto.CompactTextString(m) }
+func (*CreateSecretRes) ProtoMessage()    {}
+func (*CreateSecretRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{2}
+}
+
+func (m *CreateSecretRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSecretRes.Unmarshal(m, b)
+}
+func (m *CreateSecretRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSecretRes.Marshal(b, m, deterministic)
+}
+func (m *CreateSecretRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSecretRes.Merge(m, src)
+}
+func (m *CreateSecretRes) XXX_Size() int {
+	return xxx_messageInfo_CreateSecretRes.Size(m)
+}
+func (m *CreateSecretRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSecretRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSecretRes proto.InternalMessageInfo
+
+func (m *CreateSecretRes) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type ReadSecretReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadSecretReq) Reset()         { *m = ReadSecretReq{} }
+func (m *ReadSecretReq) String() string { return proto.CompactTextString(m) }
+func (*ReadSecretReq) ProtoMessage()    {}
+func (*ReadSecretReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{3}
+}
+
+func (m *ReadSecretReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadSecretReq.Unmarshal(m, b)
+}
+func (m *ReadSecretReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadSecretReq.Marshal(b, m, deterministic)
+}
+func (m *ReadSecretReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadSecretReq.Merge(m, src)
+}
+func (m *ReadSecretReq) XXX_Size() int {
+	return xxx_messageInfo_ReadSecretReq.Size(m)
+}
+func (m *ReadSecretReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadSecretReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadSecretReq proto.InternalMessageInfo
+
+func (m *ReadSecretReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadSecretRes struct {
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadSecretRes) Reset()         { *m = ReadSecretRes{} }
+func (m *ReadSecretRes) String() string { return proto.CompactTextString(m) }
+func (*ReadSecretRes) ProtoMessage()    {}
+func (*ReadSecretRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{4}
+}
+
+func (m *ReadSecretRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadSecretRes.Unmarshal(m, b)
+}
+func (m *ReadSecretRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadSecretRes.Marshal(b, m, deterministic)
+}
+func (m *ReadSecretRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadSecretRes.Merge(m, src)
+}
+func (m *ReadSecretRes) XXX_Size() int {
+	return xxx_messageInfo_ReadSecretRes.Size(m)
+}
+func (m *ReadSecretRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadSecretRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadSecretRes proto.InternalMessageInfo
+
+func (m *ReadSecretRes) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type UpdateSecretReq struct {
+	// Replaces the description, and the value unless it's empty
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateSecretReq) Reset()         { *m = UpdateSecretReq{} }
+func (m *UpdateSecretReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateSecretReq) ProtoMessage()    {}
+func (*UpdateSecretReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{5}
+}
+
+func (m *UpdateSecretReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSecretReq.Unmarshal(m, b)
+}
+func (m *UpdateSecretReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSecretReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateSecretReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSecretReq.Merge(m, src)
+}
+func (m *UpdateSecretReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateSecretReq.Size(m)
+}
+func (m *UpdateSecretReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSecretReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSecretReq proto.InternalMessageInfo
+
+func (m *UpdateSecretReq) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type UpdateSecretRes struct {
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateSecretRes) Reset()         { *m = UpdateSecretRes{} }
+func (m *UpdateSecretRes) String() string { return proto.CompactTextString(m) }
+func (*UpdateSecretRes) ProtoMessage()    {}
+func (*UpdateSecretRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{6}
+}
+
+func (m *UpdateSecretRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSecretRes.Unmarshal(m, b)
+}
+func (m *UpdateSecretRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSecretRes.Marshal(b, m, deterministic)
+}
+func (m *UpdateSecretRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSecretRes.Merge(m, src)
+}
+func (m *UpdateSecretRes) XXX_Size() int {
+	return xxx_messageInfo_UpdateSecretRes.Size(m)
+}
+func (m *UpdateSecretRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSecretRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSecretRes proto.InternalMessageInfo
+
+func (m *UpdateSecretRes) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type DeleteSecretReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSecretReq) Reset()         { *m = DeleteSecretReq{} }
+func (m *DeleteSecretReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteSecretReq) ProtoMessage()    {}
+func (*DeleteSecretReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{7}
+}
+
+func (m *DeleteSecretReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSecretReq.Unmarshal(m, b)
+}
+func (m *DeleteSecretReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSecretReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteSecretReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSecretReq.Merge(m, src)
+}
+func (m *DeleteSecretReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteSecretReq.Size(m)
+}
+func (m *DeleteSecretReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSecretReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSecretReq proto.InternalMessageInfo
+
+func (m *DeleteSecretReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteSecretRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSecretRes) Reset()         { *m = DeleteSecretRes{} }
+func (m *DeleteSecretRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteSecretRes) ProtoMessage()    {}
+func (*DeleteSecretRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{8}
+}
+
+func (m *DeleteSecretRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSecretRes.Unmarshal(m, b)
+}
+func (m *DeleteSecretRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSecretRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteSecretRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSecretRes.Merge(m, src)
+}
+func (m *DeleteSecretRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteSecretRes.Size(m)
+}
+func (m *DeleteSecretRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSecretRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSecretRes proto.InternalMessageInfo
+
+type ListSecretsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSecretsReq) Reset()         { *m = ListSecretsReq{} }
+func (m *ListSecretsReq) String() string { return proto.CompactTextString(m) }
+func (*ListSecretsReq) ProtoMessage()    {}
+func (*ListSecretsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{9}
+}
+
+func (m *ListSecretsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSecretsReq.Unmarshal(m, b)
+}
+func (m *ListSecretsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSecretsReq.Marshal(b, m, deterministic)
+}
+func (m *ListSecretsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSecretsReq.Merge(m, src)
+}
+func (m *ListSecretsReq) XXX_Size() int {
+	return xxx_messageInfo_ListSecretsReq.Size(m)
+}
+func (m *ListSecretsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSecretsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSecretsReq proto.InternalMessageInfo
+
+type ListSecretsRes struct {
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSecretsRes) Reset()         { *m = ListSecretsRes{} }
+func (m *ListSecretsRes) String() string { return proto.CompactTextString(m) }
+func (*ListSecretsRes) ProtoMessage()    {}
+func (*ListSecretsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6acf428160d7a216, []int{10}
+}
+
+func (m *ListSecretsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSecretsRes.Unmarshal(m, b)
+}
+func (m *ListSecretsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSecretsRes.Marshal(b, m, deterministic)
+}
+func (m *ListSecretsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSecretsRes.Merge(m, src)
+}
+func (m *ListSecretsRes) XXX_Size() int {
+	return xxx_messageInfo_ListSecretsRes.Size(m)
+}
+func (m *ListSecretsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSecretsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSecretsRes proto.InternalMessageInfo
+
+func (m *ListSecretsRes) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Secret)(nil), "model.Secret")
+	proto.RegisterType((*CreateSecretReq)(nil), "model.CreateSecretReq")
+	proto.RegisterType((*CreateSecretRes)(nil), "model.CreateSecretRes")
+	proto.RegisterType((*ReadSecretReq)(nil), "model.ReadSecretReq")
+	proto.RegisterType((*ReadSecretRes)(nil), "model.ReadSecretRes")
+	proto.RegisterType((*UpdateSecretReq)(nil), "model.UpdateSecretReq")
+	proto.RegisterType((*UpdateSecretRes)(nil), "model.UpdateSecretRes")
+	proto.RegisterType((*DeleteSecretReq)(nil), "model.DeleteSecretReq")
+	proto.RegisterType((*DeleteSecretRes)(nil), "model.DeleteSecretRes")
+	proto.RegisterType((*ListSecretsReq)(nil), "model.ListSecretsReq")
+	proto.RegisterType((*ListSecretsRes)(nil), "model.ListSecretsRes")
+}
+
+func init() { proto.RegisterFile("secret.proto", fileDescriptor_6acf428160d7a216) }
+
+var fileDescriptor_6acf428160d7a216 = []byte{
+	// 474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x96, 0xd3, 0x26, 0xa8, 0xe3, 0xa4, 0x2d, 0x43, 0x0b, 0xd6, 0x82, 0xd4, 0x74, 0x25, 0x44,
+	0xc5, 0xc1, 0x86, 0x20, 0xf1, 0x77, 0xab, 0xe0, 0xc8, 0xc9, 0x05, 0x09, 0x89, 0x03, 0xda, 0x66,
+	0x87, 0x68, 0xa5, 0xc4, 0x76, 0xbd, 0x9b, 0x70, 0x40, 0xbd, 0xf0, 0x0a, 0x3c, 0x1a, 0x07, 0x5e,
+	0x80, 0x07, 0xe0, 0x11, 0x50, 0x76, 0x37, 0xa9, 0xed, 0x1a, 0x11, 0xb8, 0x79, 0xbf, 0x99, 0xf9,
+	0xe6, 0xfb, 0xe6, 0x93, 0xa1, 0xaf, 0x69, 0x5c, 0x92, 0x89, 0x8b, 0x32, 0x37, 0x39, 0x76, 0x67,
+	0xb9, 0xa4, 0x29, 0xbb, 0x37, 0xc9, 0xf3, 0xc9, 0x94, 0x12, 0x51, 0xa8, 0x44, 0x64, 0x59, 0x6e,
+	0x84, 0x51, 0x79, 0xa6, 0x5d, 0x13, 0x3b, 0xf2, 0x55, 0xfb, 0x3a, 0x9f, 0x7f, 0x4a, 0x8c, 0x9a,
+	0x91, 0x36, 0x62, 0x56, 0xb8, 0x06, 0xfe, 0x2b, 0x80, 0xde, 0x99, 0xa5, 0xc5, 0x5d, 0xe8, 0x28,
+	0x19, 0x05, 0xc3, 0xe0, 0x64, 0x27, 0xed, 0x28, 0x89, 0x08, 0xdb, 0x99, 0x98, 0x51, 0xd4, 0xb1,
+	0x88, 0xfd, 0xc6, 0x21, 0x84, 0x92, 0xf4, 0xb8, 0x54, 0xc5, 0x72, 0x4b, 0xb4, 0x65, 0x4b, 0x55,
+	0x08, 0x0f, 0xa0, 0x9b, 0x7f, 0xce, 0xa8, 0x8c, 0xb6, 0x6d, 0xcd, 0x3d, 0x96, 0xe8, 0x42, 0x4c,
+	0xe7, 0x14, 0x75, 0x1d, 0x6a, 0x1f, 0xf8, 0x02, 0x60, 0x5c, 0x92, 0x30, 0x24, 0x3f, 0x0a, 0x13,
+	0xf5, 0x86, 0xc1, 0x49, 0x38, 0x62, 0xb1, 0x93, 0x1c, 0xaf, 0x24, 0xc7, 0x6f, 0x57, 0x92, 0xd3,
+	0x1d, 0xdf, 0x7d, 0x6a, 0x96, 0xa3, 0xf3, 0x42, 0xae, 0x46, 0x6f, 0xfc, 0x7d, 0xd4, 0x77, 0x9f,
+	0x1a, 0xfe, 0x1c, 0xf6, 0x5e, 0x59, 0x1e, 0xe7, 0x3b, 0xa5, 0x0b, 0xbc, 0x0f, 0x3d, 0x77, 0x5b,
+	0x6b, 0x3f, 0x1c, 0x0d, 0x62, 0x7b, 0xdc, 0xd8, 0x77, 0xf8, 0xe2, 0xf5, 0x49, 0xbd, 0xe9, 0xe4,
+	0x11, 0x0c, 0x52, 0x12, 0xf2, 0x6a, 0x63, 0xe3, 0xd8, 0xfc, 0x69, 0xbd, 0x41, 0xff, 0x83, 0xa4,
+	0x77, 0xd6, 0xd9, 0xff, 0x98, 0xa9, 0x4f, 0x6e, 0xbc, 0xf3, 0x18, 0xf6, 0x5e, 0xd3, 0x94, 0x0c,
+	0xfd, 0xd9, 0xce, 0xcd, 0x66, 0x8b, 0xe6, 0xfb, 0xb0, 0xfb, 0x46, 0x69, 0xe3, 0x00, 0x9d, 0xd2,
+	0x05, 0x7f, 0xd6, 0x40, 0x36, 0x15, 0x30, 0xfa, 0xb1, 0x05, 0x03, 0x07, 0x9d, 0x51, 0xb9, 0x50,
+	0x63, 0xc2, 0x0f, 0xd0, 0xaf, 0x26, 0x83, 0xb7, 0xfd, 0x60, 0x23, 0x68, 0xd6, 0x8e, 0x6b, 0x7e,
+	0xf7, 0xeb, 0xf7, 0x9f, 0xdf, 0x3a, 0x87, 0x3c, 0x4c, 0x16, 0x8f, 0x13, 0xb7, 0x45, 0xbf, 0xf4,
+	0xeb, 0x30, 0x05, 0xb8, 0xca, 0x06, 0x0f, 0x3c, 0x45, 0x2d, 0x4f, 0xd6, 0x86, 0x6a, 0x1e, 0x59,
+	0x5a, 0xc4, 0xfd, 0x0a, 0x6d, 0xf2, 0x45, 0xc9, 0x4b, 0x9c, 0x40, 0xbf, 0x7a, 0xfd, 0xb5, 0xe0,
+	0x46, 0x98, 0xac, 0x1d, 0xd7, 0xfc, 0x81, 0x65, 0x3e, 0x66, 0x77, 0x6a, 0xcc, 0xee, 0x23, 0x56,
+	0xf2, 0x72, 0x2d, 0xfe, 0x3d, 0xf4, 0xab, 0x49, 0xac, 0x17, 0x35, 0x12, 0x64, 0xed, 0xf8, 0xda,
+	0xc2, 0xc3, 0xeb, 0x16, 0x52, 0x08, 0x2b, 0xf1, 0xe1, 0xa1, 0x27, 0xa8, 0x87, 0xcc, 0x5a, 0x61,
+	0xcd, 0x6f, 0x59, 0xda, 0x01, 0x56, 0x0f, 0xfe, 0x28, 0x38, 0xef, 0xd9, 0x5f, 0xf7, 0xc9, 0xef,
+	0x01, 0x00, 0x48, 0x4a, 0x28, 0xfd, 0xeb, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SecretServiceClient is the client API for SecretService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SecretServiceClient interface {
+	CreateSecret(ctx context.Context, in *CreateSecretReq, opts ...grpc.CallOption) (*CreateSecretRes, error)
+	ReadSecret(ctx context.Context, in *ReadSecretReq, opts ...grpc.CallOption) (*ReadSecretRes, error)
+	// Replaces the description of a secret and rotates its value, runs starting afterwards get the new one
+	UpdateSecret(ctx context.Context, in *UpdateSecretReq, opts ...grpc.CallOption) (*UpdateSecretRes, error)
+	// Deletes a secret, the runs of jobs still referring to it fail
+	DeleteSecret(ctx context.Context, in *DeleteSecretReq, opts ...grpc.CallOption) (*DeleteSecretRes, error)
+	// Streams the secrets of the caller and their teams oldest first, without their values
+	ListSecrets(ctx context.Context, in *ListSecretsReq, opts ...grpc.CallOption) (SecretService_ListSecretsClient, error)
+}
+
+type secretServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSecretServiceClient(cc *grpc.ClientConn) SecretServiceClient {
+	return &secretServiceClient{cc}
+}
+
+func (c *secretServiceClient) CreateSecret(ctx context.Context, in *CreateSecretReq, opts ...grpc.CallOption) (*CreateSecretRes, error) {
+	out := new(CreateSecretRes)
+	err := c.cc.Invoke(ctx, "/model.SecretService/CreateSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ReadSecret(ctx context.Context, in *ReadSecretReq, opts ...grpc.CallOption) (*ReadSecretRes, error) {
+	out := new(ReadSecretRes)
+	err := c.cc.Invoke(ctx, "/model.SecretService/ReadSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) UpdateSecret(ctx context.Context, in *UpdateSecretReq, opts ...grpc.CallOption) (*UpdateSecretRes, error) {
+	out := new(UpdateSecretRes)
+	err := c.cc.Invoke(ctx, "/model.SecretService/UpdateSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) DeleteSecret(ctx context.Context, in *DeleteSecretReq, opts ...grpc.CallOption) (*DeleteSecretRes, error) {
+	out := new(DeleteSecretRes)
+	err := c.cc.Invoke(ctx, "/model.SecretService/DeleteSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSecrets(ctx context.Context, in *ListSecretsReq, opts ...grpc.CallOption) (SecretService_ListSecretsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SecretService_serviceDesc.Streams[0], "/model.SecretService/ListSecrets", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &secretServiceListSecretsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SecretService_ListSecretsClient interface {
+	Recv() (*ListSecretsRes, error)
+	grpc.ClientStream
+}
+
+type secretServiceListSecretsClient struct {
+	grpc.ClientStream
+}
+
+func (x *secretServiceListSecretsClient) Recv() (*ListSecretsRes, error) {
+	m := new(ListSecretsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SecretServiceServer is the server API for SecretService service.
+type SecretServiceServer interface {
+	CreateSecret(context.Context, *CreateSecretReq) (*CreateSecretRes, error)
+	ReadSecret(context.Context, *ReadSecretReq) (*ReadSecretRes, error)
+	// Replaces the description of a secret and rotates its value, runs starting afterwards get the new one
+	UpdateSecret(context.Context, *UpdateSecretReq) (*UpdateSecretRes, error)
+	// Deletes a secret, the runs of jobs still referring to it fail
+	DeleteSecret(context.Context, *DeleteSecretReq) (*DeleteSecretRes, error)
+	// Streams the secrets of the caller and their teams oldest first, without their values
+	ListSecrets(*ListSecretsReq, SecretService_ListSecretsServer) error
+}
+
+func RegisterSecretServiceServer(s *grpc.Server, srv SecretServiceServer) {
+	s.RegisterService(&_SecretService_serviceDesc, srv)
+}
+
+func _SecretService_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).CreateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.SecretService/CreateSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).CreateSecret(ctx, req.(*CreateSecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ReadSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadSecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ReadSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.SecretService/ReadSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ReadSecret(ctx, req.(*ReadSecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_UpdateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).UpdateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.SecretService/UpdateSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).UpdateSecret(ctx, req.(*UpdateSecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.SecretService/DeleteSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).DeleteSecret(ctx, req.(*DeleteSecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSecrets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSecretsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SecretServiceServer).ListSecrets(m, &secretServiceListSecretsServer{stream})
+}
+
+type SecretService_ListSecretsServer interface {
+	Send(*ListSecretsRes) error
+	grpc.ServerStream
+}
+
+type secretServiceListSecretsServer struct {
+	grpc.ServerStream
+}
+
+func (x *secretServiceListSecretsServer) Send(m *ListSecretsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _SecretService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.SecretService",
+	HandlerType: (*SecretServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSecret",
+			Handler:    _SecretService_CreateSecret_Handler,
+		},
+		{
+			MethodName: "ReadSecret",
+			Handler:    _SecretService_ReadSecret_Handler,
+		},
+		{
+			MethodName: "UpdateSecret",
+			Handler:    _SecretService_UpdateSecret_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _SecretService_DeleteSecret_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSecrets",
+			Handler:       _SecretService_ListSecrets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "secret.proto",
+}
@@ -0,0 +1,1040 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: team.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Team shares the jobs it owns with its members, jobs are owned by a team with the owner team:<team id>
+type Team struct {
+	// Set by the server
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Ids of the users in the team, set by the server and managed with AddTeamMember and RemoveTeamMember
+	Members []string `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	// Set by the server
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Roles granted to every member of the team on top of their own while RBAC is enabled, only admins can set them
+	Roles                []string `protobuf:"bytes,6,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Team) Reset()         { *m = Team{} }
+func (m *Team) String() string { return proto.CompactTextString(m) }
+func (*Team) ProtoMessage()    {}
+func (*Team) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{0}
+}
+
+func (m *Team) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Team.Unmarshal(m, b)
+}
+func (m *Team) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Team.Marshal(b, m, deterministic)
+}
+func (m *Team) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Team.Merge(m, src)
+}
+func (m *Team) XXX_Size() int {
+	return xxx_messageInfo_Team.Size(m)
+}
+func (m *Team) XXX_DiscardUnknown() {
+	xxx_messageInfo_Team.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Team proto.InternalMessageInfo
+
+func (m *Team) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Team) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Team) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *Team) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Team) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Team) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type CreateTeamReq struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTeamReq) Reset()         { *m = CreateTeamReq{} }
+func (m *CreateTeamReq) String() string { return proto.CompactTextString(m) }
+func (*CreateTeamReq) ProtoMessage()    {}
+func (*CreateTeamReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{1}
+}
+
+func (m *CreateTeamReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTeamReq.Unmarshal(m, b)
+}
+func (m *CreateTeamReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTeamReq.Marshal(b, m, deterministic)
+}
+func (m *CreateTeamReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTeamReq.Merge(m, src)
+}
+func (m *CreateTeamReq) XXX_Size() int {
+	return xxx_messageInfo_CreateTeamReq.Size(m)
+}
+func (m *CreateTeamReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTeamReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTeamReq proto.InternalMessageInfo
+
+func (m *CreateTeamReq) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type CreateTeamRes struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTeamRes) Reset()         { *m = CreateTeamRes{} }
+func (m *CreateTeamRes) String() string { return proto.CompactTextString(m) }
+func (*CreateTeamRes) ProtoMessage()    {}
+func (*CreateTeamRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{2}
+}
+
+func (m *CreateTeamRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTeamRes.Unmarshal(m, b)
+}
+func (m *CreateTeamRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTeamRes.Marshal(b, m, deterministic)
+}
+func (m *CreateTeamRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTeamRes.Merge(m, src)
+}
+func (m *CreateTeamRes) XXX_Size() int {
+	return xxx_messageInfo_CreateTeamRes.Size(m)
+}
+func (m *CreateTeamRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTeamRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTeamRes proto.InternalMessageInfo
+
+func (m *CreateTeamRes) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type ReadTeamReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadTeamReq) Reset()         { *m = ReadTeamReq{} }
+func (m *ReadTeamReq) String() string { return proto.CompactTextString(m) }
+func (*ReadTeamReq) ProtoMessage()    {}
+func (*ReadTeamReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{3}
+}
+
+func (m *ReadTeamReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadTeamReq.Unmarshal(m, b)
+}
+func (m *ReadTeamReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadTeamReq.Marshal(b, m, deterministic)
+}
+func (m *ReadTeamReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadTeamReq.Merge(m, src)
+}
+func (m *ReadTeamReq) XXX_Size() int {
+	return xxx_messageInfo_ReadTeamReq.Size(m)
+}
+func (m *ReadTeamReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadTeamReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadTeamReq proto.InternalMessageInfo
+
+func (m *ReadTeamReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadTeamRes struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadTeamRes) Reset()         { *m = ReadTeamRes{} }
+func (m *ReadTeamRes) String() string { return proto.CompactTextString(m) }
+func (*ReadTeamRes) ProtoMessage()    {}
+func (*ReadTeamRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{4}
+}
+
+func (m *ReadTeamRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadTeamRes.Unmarshal(m, b)
+}
+func (m *ReadTeamRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadTeamRes.Marshal(b, m, deterministic)
+}
+func (m *ReadTeamRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadTeamRes.Merge(m, src)
+}
+func (m *ReadTeamRes) XXX_Size() int {
+	return xxx_messageInfo_ReadTeamRes.Size(m)
+}
+func (m *ReadTeamRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadTeamRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadTeamRes proto.InternalMessageInfo
+
+func (m *ReadTeamRes) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type UpdateTeamReq struct {
+	// The team with its new name or roles
+	Team *Team `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	// Fields of team to update (name, roles), all of them when empty. Only admins can update the roles, an empty mask
+	// leaves them as they are for everyone else.
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateTeamReq) Reset()         { *m = UpdateTeamReq{} }
+func (m *UpdateTeamReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateTeamReq) ProtoMessage()    {}
+func (*UpdateTeamReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{5}
+}
+
+func (m *UpdateTeamReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTeamReq.Unmarshal(m, b)
+}
+func (m *UpdateTeamReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTeamReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateTeamReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTeamReq.Merge(m, src)
+}
+func (m *UpdateTeamReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateTeamReq.Size(m)
+}
+func (m *UpdateTeamReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTeamReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTeamReq proto.InternalMessageInfo
+
+func (m *UpdateTeamReq) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func (m *UpdateTeamReq) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTeamRes struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTeamRes) Reset()         { *m = UpdateTeamRes{} }
+func (m *UpdateTeamRes) String() string { return proto.CompactTextString(m) }
+func (*UpdateTeamRes) ProtoMessage()    {}
+func (*UpdateTeamRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{6}
+}
+
+func (m *UpdateTeamRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTeamRes.Unmarshal(m, b)
+}
+func (m *UpdateTeamRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTeamRes.Marshal(b, m, deterministic)
+}
+func (m *UpdateTeamRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTeamRes.Merge(m, src)
+}
+func (m *UpdateTeamRes) XXX_Size() int {
+	return xxx_messageInfo_UpdateTeamRes.Size(m)
+}
+func (m *UpdateTeamRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTeamRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTeamRes proto.InternalMessageInfo
+
+func (m *UpdateTeamRes) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type DeleteTeamReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTeamReq) Reset()         { *m = DeleteTeamReq{} }
+func (m *DeleteTeamReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteTeamReq) ProtoMessage()    {}
+func (*DeleteTeamReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{7}
+}
+
+func (m *DeleteTeamReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTeamReq.Unmarshal(m, b)
+}
+func (m *DeleteTeamReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTeamReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteTeamReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTeamReq.Merge(m, src)
+}
+func (m *DeleteTeamReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteTeamReq.Size(m)
+}
+func (m *DeleteTeamReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTeamReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTeamReq proto.InternalMessageInfo
+
+func (m *DeleteTeamReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteTeamRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTeamRes) Reset()         { *m = DeleteTeamRes{} }
+func (m *DeleteTeamRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteTeamRes) ProtoMessage()    {}
+func (*DeleteTeamRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{8}
+}
+
+func (m *DeleteTeamRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTeamRes.Unmarshal(m, b)
+}
+func (m *DeleteTeamRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTeamRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteTeamRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTeamRes.Merge(m, src)
+}
+func (m *DeleteTeamRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteTeamRes.Size(m)
+}
+func (m *DeleteTeamRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTeamRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTeamRes proto.InternalMessageInfo
+
+type ListTeamsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTeamsReq) Reset()         { *m = ListTeamsReq{} }
+func (m *ListTeamsReq) String() string { return proto.CompactTextString(m) }
+func (*ListTeamsReq) ProtoMessage()    {}
+func (*ListTeamsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{9}
+}
+
+func (m *ListTeamsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTeamsReq.Unmarshal(m, b)
+}
+func (m *ListTeamsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTeamsReq.Marshal(b, m, deterministic)
+}
+func (m *ListTeamsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTeamsReq.Merge(m, src)
+}
+func (m *ListTeamsReq) XXX_Size() int {
+	return xxx_messageInfo_ListTeamsReq.Size(m)
+}
+func (m *ListTeamsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTeamsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTeamsReq proto.InternalMessageInfo
+
+type ListTeamsRes struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTeamsRes) Reset()         { *m = ListTeamsRes{} }
+func (m *ListTeamsRes) String() string { return proto.CompactTextString(m) }
+func (*ListTeamsRes) ProtoMessage()    {}
+func (*ListTeamsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{10}
+}
+
+func (m *ListTeamsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTeamsRes.Unmarshal(m, b)
+}
+func (m *ListTeamsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTeamsRes.Marshal(b, m, deterministic)
+}
+func (m *ListTeamsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTeamsRes.Merge(m, src)
+}
+func (m *ListTeamsRes) XXX_Size() int {
+	return xxx_messageInfo_ListTeamsRes.Size(m)
+}
+func (m *ListTeamsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTeamsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTeamsRes proto.InternalMessageInfo
+
+func (m *ListTeamsRes) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type AddTeamMemberReq struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTeamMemberReq) Reset()         { *m = AddTeamMemberReq{} }
+func (m *AddTeamMemberReq) String() string { return proto.CompactTextString(m) }
+func (*AddTeamMemberReq) ProtoMessage()    {}
+func (*AddTeamMemberReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{11}
+}
+
+func (m *AddTeamMemberReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTeamMemberReq.Unmarshal(m, b)
+}
+func (m *AddTeamMemberReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTeamMemberReq.Marshal(b, m, deterministic)
+}
+func (m *AddTeamMemberReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTeamMemberReq.Merge(m, src)
+}
+func (m *AddTeamMemberReq) XXX_Size() int {
+	return xxx_messageInfo_AddTeamMemberReq.Size(m)
+}
+func (m *AddTeamMemberReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTeamMemberReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTeamMemberReq proto.InternalMessageInfo
+
+func (m *AddTeamMemberReq) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *AddTeamMemberReq) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type AddTeamMemberRes struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTeamMemberRes) Reset()         { *m = AddTeamMemberRes{} }
+func (m *AddTeamMemberRes) String() string { return proto.CompactTextString(m) }
+func (*AddTeamMemberRes) ProtoMessage()    {}
+func (*AddTeamMemberRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{12}
+}
+
+func (m *AddTeamMemberRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTeamMemberRes.Unmarshal(m, b)
+}
+func (m *AddTeamMemberRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTeamMemberRes.Marshal(b, m, deterministic)
+}
+func (m *AddTeamMemberRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTeamMemberRes.Merge(m, src)
+}
+func (m *AddTeamMemberRes) XXX_Size() int {
+	return xxx_messageInfo_AddTeamMemberRes.Size(m)
+}
+func (m *AddTeamMemberRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTeamMemberRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTeamMemberRes proto.InternalMessageInfo
+
+func (m *AddTeamMemberRes) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type RemoveTeamMemberReq struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveTeamMemberReq) Reset()         { *m = RemoveTeamMemberReq{} }
+func (m *RemoveTeamMemberReq) String() string { return proto.CompactTextString(m) }
+func (*RemoveTeamMemberReq) ProtoMessage()    {}
+func (*RemoveTeamMemberReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{13}
+}
+
+func (m *RemoveTeamMemberReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveTeamMemberReq.Unmarshal(m, b)
+}
+func (m *RemoveTeamMemberReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveTeamMemberReq.Marshal(b, m, deterministic)
+}
+func (m *RemoveTeamMemberReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveTeamMemberReq.Merge(m, src)
+}
+func (m *RemoveTeamMemberReq) XXX_Size() int {
+	return xxx_messageInfo_RemoveTeamMemberReq.Size(m)
+}
+func (m *RemoveTeamMemberReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveTeamMemberReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveTeamMemberReq proto.InternalMessageInfo
+
+func (m *RemoveTeamMemberReq) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *RemoveTeamMemberReq) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type RemoveTeamMemberRes struct {
+	Team                 *Team    `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveTeamMemberRes) Reset()         { *m = RemoveTeamMemberRes{} }
+func (m *RemoveTeamMemberRes) String() string { return proto.CompactTextString(m) }
+func (*RemoveTeamMemberRes) ProtoMessage()    {}
+func (*RemoveTeamMemberRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{14}
+}
+
+func (m *RemoveTeamMemberRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveTeamMemberRes.Unmarshal(m, b)
+}
+func (m *RemoveTeamMemberRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveTeamMemberRes.Marshal(b, m, deterministic)
+}
+func (m *RemoveTeamMemberRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveTeamMemberRes.Merge(m, src)
+}
+func (m *RemoveTeamMemberRes) XXX_Size() int {
+	return xxx_messageInfo_RemoveTeamMemberRes.Size(m)
+}
+func (m *RemoveTeamMemberRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveTeamMemberRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveTeamMemberRes proto.InternalMessageInfo
+
+func (m *RemoveTeamMemberRes) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Team)(nil), "model.Team")
+	proto.RegisterType((*CreateTeamReq)(nil), "model.CreateTeamReq")
+	proto.RegisterType((*CreateTeamRes)(nil), "model.CreateTeamRes")
+	proto.RegisterType((*ReadTeamReq)(nil), "model.ReadTeamReq")
+	proto.RegisterType((*ReadTeamRes)(nil), "model.ReadTeamRes")
+	proto.RegisterType((*UpdateTeamReq)(nil), "model.UpdateTeamReq")
+	proto.RegisterType((*UpdateTeamRes)(nil), "model.UpdateTeamRes")
+	proto.RegisterType((*DeleteTeamReq)(nil), "model.DeleteTeamReq")
+	proto.RegisterType((*DeleteTeamRes)(nil), "model.DeleteTeamRes")
+	proto.RegisterType((*ListTeamsReq)(nil), "model.ListTeamsReq")
+	proto.RegisterType((*ListTeamsRes)(nil), "model.ListTeamsRes")
+	proto.RegisterType((*AddTeamMemberReq)(nil), "model.AddTeamMemberReq")
+	proto.RegisterType((*AddTeamMemberRes)(nil), "model.AddTeamMemberRes")
+	proto.RegisterType((*RemoveTeamMemberReq)(nil), "model.RemoveTeamMemberReq")
+	proto.RegisterType((*RemoveTeamMemberRes)(nil), "model.RemoveTeamMemberRes")
+}
+
+func init() { proto.RegisterFile("team.proto", fileDescriptor_8b4e9e93d7b2c6bb) }
+
+var fileDescriptor_8b4e9e93d7b2c6bb = []byte{
+	// 618 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x41, 0x6b, 0x13, 0x41,
+	0x14, 0xc7, 0xd9, 0x34, 0x49, 0xcd, 0x5b, 0x53, 0xdb, 0x69, 0xb1, 0xcb, 0x56, 0x69, 0x1c, 0x50,
+	0x4b, 0xc1, 0xdd, 0x98, 0x82, 0x60, 0x3d, 0x05, 0x8b, 0xd2, 0x62, 0x41, 0xd7, 0x7a, 0xf2, 0x10,
+	0x26, 0x9d, 0x69, 0x19, 0x9a, 0xcd, 0xa6, 0x3b, 0x93, 0x80, 0x94, 0x5c, 0xbc, 0x7b, 0xf2, 0x8b,
+	0x09, 0x7e, 0x05, 0x3f, 0x88, 0xcc, 0xec, 0xec, 0x26, 0xbb, 0x49, 0x4c, 0xc0, 0xd3, 0xee, 0x9b,
+	0xf7, 0xde, 0xff, 0xff, 0xe6, 0xed, 0x8f, 0x05, 0x90, 0x8c, 0x84, 0xde, 0x20, 0x8e, 0x64, 0x84,
+	0x2a, 0x61, 0x44, 0x59, 0xcf, 0x7d, 0x74, 0x1d, 0x45, 0xd7, 0x3d, 0xe6, 0x93, 0x01, 0xf7, 0x49,
+	0xbf, 0x1f, 0x49, 0x22, 0x79, 0xd4, 0x17, 0x49, 0x91, 0xdb, 0x30, 0x59, 0x1d, 0x75, 0x87, 0x57,
+	0xfe, 0x15, 0x67, 0x3d, 0xda, 0x09, 0x89, 0xb8, 0x31, 0x15, 0xfb, 0xc5, 0x0a, 0xc9, 0x43, 0x26,
+	0x24, 0x09, 0x07, 0x49, 0x01, 0xfe, 0x65, 0x41, 0xf9, 0x82, 0x91, 0x10, 0x6d, 0x40, 0x89, 0x53,
+	0xc7, 0x6a, 0x58, 0x07, 0xb5, 0xa0, 0xc4, 0x29, 0x42, 0x50, 0xee, 0x93, 0x90, 0x39, 0x25, 0x7d,
+	0xa2, 0xdf, 0x91, 0x03, 0xeb, 0x21, 0x0b, 0xbb, 0x2c, 0x16, 0xce, 0x5a, 0x63, 0xed, 0xa0, 0x16,
+	0xa4, 0x21, 0x7a, 0x0d, 0x70, 0x19, 0x33, 0x22, 0x19, 0xed, 0x10, 0xe9, 0x94, 0x1b, 0xd6, 0x81,
+	0xdd, 0x72, 0xbd, 0xc4, 0xdc, 0x4b, 0xcd, 0xbd, 0x8b, 0xd4, 0x3c, 0xa8, 0x99, 0xea, 0xb6, 0x54,
+	0xad, 0xc3, 0x01, 0x4d, 0x5b, 0x2b, 0xcb, 0x5b, 0x4d, 0x75, 0x5b, 0xa2, 0x1d, 0xa8, 0xc4, 0x51,
+	0x8f, 0x09, 0xa7, 0xaa, 0xa7, 0x49, 0x02, 0xdc, 0x84, 0xfa, 0x5b, 0xad, 0xae, 0xee, 0x15, 0xb0,
+	0x5b, 0xb4, 0x0f, 0x65, 0xb5, 0x59, 0x7d, 0x39, 0xbb, 0x65, 0x7b, 0x7a, 0xb5, 0x9e, 0xce, 0xea,
+	0x44, 0xb1, 0x43, 0x2c, 0xef, 0x78, 0x0c, 0x76, 0xc0, 0x08, 0x4d, 0x1d, 0x0a, 0xcb, 0xc3, 0xde,
+	0x74, 0x7a, 0x05, 0xb9, 0x10, 0xea, 0x5f, 0xf4, 0xad, 0x56, 0x1d, 0x19, 0xbd, 0x01, 0x3b, 0xd9,
+	0x83, 0xfe, 0xda, 0x4e, 0x69, 0xc1, 0xda, 0xde, 0x29, 0x20, 0xce, 0x89, 0xb8, 0x09, 0xcc, 0x92,
+	0xd5, 0x3b, 0x6e, 0xe6, 0xed, 0x56, 0x18, 0x70, 0x1f, 0xea, 0x27, 0xac, 0xc7, 0x24, 0x5b, 0x74,
+	0xe3, 0x07, 0xf9, 0x02, 0x81, 0x37, 0xe0, 0xfe, 0x07, 0x2e, 0xa4, 0x0a, 0x45, 0xc0, 0x6e, 0xb1,
+	0x9f, 0x8b, 0x57, 0xb0, 0x3c, 0x81, 0xcd, 0x36, 0xd5, 0x2b, 0x3c, 0xd7, 0x90, 0x29, 0xd7, 0x5d,
+	0x58, 0x57, 0xb9, 0x4e, 0x66, 0x5d, 0x55, 0xe1, 0x29, 0x55, 0x89, 0xa1, 0x60, 0xb1, 0x4a, 0x24,
+	0xc0, 0x56, 0x55, 0x78, 0x4a, 0xf1, 0xd1, 0x8c, 0xca, 0x0a, 0xd6, 0xef, 0x61, 0x3b, 0x60, 0x61,
+	0x34, 0x62, 0xff, 0xeb, 0xfe, 0x6a, 0x9e, 0xd0, 0xf2, 0x01, 0x5a, 0x3f, 0x2a, 0x60, 0xab, 0xf0,
+	0x33, 0x8b, 0x47, 0xfc, 0x92, 0xa1, 0x4f, 0x00, 0x13, 0x40, 0xd1, 0x8e, 0x69, 0xc8, 0x51, 0xee,
+	0xce, 0x3b, 0x15, 0x78, 0xf7, 0xfb, 0xef, 0x3f, 0x3f, 0x4b, 0x5b, 0xb8, 0xe6, 0x8f, 0x5e, 0xfa,
+	0x4a, 0x5b, 0x1c, 0x27, 0x00, 0x9d, 0xc1, 0xbd, 0x14, 0x51, 0x84, 0x4c, 0xeb, 0x14, 0xd2, 0xee,
+	0xec, 0x99, 0xc0, 0x0f, 0xb5, 0xd8, 0x26, 0xda, 0xc8, 0xc4, 0xfc, 0x3b, 0x4e, 0xc7, 0xe8, 0x2b,
+	0xc0, 0x84, 0xa7, 0x6c, 0xbc, 0x1c, 0xd1, 0xee, 0xbc, 0x53, 0x81, 0x9f, 0x68, 0xc5, 0xbd, 0xd6,
+	0xf6, 0x94, 0xa2, 0x7a, 0x78, 0x9c, 0x8e, 0xcd, 0xa0, 0x1f, 0x01, 0x26, 0x64, 0x65, 0xe2, 0x39,
+	0x1a, 0xdd, 0x79, 0xa7, 0xd9, 0xb8, 0x87, 0xc5, 0x71, 0xcf, 0xa0, 0x96, 0xa1, 0x88, 0xb6, 0x4d,
+	0xeb, 0x34, 0xac, 0xee, 0x9c, 0x43, 0x81, 0xb7, 0xb4, 0x9c, 0x8d, 0x26, 0xab, 0x6c, 0x5a, 0x88,
+	0x43, 0x3d, 0xc7, 0x17, 0xda, 0x35, 0xad, 0x45, 0x76, 0xdd, 0x05, 0x09, 0x81, 0x9f, 0x69, 0xdd,
+	0x06, 0xde, 0x2b, 0xec, 0xa0, 0xc3, 0xe9, 0xd8, 0x37, 0x3f, 0xd8, 0x63, 0xeb, 0x10, 0x7d, 0x83,
+	0xcd, 0x22, 0x4c, 0xc8, 0xcd, 0xbe, 0xd2, 0x0c, 0xae, 0xee, 0xe2, 0x9c, 0xc0, 0x2f, 0xb4, 0xe7,
+	0xf3, 0xc3, 0xa7, 0xff, 0xf0, 0xf4, 0xef, 0x0c, 0xd4, 0xe3, 0x6e, 0x55, 0xff, 0x50, 0x8e, 0xfe,
+	0x0e, 0x00, 0x8a, 0x33, 0xcd, 0x10, 0xa2, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TeamServiceClient is the client API for TeamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TeamServiceClient interface {
+	// Creates a team with the caller as its first member
+	CreateTeam(ctx context.Context, in *CreateTeamReq, opts ...grpc.CallOption) (*CreateTeamRes, error)
+	ReadTeam(ctx context.Context, in *ReadTeamReq, opts ...grpc.CallOption) (*ReadTeamRes, error)
+	// Renames a team
+	UpdateTeam(ctx context.Context, in *UpdateTeamReq, opts ...grpc.CallOption) (*UpdateTeamRes, error)
+	// Deletes a team, which fails while it still owns jobs
+	DeleteTeam(ctx context.Context, in *DeleteTeamReq, opts ...grpc.CallOption) (*DeleteTeamRes, error)
+	// Streams the teams of the caller oldest first, all teams for admins
+	ListTeams(ctx context.Context, in *ListTeamsReq, opts ...grpc.CallOption) (TeamService_ListTeamsClient, error)
+	// Adds an existing user to a team, adding a member again changes nothing
+	AddTeamMember(ctx context.Context, in *AddTeamMemberReq, opts ...grpc.CallOption) (*AddTeamMemberRes, error)
+	// Removes a user from a team, members can also leave a team themselves
+	RemoveTeamMember(ctx context.Context, in *RemoveTeamMemberReq, opts ...grpc.CallOption) (*RemoveTeamMemberRes, error)
+}
+
+type teamServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTeamServiceClient(cc *grpc.ClientConn) TeamServiceClient {
+	return &teamServiceClient{cc}
+}
+
+func (c *teamServiceClient) CreateTeam(ctx context.Context, in *CreateTeamReq, opts ...grpc.CallOption) (*CreateTeamRes, error) {
+	out := new(CreateTeamRes)
+	err := c.cc.Invoke(ctx, "/model.TeamService/CreateTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ReadTeam(ctx context.Context, in *ReadTeamReq, opts ...grpc.CallOption) (*ReadTeamRes, error) {
+	out := new(ReadTeamRes)
+	err := c.cc.Invoke(ctx, "/model.TeamService/ReadTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) UpdateTeam(ctx context.Context, in *UpdateTeamReq, opts ...grpc.CallOption) (*UpdateTeamRes, error) {
+	out := new(UpdateTeamRes)
+	err := c.cc.Invoke(ctx, "/model.TeamService/UpdateTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) DeleteTeam(ctx context.Context, in *DeleteTeamReq, opts ...grpc.CallOption) (*DeleteTeamRes, error) {
+	out := new(DeleteTeamRes)
+	err := c.cc.Invoke(ctx, "/model.TeamService/DeleteTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListTeams(ctx context.Context, in *ListTeamsReq, opts ...grpc.CallOption) (TeamService_ListTeamsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TeamService_serviceDesc.Streams[0], "/model.TeamService/ListTeams", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &teamServiceListTeamsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TeamService_ListTeamsClient interface {
+	Recv() (*ListTeamsRes, error)
+	grpc.ClientStream
+}
+
+type teamServiceListTeamsClient struct {
+	grpc.ClientStream
+}
+
+func (x *teamServiceListTeamsClient) Recv() (*ListTeamsRes, error) {
+	m := new(ListTeamsRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *teamServiceClient) AddTeamMember(ctx context.Context, in *AddTeamMemberReq, opts ...grpc.CallOption) (*AddTeamMemberRes, error) {
+	out := new(AddTeamMemberRes)
+	err := c.cc.Invoke(ctx, "/model.TeamService/AddTeamMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) RemoveTeamMember(ctx context.Context, in *RemoveTeamMemberReq, opts ...grpc.CallOption) (*RemoveTeamMemberRes, error) {
+	out := new(RemoveTeamMemberRes)
+	err := c.cc.Invoke(ctx, "/model.TeamService/RemoveTeamMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TeamServiceServer is the server API for TeamService service.
+type TeamServiceServer interface {
+	// Creates a team with the caller as its first member
+	CreateTeam(context.Context, *CreateTeamReq) (*CreateTeamRes, error)
+	ReadTeam(context.Context, *ReadTeamReq) (*ReadTeamRes, error)
+	// Renames a team
+	UpdateTeam(context.Context, *UpdateTeamReq) (*UpdateTeamRes, error)
+	// Deletes a team, which fails while it still owns jobs
+	DeleteTeam(context.Context, *DeleteTeamReq) (*DeleteTeamRes, error)
+	// Streams the teams of the caller oldest first, all teams for admins
+	ListTeams(*ListTeamsReq, TeamService_ListTeamsServer) error
+	// Adds an existing user to a team, adding a member again changes nothing
+	AddTeamMember(context.Context, *AddTeamMemberReq) (*AddTeamMemberRes, error)
+	// Removes a user from a team, members can also leave a team themselves
+	RemoveTeamMember(context.Context, *RemoveTeamMemberReq) (*RemoveTeamMemberRes, error)
+}
+
+func RegisterTeamServiceServer(s *grpc.Server, srv TeamServiceServer) {
+	s.RegisterService(&_TeamService_serviceDesc, srv)
+}
+
+func _TeamService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TeamService/CreateTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).CreateTeam(ctx, req.(*CreateTeamReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ReadTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadTeamReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ReadTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TeamService/ReadTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ReadTeam(ctx, req.(*ReadTeamReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_UpdateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTeamReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).UpdateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TeamService/UpdateTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).UpdateTeam(ctx, req.(*UpdateTeamReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_DeleteTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTeamReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).DeleteTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TeamService/DeleteTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).DeleteTeam(ctx, req.(*DeleteTeamReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ListTeams_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTeamsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TeamServiceServer).ListTeams(m, &teamServiceListTeamsServer{stream})
+}
+
+type TeamService_ListTeamsServer interface {
+	Send(*ListTeamsRes) error
+	grpc.ServerStream
+}
+
+type teamServiceListTeamsServer struct {
+	grpc.ServerStream
+}
+
+func (x *teamServiceListTeamsServer) Send(m *ListTeamsRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TeamService_AddTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTeamMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).AddTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TeamService/AddTeamMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).AddTeamMember(ctx, req.(*AddTeamMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_RemoveTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTeamMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).RemoveTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TeamService/RemoveTeamMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).RemoveTeamMember(ctx, req.(*RemoveTeamMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TeamService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.TeamService",
+	HandlerType: (*TeamServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTeam",
+			Handler:    _TeamService_CreateTeam_Handler,
+		},
+		{
+			MethodName: "ReadTeam",
+			Handler:    _TeamService_ReadTeam_Handler,
+		},
+		{
+			MethodName: "UpdateTeam",
+			Handler:    _TeamService_UpdateTeam_Handler,
+		},
+		{
+			MethodName: "DeleteTeam",
+			Handler:    _TeamService_DeleteTeam_Handler,
+		},
+		{
+			MethodName: "AddTeamMember",
+			Handler:    _TeamService_AddTeamMember_Handler,
+		},
+		{
+			MethodName: "RemoveTeamMember",
+			Handler:    _TeamService_RemoveTeamMember_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListTeams",
+			Handler:       _TeamService_ListTeams_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "team.proto",
+}
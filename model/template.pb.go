@@ -0,0 +1,1027 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: template.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// TemplateParameter is a parameter of a template, the job of the template refers to it as {{name}}
+type TemplateParameter struct {
+	// At most 64 letters, digits and underscores, starting with a letter
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Replaces the placeholders of optional parameters jobs are created without
+	DefaultValue string `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Jobs can only be created from the template with a value for required parameters
+	Required             bool     `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TemplateParameter) Reset()         { *m = TemplateParameter{} }
+func (m *TemplateParameter) String() string { return proto.CompactTextString(m) }
+func (*TemplateParameter) ProtoMessage()    {}
+func (*TemplateParameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{0}
+}
+
+func (m *TemplateParameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemplateParameter.Unmarshal(m, b)
+}
+func (m *TemplateParameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemplateParameter.Marshal(b, m, deterministic)
+}
+func (m *TemplateParameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemplateParameter.Merge(m, src)
+}
+func (m *TemplateParameter) XXX_Size() int {
+	return xxx_messageInfo_TemplateParameter.Size(m)
+}
+func (m *TemplateParameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemplateParameter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TemplateParameter proto.InternalMessageInfo
+
+func (m *TemplateParameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TemplateParameter) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *TemplateParameter) GetDefaultValue() string {
+	if m != nil {
+		return m.DefaultValue
+	}
+	return ""
+}
+
+func (m *TemplateParameter) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+// JobTemplate is a job that only differs in the values of its parameters every time it's created
+type JobTemplate struct {
+	// Set by the server
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Defaults to the caller like the owner of a job, templates owned by team:<team id> are shared with its members
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// At most 32 parameters with unique names
+	Parameters []*TemplateParameter `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// The job created from the template. Its name, description, handler, command, the cron expression and time zone of
+	// its schedule, the values of its labels, the channels of its notifications and the strings in its params can contain
+	// placeholders for the parameters like {{name}}. The owner and the fields set by the server are ignored.
+	Job *Job `protobuf:"bytes,6,opt,name=job,proto3" json:"job,omitempty"`
+	// Set by the server, starts at 1 and is incremented by every update
+	Version              int64                `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *JobTemplate) Reset()         { *m = JobTemplate{} }
+func (m *JobTemplate) String() string { return proto.CompactTextString(m) }
+func (*JobTemplate) ProtoMessage()    {}
+func (*JobTemplate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{1}
+}
+
+func (m *JobTemplate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobTemplate.Unmarshal(m, b)
+}
+func (m *JobTemplate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobTemplate.Marshal(b, m, deterministic)
+}
+func (m *JobTemplate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobTemplate.Merge(m, src)
+}
+func (m *JobTemplate) XXX_Size() int {
+	return xxx_messageInfo_JobTemplate.Size(m)
+}
+func (m *JobTemplate) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobTemplate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobTemplate proto.InternalMessageInfo
+
+func (m *JobTemplate) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetParameters() []*TemplateParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *JobTemplate) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *JobTemplate) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *JobTemplate) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *JobTemplate) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type CreateTemplateReq struct {
+	Template             *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CreateTemplateReq) Reset()         { *m = CreateTemplateReq{} }
+func (m *CreateTemplateReq) String() string { return proto.CompactTextString(m) }
+func (*CreateTemplateReq) ProtoMessage()    {}
+func (*CreateTemplateReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{2}
+}
+
+func (m *CreateTemplateReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTemplateReq.Unmarshal(m, b)
+}
+func (m *CreateTemplateReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTemplateReq.Marshal(b, m, deterministic)
+}
+func (m *CreateTemplateReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTemplateReq.Merge(m, src)
+}
+func (m *CreateTemplateReq) XXX_Size() int {
+	return xxx_messageInfo_CreateTemplateReq.Size(m)
+}
+func (m *CreateTemplateReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTemplateReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTemplateReq proto.InternalMessageInfo
+
+func (m *CreateTemplateReq) GetTemplate() *JobTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type CreateTemplateRes struct {
+	Template             *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CreateTemplateRes) Reset()         { *m = CreateTemplateRes{} }
+func (m *CreateTemplateRes) String() string { return proto.CompactTextString(m) }
+func (*CreateTemplateRes) ProtoMessage()    {}
+func (*CreateTemplateRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{3}
+}
+
+func (m *CreateTemplateRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTemplateRes.Unmarshal(m, b)
+}
+func (m *CreateTemplateRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTemplateRes.Marshal(b, m, deterministic)
+}
+func (m *CreateTemplateRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTemplateRes.Merge(m, src)
+}
+func (m *CreateTemplateRes) XXX_Size() int {
+	return xxx_messageInfo_CreateTemplateRes.Size(m)
+}
+func (m *CreateTemplateRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTemplateRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTemplateRes proto.InternalMessageInfo
+
+func (m *CreateTemplateRes) GetTemplate() *JobTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type ReadTemplateReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadTemplateReq) Reset()         { *m = ReadTemplateReq{} }
+func (m *ReadTemplateReq) String() string { return proto.CompactTextString(m) }
+func (*ReadTemplateReq) ProtoMessage()    {}
+func (*ReadTemplateReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{4}
+}
+
+func (m *ReadTemplateReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadTemplateReq.Unmarshal(m, b)
+}
+func (m *ReadTemplateReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadTemplateReq.Marshal(b, m, deterministic)
+}
+func (m *ReadTemplateReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadTemplateReq.Merge(m, src)
+}
+func (m *ReadTemplateReq) XXX_Size() int {
+	return xxx_messageInfo_ReadTemplateReq.Size(m)
+}
+func (m *ReadTemplateReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadTemplateReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadTemplateReq proto.InternalMessageInfo
+
+func (m *ReadTemplateReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadTemplateRes struct {
+	Template             *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ReadTemplateRes) Reset()         { *m = ReadTemplateRes{} }
+func (m *ReadTemplateRes) String() string { return proto.CompactTextString(m) }
+func (*ReadTemplateRes) ProtoMessage()    {}
+func (*ReadTemplateRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{5}
+}
+
+func (m *ReadTemplateRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadTemplateRes.Unmarshal(m, b)
+}
+func (m *ReadTemplateRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadTemplateRes.Marshal(b, m, deterministic)
+}
+func (m *ReadTemplateRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadTemplateRes.Merge(m, src)
+}
+func (m *ReadTemplateRes) XXX_Size() int {
+	return xxx_messageInfo_ReadTemplateRes.Size(m)
+}
+func (m *ReadTemplateRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadTemplateRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadTemplateRes proto.InternalMessageInfo
+
+func (m *ReadTemplateRes) GetTemplate() *JobTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type UpdateTemplateReq struct {
+	// Replaces everything but the fields set by the server
+	Template             *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpdateTemplateReq) Reset()         { *m = UpdateTemplateReq{} }
+func (m *UpdateTemplateReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateTemplateReq) ProtoMessage()    {}
+func (*UpdateTemplateReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{6}
+}
+
+func (m *UpdateTemplateReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTemplateReq.Unmarshal(m, b)
+}
+func (m *UpdateTemplateReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTemplateReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateTemplateReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTemplateReq.Merge(m, src)
+}
+func (m *UpdateTemplateReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateTemplateReq.Size(m)
+}
+func (m *UpdateTemplateReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTemplateReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTemplateReq proto.InternalMessageInfo
+
+func (m *UpdateTemplateReq) GetTemplate() *JobTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type UpdateTemplateRes struct {
+	Template             *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpdateTemplateRes) Reset()         { *m = UpdateTemplateRes{} }
+func (m *UpdateTemplateRes) String() string { return proto.CompactTextString(m) }
+func (*UpdateTemplateRes) ProtoMessage()    {}
+func (*UpdateTemplateRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{7}
+}
+
+func (m *UpdateTemplateRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTemplateRes.Unmarshal(m, b)
+}
+func (m *UpdateTemplateRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTemplateRes.Marshal(b, m, deterministic)
+}
+func (m *UpdateTemplateRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTemplateRes.Merge(m, src)
+}
+func (m *UpdateTemplateRes) XXX_Size() int {
+	return xxx_messageInfo_UpdateTemplateRes.Size(m)
+}
+func (m *UpdateTemplateRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTemplateRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTemplateRes proto.InternalMessageInfo
+
+func (m *UpdateTemplateRes) GetTemplate() *JobTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type DeleteTemplateReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTemplateReq) Reset()         { *m = DeleteTemplateReq{} }
+func (m *DeleteTemplateReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteTemplateReq) ProtoMessage()    {}
+func (*DeleteTemplateReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{8}
+}
+
+func (m *DeleteTemplateReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTemplateReq.Unmarshal(m, b)
+}
+func (m *DeleteTemplateReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTemplateReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteTemplateReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTemplateReq.Merge(m, src)
+}
+func (m *DeleteTemplateReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteTemplateReq.Size(m)
+}
+func (m *DeleteTemplateReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTemplateReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTemplateReq proto.InternalMessageInfo
+
+func (m *DeleteTemplateReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteTemplateRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTemplateRes) Reset()         { *m = DeleteTemplateRes{} }
+func (m *DeleteTemplateRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteTemplateRes) ProtoMessage()    {}
+func (*DeleteTemplateRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{9}
+}
+
+func (m *DeleteTemplateRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTemplateRes.Unmarshal(m, b)
+}
+func (m *DeleteTemplateRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTemplateRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteTemplateRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTemplateRes.Merge(m, src)
+}
+func (m *DeleteTemplateRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteTemplateRes.Size(m)
+}
+func (m *DeleteTemplateRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTemplateRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTemplateRes proto.InternalMessageInfo
+
+type ListTemplatesReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTemplatesReq) Reset()         { *m = ListTemplatesReq{} }
+func (m *ListTemplatesReq) String() string { return proto.CompactTextString(m) }
+func (*ListTemplatesReq) ProtoMessage()    {}
+func (*ListTemplatesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{10}
+}
+
+func (m *ListTemplatesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTemplatesReq.Unmarshal(m, b)
+}
+func (m *ListTemplatesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTemplatesReq.Marshal(b, m, deterministic)
+}
+func (m *ListTemplatesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTemplatesReq.Merge(m, src)
+}
+func (m *ListTemplatesReq) XXX_Size() int {
+	return xxx_messageInfo_ListTemplatesReq.Size(m)
+}
+func (m *ListTemplatesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTemplatesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTemplatesReq proto.InternalMessageInfo
+
+type ListTemplatesRes struct {
+	Template             *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListTemplatesRes) Reset()         { *m = ListTemplatesRes{} }
+func (m *ListTemplatesRes) String() string { return proto.CompactTextString(m) }
+func (*ListTemplatesRes) ProtoMessage()    {}
+func (*ListTemplatesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{11}
+}
+
+func (m *ListTemplatesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTemplatesRes.Unmarshal(m, b)
+}
+func (m *ListTemplatesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTemplatesRes.Marshal(b, m, deterministic)
+}
+func (m *ListTemplatesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTemplatesRes.Merge(m, src)
+}
+func (m *ListTemplatesRes) XXX_Size() int {
+	return xxx_messageInfo_ListTemplatesRes.Size(m)
+}
+func (m *ListTemplatesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTemplatesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTemplatesRes proto.InternalMessageInfo
+
+func (m *ListTemplatesRes) GetTemplate() *JobTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type CreateJobFromTemplateReq struct {
+	TemplateId string `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	// Values of the parameters of the template, the ones left out get their default
+	Parameters map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Owner of the job, defaults to the caller like the owner of CreateJob
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Like the idempotency key of CreateJob
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateJobFromTemplateReq) Reset()         { *m = CreateJobFromTemplateReq{} }
+func (m *CreateJobFromTemplateReq) String() string { return proto.CompactTextString(m) }
+func (*CreateJobFromTemplateReq) ProtoMessage()    {}
+func (*CreateJobFromTemplateReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{12}
+}
+
+func (m *CreateJobFromTemplateReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJobFromTemplateReq.Unmarshal(m, b)
+}
+func (m *CreateJobFromTemplateReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJobFromTemplateReq.Marshal(b, m, deterministic)
+}
+func (m *CreateJobFromTemplateReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJobFromTemplateReq.Merge(m, src)
+}
+func (m *CreateJobFromTemplateReq) XXX_Size() int {
+	return xxx_messageInfo_CreateJobFromTemplateReq.Size(m)
+}
+func (m *CreateJobFromTemplateReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJobFromTemplateReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJobFromTemplateReq proto.InternalMessageInfo
+
+func (m *CreateJobFromTemplateReq) GetTemplateId() string {
+	if m != nil {
+		return m.TemplateId
+	}
+	return ""
+}
+
+func (m *CreateJobFromTemplateReq) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *CreateJobFromTemplateReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *CreateJobFromTemplateReq) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateJobFromTemplateRes struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateJobFromTemplateRes) Reset()         { *m = CreateJobFromTemplateRes{} }
+func (m *CreateJobFromTemplateRes) String() string { return proto.CompactTextString(m) }
+func (*CreateJobFromTemplateRes) ProtoMessage()    {}
+func (*CreateJobFromTemplateRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b1b68e1b5f001c74, []int{13}
+}
+
+func (m *CreateJobFromTemplateRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJobFromTemplateRes.Unmarshal(m, b)
+}
+func (m *CreateJobFromTemplateRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJobFromTemplateRes.Marshal(b, m, deterministic)
+}
+func (m *CreateJobFromTemplateRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJobFromTemplateRes.Merge(m, src)
+}
+func (m *CreateJobFromTemplateRes) XXX_Size() int {
+	return xxx_messageInfo_CreateJobFromTemplateRes.Size(m)
+}
+func (m *CreateJobFromTemplateRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJobFromTemplateRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJobFromTemplateRes proto.InternalMessageInfo
+
+func (m *CreateJobFromTemplateRes) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TemplateParameter)(nil), "model.TemplateParameter")
+	proto.RegisterType((*JobTemplate)(nil), "model.JobTemplate")
+	proto.RegisterType((*CreateTemplateReq)(nil), "model.CreateTemplateReq")
+	proto.RegisterType((*CreateTemplateRes)(nil), "model.CreateTemplateRes")
+	proto.RegisterType((*ReadTemplateReq)(nil), "model.ReadTemplateReq")
+	proto.RegisterType((*ReadTemplateRes)(nil), "model.ReadTemplateRes")
+	proto.RegisterType((*UpdateTemplateReq)(nil), "model.UpdateTemplateReq")
+	proto.RegisterType((*UpdateTemplateRes)(nil), "model.UpdateTemplateRes")
+	proto.RegisterType((*DeleteTemplateReq)(nil), "model.DeleteTemplateReq")
+	proto.RegisterType((*DeleteTemplateRes)(nil), "model.DeleteTemplateRes")
+	proto.RegisterType((*ListTemplatesReq)(nil), "model.ListTemplatesReq")
+	proto.RegisterType((*ListTemplatesRes)(nil), "model.ListTemplatesRes")
+	proto.RegisterType((*CreateJobFromTemplateReq)(nil), "model.CreateJobFromTemplateReq")
+	proto.RegisterMapType((map[string]string)(nil), "model.CreateJobFromTemplateReq.ParametersEntry")
+	proto.RegisterType((*CreateJobFromTemplateRes)(nil), "model.CreateJobFromTemplateRes")
+}
+
+func init() { proto.RegisterFile("template.proto", fileDescriptor_b1b68e1b5f001c74) }
+
+var fileDescriptor_b1b68e1b5f001c74 = []byte{
+	// 737 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4d, 0x6f, 0xd3, 0x4a,
+	0x14, 0x95, 0x9d, 0xa6, 0x4d, 0x6e, 0xda, 0xa4, 0x9d, 0xd7, 0xbe, 0xe7, 0x67, 0x2a, 0x25, 0xb8,
+	0x42, 0x8d, 0x8a, 0xb0, 0x21, 0x6c, 0x4a, 0x25, 0x16, 0xa5, 0x80, 0x44, 0x41, 0x02, 0x99, 0xc2,
+	0xa2, 0x9b, 0x60, 0xc7, 0xb7, 0x95, 0x4b, 0x9c, 0x71, 0x3d, 0x93, 0xa0, 0x08, 0x75, 0xc3, 0x82,
+	0x45, 0x57, 0x48, 0xfc, 0x32, 0xc4, 0x5f, 0xe0, 0x87, 0x20, 0x8f, 0x3f, 0xb0, 0x9d, 0x98, 0xaa,
+	0xb0, 0x9b, 0xb9, 0x73, 0xe6, 0x9e, 0x73, 0xef, 0x9d, 0x63, 0x43, 0x93, 0xa3, 0xe7, 0x0f, 0x2d,
+	0x8e, 0xba, 0x1f, 0x50, 0x4e, 0x49, 0xd5, 0xa3, 0x0e, 0x0e, 0xd5, 0xcd, 0x53, 0x4a, 0x4f, 0x87,
+	0x68, 0x58, 0xbe, 0x6b, 0x58, 0xa3, 0x11, 0xe5, 0x16, 0x77, 0xe9, 0x88, 0x45, 0x20, 0xb5, 0x1d,
+	0x9f, 0x8a, 0x9d, 0x3d, 0x3e, 0x31, 0xb8, 0xeb, 0x21, 0xe3, 0x96, 0xe7, 0xc7, 0x80, 0xfa, 0x19,
+	0xb5, 0xa3, 0xa5, 0x76, 0x29, 0xc1, 0xda, 0x51, 0xcc, 0xf1, 0xca, 0x0a, 0x2c, 0x0f, 0x39, 0x06,
+	0x84, 0xc0, 0xc2, 0xc8, 0xf2, 0x50, 0x91, 0x3a, 0x52, 0xb7, 0x6e, 0x8a, 0x35, 0xe9, 0x40, 0xc3,
+	0x41, 0x36, 0x08, 0x5c, 0x3f, 0xe4, 0x52, 0x64, 0x71, 0x94, 0x0d, 0x91, 0x2d, 0x58, 0x71, 0xf0,
+	0xc4, 0x1a, 0x0f, 0x79, 0x7f, 0x62, 0x0d, 0xc7, 0xa8, 0x54, 0x04, 0x66, 0x39, 0x0e, 0xbe, 0x0d,
+	0x63, 0x44, 0x85, 0x5a, 0x80, 0xe7, 0x63, 0x37, 0x40, 0x47, 0x59, 0xe8, 0x48, 0xdd, 0x9a, 0x99,
+	0xee, 0xb5, 0x6f, 0x32, 0x34, 0x0e, 0xa9, 0x9d, 0xe8, 0x21, 0x4d, 0x90, 0x5d, 0x27, 0x16, 0x21,
+	0xbb, 0x4e, 0x2a, 0x4b, 0x2e, 0x97, 0x55, 0x99, 0x95, 0xb5, 0x0e, 0x55, 0xfa, 0x61, 0x84, 0x81,
+	0xa0, 0xab, 0x9b, 0xd1, 0x86, 0xec, 0x02, 0xf8, 0x49, 0xbd, 0x4c, 0xa9, 0x76, 0x2a, 0xdd, 0x46,
+	0x4f, 0xd1, 0x45, 0x7b, 0xf5, 0x99, 0x86, 0x98, 0x19, 0x2c, 0xd9, 0x84, 0xca, 0x19, 0xb5, 0x95,
+	0xc5, 0x8e, 0xd4, 0x6d, 0xf4, 0x20, 0xbe, 0x72, 0x48, 0x6d, 0x33, 0x0c, 0x13, 0x05, 0x96, 0x26,
+	0x18, 0xb0, 0x50, 0xcb, 0x52, 0x47, 0xea, 0x56, 0xcc, 0x64, 0x4b, 0x1e, 0x00, 0x0c, 0x02, 0xb4,
+	0x38, 0x3a, 0x7d, 0x8b, 0x2b, 0x35, 0x71, 0x5d, 0xd5, 0xa3, 0x59, 0xe9, 0xc9, 0xac, 0xf4, 0xa3,
+	0x64, 0x56, 0x66, 0x3d, 0x46, 0xef, 0xf3, 0xf0, 0xea, 0xd8, 0x77, 0x92, 0xab, 0xf5, 0xab, 0xaf,
+	0xc6, 0xe8, 0x7d, 0xae, 0x1d, 0xc0, 0xda, 0x81, 0xc8, 0x93, 0x14, 0x65, 0xe2, 0x39, 0xd1, 0xa1,
+	0x96, 0x3c, 0x2c, 0xd1, 0xde, 0x46, 0x8f, 0xfc, 0xaa, 0x23, 0x05, 0xa6, 0x98, 0x79, 0x49, 0xd8,
+	0xb5, 0x93, 0xdc, 0x84, 0x96, 0x89, 0x96, 0x93, 0xd5, 0x51, 0x18, 0xb0, 0xb6, 0x5f, 0x84, 0xb0,
+	0x3f, 0x91, 0xfa, 0x46, 0x14, 0xff, 0x97, 0xf5, 0x16, 0x93, 0x5c, 0x5f, 0xc9, 0x16, 0xac, 0x3d,
+	0xc6, 0x21, 0x72, 0xfc, 0x5d, 0xc5, 0xff, 0xcc, 0x82, 0x98, 0x46, 0x60, 0xf5, 0x85, 0xcb, 0x78,
+	0x12, 0x62, 0x26, 0x9e, 0x6b, 0x8f, 0x66, 0x62, 0xd7, 0x57, 0x74, 0x29, 0x83, 0x12, 0xcd, 0xf1,
+	0x90, 0xda, 0x4f, 0x03, 0xea, 0x65, 0x95, 0xb5, 0xa1, 0x91, 0x00, 0xfb, 0xa9, 0x44, 0x48, 0x42,
+	0xcf, 0x1c, 0xf2, 0x32, 0xe7, 0x18, 0x59, 0x38, 0xc6, 0x88, 0xf9, 0xca, 0xb2, 0xea, 0xa9, 0x85,
+	0xd8, 0x93, 0x11, 0x0f, 0xa6, 0x39, 0x23, 0xa5, 0xc6, 0xac, 0x64, 0x8d, 0xb9, 0x0d, 0x2d, 0xd7,
+	0x41, 0xcf, 0xa7, 0x1c, 0x47, 0x83, 0x69, 0xff, 0x3d, 0x4e, 0x63, 0xe3, 0x36, 0x33, 0xe1, 0xe7,
+	0x38, 0x55, 0x1f, 0x42, 0xab, 0x90, 0x9d, 0xac, 0x42, 0x25, 0xc4, 0x47, 0xda, 0xc3, 0x65, 0xc8,
+	0x11, 0x7d, 0x8b, 0xa2, 0x6f, 0x46, 0xb4, 0xd9, 0x93, 0x77, 0x25, 0x6d, 0xb7, 0xb4, 0x17, 0xa9,
+	0xc5, 0xa5, 0xb9, 0x16, 0xef, 0x7d, 0xa9, 0x42, 0x2b, 0x41, 0xbf, 0xc6, 0x60, 0xe2, 0x0e, 0x90,
+	0x20, 0x34, 0xf3, 0x0e, 0x21, 0x4a, 0xae, 0x35, 0x99, 0x9e, 0xa8, 0x65, 0x27, 0x4c, 0x6b, 0x7f,
+	0xfa, 0xfe, 0xe3, 0xab, 0xfc, 0xbf, 0xb6, 0x62, 0x4c, 0xee, 0x19, 0x49, 0xeb, 0xd9, 0x5e, 0x3a,
+	0x41, 0x72, 0x0c, 0xcb, 0x59, 0x83, 0x90, 0x7f, 0xe3, 0x54, 0x05, 0x63, 0xa9, 0xf3, 0xe3, 0x4c,
+	0x53, 0x05, 0xc1, 0x3a, 0x21, 0x39, 0x02, 0xe3, 0xa3, 0xeb, 0x5c, 0x10, 0x06, 0xcd, 0xfc, 0xa3,
+	0x4f, 0x4b, 0x98, 0x31, 0x94, 0x5a, 0x76, 0xc2, 0xb4, 0x3b, 0x82, 0x61, 0x5b, 0xbd, 0x51, 0x60,
+	0x48, 0x96, 0xba, 0xeb, 0x5c, 0x64, 0x0a, 0x7a, 0x07, 0xcd, 0xfc, 0xfb, 0x4f, 0x49, 0x67, 0xbc,
+	0xa3, 0x96, 0x9d, 0xa4, 0x65, 0xed, 0xcc, 0x2b, 0xeb, 0x18, 0x56, 0x72, 0xc6, 0x21, 0xff, 0xc5,
+	0x69, 0x8a, 0x16, 0x53, 0x4b, 0x0e, 0x98, 0xb6, 0x21, 0xd2, 0xb7, 0x48, 0x7e, 0x2c, 0x77, 0x25,
+	0xf2, 0x59, 0x82, 0x8d, 0xb9, 0x8f, 0x88, 0xb4, 0xaf, 0x30, 0x86, 0x7a, 0x05, 0x80, 0x69, 0xb7,
+	0x05, 0xe9, 0x2d, 0xad, 0x53, 0xd2, 0xc8, 0xbe, 0xeb, 0x5c, 0x18, 0x67, 0xd4, 0x66, 0x7b, 0xd2,
+	0x8e, 0xbd, 0x28, 0x7e, 0x02, 0xf7, 0x7f, 0x0e, 0x00, 0xd9, 0x5f, 0x22, 0x0e, 0x30, 0x08, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TemplateServiceClient is the client API for TemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TemplateServiceClient interface {
+	CreateTemplate(ctx context.Context, in *CreateTemplateReq, opts ...grpc.CallOption) (*CreateTemplateRes, error)
+	ReadTemplate(ctx context.Context, in *ReadTemplateReq, opts ...grpc.CallOption) (*ReadTemplateRes, error)
+	// Replaces a template and increments its version, the jobs created from it are left as they are
+	UpdateTemplate(ctx context.Context, in *UpdateTemplateReq, opts ...grpc.CallOption) (*UpdateTemplateRes, error)
+	// Deletes a template, the jobs created from it are kept
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateReq, opts ...grpc.CallOption) (*DeleteTemplateRes, error)
+	// Streams the templates of the caller and their teams oldest first
+	ListTemplates(ctx context.Context, in *ListTemplatesReq, opts ...grpc.CallOption) (TemplateService_ListTemplatesClient, error)
+	// Creates a job from a template, replacing the placeholders of its parameters with the given values
+	CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateReq, opts ...grpc.CallOption) (*CreateJobFromTemplateRes, error)
+}
+
+type templateServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTemplateServiceClient(cc *grpc.ClientConn) TemplateServiceClient {
+	return &templateServiceClient{cc}
+}
+
+func (c *templateServiceClient) CreateTemplate(ctx context.Context, in *CreateTemplateReq, opts ...grpc.CallOption) (*CreateTemplateRes, error) {
+	out := new(CreateTemplateRes)
+	err := c.cc.Invoke(ctx, "/model.TemplateService/CreateTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) ReadTemplate(ctx context.Context, in *ReadTemplateReq, opts ...grpc.CallOption) (*ReadTemplateRes, error) {
+	out := new(ReadTemplateRes)
+	err := c.cc.Invoke(ctx, "/model.TemplateService/ReadTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) UpdateTemplate(ctx context.Context, in *UpdateTemplateReq, opts ...grpc.CallOption) (*UpdateTemplateRes, error) {
+	out := new(UpdateTemplateRes)
+	err := c.cc.Invoke(ctx, "/model.TemplateService/UpdateTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateReq, opts ...grpc.CallOption) (*DeleteTemplateRes, error) {
+	out := new(DeleteTemplateRes)
+	err := c.cc.Invoke(ctx, "/model.TemplateService/DeleteTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesReq, opts ...grpc.CallOption) (TemplateService_ListTemplatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TemplateService_serviceDesc.Streams[0], "/model.TemplateService/ListTemplates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &templateServiceListTemplatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TemplateService_ListTemplatesClient interface {
+	Recv() (*ListTemplatesRes, error)
+	grpc.ClientStream
+}
+
+type templateServiceListTemplatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *templateServiceListTemplatesClient) Recv() (*ListTemplatesRes, error) {
+	m := new(ListTemplatesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *templateServiceClient) CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateReq, opts ...grpc.CallOption) (*CreateJobFromTemplateRes, error) {
+	out := new(CreateJobFromTemplateRes)
+	err := c.cc.Invoke(ctx, "/model.TemplateService/CreateJobFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TemplateServiceServer is the server API for TemplateService service.
+type TemplateServiceServer interface {
+	CreateTemplate(context.Context, *CreateTemplateReq) (*CreateTemplateRes, error)
+	ReadTemplate(context.Context, *ReadTemplateReq) (*ReadTemplateRes, error)
+	// Replaces a template and increments its version, the jobs created from it are left as they are
+	UpdateTemplate(context.Context, *UpdateTemplateReq) (*UpdateTemplateRes, error)
+	// Deletes a template, the jobs created from it are kept
+	DeleteTemplate(context.Context, *DeleteTemplateReq) (*DeleteTemplateRes, error)
+	// Streams the templates of the caller and their teams oldest first
+	ListTemplates(*ListTemplatesReq, TemplateService_ListTemplatesServer) error
+	// Creates a job from a template, replacing the placeholders of its parameters with the given values
+	CreateJobFromTemplate(context.Context, *CreateJobFromTemplateReq) (*CreateJobFromTemplateRes, error)
+}
+
+func RegisterTemplateServiceServer(s *grpc.Server, srv TemplateServiceServer) {
+	s.RegisterService(&_TemplateService_serviceDesc, srv)
+}
+
+func _TemplateService_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TemplateService/CreateTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, req.(*CreateTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_ReadTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).ReadTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TemplateService/ReadTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).ReadTemplate(ctx, req.(*ReadTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TemplateService/UpdateTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, req.(*UpdateTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TemplateService/DeleteTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, req.(*DeleteTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_ListTemplates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTemplatesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TemplateServiceServer).ListTemplates(m, &templateServiceListTemplatesServer{stream})
+}
+
+type TemplateService_ListTemplatesServer interface {
+	Send(*ListTemplatesRes) error
+	grpc.ServerStream
+}
+
+type templateServiceListTemplatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *templateServiceListTemplatesServer) Send(m *ListTemplatesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TemplateService_CreateJobFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobFromTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).CreateJobFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.TemplateService/CreateJobFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).CreateJobFromTemplate(ctx, req.(*CreateJobFromTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TemplateService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.TemplateService",
+	HandlerType: (*TemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _TemplateService_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "ReadTemplate",
+			Handler:    _TemplateService_ReadTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _TemplateService_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _TemplateService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "CreateJobFromTemplate",
+			Handler:    _TemplateService_CreateJobFromTemplate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListTemplates",
+			Handler:       _TemplateService_ListTemplates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "template.proto",
+}
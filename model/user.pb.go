@@ -0,0 +1,1507 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: user.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// User is a caller of the API, jobs and webhooks are owned by the id of a user
+type User struct {
+	// The subject of the tokens of users registering themselves. Admins may set it when creating a user, it's generated
+	// for service users that only authenticate with API keys.
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email       string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	DisplayName string `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Roles granted to callers authenticating with the API keys of the user, only admins can set them. Callers with a
+	// token get their roles from the token, and from their user as well while RBAC is enabled.
+	Roles []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	// Set by the server
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *User) Reset()         { *m = User{} }
+func (m *User) String() string { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()    {}
+func (*User) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{0}
+}
+
+func (m *User) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_User.Unmarshal(m, b)
+}
+func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_User.Marshal(b, m, deterministic)
+}
+func (m *User) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User.Merge(m, src)
+}
+func (m *User) XXX_Size() int {
+	return xxx_messageInfo_User.Size(m)
+}
+func (m *User) XXX_DiscardUnknown() {
+	xxx_messageInfo_User.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User proto.InternalMessageInfo
+
+func (m *User) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *User) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *User) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *User) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *User) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *User) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+// ApiKey lets a machine client authenticate as a user without a token of the identity provider
+type ApiKey struct {
+	// Set by the server
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Tells the keys of a user apart
+	Name      string               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// When the secret was last replaced, not set for keys that still have their first one
+	RotatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=rotated_at,json=rotatedAt,proto3" json:"rotated_at,omitempty"`
+	// The bearer token of the key, only returned by CreateApiKey and RotateApiKey
+	Token                string   `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApiKey) Reset()         { *m = ApiKey{} }
+func (m *ApiKey) String() string { return proto.CompactTextString(m) }
+func (*ApiKey) ProtoMessage()    {}
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{1}
+}
+
+func (m *ApiKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApiKey.Unmarshal(m, b)
+}
+func (m *ApiKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApiKey.Marshal(b, m, deterministic)
+}
+func (m *ApiKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApiKey.Merge(m, src)
+}
+func (m *ApiKey) XXX_Size() int {
+	return xxx_messageInfo_ApiKey.Size(m)
+}
+func (m *ApiKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApiKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApiKey proto.InternalMessageInfo
+
+func (m *ApiKey) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ApiKey) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ApiKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApiKey) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *ApiKey) GetRotatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RotatedAt
+	}
+	return nil
+}
+
+func (m *ApiKey) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type RegisterUserReq struct {
+	// Email and display name of the caller, the id is the subject of the caller's token
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterUserReq) Reset()         { *m = RegisterUserReq{} }
+func (m *RegisterUserReq) String() string { return proto.CompactTextString(m) }
+func (*RegisterUserReq) ProtoMessage()    {}
+func (*RegisterUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{2}
+}
+
+func (m *RegisterUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterUserReq.Unmarshal(m, b)
+}
+func (m *RegisterUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterUserReq.Marshal(b, m, deterministic)
+}
+func (m *RegisterUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterUserReq.Merge(m, src)
+}
+func (m *RegisterUserReq) XXX_Size() int {
+	return xxx_messageInfo_RegisterUserReq.Size(m)
+}
+func (m *RegisterUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterUserReq proto.InternalMessageInfo
+
+func (m *RegisterUserReq) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type RegisterUserRes struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterUserRes) Reset()         { *m = RegisterUserRes{} }
+func (m *RegisterUserRes) String() string { return proto.CompactTextString(m) }
+func (*RegisterUserRes) ProtoMessage()    {}
+func (*RegisterUserRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{3}
+}
+
+func (m *RegisterUserRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterUserRes.Unmarshal(m, b)
+}
+func (m *RegisterUserRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterUserRes.Marshal(b, m, deterministic)
+}
+func (m *RegisterUserRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterUserRes.Merge(m, src)
+}
+func (m *RegisterUserRes) XXX_Size() int {
+	return xxx_messageInfo_RegisterUserRes.Size(m)
+}
+func (m *RegisterUserRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterUserRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterUserRes proto.InternalMessageInfo
+
+func (m *RegisterUserRes) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type CreateUserReq struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateUserReq) Reset()         { *m = CreateUserReq{} }
+func (m *CreateUserReq) String() string { return proto.CompactTextString(m) }
+func (*CreateUserReq) ProtoMessage()    {}
+func (*CreateUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{4}
+}
+
+func (m *CreateUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUserReq.Unmarshal(m, b)
+}
+func (m *CreateUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUserReq.Marshal(b, m, deterministic)
+}
+func (m *CreateUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUserReq.Merge(m, src)
+}
+func (m *CreateUserReq) XXX_Size() int {
+	return xxx_messageInfo_CreateUserReq.Size(m)
+}
+func (m *CreateUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUserReq proto.InternalMessageInfo
+
+func (m *CreateUserReq) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type CreateUserRes struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateUserRes) Reset()         { *m = CreateUserRes{} }
+func (m *CreateUserRes) String() string { return proto.CompactTextString(m) }
+func (*CreateUserRes) ProtoMessage()    {}
+func (*CreateUserRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{5}
+}
+
+func (m *CreateUserRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUserRes.Unmarshal(m, b)
+}
+func (m *CreateUserRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUserRes.Marshal(b, m, deterministic)
+}
+func (m *CreateUserRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUserRes.Merge(m, src)
+}
+func (m *CreateUserRes) XXX_Size() int {
+	return xxx_messageInfo_CreateUserRes.Size(m)
+}
+func (m *CreateUserRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUserRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUserRes proto.InternalMessageInfo
+
+func (m *CreateUserRes) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type ReadUserReq struct {
+	// Defaults to the caller
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadUserReq) Reset()         { *m = ReadUserReq{} }
+func (m *ReadUserReq) String() string { return proto.CompactTextString(m) }
+func (*ReadUserReq) ProtoMessage()    {}
+func (*ReadUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{6}
+}
+
+func (m *ReadUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadUserReq.Unmarshal(m, b)
+}
+func (m *ReadUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadUserReq.Marshal(b, m, deterministic)
+}
+func (m *ReadUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadUserReq.Merge(m, src)
+}
+func (m *ReadUserReq) XXX_Size() int {
+	return xxx_messageInfo_ReadUserReq.Size(m)
+}
+func (m *ReadUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadUserReq proto.InternalMessageInfo
+
+func (m *ReadUserReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ReadUserRes struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadUserRes) Reset()         { *m = ReadUserRes{} }
+func (m *ReadUserRes) String() string { return proto.CompactTextString(m) }
+func (*ReadUserRes) ProtoMessage()    {}
+func (*ReadUserRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{7}
+}
+
+func (m *ReadUserRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadUserRes.Unmarshal(m, b)
+}
+func (m *ReadUserRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadUserRes.Marshal(b, m, deterministic)
+}
+func (m *ReadUserRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadUserRes.Merge(m, src)
+}
+func (m *ReadUserRes) XXX_Size() int {
+	return xxx_messageInfo_ReadUserRes.Size(m)
+}
+func (m *ReadUserRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadUserRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadUserRes proto.InternalMessageInfo
+
+func (m *ReadUserRes) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type UpdateUserReq struct {
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// Fields of user to update (email, display_name, roles), all of them when empty. Only admins can update the roles,
+	// an empty mask leaves them as they are for everyone else.
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateUserReq) Reset()         { *m = UpdateUserReq{} }
+func (m *UpdateUserReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserReq) ProtoMessage()    {}
+func (*UpdateUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{8}
+}
+
+func (m *UpdateUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateUserReq.Unmarshal(m, b)
+}
+func (m *UpdateUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateUserReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserReq.Merge(m, src)
+}
+func (m *UpdateUserReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateUserReq.Size(m)
+}
+func (m *UpdateUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserReq proto.InternalMessageInfo
+
+func (m *UpdateUserReq) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *UpdateUserReq) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateUserRes struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateUserRes) Reset()         { *m = UpdateUserRes{} }
+func (m *UpdateUserRes) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserRes) ProtoMessage()    {}
+func (*UpdateUserRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{9}
+}
+
+func (m *UpdateUserRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateUserRes.Unmarshal(m, b)
+}
+func (m *UpdateUserRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateUserRes.Marshal(b, m, deterministic)
+}
+func (m *UpdateUserRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserRes.Merge(m, src)
+}
+func (m *UpdateUserRes) XXX_Size() int {
+	return xxx_messageInfo_UpdateUserRes.Size(m)
+}
+func (m *UpdateUserRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserRes proto.InternalMessageInfo
+
+func (m *UpdateUserRes) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type DeleteUserReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteUserReq) Reset()         { *m = DeleteUserReq{} }
+func (m *DeleteUserReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteUserReq) ProtoMessage()    {}
+func (*DeleteUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{10}
+}
+
+func (m *DeleteUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteUserReq.Unmarshal(m, b)
+}
+func (m *DeleteUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteUserReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteUserReq.Merge(m, src)
+}
+func (m *DeleteUserReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteUserReq.Size(m)
+}
+func (m *DeleteUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteUserReq proto.InternalMessageInfo
+
+func (m *DeleteUserReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteUserRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteUserRes) Reset()         { *m = DeleteUserRes{} }
+func (m *DeleteUserRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteUserRes) ProtoMessage()    {}
+func (*DeleteUserRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{11}
+}
+
+func (m *DeleteUserRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteUserRes.Unmarshal(m, b)
+}
+func (m *DeleteUserRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteUserRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteUserRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteUserRes.Merge(m, src)
+}
+func (m *DeleteUserRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteUserRes.Size(m)
+}
+func (m *DeleteUserRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteUserRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteUserRes proto.InternalMessageInfo
+
+type ListUsersReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUsersReq) Reset()         { *m = ListUsersReq{} }
+func (m *ListUsersReq) String() string { return proto.CompactTextString(m) }
+func (*ListUsersReq) ProtoMessage()    {}
+func (*ListUsersReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{12}
+}
+
+func (m *ListUsersReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersReq.Unmarshal(m, b)
+}
+func (m *ListUsersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersReq.Marshal(b, m, deterministic)
+}
+func (m *ListUsersReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersReq.Merge(m, src)
+}
+func (m *ListUsersReq) XXX_Size() int {
+	return xxx_messageInfo_ListUsersReq.Size(m)
+}
+func (m *ListUsersReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersReq proto.InternalMessageInfo
+
+type ListUsersRes struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUsersRes) Reset()         { *m = ListUsersRes{} }
+func (m *ListUsersRes) String() string { return proto.CompactTextString(m) }
+func (*ListUsersRes) ProtoMessage()    {}
+func (*ListUsersRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{13}
+}
+
+func (m *ListUsersRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersRes.Unmarshal(m, b)
+}
+func (m *ListUsersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersRes.Marshal(b, m, deterministic)
+}
+func (m *ListUsersRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersRes.Merge(m, src)
+}
+func (m *ListUsersRes) XXX_Size() int {
+	return xxx_messageInfo_ListUsersRes.Size(m)
+}
+func (m *ListUsersRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersRes proto.InternalMessageInfo
+
+func (m *ListUsersRes) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type CreateApiKeyReq struct {
+	// Defaults to the caller
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateApiKeyReq) Reset()         { *m = CreateApiKeyReq{} }
+func (m *CreateApiKeyReq) String() string { return proto.CompactTextString(m) }
+func (*CreateApiKeyReq) ProtoMessage()    {}
+func (*CreateApiKeyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{14}
+}
+
+func (m *CreateApiKeyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApiKeyReq.Unmarshal(m, b)
+}
+func (m *CreateApiKeyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApiKeyReq.Marshal(b, m, deterministic)
+}
+func (m *CreateApiKeyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApiKeyReq.Merge(m, src)
+}
+func (m *CreateApiKeyReq) XXX_Size() int {
+	return xxx_messageInfo_CreateApiKeyReq.Size(m)
+}
+func (m *CreateApiKeyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApiKeyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApiKeyReq proto.InternalMessageInfo
+
+func (m *CreateApiKeyReq) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *CreateApiKeyReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CreateApiKeyRes struct {
+	ApiKey               *ApiKey  `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateApiKeyRes) Reset()         { *m = CreateApiKeyRes{} }
+func (m *CreateApiKeyRes) String() string { return proto.CompactTextString(m) }
+func (*CreateApiKeyRes) ProtoMessage()    {}
+func (*CreateApiKeyRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{15}
+}
+
+func (m *CreateApiKeyRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApiKeyRes.Unmarshal(m, b)
+}
+func (m *CreateApiKeyRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApiKeyRes.Marshal(b, m, deterministic)
+}
+func (m *CreateApiKeyRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApiKeyRes.Merge(m, src)
+}
+func (m *CreateApiKeyRes) XXX_Size() int {
+	return xxx_messageInfo_CreateApiKeyRes.Size(m)
+}
+func (m *CreateApiKeyRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApiKeyRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApiKeyRes proto.InternalMessageInfo
+
+func (m *CreateApiKeyRes) GetApiKey() *ApiKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+type ListApiKeysReq struct {
+	// Defaults to the caller
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListApiKeysReq) Reset()         { *m = ListApiKeysReq{} }
+func (m *ListApiKeysReq) String() string { return proto.CompactTextString(m) }
+func (*ListApiKeysReq) ProtoMessage()    {}
+func (*ListApiKeysReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{16}
+}
+
+func (m *ListApiKeysReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApiKeysReq.Unmarshal(m, b)
+}
+func (m *ListApiKeysReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApiKeysReq.Marshal(b, m, deterministic)
+}
+func (m *ListApiKeysReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApiKeysReq.Merge(m, src)
+}
+func (m *ListApiKeysReq) XXX_Size() int {
+	return xxx_messageInfo_ListApiKeysReq.Size(m)
+}
+func (m *ListApiKeysReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApiKeysReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApiKeysReq proto.InternalMessageInfo
+
+func (m *ListApiKeysReq) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type ListApiKeysRes struct {
+	ApiKey               *ApiKey  `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListApiKeysRes) Reset()         { *m = ListApiKeysRes{} }
+func (m *ListApiKeysRes) String() string { return proto.CompactTextString(m) }
+func (*ListApiKeysRes) ProtoMessage()    {}
+func (*ListApiKeysRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{17}
+}
+
+func (m *ListApiKeysRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApiKeysRes.Unmarshal(m, b)
+}
+func (m *ListApiKeysRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApiKeysRes.Marshal(b, m, deterministic)
+}
+func (m *ListApiKeysRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApiKeysRes.Merge(m, src)
+}
+func (m *ListApiKeysRes) XXX_Size() int {
+	return xxx_messageInfo_ListApiKeysRes.Size(m)
+}
+func (m *ListApiKeysRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApiKeysRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApiKeysRes proto.InternalMessageInfo
+
+func (m *ListApiKeysRes) GetApiKey() *ApiKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+type RotateApiKeyReq struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateApiKeyReq) Reset()         { *m = RotateApiKeyReq{} }
+func (m *RotateApiKeyReq) String() string { return proto.CompactTextString(m) }
+func (*RotateApiKeyReq) ProtoMessage()    {}
+func (*RotateApiKeyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{18}
+}
+
+func (m *RotateApiKeyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateApiKeyReq.Unmarshal(m, b)
+}
+func (m *RotateApiKeyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateApiKeyReq.Marshal(b, m, deterministic)
+}
+func (m *RotateApiKeyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateApiKeyReq.Merge(m, src)
+}
+func (m *RotateApiKeyReq) XXX_Size() int {
+	return xxx_messageInfo_RotateApiKeyReq.Size(m)
+}
+func (m *RotateApiKeyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateApiKeyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateApiKeyReq proto.InternalMessageInfo
+
+func (m *RotateApiKeyReq) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *RotateApiKeyReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RotateApiKeyRes struct {
+	ApiKey               *ApiKey  `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateApiKeyRes) Reset()         { *m = RotateApiKeyRes{} }
+func (m *RotateApiKeyRes) String() string { return proto.CompactTextString(m) }
+func (*RotateApiKeyRes) ProtoMessage()    {}
+func (*RotateApiKeyRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{19}
+}
+
+func (m *RotateApiKeyRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateApiKeyRes.Unmarshal(m, b)
+}
+func (m *RotateApiKeyRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateApiKeyRes.Marshal(b, m, deterministic)
+}
+func (m *RotateApiKeyRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateApiKeyRes.Merge(m, src)
+}
+func (m *RotateApiKeyRes) XXX_Size() int {
+	return xxx_messageInfo_RotateApiKeyRes.Size(m)
+}
+func (m *RotateApiKeyRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateApiKeyRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateApiKeyRes proto.InternalMessageInfo
+
+func (m *RotateApiKeyRes) GetApiKey() *ApiKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+type DeleteApiKeyReq struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteApiKeyReq) Reset()         { *m = DeleteApiKeyReq{} }
+func (m *DeleteApiKeyReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteApiKeyReq) ProtoMessage()    {}
+func (*DeleteApiKeyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{20}
+}
+
+func (m *DeleteApiKeyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteApiKeyReq.Unmarshal(m, b)
+}
+func (m *DeleteApiKeyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteApiKeyReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteApiKeyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteApiKeyReq.Merge(m, src)
+}
+func (m *DeleteApiKeyReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteApiKeyReq.Size(m)
+}
+func (m *DeleteApiKeyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteApiKeyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteApiKeyReq proto.InternalMessageInfo
+
+func (m *DeleteApiKeyReq) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *DeleteApiKeyReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteApiKeyRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteApiKeyRes) Reset()         { *m = DeleteApiKeyRes{} }
+func (m *DeleteApiKeyRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteApiKeyRes) ProtoMessage()    {}
+func (*DeleteApiKeyRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{21}
+}
+
+func (m *DeleteApiKeyRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteApiKeyRes.Unmarshal(m, b)
+}
+func (m *DeleteApiKeyRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteApiKeyRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteApiKeyRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteApiKeyRes.Merge(m, src)
+}
+func (m *DeleteApiKeyRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteApiKeyRes.Size(m)
+}
+func (m *DeleteApiKeyRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteApiKeyRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteApiKeyRes proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*User)(nil), "model.User")
+	proto.RegisterType((*ApiKey)(nil), "model.ApiKey")
+	proto.RegisterType((*RegisterUserReq)(nil), "model.RegisterUserReq")
+	proto.RegisterType((*RegisterUserRes)(nil), "model.RegisterUserRes")
+	proto.RegisterType((*CreateUserReq)(nil), "model.CreateUserReq")
+	proto.RegisterType((*CreateUserRes)(nil), "model.CreateUserRes")
+	proto.RegisterType((*ReadUserReq)(nil), "model.ReadUserReq")
+	proto.RegisterType((*ReadUserRes)(nil), "model.ReadUserRes")
+	proto.RegisterType((*UpdateUserReq)(nil), "model.UpdateUserReq")
+	proto.RegisterType((*UpdateUserRes)(nil), "model.UpdateUserRes")
+	proto.RegisterType((*DeleteUserReq)(nil), "model.DeleteUserReq")
+	proto.RegisterType((*DeleteUserRes)(nil), "model.DeleteUserRes")
+	proto.RegisterType((*ListUsersReq)(nil), "model.ListUsersReq")
+	proto.RegisterType((*ListUsersRes)(nil), "model.ListUsersRes")
+	proto.RegisterType((*CreateApiKeyReq)(nil), "model.CreateApiKeyReq")
+	proto.RegisterType((*CreateApiKeyRes)(nil), "model.CreateApiKeyRes")
+	proto.RegisterType((*ListApiKeysReq)(nil), "model.ListApiKeysReq")
+	proto.RegisterType((*ListApiKeysRes)(nil), "model.ListApiKeysRes")
+	proto.RegisterType((*RotateApiKeyReq)(nil), "model.RotateApiKeyReq")
+	proto.RegisterType((*RotateApiKeyRes)(nil), "model.RotateApiKeyRes")
+	proto.RegisterType((*DeleteApiKeyReq)(nil), "model.DeleteApiKeyReq")
+	proto.RegisterType((*DeleteApiKeyRes)(nil), "model.DeleteApiKeyRes")
+}
+
+func init() { proto.RegisterFile("user.proto", fileDescriptor_116e343673f7ffaf) }
+
+var fileDescriptor_116e343673f7ffaf = []byte{
+	// 802 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x6e, 0xd3, 0x4a,
+	0x10, 0x96, 0xd3, 0x34, 0x3d, 0x19, 0xe7, 0xe7, 0x74, 0xdb, 0xd3, 0x46, 0x3e, 0x85, 0x26, 0x0b,
+	0x82, 0x36, 0x12, 0x4e, 0x08, 0x37, 0x34, 0x48, 0x48, 0x11, 0x08, 0x89, 0xf2, 0x23, 0x30, 0xf4,
+	0x8a, 0x8b, 0xe0, 0xd6, 0xdb, 0x68, 0x95, 0x38, 0x76, 0xbd, 0xdb, 0x4a, 0x11, 0xea, 0x0d, 0xaf,
+	0xc0, 0xab, 0xf0, 0x22, 0x88, 0x4b, 0x6e, 0x79, 0x10, 0xb4, 0xeb, 0x8d, 0xed, 0xb8, 0x6e, 0xeb,
+	0x72, 0x65, 0xcf, 0xec, 0xcc, 0x7c, 0xdf, 0xec, 0xcc, 0x27, 0x1b, 0xe0, 0x94, 0x91, 0xc0, 0xf4,
+	0x03, 0x8f, 0x7b, 0x68, 0xd9, 0xf5, 0x1c, 0x32, 0x31, 0xb6, 0x46, 0x9e, 0x37, 0x9a, 0x90, 0x8e,
+	0xed, 0xd3, 0x8e, 0x3d, 0x9d, 0x7a, 0xdc, 0xe6, 0xd4, 0x9b, 0xb2, 0x30, 0xc8, 0x68, 0xaa, 0x53,
+	0x69, 0x1d, 0x9e, 0x1e, 0x77, 0x8e, 0x29, 0x99, 0x38, 0x43, 0xd7, 0x66, 0x63, 0x15, 0xb1, 0x9d,
+	0x8e, 0xe0, 0xd4, 0x25, 0x8c, 0xdb, 0xae, 0x1f, 0x06, 0xe0, 0x5f, 0x1a, 0x14, 0x0f, 0x18, 0x09,
+	0x50, 0x0d, 0x0a, 0xd4, 0x69, 0x68, 0x4d, 0x6d, 0xa7, 0x6c, 0x15, 0xa8, 0x83, 0xd6, 0x61, 0x99,
+	0xb8, 0x36, 0x9d, 0x34, 0x0a, 0xd2, 0x15, 0x1a, 0xa8, 0x05, 0x15, 0x87, 0x32, 0x7f, 0x62, 0xcf,
+	0x86, 0x53, 0xdb, 0x25, 0x8d, 0x25, 0x79, 0xa8, 0x2b, 0xdf, 0x5b, 0xdb, 0x25, 0x22, 0x31, 0xf0,
+	0x26, 0x84, 0x35, 0x8a, 0xcd, 0x25, 0x91, 0x28, 0x0d, 0xb4, 0x07, 0x70, 0x14, 0x10, 0x9b, 0x13,
+	0x67, 0x68, 0xf3, 0xc6, 0x72, 0x53, 0xdb, 0xd1, 0x7b, 0x86, 0x19, 0xb2, 0x33, 0xe7, 0xec, 0xcc,
+	0x8f, 0x73, 0x76, 0x56, 0x59, 0x45, 0x0f, 0xb8, 0x48, 0x3d, 0xf5, 0x9d, 0x79, 0x6a, 0xe9, 0xfa,
+	0x54, 0x15, 0x3d, 0xe0, 0xf8, 0x87, 0x06, 0xa5, 0x81, 0x4f, 0x5f, 0x91, 0xd9, 0x85, 0xfe, 0x36,
+	0x61, 0x45, 0x5c, 0xf7, 0x90, 0x3a, 0xaa, 0xc3, 0x92, 0x30, 0x5f, 0x3a, 0x08, 0x41, 0x31, 0xd1,
+	0x9a, 0x7c, 0x4f, 0xb1, 0x2f, 0xde, 0x90, 0x7d, 0xe0, 0xf1, 0x79, 0x6a, 0x8e, 0xc6, 0x55, 0xf4,
+	0x80, 0x8b, 0x9b, 0xe4, 0xde, 0x98, 0x4c, 0x65, 0xcf, 0x65, 0x2b, 0x34, 0x70, 0x0f, 0xea, 0x16,
+	0x19, 0x51, 0xc6, 0x49, 0x20, 0x06, 0x67, 0x91, 0x13, 0xb4, 0x0d, 0x45, 0x41, 0x5e, 0x76, 0xa7,
+	0xf7, 0x74, 0x53, 0xee, 0x8e, 0x29, 0x4f, 0xe5, 0xc1, 0xc5, 0x1c, 0x76, 0x7d, 0x4e, 0x17, 0xaa,
+	0xcf, 0x64, 0x17, 0xb9, 0x51, 0x52, 0x19, 0x39, 0x30, 0x6e, 0x81, 0x6e, 0x11, 0xdb, 0x99, 0x23,
+	0xa4, 0x66, 0x84, 0xcd, 0xe4, 0x71, 0x8e, 0x72, 0x2e, 0x54, 0x0f, 0xe4, 0xec, 0xf3, 0x52, 0x46,
+	0x4f, 0x40, 0x0f, 0xb7, 0x45, 0x8a, 0xa6, 0x51, 0xb8, 0x64, 0x3c, 0x2f, 0x84, 0xae, 0xde, 0xd8,
+	0x6c, 0x6c, 0xa9, 0x55, 0x14, 0xef, 0xb8, 0xbb, 0x08, 0x97, 0x83, 0xe0, 0x36, 0x54, 0x9f, 0x93,
+	0x09, 0xe1, 0xe4, 0xb2, 0x8e, 0xeb, 0x8b, 0x01, 0x0c, 0xd7, 0xa0, 0xf2, 0x9a, 0x32, 0x2e, 0x4c,
+	0x66, 0x91, 0x13, 0xdc, 0x59, 0xb0, 0x73, 0x40, 0x3e, 0x85, 0x7a, 0x38, 0x94, 0x50, 0x07, 0x02,
+	0x34, 0xb1, 0xfa, 0x5a, 0xe6, 0xea, 0x17, 0xe2, 0xd5, 0xc7, 0x7b, 0xe9, 0x7c, 0x86, 0xee, 0xc1,
+	0x8a, 0xed, 0xd3, 0xe1, 0x98, 0xcc, 0x14, 0x6c, 0x55, 0xc1, 0xaa, 0x90, 0x92, 0x2d, 0x9f, 0x78,
+	0x17, 0x6a, 0x82, 0x6b, 0xe8, 0x65, 0x57, 0x21, 0xe3, 0xc7, 0xa9, 0xd0, 0xfc, 0x20, 0x7d, 0xa8,
+	0x5b, 0x52, 0x31, 0x39, 0xfa, 0x0b, 0x6f, 0xbb, 0x10, 0xdd, 0xf6, 0x5e, 0x3a, 0xf7, 0x46, 0xb0,
+	0xe1, 0xa0, 0xfe, 0x02, 0x76, 0x35, 0x9d, 0xcb, 0x7a, 0xdf, 0x57, 0x40, 0x17, 0x43, 0xfb, 0x40,
+	0x82, 0x33, 0x7a, 0x44, 0xd0, 0x67, 0xa8, 0x24, 0x05, 0x8b, 0x36, 0x14, 0x8b, 0x94, 0xf2, 0x8d,
+	0x6c, 0x3f, 0xc3, 0xcd, 0xaf, 0x3f, 0x7f, 0x7f, 0x2b, 0x18, 0x18, 0x75, 0xce, 0x1e, 0x76, 0x04,
+	0x0d, 0xd6, 0x0f, 0x54, 0x48, 0x3f, 0xdc, 0xfc, 0xf7, 0x00, 0xb1, 0x58, 0xd1, 0xba, 0xaa, 0xb3,
+	0xa0, 0x78, 0x23, 0xcb, 0xcb, 0xf0, 0xa6, 0xac, 0xbd, 0x8a, 0xcb, 0x71, 0xed, 0xb0, 0xe4, 0x3e,
+	0xfc, 0x33, 0x97, 0x2b, 0x42, 0x11, 0xb1, 0x48, 0xde, 0xc6, 0x45, 0x1f, 0xc3, 0x1b, 0xb2, 0xd8,
+	0xbf, 0xa8, 0x16, 0x15, 0xeb, 0x7c, 0xa1, 0xce, 0x39, 0xfa, 0x04, 0x10, 0x6b, 0x2b, 0xa2, 0xb7,
+	0xa0, 0x6e, 0x23, 0xcb, 0xcb, 0x70, 0x4b, 0x56, 0xfc, 0xbf, 0xb7, 0x96, 0xa8, 0x28, 0x1e, 0x26,
+	0x75, 0xce, 0x15, 0xd1, 0x77, 0x00, 0xb1, 0xca, 0xa2, 0xe2, 0x0b, 0xca, 0x34, 0xb2, 0xbc, 0x11,
+	0xdd, 0x76, 0x9a, 0xee, 0x3e, 0x94, 0x23, 0x59, 0xa2, 0x35, 0x95, 0x9a, 0x14, 0xae, 0x91, 0xe1,
+	0x64, 0x78, 0x55, 0x96, 0xd3, 0x51, 0x7c, 0x95, 0x5d, 0x0d, 0x8d, 0xa0, 0x92, 0x54, 0x5c, 0x34,
+	0xfb, 0x94, 0x8c, 0x8d, 0x6c, 0x3f, 0xc3, 0xf7, 0x65, 0xd1, 0x16, 0xde, 0x4a, 0x5d, 0xc0, 0x90,
+	0x3a, 0xe7, 0xe2, 0x47, 0xe2, 0xc1, 0x98, 0xcc, 0x58, 0x5f, 0x6b, 0xa3, 0x23, 0xd0, 0x13, 0xa2,
+	0x43, 0xff, 0x25, 0x18, 0xc6, 0x9a, 0x35, 0x32, 0xdd, 0x0c, 0xdf, 0x95, 0x28, 0xb7, 0xd1, 0x95,
+	0x28, 0x5d, 0x0d, 0xf9, 0x50, 0x49, 0x6a, 0x2c, 0xde, 0x64, 0x8f, 0x67, 0x76, 0xb3, 0xe8, 0x67,
+	0xb8, 0x2b, 0x71, 0xda, 0x78, 0xe7, 0x2a, 0x1c, 0x39, 0x84, 0x7e, 0xf8, 0xe5, 0x44, 0x14, 0x2a,
+	0x49, 0x79, 0x45, 0x88, 0x29, 0xbd, 0x1a, 0xd9, 0x7e, 0x86, 0x77, 0x25, 0xe2, 0x9d, 0x76, 0xeb,
+	0x5a, 0xc4, 0xc3, 0x92, 0xfc, 0x42, 0x3c, 0xfa, 0x33, 0x00, 0x7d, 0x70, 0x18, 0x11, 0xba, 0x09,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UserServiceClient interface {
+	// Creates the user of the caller, with the subject of the caller's token as id
+	RegisterUser(ctx context.Context, in *RegisterUserReq, opts ...grpc.CallOption) (*RegisterUserRes, error)
+	// Creates a user for someone else or a service user, only admins can create users
+	CreateUser(ctx context.Context, in *CreateUserReq, opts ...grpc.CallOption) (*CreateUserRes, error)
+	ReadUser(ctx context.Context, in *ReadUserReq, opts ...grpc.CallOption) (*ReadUserRes, error)
+	UpdateUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*UpdateUserRes, error)
+	// Deletes a user together with its API keys, the jobs of the user are kept
+	DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*DeleteUserRes, error)
+	// Streams all users oldest first, only admins can list users
+	ListUsers(ctx context.Context, in *ListUsersReq, opts ...grpc.CallOption) (UserService_ListUsersClient, error)
+	CreateApiKey(ctx context.Context, in *CreateApiKeyReq, opts ...grpc.CallOption) (*CreateApiKeyRes, error)
+	// Streams the API keys of a user oldest first, without their tokens
+	ListApiKeys(ctx context.Context, in *ListApiKeysReq, opts ...grpc.CallOption) (UserService_ListApiKeysClient, error)
+	// Replaces the secret of an API key, the old token stops working right away
+	RotateApiKey(ctx context.Context, in *RotateApiKeyReq, opts ...grpc.CallOption) (*RotateApiKeyRes, error)
+	DeleteApiKey(ctx context.Context, in *DeleteApiKeyReq, opts ...grpc.CallOption) (*DeleteApiKeyRes, error)
+}
+
+type userServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserServiceClient(cc *grpc.ClientConn) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) RegisterUser(ctx context.Context, in *RegisterUserReq, opts ...grpc.CallOption) (*RegisterUserRes, error) {
+	out := new(RegisterUserRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/RegisterUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserReq, opts ...grpc.CallOption) (*CreateUserRes, error) {
+	out := new(CreateUserRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReadUser(ctx context.Context, in *ReadUserReq, opts ...grpc.CallOption) (*ReadUserRes, error) {
+	out := new(ReadUserRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/ReadUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*UpdateUserRes, error) {
+	out := new(UpdateUserRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*DeleteUserRes, error) {
+	out := new(DeleteUserRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/DeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersReq, opts ...grpc.CallOption) (UserService_ListUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserService_serviceDesc.Streams[0], "/model.UserService/ListUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceListUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ListUsersClient interface {
+	Recv() (*ListUsersRes, error)
+	grpc.ClientStream
+}
+
+type userServiceListUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceListUsersClient) Recv() (*ListUsersRes, error) {
+	m := new(ListUsersRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) CreateApiKey(ctx context.Context, in *CreateApiKeyReq, opts ...grpc.CallOption) (*CreateApiKeyRes, error) {
+	out := new(CreateApiKeyRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/CreateApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListApiKeys(ctx context.Context, in *ListApiKeysReq, opts ...grpc.CallOption) (UserService_ListApiKeysClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserService_serviceDesc.Streams[1], "/model.UserService/ListApiKeys", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceListApiKeysClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ListApiKeysClient interface {
+	Recv() (*ListApiKeysRes, error)
+	grpc.ClientStream
+}
+
+type userServiceListApiKeysClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceListApiKeysClient) Recv() (*ListApiKeysRes, error) {
+	m := new(ListApiKeysRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) RotateApiKey(ctx context.Context, in *RotateApiKeyReq, opts ...grpc.CallOption) (*RotateApiKeyRes, error) {
+	out := new(RotateApiKeyRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/RotateApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteApiKey(ctx context.Context, in *DeleteApiKeyReq, opts ...grpc.CallOption) (*DeleteApiKeyRes, error) {
+	out := new(DeleteApiKeyRes)
+	err := c.cc.Invoke(ctx, "/model.UserService/DeleteApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+type UserServiceServer interface {
+	// Creates the user of the caller, with the subject of the caller's token as id
+	RegisterUser(context.Context, *RegisterUserReq) (*RegisterUserRes, error)
+	// Creates a user for someone else or a service user, only admins can create users
+	CreateUser(context.Context, *CreateUserReq) (*CreateUserRes, error)
+	ReadUser(context.Context, *ReadUserReq) (*ReadUserRes, error)
+	UpdateUser(context.Context, *UpdateUserReq) (*UpdateUserRes, error)
+	// Deletes a user together with its API keys, the jobs of the user are kept
+	DeleteUser(context.Context, *DeleteUserReq) (*DeleteUserRes, error)
+	// Streams all users oldest first, only admins can list users
+	ListUsers(*ListUsersReq, UserService_ListUsersServer) error
+	CreateApiKey(context.Context, *CreateApiKeyReq) (*CreateApiKeyRes, error)
+	// Streams the API keys of a user oldest first, without their tokens
+	ListApiKeys(*ListApiKeysReq, UserService_ListApiKeysServer) error
+	// Replaces the secret of an API key, the old token stops working right away
+	RotateApiKey(context.Context, *RotateApiKeyReq) (*RotateApiKeyRes, error)
+	DeleteApiKey(context.Context, *DeleteApiKeyReq) (*DeleteApiKeyRes, error)
+}
+
+func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
+	s.RegisterService(&_UserService_serviceDesc, srv)
+}
+
+func _UserService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/RegisterUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RegisterUser(ctx, req.(*RegisterUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReadUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReadUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/ReadUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReadUser(ctx, req.(*ReadUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUsersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ListUsers(m, &userServiceListUsersServer{stream})
+}
+
+type UserService_ListUsersServer interface {
+	Send(*ListUsersRes) error
+	grpc.ServerStream
+}
+
+type userServiceListUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceListUsersServer) Send(m *ListUsersRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/CreateApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateApiKey(ctx, req.(*CreateApiKeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListApiKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListApiKeysReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ListApiKeys(m, &userServiceListApiKeysServer{stream})
+}
+
+type UserService_ListApiKeysServer interface {
+	Send(*ListApiKeysRes) error
+	grpc.ServerStream
+}
+
+type userServiceListApiKeysServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceListApiKeysServer) Send(m *ListApiKeysRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_RotateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateApiKeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RotateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/RotateApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RotateApiKey(ctx, req.(*RotateApiKeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteApiKeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.UserService/DeleteApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteApiKey(ctx, req.(*DeleteApiKeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UserService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterUser",
+			Handler:    _UserService_RegisterUser_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "ReadUser",
+			Handler:    _UserService_ReadUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UserService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UserService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _UserService_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "RotateApiKey",
+			Handler:    _UserService_RotateApiKey_Handler,
+		},
+		{
+			MethodName: "DeleteApiKey",
+			Handler:    _UserService_DeleteApiKey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUsers",
+			Handler:       _UserService_ListUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListApiKeys",
+			Handler:       _UserService_ListApiKeys_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "user.proto",
+}
@@ -0,0 +1,871 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: webhook.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// WebhookEvent is a type of event the server posts to webhooks
+type WebhookEvent int32
+
+const (
+	WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED WebhookEvent = 0
+	// Sent as job.created, job.updated and job.deleted. Updates include status changes and schedule changes.
+	WebhookEvent_WEBHOOK_EVENT_JOB_CREATED WebhookEvent = 1
+	WebhookEvent_WEBHOOK_EVENT_JOB_UPDATED WebhookEvent = 2
+	WebhookEvent_WEBHOOK_EVENT_JOB_DELETED WebhookEvent = 3
+	// Sent as run.succeeded and run.failed, timed out runs count as failed
+	WebhookEvent_WEBHOOK_EVENT_RUN_SUCCEEDED WebhookEvent = 4
+	WebhookEvent_WEBHOOK_EVENT_RUN_FAILED    WebhookEvent = 5
+	// Sent as sla.breached when a run took longer than the SLA of its job allows or a deadline passed without a succeeded run
+	WebhookEvent_WEBHOOK_EVENT_SLA_BREACHED WebhookEvent = 6
+	// Sent as run.anomalous when the duration of a succeeded run deviated from the baseline of its job
+	WebhookEvent_WEBHOOK_EVENT_RUN_ANOMALOUS WebhookEvent = 7
+)
+
+var WebhookEvent_name = map[int32]string{
+	0: "WEBHOOK_EVENT_UNSPECIFIED",
+	1: "WEBHOOK_EVENT_JOB_CREATED",
+	2: "WEBHOOK_EVENT_JOB_UPDATED",
+	3: "WEBHOOK_EVENT_JOB_DELETED",
+	4: "WEBHOOK_EVENT_RUN_SUCCEEDED",
+	5: "WEBHOOK_EVENT_RUN_FAILED",
+	6: "WEBHOOK_EVENT_SLA_BREACHED",
+	7: "WEBHOOK_EVENT_RUN_ANOMALOUS",
+}
+
+var WebhookEvent_value = map[string]int32{
+	"WEBHOOK_EVENT_UNSPECIFIED":   0,
+	"WEBHOOK_EVENT_JOB_CREATED":   1,
+	"WEBHOOK_EVENT_JOB_UPDATED":   2,
+	"WEBHOOK_EVENT_JOB_DELETED":   3,
+	"WEBHOOK_EVENT_RUN_SUCCEEDED": 4,
+	"WEBHOOK_EVENT_RUN_FAILED":    5,
+	"WEBHOOK_EVENT_SLA_BREACHED":  6,
+	"WEBHOOK_EVENT_RUN_ANOMALOUS": 7,
+}
+
+func (x WebhookEvent) String() string {
+	return proto.EnumName(WebhookEvent_name, int32(x))
+}
+
+func (WebhookEvent) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{0}
+}
+
+// Webhook is an HTTPS endpoint the server posts the events of the jobs of its owner to
+type Webhook struct {
+	// Set by the server, ignored when sent by a client
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// HTTPS URL the events are posted to
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Defaults to the caller, only admins can create webhooks for other owners
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Events the webhook receives, all of them when empty
+	Events []WebhookEvent `protobuf:"varint,4,rep,packed,name=events,proto3,enum=model.WebhookEvent" json:"events,omitempty"`
+	// Set by the server
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Key of the HMAC-SHA256 signature of every payload, generated by the server and only returned by CreateWebhook
+	Secret               string   `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{0}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Webhook) GetEvents() []WebhookEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Webhook) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+// DeadLetter is a delivery that failed every attempt
+type DeadLetter struct {
+	Id        string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId string       `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Event     WebhookEvent `protobuf:"varint,3,opt,name=event,proto3,enum=model.WebhookEvent" json:"event,omitempty"`
+	// JSON body that was posted
+	Payload  string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Attempts int32  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Why the last attempt failed
+	Error                string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{1}
+}
+
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetWebhookId() string {
+	if m != nil {
+		return m.WebhookId
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetEvent() WebhookEvent {
+	if m != nil {
+		return m.Event
+	}
+	return WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED
+}
+
+func (m *DeadLetter) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type CreateWebhookReq struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookReq) Reset()         { *m = CreateWebhookReq{} }
+func (m *CreateWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookReq) ProtoMessage()    {}
+func (*CreateWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{2}
+}
+
+func (m *CreateWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookReq.Unmarshal(m, b)
+}
+func (m *CreateWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookReq.Merge(m, src)
+}
+func (m *CreateWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookReq.Size(m)
+}
+func (m *CreateWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookReq proto.InternalMessageInfo
+
+func (m *CreateWebhookReq) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type CreateWebhookRes struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookRes) Reset()         { *m = CreateWebhookRes{} }
+func (m *CreateWebhookRes) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookRes) ProtoMessage()    {}
+func (*CreateWebhookRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{3}
+}
+
+func (m *CreateWebhookRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookRes.Unmarshal(m, b)
+}
+func (m *CreateWebhookRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookRes.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookRes.Merge(m, src)
+}
+func (m *CreateWebhookRes) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookRes.Size(m)
+}
+func (m *CreateWebhookRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookRes proto.InternalMessageInfo
+
+func (m *CreateWebhookRes) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksReq) Reset()         { *m = ListWebhooksReq{} }
+func (m *ListWebhooksReq) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksReq) ProtoMessage()    {}
+func (*ListWebhooksReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{4}
+}
+
+func (m *ListWebhooksReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksReq.Unmarshal(m, b)
+}
+func (m *ListWebhooksReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksReq.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksReq.Merge(m, src)
+}
+func (m *ListWebhooksReq) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksReq.Size(m)
+}
+func (m *ListWebhooksReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksReq proto.InternalMessageInfo
+
+type ListWebhooksRes struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRes) Reset()         { *m = ListWebhooksRes{} }
+func (m *ListWebhooksRes) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRes) ProtoMessage()    {}
+func (*ListWebhooksRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{5}
+}
+
+func (m *ListWebhooksRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRes.Unmarshal(m, b)
+}
+func (m *ListWebhooksRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRes.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRes.Merge(m, src)
+}
+func (m *ListWebhooksRes) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRes.Size(m)
+}
+func (m *ListWebhooksRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRes proto.InternalMessageInfo
+
+func (m *ListWebhooksRes) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type DeleteWebhookReq struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookReq) Reset()         { *m = DeleteWebhookReq{} }
+func (m *DeleteWebhookReq) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookReq) ProtoMessage()    {}
+func (*DeleteWebhookReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{6}
+}
+
+func (m *DeleteWebhookReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookReq.Unmarshal(m, b)
+}
+func (m *DeleteWebhookReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookReq.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookReq.Merge(m, src)
+}
+func (m *DeleteWebhookReq) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookReq.Size(m)
+}
+func (m *DeleteWebhookReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookReq proto.InternalMessageInfo
+
+func (m *DeleteWebhookReq) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteWebhookRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookRes) Reset()         { *m = DeleteWebhookRes{} }
+func (m *DeleteWebhookRes) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookRes) ProtoMessage()    {}
+func (*DeleteWebhookRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{7}
+}
+
+func (m *DeleteWebhookRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookRes.Unmarshal(m, b)
+}
+func (m *DeleteWebhookRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookRes.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookRes.Merge(m, src)
+}
+func (m *DeleteWebhookRes) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookRes.Size(m)
+}
+func (m *DeleteWebhookRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookRes proto.InternalMessageInfo
+
+type ListDeadLettersReq struct {
+	WebhookId string `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Maximum number of dead letters to stream, defaults to 100 and is capped at 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response to continue listing after the last dead letter returned
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersReq) Reset()         { *m = ListDeadLettersReq{} }
+func (m *ListDeadLettersReq) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersReq) ProtoMessage()    {}
+func (*ListDeadLettersReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{8}
+}
+
+func (m *ListDeadLettersReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersReq.Unmarshal(m, b)
+}
+func (m *ListDeadLettersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersReq.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersReq.Merge(m, src)
+}
+func (m *ListDeadLettersReq) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersReq.Size(m)
+}
+func (m *ListDeadLettersReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersReq proto.InternalMessageInfo
+
+func (m *ListDeadLettersReq) GetWebhookId() string {
+	if m != nil {
+		return m.WebhookId
+	}
+	return ""
+}
+
+func (m *ListDeadLettersReq) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDeadLettersReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListDeadLettersRes struct {
+	DeadLetter *DeadLetter `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	// Only set on the last message of a page when more dead letters are available
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersRes) Reset()         { *m = ListDeadLettersRes{} }
+func (m *ListDeadLettersRes) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersRes) ProtoMessage()    {}
+func (*ListDeadLettersRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{9}
+}
+
+func (m *ListDeadLettersRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersRes.Unmarshal(m, b)
+}
+func (m *ListDeadLettersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersRes.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersRes.Merge(m, src)
+}
+func (m *ListDeadLettersRes) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersRes.Size(m)
+}
+func (m *ListDeadLettersRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersRes proto.InternalMessageInfo
+
+func (m *ListDeadLettersRes) GetDeadLetter() *DeadLetter {
+	if m != nil {
+		return m.DeadLetter
+	}
+	return nil
+}
+
+func (m *ListDeadLettersRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("model.WebhookEvent", WebhookEvent_name, WebhookEvent_value)
+	proto.RegisterType((*Webhook)(nil), "model.Webhook")
+	proto.RegisterType((*DeadLetter)(nil), "model.DeadLetter")
+	proto.RegisterType((*CreateWebhookReq)(nil), "model.CreateWebhookReq")
+	proto.RegisterType((*CreateWebhookRes)(nil), "model.CreateWebhookRes")
+	proto.RegisterType((*ListWebhooksReq)(nil), "model.ListWebhooksReq")
+	proto.RegisterType((*ListWebhooksRes)(nil), "model.ListWebhooksRes")
+	proto.RegisterType((*DeleteWebhookReq)(nil), "model.DeleteWebhookReq")
+	proto.RegisterType((*DeleteWebhookRes)(nil), "model.DeleteWebhookRes")
+	proto.RegisterType((*ListDeadLettersReq)(nil), "model.ListDeadLettersReq")
+	proto.RegisterType((*ListDeadLettersRes)(nil), "model.ListDeadLettersRes")
+}
+
+func init() { proto.RegisterFile("webhook.proto", fileDescriptor_4a0479a603100288) }
+
+var fileDescriptor_4a0479a603100288 = []byte{
+	// 740 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x52, 0x1a, 0x4b,
+	0x14, 0xbe, 0x03, 0x02, 0x72, 0x14, 0x1c, 0x5b, 0x4b, 0xc7, 0x51, 0xaf, 0xd6, 0x2c, 0x2c, 0xae,
+	0xb7, 0x2e, 0x78, 0xc9, 0x2a, 0x3f, 0x1b, 0x64, 0xda, 0x92, 0x84, 0x80, 0x35, 0x80, 0x6e, 0x52,
+	0x35, 0x19, 0x9d, 0x8e, 0x99, 0x12, 0x68, 0x9c, 0x6e, 0x35, 0x9a, 0x72, 0x93, 0x65, 0xb6, 0x79,
+	0x84, 0x3c, 0x49, 0x9e, 0x21, 0xaf, 0x90, 0x6d, 0xde, 0x21, 0x35, 0x3d, 0x8d, 0x32, 0x03, 0x2c,
+	0xdc, 0x71, 0xce, 0x77, 0xe6, 0x9c, 0xef, 0x3b, 0xfd, 0x1d, 0x20, 0x77, 0x43, 0x4e, 0x3f, 0x52,
+	0x7a, 0x51, 0x1c, 0xf8, 0x94, 0x53, 0x94, 0xea, 0x51, 0x97, 0x74, 0xf5, 0x8d, 0x73, 0x4a, 0xcf,
+	0xbb, 0xa4, 0xe4, 0x0c, 0xbc, 0x92, 0xd3, 0xef, 0x53, 0xee, 0x70, 0x8f, 0xf6, 0x59, 0x58, 0xa4,
+	0x6f, 0x49, 0x54, 0x44, 0xa7, 0x57, 0x1f, 0x4a, 0xdc, 0xeb, 0x11, 0xc6, 0x9d, 0xde, 0x20, 0x2c,
+	0x30, 0x7e, 0x28, 0x90, 0x39, 0x09, 0xfb, 0xa2, 0x3c, 0x24, 0x3c, 0x57, 0x53, 0xb6, 0x95, 0x42,
+	0xd6, 0x4a, 0x78, 0x2e, 0x52, 0x21, 0x79, 0xe5, 0x77, 0xb5, 0x84, 0x48, 0x04, 0x3f, 0xd1, 0x32,
+	0xa4, 0xe8, 0x4d, 0x9f, 0xf8, 0x5a, 0x52, 0xe4, 0xc2, 0x00, 0xfd, 0x0b, 0x69, 0x72, 0x4d, 0xfa,
+	0x9c, 0x69, 0x33, 0xdb, 0xc9, 0x42, 0xbe, 0xbc, 0x54, 0x14, 0xd4, 0x8a, 0xb2, 0x2f, 0x0e, 0x30,
+	0x4b, 0x96, 0xa0, 0xe7, 0x00, 0x67, 0x3e, 0x71, 0x38, 0x71, 0x6d, 0x87, 0x6b, 0xa9, 0x6d, 0xa5,
+	0x30, 0x57, 0xd6, 0x8b, 0x21, 0xcd, 0xe2, 0x90, 0x66, 0xb1, 0x3d, 0xa4, 0x69, 0x65, 0x65, 0x75,
+	0x85, 0xa3, 0x15, 0x48, 0x33, 0x72, 0xe6, 0x13, 0xae, 0xa5, 0xc5, 0x78, 0x19, 0x19, 0xbf, 0x15,
+	0x00, 0x93, 0x38, 0x6e, 0x9d, 0x70, 0x4e, 0xfc, 0x31, 0x19, 0x9b, 0x00, 0x72, 0x73, 0xb6, 0xe7,
+	0x4a, 0x35, 0x59, 0x99, 0xa9, 0xb9, 0xe8, 0x1f, 0x48, 0x09, 0x6a, 0x42, 0xd3, 0x14, 0xf2, 0x61,
+	0x05, 0xd2, 0x20, 0x33, 0x70, 0x6e, 0xbb, 0xd4, 0x71, 0xb5, 0x19, 0xd1, 0x66, 0x18, 0x22, 0x1d,
+	0x66, 0x1d, 0xce, 0x49, 0x6f, 0xc0, 0x99, 0xd0, 0x94, 0xb2, 0x1e, 0xe2, 0x60, 0x69, 0xc4, 0xf7,
+	0xa9, 0x2f, 0x59, 0x87, 0x41, 0x6c, 0x0f, 0x99, 0x27, 0xec, 0xc1, 0x78, 0x05, 0x6a, 0x55, 0x04,
+	0x92, 0xa3, 0x45, 0x2e, 0x51, 0x01, 0x32, 0x52, 0x92, 0x50, 0x3e, 0x57, 0xce, 0x47, 0x75, 0x58,
+	0x43, 0x78, 0xc2, 0xd7, 0xec, 0x09, 0x5f, 0x2f, 0xc2, 0x42, 0xdd, 0x63, 0x5c, 0xe6, 0x99, 0x45,
+	0x2e, 0x8d, 0x97, 0xf1, 0xd4, 0x53, 0xfa, 0x19, 0xa0, 0x9a, 0xa4, 0x4b, 0x22, 0x5a, 0x62, 0x0f,
+	0x68, 0xa0, 0xb1, 0x1a, 0x66, 0x50, 0x40, 0xc1, 0xd0, 0xc7, 0x67, 0x0f, 0xa8, 0xc4, 0x9e, 0x5a,
+	0x89, 0x3f, 0xf5, 0x3a, 0x64, 0x07, 0xce, 0x39, 0xb1, 0x99, 0x77, 0x47, 0x84, 0x11, 0x52, 0xd6,
+	0x6c, 0x90, 0x68, 0x79, 0x77, 0x24, 0xf8, 0x56, 0x80, 0x9c, 0x5e, 0x90, 0xbe, 0x34, 0xb8, 0x28,
+	0x6f, 0x07, 0x09, 0x63, 0x30, 0x61, 0x20, 0x43, 0x65, 0x98, 0x73, 0x89, 0xe3, 0xda, 0x5d, 0x91,
+	0x92, 0x62, 0x17, 0xa5, 0xd8, 0xc7, 0x5a, 0x0b, 0xdc, 0x87, 0xdf, 0x68, 0x07, 0x16, 0xfa, 0xe4,
+	0x13, 0xb7, 0x47, 0xa6, 0x85, 0xa6, 0xcc, 0x05, 0xe9, 0xa3, 0xe1, 0xc4, 0xdd, 0xaf, 0x09, 0x98,
+	0x1f, 0x75, 0x21, 0xda, 0x84, 0xb5, 0x13, 0xbc, 0x7f, 0xd8, 0x6c, 0xbe, 0xb1, 0xf1, 0x31, 0x6e,
+	0xb4, 0xed, 0x4e, 0xa3, 0x75, 0x84, 0xab, 0xb5, 0x83, 0x1a, 0x36, 0xd5, 0xbf, 0xc6, 0xe1, 0xd7,
+	0xcd, 0x7d, 0xbb, 0x6a, 0xe1, 0x4a, 0x1b, 0x9b, 0xaa, 0x32, 0x19, 0xee, 0x1c, 0x99, 0x02, 0x4e,
+	0x4c, 0x86, 0x4d, 0x5c, 0xc7, 0x01, 0x9c, 0x44, 0x5b, 0xb0, 0x1e, 0x85, 0xad, 0x4e, 0xc3, 0x6e,
+	0x75, 0xaa, 0x55, 0x8c, 0x4d, 0x6c, 0xaa, 0x33, 0x68, 0x03, 0xb4, 0xf1, 0x82, 0x83, 0x4a, 0xad,
+	0x8e, 0x4d, 0x35, 0x85, 0xfe, 0x06, 0x3d, 0x8a, 0xb6, 0xea, 0x15, 0x7b, 0xdf, 0xc2, 0x95, 0xea,
+	0x21, 0x36, 0xd5, 0xf4, 0xe4, 0xf6, 0x95, 0x46, 0xf3, 0x6d, 0xa5, 0xde, 0xec, 0xb4, 0xd4, 0x4c,
+	0xf9, 0x7b, 0x12, 0xf2, 0x72, 0x19, 0x2d, 0xe2, 0x5f, 0x7b, 0x67, 0x04, 0xbd, 0x87, 0x5c, 0xc4,
+	0xc8, 0x68, 0x55, 0xee, 0x3d, 0x7e, 0x1c, 0xfa, 0x14, 0x80, 0x19, 0x9b, 0x5f, 0x7e, 0xfe, 0xfa,
+	0x96, 0x58, 0x35, 0xe6, 0x4b, 0xd7, 0xff, 0x97, 0xa4, 0x4f, 0xd8, 0x8b, 0xa1, 0x39, 0xd1, 0x31,
+	0xcc, 0x8f, 0x3a, 0x1b, 0xad, 0xc8, 0x3e, 0xb1, 0x0b, 0xd0, 0x27, 0xe7, 0x99, 0xb1, 0x2c, 0xda,
+	0xe7, 0x51, 0xa4, 0xfd, 0x9e, 0x82, 0xde, 0x41, 0x2e, 0x62, 0xe8, 0x07, 0xe6, 0xf1, 0x53, 0xd0,
+	0xa7, 0x00, 0xcc, 0x58, 0x13, 0xad, 0x97, 0x76, 0x17, 0x47, 0x5b, 0x97, 0x3e, 0x7b, 0xee, 0x3d,
+	0xba, 0x0d, 0xef, 0x71, 0xc4, 0xa9, 0x68, 0x6d, 0x84, 0x60, 0xf4, 0x64, 0xf4, 0xa9, 0x10, 0x33,
+	0x8a, 0x62, 0x46, 0x01, 0xed, 0x44, 0x67, 0x3c, 0x5e, 0xd8, 0x7d, 0x29, 0xb0, 0xf5, 0x7f, 0xa1,
+	0xf9, 0xd9, 0x9e, 0x72, 0x9a, 0x16, 0x7f, 0x5c, 0xcf, 0xfe, 0x0c, 0x00, 0x08, 0x3f, 0xf9, 0xa1,
+	0xab, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*CreateWebhookRes, error)
+	// Streams the webhooks of the caller oldest first, all of them for admins
+	ListWebhooks(ctx context.Context, in *ListWebhooksReq, opts ...grpc.CallOption) (WebhookService_ListWebhooksClient, error)
+	// Deletes a webhook together with its dead letters
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...grpc.CallOption) (*DeleteWebhookRes, error)
+	// Streams the deliveries to a webhook that failed every attempt, newest first
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (WebhookService_ListDeadLettersClient, error)
+}
+
+type webhookServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWebhookServiceClient(cc *grpc.ClientConn) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*CreateWebhookRes, error) {
+	out := new(CreateWebhookRes)
+	err := c.cc.Invoke(ctx, "/model.WebhookService/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksReq, opts ...grpc.CallOption) (WebhookService_ListWebhooksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WebhookService_serviceDesc.Streams[0], "/model.WebhookService/ListWebhooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &webhookServiceListWebhooksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WebhookService_ListWebhooksClient interface {
+	Recv() (*ListWebhooksRes, error)
+	grpc.ClientStream
+}
+
+type webhookServiceListWebhooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *webhookServiceListWebhooksClient) Recv() (*ListWebhooksRes, error) {
+	m := new(ListWebhooksRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...grpc.CallOption) (*DeleteWebhookRes, error) {
+	out := new(DeleteWebhookRes)
+	err := c.cc.Invoke(ctx, "/model.WebhookService/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (WebhookService_ListDeadLettersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WebhookService_serviceDesc.Streams[1], "/model.WebhookService/ListDeadLetters", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &webhookServiceListDeadLettersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WebhookService_ListDeadLettersClient interface {
+	Recv() (*ListDeadLettersRes, error)
+	grpc.ClientStream
+}
+
+type webhookServiceListDeadLettersClient struct {
+	grpc.ClientStream
+}
+
+func (x *webhookServiceListDeadLettersClient) Recv() (*ListDeadLettersRes, error) {
+	m := new(ListDeadLettersRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+type WebhookServiceServer interface {
+	CreateWebhook(context.Context, *CreateWebhookReq) (*CreateWebhookRes, error)
+	// Streams the webhooks of the caller oldest first, all of them for admins
+	ListWebhooks(*ListWebhooksReq, WebhookService_ListWebhooksServer) error
+	// Deletes a webhook together with its dead letters
+	DeleteWebhook(context.Context, *DeleteWebhookReq) (*DeleteWebhookRes, error)
+	// Streams the deliveries to a webhook that failed every attempt, newest first
+	ListDeadLetters(*ListDeadLettersReq, WebhookService_ListDeadLettersServer) error
+}
+
+func RegisterWebhookServiceServer(s *grpc.Server, srv WebhookServiceServer) {
+	s.RegisterService(&_WebhookService_serviceDesc, srv)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WebhookService/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWebhooksReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WebhookServiceServer).ListWebhooks(m, &webhookServiceListWebhooksServer{stream})
+}
+
+type WebhookService_ListWebhooksServer interface {
+	Send(*ListWebhooksRes) error
+	grpc.ServerStream
+}
+
+type webhookServiceListWebhooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *webhookServiceListWebhooksServer) Send(m *ListWebhooksRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WebhookService/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListDeadLetters_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDeadLettersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WebhookServiceServer).ListDeadLetters(m, &webhookServiceListDeadLettersServer{stream})
+}
+
+type WebhookService_ListDeadLettersServer interface {
+	Send(*ListDeadLettersRes) error
+	grpc.ServerStream
+}
+
+type webhookServiceListDeadLettersServer struct {
+	grpc.ServerStream
+}
+
+func (x *webhookServiceListDeadLettersServer) Send(m *ListDeadLettersRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _WebhookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListWebhooks",
+			Handler:       _WebhookService_ListWebhooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDeadLetters",
+			Handler:       _WebhookService_ListDeadLetters_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "webhook.proto",
+}
@@ -0,0 +1,1002 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: worker.proto
+
+package model
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Worker is an external worker that runs the jobs with the remote handler
+type Worker struct {
+	// Set by the server
+	Id           string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RegisteredAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	HeartbeatAt  *timestamp.Timestamp `protobuf:"bytes,4,opt,name=heartbeat_at,json=heartbeatAt,proto3" json:"heartbeat_at,omitempty"`
+	// Runs the worker holds the lease of
+	RunIds               []string `protobuf:"bytes,5,rep,name=run_ids,json=runIds,proto3" json:"run_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Worker) Reset()         { *m = Worker{} }
+func (m *Worker) String() string { return proto.CompactTextString(m) }
+func (*Worker) ProtoMessage()    {}
+func (*Worker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{0}
+}
+
+func (m *Worker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Worker.Unmarshal(m, b)
+}
+func (m *Worker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Worker.Marshal(b, m, deterministic)
+}
+func (m *Worker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Worker.Merge(m, src)
+}
+func (m *Worker) XXX_Size() int {
+	return xxx_messageInfo_Worker.Size(m)
+}
+func (m *Worker) XXX_DiscardUnknown() {
+	xxx_messageInfo_Worker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Worker proto.InternalMessageInfo
+
+func (m *Worker) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Worker) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Worker) GetRegisteredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RegisteredAt
+	}
+	return nil
+}
+
+func (m *Worker) GetHeartbeatAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.HeartbeatAt
+	}
+	return nil
+}
+
+func (m *Worker) GetRunIds() []string {
+	if m != nil {
+		return m.RunIds
+	}
+	return nil
+}
+
+// RunLease is a run handed to a worker, the worker holds it as long as it keeps sending heartbeats
+type RunLease struct {
+	RunId   string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	JobId   string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName string `protobuf:"bytes,3,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Command string `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	// When the timeout of the job stops the run, not set for jobs without a timeout
+	Deadline *timestamp.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// The run goes to another worker unless the worker sends a heartbeat before
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// The params of the job
+	Params               *_struct.Struct `protobuf:"bytes,7,opt,name=params,proto3" json:"params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RunLease) Reset()         { *m = RunLease{} }
+func (m *RunLease) String() string { return proto.CompactTextString(m) }
+func (*RunLease) ProtoMessage()    {}
+func (*RunLease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{1}
+}
+
+func (m *RunLease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunLease.Unmarshal(m, b)
+}
+func (m *RunLease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunLease.Marshal(b, m, deterministic)
+}
+func (m *RunLease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunLease.Merge(m, src)
+}
+func (m *RunLease) XXX_Size() int {
+	return xxx_messageInfo_RunLease.Size(m)
+}
+func (m *RunLease) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunLease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunLease proto.InternalMessageInfo
+
+func (m *RunLease) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *RunLease) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *RunLease) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *RunLease) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *RunLease) GetDeadline() *timestamp.Timestamp {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
+func (m *RunLease) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *RunLease) GetParams() *_struct.Struct {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+type RegisterWorkerReq struct {
+	// Name the runs of the worker are recorded with, like its host name
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterWorkerReq) Reset()         { *m = RegisterWorkerReq{} }
+func (m *RegisterWorkerReq) String() string { return proto.CompactTextString(m) }
+func (*RegisterWorkerReq) ProtoMessage()    {}
+func (*RegisterWorkerReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{2}
+}
+
+func (m *RegisterWorkerReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterWorkerReq.Unmarshal(m, b)
+}
+func (m *RegisterWorkerReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterWorkerReq.Marshal(b, m, deterministic)
+}
+func (m *RegisterWorkerReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterWorkerReq.Merge(m, src)
+}
+func (m *RegisterWorkerReq) XXX_Size() int {
+	return xxx_messageInfo_RegisterWorkerReq.Size(m)
+}
+func (m *RegisterWorkerReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterWorkerReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterWorkerReq proto.InternalMessageInfo
+
+func (m *RegisterWorkerReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RegisterWorkerRes struct {
+	Worker *Worker `protobuf:"bytes,1,opt,name=worker,proto3" json:"worker,omitempty"`
+	// How long the worker keeps its leases without sending a heartbeat
+	LeaseTtl             *duration.Duration `protobuf:"bytes,2,opt,name=lease_ttl,json=leaseTtl,proto3" json:"lease_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *RegisterWorkerRes) Reset()         { *m = RegisterWorkerRes{} }
+func (m *RegisterWorkerRes) String() string { return proto.CompactTextString(m) }
+func (*RegisterWorkerRes) ProtoMessage()    {}
+func (*RegisterWorkerRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{3}
+}
+
+func (m *RegisterWorkerRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterWorkerRes.Unmarshal(m, b)
+}
+func (m *RegisterWorkerRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterWorkerRes.Marshal(b, m, deterministic)
+}
+func (m *RegisterWorkerRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterWorkerRes.Merge(m, src)
+}
+func (m *RegisterWorkerRes) XXX_Size() int {
+	return xxx_messageInfo_RegisterWorkerRes.Size(m)
+}
+func (m *RegisterWorkerRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterWorkerRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterWorkerRes proto.InternalMessageInfo
+
+func (m *RegisterWorkerRes) GetWorker() *Worker {
+	if m != nil {
+		return m.Worker
+	}
+	return nil
+}
+
+func (m *RegisterWorkerRes) GetLeaseTtl() *duration.Duration {
+	if m != nil {
+		return m.LeaseTtl
+	}
+	return nil
+}
+
+type UnregisterWorkerReq struct {
+	WorkerId             string   `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnregisterWorkerReq) Reset()         { *m = UnregisterWorkerReq{} }
+func (m *UnregisterWorkerReq) String() string { return proto.CompactTextString(m) }
+func (*UnregisterWorkerReq) ProtoMessage()    {}
+func (*UnregisterWorkerReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{4}
+}
+
+func (m *UnregisterWorkerReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnregisterWorkerReq.Unmarshal(m, b)
+}
+func (m *UnregisterWorkerReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnregisterWorkerReq.Marshal(b, m, deterministic)
+}
+func (m *UnregisterWorkerReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnregisterWorkerReq.Merge(m, src)
+}
+func (m *UnregisterWorkerReq) XXX_Size() int {
+	return xxx_messageInfo_UnregisterWorkerReq.Size(m)
+}
+func (m *UnregisterWorkerReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnregisterWorkerReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnregisterWorkerReq proto.InternalMessageInfo
+
+func (m *UnregisterWorkerReq) GetWorkerId() string {
+	if m != nil {
+		return m.WorkerId
+	}
+	return ""
+}
+
+type UnregisterWorkerRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnregisterWorkerRes) Reset()         { *m = UnregisterWorkerRes{} }
+func (m *UnregisterWorkerRes) String() string { return proto.CompactTextString(m) }
+func (*UnregisterWorkerRes) ProtoMessage()    {}
+func (*UnregisterWorkerRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{5}
+}
+
+func (m *UnregisterWorkerRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnregisterWorkerRes.Unmarshal(m, b)
+}
+func (m *UnregisterWorkerRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnregisterWorkerRes.Marshal(b, m, deterministic)
+}
+func (m *UnregisterWorkerRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnregisterWorkerRes.Merge(m, src)
+}
+func (m *UnregisterWorkerRes) XXX_Size() int {
+	return xxx_messageInfo_UnregisterWorkerRes.Size(m)
+}
+func (m *UnregisterWorkerRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnregisterWorkerRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnregisterWorkerRes proto.InternalMessageInfo
+
+type HeartbeatReq struct {
+	WorkerId             string   `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeartbeatReq) Reset()         { *m = HeartbeatReq{} }
+func (m *HeartbeatReq) String() string { return proto.CompactTextString(m) }
+func (*HeartbeatReq) ProtoMessage()    {}
+func (*HeartbeatReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{6}
+}
+
+func (m *HeartbeatReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeartbeatReq.Unmarshal(m, b)
+}
+func (m *HeartbeatReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeartbeatReq.Marshal(b, m, deterministic)
+}
+func (m *HeartbeatReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeartbeatReq.Merge(m, src)
+}
+func (m *HeartbeatReq) XXX_Size() int {
+	return xxx_messageInfo_HeartbeatReq.Size(m)
+}
+func (m *HeartbeatReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeartbeatReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeartbeatReq proto.InternalMessageInfo
+
+func (m *HeartbeatReq) GetWorkerId() string {
+	if m != nil {
+		return m.WorkerId
+	}
+	return ""
+}
+
+type HeartbeatRes struct {
+	// Runs the worker leased that were cancelled or timed out since the last heartbeat, the worker should stop them
+	CancelledRunIds      []string `protobuf:"bytes,1,rep,name=cancelled_run_ids,json=cancelledRunIds,proto3" json:"cancelled_run_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeartbeatRes) Reset()         { *m = HeartbeatRes{} }
+func (m *HeartbeatRes) String() string { return proto.CompactTextString(m) }
+func (*HeartbeatRes) ProtoMessage()    {}
+func (*HeartbeatRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{7}
+}
+
+func (m *HeartbeatRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeartbeatRes.Unmarshal(m, b)
+}
+func (m *HeartbeatRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeartbeatRes.Marshal(b, m, deterministic)
+}
+func (m *HeartbeatRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeartbeatRes.Merge(m, src)
+}
+func (m *HeartbeatRes) XXX_Size() int {
+	return xxx_messageInfo_HeartbeatRes.Size(m)
+}
+func (m *HeartbeatRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeartbeatRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeartbeatRes proto.InternalMessageInfo
+
+func (m *HeartbeatRes) GetCancelledRunIds() []string {
+	if m != nil {
+		return m.CancelledRunIds
+	}
+	return nil
+}
+
+type LeaseRunReq struct {
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	// How long to wait for a run, 30s by default and at most 60s
+	Wait                 *duration.Duration `protobuf:"bytes,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *LeaseRunReq) Reset()         { *m = LeaseRunReq{} }
+func (m *LeaseRunReq) String() string { return proto.CompactTextString(m) }
+func (*LeaseRunReq) ProtoMessage()    {}
+func (*LeaseRunReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{8}
+}
+
+func (m *LeaseRunReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseRunReq.Unmarshal(m, b)
+}
+func (m *LeaseRunReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseRunReq.Marshal(b, m, deterministic)
+}
+func (m *LeaseRunReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseRunReq.Merge(m, src)
+}
+func (m *LeaseRunReq) XXX_Size() int {
+	return xxx_messageInfo_LeaseRunReq.Size(m)
+}
+func (m *LeaseRunReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseRunReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseRunReq proto.InternalMessageInfo
+
+func (m *LeaseRunReq) GetWorkerId() string {
+	if m != nil {
+		return m.WorkerId
+	}
+	return ""
+}
+
+func (m *LeaseRunReq) GetWait() *duration.Duration {
+	if m != nil {
+		return m.Wait
+	}
+	return nil
+}
+
+type LeaseRunRes struct {
+	// Not set when no run was waiting within the wait
+	Lease                *RunLease `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *LeaseRunRes) Reset()         { *m = LeaseRunRes{} }
+func (m *LeaseRunRes) String() string { return proto.CompactTextString(m) }
+func (*LeaseRunRes) ProtoMessage()    {}
+func (*LeaseRunRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{9}
+}
+
+func (m *LeaseRunRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseRunRes.Unmarshal(m, b)
+}
+func (m *LeaseRunRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseRunRes.Marshal(b, m, deterministic)
+}
+func (m *LeaseRunRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseRunRes.Merge(m, src)
+}
+func (m *LeaseRunRes) XXX_Size() int {
+	return xxx_messageInfo_LeaseRunRes.Size(m)
+}
+func (m *LeaseRunRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseRunRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseRunRes proto.InternalMessageInfo
+
+func (m *LeaseRunRes) GetLease() *RunLease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+type CompleteRunReq struct {
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	RunId    string `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Output of the run, like the combined output of a command, cut to 64KiB
+	Output string `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// The run failed with this error, it succeeded when it's empty
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteRunReq) Reset()         { *m = CompleteRunReq{} }
+func (m *CompleteRunReq) String() string { return proto.CompactTextString(m) }
+func (*CompleteRunReq) ProtoMessage()    {}
+func (*CompleteRunReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{10}
+}
+
+func (m *CompleteRunReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteRunReq.Unmarshal(m, b)
+}
+func (m *CompleteRunReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteRunReq.Marshal(b, m, deterministic)
+}
+func (m *CompleteRunReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteRunReq.Merge(m, src)
+}
+func (m *CompleteRunReq) XXX_Size() int {
+	return xxx_messageInfo_CompleteRunReq.Size(m)
+}
+func (m *CompleteRunReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteRunReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteRunReq proto.InternalMessageInfo
+
+func (m *CompleteRunReq) GetWorkerId() string {
+	if m != nil {
+		return m.WorkerId
+	}
+	return ""
+}
+
+func (m *CompleteRunReq) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *CompleteRunReq) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *CompleteRunReq) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CompleteRunRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteRunRes) Reset()         { *m = CompleteRunRes{} }
+func (m *CompleteRunRes) String() string { return proto.CompactTextString(m) }
+func (*CompleteRunRes) ProtoMessage()    {}
+func (*CompleteRunRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{11}
+}
+
+func (m *CompleteRunRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteRunRes.Unmarshal(m, b)
+}
+func (m *CompleteRunRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteRunRes.Marshal(b, m, deterministic)
+}
+func (m *CompleteRunRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteRunRes.Merge(m, src)
+}
+func (m *CompleteRunRes) XXX_Size() int {
+	return xxx_messageInfo_CompleteRunRes.Size(m)
+}
+func (m *CompleteRunRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteRunRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteRunRes proto.InternalMessageInfo
+
+type ListWorkersReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWorkersReq) Reset()         { *m = ListWorkersReq{} }
+func (m *ListWorkersReq) String() string { return proto.CompactTextString(m) }
+func (*ListWorkersReq) ProtoMessage()    {}
+func (*ListWorkersReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{12}
+}
+
+func (m *ListWorkersReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkersReq.Unmarshal(m, b)
+}
+func (m *ListWorkersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkersReq.Marshal(b, m, deterministic)
+}
+func (m *ListWorkersReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkersReq.Merge(m, src)
+}
+func (m *ListWorkersReq) XXX_Size() int {
+	return xxx_messageInfo_ListWorkersReq.Size(m)
+}
+func (m *ListWorkersReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkersReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkersReq proto.InternalMessageInfo
+
+type ListWorkersRes struct {
+	Workers              []*Worker `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListWorkersRes) Reset()         { *m = ListWorkersRes{} }
+func (m *ListWorkersRes) String() string { return proto.CompactTextString(m) }
+func (*ListWorkersRes) ProtoMessage()    {}
+func (*ListWorkersRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4ff6184b07e587a, []int{13}
+}
+
+func (m *ListWorkersRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkersRes.Unmarshal(m, b)
+}
+func (m *ListWorkersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkersRes.Marshal(b, m, deterministic)
+}
+func (m *ListWorkersRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkersRes.Merge(m, src)
+}
+func (m *ListWorkersRes) XXX_Size() int {
+	return xxx_messageInfo_ListWorkersRes.Size(m)
+}
+func (m *ListWorkersRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkersRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkersRes proto.InternalMessageInfo
+
+func (m *ListWorkersRes) GetWorkers() []*Worker {
+	if m != nil {
+		return m.Workers
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Worker)(nil), "model.Worker")
+	proto.RegisterType((*RunLease)(nil), "model.RunLease")
+	proto.RegisterType((*RegisterWorkerReq)(nil), "model.RegisterWorkerReq")
+	proto.RegisterType((*RegisterWorkerRes)(nil), "model.RegisterWorkerRes")
+	proto.RegisterType((*UnregisterWorkerReq)(nil), "model.UnregisterWorkerReq")
+	proto.RegisterType((*UnregisterWorkerRes)(nil), "model.UnregisterWorkerRes")
+	proto.RegisterType((*HeartbeatReq)(nil), "model.HeartbeatReq")
+	proto.RegisterType((*HeartbeatRes)(nil), "model.HeartbeatRes")
+	proto.RegisterType((*LeaseRunReq)(nil), "model.LeaseRunReq")
+	proto.RegisterType((*LeaseRunRes)(nil), "model.LeaseRunRes")
+	proto.RegisterType((*CompleteRunReq)(nil), "model.CompleteRunReq")
+	proto.RegisterType((*CompleteRunRes)(nil), "model.CompleteRunRes")
+	proto.RegisterType((*ListWorkersReq)(nil), "model.ListWorkersReq")
+	proto.RegisterType((*ListWorkersRes)(nil), "model.ListWorkersRes")
+}
+
+func init() { proto.RegisterFile("worker.proto", fileDescriptor_e4ff6184b07e587a) }
+
+var fileDescriptor_e4ff6184b07e587a = []byte{
+	// 780 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xed, 0x6a, 0x13, 0x4d,
+	0x14, 0xc7, 0x49, 0x9a, 0x6c, 0x92, 0xb3, 0x49, 0x5f, 0xa6, 0x4f, 0xdb, 0xed, 0x3e, 0x7d, 0x9e,
+	0xd6, 0x01, 0x6d, 0xa8, 0x9a, 0xc5, 0x54, 0x0a, 0x0d, 0x88, 0x14, 0xfd, 0x60, 0xa1, 0x88, 0x6c,
+	0x2b, 0x22, 0x14, 0xc2, 0x26, 0x3b, 0xd6, 0x6d, 0xf7, 0x25, 0x9d, 0x99, 0x6d, 0x85, 0xd2, 0x2f,
+	0xde, 0x81, 0x78, 0x4d, 0x5e, 0x81, 0xb7, 0x20, 0x78, 0x1b, 0xb2, 0x33, 0xb3, 0x9b, 0xcd, 0x1b,
+	0xf1, 0x5b, 0xe6, 0x9c, 0xff, 0x39, 0xff, 0x99, 0x73, 0x7e, 0x1b, 0xa8, 0xdf, 0x46, 0xf4, 0x8a,
+	0xd0, 0xd6, 0x80, 0x46, 0x3c, 0x42, 0xe5, 0x20, 0x72, 0x89, 0x6f, 0x6e, 0x5d, 0x44, 0xd1, 0x85,
+	0x4f, 0x2c, 0x67, 0xe0, 0x59, 0x4e, 0x18, 0x46, 0xdc, 0xe1, 0x5e, 0x14, 0x32, 0x29, 0x32, 0xff,
+	0x57, 0x59, 0x71, 0xea, 0xc5, 0x9f, 0x2c, 0x37, 0xa6, 0x42, 0xa0, 0xf2, 0x5b, 0xe3, 0x79, 0xc6,
+	0x69, 0xdc, 0xe7, 0x2a, 0xbb, 0x3d, 0x9e, 0xe5, 0x5e, 0x40, 0x18, 0x77, 0x82, 0x81, 0x14, 0xe0,
+	0x1f, 0x05, 0xd0, 0x3e, 0x88, 0x4b, 0xa1, 0x45, 0x28, 0x7a, 0xae, 0x51, 0xd8, 0x29, 0x34, 0x6b,
+	0x76, 0xd1, 0x73, 0x11, 0x82, 0x52, 0xe8, 0x04, 0xc4, 0x28, 0x8a, 0x88, 0xf8, 0x8d, 0x5e, 0x42,
+	0x83, 0x92, 0x0b, 0x8f, 0x71, 0x42, 0x89, 0xdb, 0x75, 0xb8, 0xb1, 0xb0, 0x53, 0x68, 0xea, 0x6d,
+	0xb3, 0x25, 0x7d, 0x5a, 0xa9, 0x4f, 0xeb, 0x2c, 0xf5, 0xb1, 0xeb, 0xc3, 0x82, 0x23, 0x8e, 0x5e,
+	0x40, 0xfd, 0x33, 0x71, 0x28, 0xef, 0x11, 0x87, 0x27, 0xf5, 0xa5, 0xb9, 0xf5, 0x7a, 0xa6, 0x3f,
+	0xe2, 0x68, 0x03, 0x2a, 0x34, 0x0e, 0xbb, 0x9e, 0xcb, 0x8c, 0xf2, 0xce, 0x42, 0xb3, 0x66, 0x6b,
+	0x34, 0x0e, 0x8f, 0x5d, 0x86, 0xbf, 0x15, 0xa1, 0x6a, 0xc7, 0xe1, 0x09, 0x71, 0x18, 0x41, 0x6b,
+	0xa0, 0x49, 0x95, 0x7a, 0x4d, 0x59, 0x88, 0x92, 0xf0, 0x65, 0xd4, 0x4b, 0xc2, 0xf2, 0x49, 0xe5,
+	0xcb, 0xa8, 0x77, 0xec, 0xa2, 0x4d, 0xa8, 0x26, 0x61, 0xf1, 0xd6, 0x05, 0x91, 0xa8, 0x5c, 0x46,
+	0xbd, 0xb7, 0xc9, 0x73, 0x0d, 0xa8, 0xf4, 0xa3, 0x20, 0x70, 0x42, 0x57, 0x5c, 0xb4, 0x66, 0xa7,
+	0x47, 0x74, 0x00, 0x55, 0x97, 0x38, 0xae, 0xef, 0x85, 0xc4, 0x28, 0xcf, 0x7d, 0x43, 0xa6, 0x45,
+	0x87, 0x00, 0xe4, 0xcb, 0xc0, 0xa3, 0x84, 0x25, 0xaf, 0xd7, 0xe6, 0x56, 0xd6, 0x94, 0xfa, 0x88,
+	0x23, 0x0b, 0xb4, 0x81, 0x43, 0x9d, 0x80, 0x19, 0x15, 0x51, 0xb6, 0x31, 0x51, 0x76, 0x2a, 0x56,
+	0x6f, 0x2b, 0x19, 0xde, 0x85, 0x15, 0x5b, 0xcd, 0x5e, 0xae, 0xd8, 0x26, 0xd7, 0xd9, 0x56, 0x0b,
+	0xc3, 0xad, 0x62, 0x3a, 0x29, 0x64, 0xe8, 0x21, 0x68, 0x92, 0x56, 0x21, 0xd5, 0xdb, 0x8d, 0x96,
+	0xc0, 0xb5, 0xa5, 0x14, 0x2a, 0x89, 0x0e, 0xa0, 0xe6, 0x27, 0x43, 0xef, 0x72, 0xee, 0x8b, 0xb9,
+	0xea, 0xed, 0xcd, 0x89, 0x8b, 0xbd, 0x56, 0xcc, 0xda, 0x55, 0xa1, 0x3d, 0xe3, 0x3e, 0x6e, 0xc3,
+	0xea, 0xfb, 0x90, 0x4e, 0x5c, 0xef, 0x5f, 0xa8, 0xc9, 0xc6, 0xc3, 0xed, 0x55, 0x65, 0xe0, 0xd8,
+	0xc5, 0x6b, 0xd3, 0x6a, 0x18, 0x7e, 0x0c, 0xf5, 0x37, 0x29, 0x23, 0x73, 0x7b, 0x74, 0x46, 0xc4,
+	0x0c, 0xed, 0xc1, 0x4a, 0xdf, 0x09, 0xfb, 0xc4, 0xf7, 0x89, 0xdb, 0x4d, 0xd9, 0x2a, 0x08, 0xb6,
+	0x96, 0xb2, 0x84, 0x2d, 0x21, 0xfb, 0x08, 0xba, 0x00, 0xcc, 0x8e, 0xc3, 0x79, 0x3e, 0xe8, 0x29,
+	0x94, 0x6e, 0x1d, 0x8f, 0xcf, 0x1f, 0x89, 0x90, 0xe1, 0xe7, 0xf9, 0xd6, 0xc9, 0xf0, 0xcb, 0x62,
+	0x52, 0x6a, 0xf6, 0x4b, 0x6a, 0xf6, 0x29, 0xe1, 0xb6, 0xcc, 0x62, 0x0a, 0x8b, 0xaf, 0xa2, 0x60,
+	0xe0, 0x13, 0xfe, 0x57, 0x77, 0x1a, 0x7e, 0x17, 0xc5, 0xfc, 0x77, 0xb1, 0x0e, 0x5a, 0x14, 0xf3,
+	0x41, 0xcc, 0x15, 0xfe, 0xea, 0x84, 0xfe, 0x81, 0x32, 0xa1, 0x34, 0xa2, 0x8a, 0x7d, 0x79, 0xc0,
+	0xcb, 0x63, 0x9e, 0x2c, 0x89, 0x9c, 0x78, 0x8c, 0xcb, 0x85, 0x30, 0x9b, 0x5c, 0xe3, 0xc3, 0xb1,
+	0x08, 0x43, 0xbb, 0x50, 0x91, 0xd7, 0x90, 0xc3, 0x9d, 0xc0, 0x29, 0xcd, 0xb6, 0x7f, 0x97, 0xa0,
+	0x21, 0x63, 0xa7, 0x84, 0xde, 0x78, 0x7d, 0x82, 0xce, 0x61, 0x71, 0x94, 0x4e, 0x64, 0xa4, 0xe3,
+	0x18, 0xc7, 0xc7, 0x9c, 0x95, 0x61, 0x78, 0xfd, 0xeb, 0xcf, 0x5f, 0xdf, 0x8b, 0xcb, 0x58, 0xb7,
+	0x6e, 0x9e, 0x59, 0xca, 0xac, 0x53, 0xd8, 0x43, 0x57, 0xb0, 0x3c, 0xce, 0x14, 0x32, 0x55, 0x97,
+	0x29, 0x80, 0x9a, 0xb3, 0x73, 0x0c, 0x6f, 0x0b, 0x8f, 0xcd, 0xbd, 0x8d, 0x9c, 0x87, 0x75, 0x97,
+	0xed, 0xe3, 0x1e, 0xf5, 0xa1, 0x96, 0xc1, 0x87, 0x56, 0x55, 0xa7, 0x3c, 0xbb, 0xe6, 0x94, 0x20,
+	0xc3, 0x4f, 0x44, 0xdf, 0x47, 0xf8, 0xc1, 0x8c, 0xbe, 0x9d, 0xec, 0x2f, 0x32, 0x79, 0xd1, 0x39,
+	0x54, 0x53, 0x94, 0x10, 0x52, 0xed, 0x72, 0xd8, 0x9a, 0x93, 0x31, 0x86, 0x9b, 0xc2, 0x01, 0xe3,
+	0xff, 0x66, 0x39, 0x08, 0xde, 0x92, 0xee, 0x37, 0xa0, 0xe7, 0xd6, 0x8f, 0xd6, 0x54, 0xb3, 0x51,
+	0x0c, 0xcd, 0xa9, 0x61, 0x86, 0x0f, 0x85, 0xcd, 0x3e, 0x6e, 0xcd, 0xb0, 0xb1, 0x68, 0x1c, 0x32,
+	0xeb, 0x4e, 0x42, 0x7a, 0xdf, 0xe9, 0xab, 0xea, 0xc4, 0xf7, 0x1d, 0xe8, 0x39, 0xa4, 0x32, 0xdf,
+	0x51, 0xf0, 0xcc, 0xa9, 0x61, 0x86, 0x57, 0x85, 0x6f, 0x03, 0xe5, 0x97, 0xdf, 0xd3, 0xc4, 0xb7,
+	0xb8, 0xff, 0x67, 0x00, 0x0a, 0x74, 0x11, 0xef, 0x95, 0x07, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WorkerServiceClient is the client API for WorkerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkerServiceClient interface {
+	// Registers a worker, it leases runs with its id until it unregisters or stops sending heartbeats
+	RegisterWorker(ctx context.Context, in *RegisterWorkerReq, opts ...grpc.CallOption) (*RegisterWorkerRes, error)
+	// Removes a worker, the runs it leased are handed to other workers
+	UnregisterWorker(ctx context.Context, in *UnregisterWorkerReq, opts ...grpc.CallOption) (*UnregisterWorkerRes, error)
+	// Keeps the leases of a worker for another lease TTL
+	Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatRes, error)
+	// Long-polls for the next run waiting for a worker and leases it to the worker
+	LeaseRun(ctx context.Context, in *LeaseRunReq, opts ...grpc.CallOption) (*LeaseRunRes, error)
+	// Stores the outcome of a leased run and releases its lease
+	CompleteRun(ctx context.Context, in *CompleteRunReq, opts ...grpc.CallOption) (*CompleteRunRes, error)
+	ListWorkers(ctx context.Context, in *ListWorkersReq, opts ...grpc.CallOption) (*ListWorkersRes, error)
+}
+
+type workerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWorkerServiceClient(cc *grpc.ClientConn) WorkerServiceClient {
+	return &workerServiceClient{cc}
+}
+
+func (c *workerServiceClient) RegisterWorker(ctx context.Context, in *RegisterWorkerReq, opts ...grpc.CallOption) (*RegisterWorkerRes, error) {
+	out := new(RegisterWorkerRes)
+	err := c.cc.Invoke(ctx, "/model.WorkerService/RegisterWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) UnregisterWorker(ctx context.Context, in *UnregisterWorkerReq, opts ...grpc.CallOption) (*UnregisterWorkerRes, error) {
+	out := new(UnregisterWorkerRes)
+	err := c.cc.Invoke(ctx, "/model.WorkerService/UnregisterWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatRes, error) {
+	out := new(HeartbeatRes)
+	err := c.cc.Invoke(ctx, "/model.WorkerService/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) LeaseRun(ctx context.Context, in *LeaseRunReq, opts ...grpc.CallOption) (*LeaseRunRes, error) {
+	out := new(LeaseRunRes)
+	err := c.cc.Invoke(ctx, "/model.WorkerService/LeaseRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) CompleteRun(ctx context.Context, in *CompleteRunReq, opts ...grpc.CallOption) (*CompleteRunRes, error) {
+	out := new(CompleteRunRes)
+	err := c.cc.Invoke(ctx, "/model.WorkerService/CompleteRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) ListWorkers(ctx context.Context, in *ListWorkersReq, opts ...grpc.CallOption) (*ListWorkersRes, error) {
+	out := new(ListWorkersRes)
+	err := c.cc.Invoke(ctx, "/model.WorkerService/ListWorkers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkerServiceServer is the server API for WorkerService service.
+type WorkerServiceServer interface {
+	// Registers a worker, it leases runs with its id until it unregisters or stops sending heartbeats
+	RegisterWorker(context.Context, *RegisterWorkerReq) (*RegisterWorkerRes, error)
+	// Removes a worker, the runs it leased are handed to other workers
+	UnregisterWorker(context.Context, *UnregisterWorkerReq) (*UnregisterWorkerRes, error)
+	// Keeps the leases of a worker for another lease TTL
+	Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatRes, error)
+	// Long-polls for the next run waiting for a worker and leases it to the worker
+	LeaseRun(context.Context, *LeaseRunReq) (*LeaseRunRes, error)
+	// Stores the outcome of a leased run and releases its lease
+	CompleteRun(context.Context, *CompleteRunReq) (*CompleteRunRes, error)
+	ListWorkers(context.Context, *ListWorkersReq) (*ListWorkersRes, error)
+}
+
+func RegisterWorkerServiceServer(s *grpc.Server, srv WorkerServiceServer) {
+	s.RegisterService(&_WorkerService_serviceDesc, srv)
+}
+
+func _WorkerService_RegisterWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWorkerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).RegisterWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WorkerService/RegisterWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).RegisterWorker(ctx, req.(*RegisterWorkerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_UnregisterWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterWorkerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).UnregisterWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WorkerService/UnregisterWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).UnregisterWorker(ctx, req.(*UnregisterWorkerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WorkerService/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).Heartbeat(ctx, req.(*HeartbeatReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_LeaseRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRunReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).LeaseRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WorkerService/LeaseRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).LeaseRun(ctx, req.(*LeaseRunReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_CompleteRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRunReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).CompleteRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WorkerService/CompleteRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).CompleteRun(ctx, req.(*CompleteRunReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_ListWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ListWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/model.WorkerService/ListWorkers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ListWorkers(ctx, req.(*ListWorkersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WorkerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "model.WorkerService",
+	HandlerType: (*WorkerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterWorker",
+			Handler:    _WorkerService_RegisterWorker_Handler,
+		},
+		{
+			MethodName: "UnregisterWorker",
+			Handler:    _WorkerService_UnregisterWorker_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _WorkerService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "LeaseRun",
+			Handler:    _WorkerService_LeaseRun_Handler,
+		},
+		{
+			MethodName: "CompleteRun",
+			Handler:    _WorkerService_CompleteRun_Handler,
+		},
+		{
+			MethodName: "ListWorkers",
+			Handler:    _WorkerService_ListWorkers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "worker.proto",
+}
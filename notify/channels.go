@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// postSlack posts msg rendered with the template with the given name to the incoming webhook of a Slack channel
+func (n *Notifier) postSlack(ctx context.Context, url, name string, msg interface{}) error {
+	text, err := n.render(name, msg)
+	if err != nil {
+		return err
+	}
+	// A map of strings always marshals
+	body, _ := json.Marshal(map[string]string{"text": text})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Slack webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// Mailer sends emails over an SMTP server. The connection is upgraded with STARTTLS when the server offers it, the
+// credentials are only sent over TLS or to localhost.
+type Mailer struct {
+	// Addr is the host:port of the server
+	Addr     string
+	Username string
+	Password string
+	// From is the sender address of every email
+	From string
+
+	// mu guards the settings against Update, emails being sent keep the ones they started with
+	mu sync.RWMutex
+}
+
+// Update replaces the settings of m, for reloading the configuration while the server runs
+func (m *Mailer) Update(addr, username, password, from string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Addr, m.Username, m.Password, m.From = addr, username, password, from
+}
+
+// current returns a copy of the settings of m, so an Update doesn't change an email being sent
+func (m *Mailer) current() *Mailer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &Mailer{Addr: m.Addr, Username: m.Username, Password: m.Password, From: m.From}
+}
+
+// email renders msg with the subject and body templates with the given names and sends it to the recipients
+func (n *Notifier) email(ctx context.Context, recipients []string, subjectTemplate, bodyTemplate string, msg interface{}) error {
+	subject, err := n.render(subjectTemplate, msg)
+	if err != nil {
+		return err
+	}
+	body, err := n.render(bodyTemplate, msg)
+	if err != nil {
+		return err
+	}
+	return n.mailer.Send(ctx, recipients, subject, body)
+}
+
+// Attachment is a file attached to an email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Send sends a plain text email, it gives up once ctx is done
+func (m *Mailer) Send(ctx context.Context, recipients []string, subject, body string) error {
+	c := m.current()
+	return c.send(ctx, recipients, c.message(recipients, subject, body))
+}
+
+// SendAttachment sends a plain text email with a file attached, it gives up once ctx is done
+func (m *Mailer) SendAttachment(ctx context.Context, recipients []string, subject, body string, attachment *Attachment) error {
+	c := m.current()
+	return c.send(ctx, recipients, c.messageWithAttachment(recipients, subject, body, attachment))
+}
+
+// send hands msg to the server for the recipients
+func (m *Mailer) send(ctx context.Context, recipients []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(m.Addr)
+	if err != nil {
+		return err
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// net/smtp doesn't take a context, the deadline limits the whole conversation instead
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if m.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.Username, m.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.From); err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		if err := c.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message returns the headers and the quoted-printable body of an email
+func (m *Mailer) message(recipients []string, subject, body string) []byte {
+	var buf bytes.Buffer
+	m.writeHeaders(&buf, recipients, subject)
+	writeText(&buf, body)
+	return buf.Bytes()
+}
+
+// messageWithAttachment returns the headers of an email and a multipart body of the text and the base64 encoded
+// attachment
+func (m *Mailer) messageWithAttachment(recipients []string, subject, body string, attachment *Attachment) []byte {
+	var buf bytes.Buffer
+	m.writeHeaders(&buf, recipients, subject)
+	// Base64 and quoted-printable never produce the boundary, it only has to differ from every header line
+	boundary := fmt.Sprintf("schedulytics-%x", time.Now().UnixNano())
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary)
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	writeText(&buf, body)
+	fmt.Fprintf(&buf, "\r\n--%s\r\n", boundary)
+	fmt.Fprintf(&buf, "Content-Type: %s\r\n", attachment.ContentType)
+	fmt.Fprintf(&buf, "Content-Disposition: %s\r\n", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	// Lines of encoded data must not be longer than 76 characters
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes()
+}
+
+// writeHeaders writes the headers every email has
+func (m *Mailer) writeHeaders(buf *bytes.Buffer, recipients []string, subject string) {
+	// Line breaks in a rendered subject would end the header
+	subject = strings.Join(strings.Fields(subject), " ")
+	fmt.Fprintf(buf, "From: %s\r\n", m.From)
+	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+}
+
+// writeText writes the headers and the quoted-printable body of a plain text part
+func writeText(buf *bytes.Buffer, body string) {
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	w := quotedprintable.NewWriter(buf)
+	w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	w.Close()
+}
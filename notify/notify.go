@@ -0,0 +1,308 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// Names of the templates messages are rendered with
+const (
+	SlackTemplate        = "slack"
+	EmailSubjectTemplate = "email_subject"
+	EmailBodyTemplate    = "email_body"
+	// The templates of SLA breaches are rendered with a BreachMessage
+	SLASlackTemplate        = "sla_slack"
+	SLAEmailSubjectTemplate = "sla_email_subject"
+	SLAEmailBodyTemplate    = "sla_email_body"
+	// The templates of anomalies are rendered with an AnomalyMessage
+	AnomalySlackTemplate        = "anomaly_slack"
+	AnomalyEmailSubjectTemplate = "anomaly_email_subject"
+	AnomalyEmailBodyTemplate    = "anomaly_email_body"
+)
+
+// defaultTemplates are used unless a template file overrides them
+const defaultTemplates = `
+{{- define "slack" -}}
+Job *{{.Job.Name}}* failed {{.Failures}} {{if eq .Failures 1}}time{{else}}times in a row{{end}}, run {{.Run.ID}} ended with {{.Run.Status}}
+{{- with .Run.Error}}: {{.}}{{end}}
+{{- end -}}
+
+{{- define "email_subject" -}}
+Job {{.Job.Name}} failed {{.Failures}} {{if eq .Failures 1}}time{{else}}times in a row{{end}}
+{{- end -}}
+
+{{- define "email_body" -}}
+Run {{.Run.ID}} of job {{.Job.Name}} ({{.Job.ID}}) ended with {{.Run.Status}}.
+{{with .Run.Error}}
+Error: {{.}}
+{{end}}
+It was attempt {{.Run.Attempt}} of the run and the job failed {{.Failures}} {{if eq .Failures 1}}time{{else}}times in a row{{end}}.
+{{- end -}}
+
+{{- define "sla_slack" -}}
+Job *{{.Job.Name}}* breached its SLA, {{if .Breach.RunID}}run {{.Breach.RunID}} took {{.Breach.Duration}}{{else}}no run succeeded by {{.Breach.Deadline.Format "2006-01-02 15:04 MST"}}{{end}}
+{{- end -}}
+
+{{- define "sla_email_subject" -}}
+Job {{.Job.Name}} breached its SLA
+{{- end -}}
+
+{{- define "sla_email_body" -}}
+Job {{.Job.Name}} ({{.Job.ID}}) breached its SLA.
+{{if .Breach.RunID}}
+Run {{.Breach.RunID}} took {{.Breach.Duration}}.
+{{else}}
+No run succeeded by the deadline at {{.Breach.Deadline.Format "2006-01-02 15:04 MST"}}.
+{{end}}
+{{- end -}}
+
+{{- define "anomaly_slack" -}}
+Run {{.Anomaly.RunID}} of job *{{.Job.Name}}* took {{.Anomaly.Duration}}, {{printf "%.1f" .Anomaly.Score}} standard deviations from its mean of {{.Anomaly.Mean}}
+{{- end -}}
+
+{{- define "anomaly_email_subject" -}}
+Run of job {{.Job.Name}} took {{if gt .Anomaly.Score 0.0}}longer{{else}}shorter{{end}} than usual
+{{- end -}}
+
+{{- define "anomaly_email_body" -}}
+Run {{.Anomaly.RunID}} of job {{.Job.Name}} ({{.Job.ID}}) took {{.Anomaly.Duration}}.
+
+The last {{.Anomaly.Samples}} succeeded runs took {{.Anomaly.Mean}} on average with a standard deviation of {{.Anomaly.Stddev}}, so the run was {{printf "%.1f" .Anomaly.Score}} standard deviations away.
+{{- end -}}
+`
+
+// pageSize is the number of runs read at once while counting failed runs
+const pageSize = 50
+
+// storeTimeout limits counting the failed runs, it doesn't use the context of the executor
+const storeTimeout = 5 * time.Second
+
+// Message is what templates are rendered with
+type Message struct {
+	Job *repository.Job
+	Run *repository.Run
+	// Failures is the number of failed runs in a row, the run included
+	Failures int
+}
+
+// BreachMessage is what the templates of SLA breaches are rendered with
+type BreachMessage struct {
+	Job    *repository.Job
+	Breach *repository.SLABreach
+}
+
+// AnomalyMessage is what the templates of anomalies are rendered with
+type AnomalyMessage struct {
+	Job     *repository.Job
+	Anomaly *repository.Anomaly
+}
+
+// ParseTemplates returns the built-in templates, the templates defined in the file at path replace them. An empty
+// path keeps all of them.
+func ParseTemplates(path string) (*template.Template, error) {
+	templates := template.Must(template.New("notifications").Parse(defaultTemplates))
+	if path == "" {
+		return templates, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := templates.Parse(string(data)); err != nil {
+		return nil, fmt.Errorf("invalid notification templates: %v", err)
+	}
+	return templates, nil
+}
+
+// Notifier sends the notifications of jobs whose runs keep failing to the channels in their settings. A notification
+// is sent by the run that makes FailureThreshold failed runs in a row, so a job that keeps failing notifies once until
+// one of its runs succeeds.
+type Notifier struct {
+	runs      repository.RunRepository
+	templates *template.Template
+	mailer    *Mailer
+	client    *http.Client
+	timeout   time.Duration
+	logger    *zap.Logger
+	wg        sync.WaitGroup
+}
+
+// New creates a Notifier rendering messages with templates. Jobs with email recipients aren't emailed when mailer is
+// nil. Sending to a single channel may take timeout.
+func New(runs repository.RunRepository, templates *template.Template, mailer *Mailer, timeout time.Duration, logger *zap.Logger) *Notifier {
+	return &Notifier{
+		runs:      runs,
+		templates: templates,
+		mailer:    mailer,
+		client:    &http.Client{Timeout: timeout},
+		timeout:   timeout,
+		logger:    logger,
+	}
+}
+
+// RunFinished sends the notifications of a failed or timed out run of job in the background. It can be passed to the
+// OnFinish of the executor.
+func (n *Notifier) RunFinished(ctx context.Context, job *repository.Job, run *repository.Run) {
+	if job.Notifications == nil || (run.Status != executor.StatusFailed && run.Status != executor.StatusTimedOut) {
+		return
+	}
+	// The executor is done with both, but copies keep it free to change them
+	j, r := *job, *run
+	tenantID := tenant.FromContext(ctx)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.notify(tenant.NewContext(context.Background(), tenantID), &j, &r)
+	}()
+}
+
+// Wait blocks until the notifications that are being sent were sent, the executor must not finish runs anymore
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// notify sends the notifications of run if it made the job reach its failure threshold
+func (n *Notifier) notify(ctx context.Context, job *repository.Job, run *repository.Run) {
+	settings := job.Notifications
+	countCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+	failures, err := n.failures(countCtx, run, settings.FailureThreshold)
+	cancel()
+	if err != nil {
+		n.logger.Error("Could not count failed runs", zap.String("job_id", job.ID), zap.String("run_id", run.ID), zap.Error(err))
+		return
+	}
+	if failures != settings.FailureThreshold {
+		return
+	}
+	msg := &Message{Job: job, Run: run, Failures: failures}
+	if settings.SlackWebhookURL != "" {
+		n.send(ctx, "slack", job, func(ctx context.Context) error {
+			return n.postSlack(ctx, settings.SlackWebhookURL, SlackTemplate, msg)
+		})
+	}
+	if len(settings.EmailRecipients) > 0 {
+		if n.mailer == nil {
+			n.logger.Warn("Could not email failed run, no SMTP server is configured", zap.String("job_id", job.ID), zap.String("run_id", run.ID))
+		} else {
+			n.send(ctx, "email", job, func(ctx context.Context) error {
+				return n.email(ctx, settings.EmailRecipients, EmailSubjectTemplate, EmailBodyTemplate, msg)
+			})
+		}
+	}
+}
+
+// SLABreached sends the notifications of a breach of the SLA of job to the channels in its settings in the
+// background, regardless of the failure threshold. It can be passed to the OnBreach of the SLA monitor.
+func (n *Notifier) SLABreached(ctx context.Context, job *repository.Job, breach *repository.SLABreach) {
+	if job.Notifications == nil {
+		return
+	}
+	j, b := *job, *breach
+	n.inBackground(ctx, func(ctx context.Context) {
+		n.sendAll(ctx, &j, "SLA breach", SLASlackTemplate, SLAEmailSubjectTemplate, SLAEmailBodyTemplate, &BreachMessage{Job: &j, Breach: &b})
+	})
+}
+
+// RunAnomalous sends the notifications of the anomaly of a run of job to the channels in its settings in the
+// background, if they include anomalies. It can be passed to the OnAnomaly of the anomaly detector.
+func (n *Notifier) RunAnomalous(ctx context.Context, job *repository.Job, anomaly *repository.Anomaly) {
+	if job.Notifications == nil || !job.Notifications.Anomalies {
+		return
+	}
+	j, a := *job, *anomaly
+	n.inBackground(ctx, func(ctx context.Context) {
+		n.sendAll(ctx, &j, "anomaly", AnomalySlackTemplate, AnomalyEmailSubjectTemplate, AnomalyEmailBodyTemplate, &AnomalyMessage{Job: &j, Anomaly: &a})
+	})
+}
+
+// inBackground calls f with a context of the tenant of ctx that isn't cancelled with it, Wait waits for it
+func (n *Notifier) inBackground(ctx context.Context, f func(context.Context)) {
+	tenantID := tenant.FromContext(ctx)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		f(tenant.NewContext(context.Background(), tenantID))
+	}()
+}
+
+// sendAll sends msg rendered with the given templates to every channel in the settings of job, what names the
+// message in the warning logged when it can't be emailed
+func (n *Notifier) sendAll(ctx context.Context, job *repository.Job, what, slackTemplate, subjectTemplate, bodyTemplate string, msg interface{}) {
+	settings := job.Notifications
+	if settings.SlackWebhookURL != "" {
+		n.send(ctx, "slack", job, func(ctx context.Context) error {
+			return n.postSlack(ctx, settings.SlackWebhookURL, slackTemplate, msg)
+		})
+	}
+	if len(settings.EmailRecipients) > 0 {
+		if n.mailer == nil {
+			n.logger.Warn("Could not email "+what+", no SMTP server is configured", zap.String("job_id", job.ID))
+		} else {
+			n.send(ctx, "email", job, func(ctx context.Context) error {
+				return n.email(ctx, settings.EmailRecipients, subjectTemplate, bodyTemplate, msg)
+			})
+		}
+	}
+}
+
+// send notifies a single channel within the timeout and records the outcome
+func (n *Notifier) send(ctx context.Context, channel string, job *repository.Job, f func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	if err := f(ctx); err != nil {
+		metrics.RecordNotification(channel, "failed")
+		n.logger.Error("Could not send notification", zap.String("channel", channel), zap.String("job_id", job.ID), zap.Error(err))
+		return
+	}
+	metrics.RecordNotification(channel, "sent")
+	n.logger.Info("Sent notification", zap.String("channel", channel), zap.String("job_id", job.ID))
+}
+
+// failures counts the failed runs of the job of run in a row up to run, including it. It stops counting once there
+// are more than limit. Runs which didn't finish yet and cancelled runs are skipped, a succeeded run ends the count.
+func (n *Notifier) failures(ctx context.Context, run *repository.Run, limit int) (int, error) {
+	failures := 1
+	before := run.ID
+	for failures <= limit {
+		runs, err := n.runs.List(ctx, run.JobID, before, pageSize)
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range runs {
+			switch r.Status {
+			case executor.StatusFailed, executor.StatusTimedOut:
+				if failures++; failures > limit {
+					return failures, nil
+				}
+			case executor.StatusSucceeded:
+				return failures, nil
+			}
+		}
+		if len(runs) < pageSize {
+			break
+		}
+		before = runs[len(runs)-1].ID
+	}
+	return failures, nil
+}
+
+// render executes the template with the given name for msg, a *Message, *BreachMessage or *AnomalyMessage
+func (n *Notifier) render(name string, msg interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := n.templates.ExecuteTemplate(&buf, name, msg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
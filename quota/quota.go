@@ -0,0 +1,199 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// Quotas checked by the server, they name the limit a call exceeded
+const (
+	// JobsPerOwner limits the jobs of an owner
+	JobsPerOwner = "jobs_per_owner"
+	// JobsPerTenant limits the jobs of a tenant, of the whole server without multi-tenancy
+	JobsPerTenant = "jobs_per_tenant"
+	// ConcurrentRuns limits the queued and running runs of the jobs of an owner
+	ConcurrentRuns = "concurrent_runs"
+	// RunsPerDay limits the runs of the jobs of an owner queued since midnight UTC
+	RunsPerDay = "runs_per_day"
+)
+
+// pageSize is the number of jobs read at once to sum up the runs of an owner
+const pageSize = 500
+
+// Limits are the quotas of the server, zero doesn't limit
+type Limits struct {
+	JobsPerOwner   int64
+	JobsPerTenant  int64
+	ConcurrentRuns int64
+	RunsPerDay     int64
+}
+
+// Enabled reports whether any quota is limited
+func (l Limits) Enabled() bool {
+	return l.JobsPerOwner > 0 || l.JobsPerTenant > 0 || l.ConcurrentRuns > 0 || l.RunsPerDay > 0
+}
+
+// Jobs is the part of the job storage quotas count jobs in
+type Jobs interface {
+	Count(ctx context.Context, q repository.Query) (int64, error)
+	List(ctx context.Context, q repository.Query, after string, limit int) ([]*repository.Job, error)
+}
+
+// Runs is the part of the run storage quotas count runs in
+type Runs interface {
+	Usage(ctx context.Context, jobIDs []string, from, to time.Time) (*repository.RunUsage, error)
+}
+
+// Usage is how much of the quotas an owner uses
+type Usage struct {
+	JobsPerOwner   int64
+	JobsPerTenant  int64
+	ConcurrentRuns int64
+	RunsPerDay     int64
+	// DayStart is the midnight UTC RunsPerDay counts from
+	DayStart time.Time
+}
+
+// ExceededError tells that a call would exceed a quota
+type ExceededError struct {
+	// Quota is one of the quota constants
+	Quota string
+	// Subject is the owner or tenant whose quota is exceeded, empty for the tenant of a server without multi-tenancy
+	Subject string
+	Limit   int64
+	Used    int64
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota %s of %q exceeded, %d of %d used", e.Quota, e.Subject, e.Used, e.Limit)
+}
+
+// Quotas counts what owners use of the limits. Usage is counted when a call is checked, calls made at the same time
+// can exceed a limit by the number of calls.
+type Quotas struct {
+	jobs   Jobs
+	runs   Runs
+	limits Limits
+}
+
+// New creates Quotas counting the jobs in jobs and their runs in runs against limits
+func New(jobs Jobs, runs Runs, limits Limits) *Quotas {
+	return &Quotas{jobs: jobs, runs: runs, limits: limits}
+}
+
+// Limits returns the limits of the quotas
+func (q *Quotas) Limits() Limits {
+	return q.limits
+}
+
+// Usage counts what owner uses of every quota at now, jobs and runs of the tenant of ctx
+func (q *Quotas) Usage(ctx context.Context, owner string, now time.Time) (*Usage, error) {
+	usage := &Usage{DayStart: dayStart(now)}
+	var err error
+	if usage.JobsPerOwner, err = q.ownerJobs(ctx, owner); err != nil {
+		return nil, err
+	}
+	if usage.JobsPerTenant, err = q.jobs.Count(ctx, repository.Query{}); err != nil {
+		return nil, err
+	}
+	if usage.ConcurrentRuns, usage.RunsPerDay, err = q.ownerRuns(ctx, owner, usage.DayStart); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// CheckJobs returns an *ExceededError when owner can't have one more job. ownerPending and tenantPending are the jobs
+// of owner and of the tenant about to be created that aren't stored yet.
+func (q *Quotas) CheckJobs(ctx context.Context, owner string, ownerPending, tenantPending int64) error {
+	if q.limits.JobsPerOwner > 0 {
+		used, err := q.ownerJobs(ctx, owner)
+		if err != nil {
+			return err
+		}
+		if used+ownerPending >= q.limits.JobsPerOwner {
+			return &ExceededError{Quota: JobsPerOwner, Subject: owner, Limit: q.limits.JobsPerOwner, Used: used + ownerPending}
+		}
+	}
+	if q.limits.JobsPerTenant > 0 {
+		used, err := q.jobs.Count(ctx, repository.Query{})
+		if err != nil {
+			return err
+		}
+		if used+tenantPending >= q.limits.JobsPerTenant {
+			return &ExceededError{Quota: JobsPerTenant, Subject: tenant.FromContext(ctx), Limit: q.limits.JobsPerTenant, Used: used + tenantPending}
+		}
+	}
+	return nil
+}
+
+// CheckRun returns an *ExceededError when a job of owner can't run once more at now
+func (q *Quotas) CheckRun(ctx context.Context, owner string, now time.Time) error {
+	if q.limits.ConcurrentRuns <= 0 && q.limits.RunsPerDay <= 0 {
+		return nil
+	}
+	concurrent, today, err := q.ownerRuns(ctx, owner, dayStart(now))
+	if err != nil {
+		return err
+	}
+	if q.limits.ConcurrentRuns > 0 && concurrent >= q.limits.ConcurrentRuns {
+		return &ExceededError{Quota: ConcurrentRuns, Subject: owner, Limit: q.limits.ConcurrentRuns, Used: concurrent}
+	}
+	if q.limits.RunsPerDay > 0 && today >= q.limits.RunsPerDay {
+		return &ExceededError{Quota: RunsPerDay, Subject: owner, Limit: q.limits.RunsPerDay, Used: today}
+	}
+	return nil
+}
+
+// ownerJobs counts the jobs of owner that aren't deleted, archived ones count too
+func (q *Quotas) ownerJobs(ctx context.Context, owner string) (int64, error) {
+	return q.jobs.Count(ctx, repository.Query{Owner: owner})
+}
+
+// ownerRuns counts the queued and running runs of the jobs of owner and the runs queued since day. The runs of
+// deleted jobs count until they are purged, so deleting a job doesn't free anything.
+func (q *Quotas) ownerRuns(ctx context.Context, owner string, day time.Time) (concurrent, today int64, err error) {
+	jobs := repository.Query{Owner: owner, IncludeDeleted: true, Fields: []string{"id"}}
+	end := day.Add(24 * time.Hour)
+	after := ""
+	for {
+		page, err := q.jobs.List(ctx, jobs, after, pageSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		ids := make([]string, 0, len(page))
+		for _, job := range page {
+			ids = append(ids, job.ID)
+		}
+		// Runs stay queued or running however long ago they were queued
+		active, err := q.runs.Usage(ctx, ids, time.Time{}, end)
+		if err != nil {
+			return 0, 0, err
+		}
+		concurrent += active.Counts[executor.StatusQueued] + active.Counts[executor.StatusRunning]
+		queued, err := q.runs.Usage(ctx, ids, day, end)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, count := range queued.Counts {
+			today += count
+		}
+		if len(page) < pageSize {
+			break
+		}
+		after = page[len(page)-1].ID
+	}
+	return concurrent, today, nil
+}
+
+// dayStart returns the midnight UTC before t
+func dayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,272 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// sweepInterval is how often buckets of clients that stopped calling are dropped
+const sweepInterval = time.Minute
+
+// Limit allows Burst calls at once and refills them at Rate calls per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// units are the periods a limit can be given per
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// unitNames lists the units shortest first, for formatting limits
+var unitNames = []string{"s", "m", "h"}
+
+// ParseLimit parses limits like 10/s, 100/m or 1000/h. The count is also the burst, so a client that was idle may
+// make that many calls at once.
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("invalid limit %q, expected <count>/<s|m|h>", s)
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil || count < 1 {
+		return Limit{}, fmt.Errorf("invalid count in limit %q", s)
+	}
+	unit, ok := units[parts[1]]
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid unit in limit %q, expected s, m or h", s)
+	}
+	return Limit{Rate: float64(count) / unit.Seconds(), Burst: count}, nil
+}
+
+// String formats the limit the way ParseLimit reads it, so it can be used as a flag value
+func (l *Limit) String() string {
+	if l == nil || l.Rate == 0 {
+		return ""
+	}
+	period := float64(l.Burst) / l.Rate
+	for _, name := range unitNames {
+		if period == units[name].Seconds() {
+			return fmt.Sprintf("%d/%s", l.Burst, name)
+		}
+	}
+	return fmt.Sprintf("%g/s", l.Rate)
+}
+
+// Set parses a limit like ParseLimit, an empty value removes the limit
+func (l *Limit) Set(s string) error {
+	if s == "" {
+		*l = Limit{}
+		return nil
+	}
+	limit, err := ParseLimit(s)
+	if err != nil {
+		return err
+	}
+	*l = limit
+	return nil
+}
+
+// MethodLimits maps full method names like /model.JobService/CreateJob to their limit
+type MethodLimits map[string]Limit
+
+func (m *MethodLimits) String() string {
+	if m == nil {
+		return ""
+	}
+	items := []string{}
+	for method, limit := range *m {
+		items = append(items, method+"="+limit.String())
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// Set parses a comma separated list like /model.JobService/CreateJob=10/s,/model.JobService/ImportJobs=1/m and
+// replaces the limits with it
+func (m *MethodLimits) Set(s string) error {
+	limits := MethodLimits{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") {
+			return fmt.Errorf("invalid method limit %q, expected /<service>/<method>=<limit>", item)
+		}
+		limit, err := ParseLimit(parts[1])
+		if err != nil {
+			return err
+		}
+		limits[parts[0]] = limit
+	}
+	*m = limits
+	return nil
+}
+
+// bucket holds the tokens left to a client for a method
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// key identifies the bucket of a client for a method
+type key struct {
+	method string
+	client string
+}
+
+// Limiter limits the calls every client can make to every method with a token bucket per client and method
+type Limiter struct {
+	// mu guards the limits too, SetLimits may replace them while calls are checked
+	mu           sync.Mutex
+	defaultLimit Limit
+	methods      MethodLimits
+	buckets      map[key]*bucket
+	lastSweep    time.Time
+}
+
+// New creates a Limiter applying methodLimits to the methods they name and defaultLimit to all other methods.
+// A zero defaultLimit doesn't limit the other methods.
+func New(defaultLimit Limit, methodLimits MethodLimits) *Limiter {
+	return &Limiter{
+		defaultLimit: defaultLimit,
+		methods:      methodLimits,
+		buckets:      map[key]*bucket{},
+	}
+}
+
+// SetLimits replaces the limits of New while the limiter is used. Clients keep the calls left in their buckets up to the
+// burst of the new limits.
+func (l *Limiter) SetLimits(defaultLimit Limit, methodLimits MethodLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultLimit = defaultLimit
+	l.methods = methodLimits
+}
+
+// UnaryServerInterceptor rejects unary calls of clients that exceeded the limit of the method
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects streams of clients that exceeded the limit of the method, a stream counts as one call
+func (l *Limiter) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := l.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// limit returns the limit that applies to method, the caller must hold the lock
+func (l *Limiter) limit(method string) Limit {
+	if limit, ok := l.methods[method]; ok {
+		return limit
+	}
+	return l.defaultLimit
+}
+
+// check takes a token for the call and returns a ResourceExhausted error telling when to retry if there is none
+func (l *Limiter) check(ctx context.Context, method string) error {
+	wait := l.take(key{method: method, client: client(ctx)})
+	if wait == 0 {
+		return nil
+	}
+	metrics.RecordRateLimited(method)
+	// Round up, retrying a little later than needed beats retrying a little too early
+	wait = wait.Truncate(time.Millisecond) + time.Millisecond
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("Rate limit exceeded, retry in %v", wait))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// take removes a token from the bucket with key k and returns 0, or how long it takes until a token is available.
+// Methods without a limit don't have buckets.
+func (l *Limiter) take(k key) time.Duration {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limit := l.limit(k.method)
+	if limit.Rate == 0 {
+		return 0
+	}
+	l.sweep(now)
+	b, ok := l.buckets[k]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[k] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+// sweep drops the buckets that filled up again, they behave the same as new ones. The caller must hold the lock.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for k, b := range l.buckets {
+		limit := l.limit(k.method)
+		if b.tokens+now.Sub(b.last).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// client identifies the caller by the subject of their token, or by their IP address for calls without a token
+func client(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok && claims.Subject != "" {
+		return "sub:" + claims.Subject
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	// Calls of the REST gateway come from loopback or the Unix socket, it appends the address of its client to
+	// x-forwarded-for. Only the last entry is trustworthy, clients can send anything before it.
+	if ip := net.ParseIP(host); (ip != nil && ip.IsLoopback()) || p.Addr.Network() == "unix" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			if forwarded := strings.TrimSpace(hops[len(hops)-1]); forwarded != "" {
+				host = forwarded
+			}
+		}
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,207 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	createJob = "/model.JobService/CreateJob"
+	readJob   = "/model.JobService/ReadJob"
+)
+
+// perHour limits to n calls per hour, so buckets don't noticeably refill while a test runs
+func perHour(n int) Limit {
+	return Limit{Rate: float64(n) / time.Hour.Seconds(), Burst: n}
+}
+
+func TestTake(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultLimit Limit
+		methods      MethodLimits
+		calls        []key
+		// allowed tells which of the calls get a token
+		allowed []bool
+	}{
+		{
+			name:    "no limits",
+			calls:   []key{{readJob, "a"}, {readJob, "a"}, {readJob, "a"}},
+			allowed: []bool{true, true, true},
+		},
+		{
+			name:         "burst of the default limit",
+			defaultLimit: perHour(2),
+			calls:        []key{{readJob, "a"}, {readJob, "a"}, {readJob, "a"}},
+			allowed:      []bool{true, true, false},
+		},
+		{
+			name:         "buckets per client",
+			defaultLimit: perHour(1),
+			calls:        []key{{readJob, "a"}, {readJob, "b"}, {readJob, "a"}, {readJob, "b"}},
+			allowed:      []bool{true, true, false, false},
+		},
+		{
+			name:         "buckets per method",
+			defaultLimit: perHour(1),
+			calls:        []key{{readJob, "a"}, {createJob, "a"}, {readJob, "a"}, {createJob, "a"}},
+			allowed:      []bool{true, true, false, false},
+		},
+		{
+			name:         "method limits take precedence",
+			defaultLimit: perHour(1),
+			methods:      MethodLimits{createJob: perHour(3)},
+			calls:        []key{{createJob, "a"}, {createJob, "a"}, {createJob, "a"}, {createJob, "a"}, {readJob, "a"}, {readJob, "a"}},
+			allowed:      []bool{true, true, true, false, true, false},
+		},
+		{
+			name:    "only methods with limits are limited",
+			methods: MethodLimits{createJob: perHour(1)},
+			calls:   []key{{createJob, "a"}, {createJob, "a"}, {readJob, "a"}, {readJob, "a"}},
+			allowed: []bool{true, false, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.defaultLimit, tt.methods)
+			for i, k := range tt.calls {
+				if got := l.take(k) == 0; got != tt.allowed[i] {
+					t.Errorf("call %d of %s by %s allowed = %v, want %v", i, k.method, k.client, got, tt.allowed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTakeWait(t *testing.T) {
+	l := New(Limit{Rate: 10, Burst: 1}, nil)
+	k := key{readJob, "a"}
+	if wait := l.take(k); wait != 0 {
+		t.Fatalf("first call waits %v, want 0", wait)
+	}
+	// The bucket is empty, a token is back after a tenth of a second
+	wait := l.take(k)
+	if wait <= 0 || wait > 100*time.Millisecond {
+		t.Errorf("second call waits %v, want up to 100ms", wait)
+	}
+	time.Sleep(wait + 10*time.Millisecond)
+	if wait := l.take(k); wait != 0 {
+		t.Errorf("call after waiting waits %v, want 0", wait)
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	l := New(perHour(1), nil)
+	k := key{readJob, "a"}
+	l.take(k)
+	if l.take(k) == 0 {
+		t.Fatal("second call allowed before the limits changed")
+	}
+	l.SetLimits(Limit{}, nil)
+	if wait := l.take(k); wait != 0 {
+		t.Errorf("call without limits waits %v, want 0", wait)
+	}
+	l.SetLimits(Limit{}, MethodLimits{readJob: perHour(1)})
+	// The bucket kept its tokens, it is still empty
+	if l.take(k) == 0 {
+		t.Error("call allowed by the new method limit with an empty bucket")
+	}
+}
+
+func TestCheckRetryInfo(t *testing.T) {
+	l := New(Limit{Rate: 1, Burst: 1}, nil)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+	if err := l.check(ctx, readJob); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	err := l.check(ctx, readJob)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("second call failed with %v, want ResourceExhausted", err)
+	}
+	var info *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.RetryInfo); ok {
+			info = d
+		}
+	}
+	if info == nil {
+		t.Fatalf("error %v has no RetryInfo detail", err)
+	}
+	delay, err := ptypes.Duration(info.GetRetryDelay())
+	if err != nil {
+		t.Fatalf("invalid retry delay: %v", err)
+	}
+	// The delay is rounded up to the next millisecond
+	if delay <= 0 || delay > time.Second+time.Millisecond || delay%time.Millisecond != 0 {
+		t.Errorf("retry delay is %v, want whole milliseconds up to a second", delay)
+	}
+}
+
+func TestClient(t *testing.T) {
+	tcp := func(ip string) net.Addr {
+		return &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}
+	}
+	tests := []struct {
+		name      string
+		addr      net.Addr
+		forwarded []string
+		want      string
+	}{
+		{"remote address", tcp("192.0.2.1"), nil, "ip:192.0.2.1"},
+		{"forwarded header of remote clients is ignored", tcp("192.0.2.1"), []string{"198.51.100.7"}, "ip:192.0.2.1"},
+		{"loopback", tcp("127.0.0.1"), nil, "ip:127.0.0.1"},
+		{"gateway", tcp("127.0.0.1"), []string{"198.51.100.7"}, "ip:198.51.100.7"},
+		{"last hop of the gateway", tcp("127.0.0.1"), []string{"203.0.113.9, 198.51.100.7"}, "ip:198.51.100.7"},
+		{"gateway on the Unix socket", &net.UnixAddr{Name: "/tmp/grpc.sock", Net: "unix"}, []string{"198.51.100.7"}, "ip:198.51.100.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.addr})
+			for _, value := range tt.forwarded {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", value))
+			}
+			if got := client(ctx); got != tt.want {
+				t.Errorf("client() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		limit string
+		want  Limit
+		err   bool
+	}{
+		{limit: "10/s", want: Limit{Rate: 10, Burst: 10}},
+		{limit: "60/m", want: Limit{Rate: 1, Burst: 60}},
+		{limit: "3600/h", want: Limit{Rate: 1, Burst: 3600}},
+		{limit: "10", err: true},
+		{limit: "0/s", err: true},
+		{limit: "x/s", err: true},
+		{limit: "10/d", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.limit)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseLimit(%q) error = %v, want error %v", tt.limit, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.limit, got, tt.want)
+		}
+		if !tt.err && got.String() != tt.limit {
+			t.Errorf("ParseLimit(%q).String() = %q", tt.limit, got.String())
+		}
+	}
+}
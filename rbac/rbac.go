@@ -0,0 +1,299 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/team"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles of the policy, every role may call the methods of the roles before it
+const (
+	Viewer = "viewer"
+	Editor = "editor"
+	Admin  = auth.AdminRole
+	// Anyone lets every authenticated caller call a method, with or without a role
+	Anyone = ""
+)
+
+// ranks orders the roles of the policy, other roles grant nothing
+var ranks = map[string]int{Viewer: 1, Editor: 2, Admin: 3}
+
+// errorDomain is the domain of the ErrorInfo details of denied calls, the same as the one of the errors of the services
+const errorDomain = "schedulytics-grpc-server"
+
+// Policy is the role every method needs at least, keyed by full gRPC method name. Viewers read and list, editors
+// create and change, admins delete and run the AdminService. Methods missing from the policy are denied to everyone.
+var Policy = map[string]string{
+	"/model.HelloService/SayHello":                                   Viewer,
+	"/grpc.health.v1.Health/Check":                                   Viewer,
+	"/grpc.health.v1.Health/Watch":                                   Viewer,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": Viewer,
+	// Channelz names the peers of every connection
+	"/grpc.channelz.v1.Channelz/GetTopChannels":   Admin,
+	"/grpc.channelz.v1.Channelz/GetServers":       Admin,
+	"/grpc.channelz.v1.Channelz/GetServer":        Admin,
+	"/grpc.channelz.v1.Channelz/GetServerSockets": Admin,
+	"/grpc.channelz.v1.Channelz/GetChannel":       Admin,
+	"/grpc.channelz.v1.Channelz/GetSubchannel":    Admin,
+	"/grpc.channelz.v1.Channelz/GetSocket":        Admin,
+
+	"/model.JobService/ReadJob":          Viewer,
+	"/model.JobService/BatchGetJobs":     Viewer,
+	"/model.JobService/CountJobs":        Viewer,
+	"/model.JobService/JobExists":        Viewer,
+	"/model.JobService/ListJobs":         Viewer,
+	"/model.JobService/SearchJobs":       Viewer,
+	"/model.JobService/GetJobGraph":      Viewer,
+	"/model.JobService/ExportJobs":       Viewer,
+	"/model.JobService/WatchJobs":        Viewer,
+	"/model.JobService/ListJobTypes":     Viewer,
+	"/model.JobService/GetQuota":         Viewer,
+	"/model.JobService/ReadChange":       Viewer,
+	"/model.JobService/ListChanges":      Viewer,
+	"/model.JobService/ListJobRevisions": Viewer,
+	"/model.JobService/CreateJob":        Editor,
+	"/model.JobService/CloneJob":         Editor,
+	"/model.JobService/PromoteJob":       Editor,
+	"/model.JobService/UpdateJob":        Editor,
+	"/model.JobService/RestoreJob":       Editor,
+	"/model.JobService/PauseJob":         Editor,
+	"/model.JobService/ResumeJob":        Editor,
+	"/model.JobService/CancelJob":        Editor,
+	"/model.JobService/TriggerJob":       Editor,
+	"/model.JobService/ImportJobs":       Editor,
+	"/model.JobService/ArchiveJob":       Editor,
+	"/model.JobService/ArchiveJobs":      Editor,
+	"/model.JobService/UnarchiveJob":     Editor,
+	"/model.JobService/RollbackJob":      Editor,
+	// The server makes sure the reviewer isn't the requester of a change
+	"/model.JobService/ApproveChange": Editor,
+	"/model.JobService/RejectChange":  Editor,
+	"/model.JobService/DeleteJob":     Admin,
+	"/model.JobService/DeleteJobs":    Admin,
+
+	"/model.ScheduleService/PreviewSchedule": Viewer,
+	"/model.ScheduleService/PreviewCron":     Viewer,
+	"/model.ScheduleService/SetSchedule":     Editor,
+	"/model.ScheduleService/RemoveSchedule":  Editor,
+
+	"/model.RunService/ListJobRuns":              Viewer,
+	"/model.RunService/GetJobRun":                Viewer,
+	"/model.RunService/GetRunRetentionPolicy":    Viewer,
+	"/model.RunService/ListRunRetentionPolicies": Viewer,
+	"/model.RunService/SetRunRetentionPolicy":    Admin,
+	"/model.RunService/DeleteRunRetentionPolicy": Admin,
+
+	"/model.AnalyticsService/GetJobStats":      Viewer,
+	"/model.AnalyticsService/GetJobTimeSeries": Viewer,
+	"/model.AnalyticsService/GetOwnerSummary":  Viewer,
+	"/model.AnalyticsService/GetCostReport":    Viewer,
+	"/model.AnalyticsService/GetSLAStatus":     Viewer,
+	"/model.AnalyticsService/ListSLABreaches":  Viewer,
+	"/model.AnalyticsService/ListAnomalies":    Viewer,
+	"/model.AnalyticsService/SetJobSLA":        Editor,
+	"/model.AnalyticsService/DeleteJobSLA":     Editor,
+
+	"/model.AuditService/ListAuditEntries": Viewer,
+
+	"/model.WebhookService/ListWebhooks":    Viewer,
+	"/model.WebhookService/ListDeadLetters": Viewer,
+	"/model.WebhookService/CreateWebhook":   Editor,
+	"/model.WebhookService/DeleteWebhook":   Admin,
+
+	// Callers register before an admin grants them a role
+	"/model.UserService/RegisterUser": Anyone,
+	"/model.UserService/ReadUser":     Viewer,
+	"/model.UserService/ListUsers":    Viewer,
+	"/model.UserService/ListApiKeys":  Viewer,
+	"/model.UserService/CreateUser":   Editor,
+	"/model.UserService/UpdateUser":   Editor,
+	"/model.UserService/CreateApiKey": Editor,
+	"/model.UserService/RotateApiKey": Editor,
+	"/model.UserService/DeleteUser":   Admin,
+	"/model.UserService/DeleteApiKey": Admin,
+
+	"/model.TeamService/ReadTeam":         Viewer,
+	"/model.TeamService/ListTeams":        Viewer,
+	"/model.TeamService/CreateTeam":       Editor,
+	"/model.TeamService/UpdateTeam":       Editor,
+	"/model.TeamService/AddTeamMember":    Editor,
+	"/model.TeamService/RemoveTeamMember": Editor,
+	"/model.TeamService/DeleteTeam":       Admin,
+
+	"/model.TemplateService/ReadTemplate":          Viewer,
+	"/model.TemplateService/ListTemplates":         Viewer,
+	"/model.TemplateService/CreateTemplate":        Editor,
+	"/model.TemplateService/UpdateTemplate":        Editor,
+	"/model.TemplateService/CreateJobFromTemplate": Editor,
+	"/model.TemplateService/DeleteTemplate":        Admin,
+
+	"/model.CalendarService/ReadCalendar":    Viewer,
+	"/model.CalendarService/ListCalendars":   Viewer,
+	"/model.CalendarService/CreateCalendar":  Editor,
+	"/model.CalendarService/UpdateCalendar":  Editor,
+	"/model.CalendarService/SetJobCalendars": Editor,
+	"/model.CalendarService/DeleteCalendar":  Admin,
+
+	// Reading a secret never returns its value
+	"/model.SecretService/ReadSecret":   Viewer,
+	"/model.SecretService/ListSecrets":  Viewer,
+	"/model.SecretService/CreateSecret": Editor,
+	"/model.SecretService/UpdateSecret": Editor,
+	"/model.SecretService/DeleteSecret": Admin,
+
+	"/model.ReportService/GenerateReport": Viewer,
+
+	// Workers run the jobs of every owner
+	"/model.WorkerService/RegisterWorker":   Admin,
+	"/model.WorkerService/UnregisterWorker": Admin,
+	"/model.WorkerService/Heartbeat":        Admin,
+	"/model.WorkerService/LeaseRun":         Admin,
+	"/model.WorkerService/CompleteRun":      Admin,
+	"/model.WorkerService/ListWorkers":      Admin,
+
+	"/model.AdminService/BackupJobs":           Admin,
+	"/model.AdminService/RestoreJobs":          Admin,
+	"/model.AdminService/GetMaintenance":       Admin,
+	"/model.AdminService/EnableMaintenance":    Admin,
+	"/model.AdminService/DisableMaintenance":   Admin,
+	"/model.AdminService/ListEnvironments":     Admin,
+	"/model.AdminService/PauseEnvironment":     Admin,
+	"/model.AdminService/ResumeEnvironment":    Admin,
+	"/model.AdminService/ProtectEnvironment":   Admin,
+	"/model.AdminService/UnprotectEnvironment": Admin,
+	"/model.AdminService/GetConfig":            Admin,
+	"/model.AdminService/GetLogLevel":          Admin,
+	"/model.AdminService/SetLogLevel":          Admin,
+}
+
+// Authorizer checks the roles of the caller of every call against a policy. The roles of a caller are the ones of
+// their token or API key, the ones stored with their user and the ones of their teams.
+type Authorizer struct {
+	users  repository.UserRepository
+	policy map[string]string
+}
+
+// New creates an authorizer enforcing policy, looking up the stored roles of callers in users
+func New(users repository.UserRepository, policy map[string]string) *Authorizer {
+	return &Authorizer{users: users, policy: policy}
+}
+
+// UnaryServerInterceptor rejects unary calls the roles of the caller don't allow
+func (a *Authorizer) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects streams the roles of the caller don't allow
+func (a *Authorizer) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorizedStream replaces the context of a stream with one carrying all roles of the caller
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize returns a context whose claims carry all roles of the caller if they allow calling method, so the
+// services see roles granted by the user or a team as well. Calls of methods exempt from authentication have no
+// caller and aren't checked.
+func (a *Authorizer) authorize(ctx context.Context, method string) (context.Context, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	required, ok := a.policy[method]
+	if !ok {
+		return nil, denied(method, required, fmt.Sprintf("Method %s is not allowed for any role", method))
+	}
+	roles, err := a.roles(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	rank := 0
+	for _, role := range roles {
+		if ranks[role] > rank {
+			rank = ranks[role]
+		}
+	}
+	if rank < ranks[required] {
+		return nil, denied(method, required, fmt.Sprintf("Method %s requires the %s role", method, required))
+	}
+	c := *claims
+	c.Roles = roles
+	return auth.NewContext(ctx, &c), nil
+}
+
+// HasRole reports whether the caller of ctx was granted role by their token or API key or by their user, for requests
+// that don't come in over gRPC. Roles of teams aren't looked up.
+func (a *Authorizer) HasRole(ctx context.Context, role string) (bool, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	roles, err := a.roles(ctx, claims)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range roles {
+		if r == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// roles returns the roles of the caller with claims, each of them once
+func (a *Authorizer) roles(ctx context.Context, claims *auth.Claims) ([]string, error) {
+	roles := append([]string{}, claims.Roles...)
+	user, err := a.users.Get(ctx, claims.Subject)
+	if err == repository.ErrNotFound {
+		// Callers don't need to be registered, they only miss the roles of their user
+		user = &repository.User{}
+	} else if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Could not look up the roles of the caller")
+	}
+	seen := map[string]bool{}
+	for _, role := range roles {
+		seen[role] = true
+	}
+	for _, role := range append(user.Roles, team.RolesFromContext(ctx)...) {
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// denied tells that the roles of the caller don't allow calling method, which needs the required role
+func denied(method, required, msg string) error {
+	st := status.New(codes.PermissionDenied, msg)
+	info := &errdetails.ErrorInfo{Reason: "PERMISSION_DENIED", Domain: errorDomain, Metadata: map[string]string{"method": method}}
+	if required != Anyone {
+		info.Metadata["required_role"] = required
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,44 @@
+package recovery
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor turns a panic of a unary handler into an Internal error instead of crashing the server
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor turns a panic of a stream handler into an Internal error instead of crashing the server
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recovered records a panic of the handler of fullMethod and returns the error sent to the caller.
+// The panic value and stack trace are only logged, they may contain details the caller must not see.
+func recovered(ctx context.Context, fullMethod string, r interface{}) error {
+	metrics.RecordPanic(fullMethod)
+	logging.FromContext(ctx).Error("Recovered from panic in handler",
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	return status.Errorf(codes.Internal, "Internal error")
+}
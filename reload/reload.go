@@ -0,0 +1,220 @@
+package reload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/config"
+	"go.uber.org/zap"
+)
+
+// Func applies the settings it was registered for from cfg. A setting it fails to apply keeps its old value until the
+// server restarts.
+type Func func(cfg *config.Config) error
+
+// handler applies the settings with names
+type handler struct {
+	names []string
+	apply Func
+}
+
+// Setting is a setting of the active configuration, values are redacted like the ones of -print-config
+type Setting struct {
+	Name  string
+	Value string
+	// Reloadable tells that a change of the setting is applied without a restart
+	Reloadable bool
+	// RestartRequired tells that the configuration was reloaded with the value Pending, which only applies after a
+	// restart
+	RestartRequired bool
+	Pending         string
+}
+
+// Snapshot is the configuration the server runs with
+type Snapshot struct {
+	// Settings are sorted by name
+	Settings []*Setting
+	// LoadedAt is when the server started, ReloadedAt when the configuration was last reloaded successfully
+	LoadedAt   time.Time
+	ReloadedAt time.Time
+	// Error tells why the last reload failed, empty when it didn't
+	Error string
+}
+
+// Reloader loads the configuration again and applies the settings that handlers were registered for while the server
+// runs. Changes of other settings are logged and only take effect after a restart.
+type Reloader struct {
+	args   []string
+	logger *zap.Logger
+
+	mu         sync.Mutex
+	handlers   []handler
+	reloadable map[string]bool
+	active     *config.Config
+	pending    map[string]string
+	loadedAt   time.Time
+	reloadedAt time.Time
+	err        error
+}
+
+// New creates a Reloader of the configuration cfg the server started with, loaded from the command line arguments args
+func New(cfg *config.Config, args []string, logger *zap.Logger) *Reloader {
+	return &Reloader{
+		args:       args,
+		logger:     logger,
+		reloadable: map[string]bool{},
+		active:     cfg,
+		pending:    map[string]string{},
+		loadedAt:   time.Now(),
+	}
+}
+
+// Handle registers apply for the settings with names, it is called with the new configuration when any of them
+// changed
+func (r *Reloader) Handle(apply Func, names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler{names: names, apply: apply})
+	for _, name := range names {
+		r.reloadable[name] = true
+	}
+}
+
+// Reload loads the configuration from the environment, the command line and the config file again and applies the
+// changed settings that can be. An invalid configuration changes nothing.
+func (r *Reloader) Reload() error {
+	cfg, err := config.Load(r.args)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.err = err
+		r.logger.Error("Could not reload the configuration, keeping the old one", zap.Error(err))
+		return err
+	}
+	changes := r.active.Changes(cfg)
+	changed := map[string]bool{}
+	for _, name := range changes {
+		changed[name] = true
+	}
+	// Values are redacted before settings that didn't apply are set back to their old ones
+	values := cfg.Settings()
+	applied := map[string]bool{}
+	for _, h := range r.handlers {
+		if !anyOf(h.names, changed) {
+			continue
+		}
+		if err := h.apply(cfg); err != nil {
+			r.logger.Warn("Could not apply settings, restart to apply them", zap.Strings("settings", h.names), zap.Error(err))
+			continue
+		}
+		for _, name := range h.names {
+			if changed[name] {
+				applied[name] = true
+			}
+		}
+	}
+	var restart []string
+	for _, name := range changes {
+		if applied[name] {
+			delete(r.pending, name)
+			continue
+		}
+		if err := cfg.Keep(name, r.active); err != nil {
+			// The old value was valid once, it can't fail to be set again
+			r.logger.Error("Could not keep setting", zap.String("setting", name), zap.Error(err))
+		}
+		r.pending[name] = values[name]
+		restart = append(restart, name)
+	}
+	// Settings changed back to the value the server runs with aren't pending anymore
+	for name := range r.pending {
+		if !changed[name] {
+			delete(r.pending, name)
+		}
+	}
+	r.active = cfg
+	r.reloadedAt = time.Now()
+	r.err = nil
+	r.logger.Info("Reloaded the configuration", zap.Strings("applied", sortedNames(applied)), zap.Strings("restart_required", restart))
+	return nil
+}
+
+// Watch reloads the configuration whenever the content of the config file changes until ctx is cancelled, it checks
+// every interval
+func (r *Reloader) Watch(ctx context.Context, path string, interval time.Duration) {
+	last := checksum(path)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		sum := checksum(path)
+		if sum == nil || bytes.Equal(sum, last) {
+			// A file that can't be read is likely being replaced, the next check sees the new one
+			continue
+		}
+		last = sum
+		r.logger.Info("Config file changed", zap.String("path", path))
+		r.Reload()
+	}
+}
+
+// Snapshot returns the configuration the server runs with
+func (r *Reloader) Snapshot() *Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot := &Snapshot{LoadedAt: r.loadedAt, ReloadedAt: r.reloadedAt}
+	if r.err != nil {
+		snapshot.Error = r.err.Error()
+	}
+	for name, value := range r.active.Settings() {
+		pending, restart := r.pending[name]
+		snapshot.Settings = append(snapshot.Settings, &Setting{
+			Name:            name,
+			Value:           value,
+			Reloadable:      r.reloadable[name],
+			RestartRequired: restart,
+			Pending:         pending,
+		})
+	}
+	sort.Slice(snapshot.Settings, func(i, j int) bool {
+		return snapshot.Settings[i].Name < snapshot.Settings[j].Name
+	})
+	return snapshot
+}
+
+// checksum returns the SHA-256 of the file at path, nil when it can't be read
+func checksum(path string) []byte {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func anyOf(names []string, set map[string]bool) bool {
+	for _, name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
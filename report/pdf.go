@@ -0,0 +1,111 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Layout of the pages of PDF reports in points, A4 with the text set in 8pt Courier
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 40
+	fontSize     = 8
+	titleSize    = 12
+	leading      = 11
+	linesPerPage = (pageHeight - 2*margin - 2*leading) / leading
+	// lineWidth is how many characters of Courier, which are 0.6 of the font size wide, fit between the margins
+	lineWidth = (pageWidth - 2*margin) * 10 / (fontSize * 6)
+)
+
+// WritePDF writes r as a PDF document. The report is set in a monospaced font the PDF viewer brings along, so the
+// document doesn't embed a font and characters outside of Latin-1 are replaced.
+func WritePDF(w io.Writer, r *Report) error {
+	text := lines(r)
+	title, text := text[0], text[1:]
+	var pages [][]string
+	for len(text) > linesPerPage {
+		pages = append(pages, text[:linesPerPage])
+		text = text[linesPerPage:]
+	}
+	pages = append(pages, text)
+
+	doc := &pdf{}
+	doc.buf.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		// The catalog, the page tree and the font come first, every page is followed by its contents
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	doc.object("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	doc.object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		var content bytes.Buffer
+		top := pageHeight - margin - leading
+		if i == 0 {
+			fmt.Fprintf(&content, "BT /F1 %d Tf %d %d Td (%s) Tj ET\n", titleSize, margin, top, escape(title))
+		}
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", fontSize, leading, margin, top-2*leading)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escape(line))
+		}
+		content.WriteString("ET\n")
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d %d Td (Page %d of %d) Tj ET\n", fontSize, margin, margin/2, i+1, len(pages))
+		doc.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 5+2*i))
+		doc.object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+	doc.finish()
+	_, err := w.Write(doc.buf.Bytes())
+	return err
+}
+
+// pdf collects the objects of a document and where they start
+type pdf struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// object appends the next object, numbered from 1
+func (p *pdf) object(body string) {
+	p.offsets = append(p.offsets, p.buf.Len())
+	fmt.Fprintf(&p.buf, "%d 0 obj\n%s\nendobj\n", len(p.offsets), body)
+}
+
+// finish appends the cross-reference table and the trailer
+func (p *pdf) finish() {
+	xref := p.buf.Len()
+	fmt.Fprintf(&p.buf, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, offset := range p.offsets {
+		fmt.Fprintf(&p.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&p.buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets)+1, xref)
+}
+
+// escape returns s as the contents of a literal string cut to the width of a line, Latin-1 characters are written as
+// octal escapes and all others replaced with a question mark
+func escape(s string) string {
+	var b strings.Builder
+	n := 0
+	for _, c := range s {
+		if n == lineWidth {
+			break
+		}
+		n++
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case c >= 0x20 && c < 0x7f:
+			b.WriteRune(c)
+		case c >= 0xa0 && c <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
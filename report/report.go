@@ -0,0 +1,376 @@
+// Package report builds weekly and monthly reports of the runs of jobs and writes them as CSV or PDF
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// Periods a report covers, weeks start on Monday and both follow the wall clock of UTC
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// Formats a report is written in
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+// pageSize is the number of jobs or breaches read at once while building a report
+const pageSize = 500
+
+// slowestJobs is the number of jobs listed as the slowest ones
+const slowestJobs = 10
+
+// fields are the fields of jobs a report reads
+var fields = []string{"id", "name", "owner"}
+
+// PeriodBefore returns the start and end of the last full week or month that ended at or before t
+func PeriodBefore(period string, t time.Time) (time.Time, time.Time) {
+	if period == PeriodMonth {
+		to := repository.TruncateMonth(t)
+		return to.AddDate(0, -1, 0), to
+	}
+	to := repository.TruncateBucket(t, repository.BucketWeek, time.UTC)
+	return to.AddDate(0, 0, -7), to
+}
+
+// NextPeriod returns the end of the week or month t falls into, when the report of that period is due
+func NextPeriod(period string, t time.Time) time.Time {
+	if period == PeriodMonth {
+		return repository.TruncateMonth(t).AddDate(0, 1, 0)
+	}
+	return repository.TruncateBucket(t, repository.BucketWeek, time.UTC).AddDate(0, 0, 7)
+}
+
+// Job is a job that had runs within the period of a report
+type Job struct {
+	ID    string
+	Name  string
+	Owner string
+	Runs  int64
+	// Stats are the stats of the runs of the job within the period
+	Stats *repository.RunStats
+	// SuccessRate is the share of the finished runs that succeeded, 0 without finished runs
+	SuccessRate float64
+}
+
+// Breach is an SLA breach detected within the period of a report
+type Breach struct {
+	*repository.SLABreach
+	JobName string
+}
+
+// Report sums up the runs of jobs queued within a period and the SLA breaches detected within it
+type Report struct {
+	Period      string
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+	// Counts holds the number of runs of all jobs by status
+	Counts      map[string]int64
+	Runs        int64
+	SuccessRate float64
+	// Jobs are the jobs with runs, lowest success rate first
+	Jobs []*Job
+	// Slowest are the jobs with the slowest p95 duration, slowest first
+	Slowest  []*Job
+	Breaches []*Breach
+}
+
+// Builder builds reports from the stored jobs, runs and SLA breaches
+type Builder struct {
+	jobs repository.JobRepository
+	runs repository.RunRepository
+	slas repository.SLARepository
+	// tenants are the tenants with a database of their own, their jobs aren't listed with the others
+	tenants []string
+}
+
+// NewBuilder creates a Builder reading from the repositories. tenants are the tenants whose jobs are stored apart from
+// the others, reports without a tenant cover them one after the other.
+func NewBuilder(jobs repository.JobRepository, runs repository.RunRepository, slas repository.SLARepository, tenants []string) *Builder {
+	sorted := append([]string(nil), tenants...)
+	sort.Strings(sorted)
+	return &Builder{jobs: jobs, runs: runs, slas: slas, tenants: sorted}
+}
+
+// Build reports the runs of the jobs matching q queued at or after from and before to, of the tenant of ctx or of
+// every tenant when ctx has none. Deleted jobs are reported until they are purged.
+func (b *Builder) Build(ctx context.Context, q repository.Query, period string, from, to time.Time) (*Report, error) {
+	r := &Report{Period: period, From: from, To: to, GeneratedAt: time.Now().UTC().Truncate(time.Second), Counts: map[string]int64{}}
+	q.IncludeDeleted = true
+	q.Fields = fields
+	for _, scope := range b.scopes(ctx) {
+		if err := b.build(scope, q, r); err != nil {
+			return nil, err
+		}
+	}
+	var succeeded, finished int64
+	for status, count := range r.Counts {
+		r.Runs += count
+		switch status {
+		case executor.StatusSucceeded:
+			succeeded += count
+			finished += count
+		case executor.StatusFailed, executor.StatusTimedOut, executor.StatusCancelled:
+			finished += count
+		}
+	}
+	if finished > 0 {
+		r.SuccessRate = float64(succeeded) / float64(finished)
+	}
+	sort.SliceStable(r.Jobs, func(i, j int) bool {
+		if r.Jobs[i].SuccessRate != r.Jobs[j].SuccessRate {
+			return r.Jobs[i].SuccessRate < r.Jobs[j].SuccessRate
+		}
+		return r.Jobs[i].Name < r.Jobs[j].Name
+	})
+	for _, job := range r.Jobs {
+		if job.Stats.Finished > 0 {
+			r.Slowest = append(r.Slowest, job)
+		}
+	}
+	sort.SliceStable(r.Slowest, func(i, j int) bool {
+		return r.Slowest[i].Stats.P95 > r.Slowest[j].Stats.P95
+	})
+	if len(r.Slowest) > slowestJobs {
+		r.Slowest = r.Slowest[:slowestJobs]
+	}
+	sort.SliceStable(r.Breaches, func(i, j int) bool {
+		return r.Breaches[i].DetectedAt.Before(r.Breaches[j].DetectedAt)
+	})
+	return r, nil
+}
+
+// scopes returns the contexts the jobs of ctx are listed with, one for the shared storage and one for each tenant
+// with a database of its own when ctx has no tenant
+func (b *Builder) scopes(ctx context.Context) []context.Context {
+	if tenant.FromContext(ctx) != "" {
+		return []context.Context{ctx}
+	}
+	scopes := []context.Context{ctx}
+	for _, t := range b.tenants {
+		scopes = append(scopes, tenant.NewContext(ctx, t))
+	}
+	return scopes
+}
+
+// build adds the jobs of a single scope to r
+func (b *Builder) build(ctx context.Context, q repository.Query, r *Report) error {
+	after := ""
+	for {
+		jobs, err := b.jobs.List(ctx, q, after, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if err := b.addJob(ctx, job, r); err != nil {
+				return err
+			}
+		}
+		if len(jobs) < pageSize {
+			return nil
+		}
+		after = jobs[len(jobs)-1].ID
+	}
+}
+
+// addJob adds the runs and breaches of job within the period of r, jobs without either are left out
+func (b *Builder) addJob(ctx context.Context, job *repository.Job, r *Report) error {
+	stats, err := b.runs.Stats(ctx, job.ID, r.From, r.To)
+	if err != nil {
+		return err
+	}
+	var runs int64
+	for status, count := range stats.Counts {
+		r.Counts[status] += count
+		runs += count
+	}
+	if runs > 0 {
+		succeeded := stats.Counts[executor.StatusSucceeded]
+		finished := succeeded + stats.Counts[executor.StatusFailed] + stats.Counts[executor.StatusTimedOut] + stats.Counts[executor.StatusCancelled]
+		j := &Job{ID: job.ID, Name: job.Name, Owner: job.Owner, Runs: runs, Stats: stats}
+		if finished > 0 {
+			j.SuccessRate = float64(succeeded) / float64(finished)
+		}
+		r.Jobs = append(r.Jobs, j)
+	}
+	before := ""
+	for {
+		breaches, err := b.slas.ListBreaches(ctx, job.ID, before, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, breach := range breaches {
+			// Newest first, the rest was detected before the period
+			if breach.DetectedAt.Before(r.From) {
+				return nil
+			}
+			if breach.DetectedAt.Before(r.To) {
+				r.Breaches = append(r.Breaches, &Breach{SLABreach: breach, JobName: job.Name})
+			}
+		}
+		if len(breaches) < pageSize {
+			return nil
+		}
+		before = breaches[len(breaches)-1].ID
+	}
+}
+
+// Filename returns the name of the file of r written in format
+func Filename(r *Report, format string) string {
+	return fmt.Sprintf("report-%s-%s.%s", r.Period, r.From.Format("2006-01-02"), format)
+}
+
+// ContentType returns the MIME type of reports written in format
+func ContentType(format string) string {
+	if format == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Write writes r to w in format
+func Write(w io.Writer, r *Report, format string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, r)
+	case FormatPDF:
+		return WritePDF(w, r)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// WriteCSV writes r as CSV. Every section starts with a row holding its name followed by a header row, sections are
+// separated by an empty row.
+func WriteCSV(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		{"summary"},
+		{"period", "from", "to", "generated_at", "runs", "succeeded", "failed", "timed_out", "cancelled", "success_rate"},
+		{r.Period, r.From.Format(time.RFC3339), r.To.Format(time.RFC3339), r.GeneratedAt.Format(time.RFC3339),
+			count(r.Runs), count(r.Counts[executor.StatusSucceeded]), count(r.Counts[executor.StatusFailed]),
+			count(r.Counts[executor.StatusTimedOut]), count(r.Counts[executor.StatusCancelled]), rate(r.SuccessRate)},
+		{},
+		{"jobs"},
+		{"job_id", "name", "owner", "runs", "succeeded", "failed", "timed_out", "cancelled", "success_rate", "mean_duration_ms", "p95_duration_ms"},
+	}
+	for _, job := range r.Jobs {
+		rows = append(rows, []string{job.ID, job.Name, job.Owner, count(job.Runs), count(job.Stats.Counts[executor.StatusSucceeded]),
+			count(job.Stats.Counts[executor.StatusFailed]), count(job.Stats.Counts[executor.StatusTimedOut]),
+			count(job.Stats.Counts[executor.StatusCancelled]), rate(job.SuccessRate), millis(job.Stats.Mean), millis(job.Stats.P95)})
+	}
+	rows = append(rows, []string{}, []string{"slowest_jobs"}, []string{"job_id", "name", "owner", "p50_duration_ms", "p95_duration_ms", "p99_duration_ms"})
+	for _, job := range r.Slowest {
+		rows = append(rows, []string{job.ID, job.Name, job.Owner, millis(job.Stats.P50), millis(job.Stats.P95), millis(job.Stats.P99)})
+	}
+	rows = append(rows, []string{}, []string{"sla_breaches"}, []string{"breach_id", "job_id", "name", "kind", "run_id", "duration_ms", "deadline", "detected_at"})
+	for _, breach := range r.Breaches {
+		deadline := ""
+		if breach.Deadline != nil {
+			deadline = breach.Deadline.Format(time.RFC3339)
+		}
+		duration := ""
+		if breach.Kind == repository.SLABreachDuration {
+			duration = millis(breach.Duration)
+		}
+		rows = append(rows, []string{breach.ID, breach.JobID, breach.JobName, breach.Kind, breach.RunID, duration, deadline,
+			breach.DetectedAt.Format(time.RFC3339)})
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// lines returns r as the lines of text the PDF shows, the columns are padded for a monospaced font
+func lines(r *Report) []string {
+	out := []string{
+		fmt.Sprintf("Schedulytics %sly report", r.Period),
+		fmt.Sprintf("%s to %s, generated %s", r.From.Format("2006-01-02"), r.To.Add(-time.Second).Format("2006-01-02"),
+			r.GeneratedAt.Format("2006-01-02 15:04 MST")),
+		"",
+		fmt.Sprintf("Runs: %d   Succeeded: %d   Failed: %d   Timed out: %d   Cancelled: %d   Success rate: %s",
+			r.Runs, r.Counts[executor.StatusSucceeded], r.Counts[executor.StatusFailed], r.Counts[executor.StatusTimedOut],
+			r.Counts[executor.StatusCancelled], percent(r.SuccessRate)),
+		"",
+		"Success rates",
+		fmt.Sprintf("%-40s %-20s %8s %8s %8s", "Job", "Owner", "Runs", "Failed", "Success"),
+	}
+	for _, job := range r.Jobs {
+		failed := job.Stats.Counts[executor.StatusFailed] + job.Stats.Counts[executor.StatusTimedOut]
+		out = append(out, fmt.Sprintf("%-40s %-20s %8d %8d %8s", clip(job.Name, 40), clip(job.Owner, 20), job.Runs, failed, percent(job.SuccessRate)))
+	}
+	if len(r.Jobs) == 0 {
+		out = append(out, "No runs")
+	}
+	out = append(out, "", "Slowest jobs", fmt.Sprintf("%-40s %-20s %10s %10s %10s", "Job", "Owner", "p50", "p95", "p99"))
+	for _, job := range r.Slowest {
+		out = append(out, fmt.Sprintf("%-40s %-20s %10s %10s %10s", clip(job.Name, 40), clip(job.Owner, 20), duration(job.Stats.P50),
+			duration(job.Stats.P95), duration(job.Stats.P99)))
+	}
+	if len(r.Slowest) == 0 {
+		out = append(out, "No finished runs")
+	}
+	out = append(out, "", "SLA breaches", fmt.Sprintf("%-40s %-10s %-20s %s", "Job", "Kind", "Detected", "Breached"))
+	for _, breach := range r.Breaches {
+		what := ""
+		if breach.Deadline != nil {
+			what = "deadline " + breach.Deadline.Format("2006-01-02 15:04 MST")
+		} else if breach.Kind == repository.SLABreachDuration {
+			what = "run " + breach.RunID + " took " + duration(breach.Duration)
+		}
+		out = append(out, fmt.Sprintf("%-40s %-10s %-20s %s", clip(breach.JobName, 40), breach.Kind,
+			breach.DetectedAt.Format("2006-01-02 15:04"), what))
+	}
+	if len(r.Breaches) == 0 {
+		out = append(out, "No breaches")
+	}
+	return out
+}
+
+func count(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func rate(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
+
+func millis(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
+
+func percent(f float64) string {
+	return strconv.FormatFloat(f*100, 'f', 1, 64) + "%"
+}
+
+// duration rounds d to a precision that fits a column
+func duration(d time.Duration) string {
+	if d >= time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// clip cuts s to n characters, marking that it was cut
+func clip(s string, n int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return string(runes[:n-1]) + "~"
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/backup"
+	"github.com/noltedennis/schedulytics-backend/notify"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"go.uber.org/zap"
+)
+
+// deliverTimeout limits building and delivering a single scheduled report
+const deliverTimeout = 10 * time.Minute
+
+// Delivery hands a written report to its readers
+type Delivery interface {
+	Deliver(ctx context.Context, r *Report, filename, contentType string, data []byte) error
+}
+
+// MailDelivery sends reports as attachments of an email to the recipients
+type MailDelivery struct {
+	Mailer     *notify.Mailer
+	Recipients []string
+}
+
+func (d *MailDelivery) Deliver(ctx context.Context, r *Report, filename, contentType string, data []byte) error {
+	subject := fmt.Sprintf("Schedulytics %sly report %s", r.Period, r.From.Format("2006-01-02"))
+	body := fmt.Sprintf("%d runs from %s to %s, %s of the finished ones succeeded. %d SLA breaches were detected.\n\nThe report is attached.",
+		r.Runs, r.From.Format("2006-01-02"), r.To.Add(-time.Second).Format("2006-01-02"), percent(r.SuccessRate), len(r.Breaches))
+	return d.Mailer.SendAttachment(ctx, d.Recipients, subject, body, &notify.Attachment{Filename: filename, ContentType: contentType, Data: data})
+}
+
+// StoreDelivery keeps reports in a store under their file name, like backups
+type StoreDelivery struct {
+	Store backup.Store
+}
+
+func (d *StoreDelivery) Deliver(ctx context.Context, r *Report, filename, contentType string, data []byte) error {
+	// Stores read from files, so uploads know their size and can be retried
+	file, err := ioutil.TempFile("", "schedulytics-report-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return d.Store.Put(ctx, filename, file)
+}
+
+// Scheduler builds the reports of all jobs of every tenant once their period ended and delivers them
+type Scheduler struct {
+	builder    *Builder
+	periods    []string
+	format     string
+	deliveries []Delivery
+	logger     *zap.Logger
+}
+
+// NewScheduler creates a Scheduler writing the reports of periods in format and handing them to every delivery
+func NewScheduler(builder *Builder, periods []string, format string, deliveries []Delivery, logger *zap.Logger) *Scheduler {
+	return &Scheduler{builder: builder, periods: periods, format: format, deliveries: deliveries, logger: logger}
+}
+
+// Run blocks and delivers the reports of the periods as they end until ctx is cancelled. Periods that ended while no
+// scheduler was running aren't reported afterwards.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.logger.Info("Scheduled reports started", zap.Strings("periods", s.periods), zap.String("format", s.format))
+	for {
+		now := time.Now().UTC()
+		var next time.Time
+		for _, period := range s.periods {
+			if end := NextPeriod(period, now); next.IsZero() || end.Before(next) {
+				next = end
+			}
+		}
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		for _, period := range s.periods {
+			if from, to := PeriodBefore(period, next); to.Equal(next) {
+				s.deliver(ctx, period, from, to)
+			}
+		}
+	}
+}
+
+// deliver builds the report of a period and hands it to every delivery, a failed delivery doesn't stop the others
+func (s *Scheduler) deliver(ctx context.Context, period string, from, to time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, deliverTimeout)
+	defer cancel()
+	r, err := s.builder.Build(ctx, repository.Query{}, period, from, to)
+	if err != nil {
+		s.logger.Error("Could not build report", zap.String("period", period), zap.Time("from", from), zap.Error(err))
+		return
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, r, s.format); err != nil {
+		s.logger.Error("Could not write report", zap.String("period", period), zap.Time("from", from), zap.Error(err))
+		return
+	}
+	filename := Filename(r, s.format)
+	delivered := 0
+	for _, d := range s.deliveries {
+		if err := d.Deliver(ctx, r, filename, ContentType(s.format), buf.Bytes()); err != nil {
+			s.logger.Error("Could not deliver report", zap.String("report", filename), zap.Error(err))
+			continue
+		}
+		delivered++
+	}
+	if delivered > 0 {
+		s.logger.Info("Delivered report", zap.String("report", filename), zap.Int64("runs", r.Runs), zap.Int("jobs", len(r.Jobs)),
+			zap.Int("deliveries", delivered))
+	}
+}
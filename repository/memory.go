@@ -0,0 +1,726 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/search"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// watchBuffer is the number of events a watcher may fall behind before it is dropped
+const watchBuffer = 100
+
+// historySize is the number of past events kept for resuming watches
+const historySize = 1000
+
+// ErrWatcherLagging is returned by Next when a watcher of a MemoryJobRepository or SQLiteJobRepository fell too far
+// behind
+var ErrWatcherLagging = errors.New("watcher fell behind, events were lost")
+
+// MemoryJobRepository keeps jobs in memory, it is meant for tests and local development
+type MemoryJobRepository struct {
+	mu       sync.RWMutex
+	jobs     map[string]*Job
+	watchers map[*localJobEvents]bool
+	// seq numbers every change, history holds the most recent ones oldest first
+	seq     int64
+	history []*change
+}
+
+// change is a single recorded change, job is the full job even for deletions so watchers can filter by owner
+type change struct {
+	seq       int64
+	eventType EventType
+	job       *Job
+}
+
+// NewMemoryJobRepository creates an empty repository
+func NewMemoryJobRepository() *MemoryJobRepository {
+	return &MemoryJobRepository{
+		jobs:     map[string]*Job{},
+		watchers: map[*localJobEvents]bool{},
+	}
+}
+
+// copyJob returns a copy of job, so callers can't change stored jobs
+func copyJob(job *Job) *Job {
+	c := *job
+	if job.Schedule != nil {
+		spec := *job.Schedule
+		c.Schedule = &spec
+	}
+	if job.NextRunTime != nil {
+		next := *job.NextRunTime
+		c.NextRunTime = &next
+	}
+	if job.DeletedAt != nil {
+		deleted := *job.DeletedAt
+		c.DeletedAt = &deleted
+	}
+	if job.ArchivedAt != nil {
+		archived := *job.ArchivedAt
+		c.ArchivedAt = &archived
+	}
+	if job.RetryPolicy != nil {
+		policy := *job.RetryPolicy
+		c.RetryPolicy = &policy
+	}
+	if job.Labels != nil {
+		c.Labels = make(map[string]string, len(job.Labels))
+		for key, value := range job.Labels {
+			c.Labels[key] = value
+		}
+	}
+	if job.DependsOn != nil {
+		c.DependsOn = append([]string{}, job.DependsOn...)
+	}
+	if job.Notifications != nil {
+		notifications := *job.Notifications
+		notifications.EmailRecipients = append([]string(nil), job.Notifications.EmailRecipients...)
+		c.Notifications = &notifications
+	}
+	if job.Template != nil {
+		lineage := *job.Template
+		if job.Template.Parameters != nil {
+			lineage.Parameters = make(map[string]string, len(job.Template.Parameters))
+			for name, value := range job.Template.Parameters {
+				lineage.Parameters[name] = value
+			}
+		}
+		c.Template = &lineage
+	}
+	if job.Blackout != nil {
+		blackout := *job.Blackout
+		blackout.CalendarIDs = append([]string{}, job.Blackout.CalendarIDs...)
+		c.Blackout = &blackout
+	}
+	if job.Params != nil {
+		c.Params = copyValue(job.Params).(map[string]interface{})
+	}
+	if job.Promotion != nil {
+		promotion := *job.Promotion
+		c.Promotion = &promotion
+	}
+	return &c
+}
+
+// copyValue returns a deep copy of a JSON value, like the params of a job
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			c[key] = copyValue(value)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, value := range v {
+			c[i] = copyValue(value)
+		}
+		return c
+	}
+	return v
+}
+
+// lookup returns the stored job with the given id matching q and the tenant of ctx, the caller must hold the lock
+func (r *MemoryJobRepository) lookup(ctx context.Context, id string, q Query) (*Job, error) {
+	// Use the same IDs as MongoDB, so clients can't tell the backends apart
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !ofTenant(ctx, job.Tenant) {
+		return nil, ErrOtherTenant
+	}
+	if !q.matches(job) {
+		return nil, ErrNotFound
+	}
+	return job, nil
+}
+
+// ofTenant reports whether ctx may access something of tenant t, contexts without a tenant may access everything
+func ofTenant(ctx context.Context, t string) bool {
+	scope := tenant.FromContext(ctx)
+	return scope == "" || scope == t
+}
+
+// matches reports whether q applies to job
+func (q Query) matches(job *Job) bool {
+	return q.OwnedBy(job.Owner) && (q.IncludeDeleted || job.DeletedAt == nil) && q.Labels.Matches(job.Labels) &&
+		q.Archived.Matches(job.ArchivedAt) && (q.Environment == "" || q.Environment == job.Environment)
+}
+
+// publish records a change and sends it to every watcher interested in it, the caller must hold the write lock
+func (r *MemoryJobRepository) publish(eventType EventType, job *Job) {
+	r.seq++
+	c := &change{seq: r.seq, eventType: eventType, job: copyJob(job)}
+	r.history = append(r.history, c)
+	if len(r.history) > historySize {
+		r.history = r.history[len(r.history)-historySize:]
+	}
+	for w := range r.watchers {
+		event := c.event(w.query, w.tenant)
+		if event != nil && !w.offer(event) {
+			delete(r.watchers, w)
+		}
+	}
+}
+
+// notify publishes a change right away, or once the transaction of ctx is committed. The caller must hold the write
+// lock.
+func (r *MemoryJobRepository) notify(ctx context.Context, eventType EventType, job *Job) {
+	tx, ok := memoryTxFrom(ctx)
+	if !ok {
+		r.publish(eventType, job)
+		return
+	}
+	c := copyJob(job)
+	tx.onCommit(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.publish(eventType, c)
+	})
+}
+
+// remember makes the transaction of ctx, if there is one, put back the stored job with the given id as it is now
+// when it's rolled back. The caller must hold the write lock and call it before changing the job.
+func (r *MemoryJobRepository) remember(ctx context.Context, id string) {
+	tx, ok := memoryTxFrom(ctx)
+	if !ok {
+		return
+	}
+	prev, existed := r.jobs[id]
+	if existed {
+		prev = copyJob(prev)
+	}
+	tx.onRollback(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.jobs[id] = prev
+		} else {
+			delete(r.jobs, id)
+		}
+	})
+}
+
+func (r *MemoryJobRepository) Create(ctx context.Context, job *Job) (*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyJob(job)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	if stored.IdempotencyKey != "" && r.byIdempotencyKey(stored.Tenant, stored.Owner, stored.IdempotencyKey) != nil {
+		return nil, ErrIdempotencyKeyUsed
+	}
+	if r.nameTaken(stored) {
+		return nil, ErrNameTaken
+	}
+	r.remember(ctx, stored.ID)
+	r.jobs[stored.ID] = stored
+	r.notify(ctx, EventCreated, stored)
+	return copyJob(stored), nil
+}
+
+// nameTaken reports whether another job of the tenant, owner and environment of job has its name, the caller must
+// hold the lock
+func (r *MemoryJobRepository) nameTaken(job *Job) bool {
+	return r.byName(job.Tenant, job.Owner, job.Environment, job.Name, job.ID) != nil
+}
+
+// byName returns the stored job of tenant t and owner named name in environment other than the one with id except,
+// the caller must hold the lock
+func (r *MemoryJobRepository) byName(t, owner, environment, name, except string) *Job {
+	for _, job := range r.jobs {
+		if job.ID != except && job.Tenant == t && job.Owner == owner && job.Environment == environment && job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// byIdempotencyKey returns the stored job of tenant t and owner created with key, the caller must hold the lock
+func (r *MemoryJobRepository) byIdempotencyKey(t, owner, key string) *Job {
+	for _, job := range r.jobs {
+		if job.Tenant == t && job.Owner == owner && job.IdempotencyKey == key {
+			return job
+		}
+	}
+	return nil
+}
+
+func (r *MemoryJobRepository) CreateMany(ctx context.Context, jobs []*Job) ([]*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	created := make([]*Job, 0, len(jobs))
+	batchErr := &BatchError{Errors: map[int]error{}}
+	for i, job := range jobs {
+		stored := copyJob(job)
+		stored.ID = newID()
+		stored.Tenant = tenant.FromContext(ctx)
+		if r.nameTaken(stored) {
+			batchErr.Errors[i] = ErrNameTaken
+			created = append(created, nil)
+			continue
+		}
+		r.remember(ctx, stored.ID)
+		r.jobs[stored.ID] = stored
+		r.notify(ctx, EventCreated, stored)
+		created = append(created, copyJob(stored))
+	}
+	if len(batchErr.Errors) > 0 {
+		return created, batchErr
+	}
+	return created, nil
+}
+
+func (r *MemoryJobRepository) Put(ctx context.Context, job *Job, replace bool) error {
+	if err := checkID(job.ID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyJob(job)
+	stored.Tenant = tenant.FromContext(ctx)
+	existing, ok := r.jobs[stored.ID]
+	if ok && (!replace || existing.Tenant != stored.Tenant) {
+		return ErrExists
+	}
+	if stored.IdempotencyKey != "" {
+		if other := r.byIdempotencyKey(stored.Tenant, stored.Owner, stored.IdempotencyKey); other != nil && other.ID != stored.ID {
+			return ErrIdempotencyKeyUsed
+		}
+	}
+	if r.nameTaken(stored) {
+		return ErrNameTaken
+	}
+	r.remember(ctx, stored.ID)
+	r.jobs[stored.ID] = stored
+	if ok {
+		r.notify(ctx, EventUpdated, stored)
+	} else {
+		r.notify(ctx, EventCreated, stored)
+	}
+	return nil
+}
+
+func (r *MemoryJobRepository) Get(ctx context.Context, id string, q Query) (*Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, err := r.lookup(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	return copyJob(job), nil
+}
+
+func (r *MemoryJobRepository) GetMany(ctx context.Context, ids []string, q Query) ([]*Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	jobs := []*Job{}
+	for _, id := range ids {
+		if job, err := r.lookup(ctx, id, q); err == nil {
+			jobs = append(jobs, copyJob(job))
+		}
+	}
+	return jobs, nil
+}
+
+func (r *MemoryJobRepository) Update(ctx context.Context, id string, q Query, update *JobUpdate) (*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, err := r.lookup(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	updated := copyJob(job)
+	update.Apply(updated)
+	if r.nameTaken(updated) {
+		return nil, ErrNameTaken
+	}
+	// Store a copy, the schedule, next run time, retry policy and labels still point into update
+	updated = copyJob(updated)
+	r.remember(ctx, id)
+	r.jobs[id] = updated
+	r.notify(ctx, EventUpdated, updated)
+	return copyJob(updated), nil
+}
+
+func (r *MemoryJobRepository) Delete(ctx context.Context, id string, q Query, deletedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, err := r.lookup(ctx, id, q)
+	if err == ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	// Deleting a job again keeps the time it was deleted first
+	if job.DeletedAt != nil {
+		return false, nil
+	}
+	r.remember(ctx, id)
+	job.DeletedAt = &deletedAt
+	r.notify(ctx, EventDeleted, job)
+	return true, nil
+}
+
+func (r *MemoryJobRepository) SetStatus(ctx context.Context, id string, q Query, from, to string, updatedAt time.Time) (*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, err := r.lookup(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != from {
+		return nil, ErrStatusConflict
+	}
+	r.remember(ctx, id)
+	job.Status = to
+	job.UpdatedAt = updatedAt
+	r.notify(ctx, EventUpdated, job)
+	return copyJob(job), nil
+}
+
+func (r *MemoryJobRepository) Restore(ctx context.Context, id string, q Query, updatedAt time.Time) (*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q.IncludeDeleted = true
+	job, err := r.lookup(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt == nil {
+		return nil, ErrNotFound
+	}
+	r.remember(ctx, id)
+	job.DeletedAt = nil
+	job.UpdatedAt = updatedAt
+	r.notify(ctx, EventUpdated, job)
+	return copyJob(job), nil
+}
+
+func (r *MemoryJobRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var purged int64
+	for id, job := range r.jobs {
+		if job.DeletedAt != nil && job.DeletedAt.Before(deletedBefore) && ofTenant(ctx, job.Tenant) {
+			r.remember(ctx, id)
+			delete(r.jobs, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (r *MemoryJobRepository) GetByIdempotencyKey(ctx context.Context, owner, key string) (*Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job := r.byIdempotencyKey(tenant.FromContext(ctx), owner, key)
+	if job == nil {
+		return nil, ErrNotFound
+	}
+	return copyJob(job), nil
+}
+
+func (r *MemoryJobRepository) GetByName(ctx context.Context, owner, environment, name string) (*Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job := r.byName(tenant.FromContext(ctx), owner, environment, name, "")
+	if job == nil {
+		return nil, ErrNotFound
+	}
+	return copyJob(job), nil
+}
+
+func (r *MemoryJobRepository) ExpireIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var expired int64
+	for id, job := range r.jobs {
+		if job.IdempotencyKey != "" && job.CreatedAt.Before(createdBefore) && ofTenant(ctx, job.Tenant) {
+			r.remember(ctx, id)
+			job.IdempotencyKey = ""
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// Search scores jobs by how often the words of text appear in them, words in the name count twice
+func (r *MemoryJobRepository) Search(ctx context.Context, q Query, text string, offset, limit int) ([]*SearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	q.Labels = nil
+	jobs := []*Job{}
+	for _, job := range r.jobs {
+		if q.matches(job) && ofTenant(ctx, job.Tenant) {
+			jobs = append(jobs, copyJob(job))
+		}
+	}
+	return rankJobs(jobs, text, offset, limit), nil
+}
+
+// rankJobs returns the page of jobs matching text at offset, the most relevant first
+func rankJobs(jobs []*Job, text string, offset, limit int) []*SearchResult {
+	query := search.Parse(text)
+	results := []*SearchResult{}
+	for _, job := range jobs {
+		// Excluded words in either field exclude the job
+		if query.Score(job.Name+"\n"+job.Description) == 0 {
+			continue
+		}
+		score := 2*query.Score(job.Name) + query.Score(job.Description)
+		results = append(results, &SearchResult{Job: job, Score: float64(score)})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Job.ID < results[j].Job.ID
+	})
+	if offset > len(results) {
+		offset = len(results)
+	}
+	results = results[offset:]
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func (r *MemoryJobRepository) Count(ctx context.Context, q Query) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := int64(0)
+	for _, job := range r.jobs {
+		if q.matches(job) && ofTenant(ctx, job.Tenant) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MemoryJobRepository) Exists(ctx context.Context, id string, q Query) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, err := r.lookup(ctx, id, q)
+	if err == ErrNotFound || err == ErrOtherTenant {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *MemoryJobRepository) List(ctx context.Context, q Query, after string, limit int) ([]*Job, error) {
+	if after != "" {
+		if err := checkID(after); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// Hex encoded Object IDs sort the same way as the IDs themselves
+	ids := make([]string, 0, len(r.jobs))
+	for id, job := range r.jobs {
+		if id > after && q.matches(job) && ofTenant(ctx, job.Tenant) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		jobs = append(jobs, copyJob(r.jobs[id]))
+	}
+	return jobs, nil
+}
+
+// event returns the event a watcher with query q scoped to tenant t receives for the change, nil if it doesn't match
+func (c *change) event(q Query, t string) *JobEvent {
+	if !q.OwnedBy(c.job.Owner) {
+		return nil
+	}
+	if t != "" && c.job.Tenant != t {
+		return nil
+	}
+	event := &JobEvent{Type: c.eventType, Job: copyJob(c.job)}
+	if c.eventType == EventDeleted {
+		event.Job = &Job{ID: c.job.ID}
+	}
+	// Without a sequence number the change can't be resumed from
+	if c.seq > 0 {
+		event.ResumeToken = strconv.FormatInt(c.seq, 10)
+	}
+	return event
+}
+
+// Watch resume tokens are sequence numbers of changes, only the most recent changes can be resumed from
+func (r *MemoryJobRepository) Watch(ctx context.Context, q Query, resumeToken string) (JobEvents, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var replay []*JobEvent
+	if resumeToken != "" {
+		after, err := strconv.ParseInt(resumeToken, 10, 64)
+		if err != nil || after < 0 || after > r.seq {
+			return nil, ErrInvalidResumeToken
+		}
+		if len(r.history) > 0 && after < r.history[0].seq-1 {
+			return nil, ErrResumeTokenExpired
+		}
+		for _, c := range r.history {
+			if c.seq <= after {
+				continue
+			}
+			if event := c.event(q, tenant.FromContext(ctx)); event != nil {
+				replay = append(replay, event)
+			}
+		}
+	}
+	w := &localJobEvents{
+		query:   q,
+		tenant:  tenant.FromContext(ctx),
+		events:  make(chan *JobEvent, watchBuffer+len(replay)),
+		lagging: make(chan struct{}),
+	}
+	w.stop = func() {
+		r.mu.Lock()
+		delete(r.watchers, w)
+		r.mu.Unlock()
+	}
+	for _, event := range replay {
+		w.events <- event
+	}
+	r.watchers[w] = true
+	return w, nil
+}
+
+func (r *MemoryJobRepository) DueJobs(ctx context.Context, now time.Time) ([]*scheduler.DueJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	jobs := []*scheduler.DueJob{}
+	for _, job := range r.jobs {
+		if job.Schedule == nil || job.NextRunTime == nil || job.NextRunTime.After(now) || job.DeletedAt != nil || job.ArchivedAt != nil ||
+			job.Status == JobPaused || !ofTenant(ctx, job.Tenant) {
+			continue
+		}
+		spec := *job.Schedule
+		due := &scheduler.DueJob{
+			ID:                job.ID,
+			Name:              job.Name,
+			Owner:             job.Owner,
+			Tenant:            job.Tenant,
+			Schedule:          &spec,
+			NextRunTime:       *job.NextRunTime,
+			Priority:          job.Priority,
+			ConcurrencyPolicy: job.ConcurrencyPolicy,
+			CatchUpPolicy:     job.CatchUpPolicy,
+			Environment:       job.Environment,
+		}
+		if job.Blackout != nil {
+			due.Calendars = append([]string{}, job.Blackout.CalendarIDs...)
+			due.DeferBlackouts = job.Blackout.Defer
+		}
+		jobs = append(jobs, due)
+	}
+	return jobs, nil
+}
+
+func (r *MemoryJobRepository) ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, err := r.lookup(ctx, id, Query{})
+	if err == ErrNotFound || err == ErrOtherTenant {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if job.NextRunTime == nil || !job.NextRunTime.Equal(prev) {
+		return false, nil
+	}
+	r.remember(ctx, id)
+	job.NextRunTime = &next
+	return true, nil
+}
+
+func (r *MemoryJobRepository) Dependents(ctx context.Context, id string) ([]*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := []string{}
+	for jobID, job := range r.jobs {
+		if job.DeletedAt != nil || !ofTenant(ctx, job.Tenant) {
+			continue
+		}
+		for _, upstream := range job.DependsOn {
+			if upstream == id {
+				ids = append(ids, jobID)
+				break
+			}
+		}
+	}
+	sort.Strings(ids)
+	jobs := make([]*Job, 0, len(ids))
+	for _, jobID := range ids {
+		jobs = append(jobs, copyJob(r.jobs[jobID]))
+	}
+	return jobs, nil
+}
+
+// localJobEvents receives the events a MemoryJobRepository or SQLiteJobRepository publishes in this process
+type localJobEvents struct {
+	// stop unregisters the watcher from the repository
+	stop    func()
+	query   Query
+	tenant  string
+	events  chan *JobEvent
+	lagging chan struct{}
+}
+
+// offer hands event to the watcher without blocking writers, a watcher that fell too far behind is told so and false
+// returned, it must not be offered anything anymore
+func (w *localJobEvents) offer(event *JobEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	default:
+		close(w.lagging)
+		return false
+	}
+}
+
+func (w *localJobEvents) Next(ctx context.Context) (*JobEvent, error) {
+	// Deliver buffered events before reporting that the watcher was dropped
+	select {
+	case event := <-w.events:
+		return event, nil
+	default:
+	}
+	select {
+	case event := <-w.events:
+		return event, nil
+	case <-w.lagging:
+		return nil, ErrWatcherLagging
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (w *localJobEvents) Close(ctx context.Context) error {
+	w.stop()
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryAnomalyRepository keeps the anomalies of runs in memory, it is meant for tests and local development
+type MemoryAnomalyRepository struct {
+	mu        sync.RWMutex
+	anomalies map[string]*Anomaly
+}
+
+// NewMemoryAnomalyRepository creates a repository without anomalies
+func NewMemoryAnomalyRepository() *MemoryAnomalyRepository {
+	return &MemoryAnomalyRepository{anomalies: map[string]*Anomaly{}}
+}
+
+func (r *MemoryAnomalyRepository) Add(ctx context.Context, anomaly *Anomaly) (*Anomaly, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *anomaly
+	stored.Tenant = tenant.FromContext(ctx)
+	for _, a := range r.anomalies {
+		if a.Tenant == stored.Tenant && a.RunID == stored.RunID {
+			return nil, ErrExists
+		}
+	}
+	stored.ID = newID()
+	r.anomalies[stored.ID] = &stored
+	c := stored
+	return &c, nil
+}
+
+func (r *MemoryAnomalyRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Anomaly, error) {
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := []string{}
+	for id, anomaly := range r.anomalies {
+		if (jobID == "" || anomaly.JobID == jobID) && (before == "" || id < before) && ofTenant(ctx, anomaly.Tenant) {
+			ids = append(ids, id)
+		}
+	}
+	// Newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	anomalies := make([]*Anomaly, 0, len(ids))
+	for _, id := range ids {
+		c := *r.anomalies[id]
+		anomalies = append(anomalies, &c)
+	}
+	return anomalies, nil
+}
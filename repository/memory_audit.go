@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryAuditRepository keeps the audit log in memory, it is meant for tests and local development
+type MemoryAuditRepository struct {
+	mu      sync.RWMutex
+	entries map[string]*AuditEntry
+}
+
+// NewMemoryAuditRepository creates an empty audit log
+func NewMemoryAuditRepository() *MemoryAuditRepository {
+	return &MemoryAuditRepository{entries: map[string]*AuditEntry{}}
+}
+
+// copyAuditEntry returns a copy of entry, so callers can't change stored entries
+func copyAuditEntry(entry *AuditEntry) *AuditEntry {
+	c := *entry
+	c.Changes = append([]AuditChange{}, entry.Changes...)
+	return &c
+}
+
+// matches reports whether filter applies to entry
+func (f AuditFilter) matches(entry *AuditEntry) bool {
+	return (f.Actor == "" || entry.Actor == f.Actor) && (f.JobID == "" || entry.JobID == f.JobID) &&
+		f.ownerQuery().OwnedBy(entry.Owner)
+}
+
+func (r *MemoryAuditRepository) Create(ctx context.Context, entry *AuditEntry) (*AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyAuditEntry(entry)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.entries[stored.ID] = stored
+	return copyAuditEntry(stored), nil
+}
+
+func (r *MemoryAuditRepository) List(ctx context.Context, filter AuditFilter, before string, limit int) ([]*AuditEntry, error) {
+	for _, id := range []string{filter.JobID, before} {
+		if id == "" {
+			continue
+		}
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.entries))
+	for id, entry := range r.entries {
+		if (before == "" || id < before) && filter.matches(entry) && ofTenant(ctx, entry.Tenant) {
+			ids = append(ids, id)
+		}
+	}
+	// Newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	entries := make([]*AuditEntry, 0, len(ids))
+	for _, id := range ids {
+		entries = append(entries, copyAuditEntry(r.entries[id]))
+	}
+	return entries, nil
+}
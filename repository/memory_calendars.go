@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryCalendarRepository keeps calendars in memory, it is meant for tests and local development
+type MemoryCalendarRepository struct {
+	mu        sync.RWMutex
+	calendars map[string]*Calendar
+}
+
+// NewMemoryCalendarRepository creates a repository without calendars
+func NewMemoryCalendarRepository() *MemoryCalendarRepository {
+	return &MemoryCalendarRepository{calendars: map[string]*Calendar{}}
+}
+
+// copyBlackouts returns a copy of blackouts that doesn't share the windows and holidays
+func copyBlackouts(blackouts scheduler.Blackouts) scheduler.Blackouts {
+	blackouts.Windows = append([]scheduler.Window{}, blackouts.Windows...)
+	blackouts.Holidays = append([]string{}, blackouts.Holidays...)
+	return blackouts
+}
+
+// copyCalendar returns a copy of calendar, so callers can't change stored calendars
+func copyCalendar(calendar *Calendar) *Calendar {
+	c := *calendar
+	c.Blackouts = copyBlackouts(calendar.Blackouts)
+	return &c
+}
+
+func (r *MemoryCalendarRepository) Create(ctx context.Context, calendar *Calendar) (*Calendar, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyCalendar(calendar)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.calendars[stored.ID] = stored
+	return copyCalendar(stored), nil
+}
+
+// calendar returns the stored calendar with id matching q in the tenant of ctx, the caller must hold the lock
+func (r *MemoryCalendarRepository) calendar(ctx context.Context, id string, q Query) (*Calendar, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	calendar, ok := r.calendars[id]
+	if !ok || !ofTenant(ctx, calendar.Tenant) || !q.OwnedBy(calendar.Owner) {
+		return nil, ErrNotFound
+	}
+	return calendar, nil
+}
+
+func (r *MemoryCalendarRepository) Get(ctx context.Context, id string, q Query) (*Calendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	calendar, err := r.calendar(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	return copyCalendar(calendar), nil
+}
+
+func (r *MemoryCalendarRepository) List(ctx context.Context, q Query) ([]*Calendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	calendars := []*Calendar{}
+	for _, calendar := range r.calendars {
+		if ofTenant(ctx, calendar.Tenant) && q.OwnedBy(calendar.Owner) {
+			calendars = append(calendars, copyCalendar(calendar))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(calendars, func(i, j int) bool { return calendars[i].ID < calendars[j].ID })
+	return calendars, nil
+}
+
+func (r *MemoryCalendarRepository) Update(ctx context.Context, calendar *Calendar, q Query) (*Calendar, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.calendar(ctx, calendar.ID, q)
+	if err != nil {
+		return nil, err
+	}
+	stored.Name = calendar.Name
+	stored.Description = calendar.Description
+	stored.Owner = calendar.Owner
+	stored.Blackouts = copyBlackouts(calendar.Blackouts)
+	stored.UpdatedAt = calendar.UpdatedAt
+	return copyCalendar(stored), nil
+}
+
+func (r *MemoryCalendarRepository) Delete(ctx context.Context, id string, q Query) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.calendar(ctx, id, q); err != nil {
+		return err
+	}
+	delete(r.calendars, id)
+	return nil
+}
+
+func (r *MemoryCalendarRepository) Blackouts(ctx context.Context, ids []string) ([]*scheduler.Blackouts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	blackouts := []*scheduler.Blackouts{}
+	for _, id := range ids {
+		if calendar, ok := r.calendars[id]; ok && ofTenant(ctx, calendar.Tenant) {
+			b := copyBlackouts(calendar.Blackouts)
+			blackouts = append(blackouts, &b)
+		}
+	}
+	return blackouts, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryChangeRepository keeps the changes of jobs in memory, it is meant for tests and local development
+type MemoryChangeRepository struct {
+	mu      sync.RWMutex
+	changes map[string]*Change
+}
+
+// NewMemoryChangeRepository creates a repository without changes
+func NewMemoryChangeRepository() *MemoryChangeRepository {
+	return &MemoryChangeRepository{changes: map[string]*Change{}}
+}
+
+// copyChange returns a copy of change, so callers can't change stored changes
+func copyChange(change *Change) *Change {
+	c := *change
+	c.Request = append([]byte{}, change.Request...)
+	if change.ReviewedAt != nil {
+		reviewedAt := *change.ReviewedAt
+		c.ReviewedAt = &reviewedAt
+	}
+	return &c
+}
+
+func (r *MemoryChangeRepository) Create(ctx context.Context, change *Change) (*Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyChange(change)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.changes[stored.ID] = stored
+	return copyChange(stored), nil
+}
+
+// change returns the stored change with id matching q in the tenant of ctx, the caller must hold the lock
+func (r *MemoryChangeRepository) change(ctx context.Context, id string, q Query) (*Change, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	change, ok := r.changes[id]
+	if !ok || !ofTenant(ctx, change.Tenant) || !q.OwnedBy(change.Owner) {
+		return nil, ErrNotFound
+	}
+	return change, nil
+}
+
+func (r *MemoryChangeRepository) Get(ctx context.Context, id string, q Query) (*Change, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	change, err := r.change(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	return copyChange(change), nil
+}
+
+func (r *MemoryChangeRepository) List(ctx context.Context, q Query, filter ChangeFilter) ([]*Change, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	changes := []*Change{}
+	for _, change := range r.changes {
+		if ofTenant(ctx, change.Tenant) && q.OwnedBy(change.Owner) && (filter.JobID == "" || filter.JobID == change.JobID) &&
+			(filter.Status == "" || filter.Status == change.Status) {
+			changes = append(changes, copyChange(change))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID > changes[j].ID })
+	return changes, nil
+}
+
+func (r *MemoryChangeRepository) Review(ctx context.Context, id string, q Query, status, reviewer, comment string, reviewedAt time.Time) (*Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	change, err := r.change(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != ChangePending {
+		return nil, ErrReviewed
+	}
+	// A rolled back transaction leaves the change pending, like the job it didn't update
+	if tx, ok := memoryTxFrom(ctx); ok {
+		prev := copyChange(change)
+		tx.onRollback(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.changes[id] = prev
+		})
+	}
+	change.Status = status
+	change.ReviewedBy = reviewer
+	change.Comment = comment
+	change.ReviewedAt = &reviewedAt
+	return copyChange(change), nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryEnvironmentRepository keeps the environments in memory, it is meant for tests and local development
+type MemoryEnvironmentRepository struct {
+	mu           sync.RWMutex
+	environments map[string]scheduler.Environment
+}
+
+// NewMemoryEnvironmentRepository creates a repository without paused environments
+func NewMemoryEnvironmentRepository() *MemoryEnvironmentRepository {
+	return &MemoryEnvironmentRepository{environments: map[string]scheduler.Environment{}}
+}
+
+// environmentID identifies the environment name of tenant t in the repository
+func environmentID(t, name string) string {
+	return t + "/" + name
+}
+
+func (r *MemoryEnvironmentRepository) Get(ctx context.Context, name string) (*scheduler.Environment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t := tenant.FromContext(ctx)
+	e, ok := r.environments[environmentID(t, name)]
+	if !ok {
+		return &scheduler.Environment{Tenant: t, Name: name}, nil
+	}
+	return &e, nil
+}
+
+// list returns copies of the environments matching f ordered by name and tenant, the caller must hold the lock
+func (r *MemoryEnvironmentRepository) list(f func(e *scheduler.Environment) bool) []*scheduler.Environment {
+	environments := []*scheduler.Environment{}
+	for _, e := range r.environments {
+		e := e
+		if f(&e) {
+			environments = append(environments, &e)
+		}
+	}
+	sort.Slice(environments, func(i, j int) bool {
+		if environments[i].Name != environments[j].Name {
+			return environments[i].Name < environments[j].Name
+		}
+		return environments[i].Tenant < environments[j].Tenant
+	})
+	return environments
+}
+
+func (r *MemoryEnvironmentRepository) List(ctx context.Context) ([]*scheduler.Environment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t := tenant.FromContext(ctx)
+	return r.list(func(e *scheduler.Environment) bool { return e.Tenant == t }), nil
+}
+
+func (r *MemoryEnvironmentRepository) Set(ctx context.Context, env *scheduler.Environment) (*scheduler.Environment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *env
+	stored.Tenant = tenant.FromContext(ctx)
+	r.environments[environmentID(stored.Tenant, stored.Name)] = stored
+	return &stored, nil
+}
+
+func (r *MemoryEnvironmentRepository) Environments(ctx context.Context) ([]*scheduler.Environment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.list(func(e *scheduler.Environment) bool { return true }), nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLeaseRepository keeps leases in memory, it only coordinates electors of a single process
+type MemoryLeaseRepository struct {
+	mu     sync.Mutex
+	leases map[string]*lease
+}
+
+// lease is a lease held by holder until expiresAt
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// NewMemoryLeaseRepository creates a repository without leases
+func NewMemoryLeaseRepository() *MemoryLeaseRepository {
+	return &MemoryLeaseRepository{leases: map[string]*lease{}}
+}
+
+func (r *MemoryLeaseRepository) AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.leases[name]; ok && l.holder != holder && l.expiresAt.After(now) {
+		return false, nil
+	}
+	r.leases[name] = &lease{holder: holder, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (r *MemoryLeaseRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.leases[name]; ok && l.holder == holder {
+		delete(r.leases, name)
+	}
+	return nil
+}
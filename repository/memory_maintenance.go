@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+)
+
+// MemoryMaintenanceRepository keeps the maintenance mode in memory, it is meant for tests and local development
+type MemoryMaintenanceRepository struct {
+	mu          sync.RWMutex
+	maintenance scheduler.Maintenance
+}
+
+// NewMemoryMaintenanceRepository creates a repository with maintenance disabled
+func NewMemoryMaintenanceRepository() *MemoryMaintenanceRepository {
+	return &MemoryMaintenanceRepository{}
+}
+
+func (r *MemoryMaintenanceRepository) Maintenance(ctx context.Context) (*scheduler.Maintenance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := r.maintenance
+	return &c, nil
+}
+
+func (r *MemoryMaintenanceRepository) SetMaintenance(ctx context.Context, m *scheduler.Maintenance) (*scheduler.Maintenance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maintenance = *m
+	c := r.maintenance
+	return &c, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryOutboxRepository keeps the outbox in memory, it is meant for tests and local development
+type MemoryOutboxRepository struct {
+	mu     sync.Mutex
+	events map[string]*OutboxEvent
+}
+
+// NewMemoryOutboxRepository creates an empty outbox
+func NewMemoryOutboxRepository() *MemoryOutboxRepository {
+	return &MemoryOutboxRepository{events: map[string]*OutboxEvent{}}
+}
+
+func (r *MemoryOutboxRepository) Create(ctx context.Context, event *OutboxEvent) (*OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *event
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.remember(ctx, stored.ID)
+	r.events[stored.ID] = &stored
+	c := stored
+	return &c, nil
+}
+
+func (r *MemoryOutboxRepository) Pending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]string, 0, len(r.events))
+	for id := range r.events {
+		ids = append(ids, id)
+	}
+	// IDs start with their creation time
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	events := make([]*OutboxEvent, 0, len(ids))
+	for _, id := range ids {
+		c := *r.events[id]
+		events = append(events, &c)
+	}
+	return events, nil
+}
+
+func (r *MemoryOutboxRepository) Delete(ctx context.Context, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if event, ok := r.events[id]; ok && ofTenant(ctx, event.Tenant) {
+		r.remember(ctx, id)
+		delete(r.events, id)
+	}
+	return nil
+}
+
+// remember makes the transaction of ctx, if there is one, put back the event with the given id as it is now when it's
+// rolled back. The caller must hold the lock and call it before changing the outbox.
+func (r *MemoryOutboxRepository) remember(ctx context.Context, id string) {
+	tx, ok := memoryTxFrom(ctx)
+	if !ok {
+		return
+	}
+	prev, existed := r.events[id]
+	tx.onRollback(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.events[id] = prev
+		} else {
+			delete(r.events, id)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// retentionKey identifies the policy or the SLA of a job of a tenant
+type retentionKey struct {
+	tenant string
+	jobID  string
+}
+
+// MemoryRunRetentionRepository keeps run retention policies in memory, it is meant for tests and local development
+type MemoryRunRetentionRepository struct {
+	mu       sync.RWMutex
+	policies map[retentionKey]*RunRetentionPolicy
+}
+
+// NewMemoryRunRetentionRepository creates a repository without policies
+func NewMemoryRunRetentionRepository() *MemoryRunRetentionRepository {
+	return &MemoryRunRetentionRepository{policies: map[retentionKey]*RunRetentionPolicy{}}
+}
+
+func (r *MemoryRunRetentionRepository) Get(ctx context.Context, jobID string) (*RunRetentionPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	policy, ok := r.policies[retentionKey{tenant: tenant.FromContext(ctx), jobID: jobID}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *policy
+	return &c, nil
+}
+
+func (r *MemoryRunRetentionRepository) List(ctx context.Context) ([]*RunRetentionPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	policies := []*RunRetentionPolicy{}
+	for _, policy := range r.policies {
+		if ofTenant(ctx, policy.Tenant) {
+			c := *policy
+			policies = append(policies, &c)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Tenant != policies[j].Tenant {
+			return policies[i].Tenant < policies[j].Tenant
+		}
+		return policies[i].JobID < policies[j].JobID
+	})
+	return policies, nil
+}
+
+func (r *MemoryRunRetentionRepository) Set(ctx context.Context, policy *RunRetentionPolicy) (*RunRetentionPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *policy
+	stored.Tenant = tenant.FromContext(ctx)
+	r.policies[retentionKey{tenant: stored.Tenant, jobID: stored.JobID}] = &stored
+	c := stored
+	return &c, nil
+}
+
+func (r *MemoryRunRetentionRepository) Delete(ctx context.Context, jobID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := retentionKey{tenant: tenant.FromContext(ctx), jobID: jobID}
+	if _, ok := r.policies[key]; !ok {
+		return ErrNotFound
+	}
+	delete(r.policies, key)
+	return nil
+}
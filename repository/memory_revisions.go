@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryRevisionRepository keeps the revisions of jobs in memory, it is meant for tests and local development
+type MemoryRevisionRepository struct {
+	mu sync.RWMutex
+	// revisions are the revisions of every job by tenant and job id, oldest first
+	revisions map[string][]*Revision
+}
+
+// NewMemoryRevisionRepository creates a repository without revisions
+func NewMemoryRevisionRepository() *MemoryRevisionRepository {
+	return &MemoryRevisionRepository{revisions: map[string][]*Revision{}}
+}
+
+// revisionKey returns the key of the revisions of the job with jobID in the tenant of ctx
+func revisionKey(ctx context.Context, jobID string) string {
+	return tenant.FromContext(ctx) + "/" + jobID
+}
+
+// copyRevision returns a copy of revision, so callers can't change stored revisions
+func copyRevision(revision *Revision) *Revision {
+	r := *revision
+	r.Job = append([]byte{}, revision.Job...)
+	r.Changes = append([]AuditChange{}, revision.Changes...)
+	return &r
+}
+
+func (r *MemoryRevisionRepository) Create(ctx context.Context, revision *Revision) (*Revision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := revisionKey(ctx, revision.JobID)
+	stored := copyRevision(revision)
+	stored.ID = newID()
+	stored.Revision = int64(len(r.revisions[key]) + 1)
+	stored.Tenant = tenant.FromContext(ctx)
+	r.revisions[key] = append(r.revisions[key], stored)
+	// A rolled back transaction drops the revision, like the update it recorded
+	if tx, ok := memoryTxFrom(ctx); ok {
+		tx.onRollback(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			revisions := r.revisions[key]
+			for i, rev := range revisions {
+				if rev.ID == stored.ID {
+					r.revisions[key] = append(revisions[:i:i], revisions[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+	return copyRevision(stored), nil
+}
+
+func (r *MemoryRevisionRepository) Get(ctx context.Context, jobID string, revision int64) (*Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, rev := range r.revisions[revisionKey(ctx, jobID)] {
+		if rev.Revision == revision {
+			return copyRevision(rev), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *MemoryRevisionRepository) List(ctx context.Context, jobID string) ([]*Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stored := r.revisions[revisionKey(ctx, jobID)]
+	revisions := make([]*Revision, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		revisions = append(revisions, copyRevision(stored[i]))
+	}
+	return revisions, nil
+}
@@ -0,0 +1,391 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryRunRepository keeps runs in memory, it is meant for tests and local development
+type MemoryRunRepository struct {
+	mu   sync.RWMutex
+	runs map[string]*Run
+}
+
+// NewMemoryRunRepository creates an empty repository
+func NewMemoryRunRepository() *MemoryRunRepository {
+	return &MemoryRunRepository{runs: map[string]*Run{}}
+}
+
+// copyRun returns a copy of run, so callers can't change stored runs
+func copyRun(run *Run) *Run {
+	c := *run
+	if run.StartTime != nil {
+		start := *run.StartTime
+		c.StartTime = &start
+	}
+	if run.EndTime != nil {
+		end := *run.EndTime
+		c.EndTime = &end
+	}
+	if run.RetryAt != nil {
+		retryAt := *run.RetryAt
+		c.RetryAt = &retryAt
+	}
+	return &c
+}
+
+// remember makes the transaction of ctx, if there is one, put back the stored run with the given id as it is now
+// when it's rolled back. The caller must hold the write lock and call it before changing the run.
+func (r *MemoryRunRepository) remember(ctx context.Context, id string) {
+	tx, ok := memoryTxFrom(ctx)
+	if !ok {
+		return
+	}
+	prev, existed := r.runs[id]
+	if existed {
+		prev = copyRun(prev)
+	}
+	tx.onRollback(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.runs[id] = prev
+		} else {
+			delete(r.runs, id)
+		}
+	})
+}
+
+func (r *MemoryRunRepository) Create(ctx context.Context, run *Run) (*Run, error) {
+	if err := checkID(run.JobID); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyRun(run)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	if stored.CycleID == "" {
+		stored.CycleID = stored.ID
+	}
+	if stored.Attempt == 1 {
+		for _, other := range r.runs {
+			if other.JobID == stored.JobID && other.CycleID == stored.CycleID && other.Attempt == 1 && other.Tenant == stored.Tenant {
+				return nil, ErrRunExists
+			}
+		}
+	}
+	r.remember(ctx, stored.ID)
+	r.runs[stored.ID] = stored
+	return copyRun(stored), nil
+}
+
+func (r *MemoryRunRepository) Put(ctx context.Context, run *Run, replace bool) error {
+	for _, id := range []string{run.ID, run.JobID} {
+		if err := checkID(id); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyRun(run)
+	stored.Tenant = tenant.FromContext(ctx)
+	if existing, ok := r.runs[stored.ID]; ok && (!replace || existing.Tenant != stored.Tenant) {
+		return ErrExists
+	}
+	if stored.CycleID != "" {
+		for _, other := range r.runs {
+			if other.ID != stored.ID && other.JobID == stored.JobID && other.CycleID == stored.CycleID &&
+				other.Attempt == stored.Attempt && other.Tenant == stored.Tenant {
+				return ErrRunExists
+			}
+		}
+	}
+	r.remember(ctx, stored.ID)
+	r.runs[stored.ID] = stored
+	return nil
+}
+
+func (r *MemoryRunRepository) Update(ctx context.Context, run *Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.runs[run.ID]
+	if !ok || !ofTenant(ctx, stored.Tenant) {
+		return ErrNotFound
+	}
+	updated := copyRun(run)
+	// Only the outcome of a run changes
+	updated.JobID = stored.JobID
+	updated.Tenant = stored.Tenant
+	updated.QueuedAt = stored.QueuedAt
+	updated.Attempt = stored.Attempt
+	updated.RetryAt = stored.RetryAt
+	updated.CycleID = stored.CycleID
+	updated.Priority = stored.Priority
+	updated.Trigger = stored.Trigger
+	updated.TriggeredBy = stored.TriggeredBy
+	r.remember(ctx, run.ID)
+	r.runs[run.ID] = updated
+	return nil
+}
+
+func (r *MemoryRunRepository) Get(ctx context.Context, id string) (*Run, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	run, ok := r.runs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !ofTenant(ctx, run.Tenant) {
+		return nil, ErrOtherTenant
+	}
+	return copyRun(run), nil
+}
+
+func (r *MemoryRunRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Run, error) {
+	for _, id := range []string{jobID, before} {
+		if id == "" {
+			continue
+		}
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.runs))
+	for id, run := range r.runs {
+		if (jobID == "" || run.JobID == jobID) && (before == "" || id < before) && ofTenant(ctx, run.Tenant) {
+			ids = append(ids, id)
+		}
+	}
+	// Newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	runs := make([]*Run, 0, len(ids))
+	for _, id := range ids {
+		runs = append(runs, copyRun(r.runs[id]))
+	}
+	return runs, nil
+}
+
+func (r *MemoryRunRepository) Prune(ctx context.Context, jobID string, except []string, keep int, maxAge time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	skip := map[string]bool{}
+	for _, id := range except {
+		skip[id] = true
+	}
+	t := tenant.FromContext(ctx)
+	byJob := map[string][]*Run{}
+	for _, run := range r.runs {
+		if run.Tenant == t && (jobID == "" || run.JobID == jobID) && !skip[run.JobID] && runFinished(run.Status) {
+			byJob[run.JobID] = append(byJob[run.JobID], run)
+		}
+	}
+	cutoff := time.Now().Add(-maxAge)
+	pruned := int64(0)
+	for _, runs := range byJob {
+		// Newest first
+		sort.Slice(runs, func(i, j int) bool { return runs[i].ID > runs[j].ID })
+		for i, run := range runs {
+			if (keep > 0 && i >= keep) || (maxAge > 0 && run.QueuedAt.Before(cutoff)) {
+				r.remember(ctx, run.ID)
+				delete(r.runs, run.ID)
+				pruned++
+			}
+		}
+	}
+	return pruned, nil
+}
+
+func (r *MemoryRunRepository) DueRetries(ctx context.Context, now time.Time) ([]*scheduler.DueRetry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	retries := []*scheduler.DueRetry{}
+	for _, run := range r.runs {
+		if run.Status != RunWaiting || run.RetryAt == nil || run.RetryAt.After(now) || !ofTenant(ctx, run.Tenant) {
+			continue
+		}
+		retries = append(retries, &scheduler.DueRetry{
+			RunID:   run.ID,
+			JobID:   run.JobID,
+			Tenant:  run.Tenant,
+			Attempt: run.Attempt,
+			RetryAt: *run.RetryAt,
+		})
+	}
+	return retries, nil
+}
+
+func (r *MemoryRunRepository) ClaimRetry(ctx context.Context, id string) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.runs[id]
+	if !ok || run.Status != RunWaiting || !ofTenant(ctx, run.Tenant) {
+		return false, nil
+	}
+	r.remember(ctx, id)
+	run.Status = RunQueued
+	return true, nil
+}
+
+func (r *MemoryRunRepository) Stats(ctx context.Context, jobID string, from, to time.Time) (*RunStats, error) {
+	if err := checkID(jobID); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := &RunStats{Counts: map[string]int64{}}
+	durations := []time.Duration{}
+	var total time.Duration
+	for _, run := range r.runs {
+		if run.JobID != jobID || run.QueuedAt.Before(from) || !run.QueuedAt.Before(to) || !ofTenant(ctx, run.Tenant) {
+			continue
+		}
+		stats.Counts[run.Status]++
+		if run.StartTime != nil && run.EndTime != nil {
+			durations = append(durations, run.Duration)
+			total += run.Duration
+		}
+	}
+	stats.Finished = int64(len(durations))
+	if stats.Finished == 0 {
+		return stats, nil
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats.Mean = total / time.Duration(stats.Finished)
+	stats.P50 = durations[percentileRank(50, stats.Finished)]
+	stats.P95 = durations[percentileRank(95, stats.Finished)]
+	stats.P99 = durations[percentileRank(99, stats.Finished)]
+	return stats, nil
+}
+
+func (r *MemoryRunRepository) Usage(ctx context.Context, jobIDs []string, from, to time.Time) (*RunUsage, error) {
+	ids := map[string]bool{}
+	for _, id := range jobIDs {
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	usage := &RunUsage{Counts: map[string]int64{}}
+	for _, run := range r.runs {
+		if !ids[run.JobID] || run.QueuedAt.Before(from) || !run.QueuedAt.Before(to) || !ofTenant(ctx, run.Tenant) {
+			continue
+		}
+		usage.Counts[run.Status]++
+		usage.ComputeTime += run.Duration
+	}
+	return usage, nil
+}
+
+func (r *MemoryRunRepository) Costs(ctx context.Context, jobIDs []string, from, to time.Time) ([]*RunCost, error) {
+	ids := map[string]bool{}
+	for _, id := range jobIDs {
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	type costKey struct {
+		jobID string
+		month int64
+	}
+	costs := map[costKey]*RunCost{}
+	for _, run := range r.runs {
+		if !ids[run.JobID] || run.QueuedAt.Before(from) || !run.QueuedAt.Before(to) || !ofTenant(ctx, run.Tenant) {
+			continue
+		}
+		month := TruncateMonth(run.QueuedAt)
+		key := costKey{jobID: run.JobID, month: month.Unix()}
+		c, ok := costs[key]
+		if !ok {
+			c = &RunCost{JobID: run.JobID, Month: month}
+			costs[key] = c
+		}
+		c.Runs++
+		c.ComputeTime += run.Duration
+		c.Cost += run.Cost
+	}
+	res := make([]*RunCost, 0, len(costs))
+	for _, c := range costs {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].JobID != res[j].JobID {
+			return res[i].JobID < res[j].JobID
+		}
+		return res[i].Month.Before(res[j].Month)
+	})
+	return res, nil
+}
+
+func (r *MemoryRunRepository) TimeSeries(ctx context.Context, jobID string, from, to time.Time, bucket string, loc *time.Location) ([]*RunBucket, error) {
+	if err := checkID(jobID); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// Keyed by the UnixNano of the bucket start, equal times in different locations aren't equal map keys
+	buckets := map[int64]*RunBucket{}
+	totals := map[int64]time.Duration{}
+	for _, run := range r.runs {
+		if run.JobID != jobID || run.QueuedAt.Before(from) || !run.QueuedAt.Before(to) || !ofTenant(ctx, run.Tenant) {
+			continue
+		}
+		start := TruncateBucket(run.QueuedAt, bucket, loc)
+		b, ok := buckets[start.UnixNano()]
+		if !ok {
+			b = &RunBucket{Start: start, Counts: map[string]int64{}}
+			buckets[start.UnixNano()] = b
+		}
+		b.Counts[run.Status]++
+		if run.StartTime != nil && run.EndTime != nil {
+			b.Finished++
+			totals[start.UnixNano()] += run.Duration
+		}
+	}
+	series := make([]*RunBucket, 0, len(buckets))
+	for key, b := range buckets {
+		if b.Finished > 0 {
+			b.Mean = totals[key] / time.Duration(b.Finished)
+		}
+		series = append(series, b)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Start.Before(series[j].Start) })
+	return series, nil
+}
+
+func (r *MemoryRunRepository) CycleRuns(ctx context.Context, cycleID string) ([]*Run, error) {
+	if err := checkID(cycleID); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	runs := []*Run{}
+	for _, run := range r.runs {
+		if run.CycleID == cycleID && ofTenant(ctx, run.Tenant) {
+			runs = append(runs, copyRun(run))
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+	return runs, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemorySecretRepository keeps secrets in memory, it is meant for tests and local development
+type MemorySecretRepository struct {
+	mu      sync.RWMutex
+	secrets map[string]*Secret
+}
+
+// NewMemorySecretRepository creates a repository without secrets
+func NewMemorySecretRepository() *MemorySecretRepository {
+	return &MemorySecretRepository{secrets: map[string]*Secret{}}
+}
+
+// copySecret returns a copy of secret, so callers can't change stored secrets
+func copySecret(secret *Secret) *Secret {
+	s := *secret
+	s.Value = append([]byte{}, secret.Value...)
+	return &s
+}
+
+func (r *MemorySecretRepository) Create(ctx context.Context, secret *Secret) (*Secret, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := tenant.FromContext(ctx)
+	for _, s := range r.secrets {
+		if s.Tenant == t && s.Owner == secret.Owner && s.Name == secret.Name {
+			return nil, ErrNameTaken
+		}
+	}
+	stored := copySecret(secret)
+	stored.ID = newID()
+	stored.Tenant = t
+	r.secrets[stored.ID] = stored
+	return copySecret(stored), nil
+}
+
+// secret returns the stored secret with id matching q in the tenant of ctx, the caller must hold the lock
+func (r *MemorySecretRepository) secret(ctx context.Context, id string, q Query) (*Secret, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	secret, ok := r.secrets[id]
+	if !ok || !ofTenant(ctx, secret.Tenant) || !q.OwnedBy(secret.Owner) {
+		return nil, ErrNotFound
+	}
+	return secret, nil
+}
+
+func (r *MemorySecretRepository) Get(ctx context.Context, id string, q Query) (*Secret, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	secret, err := r.secret(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	return copySecret(secret), nil
+}
+
+// find returns copies of the secrets in the tenant of ctx matching f oldest first, the caller must hold the lock
+func (r *MemorySecretRepository) find(ctx context.Context, f func(secret *Secret) bool) []*Secret {
+	secrets := []*Secret{}
+	for _, secret := range r.secrets {
+		if ofTenant(ctx, secret.Tenant) && f(secret) {
+			secrets = append(secrets, copySecret(secret))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].ID < secrets[j].ID })
+	return secrets
+}
+
+func (r *MemorySecretRepository) List(ctx context.Context, q Query) ([]*Secret, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(ctx, func(secret *Secret) bool { return q.OwnedBy(secret.Owner) }), nil
+}
+
+func (r *MemorySecretRepository) Update(ctx context.Context, secret *Secret, q Query) (*Secret, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.secret(ctx, secret.ID, q)
+	if err != nil {
+		return nil, err
+	}
+	stored.Description = secret.Description
+	stored.Value = append([]byte{}, secret.Value...)
+	stored.UpdatedAt = secret.UpdatedAt
+	return copySecret(stored), nil
+}
+
+func (r *MemorySecretRepository) Delete(ctx context.Context, id string, q Query) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.secret(ctx, id, q); err != nil {
+		return err
+	}
+	delete(r.secrets, id)
+	return nil
+}
+
+func (r *MemorySecretRepository) Named(ctx context.Context, owner string, names []string) ([]*Secret, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	return r.find(ctx, func(secret *Secret) bool { return secret.Owner == owner && wanted[secret.Name] }), nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemorySLARepository keeps SLAs and their breaches in memory, it is meant for tests and local development
+type MemorySLARepository struct {
+	mu       sync.RWMutex
+	slas     map[retentionKey]*SLA
+	breaches map[string]*SLABreach
+}
+
+// NewMemorySLARepository creates a repository without SLAs
+func NewMemorySLARepository() *MemorySLARepository {
+	return &MemorySLARepository{slas: map[retentionKey]*SLA{}, breaches: map[string]*SLABreach{}}
+}
+
+func (r *MemorySLARepository) Get(ctx context.Context, jobID string) (*SLA, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sla, ok := r.slas[retentionKey{tenant: tenant.FromContext(ctx), jobID: jobID}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *sla
+	return &c, nil
+}
+
+func (r *MemorySLARepository) List(ctx context.Context) ([]*SLA, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	slas := []*SLA{}
+	for _, sla := range r.slas {
+		if ofTenant(ctx, sla.Tenant) {
+			c := *sla
+			slas = append(slas, &c)
+		}
+	}
+	sort.Slice(slas, func(i, j int) bool {
+		if slas[i].Tenant != slas[j].Tenant {
+			return slas[i].Tenant < slas[j].Tenant
+		}
+		return slas[i].JobID < slas[j].JobID
+	})
+	return slas, nil
+}
+
+func (r *MemorySLARepository) Set(ctx context.Context, sla *SLA) (*SLA, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *sla
+	stored.Tenant = tenant.FromContext(ctx)
+	r.slas[retentionKey{tenant: stored.Tenant, jobID: stored.JobID}] = &stored
+	c := stored
+	return &c, nil
+}
+
+func (r *MemorySLARepository) Delete(ctx context.Context, jobID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := retentionKey{tenant: tenant.FromContext(ctx), jobID: jobID}
+	if _, ok := r.slas[key]; !ok {
+		return ErrNotFound
+	}
+	delete(r.slas, key)
+	return nil
+}
+
+func (r *MemorySLARepository) AddBreach(ctx context.Context, breach *SLABreach) (*SLABreach, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *breach
+	stored.Tenant = tenant.FromContext(ctx)
+	for _, b := range r.breaches {
+		if b.Tenant == stored.Tenant && b.JobID == stored.JobID && b.key() == stored.key() {
+			return nil, ErrExists
+		}
+	}
+	stored.ID = newID()
+	r.breaches[stored.ID] = &stored
+	c := stored
+	return &c, nil
+}
+
+func (r *MemorySLARepository) ListBreaches(ctx context.Context, jobID, before string, limit int) ([]*SLABreach, error) {
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := []string{}
+	for id, breach := range r.breaches {
+		if (jobID == "" || breach.JobID == jobID) && (before == "" || id < before) && ofTenant(ctx, breach.Tenant) {
+			ids = append(ids, id)
+		}
+	}
+	// Newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	breaches := make([]*SLABreach, 0, len(ids))
+	for _, id := range ids {
+		c := *r.breaches[id]
+		breaches = append(breaches, &c)
+	}
+	return breaches, nil
+}
+
+func (r *MemorySLARepository) CountBreaches(ctx context.Context, jobID string, from, to time.Time) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var n int64
+	for _, breach := range r.breaches {
+		if breach.JobID == jobID && ofTenant(ctx, breach.Tenant) && !breach.DetectedAt.Before(from) && breach.DetectedAt.Before(to) {
+			n++
+		}
+	}
+	return n, nil
+}
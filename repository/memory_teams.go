@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryTeamRepository keeps teams in memory, it is meant for tests and local development
+type MemoryTeamRepository struct {
+	mu    sync.RWMutex
+	teams map[string]*Team
+}
+
+// NewMemoryTeamRepository creates a repository without teams
+func NewMemoryTeamRepository() *MemoryTeamRepository {
+	return &MemoryTeamRepository{teams: map[string]*Team{}}
+}
+
+// copyTeam returns a copy of team, so callers can't change stored teams
+func copyTeam(team *Team) *Team {
+	c := *team
+	c.Members = append([]string{}, team.Members...)
+	c.Roles = append([]string{}, team.Roles...)
+	return &c
+}
+
+func (r *MemoryTeamRepository) Create(ctx context.Context, team *Team) (*Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyTeam(team)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.teams[stored.ID] = stored
+	return copyTeam(stored), nil
+}
+
+// team returns the stored team with id of the tenant of ctx, the caller must hold the lock
+func (r *MemoryTeamRepository) team(ctx context.Context, id string) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	team, ok := r.teams[id]
+	if !ok || !ofTenant(ctx, team.Tenant) {
+		return nil, ErrNotFound
+	}
+	return team, nil
+}
+
+func (r *MemoryTeamRepository) Get(ctx context.Context, id string) (*Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	team, err := r.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return copyTeam(team), nil
+}
+
+func (r *MemoryTeamRepository) List(ctx context.Context, member string) ([]*Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	teams := []*Team{}
+	for _, team := range r.teams {
+		if ofTenant(ctx, team.Tenant) && (member == "" || team.isMember(member)) {
+			teams = append(teams, copyTeam(team))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+	return teams, nil
+}
+
+func (r *MemoryTeamRepository) Rename(ctx context.Context, id, name string, updatedAt time.Time) (*Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team, err := r.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	team.Name = name
+	team.UpdatedAt = updatedAt
+	return copyTeam(team), nil
+}
+
+func (r *MemoryTeamRepository) SetRoles(ctx context.Context, id string, roles []string, updatedAt time.Time) (*Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team, err := r.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	team.Roles = append([]string{}, roles...)
+	team.UpdatedAt = updatedAt
+	return copyTeam(team), nil
+}
+
+func (r *MemoryTeamRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.team(ctx, id); err != nil {
+		return err
+	}
+	delete(r.teams, id)
+	return nil
+}
+
+func (r *MemoryTeamRepository) AddMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team, err := r.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !team.isMember(userID) {
+		team.Members = append(team.Members, userID)
+		team.UpdatedAt = updatedAt
+	}
+	return copyTeam(team), nil
+}
+
+func (r *MemoryTeamRepository) RemoveMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team, err := r.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if members := team.withoutMember(userID); len(members) != len(team.Members) {
+		team.Members = members
+		team.UpdatedAt = updatedAt
+	}
+	return copyTeam(team), nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryTemplateRepository keeps job templates in memory, it is meant for tests and local development
+type MemoryTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[string]*JobTemplate
+}
+
+// NewMemoryTemplateRepository creates a repository without templates
+func NewMemoryTemplateRepository() *MemoryTemplateRepository {
+	return &MemoryTemplateRepository{templates: map[string]*JobTemplate{}}
+}
+
+// copyTemplate returns a copy of template, so callers can't change stored templates
+func copyTemplate(template *JobTemplate) *JobTemplate {
+	c := *template
+	c.Parameters = append([]TemplateParameter{}, template.Parameters...)
+	return &c
+}
+
+func (r *MemoryTemplateRepository) Create(ctx context.Context, template *JobTemplate) (*JobTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyTemplate(template)
+	stored.ID = newID()
+	stored.Version = 1
+	stored.Tenant = tenant.FromContext(ctx)
+	r.templates[stored.ID] = stored
+	return copyTemplate(stored), nil
+}
+
+// template returns the stored template with id matching q in the tenant of ctx, the caller must hold the lock
+func (r *MemoryTemplateRepository) template(ctx context.Context, id string, q Query) (*JobTemplate, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	template, ok := r.templates[id]
+	if !ok || !ofTenant(ctx, template.Tenant) || !q.OwnedBy(template.Owner) {
+		return nil, ErrNotFound
+	}
+	return template, nil
+}
+
+func (r *MemoryTemplateRepository) Get(ctx context.Context, id string, q Query) (*JobTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	template, err := r.template(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	return copyTemplate(template), nil
+}
+
+func (r *MemoryTemplateRepository) List(ctx context.Context, q Query) ([]*JobTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	templates := []*JobTemplate{}
+	for _, template := range r.templates {
+		if ofTenant(ctx, template.Tenant) && q.OwnedBy(template.Owner) {
+			templates = append(templates, copyTemplate(template))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(templates, func(i, j int) bool { return templates[i].ID < templates[j].ID })
+	return templates, nil
+}
+
+func (r *MemoryTemplateRepository) Update(ctx context.Context, template *JobTemplate, q Query) (*JobTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.template(ctx, template.ID, q)
+	if err != nil {
+		return nil, err
+	}
+	stored.Name = template.Name
+	stored.Description = template.Description
+	stored.Owner = template.Owner
+	stored.Parameters = append([]TemplateParameter{}, template.Parameters...)
+	stored.Job = template.Job
+	stored.UpdatedAt = template.UpdatedAt
+	stored.Version++
+	return copyTemplate(stored), nil
+}
+
+func (r *MemoryTemplateRepository) Delete(ctx context.Context, id string, q Query) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.template(ctx, id, q); err != nil {
+		return err
+	}
+	delete(r.templates, id)
+	return nil
+}
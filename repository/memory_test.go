@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// storeJobs creates jobs in a new repository and returns it with the created jobs
+func storeJobs(t *testing.T, jobs ...*Job) (*MemoryJobRepository, []*Job) {
+	t.Helper()
+	r := NewMemoryJobRepository()
+	created := make([]*Job, len(jobs))
+	for i, job := range jobs {
+		var err error
+		if created[i], err = r.Create(context.Background(), job); err != nil {
+			t.Fatalf("Create(%q) failed: %v", job.Name, err)
+		}
+	}
+	return r, created
+}
+
+func TestMemoryCreate(t *testing.T) {
+	ctx := context.Background()
+	r, jobs := storeJobs(t, &Job{Name: "backup", Owner: "alice", Labels: map[string]string{"team": "data"}})
+	job := jobs[0]
+	if err := checkID(job.ID); err != nil {
+		t.Fatalf("Create returned the invalid id %q", job.ID)
+	}
+	// Changing the returned job must not change the stored one
+	job.Labels["team"] = "web"
+	stored, err := r.Get(ctx, job.ID, Query{})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.Name != "backup" || stored.Labels["team"] != "data" {
+		t.Errorf("Get returned %+v, want the job as it was created", stored)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		job  *Job
+		want error
+	}{
+		{"name of the same owner", ctx, &Job{Name: "backup", Owner: "alice"}, ErrNameTaken},
+		{"name of another owner", ctx, &Job{Name: "backup", Owner: "bob"}, nil},
+		{"name in another environment", ctx, &Job{Name: "backup", Owner: "alice", Environment: "staging"}, nil},
+		{"name in another tenant", tenant.NewContext(ctx, "acme"), &Job{Name: "backup", Owner: "alice"}, nil},
+		{"new idempotency key", ctx, &Job{Name: "report", Owner: "alice", IdempotencyKey: "k1"}, nil},
+		{"used idempotency key", ctx, &Job{Name: "export", Owner: "alice", IdempotencyKey: "k1"}, ErrIdempotencyKeyUsed},
+		{"idempotency key of another owner", ctx, &Job{Name: "export", Owner: "bob", IdempotencyKey: "k1"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := r.Create(tt.ctx, tt.job); err != tt.want {
+				t.Errorf("Create() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryGet(t *testing.T) {
+	r, jobs := storeJobs(t, &Job{Name: "backup", Owner: "alice"}, &Job{Name: "team job", Owner: TeamOwnerPrefix + "t1"})
+	deleted, err := r.Create(context.Background(), &Job{Name: "old", Owner: "alice"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := r.Delete(context.Background(), deleted.ID, Query{}, time.Now()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	acme, err := r.Create(tenant.NewContext(context.Background(), "acme"), &Job{Name: "backup", Owner: "alice"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		id   string
+		q    Query
+		want error
+	}{
+		{"any owner", context.Background(), jobs[0].ID, Query{}, nil},
+		{"owner", context.Background(), jobs[0].ID, Query{Owner: "alice"}, nil},
+		{"other owner", context.Background(), jobs[0].ID, Query{Owner: "bob"}, ErrNotFound},
+		{"team of the owner", context.Background(), jobs[1].ID, Query{Owner: "bob", Teams: []string{"t1"}}, nil},
+		{"other team", context.Background(), jobs[1].ID, Query{Owner: "bob", Teams: []string{"t2"}}, ErrNotFound},
+		{"deleted", context.Background(), deleted.ID, Query{}, ErrNotFound},
+		{"deleted included", context.Background(), deleted.ID, Query{IncludeDeleted: true}, nil},
+		{"missing", context.Background(), newID(), Query{}, ErrNotFound},
+		{"invalid id", context.Background(), "nope", Query{}, ErrInvalidID},
+		{"same tenant", tenant.NewContext(context.Background(), "acme"), acme.ID, Query{}, nil},
+		{"other tenant", tenant.NewContext(context.Background(), "other"), acme.ID, Query{}, ErrOtherTenant},
+		{"no tenant", context.Background(), acme.ID, Query{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := r.Get(tt.ctx, tt.id, tt.q)
+			if err != tt.want {
+				t.Fatalf("Get() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && job.ID != tt.id {
+				t.Errorf("Get() returned job %s, want %s", job.ID, tt.id)
+			}
+		})
+	}
+}
+
+func TestMemoryList(t *testing.T) {
+	ctx := context.Background()
+	r, jobs := storeJobs(t,
+		&Job{Name: "a", Owner: "alice", Labels: map[string]string{"team": "data"}},
+		&Job{Name: "b", Owner: "alice", Environment: "staging"},
+		&Job{Name: "c", Owner: "bob", Labels: map[string]string{"team": "data"}},
+		&Job{Name: "d", Owner: "alice"},
+	)
+	if _, err := r.Delete(ctx, jobs[3].ID, Query{}, time.Now()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	archivedAt := time.Now()
+	if _, err := r.Update(ctx, jobs[2].ID, Query{}, &JobUpdate{SetArchivedAt: true, ArchivedAt: &archivedAt}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	team, err := labels.Parse("team=data")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	tests := []struct {
+		name  string
+		q     Query
+		after string
+		limit int
+		want  []string
+	}{
+		{"everything", Query{}, "", 10, []string{"a", "b", "c"}},
+		{"limit", Query{}, "", 2, []string{"a", "b"}},
+		{"after", Query{}, jobs[0].ID, 10, []string{"b", "c"}},
+		{"owner", Query{Owner: "alice"}, "", 10, []string{"a", "b"}},
+		{"deleted included", Query{Owner: "alice", IncludeDeleted: true}, "", 10, []string{"a", "b", "d"}},
+		{"labels", Query{Labels: team}, "", 10, []string{"a", "c"}},
+		{"environment", Query{Environment: "staging"}, "", 10, []string{"b"}},
+		{"without archived", Query{Archived: WithoutArchived}, "", 10, []string{"a", "b"}},
+		{"only archived", Query{Archived: OnlyArchived}, "", 10, []string{"c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed, err := r.List(ctx, tt.q, tt.after, tt.limit)
+			if err != nil {
+				t.Fatalf("List() failed: %v", err)
+			}
+			names := make([]string, len(listed))
+			for i, job := range listed {
+				names[i] = job.Name
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("List() = %v, want %v", names, tt.want)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Fatalf("List() = %v, want %v", names, tt.want)
+				}
+			}
+			count, err := r.Count(ctx, tt.q)
+			if err != nil {
+				t.Fatalf("Count() failed: %v", err)
+			}
+			// Count ignores the page
+			if tt.after == "" && tt.limit >= len(jobs) && count != int64(len(tt.want)) {
+				t.Errorf("Count() = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+	if _, err := r.List(ctx, Query{}, "nope", 10); err != ErrInvalidID {
+		t.Errorf("List() after an invalid id failed with %v, want %v", err, ErrInvalidID)
+	}
+}
+
+func TestMemoryUpdate(t *testing.T) {
+	ctx := context.Background()
+	r, jobs := storeJobs(t, &Job{Name: "backup", Owner: "alice", Description: "nightly"}, &Job{Name: "report", Owner: "alice"})
+	name, description := "export", "weekly"
+	updatedAt := time.Now().UTC()
+	tests := []struct {
+		name   string
+		id     string
+		q      Query
+		update *JobUpdate
+		want   error
+	}{
+		{"description", jobs[0].ID, Query{Owner: "alice"}, &JobUpdate{Description: &description, UpdatedAt: updatedAt}, nil},
+		{"other owner", jobs[0].ID, Query{Owner: "bob"}, &JobUpdate{Description: &description}, ErrNotFound},
+		{"taken name", jobs[0].ID, Query{}, &JobUpdate{Name: &jobs[1].Name}, ErrNameTaken},
+		{"own name", jobs[0].ID, Query{}, &JobUpdate{Name: &jobs[0].Name, UpdatedAt: updatedAt}, nil},
+		{"missing", newID(), Query{}, &JobUpdate{Name: &name}, ErrNotFound},
+		{"invalid id", "nope", Query{}, &JobUpdate{Name: &name}, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := r.Update(ctx, tt.id, tt.q, tt.update)
+			if err != tt.want {
+				t.Fatalf("Update() error = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			stored, err := r.Get(ctx, tt.id, Query{})
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if updated.Description != description || stored.Description != description || !stored.UpdatedAt.Equal(updatedAt) {
+				t.Errorf("Update() stored %+v, want the description %q updated at %v", stored, description, updatedAt)
+			}
+		})
+	}
+}
+
+func TestMemoryDeleteRestore(t *testing.T) {
+	ctx := context.Background()
+	r, jobs := storeJobs(t, &Job{Name: "backup", Owner: "alice"})
+	id := jobs[0].ID
+	deletedAt := time.Now().UTC()
+
+	if deleted, err := r.Delete(ctx, id, Query{Owner: "bob"}, deletedAt); deleted || err != nil {
+		t.Errorf("Delete() of another owner's job = %v, %v, want false, nil", deleted, err)
+	}
+	if _, err := r.Restore(ctx, id, Query{}, time.Now()); err != ErrNotFound {
+		t.Errorf("Restore() of a job that isn't deleted failed with %v, want %v", err, ErrNotFound)
+	}
+	if deleted, err := r.Delete(ctx, id, Query{Owner: "alice"}, deletedAt); !deleted || err != nil {
+		t.Fatalf("Delete() = %v, %v, want true, nil", deleted, err)
+	}
+	// Deleting again keeps the first time
+	if deleted, err := r.Delete(ctx, id, Query{}, deletedAt.Add(time.Hour)); deleted || err != nil {
+		t.Errorf("second Delete() = %v, %v, want false, nil", deleted, err)
+	}
+	stored, err := r.Get(ctx, id, Query{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.DeletedAt == nil || !stored.DeletedAt.Equal(deletedAt) {
+		t.Errorf("job was deleted at %v, want %v", stored.DeletedAt, deletedAt)
+	}
+
+	if _, err := r.Restore(ctx, id, Query{Owner: "bob"}, time.Now()); err != ErrNotFound {
+		t.Errorf("Restore() of another owner's job failed with %v, want %v", err, ErrNotFound)
+	}
+	restoredAt := deletedAt.Add(time.Minute)
+	restored, err := r.Restore(ctx, id, Query{Owner: "alice"}, restoredAt)
+	if err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if restored.DeletedAt != nil || !restored.UpdatedAt.Equal(restoredAt) {
+		t.Errorf("Restore() = %+v, want it not deleted and updated at %v", restored, restoredAt)
+	}
+	if _, err := r.Get(ctx, id, Query{}); err != nil {
+		t.Errorf("Get() of the restored job failed: %v", err)
+	}
+
+	// Purging only removes jobs deleted before the given time
+	if _, err := r.Delete(ctx, id, Query{}, deletedAt); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if purged, err := r.Purge(ctx, deletedAt); purged != 0 || err != nil {
+		t.Errorf("Purge() before the deletion = %d, %v, want 0, nil", purged, err)
+	}
+	if purged, err := r.Purge(ctx, deletedAt.Add(time.Second)); purged != 1 || err != nil {
+		t.Errorf("Purge() after the deletion = %d, %v, want 1, nil", purged, err)
+	}
+	if _, err := r.Get(ctx, id, Query{IncludeDeleted: true}); err != ErrNotFound {
+		t.Errorf("Get() of the purged job failed with %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestMemorySetStatus(t *testing.T) {
+	ctx := context.Background()
+	r, jobs := storeJobs(t, &Job{Name: "backup", Owner: "alice", Status: JobPending})
+	id := jobs[0].ID
+	tests := []struct {
+		name     string
+		q        Query
+		from, to string
+		want     error
+	}{
+		{"other owner", Query{Owner: "bob"}, JobPending, JobPaused, ErrNotFound},
+		{"pause", Query{Owner: "alice"}, JobPending, JobPaused, nil},
+		{"changed concurrently", Query{}, JobPending, JobPaused, ErrStatusConflict},
+		{"resume", Query{}, JobPaused, JobPending, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := r.SetStatus(ctx, id, tt.q, tt.from, tt.to, time.Now())
+			if err != tt.want {
+				t.Fatalf("SetStatus() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && job.Status != tt.to {
+				t.Errorf("SetStatus() returned status %s, want %s", job.Status, tt.to)
+			}
+		})
+	}
+}
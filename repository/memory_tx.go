@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryTxKey is the context key of the transaction of MemoryTransactor
+type memoryTxKey struct{}
+
+// memoryTx collects what the memory repositories did in a transaction, undo puts back the changes when it's rolled
+// back and commit holds what has to wait until it's committed, like telling watchers
+type memoryTx struct {
+	mu     sync.Mutex
+	undo   []func()
+	commit []func()
+}
+
+// memoryTxFrom returns the transaction ctx was created with by MemoryTransactor
+func memoryTxFrom(ctx context.Context) (*memoryTx, bool) {
+	tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx)
+	return tx, ok
+}
+
+// onRollback makes the transaction call f when it's rolled back, the last f added is called first
+func (tx *memoryTx) onRollback(f func()) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.undo = append(tx.undo, f)
+}
+
+// onCommit makes the transaction call f once it's committed
+func (tx *memoryTx) onCommit(f func()) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.commit = append(tx.commit, f)
+}
+
+// MemoryTransactor runs transactions over the memory repositories. A transaction isn't isolated from other changes
+// while it runs, but when it fails the jobs, runs and outbox events it changed are put back the way they were and
+// watchers only hear about changes that were committed.
+type MemoryTransactor struct{}
+
+// NewMemoryTransactor creates a Transactor for the memory repositories
+func NewMemoryTransactor() *MemoryTransactor {
+	return &MemoryTransactor{}
+}
+
+func (t *MemoryTransactor) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	tx := &memoryTx{}
+	if err := f(context.WithValue(ctx, memoryTxKey{}, tx)); err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		return err
+	}
+	for _, f := range tx.commit {
+		f()
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryUserRepository keeps users and their API keys in memory, it is meant for tests and local development
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]*User
+	keys  map[string]*APIKey
+}
+
+// NewMemoryUserRepository creates a repository without users
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{users: map[string]*User{}, keys: map[string]*APIKey{}}
+}
+
+// copyUser returns a copy of user, so callers can't change stored users
+func copyUser(user *User) *User {
+	c := *user
+	c.Roles = append([]string(nil), user.Roles...)
+	return &c
+}
+
+// copyAPIKey returns a copy of key, so callers can't change stored keys
+func copyAPIKey(key *APIKey) *APIKey {
+	c := *key
+	if key.RotatedAt != nil {
+		t := *key.RotatedAt
+		c.RotatedAt = &t
+	}
+	return &c
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *User) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyUser(user)
+	if stored.ID == "" {
+		stored.ID = newID()
+	}
+	if _, ok := r.users[stored.ID]; ok {
+		return nil, ErrExists
+	}
+	stored.Tenant = tenant.FromContext(ctx)
+	r.users[stored.ID] = stored
+	return copyUser(stored), nil
+}
+
+// user returns the stored user with id of the tenant of ctx, the caller must hold the lock
+func (r *MemoryUserRepository) user(ctx context.Context, id string) (*User, error) {
+	user, ok := r.users[id]
+	if !ok || !ofTenant(ctx, user.Tenant) {
+		return nil, ErrNotFound
+	}
+	return user, nil
+}
+
+func (r *MemoryUserRepository) Get(ctx context.Context, id string) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user, err := r.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return copyUser(user), nil
+}
+
+func (r *MemoryUserRepository) List(ctx context.Context) ([]*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := []*User{}
+	for _, user := range r.users {
+		if ofTenant(ctx, user.Tenant) {
+			users = append(users, copyUser(user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.Before(users[j].CreatedAt)
+		}
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user *User) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.user(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	stored.Email = user.Email
+	stored.DisplayName = user.DisplayName
+	stored.Roles = append([]string(nil), user.Roles...)
+	stored.UpdatedAt = user.UpdatedAt
+	return copyUser(stored), nil
+}
+
+func (r *MemoryUserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.user(ctx, id); err != nil {
+		return err
+	}
+	delete(r.users, id)
+	for keyID, key := range r.keys {
+		if key.UserID == id {
+			delete(r.keys, keyID)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyAPIKey(key)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.keys[stored.ID] = stored
+	return copyAPIKey(stored), nil
+}
+
+func (r *MemoryUserRepository) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := []*APIKey{}
+	for _, key := range r.keys {
+		if key.UserID == userID && ofTenant(ctx, key.Tenant) {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys, nil
+}
+
+// key returns the stored key of the user with id in the tenant of ctx, the caller must hold the lock
+func (r *MemoryUserRepository) key(ctx context.Context, userID, id string) (*APIKey, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	key, ok := r.keys[id]
+	if !ok || key.UserID != userID || !ofTenant(ctx, key.Tenant) {
+		return nil, ErrNotFound
+	}
+	return key, nil
+}
+
+func (r *MemoryUserRepository) RotateAPIKey(ctx context.Context, userID, id, hash string, rotatedAt time.Time) (*APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key, err := r.key(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	key.Hash = hash
+	key.RotatedAt = &rotatedAt
+	return copyAPIKey(key), nil
+}
+
+func (r *MemoryUserRepository) DeleteAPIKey(ctx context.Context, userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.key(ctx, userID, id); err != nil {
+		return err
+	}
+	delete(r.keys, id)
+	return nil
+}
+
+func (r *MemoryUserRepository) APIKey(ctx context.Context, id string) (*APIKey, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[id]
+	if !ok || !ofTenant(ctx, key.Tenant) {
+		return nil, ErrNotFound
+	}
+	return copyAPIKey(key), nil
+}
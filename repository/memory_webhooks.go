@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// MemoryWebhookRepository keeps webhooks and their dead letters in memory, it is meant for tests and local development
+type MemoryWebhookRepository struct {
+	mu      sync.RWMutex
+	hooks   map[string]*Webhook
+	letters map[string]*DeadLetter
+}
+
+// NewMemoryWebhookRepository creates a repository without webhooks
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{hooks: map[string]*Webhook{}, letters: map[string]*DeadLetter{}}
+}
+
+// copyWebhook returns a copy of hook, so callers can't change stored webhooks
+func copyWebhook(hook *Webhook) *Webhook {
+	c := *hook
+	c.Events = append([]string(nil), hook.Events...)
+	return &c
+}
+
+// receives reports whether hook receives events of the given type
+func (hook *Webhook) receives(event string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, e := range hook.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryWebhookRepository) Create(ctx context.Context, hook *Webhook) (*Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := copyWebhook(hook)
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.hooks[stored.ID] = stored
+	return copyWebhook(stored), nil
+}
+
+func (r *MemoryWebhookRepository) Get(ctx context.Context, id, owner string) (*Webhook, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hook, ok := r.hooks[id]
+	if !ok || !ofTenant(ctx, hook.Tenant) || (owner != "" && hook.Owner != owner) {
+		return nil, ErrNotFound
+	}
+	return copyWebhook(hook), nil
+}
+
+func (r *MemoryWebhookRepository) List(ctx context.Context, owner string) ([]*Webhook, error) {
+	return r.find(ctx, owner, "")
+}
+
+func (r *MemoryWebhookRepository) Subscribed(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	return r.find(ctx, owner, event)
+}
+
+// find returns the webhooks of owner receiving the event oldest first, of all owners and events when they are empty
+func (r *MemoryWebhookRepository) find(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hooks := []*Webhook{}
+	for _, hook := range r.hooks {
+		if ofTenant(ctx, hook.Tenant) && (owner == "" || hook.Owner == owner) && (event == "" || hook.receives(event)) {
+			hooks = append(hooks, copyWebhook(hook))
+		}
+	}
+	// IDs start with their creation time
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].ID < hooks[j].ID })
+	return hooks, nil
+}
+
+func (r *MemoryWebhookRepository) Delete(ctx context.Context, id, owner string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hook, ok := r.hooks[id]
+	if !ok || !ofTenant(ctx, hook.Tenant) || (owner != "" && hook.Owner != owner) {
+		return ErrNotFound
+	}
+	delete(r.hooks, id)
+	for letterID, letter := range r.letters {
+		if letter.WebhookID == id {
+			delete(r.letters, letterID)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryWebhookRepository) CreateDeadLetter(ctx context.Context, letter *DeadLetter) (*DeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *letter
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	r.letters[stored.ID] = &stored
+	c := stored
+	return &c, nil
+}
+
+func (r *MemoryWebhookRepository) ListDeadLetters(ctx context.Context, webhookID, before string, limit int) ([]*DeadLetter, error) {
+	if err := checkID(webhookID); err != nil {
+		return nil, err
+	}
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := []string{}
+	for id, letter := range r.letters {
+		if letter.WebhookID == webhookID && (before == "" || id < before) && ofTenant(ctx, letter.Tenant) {
+			ids = append(ids, id)
+		}
+	}
+	// Newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	letters := make([]*DeadLetter, 0, len(ids))
+	for _, id := range ids {
+		c := *r.letters[id]
+		letters = append(letters, &c)
+	}
+	return letters, nil
+}
@@ -0,0 +1,420 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// migrationLock is the advisory lock that keeps replicas from migrating the schema at the same time
+const migrationLock = 7239823905
+
+// migrations brings an empty database up to the current schema, entry i creates schema version i+1.
+// Never change a migration that was released, append a new one instead.
+var migrations = []string{
+	`CREATE TABLE jobs (
+		id CHAR(24) PRIMARY KEY,
+		name TEXT NOT NULL,
+		owner TEXT NOT NULL,
+		description TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		schedule_cron TEXT,
+		schedule_interval BIGINT,
+		next_run_time TIMESTAMPTZ,
+		handler TEXT NOT NULL DEFAULT '',
+		command TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX jobs_owner_idx ON jobs (owner, id);
+	CREATE INDEX jobs_next_run_time_idx ON jobs (next_run_time) WHERE next_run_time IS NOT NULL;
+
+	CREATE TABLE job_runs (
+		id CHAR(24) PRIMARY KEY,
+		job_id CHAR(24) NOT NULL,
+		status TEXT NOT NULL,
+		queued_at TIMESTAMPTZ NOT NULL,
+		start_time TIMESTAMPTZ,
+		end_time TIMESTAMPTZ,
+		output TEXT NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX job_runs_job_id_idx ON job_runs (job_id, id);`,
+
+	// Every change of a job is announced on the job_changes channel for Watch
+	`CREATE FUNCTION notify_job_change() RETURNS trigger AS $$
+	BEGIN
+		IF TG_OP = 'DELETE' THEN
+			PERFORM pg_notify('job_changes', json_build_object('op', TG_OP, 'id', OLD.id)::text);
+			RETURN OLD;
+		END IF;
+		PERFORM pg_notify('job_changes', json_build_object('op', TG_OP, 'id', NEW.id)::text);
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql;
+
+	CREATE TRIGGER jobs_notify AFTER INSERT OR UPDATE OR DELETE ON jobs
+		FOR EACH ROW EXECUTE PROCEDURE notify_job_change();`,
+
+	// Soft delete, notifications tell Watch which update deleted a job
+	`ALTER TABLE jobs ADD COLUMN deleted_at TIMESTAMPTZ;
+	CREATE INDEX jobs_deleted_at_idx ON jobs (deleted_at) WHERE deleted_at IS NOT NULL;
+
+	CREATE OR REPLACE FUNCTION notify_job_change() RETURNS trigger AS $$
+	DECLARE
+		deleted BOOLEAN := FALSE;
+	BEGIN
+		IF TG_OP = 'DELETE' THEN
+			PERFORM pg_notify('job_changes', json_build_object('op', TG_OP, 'id', OLD.id)::text);
+			RETURN OLD;
+		END IF;
+		IF TG_OP = 'UPDATE' THEN
+			deleted := OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL;
+		END IF;
+		PERFORM pg_notify('job_changes', json_build_object('op', TG_OP, 'id', NEW.id, 'deleted', deleted)::text);
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql;`,
+
+	`ALTER TABLE jobs ADD COLUMN status TEXT NOT NULL DEFAULT 'PENDING';`,
+
+	`ALTER TABLE jobs ADD COLUMN schedule_timezone TEXT;`,
+
+	// Retries are recorded as runs waiting for their retry_at time
+	`ALTER TABLE jobs ADD COLUMN retry_policy JSONB;
+	ALTER TABLE job_runs ADD COLUMN attempt INT NOT NULL DEFAULT 1, ADD COLUMN retry_at TIMESTAMPTZ;
+	CREATE INDEX job_runs_retry_at_idx ON job_runs (retry_at) WHERE status = 'WAITING';`,
+
+	// Durations are stored in nanoseconds like schedule_interval
+	`ALTER TABLE jobs ADD COLUMN timeout BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE job_runs ADD COLUMN timeout BIGINT NOT NULL DEFAULT 0, ADD COLUMN duration BIGINT NOT NULL DEFAULT 0;`,
+
+	// Leader election, the replica holding a lease until expires_at leads
+	`CREATE TABLE leases (
+		name TEXT PRIMARY KEY,
+		holder TEXT NOT NULL,
+		expires_at TIMESTAMPTZ NOT NULL
+	);`,
+
+	// Audit log, job_id has no foreign key because entries outlive purged jobs
+	`CREATE TABLE audit_entries (
+		id CHAR(24) PRIMARY KEY,
+		time TIMESTAMPTZ NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		method TEXT NOT NULL,
+		job_id TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		changes JSONB NOT NULL
+	);
+	CREATE INDEX audit_entries_actor_idx ON audit_entries (actor, id);
+	CREATE INDEX audit_entries_job_id_idx ON audit_entries (job_id, id);
+	CREATE INDEX audit_entries_owner_idx ON audit_entries (owner, id);`,
+
+	// Multi-tenancy, rows stored before it belong to no tenant
+	`ALTER TABLE jobs ADD COLUMN tenant_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_runs ADD COLUMN tenant_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE audit_entries ADD COLUMN tenant_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX jobs_tenant_id_idx ON jobs (tenant_id, id);
+	CREATE INDEX job_runs_tenant_id_idx ON job_runs (tenant_id, id);
+	CREATE INDEX audit_entries_tenant_id_idx ON audit_entries (tenant_id, id);`,
+
+	// Idempotency keys of CreateJob, NULL once they expired
+	`ALTER TABLE jobs ADD COLUMN idempotency_key TEXT;
+	CREATE UNIQUE INDEX jobs_idempotency_key_idx ON jobs (tenant_id, owner, idempotency_key) WHERE idempotency_key IS NOT NULL;`,
+
+	// Names are unique per owner, deleted jobs keep theirs until they are purged. Fails while duplicates exist.
+	`CREATE UNIQUE INDEX jobs_owner_name_idx ON jobs (tenant_id, owner, name);`,
+
+	// Labels as a JSON object, NULL for jobs without labels
+	`ALTER TABLE jobs ADD COLUMN labels JSONB;`,
+
+	// Full text search over names and descriptions, the expression must match searchVector
+	`CREATE INDEX jobs_search_idx ON jobs USING GIN ((setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', description), 'B')));`,
+
+	// Dependencies between jobs and the scheduling cycles of runs, runs stored before have no cycle. Retries have higher
+	// attempts, so the unique index only keeps a job from being submitted twice in a cycle.
+	`ALTER TABLE jobs ADD COLUMN depends_on TEXT[];
+	CREATE INDEX jobs_depends_on_idx ON jobs USING GIN (depends_on);
+	ALTER TABLE job_runs ADD COLUMN cycle_id TEXT;
+	CREATE UNIQUE INDEX job_runs_cycle_idx ON job_runs (cycle_id, job_id, attempt);`,
+
+	// Priorities of jobs and of the runs queued for them, rows stored before are normal
+	`ALTER TABLE jobs ADD COLUMN priority TEXT NOT NULL DEFAULT 'NORMAL';
+	ALTER TABLE job_runs ADD COLUMN priority TEXT NOT NULL DEFAULT 'NORMAL';`,
+
+	// What started a run and who triggered manual runs, runs stored before have neither
+	`ALTER TABLE job_runs ADD COLUMN trigger TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_runs ADD COLUMN triggered_by TEXT NOT NULL DEFAULT '';`,
+
+	// Webhooks and the deliveries to them that failed for good, dead letters go away with their webhook
+	`CREATE TABLE webhooks (
+		id CHAR(24) PRIMARY KEY,
+		owner TEXT NOT NULL,
+		url TEXT NOT NULL,
+		secret TEXT NOT NULL,
+		events TEXT[],
+		created_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX webhooks_owner_idx ON webhooks (tenant_id, owner, id);
+	CREATE TABLE webhook_dead_letters (
+		id CHAR(24) PRIMARY KEY,
+		webhook_id CHAR(24) NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+		event TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX webhook_dead_letters_webhook_id_idx ON webhook_dead_letters (webhook_id, id);`,
+
+	// Who is notified about failing runs of a job
+	`ALTER TABLE jobs ADD COLUMN notifications JSONB;`,
+
+	// Domain events waiting to be published to the message broker
+	`CREATE TABLE outbox_events (
+		id CHAR(24) PRIMARY KEY,
+		type TEXT NOT NULL,
+		key TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);`,
+
+	// Users and the API keys of machine clients, keys go away with their user
+	`CREATE TABLE users (
+		id TEXT PRIMARY KEY,
+		email TEXT NOT NULL DEFAULT '',
+		display_name TEXT NOT NULL DEFAULT '',
+		roles TEXT[],
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX users_created_at_idx ON users (tenant_id, created_at, id);
+	CREATE TABLE api_keys (
+		id CHAR(24) PRIMARY KEY,
+		user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+		name TEXT NOT NULL,
+		hash TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		rotated_at TIMESTAMPTZ,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX api_keys_user_id_idx ON api_keys (user_id, id);`,
+
+	// Teams sharing the jobs they own between their members
+	`CREATE TABLE teams (
+		id CHAR(24) PRIMARY KEY,
+		name TEXT NOT NULL,
+		members TEXT[] NOT NULL DEFAULT '{}',
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);`,
+
+	// Roles members get from their teams
+	`ALTER TABLE teams ADD COLUMN roles TEXT[] NOT NULL DEFAULT '{}';`,
+
+	// Job templates and the template every job was created from
+	`CREATE TABLE templates (
+		id CHAR(24) PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		parameters JSONB NOT NULL DEFAULT '[]',
+		job TEXT NOT NULL,
+		version BIGINT NOT NULL DEFAULT 1,
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX templates_owner_idx ON templates (owner, id);
+	ALTER TABLE jobs ADD COLUMN template JSONB;`,
+
+	// Archived jobs aren't listed by default, the index serves the listings of archived jobs
+	`ALTER TABLE jobs ADD COLUMN archived_at TIMESTAMPTZ;
+	CREATE INDEX jobs_archived_idx ON jobs (archived_at) WHERE archived_at IS NOT NULL;`,
+
+	// How long the finished runs of the jobs of a tenant are kept, the policy without a job is the default
+	`CREATE TABLE run_retention_policies (
+		tenant_id TEXT NOT NULL DEFAULT '',
+		job_id TEXT NOT NULL DEFAULT '',
+		keep_runs INT NOT NULL DEFAULT 0,
+		max_age BIGINT NOT NULL DEFAULT 0,
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (tenant_id, job_id)
+	);`,
+
+	// The SLAs of jobs and the breaches detected, every run and deadline of a job is breached once at most
+	`CREATE TABLE slas (
+		tenant_id TEXT NOT NULL DEFAULT '',
+		job_id TEXT NOT NULL,
+		max_duration BIGINT NOT NULL DEFAULT 0,
+		deadline TEXT NOT NULL DEFAULT '',
+		timezone TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (tenant_id, job_id)
+	);
+	CREATE TABLE sla_breaches (
+		id CHAR(24) PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		breach_key TEXT NOT NULL,
+		run_id TEXT NOT NULL DEFAULT '',
+		duration BIGINT NOT NULL DEFAULT 0,
+		deadline TIMESTAMPTZ,
+		detected_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT '',
+		UNIQUE (tenant_id, job_id, breach_key)
+	);
+	CREATE INDEX sla_breaches_job_id_idx ON sla_breaches (job_id, id);`,
+
+	// The anomalies detected in the durations of runs, once per run at most
+	`CREATE TABLE run_anomalies (
+		id CHAR(24) PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		run_id TEXT NOT NULL,
+		duration BIGINT NOT NULL,
+		mean BIGINT NOT NULL,
+		stddev BIGINT NOT NULL,
+		samples INTEGER NOT NULL,
+		score DOUBLE PRECISION NOT NULL,
+		detected_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT '',
+		UNIQUE (tenant_id, run_id)
+	);
+	CREATE INDEX run_anomalies_job_id_idx ON run_anomalies (job_id, id);`,
+
+	// The worker that ran a run and what it cost, runs from before cost nothing
+	`ALTER TABLE job_runs ADD COLUMN worker TEXT NOT NULL DEFAULT '', ADD COLUMN cost_per_second DOUBLE PRECISION NOT NULL DEFAULT 0,
+		ADD COLUMN cost DOUBLE PRECISION NOT NULL DEFAULT 0;`,
+
+	// Calendars of blackout windows and holidays and the calendars every job is attached to
+	`CREATE TABLE calendars (
+		id CHAR(24) PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		timezone TEXT NOT NULL DEFAULT '',
+		windows JSONB NOT NULL DEFAULT '[]',
+		holidays JSONB NOT NULL DEFAULT '[]',
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX calendars_owner_idx ON calendars (owner, id);
+	ALTER TABLE jobs ADD COLUMN blackout JSONB;`,
+
+	// What the executor does with runs of a job that overlap, jobs from before allow them
+	`ALTER TABLE jobs ADD COLUMN concurrency_policy TEXT NOT NULL DEFAULT 'ALLOW';`,
+
+	// What the scheduler does with the occurrences of a job it missed, jobs from before are fired once
+	`ALTER TABLE jobs ADD COLUMN catch_up_policy TEXT NOT NULL DEFAULT 'FIRE_ONCE';`,
+
+	// The maintenance mode of the scheduler, in a single row shared by all tenants
+	`CREATE TABLE maintenance (
+		id INT PRIMARY KEY CHECK (id = 1),
+		enabled BOOLEAN NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ NOT NULL
+	);`,
+
+	// The params handed to the handler of a job
+	`ALTER TABLE jobs ADD COLUMN params JSONB;`,
+
+	// Secrets the params of jobs refer to, with their sealed values
+	`CREATE TABLE secrets (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		value BYTEA NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE UNIQUE INDEX secrets_owner_name_idx ON secrets (tenant_id, owner, name);`,
+
+	// The environments of jobs with their names unique per owner and environment, what jobs were promoted from and
+	// the environments that were paused
+	`ALTER TABLE jobs ADD COLUMN environment TEXT NOT NULL DEFAULT '';
+	ALTER TABLE jobs ADD COLUMN promotion JSONB;
+	DROP INDEX jobs_owner_name_idx;
+	CREATE UNIQUE INDEX jobs_owner_name_idx ON jobs (tenant_id, owner, environment, name);
+	CREATE TABLE environments (
+		tenant_id TEXT NOT NULL DEFAULT '',
+		name TEXT NOT NULL,
+		paused BOOLEAN NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (tenant_id, name)
+	);`,
+	// Environments where updates of jobs need a second person's approval, and the changes waiting for it
+	`ALTER TABLE environments ADD COLUMN protected BOOLEAN NOT NULL DEFAULT false;
+	ALTER TABLE environments ADD COLUMN protected_by TEXT NOT NULL DEFAULT '';
+	ALTER TABLE environments ADD COLUMN protected_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00';
+	CREATE TABLE job_changes (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		owner TEXT NOT NULL DEFAULT '',
+		environment TEXT NOT NULL DEFAULT '',
+		request BYTEA NOT NULL,
+		status TEXT NOT NULL,
+		requested_by TEXT NOT NULL DEFAULT '',
+		requested_at TIMESTAMPTZ NOT NULL,
+		job_updated_at TIMESTAMPTZ NOT NULL,
+		reviewed_by TEXT NOT NULL DEFAULT '',
+		reviewed_at TIMESTAMPTZ,
+		comment TEXT NOT NULL DEFAULT '',
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX job_changes_job_id_idx ON job_changes (tenant_id, job_id);`,
+	// Versions of jobs before their updates, numbered per job
+	`CREATE TABLE job_revisions (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		revision BIGINT NOT NULL,
+		job BYTEA NOT NULL,
+		changes JSONB NOT NULL,
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE UNIQUE INDEX job_revisions_revision_idx ON job_revisions (tenant_id, job_id, revision);`,
+}
+
+// Migrate applies all migrations the database doesn't have yet in a single transaction
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+		return fmt.Errorf("could not lock schema: %v", err)
+	}
+	if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+	var version int
+	if err := tx.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return err
+	}
+	for i := version; i < len(migrations); i++ {
+		if _, err := tx.Exec(ctx, migrations[i]); err != nil {
+			return fmt.Errorf("migration %d failed: %v", i+1, err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", i+1); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,981 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"github.com/noltedennis/schedulytics-backend/tracing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// jobDocument is how a job is stored in MongoDB
+type jobDocument struct {
+	ID                primitive.ObjectID     `bson:"_id,omitempty"`
+	Name              string                 `bson:"name"`
+	Owner             string                 `bson:"owner"`
+	Description       string                 `bson:"description"`
+	CreatedAt         time.Time              `bson:"created_at"`
+	UpdatedAt         time.Time              `bson:"updated_at"`
+	Schedule          *scheduler.Spec        `bson:"schedule,omitempty"`
+	NextRunTime       *time.Time             `bson:"next_run_time,omitempty"`
+	Handler           string                 `bson:"handler,omitempty"`
+	Command           string                 `bson:"command,omitempty"`
+	Status            string                 `bson:"status,omitempty"`
+	DeletedAt         *time.Time             `bson:"deleted_at,omitempty"`
+	ArchivedAt        *time.Time             `bson:"archived_at,omitempty"`
+	RetryPolicy       *scheduler.RetryPolicy `bson:"retry_policy,omitempty"`
+	Timeout           time.Duration          `bson:"timeout,omitempty"`
+	Tenant            string                 `bson:"tenant_id,omitempty"`
+	IdempotencyKey    string                 `bson:"idempotency_key,omitempty"`
+	Labels            []labelDocument        `bson:"labels,omitempty"`
+	DependsOn         []string               `bson:"depends_on,omitempty"`
+	Priority          string                 `bson:"priority,omitempty"`
+	ConcurrencyPolicy string                 `bson:"concurrency_policy,omitempty"`
+	CatchUpPolicy     string                 `bson:"catch_up_policy,omitempty"`
+	Notifications     *Notifications         `bson:"notifications,omitempty"`
+	Template          *TemplateLineage       `bson:"template,omitempty"`
+	Blackout          *Blackout              `bson:"blackout,omitempty"`
+	Params            map[string]interface{} `bson:"params,omitempty"`
+	Environment       string                 `bson:"environment,omitempty"`
+	Promotion         *Promotion             `bson:"promotion,omitempty"`
+}
+
+// labelDocument is a single label of a job. Labels are stored as a list, so keys with dots don't turn into paths.
+type labelDocument struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+// labelDocuments converts labels into their stored form ordered by key, nil when there are none
+func labelDocuments(l map[string]string) []labelDocument {
+	if len(l) == 0 {
+		return nil
+	}
+	docs := make([]labelDocument, 0, len(l))
+	for _, key := range labels.Keys(l) {
+		docs = append(docs, labelDocument{Key: key, Value: l[key]})
+	}
+	return docs
+}
+
+// labelMap converts stored labels back into a map, nil when there are none
+func labelMap(docs []labelDocument) map[string]string {
+	if len(docs) == 0 {
+		return nil
+	}
+	l := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		l[doc.Key] = doc.Value
+	}
+	return l
+}
+
+// paramValue converts a decoded param back into the JSON value it was stored from, BSON keeps integers, documents
+// and arrays in types of its own
+func paramValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case primitive.D:
+		return paramValue(map[string]interface{}(v.Map()))
+	case primitive.M:
+		return paramValue(map[string]interface{}(v))
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = paramValue(value)
+		}
+		return m
+	case primitive.A:
+		return paramValue([]interface{}(v))
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = paramValue(value)
+		}
+		return l
+	}
+	return v
+}
+
+// params returns the params of the job, nil when it has none
+func (d *jobDocument) params() map[string]interface{} {
+	if len(d.Params) == 0 {
+		return nil
+	}
+	return paramValue(d.Params).(map[string]interface{})
+}
+
+// labelFilter translates a requirement of a label selector into a filter on the stored labels
+func labelFilter(r labels.Requirement) bson.M {
+	match := bson.M{"key": r.Key}
+	switch r.Operator {
+	case labels.Equals, labels.NotEquals, labels.In, labels.NotIn:
+		match["value"] = bson.M{"$in": r.Values}
+	}
+	switch r.Operator {
+	case labels.NotEquals, labels.NotIn, labels.DoesNotExist:
+		return bson.M{"labels": bson.M{"$not": bson.M{"$elemMatch": match}}}
+	}
+	return bson.M{"labels": bson.M{"$elemMatch": match}}
+}
+
+// priority returns the priority of the job, jobs stored before they had one are normal
+func (d *jobDocument) priority() string {
+	if d.Priority == "" {
+		return PriorityNormal
+	}
+	return d.Priority
+}
+
+// concurrencyPolicy returns the concurrency policy of the job, jobs stored before they had one allow overlapping runs
+func (d *jobDocument) concurrencyPolicy() string {
+	if d.ConcurrencyPolicy == "" {
+		return ConcurrencyAllow
+	}
+	return d.ConcurrencyPolicy
+}
+
+// catchUpPolicy returns the catch-up policy of the job, jobs stored before they had one are fired once
+func (d *jobDocument) catchUpPolicy() string {
+	if d.CatchUpPolicy == "" {
+		return scheduler.CatchUpFireOnce
+	}
+	return d.CatchUpPolicy
+}
+
+func (d *jobDocument) toJob() *Job {
+	// Jobs stored before they had a status never ran
+	status := d.Status
+	if status == "" {
+		status = JobPending
+	}
+	return &Job{
+		ID:                d.ID.Hex(),
+		Name:              d.Name,
+		Owner:             d.Owner,
+		Description:       d.Description,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+		Schedule:          d.Schedule,
+		NextRunTime:       d.NextRunTime,
+		Handler:           d.Handler,
+		Command:           d.Command,
+		Status:            status,
+		DeletedAt:         d.DeletedAt,
+		ArchivedAt:        d.ArchivedAt,
+		RetryPolicy:       d.RetryPolicy,
+		Timeout:           d.Timeout,
+		Tenant:            d.Tenant,
+		IdempotencyKey:    d.IdempotencyKey,
+		Labels:            labelMap(d.Labels),
+		DependsOn:         d.DependsOn,
+		Priority:          d.priority(),
+		ConcurrencyPolicy: d.concurrencyPolicy(),
+		CatchUpPolicy:     d.catchUpPolicy(),
+		Notifications:     d.Notifications,
+		Template:          d.Template,
+		Blackout:          d.Blackout,
+		Params:            d.params(),
+		Environment:       d.Environment,
+		Promotion:         d.Promotion,
+	}
+}
+
+// MongoJobRepository stores jobs in a MongoDB collection
+type MongoJobRepository struct {
+	jobs tenantCollections
+	// BatchSize is the number of jobs the cursors of GetMany and List fetch per round trip, the driver's default
+	// when it's 0. Smaller batches return the first jobs sooner and keep less on the server at once.
+	BatchSize int32
+}
+
+// NewMongoJobRepository creates a repository for the jobs in collection, the jobs of the tenants in tenantJobs are
+// kept in their collection instead
+func NewMongoJobRepository(jobs *mongo.Collection, tenantJobs map[string]*mongo.Collection) *MongoJobRepository {
+	return &MongoJobRepository{jobs: tenantCollections{shared: jobs, tenants: tenantJobs}}
+}
+
+// find returns the options of the queries of GetMany and List reading the fields of q
+func (r *MongoJobRepository) find(q Query) *options.FindOptions {
+	o := options.Find().SetProjection(projection(q))
+	if r.BatchSize > 0 {
+		o.SetBatchSize(r.BatchSize)
+	}
+	return o
+}
+
+// closeCursor closes cursor with a context of its own, so the server drops it right away even when the query stopped
+// because its context was canceled, like when the client went away, instead of keeping it until it times out
+func closeCursor(cursor *mongo.Cursor) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cursor.Close(ctx)
+}
+
+// filter builds the filter for the job with the given id matching q and the tenant of ctx
+func (r *MongoJobRepository) filter(ctx context.Context, id string, q Query) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	addQuery(filter, q)
+	addTenant(ctx, filter)
+	return filter, nil
+}
+
+// projection returns the projection reading the fields of q, nil reads whole documents. The fields of the Job message
+// are stored under the same names except for the id.
+func projection(q Query) interface{} {
+	if len(q.Fields) == 0 {
+		return nil
+	}
+	p := bson.M{"_id": 1}
+	for _, field := range q.Fields {
+		if field != "id" {
+			p[field] = 1
+		}
+	}
+	return p
+}
+
+// addQuery adds the conditions of q to filter, a nil deleted_at or archived_at also matches documents without the
+// field
+func addQuery(filter bson.M, q Query) {
+	if owners := q.Owners(); owners != nil {
+		filter["owner"] = bson.M{"$in": owners}
+	}
+	if !q.IncludeDeleted {
+		filter["deleted_at"] = nil
+	}
+	switch q.Archived {
+	case WithoutArchived:
+		filter["archived_at"] = nil
+	case OnlyArchived:
+		filter["archived_at"] = bson.M{"$ne": nil}
+	}
+	if len(q.Labels) > 0 {
+		requirements := bson.A{}
+		for _, r := range q.Labels {
+			requirements = append(requirements, labelFilter(r))
+		}
+		filter["$and"] = requirements
+	}
+	if q.Environment != "" {
+		filter["environment"] = q.Environment
+	}
+}
+
+// newJobDocument converts a new job into a document, the ID is left empty
+func newJobDocument(job *Job) jobDocument {
+	return jobDocument{
+		Name:              job.Name,
+		Owner:             job.Owner,
+		Description:       job.Description,
+		CreatedAt:         job.CreatedAt,
+		UpdatedAt:         job.UpdatedAt,
+		Schedule:          job.Schedule,
+		NextRunTime:       job.NextRunTime,
+		Handler:           job.Handler,
+		Command:           job.Command,
+		Status:            job.Status,
+		RetryPolicy:       job.RetryPolicy,
+		Timeout:           job.Timeout,
+		IdempotencyKey:    job.IdempotencyKey,
+		Labels:            labelDocuments(job.Labels),
+		DependsOn:         job.DependsOn,
+		Priority:          job.Priority,
+		ConcurrencyPolicy: job.ConcurrencyPolicy,
+		CatchUpPolicy:     job.CatchUpPolicy,
+		Notifications:     job.Notifications,
+		Template:          job.Template,
+		Blackout:          job.Blackout,
+		Params:            job.Params,
+		Environment:       job.Environment,
+		Promotion:         job.Promotion,
+	}
+}
+
+func (r *MongoJobRepository) Create(ctx context.Context, job *Job) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	// ID: Empty, so it gets omitted and MongoDB generates a unique Object ID upon insertion.
+	data := newJobDocument(job)
+	data.Tenant = tenant.FromContext(ctx)
+	spanCtx, span := tracing.StartMongoSpan(ctx, coll, "insert")
+	result, err := coll.InsertOne(ctx, data)
+	tracing.EndSpan(spanCtx, span, err)
+	if err != nil {
+		return nil, uniqueIndexError(err)
+	}
+	data.ID = result.InsertedID.(primitive.ObjectID)
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) CreateMany(ctx context.Context, jobs []*Job) ([]*Job, error) {
+	coll := r.jobs.get(ctx)
+	// Generate the IDs up front, so the stored jobs are known even when some inserts fail
+	docs := make([]interface{}, 0, len(jobs))
+	created := make([]*Job, 0, len(jobs))
+	for _, job := range jobs {
+		data := newJobDocument(job)
+		data.ID = primitive.NewObjectID()
+		data.Tenant = tenant.FromContext(ctx)
+		docs = append(docs, data)
+		created = append(created, data.toJob())
+	}
+	// Unordered, so one failing job doesn't stop the rest of the batch
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "insert")
+	_, err := coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	tracing.EndSpan(ctx, span, err)
+	if bulkErr, ok := err.(mongo.BulkWriteException); ok && bulkErr.WriteConcernError == nil {
+		batchErr := &BatchError{Errors: map[int]error{}}
+		for _, writeErr := range bulkErr.WriteErrors {
+			batchErr.Errors[writeErr.Index] = uniqueIndexError(mongo.WriteException{WriteErrors: mongo.WriteErrors{writeErr.WriteError}})
+			created[writeErr.Index] = nil
+		}
+		return created, batchErr
+	} else if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *MongoJobRepository) Get(ctx context.Context, id string, q Query) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	filter, err := r.filter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	data := jobDocument{}
+	err = coll.FindOne(ctx, filter, options.FindOne().SetProjection(projection(q))).Decode(&data)
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		return nil, r.jobs.otherTenant(ctx, filter["_id"].(primitive.ObjectID))
+	} else if err != nil {
+		return nil, err
+	}
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) GetMany(ctx context.Context, ids []string, q Query) ([]*Job, error) {
+	coll := r.jobs.get(ctx)
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	filter := bson.M{"_id": bson.M{"$in": oids}}
+	addQuery(filter, q)
+	addTenant(ctx, filter)
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	cursor, err := coll.Find(ctx, filter, r.find(q))
+	if err != nil {
+		tracing.EndSpan(ctx, span, err)
+		return nil, err
+	}
+	defer closeCursor(cursor)
+	jobs := []*Job{}
+	for cursor.Next(ctx) {
+		data := &jobDocument{}
+		if err := cursor.Decode(data); err != nil {
+			tracing.EndSpan(ctx, span, err)
+			return nil, err
+		}
+		jobs = append(jobs, data.toJob())
+	}
+	err = cursor.Err()
+	tracing.EndSpan(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *MongoJobRepository) Update(ctx context.Context, id string, q Query, update *JobUpdate) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	filter, err := r.filter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	set := bson.M{"updated_at": update.UpdatedAt}
+	for field, value := range map[string]*string{
+		"name":               update.Name,
+		"description":        update.Description,
+		"owner":              update.Owner,
+		"handler":            update.Handler,
+		"command":            update.Command,
+		"priority":           update.Priority,
+		"concurrency_policy": update.ConcurrencyPolicy,
+		"catch_up_policy":    update.CatchUpPolicy,
+	} {
+		if value != nil {
+			set[field] = *value
+		}
+	}
+	if update.SetSchedule {
+		set["schedule"] = update.Schedule
+		set["next_run_time"] = update.NextRunTime
+	}
+	if update.Timeout != nil {
+		set["timeout"] = *update.Timeout
+	}
+	if update.SetRetryPolicy {
+		set["retry_policy"] = update.RetryPolicy
+	}
+	if update.SetLabels {
+		set["labels"] = labelDocuments(update.Labels)
+	}
+	if update.SetDependsOn {
+		set["depends_on"] = update.DependsOn
+	}
+	if update.SetNotifications {
+		set["notifications"] = update.Notifications
+	}
+	if update.SetArchivedAt {
+		set["archived_at"] = update.ArchivedAt
+	}
+	if update.SetBlackout {
+		set["blackout"] = update.Blackout
+	}
+	if update.SetParams {
+		set["params"] = update.Params
+	}
+	if update.SetPromotion {
+		set["promotion"] = update.Promotion
+	}
+	change := bson.M{"$set": set}
+	// Jobs without an environment have no environment field, like new ones, so the unique index on names treats
+	// them the same
+	if update.Environment != nil && *update.Environment == "" {
+		change["$unset"] = bson.M{"environment": ""}
+	} else if update.Environment != nil {
+		set["environment"] = *update.Environment
+	}
+
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "findAndModify")
+	data := jobDocument{}
+	err = coll.FindOneAndUpdate(ctx, filter, change, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&data)
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		return nil, r.jobs.otherTenant(ctx, filter["_id"].(primitive.ObjectID))
+	} else if err != nil {
+		return nil, uniqueIndexError(err)
+	}
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) Delete(ctx context.Context, id string, q Query, deletedAt time.Time) (bool, error) {
+	coll := r.jobs.get(ctx)
+	filter, err := r.filter(ctx, id, q)
+	if err != nil {
+		return false, err
+	}
+	// Deleting a job again keeps the time it was deleted first
+	filter["deleted_at"] = nil
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "update")
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"deleted_at": deletedAt}})
+	tracing.EndSpan(ctx, span, err)
+	if err != nil {
+		return false, err
+	}
+	if result.ModifiedCount == 0 {
+		// Tell jobs of other tenants apart from missing and already deleted ones
+		if err := r.jobs.otherTenant(ctx, filter["_id"].(primitive.ObjectID)); err != ErrNotFound {
+			return false, err
+		}
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *MongoJobRepository) SetStatus(ctx context.Context, id string, q Query, from, to string, updatedAt time.Time) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	filter, err := r.filter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	filter["status"] = from
+	if from == JobPending {
+		// Also matches jobs stored before they had a status
+		filter["status"] = bson.M{"$in": bson.A{JobPending, nil}}
+	}
+	set := bson.M{"status": to, "updated_at": updatedAt}
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "findAndModify")
+	data := jobDocument{}
+	err = coll.FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&data)
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		// Tell a missing job apart from one with another status
+		if _, err := r.Get(ctx, id, q); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	} else if err != nil {
+		return nil, err
+	}
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) Restore(ctx context.Context, id string, q Query, updatedAt time.Time) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	q.IncludeDeleted = true
+	filter, err := r.filter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	filter["deleted_at"] = bson.M{"$ne": nil}
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "findAndModify")
+	data := jobDocument{}
+	err = coll.FindOneAndUpdate(ctx, filter, bson.M{"$unset": bson.M{"deleted_at": ""}, "$set": bson.M{"updated_at": updatedAt}}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&data)
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		return nil, r.jobs.otherTenant(ctx, filter["_id"].(primitive.ObjectID))
+	} else if err != nil {
+		return nil, err
+	}
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+	addTenant(ctx, filter)
+	var purged int64
+	for _, coll := range r.jobs.all() {
+		spanCtx, span := tracing.StartMongoSpan(ctx, coll, "delete")
+		result, err := coll.DeleteMany(spanCtx, filter)
+		tracing.EndSpan(spanCtx, span, err)
+		if err != nil {
+			return purged, err
+		}
+		purged += result.DeletedCount
+	}
+	return purged, nil
+}
+
+func (r *MongoJobRepository) GetByIdempotencyKey(ctx context.Context, owner, key string) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	// Keys are unique per tenant, jobs without one have no tenant_id, which matches nil
+	filter := bson.M{"owner": owner, "idempotency_key": key, "tenant_id": nil}
+	addTenant(ctx, filter)
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	data := jobDocument{}
+	err := coll.FindOne(ctx, filter).Decode(&data)
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) GetByName(ctx context.Context, owner, environment, name string) (*Job, error) {
+	coll := r.jobs.get(ctx)
+	// Jobs without an environment or tenant don't have the fields, which matches nil
+	filter := bson.M{"owner": owner, "name": name, "environment": nil, "tenant_id": nil}
+	if environment != "" {
+		filter["environment"] = environment
+	}
+	addTenant(ctx, filter)
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	data := jobDocument{}
+	err := coll.FindOne(ctx, filter).Decode(&data)
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return data.toJob(), nil
+}
+
+func (r *MongoJobRepository) ExpireIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	filter := bson.M{"idempotency_key": bson.M{"$exists": true}, "created_at": bson.M{"$lt": createdBefore}}
+	addTenant(ctx, filter)
+	var expired int64
+	for _, coll := range r.jobs.all() {
+		spanCtx, span := tracing.StartMongoSpan(ctx, coll, "update")
+		result, err := coll.UpdateMany(spanCtx, filter, bson.M{"$unset": bson.M{"idempotency_key": ""}})
+		tracing.EndSpan(spanCtx, span, err)
+		if err != nil {
+			return expired, err
+		}
+		expired += result.ModifiedCount
+	}
+	return expired, nil
+}
+
+// Names of the unique indexes of the job collections
+const (
+	idempotencyKeyIndex = "idempotency_key"
+	// nameIndex kept names unique per owner until migration 7 replaced it with environmentNameIndex
+	nameIndex            = "owner_name"
+	environmentNameIndex = "owner_environment_name"
+	// textIndex is the text index Search uses, a collection can only have one
+	textIndex = "text"
+	// cycleIndex keeps jobs from running twice in a scheduling cycle, it is an index of the runs
+	cycleIndex = "cycle"
+)
+
+// uniqueIndexError returns ErrNameTaken, ErrIdempotencyKeyUsed or ErrRunExists when err was caused by a job
+// violating the unique index on names or idempotency keys or by a run violating the one on cycles, err otherwise
+func uniqueIndexError(err error) error {
+	var messages []string
+	switch e := err.(type) {
+	case mongo.WriteException:
+		for _, we := range e.WriteErrors {
+			if we.Code == duplicateKeyError {
+				messages = append(messages, we.Message)
+			}
+		}
+	case mongo.CommandError:
+		if e.Code == duplicateKeyError {
+			messages = append(messages, e.Message)
+		}
+	}
+	// The message names the violated index like "E11000 duplicate key error collection: db.jobs index: owner_name dup key"
+	for _, message := range messages {
+		switch {
+		case strings.Contains(message, "index: "+environmentNameIndex+" "), strings.Contains(message, "index: "+nameIndex+" "):
+			return ErrNameTaken
+		case strings.Contains(message, "index: "+idempotencyKeyIndex+" "):
+			return ErrIdempotencyKeyUsed
+		case strings.Contains(message, "index: "+cycleIndex+" "):
+			return ErrRunExists
+		case strings.Contains(message, "index: _id_ "):
+			return ErrExists
+		}
+	}
+	return err
+}
+
+func (r *MongoJobRepository) Count(ctx context.Context, q Query) (int64, error) {
+	coll := r.jobs.get(ctx)
+	filter := bson.M{}
+	addQuery(filter, q)
+	addTenant(ctx, filter)
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "count")
+	count, err := coll.CountDocuments(ctx, filter)
+	tracing.EndSpan(ctx, span, err)
+	return count, err
+}
+
+func (r *MongoJobRepository) Exists(ctx context.Context, id string, q Query) (bool, error) {
+	coll := r.jobs.get(ctx)
+	filter, err := r.filter(ctx, id, q)
+	if err != nil {
+		return false, err
+	}
+	// Only the id is read, the rest of the document stays on the server
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	err = coll.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+	tracing.EndSpan(ctx, span, err)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *MongoJobRepository) List(ctx context.Context, q Query, after string, limit int) ([]*Job, error) {
+	coll := r.jobs.get(ctx)
+	filter := bson.M{}
+	addQuery(filter, q)
+	addTenant(ctx, filter)
+	if after != "" {
+		oid, err := primitive.ObjectIDFromHex(after)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		// Object IDs are unique and ordered, so continuing after the last seen ID is deterministic
+		filter["_id"] = bson.M{"$gt": oid}
+	}
+	findOptions := r.find(q).SetSort(bson.M{"_id": 1}).SetLimit(int64(limit))
+
+	// The span covers the query and reading the whole page from the cursor
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	cursor, err := coll.Find(ctx, filter, findOptions)
+	if err != nil {
+		tracing.EndSpan(ctx, span, err)
+		return nil, err
+	}
+	defer closeCursor(cursor)
+	jobs := []*Job{}
+	for cursor.Next(ctx) {
+		// Decode into a fresh document, so fields missing in older documents stay empty
+		data := &jobDocument{}
+		if err := cursor.Decode(data); err != nil {
+			tracing.EndSpan(ctx, span, err)
+			return nil, err
+		}
+		jobs = append(jobs, data.toJob())
+	}
+	err = cursor.Err()
+	tracing.EndSpan(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Search runs a $text query on the text index created by MigrateMongo, results are sorted by text score
+func (r *MongoJobRepository) Search(ctx context.Context, q Query, text string, offset, limit int) ([]*SearchResult, error) {
+	coll := r.jobs.get(ctx)
+	q.Labels = nil
+	filter := bson.M{"$text": bson.M{"$search": text}}
+	addQuery(filter, q)
+	addTenant(ctx, filter)
+	score := bson.M{"$meta": "textScore"}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	cursor, err := coll.Find(ctx, filter, findOptions)
+	if err != nil {
+		tracing.EndSpan(ctx, span, err)
+		return nil, err
+	}
+	defer closeCursor(cursor)
+	results := []*SearchResult{}
+	for cursor.Next(ctx) {
+		data := &struct {
+			jobDocument `bson:",inline"`
+			Score       float64 `bson:"score"`
+		}{}
+		if err := cursor.Decode(data); err != nil {
+			tracing.EndSpan(ctx, span, err)
+			return nil, err
+		}
+		results = append(results, &SearchResult{Job: data.toJob(), Score: data.Score})
+	}
+	err = cursor.Err()
+	tracing.EndSpan(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Watch tails a change stream on the collection, this requires MongoDB to run as a replica set.
+// Resume tokens are the base64 encoded resume tokens of the change stream.
+func (r *MongoJobRepository) Watch(ctx context.Context, q Query, resumeToken string) (JobEvents, error) {
+	coll := r.jobs.get(ctx)
+	// Jobs are only deleted for good when they are purged, which isn't reported
+	match := bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace"}}}
+	if owners := q.Owners(); owners != nil {
+		match["fullDocument.owner"] = bson.M{"$in": owners}
+	}
+	if t := tenant.FromContext(ctx); t != "" {
+		match["fullDocument.tenant_id"] = t
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	streamOptions := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil || bson.Raw(raw).Validate() != nil {
+			return nil, ErrInvalidResumeToken
+		}
+		streamOptions.SetResumeAfter(bson.Raw(raw))
+	}
+	stream, err := coll.Watch(ctx, pipeline, streamOptions)
+	if cmdErr, ok := err.(mongo.CommandError); ok && (cmdErr.Code == changeStreamHistoryLost || cmdErr.Code == changeStreamFatalError) {
+		return nil, ErrResumeTokenExpired
+	} else if err != nil {
+		return nil, err
+	}
+	return &mongoJobEvents{stream: stream}, nil
+}
+
+// Error codes MongoDB returns when a change stream can't be resumed from a token
+const (
+	changeStreamFatalError  = 280
+	changeStreamHistoryLost = 286
+)
+
+// mongoJobEvents turns change events into JobEvents
+type mongoJobEvents struct {
+	stream *mongo.ChangeStream
+}
+
+// changeEvent holds the fields of a change event we need
+type changeEvent struct {
+	OperationType     string       `bson:"operationType"`
+	FullDocument      *jobDocument `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+func (e *mongoJobEvents) Next(ctx context.Context) (*JobEvent, error) {
+	for e.stream.Next(ctx) {
+		change := changeEvent{}
+		if err := e.stream.Decode(&change); err != nil {
+			return nil, err
+		}
+		event := &JobEvent{}
+		switch change.OperationType {
+		case "insert":
+			event.Type = EventCreated
+		case "update", "replace":
+			event.Type = EventUpdated
+		}
+		// The document can be gone already when it was purged right after an update
+		if change.FullDocument == nil {
+			continue
+		}
+		event.Job = change.FullDocument.toJob()
+		if change.FullDocument.DeletedAt != nil {
+			// Updates looked up after the job was deleted are skipped, only the deletion itself is reported
+			if _, deleted := change.UpdateDescription.UpdatedFields["deleted_at"]; !deleted {
+				continue
+			}
+			event.Type = EventDeleted
+			event.Job = &Job{ID: change.FullDocument.ID.Hex()}
+		}
+		event.ResumeToken = base64.RawURLEncoding.EncodeToString(e.stream.ResumeToken())
+		return event, nil
+	}
+	if err := e.stream.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ctx.Err()
+}
+
+func (e *mongoJobEvents) Close(ctx context.Context) error {
+	return e.stream.Close(ctx)
+}
+
+func (r *MongoJobRepository) DueJobs(ctx context.Context, now time.Time) ([]*scheduler.DueJob, error) {
+	filter := bson.M{
+		"schedule":      bson.M{"$ne": nil},
+		"next_run_time": bson.M{"$lte": now},
+		"deleted_at":    nil,
+		"archived_at":   nil,
+		"status":        bson.M{"$ne": JobPaused},
+	}
+	addTenant(ctx, filter)
+	jobs := []*scheduler.DueJob{}
+	for _, coll := range r.jobs.all() {
+		cursor, err := coll.Find(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for cursor.Next(ctx) {
+			data := &jobDocument{}
+			if err := cursor.Decode(data); err != nil {
+				cursor.Close(ctx)
+				return nil, err
+			}
+			due := &scheduler.DueJob{
+				ID:                data.ID.Hex(),
+				Name:              data.Name,
+				Owner:             data.Owner,
+				Tenant:            data.Tenant,
+				Schedule:          data.Schedule,
+				NextRunTime:       *data.NextRunTime,
+				Priority:          data.priority(),
+				ConcurrencyPolicy: data.concurrencyPolicy(),
+				CatchUpPolicy:     data.catchUpPolicy(),
+				Environment:       data.Environment,
+			}
+			if data.Blackout != nil {
+				due.Calendars = data.Blackout.CalendarIDs
+				due.DeferBlackouts = data.Blackout.Defer
+			}
+			jobs = append(jobs, due)
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
+func (r *MongoJobRepository) ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error) {
+	coll := r.jobs.get(ctx)
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid, "next_run_time": prev, "deleted_at": nil}
+	addTenant(ctx, filter)
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"next_run_time": next}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *MongoJobRepository) Dependents(ctx context.Context, id string) ([]*Job, error) {
+	coll := r.jobs.get(ctx)
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, ErrInvalidID
+	}
+	// Matches the jobs whose depends_on array contains id
+	filter := bson.M{"depends_on": id, "deleted_at": nil}
+	addTenant(ctx, filter)
+	ctx, span := tracing.StartMongoSpan(ctx, coll, "find")
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		tracing.EndSpan(ctx, span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	jobs := []*Job{}
+	for cursor.Next(ctx) {
+		data := &jobDocument{}
+		if err := cursor.Decode(data); err != nil {
+			tracing.EndSpan(ctx, span, err)
+			return nil, err
+		}
+		jobs = append(jobs, data.toJob())
+	}
+	err = cursor.Err()
+	tracing.EndSpan(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *MongoJobRepository) Put(ctx context.Context, job *Job, replace bool) error {
+	oid, err := primitive.ObjectIDFromHex(job.ID)
+	if err != nil {
+		return ErrInvalidID
+	}
+	coll := r.jobs.get(ctx)
+	data := newJobDocument(job)
+	data.ID = oid
+	data.DeletedAt = job.DeletedAt
+	data.ArchivedAt = job.ArchivedAt
+	data.Tenant = tenant.FromContext(ctx)
+	if !replace {
+		spanCtx, span := tracing.StartMongoSpan(ctx, coll, "insert")
+		_, err = coll.InsertOne(spanCtx, data)
+		tracing.EndSpan(spanCtx, span, err)
+		return uniqueIndexError(err)
+	}
+	// The upsert of a job of another tenant collides with it on the ID instead of replacing it
+	filter := bson.M{"_id": oid}
+	addTenant(ctx, filter)
+	spanCtx, span := tracing.StartMongoSpan(ctx, coll, "update")
+	_, err = coll.ReplaceOne(spanCtx, filter, data, options.Replace().SetUpsert(true))
+	tracing.EndSpan(spanCtx, span, err)
+	return uniqueIndexError(err)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// anomalyDocument is how the anomaly of a run is stored in MongoDB
+type anomalyDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	JobID      string             `bson:"job_id"`
+	RunID      string             `bson:"run_id"`
+	Duration   time.Duration      `bson:"duration"`
+	Mean       time.Duration      `bson:"mean"`
+	Stddev     time.Duration      `bson:"stddev"`
+	Samples    int                `bson:"samples"`
+	Score      float64            `bson:"score"`
+	DetectedAt time.Time          `bson:"detected_at"`
+	Tenant     string             `bson:"tenant_id"`
+}
+
+func (d *anomalyDocument) toAnomaly() *Anomaly {
+	return &Anomaly{
+		ID:         d.ID.Hex(),
+		JobID:      d.JobID,
+		RunID:      d.RunID,
+		Duration:   d.Duration,
+		Mean:       d.Mean,
+		Stddev:     d.Stddev,
+		Samples:    d.Samples,
+		Score:      d.Score,
+		DetectedAt: d.DetectedAt,
+		Tenant:     d.Tenant,
+	}
+}
+
+// MongoAnomalyRepository stores the anomalies of runs in a MongoDB collection
+type MongoAnomalyRepository struct {
+	anomalies tenantCollections
+}
+
+// NewMongoAnomalyRepository creates a repository for the anomalies in collection, the anomalies of the tenants in
+// tenantAnomalies are kept in their collection instead
+func NewMongoAnomalyRepository(anomalies *mongo.Collection, tenantAnomalies map[string]*mongo.Collection) *MongoAnomalyRepository {
+	return &MongoAnomalyRepository{anomalies: tenantCollections{shared: anomalies, tenants: tenantAnomalies}}
+}
+
+func (r *MongoAnomalyRepository) Add(ctx context.Context, anomaly *Anomaly) (*Anomaly, error) {
+	doc := &anomalyDocument{
+		JobID:      anomaly.JobID,
+		RunID:      anomaly.RunID,
+		Duration:   anomaly.Duration,
+		Mean:       anomaly.Mean,
+		Stddev:     anomaly.Stddev,
+		Samples:    anomaly.Samples,
+		Score:      anomaly.Score,
+		DetectedAt: anomaly.DetectedAt,
+		Tenant:     tenant.FromContext(ctx),
+	}
+	// Only inserting when the run has no anomaly yet records every run once
+	filter := bson.M{"tenant_id": doc.Tenant, "run_id": doc.RunID}
+	result, err := r.anomalies.get(ctx).UpdateOne(ctx, filter, bson.M{"$setOnInsert": doc}, options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	if result.UpsertedID == nil {
+		return nil, ErrExists
+	}
+	doc.ID = result.UpsertedID.(primitive.ObjectID)
+	return doc.toAnomaly(), nil
+}
+
+func (r *MongoAnomalyRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Anomaly, error) {
+	query := bson.M{}
+	if jobID != "" {
+		query["job_id"] = jobID
+	}
+	if before != "" {
+		oid, err := primitive.ObjectIDFromHex(before)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		// Anomalies are listed newest first, so the next page continues below the last ID
+		query["_id"] = bson.M{"$lt": oid}
+	}
+	addTenant(ctx, query)
+	findOptions := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit))
+	cursor, err := r.anomalies.get(ctx).Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	anomalies := []*Anomaly{}
+	for cursor.Next(ctx) {
+		doc := &anomalyDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		anomalies = append(anomalies, doc.toAnomaly())
+	}
+	return anomalies, cursor.Err()
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditDocument is how an audit entry is stored in MongoDB
+type auditDocument struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	Time    time.Time          `bson:"time"`
+	Actor   string             `bson:"actor,omitempty"`
+	Method  string             `bson:"method"`
+	JobID   string             `bson:"job_id,omitempty"`
+	Owner   string             `bson:"owner,omitempty"`
+	Changes []AuditChange      `bson:"changes"`
+	Tenant  string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *auditDocument) toAuditEntry() *AuditEntry {
+	return &AuditEntry{
+		ID:      d.ID.Hex(),
+		Time:    d.Time,
+		Actor:   d.Actor,
+		Method:  d.Method,
+		JobID:   d.JobID,
+		Owner:   d.Owner,
+		Changes: d.Changes,
+		Tenant:  d.Tenant,
+	}
+}
+
+// MongoAuditRepository stores the audit log in a MongoDB collection
+type MongoAuditRepository struct {
+	entries tenantCollections
+}
+
+// NewMongoAuditRepository creates a repository for the audit entries in collection, the entries of the tenants in
+// tenantEntries are kept in their collection instead
+func NewMongoAuditRepository(entries *mongo.Collection, tenantEntries map[string]*mongo.Collection) *MongoAuditRepository {
+	return &MongoAuditRepository{entries: tenantCollections{shared: entries, tenants: tenantEntries}}
+}
+
+func (r *MongoAuditRepository) Create(ctx context.Context, entry *AuditEntry) (*AuditEntry, error) {
+	doc := &auditDocument{
+		Time:    entry.Time,
+		Actor:   entry.Actor,
+		Method:  entry.Method,
+		JobID:   entry.JobID,
+		Owner:   entry.Owner,
+		Changes: entry.Changes,
+		Tenant:  tenant.FromContext(ctx),
+	}
+	result, err := r.entries.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toAuditEntry(), nil
+}
+
+func (r *MongoAuditRepository) List(ctx context.Context, filter AuditFilter, before string, limit int) ([]*AuditEntry, error) {
+	// Job ids are kept as strings, entries outlive the jobs they are about
+	query := bson.M{}
+	if filter.Actor != "" {
+		query["actor"] = filter.Actor
+	}
+	if filter.JobID != "" {
+		if err := checkID(filter.JobID); err != nil {
+			return nil, err
+		}
+		query["job_id"] = filter.JobID
+	}
+	if owners := filter.ownerQuery().Owners(); owners != nil {
+		query["owner"] = bson.M{"$in": owners}
+	}
+	if before != "" {
+		oid, err := primitive.ObjectIDFromHex(before)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		// Entries are listed newest first, so the next page continues below the last ID
+		query["_id"] = bson.M{"$lt": oid}
+	}
+	addTenant(ctx, query)
+	findOptions := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit))
+	cursor, err := r.entries.get(ctx).Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	entries := []*AuditEntry{}
+	for cursor.Next(ctx) {
+		data := &auditDocument{}
+		if err := cursor.Decode(data); err != nil {
+			return nil, err
+		}
+		entries = append(entries, data.toAuditEntry())
+	}
+	return entries, cursor.Err()
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// calendarDocument is how a calendar is stored in MongoDB, with its windows and holidays embedded
+type calendarDocument struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description,omitempty"`
+	Owner       string             `bson:"owner,omitempty"`
+	Timezone    string             `bson:"timezone,omitempty"`
+	Windows     []scheduler.Window `bson:"windows"`
+	Holidays    []string           `bson:"holidays"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	Tenant      string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *calendarDocument) blackouts() scheduler.Blackouts {
+	return copyBlackouts(scheduler.Blackouts{Timezone: d.Timezone, Windows: d.Windows, Holidays: d.Holidays})
+}
+
+func (d *calendarDocument) toCalendar() *Calendar {
+	return &Calendar{
+		ID:          d.ID.Hex(),
+		Name:        d.Name,
+		Description: d.Description,
+		Owner:       d.Owner,
+		Blackouts:   d.blackouts(),
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		Tenant:      d.Tenant,
+	}
+}
+
+// MongoCalendarRepository stores calendars in a MongoDB collection
+type MongoCalendarRepository struct {
+	calendars tenantCollections
+}
+
+// NewMongoCalendarRepository creates a repository for the calendars in calendars, the ones of the tenants in
+// tenantCalendars are kept in their collections instead
+func NewMongoCalendarRepository(calendars *mongo.Collection, tenantCalendars map[string]*mongo.Collection) *MongoCalendarRepository {
+	return &MongoCalendarRepository{calendars: tenantCollections{shared: calendars, tenants: tenantCalendars}}
+}
+
+func (r *MongoCalendarRepository) Create(ctx context.Context, calendar *Calendar) (*Calendar, error) {
+	blackouts := copyBlackouts(calendar.Blackouts)
+	doc := &calendarDocument{
+		Name:        calendar.Name,
+		Description: calendar.Description,
+		Owner:       calendar.Owner,
+		Timezone:    blackouts.Timezone,
+		Windows:     blackouts.Windows,
+		Holidays:    blackouts.Holidays,
+		CreatedAt:   calendar.CreatedAt,
+		UpdatedAt:   calendar.UpdatedAt,
+		Tenant:      tenant.FromContext(ctx),
+	}
+	result, err := r.calendars.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toCalendar(), nil
+}
+
+func (r *MongoCalendarRepository) Get(ctx context.Context, id string, q Query) (*Calendar, error) {
+	// Calendars are filtered like templates
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	doc := &calendarDocument{}
+	if err := r.calendars.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toCalendar(), nil
+}
+
+// find returns the calendars matching filter oldest first
+func (r *MongoCalendarRepository) find(ctx context.Context, filter bson.M) ([]*calendarDocument, error) {
+	cursor, err := r.calendars.get(ctx).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	docs := []*calendarDocument{}
+	for cursor.Next(ctx) {
+		doc := &calendarDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, cursor.Err()
+}
+
+func (r *MongoCalendarRepository) List(ctx context.Context, q Query) ([]*Calendar, error) {
+	filter := bson.M{}
+	if owners := q.Owners(); owners != nil {
+		filter["owner"] = bson.M{"$in": owners}
+	}
+	addTenant(ctx, filter)
+	docs, err := r.find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	calendars := make([]*Calendar, 0, len(docs))
+	for _, doc := range docs {
+		calendars = append(calendars, doc.toCalendar())
+	}
+	return calendars, nil
+}
+
+func (r *MongoCalendarRepository) Update(ctx context.Context, calendar *Calendar, q Query) (*Calendar, error) {
+	filter, err := templateFilter(ctx, calendar.ID, q)
+	if err != nil {
+		return nil, err
+	}
+	blackouts := copyBlackouts(calendar.Blackouts)
+	update := bson.M{
+		"$set": bson.M{
+			"name":        calendar.Name,
+			"description": calendar.Description,
+			"owner":       calendar.Owner,
+			"timezone":    blackouts.Timezone,
+			"windows":     blackouts.Windows,
+			"holidays":    blackouts.Holidays,
+			"updated_at":  calendar.UpdatedAt,
+		},
+	}
+	doc := &calendarDocument{}
+	err = r.calendars.get(ctx).FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toCalendar(), nil
+}
+
+func (r *MongoCalendarRepository) Delete(ctx context.Context, id string, q Query) error {
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return err
+	}
+	result, err := r.calendars.get(ctx).DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoCalendarRepository) Blackouts(ctx context.Context, ids []string) ([]*scheduler.Blackouts, error) {
+	oids := []primitive.ObjectID{}
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	filter := bson.M{"_id": bson.M{"$in": oids}}
+	addTenant(ctx, filter)
+	docs, err := r.find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	blackouts := make([]*scheduler.Blackouts, 0, len(docs))
+	for _, doc := range docs {
+		b := doc.blackouts()
+		blackouts = append(blackouts, &b)
+	}
+	return blackouts, nil
+}
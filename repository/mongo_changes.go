@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// changeDocument is how a change of a job is stored in MongoDB, with its request as binary data
+type changeDocument struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	JobID        string             `bson:"job_id"`
+	Owner        string             `bson:"owner"`
+	Environment  string             `bson:"environment,omitempty"`
+	Request      []byte             `bson:"request"`
+	Status       string             `bson:"status"`
+	RequestedBy  string             `bson:"requested_by,omitempty"`
+	RequestedAt  time.Time          `bson:"requested_at"`
+	JobUpdatedAt time.Time          `bson:"job_updated_at"`
+	ReviewedBy   string             `bson:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time         `bson:"reviewed_at,omitempty"`
+	Comment      string             `bson:"comment,omitempty"`
+	Tenant       string             `bson:"tenant_id"`
+}
+
+func (d *changeDocument) toChange() *Change {
+	return &Change{
+		ID:           d.ID.Hex(),
+		JobID:        d.JobID,
+		Owner:        d.Owner,
+		Environment:  d.Environment,
+		Request:      d.Request,
+		Status:       d.Status,
+		RequestedBy:  d.RequestedBy,
+		RequestedAt:  d.RequestedAt,
+		JobUpdatedAt: d.JobUpdatedAt,
+		ReviewedBy:   d.ReviewedBy,
+		ReviewedAt:   d.ReviewedAt,
+		Comment:      d.Comment,
+		Tenant:       d.Tenant,
+	}
+}
+
+// MongoChangeRepository stores the changes of jobs in a MongoDB collection
+type MongoChangeRepository struct {
+	changes tenantCollections
+}
+
+// NewMongoChangeRepository creates a repository for the changes in changes, the ones of the tenants in tenantChanges
+// are kept in their collections instead
+func NewMongoChangeRepository(changes *mongo.Collection, tenantChanges map[string]*mongo.Collection) *MongoChangeRepository {
+	return &MongoChangeRepository{changes: tenantCollections{shared: changes, tenants: tenantChanges}}
+}
+
+func (r *MongoChangeRepository) Create(ctx context.Context, change *Change) (*Change, error) {
+	doc := &changeDocument{
+		JobID:        change.JobID,
+		Owner:        change.Owner,
+		Environment:  change.Environment,
+		Request:      change.Request,
+		Status:       change.Status,
+		RequestedBy:  change.RequestedBy,
+		RequestedAt:  change.RequestedAt,
+		JobUpdatedAt: change.JobUpdatedAt,
+		Tenant:       tenant.FromContext(ctx),
+	}
+	result, err := r.changes.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toChange(), nil
+}
+
+func (r *MongoChangeRepository) Get(ctx context.Context, id string, q Query) (*Change, error) {
+	// Changes are filtered by their owner like templates
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	doc := &changeDocument{}
+	if err := r.changes.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toChange(), nil
+}
+
+func (r *MongoChangeRepository) List(ctx context.Context, q Query, filter ChangeFilter) ([]*Change, error) {
+	f := bson.M{}
+	if owners := q.Owners(); owners != nil {
+		f["owner"] = bson.M{"$in": owners}
+	}
+	if filter.JobID != "" {
+		f["job_id"] = filter.JobID
+	}
+	if filter.Status != "" {
+		f["status"] = filter.Status
+	}
+	addTenant(ctx, f)
+	cursor, err := r.changes.get(ctx).Find(ctx, f, options.Find().SetSort(bson.M{"_id": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	changes := []*Change{}
+	for cursor.Next(ctx) {
+		doc := &changeDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		changes = append(changes, doc.toChange())
+	}
+	return changes, cursor.Err()
+}
+
+func (r *MongoChangeRepository) Review(ctx context.Context, id string, q Query, status, reviewer, comment string, reviewedAt time.Time) (*Change, error) {
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	filter["status"] = ChangePending
+	update := bson.M{
+		"$set": bson.M{
+			"status":      status,
+			"reviewed_by": reviewer,
+			"reviewed_at": reviewedAt,
+			"comment":     comment,
+		},
+	}
+	doc := &changeDocument{}
+	err = r.changes.get(ctx).FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		// Either there is no such change or it was reviewed already
+		if _, err := r.Get(ctx, id, q); err != nil {
+			return nil, err
+		}
+		return nil, ErrReviewed
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toChange(), nil
+}
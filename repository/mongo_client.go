@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// mongoPingTimeout limits a single attempt to reach MongoDB on startup
+	mongoPingTimeout = 5 * time.Second
+	// mongoMinBackoff and mongoMaxBackoff bound the wait between two attempts, it doubles after every failed one
+	mongoMinBackoff = 500 * time.Millisecond
+	mongoMaxBackoff = 10 * time.Second
+)
+
+// OpenMongo creates a client with opts and pings MongoDB until it answers, waiting longer after every failed attempt.
+// retry is called with the error before every wait. It gives up with the last error once ctx is done, so the
+// deadline of ctx bounds how long MongoDB may take to come up. After that the driver reconnects on its own.
+func OpenMongo(ctx context.Context, opts *options.ClientOptions, retry func(attempt int, wait time.Duration, err error)) (*mongo.Client, error) {
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	wait := mongoMinBackoff
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, mongoPingTimeout)
+		err = client.Ping(pingCtx, nil)
+		cancel()
+		if err == nil {
+			return client, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			break
+		}
+		retry(attempt, wait, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if wait *= 2; wait > mongoMaxBackoff {
+			wait = mongoMaxBackoff
+		}
+	}
+	client.Disconnect(context.Background())
+	return nil, err
+}
+
+// MongoTransactor runs transactions in sessions of a client, which needs MongoDB to run as a replica set
+type MongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewMongoTransactor creates a Transactor for the repositories of collections of client
+func NewMongoTransactor(client *mongo.Client) *MongoTransactor {
+	return &MongoTransactor{client: client}
+}
+
+// InTransaction runs f with the context of a session, the driver retries it and the commit on transient errors
+func (t *MongoTransactor) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	return t.client.UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, f(sc)
+		})
+		return err
+	})
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// environmentKeyDocument is the ID of an environment document, which keeps environments unique per tenant
+type environmentKeyDocument struct {
+	Tenant string `bson:"tenant_id"`
+	Name   string `bson:"name"`
+}
+
+// environmentDocument is how the state of an environment is stored in MongoDB
+type environmentDocument struct {
+	ID          environmentKeyDocument `bson:"_id"`
+	Paused      bool                   `bson:"paused"`
+	Reason      string                 `bson:"reason,omitempty"`
+	UpdatedBy   string                 `bson:"updated_by,omitempty"`
+	UpdatedAt   time.Time              `bson:"updated_at"`
+	Protected   bool                   `bson:"protected,omitempty"`
+	ProtectedBy string                 `bson:"protected_by,omitempty"`
+	ProtectedAt time.Time              `bson:"protected_at,omitempty"`
+}
+
+func (d *environmentDocument) toEnvironment() *scheduler.Environment {
+	return &scheduler.Environment{
+		Tenant:      d.ID.Tenant,
+		Name:        d.ID.Name,
+		Paused:      d.Paused,
+		Reason:      d.Reason,
+		UpdatedBy:   d.UpdatedBy,
+		UpdatedAt:   d.UpdatedAt,
+		Protected:   d.Protected,
+		ProtectedBy: d.ProtectedBy,
+		ProtectedAt: d.ProtectedAt,
+	}
+}
+
+// MongoEnvironmentRepository stores the environments of all tenants in a MongoDB collection
+type MongoEnvironmentRepository struct {
+	environments *mongo.Collection
+}
+
+// NewMongoEnvironmentRepository creates a repository for the environments in environments
+func NewMongoEnvironmentRepository(environments *mongo.Collection) *MongoEnvironmentRepository {
+	return &MongoEnvironmentRepository{environments: environments}
+}
+
+func (r *MongoEnvironmentRepository) Get(ctx context.Context, name string) (*scheduler.Environment, error) {
+	key := environmentKeyDocument{Tenant: tenant.FromContext(ctx), Name: name}
+	doc := &environmentDocument{}
+	err := r.environments.FindOne(ctx, bson.M{"_id": key}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return &scheduler.Environment{Tenant: key.Tenant, Name: name}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toEnvironment(), nil
+}
+
+// find returns the environments matching filter ordered by name and tenant
+func (r *MongoEnvironmentRepository) find(ctx context.Context, filter bson.M) ([]*scheduler.Environment, error) {
+	cursor, err := r.environments.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id.name", Value: 1}, {Key: "_id.tenant_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	environments := []*scheduler.Environment{}
+	for cursor.Next(ctx) {
+		doc := &environmentDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		environments = append(environments, doc.toEnvironment())
+	}
+	return environments, cursor.Err()
+}
+
+func (r *MongoEnvironmentRepository) List(ctx context.Context) ([]*scheduler.Environment, error) {
+	return r.find(ctx, bson.M{"_id.tenant_id": tenant.FromContext(ctx)})
+}
+
+func (r *MongoEnvironmentRepository) Set(ctx context.Context, env *scheduler.Environment) (*scheduler.Environment, error) {
+	doc := &environmentDocument{
+		ID:          environmentKeyDocument{Tenant: tenant.FromContext(ctx), Name: env.Name},
+		Paused:      env.Paused,
+		Reason:      env.Reason,
+		UpdatedBy:   env.UpdatedBy,
+		UpdatedAt:   env.UpdatedAt,
+		Protected:   env.Protected,
+		ProtectedBy: env.ProtectedBy,
+		ProtectedAt: env.ProtectedAt,
+	}
+	if _, err := r.environments.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true)); err != nil {
+		return nil, err
+	}
+	return doc.toEnvironment(), nil
+}
+
+func (r *MongoEnvironmentRepository) Environments(ctx context.Context) ([]*scheduler.Environment, error) {
+	return r.find(ctx, bson.M{})
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// duplicateKeyError is the code of MongoDB write errors violating a unique index
+const duplicateKeyError = 11000
+
+// MongoLeaseRepository stores leases in a MongoDB collection, one document per lease with the lease name as _id
+type MongoLeaseRepository struct {
+	leases *mongo.Collection
+}
+
+// NewMongoLeaseRepository creates a repository for the leases in collection
+func NewMongoLeaseRepository(leases *mongo.Collection) *MongoLeaseRepository {
+	return &MongoLeaseRepository{leases: leases}
+}
+
+// AcquireLease updates the lease document if it is free, creating it if it doesn't exist. When another holder has
+// an unexpired lease the filter doesn't match and the upsert fails on the duplicate _id instead.
+func (r *MongoLeaseRepository) AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"holder": holder},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"holder": holder, "expires_at": now.Add(ttl)}}
+	_, err := r.leases.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if isDuplicateKey(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MongoLeaseRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	_, err := r.leases.DeleteOne(ctx, bson.M{"_id": name, "holder": holder})
+	return err
+}
+
+// isDuplicateKey reports whether err is a write error violating a unique index
+func isDuplicateKey(err error) bool {
+	if e, ok := err.(mongo.WriteException); ok {
+		for _, we := range e.WriteErrors {
+			if we.Code == duplicateKeyError {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maintenanceID is the ID of the only document of the maintenance collection
+const maintenanceID = "scheduler"
+
+// maintenanceDocument is how the maintenance mode is stored in MongoDB
+type maintenanceDocument struct {
+	ID        string    `bson:"_id"`
+	Enabled   bool      `bson:"enabled"`
+	Reason    string    `bson:"reason,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func (d *maintenanceDocument) toMaintenance() *scheduler.Maintenance {
+	return &scheduler.Maintenance{
+		Enabled:   d.Enabled,
+		Reason:    d.Reason,
+		UpdatedBy: d.UpdatedBy,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// MongoMaintenanceRepository stores the maintenance mode in a single document of a MongoDB collection
+type MongoMaintenanceRepository struct {
+	maintenance *mongo.Collection
+}
+
+// NewMongoMaintenanceRepository creates a repository for the maintenance mode in maintenance
+func NewMongoMaintenanceRepository(maintenance *mongo.Collection) *MongoMaintenanceRepository {
+	return &MongoMaintenanceRepository{maintenance: maintenance}
+}
+
+func (r *MongoMaintenanceRepository) Maintenance(ctx context.Context) (*scheduler.Maintenance, error) {
+	doc := &maintenanceDocument{}
+	err := r.maintenance.FindOne(ctx, bson.M{"_id": maintenanceID}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return &scheduler.Maintenance{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toMaintenance(), nil
+}
+
+func (r *MongoMaintenanceRepository) SetMaintenance(ctx context.Context, m *scheduler.Maintenance) (*scheduler.Maintenance, error) {
+	doc := &maintenanceDocument{
+		ID:        maintenanceID,
+		Enabled:   m.Enabled,
+		Reason:    m.Reason,
+		UpdatedBy: m.UpdatedBy,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if _, err := r.maintenance.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true)); err != nil {
+		return nil, err
+	}
+	return doc.toMaintenance(), nil
+}
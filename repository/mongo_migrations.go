@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoMigration is a versioned change of the job and run collections of a database. Replicas starting at the same
+// time may both apply it, so it must leave the collections the same when it's applied twice.
+type mongoMigration struct {
+	version     int
+	description string
+	// up applies the migration to the collections of t, in a dry run it only counts the indexes it would create and
+	// the documents it would change. It returns that count.
+	up func(ctx context.Context, t *mongoTarget) (int64, error)
+}
+
+// mongoTarget holds the collections of the database a migration is applied to
+type mongoTarget struct {
+	jobs   *mongo.Collection
+	runs   *mongo.Collection
+	dryRun bool
+	// progress is told about every index that is built
+	progress func(IndexBuild)
+}
+
+// IndexBuild reports the build of an index by a migration, once when it starts and once when it's done
+type IndexBuild struct {
+	Database   string
+	Collection string
+	Index      string
+	Done       bool
+	// Took is how long the build took once it's done
+	Took time.Duration
+}
+
+// mongoMigrations bring the collections of a database up to the current version, entry i creates version i+1.
+// Never change a migration that was released, append a new one instead.
+var mongoMigrations = []mongoMigration{
+	{
+		version:     1,
+		description: "create the unique indexes on names and idempotency keys, the text index and the index on dependencies of jobs",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			// Jobs without a tenant have no tenant_id, they are indexed as null and still unique per owner
+			return createIndexes(ctx, t, t.jobs, []mongo.IndexModel{
+				{
+					Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "owner", Value: 1}, {Key: "idempotency_key", Value: 1}},
+					Options: options.Index().SetName(idempotencyKeyIndex).SetUnique(true).
+						SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+				},
+				{
+					Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "owner", Value: 1}, {Key: "name", Value: 1}},
+					Options: options.Index().SetName(nameIndex).SetUnique(true),
+				},
+				{
+					// Words in the name are twice as relevant as words in the description
+					Keys:    bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+					Options: options.Index().SetName(textIndex).SetWeights(bson.M{"name": 2, "description": 1}),
+				},
+				{
+					// The name MongoDB generates, which collections created before migrations already have
+					Keys:    bson.D{{Key: "depends_on", Value: 1}},
+					Options: options.Index().SetName("depends_on_1"),
+				},
+			})
+		},
+	},
+	{
+		version:     2,
+		description: "create the unique index on cycles of runs",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			// Retries have higher attempts, so only a job submitted twice in a cycle violates the index. Runs stored
+			// before cycles existed have no cycle_id and aren't indexed.
+			return createIndexes(ctx, t, t.runs, []mongo.IndexModel{{
+				Keys: bson.D{{Key: "cycle_id", Value: 1}, {Key: "job_id", Value: 1}, {Key: "attempt", Value: 1}},
+				Options: options.Index().SetName(cycleIndex).SetUnique(true).
+					SetPartialFilterExpression(bson.M{"cycle_id": bson.M{"$exists": true}}),
+			}})
+		},
+	},
+	{
+		version:     3,
+		description: "backfill the status and priority of jobs stored before they had them",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			status, err := backfill(ctx, t, t.jobs, "status", JobPending)
+			if err != nil {
+				return 0, err
+			}
+			priority, err := backfill(ctx, t, t.jobs, "priority", PriorityNormal)
+			return status + priority, err
+		},
+	},
+	{
+		version:     4,
+		description: "backfill the attempt and priority of runs stored before they had them",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			attempt, err := backfill(ctx, t, t.runs, "attempt", 1)
+			if err != nil {
+				return 0, err
+			}
+			priority, err := backfill(ctx, t, t.runs, "priority", PriorityNormal)
+			return attempt + priority, err
+		},
+	},
+	{
+		version:     5,
+		description: "create the indexes the queries of jobs and runs filter and sort by",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			// Names are looked up together with the owner, the unique index of version 1 covers them
+			jobs, err := createIndexes(ctx, t, t.jobs, []mongo.IndexModel{
+				{
+					// Listing the jobs of an owner in the order of their IDs
+					Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "owner", Value: 1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName("owner"),
+				},
+				{
+					Keys:    bson.D{{Key: "labels.key", Value: 1}, {Key: "labels.value", Value: 1}},
+					Options: options.Index().SetName("labels"),
+				},
+				{
+					Keys:    bson.D{{Key: "status", Value: 1}},
+					Options: options.Index().SetName("status"),
+				},
+				{
+					// Jobs without a schedule are never due
+					Keys: bson.D{{Key: "next_run_time", Value: 1}},
+					Options: options.Index().SetName("next_run_time").
+						SetPartialFilterExpression(bson.M{"next_run_time": bson.M{"$exists": true}}),
+				},
+				{
+					// Purging only looks for deleted jobs
+					Keys: bson.D{{Key: "deleted_at", Value: 1}},
+					Options: options.Index().SetName("deleted_at").
+						SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$exists": true}}),
+				},
+			})
+			if err != nil {
+				return 0, err
+			}
+			runs, err := createIndexes(ctx, t, t.runs, []mongo.IndexModel{
+				{
+					// Listing the runs of a job newest first
+					Keys:    bson.D{{Key: "job_id", Value: 1}, {Key: "_id", Value: -1}},
+					Options: options.Index().SetName("job"),
+				},
+				{
+					// The stats and time series of a job
+					Keys:    bson.D{{Key: "job_id", Value: 1}, {Key: "queued_at", Value: 1}},
+					Options: options.Index().SetName("job_queued_at"),
+				},
+				{
+					Keys:    bson.D{{Key: "retry_at", Value: 1}},
+					Options: options.Index().SetName("retry_at").SetPartialFilterExpression(bson.M{"status": RunWaiting}),
+				},
+			})
+			return jobs + runs, err
+		},
+	},
+	{
+		version:     6,
+		description: "create the TTL index removing runs past the max age of their retention policy",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			// Prune sets expire_at of finished runs, the others are never removed
+			return createIndexes(ctx, t, t.runs, []mongo.IndexModel{{
+				Keys:    bson.D{{Key: "expire_at", Value: 1}},
+				Options: options.Index().SetName("expire_at").SetExpireAfterSeconds(0),
+			}})
+		},
+	},
+	{
+		version:     7,
+		description: "make the names of jobs unique per owner and environment instead of per owner",
+		up: func(ctx context.Context, t *mongoTarget) (int64, error) {
+			// Jobs without an environment have no environment field, they are indexed as null like the ones without
+			// a tenant. The new index is built before the old one is dropped, so names stay unique meanwhile.
+			created, err := createIndexes(ctx, t, t.jobs, []mongo.IndexModel{{
+				Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "owner", Value: 1}, {Key: "environment", Value: 1},
+					{Key: "name", Value: 1}},
+				Options: options.Index().SetName(environmentNameIndex).SetUnique(true),
+			}})
+			if err != nil {
+				return 0, err
+			}
+			dropped, err := dropIndex(ctx, t, t.jobs, nameIndex)
+			return created + dropped, err
+		},
+	},
+}
+
+// namespaceNotFound is the code of the error MongoDB answers listing the indexes of a missing collection with
+const namespaceNotFound = 26
+
+// indexNotFound is the code of the error MongoDB answers dropping an index that doesn't exist with
+const indexNotFound = 27
+
+// createIndexes creates the indexes coll doesn't have yet one after the other and returns how many it created, or
+// would create in a dry run. Indexes are told apart by name, every model must have one.
+func createIndexes(ctx context.Context, t *mongoTarget, coll *mongo.Collection, models []mongo.IndexModel) (int64, error) {
+	names, err := indexNames(ctx, coll)
+	if err != nil {
+		return 0, err
+	}
+	var created int64
+	for _, model := range models {
+		name := *model.Options.Name
+		if names[name] {
+			continue
+		}
+		created++
+		if t.dryRun {
+			continue
+		}
+		build := IndexBuild{Database: coll.Database().Name(), Collection: coll.Name(), Index: name}
+		t.progress(build)
+		start := time.Now()
+		if _, err := coll.Indexes().CreateOne(ctx, model); err != nil {
+			return 0, fmt.Errorf("could not create index %s of %s: %v", name, coll.Name(), err)
+		}
+		build.Done = true
+		build.Took = time.Since(start)
+		t.progress(build)
+	}
+	return created, nil
+}
+
+// dropIndex drops the index of coll with name and returns 1 when it did, or would in a dry run, and 0 when coll had
+// no such index
+func dropIndex(ctx context.Context, t *mongoTarget, coll *mongo.Collection, name string) (int64, error) {
+	names, err := indexNames(ctx, coll)
+	if err != nil || !names[name] {
+		return 0, err
+	}
+	if t.dryRun {
+		return 1, nil
+	}
+	if _, err := coll.Indexes().DropOne(ctx, name); err != nil {
+		// A replica applying the migration at the same time may have dropped it already
+		if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Code == indexNotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("could not drop index %s of %s: %v", name, coll.Name(), err)
+	}
+	return 1, nil
+}
+
+// indexNames returns the names of the indexes of coll, none when it doesn't exist yet
+func indexNames(ctx context.Context, coll *mongo.Collection) (map[string]bool, error) {
+	names := map[string]bool{}
+	cursor, err := coll.Indexes().List(ctx)
+	if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Code == namespaceNotFound {
+		// Creating the first index creates the collection
+		return names, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var indexes []struct {
+		Name string `bson:"name"`
+	}
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		names[index.Name] = true
+	}
+	return names, nil
+}
+
+// backfill sets field to value in the documents of coll that don't have it and returns how many it changed, or
+// would change in a dry run
+func backfill(ctx context.Context, t *mongoTarget, coll *mongo.Collection, field string, value interface{}) (int64, error) {
+	filter := bson.M{field: bson.M{"$exists": false}}
+	if t.dryRun {
+		return coll.CountDocuments(ctx, filter)
+	}
+	result, err := coll.UpdateMany(ctx, filter, bson.M{"$set": bson.M{field: value}})
+	if err != nil {
+		return 0, fmt.Errorf("could not backfill %s of %s: %v", field, coll.Name(), err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// MigrationResult describes a migration that was applied to a database, or would be in a dry run
+type MigrationResult struct {
+	Database    string
+	Version     int
+	Description string
+	// Changed is the number of indexes created and documents changed
+	Changed int64
+}
+
+// migrationDocument records a migration that was applied to a database, the ID is the version
+type migrationDocument struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// MigrateMongo applies the migrations every database of jobs and runs doesn't have yet, the shared one first. The
+// versions applied to a database are recorded in its collection with the given name. With dryRun nothing is changed
+// or recorded, the results tell what would be. A migration that fails stops the others, the ones applied before
+// stay recorded. progress is told about every index that is built.
+func MigrateMongo(ctx context.Context, jobs *MongoJobRepository, runs *MongoRunRepository, collection string, dryRun bool,
+	progress func(IndexBuild)) ([]*MigrationResult, error) {
+	results := []*MigrationResult{}
+	// Both list the shared collection first and the ones of the tenants sorted by tenant
+	runColls := runs.runs.all()
+	for i, jobColl := range jobs.jobs.all() {
+		t := &mongoTarget{jobs: jobColl, runs: runColls[i], dryRun: dryRun, progress: progress}
+		applied, err := appliedMigrations(ctx, jobColl.Database().Collection(collection))
+		if err != nil {
+			return results, fmt.Errorf("could not read migrations of %s: %v", jobColl.Database().Name(), err)
+		}
+		for _, m := range mongoMigrations {
+			if applied[m.version] {
+				continue
+			}
+			changed, err := m.up(ctx, t)
+			if err != nil {
+				return results, fmt.Errorf("migration %d of %s failed: %v", m.version, jobColl.Database().Name(), err)
+			}
+			results = append(results, &MigrationResult{
+				Database:    jobColl.Database().Name(),
+				Version:     m.version,
+				Description: m.description,
+				Changed:     changed,
+			})
+			if dryRun {
+				continue
+			}
+			// A replica that applied the migration at the same time recorded it already
+			record := bson.M{"description": m.description, "applied_at": time.Now().UTC()}
+			if _, err := jobColl.Database().Collection(collection).UpdateOne(ctx, bson.M{"_id": m.version},
+				bson.M{"$setOnInsert": record}, options.Update().SetUpsert(true)); err != nil {
+				return results, fmt.Errorf("could not record migration %d of %s: %v", m.version, jobColl.Database().Name(), err)
+			}
+		}
+	}
+	return results, nil
+}
+
+// appliedMigrations returns the versions recorded in coll
+func appliedMigrations(ctx context.Context, coll *mongo.Collection) (map[int]bool, error) {
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var docs []migrationDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	applied := map[int]bool{}
+	for _, doc := range docs {
+		applied[doc.Version] = true
+	}
+	return applied, nil
+}
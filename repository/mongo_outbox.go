@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// outboxDocument is how an event waiting in the outbox is stored in MongoDB
+type outboxDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Type      string             `bson:"type"`
+	Key       string             `bson:"key"`
+	Payload   string             `bson:"payload"`
+	CreatedAt time.Time          `bson:"created_at"`
+	Tenant    string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *outboxDocument) toEvent() *OutboxEvent {
+	return &OutboxEvent{
+		ID:        d.ID.Hex(),
+		Type:      d.Type,
+		Key:       d.Key,
+		Payload:   d.Payload,
+		CreatedAt: d.CreatedAt,
+		Tenant:    d.Tenant,
+	}
+}
+
+// MongoOutboxRepository stores the outbox in a MongoDB collection
+type MongoOutboxRepository struct {
+	events tenantCollections
+}
+
+// NewMongoOutboxRepository creates a repository for the events in coll, the ones of the tenants in tenantColls are
+// kept in their collections instead
+func NewMongoOutboxRepository(coll *mongo.Collection, tenantColls map[string]*mongo.Collection) *MongoOutboxRepository {
+	return &MongoOutboxRepository{events: tenantCollections{shared: coll, tenants: tenantColls}}
+}
+
+// namespaceExists is the code of the error MongoDB answers a create command for an existing collection with
+const namespaceExists = 48
+
+// CreateCollections creates the collections of the outbox. Events are stored in the transaction of the change they are
+// about and MongoDB before 4.4 can't create collections in a transaction.
+func (r *MongoOutboxRepository) CreateCollections(ctx context.Context) error {
+	for _, coll := range r.events.all() {
+		err := coll.Database().RunCommand(ctx, bson.D{{Key: "create", Value: coll.Name()}}).Err()
+		if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Code == namespaceExists {
+			continue
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MongoOutboxRepository) Create(ctx context.Context, event *OutboxEvent) (*OutboxEvent, error) {
+	doc := &outboxDocument{
+		Type:      event.Type,
+		Key:       event.Key,
+		Payload:   event.Payload,
+		CreatedAt: event.CreatedAt,
+		Tenant:    tenant.FromContext(ctx),
+	}
+	result, err := r.events.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toEvent(), nil
+}
+
+func (r *MongoOutboxRepository) Pending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	events := []*OutboxEvent{}
+	// Every collection holds the oldest events of its tenants, the oldest of all of them are among those
+	for _, coll := range r.events.all() {
+		cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}).SetLimit(int64(limit)))
+		if err != nil {
+			return nil, err
+		}
+		for cursor.Next(ctx) {
+			doc := &outboxDocument{}
+			if err := cursor.Decode(doc); err != nil {
+				cursor.Close(ctx)
+				return nil, err
+			}
+			events = append(events, doc.toEvent())
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *MongoOutboxRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	addTenant(ctx, filter)
+	_, err = r.events.get(ctx).DeleteOne(ctx, filter)
+	return err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// retentionID is the ID of the policy or the SLA of a job of a tenant, so every job has one at most without an index
+type retentionID struct {
+	Tenant string `bson:"tenant_id"`
+	JobID  string `bson:"job_id"`
+}
+
+// retentionDocument is how a run retention policy is stored in MongoDB
+type retentionDocument struct {
+	ID        retentionID   `bson:"_id"`
+	KeepRuns  int           `bson:"keep_runs,omitempty"`
+	MaxAge    time.Duration `bson:"max_age,omitempty"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+func (d *retentionDocument) toPolicy() *RunRetentionPolicy {
+	return &RunRetentionPolicy{
+		JobID:     d.ID.JobID,
+		KeepRuns:  d.KeepRuns,
+		MaxAge:    d.MaxAge,
+		UpdatedAt: d.UpdatedAt,
+		Tenant:    d.ID.Tenant,
+	}
+}
+
+// MongoRunRetentionRepository stores run retention policies in a MongoDB collection shared by all tenants
+type MongoRunRetentionRepository struct {
+	policies *mongo.Collection
+}
+
+// NewMongoRunRetentionRepository creates a repository for the policies in policies
+func NewMongoRunRetentionRepository(policies *mongo.Collection) *MongoRunRetentionRepository {
+	return &MongoRunRetentionRepository{policies: policies}
+}
+
+func (r *MongoRunRetentionRepository) Get(ctx context.Context, jobID string) (*RunRetentionPolicy, error) {
+	doc := &retentionDocument{}
+	err := r.policies.FindOne(ctx, bson.M{"_id": retentionID{Tenant: tenant.FromContext(ctx), JobID: jobID}}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toPolicy(), nil
+}
+
+func (r *MongoRunRetentionRepository) List(ctx context.Context) ([]*RunRetentionPolicy, error) {
+	filter := bson.M{}
+	if t := tenant.FromContext(ctx); t != "" {
+		filter["_id.tenant_id"] = t
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "_id.tenant_id", Value: 1}, {Key: "_id.job_id", Value: 1}})
+	cursor, err := r.policies.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	policies := []*RunRetentionPolicy{}
+	for cursor.Next(ctx) {
+		doc := &retentionDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		policies = append(policies, doc.toPolicy())
+	}
+	return policies, cursor.Err()
+}
+
+func (r *MongoRunRetentionRepository) Set(ctx context.Context, policy *RunRetentionPolicy) (*RunRetentionPolicy, error) {
+	doc := &retentionDocument{
+		ID:        retentionID{Tenant: tenant.FromContext(ctx), JobID: policy.JobID},
+		KeepRuns:  policy.KeepRuns,
+		MaxAge:    policy.MaxAge,
+		UpdatedAt: policy.UpdatedAt,
+	}
+	if _, err := r.policies.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true)); err != nil {
+		return nil, err
+	}
+	return doc.toPolicy(), nil
+}
+
+func (r *MongoRunRetentionRepository) Delete(ctx context.Context, jobID string) error {
+	result, err := r.policies.DeleteOne(ctx, bson.M{"_id": retentionID{Tenant: tenant.FromContext(ctx), JobID: jobID}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
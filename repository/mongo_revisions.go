@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// revisionDocument is how a revision of a job is stored in MongoDB, with the job as binary data
+type revisionDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	JobID     string             `bson:"job_id"`
+	Revision  int64              `bson:"revision"`
+	Job       []byte             `bson:"job"`
+	Changes   []AuditChange      `bson:"changes"`
+	CreatedBy string             `bson:"created_by,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+	Tenant    string             `bson:"tenant_id"`
+}
+
+func (d *revisionDocument) toRevision() *Revision {
+	return &Revision{
+		ID:        d.ID.Hex(),
+		JobID:     d.JobID,
+		Revision:  d.Revision,
+		Job:       d.Job,
+		Changes:   d.Changes,
+		CreatedBy: d.CreatedBy,
+		CreatedAt: d.CreatedAt,
+		Tenant:    d.Tenant,
+	}
+}
+
+// MongoRevisionRepository stores the revisions of jobs in a MongoDB collection
+type MongoRevisionRepository struct {
+	revisions tenantCollections
+}
+
+// NewMongoRevisionRepository creates a repository for the revisions in revisions, the ones of the tenants in
+// tenantRevisions are kept in their collections instead
+func NewMongoRevisionRepository(revisions *mongo.Collection, tenantRevisions map[string]*mongo.Collection) *MongoRevisionRepository {
+	return &MongoRevisionRepository{revisions: tenantCollections{shared: revisions, tenants: tenantRevisions}}
+}
+
+func (r *MongoRevisionRepository) Create(ctx context.Context, revision *Revision) (*Revision, error) {
+	doc := &revisionDocument{
+		JobID:     revision.JobID,
+		Job:       revision.Job,
+		Changes:   revision.Changes,
+		CreatedBy: revision.CreatedBy,
+		CreatedAt: revision.CreatedAt,
+		Tenant:    tenant.FromContext(ctx),
+	}
+	if doc.Changes == nil {
+		doc.Changes = []AuditChange{}
+	}
+	latest, err := r.latest(ctx, doc.JobID)
+	if err != nil {
+		return nil, err
+	}
+	doc.Revision = latest + 1
+	// Only inserting when the number is still free keeps the numbers unique, like the names of secrets
+	filter := bson.M{"tenant_id": doc.Tenant, "job_id": doc.JobID, "revision": doc.Revision}
+	result, err := r.revisions.get(ctx).UpdateOne(ctx, filter, bson.M{"$setOnInsert": doc}, options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	if result.UpsertedID == nil {
+		return nil, ErrRevisionTaken
+	}
+	doc.ID = result.UpsertedID.(primitive.ObjectID)
+	return doc.toRevision(), nil
+}
+
+// latest returns the number of the latest revision of the job with jobID, 0 when it has none
+func (r *MongoRevisionRepository) latest(ctx context.Context, jobID string) (int64, error) {
+	filter := bson.M{"job_id": jobID}
+	addTenant(ctx, filter)
+	doc := &revisionDocument{}
+	err := r.revisions.get(ctx).FindOne(ctx, filter, options.FindOne().SetSort(bson.M{"revision": -1})).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return doc.Revision, err
+}
+
+func (r *MongoRevisionRepository) Get(ctx context.Context, jobID string, revision int64) (*Revision, error) {
+	filter := bson.M{"job_id": jobID, "revision": revision}
+	addTenant(ctx, filter)
+	doc := &revisionDocument{}
+	if err := r.revisions.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toRevision(), nil
+}
+
+func (r *MongoRevisionRepository) List(ctx context.Context, jobID string) ([]*Revision, error) {
+	filter := bson.M{"job_id": jobID}
+	addTenant(ctx, filter)
+	cursor, err := r.revisions.get(ctx).Find(ctx, filter, options.Find().SetSort(bson.M{"revision": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	revisions := []*Revision{}
+	for cursor.Next(ctx) {
+		doc := &revisionDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, doc.toRevision())
+	}
+	return revisions, cursor.Err()
+}
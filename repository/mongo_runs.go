@@ -0,0 +1,649 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// runDocument is how a run is stored in MongoDB
+type runDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	JobID     primitive.ObjectID `bson:"job_id"`
+	Status    string             `bson:"status"`
+	QueuedAt  time.Time          `bson:"queued_at"`
+	StartTime *time.Time         `bson:"start_time,omitempty"`
+	EndTime   *time.Time         `bson:"end_time,omitempty"`
+	Output    string             `bson:"output,omitempty"`
+	Error     string             `bson:"error,omitempty"`
+	Attempt   int                `bson:"attempt,omitempty"`
+	RetryAt   *time.Time         `bson:"retry_at,omitempty"`
+	Timeout   time.Duration      `bson:"timeout,omitempty"`
+	Duration  time.Duration      `bson:"duration,omitempty"`
+	Tenant    string             `bson:"tenant_id,omitempty"`
+	CycleID   string             `bson:"cycle_id,omitempty"`
+	Priority  string             `bson:"priority,omitempty"`
+	// Runs stored before triggers were recorded have none
+	Trigger     string `bson:"trigger,omitempty"`
+	TriggeredBy string `bson:"triggered_by,omitempty"`
+	// Runs stored before costs were recorded cost nothing
+	Worker        string  `bson:"worker,omitempty"`
+	CostPerSecond float64 `bson:"cost_per_second,omitempty"`
+	Cost          float64 `bson:"cost,omitempty"`
+}
+
+func (d *runDocument) toRun() *Run {
+	// Runs stored before they were retried were always the first attempt, the ones before priorities were normal
+	attempt := d.Attempt
+	if attempt == 0 {
+		attempt = 1
+	}
+	priority := d.Priority
+	if priority == "" {
+		priority = PriorityNormal
+	}
+	return &Run{
+		ID:            d.ID.Hex(),
+		JobID:         d.JobID.Hex(),
+		Status:        d.Status,
+		QueuedAt:      d.QueuedAt,
+		StartTime:     d.StartTime,
+		EndTime:       d.EndTime,
+		Output:        d.Output,
+		Error:         d.Error,
+		Attempt:       attempt,
+		RetryAt:       d.RetryAt,
+		Timeout:       d.Timeout,
+		Duration:      d.Duration,
+		Tenant:        d.Tenant,
+		CycleID:       d.CycleID,
+		Priority:      priority,
+		Trigger:       d.Trigger,
+		TriggeredBy:   d.TriggeredBy,
+		Worker:        d.Worker,
+		CostPerSecond: d.CostPerSecond,
+		Cost:          d.Cost,
+	}
+}
+
+// MongoRunRepository stores runs in a MongoDB collection
+type MongoRunRepository struct {
+	runs tenantCollections
+}
+
+// NewMongoRunRepository creates a repository for the runs in collection, the runs of the tenants in tenantRuns are
+// kept in their collection instead
+func NewMongoRunRepository(runs *mongo.Collection, tenantRuns map[string]*mongo.Collection) *MongoRunRepository {
+	return &MongoRunRepository{runs: tenantCollections{shared: runs, tenants: tenantRuns}}
+}
+
+// newRunDocument converts run into a document for the tenant of ctx, the ID is left empty
+func newRunDocument(ctx context.Context, run *Run) (runDocument, error) {
+	jobID, err := primitive.ObjectIDFromHex(run.JobID)
+	if err != nil {
+		return runDocument{}, ErrInvalidID
+	}
+	return runDocument{
+		JobID:         jobID,
+		Status:        run.Status,
+		QueuedAt:      run.QueuedAt,
+		StartTime:     run.StartTime,
+		EndTime:       run.EndTime,
+		Output:        run.Output,
+		Error:         run.Error,
+		Attempt:       run.Attempt,
+		RetryAt:       run.RetryAt,
+		Timeout:       run.Timeout,
+		Duration:      run.Duration,
+		Tenant:        tenant.FromContext(ctx),
+		CycleID:       run.CycleID,
+		Priority:      run.Priority,
+		Trigger:       run.Trigger,
+		TriggeredBy:   run.TriggeredBy,
+		Worker:        run.Worker,
+		CostPerSecond: run.CostPerSecond,
+		Cost:          run.Cost,
+	}, nil
+}
+
+func (r *MongoRunRepository) Create(ctx context.Context, run *Run) (*Run, error) {
+	coll := r.runs.get(ctx)
+	data, err := newRunDocument(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+	// Generate the ID up front, a run starting a cycle has its own ID as cycle ID
+	data.ID = primitive.NewObjectID()
+	if data.CycleID == "" {
+		data.CycleID = data.ID.Hex()
+	}
+	if _, err := coll.InsertOne(ctx, data); err != nil {
+		return nil, uniqueIndexError(err)
+	}
+	return data.toRun(), nil
+}
+
+func (r *MongoRunRepository) Update(ctx context.Context, run *Run) error {
+	coll := r.runs.get(ctx)
+	oid, err := primitive.ObjectIDFromHex(run.ID)
+	if err != nil {
+		return ErrInvalidID
+	}
+	set := bson.M{
+		"status":          run.Status,
+		"output":          run.Output,
+		"error":           run.Error,
+		"timeout":         run.Timeout,
+		"duration":        run.Duration,
+		"worker":          run.Worker,
+		"cost_per_second": run.CostPerSecond,
+		"cost":            run.Cost,
+	}
+	if run.StartTime != nil {
+		set["start_time"] = run.StartTime
+	}
+	if run.EndTime != nil {
+		set["end_time"] = run.EndTime
+	}
+	filter := bson.M{"_id": oid}
+	addTenant(ctx, filter)
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoRunRepository) Get(ctx context.Context, id string) (*Run, error) {
+	coll := r.runs.get(ctx)
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	addTenant(ctx, filter)
+	data := runDocument{}
+	err = coll.FindOne(ctx, filter).Decode(&data)
+	if err == mongo.ErrNoDocuments {
+		return nil, r.runs.otherTenant(ctx, oid)
+	} else if err != nil {
+		return nil, err
+	}
+	return data.toRun(), nil
+}
+
+func (r *MongoRunRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Run, error) {
+	coll := r.runs.get(ctx)
+	filter := bson.M{}
+	if jobID != "" {
+		oid, err := primitive.ObjectIDFromHex(jobID)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		filter["job_id"] = oid
+	}
+	if before != "" {
+		oid, err := primitive.ObjectIDFromHex(before)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		// Runs are listed newest first, so the next page continues below the last ID
+		filter["_id"] = bson.M{"$lt": oid}
+	}
+	addTenant(ctx, filter)
+	findOptions := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit))
+	cursor, err := coll.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	runs := []*Run{}
+	for cursor.Next(ctx) {
+		data := &runDocument{}
+		if err := cursor.Decode(data); err != nil {
+			return nil, err
+		}
+		runs = append(runs, data.toRun())
+	}
+	return runs, cursor.Err()
+}
+
+func (r *MongoRunRepository) DueRetries(ctx context.Context, now time.Time) ([]*scheduler.DueRetry, error) {
+	filter := bson.M{
+		"status":   RunWaiting,
+		"retry_at": bson.M{"$lte": now},
+	}
+	addTenant(ctx, filter)
+	retries := []*scheduler.DueRetry{}
+	for _, coll := range r.runs.all() {
+		cursor, err := coll.Find(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for cursor.Next(ctx) {
+			data := &runDocument{}
+			if err := cursor.Decode(data); err != nil {
+				cursor.Close(ctx)
+				return nil, err
+			}
+			retries = append(retries, &scheduler.DueRetry{
+				RunID:   data.ID.Hex(),
+				JobID:   data.JobID.Hex(),
+				Tenant:  data.Tenant,
+				Attempt: data.Attempt,
+				RetryAt: *data.RetryAt,
+			})
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return retries, nil
+}
+
+func (r *MongoRunRepository) ClaimRetry(ctx context.Context, id string) (bool, error) {
+	coll := r.runs.get(ctx)
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, ErrInvalidID
+	}
+	// Only one replica can move the run out of waiting
+	filter := bson.M{"_id": oid, "status": RunWaiting}
+	addTenant(ctx, filter)
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"status": RunQueued}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// runStatsResult is the result of the aggregation in Stats
+type runStatsResult struct {
+	Statuses []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	} `bson:"statuses"`
+	Durations []struct {
+		Count int64   `bson:"count"`
+		Mean  float64 `bson:"mean"`
+	} `bson:"durations"`
+}
+
+// Stats counts the runs by status and averages the durations in a single aggregation. MongoDB has no percentile
+// operator before 7.0, so every percentile is looked up by its rank in the runs sorted by duration.
+func (r *MongoRunRepository) Stats(ctx context.Context, jobID string, from, to time.Time) (*RunStats, error) {
+	coll := r.runs.get(ctx)
+	oid, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	match := bson.M{"job_id": oid, "queued_at": bson.M{"$gte": from, "$lt": to}}
+	finished := bson.M{"job_id": oid, "queued_at": bson.M{"$gte": from, "$lt": to}, "start_time": bson.M{"$ne": nil}, "end_time": bson.M{"$ne": nil}}
+	addTenant(ctx, match)
+	addTenant(ctx, finished)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.M{
+			"statuses": bson.A{
+				bson.M{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+			},
+			"durations": bson.A{
+				bson.M{"$match": bson.M{"start_time": bson.M{"$ne": nil}, "end_time": bson.M{"$ne": nil}}},
+				// Runs stored before durations were recorded count as instant
+				bson.M{"$group": bson.M{"_id": nil, "count": bson.M{"$sum": 1}, "mean": bson.M{"$avg": bson.M{"$ifNull": bson.A{"$duration", 0}}}}},
+			},
+		}}},
+	}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	results := []runStatsResult{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	stats := &RunStats{Counts: map[string]int64{}}
+	if len(results) == 0 {
+		return stats, nil
+	}
+	for _, s := range results[0].Statuses {
+		stats.Counts[s.Status] = s.Count
+	}
+	if len(results[0].Durations) == 0 {
+		return stats, nil
+	}
+	stats.Finished = results[0].Durations[0].Count
+	stats.Mean = time.Duration(results[0].Durations[0].Mean)
+	for p, d := range map[int64]*time.Duration{50: &stats.P50, 95: &stats.P95, 99: &stats.P99} {
+		findOptions := options.FindOne().
+			SetSort(bson.D{{Key: "duration", Value: 1}, {Key: "_id", Value: 1}}).
+			SetSkip(percentileRank(p, stats.Finished)).
+			SetProjection(bson.M{"duration": 1})
+		data := runDocument{}
+		if err := coll.FindOne(ctx, finished, findOptions).Decode(&data); err != nil {
+			return nil, err
+		}
+		*d = data.Duration
+	}
+	return stats, nil
+}
+
+// runUsageResult is a group of the aggregation in Usage
+type runUsageResult struct {
+	Status   string `bson:"_id"`
+	Count    int64  `bson:"count"`
+	Duration int64  `bson:"duration"`
+}
+
+// Usage groups the runs by status in a single aggregation
+func (r *MongoRunRepository) Usage(ctx context.Context, jobIDs []string, from, to time.Time) (*RunUsage, error) {
+	oids := make([]primitive.ObjectID, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		oids = append(oids, oid)
+	}
+	match := bson.M{"job_id": bson.M{"$in": oids}, "queued_at": bson.M{"$gte": from, "$lt": to}}
+	addTenant(ctx, match)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		// Runs that didn't finish and runs stored before durations were recorded have no duration
+		{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}, "duration": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$duration", 0}}}}}},
+	}
+	cursor, err := r.runs.get(ctx).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	results := []runUsageResult{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	usage := &RunUsage{Counts: map[string]int64{}}
+	for _, result := range results {
+		usage.Counts[result.Status] = result.Count
+		usage.ComputeTime += time.Duration(result.Duration)
+	}
+	return usage, nil
+}
+
+// runCostResult is a group of the aggregation in Costs
+type runCostResult struct {
+	ID struct {
+		JobID primitive.ObjectID `bson:"job_id"`
+		Month time.Time          `bson:"month"`
+	} `bson:"_id"`
+	Runs     int64   `bson:"runs"`
+	Duration int64   `bson:"duration"`
+	Cost     float64 `bson:"cost"`
+}
+
+// Costs groups the runs by job and month with $dateTrunc, which needs MongoDB 5.0
+func (r *MongoRunRepository) Costs(ctx context.Context, jobIDs []string, from, to time.Time) ([]*RunCost, error) {
+	oids := make([]primitive.ObjectID, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		oids = append(oids, oid)
+	}
+	match := bson.M{"job_id": bson.M{"$in": oids}, "queued_at": bson.M{"$gte": from, "$lt": to}}
+	addTenant(ctx, match)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"job_id": "$job_id",
+				"month":  bson.M{"$dateTrunc": bson.M{"date": "$queued_at", "unit": "month", "timezone": "UTC"}},
+			},
+			"runs":     bson.M{"$sum": 1},
+			"duration": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$duration", 0}}},
+			"cost":     bson.M{"$sum": bson.M{"$ifNull": bson.A{"$cost", 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.job_id", Value: 1}, {Key: "_id.month", Value: 1}}}},
+	}
+	cursor, err := r.runs.get(ctx).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	results := []runCostResult{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	costs := make([]*RunCost, 0, len(results))
+	for _, result := range results {
+		costs = append(costs, &RunCost{
+			JobID:       result.ID.JobID.Hex(),
+			Month:       result.ID.Month.UTC(),
+			Runs:        result.Runs,
+			ComputeTime: time.Duration(result.Duration),
+			Cost:        result.Cost,
+		})
+	}
+	return costs, nil
+}
+
+// runBucketResult is a group of the aggregation in TimeSeries
+type runBucketResult struct {
+	ID struct {
+		Start  time.Time `bson:"start"`
+		Status string    `bson:"status"`
+	} `bson:"_id"`
+	Count    int64 `bson:"count"`
+	Finished int64 `bson:"finished"`
+	Total    int64 `bson:"total"`
+}
+
+// TimeSeries groups the runs by bucket and status with $dateTrunc, which needs MongoDB 5.0
+func (r *MongoRunRepository) TimeSeries(ctx context.Context, jobID string, from, to time.Time, bucket string, loc *time.Location) ([]*RunBucket, error) {
+	coll := r.runs.get(ctx)
+	oid, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	finished := bson.M{"$and": bson.A{
+		bson.M{"$gt": bson.A{"$start_time", nil}},
+		bson.M{"$gt": bson.A{"$end_time", nil}},
+	}}
+	match := bson.M{"job_id": oid, "queued_at": bson.M{"$gte": from, "$lt": to}}
+	addTenant(ctx, match)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"start": bson.M{"$dateTrunc": bson.M{
+					"date":        "$queued_at",
+					"unit":        bucket,
+					"timezone":    loc.String(),
+					"startOfWeek": "monday",
+				}},
+				"status": "$status",
+			},
+			"count":    bson.M{"$sum": 1},
+			"finished": bson.M{"$sum": bson.M{"$cond": bson.A{finished, 1, 0}}},
+			"total":    bson.M{"$sum": bson.M{"$cond": bson.A{finished, bson.M{"$ifNull": bson.A{"$duration", 0}}, 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id.start": 1}}},
+	}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	results := []runBucketResult{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	// The groups are sorted by the start of their bucket, so the groups of a bucket follow each other
+	series := []*RunBucket{}
+	var total int64
+	for _, result := range results {
+		if len(series) == 0 || !series[len(series)-1].Start.Equal(result.ID.Start) {
+			if len(series) > 0 {
+				setMean(series[len(series)-1], total)
+			}
+			series = append(series, &RunBucket{Start: result.ID.Start.In(loc), Counts: map[string]int64{}})
+			total = 0
+		}
+		b := series[len(series)-1]
+		b.Counts[result.ID.Status] += result.Count
+		b.Finished += result.Finished
+		total += result.Total
+	}
+	if len(series) > 0 {
+		setMean(series[len(series)-1], total)
+	}
+	return series, nil
+}
+
+func (r *MongoRunRepository) CycleRuns(ctx context.Context, cycleID string) ([]*Run, error) {
+	coll := r.runs.get(ctx)
+	if _, err := primitive.ObjectIDFromHex(cycleID); err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"cycle_id": cycleID}
+	addTenant(ctx, filter)
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	runs := []*Run{}
+	for cursor.Next(ctx) {
+		data := &runDocument{}
+		if err := cursor.Decode(data); err != nil {
+			return nil, err
+		}
+		runs = append(runs, data.toRun())
+	}
+	return runs, cursor.Err()
+}
+
+func (r *MongoRunRepository) Put(ctx context.Context, run *Run, replace bool) error {
+	oid, err := primitive.ObjectIDFromHex(run.ID)
+	if err != nil {
+		return ErrInvalidID
+	}
+	coll := r.runs.get(ctx)
+	data, err := newRunDocument(ctx, run)
+	if err != nil {
+		return err
+	}
+	data.ID = oid
+	if !replace {
+		_, err = coll.InsertOne(ctx, data)
+		return uniqueIndexError(err)
+	}
+	filter := bson.M{"_id": oid}
+	addTenant(ctx, filter)
+	_, err = coll.ReplaceOne(ctx, filter, data, options.Replace().SetUpsert(true))
+	return uniqueIndexError(err)
+}
+
+// pruneFilter matches the finished runs Prune removes of the tenant of ctx, exactly, and of the job with jobID or of
+// the jobs not in except
+func pruneFilter(ctx context.Context, jobID string, except []string) (bson.M, error) {
+	// Runs without a tenant have no tenant_id
+	filter := bson.M{"status": bson.M{"$nin": bson.A{RunQueued, RunWaiting, RunRunning}}, "tenant_id": nil}
+	addTenant(ctx, filter)
+	if jobID != "" {
+		oid, err := primitive.ObjectIDFromHex(jobID)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		filter["job_id"] = oid
+	} else if len(except) > 0 {
+		oids := make([]primitive.ObjectID, 0, len(except))
+		for _, id := range except {
+			if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+				oids = append(oids, oid)
+			}
+		}
+		filter["job_id"] = bson.M{"$nin": oids}
+	}
+	return filter, nil
+}
+
+func (r *MongoRunRepository) Prune(ctx context.Context, jobID string, except []string, keep int, maxAge time.Duration) (int64, error) {
+	coll := r.runs.get(ctx)
+	filter, err := pruneFilter(ctx, jobID, except)
+	if err != nil {
+		return 0, err
+	}
+	pruned := int64(0)
+	if keep > 0 {
+		if pruned, err = pruneBeyond(ctx, coll, filter, keep); err != nil {
+			return pruned, err
+		}
+	}
+	// Runs remember the max age their expiry was computed with, so only the ones marked differently are updated
+	expiring := bson.M{}
+	for key, value := range filter {
+		expiring[key] = value
+	}
+	if maxAge > 0 {
+		seconds := int64(maxAge / time.Second)
+		expiring["max_age"] = bson.M{"$ne": seconds}
+		_, err = coll.UpdateMany(ctx, expiring, mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"expire_at": bson.M{"$add": bson.A{"$queued_at", maxAge.Milliseconds()}},
+			"max_age":   seconds,
+		}}}})
+	} else {
+		expiring["expire_at"] = bson.M{"$exists": true}
+		_, err = coll.UpdateMany(ctx, expiring, bson.M{"$unset": bson.M{"expire_at": "", "max_age": ""}})
+	}
+	return pruned, err
+}
+
+// pruneBeyond removes the runs matching filter beyond the keep newest of their job and returns how many it removed
+func pruneBeyond(ctx context.Context, coll *mongo.Collection, filter bson.M, keep int) (int64, error) {
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$job_id", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": keep}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	jobs := []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return 0, err
+	}
+	pruned := int64(0)
+	for _, job := range jobs {
+		runs := bson.M{}
+		for key, value := range filter {
+			runs[key] = value
+		}
+		runs["job_id"] = job.ID
+		// The newest run that isn't kept, it and every older one are removed
+		data := runDocument{}
+		findOptions := options.FindOne().SetSort(bson.M{"_id": -1}).SetSkip(int64(keep)).SetProjection(bson.M{"_id": 1})
+		if err := coll.FindOne(ctx, runs, findOptions).Decode(&data); err == mongo.ErrNoDocuments {
+			continue
+		} else if err != nil {
+			return pruned, err
+		}
+		runs["_id"] = bson.M{"$lte": data.ID}
+		result, err := coll.DeleteMany(ctx, runs)
+		if err != nil {
+			return pruned, err
+		}
+		pruned += result.DeletedCount
+	}
+	return pruned, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// secretDocument is how a secret is stored in MongoDB, with its sealed value as binary data
+type secretDocument struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description,omitempty"`
+	Owner       string             `bson:"owner"`
+	Value       []byte             `bson:"value"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	Tenant      string             `bson:"tenant_id"`
+}
+
+func (d *secretDocument) toSecret() *Secret {
+	return &Secret{
+		ID:          d.ID.Hex(),
+		Name:        d.Name,
+		Description: d.Description,
+		Owner:       d.Owner,
+		Value:       d.Value,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		Tenant:      d.Tenant,
+	}
+}
+
+// MongoSecretRepository stores secrets in a MongoDB collection
+type MongoSecretRepository struct {
+	secrets tenantCollections
+}
+
+// NewMongoSecretRepository creates a repository for the secrets in secrets, the ones of the tenants in tenantSecrets
+// are kept in their collections instead
+func NewMongoSecretRepository(secrets *mongo.Collection, tenantSecrets map[string]*mongo.Collection) *MongoSecretRepository {
+	return &MongoSecretRepository{secrets: tenantCollections{shared: secrets, tenants: tenantSecrets}}
+}
+
+func (r *MongoSecretRepository) Create(ctx context.Context, secret *Secret) (*Secret, error) {
+	doc := &secretDocument{
+		Name:        secret.Name,
+		Description: secret.Description,
+		Owner:       secret.Owner,
+		Value:       secret.Value,
+		CreatedAt:   secret.CreatedAt,
+		UpdatedAt:   secret.UpdatedAt,
+		Tenant:      tenant.FromContext(ctx),
+	}
+	// Only inserting when the owner has no secret with the name yet keeps names unique
+	filter := bson.M{"tenant_id": doc.Tenant, "owner": doc.Owner, "name": doc.Name}
+	result, err := r.secrets.get(ctx).UpdateOne(ctx, filter, bson.M{"$setOnInsert": doc}, options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	if result.UpsertedID == nil {
+		return nil, ErrNameTaken
+	}
+	doc.ID = result.UpsertedID.(primitive.ObjectID)
+	return doc.toSecret(), nil
+}
+
+func (r *MongoSecretRepository) Get(ctx context.Context, id string, q Query) (*Secret, error) {
+	// Secrets are filtered like templates
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	doc := &secretDocument{}
+	if err := r.secrets.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toSecret(), nil
+}
+
+// find returns the secrets matching filter oldest first
+func (r *MongoSecretRepository) find(ctx context.Context, filter bson.M) ([]*Secret, error) {
+	addTenant(ctx, filter)
+	cursor, err := r.secrets.get(ctx).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	secrets := []*Secret{}
+	for cursor.Next(ctx) {
+		doc := &secretDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, doc.toSecret())
+	}
+	return secrets, cursor.Err()
+}
+
+func (r *MongoSecretRepository) List(ctx context.Context, q Query) ([]*Secret, error) {
+	filter := bson.M{}
+	if owners := q.Owners(); owners != nil {
+		filter["owner"] = bson.M{"$in": owners}
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *MongoSecretRepository) Update(ctx context.Context, secret *Secret, q Query) (*Secret, error) {
+	filter, err := templateFilter(ctx, secret.ID, q)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"description": secret.Description,
+			"value":       secret.Value,
+			"updated_at":  secret.UpdatedAt,
+		},
+	}
+	doc := &secretDocument{}
+	err = r.secrets.get(ctx).FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toSecret(), nil
+}
+
+func (r *MongoSecretRepository) Delete(ctx context.Context, id string, q Query) error {
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return err
+	}
+	result, err := r.secrets.get(ctx).DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoSecretRepository) Named(ctx context.Context, owner string, names []string) ([]*Secret, error) {
+	return r.find(ctx, bson.M{"owner": owner, "name": bson.M{"$in": names}})
+}
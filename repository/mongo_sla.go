@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// slaDocument is how the SLA of a job is stored in MongoDB
+type slaDocument struct {
+	ID          retentionID   `bson:"_id"`
+	MaxDuration time.Duration `bson:"max_duration,omitempty"`
+	Deadline    string        `bson:"deadline,omitempty"`
+	Timezone    string        `bson:"timezone,omitempty"`
+	UpdatedAt   time.Time     `bson:"updated_at"`
+}
+
+func (d *slaDocument) toSLA() *SLA {
+	return &SLA{
+		JobID:       d.ID.JobID,
+		MaxDuration: d.MaxDuration,
+		Deadline:    d.Deadline,
+		Timezone:    d.Timezone,
+		UpdatedAt:   d.UpdatedAt,
+		Tenant:      d.ID.Tenant,
+	}
+}
+
+// breachDocument is how an SLA breach is stored in MongoDB, key tells breaches of the same run or deadline apart
+type breachDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	JobID      string             `bson:"job_id"`
+	Kind       string             `bson:"kind"`
+	Key        string             `bson:"key"`
+	RunID      string             `bson:"run_id,omitempty"`
+	Duration   time.Duration      `bson:"duration,omitempty"`
+	Deadline   *time.Time         `bson:"deadline,omitempty"`
+	DetectedAt time.Time          `bson:"detected_at"`
+	Tenant     string             `bson:"tenant_id"`
+}
+
+func (d *breachDocument) toBreach() *SLABreach {
+	return &SLABreach{
+		ID:         d.ID.Hex(),
+		JobID:      d.JobID,
+		Kind:       d.Kind,
+		RunID:      d.RunID,
+		Duration:   d.Duration,
+		Deadline:   d.Deadline,
+		DetectedAt: d.DetectedAt,
+		Tenant:     d.Tenant,
+	}
+}
+
+// MongoSLARepository stores SLAs and their breaches in MongoDB collections shared by all tenants
+type MongoSLARepository struct {
+	slas     *mongo.Collection
+	breaches *mongo.Collection
+}
+
+// NewMongoSLARepository creates a repository for the SLAs in slas and the breaches in breaches
+func NewMongoSLARepository(slas, breaches *mongo.Collection) *MongoSLARepository {
+	return &MongoSLARepository{slas: slas, breaches: breaches}
+}
+
+func (r *MongoSLARepository) Get(ctx context.Context, jobID string) (*SLA, error) {
+	doc := &slaDocument{}
+	err := r.slas.FindOne(ctx, bson.M{"_id": retentionID{Tenant: tenant.FromContext(ctx), JobID: jobID}}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toSLA(), nil
+}
+
+func (r *MongoSLARepository) List(ctx context.Context) ([]*SLA, error) {
+	filter := bson.M{}
+	if t := tenant.FromContext(ctx); t != "" {
+		filter["_id.tenant_id"] = t
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "_id.tenant_id", Value: 1}, {Key: "_id.job_id", Value: 1}})
+	cursor, err := r.slas.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	slas := []*SLA{}
+	for cursor.Next(ctx) {
+		doc := &slaDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		slas = append(slas, doc.toSLA())
+	}
+	return slas, cursor.Err()
+}
+
+func (r *MongoSLARepository) Set(ctx context.Context, sla *SLA) (*SLA, error) {
+	doc := &slaDocument{
+		ID:          retentionID{Tenant: tenant.FromContext(ctx), JobID: sla.JobID},
+		MaxDuration: sla.MaxDuration,
+		Deadline:    sla.Deadline,
+		Timezone:    sla.Timezone,
+		UpdatedAt:   sla.UpdatedAt,
+	}
+	if _, err := r.slas.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true)); err != nil {
+		return nil, err
+	}
+	return doc.toSLA(), nil
+}
+
+func (r *MongoSLARepository) Delete(ctx context.Context, jobID string) error {
+	result, err := r.slas.DeleteOne(ctx, bson.M{"_id": retentionID{Tenant: tenant.FromContext(ctx), JobID: jobID}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoSLARepository) AddBreach(ctx context.Context, breach *SLABreach) (*SLABreach, error) {
+	doc := &breachDocument{
+		JobID:      breach.JobID,
+		Kind:       breach.Kind,
+		Key:        breach.key(),
+		RunID:      breach.RunID,
+		Duration:   breach.Duration,
+		Deadline:   breach.Deadline,
+		DetectedAt: breach.DetectedAt,
+		Tenant:     tenant.FromContext(ctx),
+	}
+	// Only inserting when there is no breach with the key yet records every run and deadline once
+	filter := bson.M{"tenant_id": doc.Tenant, "job_id": doc.JobID, "key": doc.Key}
+	result, err := r.breaches.UpdateOne(ctx, filter, bson.M{"$setOnInsert": doc}, options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	if result.UpsertedID == nil {
+		return nil, ErrExists
+	}
+	doc.ID = result.UpsertedID.(primitive.ObjectID)
+	return doc.toBreach(), nil
+}
+
+func (r *MongoSLARepository) ListBreaches(ctx context.Context, jobID, before string, limit int) ([]*SLABreach, error) {
+	query := bson.M{}
+	if jobID != "" {
+		query["job_id"] = jobID
+	}
+	if before != "" {
+		oid, err := primitive.ObjectIDFromHex(before)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		// Breaches are listed newest first, so the next page continues below the last ID
+		query["_id"] = bson.M{"$lt": oid}
+	}
+	addTenant(ctx, query)
+	findOptions := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit))
+	cursor, err := r.breaches.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	breaches := []*SLABreach{}
+	for cursor.Next(ctx) {
+		doc := &breachDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		breaches = append(breaches, doc.toBreach())
+	}
+	return breaches, cursor.Err()
+}
+
+func (r *MongoSLARepository) CountBreaches(ctx context.Context, jobID string, from, to time.Time) (int64, error) {
+	filter := bson.M{"job_id": jobID, "detected_at": bson.M{"$gte": from, "$lt": to}}
+	addTenant(ctx, filter)
+	return r.breaches.CountDocuments(ctx, filter)
+}
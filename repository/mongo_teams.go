@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// teamDocument is how a team is stored in MongoDB, with its members embedded
+type teamDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Name      string             `bson:"name"`
+	Members   []string           `bson:"members"`
+	Roles     []string           `bson:"roles"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+	Tenant    string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *teamDocument) toTeam() *Team {
+	return &Team{
+		ID:        d.ID.Hex(),
+		Name:      d.Name,
+		Members:   append([]string{}, d.Members...),
+		Roles:     append([]string{}, d.Roles...),
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+		Tenant:    d.Tenant,
+	}
+}
+
+// MongoTeamRepository stores teams in a MongoDB collection
+type MongoTeamRepository struct {
+	teams tenantCollections
+}
+
+// NewMongoTeamRepository creates a repository for the teams in teams, the ones of the tenants in tenantTeams are kept
+// in their collections instead
+func NewMongoTeamRepository(teams *mongo.Collection, tenantTeams map[string]*mongo.Collection) *MongoTeamRepository {
+	return &MongoTeamRepository{teams: tenantCollections{shared: teams, tenants: tenantTeams}}
+}
+
+func (r *MongoTeamRepository) Create(ctx context.Context, team *Team) (*Team, error) {
+	doc := &teamDocument{
+		Name:      team.Name,
+		Members:   append([]string{}, team.Members...),
+		Roles:     append([]string{}, team.Roles...),
+		CreatedAt: team.CreatedAt,
+		UpdatedAt: team.UpdatedAt,
+		Tenant:    tenant.FromContext(ctx),
+	}
+	result, err := r.teams.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toTeam(), nil
+}
+
+// teamFilter matches the team with the given id in the tenant of ctx
+func teamFilter(ctx context.Context, id string) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	addTenant(ctx, filter)
+	return filter, nil
+}
+
+func (r *MongoTeamRepository) Get(ctx context.Context, id string) (*Team, error) {
+	filter, err := teamFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	doc := &teamDocument{}
+	if err := r.teams.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toTeam(), nil
+}
+
+func (r *MongoTeamRepository) List(ctx context.Context, member string) ([]*Team, error) {
+	filter := bson.M{}
+	if member != "" {
+		filter["members"] = member
+	}
+	addTenant(ctx, filter)
+	cursor, err := r.teams.get(ctx).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	teams := []*Team{}
+	for cursor.Next(ctx) {
+		doc := &teamDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		teams = append(teams, doc.toTeam())
+	}
+	return teams, cursor.Err()
+}
+
+// update applies update to the team with id and returns the updated team. When condition is set and the team doesn't
+// match it, the team is returned unchanged.
+func (r *MongoTeamRepository) update(ctx context.Context, id string, condition, update bson.M) (*Team, error) {
+	filter, err := teamFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range condition {
+		filter[key] = value
+	}
+	doc := &teamDocument{}
+	err = r.teams.get(ctx).FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments && condition != nil {
+		// Tell a missing team apart from one that needs no change
+		return r.Get(ctx, id)
+	} else if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toTeam(), nil
+}
+
+func (r *MongoTeamRepository) Rename(ctx context.Context, id, name string, updatedAt time.Time) (*Team, error) {
+	return r.update(ctx, id, nil, bson.M{"$set": bson.M{"name": name, "updated_at": updatedAt}})
+}
+
+func (r *MongoTeamRepository) SetRoles(ctx context.Context, id string, roles []string, updatedAt time.Time) (*Team, error) {
+	return r.update(ctx, id, nil, bson.M{"$set": bson.M{"roles": append([]string{}, roles...), "updated_at": updatedAt}})
+}
+
+func (r *MongoTeamRepository) Delete(ctx context.Context, id string) error {
+	filter, err := teamFilter(ctx, id)
+	if err != nil {
+		return err
+	}
+	result, err := r.teams.get(ctx).DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoTeamRepository) AddMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	return r.update(ctx, id, bson.M{"members": bson.M{"$ne": userID}},
+		bson.M{"$push": bson.M{"members": userID}, "$set": bson.M{"updated_at": updatedAt}})
+}
+
+func (r *MongoTeamRepository) RemoveMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	return r.update(ctx, id, bson.M{"members": userID},
+		bson.M{"$pull": bson.M{"members": userID}, "$set": bson.M{"updated_at": updatedAt}})
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// templateDocument is how a job template is stored in MongoDB, with its parameters embedded
+type templateDocument struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty"`
+	Name        string              `bson:"name"`
+	Description string              `bson:"description,omitempty"`
+	Owner       string              `bson:"owner,omitempty"`
+	Parameters  []TemplateParameter `bson:"parameters"`
+	Job         string              `bson:"job"`
+	Version     int64               `bson:"version"`
+	CreatedAt   time.Time           `bson:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at"`
+	Tenant      string              `bson:"tenant_id,omitempty"`
+}
+
+func (d *templateDocument) toTemplate() *JobTemplate {
+	return &JobTemplate{
+		ID:          d.ID.Hex(),
+		Name:        d.Name,
+		Description: d.Description,
+		Owner:       d.Owner,
+		Parameters:  append([]TemplateParameter{}, d.Parameters...),
+		Job:         d.Job,
+		Version:     d.Version,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		Tenant:      d.Tenant,
+	}
+}
+
+// MongoTemplateRepository stores job templates in a MongoDB collection
+type MongoTemplateRepository struct {
+	templates tenantCollections
+}
+
+// NewMongoTemplateRepository creates a repository for the templates in templates, the ones of the tenants in
+// tenantTemplates are kept in their collections instead
+func NewMongoTemplateRepository(templates *mongo.Collection, tenantTemplates map[string]*mongo.Collection) *MongoTemplateRepository {
+	return &MongoTemplateRepository{templates: tenantCollections{shared: templates, tenants: tenantTemplates}}
+}
+
+func (r *MongoTemplateRepository) Create(ctx context.Context, template *JobTemplate) (*JobTemplate, error) {
+	doc := &templateDocument{
+		Name:        template.Name,
+		Description: template.Description,
+		Owner:       template.Owner,
+		Parameters:  append([]TemplateParameter{}, template.Parameters...),
+		Job:         template.Job,
+		Version:     1,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+		Tenant:      tenant.FromContext(ctx),
+	}
+	result, err := r.templates.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toTemplate(), nil
+}
+
+// templateFilter matches the template with the given id of the owners of q in the tenant of ctx
+func templateFilter(ctx context.Context, id string, q Query) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	if owners := q.Owners(); owners != nil {
+		filter["owner"] = bson.M{"$in": owners}
+	}
+	addTenant(ctx, filter)
+	return filter, nil
+}
+
+func (r *MongoTemplateRepository) Get(ctx context.Context, id string, q Query) (*JobTemplate, error) {
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return nil, err
+	}
+	doc := &templateDocument{}
+	if err := r.templates.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toTemplate(), nil
+}
+
+func (r *MongoTemplateRepository) List(ctx context.Context, q Query) ([]*JobTemplate, error) {
+	filter := bson.M{}
+	if owners := q.Owners(); owners != nil {
+		filter["owner"] = bson.M{"$in": owners}
+	}
+	addTenant(ctx, filter)
+	cursor, err := r.templates.get(ctx).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	templates := []*JobTemplate{}
+	for cursor.Next(ctx) {
+		doc := &templateDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		templates = append(templates, doc.toTemplate())
+	}
+	return templates, cursor.Err()
+}
+
+func (r *MongoTemplateRepository) Update(ctx context.Context, template *JobTemplate, q Query) (*JobTemplate, error) {
+	filter, err := templateFilter(ctx, template.ID, q)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"name":        template.Name,
+			"description": template.Description,
+			"owner":       template.Owner,
+			"parameters":  append([]TemplateParameter{}, template.Parameters...),
+			"job":         template.Job,
+			"updated_at":  template.UpdatedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	doc := &templateDocument{}
+	err = r.templates.get(ctx).FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toTemplate(), nil
+}
+
+func (r *MongoTemplateRepository) Delete(ctx context.Context, id string, q Query) error {
+	filter, err := templateFilter(ctx, id, q)
+	if err != nil {
+		return err
+	}
+	result, err := r.templates.get(ctx).DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
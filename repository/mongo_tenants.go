@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// tenantCollections holds the collection shared by all tenants and the ones of tenants with a database of their own
+type tenantCollections struct {
+	shared  *mongo.Collection
+	tenants map[string]*mongo.Collection
+}
+
+// get returns the collection of the tenant of ctx, contexts without a tenant use the shared one
+func (c tenantCollections) get(ctx context.Context) *mongo.Collection {
+	if coll, ok := c.tenants[tenant.FromContext(ctx)]; ok {
+		return coll
+	}
+	return c.shared
+}
+
+// all returns every collection, the shared one first, for background work that isn't done for a single tenant
+func (c tenantCollections) all() []*mongo.Collection {
+	names := make([]string, 0, len(c.tenants))
+	for name := range c.tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	all := []*mongo.Collection{c.shared}
+	for _, name := range names {
+		all = append(all, c.tenants[name])
+	}
+	return all
+}
+
+// otherTenant tells whether the document with id wasn't found because it belongs to another tenant sharing the
+// collection and returns ErrOtherTenant then, ErrNotFound otherwise. Documents in the databases of other tenants
+// aren't looked for, they stay not found.
+func (c tenantCollections) otherTenant(ctx context.Context, id primitive.ObjectID) error {
+	t := tenant.FromContext(ctx)
+	if t == "" {
+		return ErrNotFound
+	}
+	n, err := c.shared.CountDocuments(ctx, bson.M{"_id": id, "tenant_id": bson.M{"$ne": t}})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrOtherTenant
+	}
+	return ErrNotFound
+}
+
+// addTenant restricts filter to the documents of the tenant of ctx, unless ctx has none
+func addTenant(ctx context.Context, filter bson.M) {
+	if t := tenant.FromContext(ctx); t != "" {
+		filter["tenant_id"] = t
+	}
+}
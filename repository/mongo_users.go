@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// userDocument is how a user is stored in MongoDB, with the ID of the user as _id
+type userDocument struct {
+	ID          string    `bson:"_id"`
+	Email       string    `bson:"email,omitempty"`
+	DisplayName string    `bson:"display_name,omitempty"`
+	Roles       []string  `bson:"roles,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+	Tenant      string    `bson:"tenant_id,omitempty"`
+}
+
+func (d *userDocument) toUser() *User {
+	return &User{
+		ID:          d.ID,
+		Email:       d.Email,
+		DisplayName: d.DisplayName,
+		Roles:       d.Roles,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		Tenant:      d.Tenant,
+	}
+}
+
+// apiKeyDocument is how an API key is stored in MongoDB
+type apiKeyDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    string             `bson:"user_id"`
+	Name      string             `bson:"name"`
+	Hash      string             `bson:"hash"`
+	CreatedAt time.Time          `bson:"created_at"`
+	RotatedAt *time.Time         `bson:"rotated_at,omitempty"`
+	Tenant    string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *apiKeyDocument) toAPIKey() *APIKey {
+	return &APIKey{
+		ID:        d.ID.Hex(),
+		UserID:    d.UserID,
+		Name:      d.Name,
+		Hash:      d.Hash,
+		CreatedAt: d.CreatedAt,
+		RotatedAt: d.RotatedAt,
+		Tenant:    d.Tenant,
+	}
+}
+
+// MongoUserRepository stores users and their API keys in two MongoDB collections. They always stay in the shared
+// database, tenants with a database of their own included, so API keys can be looked up before the tenant is known.
+type MongoUserRepository struct {
+	users *mongo.Collection
+	keys  *mongo.Collection
+}
+
+// NewMongoUserRepository creates a repository for the users in users and their API keys in keys
+func NewMongoUserRepository(users, keys *mongo.Collection) *MongoUserRepository {
+	return &MongoUserRepository{users: users, keys: keys}
+}
+
+func (r *MongoUserRepository) Create(ctx context.Context, user *User) (*User, error) {
+	doc := &userDocument{
+		ID:          user.ID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Roles:       user.Roles,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		Tenant:      tenant.FromContext(ctx),
+	}
+	if doc.ID == "" {
+		doc.ID = newID()
+	}
+	if _, err := r.users.InsertOne(ctx, doc); isDuplicateKey(err) {
+		return nil, ErrExists
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toUser(), nil
+}
+
+// userFilter matches the user with the given id in the tenant of ctx
+func userFilter(ctx context.Context, id string) bson.M {
+	filter := bson.M{"_id": id}
+	addTenant(ctx, filter)
+	return filter
+}
+
+func (r *MongoUserRepository) Get(ctx context.Context, id string) (*User, error) {
+	doc := &userDocument{}
+	if err := r.users.FindOne(ctx, userFilter(ctx, id)).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toUser(), nil
+}
+
+func (r *MongoUserRepository) List(ctx context.Context) ([]*User, error) {
+	filter := bson.M{}
+	addTenant(ctx, filter)
+	cursor, err := r.users.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	users := []*User{}
+	for cursor.Next(ctx) {
+		doc := &userDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		users = append(users, doc.toUser())
+	}
+	return users, cursor.Err()
+}
+
+func (r *MongoUserRepository) Update(ctx context.Context, user *User) (*User, error) {
+	update := bson.M{"$set": bson.M{
+		"email":        user.Email,
+		"display_name": user.DisplayName,
+		"roles":        user.Roles,
+		"updated_at":   user.UpdatedAt,
+	}}
+	doc := &userDocument{}
+	err := r.users.FindOneAndUpdate(ctx, userFilter(ctx, user.ID), update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toUser(), nil
+}
+
+func (r *MongoUserRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.users.DeleteOne(ctx, userFilter(ctx, id))
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	// Keys of a deleted user must not authenticate anyone anymore
+	_, err = r.keys.DeleteMany(ctx, bson.M{"user_id": id})
+	return err
+}
+
+func (r *MongoUserRepository) CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error) {
+	doc := &apiKeyDocument{
+		UserID:    key.UserID,
+		Name:      key.Name,
+		Hash:      key.Hash,
+		CreatedAt: key.CreatedAt,
+		Tenant:    tenant.FromContext(ctx),
+	}
+	result, err := r.keys.InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toAPIKey(), nil
+}
+
+func (r *MongoUserRepository) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	filter := bson.M{"user_id": userID}
+	addTenant(ctx, filter)
+	cursor, err := r.keys.Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	keys := []*APIKey{}
+	for cursor.Next(ctx) {
+		doc := &apiKeyDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		keys = append(keys, doc.toAPIKey())
+	}
+	return keys, cursor.Err()
+}
+
+// keyFilter matches the key with the given id in the tenant of ctx, of the user when userID is set
+func keyFilter(ctx context.Context, userID, id string) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	if userID != "" {
+		filter["user_id"] = userID
+	}
+	addTenant(ctx, filter)
+	return filter, nil
+}
+
+func (r *MongoUserRepository) RotateAPIKey(ctx context.Context, userID, id, hash string, rotatedAt time.Time) (*APIKey, error) {
+	filter, err := keyFilter(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{"$set": bson.M{"hash": hash, "rotated_at": rotatedAt}}
+	doc := &apiKeyDocument{}
+	err = r.keys.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toAPIKey(), nil
+}
+
+func (r *MongoUserRepository) DeleteAPIKey(ctx context.Context, userID, id string) error {
+	filter, err := keyFilter(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	result, err := r.keys.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoUserRepository) APIKey(ctx context.Context, id string) (*APIKey, error) {
+	filter, err := keyFilter(ctx, "", id)
+	if err != nil {
+		return nil, err
+	}
+	doc := &apiKeyDocument{}
+	if err := r.keys.FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toAPIKey(), nil
+}
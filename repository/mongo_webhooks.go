@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// webhookDocument is how a webhook is stored in MongoDB
+type webhookDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Owner     string             `bson:"owner"`
+	URL       string             `bson:"url"`
+	Secret    string             `bson:"secret"`
+	Events    []string           `bson:"events,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+	Tenant    string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *webhookDocument) toWebhook() *Webhook {
+	return &Webhook{
+		ID:        d.ID.Hex(),
+		Owner:     d.Owner,
+		URL:       d.URL,
+		Secret:    d.Secret,
+		Events:    d.Events,
+		CreatedAt: d.CreatedAt,
+		Tenant:    d.Tenant,
+	}
+}
+
+// deadLetterDocument is how a dead letter is stored in MongoDB
+type deadLetterDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	WebhookID string             `bson:"webhook_id"`
+	Event     string             `bson:"event"`
+	Payload   string             `bson:"payload"`
+	Attempts  int                `bson:"attempts"`
+	Error     string             `bson:"error,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+	Tenant    string             `bson:"tenant_id,omitempty"`
+}
+
+func (d *deadLetterDocument) toDeadLetter() *DeadLetter {
+	return &DeadLetter{
+		ID:        d.ID.Hex(),
+		WebhookID: d.WebhookID,
+		Event:     d.Event,
+		Payload:   d.Payload,
+		Attempts:  d.Attempts,
+		Error:     d.Error,
+		CreatedAt: d.CreatedAt,
+		Tenant:    d.Tenant,
+	}
+}
+
+// MongoWebhookRepository stores webhooks and their dead letters in two MongoDB collections
+type MongoWebhookRepository struct {
+	hooks   tenantCollections
+	letters tenantCollections
+}
+
+// NewMongoWebhookRepository creates a repository for the webhooks in hooks and the dead letters in letters, the ones
+// of the tenants in tenantHooks and tenantLetters are kept in their collections instead
+func NewMongoWebhookRepository(hooks, letters *mongo.Collection, tenantHooks, tenantLetters map[string]*mongo.Collection) *MongoWebhookRepository {
+	return &MongoWebhookRepository{
+		hooks:   tenantCollections{shared: hooks, tenants: tenantHooks},
+		letters: tenantCollections{shared: letters, tenants: tenantLetters},
+	}
+}
+
+func (r *MongoWebhookRepository) Create(ctx context.Context, hook *Webhook) (*Webhook, error) {
+	doc := &webhookDocument{
+		Owner:     hook.Owner,
+		URL:       hook.URL,
+		Secret:    hook.Secret,
+		Events:    hook.Events,
+		CreatedAt: hook.CreatedAt,
+		Tenant:    tenant.FromContext(ctx),
+	}
+	result, err := r.hooks.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toWebhook(), nil
+}
+
+// hookFilter matches the webhook with the given id of owner, of every owner when it's empty
+func hookFilter(ctx context.Context, id, owner string) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	filter := bson.M{"_id": oid}
+	if owner != "" {
+		filter["owner"] = owner
+	}
+	addTenant(ctx, filter)
+	return filter, nil
+}
+
+func (r *MongoWebhookRepository) Get(ctx context.Context, id, owner string) (*Webhook, error) {
+	filter, err := hookFilter(ctx, id, owner)
+	if err != nil {
+		return nil, err
+	}
+	doc := &webhookDocument{}
+	if err := r.hooks.get(ctx).FindOne(ctx, filter).Decode(doc); err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.toWebhook(), nil
+}
+
+func (r *MongoWebhookRepository) List(ctx context.Context, owner string) ([]*Webhook, error) {
+	filter := bson.M{}
+	if owner != "" {
+		filter["owner"] = owner
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *MongoWebhookRepository) Subscribed(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	// Webhooks without events receive all of them
+	filter := bson.M{"$or": bson.A{bson.M{"events": event}, bson.M{"events": bson.M{"$exists": false}}}}
+	if owner != "" {
+		filter["owner"] = owner
+	}
+	return r.find(ctx, filter)
+}
+
+// find returns the webhooks matching filter oldest first
+func (r *MongoWebhookRepository) find(ctx context.Context, filter bson.M) ([]*Webhook, error) {
+	addTenant(ctx, filter)
+	cursor, err := r.hooks.get(ctx).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	hooks := []*Webhook{}
+	for cursor.Next(ctx) {
+		doc := &webhookDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, doc.toWebhook())
+	}
+	return hooks, cursor.Err()
+}
+
+func (r *MongoWebhookRepository) Delete(ctx context.Context, id, owner string) error {
+	filter, err := hookFilter(ctx, id, owner)
+	if err != nil {
+		return err
+	}
+	result, err := r.hooks.get(ctx).DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	// Dead letters without their webhook can't be listed anymore
+	_, err = r.letters.get(ctx).DeleteMany(ctx, bson.M{"webhook_id": id})
+	return err
+}
+
+func (r *MongoWebhookRepository) CreateDeadLetter(ctx context.Context, letter *DeadLetter) (*DeadLetter, error) {
+	doc := &deadLetterDocument{
+		WebhookID: letter.WebhookID,
+		Event:     letter.Event,
+		Payload:   letter.Payload,
+		Attempts:  letter.Attempts,
+		Error:     letter.Error,
+		CreatedAt: letter.CreatedAt,
+		Tenant:    tenant.FromContext(ctx),
+	}
+	result, err := r.letters.get(ctx).InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.ID = result.InsertedID.(primitive.ObjectID)
+	return doc.toDeadLetter(), nil
+}
+
+func (r *MongoWebhookRepository) ListDeadLetters(ctx context.Context, webhookID, before string, limit int) ([]*DeadLetter, error) {
+	if err := checkID(webhookID); err != nil {
+		return nil, err
+	}
+	query := bson.M{"webhook_id": webhookID}
+	if before != "" {
+		oid, err := primitive.ObjectIDFromHex(before)
+		if err != nil {
+			return nil, ErrInvalidID
+		}
+		// Dead letters are listed newest first, so the next page continues below the last ID
+		query["_id"] = bson.M{"$lt": oid}
+	}
+	addTenant(ctx, query)
+	findOptions := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit))
+	cursor, err := r.letters.get(ctx).Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	letters := []*DeadLetter{}
+	for cursor.Next(ctx) {
+		doc := &deadLetterDocument{}
+		if err := cursor.Decode(doc); err != nil {
+			return nil, err
+		}
+		letters = append(letters, doc.toDeadLetter())
+	}
+	return letters, cursor.Err()
+}
@@ -0,0 +1,2680 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// OpenPostgres connects to the database at url and migrates it to the current schema
+func OpenPostgres(ctx context.Context, url string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.Connect(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if err := Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("could not migrate database: %v", err)
+	}
+	return pool, nil
+}
+
+// querier runs statements, both the pool and transactions do
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error)
+}
+
+// txKey is the context key of the transaction of PostgresTransactor
+type txKey struct{}
+
+// conn returns the transaction ctx was created with by PostgresTransactor, or pool outside of a transaction
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// PostgresTransactor runs transactions on a pool, the repositories using the same pool take part in them
+type PostgresTransactor struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTransactor creates a Transactor for the repositories created with pool
+func NewPostgresTransactor(pool *pgxpool.Pool) *PostgresTransactor {
+	return &PostgresTransactor{pool: pool}
+}
+
+func (t *PostgresTransactor) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// Rolling back a committed transaction does nothing
+	defer tx.Rollback(context.Background())
+	if err := f(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// utc converts a timestamp read from the database, which is in the local time zone, to UTC
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+const jobColumns = "id, name, owner, description, created_at, updated_at, schedule_cron, schedule_interval, next_run_time, handler, command, deleted_at, status, schedule_timezone, retry_policy, timeout, tenant_id, idempotency_key, labels, depends_on, priority, notifications, template, archived_at, blackout, concurrency_policy, catch_up_policy, params, environment, promotion"
+
+// scanJob reads a row selected with jobColumns, followed by the columns read into extra
+func scanJob(row pgx.Row, extra ...interface{}) (*Job, error) {
+	job := &Job{}
+	var cron, timezone, retryPolicy, idempotencyKey, jobLabels, notifications, template, blackout, params, promotion *string
+	var interval *int64
+	var timeout int64
+	dest := []interface{}{&job.ID, &job.Name, &job.Owner, &job.Description, &job.CreatedAt, &job.UpdatedAt,
+		&cron, &interval, &job.NextRunTime, &job.Handler, &job.Command, &job.DeletedAt, &job.Status, &timezone, &retryPolicy, &timeout, &job.Tenant,
+		&idempotencyKey, &jobLabels, &job.DependsOn, &job.Priority, &notifications, &template, &job.ArchivedAt, &blackout,
+		&job.ConcurrencyPolicy, &job.CatchUpPolicy, &params, &job.Environment, &promotion}
+	err := row.Scan(append(dest, extra...)...)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	job.CreatedAt = job.CreatedAt.UTC()
+	job.UpdatedAt = job.UpdatedAt.UTC()
+	job.NextRunTime = utc(job.NextRunTime)
+	job.DeletedAt = utc(job.DeletedAt)
+	job.ArchivedAt = utc(job.ArchivedAt)
+	job.Timeout = time.Duration(timeout)
+	if idempotencyKey != nil {
+		job.IdempotencyKey = *idempotencyKey
+	}
+	job.Schedule = scheduleSpec(cron, interval, timezone)
+	if retryPolicy != nil {
+		job.RetryPolicy = &scheduler.RetryPolicy{}
+		if err := json.Unmarshal([]byte(*retryPolicy), job.RetryPolicy); err != nil {
+			return nil, fmt.Errorf("invalid retry policy of job %s: %v", job.ID, err)
+		}
+	}
+	if jobLabels != nil {
+		if err := json.Unmarshal([]byte(*jobLabels), &job.Labels); err != nil {
+			return nil, fmt.Errorf("invalid labels of job %s: %v", job.ID, err)
+		}
+	}
+	if notifications != nil {
+		job.Notifications = &Notifications{}
+		if err := json.Unmarshal([]byte(*notifications), job.Notifications); err != nil {
+			return nil, fmt.Errorf("invalid notifications of job %s: %v", job.ID, err)
+		}
+	}
+	if template != nil {
+		job.Template = &TemplateLineage{}
+		if err := json.Unmarshal([]byte(*template), job.Template); err != nil {
+			return nil, fmt.Errorf("invalid template of job %s: %v", job.ID, err)
+		}
+	}
+	if blackout != nil {
+		job.Blackout = &Blackout{}
+		if err := json.Unmarshal([]byte(*blackout), job.Blackout); err != nil {
+			return nil, fmt.Errorf("invalid blackout of job %s: %v", job.ID, err)
+		}
+	}
+	if params != nil {
+		if err := json.Unmarshal([]byte(*params), &job.Params); err != nil {
+			return nil, fmt.Errorf("invalid params of job %s: %v", job.ID, err)
+		}
+	}
+	if promotion != nil {
+		job.Promotion = &Promotion{}
+		if err := json.Unmarshal([]byte(*promotion), job.Promotion); err != nil {
+			return nil, fmt.Errorf("invalid promotion of job %s: %v", job.ID, err)
+		}
+	}
+	return job, nil
+}
+
+// scheduleSpec returns the schedule stored in the schedule_cron, schedule_interval and schedule_timezone columns, nil
+// for jobs without one
+func scheduleSpec(cron *string, interval *int64, timezone *string) *scheduler.Spec {
+	if cron == nil && interval == nil {
+		return nil
+	}
+	spec := &scheduler.Spec{}
+	if cron != nil {
+		spec.Cron = *cron
+	}
+	if interval != nil {
+		spec.Interval = time.Duration(*interval)
+	}
+	if timezone != nil {
+		spec.Timezone = *timezone
+	}
+	return spec
+}
+
+// scheduleColumns returns the values of the schedule_cron, schedule_interval and schedule_timezone columns
+func scheduleColumns(spec *scheduler.Spec) (*string, *int64, *string) {
+	if spec == nil {
+		return nil, nil, nil
+	}
+	var cron, timezone *string
+	var interval *int64
+	if spec.Cron != "" {
+		cron = &spec.Cron
+	}
+	if spec.Interval != 0 {
+		i := int64(spec.Interval)
+		interval = &i
+	}
+	if spec.Timezone != "" {
+		timezone = &spec.Timezone
+	}
+	return cron, interval, timezone
+}
+
+// retryPolicyColumn returns the value of the retry_policy column
+func retryPolicyColumn(policy *scheduler.RetryPolicy) *string {
+	if policy == nil {
+		return nil
+	}
+	// A struct of numbers always marshals
+	data, _ := json.Marshal(policy)
+	value := string(data)
+	return &value
+}
+
+// notificationsColumn returns the value of the notifications column
+func notificationsColumn(notifications *Notifications) *string {
+	if notifications == nil {
+		return nil
+	}
+	// A struct of strings and numbers always marshals
+	data, _ := json.Marshal(notifications)
+	value := string(data)
+	return &value
+}
+
+// templateColumn returns the value of the template column
+func templateColumn(lineage *TemplateLineage) *string {
+	if lineage == nil {
+		return nil
+	}
+	// A struct of strings and numbers always marshals
+	data, _ := json.Marshal(lineage)
+	value := string(data)
+	return &value
+}
+
+// blackoutColumn returns the value of the blackout column
+func blackoutColumn(blackout *Blackout) *string {
+	if blackout == nil {
+		return nil
+	}
+	// A struct of strings and booleans always marshals
+	data, _ := json.Marshal(blackout)
+	value := string(data)
+	return &value
+}
+
+// promotionColumn returns the value of the promotion column
+func promotionColumn(promotion *Promotion) *string {
+	if promotion == nil {
+		return nil
+	}
+	// A struct of strings and a time always marshals
+	data, _ := json.Marshal(promotion)
+	value := string(data)
+	return &value
+}
+
+// paramsColumn returns the value of the params column, NULL without params
+func paramsColumn(params map[string]interface{}) *string {
+	if len(params) == 0 {
+		return nil
+	}
+	// Params only hold JSON values, they always marshal
+	data, _ := json.Marshal(params)
+	value := string(data)
+	return &value
+}
+
+// labelsColumn returns the value of the labels column, NULL without labels
+func labelsColumn(l map[string]string) *string {
+	if len(l) == 0 {
+		return nil
+	}
+	// A map of strings always marshals
+	data, _ := json.Marshal(l)
+	value := string(data)
+	return &value
+}
+
+// labelConditions translates a label selector into SQL conditions on the labels column, their values are appended
+// to args. A job without a label has NULL as its value, which never equals anything.
+func labelConditions(selector labels.Selector, args []interface{}) (string, []interface{}) {
+	conditions := ""
+	for _, r := range selector {
+		args = append(args, r.Key)
+		key := fmt.Sprintf("labels->>$%d", len(args))
+		var condition string
+		switch r.Operator {
+		case labels.Exists:
+			condition = key + " IS NOT NULL"
+		case labels.DoesNotExist:
+			condition = key + " IS NULL"
+		case labels.Equals, labels.In:
+			args = append(args, r.Values)
+			condition = fmt.Sprintf("%s = ANY($%d)", key, len(args))
+		case labels.NotEquals, labels.NotIn:
+			args = append(args, r.Values)
+			condition = fmt.Sprintf("NOT COALESCE(%s = ANY($%d), false)", key, len(args))
+		}
+		conditions += " AND " + condition
+	}
+	return conditions, args
+}
+
+// archivedCondition returns the condition of the WHERE clause selecting the jobs f matches, empty when it matches
+// every job
+func archivedCondition(f ArchiveFilter) string {
+	switch f {
+	case WithoutArchived:
+		return " AND archived_at IS NULL"
+	case OnlyArchived:
+		return " AND archived_at IS NOT NULL"
+	}
+	return ""
+}
+
+// environmentCondition returns the condition of the WHERE clause selecting the jobs in environment with its value
+// appended to args, empty when environment is
+func environmentCondition(environment string, args []interface{}) (string, []interface{}) {
+	if environment == "" {
+		return "", args
+	}
+	args = append(args, environment)
+	return fmt.Sprintf(" AND environment = $%d", len(args)), args
+}
+
+// idempotencyKeyColumn returns the value of the idempotency_key column, NULL without a key so the unique index
+// doesn't apply
+func idempotencyKeyColumn(key string) *string {
+	if key == "" {
+		return nil
+	}
+	return &key
+}
+
+// cycleIDColumn returns the value of the cycle_id column, NULL for runs stored before cycles existed
+func cycleIDColumn(cycleID string) *string {
+	if cycleID == "" {
+		return nil
+	}
+	return &cycleID
+}
+
+// uniqueViolation returns ErrNameTaken, ErrIdempotencyKeyUsed, ErrRunExists or ErrRevisionTaken when err was caused by
+// a job or secret violating the unique index on names, a job violating the one on idempotency keys, a run violating
+// the one on cycles or a revision violating the one on numbers, err otherwise
+func uniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case "jobs_owner_name_idx", "secrets_owner_name_idx":
+		return ErrNameTaken
+	case "jobs_idempotency_key_idx":
+		return ErrIdempotencyKeyUsed
+	case "job_runs_cycle_idx":
+		return ErrRunExists
+	case "job_revisions_revision_idx":
+		return ErrRevisionTaken
+	}
+	return err
+}
+
+// updateExcluded returns the assignments of ON CONFLICT DO UPDATE setting every column but the ID to the value of the
+// row that conflicted
+func updateExcluded(columns string) string {
+	var assignments []string
+	for _, column := range strings.Split(columns, ", ")[1:] {
+		assignments = append(assignments, column+" = EXCLUDED."+column)
+	}
+	return strings.Join(assignments, ", ")
+}
+
+// onConflict returns the ON CONFLICT clause of Put, rows of other tenants make the insert do nothing like rows that
+// aren't replaced
+func onConflict(table, columns string, replace bool) string {
+	if !replace {
+		return " ON CONFLICT (id) DO NOTHING"
+	}
+	return " ON CONFLICT (id) DO UPDATE SET " + updateExcluded(columns) + " WHERE " + table + ".tenant_id = EXCLUDED.tenant_id"
+}
+
+// otherTenant tells whether the row with id wasn't found in table because it belongs to another tenant and returns
+// ErrOtherTenant then, ErrNotFound otherwise
+func otherTenant(ctx context.Context, pool *pgxpool.Pool, table, id string) error {
+	t := tenant.FromContext(ctx)
+	if t == "" {
+		return ErrNotFound
+	}
+	var other bool
+	if err := conn(ctx, pool).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = $1 AND tenant_id <> $2)`, id, t).Scan(&other); err != nil {
+		return err
+	}
+	if other {
+		return ErrOtherTenant
+	}
+	return ErrNotFound
+}
+
+// PostgresJobRepository stores jobs in the jobs table
+type PostgresJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresJobRepository creates a repository for the jobs in a database migrated with Migrate
+func NewPostgresJobRepository(pool *pgxpool.Pool) *PostgresJobRepository {
+	return &PostgresJobRepository{pool: pool}
+}
+
+func (r *PostgresJobRepository) Create(ctx context.Context, job *Job) (*Job, error) {
+	cron, interval, timezone := scheduleColumns(job.Schedule)
+	row := conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO jobs (`+jobColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
+			$29, $30)
+		RETURNING `+jobColumns,
+		newID(), job.Name, job.Owner, job.Description, job.CreatedAt, job.UpdatedAt,
+		cron, interval, job.NextRunTime, job.Handler, job.Command, job.DeletedAt, job.Status, timezone, retryPolicyColumn(job.RetryPolicy), int64(job.Timeout),
+		tenant.FromContext(ctx), idempotencyKeyColumn(job.IdempotencyKey), labelsColumn(job.Labels), job.DependsOn,
+		job.Priority, notificationsColumn(job.Notifications), templateColumn(job.Template), job.ArchivedAt, blackoutColumn(job.Blackout),
+		job.ConcurrencyPolicy, job.CatchUpPolicy, paramsColumn(job.Params), job.Environment, promotionColumn(job.Promotion))
+	created, err := scanJob(row)
+	if err != nil {
+		return nil, uniqueViolation(err)
+	}
+	return created, nil
+}
+
+// CreateMany copies the jobs into the table in one go, either all of them are stored or none. When a name is taken
+// the jobs are inserted one by one instead, so only the jobs with taken names fail.
+func (r *PostgresJobRepository) CreateMany(ctx context.Context, jobs []*Job) ([]*Job, error) {
+	created := make([]*Job, 0, len(jobs))
+	rows := make([][]interface{}, 0, len(jobs))
+	for _, job := range jobs {
+		stored := *job
+		stored.ID = newID()
+		stored.Tenant = tenant.FromContext(ctx)
+		cron, interval, timezone := scheduleColumns(stored.Schedule)
+		rows = append(rows, []interface{}{stored.ID, stored.Name, stored.Owner, stored.Description, stored.CreatedAt, stored.UpdatedAt,
+			cron, interval, stored.NextRunTime, stored.Handler, stored.Command, stored.DeletedAt, stored.Status, timezone,
+			retryPolicyColumn(stored.RetryPolicy), int64(stored.Timeout), stored.Tenant, idempotencyKeyColumn(stored.IdempotencyKey),
+			labelsColumn(stored.Labels), stored.DependsOn, stored.Priority, notificationsColumn(stored.Notifications),
+			templateColumn(stored.Template), stored.ArchivedAt, blackoutColumn(stored.Blackout), stored.ConcurrencyPolicy,
+			stored.CatchUpPolicy, paramsColumn(stored.Params), stored.Environment, promotionColumn(stored.Promotion)})
+		created = append(created, &stored)
+	}
+	columns := strings.Split(jobColumns, ", ")
+	_, err := conn(ctx, r.pool).CopyFrom(ctx, pgx.Identifier{"jobs"}, columns, pgx.CopyFromRows(rows))
+	if uniqueViolation(err) == ErrNameTaken {
+		return r.createEach(ctx, jobs)
+	} else if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// createEach inserts the jobs one at a time and reports the ones that couldn't be stored in a *BatchError
+func (r *PostgresJobRepository) createEach(ctx context.Context, jobs []*Job) ([]*Job, error) {
+	created := make([]*Job, len(jobs))
+	batchErr := &BatchError{Errors: map[int]error{}}
+	for i, job := range jobs {
+		stored, err := r.Create(ctx, job)
+		if err != nil {
+			batchErr.Errors[i] = err
+			continue
+		}
+		created[i] = stored
+	}
+	if len(batchErr.Errors) > 0 {
+		return created, batchErr
+	}
+	return created, nil
+}
+
+func (r *PostgresJobRepository) Put(ctx context.Context, job *Job, replace bool) error {
+	if err := checkID(job.ID); err != nil {
+		return err
+	}
+	cron, interval, timezone := scheduleColumns(job.Schedule)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO jobs (`+jobColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
+			$29, $30)`+
+		onConflict("jobs", jobColumns, replace),
+		job.ID, job.Name, job.Owner, job.Description, job.CreatedAt, job.UpdatedAt,
+		cron, interval, job.NextRunTime, job.Handler, job.Command, job.DeletedAt, job.Status, timezone, retryPolicyColumn(job.RetryPolicy), int64(job.Timeout),
+		tenant.FromContext(ctx), idempotencyKeyColumn(job.IdempotencyKey), labelsColumn(job.Labels), job.DependsOn,
+		job.Priority, notificationsColumn(job.Notifications), templateColumn(job.Template), job.ArchivedAt, blackoutColumn(job.Blackout),
+		job.ConcurrencyPolicy, job.CatchUpPolicy, paramsColumn(job.Params), job.Environment, promotionColumn(job.Promotion))
+	if err != nil {
+		return uniqueViolation(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrExists
+	}
+	return nil
+}
+
+func (r *PostgresJobRepository) Get(ctx context.Context, id string, q Query) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	row := conn(ctx, r.pool).QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 OR deleted_at IS NULL) AND ($4 = '' OR tenant_id = $4)`,
+		id, q.Owners(), q.IncludeDeleted, tenant.FromContext(ctx))
+	job, err := scanJob(row)
+	if err == ErrNotFound {
+		return nil, otherTenant(ctx, r.pool, "jobs", id)
+	}
+	return job, err
+}
+
+func (r *PostgresJobRepository) GetMany(ctx context.Context, ids []string, q Query) ([]*Job, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if checkID(id) == nil {
+			valid = append(valid, id)
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE id = ANY ($1) AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 OR deleted_at IS NULL) AND ($4 = '' OR tenant_id = $4)`,
+		valid, q.Owners(), q.IncludeDeleted, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	jobs := []*Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *PostgresJobRepository) Update(ctx context.Context, id string, q Query, update *JobUpdate) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	args := []interface{}{id, q.Owners(), q.IncludeDeleted, tenant.FromContext(ctx)}
+	set := []string{}
+	column := func(name string, value interface{}) {
+		args = append(args, value)
+		set = append(set, fmt.Sprintf("%s = $%d", name, len(args)))
+	}
+	column("updated_at", update.UpdatedAt)
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"name", update.Name},
+		{"description", update.Description},
+		{"owner", update.Owner},
+		{"handler", update.Handler},
+		{"command", update.Command},
+		{"priority", update.Priority},
+		{"concurrency_policy", update.ConcurrencyPolicy},
+		{"catch_up_policy", update.CatchUpPolicy},
+		{"environment", update.Environment},
+	} {
+		if field.value != nil {
+			column(field.name, *field.value)
+		}
+	}
+	if update.Timeout != nil {
+		column("timeout", int64(*update.Timeout))
+	}
+	if update.SetSchedule {
+		cron, interval, timezone := scheduleColumns(update.Schedule)
+		column("schedule_cron", cron)
+		column("schedule_interval", interval)
+		column("schedule_timezone", timezone)
+		column("next_run_time", update.NextRunTime)
+	}
+	if update.SetRetryPolicy {
+		column("retry_policy", retryPolicyColumn(update.RetryPolicy))
+	}
+	if update.SetLabels {
+		column("labels", labelsColumn(update.Labels))
+	}
+	if update.SetDependsOn {
+		column("depends_on", update.DependsOn)
+	}
+	if update.SetNotifications {
+		column("notifications", notificationsColumn(update.Notifications))
+	}
+	if update.SetArchivedAt {
+		column("archived_at", update.ArchivedAt)
+	}
+	if update.SetBlackout {
+		column("blackout", blackoutColumn(update.Blackout))
+	}
+	if update.SetParams {
+		column("params", paramsColumn(update.Params))
+	}
+	if update.SetPromotion {
+		column("promotion", promotionColumn(update.Promotion))
+	}
+	row := conn(ctx, r.pool).QueryRow(ctx, `UPDATE jobs SET `+strings.Join(set, ", ")+`
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 OR deleted_at IS NULL) AND ($4 = '' OR tenant_id = $4)
+		RETURNING `+jobColumns, args...)
+	job, err := scanJob(row)
+	if err == ErrNotFound {
+		return nil, otherTenant(ctx, r.pool, "jobs", id)
+	} else if err != nil {
+		return nil, uniqueViolation(err)
+	}
+	return job, nil
+}
+
+func (r *PostgresJobRepository) Delete(ctx context.Context, id string, q Query, deletedAt time.Time) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	// Deleting a job again keeps the time it was deleted first
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE jobs SET deleted_at = $3
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND deleted_at IS NULL AND ($4 = '' OR tenant_id = $4)`,
+		id, q.Owners(), deletedAt, tenant.FromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		// Tell jobs of other tenants apart from missing and already deleted ones
+		if err := otherTenant(ctx, r.pool, "jobs", id); err != ErrNotFound {
+			return false, err
+		}
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresJobRepository) SetStatus(ctx context.Context, id string, q Query, from, to string, updatedAt time.Time) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	row := conn(ctx, r.pool).QueryRow(ctx, `UPDATE jobs SET status = $5, updated_at = $6
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 OR deleted_at IS NULL) AND status = $4 AND ($7 = '' OR tenant_id = $7)
+		RETURNING `+jobColumns, id, q.Owners(), q.IncludeDeleted, from, to, updatedAt, tenant.FromContext(ctx))
+	job, err := scanJob(row)
+	if err == ErrNotFound {
+		// Tell a missing job apart from one with another status
+		if _, err := r.Get(ctx, id, q); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	}
+	return job, err
+}
+
+func (r *PostgresJobRepository) Restore(ctx context.Context, id string, q Query, updatedAt time.Time) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	row := conn(ctx, r.pool).QueryRow(ctx, `UPDATE jobs SET deleted_at = NULL, updated_at = $4
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND deleted_at IS NOT NULL AND ($3 = '' OR tenant_id = $3)
+		RETURNING `+jobColumns, id, q.Owners(), tenant.FromContext(ctx), updatedAt)
+	job, err := scanJob(row)
+	if err == ErrNotFound {
+		return nil, otherTenant(ctx, r.pool, "jobs", id)
+	}
+	return job, err
+}
+
+func (r *PostgresJobRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM jobs WHERE deleted_at < $1 AND ($2 = '' OR tenant_id = $2)`, deletedBefore, tenant.FromContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *PostgresJobRepository) GetByIdempotencyKey(ctx context.Context, owner, key string) (*Job, error) {
+	row := conn(ctx, r.pool).QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE owner = $1 AND idempotency_key = $2 AND tenant_id = $3`, owner, key, tenant.FromContext(ctx))
+	return scanJob(row)
+}
+
+func (r *PostgresJobRepository) GetByName(ctx context.Context, owner, environment, name string) (*Job, error) {
+	row := conn(ctx, r.pool).QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE owner = $1 AND environment = $2 AND name = $3 AND tenant_id = $4`, owner, environment, name, tenant.FromContext(ctx))
+	return scanJob(row)
+}
+
+func (r *PostgresJobRepository) ExpireIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE jobs SET idempotency_key = NULL
+		WHERE idempotency_key IS NOT NULL AND created_at < $1 AND ($2 = '' OR tenant_id = $2)`, createdBefore, tenant.FromContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *PostgresJobRepository) Count(ctx context.Context, q Query) (int64, error) {
+	conditions, args := labelConditions(q.Labels, []interface{}{q.Owners(), q.IncludeDeleted, tenant.FromContext(ctx)})
+	environment, args := environmentCondition(q.Environment, args)
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM jobs
+		WHERE ($1::text[] IS NULL OR owner = ANY ($1)) AND ($2 OR deleted_at IS NULL) AND ($3 = '' OR tenant_id = $3)`+
+		conditions+environment+archivedCondition(q.Archived), args...).Scan(&count)
+	return count, err
+}
+
+func (r *PostgresJobRepository) Exists(ctx context.Context, id string, q Query) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	var exists bool
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM jobs
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 OR deleted_at IS NULL) AND ($4 = '' OR tenant_id = $4))`,
+		id, q.Owners(), q.IncludeDeleted, tenant.FromContext(ctx)).Scan(&exists)
+	return exists, err
+}
+
+func (r *PostgresJobRepository) List(ctx context.Context, q Query, after string, limit int) ([]*Job, error) {
+	if after != "" {
+		if err := checkID(after); err != nil {
+			return nil, err
+		}
+	}
+	conditions, args := labelConditions(q.Labels, []interface{}{q.Owners(), q.IncludeDeleted, after, limit, tenant.FromContext(ctx)})
+	environment, args := environmentCondition(q.Environment, args)
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE ($1::text[] IS NULL OR owner = ANY ($1)) AND ($2 OR deleted_at IS NULL) AND id > $3 AND ($5 = '' OR tenant_id = $5)`+
+		conditions+environment+archivedCondition(q.Archived)+`
+		ORDER BY id LIMIT $4`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	jobs := []*Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// searchVector is the text search vector of a job, it must match the expression of jobs_search_idx
+const searchVector = `setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', description), 'B')`
+
+// Search matches the vector of names and descriptions with websearch_to_tsquery and ranks the jobs with ts_rank
+func (r *PostgresJobRepository) Search(ctx context.Context, q Query, text string, offset, limit int) ([]*SearchResult, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+jobColumns+`, ts_rank(`+searchVector+`, query) AS score
+		FROM jobs, websearch_to_tsquery('english', $1) query
+		WHERE `+searchVector+` @@ query AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 OR deleted_at IS NULL) AND ($4 = '' OR tenant_id = $4)
+		ORDER BY score DESC, id OFFSET $5 LIMIT $6`, text, q.Owners(), q.IncludeDeleted, tenant.FromContext(ctx), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	results := []*SearchResult{}
+	for rows.Next() {
+		var score float32
+		job, err := scanJob(rows, &score)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &SearchResult{Job: job, Score: float64(score)})
+	}
+	return results, rows.Err()
+}
+
+func (r *PostgresJobRepository) DueJobs(ctx context.Context, now time.Time) ([]*scheduler.DueJob, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE next_run_time <= $1 AND (schedule_cron IS NOT NULL OR schedule_interval IS NOT NULL) AND deleted_at IS NULL AND status <> 'PAUSED'
+		AND archived_at IS NULL AND ($2 = '' OR tenant_id = $2)`, now, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	jobs := []*scheduler.DueJob{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job.dueJob())
+	}
+	return jobs, rows.Err()
+}
+
+func (r *PostgresJobRepository) ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE jobs SET next_run_time = $3
+		WHERE id = $1 AND next_run_time = $2 AND deleted_at IS NULL AND ($4 = '' OR tenant_id = $4)`, id, prev, next, tenant.FromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresJobRepository) Dependents(ctx context.Context, id string) ([]*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	// @> can use the GIN index on depends_on, = ANY can't
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE depends_on @> ARRAY[$1] AND deleted_at IS NULL AND ($2 = '' OR tenant_id = $2)
+		ORDER BY id`, id, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	jobs := []*Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Watch listens for the notifications the jobs table trigger sends, it holds on to one connection of the pool.
+// Notifications aren't kept, so a watch can't be resumed.
+func (r *PostgresJobRepository) Watch(ctx context.Context, q Query, resumeToken string) (JobEvents, error) {
+	if resumeToken != "" {
+		return nil, ErrResumeUnsupported
+	}
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN job_changes"); err != nil {
+		conn.Release()
+		return nil, err
+	}
+	// Deleted jobs are looked up to report their deletion
+	q.IncludeDeleted = true
+	return &postgresJobEvents{repo: r, conn: conn, query: q}, nil
+}
+
+// postgresJobEvents turns job_changes notifications into JobEvents
+type postgresJobEvents struct {
+	repo  *PostgresJobRepository
+	conn  *pgxpool.Conn
+	query Query
+}
+
+func (e *postgresJobEvents) Next(ctx context.Context) (*JobEvent, error) {
+	for {
+		notification, err := e.conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return nil, err
+		}
+		change := struct {
+			Op      string `json:"op"`
+			ID      string `json:"id"`
+			Deleted bool   `json:"deleted"`
+		}{}
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			return nil, err
+		}
+		// Rows are only deleted when jobs are purged, which isn't reported
+		if change.Op == "DELETE" {
+			continue
+		}
+		// Notifications only carry the ID, jobs of other owners or tenants or purged in the meantime are skipped
+		job, err := e.repo.Get(ctx, change.ID, e.query)
+		if err == ErrNotFound || err == ErrOtherTenant {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if change.Deleted {
+			return &JobEvent{Type: EventDeleted, Job: &Job{ID: job.ID}}, nil
+		}
+		// Updates looked up after the job was deleted are skipped, only the deletion itself is reported
+		if job.DeletedAt != nil {
+			continue
+		}
+		event := &JobEvent{Type: EventUpdated, Job: job}
+		if change.Op == "INSERT" {
+			event.Type = EventCreated
+		}
+		return event, nil
+	}
+}
+
+func (e *postgresJobEvents) Close(ctx context.Context) error {
+	defer e.conn.Release()
+	// A cancelled wait closes the connection, the pool drops it on release
+	if e.conn.Conn().IsClosed() {
+		return nil
+	}
+	_, err := e.conn.Exec(ctx, "UNLISTEN job_changes")
+	return err
+}
+
+const runColumns = "id, job_id, status, queued_at, start_time, end_time, output, error, attempt, retry_at, timeout, duration, tenant_id, cycle_id, priority, trigger, triggered_by, worker, cost_per_second, cost"
+
+// scanRun reads a row selected with runColumns
+func scanRun(row pgx.Row) (*Run, error) {
+	run := &Run{}
+	var timeout, duration int64
+	var cycleID *string
+	err := row.Scan(&run.ID, &run.JobID, &run.Status, &run.QueuedAt, &run.StartTime, &run.EndTime, &run.Output, &run.Error,
+		&run.Attempt, &run.RetryAt, &timeout, &duration, &run.Tenant, &cycleID, &run.Priority,
+		&run.Trigger, &run.TriggeredBy, &run.Worker, &run.CostPerSecond, &run.Cost)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	run.QueuedAt = run.QueuedAt.UTC()
+	run.StartTime = utc(run.StartTime)
+	run.EndTime = utc(run.EndTime)
+	run.RetryAt = utc(run.RetryAt)
+	run.Timeout = time.Duration(timeout)
+	run.Duration = time.Duration(duration)
+	// Runs stored before cycles existed have none
+	if cycleID != nil {
+		run.CycleID = *cycleID
+	}
+	return run, nil
+}
+
+// PostgresRunRepository stores runs in the job_runs table
+type PostgresRunRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresRunRepository creates a repository for the runs in a database migrated with Migrate
+func NewPostgresRunRepository(pool *pgxpool.Pool) *PostgresRunRepository {
+	return &PostgresRunRepository{pool: pool}
+}
+
+func (r *PostgresRunRepository) Create(ctx context.Context, run *Run) (*Run, error) {
+	if err := checkID(run.JobID); err != nil {
+		return nil, err
+	}
+	id := newID()
+	cycleID := run.CycleID
+	if cycleID == "" {
+		cycleID = id
+	}
+	row := conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO job_runs (`+runColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING `+runColumns,
+		id, run.JobID, run.Status, run.QueuedAt, run.StartTime, run.EndTime, run.Output, run.Error, run.Attempt, run.RetryAt,
+		int64(run.Timeout), int64(run.Duration), tenant.FromContext(ctx), cycleID, run.Priority, run.Trigger, run.TriggeredBy,
+		run.Worker, run.CostPerSecond, run.Cost)
+	created, err := scanRun(row)
+	if err != nil {
+		return nil, uniqueViolation(err)
+	}
+	return created, nil
+}
+
+func (r *PostgresRunRepository) Put(ctx context.Context, run *Run, replace bool) error {
+	for _, id := range []string{run.ID, run.JobID} {
+		if err := checkID(id); err != nil {
+			return err
+		}
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO job_runs (`+runColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`+
+		onConflict("job_runs", runColumns, replace),
+		run.ID, run.JobID, run.Status, run.QueuedAt, run.StartTime, run.EndTime, run.Output, run.Error, run.Attempt, run.RetryAt,
+		int64(run.Timeout), int64(run.Duration), tenant.FromContext(ctx), cycleIDColumn(run.CycleID), run.Priority, run.Trigger, run.TriggeredBy,
+		run.Worker, run.CostPerSecond, run.Cost)
+	if err != nil {
+		return uniqueViolation(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrExists
+	}
+	return nil
+}
+
+func (r *PostgresRunRepository) Update(ctx context.Context, run *Run) error {
+	if err := checkID(run.ID); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE job_runs SET status = $2, start_time = $3, end_time = $4, output = $5, error = $6,
+		timeout = $7, duration = $8, worker = $10, cost_per_second = $11, cost = $12
+		WHERE id = $1 AND ($9 = '' OR tenant_id = $9)`, run.ID, run.Status, run.StartTime, run.EndTime, run.Output, run.Error, int64(run.Timeout),
+		int64(run.Duration), tenant.FromContext(ctx), run.Worker, run.CostPerSecond, run.Cost)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRunRepository) Get(ctx context.Context, id string) (*Run, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	run, err := scanRun(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+runColumns+` FROM job_runs WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`,
+		id, tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		return nil, otherTenant(ctx, r.pool, "job_runs", id)
+	}
+	return run, err
+}
+
+func (r *PostgresRunRepository) Prune(ctx context.Context, jobID string, except []string, keep int, maxAge time.Duration) (int64, error) {
+	if jobID != "" {
+		if err := checkID(jobID); err != nil {
+			return 0, err
+		}
+	}
+	// NULL would match no job
+	if except == nil {
+		except = []string{}
+	}
+	var queuedBefore *time.Time
+	if maxAge > 0 {
+		t := time.Now().Add(-maxAge)
+		queuedBefore = &t
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM job_runs WHERE id IN (
+		SELECT id FROM (
+			SELECT id, queued_at, row_number() OVER (PARTITION BY job_id ORDER BY id DESC) AS n FROM job_runs
+			WHERE tenant_id = $1 AND ($2 = '' OR job_id = $2) AND NOT (job_id = ANY ($3))
+				AND status NOT IN ('QUEUED', 'WAITING', 'RUNNING')
+		) finished
+		WHERE ($4 > 0 AND n > $4) OR queued_at < $5)`,
+		tenant.FromContext(ctx), jobID, except, keep, queuedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *PostgresRunRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Run, error) {
+	for _, id := range []string{jobID, before} {
+		if id == "" {
+			continue
+		}
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE ($1 = '' OR job_id = $1) AND ($2 = '' OR id < $2) AND ($4 = '' OR tenant_id = $4)
+		ORDER BY id DESC LIMIT $3`, jobID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	runs := []*Run{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *PostgresRunRepository) CycleRuns(ctx context.Context, cycleID string) ([]*Run, error) {
+	if err := checkID(cycleID); err != nil {
+		return nil, err
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE cycle_id = $1 AND ($2 = '' OR tenant_id = $2)
+		ORDER BY id`, cycleID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	runs := []*Run{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *PostgresRunRepository) DueRetries(ctx context.Context, now time.Time) ([]*scheduler.DueRetry, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE status = $1 AND retry_at <= $2 AND ($3 = '' OR tenant_id = $3)`, RunWaiting, now, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	retries := []*scheduler.DueRetry{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		retries = append(retries, &scheduler.DueRetry{
+			RunID:   run.ID,
+			JobID:   run.JobID,
+			Tenant:  run.Tenant,
+			Attempt: run.Attempt,
+			RetryAt: *run.RetryAt,
+		})
+	}
+	return retries, rows.Err()
+}
+
+func (r *PostgresRunRepository) ClaimRetry(ctx context.Context, id string) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	// Only one replica can move the run out of waiting
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE job_runs SET status = $3 WHERE id = $1 AND status = $2 AND ($4 = '' OR tenant_id = $4)`,
+		id, RunWaiting, RunQueued, tenant.FromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Stats counts the runs by status in one query and computes the durations in another, percentile_disc picks the same
+// nearest rank percentiles as the other backends
+func (r *PostgresRunRepository) Stats(ctx context.Context, jobID string, from, to time.Time) (*RunStats, error) {
+	if err := checkID(jobID); err != nil {
+		return nil, err
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT status, count(*) FROM job_runs
+		WHERE job_id = $1 AND queued_at >= $2 AND queued_at < $3 AND ($4 = '' OR tenant_id = $4)
+		GROUP BY status`, jobID, from, to, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	stats := &RunStats{Counts: map[string]int64{}}
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		stats.Counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var mean int64
+	var percentiles []int64
+	err = conn(ctx, r.pool).QueryRow(ctx, `SELECT count(*), COALESCE(avg(duration), 0)::BIGINT,
+		percentile_disc(ARRAY[0.5, 0.95, 0.99]) WITHIN GROUP (ORDER BY duration)
+		FROM job_runs
+		WHERE job_id = $1 AND queued_at >= $2 AND queued_at < $3 AND start_time IS NOT NULL AND end_time IS NOT NULL
+		AND ($4 = '' OR tenant_id = $4)`,
+		jobID, from, to, tenant.FromContext(ctx)).Scan(&stats.Finished, &mean, &percentiles)
+	if err != nil {
+		return nil, err
+	}
+	stats.Mean = time.Duration(mean)
+	if len(percentiles) == 3 {
+		stats.P50 = time.Duration(percentiles[0])
+		stats.P95 = time.Duration(percentiles[1])
+		stats.P99 = time.Duration(percentiles[2])
+	}
+	return stats, nil
+}
+
+func (r *PostgresRunRepository) Usage(ctx context.Context, jobIDs []string, from, to time.Time) (*RunUsage, error) {
+	for _, id := range jobIDs {
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT status, count(*), COALESCE(sum(duration), 0)::BIGINT FROM job_runs
+		WHERE job_id = ANY ($1) AND queued_at >= $2 AND queued_at < $3 AND ($4 = '' OR tenant_id = $4)
+		GROUP BY status`, jobIDs, from, to, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	usage := &RunUsage{Counts: map[string]int64{}}
+	for rows.Next() {
+		var status string
+		var count, duration int64
+		if err := rows.Scan(&status, &count, &duration); err != nil {
+			return nil, err
+		}
+		usage.Counts[status] = count
+		usage.ComputeTime += time.Duration(duration)
+	}
+	return usage, rows.Err()
+}
+
+// Costs groups the runs by job and month with date_trunc in UTC
+func (r *PostgresRunRepository) Costs(ctx context.Context, jobIDs []string, from, to time.Time) ([]*RunCost, error) {
+	for _, id := range jobIDs {
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT job_id, date_trunc('month', queued_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', count(*),
+		COALESCE(sum(duration), 0)::BIGINT, COALESCE(sum(cost), 0)
+		FROM job_runs
+		WHERE job_id = ANY ($1) AND queued_at >= $2 AND queued_at < $3 AND ($4 = '' OR tenant_id = $4)
+		GROUP BY 1, 2
+		ORDER BY 1, 2`, jobIDs, from, to, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	costs := []*RunCost{}
+	for rows.Next() {
+		c := &RunCost{}
+		var duration int64
+		if err := rows.Scan(&c.JobID, &c.Month, &c.Runs, &duration, &c.Cost); err != nil {
+			return nil, err
+		}
+		c.Month = c.Month.UTC()
+		c.ComputeTime = time.Duration(duration)
+		costs = append(costs, c)
+	}
+	return costs, rows.Err()
+}
+
+// TimeSeries groups the runs by bucket with date_trunc, converting to the wall clock of loc and back
+func (r *PostgresRunRepository) TimeSeries(ctx context.Context, jobID string, from, to time.Time, bucket string, loc *time.Location) ([]*RunBucket, error) {
+	if err := checkID(jobID); err != nil {
+		return nil, err
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT date_trunc($4, queued_at AT TIME ZONE $5) AT TIME ZONE $5 AS bucket, status, count(*),
+		count(*) FILTER (WHERE start_time IS NOT NULL AND end_time IS NOT NULL),
+		COALESCE(sum(duration) FILTER (WHERE start_time IS NOT NULL AND end_time IS NOT NULL), 0)::BIGINT
+		FROM job_runs
+		WHERE job_id = $1 AND queued_at >= $2 AND queued_at < $3 AND ($6 = '' OR tenant_id = $6)
+		GROUP BY 1, 2
+		ORDER BY 1`, jobID, from, to, bucket, loc.String(), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// The groups are sorted by the start of their bucket, so the groups of a bucket follow each other
+	series := []*RunBucket{}
+	var total int64
+	for rows.Next() {
+		var start time.Time
+		var status string
+		var count, finished, duration int64
+		if err := rows.Scan(&start, &status, &count, &finished, &duration); err != nil {
+			return nil, err
+		}
+		if len(series) == 0 || !series[len(series)-1].Start.Equal(start) {
+			if len(series) > 0 {
+				setMean(series[len(series)-1], total)
+			}
+			series = append(series, &RunBucket{Start: start.In(loc), Counts: map[string]int64{}})
+			total = 0
+		}
+		b := series[len(series)-1]
+		b.Counts[status] += count
+		b.Finished += finished
+		total += duration
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(series) > 0 {
+		setMean(series[len(series)-1], total)
+	}
+	return series, nil
+}
+
+// PostgresLeaseRepository stores leases in the leases table
+type PostgresLeaseRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresLeaseRepository creates a repository for the leases in a database migrated with Migrate
+func NewPostgresLeaseRepository(pool *pgxpool.Pool) *PostgresLeaseRepository {
+	return &PostgresLeaseRepository{pool: pool}
+}
+
+// AcquireLease inserts the lease or takes it over, the conflict update only applies while the lease is free
+func (r *PostgresLeaseRepository) AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO leases (name, holder, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+		WHERE leases.holder = EXCLUDED.holder OR leases.expires_at <= $4`, name, holder, now.Add(ttl), now)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresLeaseRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM leases WHERE name = $1 AND holder = $2`, name, holder)
+	return err
+}
+
+// PostgresAuditRepository stores the audit log in the audit_entries table
+type PostgresAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAuditRepository creates a repository for the audit entries in a database migrated with Migrate
+func NewPostgresAuditRepository(pool *pgxpool.Pool) *PostgresAuditRepository {
+	return &PostgresAuditRepository{pool: pool}
+}
+
+// auditColumns are the columns of audit_entries in the order scanAuditEntry expects them
+const auditColumns = "id, time, actor, method, job_id, owner, changes, tenant_id"
+
+// scanAuditEntry reads an audit entry selected with auditColumns
+func scanAuditEntry(row pgx.Row) (*AuditEntry, error) {
+	entry := &AuditEntry{}
+	var changes string
+	if err := row.Scan(&entry.ID, &entry.Time, &entry.Actor, &entry.Method, &entry.JobID, &entry.Owner, &changes, &entry.Tenant); err != nil {
+		return nil, err
+	}
+	entry.Time = entry.Time.UTC()
+	if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+		return nil, fmt.Errorf("invalid changes of audit entry %s: %v", entry.ID, err)
+	}
+	return entry, nil
+}
+
+func (r *PostgresAuditRepository) Create(ctx context.Context, entry *AuditEntry) (*AuditEntry, error) {
+	stored := *entry
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	changes := stored.Changes
+	if changes == nil {
+		changes = []AuditChange{}
+	}
+	// A slice of strings always marshals
+	data, _ := json.Marshal(changes)
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO audit_entries (`+auditColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		stored.ID, stored.Time, stored.Actor, stored.Method, stored.JobID, stored.Owner, string(data), stored.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (r *PostgresAuditRepository) List(ctx context.Context, filter AuditFilter, before string, limit int) ([]*AuditEntry, error) {
+	for _, id := range []string{filter.JobID, before} {
+		if id == "" {
+			continue
+		}
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+auditColumns+` FROM audit_entries
+		WHERE ($1 = '' OR actor = $1) AND ($2 = '' OR job_id = $2) AND ($3::text[] IS NULL OR owner = ANY ($3)) AND ($4 = '' OR id < $4)
+		AND ($6 = '' OR tenant_id = $6)
+		ORDER BY id DESC LIMIT $5`, filter.Actor, filter.JobID, filter.ownerQuery().Owners(), before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := []*AuditEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// PostgresWebhookRepository stores webhooks in the webhooks table and their dead letters in webhook_dead_letters
+type PostgresWebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresWebhookRepository creates a repository for the webhooks in a database migrated with Migrate
+func NewPostgresWebhookRepository(pool *pgxpool.Pool) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{pool: pool}
+}
+
+// webhookColumns are the columns of webhooks in the order scanWebhook expects them
+const webhookColumns = "id, owner, url, secret, events, created_at, tenant_id"
+
+// scanWebhook reads a webhook selected with webhookColumns
+func scanWebhook(row pgx.Row) (*Webhook, error) {
+	hook := &Webhook{}
+	err := row.Scan(&hook.ID, &hook.Owner, &hook.URL, &hook.Secret, &hook.Events, &hook.CreatedAt, &hook.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	hook.CreatedAt = hook.CreatedAt.UTC()
+	return hook, nil
+}
+
+func (r *PostgresWebhookRepository) Create(ctx context.Context, hook *Webhook) (*Webhook, error) {
+	return scanWebhook(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO webhooks (`+webhookColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+webhookColumns, newID(), hook.Owner, hook.URL, hook.Secret, hook.Events, hook.CreatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresWebhookRepository) Get(ctx context.Context, id, owner string) (*Webhook, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanWebhook(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks
+		WHERE id = $1 AND ($2 = '' OR owner = $2) AND ($3 = '' OR tenant_id = $3)`, id, owner, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresWebhookRepository) List(ctx context.Context, owner string) ([]*Webhook, error) {
+	return r.find(ctx, owner, "")
+}
+
+func (r *PostgresWebhookRepository) Subscribed(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	return r.find(ctx, owner, event)
+}
+
+// find returns the webhooks of owner receiving the event oldest first, of all owners and events when they are empty.
+// Webhooks without events receive all of them.
+func (r *PostgresWebhookRepository) find(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+webhookColumns+` FROM webhooks
+		WHERE ($1 = '' OR owner = $1) AND ($2 = '' OR events IS NULL OR $2 = ANY (events)) AND ($3 = '' OR tenant_id = $3)
+		ORDER BY id`, owner, event, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hooks := []*Webhook{}
+	for rows.Next() {
+		hook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *PostgresWebhookRepository) Delete(ctx context.Context, id, owner string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	// The dead letters are deleted by the foreign key
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM webhooks WHERE id = $1 AND ($2 = '' OR owner = $2) AND ($3 = '' OR tenant_id = $3)`,
+		id, owner, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// deadLetterColumns are the columns of webhook_dead_letters in the order scanDeadLetter expects them
+const deadLetterColumns = "id, webhook_id, event, payload, attempts, error, created_at, tenant_id"
+
+// scanDeadLetter reads a dead letter selected with deadLetterColumns
+func scanDeadLetter(row pgx.Row) (*DeadLetter, error) {
+	letter := &DeadLetter{}
+	if err := row.Scan(&letter.ID, &letter.WebhookID, &letter.Event, &letter.Payload, &letter.Attempts, &letter.Error,
+		&letter.CreatedAt, &letter.Tenant); err != nil {
+		return nil, err
+	}
+	letter.CreatedAt = letter.CreatedAt.UTC()
+	return letter, nil
+}
+
+func (r *PostgresWebhookRepository) CreateDeadLetter(ctx context.Context, letter *DeadLetter) (*DeadLetter, error) {
+	if err := checkID(letter.WebhookID); err != nil {
+		return nil, err
+	}
+	return scanDeadLetter(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO webhook_dead_letters (`+deadLetterColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING `+deadLetterColumns,
+		newID(), letter.WebhookID, letter.Event, letter.Payload, letter.Attempts, letter.Error, letter.CreatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresWebhookRepository) ListDeadLetters(ctx context.Context, webhookID, before string, limit int) ([]*DeadLetter, error) {
+	if err := checkID(webhookID); err != nil {
+		return nil, err
+	}
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+deadLetterColumns+` FROM webhook_dead_letters
+		WHERE webhook_id = $1 AND ($2 = '' OR id < $2) AND ($4 = '' OR tenant_id = $4)
+		ORDER BY id DESC LIMIT $3`, webhookID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	letters := []*DeadLetter{}
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+	return letters, rows.Err()
+}
+
+// PostgresUserRepository stores users in the users table and their API keys in api_keys
+type PostgresUserRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresUserRepository creates a repository for the users in a database migrated with Migrate
+func NewPostgresUserRepository(pool *pgxpool.Pool) *PostgresUserRepository {
+	return &PostgresUserRepository{pool: pool}
+}
+
+// userColumns are the columns of users in the order scanUser expects them
+const userColumns = "id, email, display_name, roles, created_at, updated_at, tenant_id"
+
+// scanUser reads a user selected with userColumns
+func scanUser(row pgx.Row) (*User, error) {
+	user := &User{}
+	err := row.Scan(&user.ID, &user.Email, &user.DisplayName, &user.Roles, &user.CreatedAt, &user.UpdatedAt, &user.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	user.CreatedAt = user.CreatedAt.UTC()
+	user.UpdatedAt = user.UpdatedAt.UTC()
+	return user, nil
+}
+
+func (r *PostgresUserRepository) Create(ctx context.Context, user *User) (*User, error) {
+	id := user.ID
+	if id == "" {
+		id = newID()
+	}
+	created, err := scanUser(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO users (`+userColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING RETURNING `+userColumns,
+		id, user.Email, user.DisplayName, user.Roles, user.CreatedAt, user.UpdatedAt, tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		// Nothing is returned when the insert was skipped
+		return nil, ErrExists
+	}
+	return created, err
+}
+
+func (r *PostgresUserRepository) Get(ctx context.Context, id string) (*User, error) {
+	return scanUser(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`,
+		id, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresUserRepository) List(ctx context.Context) ([]*User, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+userColumns+` FROM users WHERE ($1 = '' OR tenant_id = $1)
+		ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	users := []*User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *PostgresUserRepository) Update(ctx context.Context, user *User) (*User, error) {
+	return scanUser(conn(ctx, r.pool).QueryRow(ctx, `UPDATE users SET email = $2, display_name = $3, roles = $4, updated_at = $5
+		WHERE id = $1 AND ($6 = '' OR tenant_id = $6) RETURNING `+userColumns,
+		user.ID, user.Email, user.DisplayName, user.Roles, user.UpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
+	// The API keys are deleted by the foreign key
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM users WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`, id, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// apiKeyColumns are the columns of api_keys in the order scanAPIKey expects them
+const apiKeyColumns = "id, user_id, name, hash, created_at, rotated_at, tenant_id"
+
+// scanAPIKey reads a key selected with apiKeyColumns
+func scanAPIKey(row pgx.Row) (*APIKey, error) {
+	key := &APIKey{}
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Hash, &key.CreatedAt, &key.RotatedAt, &key.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	key.CreatedAt = key.CreatedAt.UTC()
+	if key.RotatedAt != nil {
+		rotatedAt := key.RotatedAt.UTC()
+		key.RotatedAt = &rotatedAt
+	}
+	return key, nil
+}
+
+func (r *PostgresUserRepository) CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error) {
+	return scanAPIKey(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO api_keys (`+apiKeyColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+apiKeyColumns, newID(), key.UserID, key.Name, key.Hash, key.CreatedAt, nil, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresUserRepository) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE user_id = $1 AND ($2 = '' OR tenant_id = $2)
+		ORDER BY id`, userID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	keys := []*APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *PostgresUserRepository) RotateAPIKey(ctx context.Context, userID, id, hash string, rotatedAt time.Time) (*APIKey, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanAPIKey(conn(ctx, r.pool).QueryRow(ctx, `UPDATE api_keys SET hash = $3, rotated_at = $4
+		WHERE id = $1 AND user_id = $2 AND ($5 = '' OR tenant_id = $5) RETURNING `+apiKeyColumns,
+		id, userID, hash, rotatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresUserRepository) DeleteAPIKey(ctx context.Context, userID, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM api_keys WHERE id = $1 AND user_id = $2 AND ($3 = '' OR tenant_id = $3)`,
+		id, userID, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresUserRepository) APIKey(ctx context.Context, id string) (*APIKey, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanAPIKey(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`,
+		id, tenant.FromContext(ctx)))
+}
+
+// PostgresTeamRepository stores teams with their members in the teams table
+type PostgresTeamRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTeamRepository creates a repository for the teams in a database migrated with Migrate
+func NewPostgresTeamRepository(pool *pgxpool.Pool) *PostgresTeamRepository {
+	return &PostgresTeamRepository{pool: pool}
+}
+
+// teamColumns are the columns of teams in the order scanTeam expects them
+const teamColumns = "id, name, members, roles, created_at, updated_at, tenant_id"
+
+// scanTeam reads a team selected with teamColumns
+func scanTeam(row pgx.Row) (*Team, error) {
+	team := &Team{}
+	err := row.Scan(&team.ID, &team.Name, &team.Members, &team.Roles, &team.CreatedAt, &team.UpdatedAt, &team.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	team.CreatedAt = team.CreatedAt.UTC()
+	team.UpdatedAt = team.UpdatedAt.UTC()
+	return team, nil
+}
+
+func (r *PostgresTeamRepository) Create(ctx context.Context, team *Team) (*Team, error) {
+	return scanTeam(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO teams (`+teamColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+teamColumns, newID(), team.Name, append([]string{}, team.Members...), append([]string{}, team.Roles...), team.CreatedAt, team.UpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTeamRepository) Get(ctx context.Context, id string) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanTeam(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+teamColumns+` FROM teams WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`,
+		id, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTeamRepository) List(ctx context.Context, member string) ([]*Team, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+teamColumns+` FROM teams
+		WHERE ($1 = '' OR $1 = ANY (members)) AND ($2 = '' OR tenant_id = $2) ORDER BY id`, member, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	teams := []*Team{}
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+func (r *PostgresTeamRepository) Rename(ctx context.Context, id, name string, updatedAt time.Time) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanTeam(conn(ctx, r.pool).QueryRow(ctx, `UPDATE teams SET name = $2, updated_at = $3
+		WHERE id = $1 AND ($4 = '' OR tenant_id = $4) RETURNING `+teamColumns, id, name, updatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTeamRepository) SetRoles(ctx context.Context, id string, roles []string, updatedAt time.Time) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanTeam(conn(ctx, r.pool).QueryRow(ctx, `UPDATE teams SET roles = $2, updated_at = $3
+		WHERE id = $1 AND ($4 = '' OR tenant_id = $4) RETURNING `+teamColumns, id, append([]string{}, roles...), updatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTeamRepository) Delete(ctx context.Context, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM teams WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`, id, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// updateMembers applies set to the team with id when it matches condition, which both refer to the user ID as $2, and
+// returns the team. Teams that don't match condition are returned unchanged.
+func (r *PostgresTeamRepository) updateMembers(ctx context.Context, id, userID, set, condition string, updatedAt time.Time) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	team, err := scanTeam(conn(ctx, r.pool).QueryRow(ctx, `UPDATE teams SET `+set+`, updated_at = $3
+		WHERE id = $1 AND `+condition+` AND ($4 = '' OR tenant_id = $4) RETURNING `+teamColumns, id, userID, updatedAt, tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		// Tell a missing team apart from one that needs no change
+		return r.Get(ctx, id)
+	}
+	return team, err
+}
+
+func (r *PostgresTeamRepository) AddMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	return r.updateMembers(ctx, id, userID, "members = array_append(members, $2)", "NOT $2 = ANY (members)", updatedAt)
+}
+
+func (r *PostgresTeamRepository) RemoveMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	return r.updateMembers(ctx, id, userID, "members = array_remove(members, $2)", "$2 = ANY (members)", updatedAt)
+}
+
+// PostgresTemplateRepository stores job templates in the templates table
+type PostgresTemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTemplateRepository creates a repository for the templates in a database migrated with Migrate
+func NewPostgresTemplateRepository(pool *pgxpool.Pool) *PostgresTemplateRepository {
+	return &PostgresTemplateRepository{pool: pool}
+}
+
+// templateColumns are the columns of templates in the order scanTemplate expects them
+const templateColumns = "id, name, description, owner, parameters, job, version, created_at, updated_at, tenant_id"
+
+// scanTemplate reads a template selected with templateColumns
+func scanTemplate(row pgx.Row) (*JobTemplate, error) {
+	template := &JobTemplate{}
+	var parameters string
+	err := row.Scan(&template.ID, &template.Name, &template.Description, &template.Owner, &parameters, &template.Job,
+		&template.Version, &template.CreatedAt, &template.UpdatedAt, &template.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(parameters), &template.Parameters); err != nil {
+		return nil, fmt.Errorf("invalid parameters of template %s: %v", template.ID, err)
+	}
+	template.CreatedAt = template.CreatedAt.UTC()
+	template.UpdatedAt = template.UpdatedAt.UTC()
+	return template, nil
+}
+
+// parametersColumn returns the value of the parameters column of templates
+func parametersColumn(parameters []TemplateParameter) string {
+	// A slice of structs of strings always marshals
+	data, _ := json.Marshal(append([]TemplateParameter{}, parameters...))
+	return string(data)
+}
+
+func (r *PostgresTemplateRepository) Create(ctx context.Context, template *JobTemplate) (*JobTemplate, error) {
+	return scanTemplate(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO templates (`+templateColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, 1, $7, $8, $9) RETURNING `+templateColumns,
+		newID(), template.Name, template.Description, template.Owner, parametersColumn(template.Parameters), template.Job,
+		template.CreatedAt, template.UpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTemplateRepository) Get(ctx context.Context, id string, q Query) (*JobTemplate, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanTemplate(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+templateColumns+` FROM templates
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTemplateRepository) List(ctx context.Context, q Query) ([]*JobTemplate, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+templateColumns+` FROM templates
+		WHERE ($1::text[] IS NULL OR owner = ANY ($1)) AND ($2 = '' OR tenant_id = $2) ORDER BY id`,
+		q.Owners(), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	templates := []*JobTemplate{}
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (r *PostgresTemplateRepository) Update(ctx context.Context, template *JobTemplate, q Query) (*JobTemplate, error) {
+	if err := checkID(template.ID); err != nil {
+		return nil, err
+	}
+	return scanTemplate(conn(ctx, r.pool).QueryRow(ctx, `UPDATE templates
+		SET name = $2, description = $3, owner = $4, parameters = $5, job = $6, updated_at = $7, version = version + 1
+		WHERE id = $1 AND ($8::text[] IS NULL OR owner = ANY ($8)) AND ($9 = '' OR tenant_id = $9) RETURNING `+templateColumns,
+		template.ID, template.Name, template.Description, template.Owner, parametersColumn(template.Parameters), template.Job,
+		template.UpdatedAt, q.Owners(), tenant.FromContext(ctx)))
+}
+
+func (r *PostgresTemplateRepository) Delete(ctx context.Context, id string, q Query) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM templates
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// PostgresCalendarRepository stores calendars in the calendars table
+type PostgresCalendarRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresCalendarRepository creates a repository for the calendars in a database migrated with Migrate
+func NewPostgresCalendarRepository(pool *pgxpool.Pool) *PostgresCalendarRepository {
+	return &PostgresCalendarRepository{pool: pool}
+}
+
+// calendarColumns are the columns of calendars in the order scanCalendar expects them
+const calendarColumns = "id, name, description, owner, timezone, windows, holidays, created_at, updated_at, tenant_id"
+
+// scanCalendar reads a calendar selected with calendarColumns
+func scanCalendar(row pgx.Row) (*Calendar, error) {
+	calendar := &Calendar{}
+	var windows, holidays string
+	err := row.Scan(&calendar.ID, &calendar.Name, &calendar.Description, &calendar.Owner, &calendar.Blackouts.Timezone, &windows, &holidays,
+		&calendar.CreatedAt, &calendar.UpdatedAt, &calendar.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(windows), &calendar.Blackouts.Windows); err != nil {
+		return nil, fmt.Errorf("invalid windows of calendar %s: %v", calendar.ID, err)
+	}
+	if err := json.Unmarshal([]byte(holidays), &calendar.Blackouts.Holidays); err != nil {
+		return nil, fmt.Errorf("invalid holidays of calendar %s: %v", calendar.ID, err)
+	}
+	calendar.CreatedAt = calendar.CreatedAt.UTC()
+	calendar.UpdatedAt = calendar.UpdatedAt.UTC()
+	return calendar, nil
+}
+
+// windowsColumn returns the value of the windows column of calendars
+func windowsColumn(windows []scheduler.Window) string {
+	// A slice of structs of strings, times and numbers always marshals
+	data, _ := json.Marshal(append([]scheduler.Window{}, windows...))
+	return string(data)
+}
+
+// holidaysColumn returns the value of the holidays column of calendars
+func holidaysColumn(holidays []string) string {
+	// A slice of strings always marshals
+	data, _ := json.Marshal(append([]string{}, holidays...))
+	return string(data)
+}
+
+func (r *PostgresCalendarRepository) Create(ctx context.Context, calendar *Calendar) (*Calendar, error) {
+	return scanCalendar(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO calendars (`+calendarColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING `+calendarColumns,
+		newID(), calendar.Name, calendar.Description, calendar.Owner, calendar.Blackouts.Timezone, windowsColumn(calendar.Blackouts.Windows),
+		holidaysColumn(calendar.Blackouts.Holidays), calendar.CreatedAt, calendar.UpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresCalendarRepository) Get(ctx context.Context, id string, q Query) (*Calendar, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanCalendar(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+calendarColumns+` FROM calendars
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx)))
+}
+
+// query returns the calendars a query selecting calendarColumns returns in order
+func (r *PostgresCalendarRepository) query(ctx context.Context, sql string, args ...interface{}) ([]*Calendar, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	calendars := []*Calendar{}
+	for rows.Next() {
+		calendar, err := scanCalendar(rows)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, rows.Err()
+}
+
+func (r *PostgresCalendarRepository) List(ctx context.Context, q Query) ([]*Calendar, error) {
+	return r.query(ctx, `SELECT `+calendarColumns+` FROM calendars
+		WHERE ($1::text[] IS NULL OR owner = ANY ($1)) AND ($2 = '' OR tenant_id = $2) ORDER BY id`,
+		q.Owners(), tenant.FromContext(ctx))
+}
+
+func (r *PostgresCalendarRepository) Update(ctx context.Context, calendar *Calendar, q Query) (*Calendar, error) {
+	if err := checkID(calendar.ID); err != nil {
+		return nil, err
+	}
+	return scanCalendar(conn(ctx, r.pool).QueryRow(ctx, `UPDATE calendars
+		SET name = $2, description = $3, owner = $4, timezone = $5, windows = $6, holidays = $7, updated_at = $8
+		WHERE id = $1 AND ($9::text[] IS NULL OR owner = ANY ($9)) AND ($10 = '' OR tenant_id = $10) RETURNING `+calendarColumns,
+		calendar.ID, calendar.Name, calendar.Description, calendar.Owner, calendar.Blackouts.Timezone, windowsColumn(calendar.Blackouts.Windows),
+		holidaysColumn(calendar.Blackouts.Holidays), calendar.UpdatedAt, q.Owners(), tenant.FromContext(ctx)))
+}
+
+func (r *PostgresCalendarRepository) Delete(ctx context.Context, id string, q Query) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM calendars
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCalendarRepository) Blackouts(ctx context.Context, ids []string) ([]*scheduler.Blackouts, error) {
+	calendars, err := r.query(ctx, `SELECT `+calendarColumns+` FROM calendars
+		WHERE id = ANY ($1) AND ($2 = '' OR tenant_id = $2) ORDER BY id`, ids, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	blackouts := make([]*scheduler.Blackouts, 0, len(calendars))
+	for _, calendar := range calendars {
+		blackouts = append(blackouts, &calendar.Blackouts)
+	}
+	return blackouts, nil
+}
+
+// PostgresSecretRepository stores secrets in the secrets table
+type PostgresSecretRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresSecretRepository creates a repository for the secrets in a database migrated with Migrate
+func NewPostgresSecretRepository(pool *pgxpool.Pool) *PostgresSecretRepository {
+	return &PostgresSecretRepository{pool: pool}
+}
+
+// secretColumns are the columns of secrets in the order scanSecret expects them
+const secretColumns = "id, name, description, owner, value, created_at, updated_at, tenant_id"
+
+// scanSecret reads a secret selected with secretColumns
+func scanSecret(row pgx.Row) (*Secret, error) {
+	secret := &Secret{}
+	err := row.Scan(&secret.ID, &secret.Name, &secret.Description, &secret.Owner, &secret.Value, &secret.CreatedAt,
+		&secret.UpdatedAt, &secret.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	secret.CreatedAt = secret.CreatedAt.UTC()
+	secret.UpdatedAt = secret.UpdatedAt.UTC()
+	return secret, nil
+}
+
+func (r *PostgresSecretRepository) Create(ctx context.Context, secret *Secret) (*Secret, error) {
+	created, err := scanSecret(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO secrets (`+secretColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING `+secretColumns,
+		newID(), secret.Name, secret.Description, secret.Owner, secret.Value, secret.CreatedAt, secret.UpdatedAt,
+		tenant.FromContext(ctx)))
+	if err != nil {
+		return nil, uniqueViolation(err)
+	}
+	return created, nil
+}
+
+func (r *PostgresSecretRepository) Get(ctx context.Context, id string, q Query) (*Secret, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSecret(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+secretColumns+` FROM secrets
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx)))
+}
+
+// query returns the secrets a query selecting secretColumns returns in order
+func (r *PostgresSecretRepository) query(ctx context.Context, sql string, args ...interface{}) ([]*Secret, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	secrets := []*Secret{}
+	for rows.Next() {
+		secret, err := scanSecret(rows)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+func (r *PostgresSecretRepository) List(ctx context.Context, q Query) ([]*Secret, error) {
+	return r.query(ctx, `SELECT `+secretColumns+` FROM secrets
+		WHERE ($1::text[] IS NULL OR owner = ANY ($1)) AND ($2 = '' OR tenant_id = $2) ORDER BY id`,
+		q.Owners(), tenant.FromContext(ctx))
+}
+
+func (r *PostgresSecretRepository) Update(ctx context.Context, secret *Secret, q Query) (*Secret, error) {
+	if err := checkID(secret.ID); err != nil {
+		return nil, err
+	}
+	return scanSecret(conn(ctx, r.pool).QueryRow(ctx, `UPDATE secrets SET description = $2, value = $3, updated_at = $4
+		WHERE id = $1 AND ($5::text[] IS NULL OR owner = ANY ($5)) AND ($6 = '' OR tenant_id = $6) RETURNING `+secretColumns,
+		secret.ID, secret.Description, secret.Value, secret.UpdatedAt, q.Owners(), tenant.FromContext(ctx)))
+}
+
+func (r *PostgresSecretRepository) Delete(ctx context.Context, id string, q Query) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM secrets
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresSecretRepository) Named(ctx context.Context, owner string, names []string) ([]*Secret, error) {
+	return r.query(ctx, `SELECT `+secretColumns+` FROM secrets
+		WHERE owner = $1 AND name = ANY ($2) AND ($3 = '' OR tenant_id = $3) ORDER BY id`, owner, names, tenant.FromContext(ctx))
+}
+
+// PostgresChangeRepository stores the changes of jobs in the job_changes table
+type PostgresChangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresChangeRepository creates a repository for the changes in a database migrated with Migrate
+func NewPostgresChangeRepository(pool *pgxpool.Pool) *PostgresChangeRepository {
+	return &PostgresChangeRepository{pool: pool}
+}
+
+// changeColumns are the columns of job_changes in the order scanChange expects them
+const changeColumns = "id, job_id, owner, environment, request, status, requested_by, requested_at, job_updated_at, reviewed_by, reviewed_at, comment, tenant_id"
+
+// scanChange reads a change selected with changeColumns
+func scanChange(row pgx.Row) (*Change, error) {
+	change := &Change{}
+	err := row.Scan(&change.ID, &change.JobID, &change.Owner, &change.Environment, &change.Request, &change.Status,
+		&change.RequestedBy, &change.RequestedAt, &change.JobUpdatedAt, &change.ReviewedBy, &change.ReviewedAt, &change.Comment,
+		&change.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	change.RequestedAt = change.RequestedAt.UTC()
+	change.JobUpdatedAt = change.JobUpdatedAt.UTC()
+	change.ReviewedAt = utc(change.ReviewedAt)
+	return change, nil
+}
+
+func (r *PostgresChangeRepository) Create(ctx context.Context, change *Change) (*Change, error) {
+	return scanChange(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO job_changes (`+changeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, '', NULL, '', $10) RETURNING `+changeColumns,
+		newID(), change.JobID, change.Owner, change.Environment, change.Request, change.Status, change.RequestedBy,
+		change.RequestedAt, change.JobUpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresChangeRepository) Get(ctx context.Context, id string, q Query) (*Change, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanChange(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+changeColumns+` FROM job_changes
+		WHERE id = $1 AND ($2::text[] IS NULL OR owner = ANY ($2)) AND ($3 = '' OR tenant_id = $3)`,
+		id, q.Owners(), tenant.FromContext(ctx)))
+}
+
+func (r *PostgresChangeRepository) List(ctx context.Context, q Query, filter ChangeFilter) ([]*Change, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+changeColumns+` FROM job_changes
+		WHERE ($1::text[] IS NULL OR owner = ANY ($1)) AND ($2 = '' OR tenant_id = $2) AND ($3 = '' OR job_id = $3)
+			AND ($4 = '' OR status = $4)
+		ORDER BY id DESC`, q.Owners(), tenant.FromContext(ctx), filter.JobID, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := []*Change{}
+	for rows.Next() {
+		change, err := scanChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (r *PostgresChangeRepository) Review(ctx context.Context, id string, q Query, status, reviewer, comment string, reviewedAt time.Time) (*Change, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	change, err := scanChange(conn(ctx, r.pool).QueryRow(ctx, `UPDATE job_changes
+		SET status = $2, reviewed_by = $3, comment = $4, reviewed_at = $5
+		WHERE id = $1 AND status = $6 AND ($7::text[] IS NULL OR owner = ANY ($7)) AND ($8 = '' OR tenant_id = $8)
+		RETURNING `+changeColumns, id, status, reviewer, comment, reviewedAt, ChangePending, q.Owners(), tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		// Either there is no such change or it was reviewed already
+		if _, err := r.Get(ctx, id, q); err != nil {
+			return nil, err
+		}
+		return nil, ErrReviewed
+	}
+	return change, err
+}
+
+// PostgresRevisionRepository stores the revisions of jobs in the job_revisions table
+type PostgresRevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresRevisionRepository creates a repository for the revisions in a database migrated with Migrate
+func NewPostgresRevisionRepository(pool *pgxpool.Pool) *PostgresRevisionRepository {
+	return &PostgresRevisionRepository{pool: pool}
+}
+
+// revisionColumns are the columns of job_revisions in the order scanRevision expects them
+const revisionColumns = "id, job_id, revision, job, changes, created_by, created_at, tenant_id"
+
+// scanRevision reads a revision selected with revisionColumns
+func scanRevision(row pgx.Row) (*Revision, error) {
+	revision := &Revision{}
+	var changes string
+	err := row.Scan(&revision.ID, &revision.JobID, &revision.Revision, &revision.Job, &changes, &revision.CreatedBy,
+		&revision.CreatedAt, &revision.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, uniqueViolation(err)
+	}
+	revision.CreatedAt = revision.CreatedAt.UTC()
+	if err := json.Unmarshal([]byte(changes), &revision.Changes); err != nil {
+		return nil, fmt.Errorf("invalid changes of revision %s: %v", revision.ID, err)
+	}
+	return revision, nil
+}
+
+func (r *PostgresRevisionRepository) Create(ctx context.Context, revision *Revision) (*Revision, error) {
+	changes := revision.Changes
+	if changes == nil {
+		changes = []AuditChange{}
+	}
+	// A slice of strings always marshals
+	data, _ := json.Marshal(changes)
+	// Concurrent updates of the job get the same number, the unique index lets all but one of them fail
+	return scanRevision(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO job_revisions (`+revisionColumns+`)
+		SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, $4, $5, $6, $7 FROM job_revisions WHERE tenant_id = $7 AND job_id = $2
+		RETURNING `+revisionColumns,
+		newID(), revision.JobID, revision.Job, string(data), revision.CreatedBy, revision.CreatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresRevisionRepository) Get(ctx context.Context, jobID string, revision int64) (*Revision, error) {
+	return scanRevision(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+revisionColumns+` FROM job_revisions
+		WHERE job_id = $1 AND revision = $2 AND ($3 = '' OR tenant_id = $3)`, jobID, revision, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresRevisionRepository) List(ctx context.Context, jobID string) ([]*Revision, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+revisionColumns+` FROM job_revisions
+		WHERE job_id = $1 AND ($2 = '' OR tenant_id = $2) ORDER BY revision DESC`, jobID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	revisions := []*Revision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+// PostgresOutboxRepository stores the outbox in the outbox_events table
+type PostgresOutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresOutboxRepository creates a repository for the outbox in a database migrated with Migrate
+func NewPostgresOutboxRepository(pool *pgxpool.Pool) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{pool: pool}
+}
+
+// outboxColumns are the columns of outbox_events in the order scanOutboxEvent expects them
+const outboxColumns = "id, type, key, payload, created_at, tenant_id"
+
+// scanOutboxEvent reads an event selected with outboxColumns
+func scanOutboxEvent(row pgx.Row) (*OutboxEvent, error) {
+	event := &OutboxEvent{}
+	if err := row.Scan(&event.ID, &event.Type, &event.Key, &event.Payload, &event.CreatedAt, &event.Tenant); err != nil {
+		return nil, err
+	}
+	event.CreatedAt = event.CreatedAt.UTC()
+	return event, nil
+}
+
+func (r *PostgresOutboxRepository) Create(ctx context.Context, event *OutboxEvent) (*OutboxEvent, error) {
+	return scanOutboxEvent(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO outbox_events (`+outboxColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+outboxColumns,
+		newID(), event.Type, event.Key, event.Payload, event.CreatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresOutboxRepository) Pending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+outboxColumns+` FROM outbox_events ORDER BY id LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	events := []*OutboxEvent{}
+	for rows.Next() {
+		event, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *PostgresOutboxRepository) Delete(ctx context.Context, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM outbox_events WHERE id = $1 AND ($2 = '' OR tenant_id = $2)`, id, tenant.FromContext(ctx))
+	return err
+}
+
+// PostgresRunRetentionRepository stores run retention policies in the run_retention_policies table
+type PostgresRunRetentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresRunRetentionRepository creates a repository for the policies in a database migrated with Migrate
+func NewPostgresRunRetentionRepository(pool *pgxpool.Pool) *PostgresRunRetentionRepository {
+	return &PostgresRunRetentionRepository{pool: pool}
+}
+
+// retentionColumns are the columns of run_retention_policies in the order scanRetention expects them
+const retentionColumns = "job_id, keep_runs, max_age, updated_at, tenant_id"
+
+// scanRetention reads a policy selected with retentionColumns
+func scanRetention(row pgx.Row) (*RunRetentionPolicy, error) {
+	policy := &RunRetentionPolicy{}
+	var maxAge int64
+	err := row.Scan(&policy.JobID, &policy.KeepRuns, &maxAge, &policy.UpdatedAt, &policy.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	policy.MaxAge = time.Duration(maxAge)
+	policy.UpdatedAt = policy.UpdatedAt.UTC()
+	return policy, nil
+}
+
+func (r *PostgresRunRetentionRepository) Get(ctx context.Context, jobID string) (*RunRetentionPolicy, error) {
+	return scanRetention(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+retentionColumns+` FROM run_retention_policies
+		WHERE tenant_id = $1 AND job_id = $2`, tenant.FromContext(ctx), jobID))
+}
+
+func (r *PostgresRunRetentionRepository) List(ctx context.Context) ([]*RunRetentionPolicy, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+retentionColumns+` FROM run_retention_policies
+		WHERE ($1 = '' OR tenant_id = $1) ORDER BY tenant_id, job_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	policies := []*RunRetentionPolicy{}
+	for rows.Next() {
+		policy, err := scanRetention(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+func (r *PostgresRunRetentionRepository) Set(ctx context.Context, policy *RunRetentionPolicy) (*RunRetentionPolicy, error) {
+	return scanRetention(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO run_retention_policies (`+retentionColumns+`)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, job_id) DO UPDATE SET keep_runs = $2, max_age = $3, updated_at = $4
+		RETURNING `+retentionColumns,
+		policy.JobID, policy.KeepRuns, int64(policy.MaxAge), policy.UpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresRunRetentionRepository) Delete(ctx context.Context, jobID string) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM run_retention_policies WHERE tenant_id = $1 AND job_id = $2`,
+		tenant.FromContext(ctx), jobID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// PostgresSLARepository stores SLAs in the slas table and their breaches in sla_breaches
+type PostgresSLARepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresSLARepository creates a repository for the SLAs in a database migrated with Migrate
+func NewPostgresSLARepository(pool *pgxpool.Pool) *PostgresSLARepository {
+	return &PostgresSLARepository{pool: pool}
+}
+
+// slaColumns are the columns of slas in the order scanSLA expects them
+const slaColumns = "job_id, max_duration, deadline, timezone, updated_at, tenant_id"
+
+// scanSLA reads an SLA selected with slaColumns
+func scanSLA(row pgx.Row) (*SLA, error) {
+	sla := &SLA{}
+	var maxDuration int64
+	err := row.Scan(&sla.JobID, &maxDuration, &sla.Deadline, &sla.Timezone, &sla.UpdatedAt, &sla.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	sla.MaxDuration = time.Duration(maxDuration)
+	sla.UpdatedAt = sla.UpdatedAt.UTC()
+	return sla, nil
+}
+
+func (r *PostgresSLARepository) Get(ctx context.Context, jobID string) (*SLA, error) {
+	return scanSLA(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+slaColumns+` FROM slas WHERE tenant_id = $1 AND job_id = $2`,
+		tenant.FromContext(ctx), jobID))
+}
+
+func (r *PostgresSLARepository) List(ctx context.Context) ([]*SLA, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+slaColumns+` FROM slas
+		WHERE ($1 = '' OR tenant_id = $1) ORDER BY tenant_id, job_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	slas := []*SLA{}
+	for rows.Next() {
+		sla, err := scanSLA(rows)
+		if err != nil {
+			return nil, err
+		}
+		slas = append(slas, sla)
+	}
+	return slas, rows.Err()
+}
+
+func (r *PostgresSLARepository) Set(ctx context.Context, sla *SLA) (*SLA, error) {
+	return scanSLA(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO slas (`+slaColumns+`) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, job_id) DO UPDATE SET max_duration = $2, deadline = $3, timezone = $4, updated_at = $5
+		RETURNING `+slaColumns,
+		sla.JobID, int64(sla.MaxDuration), sla.Deadline, sla.Timezone, sla.UpdatedAt, tenant.FromContext(ctx)))
+}
+
+func (r *PostgresSLARepository) Delete(ctx context.Context, jobID string) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM slas WHERE tenant_id = $1 AND job_id = $2`, tenant.FromContext(ctx), jobID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// breachColumns are the columns of sla_breaches in the order scanBreach expects them
+const breachColumns = "id, job_id, kind, run_id, duration, deadline, detected_at, tenant_id"
+
+// scanBreach reads a breach selected with breachColumns
+func scanBreach(row pgx.Row) (*SLABreach, error) {
+	breach := &SLABreach{}
+	var duration int64
+	err := row.Scan(&breach.ID, &breach.JobID, &breach.Kind, &breach.RunID, &duration, &breach.Deadline, &breach.DetectedAt,
+		&breach.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	breach.Duration = time.Duration(duration)
+	breach.DetectedAt = breach.DetectedAt.UTC()
+	if breach.Deadline != nil {
+		deadline := breach.Deadline.UTC()
+		breach.Deadline = &deadline
+	}
+	return breach, nil
+}
+
+func (r *PostgresSLARepository) AddBreach(ctx context.Context, breach *SLABreach) (*SLABreach, error) {
+	added, err := scanBreach(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO sla_breaches (`+breachColumns+`, breach_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (tenant_id, job_id, breach_key) DO NOTHING RETURNING `+breachColumns,
+		newID(), breach.JobID, breach.Kind, breach.RunID, int64(breach.Duration), breach.Deadline, breach.DetectedAt,
+		tenant.FromContext(ctx), breach.key()))
+	if err == ErrNotFound {
+		// Nothing is returned when the insert was skipped
+		return nil, ErrExists
+	}
+	return added, err
+}
+
+func (r *PostgresSLARepository) ListBreaches(ctx context.Context, jobID, before string, limit int) ([]*SLABreach, error) {
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+breachColumns+` FROM sla_breaches
+		WHERE ($1 = '' OR job_id = $1) AND ($2 = '' OR id < $2) AND ($4 = '' OR tenant_id = $4)
+		ORDER BY id DESC LIMIT $3`, jobID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	breaches := []*SLABreach{}
+	for rows.Next() {
+		breach, err := scanBreach(rows)
+		if err != nil {
+			return nil, err
+		}
+		breaches = append(breaches, breach)
+	}
+	return breaches, rows.Err()
+}
+
+func (r *PostgresSLARepository) CountBreaches(ctx context.Context, jobID string, from, to time.Time) (int64, error) {
+	var n int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM sla_breaches
+		WHERE job_id = $1 AND detected_at >= $2 AND detected_at < $3 AND ($4 = '' OR tenant_id = $4)`,
+		jobID, from, to, tenant.FromContext(ctx)).Scan(&n)
+	return n, err
+}
+
+// PostgresAnomalyRepository stores the anomalies of runs in the run_anomalies table
+type PostgresAnomalyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAnomalyRepository creates a repository for the anomalies in a database migrated with Migrate
+func NewPostgresAnomalyRepository(pool *pgxpool.Pool) *PostgresAnomalyRepository {
+	return &PostgresAnomalyRepository{pool: pool}
+}
+
+// anomalyColumns are the columns of run_anomalies in the order scanAnomaly expects them
+const anomalyColumns = "id, job_id, run_id, duration, mean, stddev, samples, score, detected_at, tenant_id"
+
+// scanAnomaly reads an anomaly selected with anomalyColumns
+func scanAnomaly(row pgx.Row) (*Anomaly, error) {
+	anomaly := &Anomaly{}
+	var duration, mean, stddev int64
+	err := row.Scan(&anomaly.ID, &anomaly.JobID, &anomaly.RunID, &duration, &mean, &stddev, &anomaly.Samples, &anomaly.Score,
+		&anomaly.DetectedAt, &anomaly.Tenant)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	anomaly.Duration, anomaly.Mean, anomaly.Stddev = time.Duration(duration), time.Duration(mean), time.Duration(stddev)
+	anomaly.DetectedAt = anomaly.DetectedAt.UTC()
+	return anomaly, nil
+}
+
+func (r *PostgresAnomalyRepository) Add(ctx context.Context, anomaly *Anomaly) (*Anomaly, error) {
+	added, err := scanAnomaly(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO run_anomalies (`+anomalyColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (tenant_id, run_id) DO NOTHING RETURNING `+anomalyColumns,
+		newID(), anomaly.JobID, anomaly.RunID, int64(anomaly.Duration), int64(anomaly.Mean), int64(anomaly.Stddev), anomaly.Samples,
+		anomaly.Score, anomaly.DetectedAt, tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		// Nothing is returned when the insert was skipped
+		return nil, ErrExists
+	}
+	return added, err
+}
+
+func (r *PostgresAnomalyRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Anomaly, error) {
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+anomalyColumns+` FROM run_anomalies
+		WHERE ($1 = '' OR job_id = $1) AND ($2 = '' OR id < $2) AND ($4 = '' OR tenant_id = $4)
+		ORDER BY id DESC LIMIT $3`, jobID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	anomalies := []*Anomaly{}
+	for rows.Next() {
+		anomaly, err := scanAnomaly(rows)
+		if err != nil {
+			return nil, err
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+	return anomalies, rows.Err()
+}
+
+// PostgresMaintenanceRepository stores the maintenance mode in the single row of the maintenance table
+type PostgresMaintenanceRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMaintenanceRepository creates a repository for the maintenance mode in a database migrated with Migrate
+func NewPostgresMaintenanceRepository(pool *pgxpool.Pool) *PostgresMaintenanceRepository {
+	return &PostgresMaintenanceRepository{pool: pool}
+}
+
+// maintenanceColumns are the columns of maintenance in the order scanMaintenance expects them
+const maintenanceColumns = "enabled, reason, updated_by, updated_at"
+
+// scanMaintenance reads the maintenance mode selected with maintenanceColumns, disabled when there is no row
+func scanMaintenance(row pgx.Row) (*scheduler.Maintenance, error) {
+	m := &scheduler.Maintenance{}
+	err := row.Scan(&m.Enabled, &m.Reason, &m.UpdatedBy, &m.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return &scheduler.Maintenance{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	m.UpdatedAt = m.UpdatedAt.UTC()
+	return m, nil
+}
+
+func (r *PostgresMaintenanceRepository) Maintenance(ctx context.Context) (*scheduler.Maintenance, error) {
+	return scanMaintenance(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+maintenanceColumns+` FROM maintenance WHERE id = 1`))
+}
+
+func (r *PostgresMaintenanceRepository) SetMaintenance(ctx context.Context, m *scheduler.Maintenance) (*scheduler.Maintenance, error) {
+	return scanMaintenance(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO maintenance (id, `+maintenanceColumns+`)
+		VALUES (1, $1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET enabled = $1, reason = $2, updated_by = $3, updated_at = $4
+		RETURNING `+maintenanceColumns,
+		m.Enabled, m.Reason, m.UpdatedBy, m.UpdatedAt))
+}
+
+// PostgresEnvironmentRepository stores the environments of all tenants in the environments table
+type PostgresEnvironmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresEnvironmentRepository creates a repository for the environments in a database migrated with Migrate
+func NewPostgresEnvironmentRepository(pool *pgxpool.Pool) *PostgresEnvironmentRepository {
+	return &PostgresEnvironmentRepository{pool: pool}
+}
+
+// environmentColumns are the columns of environments in the order scanEnvironment expects them
+const environmentColumns = "tenant_id, name, paused, reason, updated_by, updated_at, protected, protected_by, protected_at"
+
+// scanEnvironment reads an environment selected with environmentColumns
+func scanEnvironment(row pgx.Row) (*scheduler.Environment, error) {
+	e := &scheduler.Environment{}
+	if err := row.Scan(&e.Tenant, &e.Name, &e.Paused, &e.Reason, &e.UpdatedBy, &e.UpdatedAt, &e.Protected, &e.ProtectedBy,
+		&e.ProtectedAt); err != nil {
+		return nil, err
+	}
+	e.UpdatedAt = e.UpdatedAt.UTC()
+	e.ProtectedAt = e.ProtectedAt.UTC()
+	return e, nil
+}
+
+func (r *PostgresEnvironmentRepository) Get(ctx context.Context, name string) (*scheduler.Environment, error) {
+	t := tenant.FromContext(ctx)
+	e, err := scanEnvironment(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+environmentColumns+` FROM environments
+		WHERE tenant_id = $1 AND name = $2`, t, name))
+	if err == pgx.ErrNoRows {
+		return &scheduler.Environment{Tenant: t, Name: name}, nil
+	}
+	return e, err
+}
+
+// list returns the environments selected by the conditions of where ordered by name and tenant
+func (r *PostgresEnvironmentRepository) list(ctx context.Context, where string, args ...interface{}) ([]*scheduler.Environment, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+environmentColumns+` FROM environments `+where+` ORDER BY name, tenant_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	environments := []*scheduler.Environment{}
+	for rows.Next() {
+		e, err := scanEnvironment(rows)
+		if err != nil {
+			return nil, err
+		}
+		environments = append(environments, e)
+	}
+	return environments, rows.Err()
+}
+
+func (r *PostgresEnvironmentRepository) List(ctx context.Context) ([]*scheduler.Environment, error) {
+	return r.list(ctx, `WHERE tenant_id = $1`, tenant.FromContext(ctx))
+}
+
+func (r *PostgresEnvironmentRepository) Set(ctx context.Context, env *scheduler.Environment) (*scheduler.Environment, error) {
+	return scanEnvironment(conn(ctx, r.pool).QueryRow(ctx, `INSERT INTO environments (`+environmentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (tenant_id, name) DO UPDATE SET paused = $3, reason = $4, updated_by = $5, updated_at = $6, protected = $7,
+			protected_by = $8, protected_at = $9
+		RETURNING `+environmentColumns,
+		tenant.FromContext(ctx), env.Name, env.Paused, env.Reason, env.UpdatedBy, env.UpdatedAt, env.Protected, env.ProtectedBy,
+		env.ProtectedAt))
+}
+
+func (r *PostgresEnvironmentRepository) Environments(ctx context.Context) ([]*scheduler.Environment, error) {
+	return r.list(ctx, ``)
+}
@@ -0,0 +1,1229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrNotFound is returned when no job or run matches the id and query
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidID is returned for ids the backend could never have generated
+	ErrInvalidID = errors.New("invalid id")
+	// ErrInvalidResumeToken is returned by Watch for tokens it didn't hand out
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	// ErrResumeTokenExpired is returned by Watch when the events after the token are no longer available
+	ErrResumeTokenExpired = errors.New("resume token expired")
+	// ErrResumeUnsupported is returned by Watch for backends that can't resume a watch
+	ErrResumeUnsupported = errors.New("resuming a watch is not supported by this storage backend")
+	// ErrStatusConflict is returned by SetStatus when the job doesn't have the expected status anymore
+	ErrStatusConflict = errors.New("job status changed")
+	// ErrOtherTenant is returned instead of ErrNotFound when the job or run exists but belongs to another tenant
+	ErrOtherTenant = errors.New("belongs to another tenant")
+	// ErrIdempotencyKeyUsed is returned by Create when another job of the owner was created with the same idempotency key
+	ErrIdempotencyKeyUsed = errors.New("idempotency key already used")
+	// ErrNameTaken is returned when a job would get the name of another job of its owner, deleted jobs keep their
+	// name until they are purged
+	ErrNameTaken = errors.New("name already taken")
+	// ErrRunExists is returned by Create for a first attempt of a job in a scheduling cycle that already has one
+	ErrRunExists = errors.New("job already ran in this cycle")
+	// ErrExists is returned by Put when a job or run with the same ID is stored already, and by Create of users
+	ErrExists = errors.New("already exists")
+	// ErrReviewed is returned by Review when the change was approved or rejected already
+	ErrReviewed = errors.New("change already reviewed")
+	// ErrRevisionTaken is returned by Create of revisions when a concurrent update of the job took the number
+	ErrRevisionTaken = errors.New("revision already taken")
+)
+
+// Job statuses, a job is pending until it ran for the first time and keeps the outcome of its latest run afterwards
+const (
+	JobPending   = "PENDING"
+	JobRunning   = "RUNNING"
+	JobSucceeded = "SUCCEEDED"
+	JobFailed    = "FAILED"
+	JobCancelled = "CANCELLED"
+	// JobPaused jobs aren't fired by the scheduler until they are resumed
+	JobPaused = "PAUSED"
+)
+
+// Job priorities, the executor gives runs of jobs with higher priorities a larger share of its workers
+const (
+	PriorityLow      = "LOW"
+	PriorityNormal   = "NORMAL"
+	PriorityHigh     = "HIGH"
+	PriorityCritical = "CRITICAL"
+)
+
+// Concurrency policies, they tell the executor what to do when a job is fired while one of its runs is still running
+const (
+	// ConcurrencyAllow lets the runs of a job overlap
+	ConcurrencyAllow = "ALLOW"
+	// ConcurrencyForbid skips firing a job while one of its runs is still queued or running
+	ConcurrencyForbid = "FORBID"
+	// ConcurrencyReplace cancels the running runs of a job when a new one starts
+	ConcurrencyReplace = "REPLACE"
+)
+
+// What started a run, retries keep the trigger of the run they retry
+const (
+	TriggerSchedule   = "SCHEDULE"
+	TriggerManual     = "MANUAL"
+	TriggerDependency = "DEPENDENCY"
+)
+
+// BatchError is returned by CreateMany when only some of the jobs could be stored
+type BatchError struct {
+	// Errors holds the reason every job that wasn't stored failed by its index
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d jobs of the batch could not be stored", len(e.Errors))
+}
+
+// newID generates the IDs of jobs and runs for backends that don't generate their own. They look like MongoDB Object IDs, so IDs and page tokens
+// don't depend on the backend and still sort by creation time.
+func newID() string {
+	return primitive.NewObjectID().Hex()
+}
+
+// checkID returns ErrInvalidID for ids newID could never have generated
+func checkID(id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// Job is the stored form of a job, independent of the storage backend
+type Job struct {
+	ID          string
+	Name        string
+	Owner       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Schedule    *scheduler.Spec
+	NextRunTime *time.Time
+	Handler     string
+	Command     string
+	Status      string
+	// DeletedAt is set for jobs that were deleted and can still be restored
+	DeletedAt *time.Time
+	// ArchivedAt is set for archived jobs, the scheduler doesn't fire them until they are unarchived
+	ArchivedAt *time.Time
+	// RetryPolicy tells how failed runs are retried, they aren't when it's nil
+	RetryPolicy *scheduler.RetryPolicy
+	// Timeout limits how long a run may take, zero doesn't limit it
+	Timeout time.Duration
+	// Tenant is taken from the context the job was created with, it is empty without multi-tenancy
+	Tenant string
+	// IdempotencyKey is the key of the CreateJob call that created the job, unique per owner until it expires
+	IdempotencyKey string
+	// Labels are the labels of the job, nil when it has none
+	Labels map[string]string
+	// DependsOn holds the IDs of the jobs this job runs after, nil when it doesn't depend on other jobs
+	DependsOn []string
+	// Priority is one of the Priority constants
+	Priority string
+	// ConcurrencyPolicy is one of the Concurrency constants
+	ConcurrencyPolicy string
+	// CatchUpPolicy is one of the scheduler.CatchUp constants
+	CatchUpPolicy string
+	// Notifications tells who is notified when runs of the job keep failing, nobody is when it's nil
+	Notifications *Notifications
+	// Template tells which template the job was created from, nil for jobs that weren't created from one
+	Template *TemplateLineage
+	// Blackout tells which calendars the scheduler doesn't fire the job in, nil for jobs without calendars
+	Blackout *Blackout
+	// Params are handed to the handler of the job, they hold JSON values and are nil when the job has none
+	Params map[string]interface{}
+	// Environment is the environment like dev or prod the job belongs to, empty when it belongs to none
+	Environment string
+	// Promotion tells which job the job was last promoted from, nil for jobs that were never promoted
+	Promotion *Promotion
+}
+
+// dueJob returns what the scheduler needs of a scheduled job to fire it
+func (j *Job) dueJob() *scheduler.DueJob {
+	due := &scheduler.DueJob{
+		ID:                j.ID,
+		Name:              j.Name,
+		Owner:             j.Owner,
+		Tenant:            j.Tenant,
+		Schedule:          j.Schedule,
+		NextRunTime:       *j.NextRunTime,
+		Priority:          j.Priority,
+		ConcurrencyPolicy: j.ConcurrencyPolicy,
+		CatchUpPolicy:     j.CatchUpPolicy,
+		Environment:       j.Environment,
+	}
+	if j.Blackout != nil {
+		due.Calendars = j.Blackout.CalendarIDs
+		due.DeferBlackouts = j.Blackout.Defer
+	}
+	return due
+}
+
+// Blackout attaches calendars to a job, the scheduler doesn't fire the job in their blackout windows and holidays
+type Blackout struct {
+	// CalendarIDs are the IDs of the calendars, calendars deleted since are ignored
+	CalendarIDs []string `bson:"calendar_ids" json:"calendar_ids"`
+	// Defer fires the occurrences in a blackout once it ended instead of skipping them
+	Defer bool `bson:"defer,omitempty" json:"defer,omitempty"`
+}
+
+// TemplateLineage is the template a job was created from with the parameters it was created with
+type TemplateLineage struct {
+	// TemplateID is the ID of the template, which may have been deleted since
+	TemplateID string `bson:"template_id" json:"template_id"`
+	// Version is the version the template had when the job was created
+	Version int64 `bson:"version" json:"version"`
+	// Parameters are the values of the parameters of the template, the defaults of the ones left out included
+	Parameters map[string]string `bson:"parameters,omitempty" json:"parameters,omitempty"`
+}
+
+// Promotion is the job a job was promoted from into its environment, with the approval of the promotion
+type Promotion struct {
+	// SourceJobID is the ID of the promoted job, which may have been deleted since
+	SourceJobID string `bson:"source_job_id" json:"source_job_id"`
+	// SourceEnvironment is the environment the job was promoted from
+	SourceEnvironment string `bson:"source_environment,omitempty" json:"source_environment,omitempty"`
+	Approval          string `bson:"approval" json:"approval"`
+	// PromotedBy is the subject of the caller who promoted the job, empty without authentication
+	PromotedBy string    `bson:"promoted_by,omitempty" json:"promoted_by,omitempty"`
+	PromotedAt time.Time `bson:"promoted_at" json:"promoted_at"`
+}
+
+// Notifications are the channels notified once runs of a job failed FailureThreshold times in a row
+type Notifications struct {
+	// FailureThreshold is the number of consecutive failed runs that sends a notification, at least 1
+	FailureThreshold int `bson:"failure_threshold" json:"failure_threshold"`
+	// SlackWebhookURL is the incoming webhook of the Slack channel messages are posted to
+	SlackWebhookURL string `bson:"slack_webhook_url,omitempty" json:"slack_webhook_url,omitempty"`
+	// EmailRecipients are the addresses emails are sent to
+	EmailRecipients []string `bson:"email_recipients,omitempty" json:"email_recipients,omitempty"`
+	// Anomalies also notifies the channels of runs whose duration is an anomaly
+	Anomalies bool `bson:"anomalies,omitempty" json:"anomalies,omitempty"`
+}
+
+// Query restricts the jobs an operation applies to, the zero value matches every job that isn't deleted
+type Query struct {
+	// Owner only matches jobs of this owner when set
+	Owner string
+	// Teams are the IDs of the teams of Owner, their jobs are matched as well. They are ignored without an owner.
+	Teams []string
+	// IncludeDeleted also matches deleted jobs that weren't purged yet
+	IncludeDeleted bool
+	// Labels only matches jobs whose labels it selects, it is only applied by List and Count
+	Labels labels.Selector
+	// Archived tells whether archived jobs are matched, it is only applied by List and Count
+	Archived ArchiveFilter
+	// Environment only matches jobs in this environment when set, it is only applied by List and Count
+	Environment string
+	// Fields only reads the given fields of the jobs, named like the fields of the Job message, when it's set. The ID
+	// is always read. Only MongoDB applies it, by Get, GetMany and List, the other backends return whole jobs.
+	Fields []string
+}
+
+// ArchiveFilter selects jobs by whether they are archived
+type ArchiveFilter int
+
+const (
+	// WithArchived matches archived jobs and the others
+	WithArchived ArchiveFilter = iota
+	// WithoutArchived only matches jobs that aren't archived
+	WithoutArchived
+	// OnlyArchived only matches archived jobs
+	OnlyArchived
+)
+
+// Matches tells whether f matches a job archived at archivedAt, nil for jobs that aren't archived
+func (f ArchiveFilter) Matches(archivedAt *time.Time) bool {
+	switch f {
+	case WithoutArchived:
+		return archivedAt == nil
+	case OnlyArchived:
+		return archivedAt != nil
+	}
+	return true
+}
+
+// TeamOwnerPrefix starts the owner of jobs owned by a team, followed by the ID of the team
+const TeamOwnerPrefix = "team:"
+
+// TeamOwner returns the owner of the jobs of the team with id
+func TeamOwner(id string) string {
+	return TeamOwnerPrefix + id
+}
+
+// Owners returns the owners of the jobs q matches, Owner followed by its teams. Nil when q matches every owner.
+func (q Query) Owners() []string {
+	if q.Owner == "" {
+		return nil
+	}
+	owners := []string{q.Owner}
+	for _, id := range q.Teams {
+		owners = append(owners, TeamOwner(id))
+	}
+	return owners
+}
+
+// OwnedBy tells whether q matches the jobs of owner
+func (q Query) OwnedBy(owner string) bool {
+	if q.Owner == "" {
+		return true
+	}
+	for _, o := range q.Owners() {
+		if o == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchResult is a job found by Search, jobs with higher scores are more relevant. Scores depend on the backend.
+type SearchResult struct {
+	Job   *Job
+	Score float64
+}
+
+// JobUpdate describes the changes of an update, nil fields are left alone
+type JobUpdate struct {
+	Name        *string
+	Description *string
+	Owner       *string
+	Handler     *string
+	Command     *string
+	Timeout     *time.Duration
+	Priority    *string
+	// ConcurrencyPolicy is one of the Concurrency constants
+	ConcurrencyPolicy *string
+	// CatchUpPolicy is one of the scheduler.CatchUp constants
+	CatchUpPolicy *string
+	Environment   *string
+	// SetSchedule replaces the schedule and next run time with Schedule and NextRunTime, nil values unschedule the job
+	SetSchedule bool
+	Schedule    *scheduler.Spec
+	NextRunTime *time.Time
+	// SetRetryPolicy replaces the retry policy with RetryPolicy, nil stops retrying failed runs
+	SetRetryPolicy bool
+	RetryPolicy    *scheduler.RetryPolicy
+	// SetNotifications replaces the notification settings with Notifications, nil stops notifying anybody
+	SetNotifications bool
+	Notifications    *Notifications
+	// SetLabels replaces all labels with Labels
+	SetLabels bool
+	Labels    map[string]string
+	// SetDependsOn replaces the jobs the job depends on with DependsOn
+	SetDependsOn bool
+	DependsOn    []string
+	// SetArchivedAt archives the job at ArchivedAt, or unarchives it when ArchivedAt is nil
+	SetArchivedAt bool
+	ArchivedAt    *time.Time
+	// SetBlackout replaces the calendars of the job with Blackout, nil detaches all of them
+	SetBlackout bool
+	Blackout    *Blackout
+	// SetParams replaces the params of the job with Params
+	SetParams bool
+	Params    map[string]interface{}
+	// SetPromotion replaces what the job was promoted from with Promotion
+	SetPromotion bool
+	Promotion    *Promotion
+	UpdatedAt    time.Time
+}
+
+// Apply changes job like the update changes the stored job, the schedule, retry policy, notifications, labels,
+// blackout, params and promotion of job point into the update afterwards
+func (u *JobUpdate) Apply(job *Job) {
+	for field, value := range map[*string]*string{
+		&job.Name:              u.Name,
+		&job.Description:       u.Description,
+		&job.Owner:             u.Owner,
+		&job.Handler:           u.Handler,
+		&job.Command:           u.Command,
+		&job.Priority:          u.Priority,
+		&job.ConcurrencyPolicy: u.ConcurrencyPolicy,
+		&job.CatchUpPolicy:     u.CatchUpPolicy,
+		&job.Environment:       u.Environment,
+	} {
+		if value != nil {
+			*field = *value
+		}
+	}
+	if u.Timeout != nil {
+		job.Timeout = *u.Timeout
+	}
+	if u.SetSchedule {
+		job.Schedule = u.Schedule
+		job.NextRunTime = u.NextRunTime
+	}
+	if u.SetRetryPolicy {
+		job.RetryPolicy = u.RetryPolicy
+	}
+	if u.SetLabels {
+		job.Labels = u.Labels
+	}
+	if u.SetDependsOn {
+		job.DependsOn = u.DependsOn
+	}
+	if u.SetNotifications {
+		job.Notifications = u.Notifications
+	}
+	if u.SetArchivedAt {
+		job.ArchivedAt = u.ArchivedAt
+	}
+	if u.SetBlackout {
+		job.Blackout = u.Blackout
+	}
+	if u.SetParams {
+		job.Params = u.Params
+	}
+	if u.SetPromotion {
+		job.Promotion = u.Promotion
+	}
+	job.UpdatedAt = u.UpdatedAt
+}
+
+// EventType tells what happened to a job
+type EventType int
+
+const (
+	EventCreated EventType = iota + 1
+	EventUpdated
+	EventDeleted
+)
+
+// JobEvent is a single change of a job. Job only has its ID set for deletions, restored jobs are reported as updated.
+type JobEvent struct {
+	Type EventType
+	Job  *Job
+	// ResumeToken can be passed to Watch to continue right after this event
+	ResumeToken string
+}
+
+// JobEvents is a stream of job changes
+type JobEvents interface {
+	// Next blocks until the next change happened or ctx is done
+	Next(ctx context.Context) (*JobEvent, error)
+	// Close stops the stream
+	Close(ctx context.Context) error
+}
+
+// JobRepository stores jobs. When the context of an operation carries a tenant, it only applies to the jobs of that
+// tenant and new jobs are created for it.
+type JobRepository interface {
+	// Create stores a new job and returns it with its generated ID
+	Create(ctx context.Context, job *Job) (*Job, error)
+	// CreateMany stores several new jobs at once and returns them with their generated IDs in the same order.
+	// When only some of them were stored the error is a *BatchError and the jobs that weren't stored are nil.
+	CreateMany(ctx context.Context, jobs []*Job) ([]*Job, error)
+	// Get returns the job with the given id
+	Get(ctx context.Context, id string, q Query) (*Job, error)
+	// GetMany returns the jobs with the given ids in no particular order. Invalid ids and the ones of jobs that don't
+	// match q or belong to another tenant are left out.
+	GetMany(ctx context.Context, ids []string, q Query) ([]*Job, error)
+	// Update applies update to the job with the given id and returns the updated job
+	Update(ctx context.Context, id string, q Query, update *JobUpdate) (*Job, error)
+	// Delete marks the job with the given id as deleted at deletedAt and reports whether there was one
+	Delete(ctx context.Context, id string, q Query, deletedAt time.Time) (bool, error)
+	// SetStatus moves the job with the given id from status from to status to and returns the updated job.
+	// It returns ErrStatusConflict if the job exists but doesn't have status from.
+	SetStatus(ctx context.Context, id string, q Query, from, to string, updatedAt time.Time) (*Job, error)
+	// Restore undeletes the deleted job with the given id, sets its UpdatedAt to updatedAt and returns it, ErrNotFound
+	// if there is no such deleted job
+	Restore(ctx context.Context, id string, q Query, updatedAt time.Time) (*Job, error)
+	// Purge permanently removes the jobs deleted before deletedBefore and returns how many there were
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// GetByIdempotencyKey returns the job of owner created with key, deleted or not
+	GetByIdempotencyKey(ctx context.Context, owner, key string) (*Job, error)
+	// GetByName returns the job of owner named name in environment, deleted or not
+	GetByName(ctx context.Context, owner, environment, name string) (*Job, error)
+	// ExpireIdempotencyKeys removes the keys of the jobs created before createdBefore, so they can be used again,
+	// and returns how many there were
+	ExpireIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error)
+	// Count returns the number of jobs matching q
+	Count(ctx context.Context, q Query) (int64, error)
+	// Exists reports whether there is a job with the given id matching q, jobs of other tenants don't exist
+	Exists(ctx context.Context, id string, q Query) (bool, error)
+	// List returns up to limit jobs ordered by ID, starting after the job with ID after when it's set
+	List(ctx context.Context, q Query, after string, limit int) ([]*Job, error)
+	// Search returns up to limit jobs matching q whose name or description contains the words of text, the most
+	// relevant first and skipping the first offset ones. q.Labels doesn't apply.
+	Search(ctx context.Context, q Query, text string, offset, limit int) ([]*SearchResult, error)
+	// Watch streams the changes made to jobs of the owner of q, q.IncludeDeleted is ignored. Purged jobs are not reported.
+	// The stream starts now or, when resumeToken is set, right after the event the token belongs to.
+	Watch(ctx context.Context, q Query, resumeToken string) (JobEvents, error)
+	// DueJobs returns the scheduled jobs that aren't deleted, archived or paused and whose next run time is not after now
+	DueJobs(ctx context.Context, now time.Time) ([]*scheduler.DueJob, error)
+	// ClaimNextRun moves the next run time of a job from prev to next and reports false if it wasn't prev anymore
+	ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error)
+	// Dependents returns the jobs that aren't deleted and depend on the job with the given id, of any owner
+	Dependents(ctx context.Context, id string) ([]*Job, error)
+	// Put stores job as it is, keeping its ID, for the tenant of ctx, like when it's restored from a backup. A stored
+	// job with the same ID is replaced when replace is set, Put returns ErrExists otherwise and when it belongs to
+	// another tenant.
+	Put(ctx context.Context, job *Job, replace bool) error
+}
+
+// Statuses of runs the repositories need to know, the executor defines the others
+const (
+	// RunWaiting runs are attempts of a failed run waiting for their RetryAt time
+	RunWaiting = "WAITING"
+	RunQueued  = "QUEUED"
+	RunRunning = "RUNNING"
+)
+
+// Run is the stored record of a single job execution
+type Run struct {
+	ID        string
+	JobID     string
+	Status    string
+	QueuedAt  time.Time
+	StartTime *time.Time
+	EndTime   *time.Time
+	Output    string
+	Error     string
+	// Attempt counts the attempts of a run from 1, retries of a failed run are recorded as new runs
+	Attempt int
+	// RetryAt is the earliest time a retry is executed, it is only set for retries
+	RetryAt *time.Time
+	// Timeout is the timeout of the job when the run started, Duration how long the run actually took
+	Timeout  time.Duration
+	Duration time.Duration
+	// Tenant is taken from the context the run was created with, like the tenant of jobs
+	Tenant string
+	// CycleID is the ID of the first run of the scheduling cycle the run belongs to. Create starts a new cycle with
+	// the ID of the run when it's empty, retries and the runs of dependent jobs keep the cycle of the run they follow.
+	CycleID string
+	// Priority is the priority of the job when the run was queued
+	Priority string
+	// Trigger is one of the Trigger constants, TriggeredBy the subject of the caller that triggered a manual run
+	Trigger     string
+	TriggeredBy string
+	// Worker is the executor worker that ran the run, written as <host>/<index>. Runs that never started have none.
+	Worker string
+	// CostPerSecond is the cost of a second of compute time when the run started, Cost what the run cost in total.
+	// Changing the rate of the executor doesn't change the cost of runs that finished before.
+	CostPerSecond float64
+	Cost          float64
+}
+
+// RunStats summarizes the runs of a job within a time range
+type RunStats struct {
+	// Counts holds the number of runs by status
+	Counts map[string]int64
+	// Finished is the number of runs that started and ended, the durations are computed over these
+	Finished int64
+	Mean     time.Duration
+	// P50, P95 and P99 are nearest rank percentiles
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+}
+
+// RunUsage sums up the runs of several jobs within a time range
+type RunUsage struct {
+	// Counts holds the number of runs by status
+	Counts map[string]int64
+	// ComputeTime is the sum of the durations of the runs
+	ComputeTime time.Duration
+}
+
+// RunCost sums up the runs of a job queued within a calendar month in UTC
+type RunCost struct {
+	JobID string
+	// Month is the first day of the month at midnight UTC
+	Month       time.Time
+	Runs        int64
+	ComputeTime time.Duration
+	Cost        float64
+}
+
+// TruncateMonth returns the start of the calendar month in UTC t falls into
+func TruncateMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// percentileRank returns the 0-based index of the nearest rank percentile p of n sorted values, n must be positive
+func percentileRank(p, n int64) int64 {
+	rank := (p*n + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return rank - 1
+}
+
+// Bucket sizes of TimeSeries
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// RunBucket summarizes the runs of a job queued within one bucket of a time series
+type RunBucket struct {
+	// Start is the beginning of the bucket in the time zone of the series
+	Start time.Time
+	// Counts holds the number of runs by status
+	Counts map[string]int64
+	// Finished is the number of runs that started and ended, Mean is their average duration
+	Finished int64
+	Mean     time.Duration
+}
+
+// TruncateBucket returns the start of the bucket t falls into, buckets follow the wall clock of loc and weeks
+// start on Monday
+func TruncateBucket(t time.Time, bucket string, loc *time.Location) time.Time {
+	local := t.In(loc)
+	switch bucket {
+	case BucketHour:
+		// Subtract instead of rebuilding the time, so the hour that is repeated when the clocks go back stays apart
+		return local.Add(-time.Duration(local.Minute())*time.Minute - time.Duration(local.Second())*time.Second -
+			time.Duration(local.Nanosecond()))
+	case BucketWeek:
+		days := (int(local.Weekday()) + 6) % 7
+		return time.Date(local.Year(), local.Month(), local.Day()-days, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// NextBucket returns the start of the bucket following the one starting at start
+func NextBucket(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case BucketHour:
+		return start.Add(time.Hour)
+	case BucketWeek:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// setMean sets the mean duration of a bucket from the total duration of its finished runs
+func setMean(b *RunBucket, total int64) {
+	if b.Finished > 0 {
+		b.Mean = time.Duration(total / b.Finished)
+	}
+}
+
+// RunRepository stores job runs, scoped to the tenant of the context like jobs
+type RunRepository interface {
+	// Create stores a new run and returns it with its generated ID. It returns ErrRunExists when the run is the
+	// first attempt and the job already has one in the cycle of the run.
+	Create(ctx context.Context, run *Run) (*Run, error)
+	// Update stores the status, start and end time, output, error, timeout, duration, worker and cost of a run
+	Update(ctx context.Context, run *Run) error
+	// Get returns the run with the given id
+	Get(ctx context.Context, id string) (*Run, error)
+	// List returns up to limit runs newest first, only of the given job when jobID is set and starting before the run with ID before when it's set
+	List(ctx context.Context, jobID, before string, limit int) ([]*Run, error)
+	// DueRetries returns the waiting runs whose retry time is not after now
+	DueRetries(ctx context.Context, now time.Time) ([]*scheduler.DueRetry, error)
+	// ClaimRetry moves the run with the given id from waiting to queued and reports false if it wasn't waiting anymore
+	ClaimRetry(ctx context.Context, id string) (bool, error)
+	// Stats summarizes the runs of the job queued at or after from and before to
+	Stats(ctx context.Context, jobID string, from, to time.Time) (*RunStats, error)
+	// Usage sums up the runs of the jobs with the given IDs queued at or after from and before to
+	Usage(ctx context.Context, jobIDs []string, from, to time.Time) (*RunUsage, error)
+	// Costs sums up the runs of the jobs with the given IDs queued at or after from and before to by job and month,
+	// months without runs are left out. They are ordered by job and month.
+	Costs(ctx context.Context, jobIDs []string, from, to time.Time) ([]*RunCost, error)
+	// TimeSeries summarizes the runs of the job queued at or after from and before to by bucket, buckets without runs
+	// are left out. Buckets follow the wall clock of loc and are ordered by their start.
+	TimeSeries(ctx context.Context, jobID string, from, to time.Time, bucket string, loc *time.Location) ([]*RunBucket, error)
+	// CycleRuns returns the runs of the scheduling cycle with the given id, of all jobs and attempts
+	CycleRuns(ctx context.Context, cycleID string) ([]*Run, error)
+	// Put stores run as it is, keeping its ID, for the tenant of ctx. It replaces a stored run like JobRepository.Put
+	// replaces jobs and returns ErrRunExists when another run of the job has its attempt in its cycle.
+	Put(ctx context.Context, run *Run, replace bool) error
+	// Prune removes the finished runs of the tenant of ctx, only the runs without a tenant when ctx has none, that
+	// were queued longer than maxAge ago when it's set and the ones beyond the keep newest finished runs of their job
+	// when keep is set. It prunes the runs of the job with jobID, of every job but the ones in except when jobID is
+	// empty, and returns how many runs it removed. MongoDB only marks the runs past maxAge to expire, its TTL index
+	// removes them, and stops expiring runs that were marked with another maxAge before.
+	Prune(ctx context.Context, jobID string, except []string, keep int, maxAge time.Duration) (int64, error)
+}
+
+// runFinished tells whether a run with status is over, only finished runs are pruned
+func runFinished(status string) bool {
+	return status != RunQueued && status != RunWaiting && status != RunRunning
+}
+
+// RunRetentionPolicy limits how long the finished runs of jobs are kept. The policy of a job applies instead of the
+// default of its tenant, which is the policy without a job.
+type RunRetentionPolicy struct {
+	// JobID is empty for the default of the tenant
+	JobID string
+	// KeepRuns is the number of finished runs kept of every job, any number when it's 0
+	KeepRuns int
+	// MaxAge is how long finished runs are kept after they were queued, forever when it's 0
+	MaxAge    time.Duration
+	UpdatedAt time.Time
+	// Tenant is taken from the context the policy was set with
+	Tenant string
+}
+
+// RunRetentionRepository stores the retention policies of runs, scoped to the tenant of the context. MongoDB keeps
+// the policies of all tenants in the same collection.
+type RunRetentionRepository interface {
+	// Get returns the policy of the job with jobID, the default of the tenant when jobID is empty. ErrNotFound when
+	// there is none.
+	Get(ctx context.Context, jobID string) (*RunRetentionPolicy, error)
+	// List returns the policies ordered by tenant and job, the default of a tenant first. Without a tenant in ctx it
+	// returns the policies of all tenants.
+	List(ctx context.Context) ([]*RunRetentionPolicy, error)
+	// Set stores policy, replacing the one of its job, and returns it
+	Set(ctx context.Context, policy *RunRetentionPolicy) (*RunRetentionPolicy, error)
+	// Delete removes the policy of the job with jobID, ErrNotFound when there is none
+	Delete(ctx context.Context, jobID string) error
+}
+
+// SLA is what the runs of a job are expected to meet, every limit that is set has to be met
+type SLA struct {
+	JobID string
+	// MaxDuration is how long a run may take at most, any time when it's 0
+	MaxDuration time.Duration
+	// Deadline is the time of day as HH:MM in Timezone a run queued since the previous deadline must have succeeded
+	// by, every day. Empty when the job has no daily deadline.
+	Deadline string
+	// Timezone is the IANA time zone of the deadline, UTC when it's empty
+	Timezone  string
+	UpdatedAt time.Time
+	// Tenant is taken from the context the SLA was set with
+	Tenant string
+}
+
+// Kinds of SLA breaches
+const (
+	// SLABreachDuration is a run that took longer than the max duration
+	SLABreachDuration = "DURATION"
+	// SLABreachDeadline is a daily deadline no run succeeded by
+	SLABreachDeadline = "DEADLINE"
+)
+
+// SLABreach records a job missing its SLA
+type SLABreach struct {
+	ID    string
+	JobID string
+	// Kind is one of the SLABreach constants
+	Kind string
+	// RunID and Duration are the run that took too long and how long it took, only for duration breaches
+	RunID    string
+	Duration time.Duration
+	// Deadline is the deadline that was missed, only for deadline breaches
+	Deadline   *time.Time
+	DetectedAt time.Time
+	// Tenant is taken from the context the breach was recorded with
+	Tenant string
+}
+
+// key identifies what was breached, a job breaches the SLA once per run and per deadline
+func (b *SLABreach) key() string {
+	if b.Kind == SLABreachDeadline && b.Deadline != nil {
+		return b.Kind + ":" + b.Deadline.UTC().Format(time.RFC3339)
+	}
+	return b.Kind + ":" + b.RunID
+}
+
+// SLARepository stores the SLAs of jobs and the breaches detected, scoped to the tenant of the context. MongoDB
+// keeps the ones of all tenants in the same collections.
+type SLARepository interface {
+	// Get returns the SLA of the job with jobID, ErrNotFound when it has none
+	Get(ctx context.Context, jobID string) (*SLA, error)
+	// List returns the SLAs ordered by tenant and job. Without a tenant in ctx it returns the SLAs of all tenants.
+	List(ctx context.Context) ([]*SLA, error)
+	// Set stores sla, replacing the one of its job, and returns it
+	Set(ctx context.Context, sla *SLA) (*SLA, error)
+	// Delete removes the SLA of the job with jobID, ErrNotFound when it has none. Its breaches are kept.
+	Delete(ctx context.Context, jobID string) error
+	// AddBreach stores a breach and returns it with its generated ID, ErrExists when the same run or deadline of the
+	// job was already recorded
+	AddBreach(ctx context.Context, breach *SLABreach) (*SLABreach, error)
+	// ListBreaches returns up to limit breaches of the job with jobID newest first, starting before the breach with
+	// ID before when it's set. An empty jobID lists the breaches of every job.
+	ListBreaches(ctx context.Context, jobID, before string, limit int) ([]*SLABreach, error)
+	// CountBreaches returns the number of breaches of the job with jobID detected at or after from and before to
+	CountBreaches(ctx context.Context, jobID string, from, to time.Time) (int64, error)
+}
+
+// Anomaly records a run whose duration deviated from the baseline of the runs of its job that succeeded before it
+type Anomaly struct {
+	ID       string
+	JobID    string
+	RunID    string
+	Duration time.Duration
+	// Mean, Stddev and Samples are the baseline: the mean and standard deviation of the durations of the last Samples
+	// succeeded runs before the run
+	Mean    time.Duration
+	Stddev  time.Duration
+	Samples int
+	// Score is how many standard deviations the run took longer than the mean, negative when it was faster. The
+	// standard deviation counts as at least a tenth of the mean.
+	Score      float64
+	DetectedAt time.Time
+	// Tenant is taken from the context the anomaly was recorded with
+	Tenant string
+}
+
+// AnomalyRepository stores the anomalies detected in the durations of runs, scoped to the tenant of the context
+type AnomalyRepository interface {
+	// Add stores an anomaly and returns it with its generated ID, ErrExists when one was already recorded for the run
+	Add(ctx context.Context, anomaly *Anomaly) (*Anomaly, error)
+	// List returns up to limit anomalies of the job with jobID newest first, starting before the anomaly with ID before
+	// when it's set. An empty jobID lists the anomalies of every job.
+	List(ctx context.Context, jobID, before string, limit int) ([]*Anomaly, error)
+}
+
+// AuditEntry records a change a caller made to jobs
+type AuditEntry struct {
+	ID   string
+	Time time.Time
+	// Actor is the subject of the caller's token, empty when authentication is disabled
+	Actor string
+	// Method is the full gRPC method that made the change, like /model.JobService/UpdateJob
+	Method string
+	// JobID and Owner are empty for changes not tied to a single job, like imports
+	JobID string
+	Owner string
+	// Changes holds the fields that changed ordered by field name
+	Changes []AuditChange
+	// Tenant is taken from the context the entry was created with, like the tenant of jobs
+	Tenant string
+}
+
+// AuditChange is the value of a single field before and after a change, a value is empty when the field wasn't set
+type AuditChange struct {
+	Field  string `bson:"field" json:"field"`
+	Before string `bson:"before,omitempty" json:"before,omitempty"`
+	After  string `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+// AuditFilter restricts the audit entries List returns, empty fields match every entry
+type AuditFilter struct {
+	Actor string
+	JobID string
+	// Owner only matches entries of jobs of this owner when set
+	Owner string
+	// Teams are the IDs of the teams of Owner, the entries of their jobs are matched as well like by a Query
+	Teams []string
+}
+
+// ownerQuery returns the query matching the jobs of the owner and teams of f
+func (f AuditFilter) ownerQuery() Query {
+	return Query{Owner: f.Owner, Teams: f.Teams}
+}
+
+// AuditRepository stores the audit log, entries are never changed once they were created. They are scoped to the
+// tenant of the context like jobs.
+type AuditRepository interface {
+	// Create stores a new entry and returns it with its generated ID
+	Create(ctx context.Context, entry *AuditEntry) (*AuditEntry, error)
+	// List returns up to limit entries matching filter newest first, starting before the entry with ID before when it's set
+	List(ctx context.Context, filter AuditFilter, before string, limit int) ([]*AuditEntry, error)
+}
+
+// Webhook is an endpoint the server posts the events of the jobs of its owner to
+type Webhook struct {
+	ID    string
+	Owner string
+	URL   string
+	// Secret is the key the payloads are signed with
+	Secret string
+	// Events holds the event types the webhook receives, all of them when it's empty
+	Events    []string
+	CreatedAt time.Time
+	// Tenant is taken from the context the webhook was created with, like the tenant of jobs
+	Tenant string
+}
+
+// DeadLetter is a delivery to a webhook that failed for good, it keeps the payload so it can be inspected and replayed
+type DeadLetter struct {
+	ID        string
+	WebhookID string
+	Event     string
+	Payload   string
+	Attempts  int
+	// Error tells why the last attempt failed
+	Error     string
+	CreatedAt time.Time
+	Tenant    string
+}
+
+// WebhookRepository stores webhooks and the deliveries to them that failed for good. Both are scoped to the tenant of
+// the context like jobs, an empty owner matches the webhooks of every owner.
+type WebhookRepository interface {
+	// Create stores a new webhook and returns it with its generated ID
+	Create(ctx context.Context, hook *Webhook) (*Webhook, error)
+	// Get returns the webhook with the given id, ErrNotFound when it doesn't exist or belongs to another owner
+	Get(ctx context.Context, id, owner string) (*Webhook, error)
+	// List returns the webhooks of owner oldest first
+	List(ctx context.Context, owner string) ([]*Webhook, error)
+	// Delete removes the webhook together with its dead letters, ErrNotFound when owner has no webhook with the id
+	Delete(ctx context.Context, id, owner string) error
+	// Subscribed returns the webhooks of owner that receive events of the given type
+	Subscribed(ctx context.Context, owner, event string) ([]*Webhook, error)
+	// CreateDeadLetter stores a failed delivery and returns it with its generated ID
+	CreateDeadLetter(ctx context.Context, letter *DeadLetter) (*DeadLetter, error)
+	// ListDeadLetters returns up to limit dead letters of the webhook newest first, starting before the dead letter
+	// with ID before when it's set
+	ListDeadLetters(ctx context.Context, webhookID, before string, limit int) ([]*DeadLetter, error)
+}
+
+// User is a caller of the API. Users with a token have the subject of their tokens as ID, which is also the owner of
+// their jobs. Service users only authenticate with API keys and get a generated ID.
+type User struct {
+	ID          string
+	Email       string
+	DisplayName string
+	// Roles are granted to callers authenticating with the API keys of the user
+	Roles     []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Tenant is taken from the context the user was created with, callers with its API keys belong to it
+	Tenant string
+}
+
+// APIKey lets a machine client authenticate as a user
+type APIKey struct {
+	ID     string
+	UserID string
+	Name   string
+	// Hash is the SHA-256 hash of the secret of the key, the secret itself is never stored
+	Hash      string
+	CreatedAt time.Time
+	// RotatedAt is when the secret was last replaced, nil while the key has its first secret
+	RotatedAt *time.Time
+	Tenant    string
+}
+
+// UserRepository stores users and their API keys. Both are scoped to the tenant of the context like jobs, but are
+// kept in one place for all tenants so APIKey can authenticate calls before their tenant is known.
+type UserRepository interface {
+	// Create stores a new user, generating its ID when it's empty. ErrExists when a user with the ID exists.
+	Create(ctx context.Context, user *User) (*User, error)
+	// Get returns the user with the given id, ErrNotFound when it doesn't exist
+	Get(ctx context.Context, id string) (*User, error)
+	// List returns all users oldest first
+	List(ctx context.Context) ([]*User, error)
+	// Update replaces the email, display name, roles and update time of the user with the ID of user and returns the
+	// stored user, ErrNotFound when it doesn't exist
+	Update(ctx context.Context, user *User) (*User, error)
+	// Delete removes the user together with its API keys, ErrNotFound when it doesn't exist
+	Delete(ctx context.Context, id string) error
+	// CreateAPIKey stores a new key and returns it with its generated ID
+	CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error)
+	// ListAPIKeys returns the keys of the user oldest first
+	ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error)
+	// RotateAPIKey replaces the hash of the key of the user with id, ErrNotFound when the user has no such key
+	RotateAPIKey(ctx context.Context, userID, id, hash string, rotatedAt time.Time) (*APIKey, error)
+	// DeleteAPIKey removes the key of the user with id, ErrNotFound when the user has no such key
+	DeleteAPIKey(ctx context.Context, userID, id string) error
+	// APIKey returns the key with id, ErrNotFound when it doesn't exist. Calls are authenticated with an unscoped
+	// context, which finds the keys of every tenant.
+	APIKey(ctx context.Context, id string) (*APIKey, error)
+}
+
+// Team shares the jobs it owns with its members, the owner of these jobs is TeamOwner of the ID of the team
+type Team struct {
+	ID   string
+	Name string
+	// Members are the IDs of the users in the team
+	Members []string
+	// Roles are granted to every member of the team on top of their own
+	Roles     []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Tenant    string
+}
+
+// isMember tells whether the user with userID is one of the members of t
+func (t *Team) isMember(userID string) bool {
+	for _, member := range t.Members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutMember returns the members of t without the user with userID
+func (t *Team) withoutMember(userID string) []string {
+	members := []string{}
+	for _, member := range t.Members {
+		if member != userID {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// TeamRepository stores teams with their members, scoped to the tenant of the context like jobs
+type TeamRepository interface {
+	// Create stores a new team and returns it with its generated ID
+	Create(ctx context.Context, team *Team) (*Team, error)
+	// Get returns the team with the given id, ErrNotFound when it doesn't exist
+	Get(ctx context.Context, id string) (*Team, error)
+	// List returns the teams oldest first, only the ones member belongs to when it's set
+	List(ctx context.Context, member string) ([]*Team, error)
+	// Rename replaces the name and update time of the team with id, ErrNotFound when it doesn't exist
+	Rename(ctx context.Context, id, name string, updatedAt time.Time) (*Team, error)
+	// SetRoles replaces the roles and update time of the team with id, ErrNotFound when it doesn't exist
+	SetRoles(ctx context.Context, id string, roles []string, updatedAt time.Time) (*Team, error)
+	// Delete removes the team with id, ErrNotFound when it doesn't exist
+	Delete(ctx context.Context, id string) error
+	// AddMember adds the user with userID to the members of the team with id, adding a member again changes nothing.
+	// ErrNotFound when the team doesn't exist.
+	AddMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error)
+	// RemoveMember removes the user with userID from the members of the team with id, removing a user that isn't a
+	// member changes nothing. ErrNotFound when the team doesn't exist.
+	RemoveMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error)
+}
+
+// TemplateParameter is a parameter of a job template, the job of the template refers to it as {{name}}
+type TemplateParameter struct {
+	Name        string `bson:"name" json:"name"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	// Default is used for optional parameters jobs are created without
+	Default string `bson:"default,omitempty" json:"default,omitempty"`
+	// Required parameters need a value for every job created from the template
+	Required bool `bson:"required,omitempty" json:"required,omitempty"`
+}
+
+// JobTemplate is a job with placeholders for parameters, jobs are created from it by replacing them with values
+type JobTemplate struct {
+	ID          string
+	Name        string
+	Description string
+	// Owner owns the template like the owner of a job, templates of a team are shared with its members
+	Owner      string
+	Parameters []TemplateParameter
+	// Job is the JSON of the Job message the template creates, with the placeholders in its string fields
+	Job string
+	// Version starts at 1 and is incremented by every update
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Tenant    string
+}
+
+// TemplateRepository stores job templates, scoped to the tenant of the context like jobs. Only the owners of q apply
+// to templates, the other fields of a Query are ignored.
+type TemplateRepository interface {
+	// Create stores a new template and returns it with its generated ID and version 1
+	Create(ctx context.Context, template *JobTemplate) (*JobTemplate, error)
+	// Get returns the template with id matching q, ErrNotFound when there is none
+	Get(ctx context.Context, id string, q Query) (*JobTemplate, error)
+	// List returns the templates matching q oldest first
+	List(ctx context.Context, q Query) ([]*JobTemplate, error)
+	// Update replaces the name, description, owner, parameters, job and update time of the template with the ID of
+	// template matching q, increments its version and returns it. ErrNotFound when there is none.
+	Update(ctx context.Context, template *JobTemplate, q Query) (*JobTemplate, error)
+	// Delete removes the template with id matching q, ErrNotFound when there is none
+	Delete(ctx context.Context, id string, q Query) error
+}
+
+// Calendar holds blackout windows and holidays, the jobs attached to it aren't fired by the scheduler in them
+type Calendar struct {
+	ID          string
+	Name        string
+	Description string
+	// Owner owns the calendar like the owner of a job, calendars of a team are shared with its members
+	Owner     string
+	Blackouts scheduler.Blackouts
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Tenant    string
+}
+
+// CalendarRepository stores calendars, scoped to the tenant of the context like jobs. Only the owners of q apply to
+// calendars, the other fields of a Query are ignored.
+type CalendarRepository interface {
+	// Create stores a new calendar and returns it with its generated ID
+	Create(ctx context.Context, calendar *Calendar) (*Calendar, error)
+	// Get returns the calendar with id matching q, ErrNotFound when there is none
+	Get(ctx context.Context, id string, q Query) (*Calendar, error)
+	// List returns the calendars matching q oldest first
+	List(ctx context.Context, q Query) ([]*Calendar, error)
+	// Update replaces the name, description, owner, blackouts and update time of the calendar with the ID of calendar
+	// matching q and returns it. ErrNotFound when there is none.
+	Update(ctx context.Context, calendar *Calendar, q Query) (*Calendar, error)
+	// Delete removes the calendar with id matching q, ErrNotFound when there is none. Jobs attached to it keep its ID.
+	Delete(ctx context.Context, id string, q Query) error
+	// Blackouts returns the blackouts of the calendars with ids of every owner, for the scheduler. Calendars that don't
+	// exist are left out.
+	Blackouts(ctx context.Context, ids []string) ([]*scheduler.Blackouts, error)
+}
+
+// Secret is a value like a password jobs refer to in their params, it is stored encrypted
+type Secret struct {
+	ID string
+	// Name is unique per owner, the params of the jobs of the owner refer to the secret by it
+	Name        string
+	Description string
+	// Owner owns the secret like the owner of a job, only the jobs of the owner can refer to it
+	Owner string
+	// Value is the encrypted value, sealed by the secrets package
+	Value     []byte
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Tenant    string
+}
+
+// SecretRepository stores secrets, scoped to the tenant of the context like jobs. Only the owners of q apply to
+// secrets, the other fields of a Query are ignored.
+type SecretRepository interface {
+	// Create stores a new secret and returns it with its generated ID, ErrNameTaken when its owner already has a
+	// secret with its name
+	Create(ctx context.Context, secret *Secret) (*Secret, error)
+	// Get returns the secret with id matching q, ErrNotFound when there is none
+	Get(ctx context.Context, id string, q Query) (*Secret, error)
+	// List returns the secrets matching q oldest first
+	List(ctx context.Context, q Query) ([]*Secret, error)
+	// Update replaces the description, value and update time of the secret with the ID of secret matching q and
+	// returns it. ErrNotFound when there is none.
+	Update(ctx context.Context, secret *Secret, q Query) (*Secret, error)
+	// Delete removes the secret with id matching q, ErrNotFound when there is none
+	Delete(ctx context.Context, id string, q Query) error
+	// Named returns the secrets of owner with the given names, for the executor. Names without a secret are left out.
+	Named(ctx context.Context, owner string, names []string) ([]*Secret, error)
+}
+
+// MaintenanceRepository stores the maintenance mode of the scheduler. There is a single one for all tenants, the
+// tenant of the context is ignored.
+type MaintenanceRepository interface {
+	// Maintenance returns the maintenance mode, disabled when it was never set
+	Maintenance(ctx context.Context) (*scheduler.Maintenance, error)
+	// SetMaintenance replaces the maintenance mode and returns it
+	SetMaintenance(ctx context.Context, m *scheduler.Maintenance) (*scheduler.Maintenance, error)
+}
+
+// EnvironmentRepository stores the environments that were paused or protected, environments without a stored state
+// run unprotected. Get, List and Set apply to the tenant of the context, Environments to all tenants.
+type EnvironmentRepository interface {
+	// Get returns the environment called name, a running and unprotected one when it was never stored
+	Get(ctx context.Context, name string) (*scheduler.Environment, error)
+	// List returns the stored environments ordered by name
+	List(ctx context.Context) ([]*scheduler.Environment, error)
+	// Set replaces the state of the environment called env.Name and returns it
+	Set(ctx context.Context, env *scheduler.Environment) (*scheduler.Environment, error)
+	// Environments returns the stored environments of all tenants, for the scheduler
+	Environments(ctx context.Context) ([]*scheduler.Environment, error)
+}
+
+// Change statuses, a change is pending until it's approved or rejected
+const (
+	ChangePending  = "PENDING"
+	ChangeApproved = "APPROVED"
+	ChangeRejected = "REJECTED"
+)
+
+// Change is an update of a job in a protected environment waiting for a second person to approve or reject it
+type Change struct {
+	ID    string
+	JobID string
+	// Owner is the owner of the job when the change was requested, changes are only visible to those who may access
+	// jobs of the owner
+	Owner       string
+	Environment string
+	// Request is the UpdateJobReq of the change in the protobuf wire format, the repositories don't look into it
+	Request     []byte
+	Status      string
+	RequestedBy string
+	RequestedAt time.Time
+	// JobUpdatedAt is when the job was last updated before the change was requested, the change only applies to that
+	// version of the job
+	JobUpdatedAt time.Time
+	ReviewedBy   string
+	ReviewedAt   *time.Time
+	Comment      string
+	Tenant       string
+}
+
+// ChangeFilter restricts the changes List returns, empty fields match every change
+type ChangeFilter struct {
+	JobID  string
+	Status string
+}
+
+// ChangeRepository stores the changes of jobs, scoped to the tenant of the context like jobs. Only the owners of q
+// apply to changes, the other fields of a Query are ignored.
+type ChangeRepository interface {
+	// Create stores a new change and returns it with its generated ID
+	Create(ctx context.Context, change *Change) (*Change, error)
+	// Get returns the change with id matching q, ErrNotFound when there is none
+	Get(ctx context.Context, id string, q Query) (*Change, error)
+	// List returns the changes matching q and filter newest first
+	List(ctx context.Context, q Query, filter ChangeFilter) ([]*Change, error)
+	// Review sets the status, reviewer, comment and review time of the pending change with id matching q and returns
+	// it. ErrNotFound when there is none, ErrReviewed when it isn't pending anymore. It takes part in the
+	// transaction of ctx, so the change is only reviewed when its job is updated.
+	Review(ctx context.Context, id string, q Query, status, reviewer, comment string, reviewedAt time.Time) (*Change, error)
+}
+
+// Revision is the version of a job before an update, kept so the update can be rolled back
+type Revision struct {
+	ID    string
+	JobID string
+	// Revision counts the revisions of the job, starting at 1
+	Revision int64
+	// Job is the job as it was before the update in the wire format of model.Job
+	Job []byte
+	// Changes are the fields the update changed, ordered by field
+	Changes   []AuditChange
+	CreatedBy string
+	CreatedAt time.Time
+	Tenant    string
+}
+
+// RevisionRepository stores the revisions of jobs. The services check who may read a job before its revisions are
+// read, so revisions are only scoped to the tenant.
+type RevisionRepository interface {
+	// Create stores a new revision numbered after the latest one of its job and returns it. It takes part in the
+	// transaction of ctx, so the revision is only kept when the job is updated.
+	Create(ctx context.Context, revision *Revision) (*Revision, error)
+	// Get returns the revision numbered revision of the job with jobID, ErrNotFound when there is none
+	Get(ctx context.Context, jobID string, revision int64) (*Revision, error)
+	// List returns the revisions of the job with jobID newest first
+	List(ctx context.Context, jobID string) ([]*Revision, error)
+}
+
+// OutboxEvent is a domain event kept in the outbox until it was published to the message broker
+type OutboxEvent struct {
+	ID string
+	// Type is the type of the event, like JobCreated
+	Type string
+	// Key is the ID of the job the event is about, brokers keep the events of a key in order
+	Key string
+	// Payload is the JSON encoded data of the event
+	Payload   string
+	CreatedAt time.Time
+	Tenant    string
+}
+
+// OutboxRepository stores the events that weren't published yet. Events are created for the tenant of the context,
+// the relay publishing them serves all tenants.
+type OutboxRepository interface {
+	// Create stores a new event and returns it with its generated ID
+	Create(ctx context.Context, event *OutboxEvent) (*OutboxEvent, error)
+	// Pending returns up to limit events of every tenant oldest first
+	Pending(ctx context.Context, limit int) ([]*OutboxEvent, error)
+	// Delete removes a published event of the tenant of the context, events that are gone already are ignored
+	Delete(ctx context.Context, id string) error
+}
+
+// Transactor runs changes of several repositories of the same storage atomically. The repositories take part in the
+// transaction when they are called with the context f gets. f may be called again when the transaction has to be
+// retried, so it must not have effects outside of the storage. Transactions can't be nested.
+type Transactor interface {
+	// InTransaction commits the changes of f if it succeeds and rolls them back when it returns an error
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+}
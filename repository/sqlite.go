@@ -0,0 +1,2549 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	// Registers the sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+)
+
+// OpenSQLite opens the database file at path, creating it when it doesn't exist, and migrates it to the current schema
+func OpenSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	// Write transactions take the lock when they begin, so they wait for each other for up to the busy timeout
+	// instead of failing when they upgrade a read lock. With the write-ahead log reads go on meanwhile.
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := MigrateSQLite(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not migrate database: %v", err)
+	}
+	return db, nil
+}
+
+// sqliteQuerier runs statements, both the database and transactions do
+type sqliteQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// sqliteRow is a row to scan, both sql.Row and sql.Rows are
+type sqliteRow interface {
+	Scan(dest ...interface{}) error
+}
+
+// sqliteTxKey is the context key of the transaction of SQLiteTransactor
+type sqliteTxKey struct{}
+
+// sqliteTx is a transaction of SQLiteTransactor, committed holds what has to wait until it's committed
+type sqliteTx struct {
+	*sql.Tx
+	mu        sync.Mutex
+	committed []func()
+}
+
+// sqliteConn returns the transaction ctx was created with by SQLiteTransactor, or db outside of a transaction
+func sqliteConn(ctx context.Context, db *sql.DB) sqliteQuerier {
+	if tx, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx); ok {
+		return tx
+	}
+	return db
+}
+
+// afterCommit calls f once the transaction of ctx is committed, right away outside of a transaction
+func afterCommit(ctx context.Context, f func()) {
+	tx, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx)
+	if !ok {
+		f()
+		return
+	}
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.committed = append(tx.committed, f)
+}
+
+// SQLiteTransactor runs transactions on a database, the repositories using the same database take part in them
+type SQLiteTransactor struct {
+	db *sql.DB
+}
+
+// NewSQLiteTransactor creates a Transactor for the repositories created with db
+func NewSQLiteTransactor(db *sql.DB) *SQLiteTransactor {
+	return &SQLiteTransactor{db: db}
+}
+
+func (t *SQLiteTransactor) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stx := &sqliteTx{Tx: tx}
+	if err := f(context.WithValue(ctx, sqliteTxKey{}, stx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, f := range stx.committed {
+		f()
+	}
+	return nil
+}
+
+// sqliteTimeFormat is how times are stored, in UTC with all digits so they sort like the times they stand for
+const sqliteTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// sqliteTime returns the value of a time column
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeFormat)
+}
+
+// sqliteNullTime returns the value of a time column that may be NULL, which nil is stored as
+func sqliteNullTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	value := sqliteTime(*t)
+	return &value
+}
+
+// timeDest scans a column written with sqliteTime into t, or into ptr when the column may be NULL
+type timeDest struct {
+	t   *time.Time
+	ptr **time.Time
+}
+
+func (d timeDest) Scan(src interface{}) error {
+	var text string
+	switch value := src.(type) {
+	case nil:
+		if d.ptr == nil {
+			return errors.New("time is NULL")
+		}
+		*d.ptr = nil
+		return nil
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	default:
+		return fmt.Errorf("unsupported time column of type %T", src)
+	}
+	t, err := time.Parse(sqliteTimeFormat, text)
+	if err != nil {
+		return err
+	}
+	if d.ptr != nil {
+		*d.ptr = &t
+	} else {
+		*d.t = t
+	}
+	return nil
+}
+
+// jsonDest scans a JSON column into v, NULL leaves v as it is
+type jsonDest struct {
+	v interface{}
+}
+
+func (d jsonDest) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(value), d.v)
+	case []byte:
+		return json.Unmarshal(value, d.v)
+	}
+	return fmt.Errorf("unsupported JSON column of type %T", src)
+}
+
+// stringsColumn returns the value of a column holding a list of strings as a JSON array, NULL for nil
+func stringsColumn(values []string) *string {
+	if values == nil {
+		return nil
+	}
+	// A slice of strings always marshals
+	data, _ := json.Marshal(values)
+	value := string(data)
+	return &value
+}
+
+// sqliteTeams appends the owners of the jobs of teams to args and returns their placeholders for owner IN (...). SQLite
+// is built without the JSON functions, so they can't be passed as a single array like to PostgreSQL.
+func sqliteTeams(teams []string, args []interface{}) (string, []interface{}) {
+	placeholders := make([]string, 0, len(teams))
+	for _, id := range teams {
+		args = append(args, TeamOwner(id))
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+// jsonElement returns s the way it appears in a JSON array written by stringsColumn, to look for it with instr
+func jsonElement(s string) string {
+	// A string always marshals
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// sqliteUniqueViolation returns ErrNameTaken, ErrIdempotencyKeyUsed, ErrRunExists or ErrRevisionTaken when err was
+// caused by a job or secret violating the unique index on names, a job violating the one on idempotency keys, a run
+// violating the one on cycles or a revision violating the one on numbers, err otherwise.
+// SQLite names the columns of the index instead of the index, the message is matched since the error type of the
+// driver only exists in builds with cgo.
+func sqliteUniqueViolation(err error) error {
+	if err == nil || !strings.HasPrefix(err.Error(), "UNIQUE constraint failed: ") {
+		return err
+	}
+	switch message := err.Error(); {
+	case strings.HasSuffix(message, "jobs.owner, jobs.environment, jobs.name"), strings.HasSuffix(message, "jobs.owner, jobs.name"),
+		strings.HasSuffix(message, "secrets.owner, secrets.name"):
+		return ErrNameTaken
+	case strings.HasSuffix(message, "jobs.owner, jobs.idempotency_key"):
+		return ErrIdempotencyKeyUsed
+	case strings.HasSuffix(message, "job_runs.job_id, job_runs.attempt"):
+		return ErrRunExists
+	case strings.HasSuffix(message, "job_revisions.job_id, job_revisions.revision"):
+		return ErrRevisionTaken
+	}
+	return err
+}
+
+// sqliteOtherTenant tells whether the row with id wasn't found in table because it belongs to another tenant and
+// returns ErrOtherTenant then, ErrNotFound otherwise
+func sqliteOtherTenant(ctx context.Context, db *sql.DB, table, id string) error {
+	t := tenant.FromContext(ctx)
+	if t == "" {
+		return ErrNotFound
+	}
+	var other bool
+	if err := sqliteConn(ctx, db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = ?1 AND tenant_id <> ?2)`,
+		id, t).Scan(&other); err != nil {
+		return err
+	}
+	if other {
+		return ErrOtherTenant
+	}
+	return ErrNotFound
+}
+
+// scanSQLiteJob reads a row selected with jobColumns
+func scanSQLiteJob(row sqliteRow) (*Job, error) {
+	job := &Job{}
+	var cron, timezone, idempotencyKey *string
+	var interval *int64
+	var timeout int64
+	err := row.Scan(&job.ID, &job.Name, &job.Owner, &job.Description, timeDest{t: &job.CreatedAt}, timeDest{t: &job.UpdatedAt},
+		&cron, &interval, timeDest{ptr: &job.NextRunTime}, &job.Handler, &job.Command, timeDest{ptr: &job.DeletedAt}, &job.Status, &timezone,
+		jsonDest{&job.RetryPolicy}, &timeout, &job.Tenant, &idempotencyKey, jsonDest{&job.Labels}, jsonDest{&job.DependsOn}, &job.Priority,
+		jsonDest{&job.Notifications}, jsonDest{&job.Template}, timeDest{ptr: &job.ArchivedAt}, jsonDest{&job.Blackout},
+		&job.ConcurrencyPolicy, &job.CatchUpPolicy, jsonDest{&job.Params}, &job.Environment, jsonDest{&job.Promotion})
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	job.Timeout = time.Duration(timeout)
+	if idempotencyKey != nil {
+		job.IdempotencyKey = *idempotencyKey
+	}
+	job.Schedule = scheduleSpec(cron, interval, timezone)
+	return job, nil
+}
+
+// scanSQLiteJobs reads the jobs selected with jobColumns
+func scanSQLiteJobs(rows *sql.Rows) ([]*Job, error) {
+	defer rows.Close()
+	jobs := []*Job{}
+	for rows.Next() {
+		job, err := scanSQLiteJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// sqliteJobValues returns the values of jobColumns for job with the given ID stored for the tenant of ctx
+func sqliteJobValues(ctx context.Context, id string, job *Job) []interface{} {
+	cron, interval, timezone := scheduleColumns(job.Schedule)
+	return []interface{}{id, job.Name, job.Owner, job.Description, sqliteTime(job.CreatedAt), sqliteTime(job.UpdatedAt),
+		cron, interval, sqliteNullTime(job.NextRunTime), job.Handler, job.Command, sqliteNullTime(job.DeletedAt), job.Status, timezone,
+		retryPolicyColumn(job.RetryPolicy), int64(job.Timeout), tenant.FromContext(ctx), idempotencyKeyColumn(job.IdempotencyKey),
+		labelsColumn(job.Labels), stringsColumn(job.DependsOn), job.Priority, notificationsColumn(job.Notifications),
+		templateColumn(job.Template), sqliteNullTime(job.ArchivedAt), blackoutColumn(job.Blackout),
+		job.ConcurrencyPolicy, job.CatchUpPolicy, paramsColumn(job.Params), job.Environment, promotionColumn(job.Promotion)}
+}
+
+// SQLiteJobRepository stores jobs in the jobs table of a SQLite database. Watches only see the changes made through
+// the repository, so the database must not be shared by several processes.
+type SQLiteJobRepository struct {
+	db       *sql.DB
+	mu       sync.Mutex
+	watchers map[*localJobEvents]bool
+}
+
+// NewSQLiteJobRepository creates a repository for the jobs in a database migrated with MigrateSQLite
+func NewSQLiteJobRepository(db *sql.DB) *SQLiteJobRepository {
+	return &SQLiteJobRepository{db: db, watchers: map[*localJobEvents]bool{}}
+}
+
+// publish sends a change to every watcher interested in it, once the transaction of ctx is committed if there is one
+func (r *SQLiteJobRepository) publish(ctx context.Context, eventType EventType, job *Job) {
+	c := &change{eventType: eventType, job: job}
+	afterCommit(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for w := range r.watchers {
+			if event := c.event(w.query, w.tenant); event != nil && !w.offer(event) {
+				delete(r.watchers, w)
+			}
+		}
+	})
+}
+
+func (r *SQLiteJobRepository) Create(ctx context.Context, job *Job) (*Job, error) {
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO jobs (`+jobColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?19, ?20, ?21, ?22, ?23, ?24, ?25, ?26, ?27, ?28,
+			?29, ?30)
+		RETURNING `+jobColumns, sqliteJobValues(ctx, newID(), job)...)
+	created, err := scanSQLiteJob(row)
+	if err != nil {
+		return nil, sqliteUniqueViolation(err)
+	}
+	r.publish(ctx, EventCreated, created)
+	return created, nil
+}
+
+// CreateMany inserts the jobs one at a time in a single transaction, only the jobs that can't be stored fail and are
+// reported in a *BatchError
+func (r *SQLiteJobRepository) CreateMany(ctx context.Context, jobs []*Job) ([]*Job, error) {
+	if _, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx); ok {
+		return r.createEach(ctx, jobs)
+	}
+	// Committing once is a lot faster than committing every job
+	var created []*Job
+	var batchErr error
+	err := NewSQLiteTransactor(r.db).InTransaction(ctx, func(ctx context.Context) error {
+		created, batchErr = r.createEach(ctx, jobs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, batchErr
+}
+
+// createEach inserts the jobs one at a time and reports the ones that couldn't be stored in a *BatchError
+func (r *SQLiteJobRepository) createEach(ctx context.Context, jobs []*Job) ([]*Job, error) {
+	created := make([]*Job, len(jobs))
+	batchErr := &BatchError{Errors: map[int]error{}}
+	for i, job := range jobs {
+		stored, err := r.Create(ctx, job)
+		if err != nil {
+			batchErr.Errors[i] = err
+			continue
+		}
+		created[i] = stored
+	}
+	if len(batchErr.Errors) > 0 {
+		return created, batchErr
+	}
+	return created, nil
+}
+
+func (r *SQLiteJobRepository) Put(ctx context.Context, job *Job, replace bool) error {
+	if err := checkID(job.ID); err != nil {
+		return err
+	}
+	// Watchers learn whether the job was created or replaced
+	var existed bool
+	if err := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM jobs WHERE id = ?1)`, job.ID).Scan(&existed); err != nil {
+		return err
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `INSERT INTO jobs (`+jobColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?19, ?20, ?21, ?22, ?23, ?24, ?25, ?26, ?27, ?28,
+			?29, ?30)`+
+		onConflict("jobs", jobColumns, replace), sqliteJobValues(ctx, job.ID, job)...)
+	if err != nil {
+		return sqliteUniqueViolation(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrExists
+	}
+	stored := copyJob(job)
+	stored.Tenant = tenant.FromContext(ctx)
+	if existed {
+		r.publish(ctx, EventUpdated, stored)
+	} else {
+		r.publish(ctx, EventCreated, stored)
+	}
+	return nil
+}
+
+func (r *SQLiteJobRepository) Get(ctx context.Context, id string, q Query) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, q.IncludeDeleted, tenant.FromContext(ctx)})
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 OR deleted_at IS NULL) AND (?4 = '' OR tenant_id = ?4)`, args...)
+	job, err := scanSQLiteJob(row)
+	if err == ErrNotFound {
+		return nil, sqliteOtherTenant(ctx, r.db, "jobs", id)
+	}
+	return job, err
+}
+
+func (r *SQLiteJobRepository) GetMany(ctx context.Context, ids []string, q Query) ([]*Job, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, q.IncludeDeleted, tenant.FromContext(ctx)})
+	placeholders := []string{}
+	for _, id := range ids {
+		if checkID(id) == nil {
+			args = append(args, id)
+			placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+		}
+	}
+	if len(placeholders) == 0 {
+		return []*Job{}, nil
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE id IN (`+strings.Join(placeholders, ", ")+`) AND (?1 = '' OR owner = ?1 OR owner IN (`+teams+`)) AND (?2 OR deleted_at IS NULL)
+		AND (?3 = '' OR tenant_id = ?3)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanSQLiteJobs(rows)
+}
+
+func (r *SQLiteJobRepository) Update(ctx context.Context, id string, q Query, update *JobUpdate) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, q.IncludeDeleted, tenant.FromContext(ctx)})
+	set := []string{}
+	column := func(name string, value interface{}) {
+		args = append(args, value)
+		set = append(set, fmt.Sprintf("%s = ?%d", name, len(args)))
+	}
+	column("updated_at", sqliteTime(update.UpdatedAt))
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"name", update.Name},
+		{"description", update.Description},
+		{"owner", update.Owner},
+		{"handler", update.Handler},
+		{"command", update.Command},
+		{"priority", update.Priority},
+		{"concurrency_policy", update.ConcurrencyPolicy},
+		{"catch_up_policy", update.CatchUpPolicy},
+		{"environment", update.Environment},
+	} {
+		if field.value != nil {
+			column(field.name, *field.value)
+		}
+	}
+	if update.Timeout != nil {
+		column("timeout", int64(*update.Timeout))
+	}
+	if update.SetSchedule {
+		cron, interval, timezone := scheduleColumns(update.Schedule)
+		column("schedule_cron", cron)
+		column("schedule_interval", interval)
+		column("schedule_timezone", timezone)
+		column("next_run_time", sqliteNullTime(update.NextRunTime))
+	}
+	if update.SetRetryPolicy {
+		column("retry_policy", retryPolicyColumn(update.RetryPolicy))
+	}
+	if update.SetLabels {
+		column("labels", labelsColumn(update.Labels))
+	}
+	if update.SetDependsOn {
+		column("depends_on", stringsColumn(update.DependsOn))
+	}
+	if update.SetNotifications {
+		column("notifications", notificationsColumn(update.Notifications))
+	}
+	if update.SetArchivedAt {
+		column("archived_at", sqliteNullTime(update.ArchivedAt))
+	}
+	if update.SetBlackout {
+		column("blackout", blackoutColumn(update.Blackout))
+	}
+	if update.SetParams {
+		column("params", paramsColumn(update.Params))
+	}
+	if update.SetPromotion {
+		column("promotion", promotionColumn(update.Promotion))
+	}
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE jobs SET `+strings.Join(set, ", ")+`
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 OR deleted_at IS NULL) AND (?4 = '' OR tenant_id = ?4)
+		RETURNING `+jobColumns, args...)
+	job, err := scanSQLiteJob(row)
+	if err == ErrNotFound {
+		return nil, sqliteOtherTenant(ctx, r.db, "jobs", id)
+	} else if err != nil {
+		return nil, sqliteUniqueViolation(err)
+	}
+	r.publish(ctx, EventUpdated, job)
+	return job, nil
+}
+
+func (r *SQLiteJobRepository) Delete(ctx context.Context, id string, q Query, deletedAt time.Time) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	// Deleting a job again keeps the time it was deleted first
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, sqliteTime(deletedAt), tenant.FromContext(ctx)})
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE jobs SET deleted_at = ?3
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND deleted_at IS NULL AND (?4 = '' OR tenant_id = ?4)
+		RETURNING `+jobColumns, args...)
+	job, err := scanSQLiteJob(row)
+	if err == ErrNotFound {
+		// Tell jobs of other tenants apart from missing and already deleted ones
+		if err := sqliteOtherTenant(ctx, r.db, "jobs", id); err != ErrNotFound {
+			return false, err
+		}
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	r.publish(ctx, EventDeleted, job)
+	return true, nil
+}
+
+func (r *SQLiteJobRepository) SetStatus(ctx context.Context, id string, q Query, from, to string, updatedAt time.Time) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, q.IncludeDeleted, from, to, sqliteTime(updatedAt), tenant.FromContext(ctx)})
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE jobs SET status = ?5, updated_at = ?6
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 OR deleted_at IS NULL) AND status = ?4 AND (?7 = '' OR tenant_id = ?7)
+		RETURNING `+jobColumns, args...)
+	job, err := scanSQLiteJob(row)
+	if err == ErrNotFound {
+		// Tell a missing job apart from one with another status
+		if _, err := r.Get(ctx, id, q); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	} else if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, EventUpdated, job)
+	return job, nil
+}
+
+func (r *SQLiteJobRepository) Restore(ctx context.Context, id string, q Query, updatedAt time.Time) (*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx), sqliteTime(updatedAt)})
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE jobs SET deleted_at = NULL, updated_at = ?4
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND deleted_at IS NOT NULL AND (?3 = '' OR tenant_id = ?3)
+		RETURNING `+jobColumns, args...)
+	job, err := scanSQLiteJob(row)
+	if err == ErrNotFound {
+		return nil, sqliteOtherTenant(ctx, r.db, "jobs", id)
+	} else if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, EventUpdated, job)
+	return job, nil
+}
+
+func (r *SQLiteJobRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM jobs WHERE deleted_at < ?1 AND (?2 = '' OR tenant_id = ?2)`,
+		sqliteTime(deletedBefore), tenant.FromContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *SQLiteJobRepository) GetByIdempotencyKey(ctx context.Context, owner, key string) (*Job, error) {
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE owner = ?1 AND idempotency_key = ?2 AND tenant_id = ?3`, owner, key, tenant.FromContext(ctx))
+	return scanSQLiteJob(row)
+}
+
+func (r *SQLiteJobRepository) GetByName(ctx context.Context, owner, environment, name string) (*Job, error) {
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE owner = ?1 AND environment = ?2 AND name = ?3 AND tenant_id = ?4`, owner, environment, name, tenant.FromContext(ctx))
+	return scanSQLiteJob(row)
+}
+
+func (r *SQLiteJobRepository) ExpireIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `UPDATE jobs SET idempotency_key = NULL
+		WHERE idempotency_key IS NOT NULL AND created_at < ?1 AND (?2 = '' OR tenant_id = ?2)`, sqliteTime(createdBefore), tenant.FromContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// List matches label selectors while reading the rows, which are only read until the page is full
+// Count counts the jobs in the database without a label selector, with one it reads the labels of the other matching
+// jobs to evaluate it
+func (r *SQLiteJobRepository) Count(ctx context.Context, q Query) (int64, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, q.IncludeDeleted, tenant.FromContext(ctx)})
+	where := `
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (` + teams + `)) AND (?2 OR deleted_at IS NULL) AND (?3 = '' OR tenant_id = ?3)` +
+		archivedCondition(q.Archived)
+	if q.Environment != "" {
+		args = append(args, q.Environment)
+		where += fmt.Sprintf(` AND environment = ?%d`, len(args))
+	}
+	var count int64
+	if len(q.Labels) == 0 {
+		err := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM jobs`+where, args...).Scan(&count)
+		return count, err
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT labels FROM jobs`+where, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jobLabels map[string]string
+		if err := rows.Scan(jsonDest{&jobLabels}); err != nil {
+			return 0, err
+		}
+		if q.Labels.Matches(jobLabels) {
+			count++
+		}
+	}
+	return count, rows.Err()
+}
+
+func (r *SQLiteJobRepository) Exists(ctx context.Context, id string, q Query) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, q.IncludeDeleted, tenant.FromContext(ctx)})
+	var exists bool
+	err := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM jobs
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 OR deleted_at IS NULL) AND (?4 = '' OR tenant_id = ?4))`,
+		args...).Scan(&exists)
+	return exists, err
+}
+
+func (r *SQLiteJobRepository) List(ctx context.Context, q Query, after string, limit int) ([]*Job, error) {
+	if after != "" {
+		if err := checkID(after); err != nil {
+			return nil, err
+		}
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, q.IncludeDeleted, after, tenant.FromContext(ctx)})
+	query := `SELECT ` + jobColumns + ` FROM jobs
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (` + teams + `)) AND (?2 OR deleted_at IS NULL) AND id > ?3 AND (?4 = '' OR tenant_id = ?4)` +
+		archivedCondition(q.Archived)
+	if q.Environment != "" {
+		args = append(args, q.Environment)
+		query += fmt.Sprintf(` AND environment = ?%d`, len(args))
+	}
+	query += `
+		ORDER BY id`
+	if len(q.Labels) == 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(` LIMIT ?%d`, len(args))
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	jobs := []*Job{}
+	for len(jobs) < limit && rows.Next() {
+		job, err := scanSQLiteJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		if q.Labels.Matches(job.Labels) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, rows.Err()
+}
+
+// Search scores the jobs of the query like MemoryJobRepository does, SQLite is built without full text search
+func (r *SQLiteJobRepository) Search(ctx context.Context, q Query, text string, offset, limit int) ([]*SearchResult, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, q.IncludeDeleted, tenant.FromContext(ctx)})
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (`+teams+`)) AND (?2 OR deleted_at IS NULL) AND (?3 = '' OR tenant_id = ?3)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := scanSQLiteJobs(rows)
+	if err != nil {
+		return nil, err
+	}
+	return rankJobs(jobs, text, offset, limit), nil
+}
+
+func (r *SQLiteJobRepository) DueJobs(ctx context.Context, now time.Time) ([]*scheduler.DueJob, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE next_run_time <= ?1 AND (schedule_cron IS NOT NULL OR schedule_interval IS NOT NULL) AND deleted_at IS NULL AND status <> 'PAUSED'
+		AND archived_at IS NULL AND (?2 = '' OR tenant_id = ?2)`, sqliteTime(now), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := scanSQLiteJobs(rows)
+	if err != nil {
+		return nil, err
+	}
+	due := make([]*scheduler.DueJob, 0, len(jobs))
+	for _, job := range jobs {
+		due = append(due, job.dueJob())
+	}
+	return due, nil
+}
+
+func (r *SQLiteJobRepository) ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `UPDATE jobs SET next_run_time = ?3
+		WHERE id = ?1 AND next_run_time = ?2 AND deleted_at IS NULL AND (?4 = '' OR tenant_id = ?4)`,
+		id, sqliteTime(prev), sqliteTime(next), tenant.FromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// Dependents looks for the quoted ID in the JSON arrays of depends_on, IDs never contain quotes themselves
+func (r *SQLiteJobRepository) Dependents(ctx context.Context, id string) ([]*Job, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs
+		WHERE instr(depends_on, ?1) > 0 AND deleted_at IS NULL AND (?2 = '' OR tenant_id = ?2)
+		ORDER BY id`, jsonElement(id), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanSQLiteJobs(rows)
+}
+
+// Watch receives the changes made through this repository once they are committed. Changes aren't kept, so a watch
+// can't be resumed.
+func (r *SQLiteJobRepository) Watch(ctx context.Context, q Query, resumeToken string) (JobEvents, error) {
+	if resumeToken != "" {
+		return nil, ErrResumeUnsupported
+	}
+	w := &localJobEvents{
+		query:   q,
+		tenant:  tenant.FromContext(ctx),
+		events:  make(chan *JobEvent, watchBuffer),
+		lagging: make(chan struct{}),
+	}
+	w.stop = func() {
+		r.mu.Lock()
+		delete(r.watchers, w)
+		r.mu.Unlock()
+	}
+	r.mu.Lock()
+	r.watchers[w] = true
+	r.mu.Unlock()
+	return w, nil
+}
+
+// scanSQLiteRun reads a row selected with runColumns
+func scanSQLiteRun(row sqliteRow) (*Run, error) {
+	run := &Run{}
+	var timeout, duration int64
+	var cycleID *string
+	err := row.Scan(&run.ID, &run.JobID, &run.Status, timeDest{t: &run.QueuedAt}, timeDest{ptr: &run.StartTime}, timeDest{ptr: &run.EndTime},
+		&run.Output, &run.Error, &run.Attempt, timeDest{ptr: &run.RetryAt}, &timeout, &duration, &run.Tenant, &cycleID, &run.Priority,
+		&run.Trigger, &run.TriggeredBy, &run.Worker, &run.CostPerSecond, &run.Cost)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	run.Timeout = time.Duration(timeout)
+	run.Duration = time.Duration(duration)
+	if cycleID != nil {
+		run.CycleID = *cycleID
+	}
+	return run, nil
+}
+
+// scanSQLiteRuns reads the runs selected with runColumns
+func scanSQLiteRuns(rows *sql.Rows) ([]*Run, error) {
+	defer rows.Close()
+	runs := []*Run{}
+	for rows.Next() {
+		run, err := scanSQLiteRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// sqliteRunValues returns the values of runColumns for run with the given ID and cycle stored for the tenant of ctx
+func sqliteRunValues(ctx context.Context, id, cycleID string, run *Run) []interface{} {
+	return []interface{}{id, run.JobID, run.Status, sqliteTime(run.QueuedAt), sqliteNullTime(run.StartTime), sqliteNullTime(run.EndTime),
+		run.Output, run.Error, run.Attempt, sqliteNullTime(run.RetryAt), int64(run.Timeout), int64(run.Duration), tenant.FromContext(ctx),
+		cycleIDColumn(cycleID), run.Priority, run.Trigger, run.TriggeredBy, run.Worker, run.CostPerSecond, run.Cost}
+}
+
+// SQLiteRunRepository stores runs in the job_runs table of a SQLite database
+type SQLiteRunRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRunRepository creates a repository for the runs in a database migrated with MigrateSQLite
+func NewSQLiteRunRepository(db *sql.DB) *SQLiteRunRepository {
+	return &SQLiteRunRepository{db: db}
+}
+
+func (r *SQLiteRunRepository) Create(ctx context.Context, run *Run) (*Run, error) {
+	if err := checkID(run.JobID); err != nil {
+		return nil, err
+	}
+	id := newID()
+	cycleID := run.CycleID
+	if cycleID == "" {
+		cycleID = id
+	}
+	row := sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO job_runs (`+runColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?19, ?20)
+		RETURNING `+runColumns, sqliteRunValues(ctx, id, cycleID, run)...)
+	created, err := scanSQLiteRun(row)
+	if err != nil {
+		return nil, sqliteUniqueViolation(err)
+	}
+	return created, nil
+}
+
+func (r *SQLiteRunRepository) Put(ctx context.Context, run *Run, replace bool) error {
+	for _, id := range []string{run.ID, run.JobID} {
+		if err := checkID(id); err != nil {
+			return err
+		}
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `INSERT INTO job_runs (`+runColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?19, ?20)`+
+		onConflict("job_runs", runColumns, replace), sqliteRunValues(ctx, run.ID, run.CycleID, run)...)
+	if err != nil {
+		return sqliteUniqueViolation(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrExists
+	}
+	return nil
+}
+
+func (r *SQLiteRunRepository) Update(ctx context.Context, run *Run) error {
+	if err := checkID(run.ID); err != nil {
+		return err
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `UPDATE job_runs SET status = ?2, start_time = ?3, end_time = ?4, output = ?5,
+		error = ?6, timeout = ?7, duration = ?8, worker = ?10, cost_per_second = ?11, cost = ?12
+		WHERE id = ?1 AND (?9 = '' OR tenant_id = ?9)`, run.ID, run.Status, sqliteNullTime(run.StartTime), sqliteNullTime(run.EndTime),
+		run.Output, run.Error, int64(run.Timeout), int64(run.Duration), tenant.FromContext(ctx), run.Worker, run.CostPerSecond, run.Cost)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRunRepository) Get(ctx context.Context, id string) (*Run, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	run, err := scanSQLiteRun(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`, id, tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		return nil, sqliteOtherTenant(ctx, r.db, "job_runs", id)
+	}
+	return run, err
+}
+
+func (r *SQLiteRunRepository) Prune(ctx context.Context, jobID string, except []string, keep int, maxAge time.Duration) (int64, error) {
+	if jobID != "" {
+		if err := checkID(jobID); err != nil {
+			return 0, err
+		}
+	}
+	var queuedBefore *string
+	if maxAge > 0 {
+		t := sqliteTime(time.Now().Add(-maxAge))
+		queuedBefore = &t
+	}
+	args := []interface{}{tenant.FromContext(ctx), jobID, keep, queuedBefore}
+	placeholders := make([]string, 0, len(except))
+	for _, id := range except {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM job_runs WHERE id IN (
+		SELECT id FROM (
+			SELECT id, queued_at, row_number() OVER (PARTITION BY job_id ORDER BY id DESC) AS n FROM job_runs
+			WHERE tenant_id = ?1 AND (?2 = '' OR job_id = ?2) AND job_id NOT IN (`+strings.Join(placeholders, ", ")+`)
+				AND status NOT IN ('QUEUED', 'WAITING', 'RUNNING')
+		)
+		WHERE (?3 > 0 AND n > ?3) OR queued_at < ?4)`, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *SQLiteRunRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Run, error) {
+	for _, id := range []string{jobID, before} {
+		if id == "" {
+			continue
+		}
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE (?1 = '' OR job_id = ?1) AND (?2 = '' OR id < ?2) AND (?4 = '' OR tenant_id = ?4)
+		ORDER BY id DESC LIMIT ?3`, jobID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanSQLiteRuns(rows)
+}
+
+func (r *SQLiteRunRepository) CycleRuns(ctx context.Context, cycleID string) ([]*Run, error) {
+	if err := checkID(cycleID); err != nil {
+		return nil, err
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE cycle_id = ?1 AND (?2 = '' OR tenant_id = ?2)
+		ORDER BY id`, cycleID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanSQLiteRuns(rows)
+}
+
+func (r *SQLiteRunRepository) DueRetries(ctx context.Context, now time.Time) ([]*scheduler.DueRetry, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+runColumns+` FROM job_runs
+		WHERE status = ?1 AND retry_at <= ?2 AND (?3 = '' OR tenant_id = ?3)`, RunWaiting, sqliteTime(now), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	runs, err := scanSQLiteRuns(rows)
+	if err != nil {
+		return nil, err
+	}
+	retries := make([]*scheduler.DueRetry, 0, len(runs))
+	for _, run := range runs {
+		retries = append(retries, &scheduler.DueRetry{
+			RunID:   run.ID,
+			JobID:   run.JobID,
+			Tenant:  run.Tenant,
+			Attempt: run.Attempt,
+			RetryAt: *run.RetryAt,
+		})
+	}
+	return retries, nil
+}
+
+func (r *SQLiteRunRepository) ClaimRetry(ctx context.Context, id string) (bool, error) {
+	if err := checkID(id); err != nil {
+		return false, err
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `UPDATE job_runs SET status = ?3 WHERE id = ?1 AND status = ?2 AND (?4 = '' OR tenant_id = ?4)`,
+		id, RunWaiting, RunQueued, tenant.FromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// Stats counts the runs by status in one query and reads the sorted durations in another to pick the nearest rank
+// percentiles from, SQLite has no percentile functions
+func (r *SQLiteRunRepository) Stats(ctx context.Context, jobID string, from, to time.Time) (*RunStats, error) {
+	if err := checkID(jobID); err != nil {
+		return nil, err
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT status, count(*) FROM job_runs
+		WHERE job_id = ?1 AND queued_at >= ?2 AND queued_at < ?3 AND (?4 = '' OR tenant_id = ?4)
+		GROUP BY status`, jobID, sqliteTime(from), sqliteTime(to), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	stats := &RunStats{Counts: map[string]int64{}}
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		stats.Counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT duration FROM job_runs
+		WHERE job_id = ?1 AND queued_at >= ?2 AND queued_at < ?3 AND start_time IS NOT NULL AND end_time IS NOT NULL
+		AND (?4 = '' OR tenant_id = ?4)
+		ORDER BY duration`, jobID, sqliteTime(from), sqliteTime(to), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	durations := []time.Duration{}
+	var total time.Duration
+	for rows.Next() {
+		var duration int64
+		if err := rows.Scan(&duration); err != nil {
+			return nil, err
+		}
+		durations = append(durations, time.Duration(duration))
+		total += time.Duration(duration)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	stats.Finished = int64(len(durations))
+	if stats.Finished == 0 {
+		return stats, nil
+	}
+	stats.Mean = total / time.Duration(stats.Finished)
+	stats.P50 = durations[percentileRank(50, stats.Finished)]
+	stats.P95 = durations[percentileRank(95, stats.Finished)]
+	stats.P99 = durations[percentileRank(99, stats.Finished)]
+	return stats, nil
+}
+
+func (r *SQLiteRunRepository) Usage(ctx context.Context, jobIDs []string, from, to time.Time) (*RunUsage, error) {
+	usage := &RunUsage{Counts: map[string]int64{}}
+	if len(jobIDs) == 0 {
+		return usage, nil
+	}
+	args := []interface{}{sqliteTime(from), sqliteTime(to), tenant.FromContext(ctx)}
+	placeholders := make([]string, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT status, count(*), COALESCE(sum(duration), 0) FROM job_runs
+		WHERE job_id IN (`+strings.Join(placeholders, ", ")+`) AND queued_at >= ?1 AND queued_at < ?2 AND (?3 = '' OR tenant_id = ?3)
+		GROUP BY status`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var count, duration int64
+		if err := rows.Scan(&status, &count, &duration); err != nil {
+			return nil, err
+		}
+		usage.Counts[status] = count
+		usage.ComputeTime += time.Duration(duration)
+	}
+	return usage, rows.Err()
+}
+
+// Costs groups the runs by job and month, months are the first seven characters of the stored UTC times
+func (r *SQLiteRunRepository) Costs(ctx context.Context, jobIDs []string, from, to time.Time) ([]*RunCost, error) {
+	costs := []*RunCost{}
+	if len(jobIDs) == 0 {
+		return costs, nil
+	}
+	args := []interface{}{sqliteTime(from), sqliteTime(to), tenant.FromContext(ctx)}
+	placeholders := make([]string, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT job_id, substr(queued_at, 1, 7), count(*), COALESCE(sum(duration), 0),
+		COALESCE(sum(cost), 0)
+		FROM job_runs
+		WHERE job_id IN (`+strings.Join(placeholders, ", ")+`) AND queued_at >= ?1 AND queued_at < ?2 AND (?3 = '' OR tenant_id = ?3)
+		GROUP BY 1, 2
+		ORDER BY 1, 2`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c := &RunCost{}
+		var month string
+		var duration int64
+		if err := rows.Scan(&c.JobID, &month, &c.Runs, &duration, &c.Cost); err != nil {
+			return nil, err
+		}
+		if c.Month, err = time.Parse("2006-01", month); err != nil {
+			return nil, err
+		}
+		c.ComputeTime = time.Duration(duration)
+		costs = append(costs, c)
+	}
+	return costs, rows.Err()
+}
+
+// TimeSeries reads the runs of the range and puts them into buckets with TruncateBucket, SQLite knows no time zones
+func (r *SQLiteRunRepository) TimeSeries(ctx context.Context, jobID string, from, to time.Time, bucket string, loc *time.Location) ([]*RunBucket, error) {
+	if err := checkID(jobID); err != nil {
+		return nil, err
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT queued_at, status, start_time IS NOT NULL AND end_time IS NOT NULL, duration
+		FROM job_runs
+		WHERE job_id = ?1 AND queued_at >= ?2 AND queued_at < ?3 AND (?4 = '' OR tenant_id = ?4)
+		ORDER BY queued_at`, jobID, sqliteTime(from), sqliteTime(to), tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// The runs are sorted by the time they were queued, so the runs of a bucket follow each other
+	series := []*RunBucket{}
+	var total int64
+	for rows.Next() {
+		var queuedAt time.Time
+		var status string
+		var finished bool
+		var duration int64
+		if err := rows.Scan(timeDest{t: &queuedAt}, &status, &finished, &duration); err != nil {
+			return nil, err
+		}
+		start := TruncateBucket(queuedAt, bucket, loc)
+		if len(series) == 0 || !series[len(series)-1].Start.Equal(start) {
+			if len(series) > 0 {
+				setMean(series[len(series)-1], total)
+			}
+			series = append(series, &RunBucket{Start: start, Counts: map[string]int64{}})
+			total = 0
+		}
+		b := series[len(series)-1]
+		b.Counts[status]++
+		if finished {
+			b.Finished++
+			total += duration
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(series) > 0 {
+		setMean(series[len(series)-1], total)
+	}
+	return series, nil
+}
+
+// SQLiteLeaseRepository stores leases in the leases table of a SQLite database
+type SQLiteLeaseRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteLeaseRepository creates a repository for the leases in a database migrated with MigrateSQLite
+func NewSQLiteLeaseRepository(db *sql.DB) *SQLiteLeaseRepository {
+	return &SQLiteLeaseRepository{db: db}
+}
+
+// AcquireLease inserts the lease or takes it over, the conflict update only applies while the lease is free
+func (r *SQLiteLeaseRepository) AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `INSERT INTO leases (name, holder, expires_at) VALUES (?1, ?2, ?3)
+		ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+		WHERE leases.holder = EXCLUDED.holder OR leases.expires_at <= ?4`, name, holder, sqliteTime(now.Add(ttl)), sqliteTime(now))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+func (r *SQLiteLeaseRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	_, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM leases WHERE name = ?1 AND holder = ?2`, name, holder)
+	return err
+}
+
+// SQLiteAuditRepository stores the audit log in the audit_entries table of a SQLite database
+type SQLiteAuditRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAuditRepository creates a repository for the audit entries in a database migrated with MigrateSQLite
+func NewSQLiteAuditRepository(db *sql.DB) *SQLiteAuditRepository {
+	return &SQLiteAuditRepository{db: db}
+}
+
+// scanSQLiteAuditEntry reads an audit entry selected with auditColumns
+func scanSQLiteAuditEntry(row sqliteRow) (*AuditEntry, error) {
+	entry := &AuditEntry{}
+	if err := row.Scan(&entry.ID, timeDest{t: &entry.Time}, &entry.Actor, &entry.Method, &entry.JobID, &entry.Owner,
+		jsonDest{&entry.Changes}, &entry.Tenant); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *SQLiteAuditRepository) Create(ctx context.Context, entry *AuditEntry) (*AuditEntry, error) {
+	stored := *entry
+	stored.ID = newID()
+	stored.Tenant = tenant.FromContext(ctx)
+	changes := stored.Changes
+	if changes == nil {
+		changes = []AuditChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	_, err = sqliteConn(ctx, r.db).ExecContext(ctx, `INSERT INTO audit_entries (`+auditColumns+`) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)`,
+		stored.ID, sqliteTime(stored.Time), stored.Actor, stored.Method, stored.JobID, stored.Owner, string(data), stored.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (r *SQLiteAuditRepository) List(ctx context.Context, filter AuditFilter, before string, limit int) ([]*AuditEntry, error) {
+	for _, id := range []string{filter.JobID, before} {
+		if id == "" {
+			continue
+		}
+		if err := checkID(id); err != nil {
+			return nil, err
+		}
+	}
+	teams, args := sqliteTeams(filter.Teams, []interface{}{filter.Actor, filter.JobID, filter.Owner, before, limit, tenant.FromContext(ctx)})
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+auditColumns+` FROM audit_entries
+		WHERE (?1 = '' OR actor = ?1) AND (?2 = '' OR job_id = ?2) AND (?3 = '' OR owner = ?3 OR owner IN (`+teams+`)) AND (?4 = '' OR id < ?4)
+		AND (?6 = '' OR tenant_id = ?6)
+		ORDER BY id DESC LIMIT ?5`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := []*AuditEntry{}
+	for rows.Next() {
+		entry, err := scanSQLiteAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// SQLiteWebhookRepository stores webhooks in the webhooks table of a SQLite database and their dead letters in
+// webhook_dead_letters
+type SQLiteWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteWebhookRepository creates a repository for the webhooks in a database migrated with MigrateSQLite
+func NewSQLiteWebhookRepository(db *sql.DB) *SQLiteWebhookRepository {
+	return &SQLiteWebhookRepository{db: db}
+}
+
+// scanSQLiteWebhook reads a webhook selected with webhookColumns
+func scanSQLiteWebhook(row sqliteRow) (*Webhook, error) {
+	hook := &Webhook{}
+	err := row.Scan(&hook.ID, &hook.Owner, &hook.URL, &hook.Secret, jsonDest{&hook.Events}, timeDest{t: &hook.CreatedAt}, &hook.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (r *SQLiteWebhookRepository) Create(ctx context.Context, hook *Webhook) (*Webhook, error) {
+	return scanSQLiteWebhook(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO webhooks (`+webhookColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7) RETURNING `+webhookColumns,
+		newID(), hook.Owner, hook.URL, hook.Secret, stringsColumn(hook.Events), sqliteTime(hook.CreatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteWebhookRepository) Get(ctx context.Context, id, owner string) (*Webhook, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSQLiteWebhook(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2) AND (?3 = '' OR tenant_id = ?3)`, id, owner, tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteWebhookRepository) List(ctx context.Context, owner string) ([]*Webhook, error) {
+	return r.find(ctx, owner, "")
+}
+
+func (r *SQLiteWebhookRepository) Subscribed(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	return r.find(ctx, owner, event)
+}
+
+// find returns the webhooks of owner receiving the event oldest first, of all owners and events when they are empty.
+// Webhooks without events receive all of them.
+func (r *SQLiteWebhookRepository) find(ctx context.Context, owner, event string) ([]*Webhook, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks
+		WHERE (?1 = '' OR owner = ?1) AND (?2 = '' OR events IS NULL OR instr(events, ?4) > 0) AND (?3 = '' OR tenant_id = ?3)
+		ORDER BY id`, owner, event, tenant.FromContext(ctx), jsonElement(event))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hooks := []*Webhook{}
+	for rows.Next() {
+		hook, err := scanSQLiteWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *SQLiteWebhookRepository) Delete(ctx context.Context, id, owner string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	// The dead letters are deleted by the foreign key
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?1 AND (?2 = '' OR owner = ?2) AND (?3 = '' OR tenant_id = ?3)`,
+		id, owner, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanSQLiteDeadLetter reads a dead letter selected with deadLetterColumns
+func scanSQLiteDeadLetter(row sqliteRow) (*DeadLetter, error) {
+	letter := &DeadLetter{}
+	if err := row.Scan(&letter.ID, &letter.WebhookID, &letter.Event, &letter.Payload, &letter.Attempts, &letter.Error,
+		timeDest{t: &letter.CreatedAt}, &letter.Tenant); err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
+
+func (r *SQLiteWebhookRepository) CreateDeadLetter(ctx context.Context, letter *DeadLetter) (*DeadLetter, error) {
+	if err := checkID(letter.WebhookID); err != nil {
+		return nil, err
+	}
+	return scanSQLiteDeadLetter(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO webhook_dead_letters (`+deadLetterColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8) RETURNING `+deadLetterColumns,
+		newID(), letter.WebhookID, letter.Event, letter.Payload, letter.Attempts, letter.Error, sqliteTime(letter.CreatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteWebhookRepository) ListDeadLetters(ctx context.Context, webhookID, before string, limit int) ([]*DeadLetter, error) {
+	if err := checkID(webhookID); err != nil {
+		return nil, err
+	}
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+deadLetterColumns+` FROM webhook_dead_letters
+		WHERE webhook_id = ?1 AND (?2 = '' OR id < ?2) AND (?4 = '' OR tenant_id = ?4)
+		ORDER BY id DESC LIMIT ?3`, webhookID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	letters := []*DeadLetter{}
+	for rows.Next() {
+		letter, err := scanSQLiteDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+	return letters, rows.Err()
+}
+
+// SQLiteUserRepository stores users in the users table of a SQLite database and their API keys in api_keys
+type SQLiteUserRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteUserRepository creates a repository for the users in a database migrated with MigrateSQLite
+func NewSQLiteUserRepository(db *sql.DB) *SQLiteUserRepository {
+	return &SQLiteUserRepository{db: db}
+}
+
+// scanSQLiteUser reads a user selected with userColumns
+func scanSQLiteUser(row sqliteRow) (*User, error) {
+	user := &User{}
+	err := row.Scan(&user.ID, &user.Email, &user.DisplayName, jsonDest{&user.Roles}, timeDest{t: &user.CreatedAt},
+		timeDest{t: &user.UpdatedAt}, &user.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *SQLiteUserRepository) Create(ctx context.Context, user *User) (*User, error) {
+	id := user.ID
+	if id == "" {
+		id = newID()
+	}
+	created, err := scanSQLiteUser(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO users (`+userColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7) ON CONFLICT (id) DO NOTHING RETURNING `+userColumns,
+		id, user.Email, user.DisplayName, stringsColumn(user.Roles), sqliteTime(user.CreatedAt), sqliteTime(user.UpdatedAt),
+		tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		// Nothing is returned when the insert was skipped
+		return nil, ErrExists
+	}
+	return created, err
+}
+
+func (r *SQLiteUserRepository) Get(ctx context.Context, id string) (*User, error) {
+	return scanSQLiteUser(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+userColumns+` FROM users
+		WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`, id, tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteUserRepository) List(ctx context.Context) ([]*User, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+userColumns+` FROM users WHERE (?1 = '' OR tenant_id = ?1)
+		ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	users := []*User{}
+	for rows.Next() {
+		user, err := scanSQLiteUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *SQLiteUserRepository) Update(ctx context.Context, user *User) (*User, error) {
+	return scanSQLiteUser(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE users SET email = ?2, display_name = ?3, roles = ?4,
+		updated_at = ?5 WHERE id = ?1 AND (?6 = '' OR tenant_id = ?6) RETURNING `+userColumns,
+		user.ID, user.Email, user.DisplayName, stringsColumn(user.Roles), sqliteTime(user.UpdatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteUserRepository) Delete(ctx context.Context, id string) error {
+	// The API keys are deleted by the foreign key
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM users WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`,
+		id, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanSQLiteAPIKey reads a key selected with apiKeyColumns
+func scanSQLiteAPIKey(row sqliteRow) (*APIKey, error) {
+	key := &APIKey{}
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Hash, timeDest{t: &key.CreatedAt}, timeDest{ptr: &key.RotatedAt},
+		&key.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r *SQLiteUserRepository) CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error) {
+	return scanSQLiteAPIKey(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO api_keys (`+apiKeyColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, NULL, ?6) RETURNING `+apiKeyColumns,
+		newID(), key.UserID, key.Name, key.Hash, sqliteTime(key.CreatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteUserRepository) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE user_id = ?1 AND (?2 = '' OR tenant_id = ?2) ORDER BY id`, userID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	keys := []*APIKey{}
+	for rows.Next() {
+		key, err := scanSQLiteAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *SQLiteUserRepository) RotateAPIKey(ctx context.Context, userID, id, hash string, rotatedAt time.Time) (*APIKey, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSQLiteAPIKey(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE api_keys SET hash = ?3, rotated_at = ?4
+		WHERE id = ?1 AND user_id = ?2 AND (?5 = '' OR tenant_id = ?5) RETURNING `+apiKeyColumns,
+		id, userID, hash, sqliteTime(rotatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteUserRepository) DeleteAPIKey(ctx context.Context, userID, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM api_keys WHERE id = ?1 AND user_id = ?2 AND (?3 = '' OR tenant_id = ?3)`,
+		id, userID, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteUserRepository) APIKey(ctx context.Context, id string) (*APIKey, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSQLiteAPIKey(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`, id, tenant.FromContext(ctx)))
+}
+
+// SQLiteTeamRepository stores teams in the teams table of a SQLite database, with their members and roles as JSON arrays
+type SQLiteTeamRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTeamRepository creates a repository for the teams in a database migrated with MigrateSQLite
+func NewSQLiteTeamRepository(db *sql.DB) *SQLiteTeamRepository {
+	return &SQLiteTeamRepository{db: db}
+}
+
+// scanSQLiteTeam reads a team selected with teamColumns
+func scanSQLiteTeam(row sqliteRow) (*Team, error) {
+	team := &Team{}
+	err := row.Scan(&team.ID, &team.Name, jsonDest{&team.Members}, jsonDest{&team.Roles}, timeDest{t: &team.CreatedAt}, timeDest{t: &team.UpdatedAt}, &team.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (r *SQLiteTeamRepository) Create(ctx context.Context, team *Team) (*Team, error) {
+	return scanSQLiteTeam(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO teams (`+teamColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7) RETURNING `+teamColumns,
+		newID(), team.Name, stringsColumn(append([]string{}, team.Members...)), stringsColumn(append([]string{}, team.Roles...)), sqliteTime(team.CreatedAt), sqliteTime(team.UpdatedAt),
+		tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteTeamRepository) Get(ctx context.Context, id string) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSQLiteTeam(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+teamColumns+` FROM teams
+		WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`, id, tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteTeamRepository) List(ctx context.Context, member string) ([]*Team, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+teamColumns+` FROM teams
+		WHERE (?1 = '' OR instr(members, ?3) > 0) AND (?2 = '' OR tenant_id = ?2) ORDER BY id`,
+		member, tenant.FromContext(ctx), jsonElement(member))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	teams := []*Team{}
+	for rows.Next() {
+		team, err := scanSQLiteTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+func (r *SQLiteTeamRepository) Rename(ctx context.Context, id, name string, updatedAt time.Time) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSQLiteTeam(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE teams SET name = ?2, updated_at = ?3
+		WHERE id = ?1 AND (?4 = '' OR tenant_id = ?4) RETURNING `+teamColumns, id, name, sqliteTime(updatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteTeamRepository) SetRoles(ctx context.Context, id string, roles []string, updatedAt time.Time) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return scanSQLiteTeam(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE teams SET roles = ?2, updated_at = ?3
+		WHERE id = ?1 AND (?4 = '' OR tenant_id = ?4) RETURNING `+teamColumns,
+		id, stringsColumn(append([]string{}, roles...)), sqliteTime(updatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteTeamRepository) Delete(ctx context.Context, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM teams WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`,
+		id, tenant.FromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// updateMembers replaces the members of the team with id with the ones members returns for the stored team and returns
+// the team. Reading and writing the members happens in one transaction, so concurrent changes aren't lost. The update
+// time is only set when the members changed.
+func (r *SQLiteTeamRepository) updateMembers(ctx context.Context, id string, updatedAt time.Time, members func(*Team) []string) (*Team, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	var team *Team
+	err := NewSQLiteTransactor(r.db).InTransaction(ctx, func(ctx context.Context) error {
+		stored, err := r.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		changed := members(stored)
+		if len(changed) == len(stored.Members) {
+			team = stored
+			return nil
+		}
+		team, err = scanSQLiteTeam(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE teams SET members = ?2, updated_at = ?3
+			WHERE id = ?1 RETURNING `+teamColumns, id, stringsColumn(changed), sqliteTime(updatedAt)))
+		return err
+	})
+	return team, err
+}
+
+func (r *SQLiteTeamRepository) AddMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	return r.updateMembers(ctx, id, updatedAt, func(team *Team) []string {
+		if team.isMember(userID) {
+			return team.Members
+		}
+		return append(team.Members, userID)
+	})
+}
+
+func (r *SQLiteTeamRepository) RemoveMember(ctx context.Context, id, userID string, updatedAt time.Time) (*Team, error) {
+	return r.updateMembers(ctx, id, updatedAt, func(team *Team) []string { return team.withoutMember(userID) })
+}
+
+// SQLiteTemplateRepository stores job templates in the templates table of a SQLite database, with their parameters as
+// a JSON array
+type SQLiteTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTemplateRepository creates a repository for the templates in a database migrated with MigrateSQLite
+func NewSQLiteTemplateRepository(db *sql.DB) *SQLiteTemplateRepository {
+	return &SQLiteTemplateRepository{db: db}
+}
+
+// scanSQLiteTemplate reads a template selected with templateColumns
+func scanSQLiteTemplate(row sqliteRow) (*JobTemplate, error) {
+	template := &JobTemplate{}
+	err := row.Scan(&template.ID, &template.Name, &template.Description, &template.Owner, jsonDest{&template.Parameters},
+		&template.Job, &template.Version, timeDest{t: &template.CreatedAt}, timeDest{t: &template.UpdatedAt}, &template.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *SQLiteTemplateRepository) Create(ctx context.Context, template *JobTemplate) (*JobTemplate, error) {
+	return scanSQLiteTemplate(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO templates (`+templateColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, 1, ?7, ?8, ?9) RETURNING `+templateColumns,
+		newID(), template.Name, template.Description, template.Owner, parametersColumn(template.Parameters), template.Job,
+		sqliteTime(template.CreatedAt), sqliteTime(template.UpdatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteTemplateRepository) Get(ctx context.Context, id string, q Query) (*JobTemplate, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteTemplate(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+templateColumns+` FROM templates
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...))
+}
+
+func (r *SQLiteTemplateRepository) List(ctx context.Context, q Query) ([]*JobTemplate, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, tenant.FromContext(ctx)})
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+templateColumns+` FROM templates
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (`+teams+`)) AND (?2 = '' OR tenant_id = ?2) ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	templates := []*JobTemplate{}
+	for rows.Next() {
+		template, err := scanSQLiteTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (r *SQLiteTemplateRepository) Update(ctx context.Context, template *JobTemplate, q Query) (*JobTemplate, error) {
+	if err := checkID(template.ID); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{template.ID, template.Name, template.Description, template.Owner,
+		parametersColumn(template.Parameters), template.Job, sqliteTime(template.UpdatedAt), q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteTemplate(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE templates
+		SET name = ?2, description = ?3, owner = ?4, parameters = ?5, job = ?6, updated_at = ?7, version = version + 1
+		WHERE id = ?1 AND (?8 = '' OR owner = ?8 OR owner IN (`+teams+`)) AND (?9 = '' OR tenant_id = ?9)
+		RETURNING `+templateColumns, args...))
+}
+
+func (r *SQLiteTemplateRepository) Delete(ctx context.Context, id string, q Query) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM templates
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SQLiteCalendarRepository stores calendars in the calendars table of a SQLite database, with their windows and
+// holidays as JSON arrays
+type SQLiteCalendarRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteCalendarRepository creates a repository for the calendars in a database migrated with MigrateSQLite
+func NewSQLiteCalendarRepository(db *sql.DB) *SQLiteCalendarRepository {
+	return &SQLiteCalendarRepository{db: db}
+}
+
+// scanSQLiteCalendar reads a calendar selected with calendarColumns
+func scanSQLiteCalendar(row sqliteRow) (*Calendar, error) {
+	calendar := &Calendar{}
+	err := row.Scan(&calendar.ID, &calendar.Name, &calendar.Description, &calendar.Owner, &calendar.Blackouts.Timezone,
+		jsonDest{&calendar.Blackouts.Windows}, jsonDest{&calendar.Blackouts.Holidays}, timeDest{t: &calendar.CreatedAt},
+		timeDest{t: &calendar.UpdatedAt}, &calendar.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func (r *SQLiteCalendarRepository) Create(ctx context.Context, calendar *Calendar) (*Calendar, error) {
+	return scanSQLiteCalendar(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO calendars (`+calendarColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10) RETURNING `+calendarColumns,
+		newID(), calendar.Name, calendar.Description, calendar.Owner, calendar.Blackouts.Timezone, windowsColumn(calendar.Blackouts.Windows),
+		holidaysColumn(calendar.Blackouts.Holidays), sqliteTime(calendar.CreatedAt), sqliteTime(calendar.UpdatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteCalendarRepository) Get(ctx context.Context, id string, q Query) (*Calendar, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteCalendar(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+calendarColumns+` FROM calendars
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...))
+}
+
+// query returns the calendars a query selecting calendarColumns returns in order
+func (r *SQLiteCalendarRepository) query(ctx context.Context, query string, args ...interface{}) ([]*Calendar, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	calendars := []*Calendar{}
+	for rows.Next() {
+		calendar, err := scanSQLiteCalendar(rows)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, rows.Err()
+}
+
+func (r *SQLiteCalendarRepository) List(ctx context.Context, q Query) ([]*Calendar, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, tenant.FromContext(ctx)})
+	return r.query(ctx, `SELECT `+calendarColumns+` FROM calendars
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (`+teams+`)) AND (?2 = '' OR tenant_id = ?2) ORDER BY id`, args...)
+}
+
+func (r *SQLiteCalendarRepository) Update(ctx context.Context, calendar *Calendar, q Query) (*Calendar, error) {
+	if err := checkID(calendar.ID); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{calendar.ID, calendar.Name, calendar.Description, calendar.Owner,
+		calendar.Blackouts.Timezone, windowsColumn(calendar.Blackouts.Windows), holidaysColumn(calendar.Blackouts.Holidays),
+		sqliteTime(calendar.UpdatedAt), q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteCalendar(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE calendars
+		SET name = ?2, description = ?3, owner = ?4, timezone = ?5, windows = ?6, holidays = ?7, updated_at = ?8
+		WHERE id = ?1 AND (?9 = '' OR owner = ?9 OR owner IN (`+teams+`)) AND (?10 = '' OR tenant_id = ?10)
+		RETURNING `+calendarColumns, args...))
+}
+
+func (r *SQLiteCalendarRepository) Delete(ctx context.Context, id string, q Query) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM calendars
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteCalendarRepository) Blackouts(ctx context.Context, ids []string) ([]*scheduler.Blackouts, error) {
+	args := []interface{}{tenant.FromContext(ctx)}
+	placeholders := []string{}
+	for _, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	if len(placeholders) == 0 {
+		return []*scheduler.Blackouts{}, nil
+	}
+	calendars, err := r.query(ctx, `SELECT `+calendarColumns+` FROM calendars
+		WHERE id IN (`+strings.Join(placeholders, ", ")+`) AND (?1 = '' OR tenant_id = ?1) ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	blackouts := make([]*scheduler.Blackouts, 0, len(calendars))
+	for _, calendar := range calendars {
+		blackouts = append(blackouts, &calendar.Blackouts)
+	}
+	return blackouts, nil
+}
+
+// SQLiteSecretRepository stores secrets in the secrets table of a SQLite database, with their sealed values as blobs
+type SQLiteSecretRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSecretRepository creates a repository for the secrets in a database migrated with MigrateSQLite
+func NewSQLiteSecretRepository(db *sql.DB) *SQLiteSecretRepository {
+	return &SQLiteSecretRepository{db: db}
+}
+
+// scanSQLiteSecret reads a secret selected with secretColumns
+func scanSQLiteSecret(row sqliteRow) (*Secret, error) {
+	secret := &Secret{}
+	err := row.Scan(&secret.ID, &secret.Name, &secret.Description, &secret.Owner, &secret.Value, timeDest{t: &secret.CreatedAt},
+		timeDest{t: &secret.UpdatedAt}, &secret.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (r *SQLiteSecretRepository) Create(ctx context.Context, secret *Secret) (*Secret, error) {
+	created, err := scanSQLiteSecret(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO secrets (`+secretColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8) RETURNING `+secretColumns,
+		newID(), secret.Name, secret.Description, secret.Owner, secret.Value, sqliteTime(secret.CreatedAt), sqliteTime(secret.UpdatedAt),
+		tenant.FromContext(ctx)))
+	if err != nil {
+		return nil, sqliteUniqueViolation(err)
+	}
+	return created, nil
+}
+
+func (r *SQLiteSecretRepository) Get(ctx context.Context, id string, q Query) (*Secret, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteSecret(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+secretColumns+` FROM secrets
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...))
+}
+
+// query returns the secrets a query selecting secretColumns returns in order
+func (r *SQLiteSecretRepository) query(ctx context.Context, query string, args ...interface{}) ([]*Secret, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	secrets := []*Secret{}
+	for rows.Next() {
+		secret, err := scanSQLiteSecret(rows)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+func (r *SQLiteSecretRepository) List(ctx context.Context, q Query) ([]*Secret, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, tenant.FromContext(ctx)})
+	return r.query(ctx, `SELECT `+secretColumns+` FROM secrets
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (`+teams+`)) AND (?2 = '' OR tenant_id = ?2) ORDER BY id`, args...)
+}
+
+func (r *SQLiteSecretRepository) Update(ctx context.Context, secret *Secret, q Query) (*Secret, error) {
+	if err := checkID(secret.ID); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{secret.ID, secret.Description, secret.Value, sqliteTime(secret.UpdatedAt),
+		q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteSecret(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE secrets SET description = ?2, value = ?3, updated_at = ?4
+		WHERE id = ?1 AND (?5 = '' OR owner = ?5 OR owner IN (`+teams+`)) AND (?6 = '' OR tenant_id = ?6)
+		RETURNING `+secretColumns, args...))
+}
+
+func (r *SQLiteSecretRepository) Delete(ctx context.Context, id string, q Query) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM secrets
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteSecretRepository) Named(ctx context.Context, owner string, names []string) ([]*Secret, error) {
+	args := []interface{}{owner, tenant.FromContext(ctx)}
+	placeholders := []string{}
+	for _, name := range names {
+		args = append(args, name)
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	if len(placeholders) == 0 {
+		return []*Secret{}, nil
+	}
+	return r.query(ctx, `SELECT `+secretColumns+` FROM secrets
+		WHERE owner = ?1 AND name IN (`+strings.Join(placeholders, ", ")+`) AND (?2 = '' OR tenant_id = ?2) ORDER BY id`, args...)
+}
+
+// SQLiteChangeRepository stores the changes of jobs in the job_changes table of a SQLite database
+type SQLiteChangeRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteChangeRepository creates a repository for the changes in a database migrated with MigrateSQLite
+func NewSQLiteChangeRepository(db *sql.DB) *SQLiteChangeRepository {
+	return &SQLiteChangeRepository{db: db}
+}
+
+// scanSQLiteChange reads a change selected with changeColumns
+func scanSQLiteChange(row sqliteRow) (*Change, error) {
+	change := &Change{}
+	err := row.Scan(&change.ID, &change.JobID, &change.Owner, &change.Environment, &change.Request, &change.Status,
+		&change.RequestedBy, timeDest{t: &change.RequestedAt}, timeDest{t: &change.JobUpdatedAt}, &change.ReviewedBy,
+		timeDest{ptr: &change.ReviewedAt}, &change.Comment, &change.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (r *SQLiteChangeRepository) Create(ctx context.Context, change *Change) (*Change, error) {
+	return scanSQLiteChange(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO job_changes (`+changeColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, '', NULL, '', ?10) RETURNING `+changeColumns,
+		newID(), change.JobID, change.Owner, change.Environment, change.Request, change.Status, change.RequestedBy,
+		sqliteTime(change.RequestedAt), sqliteTime(change.JobUpdatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteChangeRepository) Get(ctx context.Context, id string, q Query) (*Change, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, q.Owner, tenant.FromContext(ctx)})
+	return scanSQLiteChange(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+changeColumns+` FROM job_changes
+		WHERE id = ?1 AND (?2 = '' OR owner = ?2 OR owner IN (`+teams+`)) AND (?3 = '' OR tenant_id = ?3)`, args...))
+}
+
+func (r *SQLiteChangeRepository) List(ctx context.Context, q Query, filter ChangeFilter) ([]*Change, error) {
+	teams, args := sqliteTeams(q.Teams, []interface{}{q.Owner, tenant.FromContext(ctx), filter.JobID, filter.Status})
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+changeColumns+` FROM job_changes
+		WHERE (?1 = '' OR owner = ?1 OR owner IN (`+teams+`)) AND (?2 = '' OR tenant_id = ?2) AND (?3 = '' OR job_id = ?3)
+			AND (?4 = '' OR status = ?4)
+		ORDER BY id DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := []*Change{}
+	for rows.Next() {
+		change, err := scanSQLiteChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (r *SQLiteChangeRepository) Review(ctx context.Context, id string, q Query, status, reviewer, comment string, reviewedAt time.Time) (*Change, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	teams, args := sqliteTeams(q.Teams, []interface{}{id, status, reviewer, comment, sqliteTime(reviewedAt), ChangePending,
+		q.Owner, tenant.FromContext(ctx)})
+	change, err := scanSQLiteChange(sqliteConn(ctx, r.db).QueryRowContext(ctx, `UPDATE job_changes
+		SET status = ?2, reviewed_by = ?3, comment = ?4, reviewed_at = ?5
+		WHERE id = ?1 AND status = ?6 AND (?7 = '' OR owner = ?7 OR owner IN (`+teams+`)) AND (?8 = '' OR tenant_id = ?8)
+		RETURNING `+changeColumns, args...))
+	if err == ErrNotFound {
+		// Either there is no such change or it was reviewed already
+		if _, err := r.Get(ctx, id, q); err != nil {
+			return nil, err
+		}
+		return nil, ErrReviewed
+	}
+	return change, err
+}
+
+// SQLiteRevisionRepository stores the revisions of jobs in the job_revisions table of a SQLite database
+type SQLiteRevisionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRevisionRepository creates a repository for the revisions in a database migrated with MigrateSQLite
+func NewSQLiteRevisionRepository(db *sql.DB) *SQLiteRevisionRepository {
+	return &SQLiteRevisionRepository{db: db}
+}
+
+// scanSQLiteRevision reads a revision selected with revisionColumns
+func scanSQLiteRevision(row sqliteRow) (*Revision, error) {
+	revision := &Revision{}
+	err := row.Scan(&revision.ID, &revision.JobID, &revision.Revision, &revision.Job, jsonDest{&revision.Changes},
+		&revision.CreatedBy, timeDest{t: &revision.CreatedAt}, &revision.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, sqliteUniqueViolation(err)
+	}
+	return revision, nil
+}
+
+func (r *SQLiteRevisionRepository) Create(ctx context.Context, revision *Revision) (*Revision, error) {
+	changes := revision.Changes
+	if changes == nil {
+		changes = []AuditChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return scanSQLiteRevision(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO job_revisions (`+revisionColumns+`)
+		SELECT ?1, ?2, COALESCE(MAX(revision), 0) + 1, ?3, ?4, ?5, ?6, ?7 FROM job_revisions WHERE tenant_id = ?7 AND job_id = ?2
+		RETURNING `+revisionColumns,
+		newID(), revision.JobID, revision.Job, string(data), revision.CreatedBy, sqliteTime(revision.CreatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteRevisionRepository) Get(ctx context.Context, jobID string, revision int64) (*Revision, error) {
+	return scanSQLiteRevision(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+revisionColumns+` FROM job_revisions
+		WHERE job_id = ?1 AND revision = ?2 AND (?3 = '' OR tenant_id = ?3)`, jobID, revision, tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteRevisionRepository) List(ctx context.Context, jobID string) ([]*Revision, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+revisionColumns+` FROM job_revisions
+		WHERE job_id = ?1 AND (?2 = '' OR tenant_id = ?2) ORDER BY revision DESC`, jobID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	revisions := []*Revision{}
+	for rows.Next() {
+		revision, err := scanSQLiteRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+// SQLiteOutboxRepository stores the outbox in the outbox_events table of a SQLite database
+type SQLiteOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteOutboxRepository creates a repository for the outbox in a database migrated with MigrateSQLite
+func NewSQLiteOutboxRepository(db *sql.DB) *SQLiteOutboxRepository {
+	return &SQLiteOutboxRepository{db: db}
+}
+
+// scanSQLiteOutboxEvent reads an event selected with outboxColumns
+func scanSQLiteOutboxEvent(row sqliteRow) (*OutboxEvent, error) {
+	event := &OutboxEvent{}
+	if err := row.Scan(&event.ID, &event.Type, &event.Key, &event.Payload, timeDest{t: &event.CreatedAt}, &event.Tenant); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (r *SQLiteOutboxRepository) Create(ctx context.Context, event *OutboxEvent) (*OutboxEvent, error) {
+	return scanSQLiteOutboxEvent(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO outbox_events (`+outboxColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6) RETURNING `+outboxColumns,
+		newID(), event.Type, event.Key, event.Payload, sqliteTime(event.CreatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteOutboxRepository) Pending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+outboxColumns+` FROM outbox_events ORDER BY id LIMIT ?1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	events := []*OutboxEvent{}
+	for rows.Next() {
+		event, err := scanSQLiteOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *SQLiteOutboxRepository) Delete(ctx context.Context, id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	_, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM outbox_events WHERE id = ?1 AND (?2 = '' OR tenant_id = ?2)`, id, tenant.FromContext(ctx))
+	return err
+}
+
+// SQLiteRunRetentionRepository stores run retention policies in the run_retention_policies table of a SQLite database
+type SQLiteRunRetentionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRunRetentionRepository creates a repository for the policies in a database migrated with MigrateSQLite
+func NewSQLiteRunRetentionRepository(db *sql.DB) *SQLiteRunRetentionRepository {
+	return &SQLiteRunRetentionRepository{db: db}
+}
+
+// scanSQLiteRetention reads a policy selected with retentionColumns
+func scanSQLiteRetention(row sqliteRow) (*RunRetentionPolicy, error) {
+	policy := &RunRetentionPolicy{}
+	var maxAge int64
+	err := row.Scan(&policy.JobID, &policy.KeepRuns, &maxAge, timeDest{t: &policy.UpdatedAt}, &policy.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	policy.MaxAge = time.Duration(maxAge)
+	return policy, nil
+}
+
+func (r *SQLiteRunRetentionRepository) Get(ctx context.Context, jobID string) (*RunRetentionPolicy, error) {
+	return scanSQLiteRetention(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+retentionColumns+` FROM run_retention_policies
+		WHERE tenant_id = ?1 AND job_id = ?2`, tenant.FromContext(ctx), jobID))
+}
+
+func (r *SQLiteRunRetentionRepository) List(ctx context.Context) ([]*RunRetentionPolicy, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+retentionColumns+` FROM run_retention_policies
+		WHERE (?1 = '' OR tenant_id = ?1) ORDER BY tenant_id, job_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	policies := []*RunRetentionPolicy{}
+	for rows.Next() {
+		policy, err := scanSQLiteRetention(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+func (r *SQLiteRunRetentionRepository) Set(ctx context.Context, policy *RunRetentionPolicy) (*RunRetentionPolicy, error) {
+	return scanSQLiteRetention(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO run_retention_policies (`+retentionColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5)
+		ON CONFLICT (tenant_id, job_id) DO UPDATE SET keep_runs = ?2, max_age = ?3, updated_at = ?4
+		RETURNING `+retentionColumns,
+		policy.JobID, policy.KeepRuns, int64(policy.MaxAge), sqliteTime(policy.UpdatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteRunRetentionRepository) Delete(ctx context.Context, jobID string) error {
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM run_retention_policies WHERE tenant_id = ?1 AND job_id = ?2`,
+		tenant.FromContext(ctx), jobID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SQLiteSLARepository stores SLAs in the slas table of a SQLite database and their breaches in sla_breaches
+type SQLiteSLARepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSLARepository creates a repository for the SLAs in a database migrated with MigrateSQLite
+func NewSQLiteSLARepository(db *sql.DB) *SQLiteSLARepository {
+	return &SQLiteSLARepository{db: db}
+}
+
+// scanSQLiteSLA reads an SLA selected with slaColumns
+func scanSQLiteSLA(row sqliteRow) (*SLA, error) {
+	sla := &SLA{}
+	var maxDuration int64
+	err := row.Scan(&sla.JobID, &maxDuration, &sla.Deadline, &sla.Timezone, timeDest{t: &sla.UpdatedAt}, &sla.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	sla.MaxDuration = time.Duration(maxDuration)
+	return sla, nil
+}
+
+func (r *SQLiteSLARepository) Get(ctx context.Context, jobID string) (*SLA, error) {
+	return scanSQLiteSLA(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+slaColumns+` FROM slas
+		WHERE tenant_id = ?1 AND job_id = ?2`, tenant.FromContext(ctx), jobID))
+}
+
+func (r *SQLiteSLARepository) List(ctx context.Context) ([]*SLA, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+slaColumns+` FROM slas
+		WHERE (?1 = '' OR tenant_id = ?1) ORDER BY tenant_id, job_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	slas := []*SLA{}
+	for rows.Next() {
+		sla, err := scanSQLiteSLA(rows)
+		if err != nil {
+			return nil, err
+		}
+		slas = append(slas, sla)
+	}
+	return slas, rows.Err()
+}
+
+func (r *SQLiteSLARepository) Set(ctx context.Context, sla *SLA) (*SLA, error) {
+	return scanSQLiteSLA(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO slas (`+slaColumns+`) VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+		ON CONFLICT (tenant_id, job_id) DO UPDATE SET max_duration = ?2, deadline = ?3, timezone = ?4, updated_at = ?5
+		RETURNING `+slaColumns,
+		sla.JobID, int64(sla.MaxDuration), sla.Deadline, sla.Timezone, sqliteTime(sla.UpdatedAt), tenant.FromContext(ctx)))
+}
+
+func (r *SQLiteSLARepository) Delete(ctx context.Context, jobID string) error {
+	result, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM slas WHERE tenant_id = ?1 AND job_id = ?2`,
+		tenant.FromContext(ctx), jobID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanSQLiteBreach reads a breach selected with breachColumns
+func scanSQLiteBreach(row sqliteRow) (*SLABreach, error) {
+	breach := &SLABreach{}
+	var duration int64
+	err := row.Scan(&breach.ID, &breach.JobID, &breach.Kind, &breach.RunID, &duration, timeDest{ptr: &breach.Deadline},
+		timeDest{t: &breach.DetectedAt}, &breach.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	breach.Duration = time.Duration(duration)
+	return breach, nil
+}
+
+func (r *SQLiteSLARepository) AddBreach(ctx context.Context, breach *SLABreach) (*SLABreach, error) {
+	added, err := scanSQLiteBreach(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO sla_breaches (`+breachColumns+`, breach_key)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9) ON CONFLICT (tenant_id, job_id, breach_key) DO NOTHING RETURNING `+breachColumns,
+		newID(), breach.JobID, breach.Kind, breach.RunID, int64(breach.Duration), sqliteNullTime(breach.Deadline),
+		sqliteTime(breach.DetectedAt), tenant.FromContext(ctx), breach.key()))
+	if err == ErrNotFound {
+		// Nothing is returned when the insert was skipped
+		return nil, ErrExists
+	}
+	return added, err
+}
+
+func (r *SQLiteSLARepository) ListBreaches(ctx context.Context, jobID, before string, limit int) ([]*SLABreach, error) {
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+breachColumns+` FROM sla_breaches
+		WHERE (?1 = '' OR job_id = ?1) AND (?2 = '' OR id < ?2) AND (?4 = '' OR tenant_id = ?4)
+		ORDER BY id DESC LIMIT ?3`, jobID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	breaches := []*SLABreach{}
+	for rows.Next() {
+		breach, err := scanSQLiteBreach(rows)
+		if err != nil {
+			return nil, err
+		}
+		breaches = append(breaches, breach)
+	}
+	return breaches, rows.Err()
+}
+
+func (r *SQLiteSLARepository) CountBreaches(ctx context.Context, jobID string, from, to time.Time) (int64, error) {
+	var n int64
+	err := sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM sla_breaches
+		WHERE job_id = ?1 AND detected_at >= ?2 AND detected_at < ?3 AND (?4 = '' OR tenant_id = ?4)`,
+		jobID, sqliteTime(from), sqliteTime(to), tenant.FromContext(ctx)).Scan(&n)
+	return n, err
+}
+
+// SQLiteAnomalyRepository stores the anomalies of runs in the run_anomalies table of a SQLite database
+type SQLiteAnomalyRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAnomalyRepository creates a repository for the anomalies in a database migrated with MigrateSQLite
+func NewSQLiteAnomalyRepository(db *sql.DB) *SQLiteAnomalyRepository {
+	return &SQLiteAnomalyRepository{db: db}
+}
+
+// scanSQLiteAnomaly reads an anomaly selected with anomalyColumns
+func scanSQLiteAnomaly(row sqliteRow) (*Anomaly, error) {
+	anomaly := &Anomaly{}
+	var duration, mean, stddev int64
+	err := row.Scan(&anomaly.ID, &anomaly.JobID, &anomaly.RunID, &duration, &mean, &stddev, &anomaly.Samples, &anomaly.Score,
+		timeDest{t: &anomaly.DetectedAt}, &anomaly.Tenant)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	anomaly.Duration, anomaly.Mean, anomaly.Stddev = time.Duration(duration), time.Duration(mean), time.Duration(stddev)
+	return anomaly, nil
+}
+
+func (r *SQLiteAnomalyRepository) Add(ctx context.Context, anomaly *Anomaly) (*Anomaly, error) {
+	added, err := scanSQLiteAnomaly(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO run_anomalies (`+anomalyColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10) ON CONFLICT (tenant_id, run_id) DO NOTHING RETURNING `+anomalyColumns,
+		newID(), anomaly.JobID, anomaly.RunID, int64(anomaly.Duration), int64(anomaly.Mean), int64(anomaly.Stddev), anomaly.Samples,
+		anomaly.Score, sqliteTime(anomaly.DetectedAt), tenant.FromContext(ctx)))
+	if err == ErrNotFound {
+		// Nothing is returned when the insert was skipped
+		return nil, ErrExists
+	}
+	return added, err
+}
+
+func (r *SQLiteAnomalyRepository) List(ctx context.Context, jobID, before string, limit int) ([]*Anomaly, error) {
+	if before != "" {
+		if err := checkID(before); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+anomalyColumns+` FROM run_anomalies
+		WHERE (?1 = '' OR job_id = ?1) AND (?2 = '' OR id < ?2) AND (?4 = '' OR tenant_id = ?4)
+		ORDER BY id DESC LIMIT ?3`, jobID, before, limit, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	anomalies := []*Anomaly{}
+	for rows.Next() {
+		anomaly, err := scanSQLiteAnomaly(rows)
+		if err != nil {
+			return nil, err
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+	return anomalies, rows.Err()
+}
+
+// SQLiteMaintenanceRepository stores the maintenance mode in the single row of the maintenance table of a SQLite
+// database
+type SQLiteMaintenanceRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteMaintenanceRepository creates a repository for the maintenance mode in a database migrated with
+// MigrateSQLite
+func NewSQLiteMaintenanceRepository(db *sql.DB) *SQLiteMaintenanceRepository {
+	return &SQLiteMaintenanceRepository{db: db}
+}
+
+// scanSQLiteMaintenance reads the maintenance mode selected with maintenanceColumns, disabled when there is no row
+func scanSQLiteMaintenance(row sqliteRow) (*scheduler.Maintenance, error) {
+	m := &scheduler.Maintenance{}
+	err := row.Scan(&m.Enabled, &m.Reason, &m.UpdatedBy, timeDest{t: &m.UpdatedAt})
+	if err == sql.ErrNoRows {
+		return &scheduler.Maintenance{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *SQLiteMaintenanceRepository) Maintenance(ctx context.Context) (*scheduler.Maintenance, error) {
+	return scanSQLiteMaintenance(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+maintenanceColumns+` FROM maintenance WHERE id = 1`))
+}
+
+func (r *SQLiteMaintenanceRepository) SetMaintenance(ctx context.Context, m *scheduler.Maintenance) (*scheduler.Maintenance, error) {
+	return scanSQLiteMaintenance(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO maintenance (id, `+maintenanceColumns+`)
+		VALUES (1, ?1, ?2, ?3, ?4)
+		ON CONFLICT (id) DO UPDATE SET enabled = ?1, reason = ?2, updated_by = ?3, updated_at = ?4
+		RETURNING `+maintenanceColumns,
+		m.Enabled, m.Reason, m.UpdatedBy, sqliteTime(m.UpdatedAt)))
+}
+
+// SQLiteEnvironmentRepository stores the environments of all tenants in the environments table of a SQLite database
+type SQLiteEnvironmentRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteEnvironmentRepository creates a repository for the environments in a database migrated with MigrateSQLite
+func NewSQLiteEnvironmentRepository(db *sql.DB) *SQLiteEnvironmentRepository {
+	return &SQLiteEnvironmentRepository{db: db}
+}
+
+// scanSQLiteEnvironment reads an environment selected with environmentColumns
+func scanSQLiteEnvironment(row sqliteRow) (*scheduler.Environment, error) {
+	e := &scheduler.Environment{}
+	if err := row.Scan(&e.Tenant, &e.Name, &e.Paused, &e.Reason, &e.UpdatedBy, timeDest{t: &e.UpdatedAt}, &e.Protected,
+		&e.ProtectedBy, timeDest{t: &e.ProtectedAt}); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *SQLiteEnvironmentRepository) Get(ctx context.Context, name string) (*scheduler.Environment, error) {
+	t := tenant.FromContext(ctx)
+	e, err := scanSQLiteEnvironment(sqliteConn(ctx, r.db).QueryRowContext(ctx, `SELECT `+environmentColumns+` FROM environments
+		WHERE tenant_id = ?1 AND name = ?2`, t, name))
+	if err == sql.ErrNoRows {
+		return &scheduler.Environment{Tenant: t, Name: name}, nil
+	}
+	return e, err
+}
+
+// list returns the environments selected by the conditions of where ordered by name and tenant
+func (r *SQLiteEnvironmentRepository) list(ctx context.Context, where string, args ...interface{}) ([]*scheduler.Environment, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, `SELECT `+environmentColumns+` FROM environments `+where+` ORDER BY name, tenant_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	environments := []*scheduler.Environment{}
+	for rows.Next() {
+		e, err := scanSQLiteEnvironment(rows)
+		if err != nil {
+			return nil, err
+		}
+		environments = append(environments, e)
+	}
+	return environments, rows.Err()
+}
+
+func (r *SQLiteEnvironmentRepository) List(ctx context.Context) ([]*scheduler.Environment, error) {
+	return r.list(ctx, `WHERE tenant_id = ?1`, tenant.FromContext(ctx))
+}
+
+func (r *SQLiteEnvironmentRepository) Set(ctx context.Context, env *scheduler.Environment) (*scheduler.Environment, error) {
+	return scanSQLiteEnvironment(sqliteConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO environments (`+environmentColumns+`)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+		ON CONFLICT (tenant_id, name) DO UPDATE SET paused = ?3, reason = ?4, updated_by = ?5, updated_at = ?6, protected = ?7,
+			protected_by = ?8, protected_at = ?9
+		RETURNING `+environmentColumns,
+		tenant.FromContext(ctx), env.Name, env.Paused, env.Reason, env.UpdatedBy, sqliteTime(env.UpdatedAt), env.Protected,
+		env.ProtectedBy, sqliteTime(env.ProtectedAt)))
+}
+
+func (r *SQLiteEnvironmentRepository) Environments(ctx context.Context) ([]*scheduler.Environment, error) {
+	return r.list(ctx, ``)
+}
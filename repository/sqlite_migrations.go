@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqliteMigrations bring an empty SQLite database up to the current schema, entry i creates schema version i+1.
+// Times are stored as text written by sqliteTime, arrays and objects as JSON. Never change a migration that was
+// released, append a new one instead.
+var sqliteMigrations = []string{
+	`CREATE TABLE jobs (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		owner TEXT NOT NULL,
+		description TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		schedule_cron TEXT,
+		schedule_interval INTEGER,
+		next_run_time TEXT,
+		handler TEXT NOT NULL DEFAULT '',
+		command TEXT NOT NULL DEFAULT '',
+		deleted_at TEXT,
+		status TEXT NOT NULL DEFAULT 'PENDING',
+		schedule_timezone TEXT,
+		retry_policy TEXT,
+		timeout INTEGER NOT NULL DEFAULT 0,
+		tenant_id TEXT NOT NULL DEFAULT '',
+		idempotency_key TEXT,
+		labels TEXT,
+		depends_on TEXT,
+		priority TEXT NOT NULL DEFAULT 'NORMAL',
+		notifications TEXT
+	);
+	CREATE INDEX jobs_owner_idx ON jobs (owner, id);
+	CREATE INDEX jobs_tenant_id_idx ON jobs (tenant_id, id);
+	CREATE INDEX jobs_next_run_time_idx ON jobs (next_run_time) WHERE next_run_time IS NOT NULL;
+	CREATE INDEX jobs_deleted_at_idx ON jobs (deleted_at) WHERE deleted_at IS NOT NULL;
+	CREATE UNIQUE INDEX jobs_owner_name_idx ON jobs (tenant_id, owner, name);
+	CREATE UNIQUE INDEX jobs_idempotency_key_idx ON jobs (tenant_id, owner, idempotency_key) WHERE idempotency_key IS NOT NULL;
+
+	CREATE TABLE job_runs (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		queued_at TEXT NOT NULL,
+		start_time TEXT,
+		end_time TEXT,
+		output TEXT NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT '',
+		attempt INTEGER NOT NULL DEFAULT 1,
+		retry_at TEXT,
+		timeout INTEGER NOT NULL DEFAULT 0,
+		duration INTEGER NOT NULL DEFAULT 0,
+		tenant_id TEXT NOT NULL DEFAULT '',
+		cycle_id TEXT,
+		priority TEXT NOT NULL DEFAULT 'NORMAL',
+		trigger TEXT NOT NULL DEFAULT '',
+		triggered_by TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX job_runs_job_id_idx ON job_runs (job_id, id);
+	CREATE INDEX job_runs_tenant_id_idx ON job_runs (tenant_id, id);
+	CREATE INDEX job_runs_retry_at_idx ON job_runs (retry_at) WHERE status = 'WAITING';
+	CREATE UNIQUE INDEX job_runs_cycle_idx ON job_runs (cycle_id, job_id, attempt);
+
+	CREATE TABLE leases (
+		name TEXT PRIMARY KEY,
+		holder TEXT NOT NULL,
+		expires_at TEXT NOT NULL
+	);
+
+	CREATE TABLE audit_entries (
+		id TEXT PRIMARY KEY,
+		time TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		method TEXT NOT NULL,
+		job_id TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		changes TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX audit_entries_actor_idx ON audit_entries (actor, id);
+	CREATE INDEX audit_entries_job_id_idx ON audit_entries (job_id, id);
+	CREATE INDEX audit_entries_owner_idx ON audit_entries (owner, id);
+	CREATE INDEX audit_entries_tenant_id_idx ON audit_entries (tenant_id, id);
+
+	CREATE TABLE webhooks (
+		id TEXT PRIMARY KEY,
+		owner TEXT NOT NULL,
+		url TEXT NOT NULL,
+		secret TEXT NOT NULL,
+		events TEXT,
+		created_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX webhooks_owner_idx ON webhooks (tenant_id, owner, id);
+	CREATE TABLE webhook_dead_letters (
+		id TEXT PRIMARY KEY,
+		webhook_id TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+		event TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX webhook_dead_letters_webhook_id_idx ON webhook_dead_letters (webhook_id, id);
+
+	CREATE TABLE outbox_events (
+		id TEXT PRIMARY KEY,
+		type TEXT NOT NULL,
+		key TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);`,
+
+	// Users and the API keys of machine clients, keys go away with their user
+	`CREATE TABLE users (
+		id TEXT PRIMARY KEY,
+		email TEXT NOT NULL DEFAULT '',
+		display_name TEXT NOT NULL DEFAULT '',
+		roles TEXT,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX users_created_at_idx ON users (tenant_id, created_at, id);
+	CREATE TABLE api_keys (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+		name TEXT NOT NULL,
+		hash TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		rotated_at TEXT,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX api_keys_user_id_idx ON api_keys (user_id, id);`,
+
+	// Teams sharing the jobs they own between their members
+	`CREATE TABLE teams (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		members TEXT NOT NULL DEFAULT '[]',
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);`,
+
+	// Roles members get from their teams
+	`ALTER TABLE teams ADD COLUMN roles TEXT NOT NULL DEFAULT '[]';`,
+
+	// Job templates and the template every job was created from
+	`CREATE TABLE templates (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		parameters TEXT NOT NULL DEFAULT '[]',
+		job TEXT NOT NULL,
+		version INTEGER NOT NULL DEFAULT 1,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX templates_owner_idx ON templates (owner, id);
+	ALTER TABLE jobs ADD COLUMN template TEXT;`,
+
+	// Archived jobs aren't listed by default, the index serves the listings of archived jobs
+	`ALTER TABLE jobs ADD COLUMN archived_at TEXT;
+	CREATE INDEX jobs_archived_idx ON jobs (archived_at) WHERE archived_at IS NOT NULL;`,
+
+	// How long the finished runs of the jobs of a tenant are kept, the policy without a job is the default
+	`CREATE TABLE run_retention_policies (
+		tenant_id TEXT NOT NULL DEFAULT '',
+		job_id TEXT NOT NULL DEFAULT '',
+		keep_runs INTEGER NOT NULL DEFAULT 0,
+		max_age INTEGER NOT NULL DEFAULT 0,
+		updated_at TEXT NOT NULL,
+		PRIMARY KEY (tenant_id, job_id)
+	);`,
+
+	// The SLAs of jobs and the breaches detected, every run and deadline of a job is breached once at most
+	`CREATE TABLE slas (
+		tenant_id TEXT NOT NULL DEFAULT '',
+		job_id TEXT NOT NULL,
+		max_duration INTEGER NOT NULL DEFAULT 0,
+		deadline TEXT NOT NULL DEFAULT '',
+		timezone TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL,
+		PRIMARY KEY (tenant_id, job_id)
+	);
+	CREATE TABLE sla_breaches (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		breach_key TEXT NOT NULL,
+		run_id TEXT NOT NULL DEFAULT '',
+		duration INTEGER NOT NULL DEFAULT 0,
+		deadline TEXT,
+		detected_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT '',
+		UNIQUE (tenant_id, job_id, breach_key)
+	);
+	CREATE INDEX sla_breaches_job_id_idx ON sla_breaches (job_id, id);`,
+
+	// The anomalies detected in the durations of runs, once per run at most
+	`CREATE TABLE run_anomalies (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		run_id TEXT NOT NULL,
+		duration INTEGER NOT NULL,
+		mean INTEGER NOT NULL,
+		stddev INTEGER NOT NULL,
+		samples INTEGER NOT NULL,
+		score REAL NOT NULL,
+		detected_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT '',
+		UNIQUE (tenant_id, run_id)
+	);
+	CREATE INDEX run_anomalies_job_id_idx ON run_anomalies (job_id, id);`,
+
+	// The worker that ran a run and what it cost, runs from before cost nothing
+	`ALTER TABLE job_runs ADD COLUMN worker TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_runs ADD COLUMN cost_per_second REAL NOT NULL DEFAULT 0;
+	ALTER TABLE job_runs ADD COLUMN cost REAL NOT NULL DEFAULT 0;`,
+
+	// Calendars of blackout windows and holidays and the calendars every job is attached to
+	`CREATE TABLE calendars (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		timezone TEXT NOT NULL DEFAULT '',
+		windows TEXT NOT NULL DEFAULT '[]',
+		holidays TEXT NOT NULL DEFAULT '[]',
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX calendars_owner_idx ON calendars (owner, id);
+	ALTER TABLE jobs ADD COLUMN blackout TEXT;`,
+
+	// What the executor does with runs of a job that overlap, jobs from before allow them
+	`ALTER TABLE jobs ADD COLUMN concurrency_policy TEXT NOT NULL DEFAULT 'ALLOW';`,
+
+	// What the scheduler does with the occurrences of a job it missed, jobs from before are fired once
+	`ALTER TABLE jobs ADD COLUMN catch_up_policy TEXT NOT NULL DEFAULT 'FIRE_ONCE';`,
+
+	// The maintenance mode of the scheduler, in a single row shared by all tenants
+	`CREATE TABLE maintenance (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		enabled INTEGER NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL
+	);`,
+
+	// The params handed to the handler of a job
+	`ALTER TABLE jobs ADD COLUMN params TEXT;`,
+
+	// Secrets the params of jobs refer to, with their sealed values
+	`CREATE TABLE secrets (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		value BLOB NOT NULL,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE UNIQUE INDEX secrets_owner_name_idx ON secrets (tenant_id, owner, name);`,
+
+	// The environments of jobs with their names unique per owner and environment, what jobs were promoted from and
+	// the environments that were paused
+	`ALTER TABLE jobs ADD COLUMN environment TEXT NOT NULL DEFAULT '';
+	ALTER TABLE jobs ADD COLUMN promotion TEXT;
+	DROP INDEX jobs_owner_name_idx;
+	CREATE UNIQUE INDEX jobs_owner_name_idx ON jobs (tenant_id, owner, environment, name);
+	CREATE TABLE environments (
+		tenant_id TEXT NOT NULL DEFAULT '',
+		name TEXT NOT NULL,
+		paused INTEGER NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL,
+		PRIMARY KEY (tenant_id, name)
+	);`,
+	// Environments where updates of jobs need a second person's approval, and the changes waiting for it
+	`ALTER TABLE environments ADD COLUMN protected INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE environments ADD COLUMN protected_by TEXT NOT NULL DEFAULT '';
+	ALTER TABLE environments ADD COLUMN protected_at TEXT NOT NULL DEFAULT '0001-01-01T00:00:00.000000000Z';
+	CREATE TABLE job_changes (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		owner TEXT NOT NULL DEFAULT '',
+		environment TEXT NOT NULL DEFAULT '',
+		request BLOB NOT NULL,
+		status TEXT NOT NULL,
+		requested_by TEXT NOT NULL DEFAULT '',
+		requested_at TEXT NOT NULL,
+		job_updated_at TEXT NOT NULL,
+		reviewed_by TEXT NOT NULL DEFAULT '',
+		reviewed_at TEXT,
+		comment TEXT NOT NULL DEFAULT '',
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX job_changes_job_id_idx ON job_changes (tenant_id, job_id);`,
+	// Versions of jobs before their updates, numbered per job
+	`CREATE TABLE job_revisions (
+		id TEXT PRIMARY KEY,
+		job_id TEXT NOT NULL,
+		revision INTEGER NOT NULL,
+		job BLOB NOT NULL,
+		changes TEXT NOT NULL,
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		tenant_id TEXT NOT NULL DEFAULT ''
+	);
+	CREATE UNIQUE INDEX job_revisions_revision_idx ON job_revisions (tenant_id, job_id, revision);`,
+}
+
+// MigrateSQLite applies all migrations the database doesn't have yet in a single transaction, which also keeps
+// other processes from migrating at the same time
+func MigrateSQLite(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return err
+	}
+	var version int
+	if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return err
+	}
+	for i := version; i < len(sqliteMigrations); i++ {
+		if _, err := tx.ExecContext(ctx, sqliteMigrations[i]); err != nil {
+			return fmt.Errorf("migration %d failed: %v", i+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, applied_at) VALUES (?1, ?2)", i+1,
+			sqliteTime(time.Now())); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
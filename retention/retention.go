@@ -0,0 +1,81 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Store is the part of the job storage the purger needs
+type Store interface {
+	// Purge permanently removes the jobs deleted before deletedBefore and returns how many there were
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// ExpireIdempotencyKeys removes the idempotency keys of the jobs created before createdBefore
+	ExpireIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error)
+}
+
+// Purger periodically removes jobs that were deleted longer than the retention window ago and expires the idempotency
+// keys of jobs created longer than the key retention ago
+type Purger struct {
+	jobs         Store
+	retention    time.Duration
+	keyRetention time.Duration
+	interval     time.Duration
+	logger       *zap.Logger
+}
+
+// New creates a Purger removing jobs deleted more than retention ago and expiring idempotency keys older than
+// keyRetention every interval. A zero retention keeps deleted jobs forever.
+func New(jobs Store, retention, keyRetention, interval time.Duration, logger *zap.Logger) *Purger {
+	return &Purger{
+		jobs:         jobs,
+		retention:    retention,
+		keyRetention: keyRetention,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// Run blocks and purges deleted jobs until ctx is cancelled
+func (p *Purger) Run(ctx context.Context) {
+	p.logger.Info("Purger started", zap.Duration("retention", p.retention), zap.Duration("key_retention", p.keyRetention),
+		zap.Duration("interval", p.interval))
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if p.retention > 0 {
+			p.purge(ctx)
+		}
+		p.expireKeys(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge removes the jobs whose retention window has passed
+func (p *Purger) purge(ctx context.Context) {
+	purged, err := p.jobs.Purge(ctx, time.Now().UTC().Add(-p.retention))
+	if err != nil {
+		p.logger.Error("Could not purge deleted jobs", zap.Error(err))
+		return
+	}
+	if purged > 0 {
+		p.logger.Info("Purged deleted jobs", zap.Int64("count", purged))
+	}
+}
+
+// expireKeys frees the idempotency keys whose retention window has passed
+func (p *Purger) expireKeys(ctx context.Context) {
+	expired, err := p.jobs.ExpireIdempotencyKeys(ctx, time.Now().UTC().Add(-p.keyRetention))
+	if err != nil {
+		p.logger.Error("Could not expire idempotency keys", zap.Error(err))
+		return
+	}
+	if expired > 0 {
+		p.logger.Info("Expired idempotency keys", zap.Int64("count", expired))
+	}
+}
@@ -0,0 +1,111 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// RunStore is the part of the run storage the janitor needs
+type RunStore interface {
+	// Prune removes the finished runs past a retention policy like repository.RunRepository.Prune
+	Prune(ctx context.Context, jobID string, except []string, keep int, maxAge time.Duration) (int64, error)
+}
+
+// PolicyStore is the part of the retention policy storage the janitor needs
+type PolicyStore interface {
+	// List returns the policies of the tenant of ctx, of all tenants when ctx has none
+	List(ctx context.Context) ([]*repository.RunRetentionPolicy, error)
+}
+
+// EnforceRuns applies the run retention policies of the tenant of ctx and returns how many runs it removed. The
+// default of the tenant applies to every job without a policy of its own, without a default their runs are kept.
+func EnforceRuns(ctx context.Context, runs RunStore, policies PolicyStore) (int64, error) {
+	all, err := policies.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	t := tenant.FromContext(ctx)
+	defaults := &repository.RunRetentionPolicy{}
+	var own []*repository.RunRetentionPolicy
+	var except []string
+	for _, policy := range all {
+		// Without a tenant in ctx the policies of every tenant are listed
+		if policy.Tenant != t {
+			continue
+		}
+		if policy.JobID == "" {
+			defaults = policy
+		} else {
+			own = append(own, policy)
+			except = append(except, policy.JobID)
+		}
+	}
+	// The default also runs without limits, so runs marked to expire by a deleted default aren't removed anymore
+	pruned, err := runs.Prune(ctx, "", except, defaults.KeepRuns, defaults.MaxAge)
+	if err != nil {
+		return pruned, err
+	}
+	for _, policy := range own {
+		n, err := runs.Prune(ctx, policy.JobID, nil, policy.KeepRuns, policy.MaxAge)
+		pruned += n
+		if err != nil {
+			return pruned, err
+		}
+	}
+	return pruned, nil
+}
+
+// RunJanitor periodically removes the finished runs past the retention policies of their tenant
+type RunJanitor struct {
+	runs     RunStore
+	policies PolicyStore
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewRunJanitor creates a janitor enforcing policies on runs every interval
+func NewRunJanitor(runs RunStore, policies PolicyStore, interval time.Duration, logger *zap.Logger) *RunJanitor {
+	return &RunJanitor{runs: runs, policies: policies, interval: interval, logger: logger}
+}
+
+// Run blocks and removes runs until ctx is cancelled
+func (j *RunJanitor) Run(ctx context.Context) {
+	j.logger.Info("Run janitor started", zap.Duration("interval", j.interval))
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		j.prune(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune enforces the policies of every tenant that has one, the runs without a tenant always
+func (j *RunJanitor) prune(ctx context.Context) {
+	policies, err := j.policies.List(ctx)
+	if err != nil {
+		j.logger.Error("Could not list run retention policies", zap.Error(err))
+		return
+	}
+	tenants := map[string]bool{"": true}
+	for _, policy := range policies {
+		tenants[policy.Tenant] = true
+	}
+	for t := range tenants {
+		pruned, err := EnforceRuns(tenant.NewContext(ctx, t), j.runs, j.policies)
+		if err != nil {
+			j.logger.Error("Could not prune runs", zap.String("tenant", t), zap.Error(err))
+			continue
+		}
+		if pruned > 0 {
+			j.logger.Info("Pruned runs", zap.String("tenant", t), zap.Int64("count", pruned))
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MaxWindowDuration is the longest a recurring blackout window may last
+const MaxWindowDuration = 7 * 24 * time.Hour
+
+// maxBlackoutSteps limits how many adjacent or overlapping blackout windows BlackoutEnd joins, a run deferred to the
+// end of the last one is deferred again when it's still blacked out
+const maxBlackoutSteps = 100
+
+// HolidayLayout is the layout of the dates of holidays
+const HolidayLayout = "2006-01-02"
+
+// Window is a blackout window from Start until End, or recurring for Duration every time Cron fires
+type Window struct {
+	Start time.Time `bson:"start,omitempty" json:"start,omitempty"`
+	End   time.Time `bson:"end,omitempty" json:"end,omitempty"`
+	// Cron is evaluated in the timezone of the calendar of the window
+	Cron        string        `bson:"cron,omitempty" json:"cron,omitempty"`
+	Duration    time.Duration `bson:"duration,omitempty" json:"duration,omitempty"`
+	Description string        `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+// Blackouts are the stored form of the blackout windows and holidays of a calendar
+type Blackouts struct {
+	// Timezone is the IANA name of the zone recurring windows and holidays are evaluated in, UTC when empty
+	Timezone string   `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	Windows  []Window `bson:"windows,omitempty" json:"windows,omitempty"`
+	// Holidays are dates like 2006-01-02 that are blacked out all day
+	Holidays []string `bson:"holidays,omitempty" json:"holidays,omitempty"`
+}
+
+// Validate checks that every window and holiday is complete and can be parsed
+func (b *Blackouts) Validate() error {
+	if b.Timezone != "" {
+		if _, err := time.LoadLocation(b.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", b.Timezone)
+		}
+	}
+	for i, w := range b.Windows {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("window %d: %v", i, err)
+		}
+	}
+	for _, h := range b.Holidays {
+		if _, err := time.Parse(HolidayLayout, h); err != nil {
+			return fmt.Errorf("holiday %q is not a date like %s", h, HolidayLayout)
+		}
+	}
+	return nil
+}
+
+// Validate checks that exactly one of a fixed and a recurring window is set
+func (w *Window) Validate() error {
+	fixed := !w.Start.IsZero() || !w.End.IsZero()
+	recurring := w.Cron != "" || w.Duration != 0
+	switch {
+	case fixed && recurring:
+		return errors.New("window must not set both start and end and cron and duration")
+	case fixed:
+		if w.Start.IsZero() || w.End.IsZero() {
+			return errors.New("window must set both start and end")
+		}
+		if !w.End.After(w.Start) {
+			return errors.New("end must be after start")
+		}
+	case recurring:
+		if w.Cron == "" || w.Duration == 0 {
+			return errors.New("recurring window must set both cron and duration")
+		}
+		if _, err := cron.ParseStandard(w.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression: %v", err)
+		}
+		if w.Duration < time.Minute || w.Duration > MaxWindowDuration {
+			return fmt.Errorf("duration must be between %v and %v", time.Minute, MaxWindowDuration)
+		}
+	default:
+		return errors.New("window must set either start and end or cron and duration")
+	}
+	return nil
+}
+
+// location returns the zone recurring windows and holidays are evaluated in, the blackouts must be valid
+func (b *Blackouts) location() *time.Location {
+	if b.Timezone == "" {
+		return time.UTC
+	}
+	loc, _ := time.LoadLocation(b.Timezone)
+	return loc
+}
+
+// end returns when the latest ending window or holiday t falls into ends, t itself when it doesn't fall into one.
+// Windows include their start and exclude their end.
+func (b *Blackouts) end(t time.Time) time.Time {
+	end := t
+	extend := func(from, to time.Time) {
+		if !t.Before(from) && t.Before(to) && to.After(end) {
+			end = to
+		}
+	}
+	loc := b.location()
+	for _, w := range b.Windows {
+		if w.Cron == "" {
+			extend(w.Start, w.End)
+			continue
+		}
+		// Validate already made sure this parses. Only the times it fires within a duration before t can cover t.
+		sched, _ := cron.ParseStandard(w.Cron)
+		for fire := sched.Next(t.Add(-w.Duration).In(loc)); !fire.IsZero() && !fire.After(t); fire = sched.Next(fire) {
+			extend(fire, fire.Add(w.Duration))
+		}
+	}
+	for _, h := range b.Holidays {
+		day, err := time.ParseInLocation(HolidayLayout, h, loc)
+		if err != nil {
+			continue
+		}
+		extend(day, day.AddDate(0, 0, 1))
+	}
+	return end
+}
+
+// BlackoutEnd returns when the blackout t falls into ends, joining the adjacent and overlapping windows and holidays
+// of all blackouts. It returns the zero time when t isn't blacked out.
+func BlackoutEnd(blackouts []*Blackouts, t time.Time) time.Time {
+	end := t
+	for i := 0; i < maxBlackoutSteps; i++ {
+		next := end
+		for _, b := range blackouts {
+			if e := b.end(end); e.After(next) {
+				next = e
+			}
+		}
+		if next.Equal(end) {
+			break
+		}
+		end = next
+	}
+	if end.Equal(t) {
+		return time.Time{}
+	}
+	return end.UTC()
+}
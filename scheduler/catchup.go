@@ -0,0 +1,45 @@
+package scheduler
+
+import "time"
+
+// Catch-up policies, they tell the scheduler what to do with the occurrences of a job it missed before it started
+const (
+	// CatchUpFireOnce fires a job once for all its missed occurrences
+	CatchUpFireOnce = "FIRE_ONCE"
+	// CatchUpFireAll fires a job for every missed occurrence, one per poll and at most MaxCatchUpRuns
+	CatchUpFireAll = "FIRE_ALL"
+	// CatchUpSkip doesn't fire a job for its missed occurrences
+	CatchUpSkip = "SKIP"
+)
+
+// MaxCatchUpRuns is the largest number of missed occurrences besides the first one a job is fired for with
+// CatchUpFireAll, older ones are skipped
+const MaxCatchUpRuns = 100
+
+// nextMissed returns the first of the last MaxCatchUpRuns occurrences of spec after from and before until, the zero
+// time when there are none. It is called on every poll while a job catches up, so it parses the spec only once and
+// doesn't walk the occurrences of intervals that are skipped anyway.
+func nextMissed(spec *Spec, from, until time.Time) time.Time {
+	if spec.Validate() != nil {
+		return time.Time{}
+	}
+	// Intervals fire every Interval after from, all but the last MaxCatchUpRuns+1 of them are skipped at once. At least
+	// MaxCatchUpRuns occurrences remain before until, so the ring below ends up the same.
+	if spec.Interval != 0 {
+		if n := int64(until.Sub(from) / spec.Interval); n > MaxCatchUpRuns+1 {
+			from = from.Add(time.Duration(n-MaxCatchUpRuns-1) * spec.Interval)
+		}
+	}
+	next := spec.stepper()
+	// The last occurrences are kept in a ring, n counts all of them
+	var missed [MaxCatchUpRuns]time.Time
+	n := 0
+	for t := next(from); !t.IsZero() && t.Before(until); t = next(t) {
+		missed[n%MaxCatchUpRuns] = t
+		n++
+	}
+	if n <= MaxCatchUpRuns {
+		return missed[0]
+	}
+	return missed[n%MaxCatchUpRuns]
+}
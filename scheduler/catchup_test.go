@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+// walkMissed finds the occurrence nextMissed returns by stepping through every occurrence with Next
+func walkMissed(spec *Spec, from, until time.Time) time.Time {
+	var missed []time.Time
+	for t, err := spec.Next(from); err == nil && !t.IsZero() && t.Before(until); t, err = spec.Next(t) {
+		missed = append(missed, t)
+	}
+	if len(missed) == 0 {
+		return time.Time{}
+	}
+	if len(missed) > MaxCatchUpRuns {
+		missed = missed[len(missed)-MaxCatchUpRuns:]
+	}
+	return missed[0]
+}
+
+func TestNextMissed(t *testing.T) {
+	from := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		spec *Spec
+		// offset moves from
+		offset time.Duration
+		until  time.Time
+	}{
+		{"nothing missed", &Spec{Interval: time.Hour}, 0, from.Add(time.Hour)},
+		{"one missed", &Spec{Interval: time.Hour}, 0, from.Add(90 * time.Minute)},
+		{"fewer than the limit", &Spec{Interval: time.Minute}, 0, from.Add(50 * time.Minute)},
+		{"exactly the limit", &Spec{Interval: time.Minute}, 0, from.Add((MaxCatchUpRuns + 1) * time.Minute)},
+		{"one more than the limit", &Spec{Interval: time.Minute}, 0, from.Add((MaxCatchUpRuns+1)*time.Minute + time.Second)},
+		{"more than the limit", &Spec{Interval: time.Second}, 0, from.Add(time.Hour + 500*time.Millisecond)},
+		{"interval from a time between milliseconds", &Spec{Interval: 1500 * time.Millisecond}, 123456 * time.Nanosecond, from.Add(time.Hour)},
+		{"cron", &Spec{Cron: "*/5 * * * *"}, 0, from.Add(3 * time.Hour)},
+		{"cron in a timezone", &Spec{Cron: "0 * * * *", Timezone: "Europe/Berlin"}, 0, from.Add(30 * 24 * time.Hour)},
+		{"cron without occurrences", &Spec{Cron: "0 0 30 2 *"}, 0, from.Add(24 * time.Hour)},
+		{"invalid spec", &Spec{}, 0, from.Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := from.Add(tt.offset)
+			want := walkMissed(tt.spec, start, tt.until)
+			if got := nextMissed(tt.spec, start, tt.until); !got.Equal(want) {
+				t.Errorf("nextMissed() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNextMissedLongOutage(t *testing.T) {
+	// Walking every second of ten years would take minutes, the skipped occurrences of intervals aren't walked
+	from := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := from.Add(10 * 365 * 24 * time.Hour)
+	spec := &Spec{Interval: time.Second}
+	want := until.Add(-MaxCatchUpRuns * time.Second)
+	if got := nextMissed(spec, from, until); !got.Equal(want) {
+		t.Errorf("nextMissed() = %v, want %v", got, want)
+	}
+
+	// Cron expressions are parsed once, a year of minutes is walked quickly
+	until = from.Add(365 * 24 * time.Hour)
+	spec = &Spec{Cron: "* * * * *"}
+	want = until.Add(-MaxCatchUpRuns * time.Minute)
+	started := time.Now()
+	if got := nextMissed(spec, from, until); !got.Equal(want) {
+		t.Errorf("nextMissed() = %v, want %v", got, want)
+	}
+	if took := time.Since(started); took > 5*time.Second {
+		t.Errorf("nextMissed() took %v for a year of minutes", took)
+	}
+}
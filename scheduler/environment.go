@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Environment is the state of an environment like dev, staging or prod of a tenant. The scheduler doesn't fire the
+// jobs of an environment while it's paused, updates of the jobs of a protected one need a second person's approval.
+type Environment struct {
+	Tenant string
+	Name   string
+	Paused bool
+	// Reason tells users why the environment is paused
+	Reason string
+	// UpdatedBy is the subject of the admin who last paused or resumed it, empty without authentication
+	UpdatedBy string
+	UpdatedAt time.Time
+	Protected bool
+	// ProtectedBy is the subject of the admin who last protected or unprotected it, at ProtectedAt
+	ProtectedBy string
+	ProtectedAt time.Time
+}
+
+// EnvironmentStore is the part of the environment storage the scheduler needs
+type EnvironmentStore interface {
+	// Environments returns the environments of all tenants that were ever paused or protected, environments that
+	// weren't are left out
+	Environments(ctx context.Context) ([]*Environment, error)
+}
+
+// environmentKey identifies the environment name of tenant in the environments read by a tick
+func environmentKey(tenant, name string) string {
+	return tenant + "/" + name
+}
@@ -0,0 +1,22 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Maintenance is the maintenance mode of the scheduler, it doesn't fire jobs or retry runs while it's enabled
+type Maintenance struct {
+	Enabled bool
+	// Reason tells users why the scheduler is paused
+	Reason string
+	// UpdatedBy is the subject of the admin who last enabled or disabled it, empty without authentication
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// MaintenanceStore is the part of the maintenance storage the scheduler needs
+type MaintenanceStore interface {
+	// Maintenance returns the maintenance mode, disabled when it was never set
+	Maintenance(ctx context.Context) (*Maintenance, error)
+}
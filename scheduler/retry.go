@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Limits and defaults of retry policies
+const (
+	// MaxAttempts is the largest number of attempts a policy may allow, including the first one
+	MaxAttempts = 10
+	// DefaultInitialBackoff is used by policies that don't set an initial backoff
+	DefaultInitialBackoff = time.Second
+	// DefaultMultiplier is used by policies that don't set a multiplier
+	DefaultMultiplier = 2.0
+	// maxBackoff caps the backoff of policies that don't set a maximum
+	maxBackoff = 24 * time.Hour
+)
+
+// RetryPolicy is the stored form of a job's retry policy, it tells how often and when failed runs are attempted again
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first run
+	MaxAttempts    int           `bson:"max_attempts" json:"max_attempts"`
+	InitialBackoff time.Duration `bson:"initial_backoff,omitempty" json:"initial_backoff,omitempty"`
+	MaxBackoff     time.Duration `bson:"max_backoff,omitempty" json:"max_backoff,omitempty"`
+	Multiplier     float64       `bson:"multiplier,omitempty" json:"multiplier,omitempty"`
+	// Jitter is the fraction the backoff is randomly shortened or lengthened by, between 0 and 1
+	Jitter float64 `bson:"jitter,omitempty" json:"jitter,omitempty"`
+}
+
+// Validate checks that the policy is within the limits
+func (p *RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 || p.MaxAttempts > MaxAttempts {
+		return fmt.Errorf("max attempts must be between 1 and %d", MaxAttempts)
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return errors.New("backoff must not be negative")
+	}
+	if p.MaxBackoff != 0 && p.MaxBackoff < p.initialBackoff() {
+		return errors.New("max backoff must not be shorter than the initial backoff")
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return errors.New("multiplier must be at least 1")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return errors.New("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+func (p *RetryPolicy) initialBackoff() time.Duration {
+	if p.InitialBackoff == 0 {
+		return DefaultInitialBackoff
+	}
+	return p.InitialBackoff
+}
+
+// Retries reports whether another attempt follows the given failed attempt, attempts count from 1
+func (p *RetryPolicy) Retries(attempt int) bool {
+	return p != nil && attempt < p.MaxAttempts
+}
+
+// Backoff returns how long to wait after the given failed attempt. The initial backoff grows by the multiplier
+// with every attempt up to the max backoff and is then spread by the jitter, so failed runs don't retry in lockstep.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultMultiplier
+	}
+	limit := p.MaxBackoff
+	if limit == 0 {
+		limit = maxBackoff
+	}
+	backoff := float64(p.initialBackoff()) * math.Pow(multiplier, float64(attempt-1))
+	if backoff > float64(limit) {
+		backoff = float64(limit)
+	}
+	backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(backoff).Truncate(time.Millisecond)
+}
@@ -0,0 +1,323 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// DueJob is the part of a stored job the scheduler needs to fire it
+type DueJob struct {
+	ID          string
+	Name        string
+	Owner       string
+	Tenant      string
+	Schedule    *Spec
+	NextRunTime time.Time
+	// Priority is the priority of the job, its run is queued with it
+	Priority string
+	// ConcurrencyPolicy tells the executor whether the run may overlap with the runs of the job still running
+	ConcurrencyPolicy string
+	// CatchUpPolicy is one of the CatchUp constants, it tells what happens to the occurrences missed before the
+	// scheduler started
+	CatchUpPolicy string
+	// Calendars are the IDs of the calendars whose blackouts the job doesn't run in
+	Calendars []string
+	// DeferBlackouts runs an occurrence that falls into a blackout once it ended instead of skipping it
+	DeferBlackouts bool
+	// Environment is the environment the job belongs to, empty when it belongs to none
+	Environment string
+}
+
+// Store is the part of the job storage the scheduler needs
+type Store interface {
+	// DueJobs returns the scheduled jobs whose next run time is not after now
+	DueJobs(ctx context.Context, now time.Time) ([]*DueJob, error)
+	// ClaimNextRun moves the next run time of a job from prev to next and reports false if it wasn't prev anymore
+	ClaimNextRun(ctx context.Context, id string, prev, next time.Time) (bool, error)
+}
+
+// CalendarStore is the part of the calendar storage the scheduler needs
+type CalendarStore interface {
+	// Blackouts returns the blackouts of the calendars with ids, the ones of calendars that don't exist are left out
+	Blackouts(ctx context.Context, ids []string) ([]*Blackouts, error)
+}
+
+// FireFunc is called for every job that became due
+type FireFunc func(ctx context.Context, job *DueJob)
+
+// DueRetry is an attempt of a failed run whose backoff has passed
+type DueRetry struct {
+	RunID   string
+	JobID   string
+	Tenant  string
+	Attempt int
+	RetryAt time.Time
+}
+
+// RetryStore is the part of the run storage the scheduler needs
+type RetryStore interface {
+	// DueRetries returns the attempts waiting to be retried whose retry time is not after now
+	DueRetries(ctx context.Context, now time.Time) ([]*DueRetry, error)
+}
+
+// RetryFunc is called for every attempt that became due, it must claim the attempt so it only runs once
+type RetryFunc func(ctx context.Context, retry *DueRetry)
+
+// Scheduler periodically looks for jobs whose next_run_time has passed, fires them and stores their next run time.
+// It also hands failed runs whose backoff has passed back for another attempt.
+type Scheduler struct {
+	jobs         Store
+	retries      RetryStore
+	calendars    CalendarStore
+	maintenance  MaintenanceStore
+	environments EnvironmentStore
+	pollInterval time.Duration
+	fire         FireFunc
+	retry        RetryFunc
+	logger       *zap.Logger
+	// started is when Run was called or maintenance ended, occurrences before it were missed
+	started time.Time
+	// paused tells whether the last tick found maintenance enabled
+	paused bool
+	// pausedEnvironments holds the environments the last tick found paused by their environmentKey
+	pausedEnvironments map[string]*Environment
+
+	mu sync.RWMutex
+	// active tells whether Run is running, ticked is when its last tick finished
+	active bool
+	ticked time.Time
+}
+
+// minStaleAfter is how long a tick may take at least before the scheduler counts as unhealthy
+const minStaleAfter = time.Minute
+
+// New creates a Scheduler polling the job and run store every pollInterval, the blackouts of jobs are read from
+// calendars. It pauses while the maintenance mode in maintenance is enabled, and doesn't fire the jobs of the
+// environments paused in environments.
+func New(jobs Store, retries RetryStore, calendars CalendarStore, maintenance MaintenanceStore, environments EnvironmentStore,
+	pollInterval time.Duration, fire FireFunc, retry RetryFunc, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		jobs:               jobs,
+		retries:            retries,
+		calendars:          calendars,
+		maintenance:        maintenance,
+		environments:       environments,
+		pollInterval:       pollInterval,
+		fire:               fire,
+		retry:              retry,
+		logger:             logger,
+		pausedEnvironments: map[string]*Environment{},
+	}
+}
+
+// Run blocks and fires due jobs until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	s.started = time.Now().UTC()
+	s.logger.Info("Scheduler started", zap.Duration("poll_interval", s.pollInterval))
+	s.setTicked()
+	defer s.setStopped()
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		s.tick(ctx)
+		s.setTicked()
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setTicked records that Run is running and finished a tick just now
+func (s *Scheduler) setTicked() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = true
+	s.ticked = time.Now()
+}
+
+// setStopped records that Run returned
+func (s *Scheduler) setStopped() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = false
+}
+
+// Check returns an error when the last tick of a running scheduler finished longer than three poll intervals ago,
+// at least a minute, as when looking for due jobs hangs. A scheduler that isn't running, like on a replica that isn't
+// the leader, is healthy.
+func (s *Scheduler) Check(context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.active {
+		return nil
+	}
+	staleAfter := 3 * s.pollInterval
+	if staleAfter < minStaleAfter {
+		staleAfter = minStaleAfter
+	}
+	if since := time.Since(s.ticked); since > staleAfter {
+		return fmt.Errorf("last poll for due jobs finished %v ago", since.Truncate(time.Second))
+	}
+	return nil
+}
+
+// tick fires every job and retries every attempt that is due right now, nothing while maintenance is enabled. The
+// jobs of paused environments stay due until their environment is resumed, the retries of their runs that already
+// started still run.
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now().UTC()
+	if !s.running(ctx, now) {
+		return
+	}
+	jobs, err := s.jobs.DueJobs(ctx, now)
+	if err != nil {
+		s.logger.Error("Could not query due jobs", zap.Error(err))
+	}
+	environments, environmentsRead := s.readEnvironments(ctx)
+	for _, job := range jobs {
+		started := s.started
+		if job.Environment != "" {
+			// Like with maintenance, firing jobs of an environment that may be paused would be worse than firing
+			// them late
+			if !environmentsRead {
+				continue
+			}
+			if e := environments[environmentKey(job.Tenant, job.Environment)]; e != nil {
+				if e.Paused {
+					continue
+				}
+				// The occurrences that passed while the environment was paused count as missed
+				if e.UpdatedAt.After(started) {
+					started = e.UpdatedAt
+				}
+			}
+		}
+		// Claim and fire every job for its tenant, so the storage finds it in the tenant's database
+		jobCtx := tenant.NewContext(ctx, job.Tenant)
+		if s.claim(jobCtx, job, now, started) {
+			s.fire(jobCtx, job)
+		}
+	}
+	retries, err := s.retries.DueRetries(ctx, now)
+	if err != nil {
+		s.logger.Error("Could not query due retries", zap.Error(err))
+	}
+	for _, retry := range retries {
+		s.retry(tenant.NewContext(ctx, retry.Tenant), retry)
+	}
+}
+
+// running reads the maintenance mode and tells whether the scheduler may fire jobs. The occurrences that passed while
+// it was paused count as missed, so the catch-up policies of the jobs apply to them.
+func (s *Scheduler) running(ctx context.Context, now time.Time) bool {
+	m, err := s.maintenance.Maintenance(ctx)
+	if err != nil {
+		// Firing jobs that were paused on purpose would be worse than firing them late
+		s.logger.Error("Could not read maintenance mode", zap.Error(err))
+		return false
+	}
+	switch {
+	case m.Enabled && !s.paused:
+		s.logger.Info("Scheduler paused for maintenance", zap.String("reason", m.Reason), zap.String("by", m.UpdatedBy))
+	case !m.Enabled && s.paused:
+		s.started = now
+		s.logger.Info("Scheduler resumed after maintenance", zap.String("by", m.UpdatedBy))
+	}
+	s.paused = m.Enabled
+	return !m.Enabled
+}
+
+// readEnvironments returns the environments of all tenants that were ever paused by their environmentKey and tells
+// whether they could be read. It logs the environments that were paused or resumed since the last tick.
+func (s *Scheduler) readEnvironments(ctx context.Context) (map[string]*Environment, bool) {
+	list, err := s.environments.Environments(ctx)
+	if err != nil {
+		s.logger.Error("Could not read environments", zap.Error(err))
+		return nil, false
+	}
+	environments := make(map[string]*Environment, len(list))
+	paused := map[string]*Environment{}
+	for _, e := range list {
+		key := environmentKey(e.Tenant, e.Name)
+		environments[key] = e
+		if !e.Paused {
+			continue
+		}
+		paused[key] = e
+		if s.pausedEnvironments[key] == nil {
+			s.logger.Info("Environment paused", zap.String("tenant", e.Tenant), zap.String("environment", e.Name),
+				zap.String("reason", e.Reason), zap.String("by", e.UpdatedBy))
+		}
+	}
+	for key, e := range s.pausedEnvironments {
+		if paused[key] == nil {
+			s.logger.Info("Environment resumed", zap.String("tenant", e.Tenant), zap.String("environment", e.Name))
+		}
+	}
+	s.pausedEnvironments = paused
+	return environments, true
+}
+
+// claim moves the job's next_run_time forward and tells whether to fire it. The update only succeeds if nobody else
+// moved it in the meantime, so a job is fired at most once per occurrence. Occurrences that passed while the job
+// waited for the next poll are skipped, the ones missed before the scheduler started are handled by the catch-up
+// policy of the job. The ones before started, when the scheduler started or the job's environment was last resumed,
+// were missed. Occurrences in a blackout of the job's calendars are skipped too unless the job defers them to
+// the end of the blackout.
+func (s *Scheduler) claim(ctx context.Context, job *DueJob, now, started time.Time) bool {
+	next, err := job.Schedule.Next(now)
+	if err != nil {
+		s.logger.Warn("Job has an invalid schedule", zap.String("job_id", job.ID), zap.Error(err))
+		return false
+	}
+	skipMissed := false
+	if job.NextRunTime.Before(started) {
+		switch job.CatchUpPolicy {
+		case CatchUpSkip:
+			skipMissed = true
+		case CatchUpFireAll:
+			// The next missed occurrence is due right away, so the job is fired for one of them on every poll
+			if missed := nextMissed(job.Schedule, job.NextRunTime, started); !missed.IsZero() {
+				next = missed
+			}
+		}
+	}
+	var blackoutEnd time.Time
+	if len(job.Calendars) > 0 {
+		blackouts, err := s.calendars.Blackouts(ctx, job.Calendars)
+		if err != nil {
+			s.logger.Error("Could not read calendars", zap.String("job_id", job.ID), zap.Error(err))
+			return false
+		}
+		blackoutEnd = BlackoutEnd(blackouts, now)
+		if !blackoutEnd.IsZero() && job.DeferBlackouts {
+			next = blackoutEnd
+		}
+	}
+	claimed, err := s.jobs.ClaimNextRun(ctx, job.ID, job.NextRunTime, next)
+	if err != nil {
+		s.logger.Error("Could not store next run time", zap.String("job_id", job.ID), zap.Error(err))
+		return false
+	}
+	if claimed && skipMissed {
+		s.logger.Info("Skipped missed run of job", zap.String("job_id", job.ID), zap.Time("missed", job.NextRunTime))
+		return false
+	}
+	if claimed && !blackoutEnd.IsZero() {
+		if job.DeferBlackouts {
+			s.logger.Info("Deferred run of job in blackout", zap.String("job_id", job.ID), zap.Time("until", blackoutEnd))
+		} else {
+			s.logger.Info("Skipped run of job in blackout", zap.String("job_id", job.ID), zap.Time("blackout_end", blackoutEnd))
+		}
+		return false
+	}
+	return claimed
+}
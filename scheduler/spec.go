@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MinInterval is the shortest fixed interval a schedule may use
+const MinInterval = time.Second
+
+// MaxPreview is the largest number of run times Preview computes at once
+const MaxPreview = 100
+
+// Spec is the stored form of a job schedule, exactly one of Cron and Interval is set
+type Spec struct {
+	Cron     string        `bson:"cron,omitempty"`
+	Interval time.Duration `bson:"interval,omitempty"`
+	// Timezone is the IANA name of the zone cron expressions are evaluated in, UTC when empty
+	Timezone string `bson:"timezone,omitempty"`
+}
+
+// Validate checks that the spec is complete and can be parsed
+func (s *Spec) Validate() error {
+	if s.Cron != "" && s.Interval != 0 {
+		return errors.New("schedule must not set both cron and interval")
+	}
+	if s.Cron == "" && s.Interval == 0 {
+		return errors.New("schedule must set either cron or interval")
+	}
+	if s.Interval != 0 && s.Interval < MinInterval {
+		return fmt.Errorf("interval must be at least %v", MinInterval)
+	}
+	if s.Cron != "" {
+		if _, err := cron.ParseStandard(s.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression: %v", err)
+		}
+	}
+	if s.Timezone != "" {
+		if s.Cron == "" {
+			return errors.New("timezone only applies to cron schedules")
+		}
+		// The descriptor would silently override the timezone of the spec
+		if strings.HasPrefix(s.Cron, "TZ=") || strings.HasPrefix(s.Cron, "CRON_TZ=") {
+			return errors.New("cron expression must not set a timezone when timezone is set")
+		}
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", s.Timezone)
+		}
+	}
+	return nil
+}
+
+// cronFields are the names of the fields of standard cron expressions in their order
+var cronFields = []string{"minute", "hour", "day_of_month", "month", "day_of_week"}
+
+// CronError is a cron expression that doesn't parse, with the part of it that's wrong
+type CronError struct {
+	// Field is the name of the wrong field like minute or day_of_week, timezone for the TZ= prefix and empty when the
+	// expression as a whole is wrong
+	Field string
+	// Position is the offset of the wrong part in the expression in bytes, Length its length in bytes
+	Position int
+	Length   int
+	Err      error
+}
+
+func (e *CronError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid cron expression at position %d: %v", e.Position, e.Err)
+	}
+	return fmt.Sprintf("invalid %s at position %d: %v", e.Field, e.Position, e.Err)
+}
+
+// cronToken is a field of a cron expression and its offset in the expression
+type cronToken struct {
+	text     string
+	position int
+}
+
+// cronTokens splits expr into its fields separated by whitespace
+func cronTokens(expr string) []cronToken {
+	tokens := []cronToken{}
+	start := -1
+	for i, r := range expr + " " {
+		if r == ' ' || r == '\t' {
+			if start >= 0 {
+				tokens = append(tokens, cronToken{text: expr[start:i], position: start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return tokens
+}
+
+// CheckCron returns a *CronError telling which part of expr is wrong when it isn't a standard cron expression, nil
+// when it parses. Every field is parsed on its own to find the wrong one.
+func CheckCron(expr string) error {
+	_, err := cron.ParseStandard(expr)
+	if err == nil {
+		return nil
+	}
+	tokens := cronTokens(expr)
+	if len(tokens) == 0 {
+		return &CronError{Err: errors.New("expression is empty")}
+	}
+	if prefix := tokens[0].text; strings.HasPrefix(prefix, "TZ=") || strings.HasPrefix(prefix, "CRON_TZ=") {
+		if _, tzErr := time.LoadLocation(prefix[strings.Index(prefix, "=")+1:]); tzErr != nil {
+			return &CronError{Field: "timezone", Position: tokens[0].position, Length: len(prefix), Err: tzErr}
+		}
+		tokens = tokens[1:]
+	}
+	// Descriptors like @daily or @every 1h are a single unit
+	if len(tokens) > 0 && strings.HasPrefix(tokens[0].text, "@") {
+		return &CronError{Position: tokens[0].position, Length: len(expr) - tokens[0].position, Err: err}
+	}
+	if len(tokens) != len(cronFields) {
+		e := &CronError{Position: len(expr), Err: fmt.Errorf("expected %d fields, found %d", len(cronFields), len(tokens))}
+		if len(tokens) > len(cronFields) {
+			extra := tokens[len(cronFields)]
+			e.Position, e.Length = extra.position, len(expr)-extra.position
+		}
+		return e
+	}
+	for i, token := range tokens {
+		fields := []string{"*", "*", "*", "*", "*"}
+		fields[i] = token.text
+		if _, fieldErr := cron.ParseStandard(strings.Join(fields, " ")); fieldErr != nil {
+			return &CronError{Field: cronFields[i], Position: token.position, Length: len(token.text), Err: fieldErr}
+		}
+	}
+	return &CronError{Length: len(expr), Err: err}
+}
+
+// location returns the zone cron expressions are evaluated in, the spec must be valid
+func (s *Spec) location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, _ := time.LoadLocation(s.Timezone)
+	return loc
+}
+
+// Next returns the first time after from the spec fires
+func (s *Spec) Next(from time.Time) (time.Time, error) {
+	if err := s.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	return s.stepper()(from), nil
+}
+
+// stepper returns a function returning the first time after its argument the spec fires, so callers stepping through
+// many occurrences parse the spec only once. The spec must be valid.
+func (s *Spec) stepper() func(from time.Time) time.Time {
+	if s.Interval != 0 {
+		return func(from time.Time) time.Time {
+			return from.Add(s.Interval).UTC().Truncate(time.Millisecond)
+		}
+	}
+	// Validate already made sure this parses. Evaluating the expression in the local time of the zone
+	// skips times that don't exist and fires once for times that exist twice when the clocks change.
+	sched, _ := cron.ParseStandard(s.Cron)
+	loc := s.location()
+	return func(from time.Time) time.Time {
+		return sched.Next(from.In(loc)).UTC()
+	}
+}
+
+// Preview returns the next n times after from the spec fires, n must be between 1 and MaxPreview
+func (s *Spec) Preview(from time.Time, n int) ([]time.Time, error) {
+	if n < 1 || n > MaxPreview {
+		return nil, fmt.Errorf("count must be between 1 and %d", MaxPreview)
+	}
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		next, err := s.Next(from)
+		if err != nil {
+			return nil, err
+		}
+		// Cron expressions that can never fire, like 30 February, have no next time
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+		from = next
+	}
+	return times, nil
+}
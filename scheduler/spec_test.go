@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckCronValid(t *testing.T) {
+	for _, expr := range []string{
+		"0 9 * * 1-5",
+		"*/15 * * * *",
+		"0 0 1 jan,jul *",
+		"0\t9 * * mon",
+		"@daily",
+		"@every 1h30m",
+		"TZ=UTC 0 9 * * *",
+		"CRON_TZ=UTC @hourly",
+	} {
+		if err := CheckCron(expr); err != nil {
+			t.Errorf("CheckCron(%q) = %v, want nil", expr, err)
+		}
+	}
+}
+
+func TestCheckCronPosition(t *testing.T) {
+	tests := []struct {
+		expr     string
+		field    string
+		position int
+		length   int
+		// err is part of the message of the error
+		err string
+	}{
+		{expr: "", err: "expression is empty"},
+		{expr: "   ", err: "expression is empty"},
+		{expr: "61 * * * *", field: "minute", position: 0, length: 2},
+		{expr: "0 25 * * *", field: "hour", position: 2, length: 2},
+		{expr: "0 9 32 * *", field: "day_of_month", position: 4, length: 2},
+		{expr: "0 9 * 13 *", field: "month", position: 6, length: 2},
+		{expr: "0 9 * * 8", field: "day_of_week", position: 8, length: 1},
+		{expr: "0  9 * * mon-xyz", field: "day_of_week", position: 9, length: 7},
+		{expr: "0\t25 * * *", field: "hour", position: 2, length: 2},
+		{expr: "0 9 * *", position: 7, length: 0, err: "expected 5 fields, found 4"},
+		{expr: "0 9 * * * extra", position: 10, length: 5, err: "expected 5 fields, found 6"},
+		{expr: "0 9 * * * a b", position: 10, length: 3, err: "expected 5 fields, found 7"},
+		{expr: "TZ=Nowhere/City 0 9 * * *", field: "timezone", position: 0, length: 15},
+		{expr: "TZ=UTC 61 * * * *", field: "minute", position: 7, length: 2},
+		{expr: "CRON_TZ=UTC 0 9 * *", position: 19, length: 0, err: "expected 5 fields, found 4"},
+		{expr: "@every nope", position: 0, length: 11},
+		{expr: "CRON_TZ=UTC @bogus", position: 12, length: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			err := CheckCron(tt.expr)
+			cronErr, ok := err.(*CronError)
+			if !ok {
+				t.Fatalf("CheckCron(%q) = %v, want a *CronError", tt.expr, err)
+			}
+			if cronErr.Field != tt.field || cronErr.Position != tt.position || cronErr.Length != tt.length {
+				t.Errorf("CheckCron(%q) reports field %q at %d with length %d, want field %q at %d with length %d",
+					tt.expr, cronErr.Field, cronErr.Position, cronErr.Length, tt.field, tt.position, tt.length)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("CheckCron(%q) = %q, want it to mention %q", tt.expr, err, tt.err)
+			}
+			// The reported part must lie within the expression
+			if cronErr.Position+cronErr.Length > len(tt.expr) {
+				t.Errorf("CheckCron(%q) reports %d bytes at %d, past the end of the expression", tt.expr, cronErr.Length, cronErr.Position)
+			}
+		})
+	}
+}
+
+func TestCronErrorMessage(t *testing.T) {
+	err := CheckCron("0 25 * * *")
+	if want := "invalid hour at position 2: "; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("error is %q, want it to start with %q", err, want)
+	}
+	err = CheckCron("0 9 * *")
+	if want := "invalid cron expression at position 7: "; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("error is %q, want it to start with %q", err, want)
+	}
+}
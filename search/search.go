@@ -0,0 +1,117 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Query is a parsed search query. Like MongoDB text search it matches text containing any of the terms, unless the
+// text contains one of the excluded terms. The words of quoted phrases are terms of their own.
+type Query struct {
+	Terms    []string
+	Excluded []string
+}
+
+// Parse splits a query into its terms, words with a leading - are excluded
+func Parse(s string) Query {
+	q := Query{}
+	for _, field := range strings.Fields(strings.ReplaceAll(s, `"`, " ")) {
+		excluded := strings.HasPrefix(field, "-")
+		for _, word := range words(strings.TrimPrefix(field, "-")) {
+			if excluded {
+				q.Excluded = append(q.Excluded, stem(word.text))
+			} else {
+				q.Terms = append(q.Terms, stem(word.text))
+			}
+		}
+	}
+	return q
+}
+
+// Score counts how often the terms of q appear in text, without case and ignoring common English suffixes. It is 0
+// when text contains an excluded term.
+func (q Query) Score(text string) int {
+	terms, excluded := set(q.Terms), set(q.Excluded)
+	score := 0
+	for _, word := range words(text) {
+		s := stem(word.text)
+		if excluded[s] {
+			return 0
+		}
+		if terms[s] {
+			score++
+		}
+	}
+	return score
+}
+
+// Highlight HTML escapes text and wraps every word matching a term of q in <em> and </em>. It reports false when no
+// word matched.
+func (q Query) Highlight(text string) (string, bool) {
+	terms := set(q.Terms)
+	var b strings.Builder
+	last, matched := 0, false
+	for _, word := range words(text) {
+		if !terms[stem(word.text)] {
+			continue
+		}
+		matched = true
+		b.WriteString(html.EscapeString(text[last:word.start]))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(word.text))
+		b.WriteString("</em>")
+		last = word.start + len(word.text)
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String(), matched
+}
+
+// set returns a set of the given terms
+func set(terms []string) map[string]bool {
+	s := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		s[term] = true
+	}
+	return s
+}
+
+// word is a run of letters and digits in a text, start is its byte offset
+type word struct {
+	text  string
+	start int
+}
+
+// words splits text into runs of letters and digits
+func words(text string) []word {
+	result := []word{}
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			result = append(result, word{text: text[start:i], start: start})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, word{text: text[start:], start: start})
+	}
+	return result
+}
+
+// suffixes are the English suffixes stem removes, longest first
+var suffixes = []string{"ing", "ed", "es", "s"}
+
+// stem lower cases a word and removes a common English suffix, so "Backups" matches "backup". It is far from a real
+// stemmer but good enough to highlight the words the storage backends matched.
+func stem(word string) string {
+	word = strings.ToLower(word)
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
@@ -0,0 +1,112 @@
+// Package secrets encrypts the secrets jobs refer to in their params and replaces the references with their values
+// when the jobs run
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// KeySize is the size of master keys in bytes, they are AES-256 keys
+const KeySize = 32
+
+// version is the first byte of sealed values, it changes with the format
+const version = 1
+
+// keyIDSize is the number of bytes of the key ID in sealed values
+const keyIDSize = 8
+
+var (
+	// ErrOtherKey is returned for values sealed with another master key than the one of the cipher
+	ErrOtherKey = errors.New("value was sealed with another master key")
+	// ErrCorrupt is returned for values that were changed or don't belong to the binding they are opened with
+	ErrCorrupt = errors.New("value is corrupt")
+)
+
+// Cipher seals the values of secrets with AES-GCM under a master key
+type Cipher struct {
+	aead cipher.AEAD
+	// keyID tells the master key apart from others without revealing it
+	keyID []byte
+}
+
+// NewCipher creates a cipher sealing values with key, it must be KeySize bytes
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &Cipher{aead: aead, keyID: sum[:keyIDSize]}, nil
+}
+
+// ParseKey decodes a base64 encoded master key, in the standard or URL alphabet with or without padding
+func ParseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := encoding.DecodeString(encoded); err == nil {
+			if len(key) != KeySize {
+				return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(key))
+			}
+			return key, nil
+		}
+	}
+	return nil, errors.New("master key must be base64 encoded")
+}
+
+// LoadKey returns the master key encoded in the file at path, like one a KMS or secret manager writes
+func LoadKey(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKey(string(data))
+}
+
+// Binding returns the additional data a secret is sealed with, so its value can't be moved to another secret
+func Binding(tenant, owner, name string) []byte {
+	return []byte(tenant + "\x00" + owner + "\x00" + name)
+}
+
+// Seal encrypts value bound to binding. The result holds the version, the key ID, the nonce and the ciphertext.
+func (c *Cipher) Seal(value string, binding []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %v", err)
+	}
+	sealed := append([]byte{version}, c.keyID...)
+	sealed = append(sealed, nonce...)
+	return c.aead.Seal(sealed, nonce, []byte(value), binding), nil
+}
+
+// Open decrypts a value sealed with the same binding
+func (c *Cipher) Open(sealed []byte, binding []byte) (string, error) {
+	header := 1 + keyIDSize + c.aead.NonceSize()
+	if len(sealed) < header+c.aead.Overhead() || sealed[0] != version {
+		return "", ErrCorrupt
+	}
+	if !bytes.Equal(sealed[1:1+keyIDSize], c.keyID) {
+		return "", ErrOtherKey
+	}
+	value, err := c.aead.Open(nil, sealed[1+keyIDSize:header], sealed[header:], binding)
+	if err != nil {
+		return "", ErrCorrupt
+	}
+	return string(value), nil
+}
@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+)
+
+// Redacted replaces the values of secrets wherever they would show up, like in the output of runs
+const Redacted = "[REDACTED]"
+
+var (
+	// NamePattern matches the names of secrets
+	NamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+	// referencePattern matches the references to secrets in the string values of params, like ${secrets.api_token}
+	referencePattern = regexp.MustCompile(`\$\{secrets\.([A-Za-z0-9_-]{1,64})\}`)
+)
+
+// ErrDisabled is returned when params refer to secrets while no master key is configured
+var ErrDisabled = errors.New("secrets are disabled, the server has no master key")
+
+// References returns the names of the secrets the string values of params refer to, sorted and without duplicates
+func References(params map[string]interface{}) []string {
+	seen := map[string]bool{}
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch value := value.(type) {
+		case string:
+			for _, match := range referencePattern.FindAllStringSubmatch(value, -1) {
+				seen[match[1]] = true
+			}
+		case map[string]interface{}:
+			for _, v := range value {
+				walk(v)
+			}
+		case []interface{}:
+			for _, v := range value {
+				walk(v)
+			}
+		}
+	}
+	walk(params)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replace returns a copy of params with the references to secrets replaced by their values, which must hold every
+// secret params refer to
+func Replace(params map[string]interface{}, values map[string]string) map[string]interface{} {
+	var replace func(value interface{}) interface{}
+	replace = func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case string:
+			return referencePattern.ReplaceAllStringFunc(value, func(ref string) string {
+				return values[referencePattern.FindStringSubmatch(ref)[1]]
+			})
+		case map[string]interface{}:
+			object := make(map[string]interface{}, len(value))
+			for k, v := range value {
+				object[k] = replace(v)
+			}
+			return object
+		case []interface{}:
+			list := make([]interface{}, len(value))
+			for i, v := range value {
+				list[i] = replace(v)
+			}
+			return list
+		}
+		return value
+	}
+	replaced, _ := replace(params).(map[string]interface{})
+	return replaced
+}
+
+// Redactor replaces the values of secrets in text
+type Redactor struct {
+	replacer *strings.Replacer
+}
+
+// NewRedactor creates a redactor for values. Their quoted forms are redacted too, since errors often quote what they
+// are about.
+func NewRedactor(values []string) *Redactor {
+	forms := []string{}
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		forms = append(forms, v)
+		if quoted := strconv.Quote(v); quoted[1:len(quoted)-1] != v {
+			forms = append(forms, quoted[1:len(quoted)-1])
+		}
+	}
+	// The replacer tries the forms in order, longer ones first don't leave parts of them behind
+	sort.Slice(forms, func(i, j int) bool { return len(forms[i]) > len(forms[j]) })
+	pairs := make([]string, 0, 2*len(forms))
+	for _, f := range forms {
+		pairs = append(pairs, f, Redacted)
+	}
+	return &Redactor{replacer: strings.NewReplacer(pairs...)}
+}
+
+// Redact returns text with the values replaced by Redacted
+func (r *Redactor) Redact(text string) string {
+	return r.replacer.Replace(text)
+}
+
+// Resolver replaces the references to secrets in the params of jobs with the values of the secrets of their owner
+type Resolver struct {
+	secrets repository.SecretRepository
+	// cipher is nil when no master key is configured, params referring to secrets can't be resolved then
+	cipher *Cipher
+}
+
+// NewResolver creates a resolver for the secrets in secrets, cipher may be nil
+func NewResolver(secrets repository.SecretRepository, cipher *Cipher) *Resolver {
+	return &Resolver{secrets: secrets, cipher: cipher}
+}
+
+// Resolve returns the params of job with the references to secrets replaced and the Redact function of a redactor
+// for the values of the secrets. Params without references are returned as they are. It fails when the owner of the
+// job has no secret with a name params refer to. It makes the resolver an executor.Resolver.
+func (r *Resolver) Resolve(ctx context.Context, job *repository.Job) (map[string]interface{}, func(string) string, error) {
+	names := References(job.Params)
+	if len(names) == 0 {
+		return job.Params, nil, nil
+	}
+	if r.cipher == nil {
+		return nil, nil, ErrDisabled
+	}
+	stored, err := r.secrets.Named(ctx, job.Owner, names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load secrets: %v", err)
+	}
+	values := map[string]string{}
+	for _, s := range stored {
+		value, err := r.cipher.Open(s.Value, Binding(s.Tenant, s.Owner, s.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not decrypt secret %q: %v", s.Name, err)
+		}
+		values[s.Name] = value
+	}
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		value, ok := values[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("secret %q doesn't exist", name)
+		}
+		resolved = append(resolved, value)
+	}
+	return Replace(job.Params, values), NewRedactor(resolved).Redact, nil
+}
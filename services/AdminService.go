@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/backup"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/reload"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// conflictPolicies maps the conflict policies of RestoreJobs to the ones of the backups, unspecified ones fail
+var conflictPolicies = map[model.ConflictPolicy]string{
+	model.ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED: backup.PolicyFail,
+	model.ConflictPolicy_CONFLICT_POLICY_SKIP:        backup.PolicySkip,
+	model.ConflictPolicy_CONFLICT_POLICY_OVERWRITE:   backup.PolicyOverwrite,
+	model.ConflictPolicy_CONFLICT_POLICY_FAIL:        backup.PolicyFail,
+}
+
+type AdminServiceServer struct {
+	// Backups is nil when no backup location is configured
+	Backups *backup.Backups
+	// Maintenance stores the maintenance mode of the scheduler
+	Maintenance repository.MaintenanceRepository
+	// Environments stores the environments that were paused
+	Environments repository.EnvironmentRepository
+	// Config reloads the configuration and knows the one the server runs with
+	Config *reload.Reloader
+	// LogLevels are the levels of the logger of the server
+	LogLevels *logging.Levels
+}
+
+func (s *AdminServiceServer) BackupJobs(ctx context.Context, req *model.BackupJobsReq) (*model.BackupJobsRes, error) {
+	if err := s.check(ctx); err != nil {
+		return nil, err
+	}
+	info, err := s.Backups.Backup(ctx, req.GetJobsOnly())
+	if err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not back up jobs: %v", err))
+	}
+	logging.FromContext(ctx).Info("Backed up jobs", zap.String("backup", info.Name), zap.Int64("jobs", info.Jobs),
+		zap.Int64("runs", info.Runs), zap.Int64("size", info.Size))
+	return &model.BackupJobsRes{Backup: &model.Backup{
+		Name:      info.Name,
+		CreatedAt: timestampProto(info.CreatedAt),
+		Jobs:      info.Jobs,
+		Runs:      info.Runs,
+		SizeBytes: info.Size,
+	}}, nil
+}
+
+func (s *AdminServiceServer) RestoreJobs(ctx context.Context, req *model.RestoreJobsReq) (*model.RestoreJobsRes, error) {
+	if err := s.check(ctx); err != nil {
+		return nil, err
+	}
+	policy, ok := conflictPolicies[req.GetConflictPolicy()]
+	if !ok {
+		return nil, invalidArgumentError("conflict_policy", fmt.Sprintf("Unknown conflict policy %v", req.GetConflictPolicy()))
+	}
+	result, err := s.Backups.Restore(ctx, req.GetName(), policy)
+	if conflict, ok := err.(*backup.ConflictError); ok {
+		msg := fmt.Sprintf("The %s %s of the backup conflicts with a stored one (%v), %d jobs and %d runs were restored before",
+			conflict.Kind, conflict.ID, conflict.Err, result.RestoredJobs, result.RestoredRuns)
+		resourceType := jobResource
+		if conflict.Kind == "run" {
+			resourceType = runResource
+		}
+		return nil, newError(codes.AlreadyExists, reasonBackupConflict, msg,
+			&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: conflict.ID, Description: msg})
+	}
+	switch err {
+	case nil:
+	case backup.ErrInvalidName:
+		return nil, invalidArgumentError("name", fmt.Sprintf("Invalid backup name %q", req.GetName()))
+	case backup.ErrNotFound:
+		return nil, notFoundError(backupResource, req.GetName(), fmt.Sprintf("Could not find backup %q", req.GetName()))
+	case backup.ErrOtherTenant:
+		return nil, otherTenantError(backupResource, req.GetName(), fmt.Sprintf("Backup %q belongs to another tenant", req.GetName()))
+	default:
+		if result != nil {
+			// Admins need to know how far the restore got, just not why it failed
+			logging.FromContext(ctx).Error("Could not restore backup", zap.String("backup", req.GetName()), zap.Error(err))
+			return nil, newError(codes.Internal, reasonInternal, fmt.Sprintf("Could not restore backup, %d jobs and %d runs were restored before",
+				result.RestoredJobs, result.RestoredRuns))
+		}
+		return nil, internalError(ctx, fmt.Errorf("could not restore backup: %v", err))
+	}
+	logging.FromContext(ctx).Info("Restored backup", zap.String("backup", req.GetName()), zap.String("conflict_policy", policy),
+		zap.Int64("restored_jobs", result.RestoredJobs), zap.Int64("skipped_jobs", result.SkippedJobs),
+		zap.Int64("restored_runs", result.RestoredRuns), zap.Int64("skipped_runs", result.SkippedRuns))
+	return &model.RestoreJobsRes{
+		RestoredJobs: result.RestoredJobs,
+		SkippedJobs:  result.SkippedJobs,
+		RestoredRuns: result.RestoredRuns,
+		SkippedRuns:  result.SkippedRuns,
+	}, nil
+}
+
+func (s *AdminServiceServer) GetMaintenance(ctx context.Context, req *model.GetMaintenanceReq) (*model.GetMaintenanceRes, error) {
+	if err := checkAdmin(ctx, "Only admins may read the maintenance mode"); err != nil {
+		return nil, err
+	}
+	m, err := s.Maintenance.Maintenance(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.GetMaintenanceRes{Maintenance: maintenanceToProto(m)}, nil
+}
+
+func (s *AdminServiceServer) EnableMaintenance(ctx context.Context, req *model.EnableMaintenanceReq) (*model.EnableMaintenanceRes, error) {
+	if msg := checkLength(req.GetReason(), maxDescriptionLength); msg != "" {
+		return nil, invalidArgumentError("reason", fmt.Sprintf("Reason %s", msg))
+	}
+	m, err := s.setMaintenance(ctx, true, req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &model.EnableMaintenanceRes{Maintenance: maintenanceToProto(m)}, nil
+}
+
+func (s *AdminServiceServer) DisableMaintenance(ctx context.Context, req *model.DisableMaintenanceReq) (*model.DisableMaintenanceRes, error) {
+	m, err := s.setMaintenance(ctx, false, "")
+	if err != nil {
+		return nil, err
+	}
+	return &model.DisableMaintenanceRes{Maintenance: maintenanceToProto(m)}, nil
+}
+
+// setMaintenance enables or disables the maintenance mode for the caller, who must be an admin
+func (s *AdminServiceServer) setMaintenance(ctx context.Context, enabled bool, reason string) (*scheduler.Maintenance, error) {
+	if err := checkAdmin(ctx, "Only admins may change the maintenance mode"); err != nil {
+		return nil, err
+	}
+	m := &scheduler.Maintenance{Enabled: enabled, Reason: reason, UpdatedAt: now()}
+	if claims, ok := auth.FromContext(ctx); ok {
+		m.UpdatedBy = claims.Subject
+	}
+	m, err := s.Maintenance.SetMaintenance(ctx, m)
+	if err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not store maintenance mode: %v", err))
+	}
+	if enabled {
+		logging.FromContext(ctx).Info("Enabled maintenance mode", zap.String("reason", reason))
+	} else {
+		logging.FromContext(ctx).Info("Disabled maintenance mode")
+	}
+	return m, nil
+}
+
+func (s *AdminServiceServer) ListEnvironments(ctx context.Context, req *model.ListEnvironmentsReq) (*model.ListEnvironmentsRes, error) {
+	if err := checkAdmin(ctx, "Only admins may read the environments"); err != nil {
+		return nil, err
+	}
+	environments, err := s.Environments.List(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	res := &model.ListEnvironmentsRes{Environments: make([]*model.Environment, 0, len(environments))}
+	for _, e := range environments {
+		res.Environments = append(res.Environments, environmentToProto(e))
+	}
+	return res, nil
+}
+
+func (s *AdminServiceServer) PauseEnvironment(ctx context.Context, req *model.PauseEnvironmentReq) (*model.PauseEnvironmentRes, error) {
+	if msg := checkLength(req.GetReason(), maxDescriptionLength); msg != "" {
+		return nil, invalidArgumentError("reason", fmt.Sprintf("Reason %s", msg))
+	}
+	e, err := s.updateEnvironment(ctx, req.GetName(), func(e *scheduler.Environment, by string, at time.Time) {
+		e.Paused, e.Reason, e.UpdatedBy, e.UpdatedAt = true, req.GetReason(), by, at
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Paused environment", zap.String("environment", e.Name), zap.String("reason", e.Reason))
+	return &model.PauseEnvironmentRes{Environment: environmentToProto(e)}, nil
+}
+
+func (s *AdminServiceServer) ResumeEnvironment(ctx context.Context, req *model.ResumeEnvironmentReq) (*model.ResumeEnvironmentRes, error) {
+	e, err := s.updateEnvironment(ctx, req.GetName(), func(e *scheduler.Environment, by string, at time.Time) {
+		e.Paused, e.Reason, e.UpdatedBy, e.UpdatedAt = false, "", by, at
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Resumed environment", zap.String("environment", e.Name))
+	return &model.ResumeEnvironmentRes{Environment: environmentToProto(e)}, nil
+}
+
+func (s *AdminServiceServer) ProtectEnvironment(ctx context.Context, req *model.ProtectEnvironmentReq) (*model.ProtectEnvironmentRes, error) {
+	e, err := s.updateEnvironment(ctx, req.GetName(), func(e *scheduler.Environment, by string, at time.Time) {
+		e.Protected, e.ProtectedBy, e.ProtectedAt = true, by, at
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Protected environment", zap.String("environment", e.Name))
+	return &model.ProtectEnvironmentRes{Environment: environmentToProto(e)}, nil
+}
+
+func (s *AdminServiceServer) UnprotectEnvironment(ctx context.Context, req *model.UnprotectEnvironmentReq) (*model.UnprotectEnvironmentRes, error) {
+	e, err := s.updateEnvironment(ctx, req.GetName(), func(e *scheduler.Environment, by string, at time.Time) {
+		e.Protected, e.ProtectedBy, e.ProtectedAt = false, by, at
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Unprotected environment", zap.String("environment", e.Name))
+	return &model.UnprotectEnvironmentRes{Environment: environmentToProto(e)}, nil
+}
+
+func (s *AdminServiceServer) GetConfig(ctx context.Context, req *model.GetConfigReq) (*model.GetConfigRes, error) {
+	if err := checkAdmin(ctx, "Only admins may read the configuration"); err != nil {
+		return nil, err
+	}
+	snapshot := s.Config.Snapshot()
+	res := &model.GetConfigRes{
+		LoadedAt:    timestampProto(snapshot.LoadedAt),
+		ReloadedAt:  timestampProto(snapshot.ReloadedAt),
+		ReloadError: snapshot.Error,
+	}
+	for _, setting := range snapshot.Settings {
+		res.Settings = append(res.Settings, &model.ConfigSetting{
+			Name:            setting.Name,
+			Value:           setting.Value,
+			Reloadable:      setting.Reloadable,
+			RestartRequired: setting.RestartRequired,
+			PendingValue:    setting.Pending,
+		})
+	}
+	return res, nil
+}
+
+func (s *AdminServiceServer) GetLogLevel(ctx context.Context, req *model.GetLogLevelReq) (*model.GetLogLevelRes, error) {
+	if _, ok := logging.Subsystems[req.GetSubsystem()]; !ok && req.GetSubsystem() != "" {
+		return nil, invalidArgumentError("subsystem", fmt.Sprintf("Unknown subsystem %q", req.GetSubsystem()))
+	}
+	if err := checkAdmin(ctx, "Only admins may read the log level"); err != nil {
+		return nil, err
+	}
+	if req.GetSubsystem() == "" {
+		return &model.GetLogLevelRes{Level: s.LogLevels.Global().String(), Subsystems: s.subsystemLogLevels()}, nil
+	}
+	level, _ := s.LogLevels.Level(req.GetSubsystem())
+	return &model.GetLogLevelRes{Level: level.String()}, nil
+}
+
+func (s *AdminServiceServer) SetLogLevel(ctx context.Context, req *model.SetLogLevelReq) (*model.SetLogLevelRes, error) {
+	if _, ok := logging.Subsystems[req.GetSubsystem()]; !ok && req.GetSubsystem() != "" {
+		return nil, invalidArgumentError("subsystem", fmt.Sprintf("Unknown subsystem %q", req.GetSubsystem()))
+	}
+	reset := req.GetLevel() == "" && req.GetSubsystem() != ""
+	if req.GetLevel() == "" && !reset {
+		return nil, invalidArgumentError("level", "Level is required without a subsystem")
+	}
+	level, err := logging.ParseLevel(req.GetLevel())
+	if err != nil && !reset {
+		return nil, invalidArgumentError("level", fmt.Sprintf("Unknown log level %q", req.GetLevel()))
+	}
+	if err := checkAdmin(ctx, "Only admins may change the log level"); err != nil {
+		return nil, err
+	}
+	// The subsystem was checked already, setting its level can't fail
+	switch {
+	case reset:
+		s.LogLevels.ResetLevel(req.GetSubsystem())
+		logging.FromContext(ctx).Info("Reset the log level of subsystem", zap.String("subsystem", req.GetSubsystem()))
+	case req.GetSubsystem() != "":
+		s.LogLevels.SetLevel(req.GetSubsystem(), level)
+		logging.FromContext(ctx).Info("Changed the log level of subsystem", zap.String("subsystem", req.GetSubsystem()), zap.Stringer("level", level))
+	default:
+		s.LogLevels.SetGlobal(level)
+		logging.FromContext(ctx).Info("Changed the log level", zap.Stringer("level", level))
+	}
+	return &model.SetLogLevelRes{Level: s.LogLevels.Global().String(), Subsystems: s.subsystemLogLevels()}, nil
+}
+
+// subsystemLogLevels returns the levels of every subsystem, sorted by name
+func (s *AdminServiceServer) subsystemLogLevels() []*model.SubsystemLogLevel {
+	var levels []*model.SubsystemLogLevel
+	for _, subsystem := range logging.SubsystemNames() {
+		level, set := s.LogLevels.Level(subsystem)
+		levels = append(levels, &model.SubsystemLogLevel{Subsystem: subsystem, Level: level.String(), Inherited: !set})
+	}
+	return levels
+}
+
+// updateEnvironment applies change to the environment called name of the caller, who must be an admin, and stores it.
+// change gets the subject of the caller and the time of the change.
+func (s *AdminServiceServer) updateEnvironment(ctx context.Context, name string, change func(e *scheduler.Environment, by string, at time.Time)) (*scheduler.Environment, error) {
+	if msg := checkEnvironment(name); msg != "" {
+		return nil, invalidArgumentError("name", fmt.Sprintf("Name %s", msg))
+	}
+	if err := checkAdmin(ctx, "Only admins may change environments"); err != nil {
+		return nil, err
+	}
+	e, err := s.Environments.Get(ctx, name)
+	if err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not read environment: %v", err))
+	}
+	var by string
+	if claims, ok := auth.FromContext(ctx); ok {
+		by = claims.Subject
+	}
+	change(e, by, now())
+	if e, err = s.Environments.Set(ctx, e); err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not store environment: %v", err))
+	}
+	return e, nil
+}
+
+// check makes sure backups are configured and the caller is an admin
+func (s *AdminServiceServer) check(ctx context.Context) error {
+	if err := checkAdmin(ctx, "Only admins may back up and restore jobs"); err != nil {
+		return err
+	}
+	if s.Backups == nil {
+		return newError(codes.FailedPrecondition, reasonBackupsDisabled, "Backups are disabled, BACKUP_LOCATION is not set")
+	}
+	return nil
+}
+
+// checkAdmin fails with msg unless the caller is an admin, calls without claims (authentication disabled) are allowed
+// like everywhere else
+func checkAdmin(ctx context.Context, msg string) error {
+	if claims, ok := auth.FromContext(ctx); ok && !claims.HasRole(auth.AdminRole) {
+		return newError(codes.PermissionDenied, reasonPermissionDenied, msg)
+	}
+	return nil
+}
+
+func maintenanceToProto(m *scheduler.Maintenance) *model.Maintenance {
+	return &model.Maintenance{
+		Enabled:   m.Enabled,
+		Reason:    m.Reason,
+		UpdatedBy: m.UpdatedBy,
+		UpdatedAt: timestampProto(m.UpdatedAt),
+	}
+}
+
+func environmentToProto(e *scheduler.Environment) *model.Environment {
+	return &model.Environment{
+		Name:        e.Name,
+		Paused:      e.Paused,
+		Reason:      e.Reason,
+		UpdatedBy:   e.UpdatedBy,
+		UpdatedAt:   timestampProto(e.UpdatedAt),
+		Protected:   e.Protected,
+		ProtectedBy: e.ProtectedBy,
+		ProtectedAt: timestampProto(e.ProtectedAt),
+	}
+}
+
+// environmentPausedMessage tells callers that the environment of a job is paused and why
+func environmentPausedMessage(e *scheduler.Environment) string {
+	if e.Reason == "" {
+		return fmt.Sprintf("Environment %s is paused, try again once it was resumed", e.Name)
+	}
+	return fmt.Sprintf("Environment %s is paused (%s), try again once it was resumed", e.Name, e.Reason)
+}
+
+// maintenanceMessage tells callers that the scheduler is in maintenance and why
+func maintenanceMessage(m *scheduler.Maintenance) string {
+	if m.Reason == "" {
+		return "The scheduler is in maintenance, try again once it ended"
+	}
+	return fmt.Sprintf("The scheduler is in maintenance (%s), try again once it ended", m.Reason)
+}
@@ -0,0 +1,614 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/sla"
+	"github.com/noltedennis/schedulytics-backend/team"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// defaultTimeRange is how far back analytics look when the request doesn't set a start time
+const defaultTimeRange = 30 * 24 * time.Hour
+
+// summaryPageSize is the number of jobs GetOwnerSummary reads at once, the runs of every page are summed up in one query
+const summaryPageSize = 500
+
+// summaryFields are the fields of jobs GetOwnerSummary reads
+var summaryFields = []string{"id", "schedule", "status", "deleted_at", "archived_at"}
+
+// costFields are the fields of jobs GetCostReport reads
+var costFields = []string{"id", "name", "owner"}
+
+// maxTimeSeriesBuckets limits the length of a time series, a year of hours fits
+const maxTimeSeriesBuckets = 10000
+
+// timeSeriesBuckets maps the bucket sizes of requests to the ones of the repository
+var timeSeriesBuckets = map[model.TimeSeriesBucket]string{
+	model.TimeSeriesBucket_TIME_SERIES_BUCKET_UNSPECIFIED: repository.BucketDay,
+	model.TimeSeriesBucket_TIME_SERIES_BUCKET_HOUR:        repository.BucketHour,
+	model.TimeSeriesBucket_TIME_SERIES_BUCKET_DAY:         repository.BucketDay,
+	model.TimeSeriesBucket_TIME_SERIES_BUCKET_WEEK:        repository.BucketWeek,
+}
+
+// slaBreachKinds maps the kinds of breaches of the repository to the ones of the API
+var slaBreachKinds = map[string]model.SLABreachKind{
+	repository.SLABreachDuration: model.SLABreachKind_SLA_BREACH_KIND_DURATION,
+	repository.SLABreachDeadline: model.SLABreachKind_SLA_BREACH_KIND_DEADLINE,
+}
+
+type AnalyticsServiceServer struct {
+	Jobs repository.JobRepository
+	Runs repository.RunRepository
+	SLAs repository.SLARepository
+	// Anomalies are the runs the anomaly detector recorded
+	Anomalies repository.AnomalyRepository
+}
+
+func (s *AnalyticsServiceServer) GetJobStats(ctx context.Context, req *model.GetJobStatsReq) (*model.GetJobStatsRes, error) {
+	if err := s.checkJob(ctx, req.GetJobId()); err != nil {
+		return nil, err
+	}
+	from, to, err := timeRange(req.GetTimeRange())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.Runs.Stats(ctx, req.GetJobId(), from, to)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.GetJobStatsRes{Stats: jobStatsToProto(req.GetJobId(), from, to, stats)}, nil
+}
+
+func (s *AnalyticsServiceServer) GetOwnerSummary(ctx context.Context, req *model.GetOwnerSummaryReq) (*model.GetOwnerSummaryRes, error) {
+	owner, err := summaryOwner(ctx, req.GetOwner(), "analytics")
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := timeRange(req.GetTimeRange())
+	if err != nil {
+		return nil, err
+	}
+	summary := &model.OwnerSummary{
+		Owner:     owner,
+		TimeRange: &model.TimeRange{StartTime: timestampProto(from), EndTime: timestampProto(to)},
+	}
+	// Deleted jobs are read as well, their runs count until they are purged
+	q := repository.Query{Owner: owner, IncludeDeleted: true, Fields: summaryFields}
+	counts := map[string]int64{}
+	var computeTime time.Duration
+	after := ""
+	for {
+		jobs, err := s.Jobs.List(ctx, q, after, summaryPageSize)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		if len(jobs) == 0 {
+			break
+		}
+		ids := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+			if job.DeletedAt != nil {
+				continue
+			}
+			summary.TotalJobs++
+			if job.Schedule != nil && job.Status != repository.JobPaused && job.ArchivedAt == nil {
+				summary.ActiveSchedules++
+			}
+		}
+		usage, err := s.Runs.Usage(ctx, ids, from, to)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		for status, count := range usage.Counts {
+			counts[status] += count
+		}
+		computeTime += usage.ComputeTime
+		if len(jobs) < summaryPageSize {
+			break
+		}
+		after = jobs[len(jobs)-1].ID
+	}
+
+	summary.SucceededRuns = counts[executor.StatusSucceeded]
+	summary.FailedRuns = counts[executor.StatusFailed]
+	summary.TimedOutRuns = counts[executor.StatusTimedOut]
+	summary.CancelledRuns = counts[executor.StatusCancelled]
+	summary.PendingRuns = counts[executor.StatusQueued] + counts[executor.StatusWaiting] + counts[executor.StatusRunning]
+	for _, count := range counts {
+		summary.TotalRuns += count
+	}
+	if finished := summary.SucceededRuns + summary.FailedRuns + summary.TimedOutRuns + summary.CancelledRuns; finished > 0 {
+		summary.SuccessRate = float64(summary.SucceededRuns) / float64(finished)
+		summary.FailureRate = float64(summary.FailedRuns+summary.TimedOutRuns) / float64(finished)
+	}
+	summary.ComputeTime = ptypes.DurationProto(computeTime)
+	return &model.GetOwnerSummaryRes{Summary: summary}, nil
+}
+
+func (s *AnalyticsServiceServer) GetCostReport(req *model.GetCostReportReq, stream model.AnalyticsService_GetCostReportServer) error {
+	ctx := stream.Context()
+	q := ownerQuery(ctx)
+	if req.GetOwner() != "" {
+		owner, err := summaryOwner(ctx, req.GetOwner(), "analytics")
+		if err != nil {
+			return err
+		}
+		q = repository.Query{Owner: owner}
+	}
+	from, to, err := timeRange(req.GetTimeRange())
+	if err != nil {
+		return err
+	}
+	group := req.GetGroupBy()
+	if _, ok := model.CostGroup_name[int32(group)]; !ok {
+		return invalidArgumentError("group_by", fmt.Sprintf("Invalid group: %v", group))
+	}
+	// Deleted jobs are read as well, their runs cost until they are purged
+	q.IncludeDeleted = true
+	q.Fields = costFields
+	rows := map[string]*model.GetCostReportRes{}
+	computeTimes := map[string]time.Duration{}
+	after := ""
+	for {
+		jobs, err := s.Jobs.List(ctx, q, after, summaryPageSize)
+		if err != nil {
+			return internalError(ctx, err)
+		}
+		if len(jobs) == 0 {
+			break
+		}
+		byID := make(map[string]*repository.Job, len(jobs))
+		ids := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			byID[job.ID] = job
+			ids = append(ids, job.ID)
+		}
+		costs, err := s.Runs.Costs(ctx, ids, from, to)
+		if err != nil {
+			return internalError(ctx, err)
+		}
+		for _, c := range costs {
+			job := byID[c.JobID]
+			var key, name string
+			switch group {
+			case model.CostGroup_COST_GROUP_OWNER:
+				key = job.Owner
+			case model.CostGroup_COST_GROUP_TEAM:
+				if !strings.HasPrefix(job.Owner, repository.TeamOwnerPrefix) {
+					continue
+				}
+				key = strings.TrimPrefix(job.Owner, repository.TeamOwnerPrefix)
+			case model.CostGroup_COST_GROUP_MONTH:
+				key = c.Month.Format("2006-01")
+			default:
+				key, name = job.ID, job.Name
+			}
+			row, ok := rows[key]
+			if !ok {
+				row = &model.GetCostReportRes{Key: key, Name: name}
+				rows[key] = row
+			}
+			row.TotalRuns += c.Runs
+			row.Cost += c.Cost
+			computeTimes[key] += c.ComputeTime
+		}
+		if len(jobs) < summaryPageSize {
+			break
+		}
+		after = jobs[len(jobs)-1].ID
+	}
+
+	report := make([]*model.GetCostReportRes, 0, len(rows))
+	for key, row := range rows {
+		row.ComputeTime = ptypes.DurationProto(computeTimes[key])
+		report = append(report, row)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if group != model.CostGroup_COST_GROUP_MONTH && report[i].Cost != report[j].Cost {
+			return report[i].Cost > report[j].Cost
+		}
+		return report[i].Key < report[j].Key
+	})
+	for _, row := range report {
+		if err := stream.Send(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AnalyticsServiceServer) GetJobTimeSeries(req *model.GetJobTimeSeriesReq, stream model.AnalyticsService_GetJobTimeSeriesServer) error {
+	ctx := stream.Context()
+	if err := s.checkJob(ctx, req.GetJobId()); err != nil {
+		return err
+	}
+	from, to, err := timeRange(req.GetTimeRange())
+	if err != nil {
+		return err
+	}
+	bucket, ok := timeSeriesBuckets[req.GetBucket()]
+	if !ok {
+		return invalidArgumentError("bucket", fmt.Sprintf("Invalid bucket: %v", req.GetBucket()))
+	}
+	loc := time.UTC
+	if req.GetTimezone() != "" {
+		loc, err = time.LoadLocation(req.GetTimezone())
+		if err != nil {
+			return invalidArgumentError("timezone", fmt.Sprintf("Invalid timezone: %v", err))
+		}
+	}
+	// Count the buckets before querying, so huge ranges of small buckets are rejected cheaply
+	starts := []time.Time{}
+	for start := repository.TruncateBucket(from, bucket, loc); start.Before(to); start = repository.NextBucket(start, bucket) {
+		if len(starts) == maxTimeSeriesBuckets {
+			return invalidArgumentError("time_range", fmt.Sprintf("Time range spans more than %d buckets", maxTimeSeriesBuckets))
+		}
+		starts = append(starts, start)
+	}
+	series, err := s.Runs.TimeSeries(ctx, req.GetJobId(), from, to, bucket, loc)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	// The repository leaves out buckets without runs, fill them in so charts get an evenly spaced series
+	for i, start := range starts {
+		b := &repository.RunBucket{Start: start}
+		if len(series) > 0 && series[0].Start.Equal(start) {
+			b, series = series[0], series[1:]
+		}
+		end := to
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if err := stream.Send(runBucketToProto(b, start, end)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AnalyticsServiceServer) SetJobSLA(ctx context.Context, req *model.SetJobSLAReq) (*model.SetJobSLARes, error) {
+	in := req.GetSla()
+	var violations []*errdetails.BadRequest_FieldViolation
+	var maxDuration time.Duration
+	if in.GetMaxDuration() != nil {
+		var err error
+		if maxDuration, err = ptypes.Duration(in.GetMaxDuration()); err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "sla.max_duration", Description: fmt.Sprintf("Invalid max duration: %v", err)})
+		} else if maxDuration <= 0 {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "sla.max_duration", Description: "Max duration must be positive"})
+		}
+	}
+	if in.GetDeadline() != "" {
+		if _, _, err := sla.ParseDeadline(in.GetDeadline()); err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "sla.deadline", Description: "Deadline must be written as HH:MM"})
+		}
+	} else if in.GetMaxDuration() == nil {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "sla", Description: "SLA needs a max duration or a deadline"})
+	}
+	if _, err := time.LoadLocation(in.GetTimezone()); err != nil {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "sla.timezone", Description: fmt.Sprintf("Invalid timezone: %v", err)})
+	}
+	if len(violations) > 0 {
+		return nil, violationsError(fmt.Sprintf("Invalid SLA: %s", violations[0].Description), violations)
+	}
+	if err := s.checkJob(ctx, in.GetJobId()); err != nil {
+		return nil, err
+	}
+	stored, err := s.SLAs.Set(ctx, &repository.SLA{
+		JobID:       in.GetJobId(),
+		MaxDuration: maxDuration,
+		Deadline:    in.GetDeadline(),
+		Timezone:    in.GetTimezone(),
+		UpdatedAt:   time.Now().UTC().Truncate(time.Millisecond),
+	})
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.SetJobSLARes{Sla: slaToProto(stored)}, nil
+}
+
+func (s *AnalyticsServiceServer) DeleteJobSLA(ctx context.Context, req *model.DeleteJobSLAReq) (*model.DeleteJobSLARes, error) {
+	if err := s.checkJob(ctx, req.GetJobId()); err != nil {
+		return nil, err
+	}
+	if err := s.SLAs.Delete(ctx, req.GetJobId()); err == repository.ErrNotFound {
+		return nil, slaNotFoundError(req.GetJobId())
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.DeleteJobSLARes{}, nil
+}
+
+func (s *AnalyticsServiceServer) GetSLAStatus(ctx context.Context, req *model.GetSLAStatusReq) (*model.GetSLAStatusRes, error) {
+	if err := s.checkJob(ctx, req.GetJobId()); err != nil {
+		return nil, err
+	}
+	from, to, err := timeRange(req.GetTimeRange())
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.SLAs.Get(ctx, req.GetJobId())
+	if err == repository.ErrNotFound {
+		return nil, slaNotFoundError(req.GetJobId())
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	status, err := sla.StatusAt(ctx, s.Runs, stored, time.Now().UTC())
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	count, err := s.SLAs.CountBreaches(ctx, req.GetJobId(), from, to)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	last, err := s.SLAs.ListBreaches(ctx, req.GetJobId(), "", 1)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	res := &model.SLAStatus{
+		JobId:           req.GetJobId(),
+		Sla:             slaToProto(stored),
+		Met:             status.Met,
+		NextDeadlineMet: status.NextDeadlineMet,
+		TimeRange:       &model.TimeRange{StartTime: timestampProto(from), EndTime: timestampProto(to)},
+		BreachCount:     count,
+	}
+	if status.Deadlines != nil {
+		res.LastDeadline = timestampProto(status.Deadlines.Last)
+		res.NextDeadline = timestampProto(status.Deadlines.Next)
+	}
+	if len(last) > 0 {
+		res.LastBreach = breachToProto(last[0])
+	}
+	return &model.GetSLAStatusRes{Status: res}, nil
+}
+
+func (s *AnalyticsServiceServer) ListSLABreaches(req *model.ListSLABreachesReq, stream model.AnalyticsService_ListSLABreachesServer) error {
+	ctx := stream.Context()
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	before := ""
+	if req.GetPageToken() != "" {
+		before, err = decodePageToken(req.GetPageToken())
+		if err != nil {
+			return invalidArgumentError("page_token", fmt.Sprintf("Invalid page token: %v", err))
+		}
+	}
+	if err := s.checkJob(ctx, req.GetJobId()); err != nil {
+		return err
+	}
+	breaches, err := s.SLAs.ListBreaches(ctx, req.GetJobId(), before, int(pageSize)+1)
+	if err == repository.ErrInvalidID {
+		return invalidArgumentError("page_token", "Invalid page token")
+	} else if err != nil {
+		return internalError(ctx, err)
+	}
+
+	hasMore := len(breaches) > int(pageSize)
+	if hasMore {
+		breaches = breaches[:pageSize]
+	}
+	for i, breach := range breaches {
+		res := &model.ListSLABreachesRes{Breach: breachToProto(breach)}
+		if hasMore && i == len(breaches)-1 {
+			res.NextPageToken = encodePageToken(breach.ID)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AnalyticsServiceServer) ListAnomalies(req *model.ListAnomaliesReq, stream model.AnalyticsService_ListAnomaliesServer) error {
+	ctx := stream.Context()
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	before := ""
+	if req.GetPageToken() != "" {
+		before, err = decodePageToken(req.GetPageToken())
+		if err != nil {
+			return invalidArgumentError("page_token", fmt.Sprintf("Invalid page token: %v", err))
+		}
+	}
+	if err := s.checkJob(ctx, req.GetJobId()); err != nil {
+		return err
+	}
+	anomalies, err := s.Anomalies.List(ctx, req.GetJobId(), before, int(pageSize)+1)
+	if err == repository.ErrInvalidID {
+		return invalidArgumentError("page_token", "Invalid page token")
+	} else if err != nil {
+		return internalError(ctx, err)
+	}
+
+	hasMore := len(anomalies) > int(pageSize)
+	if hasMore {
+		anomalies = anomalies[:pageSize]
+	}
+	for i, anomaly := range anomalies {
+		res := &model.ListAnomaliesRes{Anomaly: anomalyToProto(anomaly)}
+		if hasMore && i == len(anomalies)-1 {
+			res.NextPageToken = encodePageToken(anomaly.ID)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkJob makes sure the job exists and belongs to the caller, runs of deleted jobs can still be analyzed
+func (s *AnalyticsServiceServer) checkJob(ctx context.Context, id string) error {
+	q := ownerQuery(ctx)
+	q.IncludeDeleted = true
+	if _, err := s.Jobs.Get(ctx, id, q); err != nil {
+		return jobError(ctx, err, id)
+	}
+	return nil
+}
+
+// summaryOwner returns the owner a summary, report or quota is for, the caller when owner is empty. Non-admins may only
+// ask for themselves and their teams, what is read is named in the error otherwise. Without authentication an empty
+// owner sums up the jobs of every owner.
+func summaryOwner(ctx context.Context, owner, what string) (string, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return owner, nil
+	}
+	if owner == "" {
+		return claims.Subject, nil
+	}
+	if owner == claims.Subject || claims.HasRole(auth.AdminRole) {
+		return owner, nil
+	}
+	if strings.HasPrefix(owner, repository.TeamOwnerPrefix) {
+		id := strings.TrimPrefix(owner, repository.TeamOwnerPrefix)
+		for _, member := range team.FromContext(ctx) {
+			if member == id {
+				return owner, nil
+			}
+		}
+	}
+	return "", newError(codes.PermissionDenied, reasonPermissionDenied, fmt.Sprintf("Not allowed to read the %s of owner %q", what, owner))
+}
+
+// timeRange applies the defaults to the time range of a request and checks that it isn't empty
+func timeRange(r *model.TimeRange) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(time.Millisecond)
+	if r.GetEndTime() != nil {
+		t, err := ptypes.Timestamp(r.GetEndTime())
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidArgumentError("time_range.end_time", fmt.Sprintf("Invalid end time: %v", err))
+		}
+		to = t
+	}
+	from := to.Add(-defaultTimeRange)
+	if r.GetStartTime() != nil {
+		t, err := ptypes.Timestamp(r.GetStartTime())
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidArgumentError("time_range.start_time", fmt.Sprintf("Invalid start time: %v", err))
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, invalidArgumentError("time_range", "Start time must be before end time")
+	}
+	return from, to, nil
+}
+
+// jobStatsToProto converts the run stats of a job into the JobStats message sent to clients
+func jobStatsToProto(jobID string, from, to time.Time, stats *repository.RunStats) *model.JobStats {
+	res := &model.JobStats{
+		JobId:         jobID,
+		TimeRange:     &model.TimeRange{StartTime: timestampProto(from), EndTime: timestampProto(to)},
+		SucceededRuns: stats.Counts[executor.StatusSucceeded],
+		FailedRuns:    stats.Counts[executor.StatusFailed],
+		TimedOutRuns:  stats.Counts[executor.StatusTimedOut],
+		CancelledRuns: stats.Counts[executor.StatusCancelled],
+		PendingRuns:   stats.Counts[executor.StatusQueued] + stats.Counts[executor.StatusWaiting] + stats.Counts[executor.StatusRunning],
+	}
+	for _, count := range stats.Counts {
+		res.TotalRuns += count
+	}
+	if finished := res.SucceededRuns + res.FailedRuns + res.TimedOutRuns + res.CancelledRuns; finished > 0 {
+		res.SuccessRate = float64(res.SucceededRuns) / float64(finished)
+		res.FailureRate = float64(res.FailedRuns+res.TimedOutRuns) / float64(finished)
+	}
+	if stats.Finished > 0 {
+		res.MeanDuration = ptypes.DurationProto(stats.Mean)
+		res.P50Duration = ptypes.DurationProto(stats.P50)
+		res.P95Duration = ptypes.DurationProto(stats.P95)
+		res.P99Duration = ptypes.DurationProto(stats.P99)
+	}
+	return res
+}
+
+// runBucketToProto converts a bucket of a time series, cut to start and end, into the message sent to clients
+func runBucketToProto(b *repository.RunBucket, start, end time.Time) *model.GetJobTimeSeriesRes {
+	res := &model.GetJobTimeSeriesRes{
+		StartTime:     timestampProto(start),
+		EndTime:       timestampProto(end),
+		SucceededRuns: b.Counts[executor.StatusSucceeded],
+		FailedRuns:    b.Counts[executor.StatusFailed],
+		TimedOutRuns:  b.Counts[executor.StatusTimedOut],
+		CancelledRuns: b.Counts[executor.StatusCancelled],
+	}
+	for _, count := range b.Counts {
+		res.TotalRuns += count
+	}
+	if b.Finished > 0 {
+		res.MeanDuration = ptypes.DurationProto(b.Mean)
+	}
+	return res
+}
+
+// slaNotFoundError tells that the job with jobID has no SLA
+func slaNotFoundError(jobID string) error {
+	return notFoundError(slaResource, jobID, fmt.Sprintf("Could not find an SLA of job %s", jobID))
+}
+
+// slaToProto converts a stored SLA into its message
+func slaToProto(stored *repository.SLA) *model.JobSLA {
+	res := &model.JobSLA{
+		JobId:     stored.JobID,
+		Deadline:  stored.Deadline,
+		Timezone:  stored.Timezone,
+		UpdatedAt: timestampProto(stored.UpdatedAt),
+	}
+	if stored.MaxDuration > 0 {
+		res.MaxDuration = ptypes.DurationProto(stored.MaxDuration)
+	}
+	return res
+}
+
+// breachToProto converts a stored SLA breach into its message
+func breachToProto(breach *repository.SLABreach) *model.SLABreach {
+	res := &model.SLABreach{
+		Id:         breach.ID,
+		JobId:      breach.JobID,
+		Kind:       slaBreachKinds[breach.Kind],
+		RunId:      breach.RunID,
+		DetectedAt: timestampProto(breach.DetectedAt),
+	}
+	if breach.Kind == repository.SLABreachDuration {
+		res.Duration = ptypes.DurationProto(breach.Duration)
+	}
+	if breach.Deadline != nil {
+		res.Deadline = timestampProto(*breach.Deadline)
+	}
+	return res
+}
+
+// anomalyToProto converts a stored anomaly into its message
+func anomalyToProto(anomaly *repository.Anomaly) *model.Anomaly {
+	return &model.Anomaly{
+		Id:             anomaly.ID,
+		JobId:          anomaly.JobID,
+		RunId:          anomaly.RunID,
+		Duration:       ptypes.DurationProto(anomaly.Duration),
+		BaselineMean:   ptypes.DurationProto(anomaly.Mean),
+		BaselineStddev: ptypes.DurationProto(anomaly.Stddev),
+		BaselineRuns:   int32(anomaly.Samples),
+		Score:          anomaly.Score,
+		DetectedAt:     timestampProto(anomaly.DetectedAt),
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+)
+
+type AuditServiceServer struct {
+	Entries repository.AuditRepository
+}
+
+func (s *AuditServiceServer) ListAuditEntries(req *model.ListAuditEntriesReq, stream model.AuditService_ListAuditEntriesServer) error {
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	before := ""
+	if req.GetPageToken() != "" {
+		before, err = decodePageToken(req.GetPageToken())
+		if err != nil {
+			return invalidArgumentError("page_token", fmt.Sprintf("Invalid page token: %v", err))
+		}
+	}
+	// Callers only see the changes of the jobs they can see, their own and the ones of their teams
+	q := ownerQuery(stream.Context())
+	filter := repository.AuditFilter{
+		Actor: req.GetActor(),
+		JobID: req.GetJobId(),
+		Owner: q.Owner,
+		Teams: q.Teams,
+	}
+	entries, err := s.Entries.List(stream.Context(), filter, before, int(pageSize)+1)
+	if err == repository.ErrInvalidID {
+		return jobIDOrPageTokenError()
+	} else if err != nil {
+		return internalError(stream.Context(), err)
+	}
+
+	hasMore := len(entries) > int(pageSize)
+	if hasMore {
+		entries = entries[:pageSize]
+	}
+	for i, entry := range entries {
+		res := &model.ListAuditEntriesRes{Entry: auditEntryToProto(entry)}
+		if hasMore && i == len(entries)-1 {
+			res.NextPageToken = encodePageToken(entry.ID)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditEntryToProto converts a stored audit entry into the AuditEntry message sent to clients
+func auditEntryToProto(entry *repository.AuditEntry) *model.AuditEntry {
+	res := &model.AuditEntry{
+		Id:     entry.ID,
+		Time:   timestampProto(entry.Time),
+		Actor:  entry.Actor,
+		Method: entry.Method,
+		JobId:  entry.JobID,
+	}
+	for _, change := range entry.Changes {
+		res.Changes = append(res.Changes, &model.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return res
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// Limits of calendars
+const (
+	maxCalendarWindows  = 100
+	maxCalendarHolidays = 366
+	maxJobCalendars     = 10
+)
+
+type CalendarServiceServer struct {
+	Calendars repository.CalendarRepository
+	Jobs      repository.JobRepository
+	// Users and Teams check the owners calendars are assigned to like the owners of jobs
+	Users repository.UserRepository
+	Teams repository.TeamRepository
+	// Events stores an update event for every job whose calendars changed in the same transaction, none are stored
+	// when it's nil
+	Events EventStore
+	// Listeners are told about every job whose calendars changed
+	Listeners []JobListener
+}
+
+func (s *CalendarServiceServer) CreateCalendar(ctx context.Context, req *model.CreateCalendarReq) (*model.CreateCalendarRes, error) {
+	data, err := s.calendarFromProto(ctx, req.GetCalendar())
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = now()
+	data.UpdatedAt = data.CreatedAt
+	created, err := s.Calendars.Create(ctx, data)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.CreateCalendarRes{Calendar: calendarToProto(created)}, nil
+}
+
+func (s *CalendarServiceServer) ReadCalendar(ctx context.Context, req *model.ReadCalendarReq) (*model.ReadCalendarRes, error) {
+	calendar, err := s.Calendars.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, calendarError(ctx, err, req.GetId())
+	}
+	return &model.ReadCalendarRes{Calendar: calendarToProto(calendar)}, nil
+}
+
+func (s *CalendarServiceServer) UpdateCalendar(ctx context.Context, req *model.UpdateCalendarReq) (*model.UpdateCalendarRes, error) {
+	id := req.GetCalendar().GetId()
+	data, err := s.calendarFromProto(ctx, req.GetCalendar())
+	if err != nil {
+		return nil, err
+	}
+	data.ID = id
+	data.UpdatedAt = now()
+	updated, err := s.Calendars.Update(ctx, data, ownerQuery(ctx))
+	if err != nil {
+		return nil, calendarError(ctx, err, id)
+	}
+	return &model.UpdateCalendarRes{Calendar: calendarToProto(updated)}, nil
+}
+
+func (s *CalendarServiceServer) DeleteCalendar(ctx context.Context, req *model.DeleteCalendarReq) (*model.DeleteCalendarRes, error) {
+	if err := s.Calendars.Delete(ctx, req.GetId(), ownerQuery(ctx)); err != nil {
+		return nil, calendarError(ctx, err, req.GetId())
+	}
+	return &model.DeleteCalendarRes{}, nil
+}
+
+func (s *CalendarServiceServer) ListCalendars(req *model.ListCalendarsReq, stream model.CalendarService_ListCalendarsServer) error {
+	ctx := stream.Context()
+	calendars, err := s.Calendars.List(ctx, ownerQuery(ctx))
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, calendar := range calendars {
+		if err := stream.Send(&model.ListCalendarsRes{Calendar: calendarToProto(calendar)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CalendarServiceServer) SetJobCalendars(ctx context.Context, req *model.SetJobCalendarsReq) (*model.SetJobCalendarsRes, error) {
+	blackout, err := s.blackoutFromProto(ctx, req.GetBlackout())
+	if err != nil {
+		return nil, err
+	}
+	update := &repository.JobUpdate{SetBlackout: true, Blackout: blackout, UpdatedAt: now()}
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.Update(ctx, req.GetJobId(), ownerQuery(ctx), update)
+	})
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetJobId())
+	}
+	return &model.SetJobCalendarsRes{Job: jobToProto(updated)}, nil
+}
+
+// blackoutFromProto checks the calendars of a job sent by a client and converts them into their stored form, nil
+// without calendars. The caller must be able to read every calendar.
+func (s *CalendarServiceServer) blackoutFromProto(ctx context.Context, blackout *model.JobBlackout) (*repository.Blackout, error) {
+	ids := blackout.GetCalendarIds()
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > maxJobCalendars {
+		return nil, invalidArgumentError("blackout.calendar_ids", fmt.Sprintf("A job can have at most %d calendars, got %d",
+			maxJobCalendars, len(ids)))
+	}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return nil, invalidArgumentError("blackout.calendar_ids", fmt.Sprintf("Calendar %s is given twice", id))
+		}
+		seen[id] = true
+		_, err := s.Calendars.Get(ctx, id, ownerQuery(ctx))
+		switch err {
+		case nil:
+		case repository.ErrInvalidID:
+			return nil, invalidArgumentError("blackout.calendar_ids", fmt.Sprintf("Invalid Calendar id %q in calendar_ids", id))
+		case repository.ErrNotFound:
+			msg := fmt.Sprintf("Could not find Calendar with id %s", id)
+			return nil, newError(codes.FailedPrecondition, reasonCalendarMissing, msg,
+				&errdetails.ResourceInfo{ResourceType: calendarResource, ResourceName: id, Description: msg})
+		default:
+			return nil, internalError(ctx, err)
+		}
+	}
+	stored := &repository.Blackout{CalendarIDs: ids}
+	switch blackout.GetAction() {
+	case model.BlackoutAction_BLACKOUT_ACTION_UNSPECIFIED, model.BlackoutAction_BLACKOUT_ACTION_SKIP:
+	case model.BlackoutAction_BLACKOUT_ACTION_DEFER:
+		stored.Defer = true
+	default:
+		return nil, invalidArgumentError("blackout.action", fmt.Sprintf("Invalid action: %v", blackout.GetAction()))
+	}
+	return stored, nil
+}
+
+// calendarFromProto checks a calendar sent by a client and converts it into its stored form, without the fields set
+// by the server
+func (s *CalendarServiceServer) calendarFromProto(ctx context.Context, calendar *model.Calendar) (*repository.Calendar, error) {
+	blackouts, err := validateCalendar(calendar)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := ownerForCaller(ctx, s.Users, s.Teams, calendar.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	return &repository.Calendar{
+		Name:        calendar.GetName(),
+		Description: calendar.GetDescription(),
+		Owner:       owner,
+		Blackouts:   blackouts,
+	}, nil
+}
+
+// validateCalendar checks a calendar sent by a client and returns its blackouts
+func validateCalendar(calendar *model.Calendar) (scheduler.Blackouts, error) {
+	blackouts := scheduler.Blackouts{Timezone: calendar.GetTimezone()}
+	name := calendar.GetName()
+	if strings.TrimSpace(name) == "" {
+		return blackouts, invalidArgumentError("calendar.name", "Name must not be empty")
+	}
+	if msg := checkLength(name, maxNameLength); msg != "" {
+		return blackouts, invalidArgumentError("calendar.name", fmt.Sprintf("Name %s", msg))
+	}
+	if msg := checkControl(name, ""); msg != "" {
+		return blackouts, invalidArgumentError("calendar.name", fmt.Sprintf("Name %s", msg))
+	}
+	if msg := checkLength(calendar.GetDescription(), maxDescriptionLength); msg != "" {
+		return blackouts, invalidArgumentError("calendar.description", fmt.Sprintf("Description %s", msg))
+	}
+	if msg := checkControl(calendar.GetDescription(), "\n\t"); msg != "" {
+		return blackouts, invalidArgumentError("calendar.description", fmt.Sprintf("Description %s", msg))
+	}
+	if blackouts.Timezone != "" {
+		if _, err := time.LoadLocation(blackouts.Timezone); err != nil {
+			return blackouts, invalidArgumentError("calendar.timezone", fmt.Sprintf("Unknown timezone %q", blackouts.Timezone))
+		}
+	}
+	if len(calendar.GetWindows()) > maxCalendarWindows {
+		return blackouts, invalidArgumentError("calendar.windows", fmt.Sprintf("A calendar can have at most %d windows, got %d",
+			maxCalendarWindows, len(calendar.GetWindows())))
+	}
+	if len(calendar.GetHolidays()) > maxCalendarHolidays {
+		return blackouts, invalidArgumentError("calendar.holidays", fmt.Sprintf("A calendar can have at most %d holidays, got %d",
+			maxCalendarHolidays, len(calendar.GetHolidays())))
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, w := range calendar.GetWindows() {
+		field := fmt.Sprintf("calendar.windows[%d]", i)
+		window, err := windowFromProto(w)
+		if err == nil {
+			err = window.Validate()
+		}
+		if err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: fmt.Sprintf("Invalid window: %v", err)})
+			continue
+		}
+		if msg := checkLength(w.GetDescription(), maxNameLength); msg != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field + ".description",
+				Description: fmt.Sprintf("Description %s", msg)})
+			continue
+		}
+		blackouts.Windows = append(blackouts.Windows, *window)
+	}
+	for i, h := range calendar.GetHolidays() {
+		if _, err := time.Parse(scheduler.HolidayLayout, h); err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: fmt.Sprintf("calendar.holidays[%d]", i),
+				Description: fmt.Sprintf("Holiday %q must be a date like %s", h, scheduler.HolidayLayout)})
+			continue
+		}
+		blackouts.Holidays = append(blackouts.Holidays, h)
+	}
+	if len(violations) > 0 {
+		return blackouts, violationsError(fmt.Sprintf("Invalid calendar: %s", violations[0].GetDescription()), violations)
+	}
+	return blackouts, nil
+}
+
+// windowFromProto converts a window from a request into its stored form without validating it
+func windowFromProto(w *model.BlackoutWindow) (*scheduler.Window, error) {
+	window := &scheduler.Window{Cron: w.GetCron(), Description: w.GetDescription()}
+	var err error
+	if w.GetStartTime() != nil {
+		if window.Start, err = ptypes.Timestamp(w.GetStartTime()); err != nil {
+			return nil, fmt.Errorf("invalid start time: %v", err)
+		}
+	}
+	if w.GetEndTime() != nil {
+		if window.End, err = ptypes.Timestamp(w.GetEndTime()); err != nil {
+			return nil, fmt.Errorf("invalid end time: %v", err)
+		}
+	}
+	if w.GetDuration() != nil {
+		if window.Duration, err = ptypes.Duration(w.GetDuration()); err != nil {
+			return nil, fmt.Errorf("invalid duration: %v", err)
+		}
+	}
+	return window, nil
+}
+
+// calendarError converts an error of the calendar repository for the calendar with id into the status returned to
+// clients
+func calendarError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(calendarResource, id, fmt.Sprintf("Invalid Calendar id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(calendarResource, id, fmt.Sprintf("Could not find Calendar with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// calendarToProto converts a stored calendar into the Calendar message sent to clients
+func calendarToProto(calendar *repository.Calendar) *model.Calendar {
+	windows := make([]*model.BlackoutWindow, 0, len(calendar.Blackouts.Windows))
+	for _, w := range calendar.Blackouts.Windows {
+		window := &model.BlackoutWindow{Cron: w.Cron, Description: w.Description}
+		if w.Cron == "" {
+			window.StartTime = timestampProto(w.Start)
+			window.EndTime = timestampProto(w.End)
+		} else {
+			window.Duration = ptypes.DurationProto(w.Duration)
+		}
+		windows = append(windows, window)
+	}
+	return &model.Calendar{
+		Id:          calendar.ID,
+		Name:        calendar.Name,
+		Description: calendar.Description,
+		Owner:       calendar.Owner,
+		Timezone:    calendar.Blackouts.Timezone,
+		Windows:     windows,
+		Holidays:    calendar.Blackouts.Holidays,
+		CreatedAt:   timestampProto(calendar.CreatedAt),
+		UpdatedAt:   timestampProto(calendar.UpdatedAt),
+	}
+}
@@ -2,17 +2,17 @@ package services
 
 import (
 	"context"
-	"log"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/noltedennis/schedulytics-backend/model"
+	"go.uber.org/zap"
 )
 
 type HelloServiceServer struct{}
 
 // Functions of Hello service
 func newHelloSever() *HelloServiceServer {
-	log.Printf("Registered helloServiceServer handler")
+	zap.L().Info("Registered helloServiceServer handler")
 	return &HelloServiceServer{}
 }
 
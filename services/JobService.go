@@ -2,186 +2,1955 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/cache"
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/logging"
 	"github.com/noltedennis/schedulytics-backend/model"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"github.com/noltedennis/schedulytics-backend/quota"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/search"
+	"github.com/noltedennis/schedulytics-backend/team"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-type JobItem struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Name        string             `bson:"name"`
-	Owner       string             `bson:"owner"`
-	Description string             `bson:"description"`
+// jobToProto converts a stored job into the Job message sent to clients
+func jobToProto(j *repository.Job) *model.Job {
+	job := &model.Job{
+		Id:                j.ID,
+		Name:              j.Name,
+		Owner:             j.Owner,
+		Description:       j.Description,
+		CreatedAt:         timestampProto(j.CreatedAt),
+		UpdatedAt:         timestampProto(j.UpdatedAt),
+		Schedule:          scheduleToProto(j.Schedule),
+		Handler:           j.Handler,
+		Command:           j.Command,
+		Status:            model.JobStatus(model.JobStatus_value["JOB_STATUS_"+j.Status]),
+		RetryPolicy:       retryPolicyToProto(j.RetryPolicy),
+		Labels:            j.Labels,
+		DependsOn:         j.DependsOn,
+		Priority:          model.JobPriority(model.JobPriority_value["JOB_PRIORITY_"+j.Priority]),
+		Notifications:     notificationsToProto(j.Notifications),
+		ConcurrencyPolicy: model.ConcurrencyPolicy(model.ConcurrencyPolicy_value["CONCURRENCY_POLICY_"+j.ConcurrencyPolicy]),
+		CatchUpPolicy:     model.CatchUpPolicy(model.CatchUpPolicy_value["CATCH_UP_POLICY_"+j.CatchUpPolicy]),
+		Params:            paramsToProto(j.Params),
+		Environment:       j.Environment,
+	}
+	if j.Template != nil {
+		job.Template = &model.TemplateLineage{TemplateId: j.Template.TemplateID, Version: j.Template.Version, Parameters: j.Template.Parameters}
+	}
+	if j.Timeout != 0 {
+		job.Timeout = ptypes.DurationProto(j.Timeout)
+	}
+	if j.NextRunTime != nil {
+		job.NextRunTime = timestampProto(*j.NextRunTime)
+	}
+	if j.DeletedAt != nil {
+		job.DeletedAt = timestampProto(*j.DeletedAt)
+	}
+	if j.ArchivedAt != nil {
+		job.ArchivedAt = timestampProto(*j.ArchivedAt)
+	}
+	if j.Blackout != nil {
+		job.Blackout = &model.JobBlackout{CalendarIds: j.Blackout.CalendarIDs, Action: model.BlackoutAction_BLACKOUT_ACTION_SKIP}
+		if j.Blackout.Defer {
+			job.Blackout.Action = model.BlackoutAction_BLACKOUT_ACTION_DEFER
+		}
+	}
+	if p := j.Promotion; p != nil {
+		job.Promotion = &model.JobPromotion{
+			SourceJobId:       p.SourceJobID,
+			SourceEnvironment: p.SourceEnvironment,
+			Approval:          p.Approval,
+			PromotedBy:        p.PromotedBy,
+			PromotedAt:        timestampProto(p.PromotedAt),
+		}
+	}
+	return job
+}
+
+// paramsToProto converts the params of a job into a Struct, nil when there are none
+func paramsToProto(params map[string]interface{}) *structpb.Struct {
+	if len(params) == 0 {
+		return nil
+	}
+	// Params only hold JSON values, they always convert
+	s, _ := structpb.NewStruct(params)
+	return s
+}
+
+// retryPolicyFromProto converts a retry policy from a request into its stored form without validating it
+func retryPolicyFromProto(policy *model.RetryPolicy) (*scheduler.RetryPolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	stored := &scheduler.RetryPolicy{
+		MaxAttempts: int(policy.GetMaxAttempts()),
+		Multiplier:  policy.GetMultiplier(),
+		Jitter:      policy.GetJitter(),
+	}
+	for _, field := range []struct {
+		value  *duration.Duration
+		stored *time.Duration
+	}{
+		{policy.GetInitialBackoff(), &stored.InitialBackoff},
+		{policy.GetMaxBackoff(), &stored.MaxBackoff},
+	} {
+		if field.value == nil {
+			continue
+		}
+		d, err := ptypes.Duration(field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.stored = d
+	}
+	return stored, nil
+}
+
+// retryPolicyToProto converts a stored retry policy back into its message form
+func retryPolicyToProto(policy *scheduler.RetryPolicy) *model.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	res := &model.RetryPolicy{
+		MaxAttempts: int32(policy.MaxAttempts),
+		Multiplier:  policy.Multiplier,
+		Jitter:      policy.Jitter,
+	}
+	if policy.InitialBackoff != 0 {
+		res.InitialBackoff = ptypes.DurationProto(policy.InitialBackoff)
+	}
+	if policy.MaxBackoff != 0 {
+		res.MaxBackoff = ptypes.DurationProto(policy.MaxBackoff)
+	}
+	return res
+}
+
+// notificationsFromProto converts notification settings from a request into their stored form without validating
+// them, the threshold defaults to a single failed run
+func notificationsFromProto(settings *model.NotificationSettings) *repository.Notifications {
+	if settings == nil {
+		return nil
+	}
+	stored := &repository.Notifications{
+		FailureThreshold: int(settings.GetFailureThreshold()),
+		SlackWebhookURL:  settings.GetSlackWebhookUrl(),
+		EmailRecipients:  settings.GetEmailRecipients(),
+		Anomalies:        settings.GetAnomalies(),
+	}
+	if stored.FailureThreshold == 0 {
+		stored.FailureThreshold = 1
+	}
+	if len(stored.EmailRecipients) == 0 {
+		stored.EmailRecipients = nil
+	}
+	return stored
+}
+
+// notificationsToProto converts stored notification settings back into their message form
+func notificationsToProto(notifications *repository.Notifications) *model.NotificationSettings {
+	if notifications == nil {
+		return nil
+	}
+	return &model.NotificationSettings{
+		FailureThreshold: int32(notifications.FailureThreshold),
+		SlackWebhookUrl:  notifications.SlackWebhookURL,
+		EmailRecipients:  notifications.EmailRecipients,
+		Anomalies:        notifications.Anomalies,
+	}
+}
+
+// timestampProto converts t to a protobuf timestamp, jobs stored before timestamps existed get nil
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// now returns the current time with the millisecond precision MongoDB stores dates with
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
 }
 
 type JobServiceServer struct {
-	JobDb    *mongo.Collection
-	MongoCtx context.Context
+	Jobs repository.JobRepository
+	// Cache serves ReadJob, jobs are read from Jobs when it's nil. Jobs must be the same cache, so it drops the jobs
+	// that are changed.
+	Cache *cache.Jobs
+	// Executor is used to check that a job's handler exists and runs triggered jobs. Handlers aren't checked when it's
+	// nil and jobs can't be triggered.
+	Executor *executor.Executor
+	// ImportBatchSize is the number of jobs ImportJobs stores at once, defaultImportBatchSize when not set
+	ImportBatchSize int
+	// Events stores the events of created, updated and deleted jobs in the transaction of the change, none are stored
+	// when it's nil
+	Events EventStore
+	// Listeners are told about every created, updated and deleted job once the change is stored, like the webhooks
+	Listeners []JobListener
+	// Users are looked up for the owners admins assign jobs to, owners aren't checked when it's nil
+	Users repository.UserRepository
+	// Teams are looked up for the teams admins assign jobs to, teams aren't checked when it's nil
+	Teams repository.TeamRepository
+	// Maintenance is checked before jobs are triggered, they can't be while it's enabled. Triggers aren't checked
+	// when it's nil.
+	Maintenance repository.MaintenanceRepository
+	// Environments are checked before jobs in an environment are triggered, they can't be while it's paused.
+	// Environments aren't checked when it's nil.
+	Environments repository.EnvironmentRepository
+	// Changes keeps the updates of jobs in protected environments until they are approved. Jobs are updated right
+	// away when it or Environments is nil.
+	Changes repository.ChangeRepository
+	// Revisions keeps the versions of jobs before their updates, updates aren't recorded when it's nil
+	Revisions repository.RevisionRepository
+	// Quotas limits the jobs of owners and tenants and how often the jobs of owners are triggered, nothing is limited
+	// when it's nil
+	Quotas *quota.Quotas
 }
 
+// defaultImportBatchSize is used by ImportJobs when no batch size is configured
+const defaultImportBatchSize = 500
+
 func newJobSever() *JobServiceServer {
-	log.Printf("Registered JobServiceServer handler")
+	zap.L().Info("Registered JobServiceServer handler")
 	return &JobServiceServer{}
 }
 
 func (s *JobServiceServer) CreateJob(ctx context.Context, req *model.CreateJobReq) (*model.CreateJobRes, error) {
 	// Essentially doing req.Job to access the struct with a nil check
-	Job := req.GetJob()
-	// Now we have to convert this into a JobItem type to convert into BSON
-	data := JobItem{
-		// ID:    Empty, so it gets omitted and MongoDB generates a unique Object ID upon insertion.
-		Name:        Job.GetName(),
-		Owner:       Job.GetOwner(),
-		Description: Job.GetDescription(),
+	return s.createJob(ctx, req.GetJob(), req.GetIdempotencyKey(), nil, nil, req.GetValidateOnly())
+}
+
+// createJob creates job like CreateJob with the idempotency key, lineage is the template the job is created from and
+// nil for jobs that aren't. Blackout holds the calendars of the job a clone is created from, clients can't set them on
+// new jobs. With validateOnly the job is only checked and returned as it would be created.
+func (s *JobServiceServer) createJob(ctx context.Context, job *model.Job, key string, lineage *repository.TemplateLineage, blackout *repository.Blackout, validateOnly bool) (*model.CreateJobRes, error) {
+	data, err := s.newJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	data.Template = lineage
+	data.Blackout = blackout
+	if msg := checkLength(key, maxIdempotencyKeyLength); msg != "" {
+		return nil, invalidArgumentError("idempotency_key", fmt.Sprintf("Invalid idempotency key: %s", msg))
+	}
+	// A retried call gets the job the first call with its key created
+	if key != "" {
+		if res, err := s.createdJob(ctx, data.Owner, key); res != nil || err != nil {
+			return res, err
+		}
+	}
+	data.IdempotencyKey = key
+	if err := s.checkJobQuota(ctx, data.Owner, 0, 0); err != nil {
+		return nil, err
+	}
+	if validateOnly {
+		// The unique index isn't asked, a job created concurrently can still take the name
+		if err := s.checkNameFree(ctx, data.Owner, data.Environment, data.Name, ""); err != nil {
+			return nil, err
+		}
+		return &model.CreateJobRes{Job: jobToProto(data)}, nil
 	}
 
-	// Insert the data into the database, result contains the newly generated Object ID for the new document
-	result, err := s.JobDb.InsertOne(s.MongoCtx, data)
+	// Insert the data into the database with the request's context, so cancellation and deadlines of the client apply.
+	// The returned job contains the newly generated ID.
+	created, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobCreated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.Create(ctx, data)
+	})
+	if key != "" && (err == repository.ErrIdempotencyKeyUsed || err == repository.ErrNameTaken) {
+		// A call with the same key was faster, its job has the same name too
+		if res, err := s.createdJob(ctx, data.Owner, key); res != nil || err != nil {
+			return res, err
+		}
+	}
+	if err == repository.ErrNameTaken {
+		return nil, nameTakenError(data.Name)
+	}
 	// check for potential errors
 	if err != nil {
 		// return internal gRPC error to be handled later
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
-		)
+		return nil, internalError(ctx, err)
 	}
-	// add the id to Job, first cast the "generic type" (go doesn't have real generics yet) to an Object ID.
-	oid := result.InsertedID.(primitive.ObjectID)
-	// Convert the object id to it's string counterpart
-	Job.Id = oid.Hex()
-	// return the Job in a CreateJobRes type
-	return &model.CreateJobRes{Job: Job}, nil
+	// return the stored Job in a CreateJobRes type
+	return &model.CreateJobRes{Job: jobToProto(created)}, nil
 }
 
-func (s *JobServiceServer) ReadJob(ctx context.Context, req *model.ReadJobReq) (*model.ReadJobRes, error) {
-	// convert string id (from proto) to mongoDB ObjectId
-	oid, err := primitive.ObjectIDFromHex(req.GetId())
+// createdJob returns the response for the job owner created with key, nil if there is none
+func (s *JobServiceServer) createdJob(ctx context.Context, owner, key string) (*model.CreateJobRes, error) {
+	job, err := s.Jobs.GetByIdempotencyKey(ctx, owner, key)
+	if err == repository.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.CreateJobRes{Job: jobToProto(job)}, nil
+}
+
+// newJob checks a job sent by a client and converts it into the stored form of a new job
+func (s *JobServiceServer) newJob(ctx context.Context, job *model.Job) (*repository.Job, error) {
+	if err := validateJob(job); err != nil {
+		return nil, err
+	}
+	if err := s.checkHandler(job.GetHandler()); err != nil {
+		return nil, err
+	}
+	if err := s.checkParams(job.GetHandler(), jobParams(job)); err != nil {
+		return nil, err
+	}
+	// Callers can only create jobs for themselves, the owner defaults to the caller
+	owner, err := ownerForCaller(ctx, s.Users, s.Teams, job.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	// Validate the optional schedule and work out when the job runs first
+	spec, next, err := scheduleFields(job.GetSchedule())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("Could not convert to ObjectId: %v", err))
+		return nil, invalidArgumentError("job.schedule", fmt.Sprintf("Invalid schedule: %v", err))
+	}
+	if err := s.checkDependencies(ctx, "", spec, jobDependsOn(job)); err != nil {
+		return nil, err
+	}
+	// Now we have to convert this into the stored form of a Job
+	createdAt := now()
+	data := &repository.Job{
+		// ID: Empty, the repository generates a unique ID upon insertion.
+		Name:        job.GetName(),
+		Owner:       owner,
+		Description: job.GetDescription(),
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		Schedule:    spec,
+		NextRunTime: next,
+		Handler:     job.GetHandler(),
+		Command:     job.GetCommand(),
+		// validateJob already made sure the policy converts
+		RetryPolicy:       retryPolicy(job),
+		Timeout:           timeout(job),
+		Labels:            jobLabels(job),
+		DependsOn:         jobDependsOn(job),
+		Priority:          jobPriority(job),
+		ConcurrencyPolicy: jobConcurrencyPolicy(job),
+		CatchUpPolicy:     jobCatchUpPolicy(job),
+		Notifications:     notificationsFromProto(job.GetNotifications()),
+		Params:            jobParams(job),
+		Environment:       job.GetEnvironment(),
+		// Clients can't choose the status, every job starts out pending
+		Status: repository.JobPending,
+	}
+	return data, nil
+}
+
+func (s *JobServiceServer) ImportJobs(stream model.JobService_ImportJobsServer) error {
+	ctx := stream.Context()
+	batchSize := s.ImportBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+	res := &model.ImportJobsRes{}
+	fail := func(index int32, message string) {
+		res.Errors = append(res.Errors, &model.ImportJobError{Index: index, Message: message})
+	}
+
+	// batch holds the jobs waiting to be stored, indexes their position in the stream and owners counts them by owner
+	batch := make([]*repository.Job, 0, batchSize)
+	indexes := make([]int32, 0, batchSize)
+	owners := map[string]int64{}
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		created, err := s.createMany(ctx, batch)
+		if batchErr, ok := err.(*repository.BatchError); ok {
+			for i, index := range indexes {
+				if itemErr, failed := batchErr.Errors[i]; itemErr == repository.ErrNameTaken {
+					fail(index, status.Convert(nameTakenError(batch[i].Name)).Message())
+				} else if failed {
+					fail(index, storeError(ctx, index, itemErr))
+				}
+			}
+		} else if err != nil {
+			for _, index := range indexes {
+				fail(index, storeError(ctx, index, err))
+			}
+		}
+		for _, job := range created {
+			if job != nil {
+				res.ImportedCount++
+			}
+		}
+		batch = batch[:0]
+		indexes = indexes[:0]
+		owners = map[string]int64{}
+	}
+
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		// Invalid jobs are reported and skipped, they don't abort the import
+		data, err := s.newJob(ctx, req.GetJob())
+		if err == nil {
+			// The jobs of the batch aren't stored yet, they count against the quotas nonetheless
+			err = s.checkJobQuota(ctx, data.Owner, owners[data.Owner], int64(len(batch)))
+		}
+		if err != nil {
+			fail(index, status.Convert(err).Message())
+			continue
+		}
+		owners[data.Owner]++
+		batch = append(batch, data)
+		indexes = append(indexes, index)
+		if len(batch) == batchSize {
+			flush()
+		}
+	}
+	flush()
+	// Errors of the validation and of the batches are collected separately
+	sort.Slice(res.Errors, func(i, j int) bool { return res.Errors[i].Index < res.Errors[j].Index })
+	logging.FromContext(ctx).Info("Imported jobs", zap.Int32("imported", res.ImportedCount), zap.Int("failed", len(res.Errors)))
+	return stream.SendAndClose(res)
+}
+
+// storeError logs why the job at index of an import couldn't be stored and returns the message reported for it
+func storeError(ctx context.Context, index int32, err error) string {
+	logging.FromContext(ctx).Error("Could not store imported job", zap.Int32("index", index), zap.Error(err))
+	return "Could not store job"
+}
+
+// createMany stores a batch of imported jobs like CreateMany of the repository and tells the listeners about the
+// created ones. With events the batch and its events are stored in one transaction, which a single failing job rolls
+// back as a whole, so the jobs of such a batch are stored one at a time then.
+func (s *JobServiceServer) createMany(ctx context.Context, jobs []*repository.Job) ([]*repository.Job, error) {
+	var created []*repository.Job
+	var err error
+	if s.Events == nil {
+		created, err = s.Jobs.CreateMany(ctx, jobs)
+	} else {
+		err = s.Events.InTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if created, err = s.Jobs.CreateMany(ctx, jobs); err != nil {
+				return err
+			}
+			for _, job := range created {
+				if err := s.Events.JobChanged(ctx, webhook.JobCreated, job); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			return s.createEach(ctx, jobs)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	for _, job := range created {
+		if job != nil {
+			jobChanged(ctx, s.Listeners, webhook.JobCreated, job)
+		}
+	}
+	return created, err
+}
+
+// createEach stores the jobs and their events one at a time and reports the ones that couldn't be stored in a
+// *repository.BatchError
+func (s *JobServiceServer) createEach(ctx context.Context, jobs []*repository.Job) ([]*repository.Job, error) {
+	created := make([]*repository.Job, len(jobs))
+	batchErr := &repository.BatchError{Errors: map[int]error{}}
+	for i, job := range jobs {
+		stored, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobCreated, func(ctx context.Context) (*repository.Job, error) {
+			return s.Jobs.Create(ctx, job)
+		})
+		if err != nil {
+			batchErr.Errors[i] = err
+			continue
+		}
+		created[i] = stored
+	}
+	if len(batchErr.Errors) > 0 {
+		return created, batchErr
+	}
+	return created, nil
+}
+
+func (s *JobServiceServer) ReadJob(ctx context.Context, req *model.ReadJobReq) (*model.ReadJobRes, error) {
+	// Jobs of other owners are reported as not found, deleted jobs too unless they are asked for
+	q := ownerQuery(ctx)
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	var err error
+	if q.Fields, err = readFields(req.GetReadMask()); err != nil {
+		return nil, invalidArgumentError("read_mask", fmt.Sprintf("Invalid read mask: %v", err))
+	}
+	get := s.Jobs.Get
+	if s.Cache != nil {
+		get = s.Cache.Read
 	}
-	result := s.JobDb.FindOne(ctx, bson.M{"_id": oid})
-	// Create an empty JobItem to write our decode result to
-	data := JobItem{}
-	// decode and write to data
-	if err := result.Decode(&data); err != nil {
-		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("Could not find Job with Object Id %s: %v", req.GetId(), err))
+	data, err := get(ctx, req.GetId(), q)
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetId())
 	}
 	// Cast to ReadJobRes type
 	response := &model.ReadJobRes{
-		Job: &model.Job{
-			Id:          oid.Hex(),
-			Name:        data.Name,
-			Owner:       data.Owner,
-			Description: data.Description,
-		},
+		Job: projectJob(jobToProto(data), q.Fields),
 	}
 	return response, nil
 }
 
-func (s *JobServiceServer) DeleteJob(ctx context.Context, req *model.DeleteJobReq) (*model.DeleteJobRes, error) {
-	// Get the ID (string) from the request message and convert it to an Object ID
-	oid, err := primitive.ObjectIDFromHex(req.GetId())
-	// Check for errors
+// maxBatchGetJobs is the number of ids a BatchGetJobs request may contain
+const maxBatchGetJobs = 1000
+
+func (s *JobServiceServer) BatchGetJobs(ctx context.Context, req *model.BatchGetJobsReq) (*model.BatchGetJobsRes, error) {
+	if len(req.GetIds()) > maxBatchGetJobs {
+		return nil, invalidArgumentError("ids", fmt.Sprintf("At most %d jobs can be read at once", maxBatchGetJobs))
+	}
+	q := ownerQuery(ctx)
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	var err error
+	if q.Fields, err = readFields(req.GetReadMask()); err != nil {
+		return nil, invalidArgumentError("read_mask", fmt.Sprintf("Invalid read mask: %v", err))
+	}
+	found, err := s.Jobs.GetMany(ctx, req.GetIds(), q)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	jobs := make(map[string]*repository.Job, len(found))
+	for _, job := range found {
+		jobs[job.ID] = job
+	}
+	// Ids requested several times are answered once
+	res := &model.BatchGetJobsRes{Jobs: make([]*model.Job, 0, len(found))}
+	seen := map[string]bool{}
+	for _, id := range req.GetIds() {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if job, ok := jobs[id]; ok {
+			res.Jobs = append(res.Jobs, projectJob(jobToProto(job), q.Fields))
+		} else {
+			res.MissingIds = append(res.MissingIds, id)
+		}
+	}
+	return res, nil
+}
+
+func (s *JobServiceServer) CountJobs(ctx context.Context, req *model.CountJobsReq) (*model.CountJobsRes, error) {
+	// Callers count their own jobs, archived ones separately like ListJobs lists them
+	q := ownerQuery(ctx)
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	var err error
+	q.Labels, err = labels.Parse(req.GetLabelSelector())
+	if err != nil {
+		return nil, invalidArgumentError("label_selector", fmt.Sprintf("Invalid label selector: %v", err))
+	}
+	q.Archived = repository.WithoutArchived
+	if req.GetArchived() {
+		q.Archived = repository.OnlyArchived
+	}
+	if q.Environment = req.GetEnvironment(); q.Environment != "" {
+		if msg := checkEnvironment(q.Environment); msg != "" {
+			return nil, invalidArgumentError("environment", fmt.Sprintf("Environment %s", msg))
+		}
+	}
+	count, err := s.Jobs.Count(ctx, q)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("Could not convert to ObjectId: %v", err))
+		return nil, internalError(ctx, err)
 	}
-	// DeleteOne returns DeleteResult which is a struct containing the amount of deleted docs (in this case only 1 always)
-	// So we return a boolean instead
-	_, err = s.JobDb.DeleteOne(ctx, bson.M{"_id": oid})
-	// Check for errors
+	return &model.CountJobsRes{Count: count}, nil
+}
+
+func (s *JobServiceServer) JobExists(ctx context.Context, req *model.JobExistsReq) (*model.JobExistsRes, error) {
+	q := ownerQuery(ctx)
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	exists, err := s.Jobs.Exists(ctx, req.GetId(), q)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("Could not find/delete Job with id %s: %v", req.GetId(), err))
+		return nil, jobError(ctx, err, req.GetId())
+	}
+	return &model.JobExistsRes{Exists: exists}, nil
+}
+
+func (s *JobServiceServer) DeleteJob(ctx context.Context, req *model.DeleteJobReq) (*model.DeleteJobRes, error) {
+	if err := s.deleteJob(ctx, req.GetId()); err != nil {
+		return nil, err
 	}
-	// Return response with success: true if no error is thrown (and thus document is removed)
+	// Return response with success: true, a job was actually deleted
 	return &model.DeleteJobRes{
 		Success: true,
 	}, nil
 }
 
-func (s *JobServiceServer) UpdateJob(ctx context.Context, req *model.UpdateJobReq) (*model.UpdateJobRes, error) {
-	// Get the Job data from the request
-	Job := req.GetJob()
+// maxDeleteJobs is the number of ids a DeleteJobs request may contain
+const maxDeleteJobs = 1000
+
+func (s *JobServiceServer) DeleteJobs(ctx context.Context, req *model.DeleteJobsReq) (*model.DeleteJobsRes, error) {
+	if len(req.GetIds()) > maxDeleteJobs {
+		return nil, invalidArgumentError("ids", fmt.Sprintf("At most %d jobs can be deleted at once", maxDeleteJobs))
+	}
+	res := &model.DeleteJobsRes{Results: make([]*model.DeleteJobResult, 0, len(req.GetIds()))}
+	for _, id := range req.GetIds() {
+		result := &model.DeleteJobResult{Id: id, Success: true}
+		if err := s.deleteJob(ctx, id); err != nil {
+			// Stop once the client is gone, the remaining jobs would fail the same way
+			if ctx.Err() != nil {
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+			result.Success = false
+			result.Error = status.Convert(err).Message()
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// deleteJob deletes the caller's job with the given id, it is kept until it's purged.
+// Jobs that don't exist or were deleted already are reported as not found.
+func (s *JobServiceServer) deleteJob(ctx context.Context, id string) error {
+	_, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobDeleted, func(ctx context.Context) (*repository.Job, error) {
+		// The event carries the job as it was before it was deleted
+		var job *repository.Job
+		if s.Events != nil || len(s.Listeners) > 0 {
+			job, _ = s.Jobs.Get(ctx, id, ownerQuery(ctx))
+		}
+		// Delete reports whether a job was deleted
+		deleted, err := s.Jobs.Delete(ctx, id, ownerQuery(ctx), now())
+		if err != nil {
+			return nil, err
+		}
+		if !deleted {
+			return nil, repository.ErrNotFound
+		}
+		return job, nil
+	})
+	if err != nil {
+		return jobError(ctx, err, id)
+	}
+	return nil
+}
+
+func (s *JobServiceServer) RestoreJob(ctx context.Context, req *model.RestoreJobReq) (*model.RestoreJobRes, error) {
+	// Restored jobs count against the quotas again, jobs that aren't deleted are reported as not found by Restore
+	if s.Quotas != nil {
+		q := ownerQuery(ctx)
+		q.IncludeDeleted = true
+		if job, err := s.Jobs.Get(ctx, req.GetId(), q); err != nil {
+			return nil, jobError(ctx, err, req.GetId())
+		} else if job.DeletedAt != nil {
+			if err := s.checkJobQuota(ctx, job.Owner, 0, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Only deleted jobs of the caller can be restored, everything else is reported as not found
+	restored, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.Restore(ctx, req.GetId(), ownerQuery(ctx), now())
+	})
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetId())
+	}
+	return &model.RestoreJobRes{Job: jobToProto(restored)}, nil
+}
+
+// jobTransitions lists the statuses a job must have to be moved to another status by a client
+var jobTransitions = map[string][]string{
+	repository.JobPaused:    {repository.JobPending, repository.JobSucceeded, repository.JobFailed, repository.JobCancelled},
+	repository.JobPending:   {repository.JobPaused},
+	repository.JobCancelled: {repository.JobRunning},
+}
+
+func (s *JobServiceServer) PauseJob(ctx context.Context, req *model.PauseJobReq) (*model.PauseJobRes, error) {
+	job, err := s.transition(ctx, req.GetId(), repository.JobPaused, "paused")
+	if err != nil {
+		return nil, err
+	}
+	return &model.PauseJobRes{Job: job}, nil
+}
+
+func (s *JobServiceServer) ResumeJob(ctx context.Context, req *model.ResumeJobReq) (*model.ResumeJobRes, error) {
+	job, err := s.transition(ctx, req.GetId(), repository.JobPending, "resumed")
+	if err != nil {
+		return nil, err
+	}
+	return &model.ResumeJobRes{Job: job}, nil
+}
+
+func (s *JobServiceServer) CancelJob(ctx context.Context, req *model.CancelJobReq) (*model.CancelJobRes, error) {
+	// Change the status first, so the outcome of the stopped run doesn't overwrite it
+	job, err := s.transition(ctx, req.GetId(), repository.JobCancelled, "cancelled")
+	if err != nil {
+		return nil, err
+	}
+	// The run may be executed by another replica, its outcome is ignored then but it isn't stopped
+	if s.Executor != nil {
+		s.Executor.Cancel(req.GetId())
+	}
+	return &model.CancelJobRes{Job: job}, nil
+}
+
+func (s *JobServiceServer) TriggerJob(ctx context.Context, req *model.TriggerJobReq) (*model.TriggerJobRes, error) {
+	if s.Executor == nil {
+		return nil, newError(codes.Unavailable, reasonTriggerDisabled, "Jobs can't be triggered on this server")
+	}
+	if s.Maintenance != nil {
+		m, err := s.Maintenance.Maintenance(ctx)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		if m.Enabled {
+			return nil, newError(codes.FailedPrecondition, reasonMaintenance, maintenanceMessage(m))
+		}
+	}
+	job, err := s.Jobs.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetId())
+	}
+	// The executor doesn't run paused jobs, so don't record a run that fails right away
+	if job.Status == repository.JobPaused {
+		return nil, newError(codes.FailedPrecondition, reasonJobStatus, fmt.Sprintf("Job %s is %s, resume it before triggering it", job.ID, job.Status))
+	}
+	if job.ArchivedAt != nil {
+		return nil, newError(codes.FailedPrecondition, reasonJobStatus, fmt.Sprintf("Job %s is archived, unarchive it before triggering it", job.ID))
+	}
+	if job.Environment != "" && s.Environments != nil {
+		e, err := s.Environments.Get(ctx, job.Environment)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		if e.Paused {
+			return nil, newError(codes.FailedPrecondition, reasonEnvironmentPaused, environmentPausedMessage(e))
+		}
+	}
+	if err := s.checkRunQuota(ctx, job.Owner); err != nil {
+		return nil, err
+	}
+	triggeredBy := ""
+	if claims, ok := auth.FromContext(ctx); ok {
+		triggeredBy = claims.Subject
+	}
+	// The run is recorded before it is queued, a full queue fails it right away
+	runID, err := s.Executor.Submit(ctx, job.ID, job.Priority, repository.TriggerManual, triggeredBy)
+	if err == executor.ErrQueueFull {
+		return nil, newError(codes.ResourceExhausted, reasonQueueFull, fmt.Sprintf("Executor queue is full, run %s of job %s failed", runID, job.ID))
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	logging.FromContext(ctx).Info("Triggered job", zap.String("job_id", job.ID), zap.String("run_id", runID))
+	return &model.TriggerJobRes{RunId: runID}, nil
+}
+
+func (s *JobServiceServer) ArchiveJob(ctx context.Context, req *model.ArchiveJobReq) (*model.ArchiveJobRes, error) {
+	job, err := s.setArchived(ctx, req.GetId(), true)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ArchiveJobRes{Job: jobToProto(job)}, nil
+}
+
+func (s *JobServiceServer) UnarchiveJob(ctx context.Context, req *model.UnarchiveJobReq) (*model.UnarchiveJobRes, error) {
+	job, err := s.setArchived(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UnarchiveJobRes{Job: jobToProto(job)}, nil
+}
+
+// setArchived archives or unarchives the caller's job with the given id. Jobs that are archived or not already are
+// returned unchanged, archiving the same job twice keeps the time it was archived first.
+func (s *JobServiceServer) setArchived(ctx context.Context, id string, archive bool) (*repository.Job, error) {
+	q := ownerQuery(ctx)
+	job, err := s.Jobs.Get(ctx, id, q)
+	if err != nil {
+		return nil, jobError(ctx, err, id)
+	}
+	if (job.ArchivedAt != nil) == archive {
+		return job, nil
+	}
+	if archive && job.Status == repository.JobRunning {
+		return nil, newError(codes.FailedPrecondition, reasonJobStatus, fmt.Sprintf("Job %s is %s, only jobs that aren't running can be archived",
+			id, job.Status))
+	}
+	return s.archive(ctx, job, q, archive)
+}
 
-	// Convert the Id string to a MongoDB ObjectId
-	oid, err := primitive.ObjectIDFromHex(Job.GetId())
+// archive archives or unarchives job, which was read with q. Unarchived jobs with a schedule run next at their next
+// scheduled time from now on instead of catching up on the runs they missed.
+func (s *JobServiceServer) archive(ctx context.Context, job *repository.Job, q repository.Query, archive bool) (*repository.Job, error) {
+	update := &repository.JobUpdate{SetArchivedAt: true, UpdatedAt: now()}
+	if archive {
+		update.ArchivedAt = &update.UpdatedAt
+	} else if job.Schedule != nil {
+		next, err := job.Schedule.Next(time.Now())
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		update.SetSchedule = true
+		update.Schedule = job.Schedule
+		update.NextRunTime = &next
+	}
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.Update(ctx, job.ID, q, update)
+	})
+	if err != nil {
+		return nil, jobError(ctx, err, job.ID)
+	}
+	return updated, nil
+}
+
+func (s *JobServiceServer) ArchiveJobs(ctx context.Context, req *model.ArchiveJobsReq) (*model.ArchiveJobsRes, error) {
+	if req.GetLabelSelector() == "" && len(req.GetStatuses()) == 0 && !req.GetUnscheduled() {
+		return nil, invalidArgumentError("label_selector", "At least a label selector, a status or unscheduled must be given")
+	}
+	statuses := map[string]bool{}
+	for _, st := range req.GetStatuses() {
+		if st == model.JobStatus_JOB_STATUS_UNSPECIFIED {
+			return nil, invalidArgumentError("statuses", "Statuses must not be unspecified")
+		}
+		statuses[strings.TrimPrefix(st.String(), "JOB_STATUS_")] = true
+	}
+	q := ownerQuery(ctx)
+	q.Archived = repository.WithoutArchived
+	var err error
+	q.Labels, err = labels.Parse(req.GetLabelSelector())
 	if err != nil {
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			fmt.Sprintf("Could not convert the supplied Job id to a MongoDB ObjectId: %v", err),
-		)
+		return nil, invalidArgumentError("label_selector", fmt.Sprintf("Invalid label selector: %v", err))
+	}
+	// Collect the matching jobs first, so archiving them doesn't interfere with the paging
+	var matching []*repository.Job
+	for after := ""; ; {
+		page, err := s.Jobs.List(ctx, q, after, maxPageSize)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		for _, job := range page {
+			if job.Status != repository.JobRunning && (len(statuses) == 0 || statuses[job.Status]) && (!req.GetUnscheduled() || job.Schedule == nil) {
+				matching = append(matching, job)
+			}
+		}
+		if len(page) < maxPageSize {
+			break
+		}
+		after = page[len(page)-1].ID
+	}
+	res := &model.ArchiveJobsRes{Ids: make([]string, 0, len(matching))}
+	for _, job := range matching {
+		// Jobs deleted in the meantime are skipped
+		if _, err := s.archive(ctx, job, ownerQuery(ctx), true); status.Code(err) == codes.NotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res.Ids = append(res.Ids, job.ID)
 	}
+	return res, nil
+}
 
-	// Convert the data to be updated into an unordered Bson document
-	update := bson.M{
-		"name":        Job.GetName(),
-		"owner":       Job.GetOwner(),
-		"description": Job.GetDescription(),
+// transition moves the caller's job with the given id to status to, if jobTransitions allows it from its current status.
+// action describes the transition in errors.
+func (s *JobServiceServer) transition(ctx context.Context, id, to, action string) (*model.Job, error) {
+	q := ownerQuery(ctx)
+	job, err := s.Jobs.Get(ctx, id, q)
+	if err != nil {
+		return nil, jobError(ctx, err, id)
+	}
+	allowed := false
+	for _, from := range jobTransitions[to] {
+		allowed = allowed || job.Status == from
 	}
+	if !allowed {
+		return nil, newError(codes.FailedPrecondition, reasonJobStatus, fmt.Sprintf("Job %s is %s, only %s jobs can be %s",
+			id, job.Status, strings.Join(jobTransitions[to], ", "), action))
+	}
+	// The status only changes if nobody changed it since we read it
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.SetStatus(ctx, id, q, job.Status, to, now())
+	})
+	if err == repository.ErrStatusConflict {
+		return nil, newError(codes.Aborted, reasonConcurrentChange, fmt.Sprintf("Status of job %s changed concurrently, try again", id))
+	} else if err != nil {
+		return nil, jobError(ctx, err, id)
+	}
+	return jobToProto(updated), nil
+}
 
-	// Convert the oid into an unordered bson document to search by id
-	filter := bson.M{"_id": oid}
+func (s *JobServiceServer) UpdateJob(ctx context.Context, req *model.UpdateJobReq) (*model.UpdateJobRes, error) {
+	// Get the Job data from the request
+	Job := req.GetJob()
 
-	// Result is the BSON encoded result
-	// To return the updated document instead of original we have to add options.
-	result := s.JobDb.FindOneAndUpdate(ctx, filter, bson.M{"$set": update}, options.FindOneAndUpdate().SetReturnDocument(1))
+	update, err := s.jobUpdate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// Only admins can hand a job over to another owner
+	if update.Owner != nil {
+		owner, err := ownerForCaller(ctx, s.Users, s.Teams, *update.Owner)
+		if err != nil {
+			return nil, err
+		}
+		update.Owner = &owner
+	}
+	if req.GetValidateOnly() {
+		return s.validateUpdate(ctx, Job.GetId(), update)
+	}
+	// Updates of jobs in protected environments wait for a second person's approval
+	if s.Changes != nil && s.Environments != nil {
+		stored, err := s.Jobs.Get(ctx, Job.GetId(), ownerQuery(ctx))
+		if err != nil {
+			return nil, jobError(ctx, err, Job.GetId())
+		}
+		protected, err := s.protectedEnvironment(ctx, stored, update)
+		if err != nil {
+			return nil, err
+		}
+		if protected != nil {
+			change, err := s.requestChange(ctx, req, stored, update)
+			if err != nil {
+				return nil, err
+			}
+			return &model.UpdateJobRes{Job: jobToProto(stored), Change: change}, nil
+		}
+	}
+	update.UpdatedAt = now()
 
-	// Decode result and write it to 'decoded'
-	decoded := JobItem{}
-	err = result.Decode(&decoded)
+	// The update only applies to the caller's jobs and returns the updated job
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.updateRevised(ctx, Job.GetId(), update)
+	})
 	if err != nil {
-		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Could not find Job with supplied ID: %v", err),
-		)
+		return nil, updateError(ctx, err, Job.GetId(), update)
 	}
 	return &model.UpdateJobRes{
-		Job: &model.Job{
-			Id:          decoded.ID.Hex(),
-			Name:        decoded.Name,
-			Owner:       decoded.Owner,
-			Description: decoded.Description,
-		},
+		Job: jobToProto(updated),
 	}, nil
 }
 
-func (s *JobServiceServer) ListJobs(req *model.ListJobsReq, stream model.JobService_ListJobsServer) error {
-	// Initiate a JobItem type to write decoded data to
-	data := &JobItem{}
-	// collection.Find returns a cursor for our (empty) query
-	cursor, err := s.JobDb.Find(context.Background(), bson.M{})
-	if err != nil {
-		return status.Errorf(codes.Internal, fmt.Sprintf("Unknown internal error: %v", err))
-	}
-	// An expression with defer will be called at the end of the function
-	defer cursor.Close(context.Background())
-	// cursor.Next() returns a boolean, if false there are no more items and loop will break
-	for cursor.Next(context.Background()) {
-		// Decode the data at the current pointer and write it to data
-		err := cursor.Decode(data)
-		// check error
-		if err != nil {
-			return status.Errorf(codes.Unavailable, fmt.Sprintf("Could not decode data: %v", err))
-		}
-		// If no error is found send Job over stream
-		stream.Send(&model.ListJobsRes{
-			Job: &model.Job{
-				Id:          data.ID.Hex(),
-				Name:        data.Name,
-				Owner:       data.Owner,
-				Description: data.Description,
-			},
-		})
+// jobUpdate converts the job of req into a JobUpdate, only containing the masked fields, and checks them against the
+// stored job. The owner isn't checked, who may hand jobs over depends on the call.
+func (s *JobServiceServer) jobUpdate(ctx context.Context, req *model.UpdateJobReq) (*repository.JobUpdate, error) {
+	Job := req.GetJob()
+	update, err := updateFromMask(Job, req.GetUpdateMask())
+	if err != nil {
+		return nil, invalidArgumentError("update_mask", fmt.Sprintf("Invalid update mask: %v", err))
 	}
-	// Check if the cursor has any errors
-	if err := cursor.Err(); err != nil {
-		return status.Errorf(codes.Internal, fmt.Sprintf("Unkown cursor error: %v", err))
+	// Only the fields being updated have to be valid, the mask was checked above
+	if err := validateJob(Job, req.GetUpdateMask().GetPaths()...); err != nil {
+		return nil, err
+	}
+	if update.Handler != nil {
+		if err := s.checkHandler(*update.Handler); err != nil {
+			return nil, err
+		}
+	}
+	// The params must match the handler, whichever of them is updated
+	if update.Handler != nil || update.SetParams {
+		if update.Handler == nil || !update.SetParams {
+			stored, err := s.Jobs.Get(ctx, Job.GetId(), ownerQuery(ctx))
+			if err != nil {
+				return nil, jobError(ctx, err, Job.GetId())
+			}
+			if update.Handler == nil {
+				update.Handler = &stored.Handler
+			} else {
+				update.SetParams = true
+				update.Params = stored.Params
+			}
+		}
+		if err := s.checkParams(*update.Handler, update.Params); err != nil {
+			return nil, err
+		}
+	}
+	// Whether the schedule and dependencies go together depends on the fields that aren't updated
+	if update.SetSchedule || update.SetDependsOn {
+		stored, err := s.Jobs.Get(ctx, Job.GetId(), ownerQuery(ctx))
+		if err != nil {
+			return nil, jobError(ctx, err, Job.GetId())
+		}
+		schedule, dependsOn := stored.Schedule, stored.DependsOn
+		if update.SetSchedule {
+			schedule = update.Schedule
+		}
+		if update.SetDependsOn {
+			dependsOn = update.DependsOn
+		}
+		if err := s.checkDependencies(ctx, stored.ID, schedule, dependsOn); err != nil {
+			return nil, err
+		}
+	}
+	return update, nil
+}
+
+// validateUpdate returns the caller's job with id as it would be stored with update, after checking that its name is
+// still free for its owner and environment
+func (s *JobServiceServer) validateUpdate(ctx context.Context, id string, update *repository.JobUpdate) (*model.UpdateJobRes, error) {
+	job, err := s.Jobs.Get(ctx, id, ownerQuery(ctx))
+	if err != nil {
+		return nil, jobError(ctx, err, id)
+	}
+	update.UpdatedAt = now()
+	update.Apply(job)
+	if update.Name != nil || update.Owner != nil || update.Environment != nil {
+		if err := s.checkNameFree(ctx, job.Owner, job.Environment, job.Name, job.ID); err != nil {
+			return nil, err
+		}
+	}
+	return &model.UpdateJobRes{Job: jobToProto(job)}, nil
+}
+
+// checkNameFree returns ErrNameTaken as a status when owner has a job other than the one with id named name in
+// environment, the one the unique index on names would reject
+func (s *JobServiceServer) checkNameFree(ctx context.Context, owner, environment, name, id string) error {
+	job, err := s.Jobs.GetByName(ctx, owner, environment, name)
+	if err == repository.ErrNotFound || (err == nil && job.ID == id) {
+		return nil
+	} else if err != nil {
+		return internalError(ctx, err)
+	}
+	return nameTakenError(name)
+}
+
+// updateError converts an error of updating the job with id into the status returned to clients
+func updateError(ctx context.Context, err error, id string, update *repository.JobUpdate) error {
+	if err == repository.ErrNameTaken && update.Name != nil {
+		return nameTakenError(*update.Name)
+	} else if err == repository.ErrNameTaken {
+		return newError(codes.AlreadyExists, reasonNameTaken, "The new owner or environment already has a job with the same name, rename it or the job")
+	} else if err == repository.ErrRevisionTaken {
+		return newError(codes.Aborted, reasonConcurrentChange, fmt.Sprintf("Job %s was updated concurrently, try again", id))
+	}
+	return jobError(ctx, err, id)
+}
+
+func (s *JobServiceServer) CloneJob(ctx context.Context, req *model.CloneJobReq) (*model.CloneJobRes, error) {
+	// Without a mask nothing is overridden, unlike UpdateJob's empty mask updating every field
+	update := &repository.JobUpdate{}
+	if len(req.GetOverrideMask().GetPaths()) > 0 {
+		var err error
+		if update, err = updateFromMask(req.GetOverrides(), req.GetOverrideMask()); err != nil {
+			return nil, invalidArgumentError("override_mask", fmt.Sprintf("Invalid override mask: %v", err))
+		}
+	}
+	stored, err := s.Jobs.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetId())
+	}
+	// Names are unique per owner and environment, so a clone in the same environment needs another one
+	if update.Name == nil && (update.Environment == nil || *update.Environment == stored.Environment) {
+		name := cloneName(stored.Name)
+		update.Name = &name
+	}
+	update.Apply(stored)
+	// The clone is checked and created like a new job, so the owner and dependencies must still be allowed. It wasn't
+	// created from the template of the job, it keeps its calendars like promoted jobs do.
+	res, err := s.createJob(ctx, jobToProto(stored), req.GetIdempotencyKey(), nil, stored.Blackout, false)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CloneJobRes{Job: res.GetJob()}, nil
+}
+
+// cloneSuffix is appended to the names of clones without a new name
+const cloneSuffix = " (copy)"
+
+// cloneName returns the name of a clone of the job called name, names too long for the suffix are cut off before it
+func cloneName(name string) string {
+	base := []rune(name)
+	if max := maxNameLength - utf8.RuneCountInString(cloneSuffix); len(base) > max {
+		base = []rune(strings.TrimRight(string(base[:max]), " "))
+	}
+	return string(base) + cloneSuffix
+}
+
+// maxApprovalLength is the maximum length in characters of the approval of PromoteJob
+const maxApprovalLength = 1024
+
+func (s *JobServiceServer) PromoteJob(ctx context.Context, req *model.PromoteJobReq) (*model.PromoteJobRes, error) {
+	target := req.GetTargetEnvironment()
+	if target == "" {
+		return nil, invalidArgumentError("target_environment", "Target environment must not be empty")
+	}
+	if msg := checkEnvironment(target); msg != "" {
+		return nil, invalidArgumentError("target_environment", fmt.Sprintf("Target environment %s", msg))
+	}
+	approval := req.GetApproval()
+	if strings.TrimSpace(approval) == "" {
+		return nil, invalidArgumentError("approval", "Approval must not be empty, promotions need one")
+	}
+	if msg := checkLength(approval, maxApprovalLength); msg != "" {
+		return nil, invalidArgumentError("approval", fmt.Sprintf("Approval %s", msg))
+	}
+	if msg := checkControl(approval, ""); msg != "" {
+		return nil, invalidArgumentError("approval", fmt.Sprintf("Approval %s", msg))
+	}
+	source, err := s.Jobs.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetId())
+	}
+	if source.Environment == target {
+		return nil, invalidArgumentError("target_environment", fmt.Sprintf("Job %s is in environment %s already", source.ID, target))
+	}
+	promotion := &repository.Promotion{
+		SourceJobID:       source.ID,
+		SourceEnvironment: source.Environment,
+		Approval:          approval,
+		PromotedAt:        now(),
+	}
+	if claims, ok := auth.FromContext(ctx); ok {
+		promotion.PromotedBy = claims.Subject
+	}
+	// The job with the name of the source in the target environment is the one promotions replace
+	existing, err := s.Jobs.GetByName(ctx, source.Owner, target, source.Name)
+	if err == repository.ErrNotFound {
+		promoted, err := s.promoteNew(ctx, source, target, promotion)
+		if err != nil {
+			return nil, err
+		}
+		logging.FromContext(ctx).Info("Promoted job", zap.String("job_id", source.ID), zap.String("target_id", promoted.ID),
+			zap.String("environment", target))
+		return &model.PromoteJobRes{Job: jobToProto(promoted), Created: true}, nil
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	if existing.DeletedAt != nil {
+		return nil, newError(codes.FailedPrecondition, reasonJobStatus, fmt.Sprintf("Job %s named %q in environment %s is deleted, restore it before promoting to it",
+			existing.ID, existing.Name, target))
+	}
+	promoted, err := s.promoteExisting(ctx, source, existing, promotion)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Promoted job", zap.String("job_id", source.ID), zap.String("target_id", promoted.ID),
+		zap.String("environment", target))
+	return &model.PromoteJobRes{Job: jobToProto(promoted)}, nil
+}
+
+// promoteNew creates a copy of source in the environment target. It is checked like a new job, so the owner must
+// still be allowed.
+func (s *JobServiceServer) promoteNew(ctx context.Context, source *repository.Job, target string, promotion *repository.Promotion) (*repository.Job, error) {
+	job := jobToProto(source)
+	job.Environment = target
+	// The dependencies are jobs of the source environment
+	job.DependsOn = nil
+	data, err := s.newJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkJobQuota(ctx, data.Owner, 0, 0); err != nil {
+		return nil, err
+	}
+	data.Template = source.Template
+	data.Blackout = source.Blackout
+	data.Promotion = promotion
+	created, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobCreated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.Create(ctx, data)
+	})
+	if err == repository.ErrNameTaken {
+		return nil, newError(codes.Aborted, reasonConcurrentChange, fmt.Sprintf("Job %q was created in environment %s concurrently, try again", source.Name, target))
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return created, nil
+}
+
+// promoteExisting replaces the definition of existing with the one of source. Its name, owner, status, dependencies
+// and runs stay, the schedule must still go together with its dependencies.
+func (s *JobServiceServer) promoteExisting(ctx context.Context, source, existing *repository.Job, promotion *repository.Promotion) (*repository.Job, error) {
+	if err := s.checkHandler(source.Handler); err != nil {
+		return nil, err
+	}
+	if err := s.checkParams(source.Handler, source.Params); err != nil {
+		return nil, err
+	}
+	spec, next, err := scheduleFields(scheduleToProto(source.Schedule))
+	if err != nil {
+		return nil, internalError(ctx, fmt.Errorf("invalid schedule of job %s: %v", source.ID, err))
+	}
+	if err := s.checkDependencies(ctx, existing.ID, spec, existing.DependsOn); err != nil {
+		return nil, err
+	}
+	update := &repository.JobUpdate{
+		Description:       &source.Description,
+		Handler:           &source.Handler,
+		Command:           &source.Command,
+		Timeout:           &source.Timeout,
+		Priority:          &source.Priority,
+		ConcurrencyPolicy: &source.ConcurrencyPolicy,
+		CatchUpPolicy:     &source.CatchUpPolicy,
+		SetSchedule:       true,
+		Schedule:          spec,
+		NextRunTime:       next,
+		SetRetryPolicy:    true,
+		RetryPolicy:       source.RetryPolicy,
+		SetNotifications:  true,
+		Notifications:     source.Notifications,
+		SetLabels:         true,
+		Labels:            source.Labels,
+		SetBlackout:       true,
+		Blackout:          source.Blackout,
+		SetParams:         true,
+		Params:            source.Params,
+		SetPromotion:      true,
+		Promotion:         promotion,
+		UpdatedAt:         now(),
+	}
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.updateRevised(ctx, existing.ID, update)
+	})
+	if err != nil {
+		return nil, updateError(ctx, err, existing.ID, update)
+	}
+	return updated, nil
+}
+
+// updatableFields maps the field mask paths clients may update to a function copying the field into the update
+var updatableFields = map[string]func(*repository.JobUpdate, *model.Job){
+	"name":        func(u *repository.JobUpdate, j *model.Job) { u.Name = &j.Name },
+	"description": func(u *repository.JobUpdate, j *model.Job) { u.Description = &j.Description },
+	"owner":       func(u *repository.JobUpdate, j *model.Job) { u.Owner = &j.Owner },
+	"handler":     func(u *repository.JobUpdate, j *model.Job) { u.Handler = &j.Handler },
+	"command":     func(u *repository.JobUpdate, j *model.Job) { u.Command = &j.Command },
+	"timeout": func(u *repository.JobUpdate, j *model.Job) {
+		t := timeout(j)
+		u.Timeout = &t
+	},
+	"retry_policy": func(u *repository.JobUpdate, j *model.Job) {
+		u.SetRetryPolicy = true
+		u.RetryPolicy = retryPolicy(j)
+	},
+	"labels": func(u *repository.JobUpdate, j *model.Job) {
+		u.SetLabels = true
+		u.Labels = jobLabels(j)
+	},
+	"depends_on": func(u *repository.JobUpdate, j *model.Job) {
+		u.SetDependsOn = true
+		u.DependsOn = jobDependsOn(j)
+	},
+	"priority": func(u *repository.JobUpdate, j *model.Job) {
+		p := jobPriority(j)
+		u.Priority = &p
+	},
+	"concurrency_policy": func(u *repository.JobUpdate, j *model.Job) {
+		p := jobConcurrencyPolicy(j)
+		u.ConcurrencyPolicy = &p
+	},
+	"catch_up_policy": func(u *repository.JobUpdate, j *model.Job) {
+		p := jobCatchUpPolicy(j)
+		u.CatchUpPolicy = &p
+	},
+	"notifications": func(u *repository.JobUpdate, j *model.Job) {
+		u.SetNotifications = true
+		u.Notifications = notificationsFromProto(j.GetNotifications())
+	},
+	"params": func(u *repository.JobUpdate, j *model.Job) {
+		u.SetParams = true
+		u.Params = jobParams(j)
+	},
+	"environment": func(u *repository.JobUpdate, j *model.Job) { u.Environment = &j.Environment },
+}
+
+// jobLabels returns the labels of a job, nil when it has none
+func jobLabels(job *model.Job) map[string]string {
+	if len(job.GetLabels()) == 0 {
+		return nil
+	}
+	return job.GetLabels()
+}
+
+// jobParams returns the params of a job, nil when it has none
+func jobParams(job *model.Job) map[string]interface{} {
+	if len(job.GetParams().GetFields()) == 0 {
+		return nil
+	}
+	return job.GetParams().AsMap()
+}
+
+// jobDependsOn returns the IDs of the jobs a job depends on, nil when it doesn't depend on any
+func jobDependsOn(job *model.Job) []string {
+	if len(job.GetDependsOn()) == 0 {
+		return nil
+	}
+	return job.GetDependsOn()
+}
+
+// jobPriority returns the stored form of the priority of a job that passed validateJob, normal when it's unspecified
+func jobPriority(job *model.Job) string {
+	if job.GetPriority() == model.JobPriority_JOB_PRIORITY_UNSPECIFIED {
+		return repository.PriorityNormal
+	}
+	return strings.TrimPrefix(job.GetPriority().String(), "JOB_PRIORITY_")
+}
+
+// jobConcurrencyPolicy returns the stored form of the concurrency policy of a job that passed validateJob, allow when
+// it's unspecified
+func jobConcurrencyPolicy(job *model.Job) string {
+	if job.GetConcurrencyPolicy() == model.ConcurrencyPolicy_CONCURRENCY_POLICY_UNSPECIFIED {
+		return repository.ConcurrencyAllow
+	}
+	return strings.TrimPrefix(job.GetConcurrencyPolicy().String(), "CONCURRENCY_POLICY_")
+}
+
+// jobCatchUpPolicy returns the stored form of the catch-up policy of a job that passed validateJob, fire once when
+// it's unspecified
+func jobCatchUpPolicy(job *model.Job) string {
+	if job.GetCatchUpPolicy() == model.CatchUpPolicy_CATCH_UP_POLICY_UNSPECIFIED {
+		return scheduler.CatchUpFireOnce
+	}
+	return strings.TrimPrefix(job.GetCatchUpPolicy().String(), "CATCH_UP_POLICY_")
+}
+
+// timeout returns the timeout of a job that passed validateJob, zero when it's unset
+func timeout(job *model.Job) time.Duration {
+	if job.GetTimeout() == nil {
+		return 0
+	}
+	t, _ := ptypes.Duration(job.GetTimeout())
+	return t
+}
+
+// retryPolicy returns the stored form of the retry policy of a job that passed validateJob
+func retryPolicy(job *model.Job) *scheduler.RetryPolicy {
+	policy, _ := retryPolicyFromProto(job.GetRetryPolicy())
+	return policy
+}
+
+// ownerQuery restricts repository operations to the jobs of the authenticated caller and the teams of the caller.
+// Admins and calls without claims (authentication disabled) aren't restricted.
+func ownerQuery(ctx context.Context) repository.Query {
+	claims, ok := auth.FromContext(ctx)
+	if ok && !claims.HasRole(auth.AdminRole) {
+		return repository.Query{Owner: claims.Subject, Teams: team.FromContext(ctx)}
+	}
+	return repository.Query{}
+}
+
+// jobError converts a repository error for the job with the given id into a gRPC status
+func jobError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(jobResource, id, fmt.Sprintf("Invalid Job id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(jobResource, id, fmt.Sprintf("Could not find Job with id %s", id))
+	case repository.ErrOtherTenant:
+		return otherTenantError(jobResource, id, fmt.Sprintf("Job with id %s belongs to another tenant", id))
+	}
+	return internalError(ctx, err)
+}
+
+// nameTakenError tells that the owner already has a job named name
+func nameTakenError(name string) error {
+	return newError(codes.AlreadyExists, reasonNameTaken, fmt.Sprintf("A job named %q already exists, choose another name or update the existing job", name))
+}
+
+// JobListener is told about the jobs changed through the API, event is one of the job events of the webhook package
+type JobListener interface {
+	JobChanged(ctx context.Context, event string, job *repository.Job)
+}
+
+// EventStore stores the events of jobs together with their changes, like the outbox of the events package
+type EventStore interface {
+	// InTransaction runs f in a transaction, the changes and events stored with the context f gets are kept if f
+	// succeeds. f may be called again when the transaction has to be retried.
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+	// JobChanged stores an event of job, it must be called with the context of InTransaction
+	JobChanged(ctx context.Context, event string, job *repository.Job) error
+}
+
+// changeJob runs f, which changes a job and returns it, and stores the event of the change in the same transaction of
+// events. The listeners are told about it once it's committed. f may return a nil job without an error, then no event is
+// stored. Without events f runs on its own.
+func changeJob(ctx context.Context, events EventStore, listeners []JobListener, event string, f func(ctx context.Context) (*repository.Job, error)) (*repository.Job, error) {
+	var job *repository.Job
+	change := func(ctx context.Context) error {
+		var err error
+		if job, err = f(ctx); err != nil || job == nil || events == nil {
+			return err
+		}
+		return events.JobChanged(ctx, event, job)
+	}
+	var err error
+	if events != nil {
+		err = events.InTransaction(ctx, change)
+	} else {
+		err = change(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		jobChanged(ctx, listeners, event, job)
+	}
+	return job, nil
+}
+
+// jobChanged tells every listener about an event of job
+func jobChanged(ctx context.Context, listeners []JobListener, event string, job *repository.Job) {
+	for _, l := range listeners {
+		l.JobChanged(ctx, event, job)
+	}
+}
+
+// ownerForCaller checks that the caller may assign a job to owner and returns the owner to store.
+// Non-admins can only assign jobs to themselves and their teams, an empty owner defaults to the caller. Admins assigning
+// a job to someone else must name a user in users or a team in teams, without authentication or the repositories owners
+// aren't checked.
+func ownerForCaller(ctx context.Context, users repository.UserRepository, teams repository.TeamRepository, owner string) (string, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return owner, nil
+	}
+	if owner == "" {
+		return claims.Subject, nil
+	}
+	if strings.HasPrefix(owner, repository.TeamOwnerPrefix) {
+		return teamOwnerForCaller(ctx, teams, claims, owner)
+	}
+	if owner != claims.Subject && !claims.HasRole(auth.AdminRole) {
+		return "", newError(codes.PermissionDenied, reasonPermissionDenied, fmt.Sprintf("Not allowed to assign jobs to owner %q", owner))
+	}
+	if owner == claims.Subject || users == nil {
+		return owner, nil
+	}
+	if _, err := users.Get(ctx, owner); err == repository.ErrNotFound {
+		msg := fmt.Sprintf("Could not find User with id %s to assign as owner", owner)
+		return "", newError(codes.FailedPrecondition, reasonOwnerNotFound, msg,
+			&errdetails.ResourceInfo{ResourceType: userResource, ResourceName: owner, Description: msg})
+	} else if err != nil {
+		return "", internalError(ctx, err)
+	}
+	return owner, nil
+}
+
+// teamOwnerForCaller checks that the caller may assign a job to the team owner and returns it. Members can assign
+// jobs to their teams, admins to every team that exists.
+func teamOwnerForCaller(ctx context.Context, teams repository.TeamRepository, claims *auth.Claims, owner string) (string, error) {
+	id := strings.TrimPrefix(owner, repository.TeamOwnerPrefix)
+	for _, member := range team.FromContext(ctx) {
+		if member == id {
+			return owner, nil
+		}
+	}
+	if !claims.HasRole(auth.AdminRole) {
+		return "", newError(codes.PermissionDenied, reasonPermissionDenied, fmt.Sprintf("Not allowed to assign jobs to owner %q", owner))
+	}
+	if teams == nil {
+		return owner, nil
+	}
+	if _, err := teams.Get(ctx, id); err == repository.ErrNotFound || err == repository.ErrInvalidID {
+		msg := fmt.Sprintf("Could not find Team with id %s to assign as owner", id)
+		return "", newError(codes.FailedPrecondition, reasonOwnerNotFound, msg,
+			&errdetails.ResourceInfo{ResourceType: teamResource, ResourceName: id, Description: msg})
+	} else if err != nil {
+		return "", internalError(ctx, err)
+	}
+	return owner, nil
+}
+
+// checkDependencies makes sure the job with the given id, empty for new jobs, may have the schedule and depend on the
+// jobs in dependsOn. The caller must be able to read the jobs it depends on and depending on them must not close a
+// cycle. Nothing can depend on new jobs yet, so they never close one.
+func (s *JobServiceServer) checkDependencies(ctx context.Context, id string, schedule *scheduler.Spec, dependsOn []string) error {
+	if len(dependsOn) == 0 {
+		return nil
+	}
+	if schedule != nil {
+		return invalidArgumentError("job.schedule", "A job depending on other jobs runs when they succeeded and can't have a schedule")
+	}
+	q := ownerQuery(ctx)
+	for _, upstream := range dependsOn {
+		if upstream == id {
+			return invalidArgumentError("job.depends_on", "A job can't depend on itself")
+		}
+		_, err := s.Jobs.Get(ctx, upstream, q)
+		switch err {
+		case nil:
+		case repository.ErrInvalidID:
+			return invalidArgumentError("job.depends_on", fmt.Sprintf("Invalid Job id %q in depends_on", upstream))
+		case repository.ErrNotFound, repository.ErrOtherTenant:
+			msg := fmt.Sprintf("Could not find Job with id %s the job depends on", upstream)
+			return newError(codes.FailedPrecondition, reasonDependencyMissing, msg,
+				&errdetails.ResourceInfo{ResourceType: jobResource, ResourceName: upstream, Description: msg})
+		default:
+			return internalError(ctx, err)
+		}
+	}
+	if id == "" {
+		return nil
+	}
+	path, err := s.upstreamPath(ctx, dependsOn, id, map[string]bool{})
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	if path != nil {
+		cycle := strings.Join(append([]string{id}, path...), " -> ")
+		return newError(codes.FailedPrecondition, reasonDependencyCycle, fmt.Sprintf("Dependencies would create the cycle %s", cycle))
+	}
+	return nil
+}
+
+// upstreamPath returns the chain of jobs from one of the jobs in from to target following their dependencies, nil when
+// target isn't upstream of any of them. Deleted jobs are followed too since they can be restored, purged ones can't.
+func (s *JobServiceServer) upstreamPath(ctx context.Context, from []string, target string, visited map[string]bool) ([]string, error) {
+	for _, id := range from {
+		if id == target {
+			return []string{id}, nil
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		job, err := s.Jobs.Get(ctx, id, repository.Query{IncludeDeleted: true})
+		if err == repository.ErrNotFound || err == repository.ErrInvalidID || err == repository.ErrOtherTenant {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		path, err := s.upstreamPath(ctx, job.DependsOn, target, visited)
+		if err != nil {
+			return nil, err
+		}
+		if path != nil {
+			return append([]string{id}, path...), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *JobServiceServer) ListJobTypes(ctx context.Context, req *model.ListJobTypesReq) (*model.ListJobTypesRes, error) {
+	res := &model.ListJobTypesRes{JobTypes: []*model.JobType{}}
+	if s.Executor == nil {
+		return res, nil
+	}
+	for _, t := range s.Executor.Types() {
+		jobType := &model.JobType{Name: t.Name, Description: t.Description, Open: t.Schema.Open}
+		for _, p := range t.Schema.Params {
+			jobType.Params = append(jobType.Params, &model.JobTypeParam{
+				Name:        p.Name,
+				Type:        paramTypeToProto(p.Type),
+				Values:      paramTypeToProto(p.Values),
+				Required:    p.Required,
+				Description: p.Description,
+			})
+		}
+		res.JobTypes = append(res.JobTypes, jobType)
+	}
+	return res, nil
+}
+
+// paramTypeToProto converts one of the executor.Param constants into a ParamType, unspecified for empty ones
+func paramTypeToProto(t string) model.ParamType {
+	return model.ParamType(model.ParamType_value["PARAM_TYPE_"+strings.ToUpper(t)])
+}
+
+// checkHandler returns InvalidArgument if the executor has no handler with this name
+func (s *JobServiceServer) checkHandler(name string) error {
+	if s.Executor != nil && !s.Executor.HasHandler(name) {
+		return invalidArgumentError("job.handler", fmt.Sprintf("Unknown handler %q", name))
+	}
+	return nil
+}
+
+// checkParams returns InvalidArgument unless the params match the schema of the executor's handler with this name
+func (s *JobServiceServer) checkParams(handler string, params map[string]interface{}) error {
+	if s.Executor == nil {
+		return nil
+	}
+	if err := s.Executor.ValidateParams(handler, params); err != nil {
+		if handler == "" {
+			handler = executor.DefaultHandler
+		}
+		return invalidArgumentError("job.params", fmt.Sprintf("Invalid params for handler %q: %v", handler, err))
+	}
+	return nil
+}
+
+// updateFromMask builds the JobUpdate for UpdateJob, an empty or missing mask updates every field
+func updateFromMask(job *model.Job, mask *field_mask.FieldMask) (*repository.JobUpdate, error) {
+	update := &repository.JobUpdate{}
+	paths := mask.GetPaths()
+	includeSchedule := len(paths) == 0
+	if len(paths) == 0 {
+		for _, set := range updatableFields {
+			set(update, job)
+		}
+	}
+	for _, path := range paths {
+		// The schedule also determines the next run time, so it's handled separately
+		if path == "schedule" {
+			includeSchedule = true
+			continue
+		}
+		set, ok := updatableFields[path]
+		if !ok {
+			return nil, fmt.Errorf("unknown or read-only field %q", path)
+		}
+		set(update, job)
+	}
+	if includeSchedule {
+		spec, next, err := scheduleFields(job.GetSchedule())
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule: %v", err)
+		}
+		update.SetSchedule = true
+		update.Schedule = spec
+		update.NextRunTime = next
+	}
+	return update, nil
+}
+
+// readFields returns the fields of jobs mask reads, nil for an empty mask reading whole jobs
+func readFields(mask *field_mask.FieldMask) ([]string, error) {
+	fields := proto.MessageReflect(&model.Job{}).Descriptor().Fields()
+	for _, path := range mask.GetPaths() {
+		if fields.ByName(protoreflect.Name(path)) == nil {
+			return nil, fmt.Errorf("unknown field %q, only whole fields of jobs can be read", path)
+		}
+	}
+	return mask.GetPaths(), nil
+}
+
+// projectJob clears the fields of job besides its id missing from fields, so backends returning whole jobs answer
+// like MongoDB. Nothing is cleared when fields is empty.
+func projectJob(job *model.Job, fields []string) *model.Job {
+	if len(fields) == 0 {
+		return job
+	}
+	keep := map[protoreflect.Name]bool{"id": true}
+	for _, field := range fields {
+		keep[protoreflect.Name(field)] = true
+	}
+	m := proto.MessageReflect(job)
+	descriptors := m.Descriptor().Fields()
+	for i := 0; i < descriptors.Len(); i++ {
+		if fd := descriptors.Get(i); !keep[fd.Name()] {
+			m.Clear(fd)
+		}
+	}
+	return job
+}
+
+func (s *JobServiceServer) ListJobs(req *model.ListJobsReq, stream model.JobService_ListJobsServer) error {
+	// Work out how many jobs to send and where the previous page stopped
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	after := ""
+	if req.GetPageToken() != "" {
+		after, err = decodePageToken(req.GetPageToken())
+		if err != nil {
+			return invalidArgumentError("page_token", fmt.Sprintf("Invalid page token: %v", err))
+		}
+	}
+	// Fetch one more job than requested to find out whether there is another page, callers only see their own jobs
+	q := ownerQuery(stream.Context())
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	q.Labels, err = labels.Parse(req.GetLabelSelector())
+	if err != nil {
+		return invalidArgumentError("label_selector", fmt.Sprintf("Invalid label selector: %v", err))
+	}
+	q.Archived = repository.WithoutArchived
+	if req.GetArchived() {
+		q.Archived = repository.OnlyArchived
+	}
+	if q.Environment = req.GetEnvironment(); q.Environment != "" {
+		if msg := checkEnvironment(q.Environment); msg != "" {
+			return invalidArgumentError("environment", fmt.Sprintf("Environment %s", msg))
+		}
+	}
+	if q.Fields, err = readFields(req.GetReadMask()); err != nil {
+		return invalidArgumentError("read_mask", fmt.Sprintf("Invalid read mask: %v", err))
+	}
+	page, err := s.Jobs.List(stream.Context(), q, after, int(pageSize)+1)
+	if err == repository.ErrInvalidID {
+		return invalidArgumentError("page_token", "Invalid page token")
+	} else if err != nil {
+		// A listing cut short because the client went away isn't an internal error
+		if ctxErr := stream.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return internalError(stream.Context(), err)
+	}
+
+	hasMore := len(page) > int(pageSize)
+	if hasMore {
+		page = page[:pageSize]
+	}
+	for i, job := range page {
+		// Send blocks while a slow client doesn't take more messages, stop once it went away instead of converting
+		// the rest of the page
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		res := &model.ListJobsRes{Job: projectJob(jobToProto(job), q.Fields)}
+		// The last message of the page tells the client where to continue
+		if hasMore && i == len(page)-1 {
+			res.NextPageToken = encodePageToken(job.ID)
+		}
+		// A job larger than the server sends ends the stream with ResourceExhausted instead of being skipped
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxSearchQueryLength is the maximum length in characters of the query of SearchJobs
+const maxSearchQueryLength = 256
+
+func (s *JobServiceServer) SearchJobs(req *model.SearchJobsReq, stream model.JobService_SearchJobsServer) error {
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		return invalidArgumentError("query", "Query must not be empty")
+	}
+	if msg := checkLength(req.GetQuery(), maxSearchQueryLength); msg != "" {
+		return invalidArgumentError("query", fmt.Sprintf("Query %s", msg))
+	}
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	// Results are ordered by relevance, so pages continue at an offset instead of after an ID
+	offset := 0
+	if req.GetPageToken() != "" {
+		token, err := decodePageToken(req.GetPageToken())
+		if err == nil {
+			offset, err = strconv.Atoi(token)
+		}
+		if err != nil || offset < 0 {
+			return invalidArgumentError("page_token", "Invalid page token")
+		}
+	}
+	q := ownerQuery(stream.Context())
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	results, err := s.Jobs.Search(stream.Context(), q, req.GetQuery(), offset, int(pageSize)+1)
+	if err != nil {
+		return internalError(stream.Context(), err)
+	}
+
+	hasMore := len(results) > int(pageSize)
+	if hasMore {
+		results = results[:pageSize]
+	}
+	query := search.Parse(req.GetQuery())
+	for i, result := range results {
+		res := &model.SearchJobsRes{Job: jobToProto(result.Job), Score: result.Score}
+		for _, field := range []struct {
+			name  string
+			value string
+		}{{"name", result.Job.Name}, {"description", result.Job.Description}} {
+			if fragment, ok := query.Highlight(field.value); ok {
+				res.Highlights = append(res.Highlights, &model.SearchHighlight{Field: field.name, Fragment: fragment})
+			}
+		}
+		if hasMore && i == len(results)-1 {
+			res.NextPageToken = encodePageToken(strconv.Itoa(offset + len(results)))
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxGraphNodes limits the nodes GetJobGraph returns, jobs several others depend on count once for each of them
+const maxGraphNodes = 1000
+
+// errGraphTooLarge is returned by graphBuilder once it built maxGraphNodes nodes
+var errGraphTooLarge = errors.New("dependency graph too large")
+
+func (s *JobServiceServer) GetJobGraph(ctx context.Context, req *model.GetJobGraphReq) (*model.GetJobGraphRes, error) {
+	q := ownerQuery(ctx)
+	job, err := s.Jobs.Get(ctx, req.GetJobId(), q)
+	if err != nil {
+		return nil, jobError(ctx, err, req.GetJobId())
+	}
+	b := &graphBuilder{jobs: s.Jobs, q: q}
+	root, err := b.node(job)
+	if err == nil {
+		root.DependsOn, err = b.upstream(ctx, job.DependsOn)
+	}
+	if err == nil {
+		root.Dependents, err = b.downstream(ctx, job.ID)
+	}
+	if err == errGraphTooLarge {
+		return nil, newError(codes.FailedPrecondition, reasonGraphTooLarge, fmt.Sprintf("Dependency graph of job %s has more than %d nodes", job.ID, maxGraphNodes))
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.GetJobGraphRes{Root: root}, nil
+}
+
+// graphBuilder builds the nodes of a dependency graph from the jobs matching q
+type graphBuilder struct {
+	jobs  repository.JobRepository
+	q     repository.Query
+	nodes int
+}
+
+// node converts a job into a node without its neighbours
+func (b *graphBuilder) node(job *repository.Job) (*model.JobGraphNode, error) {
+	if b.nodes++; b.nodes > maxGraphNodes {
+		return nil, errGraphTooLarge
+	}
+	return &model.JobGraphNode{
+		JobId:   job.ID,
+		Name:    job.Name,
+		Status:  model.JobStatus(model.JobStatus_value["JOB_STATUS_"+job.Status]),
+		Deleted: job.DeletedAt != nil,
+	}, nil
+}
+
+// upstream returns the nodes of the jobs in ids with the jobs they depend on, jobs that can't be read only have their id
+func (b *graphBuilder) upstream(ctx context.Context, ids []string) ([]*model.JobGraphNode, error) {
+	q := b.q
+	q.IncludeDeleted = true
+	nodes := []*model.JobGraphNode{}
+	for _, id := range ids {
+		job, err := b.jobs.Get(ctx, id, q)
+		if err == repository.ErrNotFound || err == repository.ErrInvalidID || err == repository.ErrOtherTenant {
+			job = &repository.Job{ID: id}
+		} else if err != nil {
+			return nil, err
+		}
+		node, err := b.node(job)
+		if err != nil {
+			return nil, err
+		}
+		if node.DependsOn, err = b.upstream(ctx, job.DependsOn); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// downstream returns the nodes of the jobs depending on the job with the given id with the jobs depending on them
+func (b *graphBuilder) downstream(ctx context.Context, id string) ([]*model.JobGraphNode, error) {
+	dependents, err := b.jobs.Dependents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*model.JobGraphNode{}
+	for _, job := range dependents {
+		if !b.q.OwnedBy(job.Owner) {
+			continue
+		}
+		node, err := b.node(job)
+		if err != nil {
+			return nil, err
+		}
+		if node.Dependents, err = b.downstream(ctx, job.ID); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// Page sizes used by ListJobs when the client asks for none or too many jobs
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// pageSizeFromRequest applies the default and maximum page size to the size a client asked for
+func pageSizeFromRequest(pageSize int32) (int32, error) {
+	if pageSize < 0 {
+		return 0, invalidArgumentError("page_size", "Page size must not be negative")
+	}
+	if pageSize == 0 {
+		return defaultPageSize, nil
+	}
+	if pageSize > maxPageSize {
+		return maxPageSize, nil
+	}
+	return pageSize, nil
+}
+
+// encodePageToken turns the ID of the last job on a page into an opaque token
+func encodePageToken(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// decodePageToken returns the job ID a page token was created from
+func decodePageToken(token string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+// jobEventTypes maps the repository event types to the ones sent to clients
+var jobEventTypes = map[repository.EventType]model.JobEventType{
+	repository.EventCreated: model.JobEventType_JOB_EVENT_TYPE_CREATED,
+	repository.EventUpdated: model.JobEventType_JOB_EVENT_TYPE_UPDATED,
+	repository.EventDeleted: model.JobEventType_JOB_EVENT_TYPE_DELETED,
+}
+
+func (s *JobServiceServer) WatchJobs(req *model.WatchJobsReq, stream model.JobService_WatchJobsServer) error {
+	ctx := stream.Context()
+	// Callers only see changes of their own jobs, deletions included. Deleted jobs only carry their id, purges aren't
+	// sent.
+	events, err := s.Jobs.Watch(ctx, ownerQuery(ctx), req.GetResumeToken())
+	switch err {
+	case nil:
+	case repository.ErrInvalidResumeToken:
+		return invalidArgumentError("resume_token", "Invalid resume token")
+	case repository.ErrResumeTokenExpired:
+		return newError(codes.OutOfRange, reasonResumeExpired, "Resume token expired, start a new watch without a token")
+	case repository.ErrResumeUnsupported:
+		return newError(codes.FailedPrecondition, reasonResumeFailed, fmt.Sprintf("Can't resume: %v", err))
+	default:
+		return internalError(ctx, fmt.Errorf("could not watch jobs: %v", err))
+	}
+	defer events.Close(context.Background())
+
+	for {
+		event, err := events.Next(ctx)
+		if err != nil {
+			// The client went away or the deadline passed
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			logging.FromContext(ctx).Warn("Watch interrupted", zap.Error(err))
+			return newError(codes.Unavailable, reasonWatchInterrupted, "Watch interrupted, resume with the last token")
+		}
+		res := &model.WatchJobsRes{
+			Type:        jobEventTypes[event.Type],
+			Job:         jobToProto(event.Job),
+			ResumeToken: event.ResumeToken,
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
 	}
-	return nil
 }
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/protobuf/proto"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// caller returns a context authenticated as subject with the given roles
+func caller(subject string, roles ...string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject},
+		Roles:            roles,
+	})
+}
+
+// newJobService returns a JobService storing its jobs in memory, without any of the optional dependencies
+func newJobService() *JobServiceServer {
+	return &JobServiceServer{Jobs: repository.NewMemoryJobRepository()}
+}
+
+// createJob creates job as the caller of ctx and fails the test if it can't
+func createJob(t *testing.T, s *JobServiceServer, ctx context.Context, job *model.Job) *model.Job {
+	t.Helper()
+	res, err := s.CreateJob(ctx, &model.CreateJobReq{Job: job})
+	if err != nil {
+		t.Fatalf("CreateJob(%q) failed: %v", job.GetName(), err)
+	}
+	return res.GetJob()
+}
+
+// checkCode fails the test unless err is a status with code want, codes.OK for no error
+func checkCode(t *testing.T, call string, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Errorf("%s failed with %v, want %v", call, err, want)
+	}
+}
+
+func TestCreateJob(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	job := createJob(t, s, alice, &model.Job{Name: "backup", Description: "nightly", Labels: map[string]string{"team": "data"}})
+	if job.GetId() == "" || job.GetOwner() != "alice" || job.GetStatus() != model.JobStatus_JOB_STATUS_PENDING {
+		t.Errorf("CreateJob() = %v, want a pending job of alice with an id", job)
+	}
+	if job.GetCreatedAt() == nil || !proto.Equal(job.GetCreatedAt(), job.GetUpdatedAt()) {
+		t.Errorf("CreateJob() = %v, want it created and updated at the same time", job)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *model.CreateJobReq
+		want codes.Code
+	}{
+		{"scheduled", alice, &model.CreateJobReq{Job: &model.Job{Name: "report", Schedule: &model.Schedule{Cron: "0 9 * * 1-5"}}}, codes.OK},
+		{"same name as another owner", caller("bob"), &model.CreateJobReq{Job: &model.Job{Name: "backup"}}, codes.OK},
+		{"owner assigned by an admin", caller("root", auth.AdminRole), &model.CreateJobReq{Job: &model.Job{Name: "export", Owner: "carol"}}, codes.OK},
+		{"taken name", alice, &model.CreateJobReq{Job: &model.Job{Name: "backup"}}, codes.AlreadyExists},
+		{"no job", alice, &model.CreateJobReq{}, codes.InvalidArgument},
+		{"empty name", alice, &model.CreateJobReq{Job: &model.Job{Name: " "}}, codes.InvalidArgument},
+		{"invalid handler", alice, &model.CreateJobReq{Job: &model.Job{Name: "sync", Handler: "Shell Script"}}, codes.InvalidArgument},
+		{"invalid schedule", alice, &model.CreateJobReq{Job: &model.Job{Name: "sync", Schedule: &model.Schedule{Cron: "0 25 * * *"}}}, codes.InvalidArgument},
+		{"idempotency key too long", alice, &model.CreateJobReq{Job: &model.Job{Name: "sync"}, IdempotencyKey: strings.Repeat("k", maxIdempotencyKeyLength+1)}, codes.InvalidArgument},
+		{"other owner", alice, &model.CreateJobReq{Job: &model.Job{Name: "sync", Owner: "bob"}}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.CreateJob(tt.ctx, tt.req)
+			checkCode(t, "CreateJob()", err, tt.want)
+			if err == nil && res.GetJob().GetName() != tt.req.GetJob().GetName() {
+				t.Errorf("CreateJob() = %v, want the job %q", res.GetJob(), tt.req.GetJob().GetName())
+			}
+		})
+	}
+}
+
+func TestCreateJobIdempotencyKey(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	req := &model.CreateJobReq{Job: &model.Job{Name: "backup"}, IdempotencyKey: "k1"}
+	first, err := s.CreateJob(alice, req)
+	if err != nil {
+		t.Fatalf("CreateJob() failed: %v", err)
+	}
+	// A retry gets the same job, even with another name
+	retried, err := s.CreateJob(alice, &model.CreateJobReq{Job: &model.Job{Name: "other"}, IdempotencyKey: "k1"})
+	if err != nil {
+		t.Fatalf("retried CreateJob() failed: %v", err)
+	}
+	if retried.GetJob().GetId() != first.GetJob().GetId() {
+		t.Errorf("retried CreateJob() created job %s, want %s", retried.GetJob().GetId(), first.GetJob().GetId())
+	}
+	// Keys are per owner
+	bobs, err := s.CreateJob(caller("bob"), req)
+	if err != nil {
+		t.Fatalf("CreateJob() of another owner failed: %v", err)
+	}
+	if bobs.GetJob().GetId() == first.GetJob().GetId() {
+		t.Errorf("CreateJob() of another owner returned the job of alice")
+	}
+}
+
+func TestCreateJobValidateOnly(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	res, err := s.CreateJob(alice, &model.CreateJobReq{Job: &model.Job{Name: "backup"}, ValidateOnly: true})
+	if err != nil {
+		t.Fatalf("CreateJob() failed: %v", err)
+	}
+	if res.GetJob().GetId() != "" || res.GetJob().GetOwner() != "alice" {
+		t.Errorf("CreateJob() = %v, want the job of alice without an id", res.GetJob())
+	}
+	// Nothing was stored, so the name is still free
+	createJob(t, s, alice, &model.Job{Name: "backup"})
+	_, err = s.CreateJob(alice, &model.CreateJobReq{Job: &model.Job{Name: "backup"}, ValidateOnly: true})
+	checkCode(t, "CreateJob() of a taken name", err, codes.AlreadyExists)
+}
+
+func TestReadJob(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	job := createJob(t, s, alice, &model.Job{Name: "backup", Description: "nightly"})
+	deleted := createJob(t, s, alice, &model.Job{Name: "old"})
+	if _, err := s.DeleteJob(alice, &model.DeleteJobReq{Id: deleted.GetId()}); err != nil {
+		t.Fatalf("DeleteJob() failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *model.ReadJobReq
+		want codes.Code
+	}{
+		{"own job", alice, &model.ReadJobReq{Id: job.GetId()}, codes.OK},
+		{"admin", caller("root", auth.AdminRole), &model.ReadJobReq{Id: job.GetId()}, codes.OK},
+		{"read mask", alice, &model.ReadJobReq{Id: job.GetId(), ReadMask: &field_mask.FieldMask{Paths: []string{"name"}}}, codes.OK},
+		{"invalid read mask", alice, &model.ReadJobReq{Id: job.GetId(), ReadMask: &field_mask.FieldMask{Paths: []string{"nope"}}}, codes.InvalidArgument},
+		{"other owner", caller("bob"), &model.ReadJobReq{Id: job.GetId()}, codes.NotFound},
+		{"deleted", alice, &model.ReadJobReq{Id: deleted.GetId()}, codes.NotFound},
+		{"deleted included", alice, &model.ReadJobReq{Id: deleted.GetId(), IncludeDeleted: true}, codes.OK},
+		{"missing", alice, &model.ReadJobReq{Id: "5f8a1b2c3d4e5f6a7b8c9d0e"}, codes.NotFound},
+		{"invalid id", alice, &model.ReadJobReq{Id: "nope"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.ReadJob(tt.ctx, tt.req)
+			checkCode(t, "ReadJob()", err, tt.want)
+			if err == nil && res.GetJob().GetId() != tt.req.GetId() {
+				t.Errorf("ReadJob() = %v, want job %s", res.GetJob(), tt.req.GetId())
+			}
+		})
+	}
+}
+
+func TestUpdateJob(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	job := createJob(t, s, alice, &model.Job{Name: "backup", Description: "nightly", Command: "backup.sh"})
+	other := createJob(t, s, alice, &model.Job{Name: "report"})
+	mask := func(paths ...string) *field_mask.FieldMask {
+		return &field_mask.FieldMask{Paths: paths}
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *model.UpdateJobReq
+		want codes.Code
+	}{
+		{"description", alice, &model.UpdateJobReq{Job: &model.Job{Id: job.GetId(), Description: "weekly"}, UpdateMask: mask("description")}, codes.OK},
+		{"validate only", alice, &model.UpdateJobReq{Job: &model.Job{Id: job.GetId(), Description: "hourly"}, UpdateMask: mask("description"), ValidateOnly: true}, codes.OK},
+		{"taken name", alice, &model.UpdateJobReq{Job: &model.Job{Id: job.GetId(), Name: other.GetName()}, UpdateMask: mask("name")}, codes.AlreadyExists},
+		{"invalid name", alice, &model.UpdateJobReq{Job: &model.Job{Id: job.GetId(), Name: ""}, UpdateMask: mask("name")}, codes.InvalidArgument},
+		{"invalid mask", alice, &model.UpdateJobReq{Job: &model.Job{Id: job.GetId()}, UpdateMask: mask("nope")}, codes.InvalidArgument},
+		{"invalid schedule", alice, &model.UpdateJobReq{Job: &model.Job{Id: job.GetId(), Schedule: &model.Schedule{Cron: "61 * * * *"}}, UpdateMask: mask("schedule")}, codes.InvalidArgument},
+		{"other owner", caller("bob"), &model.UpdateJobReq{Job: &model.Job{Id: job.GetId(), Description: "weekly"}, UpdateMask: mask("description")}, codes.NotFound},
+		{"missing", alice, &model.UpdateJobReq{Job: &model.Job{Id: "5f8a1b2c3d4e5f6a7b8c9d0e", Description: "weekly"}, UpdateMask: mask("description")}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.UpdateJob(tt.ctx, tt.req)
+			checkCode(t, "UpdateJob()", err, tt.want)
+			if err == nil && res.GetJob().GetDescription() != tt.req.GetJob().GetDescription() {
+				t.Errorf("UpdateJob() = %v, want the description %q", res.GetJob(), tt.req.GetJob().GetDescription())
+			}
+		})
+	}
+	// Only the masked field changed, validating didn't store anything
+	res, err := s.ReadJob(alice, &model.ReadJobReq{Id: job.GetId()})
+	if err != nil {
+		t.Fatalf("ReadJob() failed: %v", err)
+	}
+	if stored := res.GetJob(); stored.GetDescription() != "weekly" || stored.GetName() != "backup" || stored.GetCommand() != "backup.sh" {
+		t.Errorf("stored job is %v, want only its description updated to weekly", stored)
+	}
+}
+
+func TestDeleteRestoreJob(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	job := createJob(t, s, alice, &model.Job{Name: "backup"})
+	id := job.GetId()
+
+	_, err := s.DeleteJob(caller("bob"), &model.DeleteJobReq{Id: id})
+	checkCode(t, "DeleteJob() of another owner's job", err, codes.NotFound)
+	_, err = s.RestoreJob(alice, &model.RestoreJobReq{Id: id})
+	checkCode(t, "RestoreJob() of a job that isn't deleted", err, codes.NotFound)
+
+	res, err := s.DeleteJob(alice, &model.DeleteJobReq{Id: id})
+	if err != nil || !res.GetSuccess() {
+		t.Fatalf("DeleteJob() = %v, %v, want success", res, err)
+	}
+	_, err = s.DeleteJob(alice, &model.DeleteJobReq{Id: id})
+	checkCode(t, "second DeleteJob()", err, codes.NotFound)
+	_, err = s.DeleteJob(alice, &model.DeleteJobReq{Id: "nope"})
+	checkCode(t, "DeleteJob() of an invalid id", err, codes.InvalidArgument)
+
+	_, err = s.RestoreJob(caller("bob"), &model.RestoreJobReq{Id: id})
+	checkCode(t, "RestoreJob() of another owner's job", err, codes.NotFound)
+	restored, err := s.RestoreJob(alice, &model.RestoreJobReq{Id: id})
+	if err != nil {
+		t.Fatalf("RestoreJob() failed: %v", err)
+	}
+	if restored.GetJob().GetDeletedAt() != nil {
+		t.Errorf("RestoreJob() = %v, want it not deleted", restored.GetJob())
+	}
+	if _, err := s.ReadJob(alice, &model.ReadJobReq{Id: id}); err != nil {
+		t.Errorf("ReadJob() of the restored job failed: %v", err)
+	}
+}
+
+func TestDeleteJobs(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	job := createJob(t, s, alice, &model.Job{Name: "backup"})
+	res, err := s.DeleteJobs(alice, &model.DeleteJobsReq{Ids: []string{job.GetId(), job.GetId(), "nope"}})
+	if err != nil {
+		t.Fatalf("DeleteJobs() failed: %v", err)
+	}
+	want := []bool{true, false, false}
+	for i, result := range res.GetResults() {
+		if result.GetSuccess() != want[i] || (result.GetError() == "") != want[i] {
+			t.Errorf("result %d of DeleteJobs() is %v, want success %v", i, result, want[i])
+		}
+	}
+	_, err = s.DeleteJobs(alice, &model.DeleteJobsReq{Ids: make([]string, maxDeleteJobs+1)})
+	checkCode(t, "DeleteJobs() of too many jobs", err, codes.InvalidArgument)
+}
+
+func TestPauseResumeJob(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	id := createJob(t, s, alice, &model.Job{Name: "backup"}).GetId()
+
+	paused, err := s.PauseJob(alice, &model.PauseJobReq{Id: id})
+	if err != nil {
+		t.Fatalf("PauseJob() failed: %v", err)
+	}
+	if paused.GetJob().GetStatus() != model.JobStatus_JOB_STATUS_PAUSED {
+		t.Errorf("PauseJob() = %v, want it paused", paused.GetJob())
+	}
+	_, err = s.PauseJob(alice, &model.PauseJobReq{Id: id})
+	checkCode(t, "PauseJob() of a paused job", err, codes.FailedPrecondition)
+	_, err = s.ResumeJob(caller("bob"), &model.ResumeJobReq{Id: id})
+	checkCode(t, "ResumeJob() of another owner's job", err, codes.NotFound)
+
+	resumed, err := s.ResumeJob(alice, &model.ResumeJobReq{Id: id})
+	if err != nil {
+		t.Fatalf("ResumeJob() failed: %v", err)
+	}
+	if resumed.GetJob().GetStatus() != model.JobStatus_JOB_STATUS_PENDING {
+		t.Errorf("ResumeJob() = %v, want it pending", resumed.GetJob())
+	}
+	_, err = s.ResumeJob(alice, &model.ResumeJobReq{Id: id})
+	checkCode(t, "ResumeJob() of a pending job", err, codes.FailedPrecondition)
+}
+
+func TestCloneJob(t *testing.T) {
+	s := newJobService()
+	alice := caller("alice")
+	source := createJob(t, s, alice, &model.Job{Name: "backup", Description: "nightly", Labels: map[string]string{"team": "data"}})
+	blackout := &repository.Blackout{CalendarIDs: []string{"5f8a1b2c3d4e5f6a7b8c9d0e"}, Defer: true}
+	if _, err := s.Jobs.Update(alice, source.GetId(), repository.Query{}, &repository.JobUpdate{SetBlackout: true, Blackout: blackout}); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	long := createJob(t, s, alice, &model.Job{Name: strings.Repeat("a", maxNameLength)})
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *model.CloneJobReq
+		want     codes.Code
+		wantName string
+	}{
+		{"same environment", alice, &model.CloneJobReq{Id: source.GetId()}, codes.OK, "backup (copy)"},
+		{"new name", alice, &model.CloneJobReq{Id: source.GetId(), Overrides: &model.Job{Name: "restore"}, OverrideMask: &field_mask.FieldMask{Paths: []string{"name"}}}, codes.OK, "restore"},
+		{"long name", alice, &model.CloneJobReq{Id: long.GetId()}, codes.OK, strings.Repeat("a", maxNameLength-len(cloneSuffix)) + cloneSuffix},
+		{"name of the clone taken", alice, &model.CloneJobReq{Id: source.GetId()}, codes.AlreadyExists, ""},
+		{"invalid mask", alice, &model.CloneJobReq{Id: source.GetId(), OverrideMask: &field_mask.FieldMask{Paths: []string{"nope"}}}, codes.InvalidArgument, ""},
+		{"other owner", caller("bob"), &model.CloneJobReq{Id: source.GetId()}, codes.NotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.CloneJob(tt.ctx, tt.req)
+			checkCode(t, "CloneJob()", err, tt.want)
+			if err != nil {
+				return
+			}
+			clone := res.GetJob()
+			if clone.GetId() == tt.req.GetId() || clone.GetName() != tt.wantName {
+				t.Errorf("CloneJob() = %v, want a new job named %q", clone, tt.wantName)
+			}
+		})
+	}
+
+	// The clone keeps the calendars of the job and what happens in their blackouts
+	res, err := s.CloneJob(alice, &model.CloneJobReq{Id: source.GetId(), Overrides: &model.Job{Name: "archive"}, OverrideMask: &field_mask.FieldMask{Paths: []string{"name"}}})
+	if err != nil {
+		t.Fatalf("CloneJob() failed: %v", err)
+	}
+	stored, err := s.Jobs.Get(alice, res.GetJob().GetId(), repository.Query{})
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if stored.Blackout == nil || !reflect.DeepEqual(*stored.Blackout, *blackout) {
+		t.Errorf("clone has the blackout %+v, want %+v", stored.Blackout, blackout)
+	}
+	if stored.Description != "nightly" || stored.Labels["team"] != "data" {
+		t.Errorf("clone is %+v, want the description and labels of the job", stored)
+	}
+	if action := res.GetJob().GetBlackout().GetAction(); action != model.BlackoutAction_BLACKOUT_ACTION_DEFER {
+		t.Errorf("CloneJob() returned the blackout action %v, want %v", action, model.BlackoutAction_BLACKOUT_ACTION_DEFER)
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/report"
+	"github.com/noltedennis/schedulytics-backend/repository"
+)
+
+// reportChunkSize is the most data of a report file a single message of GenerateReport carries
+const reportChunkSize = 64 << 10
+
+// reportPeriods maps the periods of requests to the ones of the reports
+var reportPeriods = map[model.ReportPeriod]string{
+	model.ReportPeriod_REPORT_PERIOD_UNSPECIFIED: report.PeriodWeek,
+	model.ReportPeriod_REPORT_PERIOD_WEEK:        report.PeriodWeek,
+	model.ReportPeriod_REPORT_PERIOD_MONTH:       report.PeriodMonth,
+}
+
+// reportFormats maps the formats of requests to the ones of the reports
+var reportFormats = map[model.ReportFormat]string{
+	model.ReportFormat_REPORT_FORMAT_UNSPECIFIED: report.FormatCSV,
+	model.ReportFormat_REPORT_FORMAT_CSV:         report.FormatCSV,
+	model.ReportFormat_REPORT_FORMAT_PDF:         report.FormatPDF,
+}
+
+type ReportServiceServer struct {
+	Reports *report.Builder
+}
+
+func (s *ReportServiceServer) GenerateReport(req *model.GenerateReportReq, stream model.ReportService_GenerateReportServer) error {
+	ctx := stream.Context()
+	period, ok := reportPeriods[req.GetPeriod()]
+	if !ok {
+		return invalidArgumentError("period", fmt.Sprintf("Invalid period: %v", req.GetPeriod()))
+	}
+	format, ok := reportFormats[req.GetFormat()]
+	if !ok {
+		return invalidArgumentError("format", fmt.Sprintf("Invalid format: %v", req.GetFormat()))
+	}
+	end := time.Now().UTC()
+	if req.GetEndTime() != nil {
+		t, err := ptypes.Timestamp(req.GetEndTime())
+		if err != nil {
+			return invalidArgumentError("end_time", fmt.Sprintf("Invalid end time: %v", err))
+		}
+		end = t
+	}
+	q := ownerQuery(ctx)
+	if req.GetOwner() != "" {
+		owner, err := summaryOwner(ctx, req.GetOwner(), "analytics")
+		if err != nil {
+			return err
+		}
+		q = repository.Query{Owner: owner}
+	}
+	from, to := report.PeriodBefore(period, end)
+	r, err := s.Reports.Build(ctx, q, period, from, to)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	var buf bytes.Buffer
+	if err := report.Write(&buf, r, format); err != nil {
+		return internalError(ctx, err)
+	}
+	res := &model.GenerateReportRes{Filename: report.Filename(r, format), ContentType: report.ContentType(format)}
+	data := buf.Bytes()
+	for {
+		n := len(data)
+		if n > reportChunkSize {
+			n = reportChunkSize
+		}
+		res.Data = data[:n]
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+		data = data[n:]
+		if len(data) == 0 {
+			return nil
+		}
+		res = &model.GenerateReportRes{}
+	}
+}
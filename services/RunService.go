@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/retention"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type RunServiceServer struct {
+	Runs repository.RunRepository
+	// Policies holds the retention policies of runs, the jobs of policies are looked up in Jobs
+	Policies repository.RunRetentionRepository
+	Jobs     repository.JobRepository
+}
+
+func (s *RunServiceServer) GetJobRun(ctx context.Context, req *model.GetJobRunReq) (*model.GetJobRunRes, error) {
+	run, err := s.Runs.Get(ctx, req.GetId())
+	if err == repository.ErrInvalidID {
+		return nil, invalidIDError(runResource, req.GetId(), fmt.Sprintf("Invalid run id %q", req.GetId()))
+	} else if err == repository.ErrNotFound {
+		return nil, notFoundError(runResource, req.GetId(), fmt.Sprintf("Could not find run with id %s", req.GetId()))
+	} else if err == repository.ErrOtherTenant {
+		return nil, otherTenantError(runResource, req.GetId(), fmt.Sprintf("Run with id %s belongs to another tenant", req.GetId()))
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.GetJobRunRes{Run: runToProto(run)}, nil
+}
+
+func (s *RunServiceServer) ListJobRuns(req *model.ListJobRunsReq, stream model.RunService_ListJobRunsServer) error {
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	before := ""
+	if req.GetPageToken() != "" {
+		before, err = decodePageToken(req.GetPageToken())
+		if err != nil {
+			return invalidArgumentError("page_token", fmt.Sprintf("Invalid page token: %v", err))
+		}
+	}
+	// Runs are listed newest first, so the next page continues below the last ID
+	runs, err := s.Runs.List(stream.Context(), req.GetJobId(), before, int(pageSize)+1)
+	if err == repository.ErrInvalidID {
+		return jobIDOrPageTokenError()
+	} else if err != nil {
+		return internalError(stream.Context(), err)
+	}
+	page := []*model.JobRun{}
+	for _, run := range runs {
+		page = append(page, runToProto(run))
+	}
+
+	hasMore := len(page) > int(pageSize)
+	if hasMore {
+		page = page[:pageSize]
+	}
+	for i, run := range page {
+		res := &model.ListJobRunsRes{Run: run}
+		if hasMore && i == len(page)-1 {
+			res.NextPageToken = encodePageToken(run.GetId())
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minRunMaxAge is the shortest max age of a run retention policy
+const minRunMaxAge = time.Hour
+
+func (s *RunServiceServer) GetRunRetentionPolicy(ctx context.Context, req *model.GetRunRetentionPolicyReq) (*model.GetRunRetentionPolicyRes, error) {
+	policy, err := s.Policies.Get(ctx, req.GetJobId())
+	if err == repository.ErrNotFound {
+		return nil, policyNotFoundError(req.GetJobId())
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.GetRunRetentionPolicyRes{Policy: policyToProto(policy)}, nil
+}
+
+func (s *RunServiceServer) SetRunRetentionPolicy(ctx context.Context, req *model.SetRunRetentionPolicyReq) (*model.SetRunRetentionPolicyRes, error) {
+	p := req.GetPolicy()
+	var violations []*errdetails.BadRequest_FieldViolation
+	if p.GetKeepRuns() < 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "policy.keep_runs", Description: "Keep runs must not be negative"})
+	}
+	var maxAge time.Duration
+	if p.GetMaxAge() != nil {
+		var err error
+		if maxAge, err = ptypes.Duration(p.GetMaxAge()); err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "policy.max_age", Description: fmt.Sprintf("Invalid max age: %v", err)})
+		} else if maxAge < minRunMaxAge {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "policy.max_age", Description: fmt.Sprintf("Max age must be at least %v", minRunMaxAge)})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, violationsError(fmt.Sprintf("Invalid run retention policy: %s", violations[0].Description), violations)
+	}
+	if id := p.GetJobId(); id != "" {
+		// Deleted jobs may still be restored, so they keep their policies
+		q := ownerQuery(ctx)
+		q.IncludeDeleted = true
+		exists, err := s.Jobs.Exists(ctx, id, q)
+		if err == nil && !exists {
+			err = repository.ErrNotFound
+		}
+		if err != nil {
+			return nil, jobError(ctx, err, id)
+		}
+	}
+	policy, err := s.Policies.Set(ctx, &repository.RunRetentionPolicy{
+		JobID:     p.GetJobId(),
+		KeepRuns:  int(p.GetKeepRuns()),
+		MaxAge:    maxAge,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	s.enforce(ctx)
+	return &model.SetRunRetentionPolicyRes{Policy: policyToProto(policy)}, nil
+}
+
+func (s *RunServiceServer) DeleteRunRetentionPolicy(ctx context.Context, req *model.DeleteRunRetentionPolicyReq) (*model.DeleteRunRetentionPolicyRes, error) {
+	err := s.Policies.Delete(ctx, req.GetJobId())
+	if err == repository.ErrNotFound {
+		return nil, policyNotFoundError(req.GetJobId())
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	s.enforce(ctx)
+	return &model.DeleteRunRetentionPolicyRes{}, nil
+}
+
+func (s *RunServiceServer) ListRunRetentionPolicies(req *model.ListRunRetentionPoliciesReq, stream model.RunService_ListRunRetentionPoliciesServer) error {
+	policies, err := s.Policies.List(stream.Context())
+	if err != nil {
+		return internalError(stream.Context(), err)
+	}
+	for _, policy := range policies {
+		if err := stream.Send(&model.ListRunRetentionPoliciesRes{Policy: policyToProto(policy)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforce removes the runs past the changed policies right away instead of with the next pass of the janitor. The
+// change is stored already, so runs that couldn't be pruned are only logged and left to the janitor.
+func (s *RunServiceServer) enforce(ctx context.Context) {
+	if _, err := retention.EnforceRuns(ctx, s.Runs, s.Policies); err != nil {
+		logging.FromContext(ctx).Warn("Could not prune runs", zap.Error(err))
+	}
+}
+
+// policyNotFoundError tells that the job with jobID, or the tenant when it's empty, has no run retention policy
+func policyNotFoundError(jobID string) error {
+	if jobID == "" {
+		return notFoundError(policyResource, "default", "There is no default run retention policy")
+	}
+	return notFoundError(policyResource, jobID, fmt.Sprintf("Could not find a run retention policy of job %s", jobID))
+}
+
+// policyToProto converts a stored run retention policy into its message
+func policyToProto(policy *repository.RunRetentionPolicy) *model.RunRetentionPolicy {
+	res := &model.RunRetentionPolicy{
+		JobId:     policy.JobID,
+		KeepRuns:  int32(policy.KeepRuns),
+		UpdatedAt: timestampProto(policy.UpdatedAt),
+	}
+	if policy.MaxAge != 0 {
+		res.MaxAge = ptypes.DurationProto(policy.MaxAge)
+	}
+	return res
+}
+
+// runToProto converts a stored run into the JobRun message sent to clients
+func runToProto(run *repository.Run) *model.JobRun {
+	res := &model.JobRun{
+		Id:            run.ID,
+		JobId:         run.JobID,
+		Status:        model.RunStatus(model.RunStatus_value["RUN_STATUS_"+run.Status]),
+		QueuedAt:      timestampProto(run.QueuedAt),
+		Output:        run.Output,
+		Error:         run.Error,
+		Attempt:       int32(run.Attempt),
+		CycleId:       run.CycleID,
+		Priority:      model.JobPriority(model.JobPriority_value["JOB_PRIORITY_"+run.Priority]),
+		Trigger:       model.RunTrigger(model.RunTrigger_value["RUN_TRIGGER_"+run.Trigger]),
+		TriggeredBy:   run.TriggeredBy,
+		Worker:        run.Worker,
+		CostPerSecond: run.CostPerSecond,
+		Cost:          run.Cost,
+	}
+	if run.StartTime != nil {
+		res.StartTime = timestampProto(*run.StartTime)
+	}
+	if run.EndTime != nil {
+		res.EndTime = timestampProto(*run.EndTime)
+	}
+	if run.RetryAt != nil {
+		res.RetryAt = timestampProto(*run.RetryAt)
+	}
+	if run.Timeout != 0 {
+		res.Timeout = ptypes.DurationProto(run.Timeout)
+	}
+	if run.EndTime != nil && run.StartTime != nil {
+		res.Duration = ptypes.DurationProto(run.Duration)
+	}
+	return res
+}
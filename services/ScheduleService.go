@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+	"google.golang.org/grpc/codes"
+)
+
+type ScheduleServiceServer struct {
+	Jobs repository.JobRepository
+	// Events stores an update event for every job whose schedule changed in the same transaction, none are stored when
+	// it's nil
+	Events EventStore
+	// Listeners are told about every job whose schedule changed
+	Listeners []JobListener
+}
+
+func (s *ScheduleServiceServer) SetSchedule(ctx context.Context, req *model.SetScheduleReq) (*model.SetScheduleRes, error) {
+	if req.GetSchedule() == nil {
+		return nil, invalidArgumentError("schedule", "Schedule must be set, use RemoveSchedule to unschedule a job")
+	}
+	job, err := s.setSchedule(ctx, req.GetJobId(), req.GetSchedule())
+	if err != nil {
+		return nil, err
+	}
+	return &model.SetScheduleRes{Job: job}, nil
+}
+
+func (s *ScheduleServiceServer) RemoveSchedule(ctx context.Context, req *model.RemoveScheduleReq) (*model.RemoveScheduleRes, error) {
+	job, err := s.setSchedule(ctx, req.GetJobId(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RemoveScheduleRes{Job: job}, nil
+}
+
+// defaultPreviewCount is the number of run times PreviewSchedule computes when the request doesn't say
+const defaultPreviewCount = 10
+
+func (s *ScheduleServiceServer) PreviewSchedule(ctx context.Context, req *model.PreviewScheduleReq) (*model.PreviewScheduleRes, error) {
+	if req.GetSchedule() == nil {
+		return nil, invalidArgumentError("schedule", "Schedule must be set")
+	}
+	spec, err := scheduleSpec(req.GetSchedule())
+	if err == nil {
+		err = spec.Validate()
+	}
+	if err != nil {
+		return nil, invalidArgumentError("schedule", fmt.Sprintf("Invalid schedule: %v", err))
+	}
+	times, err := previewTimes(ctx, spec, req.GetCount(), req.GetStartTime())
+	if err != nil {
+		return nil, err
+	}
+	return &model.PreviewScheduleRes{NextRunTimes: times}, nil
+}
+
+func (s *ScheduleServiceServer) PreviewCron(ctx context.Context, req *model.PreviewCronReq) (*model.PreviewCronRes, error) {
+	if req.GetExpression() == "" {
+		return nil, invalidArgumentError("expression", "Expression must not be empty")
+	}
+	if err := scheduler.CheckCron(req.GetExpression()); err != nil {
+		return nil, cronError("expression", err.(*scheduler.CronError))
+	}
+	if req.GetTimezone() != "" {
+		if _, err := time.LoadLocation(req.GetTimezone()); err != nil {
+			return nil, invalidArgumentError("timezone", fmt.Sprintf("Unknown timezone %q", req.GetTimezone()))
+		}
+	}
+	// Only combinations of a valid expression and timezone are left, like a TZ= prefix together with a timezone
+	spec := &scheduler.Spec{Cron: req.GetExpression(), Timezone: req.GetTimezone()}
+	if err := spec.Validate(); err != nil {
+		return nil, invalidArgumentError("expression", fmt.Sprintf("Invalid schedule: %v", err))
+	}
+	times, err := previewTimes(ctx, spec, req.GetCount(), req.GetStartTime())
+	if err != nil {
+		return nil, err
+	}
+	return &model.PreviewCronRes{NextRunTimes: times}, nil
+}
+
+// previewTimes computes count run times of spec after start like PreviewSchedule, count and start default to
+// defaultPreviewCount and now
+func previewTimes(ctx context.Context, spec *scheduler.Spec, count int32, start *timestamp.Timestamp) ([]*timestamp.Timestamp, error) {
+	n := int(count)
+	if n == 0 {
+		n = defaultPreviewCount
+	}
+	if n < 1 || n > scheduler.MaxPreview {
+		return nil, invalidArgumentError("count", fmt.Sprintf("Count must be between 1 and %d", scheduler.MaxPreview))
+	}
+	from := time.Now()
+	if start != nil {
+		var err error
+		if from, err = ptypes.Timestamp(start); err != nil {
+			return nil, invalidArgumentError("start_time", fmt.Sprintf("Invalid start time: %v", err))
+		}
+	}
+	times, err := spec.Preview(from, n)
+	if err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not compute run times: %v", err))
+	}
+	res := make([]*timestamp.Timestamp, 0, len(times))
+	for _, t := range times {
+		res = append(res, timestampProto(t))
+	}
+	return res, nil
+}
+
+// setSchedule stores the schedule (nil removes it) together with the resulting next run time and returns the updated job
+func (s *ScheduleServiceServer) setSchedule(ctx context.Context, id string, schedule *model.Schedule) (*model.Job, error) {
+	spec, next, err := scheduleFields(schedule)
+	if err != nil {
+		return nil, invalidArgumentError("schedule", fmt.Sprintf("Invalid schedule: %v", err))
+	}
+	// Jobs depending on others run when those succeeded
+	if spec != nil {
+		job, err := s.Jobs.Get(ctx, id, ownerQuery(ctx))
+		if err != nil {
+			return nil, jobError(ctx, err, id)
+		}
+		if len(job.DependsOn) > 0 {
+			return nil, newError(codes.FailedPrecondition, reasonHasDependencies, fmt.Sprintf("Job %s depends on other jobs and runs when they succeeded, remove its dependencies to schedule it", id))
+		}
+	}
+	update := &repository.JobUpdate{
+		SetSchedule: true,
+		Schedule:    spec,
+		NextRunTime: next,
+		UpdatedAt:   now(),
+	}
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		return s.Jobs.Update(ctx, id, ownerQuery(ctx), update)
+	})
+	if err != nil {
+		return nil, jobError(ctx, err, id)
+	}
+	return jobToProto(updated), nil
+}
+
+// scheduleFields converts a schedule from a request into its stored form and computes the first run time.
+// A nil schedule results in nil values, which unschedules the job.
+func scheduleFields(schedule *model.Schedule) (*scheduler.Spec, *time.Time, error) {
+	if schedule == nil {
+		return nil, nil, nil
+	}
+	spec, err := scheduleSpec(schedule)
+	if err != nil {
+		return nil, nil, err
+	}
+	next, err := spec.Next(time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return spec, &next, nil
+}
+
+// scheduleSpec converts a schedule from a request into its stored form without validating it
+func scheduleSpec(schedule *model.Schedule) (*scheduler.Spec, error) {
+	spec := &scheduler.Spec{Cron: schedule.GetCron(), Timezone: schedule.GetTimezone()}
+	if schedule.GetInterval() != nil {
+		interval, err := ptypes.Duration(schedule.GetInterval())
+		if err != nil {
+			return nil, err
+		}
+		spec.Interval = interval
+	}
+	return spec, nil
+}
+
+// scheduleToProto converts a stored schedule back into its message form
+func scheduleToProto(spec *scheduler.Spec) *model.Schedule {
+	if spec == nil {
+		return nil
+	}
+	schedule := &model.Schedule{Cron: spec.Cron, Timezone: spec.Timezone}
+	if spec.Interval != 0 {
+		schedule.Interval = ptypes.DurationProto(spec.Interval)
+	}
+	return schedule
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/secrets"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"google.golang.org/grpc/codes"
+)
+
+// maxSecretSize is the largest value of a secret in bytes
+const maxSecretSize = 64 * 1024
+
+type SecretServiceServer struct {
+	Secrets repository.SecretRepository
+	// Cipher seals the values of secrets, secrets can't be created or changed when it's nil
+	Cipher *secrets.Cipher
+	// Users and Teams check the owners secrets are assigned to like the owners of jobs
+	Users repository.UserRepository
+	Teams repository.TeamRepository
+}
+
+func (s *SecretServiceServer) CreateSecret(ctx context.Context, req *model.CreateSecretReq) (*model.CreateSecretRes, error) {
+	secret := req.GetSecret()
+	if err := validateSecret(secret, true); err != nil {
+		return nil, err
+	}
+	if err := s.checkCipher(); err != nil {
+		return nil, err
+	}
+	owner, err := ownerForCaller(ctx, s.Users, s.Teams, secret.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.Cipher.Seal(secret.GetValue(), secrets.Binding(tenant.FromContext(ctx), owner, secret.GetName()))
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	createdAt := now()
+	created, err := s.Secrets.Create(ctx, &repository.Secret{
+		Name:        secret.GetName(),
+		Description: secret.GetDescription(),
+		Owner:       owner,
+		Value:       value,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	})
+	if err == repository.ErrNameTaken {
+		return nil, newError(codes.AlreadyExists, reasonNameTaken, fmt.Sprintf("A secret named %q already exists, choose another name or update the existing secret", secret.GetName()))
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.CreateSecretRes{Secret: secretToProto(created)}, nil
+}
+
+func (s *SecretServiceServer) ReadSecret(ctx context.Context, req *model.ReadSecretReq) (*model.ReadSecretRes, error) {
+	secret, err := s.Secrets.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, secretError(ctx, err, req.GetId())
+	}
+	return &model.ReadSecretRes{Secret: secretToProto(secret)}, nil
+}
+
+func (s *SecretServiceServer) UpdateSecret(ctx context.Context, req *model.UpdateSecretReq) (*model.UpdateSecretRes, error) {
+	secret := req.GetSecret()
+	id := secret.GetId()
+	if err := validateSecret(secret, false); err != nil {
+		return nil, err
+	}
+	if err := s.checkCipher(); err != nil {
+		return nil, err
+	}
+	// The value is sealed with the name and owner of the stored secret, clients can't change them
+	stored, err := s.Secrets.Get(ctx, id, ownerQuery(ctx))
+	if err != nil {
+		return nil, secretError(ctx, err, id)
+	}
+	stored.Description = secret.GetDescription()
+	if secret.GetValue() != "" {
+		if stored.Value, err = s.Cipher.Seal(secret.GetValue(), secrets.Binding(stored.Tenant, stored.Owner, stored.Name)); err != nil {
+			return nil, internalError(ctx, err)
+		}
+	}
+	stored.UpdatedAt = now()
+	updated, err := s.Secrets.Update(ctx, stored, ownerQuery(ctx))
+	if err != nil {
+		return nil, secretError(ctx, err, id)
+	}
+	return &model.UpdateSecretRes{Secret: secretToProto(updated)}, nil
+}
+
+func (s *SecretServiceServer) DeleteSecret(ctx context.Context, req *model.DeleteSecretReq) (*model.DeleteSecretRes, error) {
+	if err := s.Secrets.Delete(ctx, req.GetId(), ownerQuery(ctx)); err != nil {
+		return nil, secretError(ctx, err, req.GetId())
+	}
+	return &model.DeleteSecretRes{}, nil
+}
+
+func (s *SecretServiceServer) ListSecrets(req *model.ListSecretsReq, stream model.SecretService_ListSecretsServer) error {
+	ctx := stream.Context()
+	list, err := s.Secrets.List(ctx, ownerQuery(ctx))
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, secret := range list {
+		if err := stream.Send(&model.ListSecretsRes{Secret: secretToProto(secret)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCipher returns FailedPrecondition when the server has no master key to seal values with
+func (s *SecretServiceServer) checkCipher() error {
+	if s.Cipher == nil {
+		return newError(codes.FailedPrecondition, reasonSecretsDisabled, "Secrets are disabled, SECRETS_MASTER_KEY is not set")
+	}
+	return nil
+}
+
+// validateSecret checks a secret sent by a client, new secrets need a name and a value. The messages never quote the
+// value.
+func validateSecret(secret *model.Secret, create bool) error {
+	if create && !secrets.NamePattern.MatchString(secret.GetName()) {
+		return invalidArgumentError("secret.name", "Name must be 1 to 64 letters, digits, _ or -")
+	}
+	if msg := checkLength(secret.GetDescription(), maxDescriptionLength); msg != "" {
+		return invalidArgumentError("secret.description", fmt.Sprintf("Description %s", msg))
+	}
+	if msg := checkControl(secret.GetDescription(), "\n\t"); msg != "" {
+		return invalidArgumentError("secret.description", fmt.Sprintf("Description %s", msg))
+	}
+	if create && secret.GetValue() == "" {
+		return invalidArgumentError("secret.value", "Value must not be empty")
+	}
+	if len(secret.GetValue()) > maxSecretSize {
+		return invalidArgumentError("secret.value", fmt.Sprintf("Value must be at most %d bytes, got %d", maxSecretSize, len(secret.GetValue())))
+	}
+	return nil
+}
+
+// secretError converts an error of the secret repository for the secret with id into the status returned to clients
+func secretError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(secretResource, id, fmt.Sprintf("Invalid Secret id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(secretResource, id, fmt.Sprintf("Could not find Secret with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// secretToProto converts a stored secret into the Secret message sent to clients, without its value
+func secretToProto(secret *repository.Secret) *model.Secret {
+	return &model.Secret{
+		Id:          secret.ID,
+		Name:        secret.Name,
+		Description: secret.Description,
+		Owner:       secret.Owner,
+		CreatedAt:   timestampProto(secret.CreatedAt),
+		UpdatedAt:   timestampProto(secret.UpdatedAt),
+	}
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// maxTeamNameLength is the maximum length in characters of the name of a team
+const maxTeamNameLength = 128
+
+// teamFields are the fields of a team UpdateTeam can change
+var teamFields = []string{"name", "roles"}
+
+type TeamServiceServer struct {
+	Teams repository.TeamRepository
+	// Users are looked up for the members added to teams
+	Users repository.UserRepository
+	// Jobs are looked up for the jobs of teams that are deleted, teams owning jobs can't be deleted
+	Jobs repository.JobRepository
+}
+
+func (s *TeamServiceServer) CreateTeam(ctx context.Context, req *model.CreateTeamReq) (*model.CreateTeamRes, error) {
+	name := req.GetTeam().GetName()
+	if err := validateTeamName(name); err != nil {
+		return nil, err
+	}
+	roles := req.GetTeam().GetRoles()
+	if len(roles) > 0 {
+		if err := requireAdmin(ctx, "Only admins can grant roles to teams"); err != nil {
+			return nil, err
+		}
+		if msg := checkRoles(roles); msg != "" {
+			return nil, invalidArgumentError("team.roles", fmt.Sprintf("Roles %s", msg))
+		}
+	}
+	data := &repository.Team{Name: name, Roles: roles, CreatedAt: now()}
+	data.UpdatedAt = data.CreatedAt
+	// Without authentication there is nobody to add, members are added with AddTeamMember then
+	if claims, ok := auth.FromContext(ctx); ok {
+		data.Members = []string{claims.Subject}
+	}
+	created, err := s.Teams.Create(ctx, data)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.CreateTeamRes{Team: teamToProto(created)}, nil
+}
+
+func (s *TeamServiceServer) ReadTeam(ctx context.Context, req *model.ReadTeamReq) (*model.ReadTeamRes, error) {
+	team, err := s.teamForCaller(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &model.ReadTeamRes{Team: teamToProto(team)}, nil
+}
+
+func (s *TeamServiceServer) UpdateTeam(ctx context.Context, req *model.UpdateTeamReq) (*model.UpdateTeamRes, error) {
+	team := req.GetTeam()
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = teamFields
+		// Only admins can grant roles, the others rename the team
+		if requireAdmin(ctx, "") != nil {
+			paths = []string{"name"}
+		}
+	}
+	for _, path := range paths {
+		switch path {
+		case "name":
+			if err := validateTeamName(team.GetName()); err != nil {
+				return nil, err
+			}
+		case "roles":
+			if err := requireAdmin(ctx, "Only admins can grant roles to teams"); err != nil {
+				return nil, err
+			}
+			if msg := checkRoles(team.GetRoles()); msg != "" {
+				return nil, invalidArgumentError("team.roles", fmt.Sprintf("Roles %s", msg))
+			}
+		default:
+			return nil, invalidArgumentError("update_mask", fmt.Sprintf("Invalid update mask: unknown or read-only field %q", path))
+		}
+	}
+	id := team.GetId()
+	updated, err := s.teamForCaller(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt := now()
+	for _, path := range paths {
+		switch path {
+		case "name":
+			updated, err = s.Teams.Rename(ctx, id, team.GetName(), updatedAt)
+		case "roles":
+			updated, err = s.Teams.SetRoles(ctx, id, team.GetRoles(), updatedAt)
+		}
+		if err != nil {
+			return nil, teamError(ctx, err, id)
+		}
+	}
+	return &model.UpdateTeamRes{Team: teamToProto(updated)}, nil
+}
+
+func (s *TeamServiceServer) DeleteTeam(ctx context.Context, req *model.DeleteTeamReq) (*model.DeleteTeamRes, error) {
+	id := req.GetId()
+	if _, err := s.teamForCaller(ctx, id); err != nil {
+		return nil, err
+	}
+	// Deleted jobs count as well, restoring them would leave them to a team that's gone
+	jobs, err := s.Jobs.List(ctx, repository.Query{Owner: repository.TeamOwner(id), IncludeDeleted: true}, "", 1)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	if len(jobs) > 0 {
+		msg := fmt.Sprintf("Team with id %s still owns jobs, assign them to another owner or delete and purge them first", id)
+		return nil, newError(codes.FailedPrecondition, reasonTeamHasJobs, msg,
+			&errdetails.ResourceInfo{ResourceType: teamResource, ResourceName: id, Description: msg})
+	}
+	if err := s.Teams.Delete(ctx, id); err != nil {
+		return nil, teamError(ctx, err, id)
+	}
+	return &model.DeleteTeamRes{}, nil
+}
+
+func (s *TeamServiceServer) ListTeams(req *model.ListTeamsReq, stream model.TeamService_ListTeamsServer) error {
+	ctx := stream.Context()
+	member := ""
+	if claims, ok := auth.FromContext(ctx); ok && !claims.HasRole(auth.AdminRole) {
+		member = claims.Subject
+	}
+	teams, err := s.Teams.List(ctx, member)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, team := range teams {
+		if err := stream.Send(&model.ListTeamsRes{Team: teamToProto(team)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TeamServiceServer) AddTeamMember(ctx context.Context, req *model.AddTeamMemberReq) (*model.AddTeamMemberRes, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgumentError("user_id", "User id must not be empty")
+	}
+	if _, err := s.teamForCaller(ctx, req.GetTeamId()); err != nil {
+		return nil, err
+	}
+	if _, err := s.Users.Get(ctx, req.GetUserId()); err != nil {
+		return nil, userError(ctx, err, req.GetUserId())
+	}
+	team, err := s.Teams.AddMember(ctx, req.GetTeamId(), req.GetUserId(), now())
+	if err != nil {
+		return nil, teamError(ctx, err, req.GetTeamId())
+	}
+	return &model.AddTeamMemberRes{Team: teamToProto(team)}, nil
+}
+
+func (s *TeamServiceServer) RemoveTeamMember(ctx context.Context, req *model.RemoveTeamMemberReq) (*model.RemoveTeamMemberRes, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgumentError("user_id", "User id must not be empty")
+	}
+	if _, err := s.teamForCaller(ctx, req.GetTeamId()); err != nil {
+		return nil, err
+	}
+	team, err := s.Teams.RemoveMember(ctx, req.GetTeamId(), req.GetUserId(), now())
+	if err != nil {
+		return nil, teamError(ctx, err, req.GetTeamId())
+	}
+	return &model.RemoveTeamMemberRes{Team: teamToProto(team)}, nil
+}
+
+// teamForCaller returns the team with id if the caller may access it. Only members and admins can, the teams of others
+// are reported as not found like the jobs of other owners.
+func (s *TeamServiceServer) teamForCaller(ctx context.Context, id string) (*repository.Team, error) {
+	team, err := s.Teams.Get(ctx, id)
+	if err != nil {
+		return nil, teamError(ctx, err, id)
+	}
+	claims, ok := auth.FromContext(ctx)
+	if !ok || claims.HasRole(auth.AdminRole) {
+		return team, nil
+	}
+	for _, member := range team.Members {
+		if member == claims.Subject {
+			return team, nil
+		}
+	}
+	return nil, teamError(ctx, repository.ErrNotFound, id)
+}
+
+// teamError converts an error of the team repository for the team with id into the status returned to clients
+func teamError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(teamResource, id, fmt.Sprintf("Invalid Team id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(teamResource, id, fmt.Sprintf("Could not find Team with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// validateTeamName checks the name of a team sent by a client
+func validateTeamName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return invalidArgumentError("team.name", "Name must not be empty")
+	}
+	if msg := checkLength(name, maxTeamNameLength); msg != "" {
+		return invalidArgumentError("team.name", fmt.Sprintf("Name %s", msg))
+	}
+	if msg := checkControl(name, ""); msg != "" {
+		return invalidArgumentError("team.name", fmt.Sprintf("Name %s", msg))
+	}
+	return nil
+}
+
+// teamToProto converts a stored team into the Team message sent to clients
+func teamToProto(team *repository.Team) *model.Team {
+	return &model.Team{
+		Id:        team.ID,
+		Name:      team.Name,
+		Members:   team.Members,
+		CreatedAt: timestampProto(team.CreatedAt),
+		UpdatedAt: timestampProto(team.UpdatedAt),
+		Roles:     team.Roles,
+	}
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Limits of job templates
+const (
+	maxTemplateParameters         = 32
+	maxParameterNameLength        = 64
+	maxParameterDescriptionLength = 1024
+	maxParameterValueLength       = 1024
+)
+
+var (
+	// parameterNamePattern matches the names of template parameters
+	parameterNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	// placeholderPattern matches the placeholders of parameters in the job of a template
+	placeholderPattern = regexp.MustCompile(`\{\{([A-Za-z][A-Za-z0-9_]*)\}\}`)
+)
+
+type TemplateServiceServer struct {
+	Templates repository.TemplateRepository
+	// Jobs creates the jobs of templates like CreateJob, with the same checks, events and listeners
+	Jobs *JobServiceServer
+}
+
+func (s *TemplateServiceServer) CreateTemplate(ctx context.Context, req *model.CreateTemplateReq) (*model.CreateTemplateRes, error) {
+	data, err := s.templateFromProto(ctx, req.GetTemplate())
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = now()
+	data.UpdatedAt = data.CreatedAt
+	created, err := s.Templates.Create(ctx, data)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return &model.CreateTemplateRes{Template: templateToProto(created)}, nil
+}
+
+func (s *TemplateServiceServer) ReadTemplate(ctx context.Context, req *model.ReadTemplateReq) (*model.ReadTemplateRes, error) {
+	template, err := s.Templates.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, templateError(ctx, err, req.GetId())
+	}
+	return &model.ReadTemplateRes{Template: templateToProto(template)}, nil
+}
+
+func (s *TemplateServiceServer) UpdateTemplate(ctx context.Context, req *model.UpdateTemplateReq) (*model.UpdateTemplateRes, error) {
+	id := req.GetTemplate().GetId()
+	data, err := s.templateFromProto(ctx, req.GetTemplate())
+	if err != nil {
+		return nil, err
+	}
+	data.ID = id
+	data.UpdatedAt = now()
+	updated, err := s.Templates.Update(ctx, data, ownerQuery(ctx))
+	if err != nil {
+		return nil, templateError(ctx, err, id)
+	}
+	return &model.UpdateTemplateRes{Template: templateToProto(updated)}, nil
+}
+
+func (s *TemplateServiceServer) DeleteTemplate(ctx context.Context, req *model.DeleteTemplateReq) (*model.DeleteTemplateRes, error) {
+	if err := s.Templates.Delete(ctx, req.GetId(), ownerQuery(ctx)); err != nil {
+		return nil, templateError(ctx, err, req.GetId())
+	}
+	return &model.DeleteTemplateRes{}, nil
+}
+
+func (s *TemplateServiceServer) ListTemplates(req *model.ListTemplatesReq, stream model.TemplateService_ListTemplatesServer) error {
+	ctx := stream.Context()
+	templates, err := s.Templates.List(ctx, ownerQuery(ctx))
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, template := range templates {
+		if err := stream.Send(&model.ListTemplatesRes{Template: templateToProto(template)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TemplateServiceServer) CreateJobFromTemplate(ctx context.Context, req *model.CreateJobFromTemplateReq) (*model.CreateJobFromTemplateRes, error) {
+	template, err := s.Templates.Get(ctx, req.GetTemplateId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, templateError(ctx, err, req.GetTemplateId())
+	}
+	values, err := parameterValues(template.Parameters, req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+	job := &model.Job{}
+	// Templates stored before fields were removed from jobs still create jobs
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(strings.NewReader(template.Job), job); err != nil {
+		return nil, internalError(ctx, fmt.Errorf("invalid job of template %s: %v", template.ID, err))
+	}
+	replacePlaceholders(job, func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+			return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+		})
+	})
+	job.Owner = req.GetOwner()
+	lineage := &repository.TemplateLineage{TemplateID: template.ID, Version: template.Version, Parameters: values}
+	res, err := s.Jobs.createJob(ctx, job, req.GetIdempotencyKey(), lineage, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CreateJobFromTemplateRes{Job: res.GetJob()}, nil
+}
+
+// templateFromProto checks a template sent by a client and converts it into its stored form, without the fields set
+// by the server
+func (s *TemplateServiceServer) templateFromProto(ctx context.Context, template *model.JobTemplate) (*repository.JobTemplate, error) {
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+	owner, err := ownerForCaller(ctx, s.Jobs.Users, s.Jobs.Teams, template.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	// The owner of jobs is given when they are created, the other fields are set by the server
+	job := proto.Clone(template.GetJob()).(*model.Job)
+	job.Id, job.Owner, job.Status = "", "", model.JobStatus_JOB_STATUS_UNSPECIFIED
+	job.CreatedAt, job.UpdatedAt, job.NextRunTime, job.DeletedAt, job.Template = nil, nil, nil, nil, nil
+	m := &jsonpb.Marshaler{OrigName: true}
+	data, err := m.MarshalToString(job)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	parameters := make([]repository.TemplateParameter, 0, len(template.GetParameters()))
+	for _, p := range template.GetParameters() {
+		parameters = append(parameters, repository.TemplateParameter{
+			Name:        p.GetName(),
+			Description: p.GetDescription(),
+			Default:     p.GetDefaultValue(),
+			Required:    p.GetRequired(),
+		})
+	}
+	return &repository.JobTemplate{
+		Name:        template.GetName(),
+		Description: template.GetDescription(),
+		Owner:       owner,
+		Parameters:  parameters,
+		Job:         data,
+	}, nil
+}
+
+// validateTemplate checks a template sent by a client. Its job is only checked once the placeholders are replaced,
+// when a job is created from it, so here only its placeholders are checked.
+func validateTemplate(template *model.JobTemplate) error {
+	name := template.GetName()
+	if strings.TrimSpace(name) == "" {
+		return invalidArgumentError("template.name", "Name must not be empty")
+	}
+	if msg := checkLength(name, maxNameLength); msg != "" {
+		return invalidArgumentError("template.name", fmt.Sprintf("Name %s", msg))
+	}
+	if msg := checkControl(name, ""); msg != "" {
+		return invalidArgumentError("template.name", fmt.Sprintf("Name %s", msg))
+	}
+	if msg := checkLength(template.GetDescription(), maxDescriptionLength); msg != "" {
+		return invalidArgumentError("template.description", fmt.Sprintf("Description %s", msg))
+	}
+	if msg := checkControl(template.GetDescription(), "\n\t"); msg != "" {
+		return invalidArgumentError("template.description", fmt.Sprintf("Description %s", msg))
+	}
+	if len(template.GetParameters()) > maxTemplateParameters {
+		return invalidArgumentError("template.parameters", fmt.Sprintf("A template can have at most %d parameters, got %d",
+			maxTemplateParameters, len(template.GetParameters())))
+	}
+	declared := map[string]bool{}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, p := range template.GetParameters() {
+		field := fmt.Sprintf("template.parameters[%d]", i)
+		violation := func(name, msg string) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field + "." + name, Description: msg})
+		}
+		switch {
+		case checkLength(p.GetName(), maxParameterNameLength) != "" || !parameterNamePattern.MatchString(p.GetName()):
+			violation("name", fmt.Sprintf("Name %q must be at most %d letters, digits and underscores starting with a letter",
+				p.GetName(), maxParameterNameLength))
+		case declared[p.GetName()]:
+			violation("name", fmt.Sprintf("Parameter %s is declared twice", p.GetName()))
+		}
+		declared[p.GetName()] = true
+		if msg := checkLength(p.GetDescription(), maxParameterDescriptionLength); msg != "" {
+			violation("description", fmt.Sprintf("Description %s", msg))
+		}
+		if msg := checkLength(p.GetDefaultValue(), maxParameterValueLength); msg != "" {
+			violation("default_value", fmt.Sprintf("Default value %s", msg))
+		}
+	}
+	if len(violations) > 0 {
+		return violationsError(fmt.Sprintf("Invalid template parameters: %s", violations[0].GetDescription()), violations)
+	}
+	if template.GetJob() == nil {
+		return invalidArgumentError("template.job", "Job must be set")
+	}
+	var unknown []string
+	replacePlaceholders(template.GetJob(), func(s string) string {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+			if !declared[match[1]] {
+				unknown = append(unknown, match[1])
+			}
+		}
+		return s
+	})
+	if len(unknown) > 0 {
+		return invalidArgumentError("template.job", fmt.Sprintf("Job uses the undeclared parameter %s", unknown[0]))
+	}
+	return nil
+}
+
+// parameterValues returns the values of all parameters of a template, given contains the values of a request. Values
+// for parameters the template doesn't have and required parameters without a value are rejected.
+func parameterValues(parameters []repository.TemplateParameter, given map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(parameters))
+	declared := map[string]bool{}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, p := range parameters {
+		declared[p.Name] = true
+		value, ok := given[p.Name]
+		if !ok && p.Required {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "parameters[" + p.Name + "]",
+				Description: fmt.Sprintf("Parameter %s is required", p.Name)})
+			continue
+		}
+		if !ok {
+			value = p.Default
+		}
+		if msg := checkLength(value, maxParameterValueLength); msg != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "parameters[" + p.Name + "]",
+				Description: fmt.Sprintf("Value of parameter %s %s", p.Name, msg)})
+		}
+		values[p.Name] = value
+	}
+	names := make([]string, 0, len(given))
+	for name := range given {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !declared[name] {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "parameters[" + name + "]",
+				Description: fmt.Sprintf("Template has no parameter %s", name)})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, violationsError(fmt.Sprintf("Invalid parameters: %s", violations[0].GetDescription()), violations)
+	}
+	return values, nil
+}
+
+// replacePlaceholders replaces the string fields of job that can contain placeholders with what replace returns for
+// them
+func replacePlaceholders(job *model.Job, replace func(string) string) {
+	job.Name = replace(job.Name)
+	job.Description = replace(job.Description)
+	job.Handler = replace(job.Handler)
+	job.Command = replace(job.Command)
+	if schedule := job.GetSchedule(); schedule != nil {
+		schedule.Cron = replace(schedule.Cron)
+		schedule.Timezone = replace(schedule.Timezone)
+	}
+	for key, value := range job.Labels {
+		job.Labels[key] = replace(value)
+	}
+	for _, value := range job.GetParams().GetFields() {
+		replaceInValue(value, replace)
+	}
+	if notifications := job.GetNotifications(); notifications != nil {
+		notifications.SlackWebhookUrl = replace(notifications.SlackWebhookUrl)
+		for i, recipient := range notifications.EmailRecipients {
+			notifications.EmailRecipients[i] = replace(recipient)
+		}
+	}
+}
+
+// replaceInValue replaces the strings in a param value, the ones nested in lists and objects included, with what
+// replace returns for them
+func replaceInValue(value *structpb.Value, replace func(string) string) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		kind.StringValue = replace(kind.StringValue)
+	case *structpb.Value_ListValue:
+		for _, v := range kind.ListValue.GetValues() {
+			replaceInValue(v, replace)
+		}
+	case *structpb.Value_StructValue:
+		for _, v := range kind.StructValue.GetFields() {
+			replaceInValue(v, replace)
+		}
+	}
+}
+
+// templateError converts an error of the template repository for the template with id into the status returned to
+// clients
+func templateError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(templateResource, id, fmt.Sprintf("Invalid JobTemplate id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(templateResource, id, fmt.Sprintf("Could not find JobTemplate with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// templateToProto converts a stored template into the JobTemplate message sent to clients. The server encoded the job
+// itself, one that doesn't decode anyway is left out.
+func templateToProto(template *repository.JobTemplate) *model.JobTemplate {
+	job := &model.Job{}
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(strings.NewReader(template.Job), job); err != nil {
+		job = nil
+	}
+	parameters := make([]*model.TemplateParameter, 0, len(template.Parameters))
+	for _, p := range template.Parameters {
+		parameters = append(parameters, &model.TemplateParameter{
+			Name:         p.Name,
+			Description:  p.Description,
+			DefaultValue: p.Default,
+			Required:     p.Required,
+		})
+	}
+	return &model.JobTemplate{
+		Id:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Owner:       template.Owner,
+		Parameters:  parameters,
+		Job:         job,
+		Version:     template.Version,
+		CreatedAt:   timestampProto(template.CreatedAt),
+		UpdatedAt:   timestampProto(template.UpdatedAt),
+	}
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// Limits of the user fields clients can set, ids are limited like owners
+const (
+	maxEmailLength       = 254
+	maxDisplayNameLength = 128
+	maxRoleLength        = 64
+	maxRoles             = 16
+	maxAPIKeyNameLength  = 128
+)
+
+// userFields are the fields of a user UpdateUser can change, in the order violations are reported
+var userFields = []string{"email", "display_name", "roles"}
+
+// userFieldRules checks a single field of a user and describes what's wrong with it, empty when the field is valid
+var userFieldRules = map[string]func(*model.User) string{
+	"id": func(u *model.User) string {
+		if msg := checkLength(u.GetId(), maxOwnerLength); msg != "" {
+			return msg
+		}
+		if strings.IndexFunc(u.GetId(), unicode.IsSpace) >= 0 {
+			return "must not contain whitespace"
+		}
+		// It would own the jobs of a team
+		if strings.HasPrefix(u.GetId(), repository.TeamOwnerPrefix) {
+			return fmt.Sprintf("must not start with %q", repository.TeamOwnerPrefix)
+		}
+		return checkControl(u.GetId(), "")
+	},
+	"email": func(u *model.User) string {
+		if u.GetEmail() == "" {
+			return ""
+		}
+		if msg := checkLength(u.GetEmail(), maxEmailLength); msg != "" {
+			return msg
+		}
+		if addr, err := mail.ParseAddress(u.GetEmail()); err != nil || addr.Address != u.GetEmail() {
+			return "must be a plain email address"
+		}
+		return ""
+	},
+	"display_name": func(u *model.User) string {
+		if msg := checkLength(u.GetDisplayName(), maxDisplayNameLength); msg != "" {
+			return msg
+		}
+		return checkControl(u.GetDisplayName(), "")
+	},
+	"roles": func(u *model.User) string {
+		return checkRoles(u.GetRoles())
+	},
+}
+
+// checkRoles describes what's wrong with the roles granted to a user or team, empty when they are valid
+func checkRoles(roles []string) string {
+	if len(roles) > maxRoles {
+		return fmt.Sprintf("must not contain more than %d roles, got %d", maxRoles, len(roles))
+	}
+	seen := map[string]bool{}
+	for _, role := range roles {
+		if role == "" || checkLength(role, maxRoleLength) != "" || checkControl(role, "") != "" {
+			return fmt.Sprintf("role %q must be between 1 and %d characters without control characters", role, maxRoleLength)
+		}
+		if seen[role] {
+			return fmt.Sprintf("must not contain role %q twice", role)
+		}
+		seen[role] = true
+	}
+	return ""
+}
+
+type UserServiceServer struct {
+	Users repository.UserRepository
+}
+
+func (s *UserServiceServer) RegisterUser(ctx context.Context, req *model.RegisterUserReq) (*model.RegisterUserRes, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, newError(codes.FailedPrecondition, reasonNotAuthenticated,
+			"Only authenticated callers can register, create users with CreateUser while authentication is disabled")
+	}
+	user := req.GetUser()
+	// Callers get their roles from their token, they can't grant themselves more for their API keys
+	if len(user.GetRoles()) > 0 {
+		return nil, newError(codes.PermissionDenied, reasonPermissionDenied, "Only admins can grant roles to users")
+	}
+	if err := validateUser(user, "email", "display_name"); err != nil {
+		return nil, err
+	}
+	created, err := s.create(ctx, &repository.User{ID: claims.Subject, Email: user.GetEmail(), DisplayName: user.GetDisplayName()})
+	if err != nil {
+		return nil, err
+	}
+	return &model.RegisterUserRes{User: userToProto(created)}, nil
+}
+
+func (s *UserServiceServer) CreateUser(ctx context.Context, req *model.CreateUserReq) (*model.CreateUserRes, error) {
+	if err := requireAdmin(ctx, "Only admins can create users"); err != nil {
+		return nil, err
+	}
+	user := req.GetUser()
+	if err := validateUser(user, "id", "email", "display_name", "roles"); err != nil {
+		return nil, err
+	}
+	// Service users without an id get a generated one
+	created, err := s.create(ctx, &repository.User{ID: user.GetId(), Email: user.GetEmail(), DisplayName: user.GetDisplayName(),
+		Roles: user.GetRoles()})
+	if err != nil {
+		return nil, err
+	}
+	return &model.CreateUserRes{User: userToProto(created)}, nil
+}
+
+// create stores a new user and converts the errors of the repository
+func (s *UserServiceServer) create(ctx context.Context, user *repository.User) (*repository.User, error) {
+	user.CreatedAt = now()
+	user.UpdatedAt = user.CreatedAt
+	created, err := s.Users.Create(ctx, user)
+	if err == repository.ErrExists {
+		msg := fmt.Sprintf("User with id %s exists already", user.ID)
+		return nil, newError(codes.AlreadyExists, reasonUserExists, msg,
+			&errdetails.ResourceInfo{ResourceType: userResource, ResourceName: user.ID, Description: msg})
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	return created, nil
+}
+
+func (s *UserServiceServer) ReadUser(ctx context.Context, req *model.ReadUserReq) (*model.ReadUserRes, error) {
+	id, err := userForCaller(ctx, "id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.Users.Get(ctx, id)
+	if err != nil {
+		return nil, userError(ctx, err, id)
+	}
+	return &model.ReadUserRes{User: userToProto(user)}, nil
+}
+
+func (s *UserServiceServer) UpdateUser(ctx context.Context, req *model.UpdateUserReq) (*model.UpdateUserRes, error) {
+	user := req.GetUser()
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = userFields
+		// Only admins can grant roles, the others update everything they can
+		if requireAdmin(ctx, "") != nil {
+			paths = []string{"email", "display_name"}
+		}
+	}
+	for _, path := range paths {
+		if _, ok := userFieldRules[path]; !ok || path == "id" {
+			return nil, invalidArgumentError("update_mask", fmt.Sprintf("Invalid update mask: unknown or read-only field %q", path))
+		}
+	}
+	id, err := userForCaller(ctx, "user.id", user.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := validateUser(user, paths...); err != nil {
+		return nil, err
+	}
+	stored, err := s.Users.Get(ctx, id)
+	if err != nil {
+		return nil, userError(ctx, err, id)
+	}
+	for _, path := range paths {
+		switch path {
+		case "email":
+			stored.Email = user.GetEmail()
+		case "display_name":
+			stored.DisplayName = user.GetDisplayName()
+		case "roles":
+			if err := requireAdmin(ctx, "Only admins can grant roles to users"); err != nil {
+				return nil, err
+			}
+			stored.Roles = user.GetRoles()
+		}
+	}
+	stored.UpdatedAt = now()
+	updated, err := s.Users.Update(ctx, stored)
+	if err != nil {
+		return nil, userError(ctx, err, id)
+	}
+	return &model.UpdateUserRes{User: userToProto(updated)}, nil
+}
+
+func (s *UserServiceServer) DeleteUser(ctx context.Context, req *model.DeleteUserReq) (*model.DeleteUserRes, error) {
+	id, err := userForCaller(ctx, "id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Users.Delete(ctx, id); err != nil {
+		return nil, userError(ctx, err, id)
+	}
+	return &model.DeleteUserRes{}, nil
+}
+
+func (s *UserServiceServer) ListUsers(req *model.ListUsersReq, stream model.UserService_ListUsersServer) error {
+	if err := requireAdmin(stream.Context(), "Only admins can list users"); err != nil {
+		return err
+	}
+	users, err := s.Users.List(stream.Context())
+	if err != nil {
+		return internalError(stream.Context(), err)
+	}
+	for _, user := range users {
+		if err := stream.Send(&model.ListUsersRes{User: userToProto(user)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *UserServiceServer) CreateApiKey(ctx context.Context, req *model.CreateApiKeyReq) (*model.CreateApiKeyRes, error) {
+	userID, err := userForCaller(ctx, "user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, invalidArgumentError("name", "Name must not be empty")
+	}
+	if msg := checkLength(req.GetName(), maxAPIKeyNameLength); msg != "" {
+		return nil, invalidArgumentError("name", fmt.Sprintf("Name %s", msg))
+	}
+	if _, err := s.Users.Get(ctx, userID); err != nil {
+		return nil, userError(ctx, err, userID)
+	}
+	secret, err := auth.NewAPIKeySecret()
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	key, err := s.Users.CreateAPIKey(ctx, &repository.APIKey{
+		UserID:    userID,
+		Name:      req.GetName(),
+		Hash:      auth.HashAPIKeySecret(secret),
+		CreatedAt: now(),
+	})
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	// The token is only ever returned here and by RotateApiKey
+	res := apiKeyToProto(key)
+	res.Token = auth.FormatAPIKey(key.ID, secret)
+	return &model.CreateApiKeyRes{ApiKey: res}, nil
+}
+
+func (s *UserServiceServer) ListApiKeys(req *model.ListApiKeysReq, stream model.UserService_ListApiKeysServer) error {
+	ctx := stream.Context()
+	userID, err := userForCaller(ctx, "user_id", req.GetUserId())
+	if err != nil {
+		return err
+	}
+	if _, err := s.Users.Get(ctx, userID); err != nil {
+		return userError(ctx, err, userID)
+	}
+	keys, err := s.Users.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, key := range keys {
+		if err := stream.Send(&model.ListApiKeysRes{ApiKey: apiKeyToProto(key)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *UserServiceServer) RotateApiKey(ctx context.Context, req *model.RotateApiKeyReq) (*model.RotateApiKeyRes, error) {
+	userID, err := userForCaller(ctx, "user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	secret, err := auth.NewAPIKeySecret()
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	key, err := s.Users.RotateAPIKey(ctx, userID, req.GetId(), auth.HashAPIKeySecret(secret), now())
+	if err != nil {
+		return nil, apiKeyError(ctx, err, req.GetId())
+	}
+	res := apiKeyToProto(key)
+	res.Token = auth.FormatAPIKey(key.ID, secret)
+	return &model.RotateApiKeyRes{ApiKey: res}, nil
+}
+
+func (s *UserServiceServer) DeleteApiKey(ctx context.Context, req *model.DeleteApiKeyReq) (*model.DeleteApiKeyRes, error) {
+	userID, err := userForCaller(ctx, "user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Users.DeleteAPIKey(ctx, userID, req.GetId()); err != nil {
+		return nil, apiKeyError(ctx, err, req.GetId())
+	}
+	return &model.DeleteApiKeyRes{}, nil
+}
+
+// VerifyAPIKey lets the Authenticator accept the API keys of users, callers get the id, roles and tenant of the user
+// the key belongs to
+func (s *UserServiceServer) VerifyAPIKey(ctx context.Context, id, secret string) (*auth.Claims, error) {
+	key, err := s.Users.APIKey(ctx, id)
+	if err == repository.ErrNotFound || err == repository.ErrInvalidID {
+		return nil, auth.ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(auth.HashAPIKeySecret(secret)), []byte(key.Hash)) != 1 {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	user, err := s.Users.Get(ctx, key.UserID)
+	if err == repository.ErrNotFound {
+		return nil, auth.ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, err
+	}
+	return &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: user.ID},
+		Roles:            user.Roles,
+		TenantID:         user.Tenant,
+	}, nil
+}
+
+// requireAdmin fails with msg unless the caller is an admin, calls without claims (authentication disabled) are
+// allowed like everywhere else
+func requireAdmin(ctx context.Context, msg string) error {
+	if claims, ok := auth.FromContext(ctx); ok && !claims.HasRole(auth.AdminRole) {
+		return newError(codes.PermissionDenied, reasonPermissionDenied, msg)
+	}
+	return nil
+}
+
+// userForCaller returns the id of the user a call accesses, the caller when id is empty. Callers can only access
+// their own user unless they are admins, other users are reported as not found like the jobs of other owners.
+func userForCaller(ctx context.Context, field, id string) (string, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		if id == "" {
+			return "", invalidArgumentError(field, fmt.Sprintf("%s is required while authentication is disabled", field))
+		}
+		return id, nil
+	}
+	if id == "" || id == claims.Subject {
+		return claims.Subject, nil
+	}
+	if !claims.HasRole(auth.AdminRole) {
+		return "", notFoundError(userResource, id, fmt.Sprintf("Could not find User with id %s", id))
+	}
+	return id, nil
+}
+
+// userError converts an error of the user repository into the status returned to clients
+func userError(ctx context.Context, err error, id string) error {
+	if err == repository.ErrNotFound {
+		return notFoundError(userResource, id, fmt.Sprintf("Could not find User with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// apiKeyError converts an error of the user repository for the API key with id into the status returned to clients
+func apiKeyError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(apiKeyResource, id, fmt.Sprintf("Invalid API key id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(apiKeyResource, id, fmt.Sprintf("Could not find API key with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// validateUser checks the given fields of a user sent by a client, every violation is reported as a BadRequest field
+// violation of an InvalidArgument status like the ones of validateJob
+func validateUser(user *model.User, fields ...string) error {
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	messages := []string{}
+	for _, field := range fields {
+		if msg := userFieldRules[field](user); msg != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "user." + field, Description: msg})
+			messages = append(messages, fmt.Sprintf("%s %s", field, msg))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return violationsError(fmt.Sprintf("Invalid user: %s", strings.Join(messages, "; ")), violations)
+}
+
+// userToProto converts a stored user into the User message sent to clients
+func userToProto(user *repository.User) *model.User {
+	return &model.User{
+		Id:          user.ID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Roles:       user.Roles,
+		CreatedAt:   timestampProto(user.CreatedAt),
+		UpdatedAt:   timestampProto(user.UpdatedAt),
+	}
+}
+
+// apiKeyToProto converts a stored API key into the ApiKey message sent to clients, without its token
+func apiKeyToProto(key *repository.APIKey) *model.ApiKey {
+	res := &model.ApiKey{
+		Id:        key.ID,
+		UserId:    key.UserID,
+		Name:      key.Name,
+		CreatedAt: timestampProto(key.CreatedAt),
+	}
+	if key.RotatedAt != nil {
+		res.RotatedAt = timestampProto(*key.RotatedAt)
+	}
+	return res
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// maxWebhookURLLength is the maximum length in characters of the URL of a webhook
+const maxWebhookURLLength = 2048
+
+// webhookEvents maps the events of the API to the event types of the webhook package
+var webhookEvents = map[model.WebhookEvent]string{
+	model.WebhookEvent_WEBHOOK_EVENT_JOB_CREATED:   webhook.JobCreated,
+	model.WebhookEvent_WEBHOOK_EVENT_JOB_UPDATED:   webhook.JobUpdated,
+	model.WebhookEvent_WEBHOOK_EVENT_JOB_DELETED:   webhook.JobDeleted,
+	model.WebhookEvent_WEBHOOK_EVENT_RUN_SUCCEEDED: webhook.RunSucceeded,
+	model.WebhookEvent_WEBHOOK_EVENT_RUN_FAILED:    webhook.RunFailed,
+	model.WebhookEvent_WEBHOOK_EVENT_SLA_BREACHED:  webhook.SLABreached,
+	model.WebhookEvent_WEBHOOK_EVENT_RUN_ANOMALOUS: webhook.RunAnomalous,
+}
+
+// webhookEventToProto returns the API form of an event type
+func webhookEventToProto(event string) model.WebhookEvent {
+	for e, name := range webhookEvents {
+		if name == event {
+			return e
+		}
+	}
+	return model.WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED
+}
+
+type WebhookServiceServer struct {
+	Webhooks repository.WebhookRepository
+	// Users are looked up for the owners admins create webhooks for, owners aren't checked when it's nil
+	Users repository.UserRepository
+}
+
+func (s *WebhookServiceServer) CreateWebhook(ctx context.Context, req *model.CreateWebhookReq) (*model.CreateWebhookRes, error) {
+	hook := req.GetWebhook()
+	if err := validateWebhook(hook); err != nil {
+		return nil, err
+	}
+	// Callers can only get the events of their own jobs, the owner defaults to the caller. Webhooks always belong to
+	// a user, the events of the jobs of teams aren't delivered to any.
+	if strings.HasPrefix(hook.GetOwner(), repository.TeamOwnerPrefix) {
+		return nil, invalidArgumentError("webhook.owner", "Webhooks can't be owned by teams")
+	}
+	owner, err := ownerForCaller(ctx, s.Users, nil, hook.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	data := &repository.Webhook{
+		Owner:     owner,
+		URL:       hook.GetUrl(),
+		Secret:    secret,
+		CreatedAt: now(),
+	}
+	for _, event := range hook.GetEvents() {
+		data.Events = append(data.Events, webhookEvents[event])
+	}
+	created, err := s.Webhooks.Create(ctx, data)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	// The secret is only ever returned here
+	res := webhookToProto(created)
+	res.Secret = created.Secret
+	return &model.CreateWebhookRes{Webhook: res}, nil
+}
+
+func (s *WebhookServiceServer) ListWebhooks(req *model.ListWebhooksReq, stream model.WebhookService_ListWebhooksServer) error {
+	// Callers only see their own webhooks, admins see all of them
+	hooks, err := s.Webhooks.List(stream.Context(), ownerQuery(stream.Context()).Owner)
+	if err != nil {
+		return internalError(stream.Context(), err)
+	}
+	for _, hook := range hooks {
+		if err := stream.Send(&model.ListWebhooksRes{Webhook: webhookToProto(hook)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *WebhookServiceServer) DeleteWebhook(ctx context.Context, req *model.DeleteWebhookReq) (*model.DeleteWebhookRes, error) {
+	if err := s.Webhooks.Delete(ctx, req.GetId(), ownerQuery(ctx).Owner); err != nil {
+		return nil, webhookError(ctx, err, req.GetId())
+	}
+	return &model.DeleteWebhookRes{}, nil
+}
+
+func (s *WebhookServiceServer) ListDeadLetters(req *model.ListDeadLettersReq, stream model.WebhookService_ListDeadLettersServer) error {
+	ctx := stream.Context()
+	pageSize, err := pageSizeFromRequest(req.GetPageSize())
+	if err != nil {
+		return err
+	}
+	before := ""
+	if req.GetPageToken() != "" {
+		before, err = decodePageToken(req.GetPageToken())
+		if err != nil {
+			return invalidArgumentError("page_token", fmt.Sprintf("Invalid page token: %v", err))
+		}
+	}
+	// Dead letters of webhooks of other owners are reported as not found like the webhooks
+	if _, err := s.Webhooks.Get(ctx, req.GetWebhookId(), ownerQuery(ctx).Owner); err != nil {
+		return webhookError(ctx, err, req.GetWebhookId())
+	}
+	letters, err := s.Webhooks.ListDeadLetters(ctx, req.GetWebhookId(), before, int(pageSize)+1)
+	if err == repository.ErrInvalidID {
+		return invalidArgumentError("page_token", "Invalid page token")
+	} else if err != nil {
+		return internalError(stream.Context(), err)
+	}
+
+	hasMore := len(letters) > int(pageSize)
+	if hasMore {
+		letters = letters[:pageSize]
+	}
+	for i, letter := range letters {
+		res := &model.ListDeadLettersRes{DeadLetter: deadLetterToProto(letter)}
+		if hasMore && i == len(letters)-1 {
+			res.NextPageToken = encodePageToken(letter.ID)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// webhookError converts an error of the webhook repository into the status returned to clients
+func webhookError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(webhookResource, id, fmt.Sprintf("Invalid webhook id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(webhookResource, id, fmt.Sprintf("Could not find webhook with id %s", id))
+	}
+	return internalError(ctx, err)
+}
+
+// validateWebhook checks a webhook sent by a client, every violation is reported as a BadRequest field violation of
+// an InvalidArgument status like the ones of validateJob
+func validateWebhook(hook *model.Webhook) error {
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	messages := []string{}
+	violate := func(field, msg string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "webhook." + field, Description: msg})
+		messages = append(messages, fmt.Sprintf("%s %s", field, msg))
+	}
+	if msg := checkWebhookURL(hook.GetUrl()); msg != "" {
+		violate("url", msg)
+	}
+	seen := map[model.WebhookEvent]bool{}
+	for _, event := range hook.GetEvents() {
+		if _, ok := webhookEvents[event]; !ok {
+			violate("events", fmt.Sprintf("must only contain specified events, got %v", event))
+			break
+		}
+		if seen[event] {
+			violate("events", fmt.Sprintf("must not contain %v twice", event))
+			break
+		}
+		seen[event] = true
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return violationsError(fmt.Sprintf("Invalid webhook: %s", strings.Join(messages, "; ")), violations)
+}
+
+// checkWebhookURL describes why a URL can't be the URL of a webhook, events are only posted over HTTPS
+func checkWebhookURL(raw string) string {
+	if raw == "" {
+		return "is required"
+	}
+	if msg := checkLength(raw, maxWebhookURLLength); msg != "" {
+		return msg
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "must be a valid URL"
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return "must be an absolute https URL"
+	}
+	return ""
+}
+
+// webhookToProto converts a stored webhook into the Webhook message sent to clients, without its secret
+func webhookToProto(hook *repository.Webhook) *model.Webhook {
+	res := &model.Webhook{
+		Id:        hook.ID,
+		Url:       hook.URL,
+		Owner:     hook.Owner,
+		CreatedAt: timestampProto(hook.CreatedAt),
+	}
+	for _, event := range hook.Events {
+		res.Events = append(res.Events, webhookEventToProto(event))
+	}
+	return res
+}
+
+// deadLetterToProto converts a stored dead letter into the DeadLetter message sent to clients
+func deadLetterToProto(letter *repository.DeadLetter) *model.DeadLetter {
+	return &model.DeadLetter{
+		Id:        letter.ID,
+		WebhookId: letter.WebhookID,
+		Event:     webhookEventToProto(letter.Event),
+		Payload:   letter.Payload,
+		Attempts:  int32(letter.Attempts),
+		Error:     letter.Error,
+		CreatedAt: timestampProto(letter.CreatedAt),
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/worker"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// defaultLeaseWait is how long LeaseRun waits for a run unless the worker asks for another time
+	defaultLeaseWait = 30 * time.Second
+	// maxLeaseWait limits how long a LeaseRun call stays open, so proxies in between don't cut it off
+	maxLeaseWait = time.Minute
+)
+
+type WorkerServiceServer struct {
+	Workers *worker.Pool
+}
+
+func (s *WorkerServiceServer) RegisterWorker(ctx context.Context, req *model.RegisterWorkerReq) (*model.RegisterWorkerRes, error) {
+	name := req.GetName()
+	if strings.TrimSpace(name) == "" {
+		return nil, invalidArgumentError("name", "Name must not be empty")
+	}
+	if msg := checkLength(name, maxNameLength); msg != "" {
+		return nil, invalidArgumentError("name", fmt.Sprintf("Name %s", msg))
+	}
+	if msg := checkControl(name, ""); msg != "" {
+		return nil, invalidArgumentError("name", fmt.Sprintf("Name %s", msg))
+	}
+	w, err := s.Workers.Register(ctx, name)
+	if err != nil {
+		return nil, workerError(err, "", "")
+	}
+	return &model.RegisterWorkerRes{Worker: workerToProto(w), LeaseTtl: ptypes.DurationProto(s.Workers.TTL())}, nil
+}
+
+func (s *WorkerServiceServer) UnregisterWorker(ctx context.Context, req *model.UnregisterWorkerReq) (*model.UnregisterWorkerRes, error) {
+	if err := s.Workers.Unregister(ctx, req.GetWorkerId()); err != nil {
+		return nil, workerError(err, req.GetWorkerId(), "")
+	}
+	return &model.UnregisterWorkerRes{}, nil
+}
+
+func (s *WorkerServiceServer) Heartbeat(ctx context.Context, req *model.HeartbeatReq) (*model.HeartbeatRes, error) {
+	cancelled, err := s.Workers.Heartbeat(ctx, req.GetWorkerId())
+	if err != nil {
+		return nil, workerError(err, req.GetWorkerId(), "")
+	}
+	return &model.HeartbeatRes{CancelledRunIds: cancelled}, nil
+}
+
+func (s *WorkerServiceServer) LeaseRun(ctx context.Context, req *model.LeaseRunReq) (*model.LeaseRunRes, error) {
+	wait := defaultLeaseWait
+	if req.GetWait() != nil {
+		d, err := ptypes.Duration(req.GetWait())
+		if err != nil || d < 0 || d > maxLeaseWait {
+			return nil, invalidArgumentError("wait", fmt.Sprintf("Wait must be between 0s and %v", maxLeaseWait))
+		}
+		wait = d
+	}
+	lease, err := s.Workers.Lease(ctx, req.GetWorkerId(), wait)
+	if err != nil {
+		return nil, workerError(err, req.GetWorkerId(), "")
+	}
+	if lease == nil {
+		return &model.LeaseRunRes{}, nil
+	}
+	return &model.LeaseRunRes{Lease: &model.RunLease{
+		RunId:     lease.RunID,
+		JobId:     lease.JobID,
+		JobName:   lease.JobName,
+		Command:   lease.Command,
+		Deadline:  timestampProto(lease.Deadline),
+		ExpiresAt: timestampProto(lease.ExpiresAt),
+		Params:    paramsToProto(lease.Params),
+	}}, nil
+}
+
+func (s *WorkerServiceServer) CompleteRun(ctx context.Context, req *model.CompleteRunReq) (*model.CompleteRunRes, error) {
+	if msg := checkLength(req.GetError(), maxDescriptionLength); msg != "" {
+		return nil, invalidArgumentError("error", fmt.Sprintf("Error %s", msg))
+	}
+	err := s.Workers.Complete(ctx, req.GetWorkerId(), req.GetRunId(), req.GetOutput(), req.GetError())
+	if err != nil {
+		return nil, workerError(err, req.GetWorkerId(), req.GetRunId())
+	}
+	return &model.CompleteRunRes{}, nil
+}
+
+func (s *WorkerServiceServer) ListWorkers(ctx context.Context, req *model.ListWorkersReq) (*model.ListWorkersRes, error) {
+	workers, err := s.Workers.List(ctx)
+	if err != nil {
+		return nil, workerError(err, "", "")
+	}
+	res := &model.ListWorkersRes{Workers: []*model.Worker{}}
+	for _, w := range workers {
+		res.Workers = append(res.Workers, workerToProto(w))
+	}
+	return res, nil
+}
+
+// workerError turns the errors of the worker pool into gRPC errors, runID is the run the worker completed
+func workerError(err error, workerID, runID string) error {
+	switch err {
+	case worker.ErrUnknownWorker:
+		return notFoundError(workerResource, workerID, fmt.Sprintf("Worker %s is not registered, register it again", workerID))
+	case worker.ErrNotLeased:
+		return notFoundError(runResource, runID, fmt.Sprintf("Worker %s doesn't hold the lease of run %s", workerID, runID))
+	case worker.ErrNotLeader:
+		return newError(codes.Unavailable, reasonNotLeader, "This replica isn't the leader, workers have to connect to the leader")
+	}
+	return err
+}
+
+func workerToProto(w *worker.Worker) *model.Worker {
+	return &model.Worker{
+		Id:           w.ID,
+		Name:         w.Name,
+		RegisteredAt: timestampProto(w.RegisteredAt),
+		HeartbeatAt:  timestampProto(w.HeartbeatAt),
+		RunIds:       w.RunIDs,
+	}
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"github.com/noltedennis/schedulytics-backend/webhook"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// protectedEnvironment returns the protected environment job is in or update moves it to, nil when neither of them
+// is protected
+func (s *JobServiceServer) protectedEnvironment(ctx context.Context, job *repository.Job, update *repository.JobUpdate) (*scheduler.Environment, error) {
+	names := []string{job.Environment}
+	if update.Environment != nil {
+		names = append(names, *update.Environment)
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		e, err := s.Environments.Get(ctx, name)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+		if e.Protected {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+// requestChange stores req as a pending change of job. The owner is stored as it was resolved for the requester, so
+// an owner defaulting to the caller doesn't become the approver.
+func (s *JobServiceServer) requestChange(ctx context.Context, req *model.UpdateJobReq, job *repository.Job, update *repository.JobUpdate) (*model.JobChange, error) {
+	pending := proto.Clone(req).(*model.UpdateJobReq)
+	if update.Owner != nil {
+		pending.Job.Owner = *update.Owner
+	}
+	data, err := proto.Marshal(pending)
+	if err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not marshal change: %v", err))
+	}
+	change := &repository.Change{
+		JobID:        job.ID,
+		Owner:        job.Owner,
+		Environment:  job.Environment,
+		Request:      data,
+		Status:       repository.ChangePending,
+		RequestedAt:  now(),
+		JobUpdatedAt: job.UpdatedAt,
+	}
+	if claims, ok := auth.FromContext(ctx); ok {
+		change.RequestedBy = claims.Subject
+	}
+	created, err := s.Changes.Create(ctx, change)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	logging.FromContext(ctx).Info("Requested change", zap.String("job_id", job.ID), zap.String("change_id", created.ID))
+	return changeToProto(ctx, created)
+}
+
+func (s *JobServiceServer) ReadChange(ctx context.Context, req *model.ReadChangeReq) (*model.ReadChangeRes, error) {
+	if err := s.checkChanges(); err != nil {
+		return nil, err
+	}
+	change, err := s.Changes.Get(ctx, req.GetId(), ownerQuery(ctx))
+	if err != nil {
+		return nil, changeError(ctx, err, req.GetId())
+	}
+	res, err := changeToProto(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ReadChangeRes{Change: res}, nil
+}
+
+func (s *JobServiceServer) ListChanges(req *model.ListChangesReq, stream model.JobService_ListChangesServer) error {
+	ctx := stream.Context()
+	if err := s.checkChanges(); err != nil {
+		return err
+	}
+	filter := repository.ChangeFilter{JobID: req.GetJobId()}
+	if req.GetStatus() != model.ChangeStatus_CHANGE_STATUS_UNSPECIFIED {
+		if _, ok := model.ChangeStatus_name[int32(req.GetStatus())]; !ok {
+			return invalidArgumentError("status", fmt.Sprintf("Unknown status %d", req.GetStatus()))
+		}
+		filter.Status = strings.TrimPrefix(req.GetStatus().String(), "CHANGE_STATUS_")
+	}
+	changes, err := s.Changes.List(ctx, ownerQuery(ctx), filter)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, change := range changes {
+		res, err := changeToProto(ctx, change)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&model.ListChangesRes{Change: res}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *JobServiceServer) ApproveChange(ctx context.Context, req *model.ApproveChangeReq) (*model.ApproveChangeRes, error) {
+	change, reviewer, err := s.reviewedChange(ctx, req.GetId(), req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	pending := &model.UpdateJobReq{}
+	if err := proto.Unmarshal(change.Request, pending); err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not unmarshal change %s: %v", change.ID, err))
+	}
+	job, err := s.Jobs.Get(ctx, change.JobID, ownerQuery(ctx))
+	if err != nil {
+		return nil, jobError(ctx, err, change.JobID)
+	}
+	// The requester saw the job as it was back then, approving the change must not undo what was changed since
+	if !job.UpdatedAt.Equal(change.JobUpdatedAt) {
+		return nil, newError(codes.FailedPrecondition, reasonChangeOutdated, fmt.Sprintf("Job %s was updated since change %s was requested, reject the change and request it again",
+			job.ID, change.ID))
+	}
+	// The update is checked again, the jobs it depends on may have changed since
+	update, err := s.jobUpdate(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	// The owner was checked for the requester, it only has to be checked again when the job is handed over
+	if update.Owner != nil && *update.Owner != job.Owner {
+		owner, err := ownerForCaller(ctx, s.Users, s.Teams, *update.Owner)
+		if err != nil {
+			return nil, err
+		}
+		update.Owner = &owner
+	}
+	update.UpdatedAt = now()
+	// The change is only approved when the job is updated, in the same transaction when there are events
+	var approved *repository.Change
+	updated, err := changeJob(ctx, s.Events, s.Listeners, webhook.JobUpdated, func(ctx context.Context) (*repository.Job, error) {
+		var err error
+		if approved, err = s.Changes.Review(ctx, change.ID, ownerQuery(ctx), repository.ChangeApproved, reviewer, req.GetComment(), update.UpdatedAt); err != nil {
+			return nil, err
+		}
+		return s.updateRevised(ctx, job.ID, update)
+	})
+	if err == repository.ErrReviewed || (err == repository.ErrNotFound && approved == nil) {
+		return nil, changeError(ctx, err, change.ID)
+	} else if err != nil {
+		return nil, updateError(ctx, err, job.ID, update)
+	}
+	logging.FromContext(ctx).Info("Approved change", zap.String("job_id", job.ID), zap.String("change_id", change.ID),
+		zap.String("requested_by", change.RequestedBy))
+	res, err := changeToProto(ctx, approved)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ApproveChangeRes{Change: res, Job: jobToProto(updated)}, nil
+}
+
+func (s *JobServiceServer) RejectChange(ctx context.Context, req *model.RejectChangeReq) (*model.RejectChangeRes, error) {
+	change, reviewer, err := s.reviewedChange(ctx, req.GetId(), req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	rejected, err := s.Changes.Review(ctx, change.ID, ownerQuery(ctx), repository.ChangeRejected, reviewer, req.GetComment(), now())
+	if err != nil {
+		return nil, changeError(ctx, err, change.ID)
+	}
+	logging.FromContext(ctx).Info("Rejected change", zap.String("job_id", change.JobID), zap.String("change_id", change.ID),
+		zap.String("requested_by", change.RequestedBy))
+	res, err := changeToProto(ctx, rejected)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RejectChangeRes{Change: res}, nil
+}
+
+// reviewedChange returns the pending change with id the caller is about to approve or reject with comment and the
+// subject of the caller. Only authenticated callers other than the requester may review a change.
+func (s *JobServiceServer) reviewedChange(ctx context.Context, id, comment string) (*repository.Change, string, error) {
+	if err := s.checkChanges(); err != nil {
+		return nil, "", err
+	}
+	if msg := checkLength(comment, maxDescriptionLength); msg != "" {
+		return nil, "", invalidArgumentError("comment", fmt.Sprintf("Comment %s", msg))
+	}
+	if msg := checkControl(comment, "\n\t"); msg != "" {
+		return nil, "", invalidArgumentError("comment", fmt.Sprintf("Comment %s", msg))
+	}
+	change, err := s.Changes.Get(ctx, id, ownerQuery(ctx))
+	if err != nil {
+		return nil, "", changeError(ctx, err, id)
+	}
+	if change.Status != repository.ChangePending {
+		return nil, "", changeError(ctx, repository.ErrReviewed, id)
+	}
+	claims, ok := auth.FromContext(ctx)
+	if !ok || claims.Subject == "" {
+		return nil, "", newError(codes.FailedPrecondition, reasonNotAuthenticated, "Only authenticated callers can review changes")
+	}
+	if claims.Subject == change.RequestedBy {
+		return nil, "", newError(codes.PermissionDenied, reasonSelfApproval, fmt.Sprintf("Change %s was requested by you, someone else has to review it", id))
+	}
+	return change, claims.Subject, nil
+}
+
+// checkChanges returns Unavailable when the server keeps no changes, updates are never pending then
+func (s *JobServiceServer) checkChanges() error {
+	if s.Changes == nil {
+		return newError(codes.Unavailable, reasonChangesDisabled, "Changes of jobs aren't kept on this server")
+	}
+	return nil
+}
+
+// changeError converts an error of the change repository for the change with id into the status returned to clients
+func changeError(ctx context.Context, err error, id string) error {
+	switch err {
+	case repository.ErrInvalidID:
+		return invalidIDError(changeResource, id, fmt.Sprintf("Invalid change id %q", id))
+	case repository.ErrNotFound:
+		return notFoundError(changeResource, id, fmt.Sprintf("Could not find change with id %s", id))
+	case repository.ErrReviewed:
+		return newError(codes.FailedPrecondition, reasonChangeReviewed, fmt.Sprintf("Change %s was approved or rejected already", id))
+	}
+	return internalError(ctx, err)
+}
+
+// changeToProto converts a stored change into the JobChange message sent to clients
+func changeToProto(ctx context.Context, change *repository.Change) (*model.JobChange, error) {
+	req := &model.UpdateJobReq{}
+	if err := proto.Unmarshal(change.Request, req); err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not unmarshal change %s: %v", change.ID, err))
+	}
+	res := &model.JobChange{
+		Id:          change.ID,
+		JobId:       change.JobID,
+		Environment: change.Environment,
+		Job:         req.GetJob(),
+		UpdateMask:  req.GetUpdateMask(),
+		Status:      model.ChangeStatus(model.ChangeStatus_value["CHANGE_STATUS_"+change.Status]),
+		RequestedBy: change.RequestedBy,
+		RequestedAt: timestampProto(change.RequestedAt),
+		ReviewedBy:  change.ReviewedBy,
+		Comment:     change.Comment,
+	}
+	if change.ReviewedAt != nil {
+		res.ReviewedAt = timestampProto(*change.ReviewedAt)
+	}
+	return res, nil
+}
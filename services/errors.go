@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/scheduler"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details of every error, the name the server reports its traces with
+const errorDomain = "schedulytics-grpc-server"
+
+// Reasons of the ErrorInfo details, clients tell errors with the same code apart by them. They are part of the API,
+// never change one that was released.
+const (
+	reasonInternal          = "INTERNAL"
+	reasonInvalidArgument   = "INVALID_ARGUMENT"
+	reasonInvalidID         = "INVALID_ID"
+	reasonNotFound          = "NOT_FOUND"
+	reasonOtherTenant       = "OTHER_TENANT"
+	reasonPermissionDenied  = "PERMISSION_DENIED"
+	reasonNameTaken         = "NAME_TAKEN"
+	reasonJobStatus         = "JOB_STATUS"
+	reasonConcurrentChange  = "CONCURRENT_CHANGE"
+	reasonDependencyMissing = "DEPENDENCY_NOT_FOUND"
+	reasonDependencyCycle   = "DEPENDENCY_CYCLE"
+	reasonHasDependencies   = "HAS_DEPENDENCIES"
+	reasonGraphTooLarge     = "GRAPH_TOO_LARGE"
+	reasonTriggerDisabled   = "TRIGGER_DISABLED"
+	reasonQueueFull         = "QUEUE_FULL"
+	reasonResumeExpired     = "RESUME_TOKEN_EXPIRED"
+	reasonResumeFailed      = "RESUME_FAILED"
+	reasonWatchInterrupted  = "WATCH_INTERRUPTED"
+	reasonBackupsDisabled   = "BACKUPS_DISABLED"
+	reasonBackupConflict    = "BACKUP_CONFLICT"
+	reasonUserExists        = "USER_EXISTS"
+	reasonOwnerNotFound     = "OWNER_NOT_FOUND"
+	reasonNotAuthenticated  = "NOT_AUTHENTICATED"
+	reasonTeamHasJobs       = "TEAM_HAS_JOBS"
+	reasonCalendarMissing   = "CALENDAR_NOT_FOUND"
+	reasonMaintenance       = "MAINTENANCE"
+	reasonSecretsDisabled   = "SECRETS_DISABLED"
+	reasonEnvironmentPaused = "ENVIRONMENT_PAUSED"
+	reasonChangeReviewed    = "CHANGE_REVIEWED"
+	reasonChangeOutdated    = "CHANGE_OUTDATED"
+	reasonSelfApproval      = "SELF_APPROVAL"
+	reasonChangesDisabled   = "CHANGES_DISABLED"
+	reasonRevisionsDisabled = "REVISIONS_DISABLED"
+	reasonInvalidCron       = "INVALID_CRON"
+	reasonQuotaExceeded     = "QUOTA_EXCEEDED"
+	reasonNotLeader         = "NOT_LEADER"
+)
+
+// Resource types of ResourceInfo details, the names of the messages of the resources
+const (
+	jobResource      = "model.Job"
+	runResource      = "model.JobRun"
+	webhookResource  = "model.Webhook"
+	backupResource   = "backup"
+	userResource     = "model.User"
+	apiKeyResource   = "model.ApiKey"
+	teamResource     = "model.Team"
+	templateResource = "model.JobTemplate"
+	policyResource   = "model.RunRetentionPolicy"
+	slaResource      = "model.JobSLA"
+	calendarResource = "model.Calendar"
+	workerResource   = "model.Worker"
+	secretResource   = "model.Secret"
+	changeResource   = "model.JobChange"
+	revisionResource = "model.JobRevision"
+)
+
+// newError returns a status with code and msg, an ErrorInfo detail with reason and the given details
+func newError(code codes.Code, reason, msg string, details ...proto.Message) error {
+	st := status.New(code, msg)
+	details = append([]proto.Message{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}, details...)
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// invalidArgumentError tells that field of the request is invalid, with a BadRequest detail naming it
+func invalidArgumentError(field, msg string) error {
+	return violationsError(msg, []*errdetails.BadRequest_FieldViolation{{Field: field, Description: msg}})
+}
+
+// violationsError tells that the fields of violations are invalid, msg sums them up
+func violationsError(msg string, violations []*errdetails.BadRequest_FieldViolation) error {
+	return newError(codes.InvalidArgument, reasonInvalidArgument, msg, &errdetails.BadRequest{FieldViolations: violations})
+}
+
+// cronError tells that the cron expression in field doesn't parse. The metadata of the ErrorInfo detail holds the
+// field of the expression, like hour, and the position and length of the wrong part of it, so clients can point at it.
+func cronError(field string, err *scheduler.CronError) error {
+	msg := fmt.Sprintf("Invalid cron expression: %v", err)
+	info := &errdetails.ErrorInfo{Reason: reasonInvalidCron, Domain: errorDomain, Metadata: map[string]string{
+		"position": strconv.Itoa(err.Position),
+		"length":   strconv.Itoa(err.Length),
+	}}
+	if err.Field != "" {
+		info.Metadata["field"] = err.Field
+	}
+	st := status.New(codes.InvalidArgument, msg)
+	violations := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: msg}}}
+	if detailed, err := st.WithDetails(info, violations); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// jobIDOrPageTokenError tells that the job ID a list is filtered by or the page token is invalid, the storage backends
+// don't tell which
+func jobIDOrPageTokenError() error {
+	msg := "Invalid job id or page token"
+	return violationsError(msg, []*errdetails.BadRequest_FieldViolation{
+		{Field: "job_id", Description: msg},
+		{Field: "page_token", Description: msg},
+	})
+}
+
+// invalidIDError tells that name can't be the id of a resourceType, with a ResourceInfo detail. Ids are passed in
+// different fields, so there is no BadRequest detail naming one.
+func invalidIDError(resourceType, name, msg string) error {
+	return newError(codes.InvalidArgument, reasonInvalidID, msg,
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: msg})
+}
+
+// notFoundError tells that there is no resourceType named name, with a ResourceInfo detail
+func notFoundError(resourceType, name, msg string) error {
+	return newError(codes.NotFound, reasonNotFound, msg,
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: msg})
+}
+
+// otherTenantError tells that the resourceType named name belongs to another tenant than the caller
+func otherTenantError(resourceType, name, msg string) error {
+	return newError(codes.PermissionDenied, reasonOtherTenant, msg,
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: msg})
+}
+
+// internalError logs err with the request it happened in and returns an Internal status without it. Errors of the
+// storage backends name collections, tables and queries the caller has no business knowing about.
+func internalError(ctx context.Context, err error) error {
+	logging.FromContext(ctx).Error("Internal error", zap.Error(err))
+	return newError(codes.Internal, reasonInternal, "Internal error")
+}
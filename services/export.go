@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exportPageSize is the number of jobs ExportJobs reads at once
+	exportPageSize = 500
+	// exportChunkSize is the size a chunk of ExportJobs grows to before it's sent, the row crossing it is included
+	exportChunkSize = 64 << 10
+)
+
+// csvColumns is the header row of CSV exports, csvRow returns the cells in the same order
+var csvColumns = []string{"id", "name", "owner", "description", "status", "priority", "handler", "command", "cron",
+	"interval", "timezone", "next_run_time", "timeout", "labels", "depends_on", "created_at", "updated_at", "deleted_at"}
+
+func (s *JobServiceServer) ExportJobs(req *model.ExportJobsReq, stream model.JobService_ExportJobsServer) error {
+	ctx := stream.Context()
+	q := ownerQuery(ctx)
+	q.IncludeDeleted = req.GetIncludeDeleted()
+	var err error
+	q.Labels, err = labels.Parse(req.GetLabelSelector())
+	if err != nil {
+		return invalidArgumentError("label_selector", fmt.Sprintf("Invalid label selector: %v", err))
+	}
+
+	// Only a page of jobs and a chunk are held at once, however many jobs there are
+	var buf bytes.Buffer
+	var encode func(job *repository.Job) error
+	switch req.GetFormat() {
+	case model.ExportFormat_EXPORT_FORMAT_CSV:
+		w := csv.NewWriter(&buf)
+		w.Write(csvColumns)
+		encode = func(job *repository.Job) error {
+			w.Write(csvRow(job))
+			w.Flush()
+			return w.Error()
+		}
+	case model.ExportFormat_EXPORT_FORMAT_NDJSON:
+		// The same JSON the gateway answers with
+		m := &jsonpb.Marshaler{OrigName: true}
+		encode = func(job *repository.Job) error {
+			if err := m.Marshal(&buf, jobToProto(job)); err != nil {
+				return err
+			}
+			return buf.WriteByte('\n')
+		}
+	default:
+		return invalidArgumentError("format", "Format must be EXPORT_FORMAT_CSV or EXPORT_FORMAT_NDJSON")
+	}
+	send := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		err := stream.Send(&model.ExportJobsRes{Data: buf.String()})
+		buf.Reset()
+		return err
+	}
+
+	exported := 0
+	after := ""
+	for {
+		// Stop reading pages once the client went away
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		page, err := s.Jobs.List(ctx, q, after, exportPageSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return internalError(ctx, err)
+		}
+		for _, job := range page {
+			if err := encode(job); err != nil {
+				return internalError(ctx, fmt.Errorf("could not export job %s: %v", job.ID, err))
+			}
+			if buf.Len() >= exportChunkSize {
+				if err := send(); err != nil {
+					return err
+				}
+			}
+		}
+		exported += len(page)
+		if len(page) < exportPageSize {
+			break
+		}
+		after = page[len(page)-1].ID
+	}
+	if err := send(); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Exported jobs", zap.Int("exported", exported), zap.Stringer("format", req.GetFormat()))
+	return nil
+}
+
+// csvRow returns the cells of job in the order of csvColumns
+func csvRow(job *repository.Job) []string {
+	var cron, interval, timezone string
+	if job.Schedule != nil {
+		cron, timezone = job.Schedule.Cron, job.Schedule.Timezone
+		interval = csvDuration(job.Schedule.Interval)
+	}
+	pairs := make([]string, 0, len(job.Labels))
+	for _, key := range labels.Keys(job.Labels) {
+		pairs = append(pairs, key+"="+job.Labels[key])
+	}
+	row := []string{job.ID, job.Name, job.Owner, job.Description, job.Status, job.Priority, job.Handler, job.Command, cron,
+		interval, timezone, csvTime(job.NextRunTime), csvDuration(job.Timeout), strings.Join(pairs, ","),
+		strings.Join(job.DependsOn, ","), csvTime(&job.CreatedAt), csvTime(&job.UpdatedAt), csvTime(job.DeletedAt)}
+	for i, cell := range row {
+		row[i] = csvCell(cell)
+	}
+	return row
+}
+
+// csvCell keeps spreadsheets from evaluating a cell as a formula by prefixing cells that would start one with a quote
+func csvCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// csvTime formats t as RFC 3339, unset and zero times are empty
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// csvDuration formats d like 1h30m0s, zero is empty
+func csvDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
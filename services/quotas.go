@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/quota"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkJobQuota returns ResourceExhausted when owner can't have another job. ownerPending and tenantPending are the
+// jobs about to be created that aren't stored yet, like the ones of a batch of ImportJobs.
+func (s *JobServiceServer) checkJobQuota(ctx context.Context, owner string, ownerPending, tenantPending int64) error {
+	if s.Quotas == nil {
+		return nil
+	}
+	if err := s.Quotas.CheckJobs(ctx, owner, ownerPending, tenantPending); err != nil {
+		return quotaError(ctx, err)
+	}
+	return nil
+}
+
+// checkRunQuota returns ResourceExhausted when a job of owner can't be triggered once more
+func (s *JobServiceServer) checkRunQuota(ctx context.Context, owner string) error {
+	if s.Quotas == nil {
+		return nil
+	}
+	if err := s.Quotas.CheckRun(ctx, owner, now()); err != nil {
+		return quotaError(ctx, err)
+	}
+	return nil
+}
+
+func (s *JobServiceServer) GetQuota(ctx context.Context, req *model.GetQuotaReq) (*model.GetQuotaRes, error) {
+	owner, err := summaryOwner(ctx, req.GetOwner(), "quota")
+	if err != nil {
+		return nil, err
+	}
+	res := &model.Quota{Owner: owner}
+	if s.Quotas == nil {
+		// Without quotas nothing is limited, so nothing has to be counted either
+		return &model.GetQuotaRes{Quota: res}, nil
+	}
+	usage, err := s.Quotas.Usage(ctx, owner, now())
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	limits := s.Quotas.Limits()
+	res.JobsPerOwner = &model.QuotaUsage{Limit: limits.JobsPerOwner, Used: usage.JobsPerOwner}
+	res.JobsPerTenant = &model.QuotaUsage{Limit: limits.JobsPerTenant, Used: usage.JobsPerTenant}
+	res.ConcurrentRuns = &model.QuotaUsage{Limit: limits.ConcurrentRuns, Used: usage.ConcurrentRuns}
+	res.RunsPerDay = &model.QuotaUsage{Limit: limits.RunsPerDay, Used: usage.RunsPerDay}
+	res.DayStartTime = timestampProto(usage.DayStart)
+	return &model.GetQuotaRes{Quota: res}, nil
+}
+
+// quotaError converts an error of checking a quota into the status returned to clients. Exceeded quotas fail with
+// ResourceExhausted and a QuotaFailure detail whose subject is the owner or tenant, the metadata of the ErrorInfo detail
+// names the quota.
+func quotaError(ctx context.Context, err error) error {
+	exceeded, ok := err.(*quota.ExceededError)
+	if !ok {
+		return internalError(ctx, err)
+	}
+	var msg, subject string
+	switch exceeded.Quota {
+	case quota.JobsPerOwner:
+		subject = "owner:" + exceeded.Subject
+		msg = fmt.Sprintf("Owner %q has %d of at most %d jobs, delete jobs before creating more", exceeded.Subject, exceeded.Used, exceeded.Limit)
+	case quota.JobsPerTenant:
+		subject = "tenant:" + exceeded.Subject
+		msg = fmt.Sprintf("Tenant %q has %d of at most %d jobs, delete jobs before creating more", exceeded.Subject, exceeded.Used, exceeded.Limit)
+		if exceeded.Subject == "" {
+			subject = "server"
+			msg = fmt.Sprintf("The server has %d of at most %d jobs, delete jobs before creating more", exceeded.Used, exceeded.Limit)
+		}
+	case quota.ConcurrentRuns:
+		subject = "owner:" + exceeded.Subject
+		msg = fmt.Sprintf("Owner %q has %d of at most %d runs queued or running, wait for them to finish", exceeded.Subject, exceeded.Used, exceeded.Limit)
+	default:
+		subject = "owner:" + exceeded.Subject
+		msg = fmt.Sprintf("Owner %q had %d of at most %d runs today, try again after midnight UTC", exceeded.Subject, exceeded.Used, exceeded.Limit)
+	}
+	st := status.New(codes.ResourceExhausted, msg)
+	info := &errdetails.ErrorInfo{Reason: reasonQuotaExceeded, Domain: errorDomain, Metadata: map[string]string{"quota": exceeded.Quota}}
+	failure := &errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{Subject: subject, Description: msg}}}
+	if detailed, err := st.WithDetails(info, failure); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/noltedennis/schedulytics-backend/audit"
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/logging"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// updateRevised applies update to the caller's job with id and records the job as it was before as a revision. It
+// must run in the transaction of the update, so the revision is only kept when the job is updated. Updates that
+// didn't change anything aren't recorded.
+func (s *JobServiceServer) updateRevised(ctx context.Context, id string, update *repository.JobUpdate) (*repository.Job, error) {
+	if s.Revisions == nil {
+		return s.Jobs.Update(ctx, id, ownerQuery(ctx), update)
+	}
+	before, err := s.Jobs.Get(ctx, id, ownerQuery(ctx))
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.Jobs.Update(ctx, id, ownerQuery(ctx), update)
+	if err != nil {
+		return nil, err
+	}
+	changes := audit.Diff(before, updated)
+	if len(changes) == 0 {
+		return updated, nil
+	}
+	data, err := proto.Marshal(jobToProto(before))
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal job %s: %v", id, err)
+	}
+	revision := &repository.Revision{JobID: id, Job: data, Changes: changes, CreatedAt: update.UpdatedAt}
+	if claims, ok := auth.FromContext(ctx); ok {
+		revision.CreatedBy = claims.Subject
+	}
+	if _, err := s.Revisions.Create(ctx, revision); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *JobServiceServer) ListJobRevisions(req *model.ListJobRevisionsReq, stream model.JobService_ListJobRevisionsServer) error {
+	ctx := stream.Context()
+	if err := s.checkRevisions(); err != nil {
+		return err
+	}
+	// Only the revisions of jobs the caller can read are listed
+	if _, err := s.Jobs.Get(ctx, req.GetJobId(), ownerQuery(ctx)); err != nil {
+		return jobError(ctx, err, req.GetJobId())
+	}
+	revisions, err := s.Revisions.List(ctx, req.GetJobId())
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	for _, revision := range revisions {
+		res, err := revisionToProto(ctx, revision)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&model.ListJobRevisionsRes{Revision: res}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *JobServiceServer) RollbackJob(ctx context.Context, req *model.RollbackJobReq) (*model.RollbackJobRes, error) {
+	if err := s.checkRevisions(); err != nil {
+		return nil, err
+	}
+	if req.GetRevision() <= 0 {
+		return nil, invalidArgumentError("revision", fmt.Sprintf("Revision must be positive, got %d", req.GetRevision()))
+	}
+	if _, err := s.Jobs.Get(ctx, req.GetId(), ownerQuery(ctx)); err != nil {
+		return nil, jobError(ctx, err, req.GetId())
+	}
+	revision, err := s.Revisions.Get(ctx, req.GetId(), req.GetRevision())
+	if err == repository.ErrNotFound {
+		return nil, notFoundError(revisionResource, fmt.Sprintf("%s/%d", req.GetId(), req.GetRevision()),
+			fmt.Sprintf("Job %s has no revision %d", req.GetId(), req.GetRevision()))
+	} else if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	job := &model.Job{}
+	if err := proto.Unmarshal(revision.Job, job); err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not unmarshal revision %d of job %s: %v", revision.Revision, revision.JobID, err))
+	}
+	// Without a mask every field UpdateJob updates is restored. The rollback is checked, recorded and approved in
+	// protected environments like any other update, the dependencies and owner of back then must still be allowed.
+	res, err := s.UpdateJob(ctx, &model.UpdateJobReq{Job: job})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetChange() == nil {
+		logging.FromContext(ctx).Info("Rolled back job", zap.String("job_id", req.GetId()), zap.Int64("revision", req.GetRevision()))
+	}
+	return &model.RollbackJobRes{Job: res.GetJob(), Change: res.GetChange()}, nil
+}
+
+// checkRevisions returns Unavailable when the server keeps no revisions of jobs
+func (s *JobServiceServer) checkRevisions() error {
+	if s.Revisions == nil {
+		return newError(codes.Unavailable, reasonRevisionsDisabled, "Revisions of jobs aren't kept on this server")
+	}
+	return nil
+}
+
+// revisionToProto converts a stored revision into the JobRevision message sent to clients
+func revisionToProto(ctx context.Context, revision *repository.Revision) (*model.JobRevision, error) {
+	job := &model.Job{}
+	if err := proto.Unmarshal(revision.Job, job); err != nil {
+		return nil, internalError(ctx, fmt.Errorf("could not unmarshal revision %d of job %s: %v", revision.Revision, revision.JobID, err))
+	}
+	res := &model.JobRevision{
+		JobId:     revision.JobID,
+		Revision:  revision.Revision,
+		Job:       job,
+		CreatedBy: revision.CreatedBy,
+		CreatedAt: timestampProto(revision.CreatedAt),
+	}
+	for _, change := range revision.Changes {
+		res.Changes = append(res.Changes, &model.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return res, nil
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/noltedennis/schedulytics-backend/labels"
+	"github.com/noltedennis/schedulytics-backend/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Maximum length in characters of the job fields clients can set
+const (
+	maxNameLength        = 128
+	maxDescriptionLength = 4096
+	maxOwnerLength       = 256
+	maxHandlerLength     = 64
+	maxCommandLength     = 4096
+	maxEnvironmentLength = 32
+)
+
+// maxIdempotencyKeyLength is the maximum length in characters of the idempotency key of CreateJob
+const maxIdempotencyKeyLength = 128
+
+// maxLabels is the number of labels a job may have
+const maxLabels = 64
+
+// maxDependencies is the number of jobs a job may depend on
+const maxDependencies = 16
+
+// maxParamsSize is the largest size in bytes the params of a job may have as JSON
+const maxParamsSize = 64 * 1024
+
+// maxTimeout is the longest timeout a job may set
+const maxTimeout = 24 * time.Hour
+
+// Limits of the notification settings of a job
+const (
+	maxFailureThreshold = 100
+	maxEmailRecipients  = 10
+)
+
+var (
+	// namePattern allows letters, digits, spaces and a few separators, names must start with a letter or digit
+	namePattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} _.:/()-]*$`)
+	// handlerPattern matches the names handlers are registered with
+	handlerPattern = regexp.MustCompile(`^[a-z0-9_-]*$`)
+	// environmentPattern matches the names of environments like dev or prod-eu
+	environmentPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+// jobFields are the fields of a job validateJob checks, in the order violations are reported
+var jobFields = []string{"name", "description", "owner", "handler", "command", "timeout", "retry_policy", "labels", "depends_on", "priority", "notifications", "concurrency_policy", "catch_up_policy", "params", "environment"}
+
+// jobFieldRules checks a single field of a job and describes what's wrong with it, empty when the field is valid
+var jobFieldRules = map[string]func(*model.Job) string{
+	"name": func(j *model.Job) string {
+		name := j.GetName()
+		if strings.TrimSpace(name) == "" {
+			return "must not be empty"
+		}
+		if msg := checkLength(name, maxNameLength); msg != "" {
+			return msg
+		}
+		if !namePattern.MatchString(name) {
+			return "must start with a letter or digit and only contain letters, digits, spaces and _ . : / ( ) -"
+		}
+		return ""
+	},
+	"description": func(j *model.Job) string {
+		if msg := checkLength(j.GetDescription(), maxDescriptionLength); msg != "" {
+			return msg
+		}
+		return checkControl(j.GetDescription(), "\n\t")
+	},
+	"owner": func(j *model.Job) string {
+		if msg := checkLength(j.GetOwner(), maxOwnerLength); msg != "" {
+			return msg
+		}
+		if strings.IndexFunc(j.GetOwner(), unicode.IsSpace) >= 0 {
+			return "must not contain whitespace"
+		}
+		return checkControl(j.GetOwner(), "")
+	},
+	"handler": func(j *model.Job) string {
+		if msg := checkLength(j.GetHandler(), maxHandlerLength); msg != "" {
+			return msg
+		}
+		if !handlerPattern.MatchString(j.GetHandler()) {
+			return "must only contain lower case letters, digits, _ and -"
+		}
+		return ""
+	},
+	"command": func(j *model.Job) string {
+		if msg := checkLength(j.GetCommand(), maxCommandLength); msg != "" {
+			return msg
+		}
+		return checkControl(j.GetCommand(), "\t")
+	},
+	"timeout": func(j *model.Job) string {
+		if j.GetTimeout() == nil {
+			return ""
+		}
+		t, err := ptypes.Duration(j.GetTimeout())
+		if err != nil {
+			return err.Error()
+		}
+		if t < 0 || t > maxTimeout {
+			return fmt.Sprintf("must be between 0 and %v", maxTimeout)
+		}
+		return ""
+	},
+	"retry_policy": func(j *model.Job) string {
+		policy, err := retryPolicyFromProto(j.GetRetryPolicy())
+		if err == nil && policy != nil {
+			err = policy.Validate()
+		}
+		if err != nil {
+			return err.Error()
+		}
+		return ""
+	},
+	"labels": func(j *model.Job) string {
+		if len(j.GetLabels()) > maxLabels {
+			return fmt.Sprintf("must be at most %d, got %d", maxLabels, len(j.GetLabels()))
+		}
+		// Check in a fixed order, so the same job always gets the same violation
+		for _, key := range labels.Keys(j.GetLabels()) {
+			if err := labels.ValidateKey(key); err != nil {
+				return err.Error()
+			}
+			if err := labels.ValidateValue(j.GetLabels()[key]); err != nil {
+				return err.Error()
+			}
+		}
+		return ""
+	},
+	"depends_on": func(j *model.Job) string {
+		if len(j.GetDependsOn()) > maxDependencies {
+			return fmt.Sprintf("must be at most %d jobs, got %d", maxDependencies, len(j.GetDependsOn()))
+		}
+		seen := map[string]bool{}
+		for _, id := range j.GetDependsOn() {
+			if id == "" {
+				return "must not contain empty ids"
+			}
+			if seen[id] {
+				return fmt.Sprintf("must not contain job %s twice", id)
+			}
+			seen[id] = true
+		}
+		return ""
+	},
+	"priority": func(j *model.Job) string {
+		if _, ok := model.JobPriority_name[int32(j.GetPriority())]; !ok {
+			return fmt.Sprintf("must be LOW, NORMAL, HIGH or CRITICAL, got %d", j.GetPriority())
+		}
+		return ""
+	},
+	"concurrency_policy": func(j *model.Job) string {
+		if _, ok := model.ConcurrencyPolicy_name[int32(j.GetConcurrencyPolicy())]; !ok {
+			return fmt.Sprintf("must be ALLOW, FORBID or REPLACE, got %d", j.GetConcurrencyPolicy())
+		}
+		return ""
+	},
+	"catch_up_policy": func(j *model.Job) string {
+		if _, ok := model.CatchUpPolicy_name[int32(j.GetCatchUpPolicy())]; !ok {
+			return fmt.Sprintf("must be FIRE_ONCE, FIRE_ALL or SKIP, got %d", j.GetCatchUpPolicy())
+		}
+		return ""
+	},
+	"notifications": func(j *model.Job) string {
+		settings := j.GetNotifications()
+		if settings == nil {
+			return ""
+		}
+		if t := settings.GetFailureThreshold(); t < 0 || t > maxFailureThreshold {
+			return fmt.Sprintf("failure threshold must be between 1 and %d, got %d", maxFailureThreshold, t)
+		}
+		if settings.GetSlackWebhookUrl() == "" && len(settings.GetEmailRecipients()) == 0 {
+			return "must have a Slack webhook URL or email recipients"
+		}
+		if settings.GetSlackWebhookUrl() != "" {
+			if msg := checkWebhookURL(settings.GetSlackWebhookUrl()); msg != "" {
+				return "Slack webhook URL " + msg
+			}
+		}
+		if len(settings.GetEmailRecipients()) > maxEmailRecipients {
+			return fmt.Sprintf("must have at most %d email recipients, got %d", maxEmailRecipients, len(settings.GetEmailRecipients()))
+		}
+		seen := map[string]bool{}
+		for _, recipient := range settings.GetEmailRecipients() {
+			// Only plain addresses, names and comments could smuggle headers into the email
+			if addr, err := mail.ParseAddress(recipient); err != nil || addr.Address != recipient {
+				return fmt.Sprintf("email recipient %q must be a plain email address", recipient)
+			}
+			if seen[recipient] {
+				return fmt.Sprintf("must not contain email recipient %q twice", recipient)
+			}
+			seen[recipient] = true
+		}
+		return ""
+	},
+	"params": func(j *model.Job) string {
+		if j.GetParams() == nil {
+			return ""
+		}
+		params := j.GetParams().AsMap()
+		data, err := json.Marshal(params)
+		if err != nil {
+			return "must only hold finite numbers"
+		}
+		if len(data) > maxParamsSize {
+			return fmt.Sprintf("must be at most %d bytes as JSON, got %d", maxParamsSize, len(data))
+		}
+		return checkParamKeys(params)
+	},
+	"environment": func(j *model.Job) string {
+		if j.GetEnvironment() == "" {
+			return ""
+		}
+		return checkEnvironment(j.GetEnvironment())
+	},
+}
+
+// checkEnvironment describes the violation of an environment name, which may be empty only where jobs belong to no
+// environment
+func checkEnvironment(name string) string {
+	if msg := checkLength(name, maxEnvironmentLength); msg != "" {
+		return msg
+	}
+	if !environmentPattern.MatchString(name) {
+		return fmt.Sprintf("must only contain lowercase letters, digits and -, starting and ending with a letter or digit, got %q", name)
+	}
+	return ""
+}
+
+// checkParamKeys describes the violation of params whose objects have keys MongoDB can't store, they are stored as a
+// document
+func checkParamKeys(value interface{}) string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == "" || strings.HasPrefix(key, "$") || strings.Contains(key, ".") {
+				return fmt.Sprintf("must not have empty keys, keys starting with $ or keys containing dots, got %q", key)
+			}
+			if msg := checkParamKeys(value[key]); msg != "" {
+				return msg
+			}
+		}
+	case []interface{}:
+		for _, v := range value {
+			if msg := checkParamKeys(v); msg != "" {
+				return msg
+			}
+		}
+	}
+	return ""
+}
+
+// checkLength describes the violation of a value longer than max characters
+func checkLength(value string, max int) string {
+	if n := utf8.RuneCountInString(value); n > max {
+		return fmt.Sprintf("must be at most %d characters long, got %d", max, n)
+	}
+	return ""
+}
+
+// checkControl describes the violation of a value containing control characters other than the allowed ones
+func checkControl(value, allowed string) string {
+	for _, r := range value {
+		if unicode.IsControl(r) && !strings.ContainsRune(allowed, r) {
+			return fmt.Sprintf("must not contain the control character %U", r)
+		}
+	}
+	return ""
+}
+
+// validateJob checks the given fields of a job sent by a client, all of them when fields is empty.
+// Every violation is reported as a BadRequest field violation of an InvalidArgument status.
+func validateJob(job *model.Job, fields ...string) error {
+	if len(fields) == 0 {
+		fields = jobFields
+	}
+	check := map[string]bool{}
+	for _, field := range fields {
+		check[field] = true
+	}
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	messages := []string{}
+	for _, field := range jobFields {
+		if !check[field] {
+			continue
+		}
+		if msg := jobFieldRules[field](job); msg != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "job." + field, Description: msg})
+			messages = append(messages, fmt.Sprintf("%s %s", field, msg))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return violationsError(fmt.Sprintf("Invalid job: %s", strings.Join(messages, "; ")), violations)
+}
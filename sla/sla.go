@@ -0,0 +1,323 @@
+// Package sla detects the runs and daily deadlines of jobs that breach their SLA
+package sla
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+// pageSize is the number of runs read at once while looking for a succeeded run
+const pageSize = 50
+
+// storeTimeout limits checking a single run or deadline, it doesn't use the context of the executor
+const storeTimeout = 5 * time.Second
+
+// ParseDeadline returns the hour and minute of a deadline written as HH:MM
+func ParseDeadline(deadline string) (int, int, error) {
+	t, err := time.Parse("15:04", deadline)
+	if err != nil {
+		return 0, 0, fmt.Errorf("deadline must be written as HH:MM")
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// Deadlines are the deadlines of an SLA around a point in time
+type Deadlines struct {
+	// Last is the last deadline at or before the point in time, Prev the one before it, which starts the runs
+	// counting for Last
+	Prev time.Time
+	Last time.Time
+	Next time.Time
+}
+
+// DeadlinesAt returns the deadlines of sla around now, nil when the SLA has no deadline
+func DeadlinesAt(sla *repository.SLA, now time.Time) (*Deadlines, error) {
+	if sla.Deadline == "" {
+		return nil, nil
+	}
+	hour, minute, err := ParseDeadline(sla.Deadline)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(sla.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	local := now.In(loc)
+	last := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if last.After(now) {
+		last = last.AddDate(0, 0, -1)
+	}
+	// Days aren't always 24 hours long, the other deadlines are at the same time of day
+	return &Deadlines{Prev: last.AddDate(0, 0, -1).UTC(), Last: last.UTC(), Next: last.AddDate(0, 0, 1).UTC()}, nil
+}
+
+// RunStore is the part of the run storage the monitor needs
+type RunStore interface {
+	// List returns runs of a job newest first like repository.RunRepository.List
+	List(ctx context.Context, jobID, before string, limit int) ([]*repository.Run, error)
+}
+
+// JobStore is the part of the job storage the monitor needs
+type JobStore interface {
+	Get(ctx context.Context, id string, q repository.Query) (*repository.Job, error)
+}
+
+// Succeeded tells whether a run of the job with jobID that was queued after from succeeded at or before by
+func Succeeded(ctx context.Context, runs RunStore, jobID string, from, by time.Time) (bool, error) {
+	before := ""
+	for {
+		page, err := runs.List(ctx, jobID, before, pageSize)
+		if err != nil {
+			return false, err
+		}
+		for _, run := range page {
+			if !run.QueuedAt.After(from) {
+				return false, nil
+			}
+			if run.Status == executor.StatusSucceeded && run.EndTime != nil && !run.EndTime.After(by) {
+				return true, nil
+			}
+		}
+		if len(page) < pageSize {
+			return false, nil
+		}
+		before = page[len(page)-1].ID
+	}
+}
+
+// TookTooLong tells whether a finished run breaches the max duration of sla, cancelled runs never do
+func TookTooLong(sla *repository.SLA, run *repository.Run) bool {
+	return sla.MaxDuration > 0 && run.Status != executor.StatusCancelled && run.Duration > sla.MaxDuration
+}
+
+// Status tells whether a job meets its SLA
+type Status struct {
+	// Met tells whether the last finished run took no longer than the max duration and a run succeeded by the last
+	// deadline. Deadlines before the SLA was set are met.
+	Met bool
+	// Deadlines is nil when the SLA has no deadline
+	Deadlines *Deadlines
+	// NextDeadlineMet tells whether a run queued since the last deadline succeeded already
+	NextDeadlineMet bool
+}
+
+// StatusAt evaluates the runs of the job of sla against it at now
+func StatusAt(ctx context.Context, runs RunStore, sla *repository.SLA, now time.Time) (*Status, error) {
+	status := &Status{Met: true}
+	if sla.MaxDuration > 0 {
+		run, err := lastFinished(ctx, runs, sla.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if run != nil && TookTooLong(sla, run) {
+			status.Met = false
+		}
+	}
+	deadlines, err := DeadlinesAt(sla, now)
+	if err != nil || deadlines == nil {
+		return status, err
+	}
+	status.Deadlines = deadlines
+	if deadlines.Last.After(sla.UpdatedAt) {
+		succeeded, err := Succeeded(ctx, runs, sla.JobID, deadlines.Prev, deadlines.Last)
+		if err != nil {
+			return nil, err
+		}
+		status.Met = status.Met && succeeded
+	}
+	if status.NextDeadlineMet, err = Succeeded(ctx, runs, sla.JobID, deadlines.Last, now); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// lastFinished returns the newest run of the job with jobID that finished, nil when none did
+func lastFinished(ctx context.Context, runs RunStore, jobID string) (*repository.Run, error) {
+	before := ""
+	for {
+		page, err := runs.List(ctx, jobID, before, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range page {
+			switch run.Status {
+			case executor.StatusQueued, executor.StatusWaiting, executor.StatusRunning:
+			default:
+				return run, nil
+			}
+		}
+		if len(page) < pageSize {
+			return nil, nil
+		}
+		before = page[len(page)-1].ID
+	}
+}
+
+// BreachFunc is called once for every breach the monitor recorded
+type BreachFunc func(ctx context.Context, job *repository.Job, breach *repository.SLABreach)
+
+// checkKey identifies the SLA of a job of a tenant the monitor checked the deadlines of
+type checkKey struct {
+	tenant string
+	jobID  string
+}
+
+// Monitor records the breaches of SLAs. Runs taking longer than the max duration are breaches once they finished,
+// deadlines are checked every interval. Only deadlines after the SLA was set are checked, and none of deleted,
+// paused and archived jobs, as they don't run.
+type Monitor struct {
+	slas     repository.SLARepository
+	jobs     JobStore
+	runs     RunStore
+	interval time.Duration
+	onBreach []BreachFunc
+	logger   *zap.Logger
+	wg       sync.WaitGroup
+
+	mu sync.Mutex
+	// checked holds the last deadline checked of every SLA, so a deadline is only looked at once
+	checked map[checkKey]time.Time
+}
+
+// New creates a Monitor checking the deadlines of slas every interval
+func New(slas repository.SLARepository, jobs JobStore, runs RunStore, interval time.Duration, logger *zap.Logger) *Monitor {
+	return &Monitor{slas: slas, jobs: jobs, runs: runs, interval: interval, logger: logger, checked: map[checkKey]time.Time{}}
+}
+
+// OnBreach makes the monitor call f for every breach it recorded, it must be called before Run
+func (m *Monitor) OnBreach(f BreachFunc) {
+	m.onBreach = append(m.onBreach, f)
+}
+
+// RunFinished records a breach in the background when run took longer than the max duration of job. It can be
+// passed to the OnFinish of the executor.
+func (m *Monitor) RunFinished(ctx context.Context, job *repository.Job, run *repository.Run) {
+	// The executor is done with both, but copies keep it free to change them
+	j, r := *job, *run
+	tenantID := tenant.FromContext(ctx)
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ctx, cancel := context.WithTimeout(tenant.NewContext(context.Background(), tenantID), storeTimeout)
+		defer cancel()
+		sla, err := m.slas.Get(ctx, j.ID)
+		if err == repository.ErrNotFound {
+			return
+		} else if err != nil {
+			m.logger.Error("Could not load SLA", zap.String("job_id", j.ID), zap.String("run_id", r.ID), zap.Error(err))
+			return
+		}
+		if TookTooLong(sla, &r) {
+			m.record(ctx, &j, &repository.SLABreach{
+				JobID:      j.ID,
+				Kind:       repository.SLABreachDuration,
+				RunID:      r.ID,
+				Duration:   r.Duration,
+				DetectedAt: time.Now().UTC().Truncate(time.Millisecond),
+			})
+		}
+	}()
+}
+
+// Wait blocks until the finished runs that are being checked were checked, the executor must not finish runs anymore
+func (m *Monitor) Wait() {
+	m.wg.Wait()
+}
+
+// Run blocks and checks the deadlines of all tenants until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	m.logger.Info("SLA monitor started", zap.Duration("interval", m.interval))
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkDeadlines(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// checkDeadlines records a breach for every SLA whose last deadline passed without a succeeded run
+func (m *Monitor) checkDeadlines(ctx context.Context, now time.Time) {
+	slas, err := m.slas.List(ctx)
+	if err != nil {
+		m.logger.Error("Could not list SLAs", zap.Error(err))
+		return
+	}
+	for _, sla := range slas {
+		deadlines, err := DeadlinesAt(sla, now)
+		if err != nil {
+			m.logger.Error("Invalid SLA deadline", zap.String("job_id", sla.JobID), zap.Error(err))
+			continue
+		}
+		key := checkKey{tenant: sla.Tenant, jobID: sla.JobID}
+		m.mu.Lock()
+		checked := m.checked[key]
+		m.mu.Unlock()
+		if deadlines == nil || !deadlines.Last.After(sla.UpdatedAt) || !deadlines.Last.After(checked) {
+			continue
+		}
+		checkCtx, cancel := context.WithTimeout(tenant.NewContext(ctx, sla.Tenant), storeTimeout)
+		err = m.checkDeadline(checkCtx, sla, deadlines)
+		cancel()
+		if err != nil {
+			m.logger.Error("Could not check SLA deadline", zap.String("job_id", sla.JobID), zap.Error(err))
+			continue
+		}
+		m.mu.Lock()
+		m.checked[key] = deadlines.Last
+		m.mu.Unlock()
+	}
+}
+
+// checkDeadline records a breach of the last deadline when no run queued after the one before succeeded by then
+func (m *Monitor) checkDeadline(ctx context.Context, sla *repository.SLA, deadlines *Deadlines) error {
+	job, err := m.jobs.Get(ctx, sla.JobID, repository.Query{})
+	if err == repository.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if job.Status == repository.JobPaused || job.ArchivedAt != nil {
+		return nil
+	}
+	succeeded, err := Succeeded(ctx, m.runs, sla.JobID, deadlines.Prev, deadlines.Last)
+	if err != nil || succeeded {
+		return err
+	}
+	m.record(ctx, job, &repository.SLABreach{
+		JobID:      sla.JobID,
+		Kind:       repository.SLABreachDeadline,
+		Deadline:   &deadlines.Last,
+		DetectedAt: time.Now().UTC().Truncate(time.Millisecond),
+	})
+	return nil
+}
+
+// record stores a breach and calls the functions registered with OnBreach, breaches recorded before are skipped
+func (m *Monitor) record(ctx context.Context, job *repository.Job, breach *repository.SLABreach) {
+	recorded, err := m.slas.AddBreach(ctx, breach)
+	if err == repository.ErrExists {
+		return
+	} else if err != nil {
+		m.logger.Error("Could not record SLA breach", zap.String("job_id", job.ID), zap.String("kind", breach.Kind), zap.Error(err))
+		return
+	}
+	metrics.RecordSLABreach(recorded.Kind)
+	m.logger.Warn("Job breached its SLA", zap.String("job_id", job.ID), zap.String("kind", recorded.Kind),
+		zap.String("breach_id", recorded.ID))
+	for _, f := range m.onBreach {
+		f(ctx, job, recorded)
+	}
+}
@@ -0,0 +1,99 @@
+package team
+
+import (
+	"context"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type teamsKey struct{}
+
+// resolved is what the context carries about the teams of the caller
+type resolved struct {
+	ids   []string
+	roles []string
+}
+
+// NewContext returns a copy of ctx carrying the IDs of teams, the teams of the caller, and the roles they grant
+func NewContext(ctx context.Context, teams []*repository.Team) context.Context {
+	r := resolved{ids: make([]string, 0, len(teams))}
+	seen := map[string]bool{}
+	for _, t := range teams {
+		r.ids = append(r.ids, t.ID)
+		for _, role := range t.Roles {
+			if !seen[role] {
+				seen[role] = true
+				r.roles = append(r.roles, role)
+			}
+		}
+	}
+	return context.WithValue(ctx, teamsKey{}, r)
+}
+
+// FromContext returns the IDs of the teams of the caller, nil when the caller belongs to none or ctx has no caller
+func FromContext(ctx context.Context) []string {
+	r, _ := ctx.Value(teamsKey{}).(resolved)
+	return r.ids
+}
+
+// RolesFromContext returns the roles the teams of the caller grant their members, each of them once
+func RolesFromContext(ctx context.Context) []string {
+	r, _ := ctx.Value(teamsKey{}).(resolved)
+	return r.roles
+}
+
+// Resolver looks up the teams of the caller of every call, so the services let callers access the jobs of their teams
+type Resolver struct {
+	teams repository.TeamRepository
+}
+
+// New creates a resolver looking up the teams of callers in teams
+func New(teams repository.TeamRepository) *Resolver {
+	return &Resolver{teams: teams}
+}
+
+// UnaryServerInterceptor adds the teams of the caller to the context of unary calls
+func (r *Resolver) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := r.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor adds the teams of the caller to the context of streams
+func (r *Resolver) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := r.resolve(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &resolvedStream{ServerStream: ss, ctx: ctx})
+}
+
+// resolvedStream replaces the context of a stream with one carrying the teams
+type resolvedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *resolvedStream) Context() context.Context {
+	return s.ctx
+}
+
+// resolve returns a context carrying the teams of the caller. Calls of methods exempt from authentication
+// have no caller and get no teams, admins access every job anyway and aren't looked up.
+func (r *Resolver) resolve(ctx context.Context) (context.Context, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok || claims.HasRole(auth.AdminRole) {
+		return ctx, nil
+	}
+	teams, err := r.teams.List(ctx, claims.Subject)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Could not look up the teams of the caller")
+	}
+	return NewContext(ctx, teams), nil
+}
@@ -0,0 +1,64 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/noltedennis/schedulytics-backend/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type tenantKey struct{}
+
+// NewContext returns a copy of ctx scoping storage operations to tenant, an empty tenant doesn't scope them
+func NewContext(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// FromContext returns the tenant storage operations with ctx are scoped to, empty when they aren't scoped
+func FromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// UnaryServerInterceptor scopes unary calls to the tenant of the caller's token
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor scopes streams to the tenant of the caller's token
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := scope(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &scopedStream{ServerStream: ss, ctx: ctx})
+}
+
+// scopedStream replaces the context of a stream with one carrying the tenant
+type scopedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *scopedStream) Context() context.Context {
+	return s.ctx
+}
+
+// scope returns a context carrying the tenant of the caller. Calls of methods exempt from authentication have no
+// claims and stay unscoped, tokens without a tenant are rejected.
+func scope(ctx context.Context) (context.Context, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	if claims.TenantID == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "Token has no tenant")
+	}
+	return NewContext(ctx, claims.TenantID), nil
+}
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcStatusCodeKey is the span attribute holding the numeric gRPC status code
+var grpcStatusCodeKey = label.Key("rpc.grpc.status_code")
+
+// UnaryServerInterceptor starts a server span for every unary RPC, continuing the trace sent by the client
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := startServerSpan(ctx, info.FullMethod)
+	defer span.End()
+	resp, err := handler(ctx, req)
+	finishServerSpan(span, err)
+	return resp, err
+}
+
+// StreamServerInterceptor starts a server span covering the whole lifetime of a stream
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+	defer span.End()
+	err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+	finishServerSpan(span, err)
+	return err
+}
+
+// tracedStream replaces the context of a stream with one carrying the RPC span
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// startServerSpan extracts the remote span context from the incoming metadata and starts a child span for the RPC
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = global.TextMapPropagator().Extract(ctx, metadataCarrier(md.Copy()))
+
+	// fullMethod looks like /model.JobService/CreateJob
+	service, method := "", fullMethod
+	if parts := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2); len(parts) == 2 {
+		service, method = parts[0], parts[1]
+	}
+	return global.Tracer(instrumentationName).Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCServiceKey.String(service),
+			semconv.RPCMethodKey.String(method),
+		),
+	)
+}
+
+// finishServerSpan records the gRPC status of the RPC on the span
+func finishServerSpan(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(grpcStatusCodeKey.Uint32(uint32(st.Code())))
+	if err != nil {
+		span.SetStatus(codes.Error, st.Message())
+	}
+}
+
+// metadataCarrier adapts gRPC metadata to the carrier interface of the propagators
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// StartMongoSpan starts a client span for a single operation on collection, end it with EndSpan
+func StartMongoSpan(ctx context.Context, collection *mongo.Collection, operation string) (context.Context, trace.Span) {
+	return global.Tracer(instrumentationName).Start(ctx, "mongodb."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongodb,
+			semconv.DBNameKey.String(collection.Database().Name()),
+			semconv.DBOperationKey.String(operation),
+			semconv.DBMongoDBCollectionKey.String(collection.Name()),
+		),
+	)
+}
+
+// EndSpan records err on the span, if any, and ends it
+func EndSpan(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
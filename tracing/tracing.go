@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/trace/jaeger"
+	"go.opentelemetry.io/otel/propagators"
+	"go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// instrumentationName identifies the tracer used by this server
+const instrumentationName = "github.com/noltedennis/schedulytics-backend"
+
+// Setup installs the global tracer provider and propagators, configured through the standard OTEL_* environment variables:
+//
+//	OTEL_TRACES_EXPORTER            otlp, jaeger or none (default none)
+//	OTEL_SERVICE_NAME               service name reported with every span (default schedulytics-grpc-server)
+//	OTEL_TRACES_SAMPLER_ARG         fraction of traces to sample between 0 and 1 (default 1)
+//	OTEL_EXPORTER_OTLP_ENDPOINT     address of the OTLP collector (default localhost:55680)
+//	OTEL_EXPORTER_OTLP_INSECURE     disable TLS towards the collector (default false)
+//	OTEL_EXPORTER_JAEGER_ENDPOINT   Jaeger collector URL (default http://localhost:14268/api/traces)
+//
+// The returned function flushes and stops the exporter.
+func Setup() (func(context.Context) error, error) {
+	// W3C trace context and baggage are propagated even if we don't export anything ourselves
+	global.SetTextMapPropagator(otel.NewCompositeTextMapPropagator(propagators.TraceContext{}, propagators.Baggage{}))
+
+	exporterName := envOr("OTEL_TRACES_EXPORTER", "none")
+	if exporterName == "none" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	ratio, err := strconv.ParseFloat(envOr("OTEL_TRACES_SAMPLER_ARG", "1"), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q", os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+	}
+
+	var exporter trace.SpanExporter
+	var shutdown func(context.Context) error
+	switch exporterName {
+	case "otlp":
+		opts := []otlp.ExporterOption{otlp.WithAddress(envOr("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:55680"))}
+		if insecure, _ := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_INSECURE")); insecure {
+			opts = append(opts, otlp.WithInsecure())
+		}
+		e, err := otlp.NewExporter(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not create OTLP exporter: %v", err)
+		}
+		exporter, shutdown = e, e.Shutdown
+	case "jaeger":
+		e, err := jaeger.NewRawExporter(jaeger.WithCollectorEndpoint(envOr("OTEL_EXPORTER_JAEGER_ENDPOINT", "http://localhost:14268/api/traces")))
+		if err != nil {
+			return nil, fmt.Errorf("could not create Jaeger exporter: %v", err)
+		}
+		exporter, shutdown = e, e.Shutdown
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q", exporterName)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))}),
+		sdktrace.WithResource(resource.New(semconv.ServiceNameKey.String(envOr("OTEL_SERVICE_NAME", "schedulytics-grpc-server")))),
+		sdktrace.WithBatcher(exporter),
+	)
+	global.SetTracerProvider(provider)
+	return shutdown, nil
+}
+
+// envOr returns the value of the environment variable key or def if it is empty
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
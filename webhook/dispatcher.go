@@ -0,0 +1,334 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/metrics"
+	"github.com/noltedennis/schedulytics-backend/repository"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.uber.org/zap"
+)
+
+const (
+	// minBackoff is the wait before the second attempt of a delivery, it doubles after every failed one up to maxBackoff
+	minBackoff = 5 * time.Second
+	maxBackoff = 10 * time.Minute
+	// storeTimeout limits looking up webhooks and storing dead letters, they don't use the context of the caller
+	storeTimeout = 5 * time.Second
+	// maxResponseBody is the part of a response that is read, so connections can be reused
+	maxResponseBody = 64 << 10
+)
+
+var (
+	// errQueueFull is the error of deliveries that didn't fit into the queue
+	errQueueFull = errors.New("delivery queue is full")
+	// errShutDown is the error of deliveries that were pending when the server shut down
+	errShutDown = errors.New("server shut down before the delivery succeeded")
+)
+
+// delivery is an event waiting to be posted to a webhook. Events that were not matched with the webhooks of their
+// owner yet have no webhook.
+type delivery struct {
+	hook     *repository.Webhook
+	owner    string
+	event    string
+	body     []byte
+	id       string
+	tenant   string
+	attempts int
+	// err is the error of the last attempt
+	err error
+}
+
+// Dispatcher posts the events of jobs and runs to the webhooks of their owner on a pool of workers. Failed deliveries
+// are attempted again with a growing backoff, deliveries that failed every attempt are stored as dead letters.
+type Dispatcher struct {
+	hooks       repository.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	workers     int
+	queue       chan *delivery
+	logger      *zap.Logger
+	wg          sync.WaitGroup
+
+	mu      sync.Mutex
+	stopped bool
+	// waiting holds the timers of the deliveries waiting for their next attempt
+	waiting map[*delivery]*time.Timer
+}
+
+// New creates a Dispatcher with the given number of workers and queue capacity. Every attempt may take timeout, a
+// delivery is given up after maxAttempts.
+func New(hooks repository.WebhookRepository, workers, queueSize, maxAttempts int, timeout time.Duration, logger *zap.Logger) *Dispatcher {
+	return &Dispatcher{
+		hooks: hooks,
+		client: &http.Client{
+			Timeout: timeout,
+			// A redirect counts as a failed attempt, the webhook has to be registered with the final URL
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		maxAttempts: maxAttempts,
+		workers:     workers,
+		queue:       make(chan *delivery, queueSize),
+		logger:      logger,
+		waiting:     map[*delivery]*time.Timer{},
+	}
+}
+
+// Start launches the workers. Once ctx is cancelled the workers stop and deliveries that are still pending are
+// stored as dead letters.
+func (d *Dispatcher) Start(ctx context.Context) {
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case del := <-d.queue:
+					d.deliver(ctx, del)
+				}
+			}
+		}()
+	}
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		<-ctx.Done()
+		d.stop()
+	}()
+	d.logger.Info("Webhook dispatcher started", zap.Int("workers", d.workers))
+}
+
+// Wait blocks until all workers returned and the pending deliveries were stored
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// JobChanged delivers a job event to the webhooks of the owner of the job, ctx only provides the tenant
+func (d *Dispatcher) JobChanged(ctx context.Context, event string, job *repository.Job) {
+	d.notify(ctx, event, &Payload{Event: event, Job: newJob(job)}, job.Owner)
+}
+
+// RunFinished delivers run.succeeded or run.failed for a finished run of job to the webhooks of its owner. Timed out
+// runs count as failed, cancelled runs aren't delivered. It can be passed to the OnFinish of the executor.
+func (d *Dispatcher) RunFinished(ctx context.Context, job *repository.Job, run *repository.Run) {
+	var event string
+	switch run.Status {
+	case executor.StatusSucceeded:
+		event = RunSucceeded
+	case executor.StatusFailed, executor.StatusTimedOut:
+		event = RunFailed
+	default:
+		return
+	}
+	payload := &Payload{Event: event, Job: newJob(job), Run: newRun(run)}
+	payload.Job.Status = ""
+	d.notify(ctx, event, payload, job.Owner)
+}
+
+// SLABreached delivers sla.breached for a breach of the SLA of job to the webhooks of its owner. It can be passed to
+// the OnBreach of the SLA monitor.
+func (d *Dispatcher) SLABreached(ctx context.Context, job *repository.Job, breach *repository.SLABreach) {
+	payload := &Payload{Event: SLABreached, Job: newJob(job), Breach: newBreach(breach)}
+	payload.Job.Status = ""
+	d.notify(ctx, SLABreached, payload, job.Owner)
+}
+
+// RunAnomalous delivers run.anomalous for the anomaly of a run of job to the webhooks of its owner. It can be passed
+// to the OnAnomaly of the anomaly detector.
+func (d *Dispatcher) RunAnomalous(ctx context.Context, job *repository.Job, anomaly *repository.Anomaly) {
+	payload := &Payload{Event: RunAnomalous, Job: newJob(job), Anomaly: newAnomaly(anomaly)}
+	payload.Job.Status = ""
+	d.notify(ctx, RunAnomalous, payload, job.Owner)
+}
+
+// notify queues an event, the workers match it with the webhooks of owner
+func (d *Dispatcher) notify(ctx context.Context, event string, payload *Payload, owner string) {
+	id, err := randomHex(16)
+	if err != nil {
+		d.logger.Error("Could not generate event ID", zap.String("event", event), zap.Error(err))
+		return
+	}
+	payload.ID = id
+	payload.Time = time.Now().UTC()
+	// Payloads only hold strings, numbers and times, they always marshal
+	body, _ := json.Marshal(payload)
+	d.enqueue(&delivery{owner: owner, event: event, body: body, id: id, tenant: tenant.FromContext(ctx)})
+}
+
+// enqueue hands a delivery to the workers. Deliveries that don't fit into the queue or come in after the shutdown
+// are stored as dead letters right away.
+func (d *Dispatcher) enqueue(del *delivery) {
+	d.mu.Lock()
+	stopped, queued := d.stopped, false
+	if !stopped {
+		select {
+		case d.queue <- del:
+			queued = true
+		default:
+		}
+	}
+	d.mu.Unlock()
+	if queued {
+		return
+	}
+	if del.hook == nil {
+		// Match the event here, so every webhook gets a dead letter
+		d.fanOut(del)
+		return
+	}
+	if del.err == nil {
+		del.err = errQueueFull
+		if stopped {
+			del.err = errShutDown
+		}
+	}
+	d.deadLetter(del)
+}
+
+// deliver makes the next attempt of a delivery, events are matched with their webhooks first
+func (d *Dispatcher) deliver(ctx context.Context, del *delivery) {
+	if del.hook == nil {
+		d.fanOut(del)
+		return
+	}
+	del.attempts++
+	del.err = d.post(ctx, del)
+	if del.err == nil {
+		metrics.RecordWebhookDelivery(del.event, "succeeded")
+		return
+	}
+	if ctx.Err() != nil {
+		del.err = errShutDown
+	}
+	if del.attempts >= d.maxAttempts || ctx.Err() != nil {
+		d.deadLetter(del)
+		return
+	}
+	metrics.RecordWebhookDelivery(del.event, "retried")
+	d.retryLater(del)
+}
+
+// fanOut queues a delivery of an event for every webhook of its owner receiving it
+func (d *Dispatcher) fanOut(event *delivery) {
+	ctx, cancel := context.WithTimeout(tenant.NewContext(context.Background(), event.tenant), storeTimeout)
+	defer cancel()
+	hooks, err := d.hooks.Subscribed(ctx, event.owner, event.event)
+	if err != nil {
+		d.logger.Error("Could not look up webhooks, the event is lost", zap.String("event", event.event),
+			zap.String("event_id", event.id), zap.Error(err))
+		return
+	}
+	for _, hook := range hooks {
+		del := *event
+		del.hook = hook
+		d.enqueue(&del)
+	}
+}
+
+// post makes a single attempt to deliver del, responses with a status other than 2xx fail it
+func (d *Dispatcher) post(ctx context.Context, del *delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, del.hook.URL, bytes.NewReader(del.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "schedulytics-webhook")
+	req.Header.Set(EventHeader, del.event)
+	req.Header.Set(DeliveryHeader, del.id)
+	req.Header.Set(SignatureHeader, Sign(del.hook.Secret, del.body))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return nil
+}
+
+// retryLater queues the next attempt of del once its backoff passed
+func (d *Dispatcher) retryLater(del *delivery) {
+	wait := minBackoff
+	for i := 1; i < del.attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		d.deadLetter(del)
+		return
+	}
+	// The timer can't remove itself before it was added, it needs the lock
+	d.waiting[del] = time.AfterFunc(wait, func() {
+		d.mu.Lock()
+		delete(d.waiting, del)
+		d.mu.Unlock()
+		d.enqueue(del)
+	})
+	d.mu.Unlock()
+}
+
+// stop makes every later delivery a dead letter and stores the deliveries that are still pending as dead letters
+func (d *Dispatcher) stop() {
+	d.mu.Lock()
+	d.stopped = true
+	pending := []*delivery{}
+	for del, timer := range d.waiting {
+		// Timers that fired already enqueue their delivery on their own
+		if timer.Stop() {
+			pending = append(pending, del)
+		}
+	}
+	d.waiting = map[*delivery]*time.Timer{}
+	d.mu.Unlock()
+	for drained := false; !drained; {
+		select {
+		case del := <-d.queue:
+			pending = append(pending, del)
+		default:
+			drained = true
+		}
+	}
+	for _, del := range pending {
+		// Enqueueing after the shutdown stores the dead letters
+		d.enqueue(del)
+	}
+}
+
+// deadLetter stores a delivery that won't be attempted again
+func (d *Dispatcher) deadLetter(del *delivery) {
+	metrics.RecordWebhookDelivery(del.event, "dead_lettered")
+	d.logger.Warn("Could not deliver event to webhook", zap.String("webhook_id", del.hook.ID), zap.String("event", del.event),
+		zap.String("event_id", del.id), zap.Int("attempts", del.attempts), zap.Error(del.err))
+	ctx, cancel := context.WithTimeout(tenant.NewContext(context.Background(), del.tenant), storeTimeout)
+	defer cancel()
+	_, err := d.hooks.CreateDeadLetter(ctx, &repository.DeadLetter{
+		WebhookID: del.hook.ID,
+		Event:     del.event,
+		Payload:   string(del.body),
+		Attempts:  del.attempts,
+		Error:     del.err.Error(),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	})
+	if err != nil {
+		d.logger.Error("Could not store dead letter", zap.String("webhook_id", del.hook.ID), zap.String("event_id", del.id), zap.Error(err))
+	}
+}
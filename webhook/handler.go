@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+)
+
+// Handler is the name of the handler that posts the runs of jobs to a URL like a webhook delivery
+const Handler = "webhook"
+
+// RunStarted is the event of the calls of the webhook handler, webhooks can't subscribe to it
+const RunStarted = "run.started"
+
+// Call is the JSON body the webhook handler posts
+type Call struct {
+	// ID is the ID of the run, it is the same for every attempt of the handler
+	ID    string    `json:"id"`
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Job   *Job      `json:"job"`
+	// Data is the data param of the job
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// Type describes the webhook handler
+var Type = executor.Type{
+	Name:        Handler,
+	Description: "Posts the run as JSON to a URL, signed like the deliveries of webhooks. Responses with a status other than 2xx fail the run.",
+	Schema: executor.Schema{
+		Params: []executor.Param{
+			{Name: "url", Type: executor.ParamString, Required: true, Description: "Absolute https URL the run is posted to"},
+			{Name: "secret", Type: executor.ParamString, Description: "Key of the signature header, the body isn't signed without it"},
+			{Name: "data", Type: executor.ParamObject, Description: "Sent as the data of the body"},
+		},
+		Check: func(params map[string]interface{}) error {
+			target, _ := params["url"].(string)
+			if u, err := url.Parse(target); err != nil || u.Scheme != "https" || u.Host == "" {
+				return errors.New("param \"url\" must be an absolute https URL")
+			}
+			return nil
+		},
+	},
+}
+
+// Call posts the run of job to the url param of the job with the client of the dispatcher and returns the status of
+// the response. It is the executor.HandlerFunc of the webhook handler.
+func (d *Dispatcher) Call(ctx context.Context, job *executor.Job) (string, error) {
+	target, _ := job.Params["url"].(string)
+	data, _ := job.Params["data"].(map[string]interface{})
+	// A struct of strings, a time and JSON values always marshals
+	body, _ := json.Marshal(&Call{
+		ID:    job.RunID,
+		Event: RunStarted,
+		Time:  time.Now().UTC().Truncate(time.Millisecond),
+		Job:   &Job{ID: job.ID, Name: job.Name},
+		Data:  data,
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "schedulytics-webhook")
+	req.Header.Set(EventHeader, RunStarted)
+	req.Header.Set(DeliveryHeader, job.RunID)
+	if secret, _ := job.Params["secret"].(string); secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.Status, fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return resp.Status, nil
+}
@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/repository"
+)
+
+// Event types webhooks can receive
+const (
+	JobCreated   = "job.created"
+	JobUpdated   = "job.updated"
+	JobDeleted   = "job.deleted"
+	RunSucceeded = "run.succeeded"
+	RunFailed    = "run.failed"
+	SLABreached  = "sla.breached"
+	RunAnomalous = "run.anomalous"
+)
+
+// Events lists every event type
+var Events = []string{JobCreated, JobUpdated, JobDeleted, RunSucceeded, RunFailed, SLABreached, RunAnomalous}
+
+// Headers of every delivery besides the JSON content type
+const (
+	// SignatureHeader holds sha256= and the hex encoded HMAC-SHA256 of the body keyed with the secret of the webhook
+	SignatureHeader = "X-Schedulytics-Signature"
+	// EventHeader holds the event type
+	EventHeader = "X-Schedulytics-Event"
+	// DeliveryHeader holds the ID of the event, it is the same for every attempt and every webhook
+	DeliveryHeader = "X-Schedulytics-Delivery"
+)
+
+// Sign returns the value of the signature header of body for a webhook with the given secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret generates the random secret of a new webhook
+func NewSecret() (string, error) {
+	return randomHex(32)
+}
+
+// randomHex returns n random bytes hex encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Payload is the JSON body posted to webhooks
+type Payload struct {
+	// ID identifies the event, receivers can use it to ignore events delivered twice
+	ID    string    `json:"id"`
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Job   *Job      `json:"job"`
+	// Run is only set for run events
+	Run *Run `json:"run,omitempty"`
+	// Breach is only set for SLA events
+	Breach *Breach `json:"breach,omitempty"`
+	// Anomaly is only set for run.anomalous
+	Anomaly *Anomaly `json:"anomaly,omitempty"`
+}
+
+// Job is the part of a job payloads carry, clients read the rest with ReadJob
+type Job struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+	// Status is left out of run events, the job may have changed since the run started
+	Status string            `json:"status,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Run is the part of a run payloads carry, clients read the rest with GetJobRun
+type Run struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	Attempt   int        `json:"attempt"`
+	Trigger   string     `json:"trigger,omitempty"`
+	CycleID   string     `json:"cycle_id,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	QueuedAt  time.Time  `json:"queued_at"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+}
+
+// Breach is an SLA breach of the job of a payload, clients read the SLA with GetSLAStatus
+type Breach struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// RunID and DurationMs are only set for runs that took too long, Deadline only for missed deadlines
+	RunID      string     `json:"run_id,omitempty"`
+	DurationMs int64      `json:"duration_ms,omitempty"`
+	Deadline   *time.Time `json:"deadline,omitempty"`
+	DetectedAt time.Time  `json:"detected_at"`
+}
+
+// Anomaly is a run of the job of a payload whose duration deviated from the baseline of the job, clients read the
+// run with GetJobRun
+type Anomaly struct {
+	ID         string `json:"id"`
+	RunID      string `json:"run_id"`
+	DurationMs int64  `json:"duration_ms"`
+	// MeanMs and StddevMs are the baseline computed from the last Samples succeeded runs of the job
+	MeanMs     int64     `json:"mean_ms"`
+	StddevMs   int64     `json:"stddev_ms"`
+	Samples    int       `json:"samples"`
+	Score      float64   `json:"score"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// newJob returns the payload form of a job
+func newJob(job *repository.Job) *Job {
+	return &Job{ID: job.ID, Name: job.Name, Owner: job.Owner, Status: job.Status, Labels: job.Labels}
+}
+
+// newRun returns the payload form of a run
+func newRun(run *repository.Run) *Run {
+	return &Run{
+		ID:        run.ID,
+		Status:    run.Status,
+		Attempt:   run.Attempt,
+		Trigger:   run.Trigger,
+		CycleID:   run.CycleID,
+		Error:     run.Error,
+		QueuedAt:  run.QueuedAt,
+		StartTime: run.StartTime,
+		EndTime:   run.EndTime,
+	}
+}
+
+// newBreach returns the payload form of an SLA breach
+func newBreach(breach *repository.SLABreach) *Breach {
+	return &Breach{
+		ID:         breach.ID,
+		Kind:       breach.Kind,
+		RunID:      breach.RunID,
+		DurationMs: breach.Duration.Milliseconds(),
+		Deadline:   breach.Deadline,
+		DetectedAt: breach.DetectedAt,
+	}
+}
+
+// newAnomaly returns the payload form of the anomaly of a run
+func newAnomaly(anomaly *repository.Anomaly) *Anomaly {
+	return &Anomaly{
+		ID:         anomaly.ID,
+		RunID:      anomaly.RunID,
+		DurationMs: anomaly.Duration.Milliseconds(),
+		MeanMs:     anomaly.Mean.Milliseconds(),
+		StddevMs:   anomaly.Stddev.Milliseconds(),
+		Samples:    anomaly.Samples,
+		Score:      anomaly.Score,
+		DetectedAt: anomaly.DetectedAt,
+	}
+}
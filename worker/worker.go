@@ -0,0 +1,427 @@
+// Package worker hands the runs of jobs to external workers that register with the server and lease them
+package worker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/noltedennis/schedulytics-backend/executor"
+	"github.com/noltedennis/schedulytics-backend/tenant"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// Handler is the name of the handler that runs jobs on external workers
+const Handler = "remote"
+
+// Type describes the remote handler, it takes any params and hands them to the workers
+var Type = executor.Type{
+	Name:        Handler,
+	Description: "Hands the run to the next external worker that leases it, with the command and params of the job",
+	Schema:      executor.Schema{Open: true},
+}
+
+var (
+	// ErrUnknownWorker is returned for workers that never registered, unregistered or whose lease expired
+	ErrUnknownWorker = errors.New("worker is not registered")
+	// ErrNotLeased is returned when a worker completes a run it doesn't hold the lease of
+	ErrNotLeased = errors.New("run is not leased by the worker")
+	// ErrNotLeader is returned by pools of replicas that aren't the leader, only the leader hands out runs
+	ErrNotLeader = errors.New("remote runs are only handed out by the leader")
+)
+
+// Worker is a registered worker
+type Worker struct {
+	ID   string
+	Name string
+	// Tenant is taken from the context the worker registered with, workers without one lease the runs of every tenant
+	Tenant       string
+	RegisteredAt time.Time
+	HeartbeatAt  time.Time
+	// RunIDs are the runs the worker holds the lease of
+	RunIDs []string
+}
+
+// Lease is a run leased by a worker
+type Lease struct {
+	RunID   string
+	JobID   string
+	JobName string
+	Command string
+	Tenant  string
+	// Params are the params of the job
+	Params map[string]interface{}
+	// Deadline is when the timeout of the job stops the run, zero without a timeout
+	Deadline time.Time
+	// ExpiresAt is when the lease goes to another worker unless the worker sends a heartbeat before
+	ExpiresAt time.Time
+}
+
+// result is what a worker reported about a run
+type result struct {
+	worker string
+	output string
+	err    error
+}
+
+// task is a run waiting for a worker or leased by one
+type task struct {
+	lease Lease
+	// worker is the ID of the worker holding the lease, empty while the run is waiting
+	worker string
+	// done receives the result once, it is buffered so completing never blocks
+	done chan result
+}
+
+// worker is a registered worker and the runs it leased
+type worker struct {
+	Worker
+	// polls counts the LeaseRun calls waiting for a run, a worker waiting for one is alive
+	polls  int
+	leases map[string]*task
+	// cancelled are the runs the worker leased that were stopped, the next heartbeat tells the worker about them
+	cancelled []string
+}
+
+// Pool hands the runs of the remote handler to the workers that lease them. A worker holds its leases as long as it
+// sends a heartbeat at least every lease TTL, the leases of workers that stop are handed to other workers.
+//
+// Workers, leases and waiting runs are only kept in memory, so a single pool must hand out all remote runs. With
+// leader election only the pool of the leader does, the pools of the other replicas fail remote runs and the calls of
+// workers with ErrNotLeader.
+type Pool struct {
+	ttl    time.Duration
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// leading tells whether the pool hands out runs
+	leading bool
+	workers map[string]*worker
+	// waiting are the runs no worker leased yet, oldest first
+	waiting []*task
+	// wake is closed and replaced whenever a run starts waiting
+	wake chan struct{}
+}
+
+// NewPool creates a pool whose workers have to send a heartbeat every ttl. It hands out runs until SetLeader stops it.
+func NewPool(ttl time.Duration, logger *zap.Logger) *Pool {
+	return &Pool{
+		ttl:     ttl,
+		logger:  logger,
+		leading: true,
+		workers: map[string]*worker{},
+		wake:    make(chan struct{}),
+	}
+}
+
+// TTL is how long workers keep their leases without sending a heartbeat
+func (p *Pool) TTL() time.Duration {
+	return p.ttl
+}
+
+// SetLeader tells the pool whether its replica is the leader. A pool that stops leading fails the runs waiting for
+// a worker or leased by one with ErrNotLeader and forgets its workers, they have to register with the new leader.
+func (p *Pool) SetLeader(leading bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.leading == leading {
+		return
+	}
+	p.leading = leading
+	if leading {
+		p.logger.Info("Handing out remote runs")
+		return
+	}
+	failed := 0
+	fail := func(t *task) {
+		t.done <- result{err: ErrNotLeader}
+		failed++
+	}
+	for _, t := range p.waiting {
+		fail(t)
+	}
+	for _, w := range p.workers {
+		for _, t := range w.leases {
+			fail(t)
+		}
+	}
+	p.logger.Warn("Stopped handing out remote runs", zap.Int("workers", len(p.workers)), zap.Int("failed_runs", failed))
+	p.waiting = nil
+	p.workers = map[string]*worker{}
+	// Workers waiting for a run learn that the pool stopped leading
+	close(p.wake)
+	p.wake = make(chan struct{})
+}
+
+// Run hands the run of job to the next worker that leases it and waits until the worker completed it or ctx is
+// done. It makes the pool an executor.Handler.
+func (p *Pool) Run(ctx context.Context, job *executor.Job) (string, error) {
+	t := &task{
+		lease: Lease{
+			RunID:   job.RunID,
+			JobID:   job.ID,
+			JobName: job.Name,
+			Command: job.Command,
+			Tenant:  tenant.FromContext(ctx),
+			Params:  job.Params,
+		},
+		done: make(chan result, 1),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		t.lease.Deadline = deadline.UTC().Truncate(time.Millisecond)
+	}
+	p.mu.Lock()
+	if !p.leading {
+		p.mu.Unlock()
+		return "", ErrNotLeader
+	}
+	p.push(t, false)
+	p.mu.Unlock()
+	select {
+	case r := <-t.done:
+		executor.RanOn(ctx, r.worker)
+		return r.output, r.err
+	case <-ctx.Done():
+		p.stop(t)
+		return "", ctx.Err()
+	}
+}
+
+// Register adds a worker for the tenant of ctx and returns it
+func (p *Pool) Register(ctx context.Context, name string) (*Worker, error) {
+	registered := now()
+	w := &worker{
+		Worker: Worker{
+			ID:           primitive.NewObjectID().Hex(),
+			Name:         name,
+			Tenant:       tenant.FromContext(ctx),
+			RegisteredAt: registered,
+			HeartbeatAt:  registered,
+		},
+		leases: map[string]*task{},
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.leading {
+		return nil, ErrNotLeader
+	}
+	p.workers[w.ID] = w
+	p.logger.Info("Registered worker", zap.String("worker_id", w.ID), zap.String("worker_name", name))
+	return w.snapshot(), nil
+}
+
+// Unregister removes a worker, the runs it leased are handed to other workers
+func (p *Pool) Unregister(ctx context.Context, id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w, err := p.worker(ctx, id)
+	if err != nil {
+		return err
+	}
+	p.remove(w)
+	p.logger.Info("Unregistered worker", zap.String("worker_id", w.ID), zap.String("worker_name", w.Name))
+	return nil
+}
+
+// Heartbeat keeps the leases of a worker and returns the IDs of the runs it leased that were stopped since the last
+// heartbeat, because they were cancelled or timed out
+func (p *Pool) Heartbeat(ctx context.Context, id string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w, err := p.worker(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	w.HeartbeatAt = now()
+	cancelled := w.cancelled
+	w.cancelled = nil
+	return cancelled, nil
+}
+
+// Lease hands the oldest waiting run of the worker's tenant to the worker, waiting up to wait for one. It returns
+// nil when no run started waiting in time.
+func (p *Pool) Lease(ctx context.Context, id string, wait time.Duration) (*Lease, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		w, err := p.worker(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		w.HeartbeatAt = now()
+		for i, t := range p.waiting {
+			if w.Tenant != "" && t.lease.Tenant != w.Tenant {
+				continue
+			}
+			p.waiting = append(p.waiting[:i], p.waiting[i+1:]...)
+			t.worker = w.ID
+			w.leases[t.lease.RunID] = t
+			lease := t.lease
+			lease.ExpiresAt = w.HeartbeatAt.Add(p.ttl)
+			p.logger.Info("Leased run", zap.String("worker_id", w.ID), zap.String("run_id", lease.RunID),
+				zap.String("job_id", lease.JobID))
+			return &lease, nil
+		}
+		wake := p.wake
+		w.polls++
+		p.mu.Unlock()
+		var done bool
+		select {
+		case <-wake:
+		case <-timer.C:
+			done = true
+		case <-ctx.Done():
+			done = true
+		}
+		p.mu.Lock()
+		w.polls--
+		if done {
+			// The worker was alive until now even if it sent no heartbeat while it waited
+			w.HeartbeatAt = now()
+			return nil, nil
+		}
+	}
+}
+
+// Complete stores the outcome of a run the worker leased, the run failed with errMsg unless it's empty
+func (p *Pool) Complete(ctx context.Context, id, runID, output, errMsg string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w, err := p.worker(ctx, id)
+	if err != nil {
+		return err
+	}
+	w.HeartbeatAt = now()
+	t, ok := w.leases[runID]
+	if !ok {
+		return ErrNotLeased
+	}
+	delete(w.leases, runID)
+	var runErr error
+	if errMsg != "" {
+		runErr = errors.New(errMsg)
+	}
+	t.done <- result{worker: w.Name, output: output, err: runErr}
+	return nil
+}
+
+// List returns the registered workers of the tenant of ctx, the ones of every tenant without one, oldest first
+func (p *Pool) List(ctx context.Context) ([]*Worker, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.leading {
+		return nil, ErrNotLeader
+	}
+	workers := []*Worker{}
+	for _, w := range p.workers {
+		if t := tenant.FromContext(ctx); t == "" || w.Tenant == t {
+			workers = append(workers, w.snapshot())
+		}
+	}
+	sort.Slice(workers, func(i, j int) bool {
+		if !workers[i].RegisteredAt.Equal(workers[j].RegisteredAt) {
+			return workers[i].RegisteredAt.Before(workers[j].RegisteredAt)
+		}
+		return workers[i].ID < workers[j].ID
+	})
+	return workers, nil
+}
+
+// Reap blocks and removes the workers that didn't send a heartbeat for longer than the TTL until ctx is cancelled,
+// the runs they leased are handed to other workers
+func (p *Pool) Reap(ctx context.Context) {
+	ticker := time.NewTicker(p.ttl / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		expired := now().Add(-p.ttl)
+		p.mu.Lock()
+		for _, w := range p.workers {
+			if w.polls == 0 && w.HeartbeatAt.Before(expired) {
+				p.logger.Warn("Lease of worker expired", zap.String("worker_id", w.ID), zap.String("worker_name", w.Name),
+					zap.Int("leased_runs", len(w.leases)))
+				p.remove(w)
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// worker returns the registered worker with id, it must belong to the tenant of ctx. The pool must be locked.
+func (p *Pool) worker(ctx context.Context, id string) (*worker, error) {
+	if !p.leading {
+		return nil, ErrNotLeader
+	}
+	w, ok := p.workers[id]
+	if !ok || w.Tenant != tenant.FromContext(ctx) {
+		return nil, ErrUnknownWorker
+	}
+	return w, nil
+}
+
+// remove unregisters a worker and hands the runs it leased to other workers, before the ones waiting. The pool must
+// be locked.
+func (p *Pool) remove(w *worker) {
+	delete(p.workers, w.ID)
+	for _, t := range w.leases {
+		p.logger.Info("Reassigning run of worker", zap.String("worker_id", w.ID), zap.String("run_id", t.lease.RunID))
+		p.push(t, true)
+	}
+}
+
+// push makes a run wait for a worker, at the front of the runs waiting when first is set. The pool must be locked.
+func (p *Pool) push(t *task, first bool) {
+	t.worker = ""
+	if first {
+		p.waiting = append([]*task{t}, p.waiting...)
+	} else {
+		p.waiting = append(p.waiting, t)
+	}
+	close(p.wake)
+	p.wake = make(chan struct{})
+}
+
+// stop takes a run that was cancelled or timed out away from the workers, the worker that leased it learns about it
+// with its next heartbeat
+func (p *Pool) stop(t *task) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t.worker == "" {
+		for i, waiting := range p.waiting {
+			if waiting == t {
+				p.waiting = append(p.waiting[:i], p.waiting[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+	if w, ok := p.workers[t.worker]; ok {
+		delete(w.leases, t.lease.RunID)
+		w.cancelled = append(w.cancelled, t.lease.RunID)
+	}
+}
+
+// snapshot copies the worker and the IDs of the runs it leased, sorted
+func (w *worker) snapshot() *Worker {
+	c := w.Worker
+	c.RunIDs = []string{}
+	for id := range w.leases {
+		c.RunIDs = append(c.RunIDs, id)
+	}
+	sort.Strings(c.RunIDs)
+	return &c
+}
+
+// now returns the current time with the millisecond precision dates are stored with
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}